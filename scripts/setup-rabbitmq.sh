@@ -9,7 +9,8 @@
 #
 # Topology:
 # - 2 Topic Exchanges (inventory.events, orders.events)
-# - 2 Main Queues with DLQ (orders.inventory_events, inventory.order_events)
+# - 3 Main Queues with DLQ (orders.inventory_events, inventory.order_events,
+#   inventory.catalog_events)
 # - Bindings with routing keys for event types
 ###############################################################################
 
@@ -228,6 +229,8 @@ main() {
     echo ""
     create_queue_with_dlq "inventory.order_events"
     echo ""
+    create_queue_with_dlq "inventory.catalog_events"
+    echo ""
     
     # 3. Create Bindings
     print_info "Step 3: Creating Bindings"
@@ -246,6 +249,12 @@ main() {
     bind_queue_to_exchange "orders.events" "inventory.order_events" "order.failed"
     echo ""
     
+    # Inventory Service consumes catalog events (catalog sync)
+    bind_queue_to_exchange "orders.events" "inventory.catalog_events" "product.created"
+    bind_queue_to_exchange "orders.events" "inventory.catalog_events" "product.updated"
+    bind_queue_to_exchange "orders.events" "inventory.catalog_events" "product.deactivated"
+    echo ""
+    
     # 4. Set HA Policies (optional, for production)
     # Uncomment the following lines if you want HA in production
     # print_info "Step 4: Setting HA Policies"
@@ -265,10 +274,10 @@ main() {
     echo ""
     echo "Topology Summary:"
     echo "  ✓ 2 Topic Exchanges: inventory.events, orders.events"
-    echo "  ✓ 2 Main Queues: orders.inventory_events, inventory.order_events"
-    echo "  ✓ 2 DLQ Exchanges: orders.inventory_events.dlx, inventory.order_events.dlx"
-    echo "  ✓ 2 DLQ Queues: orders.inventory_events.dlq, inventory.order_events.dlq"
-    echo "  ✓ 7 Bindings configured with routing keys"
+    echo "  ✓ 3 Main Queues: orders.inventory_events, inventory.order_events, inventory.catalog_events"
+    echo "  ✓ 3 DLQ Exchanges: orders.inventory_events.dlx, inventory.order_events.dlx, inventory.catalog_events.dlx"
+    echo "  ✓ 3 DLQ Queues: orders.inventory_events.dlq, inventory.order_events.dlq, inventory.catalog_events.dlq"
+    echo "  ✓ 10 Bindings configured with routing keys"
     echo ""
 }
 
@@ -2,52 +2,65 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
+	"io"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
-	"strconv"
 	"syscall"
 	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/joho/godotenv"
-	"github.com/kelseyhightower/envconfig"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	grpchealth "google.golang.org/grpc/health"
 
 	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/application/usecase"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/events"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/infrastructure/auth"
 	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/infrastructure/cache"
 	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/infrastructure/config"
 	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/infrastructure/database"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/infrastructure/health"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/infrastructure/messaging/memory"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/infrastructure/messaging/natsjs"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/infrastructure/messaging/noop"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/infrastructure/messaging/rabbitmq"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/infrastructure/messaging/schema"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/infrastructure/messaging/transport"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/infrastructure/metrics"
 	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/infrastructure/persistence/repository"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/infrastructure/ratelimit"
 	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/infrastructure/repository/stub"
 	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/infrastructure/scheduler"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/interfaces/grpc/interceptor"
+	grpcserver "github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/interfaces/grpc/server"
 	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/interfaces/http/handler"
 	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/interfaces/http/middleware"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/interfaces/messaging"
 )
 
 func main() {
-	// 1. Load environment variables
-	if err := godotenv.Load(); err != nil {
-		log.Println("No .env file found, using system environment variables")
+	// 1. Parse flags
+	configPath := flag.String("config", os.Getenv("CONFIG_FILE"), "Path to an optional YAML configuration file (env vars override it)")
+	printConfigOnly := flag.Bool("print-config", false, "Print the effective configuration with secrets redacted and exit")
+	flag.Parse()
+
+	// 2. Load configuration (defaults < YAML file < environment variables)
+	if *printConfigOnly {
+		os.Exit(runPrintConfig(os.Stdout, os.Stderr, *configPath))
 	}
 
-	// 2. Load configuration
-	var cfg config.Config
-	if err := envconfig.Process("", &cfg); err != nil {
+	cfg, err := config.Load(*configPath)
+	if err != nil {
 		log.Fatalf("Error loading config: %v", err)
 	}
 
-	port := getEnv("PORT", "8080")
-	schedulerIntervalMinutes := getEnvAsInt("SCHEDULER_INTERVAL_MINUTES", 10)
-	env := getEnv("ENV", "development")
-	serviceAPIKeys := getEnv("SERVICE_API_KEYS", "")
-
-	// Validate that API keys are configured in production
-	if env == "production" && serviceAPIKeys == "" {
-		log.Fatal("SERVICE_API_KEYS must be configured in production environment")
-	}
+	port := cfg.Server.Port
+	env := cfg.Server.Environment
 
 	// 3. Connect to PostgreSQL
 	db, err := database.NewPostgresDB(&cfg.Database, env)
@@ -58,72 +71,320 @@ func main() {
 
 	// 3.5. Connect to Redis (optional - for rate limiting and caching)
 	var redisClient *cache.RedisClient
-	redisConfig := &cache.RedisConfig{
-		Host:     getEnv("REDIS_HOST", "localhost"),
-		Port:     getEnvAsInt("REDIS_PORT", 6379),
-		Password: getEnv("REDIS_PASSWORD", ""),
-		DB:       getEnvAsInt("REDIS_DB", 0),
+	if cfg.Redis.Enabled {
+		redisConfig := &cache.RedisConfig{
+			Host:     cfg.Redis.Host,
+			Port:     cfg.Redis.Port,
+			Password: cfg.Redis.Password,
+			DB:       cfg.Redis.DB,
+		}
+
+		redisClient, err = cache.NewRedisClient(redisConfig, cfg.Redis.CacheTTL())
+		if err != nil {
+			log.Printf("⚠️  WARNING: Redis connection failed: %v", err)
+			log.Println("⚠️  Rate limiting will be disabled (fail-open mode)")
+			redisClient = nil // Ensure it's nil for fail-open behavior
+		} else {
+			log.Println("✅ Successfully connected to Redis")
+		}
+	} else {
+		log.Println("⚠️  Redis disabled by configuration")
 	}
 
-	redisClient, err = cache.NewRedisClient(redisConfig, 5*time.Minute)
+	// 3.6. Open the event transport (optional - events are not published when unavailable)
+	transports := transport.NewRegistry()
+	mustRegister(transports, transport.Noop, func(context.Context) (events.Publisher, error) {
+		return noop.NewPublisher(), nil
+	})
+	mustRegister(transports, transport.Memory, func(context.Context) (events.Publisher, error) {
+		return memory.NewBus(), nil
+	})
+	mustRegister(transports, transport.RabbitMQ, func(context.Context) (events.Publisher, error) {
+		return rabbitmq.NewPublisher(rabbitmq.PublisherConfig{
+			URL:          cfg.Publisher.URL,
+			Exchange:     cfg.Publisher.Exchange,
+			ExchangeType: cfg.Publisher.ExchangeType,
+			MaxRetries:   cfg.Publisher.MaxRetries,
+			RetryDelay:   cfg.Publisher.RetryDelay(),
+			Format:       cfg.Publisher.EventFormat,
+		})
+	})
+	mustRegister(transports, transport.NATS, func(ctx context.Context) (events.Publisher, error) {
+		return natsjs.NewPublisher(ctx, natsjs.PublisherConfig{
+			URL:            cfg.NATS.URL,
+			Stream:         cfg.NATS.Stream,
+			PublishTimeout: cfg.NATS.PublishTimeout(),
+		})
+	})
+
+	transportName := cfg.Publisher.TransportName()
+	transportPublisher, err := transports.Open(context.Background(), transportName)
 	if err != nil {
-		log.Printf("⚠️  WARNING: Redis connection failed: %v", err)
-		log.Println("⚠️  Rate limiting will be disabled (fail-open mode)")
-		redisClient = nil // Ensure it's nil for fail-open behavior
+		log.Printf("⚠️  WARNING: %v", err)
+		log.Println("⚠️  Event publishing disabled (no broker available)")
+		transportName = transport.Noop
+		transportPublisher = noop.NewPublisher()
 	} else {
-		log.Println("✅ Successfully connected to Redis")
+		log.Printf("✅ Publishing events over %s", transportName)
+	}
+	eventPublisher := transportPublisher
+	if cfg.ValidatesEventSchemas() {
+		eventPublisher = schema.NewValidatingPublisher(eventPublisher, schema.Default())
+		log.Println("✅ Events are validated against their JSON Schemas before publishing")
 	}
 
 	// 4. Initialize repositories (PostgreSQL implementations)
 	inventoryRepo := repository.NewInventoryRepository(db)
 	reservationRepo := repository.NewReservationRepository(db)
 	dlqRepo := stub.NewDLQRepositoryStub() // TODO: Replace with PostgreSQL implementation in Epic 3.5
+	adminAuditRepo := repository.NewAdminAuditRepository(db)
+	reconciliationRepo := repository.NewReconciliationRepository(db)
+	reservationArchiveRepo := repository.NewReservationArchiveRepository(db)
+	stockHistoryRepo := repository.NewStockHistoryRepository(db)
+	lotRepo := repository.NewLotRepository(db)
+	serialRepo := repository.NewSerialUnitRepository(db)
+	bundleRepo := repository.NewBundleRepository(db)
+	channelRepo := repository.NewChannelAllocationRepository(db)
+	limitRepo := repository.NewPurchaseLimitRepository(db)
+	waitlistRepo := repository.NewWaitlistRepository(db)
 
 	// 3. Initialize use cases
-	// Note: Publisher is nil for now (will be integrated with RabbitMQ later)
-	releaseExpiredUseCase := usecase.NewReleaseExpiredReservationsUseCase(inventoryRepo, reservationRepo, nil)
+	// Optimistic-lock conflicts on inventory items are retried with jittered backoff
+	conflictRetry := usecase.RetryPolicy{
+		MaxAttempts: cfg.Reservation.ConflictMaxAttempts,
+		BaseDelay:   cfg.Reservation.ConflictBaseDelay(),
+		MaxDelay:    cfg.Reservation.ConflictMaxDelay(),
+		RetryAfter:  cfg.Reservation.ConflictRetryAfter(),
+		Observer:    metrics.NewContentionMetrics(),
+	}
+	releaseExpiredUseCase := usecase.NewReleaseExpiredReservationsUseCase(inventoryRepo, reservationRepo, eventPublisher).
+		WithRetryPolicy(conflictRetry)
+	checkAvailabilityUseCase := usecase.NewCheckAvailabilityUseCase(inventoryRepo).
+		WithBundles(bundleRepo).
+		WithChannels(channelRepo)
+	reserveStockUseCase := usecase.NewReserveStockUseCase(inventoryRepo, reservationRepo, eventPublisher).
+		WithRetryPolicy(conflictRetry)
+	confirmReservationUseCase := usecase.NewConfirmReservationUseCase(inventoryRepo, reservationRepo, eventPublisher).
+		WithRetryPolicy(conflictRetry)
+	releaseReservationUseCase := usecase.NewReleaseReservationUseCase(inventoryRepo, reservationRepo, eventPublisher).
+		WithRetryPolicy(conflictRetry)
+	if cfg.Reservation.UsesConditionalUpdates() {
+		// Push the stock invariants into single conditional UPDATE statements
+		releaseExpiredUseCase.WithAtomicStock(inventoryRepo)
+		reserveStockUseCase.WithAtomicStock(inventoryRepo)
+		confirmReservationUseCase.WithAtomicStock(inventoryRepo)
+		releaseReservationUseCase.WithAtomicStock(inventoryRepo)
+	}
+	if cfg.Lots.Enabled {
+		// Allocate reservations from lots first-expired-first-out and record the lots they consume
+		releaseExpiredUseCase.WithLots(lotRepo)
+		reserveStockUseCase.WithLots(lotRepo)
+		confirmReservationUseCase.WithLots(lotRepo)
+		releaseReservationUseCase.WithLots(lotRepo)
+	}
+	// Serial-tracked products reserve, sell and release concrete units instead of counts
+	releaseExpiredUseCase.WithSerials(serialRepo)
+	reserveStockUseCase.WithSerials(serialRepo)
+	confirmReservationUseCase.WithSerials(serialRepo)
+	releaseReservationUseCase.WithSerials(serialRepo)
+	// Bundles reserve, sell and release the units of every component under one reservation
+	releaseExpiredUseCase.WithBundles(bundleRepo)
+	reserveStockUseCase.WithBundles(bundleRepo)
+	confirmReservationUseCase.WithBundles(bundleRepo)
+	releaseReservationUseCase.WithBundles(bundleRepo)
+	// Products allocated to sales channels reserve from the allocation of the reservation's channel
+	reserveStockUseCase.WithChannels(channelRepo)
+	// Products with purchase limits cap the units an order, and a customer over a window, can reserve
+	reserveStockUseCase.WithPurchaseLimits(limitRepo)
+	syncCatalogUseCase := usecase.NewSyncCatalogUseCase(inventoryRepo, catalogStockPolicy(cfg.CatalogSync)).
+		WithRetryPolicy(conflictRetry)
 	listDLQMessagesUseCase := usecase.NewListDLQMessagesUseCase(dlqRepo)
 	getDLQCountUseCase := usecase.NewGetDLQCountUseCase(dlqRepo)
 	retryDLQMessageUseCase := usecase.NewRetryDLQMessageUseCase(dlqRepo)
+	recordAdminOperationUseCase := usecase.NewRecordAdminOperationUseCase(adminAuditRepo)
+	listAdminAuditLogUseCase := usecase.NewListAdminAuditLogUseCase(adminAuditRepo)
+	// The catalog lives in orders-service, so missing items are only checked by `sync -reconcile`
+	importStockUseCase := usecase.NewImportStockUseCase(inventoryRepo, inventoryRepo).
+		WithRetryPolicy(conflictRetry)
+	if cfg.Reservation.UsesConditionalUpdates() {
+		// Stock adjustments (delta rows) use the same conditional UPDATE as reservations
+		importStockUseCase.WithAtomicStock(inventoryRepo)
+	}
+	exportStockUseCase := usecase.NewExportStockUseCase(inventoryRepo)
+	reconcileInventoryUseCase := usecase.NewReconcileInventoryUseCase(reconciliationRepo, cfg.Reconcile.Grace()).
+		WithObserver(metrics.NewDriftMetrics())
+	if cfg.Lots.Enabled {
+		reconcileInventoryUseCase.WithLots()
+	}
+	archiveReservationsUseCase := usecase.NewArchiveReservationsUseCase(reservationArchiveRepo, reservationArchiveRepo, usecase.ReservationRetentionPolicy{
+		Retention:       cfg.Retention.Retention(),
+		BatchSize:       cfg.Retention.BatchSize,
+		MaxBatches:      cfg.Retention.MaxBatches,
+		PartitionsAhead: cfg.Retention.PartitionsAhead,
+	}).WithObserver(metrics.NewRetentionMetrics())
+	listArchivedReservationsUseCase := usecase.NewListArchivedReservationsUseCase(reservationArchiveRepo)
+	getArchivedReservationUseCase := usecase.NewGetArchivedReservationUseCase(reservationArchiveRepo)
+	listInventoryItemsUseCase := usecase.NewListInventoryItemsUseCase(inventoryRepo)
+	listReservationsUseCase := usecase.NewListReservationsUseCase(reservationRepo)
+	getOrderReservationUseCase := usecase.NewGetOrderReservationUseCase(reservationRepo, inventoryRepo).
+		WithSerials(serialRepo).
+		WithBundles(bundleRepo)
+	takeStockSnapshotUseCase := usecase.NewTakeStockSnapshotUseCase(stockHistoryRepo, usecase.StockHistoryPolicy{
+		Settle:    cfg.StockHistory.Settle(),
+		Retention: cfg.StockHistory.Retention(),
+	})
+	getStockAsOfUseCase := usecase.NewGetStockAsOfUseCase(stockHistoryRepo)
+	exportStockAsOfUseCase := usecase.NewExportStockAsOfUseCase(stockHistoryRepo)
+	receiveLotUseCase := usecase.NewReceiveLotUseCase(inventoryRepo, lotRepo)
+	listLotsUseCase := usecase.NewListLotsUseCase(inventoryRepo, lotRepo)
+	getReservationLotsUseCase := usecase.NewGetReservationLotsUseCase(reservationRepo, lotRepo)
+	quarantineExpiredLotsUseCase := usecase.NewQuarantineExpiredLotsUseCase(lotRepo, eventPublisher)
+	setSerialTrackingUseCase := usecase.NewSetSerialTrackingUseCase(inventoryRepo)
+	registerSerialsUseCase := usecase.NewRegisterSerialsUseCase(inventoryRepo, serialRepo)
+	listSerialsUseCase := usecase.NewListSerialsUseCase(inventoryRepo, serialRepo)
+	getReservationSerialsUseCase := usecase.NewGetReservationSerialsUseCase(reservationRepo, serialRepo)
+	returnSerialUseCase := usecase.NewReturnSerialUseCase(inventoryRepo, serialRepo)
+	restockSerialUseCase := usecase.NewRestockSerialUseCase(inventoryRepo, serialRepo)
+	defineBundleUseCase := usecase.NewDefineBundleUseCase(inventoryRepo, bundleRepo)
+	getBundleUseCase := usecase.NewGetBundleUseCase(inventoryRepo, bundleRepo)
+	deleteBundleUseCase := usecase.NewDeleteBundleUseCase(inventoryRepo, bundleRepo)
+	getChannelAllocationsUseCase := usecase.NewGetChannelAllocationsUseCase(inventoryRepo, channelRepo)
+	setChannelAllocationsUseCase := usecase.NewSetChannelAllocationsUseCase(inventoryRepo, channelRepo)
+	rebalanceChannelAllocationsUseCase := usecase.NewRebalanceChannelAllocationsUseCase(inventoryRepo, channelRepo)
+	getPurchaseLimitUseCase := usecase.NewGetPurchaseLimitUseCase(limitRepo)
+	setPurchaseLimitUseCase := usecase.NewSetPurchaseLimitUseCase(inventoryRepo, limitRepo)
+	deletePurchaseLimitUseCase := usecase.NewDeletePurchaseLimitUseCase(inventoryRepo, limitRepo)
+	joinWaitlistUseCase := usecase.NewJoinWaitlistUseCase(inventoryRepo, reservationRepo, waitlistRepo).
+		WithTTL(cfg.Waitlist.DefaultTTL(), cfg.Waitlist.MaxTTL())
+	getWaitlistEntryUseCase := usecase.NewGetWaitlistEntryUseCase(waitlistRepo)
+	cancelWaitlistEntryUseCase := usecase.NewCancelWaitlistEntryUseCase(waitlistRepo)
+	listWaitlistUseCase := usecase.NewListWaitlistUseCase(inventoryRepo, waitlistRepo)
+	serveWaitlistUseCase := usecase.NewServeWaitlistUseCase(inventoryRepo, reservationRepo, waitlistRepo,
+		reserveStockUseCase, releaseReservationUseCase, eventPublisher).
+		WithReservationTTL(cfg.Waitlist.ReservationTTL())
+	sweepWaitlistsUseCase := usecase.NewSweepWaitlistsUseCase(waitlistRepo, serveWaitlistUseCase)
+
+	// 3.5. Initialize service authentication (signed tokens; disabled when no keys are configured)
+	denialAudit := auth.NewDenialAudit(cfg.Auth.DenialAuditSize)
+	var tokenVerifier *auth.Verifier
+	if cfg.Auth.Enabled() {
+		tokenKeys, err := cfg.Auth.Keys()
+		if err != nil {
+			log.Fatalf("Invalid service token keys: %v", err)
+		}
+		tokenVerifier, err = auth.NewVerifier(tokenKeys, cfg.Auth.VerifierOptions())
+		if err != nil {
+			log.Fatalf("Failed to initialize token verifier: %v", err)
+		}
+	}
 
 	// 4. Initialize handlers
 	reservationMaintenanceHandler := handler.NewReservationMaintenanceHandler(releaseExpiredUseCase)
 	dlqAdminHandler := handler.NewDLQAdminHandler(listDLQMessagesUseCase, getDLQCountUseCase, retryDLQMessageUseCase)
+	authAuditHandler := handler.NewAuthAuditHandler(denialAudit)
+	adminAuditHandler := handler.NewAdminAuditHandler(listAdminAuditLogUseCase)
+	stockAdminHandler := handler.NewStockAdminHandler(importStockUseCase, exportStockUseCase)
+	adminListingHandler := handler.NewAdminListingHandler(listInventoryItemsUseCase, listReservationsUseCase)
+	reservationArchiveHandler := handler.NewReservationArchiveHandler(listArchivedReservationsUseCase, getArchivedReservationUseCase, archiveReservationsUseCase)
+	orderReservationHandler := handler.NewOrderReservationHandler(getOrderReservationUseCase, confirmReservationUseCase, releaseReservationUseCase)
+	stockHistoryHandler := handler.NewStockHistoryHandler(getStockAsOfUseCase, exportStockAsOfUseCase)
+	lotHandler := handler.NewLotHandler(receiveLotUseCase, listLotsUseCase, getReservationLotsUseCase)
+	serialHandler := handler.NewSerialHandler(setSerialTrackingUseCase, registerSerialsUseCase, listSerialsUseCase,
+		getReservationSerialsUseCase, returnSerialUseCase, restockSerialUseCase)
+	bundleHandler := handler.NewBundleHandler(defineBundleUseCase, getBundleUseCase, deleteBundleUseCase)
+	channelAllocationHandler := handler.NewChannelAllocationHandler(getChannelAllocationsUseCase, setChannelAllocationsUseCase,
+		rebalanceChannelAllocationsUseCase)
+	purchaseLimitHandler := handler.NewPurchaseLimitHandler(getPurchaseLimitUseCase, setPurchaseLimitUseCase, deletePurchaseLimitUseCase)
+	waitlistHandler := handler.NewWaitlistHandler(joinWaitlistUseCase, getWaitlistEntryUseCase, cancelWaitlistEntryUseCase, listWaitlistUseCase)
 
 	// 5. Initialize scheduler
-	schedulerInterval := time.Duration(schedulerIntervalMinutes) * time.Minute
+	schedulerInterval := cfg.Scheduler.Interval()
 	reservationScheduler := scheduler.NewReservationScheduler(releaseExpiredUseCase, schedulerInterval)
+	reconciliationScheduler := scheduler.NewReconciliationScheduler(reconcileInventoryUseCase, cfg.Reconcile.Interval(), cfg.Reconcile.Repair)
+	retentionScheduler := scheduler.NewRetentionScheduler(archiveReservationsUseCase, cfg.Retention.Interval())
+	stockSnapshotScheduler := scheduler.NewStockSnapshotScheduler(takeStockSnapshotUseCase, cfg.StockHistory.SnapshotInterval())
+	lotQuarantineScheduler := scheduler.NewLotQuarantineScheduler(quarantineExpiredLotsUseCase, cfg.Lots.QuarantineInterval())
+	waitlistScheduler := scheduler.NewWaitlistScheduler(sweepWaitlistsUseCase, serveWaitlistUseCase, cfg.Waitlist.SweepInterval())
+	if cfg.Waitlist.Enabled {
+		// Stock freed by releases, expiries, restocks and adjustments is offered to the waitlists first
+		releaseReservationUseCase.WithWaitlist(waitlistScheduler)
+		releaseExpiredUseCase.WithWaitlist(waitlistScheduler)
+		importStockUseCase.WithWaitlist(waitlistScheduler)
+		receiveLotUseCase.WithWaitlist(waitlistScheduler)
+		registerSerialsUseCase.WithWaitlist(waitlistScheduler)
+		restockSerialUseCase.WithWaitlist(waitlistScheduler)
+		joinWaitlistUseCase.WithWaitlist(waitlistScheduler)
+	}
+
+	// 5.2. Initialize catalog sync consumer (optional - product events create and archive inventory items)
+	var catalogConsumer *rabbitmq.Consumer
+	if cfg.CatalogSync.Enabled {
+		catalogHandler := messaging.NewCatalogEventHandler(syncCatalogUseCase)
+		catalogConsumer, err = rabbitmq.NewConsumer(rabbitmq.ConsumerConfig{
+			URL:         cfg.Publisher.URL,
+			Exchange:    cfg.CatalogSync.Exchange,
+			Queue:       cfg.CatalogSync.Queue,
+			BindingKeys: catalogHandler.BindingKeys(),
+			Prefetch:    cfg.CatalogSync.Prefetch,
+			ConsumerTag: "inventory-service-catalog-sync",
+		}, catalogHandler.Handle)
+		if err != nil {
+			log.Printf("⚠️  Catalog sync disabled: %v", err)
+			catalogConsumer = nil
+		}
+	}
+
+	// 5.5. Initialize readiness checks (PostgreSQL and scheduler are critical, Redis and RabbitMQ optional)
+	healthChecker := health.NewChecker(cfg.Health.CheckTimeout())
+	healthChecker.Register("postgres", true, health.DatabaseCheck(db))
+	if cfg.Scheduler.Enabled {
+		healthChecker.Register("scheduler", true, health.HeartbeatCheck(reservationScheduler, 2*schedulerInterval+time.Minute))
+	}
+	if cfg.Redis.Enabled {
+		// A client that failed to connect at startup is reported as not connected
+		var redisPinger health.Pinger
+		if redisClient != nil {
+			redisPinger = redisClient
+		}
+		healthChecker.Register("redis", false, health.PingCheck(redisPinger))
+	}
+	if pinger, ok := transportPublisher.(health.Pinger); ok {
+		healthChecker.Register(transportName, false, health.PingCheck(pinger))
+	}
+	if catalogConsumer != nil {
+		healthChecker.Register("catalog_sync", false, health.PingCheck(catalogConsumer))
+	}
+	healthHandler := handler.NewHealthHandler(healthChecker, "inventory-service", "0.1.0")
 
 	// 6. Configurar Gin
-	gin.SetMode(getEnv("GIN_MODE", gin.DebugMode))
+	gin.SetMode(cfg.Server.GinMode)
 	router := gin.Default()
 
-	// 6.5. Configure rate limiting middleware (T4.3.3)
-	if redisClient != nil {
-		redisAdapter := middleware.NewRedisClientAdapter(redisClient)
-		rateLimitWindowSeconds := getEnvAsInt("RATE_LIMIT_WINDOW_SECONDS", 60)
-		rateLimiterConfig := middleware.MethodBasedRateLimiterConfig{
-			Redis:      redisAdapter,
-			GetLimit:   200, // 200 requests per window for GET/HEAD
-			WriteLimit: 100, // 100 requests per window for POST/PUT/PATCH/DELETE
-			Window:     time.Duration(rateLimitWindowSeconds) * time.Second,
-		}
-		rateLimiter := middleware.NewMethodBasedRateLimiter(rateLimiterConfig)
-		router.Use(rateLimiter.Middleware())
-		log.Printf("🚦 Method-based rate limiting enabled (GET: 200/window, POST: 100/window, window: %ds)", rateLimitWindowSeconds)
+	// 6.5. Configure rate limiting (token bucket per authenticated service, applied to /api, /admin and gRPC)
+	var rateLimiter ratelimit.Limiter
+	rateLimitPolicy := cfg.RateLimit.Policy()
+	if !cfg.RateLimit.Enabled {
+		log.Println("⚠️  Rate limiting disabled by configuration")
+	} else if redisClient != nil {
+		redisLimiter := ratelimit.NewRedisLimiter(redisClient.Scripter(), cfg.RateLimit.RedisTimeout())
+		rateLimiter = ratelimit.NewFallbackLimiter(redisLimiter, ratelimit.NewLocalLimiter(), cfg.RateLimit.ProbeInterval())
+		log.Printf("🚦 Rate limiting enabled per service (GET: %d, writes: %d per %ds, %d quota overrides)",
+			cfg.RateLimit.GetLimit, cfg.RateLimit.WriteLimit, cfg.RateLimit.WindowSeconds, len(cfg.RateLimit.Quotas))
 	} else {
-		log.Println("⚠️  Rate limiting disabled (Redis unavailable)")
+		rateLimiter = ratelimit.NewLocalLimiter()
+		log.Println("⚠️  Rate limiting enforced per instance (Redis unavailable)")
+	}
+	useRateLimit := func(group *gin.RouterGroup) {
+		if rateLimiter != nil {
+			group.Use(middleware.ServiceRateLimitMiddleware(rateLimiter, rateLimitPolicy))
+		}
 	}
 
-	// 7. Health check básico (public endpoint - no auth required)
-	router.GET("/health", func(c *gin.Context) {
-		c.JSON(http.StatusOK, gin.H{
-			"status":    "ok",
-			"service":   "inventory-service",
-			"version":   "0.1.0",
-			"timestamp": time.Now().UTC().Format(time.RFC3339),
-		})
-	})
+	// 7. Health checks (public endpoints - no auth required)
+	// /health is kept as an alias of the liveness probe for backwards compatibility
+	router.GET("/health", healthHandler.Live)
+	router.GET("/health/live", healthHandler.Live)
+	router.GET("/health/ready", healthHandler.Ready)
 
 	// 8. Prometheus metrics endpoint (public endpoint - no auth required)
 	router.GET("/metrics", gin.WrapH(promhttp.Handler()))
@@ -137,77 +398,244 @@ func main() {
 		})
 	})
 
-	// 10. Protected API routes (service-to-service authentication required)
-	// All /api/* and /admin/* routes require valid API key
-	if serviceAPIKeys != "" {
-		apiGroup := router.Group("/api")
-		apiGroup.Use(middleware.ServiceAuthMiddleware(serviceAPIKeys))
-		// TODO: Register API endpoints here in future tasks
-
-		adminGroup := router.Group("/admin")
-		adminGroup.Use(middleware.ServiceAuthMiddleware(serviceAPIKeys))
-		{
-			// T3.3.1 - Reservation maintenance
-			adminGroup.POST("/reservations/release-expired", reservationMaintenanceHandler.ReleaseExpired)
-
-			// T3.3.3 - DLQ management
-			adminGroup.GET("/dlq", dlqAdminHandler.ListDLQMessages)
-			adminGroup.GET("/dlq/count", dlqAdminHandler.GetDLQCount)
-			adminGroup.POST("/dlq/:id/retry", dlqAdminHandler.RetryMessage)
+	// 10. API and admin routes, protected by signed service tokens with per-route
+	// scopes. Development mode registers the same routes without authentication.
+	apiGroup := router.Group("/api")
+	adminGroup := router.Group("/admin")
+	var requireScopes func(scopes ...string) gin.HandlerFunc
+	if tokenVerifier != nil {
+		apiGroup.Use(middleware.ServiceAuthMiddleware(tokenVerifier, denialAudit))
+		adminGroup.Use(middleware.ServiceAuthMiddleware(tokenVerifier, denialAudit))
+		requireScopes = func(scopes ...string) gin.HandlerFunc {
+			return middleware.RequireScopes(denialAudit, scopes...)
 		}
-		log.Println("🔒 Service-to-Service authentication enabled for /api and /admin routes")
+	}
+	apiGroup.Use(middleware.UserIdentityMiddleware())
+	useRateLimit(apiGroup)
+	adminGroup.Use(middleware.AdminAuditMiddleware(recordAdminOperationUseCase))
+	useRateLimit(adminGroup)
+	registerRoutes(apiGroup, adminGroup, routeHandlers{
+		orderReservation:       orderReservationHandler,
+		waitlist:               waitlistHandler,
+		reservationMaintenance: reservationMaintenanceHandler,
+		adminListing:           adminListingHandler,
+		reservationArchive:     reservationArchiveHandler,
+		dlqAdmin:               dlqAdminHandler,
+		authAudit:              authAuditHandler,
+		adminAudit:             adminAuditHandler,
+		stockAdmin:             stockAdminHandler,
+		stockHistory:           stockHistoryHandler,
+		lot:                    lotHandler,
+		serial:                 serialHandler,
+		bundle:                 bundleHandler,
+		channelAllocation:      channelAllocationHandler,
+		purchaseLimit:          purchaseLimitHandler,
+	}, requireScopes)
+	if tokenVerifier != nil {
+		log.Printf("🔒 Service token authentication enabled for /api and /admin routes (%d keys)", len(cfg.Auth.TokenKeys))
 	} else {
-		// Development mode: admin endpoints without authentication
-		adminGroup := router.Group("/admin")
-		{
-			adminGroup.POST("/reservations/release-expired", reservationMaintenanceHandler.ReleaseExpired)
-			adminGroup.GET("/dlq", dlqAdminHandler.ListDLQMessages)
-			adminGroup.GET("/dlq/count", dlqAdminHandler.GetDLQCount)
-			adminGroup.POST("/dlq/:id/retry", dlqAdminHandler.RetryMessage)
-		}
 		log.Println("⚠️  WARNING: Running without service authentication (development mode)")
 	}
 
 	// 11. Start scheduler (T3.3.1 - auto-release expired reservations)
-	reservationScheduler.Start()
-	log.Printf("🔄 Reservation scheduler started (interval: %d minutes)", schedulerIntervalMinutes)
+	if cfg.Scheduler.Enabled {
+		reservationScheduler.Start()
+		log.Printf("🔄 Reservation scheduler started (interval: %d minutes)", cfg.Scheduler.IntervalMinutes)
+	} else {
+		log.Println("⚠️  Reservation scheduler disabled by configuration")
+	}
+	if cfg.Reconcile.Enabled {
+		reconciliationScheduler.Start()
+		log.Printf("🔄 Reconciliation scheduler started (interval: %d minutes, repair: %v)", cfg.Reconcile.IntervalMinutes, cfg.Reconcile.Repair)
+	}
+	if cfg.Retention.Enabled {
+		retentionScheduler.Start()
+		log.Printf("🔄 Retention scheduler started (interval: %d minutes, retention: %d days)", cfg.Retention.IntervalMinutes, cfg.Retention.Days)
+	}
+	if cfg.StockHistory.Enabled {
+		stockSnapshotScheduler.Start()
+		log.Printf("🔄 Stock snapshot scheduler started (interval: %d minutes, retention: %d days)", cfg.StockHistory.SnapshotIntervalMinutes, cfg.StockHistory.RetentionDays)
+	}
+	if cfg.Lots.Enabled {
+		lotQuarantineScheduler.Start()
+		log.Printf("🔄 Lot quarantine scheduler started (interval: %d minutes)", cfg.Lots.QuarantineIntervalMinutes)
+	}
+	if cfg.Waitlist.Enabled {
+		waitlistScheduler.Start()
+		log.Printf("🔄 Waitlist scheduler started (sweep interval: %d minutes)", cfg.Waitlist.SweepIntervalMinutes)
+	}
+
+	// 11.2. Start catalog sync consumer
+	stopCatalogSync := func() {}
+	if catalogConsumer != nil {
+		catalogCtx, cancelCatalog := context.WithCancel(context.Background())
+		stopCatalogSync = cancelCatalog
+		go func() {
+			if err := catalogConsumer.Run(catalogCtx); err != nil {
+				log.Printf("❌ Catalog sync consumer stopped: %v", err)
+			}
+		}()
+		log.Printf("🔄 Catalog sync consuming %s (initial stock: %d)", cfg.CatalogSync.Queue, cfg.CatalogSync.InitialStock)
+	}
+
+	// 11.5. Configurar servidor gRPC (service-to-service, same use cases as the HTTP API)
+	var grpcServer *grpc.Server
+	var grpcListener net.Listener
+	stopHealthWatch := func() {}
+	if cfg.Server.GRPCEnabled {
+		var grpcOpts []grpc.ServerOption
+		if tokenVerifier != nil {
+			grpcAuth := interceptor.NewServiceAuth(tokenVerifier, denialAudit, grpcserver.MethodScopes())
+			grpcOpts = append(grpcOpts,
+				grpc.ChainUnaryInterceptor(grpcAuth.Unary()),
+				grpc.ChainStreamInterceptor(grpcAuth.Stream()),
+			)
+		}
+		// End users come from the token's user claim or the x-user-id metadata, so it
+		// must follow authentication
+		userIdentity := interceptor.NewUserIdentity()
+		grpcOpts = append(grpcOpts,
+			grpc.ChainUnaryInterceptor(userIdentity.Unary()),
+			grpc.ChainStreamInterceptor(userIdentity.Stream()),
+		)
+		if rateLimiter != nil {
+			grpcRateLimit := interceptor.NewRateLimit(rateLimiter, rateLimitPolicy, grpcserver.WriteMethods())
+			grpcOpts = append(grpcOpts, grpc.ChainUnaryInterceptor(grpcRateLimit.Unary()))
+		}
+
+		inventoryGRPCServer := grpcserver.NewInventoryServer(
+			checkAvailabilityUseCase,
+			reserveStockUseCase,
+			confirmReservationUseCase,
+			releaseReservationUseCase,
+			cfg.Reservation.MaxTTL(),
+		)
+		grpcHealthServer := grpchealth.NewServer()
+		grpcServer = grpcserver.NewGRPCServer(inventoryGRPCServer, grpcHealthServer, grpcOpts...)
+
+		healthWatchCtx, cancelHealthWatch := context.WithCancel(context.Background())
+		stopHealthWatch = cancelHealthWatch
+		go grpcserver.WatchReadiness(healthWatchCtx, healthChecker, grpcHealthServer, 10*time.Second)
+
+		grpcListener, err = net.Listen("tcp", fmt.Sprintf(":%s", cfg.Server.GRPCPort))
+		if err != nil {
+			log.Fatalf("❌ Failed to listen on gRPC port %s: %v", cfg.Server.GRPCPort, err)
+		}
+	}
 
 	// 12. Configurar servidor HTTP
 	srv := &http.Server{
 		Addr:         fmt.Sprintf(":%s", port),
 		Handler:      router,
-		ReadTimeout:  10 * time.Second,
-		WriteTimeout: 10 * time.Second,
-		IdleTimeout:  120 * time.Second,
+		ReadTimeout:  time.Duration(cfg.Server.ReadTimeout) * time.Second,
+		WriteTimeout: time.Duration(cfg.Server.WriteTimeout) * time.Second,
+		IdleTimeout:  time.Duration(cfg.Server.IdleTimeout) * time.Second,
 	}
 
 	// 13. Iniciar servidor en goroutine
 	go func() {
 		log.Printf("🚀 Starting Inventory Service on port %s", port)
-		log.Printf("📊 Health check: http://localhost:%s/health", port)
+		log.Printf("📊 Health check: http://localhost:%s/health/live (liveness), /health/ready (readiness)", port)
 		log.Printf("📈 Metrics endpoint: http://localhost:%s/metrics", port)
+		log.Printf("🛒 Order reservation endpoints:")
+		log.Printf("   GET  http://localhost:%s/api/inventory/orders/:orderId/reservation", port)
+		log.Printf("   POST http://localhost:%s/api/inventory/orders/:orderId/reservation/confirm", port)
+		log.Printf("   DEL  http://localhost:%s/api/inventory/orders/:orderId/reservation", port)
+		log.Printf("⏳ Waitlist endpoints:")
+		log.Printf("   POST http://localhost:%s/api/inventory/waitlist", port)
+		log.Printf("   GET  http://localhost:%s/api/inventory/waitlist/:id", port)
+		log.Printf("   DEL  http://localhost:%s/api/inventory/waitlist/:id", port)
 		log.Printf("🔧 Admin endpoints:")
 		log.Printf("   POST http://localhost:%s/admin/reservations/release-expired", port)
+		log.Printf("   GET  http://localhost:%s/admin/reservations", port)
+		log.Printf("   GET  http://localhost:%s/admin/reservations/archive", port)
+		log.Printf("   GET  http://localhost:%s/admin/reservations/archive/:id", port)
+		log.Printf("   POST http://localhost:%s/admin/reservations/archive/run", port)
 		log.Printf("   GET  http://localhost:%s/admin/dlq", port)
 		log.Printf("   GET  http://localhost:%s/admin/dlq/count", port)
 		log.Printf("   POST http://localhost:%s/admin/dlq/:id/retry", port)
+		log.Printf("   GET  http://localhost:%s/admin/auth/denials", port)
+		log.Printf("   GET  http://localhost:%s/admin/audit", port)
+		log.Printf("   GET  http://localhost:%s/admin/inventory", port)
+		log.Printf("   POST http://localhost:%s/admin/inventory/import", port)
+		log.Printf("   GET  http://localhost:%s/admin/inventory/export", port)
+		log.Printf("   GET  http://localhost:%s/admin/inventory/export/as-of?ts=", port)
+		log.Printf("   GET  http://localhost:%s/admin/inventory/:productId/as-of?ts=", port)
+		log.Printf("   POST http://localhost:%s/admin/inventory/:productId/lots", port)
+		log.Printf("   GET  http://localhost:%s/admin/inventory/:productId/lots", port)
+		log.Printf("   GET  http://localhost:%s/admin/reservations/:id/lots", port)
+		log.Printf("   PUT  http://localhost:%s/admin/inventory/:productId/serial-tracking", port)
+		log.Printf("   POST http://localhost:%s/admin/inventory/:productId/serials", port)
+		log.Printf("   GET  http://localhost:%s/admin/inventory/:productId/serials", port)
+		log.Printf("   POST http://localhost:%s/admin/inventory/:productId/serials/:serial/return", port)
+		log.Printf("   POST http://localhost:%s/admin/inventory/:productId/serials/:serial/restock", port)
+		log.Printf("   GET  http://localhost:%s/admin/reservations/:id/serials", port)
+		log.Printf("   PUT  http://localhost:%s/admin/inventory/:productId/bundle", port)
+		log.Printf("   GET  http://localhost:%s/admin/inventory/:productId/bundle", port)
+		log.Printf("   DEL  http://localhost:%s/admin/inventory/:productId/bundle", port)
+		log.Printf("   GET  http://localhost:%s/admin/inventory/:productId/channels", port)
+		log.Printf("   PUT  http://localhost:%s/admin/inventory/:productId/channels", port)
+		log.Printf("   POST http://localhost:%s/admin/inventory/:productId/channels/rebalance", port)
+		log.Printf("   GET  http://localhost:%s/admin/inventory/:productId/limits", port)
+		log.Printf("   PUT  http://localhost:%s/admin/inventory/:productId/limits", port)
+		log.Printf("   DEL  http://localhost:%s/admin/inventory/:productId/limits", port)
+		log.Printf("   GET  http://localhost:%s/admin/inventory/:productId/waitlist", port)
 		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 			log.Fatalf("❌ Server failed to start: %v", err)
 		}
 	}()
 
+	if grpcServer != nil {
+		go func() {
+			log.Printf("🚀 Starting gRPC server on port %s (inventory.v1.InventoryService, health, reflection)", cfg.Server.GRPCPort)
+			if err := grpcServer.Serve(grpcListener); err != nil {
+				log.Fatalf("❌ gRPC server failed to start: %v", err)
+			}
+		}()
+	}
+
 	// 14. Graceful shutdown
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 
 	log.Println("⏳ Shutting down server...")
-	log.Println("⏳ Stopping scheduler...")
-	reservationScheduler.Stop()
+	if cfg.Scheduler.Enabled {
+		log.Println("⏳ Stopping scheduler...")
+		reservationScheduler.Stop()
+	}
+	if cfg.Reconcile.Enabled {
+		reconciliationScheduler.Stop()
+	}
+	if cfg.Retention.Enabled {
+		retentionScheduler.Stop()
+	}
+	if cfg.StockHistory.Enabled {
+		stockSnapshotScheduler.Stop()
+	}
+	if cfg.Lots.Enabled {
+		lotQuarantineScheduler.Stop()
+	}
+	if cfg.Waitlist.Enabled {
+		waitlistScheduler.Stop()
+	}
+	if catalogConsumer != nil {
+		log.Println("⏳ Stopping catalog sync consumer...")
+		stopCatalogSync()
+		if err := catalogConsumer.Close(); err != nil {
+			log.Printf("⚠️  Error closing catalog sync consumer: %v", err)
+		}
+	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(cfg.Server.ShutdownTimeout)*time.Second)
 	defer cancel()
 
+	if grpcServer != nil {
+		log.Println("⏳ Stopping gRPC server...")
+		stopHealthWatch()
+		stopGRPCServer(ctx, grpcServer)
+	}
+
 	if err := srv.Shutdown(ctx); err != nil {
 		log.Fatalf("❌ Server forced to shutdown: %v", err)
 	}
@@ -220,6 +648,14 @@ func main() {
 		log.Println("✅ Database connection closed")
 	}
 
+	// Close the event transport
+	log.Printf("⏳ Closing %s event transport...", transportName)
+	if err := transportPublisher.Close(); err != nil {
+		log.Printf("⚠️  Error closing %s: %v", transportName, err)
+	} else {
+		log.Printf("✅ %s event transport closed", transportName)
+	}
+
 	// Close Redis connection
 	if redisClient != nil {
 		log.Println("⏳ Closing Redis connection...")
@@ -233,24 +669,156 @@ func main() {
 	log.Println("✅ Server exited gracefully")
 }
 
-// getEnv obtiene una variable de entorno o retorna un valor por defecto
-func getEnv(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
+// runPrintConfig prints the effective configuration with secrets redacted.
+// Validation problems are reported on stderr and produce a non-zero exit code,
+// so the mode can be used in CI to check a deployment's configuration.
+func runPrintConfig(stdout, stderr io.Writer, configPath string) int {
+	cfg, err := config.LoadUnvalidated(configPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error loading config: %v\n", err)
+		return 1
 	}
-	return defaultValue
+
+	out, err := cfg.YAML()
+	if err != nil {
+		fmt.Fprintf(stderr, "Error rendering config: %v\n", err)
+		return 1
+	}
+	fmt.Fprint(stdout, string(out))
+
+	if err := cfg.Validate(); err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+
+	return 0
 }
 
-// getEnvAsInt obtiene una variable de entorno como int o retorna un valor por defecto
-// Si la variable existe pero no puede parsearse, loguea un warning para facilitar troubleshooting
-func getEnvAsInt(key string, defaultValue int) int {
-	if value := os.Getenv(key); value != "" {
-		intValue, err := strconv.Atoi(value)
-		if err != nil {
-			log.Printf("Warning: Invalid value for %s: '%s' (error: %v), using default: %d", key, value, err, defaultValue)
-			return defaultValue
+// stopGRPCServer drains in-flight RPCs, forcing the stop if ctx expires first
+func stopGRPCServer(ctx context.Context, srv *grpc.Server) {
+	stopped := make(chan struct{})
+	go func() {
+		srv.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		srv.Stop()
+	}
+}
+
+// catalogStockPolicy builds the initial stock policy of catalog sync from its configuration
+func catalogStockPolicy(cfg config.CatalogSyncConfig) usecase.InitialStockPolicy {
+	policy := usecase.InitialStockPolicy{DefaultQuantity: cfg.InitialStock}
+	for _, rule := range cfg.InitialStockRules {
+		policy.Rules = append(policy.Rules, usecase.InitialStockRule{SKUPrefix: rule.SKUPrefix, Quantity: rule.Quantity})
+	}
+	return policy
+}
+
+// mustRegister adds a transport factory; names are fixed at compile time so a clash is a bug
+func mustRegister(registry *transport.Registry, name string, factory transport.Factory) {
+	if err := registry.Register(name, factory); err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+}
+
+// routeHandlers are the HTTP handlers behind the /api and /admin routes
+type routeHandlers struct {
+	orderReservation       *handler.OrderReservationHandler
+	waitlist               *handler.WaitlistHandler
+	reservationMaintenance *handler.ReservationMaintenanceHandler
+	adminListing           *handler.AdminListingHandler
+	reservationArchive     *handler.ReservationArchiveHandler
+	dlqAdmin               *handler.DLQAdminHandler
+	authAudit              *handler.AuthAuditHandler
+	adminAudit             *handler.AdminAuditHandler
+	stockAdmin             *handler.StockAdminHandler
+	stockHistory           *handler.StockHistoryHandler
+	lot                    *handler.LotHandler
+	serial                 *handler.SerialHandler
+	bundle                 *handler.BundleHandler
+	channelAllocation      *handler.ChannelAllocationHandler
+	purchaseLimit          *handler.PurchaseLimitHandler
+}
+
+// registerRoutes registers the /api and /admin routes on their groups. requireScopes
+// returns the middleware that checks the scopes a route needs; nil registers every
+// route without it, in development mode.
+func registerRoutes(api, admin *gin.RouterGroup, h routeHandlers, requireScopes func(scopes ...string) gin.HandlerFunc) {
+	scoped := func(scope string, handle gin.HandlerFunc) []gin.HandlerFunc {
+		if requireScopes == nil {
+			return []gin.HandlerFunc{handle}
 		}
-		return intValue
+		return []gin.HandlerFunc{requireScopes(scope), handle}
 	}
-	return defaultValue
+
+	// Reservations addressed by order ID (orders-service does not know reservation IDs)
+	api.GET("/inventory/orders/:orderId/reservation", scoped(auth.ScopeInventoryRead, h.orderReservation.GetOrderReservation)...)
+	api.POST("/inventory/orders/:orderId/reservation/confirm", scoped(auth.ScopeInventoryReserve, h.orderReservation.ConfirmOrderReservation)...)
+	api.DELETE("/inventory/orders/:orderId/reservation", scoped(auth.ScopeInventoryReserve, h.orderReservation.ReleaseOrderReservation)...)
+
+	// Waitlists: orders reserved automatically when stock frees up
+	api.POST("/inventory/waitlist", scoped(auth.ScopeInventoryReserve, h.waitlist.JoinWaitlist)...)
+	api.GET("/inventory/waitlist/:id", scoped(auth.ScopeInventoryRead, h.waitlist.GetWaitlistEntry)...)
+	api.DELETE("/inventory/waitlist/:id", scoped(auth.ScopeInventoryReserve, h.waitlist.CancelWaitlistEntry)...)
+	// TODO: Register the remaining API endpoints here in future tasks
+
+	// T3.3.1 - Reservation maintenance
+	admin.POST("/reservations/release-expired", scoped(auth.ScopeAdminReservations, h.reservationMaintenance.ReleaseExpired)...)
+	admin.GET("/reservations", scoped(auth.ScopeAdminReservations, h.adminListing.ListReservations)...)
+	admin.GET("/reservations/archive", scoped(auth.ScopeAdminReservations, h.reservationArchive.ListArchived)...)
+	admin.GET("/reservations/archive/:id", scoped(auth.ScopeAdminReservations, h.reservationArchive.GetArchived)...)
+	admin.POST("/reservations/archive/run", scoped(auth.ScopeAdminReservations, h.reservationArchive.RunRetention)...)
+
+	// T3.3.3 - DLQ management
+	admin.GET("/dlq", scoped(auth.ScopeAdminDLQ, h.dlqAdmin.ListDLQMessages)...)
+	admin.GET("/dlq/count", scoped(auth.ScopeAdminDLQ, h.dlqAdmin.GetDLQCount)...)
+	admin.POST("/dlq/:id/retry", scoped(auth.ScopeAdminDLQ, h.dlqAdmin.RetryMessage)...)
+
+	// Authentication denial audit
+	admin.GET("/auth/denials", scoped(auth.ScopeAdminAudit, h.authAudit.ListDenials)...)
+
+	// Admin operations audit trail
+	admin.GET("/audit", scoped(auth.ScopeAdminAudit, h.adminAudit.ListAuditLog)...)
+
+	// Bulk stock counts
+	admin.GET("/inventory", scoped(auth.ScopeAdminStock, h.adminListing.ListInventory)...)
+	admin.POST("/inventory/import", scoped(auth.ScopeAdminStock, h.stockAdmin.ImportStock)...)
+	admin.GET("/inventory/export", scoped(auth.ScopeAdminStock, h.stockAdmin.ExportStock)...)
+	admin.GET("/inventory/export/as-of", scoped(auth.ScopeAdminStock, h.stockHistory.ExportStockAsOf)...)
+	admin.GET("/inventory/:productId/as-of", scoped(auth.ScopeAdminStock, h.stockHistory.GetStockAsOf)...)
+
+	// Lots
+	admin.POST("/inventory/:productId/lots", scoped(auth.ScopeAdminStock, h.lot.ReceiveLot)...)
+	admin.GET("/inventory/:productId/lots", scoped(auth.ScopeAdminStock, h.lot.ListLots)...)
+	admin.GET("/reservations/:id/lots", scoped(auth.ScopeAdminReservations, h.lot.GetReservationLots)...)
+
+	// Serialized units
+	admin.PUT("/inventory/:productId/serial-tracking", scoped(auth.ScopeAdminStock, h.serial.SetSerialTracking)...)
+	admin.POST("/inventory/:productId/serials", scoped(auth.ScopeAdminStock, h.serial.RegisterSerials)...)
+	admin.GET("/inventory/:productId/serials", scoped(auth.ScopeAdminStock, h.serial.ListSerials)...)
+	admin.POST("/inventory/:productId/serials/:serial/return", scoped(auth.ScopeAdminStock, h.serial.ReturnSerial)...)
+	admin.POST("/inventory/:productId/serials/:serial/restock", scoped(auth.ScopeAdminStock, h.serial.RestockSerial)...)
+	admin.GET("/reservations/:id/serials", scoped(auth.ScopeAdminReservations, h.serial.GetReservationSerials)...)
+
+	// Bundles
+	admin.PUT("/inventory/:productId/bundle", scoped(auth.ScopeAdminStock, h.bundle.DefineBundle)...)
+	admin.GET("/inventory/:productId/bundle", scoped(auth.ScopeAdminStock, h.bundle.GetBundle)...)
+	admin.DELETE("/inventory/:productId/bundle", scoped(auth.ScopeAdminStock, h.bundle.DeleteBundle)...)
+
+	// Sales channel allocations
+	admin.GET("/inventory/:productId/channels", scoped(auth.ScopeAdminStock, h.channelAllocation.GetChannelAllocations)...)
+	admin.PUT("/inventory/:productId/channels", scoped(auth.ScopeAdminStock, h.channelAllocation.SetChannelAllocations)...)
+	admin.POST("/inventory/:productId/channels/rebalance", scoped(auth.ScopeAdminStock, h.channelAllocation.RebalanceChannelAllocations)...)
+
+	// Purchase limits
+	admin.GET("/inventory/:productId/limits", scoped(auth.ScopeAdminStock, h.purchaseLimit.GetPurchaseLimit)...)
+	admin.PUT("/inventory/:productId/limits", scoped(auth.ScopeAdminStock, h.purchaseLimit.SetPurchaseLimit)...)
+	admin.DELETE("/inventory/:productId/limits", scoped(auth.ScopeAdminStock, h.purchaseLimit.DeletePurchaseLimit)...)
+
+	// Waitlist of a product, in serving order
+	admin.GET("/inventory/:productId/waitlist", scoped(auth.ScopeAdminReservations, h.waitlist.ListWaitlist)...)
 }
@@ -3,11 +3,10 @@ package main
 import (
 	"context"
 	"log"
+	"os"
 	"time"
 
 	"github.com/google/uuid"
-	"github.com/joho/godotenv"
-	"github.com/kelseyhightower/envconfig"
 	"gorm.io/gorm"
 
 	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
@@ -27,14 +26,9 @@ type SeedProduct struct {
 func main() {
 	log.Println("🌱 Starting database seed process...")
 
-	// 1. Load environment variables
-	if err := godotenv.Load(); err != nil {
-		log.Println("No .env file found, using system environment variables")
-	}
-
-	// 2. Load configuration
-	var cfg config.Config
-	if err := envconfig.Process("", &cfg); err != nil {
+	// 1-2. Load configuration (.env, optional CONFIG_FILE and environment variables)
+	cfg, err := config.Load(os.Getenv("CONFIG_FILE"))
+	if err != nil {
 		log.Fatalf("Error loading config: %v", err)
 	}
 
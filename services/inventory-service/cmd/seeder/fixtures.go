@@ -0,0 +1,423 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
+	"gorm.io/gorm"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/infrastructure/persistence/model"
+)
+
+// DefaultFixtureSeed is used when neither the fixtures nor -seed set one
+const DefaultFixtureSeed = 1
+
+// DLQ message statuses accepted by the dlq_messages table
+var dlqStatuses = []string{"pending", "retrying", "failed", "resolved"}
+
+// Fixtures describes a reproducible inventory scenario. Times are relative to
+// the moment the fixtures are loaded, and every generated ID comes from Seed,
+// so loading the same file twice produces the same rows.
+type Fixtures struct {
+	Seed        int64               `yaml:"seed" json:"seed"`
+	Items       []ItemFixture       `yaml:"items" json:"items"`
+	DLQMessages []DLQMessageFixture `yaml:"dlq_messages" json:"dlq_messages"`
+}
+
+// ItemFixture is an inventory item and its reservations.
+// Reserved is derived from the pending reservations, including the ones whose
+// expiry is already in the past.
+type ItemFixture struct {
+	Key          string               `yaml:"key" json:"key"`               // name used in errors and logs
+	ProductID    string               `yaml:"product_id" json:"product_id"` // generated from the seed when empty
+	Quantity     int                  `yaml:"quantity" json:"quantity"`
+	Archived     bool                 `yaml:"archived" json:"archived"`
+	Reservations []ReservationFixture `yaml:"reservations" json:"reservations"`
+}
+
+// ReservationFixture is one or more identical reservations of an item
+type ReservationFixture struct {
+	Status    entity.ReservationStatus `yaml:"status" json:"status"`
+	Quantity  int                      `yaml:"quantity" json:"quantity"`
+	Count     int                      `yaml:"count" json:"count"`           // defaults to 1
+	ExpiresIn *Duration                `yaml:"expires_in" json:"expires_in"` // negative for past expiry
+	OrderID   string                   `yaml:"order_id" json:"order_id"`     // only with count 1
+}
+
+// DLQMessageFixture is a message in the dead letter queue
+type DLQMessageFixture struct {
+	MessageType  string         `yaml:"message_type" json:"message_type"`
+	Payload      map[string]any `yaml:"payload" json:"payload"`
+	ErrorMessage string         `yaml:"error_message" json:"error_message"`
+	RetryCount   int            `yaml:"retry_count" json:"retry_count"`
+	MaxRetries   int            `yaml:"max_retries" json:"max_retries"` // defaults to 3
+	Status       string         `yaml:"status" json:"status"`           // defaults to pending
+	Age          Duration       `yaml:"age" json:"age"`                 // how long ago the message failed
+}
+
+// Duration is a time.Duration written as "15m", "-2h" or "1h30m"
+type Duration time.Duration
+
+// UnmarshalYAML parses the duration string
+func (d *Duration) UnmarshalYAML(value *yaml.Node) error {
+	return d.parse(value.Value)
+}
+
+// UnmarshalJSON parses the duration string
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return fmt.Errorf("duration must be a string like \"15m\": %w", err)
+	}
+	return d.parse(text)
+}
+
+func (d *Duration) parse(text string) error {
+	parsed, err := time.ParseDuration(strings.TrimSpace(text))
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// FixtureRows are the rows generated from fixtures, ready to insert
+type FixtureRows struct {
+	Items        []model.InventoryItemModel
+	Reservations []model.ReservationModel
+	DLQMessages  []DLQMessageRecord
+}
+
+// DLQMessageRecord is a row of the dlq_messages table
+type DLQMessageRecord struct {
+	ID                uuid.UUID
+	MessageType       string
+	Payload           string
+	ErrorMessage      string
+	RetryCount        int
+	MaxRetries        int
+	Status            string
+	OriginalTimestamp time.Time
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+	LastRetryAt       *time.Time
+}
+
+// LoadFixtures reads a fixtures file. Files ending in .json are decoded as JSON,
+// anything else as YAML. Unknown fields are rejected to catch typos.
+func LoadFixtures(path string) (*Fixtures, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixtures: %w", err)
+	}
+
+	var fixtures Fixtures
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(&fixtures)
+	} else {
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		err = decoder.Decode(&fixtures)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse fixtures %s: %w", path, err)
+	}
+
+	if err := fixtures.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid fixtures %s: %w", path, err)
+	}
+	return &fixtures, nil
+}
+
+// Validate checks the fixtures and reports every problem found
+func (f *Fixtures) Validate() error {
+	var problems []error
+	fail := func(format string, args ...any) {
+		problems = append(problems, fmt.Errorf(format, args...))
+	}
+
+	keys := make(map[string]bool, len(f.Items))
+	productIDs := make(map[string]string, len(f.Items))
+	orderIDs := make(map[string]string)
+
+	for i, item := range f.Items {
+		name := item.name(i)
+		if item.Key != "" {
+			if keys[item.Key] {
+				fail("%s: duplicate key", name)
+			}
+			keys[item.Key] = true
+		}
+		if item.ProductID != "" {
+			if id, err := uuid.Parse(item.ProductID); err != nil {
+				fail("%s: product_id %q is not a UUID", name, item.ProductID)
+			} else if other, seen := productIDs[id.String()]; seen {
+				fail("%s: product_id is also used by %s", name, other)
+			} else {
+				productIDs[id.String()] = name
+			}
+		}
+		if item.Quantity < 0 {
+			fail("%s: quantity must be >= 0, got %d", name, item.Quantity)
+		}
+
+		reserved := 0
+		for j, reservation := range item.Reservations {
+			where := fmt.Sprintf("%s reservation %d", name, j+1)
+			switch reservation.Status {
+			case entity.ReservationPending, entity.ReservationConfirmed, entity.ReservationReleased, entity.ReservationExpired:
+			default:
+				fail("%s: status must be pending, confirmed, released or expired, got %q", where, reservation.Status)
+			}
+			if reservation.Quantity <= 0 {
+				fail("%s: quantity must be > 0, got %d", where, reservation.Quantity)
+			}
+			if reservation.Count < 0 {
+				fail("%s: count must be >= 0, got %d", where, reservation.Count)
+			}
+			if reservation.Status == entity.ReservationExpired && reservation.ExpiresIn != nil && *reservation.ExpiresIn > 0 {
+				fail("%s: expired reservations need a negative expires_in", where)
+			}
+			if reservation.OrderID != "" {
+				if reservation.count() != 1 {
+					fail("%s: order_id can only be set with count 1", where)
+				}
+				if id, err := uuid.Parse(reservation.OrderID); err != nil {
+					fail("%s: order_id %q is not a UUID", where, reservation.OrderID)
+				} else if other, seen := orderIDs[id.String()]; seen {
+					fail("%s: order_id is also used by %s", where, other)
+				} else {
+					orderIDs[id.String()] = where
+				}
+			}
+			if reservation.Status == entity.ReservationPending {
+				reserved += reservation.Quantity * reservation.count()
+			}
+		}
+		if reserved > item.Quantity {
+			fail("%s: pending reservations hold %d units but quantity is %d", name, reserved, item.Quantity)
+		}
+	}
+
+	for i, message := range f.DLQMessages {
+		where := fmt.Sprintf("dlq message %d", i+1)
+		if message.MessageType == "" {
+			fail("%s: message_type is required", where)
+		}
+		if message.ErrorMessage == "" {
+			fail("%s: error_message is required", where)
+		}
+		if message.RetryCount < 0 {
+			fail("%s: retry_count must be >= 0, got %d", where, message.RetryCount)
+		}
+		if message.MaxRetries < 0 {
+			fail("%s: max_retries must be >= 0, got %d", where, message.MaxRetries)
+		}
+		if message.Status != "" && !contains(dlqStatuses, message.Status) {
+			fail("%s: status must be one of %s, got %q", where, strings.Join(dlqStatuses, ", "), message.Status)
+		}
+		if message.Age < 0 {
+			fail("%s: age must be >= 0", where)
+		}
+	}
+
+	return errors.Join(problems...)
+}
+
+// Build generates the rows of the fixtures. IDs come from seed; times are relative to now.
+func (f *Fixtures) Build(now time.Time, seed int64) (*FixtureRows, error) {
+	rnd := rand.New(rand.NewSource(seed))
+	newID := func() uuid.UUID {
+		id, err := uuid.NewRandomFromReader(rnd)
+		if err != nil {
+			panic(err) // math/rand never fails to read
+		}
+		return id
+	}
+	now = now.UTC()
+
+	rows := &FixtureRows{
+		Items:        make([]model.InventoryItemModel, 0, len(f.Items)),
+		Reservations: make([]model.ReservationModel, 0),
+		DLQMessages:  make([]DLQMessageRecord, 0, len(f.DLQMessages)),
+	}
+
+	for _, item := range f.Items {
+		// IDs are drawn even when given so that editing one item keeps the others stable
+		itemID, productID := newID(), newID()
+		if item.ProductID != "" {
+			productID = uuid.MustParse(item.ProductID)
+		}
+
+		itemRow := model.InventoryItemModel{
+			ID:        itemID,
+			ProductID: productID,
+			Quantity:  item.Quantity,
+			Version:   1,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		if item.Archived {
+			archivedAt := now
+			itemRow.ArchivedAt = &archivedAt
+		}
+
+		for _, reservation := range item.Reservations {
+			for n := 0; n < reservation.count(); n++ {
+				reservationID, orderID := newID(), newID()
+				if reservation.OrderID != "" {
+					orderID = uuid.MustParse(reservation.OrderID)
+				}
+				expiresAt := now.Add(reservation.expiresIn())
+				createdAt := expiresAt.Add(-entity.DefaultReservationDuration)
+				if createdAt.After(now) {
+					createdAt = now
+				}
+
+				rows.Reservations = append(rows.Reservations, model.ReservationModel{
+					ID:              reservationID,
+					InventoryItemID: itemID,
+					OrderID:         orderID,
+					Quantity:        reservation.Quantity,
+					Status:          string(reservation.Status),
+					ExpiresAt:       expiresAt,
+					CreatedAt:       createdAt,
+					UpdatedAt:       createdAt,
+				})
+				if reservation.Status == entity.ReservationPending {
+					itemRow.Reserved += reservation.Quantity
+				}
+			}
+		}
+
+		rows.Items = append(rows.Items, itemRow)
+	}
+
+	for _, message := range f.DLQMessages {
+		payload := message.Payload
+		if payload == nil {
+			payload = map[string]any{}
+		}
+		encoded, err := json.Marshal(payload)
+		if err != nil {
+			return nil, fmt.Errorf("dlq message %s: payload is not JSON: %w", message.MessageType, err)
+		}
+
+		failedAt := now.Add(-time.Duration(message.Age))
+		record := DLQMessageRecord{
+			ID:                newID(),
+			MessageType:       message.MessageType,
+			Payload:           string(encoded),
+			ErrorMessage:      message.ErrorMessage,
+			RetryCount:        message.RetryCount,
+			MaxRetries:        message.MaxRetries,
+			Status:            message.Status,
+			OriginalTimestamp: failedAt,
+			CreatedAt:         failedAt,
+			UpdatedAt:         now,
+		}
+		if record.MaxRetries == 0 {
+			record.MaxRetries = 3
+		}
+		if record.Status == "" {
+			record.Status = "pending"
+		}
+		if record.RetryCount > 0 {
+			record.LastRetryAt = &now
+		}
+		rows.DLQMessages = append(rows.DLQMessages, record)
+	}
+
+	return rows, nil
+}
+
+// name identifies the item in error messages
+func (item ItemFixture) name(index int) string {
+	if item.Key != "" {
+		return fmt.Sprintf("item %q", item.Key)
+	}
+	return fmt.Sprintf("item %d", index+1)
+}
+
+// count returns how many reservations to create
+func (r ReservationFixture) count() int {
+	if r.Count == 0 {
+		return 1
+	}
+	return r.Count
+}
+
+// expiresIn returns the expiry relative to now; expired reservations default to an hour ago
+func (r ReservationFixture) expiresIn() time.Duration {
+	if r.ExpiresIn != nil {
+		return time.Duration(*r.ExpiresIn)
+	}
+	if r.Status == entity.ReservationExpired {
+		return -time.Hour
+	}
+	return entity.DefaultReservationDuration
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// SeedFixtures inserts the fixture rows in one transaction. With wipe, the
+// reservations, inventory items and DLQ messages are deleted first.
+func (s *Seeder) SeedFixtures(ctx context.Context, rows *FixtureRows, wipe bool) error {
+	return s.inventoryDB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if wipe {
+			if err := clearTables(tx, "reservations", "inventory_items", "dlq_messages"); err != nil {
+				return err
+			}
+		}
+
+		// The model hooks would replace the fixture timestamps
+		insert := tx.Session(&gorm.Session{SkipHooks: true})
+		if len(rows.Items) > 0 {
+			if err := insert.CreateInBatches(rows.Items, 50).Error; err != nil {
+				return fmt.Errorf("failed to insert inventory items: %w", err)
+			}
+		}
+		if len(rows.Reservations) > 0 {
+			if err := insert.CreateInBatches(rows.Reservations, 50).Error; err != nil {
+				return fmt.Errorf("failed to insert reservations: %w", err)
+			}
+		}
+		for _, message := range rows.DLQMessages {
+			err := tx.Exec(`INSERT INTO dlq_messages
+				(id, message_type, payload, error_message, retry_count, max_retries, status,
+				 original_timestamp, created_at, updated_at, last_retry_at)
+				VALUES (?, ?, ?::jsonb, ?, ?, ?, ?, ?, ?, ?, ?)`,
+				message.ID, message.MessageType, message.Payload, message.ErrorMessage, message.RetryCount,
+				message.MaxRetries, message.Status, message.OriginalTimestamp, message.CreatedAt,
+				message.UpdatedAt, message.LastRetryAt).Error
+			if err != nil {
+				return fmt.Errorf("failed to insert dlq message %s: %w", message.MessageType, err)
+			}
+		}
+
+		log.Printf("Seeded %d inventory items, %d reservations and %d DLQ messages",
+			len(rows.Items), len(rows.Reservations), len(rows.DLQMessages))
+		return nil
+	})
+}
@@ -0,0 +1,237 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/infrastructure/persistence/model"
+)
+
+var fixtureNow = time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func writeFixtures(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+// TestLoadFixtures_Example verifies the example scenario shipped with the seeder
+func TestLoadFixtures_Example(t *testing.T) {
+	fixtures, err := LoadFixtures("fixtures/example.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), fixtures.Seed)
+
+	rows, err := fixtures.Build(fixtureNow, fixtures.Seed)
+	require.NoError(t, err)
+	require.Len(t, rows.Items, 5)
+	require.Len(t, rows.DLQMessages, 2)
+
+	mixed := rows.Items[0]
+	assert.Equal(t, "5d0b2f7e-3c1a-4e8b-9f6d-2a7c4b1e0d93", mixed.ProductID.String())
+	assert.Equal(t, 50, mixed.Quantity)
+	assert.Equal(t, 7, mixed.Reserved, "pending reservations, including the expired-but-unreleased one")
+
+	var pending, overdue, confirmed int
+	for _, reservation := range rows.Reservations {
+		if reservation.InventoryItemID != mixed.ID {
+			continue
+		}
+		switch {
+		case reservation.Status == string(entity.ReservationPending) && reservation.ExpiresAt.Before(fixtureNow):
+			overdue++
+		case reservation.Status == string(entity.ReservationPending):
+			pending++
+		case reservation.Status == string(entity.ReservationConfirmed):
+			confirmed++
+		}
+	}
+	assert.Equal(t, 3, pending)
+	assert.Equal(t, 1, overdue)
+	assert.Equal(t, 2, confirmed)
+
+	assert.Equal(t, 3, rows.Items[1].Reserved)
+	assert.Zero(t, rows.Items[2].Reserved, "released and expired reservations hold no units")
+	assert.NotNil(t, rows.Items[4].ArchivedAt)
+	assert.Nil(t, rows.Items[0].ArchivedAt)
+}
+
+// TestFixtures_Build_Deterministic verifies that the seed decides every generated ID
+func TestFixtures_Build_Deterministic(t *testing.T) {
+	fixtures, err := LoadFixtures("fixtures/example.yaml")
+	require.NoError(t, err)
+
+	first, err := fixtures.Build(fixtureNow, 7)
+	require.NoError(t, err)
+	second, err := fixtures.Build(fixtureNow.Add(time.Hour), 7)
+	require.NoError(t, err)
+	other, err := fixtures.Build(fixtureNow, 8)
+	require.NoError(t, err)
+
+	for i := range first.Items {
+		assert.Equal(t, first.Items[i].ID, second.Items[i].ID)
+		assert.Equal(t, first.Items[i].ProductID, second.Items[i].ProductID)
+		assert.NotEqual(t, first.Items[i].ID, other.Items[i].ID)
+	}
+	for i := range first.Reservations {
+		assert.Equal(t, first.Reservations[i].ID, second.Reservations[i].ID)
+		assert.Equal(t, first.Reservations[i].OrderID, second.Reservations[i].OrderID)
+	}
+	assert.Equal(t, first.DLQMessages[0].ID, second.DLQMessages[0].ID)
+}
+
+// TestFixtures_Build_Times verifies that times are relative to now
+func TestFixtures_Build_Times(t *testing.T) {
+	in := Duration(20 * time.Minute)
+	fixtures := &Fixtures{
+		Items: []ItemFixture{{Quantity: 10, Reservations: []ReservationFixture{
+			{Status: entity.ReservationPending, Quantity: 1, ExpiresIn: &in},
+			{Status: entity.ReservationExpired, Quantity: 1},
+			{Status: entity.ReservationConfirmed, Quantity: 1},
+		}}},
+		DLQMessages: []DLQMessageFixture{
+			{MessageType: "inventory.reserved", ErrorMessage: "boom", RetryCount: 2, Age: Duration(time.Hour)},
+		},
+	}
+	require.NoError(t, fixtures.Validate())
+
+	rows, err := fixtures.Build(fixtureNow, 1)
+	require.NoError(t, err)
+
+	assert.Equal(t, fixtureNow.Add(20*time.Minute), rows.Reservations[0].ExpiresAt)
+	assert.Equal(t, fixtureNow, rows.Reservations[0].CreatedAt, "creation is never in the future")
+	assert.Equal(t, fixtureNow.Add(-time.Hour), rows.Reservations[1].ExpiresAt)
+	assert.Equal(t, fixtureNow.Add(-time.Hour-entity.DefaultReservationDuration), rows.Reservations[1].CreatedAt)
+	assert.Equal(t, fixtureNow.Add(entity.DefaultReservationDuration), rows.Reservations[2].ExpiresAt)
+
+	message := rows.DLQMessages[0]
+	assert.Equal(t, "pending", message.Status)
+	assert.Equal(t, 3, message.MaxRetries)
+	assert.Equal(t, "{}", message.Payload)
+	assert.Equal(t, fixtureNow.Add(-time.Hour), message.OriginalTimestamp)
+	require.NotNil(t, message.LastRetryAt)
+}
+
+// TestLoadFixtures_JSON verifies the JSON format
+func TestLoadFixtures_JSON(t *testing.T) {
+	path := writeFixtures(t, "scenario.json", `{
+		"seed": 3,
+		"items": [{"key": "a", "quantity": 5, "reservations": [{"status": "pending", "quantity": 2, "expires_in": "-1m"}]}],
+		"dlq_messages": [{"message_type": "inventory.released", "error_message": "timeout", "payload": {"id": 1}}]
+	}`)
+
+	fixtures, err := LoadFixtures(path)
+	require.NoError(t, err)
+	assert.Equal(t, Duration(-time.Minute), *fixtures.Items[0].Reservations[0].ExpiresIn)
+
+	rows, err := fixtures.Build(fixtureNow, fixtures.Seed)
+	require.NoError(t, err)
+	assert.Equal(t, 2, rows.Items[0].Reserved)
+	assert.JSONEq(t, `{"id": 1}`, rows.DLQMessages[0].Payload)
+}
+
+// TestLoadFixtures_Errors verifies that parse and validation errors are reported
+func TestLoadFixtures_Errors(t *testing.T) {
+	productID := uuid.New().String()
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		want    []string
+	}{
+		{"unknown field", "f.yaml", "items:\n  - key: a\n    quantiy: 3\n", []string{"quantiy"}},
+		{"unknown json field", "f.json", `{"itemz": []}`, []string{"itemz"}},
+		{"bad duration", "f.yaml", "items:\n  - quantity: 1\n    reservations:\n      - {status: pending, quantity: 1, expires_in: soon}\n", []string{"soon"}},
+		{
+			"over reserved",
+			"f.yaml",
+			"items:\n  - key: a\n    quantity: 3\n    reservations:\n      - {status: pending, quantity: 2, count: 2}\n",
+			[]string{`item "a": pending reservations hold 4 units but quantity is 3`},
+		},
+		{
+			"every problem",
+			"f.yaml",
+			"items:\n" +
+				"  - {key: a, product_id: " + productID + ", quantity: -1}\n" +
+				"  - {key: a, product_id: " + productID + ", quantity: 1, reservations: [{status: held, quantity: 0}]}\n" +
+				"  - {quantity: 1, reservations: [{status: expired, quantity: 1, expires_in: 5m}, {status: confirmed, quantity: 1, count: 2, order_id: x}]}\n" +
+				"dlq_messages:\n  - {status: lost}\n",
+			[]string{
+				`item "a": quantity must be >= 0`,
+				`item "a": duplicate key`,
+				`item "a": product_id is also used by item "a"`,
+				`status must be pending, confirmed, released or expired, got "held"`,
+				"quantity must be > 0",
+				"item 3 reservation 1: expired reservations need a negative expires_in",
+				"item 3 reservation 2: order_id can only be set with count 1",
+				`order_id "x" is not a UUID`,
+				"dlq message 1: message_type is required",
+				"dlq message 1: error_message is required",
+				`got "lost"`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadFixtures(writeFixtures(t, tt.file, tt.content))
+			require.Error(t, err)
+			for _, want := range tt.want {
+				assert.Contains(t, err.Error(), want)
+			}
+		})
+	}
+
+	_, err := LoadFixtures(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.ErrorContains(t, err, "failed to read fixtures")
+}
+
+// TestSeeder_SeedFixtures_Integration loads the example scenario into PostgreSQL
+func TestSeeder_SeedFixtures_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	container, db := setupPostgresContainer(t, ctx, "inventory_test")
+	defer container.Terminate(ctx)
+	setupInventorySchema(t, db)
+
+	fixtures, err := LoadFixtures("fixtures/example.yaml")
+	require.NoError(t, err)
+	rows, err := fixtures.Build(time.Now(), fixtures.Seed)
+	require.NoError(t, err)
+
+	seeder := NewSeeder(nil, db, "")
+	require.NoError(t, seeder.SeedFixtures(ctx, rows, false))
+
+	// Without wipe a second load collides with the first
+	assert.Error(t, seeder.SeedFixtures(ctx, rows, false))
+	// With wipe it replaces it
+	require.NoError(t, seeder.SeedFixtures(ctx, rows, true))
+
+	var items, reservations, messages int64
+	db.Model(&model.InventoryItemModel{}).Count(&items)
+	db.Model(&model.ReservationModel{}).Count(&reservations)
+	db.Table("dlq_messages").Count(&messages)
+	assert.Equal(t, int64(5), items)
+	assert.Equal(t, int64(len(rows.Reservations)), reservations)
+	assert.Equal(t, int64(2), messages)
+
+	var overdue int64
+	db.Model(&model.ReservationModel{}).
+		Where("status = ? AND expires_at < ?", entity.ReservationPending, time.Now()).
+		Count(&overdue)
+	assert.Equal(t, int64(1), overdue)
+
+	var reservationID string
+	require.NoError(t, db.Raw("SELECT payload->>'reservation_id' FROM dlq_messages WHERE status = 'failed'").Scan(&reservationID).Error)
+	assert.Equal(t, "7f3e1c2a-9b4d-4e6f-8a1b-3c5d7e9f0a2b", reservationID)
+}
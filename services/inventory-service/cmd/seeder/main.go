@@ -46,6 +46,7 @@ type Seeder struct {
 	ordersDB    *gorm.DB
 	inventoryDB *gorm.DB
 	dataset     string
+	seed        *int64
 }
 
 // ProductRecord represents a product from Orders Service
@@ -69,6 +70,12 @@ func NewSeeder(ordersDB, inventoryDB *gorm.DB, dataset string) *Seeder {
 	}
 }
 
+// WithSeed makes the generated quantities reproducible
+func (s *Seeder) WithSeed(seed int64) *Seeder {
+	s.seed = &seed
+	return s
+}
+
 // Seed executes the seeding process
 func (s *Seeder) Seed(ctx context.Context) error {
 	log.Printf("Starting seed process with dataset: %s", s.dataset)
@@ -123,16 +130,15 @@ func (s *Seeder) fetchProducts(ctx context.Context) ([]ProductRecord, error) {
 
 // clearInventory removes all existing inventory items
 func (s *Seeder) clearInventory(ctx context.Context) error {
-	// Delete all reservations first (FK constraint)
-	if err := s.inventoryDB.WithContext(ctx).
-		Exec("DELETE FROM reservations").Error; err != nil {
-		return fmt.Errorf("failed to delete reservations: %w", err)
-	}
+	return clearTables(s.inventoryDB.WithContext(ctx), "reservations", "inventory_items")
+}
 
-	// Delete all inventory items
-	if err := s.inventoryDB.WithContext(ctx).
-		Exec("DELETE FROM inventory_items").Error; err != nil {
-		return fmt.Errorf("failed to delete inventory_items: %w", err)
+// clearTables deletes every row of the tables, in order
+func clearTables(db *gorm.DB, tables ...string) error {
+	for _, table := range tables {
+		if err := db.Exec("DELETE FROM " + table).Error; err != nil {
+			return fmt.Errorf("failed to delete %s: %w", table, err)
+		}
 	}
 
 	log.Println("Cleared existing inventory data")
@@ -143,7 +149,11 @@ func (s *Seeder) clearInventory(ctx context.Context) error {
 func (s *Seeder) generateInventoryItems(products []ProductRecord) []model.InventoryItemModel {
 	items := make([]model.InventoryItemModel, 0, len(products))
 	now := time.Now()
-	rnd := rand.New(rand.NewSource(time.Now().UnixNano()))
+	seed := time.Now().UnixNano()
+	if s.seed != nil {
+		seed = *s.seed
+	}
+	rnd := rand.New(rand.NewSource(seed))
 
 	for _, product := range products {
 		var quantity, reserved int
@@ -324,6 +334,9 @@ func ConnectDB(host, port, user, password, dbname string) (*gorm.DB, error) {
 func main() {
 	// Parse command-line flags
 	dataset := flag.String("dataset", DatasetDev, "Dataset type: dev, test, or demo")
+	fixturesPath := flag.String("fixtures", "", "YAML or JSON fixtures file to load instead of a dataset")
+	seed := flag.Int64("seed", 0, "Random seed (default: the fixtures seed, or the current time for datasets)")
+	wipe := flag.Bool("wipe", false, "Delete reservations, inventory items and DLQ messages before loading fixtures")
 	help := flag.Bool("help", false, "Show help message")
 	flag.Parse()
 
@@ -332,14 +345,30 @@ func main() {
 		os.Exit(0)
 	}
 
+	seedSet := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == "seed" {
+			seedSet = true
+		}
+	})
+
+	// Load configuration
+	config := LoadConfigFromEnv()
+	ctx := context.Background()
+
+	if *fixturesPath != "" {
+		seedFixtures(ctx, config, *fixturesPath, *seed, seedSet, *wipe)
+		return
+	}
+	if *wipe {
+		log.Fatalf("-wipe only applies to -fixtures; datasets always replace the inventory")
+	}
+
 	// Validate dataset
 	if *dataset != DatasetDev && *dataset != DatasetTest && *dataset != DatasetDemo {
 		log.Fatalf("Invalid dataset: %s. Must be one of: dev, test, demo", *dataset)
 	}
 
-	// Load configuration
-	config := LoadConfigFromEnv()
-
 	// Connect to Orders Service database
 	log.Println("Connecting to Orders Service database...")
 	ordersDB, err := ConnectDB(
@@ -353,7 +382,51 @@ func main() {
 		log.Fatalf("Failed to connect to Orders DB: %v", err)
 	}
 
-	// Connect to Inventory Service database
+	inventoryDB := connectInventoryDB(config)
+
+	// Create seeder and execute
+	seeder := NewSeeder(ordersDB, inventoryDB, *dataset)
+	if seedSet {
+		seeder.WithSeed(*seed)
+	}
+
+	if err := seeder.Seed(ctx); err != nil {
+		log.Fatalf("Seed failed: %v", err)
+	}
+
+	log.Println("✅ Seed completed successfully!")
+}
+
+// seedFixtures loads a fixtures file into the Inventory Service database
+func seedFixtures(ctx context.Context, config *Config, path string, seed int64, seedSet, wipe bool) {
+	fixtures, err := LoadFixtures(path)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+
+	if !seedSet {
+		seed = fixtures.Seed
+		if seed == 0 {
+			seed = DefaultFixtureSeed
+		}
+	}
+	rows, err := fixtures.Build(time.Now(), seed)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+
+	// Fixtures describe the inventory only, so the Orders Service is not needed
+	seeder := NewSeeder(nil, connectInventoryDB(config), "")
+	log.Printf("Loading fixtures %s with seed %d", path, seed)
+	if err := seeder.SeedFixtures(ctx, rows, wipe); err != nil {
+		log.Fatalf("Seed failed: %v", err)
+	}
+
+	log.Println("✅ Seed completed successfully!")
+}
+
+// connectInventoryDB connects to the Inventory Service database or exits
+func connectInventoryDB(config *Config) *gorm.DB {
 	log.Println("Connecting to Inventory Service database...")
 	inventoryDB, err := ConnectDB(
 		config.InventoryDBHost,
@@ -365,29 +438,32 @@ func main() {
 	if err != nil {
 		log.Fatalf("Failed to connect to Inventory DB: %v", err)
 	}
-
-	// Create seeder and execute
-	seeder := NewSeeder(ordersDB, inventoryDB, *dataset)
-	ctx := context.Background()
-
-	if err := seeder.Seed(ctx); err != nil {
-		log.Fatalf("Seed failed: %v", err)
-	}
-
-	log.Println("✅ Seed completed successfully!")
+	return inventoryDB
 }
 
 func printHelp() {
 	fmt.Print(`
-Inventory Seeder - Seed inventory data from Orders Service products
+Inventory Seeder - Seed inventory data from Orders Service products or fixtures
 
 Usage:
-  go run cmd/seeder/main.go [options]
+  go run ./cmd/seeder [options]
 
 Options:
   -dataset string
         Dataset type to seed (default "dev")
         Values: dev, test, demo
+
+  -fixtures string
+        YAML or JSON file describing items, reservations and DLQ messages
+        to load instead of a dataset (see cmd/seeder/fixtures/example.yaml)
+
+  -seed int
+        Random seed. Fixtures use their own seed (or 1) by default;
+        datasets use the current time
+
+  -wipe
+        With -fixtures, delete reservations, inventory items and DLQ
+        messages before loading. Without it the fixtures are added
   
   -help
         Show this help message
@@ -423,16 +499,22 @@ Environment Variables:
 
 Examples:
   # Seed dev dataset
-  go run cmd/seeder/main.go
+  go run ./cmd/seeder
 
   # Seed test dataset
-  go run cmd/seeder/main.go -dataset=test
+  go run ./cmd/seeder -dataset=test
 
   # Seed demo dataset
-  go run cmd/seeder/main.go -dataset=demo
+  go run ./cmd/seeder -dataset=demo
+
+  # Reproducible demo dataset
+  go run ./cmd/seeder -dataset=demo -seed=42
+
+  # Load a QA scenario on an empty inventory
+  go run ./cmd/seeder -fixtures=cmd/seeder/fixtures/example.yaml -wipe
 
   # With custom database
   ORDERS_DB_HOST=orders-db INVENTORY_DB_HOST=inventory-db \
-    go run cmd/seeder/main.go -dataset=dev
+    go run ./cmd/seeder -dataset=dev
 `)
 }
@@ -178,6 +178,19 @@ func TestSeeder_GenerateInventoryItems(t *testing.T) {
 	}
 }
 
+// TestSeeder_WithSeed verifies that a fixed seed reproduces the quantities
+func TestSeeder_WithSeed(t *testing.T) {
+	products := []ProductRecord{{ID: uuid.New()}, {ID: uuid.New()}, {ID: uuid.New()}, {ID: uuid.New()}}
+
+	first := NewSeeder(nil, nil, DatasetDemo).WithSeed(42).generateInventoryItems(products)
+	second := NewSeeder(nil, nil, DatasetDemo).WithSeed(42).generateInventoryItems(products)
+
+	for i := range products {
+		assert.Equal(t, first[i].Quantity, second[i].Quantity)
+		assert.Equal(t, first[i].Reserved, second[i].Reserved)
+	}
+}
+
 // Integration test with Testcontainers
 func TestSeeder_Integration(t *testing.T) {
 	if testing.Short() {
@@ -279,6 +292,7 @@ func setupInventorySchema(t *testing.T, db *gorm.DB) {
 			version INT NOT NULL DEFAULT 1,
 			created_at TIMESTAMP NOT NULL,
 			updated_at TIMESTAMP NOT NULL,
+			archived_at TIMESTAMP,
 			CHECK (quantity >= 0),
 			CHECK (reserved >= 0),
 			CHECK (reserved <= quantity)
@@ -300,6 +314,24 @@ func setupInventorySchema(t *testing.T, db *gorm.DB) {
 		)
 	`).Error
 	require.NoError(t, err)
+
+	// Create dlq_messages table
+	err = db.Exec(`
+		CREATE TABLE dlq_messages (
+			id UUID PRIMARY KEY,
+			message_type VARCHAR(100) NOT NULL,
+			payload JSONB NOT NULL,
+			error_message TEXT NOT NULL,
+			retry_count INT NOT NULL DEFAULT 0,
+			max_retries INT NOT NULL DEFAULT 3,
+			status VARCHAR(20) NOT NULL DEFAULT 'pending',
+			original_timestamp TIMESTAMP NOT NULL,
+			created_at TIMESTAMP NOT NULL,
+			updated_at TIMESTAMP NOT NULL,
+			last_retry_at TIMESTAMP
+		)
+	`).Error
+	require.NoError(t, err)
 }
 
 func seedOrdersProducts(t *testing.T, db *gorm.DB, count int) {
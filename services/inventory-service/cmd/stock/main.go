@@ -0,0 +1,210 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/application/usecase"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/infrastructure/config"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/infrastructure/database"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/infrastructure/persistence/repository"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/infrastructure/stockfile"
+)
+
+const usage = `Usage:
+  stock import [--file <path>|-] [--format csv|jsonl] [--dry-run] [--chunk 100] [--report <path>]
+      Apply stock counts (product_id, sku and quantity or delta per row). Prints the
+      per-row JSON report; exits with 3 when any row failed.
+
+  stock export [--format csv|jsonl] [--out <path>]
+      Stream the current stock of every inventory item, ordered by product ID.
+
+Both commands read the database settings from the service configuration
+(DB_* variables / --config).
+`
+
+// errRowsFailed makes the process exit with exitRowsFailed
+var errRowsFailed = errors.New("some rows failed")
+
+const exitRowsFailed = 3
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run executes the command and returns the process exit code
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return 2
+	}
+
+	var err error
+	switch args[0] {
+	case "import":
+		err = runImport(args[1:], stdin, stdout, stderr)
+	case "export":
+		err = runExport(args[1:], stdout, stderr)
+	default:
+		fmt.Fprint(stderr, usage)
+		return 2
+	}
+
+	if errors.Is(err, errRowsFailed) {
+		return exitRowsFailed
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "❌ %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// runImport applies a stock count file and writes the report
+func runImport(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	configPath := fs.String("config", os.Getenv("CONFIG_FILE"), "Path to an optional YAML configuration file")
+	filePath := fs.String("file", "-", "Stock count file, - for stdin")
+	formatName := fs.String("format", "", "csv or jsonl (default: from the file extension, csv for stdin)")
+	dryRun := fs.Bool("dry-run", false, "Validate and preview the changes without writing them")
+	chunk := fs.Int("chunk", usecase.DefaultStockImportChunkSize, "Rows written per transaction")
+	reportPath := fs.String("report", "", "Write the JSON report to this file (default stdout)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *chunk <= 0 {
+		return fmt.Errorf("--chunk must be positive, got %d", *chunk)
+	}
+
+	format := stockfile.FormatFromFilename(*filePath)
+	if *formatName != "" {
+		var err error
+		if format, err = stockfile.ParseFormat(*formatName); err != nil {
+			return err
+		}
+	}
+
+	in := stdin
+	if *filePath != "-" {
+		file, err := os.Open(*filePath)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		in = file
+	}
+	rows, err := stockfile.Read(in, format)
+	if err != nil {
+		return err
+	}
+
+	repo, closeDB, err := openRepository(*configPath)
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	uc := usecase.NewImportStockUseCase(repo, repo).WithChunkSize(*chunk)
+	output, err := uc.Execute(context.Background(), usecase.ImportStockInput{Rows: rows, DryRun: *dryRun})
+	if err != nil {
+		return err
+	}
+
+	if err := writeOutput(*reportPath, stdout, func(w io.Writer) error { return writeReport(w, output) }); err != nil {
+		return err
+	}
+
+	mode := "applied"
+	if output.DryRun {
+		mode = "dry run"
+	}
+	fmt.Fprintf(stderr, "📦 Stock import (%s): %d rows, %d changed, %d unchanged, %d failed\n",
+		mode, output.Total, output.Changed, output.Unchanged, output.Failed)
+	if output.Failed > 0 {
+		return errRowsFailed
+	}
+	return nil
+}
+
+// runExport streams the current stock levels
+func runExport(args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	configPath := fs.String("config", os.Getenv("CONFIG_FILE"), "Path to an optional YAML configuration file")
+	formatName := fs.String("format", string(stockfile.FormatCSV), "csv or jsonl")
+	outPath := fs.String("out", "", "Write the export to this file (default stdout)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	format, err := stockfile.ParseFormat(*formatName)
+	if err != nil {
+		return err
+	}
+
+	repo, closeDB, err := openRepository(*configPath)
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	count := 0
+	err = writeOutput(*outPath, stdout, func(w io.Writer) error {
+		writer := stockfile.NewWriter(w, format)
+		if count, err = usecase.NewExportStockUseCase(repo).Execute(context.Background(), writer); err != nil {
+			return err
+		}
+		return writer.Flush()
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(stderr, "📦 Exported the stock of %d inventory items\n", count)
+	return nil
+}
+
+// writeReport encodes the import report as indented JSON
+func writeReport(w io.Writer, output *usecase.ImportStockOutput) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(output)
+}
+
+// writeOutput calls write with the file at path, or with stdout when path is empty
+func writeOutput(path string, stdout io.Writer, write func(w io.Writer) error) error {
+	if path == "" {
+		return write(stdout)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(file); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// openRepository connects to the inventory database of the service configuration
+func openRepository(configPath string) (*repository.InventoryRepositoryImpl, func(), error) {
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	db, err := database.NewPostgresDB(&cfg.Database, "production")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to connect to PostgreSQL: %w", err)
+	}
+
+	return repository.NewInventoryRepository(db), func() { _ = database.CloseDB(db) }, nil
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/application/usecase"
+)
+
+// TestRun_Usage tests that missing and unknown commands print the usage
+func TestRun_Usage(t *testing.T) {
+	for _, args := range [][]string{nil, {"purge"}} {
+		var stdout, stderr bytes.Buffer
+		if code := run(args, strings.NewReader(""), &stdout, &stderr); code != 2 {
+			t.Errorf("run(%v) = %d, want 2", args, code)
+		}
+		if !strings.Contains(stderr.String(), "stock import") {
+			t.Errorf("usage not printed for %v", args)
+		}
+	}
+}
+
+// TestRunImport_RejectsBadInputBeforeConnecting tests the checks that run without a database
+func TestRunImport_RejectsBadInputBeforeConnecting(t *testing.T) {
+	tests := []struct {
+		name  string
+		args  []string
+		stdin string
+		want  string
+	}{
+		{"chunk", []string{"--chunk", "0"}, "", "--chunk must be positive"},
+		{"format", []string{"--format", "xlsx"}, "", "unsupported stock file format"},
+		{"missing file", []string{"--file", filepath.Join(t.TempDir(), "missing.csv")}, "", "no such file"},
+		{"header", nil, "sku,quantity\n", "no product_id column"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			code := run(append([]string{"import"}, tt.args...), strings.NewReader(tt.stdin), &stdout, &stderr)
+			if code != 1 {
+				t.Fatalf("exit code = %d, want 1", code)
+			}
+			if !strings.Contains(stderr.String(), tt.want) {
+				t.Errorf("stderr = %q, want it to contain %q", stderr.String(), tt.want)
+			}
+		})
+	}
+}
+
+// TestRunExport_InvalidFormat tests that the format is checked before connecting
+func TestRunExport_InvalidFormat(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"export", "--format", "xml"}, nil, &stdout, &stderr); code != 1 {
+		t.Fatalf("exit code = %d, want 1", code)
+	}
+}
+
+// TestWriteOutput_File tests that the report can be written to a file
+func TestWriteOutput_File(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.json")
+	output := &usecase.ImportStockOutput{
+		Total:  1,
+		Failed: 1,
+		Rows:   []usecase.StockImportRowResult{{Line: 2, Status: usecase.StockImportFailed, Error: "product_id is required"}},
+	}
+
+	var stdout bytes.Buffer
+	if err := writeOutput(path, &stdout, func(w io.Writer) error { return writeReport(w, output) }); err != nil {
+		t.Fatal(err)
+	}
+	if stdout.Len() != 0 {
+		t.Errorf("report written to stdout: %q", stdout.String())
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var report usecase.ImportStockOutput
+	if err := json.Unmarshal(data, &report); err != nil {
+		t.Fatalf("report is not JSON: %v", err)
+	}
+	if report.Failed != 1 || report.Rows[0].Error != "product_id is required" {
+		t.Errorf("unexpected report: %+v", report)
+	}
+}
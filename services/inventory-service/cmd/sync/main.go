@@ -2,8 +2,10 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"time"
@@ -13,9 +15,14 @@ import (
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/application/usecase"
 	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/infrastructure/persistence/model"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/infrastructure/persistence/repository"
 )
 
+// exitDriftRemaining is the exit code of -reconcile when drift is left unrepaired
+const exitDriftRemaining = 3
+
 // Config holds database configuration
 type Config struct {
 	OrdersDBHost     string
@@ -59,6 +66,8 @@ type SyncResult struct {
 	NewItems        int
 	ExistingItems   int
 	SkippedInactive int
+	ArchivedItems   int // inactive products whose inventory item was archived
+	RestoredItems   int // active products whose archived inventory item was restored
 	Errors          int
 	Duration        time.Duration
 }
@@ -103,17 +112,45 @@ func (s *Synchronizer) Sync(ctx context.Context) (*SyncResult, error) {
 		return nil, fmt.Errorf("failed to get existing inventory items: %w", err)
 	}
 
-	log.Printf("Found %d existing inventory items", len(existingProductIDs))
+	archivedProductIDs, err := s.getArchivedProductIDs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get archived inventory items: %w", err)
+	}
+
+	log.Printf("Found %d existing inventory items (%d archived)", len(existingProductIDs), len(archivedProductIDs))
 
 	// Step 3: Process each product
 	for _, product := range products {
+		exists := existingProductIDs[product.ID]
+		archived := archivedProductIDs[product.ID]
+
 		if !product.IsActive {
-			result.SkippedInactive++
-			log.Printf("⏭️  Skipping inactive product: %s (SKU: %s)", product.Name, product.SKU)
+			if !exists || archived {
+				result.SkippedInactive++
+				log.Printf("⏭️  Skipping inactive product: %s (SKU: %s)", product.Name, product.SKU)
+				continue
+			}
+			if err := s.setArchived(ctx, product.ID, true); err != nil {
+				result.Errors++
+				log.Printf("❌ Error archiving inventory item for product %s: %v", product.Name, err)
+				continue
+			}
+			result.ArchivedItems++
+			log.Printf("📦 Archived inventory item of inactive product: %s (SKU: %s)", product.Name, product.SKU)
+			continue
+		}
+
+		if exists && archived {
+			if err := s.setArchived(ctx, product.ID, false); err != nil {
+				result.Errors++
+				log.Printf("❌ Error restoring inventory item for product %s: %v", product.Name, err)
+				continue
+			}
+			result.RestoredItems++
+			log.Printf("♻️  Restored inventory item of reactivated product: %s (SKU: %s)", product.Name, product.SKU)
 			continue
 		}
 
-		exists := existingProductIDs[product.ID]
 		if exists {
 			result.ExistingItems++
 			log.Printf("✅ Product already exists in inventory: %s (SKU: %s)", product.Name, product.SKU)
@@ -158,6 +195,40 @@ func (s *Synchronizer) fetchProducts(ctx context.Context) ([]ProductRecord, erro
 	return products, nil
 }
 
+// ActiveProducts lists the active products of Orders Service.
+// It lets the synchronizer act as the catalog for reconciliation.
+func (s *Synchronizer) ActiveProducts(ctx context.Context) ([]usecase.CatalogProduct, error) {
+	products, err := s.fetchProducts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	active := make([]usecase.CatalogProduct, 0, len(products))
+	for _, product := range products {
+		if product.IsActive {
+			active = append(active, usecase.CatalogProduct{ID: product.ID, SKU: product.SKU})
+		}
+	}
+	return active, nil
+}
+
+// Reconcile checks inventory items against their reservations and the catalog.
+// Drift is repaired only when repair is set and the synchronizer is not in dry-run mode;
+// missing items are created with the default quantity.
+func (s *Synchronizer) Reconcile(ctx context.Context, repair bool, grace time.Duration, actor string) (*usecase.ReconcileInventoryOutput, error) {
+	uc := usecase.NewReconcileInventoryUseCase(repository.NewReconciliationRepository(s.inventoryDB), grace).
+		WithCatalog(s, usecase.InitialStockPolicy{DefaultQuantity: s.defaultQuantity})
+
+	return uc.Execute(ctx, usecase.ReconcileInventoryInput{Repair: repair && !s.dryRun, Actor: actor})
+}
+
+// WriteDriftReport writes the reconciliation report as indented JSON
+func WriteDriftReport(w io.Writer, report *usecase.ReconcileInventoryOutput) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
+
 // getExistingProductIDs returns a map of existing product IDs in inventory
 func (s *Synchronizer) getExistingProductIDs(ctx context.Context) (map[uuid.UUID]bool, error) {
 	var items []model.InventoryItemModel
@@ -178,6 +249,50 @@ func (s *Synchronizer) getExistingProductIDs(ctx context.Context) (map[uuid.UUID
 	return productIDs, nil
 }
 
+// getArchivedProductIDs returns the product IDs whose inventory item is archived
+func (s *Synchronizer) getArchivedProductIDs(ctx context.Context) (map[uuid.UUID]bool, error) {
+	var items []model.InventoryItemModel
+
+	result := s.inventoryDB.WithContext(ctx).
+		Select("product_id").
+		Where("archived_at IS NOT NULL").
+		Find(&items)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	productIDs := make(map[uuid.UUID]bool, len(items))
+	for _, item := range items {
+		productIDs[item.ProductID] = true
+	}
+
+	return productIDs, nil
+}
+
+// setArchived archives or restores the inventory item of a product, the same
+// way catalog sync does for product.deactivated and product.updated events
+func (s *Synchronizer) setArchived(ctx context.Context, productID uuid.UUID, archived bool) error {
+	if s.dryRun {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	var archivedAt *time.Time
+	if archived {
+		archivedAt = &now
+	}
+
+	return s.inventoryDB.WithContext(ctx).
+		Model(&model.InventoryItemModel{}).
+		Where("product_id = ?", productID).
+		Updates(map[string]interface{}{
+			"archived_at": archivedAt,
+			"version":     gorm.Expr("version + 1"),
+			"updated_at":  now,
+		}).Error
+}
+
 // createInventoryItem creates a new inventory item
 func (s *Synchronizer) createInventoryItem(ctx context.Context, product ProductRecord) error {
 	if s.dryRun {
@@ -304,11 +419,13 @@ func PrintSummary(result *SyncResult) {
 	fmt.Printf("New Inventory Items:      %d ✨\n", result.NewItems)
 	fmt.Printf("Already Existing:         %d ✅\n", result.ExistingItems)
 	fmt.Printf("Skipped (Inactive):       %d ⏭️\n", result.SkippedInactive)
+	fmt.Printf("Archived (Inactive):      %d 📦\n", result.ArchivedItems)
+	fmt.Printf("Restored (Reactivated):   %d ♻️\n", result.RestoredItems)
 	fmt.Printf("Errors:                   %d ❌\n", result.Errors)
 	fmt.Printf("Duration:                 %v\n", result.Duration.Round(time.Millisecond))
 	fmt.Println(separator)
 
-	successRate := float64(result.NewItems+result.ExistingItems) / float64(result.TotalProducts-result.SkippedInactive) * 100
+	successRate := float64(result.NewItems+result.ExistingItems+result.ArchivedItems+result.RestoredItems) / float64(result.TotalProducts-result.SkippedInactive) * 100
 	fmt.Printf("Success Rate:             %.1f%%\n", successRate)
 	fmt.Println(separator + "\n")
 }
@@ -318,6 +435,11 @@ func main() {
 	defaultQty := flag.Int("default-quantity", 100, "Default quantity for new inventory items")
 	dryRun := flag.Bool("dry-run", false, "Run in dry-run mode (no database changes)")
 	validate := flag.Bool("validate", true, "Run validation checks before sync")
+	reconcile := flag.Bool("reconcile", false, "Report inventory drift instead of synchronizing")
+	repair := flag.Bool("repair", false, "With -reconcile, repair the drift found")
+	reportPath := flag.String("report", "", "With -reconcile, write the JSON drift report to this file (default stdout)")
+	grace := flag.Duration("grace", 5*time.Minute, "With -reconcile, skip rows changed more recently than this")
+	actor := flag.String("actor", usecase.ReconcileAuditActor, "With -reconcile -repair, actor recorded in the admin audit log")
 	help := flag.Bool("help", false, "Show help message")
 	flag.Parse()
 
@@ -330,6 +452,9 @@ func main() {
 	if *defaultQty < 0 {
 		log.Fatalf("Invalid default-quantity: %d. Must be non-negative", *defaultQty)
 	}
+	if *repair && (!*reconcile || *dryRun) {
+		log.Fatal("-repair requires -reconcile and cannot be combined with -dry-run")
+	}
 
 	// Load configuration
 	config := LoadConfigFromEnv()
@@ -371,6 +496,10 @@ func main() {
 		}
 	}
 
+	if *reconcile {
+		os.Exit(runReconcile(ctx, sync, *repair, *grace, *actor, *reportPath))
+	}
+
 	// Execute synchronization
 	result, err := sync.Sync(ctx)
 	if err != nil {
@@ -387,9 +516,40 @@ func main() {
 	}
 }
 
+// runReconcile runs reconciliation, writes the drift report and returns the exit code
+func runReconcile(ctx context.Context, sync *Synchronizer, repair bool, grace time.Duration, actor, reportPath string) int {
+	report, err := sync.Reconcile(ctx, repair, grace, actor)
+	if err != nil {
+		log.Printf("❌ Reconciliation failed: %v", err)
+		return 1
+	}
+
+	out := os.Stdout
+	if reportPath != "" {
+		file, err := os.Create(reportPath)
+		if err != nil {
+			log.Printf("❌ Cannot write report: %v", err)
+			return 1
+		}
+		defer file.Close()
+		out = file
+	}
+	if err := WriteDriftReport(out, report); err != nil {
+		log.Printf("❌ Cannot write report: %v", err)
+		return 1
+	}
+
+	log.Printf("🔎 Reconciliation found %d drift(s): %d repaired, %d failed, %d remaining",
+		len(report.Drifts), report.Repaired, report.Failed, report.Remaining())
+	if report.Remaining() > 0 {
+		return exitDriftRemaining
+	}
+	return 0
+}
+
 func printHelp() {
 	fmt.Print(`
-Product Synchronization Tool - Sync products from Orders to Inventory
+Product Synchronization Tool - One-off backfill from Orders to Inventory
 
 Usage:
   go run cmd/sync/main.go [options]
@@ -403,13 +563,39 @@ Options:
   
   -validate
         Run validation checks before sync (default true)
+
+  -reconcile
+        Report drift instead of synchronizing: reserved counters that differ
+        from pending reservations, orphan and stuck reservations, and active
+        products without inventory item. Prints a JSON report; exits with 3
+        when drift remains
+
+  -repair
+        With -reconcile, repair each drift in its own transaction and record
+        it in the admin audit log (route reconcile/<kind>). Missing items are
+        created with -default-quantity
+
+  -report string
+        With -reconcile, write the JSON report to a file instead of stdout
+
+  -grace duration
+        With -reconcile, skip rows changed more recently than this (default 5m)
+
+  -actor string
+        With -reconcile -repair, actor recorded in the audit log (default "reconciler")
   
   -help
         Show this help message
 
 Description:
   This tool synchronizes products from Orders Service to Inventory Service.
-  It creates inventory items for new products while preserving existing ones.
+  It creates inventory items for new products while preserving existing ones,
+  archives the items of inactive products and restores reactivated ones.
+
+  Day-to-day synchronization is event driven: with CATALOG_SYNC_ENABLED=true
+  the inventory service consumes product.created, product.updated and
+  product.deactivated events. Use this tool only to backfill products that
+  existed before catalog sync was enabled or to recover from lost events.
   
   Features:
   - Idempotent: Safe to run multiple times
@@ -421,9 +607,8 @@ Description:
   
   Use Cases:
   - Initial migration from monolith to microservices
-  - Adding new products to inventory
-  - Recovering from data loss
-  - Regular synchronization jobs
+  - Backfilling products created before catalog sync was enabled
+  - Recovering from data loss or dead-lettered catalog events
 
 Environment Variables:
   ORDERS_DB_HOST      (default: localhost)
@@ -445,6 +630,10 @@ Examples:
   # Sync with default quantity of 50
   go run cmd/sync/main.go -default-quantity=50
 
+  # Report drift, then repair it
+  go run cmd/sync/main.go -reconcile -report drift.json
+  go run cmd/sync/main.go -reconcile -repair -actor ops@example.com
+
   # Sync without validation (faster, use with caution)
   go run cmd/sync/main.go -validate=false
 
@@ -455,8 +644,8 @@ Examples:
 Important Notes:
   - Always run with -dry-run first to preview changes
   - Ensure migrations are applied to Inventory database
-  - Inactive products are skipped automatically
-  - Existing inventory items are never modified
+  - Inactive products get their inventory item archived (never deleted)
+  - Stock of existing inventory items is never modified
   - Use default-quantity wisely (e.g., 0 for pre-order items)
 `)
 }
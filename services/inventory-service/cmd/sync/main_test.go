@@ -1,6 +1,7 @@
 package main
 
 import (
+	"bytes"
 	"context"
 	"testing"
 	"time"
@@ -15,6 +16,7 @@ import (
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
 	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/infrastructure/persistence/model"
 )
 
@@ -176,6 +178,139 @@ func TestSynchronizer_SkipsInactiveProducts(t *testing.T) {
 	assert.Equal(t, int64(2), count, "Should have 2 inventory items")
 }
 
+// TestSynchronizer_ArchivesAndRestoresItems verifies the backfill applies
+// deactivations and reactivations to existing inventory items
+func TestSynchronizer_ArchivesAndRestoresItems(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+
+	ordersContainer, ordersDB := setupPostgresContainer(t, ctx, "orders_archive")
+	defer ordersContainer.Terminate(ctx)
+	setupOrdersSchema(t, ordersDB)
+
+	inventoryContainer, inventoryDB := setupPostgresContainer(t, ctx, "inventory_archive")
+	defer inventoryContainer.Terminate(ctx)
+	setupInventorySchema(t, inventoryDB)
+
+	deactivated := ProductRecord{ID: uuid.New(), Name: "Deactivated", SKU: "DEA001", Price: 10.0, IsActive: false}
+	reactivated := ProductRecord{ID: uuid.New(), Name: "Reactivated", SKU: "REA001", Price: 10.0, IsActive: true}
+	require.NoError(t, ordersDB.Create(&deactivated).Error)
+	require.NoError(t, ordersDB.Create(&reactivated).Error)
+
+	archivedAt := time.Now().Add(-time.Hour)
+	for _, item := range []model.InventoryItemModel{
+		{ID: uuid.New(), ProductID: deactivated.ID, Quantity: 5, Version: 1},
+		{ID: uuid.New(), ProductID: reactivated.ID, Quantity: 7, Version: 1, ArchivedAt: &archivedAt},
+	} {
+		require.NoError(t, inventoryDB.Create(&item).Error)
+	}
+
+	sync := NewSynchronizer(ordersDB, inventoryDB, 100, false)
+	result, err := sync.Sync(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.ArchivedItems)
+	assert.Equal(t, 1, result.RestoredItems)
+	assert.Equal(t, 0, result.NewItems)
+
+	var items []model.InventoryItemModel
+	require.NoError(t, inventoryDB.Find(&items).Error)
+	for _, item := range items {
+		if item.ProductID == deactivated.ID {
+			assert.NotNil(t, item.ArchivedAt, "inactive product is archived")
+			assert.Equal(t, 5, item.Quantity, "stock is kept")
+		} else {
+			assert.Nil(t, item.ArchivedAt, "reactivated product is restored")
+		}
+	}
+
+	// A second run has nothing left to do
+	result, err = sync.Sync(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.ArchivedItems)
+	assert.Equal(t, 0, result.RestoredItems)
+	assert.Equal(t, 1, result.SkippedInactive)
+}
+
+// TestSynchronizer_Reconcile verifies drift is reported, repaired and audited
+func TestSynchronizer_Reconcile(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+
+	ordersContainer, ordersDB := setupPostgresContainer(t, ctx, "orders_reconcile")
+	defer ordersContainer.Terminate(ctx)
+	setupOrdersSchema(t, ordersDB)
+
+	inventoryContainer, inventoryDB := setupPostgresContainer(t, ctx, "inventory_reconcile")
+	defer inventoryContainer.Terminate(ctx)
+	setupInventorySchema(t, inventoryDB)
+	setupReconcileSchema(t, inventoryDB)
+
+	missing := ProductRecord{ID: uuid.New(), Name: "Missing", SKU: "MIS001", Price: 10.0, IsActive: true}
+	inactive := ProductRecord{ID: uuid.New(), Name: "Inactive", SKU: "INA001", Price: 10.0, IsActive: false}
+	require.NoError(t, ordersDB.Create(&missing).Error)
+	require.NoError(t, ordersDB.Create(&inactive).Error)
+
+	hourAgo := time.Now().UTC().Add(-time.Hour)
+	drifted := model.InventoryItemModel{ID: uuid.New(), ProductID: uuid.New(), Quantity: 10, Reserved: 5, Version: 1}
+	stuckItem := model.InventoryItemModel{ID: uuid.New(), ProductID: uuid.New(), Quantity: 10, Reserved: 3, Version: 1}
+	require.NoError(t, inventoryDB.Create(&drifted).Error)
+	require.NoError(t, inventoryDB.Create(&stuckItem).Error)
+	for _, reservation := range []model.ReservationModel{
+		{InventoryItemID: drifted.ID, OrderID: uuid.New(), Quantity: 2, ExpiresAt: time.Now().Add(time.Hour)},
+		{InventoryItemID: stuckItem.ID, OrderID: uuid.New(), Quantity: 3, ExpiresAt: hourAgo},
+		{InventoryItemID: uuid.New(), OrderID: uuid.New(), Quantity: 1, ExpiresAt: time.Now().Add(time.Hour)},
+	} {
+		require.NoError(t, inventoryDB.Create(&reservation).Error)
+	}
+	require.NoError(t, inventoryDB.Exec("UPDATE inventory_items SET updated_at = ?", hourAgo).Error)
+	require.NoError(t, inventoryDB.Exec("UPDATE reservations SET updated_at = ?", hourAgo).Error)
+
+	sync := NewSynchronizer(ordersDB, inventoryDB, 25, false)
+
+	report, err := sync.Reconcile(ctx, false, 5*time.Minute, "")
+	require.NoError(t, err)
+	assert.Equal(t, map[entity.DriftKind]int{
+		entity.DriftStuckReservation:  1,
+		entity.DriftOrphanReservation: 1,
+		entity.DriftMissingItem:       1,
+		entity.DriftReservedMismatch:  1,
+	}, report.Counts)
+	assert.Equal(t, 4, report.Remaining())
+
+	report, err = sync.Reconcile(ctx, true, 5*time.Minute, "ops")
+	require.NoError(t, err)
+	assert.Equal(t, 4, report.Repaired)
+	assert.Equal(t, 0, report.Remaining())
+
+	var items []model.InventoryItemModel
+	require.NoError(t, inventoryDB.Find(&items).Error)
+	reserved := make(map[uuid.UUID]int)
+	for _, item := range items {
+		reserved[item.ProductID] = item.Reserved
+		if item.ProductID == missing.ID {
+			assert.Equal(t, 25, item.Quantity, "missing item gets the default quantity")
+		}
+	}
+	assert.Len(t, items, 3)
+	assert.Equal(t, 2, reserved[drifted.ProductID], "counter matches the pending reservation")
+	assert.Equal(t, 0, reserved[stuckItem.ProductID], "stuck reservation is returned")
+
+	var audited int64
+	require.NoError(t, inventoryDB.Model(&model.AdminAuditEntryModel{}).Where("actor = ? AND method = ?", "ops", "RECONCILE").Count(&audited).Error)
+	assert.Equal(t, int64(4), audited)
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteDriftReport(&buf, report))
+	assert.Contains(t, buf.String(), `"outcome": "repaired"`)
+}
+
 // TestSynchronizer_IdempotentSync verifies repeated syncs don't create duplicates
 func TestSynchronizer_IdempotentSync(t *testing.T) {
 	if testing.Short() {
@@ -328,6 +463,7 @@ func setupInventorySchema(t *testing.T, db *gorm.DB) {
 			version INT NOT NULL DEFAULT 1,
 			created_at TIMESTAMP NOT NULL,
 			updated_at TIMESTAMP NOT NULL,
+			archived_at TIMESTAMP NULL,
 			CHECK (quantity >= 0),
 			CHECK (reserved >= 0),
 			CHECK (reserved <= quantity)
@@ -336,6 +472,12 @@ func setupInventorySchema(t *testing.T, db *gorm.DB) {
 	require.NoError(t, err)
 }
 
+// setupReconcileSchema adds the reservation and audit tables; reservations have no
+// foreign key so orphans can be created
+func setupReconcileSchema(t *testing.T, db *gorm.DB) {
+	require.NoError(t, db.AutoMigrate(&model.ReservationModel{}, &model.AdminAuditEntryModel{}))
+}
+
 func seedOrdersProducts(t *testing.T, db *gorm.DB, count int) {
 	for i := 0; i < count; i++ {
 		product := ProductRecord{
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/infrastructure/auth"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/infrastructure/config"
+)
+
+const usage = `Usage:
+  token keygen --kid <id> [--alg HS256|EdDSA] [--not-before RFC3339] [--not-after RFC3339]
+      Generate a new key. Prints the SERVICE_TOKEN_KEYS entry (and the EdDSA private key).
+
+  token sign --kid <id> --service <name> --scopes <s1,s2> [--user <id>] [--ttl 24h] [--private-key <base64>]
+      Issue a service token. HS256 secrets are read from the service configuration
+      (SERVICE_TOKEN_KEYS / --config); EdDSA needs the private key (or SERVICE_TOKEN_PRIVATE_KEY).
+`
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run executes the command and returns the process exit code
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return 2
+	}
+
+	var err error
+	switch args[0] {
+	case "keygen":
+		err = runKeygen(args[1:], stdout)
+	case "sign":
+		err = runSign(args[1:], stdout)
+	default:
+		fmt.Fprint(stderr, usage)
+		return 2
+	}
+
+	if err != nil {
+		fmt.Fprintf(stderr, "❌ %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// runKeygen generates a key and prints its configuration entry
+func runKeygen(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("keygen", flag.ContinueOnError)
+	kid := fs.String("kid", "", "Key ID (e.g. 2025-01)")
+	alg := fs.String("alg", auth.AlgorithmHS256, "Algorithm: HS256 or EdDSA")
+	notBefore := fs.String("not-before", "", "Start of the key validity window (RFC3339)")
+	notAfter := fs.String("not-after", "", "End of the key validity window (RFC3339)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *kid == "" {
+		return fmt.Errorf("--kid is required")
+	}
+
+	entry := config.TokenKeyConfig{ID: *kid, Algorithm: *alg}
+	var err error
+	if entry.NotBefore, err = parseTime(*notBefore); err != nil {
+		return fmt.Errorf("--not-before: %w", err)
+	}
+	if entry.NotAfter, err = parseTime(*notAfter); err != nil {
+		return fmt.Errorf("--not-after: %w", err)
+	}
+
+	var privateKey ed25519.PrivateKey
+	switch *alg {
+	case auth.AlgorithmHS256:
+		secret := make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return err
+		}
+		entry.Secret = base64.StdEncoding.EncodeToString(secret)
+	case auth.AlgorithmEdDSA:
+		var publicKey ed25519.PublicKey
+		publicKey, privateKey, err = ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return err
+		}
+		entry.PublicKey = base64.StdEncoding.EncodeToString(publicKey)
+	default:
+		return fmt.Errorf("unsupported algorithm %q", *alg)
+	}
+
+	out, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "# Add this entry to SERVICE_TOKEN_KEYS:\n%s\n", out)
+	if privateKey != nil {
+		fmt.Fprintf(stdout, "# EdDSA private key (keep it with the token issuer, never in the service):\n%s\n",
+			base64.StdEncoding.EncodeToString(privateKey.Seed()))
+	}
+	return nil
+}
+
+// runSign issues a token for a service
+func runSign(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("sign", flag.ContinueOnError)
+	configPath := fs.String("config", os.Getenv("CONFIG_FILE"), "Path to an optional YAML configuration file")
+	kid := fs.String("kid", "", "Key ID to sign with")
+	service := fs.String("service", "", "Calling service name (token subject)")
+	scopes := fs.String("scopes", "", "Comma-separated scopes (e.g. inventory:read,inventory:reserve)")
+	user := fs.String("user", "", "End user the service calls on behalf of (optional user_id claim)")
+	ttl := fs.Duration("ttl", 24*time.Hour, "Token lifetime")
+	privateKeyFlag := fs.String("private-key", os.Getenv("SERVICE_TOKEN_PRIVATE_KEY"), "Base64 EdDSA private key seed")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *kid == "" || *service == "" {
+		return fmt.Errorf("--kid and --service are required")
+	}
+
+	cfg, err := config.LoadUnvalidated(*configPath)
+	if err != nil {
+		return err
+	}
+
+	var keyConfig *config.TokenKeyConfig
+	for i := range cfg.Auth.TokenKeys {
+		if cfg.Auth.TokenKeys[i].ID == *kid {
+			keyConfig = &cfg.Auth.TokenKeys[i]
+			break
+		}
+	}
+	if keyConfig == nil {
+		return fmt.Errorf("key %q is not configured in SERVICE_TOKEN_KEYS", *kid)
+	}
+
+	opts := auth.SignerOptions{Audience: cfg.Auth.TokenAudience, Issuer: cfg.Auth.TokenIssuer}
+	var signer *auth.Signer
+	switch keyConfig.Algorithm {
+	case auth.AlgorithmHS256:
+		key, err := auth.DecodeKey(keyConfig.ID, keyConfig.Algorithm, keyConfig.Secret, keyConfig.NotBefore, keyConfig.NotAfter)
+		if err != nil {
+			return err
+		}
+		signer = auth.NewHMACSigner(key.ID, key.Secret, opts)
+	case auth.AlgorithmEdDSA:
+		seed, err := base64.StdEncoding.DecodeString(strings.TrimSpace(*privateKeyFlag))
+		if err != nil || len(seed) != ed25519.SeedSize {
+			return fmt.Errorf("--private-key must be a base64 %d-byte EdDSA seed", ed25519.SeedSize)
+		}
+		signer = auth.NewEd25519Signer(keyConfig.ID, ed25519.NewKeyFromSeed(seed), opts)
+	default:
+		return fmt.Errorf("key %q has unsupported algorithm %q", keyConfig.ID, keyConfig.Algorithm)
+	}
+
+	token, err := signer.SignForUser(*service, *user, splitScopes(*scopes), *ttl)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(stdout, token)
+	return nil
+}
+
+func splitScopes(value string) []string {
+	var scopes []string
+	for _, scope := range strings.Split(value, ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes
+}
+
+func parseTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
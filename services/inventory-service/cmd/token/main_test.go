@@ -0,0 +1,101 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/infrastructure/auth"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/infrastructure/config"
+)
+
+// TestKeygenAndSign_HMAC tests that a generated key can sign tokens the service accepts
+func TestKeygenAndSign_HMAC(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	// Generate key
+	if code := run([]string{"keygen", "--kid", "2025-01"}, &stdout, &stderr); code != 0 {
+		t.Fatalf("keygen failed: %s", stderr.String())
+	}
+	entryJSON := stdout.String()[strings.Index(stdout.String(), "{"):]
+	var entry config.TokenKeyConfig
+	if err := json.Unmarshal([]byte(entryJSON), &entry); err != nil {
+		t.Fatalf("keygen output is not a key entry: %v", err)
+	}
+	if entry.Algorithm != auth.AlgorithmHS256 || entry.Secret == "" {
+		t.Fatalf("unexpected key entry: %+v", entry)
+	}
+
+	// Sign with the configured key
+	keysJSON, _ := json.Marshal([]config.TokenKeyConfig{entry})
+	t.Setenv("SERVICE_TOKEN_KEYS", string(keysJSON))
+	stdout.Reset()
+	if code := run([]string{"sign", "--kid", "2025-01", "--service", "orders-service", "--scopes", "inventory:read, inventory:reserve", "--user", "user-42"}, &stdout, &stderr); code != 0 {
+		t.Fatalf("sign failed: %s", stderr.String())
+	}
+
+	// Verify as the service would
+	key, err := auth.DecodeKey(entry.ID, entry.Algorithm, entry.Secret, entry.NotBefore, entry.NotAfter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	verifier, err := auth.NewVerifier([]auth.Key{key}, auth.VerifierOptions{Audience: "inventory-service"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	principal, err := verifier.Verify(strings.TrimSpace(stdout.String()))
+	if err != nil {
+		t.Fatalf("issued token was rejected: %v", err)
+	}
+	if principal.Service != "orders-service" || principal.UserID != "user-42" || !principal.HasScope(auth.ScopeInventoryReserve) {
+		t.Errorf("unexpected principal: %+v", principal)
+	}
+}
+
+// TestSign_UnknownKey tests that signing with an unconfigured key fails
+func TestSign_UnknownKey(t *testing.T) {
+	t.Setenv("SERVICE_TOKEN_KEYS", "")
+	var stdout, stderr bytes.Buffer
+
+	code := run([]string{"sign", "--kid", "missing", "--service", "orders-service"}, &stdout, &stderr)
+
+	if code != 1 {
+		t.Fatalf("Expected exit code 1, got %d", code)
+	}
+	if !strings.Contains(stderr.String(), "not configured") {
+		t.Errorf("Expected error about unconfigured key, got: %s", stderr.String())
+	}
+}
+
+// TestKeygen_EdDSA tests that EdDSA keygen prints the public entry and the private seed
+func TestKeygen_EdDSA(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	code := run([]string{"keygen", "--kid", "ed-2025", "--alg", "EdDSA", "--not-after", "2030-01-01T00:00:00Z"}, &stdout, &stderr)
+
+	if code != 0 {
+		t.Fatalf("keygen failed: %s", stderr.String())
+	}
+	output := stdout.String()
+	for _, expected := range []string{`"public_key"`, `"not_after": "2030-01-01T00:00:00Z"`, "private key"} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected output to contain %s, got: %s", expected, output)
+		}
+	}
+	if strings.Contains(output, `"secret"`) {
+		t.Errorf("EdDSA entry must not contain a secret: %s", output)
+	}
+}
+
+// TestRun_Usage tests that unknown commands print usage
+func TestRun_Usage(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	if code := run([]string{"unknown"}, &stdout, &stderr); code != 2 {
+		t.Errorf("Expected exit code 2, got %d", code)
+	}
+	if !strings.Contains(stderr.String(), "Usage") {
+		t.Errorf("Expected usage output, got: %s", stderr.String())
+	}
+}
@@ -0,0 +1,117 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/repository"
+)
+
+// RecordAdminOperationUseCase appends admin operations to the audit log
+type RecordAdminOperationUseCase struct {
+	auditRepo repository.AdminAuditRepository
+}
+
+// NewRecordAdminOperationUseCase creates a new instance
+func NewRecordAdminOperationUseCase(auditRepo repository.AdminAuditRepository) *RecordAdminOperationUseCase {
+	if auditRepo == nil {
+		panic("auditRepo cannot be nil")
+	}
+
+	return &RecordAdminOperationUseCase{
+		auditRepo: auditRepo,
+	}
+}
+
+// Execute validates and persists an audit entry
+func (uc *RecordAdminOperationUseCase) Execute(ctx context.Context, entry *entity.AdminAuditEntry) error {
+	if entry == nil || entry.Actor == "" || entry.Method == "" || entry.Route == "" {
+		return errors.ErrInvalidInput.WithDetails("audit entry requires actor, method and route")
+	}
+	if !entity.IsValidAdminAuditOutcome(entry.Outcome) {
+		return errors.ErrInvalidInput.WithDetails("invalid audit outcome: " + string(entry.Outcome))
+	}
+
+	return uc.auditRepo.Save(ctx, entry)
+}
+
+// ListAdminAuditLogInput represents the filters for querying the audit log
+type ListAdminAuditLogInput struct {
+	Actor    string
+	Route    string
+	Outcome  string
+	EntityID string
+	From     time.Time
+	To       time.Time
+	Limit    int
+	Offset   int
+}
+
+// ListAdminAuditLogOutput represents a page of audit entries
+type ListAdminAuditLogOutput struct {
+	Entries    []*entity.AdminAuditEntry
+	TotalCount int64
+	Limit      int
+	Offset     int
+}
+
+// ListAdminAuditLogUseCase handles querying the admin audit log
+type ListAdminAuditLogUseCase struct {
+	auditRepo repository.AdminAuditRepository
+}
+
+// NewListAdminAuditLogUseCase creates a new instance
+func NewListAdminAuditLogUseCase(auditRepo repository.AdminAuditRepository) *ListAdminAuditLogUseCase {
+	if auditRepo == nil {
+		panic("auditRepo cannot be nil")
+	}
+
+	return &ListAdminAuditLogUseCase{
+		auditRepo: auditRepo,
+	}
+}
+
+// Execute lists audit entries, newest first, with pagination
+func (uc *ListAdminAuditLogUseCase) Execute(ctx context.Context, input ListAdminAuditLogInput) (*ListAdminAuditLogOutput, error) {
+	// Validate pagination params
+	if input.Limit <= 0 {
+		input.Limit = 50 // default
+	}
+	if input.Limit > 500 {
+		input.Limit = 500 // max
+	}
+	if input.Offset < 0 {
+		input.Offset = 0
+	}
+
+	outcome := entity.AdminAuditOutcome(input.Outcome)
+	if outcome != "" && !entity.IsValidAdminAuditOutcome(outcome) {
+		return nil, errors.ErrInvalidInput.WithDetails("outcome must be success, failure or denied")
+	}
+	if !input.From.IsZero() && !input.To.IsZero() && !input.From.Before(input.To) {
+		return nil, errors.ErrInvalidInput.WithDetails("from must be before to")
+	}
+
+	entries, total, err := uc.auditRepo.List(ctx, repository.AdminAuditFilter{
+		Actor:    input.Actor,
+		Route:    input.Route,
+		Outcome:  outcome,
+		EntityID: input.EntityID,
+		From:     input.From,
+		To:       input.To,
+		Limit:    input.Limit,
+		Offset:   input.Offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListAdminAuditLogOutput{
+		Entries:    entries,
+		TotalCount: total,
+		Limit:      input.Limit,
+		Offset:     input.Offset,
+	}, nil
+}
@@ -0,0 +1,163 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	domainErrors "github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockAdminAuditRepository is a mock implementation of AdminAuditRepository
+type MockAdminAuditRepository struct {
+	mock.Mock
+}
+
+func (m *MockAdminAuditRepository) Save(ctx context.Context, entry *entity.AdminAuditEntry) error {
+	args := m.Called(ctx, entry)
+	return args.Error(0)
+}
+
+func (m *MockAdminAuditRepository) List(ctx context.Context, filter repository.AdminAuditFilter) ([]*entity.AdminAuditEntry, int64, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*entity.AdminAuditEntry), args.Get(1).(int64), args.Error(2)
+}
+
+func newAuditEntry(t *testing.T) *entity.AdminAuditEntry {
+	entry, err := entity.NewAdminAuditEntry("orders-service", "POST", "/admin/dlq/:id/retry")
+	require.NoError(t, err)
+	entry.Complete(200, 15*time.Millisecond)
+	return entry
+}
+
+// Tests for RecordAdminOperationUseCase
+
+func TestNewRecordAdminOperationUseCase_NilRepo_Panics(t *testing.T) {
+	assert.Panics(t, func() {
+		NewRecordAdminOperationUseCase(nil)
+	})
+}
+
+func TestRecordAdminOperationUseCase_Execute_Success(t *testing.T) {
+	mockRepo := new(MockAdminAuditRepository)
+	useCase := NewRecordAdminOperationUseCase(mockRepo)
+	entry := newAuditEntry(t)
+	mockRepo.On("Save", mock.Anything, entry).Return(nil)
+
+	err := useCase.Execute(context.Background(), entry)
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestRecordAdminOperationUseCase_Execute_RejectsIncompleteEntry(t *testing.T) {
+	mockRepo := new(MockAdminAuditRepository)
+	useCase := NewRecordAdminOperationUseCase(mockRepo)
+	entry := newAuditEntry(t)
+	entry.Outcome = ""
+
+	err := useCase.Execute(context.Background(), entry)
+
+	assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+	assert.ErrorIs(t, useCase.Execute(context.Background(), nil), domainErrors.ErrInvalidInput)
+	mockRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestRecordAdminOperationUseCase_Execute_RepositoryError(t *testing.T) {
+	mockRepo := new(MockAdminAuditRepository)
+	useCase := NewRecordAdminOperationUseCase(mockRepo)
+	entry := newAuditEntry(t)
+	mockRepo.On("Save", mock.Anything, entry).Return(errors.New("connection refused"))
+
+	err := useCase.Execute(context.Background(), entry)
+
+	assert.EqualError(t, err, "connection refused")
+}
+
+// Tests for ListAdminAuditLogUseCase
+
+func TestNewListAdminAuditLogUseCase_NilRepo_Panics(t *testing.T) {
+	assert.Panics(t, func() {
+		NewListAdminAuditLogUseCase(nil)
+	})
+}
+
+func TestListAdminAuditLogUseCase_Execute_PassesFilter(t *testing.T) {
+	mockRepo := new(MockAdminAuditRepository)
+	useCase := NewListAdminAuditLogUseCase(mockRepo)
+	from := time.Now().Add(-time.Hour)
+	entries := []*entity.AdminAuditEntry{newAuditEntry(t)}
+	mockRepo.On("List", mock.Anything, repository.AdminAuditFilter{
+		Actor:    "orders-service",
+		Outcome:  entity.AdminAuditSuccess,
+		EntityID: "abc",
+		From:     from,
+		Limit:    10,
+		Offset:   20,
+	}).Return(entries, int64(21), nil)
+
+	output, err := useCase.Execute(context.Background(), ListAdminAuditLogInput{
+		Actor:    "orders-service",
+		Outcome:  "success",
+		EntityID: "abc",
+		From:     from,
+		Limit:    10,
+		Offset:   20,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, entries, output.Entries)
+	assert.Equal(t, int64(21), output.TotalCount)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestListAdminAuditLogUseCase_Execute_NormalizesPagination(t *testing.T) {
+	tests := []struct {
+		name           string
+		limit, offset  int
+		expectedLimit  int
+		expectedOffset int
+	}{
+		{"defaults", 0, 0, 50, 0},
+		{"caps limit", 1000, 0, 500, 0},
+		{"negative offset", 10, -5, 10, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockAdminAuditRepository)
+			useCase := NewListAdminAuditLogUseCase(mockRepo)
+			mockRepo.On("List", mock.Anything, repository.AdminAuditFilter{Limit: tt.expectedLimit, Offset: tt.expectedOffset}).
+				Return([]*entity.AdminAuditEntry{}, int64(0), nil)
+
+			output, err := useCase.Execute(context.Background(), ListAdminAuditLogInput{Limit: tt.limit, Offset: tt.offset})
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedLimit, output.Limit)
+			assert.Equal(t, tt.expectedOffset, output.Offset)
+		})
+	}
+}
+
+func TestListAdminAuditLogUseCase_Execute_InvalidFilters(t *testing.T) {
+	mockRepo := new(MockAdminAuditRepository)
+	useCase := NewListAdminAuditLogUseCase(mockRepo)
+	now := time.Now()
+
+	_, err := useCase.Execute(context.Background(), ListAdminAuditLogInput{Outcome: "maybe"})
+	assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+
+	_, err = useCase.Execute(context.Background(), ListAdminAuditLogInput{From: now, To: now.Add(-time.Hour)})
+	assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+
+	mockRepo.AssertNotCalled(t, "List", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,155 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/repository"
+)
+
+// Defaults of ReservationRetentionPolicy
+const (
+	DefaultReservationRetention       = 90 * 24 * time.Hour
+	DefaultReservationArchiveBatch    = 1000
+	DefaultReservationPartitionsAhead = 3
+)
+
+// ReservationRetentionPolicy controls how long terminal reservations stay in the
+// reservations table and how the monthly partitions are maintained
+type ReservationRetentionPolicy struct {
+	Retention       time.Duration // terminal reservations last updated longer ago are archived
+	BatchSize       int           // reservations moved per transaction
+	MaxBatches      int           // batches per run, 0 for no limit
+	PartitionsAhead int           // future monthly partitions kept ready
+}
+
+// withDefaults fills the zero fields
+func (p ReservationRetentionPolicy) withDefaults() ReservationRetentionPolicy {
+	if p.Retention <= 0 {
+		p.Retention = DefaultReservationRetention
+	}
+	if p.BatchSize <= 0 {
+		p.BatchSize = DefaultReservationArchiveBatch
+	}
+	if p.PartitionsAhead <= 0 {
+		p.PartitionsAhead = DefaultReservationPartitionsAhead
+	}
+	return p
+}
+
+// RetentionObserver receives the report of every retention run.
+// On failure the report holds what was done before the error.
+type RetentionObserver interface {
+	ObserveRetention(report *ArchiveReservationsOutput, err error)
+}
+
+// ArchiveReservationsOutput reports a retention run
+type ArchiveReservationsOutput struct {
+	StartedAt            time.Time `json:"started_at"`
+	DurationMillis       int64     `json:"duration_ms"`
+	Cutoff               time.Time `json:"cutoff"`
+	Archived             int       `json:"archived"`
+	Batches              int       `json:"batches"`
+	Complete             bool      `json:"complete"` // false when MaxBatches stopped the run with reservations left to archive
+	PartitionsCreated    []string  `json:"partitions_created"`
+	PartitionsDropped    []string  `json:"partitions_dropped"`
+	DefaultPartitionRows int64     `json:"default_partition_rows"`
+}
+
+// ArchiveReservationsUseCase applies the reservation retention policy: it creates
+// the upcoming monthly partitions, moves old terminal reservations to the archive
+// in batches and drops the partitions left empty
+type ArchiveReservationsUseCase struct {
+	archiveRepo   repository.ReservationArchiveRepository
+	partitionRepo repository.ReservationPartitionRepository
+	policy        ReservationRetentionPolicy
+	observer      RetentionObserver
+	now           func() time.Time
+}
+
+// NewArchiveReservationsUseCase creates a new instance
+func NewArchiveReservationsUseCase(
+	archiveRepo repository.ReservationArchiveRepository,
+	partitionRepo repository.ReservationPartitionRepository,
+	policy ReservationRetentionPolicy,
+) *ArchiveReservationsUseCase {
+	if archiveRepo == nil {
+		panic("archiveRepo cannot be nil")
+	}
+	if partitionRepo == nil {
+		panic("partitionRepo cannot be nil")
+	}
+
+	return &ArchiveReservationsUseCase{
+		archiveRepo:   archiveRepo,
+		partitionRepo: partitionRepo,
+		policy:        policy.withDefaults(),
+		now:           time.Now,
+	}
+}
+
+// WithObserver reports every run to the observer
+func (uc *ArchiveReservationsUseCase) WithObserver(observer RetentionObserver) *ArchiveReservationsUseCase {
+	uc.observer = observer
+	return uc
+}
+
+// Execute runs the retention policy once
+func (uc *ArchiveReservationsUseCase) Execute(ctx context.Context) (*ArchiveReservationsOutput, error) {
+	startedAt := uc.now()
+	output := &ArchiveReservationsOutput{
+		StartedAt:         startedAt.UTC(),
+		Cutoff:            startedAt.Add(-uc.policy.Retention).UTC(),
+		PartitionsCreated: []string{},
+		PartitionsDropped: []string{},
+	}
+
+	err := uc.run(ctx, output)
+	output.DurationMillis = uc.now().Sub(startedAt).Milliseconds()
+	if uc.observer != nil {
+		uc.observer.ObserveRetention(output, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+// run fills output step by step, so a failure keeps what was already done
+func (uc *ArchiveReservationsUseCase) run(ctx context.Context, output *ArchiveReservationsOutput) error {
+	// New reservations must never land in the default partition
+	created, err := uc.partitionRepo.EnsurePartitions(ctx, output.StartedAt, uc.policy.PartitionsAhead)
+	output.PartitionsCreated = append(output.PartitionsCreated, created...)
+	if err != nil {
+		return err
+	}
+
+	for uc.policy.MaxBatches == 0 || output.Batches < uc.policy.MaxBatches {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		moved, err := uc.archiveRepo.ArchiveTerminal(ctx, output.Cutoff, uc.policy.BatchSize)
+		if err != nil {
+			return err
+		}
+		output.Batches++
+		output.Archived += moved
+
+		if moved < uc.policy.BatchSize {
+			output.Complete = true
+			break
+		}
+	}
+
+	// Partitions are dropped only once every row in them is gone, which normally
+	// happens after the archive step emptied a month older than the cutoff
+	dropped, err := uc.partitionRepo.DropEmptyPartitions(ctx, output.Cutoff)
+	output.PartitionsDropped = append(output.PartitionsDropped, dropped...)
+	if err != nil {
+		return err
+	}
+
+	output.DefaultPartitionRows, err = uc.partitionRepo.CountDefaultPartitionRows(ctx)
+	return err
+}
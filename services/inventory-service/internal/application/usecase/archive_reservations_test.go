@@ -0,0 +1,185 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockReservationArchiveRepository is a mock implementation of
+// ReservationArchiveRepository and ReservationPartitionRepository
+type MockReservationArchiveRepository struct {
+	mock.Mock
+}
+
+func (m *MockReservationArchiveRepository) ArchiveTerminal(ctx context.Context, cutoff time.Time, limit int) (int, error) {
+	args := m.Called(ctx, cutoff, limit)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockReservationArchiveRepository) ListArchived(ctx context.Context, filter repository.ArchivedReservationFilter) ([]*entity.ArchivedReservation, int64, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*entity.ArchivedReservation), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockReservationArchiveRepository) FindArchivedByID(ctx context.Context, id uuid.UUID) (*entity.ArchivedReservation, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ArchivedReservation), args.Error(1)
+}
+
+func (m *MockReservationArchiveRepository) EnsurePartitions(ctx context.Context, from time.Time, monthsAhead int) ([]string, error) {
+	args := m.Called(ctx, from, monthsAhead)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockReservationArchiveRepository) DropEmptyPartitions(ctx context.Context, cutoff time.Time) ([]string, error) {
+	args := m.Called(ctx, cutoff)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockReservationArchiveRepository) CountDefaultPartitionRows(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// recordingRetentionObserver keeps the reports it receives
+type recordingRetentionObserver struct {
+	reports []*ArchiveReservationsOutput
+	errs    []error
+}
+
+func (o *recordingRetentionObserver) ObserveRetention(report *ArchiveReservationsOutput, err error) {
+	o.reports = append(o.reports, report)
+	o.errs = append(o.errs, err)
+}
+
+var retentionNow = time.Date(2025, 11, 24, 3, 0, 0, 0, time.UTC)
+
+func newArchiveReservationsUseCase(repo *MockReservationArchiveRepository, policy ReservationRetentionPolicy) *ArchiveReservationsUseCase {
+	uc := NewArchiveReservationsUseCase(repo, repo, policy)
+	uc.now = func() time.Time { return retentionNow }
+	return uc
+}
+
+func TestNewArchiveReservationsUseCase_NilRepos_Panic(t *testing.T) {
+	repo := new(MockReservationArchiveRepository)
+	assert.Panics(t, func() { NewArchiveReservationsUseCase(nil, repo, ReservationRetentionPolicy{}) })
+	assert.Panics(t, func() { NewArchiveReservationsUseCase(repo, nil, ReservationRetentionPolicy{}) })
+}
+
+func TestNewArchiveReservationsUseCase_Defaults(t *testing.T) {
+	repo := new(MockReservationArchiveRepository)
+	uc := NewArchiveReservationsUseCase(repo, repo, ReservationRetentionPolicy{})
+
+	assert.Equal(t, ReservationRetentionPolicy{
+		Retention:       DefaultReservationRetention,
+		BatchSize:       DefaultReservationArchiveBatch,
+		PartitionsAhead: DefaultReservationPartitionsAhead,
+	}, uc.policy)
+}
+
+func TestArchiveReservationsUseCase_Execute_ArchivesInBatches(t *testing.T) {
+	repo := new(MockReservationArchiveRepository)
+	cutoff := retentionNow.AddDate(0, 0, -30)
+	repo.On("EnsurePartitions", mock.Anything, retentionNow, 2).Return([]string{"reservations_p202602"}, nil)
+	repo.On("ArchiveTerminal", mock.Anything, cutoff, 10).Return(10, nil).Twice()
+	repo.On("ArchiveTerminal", mock.Anything, cutoff, 10).Return(4, nil).Once()
+	repo.On("DropEmptyPartitions", mock.Anything, cutoff).Return([]string{"reservations_p202508"}, nil)
+	repo.On("CountDefaultPartitionRows", mock.Anything).Return(int64(0), nil)
+
+	observer := &recordingRetentionObserver{}
+	uc := newArchiveReservationsUseCase(repo, ReservationRetentionPolicy{
+		Retention:       30 * 24 * time.Hour,
+		BatchSize:       10,
+		PartitionsAhead: 2,
+	}).WithObserver(observer)
+
+	output, err := uc.Execute(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 24, output.Archived)
+	assert.Equal(t, 3, output.Batches)
+	assert.True(t, output.Complete)
+	assert.Equal(t, cutoff, output.Cutoff)
+	assert.Equal(t, []string{"reservations_p202602"}, output.PartitionsCreated)
+	assert.Equal(t, []string{"reservations_p202508"}, output.PartitionsDropped)
+	require.Len(t, observer.reports, 1)
+	assert.Same(t, output, observer.reports[0])
+	assert.NoError(t, observer.errs[0])
+	repo.AssertExpectations(t)
+}
+
+func TestArchiveReservationsUseCase_Execute_StopsAtMaxBatches(t *testing.T) {
+	repo := new(MockReservationArchiveRepository)
+	repo.On("EnsurePartitions", mock.Anything, mock.Anything, mock.Anything).Return([]string{}, nil)
+	repo.On("ArchiveTerminal", mock.Anything, mock.Anything, 5).Return(5, nil).Twice()
+	repo.On("DropEmptyPartitions", mock.Anything, mock.Anything).Return([]string{}, nil)
+	repo.On("CountDefaultPartitionRows", mock.Anything).Return(int64(3), nil)
+
+	uc := newArchiveReservationsUseCase(repo, ReservationRetentionPolicy{BatchSize: 5, MaxBatches: 2})
+
+	output, err := uc.Execute(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 10, output.Archived)
+	assert.False(t, output.Complete, "reservations are left for the next run")
+	assert.Equal(t, int64(3), output.DefaultPartitionRows)
+	repo.AssertExpectations(t)
+}
+
+func TestArchiveReservationsUseCase_Execute_ReportsPartialFailure(t *testing.T) {
+	repo := new(MockReservationArchiveRepository)
+	repo.On("EnsurePartitions", mock.Anything, mock.Anything, mock.Anything).Return([]string{}, nil)
+	repo.On("ArchiveTerminal", mock.Anything, mock.Anything, 5).Return(5, nil).Once()
+	repo.On("ArchiveTerminal", mock.Anything, mock.Anything, 5).Return(0, errors.New("connection reset")).Once()
+
+	observer := &recordingRetentionObserver{}
+	uc := newArchiveReservationsUseCase(repo, ReservationRetentionPolicy{BatchSize: 5}).WithObserver(observer)
+
+	output, err := uc.Execute(context.Background())
+
+	assert.Nil(t, output)
+	assert.EqualError(t, err, "connection reset")
+	require.Len(t, observer.reports, 1)
+	assert.Equal(t, 5, observer.reports[0].Archived, "the first batch was committed")
+	assert.Error(t, observer.errs[0])
+	repo.AssertNotCalled(t, "DropEmptyPartitions", mock.Anything, mock.Anything)
+}
+
+func TestArchiveReservationsUseCase_Execute_PartitionFailureStopsRun(t *testing.T) {
+	repo := new(MockReservationArchiveRepository)
+	repo.On("EnsurePartitions", mock.Anything, mock.Anything, mock.Anything).Return([]string{}, errors.New("lock timeout"))
+
+	uc := newArchiveReservationsUseCase(repo, ReservationRetentionPolicy{})
+
+	_, err := uc.Execute(context.Background())
+
+	assert.EqualError(t, err, "lock timeout")
+	repo.AssertNotCalled(t, "ArchiveTerminal", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestArchiveReservationsUseCase_Execute_CanceledContext(t *testing.T) {
+	repo := new(MockReservationArchiveRepository)
+	repo.On("EnsurePartitions", mock.Anything, mock.Anything, mock.Anything).Return([]string{}, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := newArchiveReservationsUseCase(repo, ReservationRetentionPolicy{}).Execute(ctx)
+
+	assert.ErrorIs(t, err, context.Canceled)
+	repo.AssertNotCalled(t, "ArchiveTerminal", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,236 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockAtomicStockRepository is a mock implementation of repository.AtomicStockRepository
+type MockAtomicStockRepository struct {
+	mock.Mock
+}
+
+func (m *MockAtomicStockRepository) ReserveStock(ctx context.Context, productID uuid.UUID, quantity int) (*entity.InventoryItem, error) {
+	args := m.Called(ctx, productID, quantity)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.InventoryItem), args.Error(1)
+}
+
+func (m *MockAtomicStockRepository) ReleaseStock(ctx context.Context, id uuid.UUID, quantity int) (*entity.InventoryItem, error) {
+	args := m.Called(ctx, id, quantity)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.InventoryItem), args.Error(1)
+}
+
+func (m *MockAtomicStockRepository) ConfirmStock(ctx context.Context, id uuid.UUID, quantity int) (*entity.InventoryItem, error) {
+	args := m.Called(ctx, id, quantity)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.InventoryItem), args.Error(1)
+}
+
+func (m *MockAtomicStockRepository) AdjustStock(ctx context.Context, productID uuid.UUID, delta int) (*entity.InventoryItem, error) {
+	args := m.Called(ctx, productID, delta)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.InventoryItem), args.Error(1)
+}
+
+func TestReserveStockUseCase_Execute_AtomicStock(t *testing.T) {
+	t.Run("should reserve with a conditional update and skip the read-modify-write", func(t *testing.T) {
+		// Arrange
+		mockInventoryRepo := new(MockInventoryRepository)
+		mockReservationRepo := new(MockReservationRepository)
+		mockPublisher := new(MockPublisher)
+		mockAtomic := new(MockAtomicStockRepository)
+		uc := NewReserveStockUseCase(mockInventoryRepo, mockReservationRepo, mockPublisher).WithAtomicStock(mockAtomic)
+
+		productID := uuid.New()
+		orderID := uuid.New()
+		stored := &entity.InventoryItem{ID: uuid.New(), ProductID: productID, Quantity: 100, Reserved: 40, Version: 3}
+
+		mockReservationRepo.On("ExistsByOrderID", mock.Anything, orderID).Return(false, nil)
+		mockAtomic.On("ReserveStock", mock.Anything, productID, 40).Return(stored, nil).Once()
+		mockReservationRepo.On("Save", mock.Anything, mock.MatchedBy(func(r *entity.Reservation) bool {
+			return r.InventoryItemID == stored.ID && r.Quantity == 40
+		})).Return(nil)
+		mockPublisher.On("PublishStockReserved", mock.Anything, mock.AnythingOfType("events.StockReservedEvent")).Return(nil)
+
+		// Act
+		output, err := uc.Execute(context.Background(), ReserveStockInput{ProductID: productID, OrderID: orderID, Quantity: 40})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 60, output.RemainingStock)
+		mockAtomic.AssertExpectations(t)
+		mockReservationRepo.AssertExpectations(t)
+		mockInventoryRepo.AssertNotCalled(t, "FindByProductID", mock.Anything, mock.Anything)
+		mockInventoryRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return insufficient stock rejected by the guard", func(t *testing.T) {
+		// Arrange
+		mockReservationRepo := new(MockReservationRepository)
+		mockAtomic := new(MockAtomicStockRepository)
+		uc := NewReserveStockUseCase(new(MockInventoryRepository), mockReservationRepo, new(MockPublisher)).WithAtomicStock(mockAtomic)
+
+		productID := uuid.New()
+		orderID := uuid.New()
+		mockReservationRepo.On("ExistsByOrderID", mock.Anything, orderID).Return(false, nil)
+		mockAtomic.On("ReserveStock", mock.Anything, productID, 10).Return(nil, errors.ErrInsufficientStock).Once()
+
+		// Act
+		output, err := uc.Execute(context.Background(), ReserveStockInput{ProductID: productID, OrderID: orderID, Quantity: 10})
+
+		// Assert
+		assert.Nil(t, output)
+		assert.Equal(t, errors.ErrInsufficientStock, err)
+		mockReservationRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should reject an invalid duration before touching stock", func(t *testing.T) {
+		// Arrange
+		mockReservationRepo := new(MockReservationRepository)
+		mockAtomic := new(MockAtomicStockRepository)
+		uc := NewReserveStockUseCase(new(MockInventoryRepository), mockReservationRepo, new(MockPublisher)).WithAtomicStock(mockAtomic)
+
+		orderID := uuid.New()
+		duration := -time.Minute
+		mockReservationRepo.On("ExistsByOrderID", mock.Anything, orderID).Return(false, nil)
+
+		// Act
+		_, err := uc.Execute(context.Background(), ReserveStockInput{ProductID: uuid.New(), OrderID: orderID, Quantity: 1, Duration: &duration})
+
+		// Assert
+		assert.Equal(t, errors.ErrInvalidDuration, err)
+		mockAtomic.AssertNotCalled(t, "ReserveStock", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should retry when the guard passes on re-read", func(t *testing.T) {
+		// Arrange
+		mockReservationRepo := new(MockReservationRepository)
+		mockPublisher := new(MockPublisher)
+		mockAtomic := new(MockAtomicStockRepository)
+		uc := NewReserveStockUseCase(new(MockInventoryRepository), mockReservationRepo, mockPublisher).
+			WithAtomicStock(mockAtomic).
+			WithRetryPolicy(noBackoff(3, nil))
+
+		productID := uuid.New()
+		orderID := uuid.New()
+		stored := &entity.InventoryItem{ID: uuid.New(), ProductID: productID, Quantity: 10, Reserved: 5}
+
+		mockReservationRepo.On("ExistsByOrderID", mock.Anything, orderID).Return(false, nil)
+		mockAtomic.On("ReserveStock", mock.Anything, productID, 5).Return(nil, errors.ErrOptimisticLockFailure).Once()
+		mockAtomic.On("ReserveStock", mock.Anything, productID, 5).Return(stored, nil).Once()
+		mockReservationRepo.On("Save", mock.Anything, mock.AnythingOfType("*entity.Reservation")).Return(nil)
+		mockPublisher.On("PublishStockReserved", mock.Anything, mock.AnythingOfType("events.StockReservedEvent")).Return(nil)
+
+		// Act
+		_, err := uc.Execute(context.Background(), ReserveStockInput{ProductID: productID, OrderID: orderID, Quantity: 5})
+
+		// Assert
+		require.NoError(t, err)
+		mockAtomic.AssertExpectations(t)
+	})
+}
+
+func TestConfirmReservationUseCase_Execute_AtomicStock(t *testing.T) {
+	// Arrange
+	mockInventoryRepo := new(MockInventoryRepository)
+	mockReservationRepo := new(MockReservationRepository)
+	mockPublisher := new(MockPublisher)
+	mockAtomic := new(MockAtomicStockRepository)
+	uc := NewConfirmReservationUseCase(mockInventoryRepo, mockReservationRepo, mockPublisher).WithAtomicStock(mockAtomic)
+
+	stored := &entity.InventoryItem{ID: uuid.New(), ProductID: uuid.New(), Quantity: 70, Reserved: 0}
+	reservation, _ := entity.NewReservation(stored.ID, uuid.New(), 30)
+
+	mockReservationRepo.On("FindByID", mock.Anything, reservation.ID).Return(reservation, nil)
+	mockAtomic.On("ConfirmStock", mock.Anything, stored.ID, 30).Return(stored, nil).Once()
+	mockReservationRepo.On("Update", mock.Anything, mock.MatchedBy(func(r *entity.Reservation) bool {
+		return r.Status == entity.ReservationConfirmed
+	})).Return(nil)
+	mockPublisher.On("PublishStockConfirmed", mock.Anything, mock.AnythingOfType("events.StockConfirmedEvent")).Return(nil)
+
+	// Act
+	output, err := uc.Execute(context.Background(), ConfirmReservationInput{ReservationID: reservation.ID})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 70, output.FinalStock)
+	assert.Equal(t, 0, output.ReservedStock)
+	mockAtomic.AssertExpectations(t)
+	mockReservationRepo.AssertExpectations(t)
+	mockInventoryRepo.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
+	mockInventoryRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestReleaseReservationUseCase_Execute_AtomicStock(t *testing.T) {
+	// Arrange
+	mockInventoryRepo := new(MockInventoryRepository)
+	mockReservationRepo := new(MockReservationRepository)
+	mockPublisher := new(MockPublisher)
+	mockAtomic := new(MockAtomicStockRepository)
+	uc := NewReleaseReservationUseCase(mockInventoryRepo, mockReservationRepo, mockPublisher).WithAtomicStock(mockAtomic)
+
+	stored := &entity.InventoryItem{ID: uuid.New(), ProductID: uuid.New(), Quantity: 100, Reserved: 10}
+	reservation, _ := entity.NewReservation(stored.ID, uuid.New(), 20)
+
+	mockReservationRepo.On("FindByID", mock.Anything, reservation.ID).Return(reservation, nil)
+	mockAtomic.On("ReleaseStock", mock.Anything, stored.ID, 20).Return(stored, nil).Once()
+	mockReservationRepo.On("Update", mock.Anything, mock.AnythingOfType("*entity.Reservation")).Return(nil)
+	mockPublisher.On("PublishStockReleased", mock.Anything, mock.AnythingOfType("events.StockReleasedEvent")).Return(nil)
+
+	// Act
+	output, err := uc.Execute(context.Background(), ReleaseReservationInput{ReservationID: reservation.ID})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 90, output.AvailableStock)
+	mockAtomic.AssertExpectations(t)
+	mockInventoryRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestReleaseExpiredReservationsUseCase_Execute_AtomicStock(t *testing.T) {
+	// Arrange
+	mockInventoryRepo := new(MockInventoryRepository)
+	mockReservationRepo := new(MockReservationRepository)
+	mockPublisher := new(MockPublisher)
+	mockAtomic := new(MockAtomicStockRepository)
+	uc := NewReleaseExpiredReservationsUseCase(mockInventoryRepo, mockReservationRepo, mockPublisher).WithAtomicStock(mockAtomic)
+
+	itemID := uuid.New()
+	released := &entity.Reservation{ID: uuid.New(), InventoryItemID: itemID, OrderID: uuid.New(), Quantity: 5, Status: "pending", ExpiresAt: time.Now().Add(-time.Minute)}
+	failing := &entity.Reservation{ID: uuid.New(), InventoryItemID: itemID, OrderID: uuid.New(), Quantity: 50, Status: "pending", ExpiresAt: time.Now().Add(-time.Minute)}
+
+	mockReservationRepo.On("FindExpired", mock.Anything, mock.Anything).Return([]*entity.Reservation{released, failing}, nil)
+	mockAtomic.On("ReleaseStock", mock.Anything, itemID, 5).Return(&entity.InventoryItem{ID: itemID, ProductID: uuid.New()}, nil)
+	mockAtomic.On("ReleaseStock", mock.Anything, itemID, 50).Return(nil, errors.ErrInvalidReservationRelease)
+	mockReservationRepo.On("Update", mock.Anything, released).Return(nil)
+	mockPublisher.On("PublishStockReleased", mock.Anything, mock.AnythingOfType("events.StockReleasedEvent")).Return(nil)
+
+	// Act
+	output, err := uc.Execute(context.Background())
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{released.ID}, output.ReleasedReservationIDs)
+	require.Len(t, output.FailedReservations, 1)
+	assert.Equal(t, failing.ID, output.FailedReservations[0].ReservationID)
+	mockReservationRepo.AssertNumberOfCalls(t, "Update", 1)
+	mockInventoryRepo.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
+}
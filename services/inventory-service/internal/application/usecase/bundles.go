@@ -0,0 +1,307 @@
+package usecase
+
+import (
+	"context"
+	goerrors "errors"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/events"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+// usesBundles reports whether a count-based stock change failed because the item
+// is a bundle and bundles can apply it to the bundle's components instead
+func usesBundles(err error, bundles repository.BundleRepository) bool {
+	return bundles != nil && goerrors.Is(err, errors.ErrBundleItem)
+}
+
+// ComponentAvailability reports the stock of a bundle component
+type ComponentAvailability struct {
+	ProductID         uuid.UUID
+	Quantity          int // units per bundle
+	AvailableQuantity int // units of the component that can be reserved
+	BundlesAvailable  int // bundles those units make up
+}
+
+// BundleAvailability reports the stock of a bundle, derived from its components
+type BundleAvailability struct {
+	// AvailableQuantity is the number of bundles every component can supply
+	AvailableQuantity int
+	// TotalStock is the number of bundles the units in stock make up, reserved or not
+	TotalStock int
+	Components []ComponentAvailability
+}
+
+// bundleAvailability computes the availability of the bundle sold as item from
+// the current stock of its components
+func bundleAvailability(
+	ctx context.Context,
+	inventoryRepo repository.InventoryRepository,
+	item *entity.InventoryItem,
+	bundle *entity.Bundle,
+) (*BundleAvailability, error) {
+	availability := &BundleAvailability{Components: make([]ComponentAvailability, 0, len(bundle.Components))}
+	for i, component := range bundle.Components {
+		componentItem, err := inventoryRepo.FindByID(ctx, component.InventoryItemID)
+		if err != nil {
+			return nil, errors.ErrInventoryItemNotFound.WithDetails("component " + component.ProductID.String())
+		}
+
+		bundles := component.BundlesAvailable(componentItem)
+		inStock := component.BundlesInStock(componentItem)
+		if i == 0 || bundles < availability.AvailableQuantity {
+			availability.AvailableQuantity = bundles
+		}
+		if i == 0 || inStock < availability.TotalStock {
+			availability.TotalStock = inStock
+		}
+		availability.Components = append(availability.Components, ComponentAvailability{
+			ProductID:         component.ProductID,
+			Quantity:          component.Quantity,
+			AvailableQuantity: componentItem.Available(),
+			BundlesAvailable:  bundles,
+		})
+	}
+
+	if item.IsArchived() {
+		availability.AvailableQuantity = 0
+	}
+	return availability, nil
+}
+
+// heldStock is the units a reservation holds of an inventory item
+type heldStock struct {
+	item     *entity.InventoryItem
+	quantity int
+}
+
+// stockHeld lists the items a reservation of quantity units holds stock of after
+// a change: the components of a bundle, as reported by change, or its own item
+func stockHeld(item *entity.InventoryItem, quantity int, change *repository.BundleStockChange) []heldStock {
+	if change == nil {
+		return []heldStock{{item: item, quantity: quantity}}
+	}
+
+	held := make([]heldStock, len(change.Components))
+	for i, component := range change.Components {
+		held[i] = heldStock{item: change.Items[i], quantity: component.Quantity}
+	}
+	return held
+}
+
+// bundleStock returns how many bundles the component items make up after a
+// change of a reservation of quantity bundles: available, and in stock whether
+// reserved or not
+func bundleStock(change *repository.BundleStockChange, quantity int) (available, inStock int) {
+	for i, component := range change.Components {
+		perBundle := entity.BundleComponent{Quantity: component.Quantity / quantity}
+		bundles := perBundle.BundlesAvailable(change.Items[i])
+		stocked := perBundle.BundlesInStock(change.Items[i])
+		if i == 0 || bundles < available {
+			available = bundles
+		}
+		if i == 0 || stocked < inStock {
+			inStock = stocked
+		}
+	}
+	return available, inStock
+}
+
+// changedComponents returns the components of a bundle stock change, nil without one
+func changedComponents(change *repository.BundleStockChange) []*entity.ReservationComponent {
+	if change == nil {
+		return nil
+	}
+	return change.Components
+}
+
+// changedComponentItems returns the component items of a bundle stock change, nil without one
+func changedComponentItems(change *repository.BundleStockChange) []*entity.InventoryItem {
+	if change == nil {
+		return nil
+	}
+	return change.Items
+}
+
+// componentQuantities lists the units a bundle reservation holds per component for events
+func componentQuantities(components []*entity.ReservationComponent) []events.ComponentQuantity {
+	if len(components) == 0 {
+		return nil
+	}
+
+	quantities := make([]events.ComponentQuantity, len(components))
+	for i, component := range components {
+		quantities[i] = events.ComponentQuantity{
+			ProductID: component.ProductID.String(),
+			Quantity:  component.Quantity,
+		}
+	}
+	return quantities
+}
+
+// BundleComponentInput represents a product that goes into a bundle
+type BundleComponentInput struct {
+	ProductID uuid.UUID
+	Quantity  int // units per bundle
+}
+
+// DefineBundleInput represents the components of a bundle
+type DefineBundleInput struct {
+	ProductID  uuid.UUID
+	Components []BundleComponentInput
+}
+
+// DefineBundleOutput represents a stored bundle definition
+type DefineBundleOutput struct {
+	Bundle *entity.Bundle
+	Item   *entity.InventoryItem
+}
+
+// DefineBundleUseCase makes a product a bundle of other products
+type DefineBundleUseCase struct {
+	inventoryRepo repository.InventoryRepository
+	bundleRepo    repository.BundleRepository
+}
+
+// NewDefineBundleUseCase creates a new instance
+func NewDefineBundleUseCase(inventoryRepo repository.InventoryRepository, bundleRepo repository.BundleRepository) *DefineBundleUseCase {
+	if inventoryRepo == nil {
+		panic("inventoryRepo cannot be nil")
+	}
+	if bundleRepo == nil {
+		panic("bundleRepo cannot be nil")
+	}
+
+	return &DefineBundleUseCase{
+		inventoryRepo: inventoryRepo,
+		bundleRepo:    bundleRepo,
+	}
+}
+
+// Execute validates the components and saves them as the bundle's definition,
+// replacing the previous one. Only items without stock of their own can become
+// bundles; pending reservations keep the components they were made with.
+func (uc *DefineBundleUseCase) Execute(ctx context.Context, input DefineBundleInput) (*DefineBundleOutput, error) {
+	if len(input.Components) == 0 {
+		return nil, errors.ErrInvalidBundle.WithDetails("components is required")
+	}
+	if len(input.Components) > entity.MaxBundleComponents {
+		return nil, errors.ErrInvalidBundle.WithDetails("too many components")
+	}
+
+	item, err := uc.inventoryRepo.FindByProductID(ctx, input.ProductID)
+	if err != nil {
+		return nil, errors.ErrInventoryItemNotFound.WithDetails(err.Error())
+	}
+
+	components := make([]entity.BundleComponent, 0, len(input.Components))
+	for _, componentInput := range input.Components {
+		componentItem, err := uc.inventoryRepo.FindByProductID(ctx, componentInput.ProductID)
+		if err != nil {
+			return nil, errors.ErrInventoryItemNotFound.WithDetails("component " + componentInput.ProductID.String())
+		}
+
+		component, err := entity.NewBundleComponent(componentItem, componentInput.Quantity)
+		if err != nil {
+			return nil, err
+		}
+		components = append(components, component)
+	}
+
+	bundle, err := entity.NewBundle(item, components)
+	if err != nil {
+		return nil, err
+	}
+
+	stored, err := uc.bundleRepo.Save(ctx, bundle)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DefineBundleOutput{Bundle: bundle, Item: stored}, nil
+}
+
+// BundleOutput represents a bundle with the availability derived from its components
+type BundleOutput struct {
+	Bundle       *entity.Bundle
+	Item         *entity.InventoryItem
+	Availability *BundleAvailability
+}
+
+// GetBundleUseCase looks up the definition and availability of a bundle
+type GetBundleUseCase struct {
+	inventoryRepo repository.InventoryRepository
+	bundleRepo    repository.BundleRepository
+}
+
+// NewGetBundleUseCase creates a new instance
+func NewGetBundleUseCase(inventoryRepo repository.InventoryRepository, bundleRepo repository.BundleRepository) *GetBundleUseCase {
+	if inventoryRepo == nil {
+		panic("inventoryRepo cannot be nil")
+	}
+	if bundleRepo == nil {
+		panic("bundleRepo cannot be nil")
+	}
+
+	return &GetBundleUseCase{
+		inventoryRepo: inventoryRepo,
+		bundleRepo:    bundleRepo,
+	}
+}
+
+// Execute returns the components of the product's bundle and how many bundles they make up
+func (uc *GetBundleUseCase) Execute(ctx context.Context, productID uuid.UUID) (*BundleOutput, error) {
+	item, err := uc.inventoryRepo.FindByProductID(ctx, productID)
+	if err != nil {
+		return nil, errors.ErrInventoryItemNotFound.WithDetails(err.Error())
+	}
+	if !item.Bundle {
+		return nil, errors.ErrBundleNotFound
+	}
+
+	bundle, err := uc.bundleRepo.FindByInventoryItemID(ctx, item.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	availability, err := bundleAvailability(ctx, uc.inventoryRepo, item, bundle)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BundleOutput{Bundle: bundle, Item: item, Availability: availability}, nil
+}
+
+// DeleteBundleUseCase turns a bundle back into a product with stock of its own
+type DeleteBundleUseCase struct {
+	inventoryRepo repository.InventoryRepository
+	bundleRepo    repository.BundleRepository
+}
+
+// NewDeleteBundleUseCase creates a new instance
+func NewDeleteBundleUseCase(inventoryRepo repository.InventoryRepository, bundleRepo repository.BundleRepository) *DeleteBundleUseCase {
+	if inventoryRepo == nil {
+		panic("inventoryRepo cannot be nil")
+	}
+	if bundleRepo == nil {
+		panic("bundleRepo cannot be nil")
+	}
+
+	return &DeleteBundleUseCase{
+		inventoryRepo: inventoryRepo,
+		bundleRepo:    bundleRepo,
+	}
+}
+
+// Execute removes the bundle definition of the product and returns its stored
+// item. Bundles with pending reservations cannot be removed.
+func (uc *DeleteBundleUseCase) Execute(ctx context.Context, productID uuid.UUID) (*entity.InventoryItem, error) {
+	item, err := uc.inventoryRepo.FindByProductID(ctx, productID)
+	if err != nil {
+		return nil, errors.ErrInventoryItemNotFound.WithDetails(err.Error())
+	}
+
+	return uc.bundleRepo.Delete(ctx, item.ID)
+}
@@ -0,0 +1,497 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	domainErrors "github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/events"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/repository"
+)
+
+// MockBundleRepository is a mock implementation of BundleRepository
+type MockBundleRepository struct {
+	mock.Mock
+}
+
+func (m *MockBundleRepository) Save(ctx context.Context, bundle *entity.Bundle) (*entity.InventoryItem, error) {
+	args := m.Called(ctx, bundle)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.InventoryItem), args.Error(1)
+}
+
+func (m *MockBundleRepository) FindByInventoryItemID(ctx context.Context, inventoryItemID uuid.UUID) (*entity.Bundle, error) {
+	args := m.Called(ctx, inventoryItemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Bundle), args.Error(1)
+}
+
+func (m *MockBundleRepository) Delete(ctx context.Context, inventoryItemID uuid.UUID) (*entity.InventoryItem, error) {
+	args := m.Called(ctx, inventoryItemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.InventoryItem), args.Error(1)
+}
+
+func (m *MockBundleRepository) Reserve(ctx context.Context, reservationID, productID uuid.UUID, quantity int) (*repository.BundleStockChange, error) {
+	args := m.Called(ctx, reservationID, productID, quantity)
+	return m.changeResult(args)
+}
+
+func (m *MockBundleRepository) Confirm(ctx context.Context, reservationID, inventoryItemID uuid.UUID) (*repository.BundleStockChange, error) {
+	args := m.Called(ctx, reservationID, inventoryItemID)
+	return m.changeResult(args)
+}
+
+func (m *MockBundleRepository) Release(ctx context.Context, reservationID, inventoryItemID uuid.UUID) (*repository.BundleStockChange, error) {
+	args := m.Called(ctx, reservationID, inventoryItemID)
+	return m.changeResult(args)
+}
+
+func (m *MockBundleRepository) FindComponents(ctx context.Context, reservationID uuid.UUID) ([]*entity.ReservationComponent, error) {
+	args := m.Called(ctx, reservationID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.ReservationComponent), args.Error(1)
+}
+
+func (m *MockBundleRepository) changeResult(args mock.Arguments) (*repository.BundleStockChange, error) {
+	change, _ := args.Get(0).(*repository.BundleStockChange)
+	return change, args.Error(1)
+}
+
+// bundleFixture is a bundle of one unit of first and two units of second
+type bundleFixture struct {
+	item   *entity.InventoryItem
+	first  *entity.InventoryItem
+	second *entity.InventoryItem
+	bundle *entity.Bundle
+}
+
+func newBundleFixture(t *testing.T) *bundleFixture {
+	t.Helper()
+	item, err := entity.NewInventoryItem(uuid.New(), 0)
+	require.NoError(t, err)
+	require.NoError(t, item.MakeBundle())
+	first, err := entity.NewInventoryItem(uuid.New(), 10)
+	require.NoError(t, err)
+	second, err := entity.NewInventoryItem(uuid.New(), 10)
+	require.NoError(t, err)
+
+	firstComponent, err := entity.NewBundleComponent(first, 1)
+	require.NoError(t, err)
+	secondComponent, err := entity.NewBundleComponent(second, 2)
+	require.NoError(t, err)
+	bundle, err := entity.NewBundle(item, []entity.BundleComponent{firstComponent, secondComponent})
+	require.NoError(t, err)
+
+	return &bundleFixture{item: item, first: first, second: second, bundle: bundle}
+}
+
+// change returns the change of a reservation of quantity bundles with the
+// component items stored afterwards with the given quantity and reserved units
+func (f *bundleFixture) change(reservationID uuid.UUID, quantity int, status entity.ReservationComponentStatus, first, second [2]int) *repository.BundleStockChange {
+	components := f.bundle.Reserve(reservationID, quantity, time.Now())
+	for _, component := range components {
+		component.Status = status
+	}
+	return &repository.BundleStockChange{
+		Bundle:     f.item,
+		Components: components,
+		Items:      []*entity.InventoryItem{withStock(f.first, first), withStock(f.second, second)},
+	}
+}
+
+func withStock(item *entity.InventoryItem, stock [2]int) *entity.InventoryItem {
+	stored := *item
+	stored.Quantity = stock[0]
+	stored.Reserved = stock[1]
+	return &stored
+}
+
+func (f *bundleFixture) componentQuantities(quantity int) []events.ComponentQuantity {
+	return []events.ComponentQuantity{
+		{ProductID: f.first.ProductID.String(), Quantity: quantity},
+		{ProductID: f.second.ProductID.String(), Quantity: 2 * quantity},
+	}
+}
+
+func TestNewBundleUseCases_NilDependencies_Panic(t *testing.T) {
+	assert.Panics(t, func() { NewDefineBundleUseCase(nil, new(MockBundleRepository)) })
+	assert.Panics(t, func() { NewDefineBundleUseCase(new(MockInventoryRepository), nil) })
+	assert.Panics(t, func() { NewGetBundleUseCase(nil, new(MockBundleRepository)) })
+	assert.Panics(t, func() { NewGetBundleUseCase(new(MockInventoryRepository), nil) })
+	assert.Panics(t, func() { NewDeleteBundleUseCase(nil, new(MockBundleRepository)) })
+	assert.Panics(t, func() { NewDeleteBundleUseCase(new(MockInventoryRepository), nil) })
+}
+
+func TestDefineBundleUseCase_Execute(t *testing.T) {
+	f := newBundleFixture(t)
+	components := []BundleComponentInput{
+		{ProductID: f.first.ProductID, Quantity: 1},
+		{ProductID: f.second.ProductID, Quantity: 2},
+	}
+	setup := func() (*MockInventoryRepository, *MockBundleRepository) {
+		inventoryRepo := new(MockInventoryRepository)
+		bundleRepo := new(MockBundleRepository)
+		for _, item := range []*entity.InventoryItem{f.item, f.first, f.second} {
+			inventoryRepo.On("FindByProductID", mock.Anything, item.ProductID).Return(item, nil)
+		}
+		inventoryRepo.On("FindByProductID", mock.Anything, mock.Anything).Return(nil, errors.New("record not found"))
+		return inventoryRepo, bundleRepo
+	}
+
+	t.Run("should save the bundle of the components", func(t *testing.T) {
+		inventoryRepo, bundleRepo := setup()
+		bundleRepo.On("Save", mock.Anything, f.bundle).Return(f.item, nil)
+
+		output, err := NewDefineBundleUseCase(inventoryRepo, bundleRepo).Execute(context.Background(), DefineBundleInput{
+			ProductID:  f.item.ProductID,
+			Components: components,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, f.bundle, output.Bundle)
+		assert.Same(t, f.item, output.Item)
+	})
+
+	t.Run("should reject invalid definitions before touching the store", func(t *testing.T) {
+		tests := []struct {
+			name       string
+			components []BundleComponentInput
+			expected   error
+		}{
+			{"no components", nil, domainErrors.ErrInvalidBundle},
+			{"too many components", make([]BundleComponentInput, entity.MaxBundleComponents+1), domainErrors.ErrInvalidBundle},
+			{"invalid quantity", []BundleComponentInput{{ProductID: f.first.ProductID, Quantity: 0}}, domainErrors.ErrInvalidQuantity},
+			{"duplicate component", []BundleComponentInput{components[0], components[0]}, domainErrors.ErrInvalidBundle},
+			{"bundle as component", []BundleComponentInput{{ProductID: f.item.ProductID, Quantity: 1}}, domainErrors.ErrInvalidBundle},
+			{"unknown component", []BundleComponentInput{{ProductID: uuid.New(), Quantity: 1}}, domainErrors.ErrInventoryItemNotFound},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				inventoryRepo, bundleRepo := setup()
+
+				_, err := NewDefineBundleUseCase(inventoryRepo, bundleRepo).Execute(context.Background(), DefineBundleInput{
+					ProductID:  f.item.ProductID,
+					Components: tt.components,
+				})
+
+				assert.ErrorIs(t, err, tt.expected)
+				bundleRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+			})
+		}
+	})
+
+	t.Run("should return the errors of the store", func(t *testing.T) {
+		inventoryRepo, bundleRepo := setup()
+		bundleRepo.On("Save", mock.Anything, mock.Anything).Return(nil, domainErrors.ErrBundleChange)
+
+		_, err := NewDefineBundleUseCase(inventoryRepo, bundleRepo).Execute(context.Background(), DefineBundleInput{
+			ProductID:  f.item.ProductID,
+			Components: components,
+		})
+
+		assert.ErrorIs(t, err, domainErrors.ErrBundleChange)
+	})
+}
+
+func TestGetBundleUseCase_Execute(t *testing.T) {
+	f := newBundleFixture(t)
+	inventoryRepo := new(MockInventoryRepository)
+	bundleRepo := new(MockBundleRepository)
+	inventoryRepo.On("FindByProductID", mock.Anything, f.item.ProductID).Return(f.item, nil)
+	inventoryRepo.On("FindByProductID", mock.Anything, f.first.ProductID).Return(f.first, nil)
+	inventoryRepo.On("FindByID", mock.Anything, f.first.ID).Return(withStock(f.first, [2]int{10, 3}), nil)
+	inventoryRepo.On("FindByID", mock.Anything, f.second.ID).Return(withStock(f.second, [2]int{9, 3}), nil)
+	bundleRepo.On("FindByInventoryItemID", mock.Anything, f.item.ID).Return(f.bundle, nil)
+
+	output, err := NewGetBundleUseCase(inventoryRepo, bundleRepo).Execute(context.Background(), f.item.ProductID)
+
+	require.NoError(t, err)
+	assert.Same(t, f.bundle, output.Bundle)
+	assert.Equal(t, 3, output.Availability.AvailableQuantity, "6 available units of the second component make 3 bundles")
+	assert.Equal(t, 4, output.Availability.TotalStock)
+	assert.Equal(t, []ComponentAvailability{
+		{ProductID: f.first.ProductID, Quantity: 1, AvailableQuantity: 7, BundlesAvailable: 7},
+		{ProductID: f.second.ProductID, Quantity: 2, AvailableQuantity: 6, BundlesAvailable: 3},
+	}, output.Availability.Components)
+
+	_, err = NewGetBundleUseCase(inventoryRepo, bundleRepo).Execute(context.Background(), f.first.ProductID)
+	assert.ErrorIs(t, err, domainErrors.ErrBundleNotFound)
+}
+
+func TestDeleteBundleUseCase_Execute(t *testing.T) {
+	f := newBundleFixture(t)
+	unbundled := *f.item
+	unbundled.Bundle = false
+	inventoryRepo := new(MockInventoryRepository)
+	bundleRepo := new(MockBundleRepository)
+	inventoryRepo.On("FindByProductID", mock.Anything, f.item.ProductID).Return(f.item, nil)
+	bundleRepo.On("Delete", mock.Anything, f.item.ID).Return(&unbundled, nil).Once()
+	bundleRepo.On("Delete", mock.Anything, f.item.ID).Return(nil, domainErrors.ErrBundleInUse)
+
+	stored, err := NewDeleteBundleUseCase(inventoryRepo, bundleRepo).Execute(context.Background(), f.item.ProductID)
+	require.NoError(t, err)
+	assert.False(t, stored.Bundle)
+
+	_, err = NewDeleteBundleUseCase(inventoryRepo, bundleRepo).Execute(context.Background(), f.item.ProductID)
+	assert.ErrorIs(t, err, domainErrors.ErrBundleInUse)
+}
+
+func TestCheckAvailabilityUseCase_Execute_WithBundles(t *testing.T) {
+	f := newBundleFixture(t)
+	inventoryRepo := new(MockInventoryRepository)
+	bundleRepo := new(MockBundleRepository)
+	inventoryRepo.On("FindByProductID", mock.Anything, f.item.ProductID).Return(f.item, nil)
+	inventoryRepo.On("FindByID", mock.Anything, f.first.ID).Return(withStock(f.first, [2]int{10, 3}), nil)
+	inventoryRepo.On("FindByID", mock.Anything, f.second.ID).Return(withStock(f.second, [2]int{9, 3}), nil)
+	bundleRepo.On("FindByInventoryItemID", mock.Anything, f.item.ID).Return(f.bundle, nil)
+
+	uc := NewCheckAvailabilityUseCase(inventoryRepo).WithBundles(bundleRepo)
+	output, err := uc.Execute(context.Background(), CheckAvailabilityInput{ProductID: f.item.ProductID, Quantity: 3})
+
+	require.NoError(t, err)
+	assert.True(t, output.IsAvailable)
+	assert.Equal(t, 3, output.AvailableQuantity)
+	assert.Equal(t, 4, output.TotalStock)
+	assert.Equal(t, 1, output.ReservedQuantity)
+	assert.Len(t, output.Components, 2)
+
+	output, err = uc.Execute(context.Background(), CheckAvailabilityInput{ProductID: f.item.ProductID, Quantity: 4})
+	require.NoError(t, err)
+	assert.False(t, output.IsAvailable)
+
+	output, err = NewCheckAvailabilityUseCase(inventoryRepo).
+		Execute(context.Background(), CheckAvailabilityInput{ProductID: f.item.ProductID, Quantity: 1})
+	require.NoError(t, err)
+	assert.False(t, output.IsAvailable, "bundles hold no stock of their own")
+	assert.Nil(t, output.Components)
+}
+
+func TestReserveStockUseCase_Execute_WithBundles(t *testing.T) {
+	f := newBundleFixture(t)
+
+	t.Run("should reserve every component under one reservation", func(t *testing.T) {
+		inventoryRepo := new(MockInventoryRepository)
+		reservationRepo := new(MockReservationRepository)
+		publisher := new(MockPublisher)
+		bundleRepo := new(MockBundleRepository)
+		lotRepo := new(MockLotRepository)
+		change := f.change(uuid.New(), 2, entity.ComponentReserved, [2]int{10, 2}, [2]int{4, 4})
+
+		reservationRepo.On("ExistsByOrderID", mock.Anything, mock.Anything).Return(false, nil)
+		inventoryRepo.On("FindByProductID", mock.Anything, f.item.ProductID).Return(f.item, nil)
+		bundleRepo.On("Reserve", mock.Anything, mock.Anything, f.item.ProductID, 2).Return(change, nil)
+		reservationRepo.On("Save", mock.Anything, mock.MatchedBy(func(r *entity.Reservation) bool {
+			return r.InventoryItemID == f.item.ID && r.Quantity == 2
+		})).Return(nil)
+		lotRepo.On("Allocate", mock.Anything, mock.Anything, f.first.ID, 2, mock.Anything).
+			Return([]*entity.LotAllocation{{LotID: uuid.New(), Quantity: 2}}, nil)
+		lotRepo.On("Allocate", mock.Anything, mock.Anything, f.second.ID, 4, mock.Anything).Return(nil, errors.New("deadlock"))
+		publisher.On("PublishStockReserved", mock.Anything, mock.MatchedBy(func(event events.StockReservedEvent) bool {
+			return event.Payload.ProductID == f.item.ProductID.String() &&
+				assert.ObjectsAreEqual(f.componentQuantities(2), event.Payload.Components)
+		})).Return(nil)
+		publisher.On("PublishStockDepleted", mock.Anything, mock.MatchedBy(func(event events.StockDepletedEvent) bool {
+			return event.Payload.ProductID == f.second.ProductID.String() && event.Payload.LastQuantity == 4
+		})).Return(nil).Once()
+
+		uc := NewReserveStockUseCase(inventoryRepo, reservationRepo, publisher).WithBundles(bundleRepo).WithLots(lotRepo)
+		output, err := uc.Execute(context.Background(), ReserveStockInput{ProductID: f.item.ProductID, OrderID: uuid.New(), Quantity: 2})
+
+		require.NoError(t, err)
+		assert.Equal(t, change.Components, output.Components)
+		assert.Equal(t, 0, output.RemainingStock, "the second component is used up")
+		assert.Len(t, output.Lots, 1, "lots are allocated per component")
+		bundleRepo.AssertCalled(t, "Reserve", mock.Anything, output.ReservationID, f.item.ProductID, 2)
+		lotRepo.AssertCalled(t, "Allocate", mock.Anything, output.ReservationID, f.second.ID, 4, mock.Anything)
+		inventoryRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		publisher.AssertExpectations(t)
+	})
+
+	t.Run("should fail without bundles", func(t *testing.T) {
+		inventoryRepo := new(MockInventoryRepository)
+		reservationRepo := new(MockReservationRepository)
+		reservationRepo.On("ExistsByOrderID", mock.Anything, mock.Anything).Return(false, nil)
+		inventoryRepo.On("FindByProductID", mock.Anything, f.item.ProductID).Return(f.item, nil)
+
+		_, err := NewReserveStockUseCase(inventoryRepo, reservationRepo, new(MockPublisher)).
+			Execute(context.Background(), ReserveStockInput{ProductID: f.item.ProductID, OrderID: uuid.New(), Quantity: 1})
+
+		assert.ErrorIs(t, err, domainErrors.ErrBundleItem)
+		reservationRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should not save the reservation when a component runs out", func(t *testing.T) {
+		inventoryRepo := new(MockInventoryRepository)
+		reservationRepo := new(MockReservationRepository)
+		bundleRepo := new(MockBundleRepository)
+		reservationRepo.On("ExistsByOrderID", mock.Anything, mock.Anything).Return(false, nil)
+		inventoryRepo.On("FindByProductID", mock.Anything, f.item.ProductID).Return(f.item, nil)
+		bundleRepo.On("Reserve", mock.Anything, mock.Anything, f.item.ProductID, 6).
+			Return(nil, domainErrors.ErrInsufficientStock.WithDetails("component "+f.second.ProductID.String()))
+
+		_, err := NewReserveStockUseCase(inventoryRepo, reservationRepo, new(MockPublisher)).WithBundles(bundleRepo).
+			Execute(context.Background(), ReserveStockInput{ProductID: f.item.ProductID, OrderID: uuid.New(), Quantity: 6})
+
+		assert.ErrorIs(t, err, domainErrors.ErrInsufficientStock)
+		assert.Contains(t, err.Error(), f.second.ProductID.String())
+		reservationRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+}
+
+func TestConfirmReservationUseCase_Execute_WithBundles(t *testing.T) {
+	f := newBundleFixture(t)
+	inventoryRepo := new(MockInventoryRepository)
+	reservationRepo := new(MockReservationRepository)
+	publisher := new(MockPublisher)
+	bundleRepo := new(MockBundleRepository)
+	reservation, _ := entity.NewReservation(f.item.ID, uuid.New(), 2)
+	change := f.change(reservation.ID, 2, entity.ComponentConfirmed, [2]int{8, 0}, [2]int{7, 1})
+
+	reservationRepo.On("FindByID", mock.Anything, reservation.ID).Return(reservation, nil)
+	inventoryRepo.On("FindByID", mock.Anything, f.item.ID).Return(f.item, nil)
+	bundleRepo.On("Confirm", mock.Anything, reservation.ID, f.item.ID).Return(change, nil)
+	reservationRepo.On("Update", mock.Anything, reservation).Return(nil)
+	publisher.On("PublishStockConfirmed", mock.Anything, mock.MatchedBy(func(event events.StockConfirmedEvent) bool {
+		return event.Payload.ProductID == f.item.ProductID.String() &&
+			assert.ObjectsAreEqual(f.componentQuantities(2), event.Payload.Components)
+	})).Return(nil)
+
+	uc := NewConfirmReservationUseCase(inventoryRepo, reservationRepo, publisher).WithBundles(bundleRepo)
+	output, err := uc.Execute(context.Background(), ConfirmReservationInput{ReservationID: reservation.ID})
+
+	require.NoError(t, err)
+	assert.Equal(t, change.Components, output.Components)
+	assert.Equal(t, change.Items, output.ComponentItems)
+	assert.Equal(t, 3, output.FinalStock, "7 units of the second component make 3 bundles")
+	assert.Equal(t, 0, output.ReservedStock, "the one reserved unit makes no bundle")
+	assert.Equal(t, entity.ReservationConfirmed, output.Reservation.Status)
+	inventoryRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	publisher.AssertNotCalled(t, "PublishStockDepleted", mock.Anything, mock.Anything)
+	publisher.AssertExpectations(t)
+}
+
+func TestReleaseReservationUseCases_WithBundles(t *testing.T) {
+	f := newBundleFixture(t)
+
+	t.Run("should release the components of a released reservation", func(t *testing.T) {
+		inventoryRepo := new(MockInventoryRepository)
+		reservationRepo := new(MockReservationRepository)
+		publisher := new(MockPublisher)
+		bundleRepo := new(MockBundleRepository)
+		reservation, _ := entity.NewReservation(f.item.ID, uuid.New(), 2)
+		change := f.change(reservation.ID, 2, entity.ComponentReleased, [2]int{10, 0}, [2]int{10, 0})
+
+		reservationRepo.On("FindByID", mock.Anything, reservation.ID).Return(reservation, nil)
+		inventoryRepo.On("FindByID", mock.Anything, f.item.ID).Return(f.item, nil)
+		bundleRepo.On("Release", mock.Anything, reservation.ID, f.item.ID).Return(change, nil)
+		reservationRepo.On("Update", mock.Anything, reservation).Return(nil)
+		publisher.On("PublishStockReleased", mock.Anything, mock.MatchedBy(func(event events.StockReleasedEvent) bool {
+			return assert.ObjectsAreEqual(f.componentQuantities(2), event.Payload.Components)
+		})).Return(nil)
+
+		uc := NewReleaseReservationUseCase(inventoryRepo, reservationRepo, publisher).WithBundles(bundleRepo)
+		output, err := uc.Execute(context.Background(), ReleaseReservationInput{ReservationID: reservation.ID})
+
+		require.NoError(t, err)
+		assert.Equal(t, change.Components, output.Components)
+		assert.Equal(t, change.Items, output.ComponentItems)
+		assert.Equal(t, 5, output.AvailableStock)
+		assert.Equal(t, 0, output.ReservedStock)
+		publisher.AssertExpectations(t)
+	})
+
+	t.Run("should release the components of an expired reservation", func(t *testing.T) {
+		inventoryRepo := new(MockInventoryRepository)
+		reservationRepo := new(MockReservationRepository)
+		publisher := new(MockPublisher)
+		bundleRepo := new(MockBundleRepository)
+		reservation, _ := entity.NewReservation(f.item.ID, uuid.New(), 1)
+		reservation.ExpiresAt = time.Now().Add(-time.Minute)
+		failing, _ := entity.NewReservation(f.item.ID, uuid.New(), 1)
+		failing.ExpiresAt = time.Now().Add(-time.Minute)
+
+		reservationRepo.On("FindExpired", mock.Anything, mock.Anything).Return([]*entity.Reservation{reservation, failing}, nil)
+		inventoryRepo.On("FindByID", mock.Anything, f.item.ID).Return(f.item, nil)
+		bundleRepo.On("Release", mock.Anything, reservation.ID, f.item.ID).
+			Return(f.change(reservation.ID, 1, entity.ComponentReleased, [2]int{10, 0}, [2]int{10, 0}), nil)
+		bundleRepo.On("Release", mock.Anything, failing.ID, f.item.ID).Return(nil, domainErrors.ErrInvalidReservationRelease)
+		reservationRepo.On("Update", mock.Anything, reservation).Return(nil)
+		publisher.On("PublishStockReleased", mock.Anything, mock.MatchedBy(func(event events.StockReleasedEvent) bool {
+			return event.Payload.Reason == "reservation_expired" &&
+				assert.ObjectsAreEqual(f.componentQuantities(1), event.Payload.Components)
+		})).Return(nil).Once()
+
+		uc := NewReleaseExpiredReservationsUseCase(inventoryRepo, reservationRepo, publisher).WithBundles(bundleRepo)
+		output, err := uc.Execute(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{reservation.ID}, output.ReleasedReservationIDs)
+		require.Len(t, output.FailedReservations, 1)
+		assert.Contains(t, output.FailedReservations[0].Reason, "failed to release bundle components")
+		publisher.AssertExpectations(t)
+	})
+}
+
+func TestGetOrderReservationUseCase_Execute_WithBundles(t *testing.T) {
+	f := newBundleFixture(t)
+	inventoryRepo := new(MockInventoryRepository)
+	reservationRepo := new(MockReservationRepository)
+	bundleRepo := new(MockBundleRepository)
+	reservation, _ := entity.NewReservation(f.item.ID, uuid.New(), 2)
+	other, _ := entity.NewReservation(f.first.ID, uuid.New(), 1)
+	components := f.bundle.Reserve(reservation.ID, 2, time.Now())
+
+	reservationRepo.On("FindByOrderID", mock.Anything, reservation.OrderID).Return(reservation, nil)
+	reservationRepo.On("FindByOrderID", mock.Anything, other.OrderID).Return(other, nil)
+	inventoryRepo.On("FindByID", mock.Anything, f.item.ID).Return(f.item, nil)
+	inventoryRepo.On("FindByID", mock.Anything, f.first.ID).Return(f.first, nil)
+	bundleRepo.On("FindComponents", mock.Anything, reservation.ID).Return(components, nil)
+	inventoryRepo.On("FindByProductIDs", mock.Anything, []uuid.UUID{f.first.ProductID, f.second.ProductID}).
+		Return(map[uuid.UUID]*entity.InventoryItem{f.second.ProductID: f.second, f.first.ProductID: f.first}, nil)
+
+	uc := NewGetOrderReservationUseCase(reservationRepo, inventoryRepo).WithBundles(bundleRepo)
+	output, err := uc.Execute(context.Background(), reservation.OrderID)
+	require.NoError(t, err)
+	assert.Equal(t, components, output.Components)
+	assert.Equal(t, []*entity.InventoryItem{f.first, f.second}, output.ComponentItems, "component stock follows the component order")
+
+	output, err = uc.Execute(context.Background(), other.OrderID)
+	require.NoError(t, err)
+	assert.Nil(t, output.Components)
+	bundleRepo.AssertNotCalled(t, "FindComponents", mock.Anything, other.ID)
+}
+
+func TestGetOrderReservationUseCase_Execute_WithBundles_ComponentItemMissing(t *testing.T) {
+	f := newBundleFixture(t)
+	inventoryRepo := new(MockInventoryRepository)
+	reservationRepo := new(MockReservationRepository)
+	bundleRepo := new(MockBundleRepository)
+	reservation, _ := entity.NewReservation(f.item.ID, uuid.New(), 2)
+
+	reservationRepo.On("FindByOrderID", mock.Anything, reservation.OrderID).Return(reservation, nil)
+	inventoryRepo.On("FindByID", mock.Anything, f.item.ID).Return(f.item, nil)
+	bundleRepo.On("FindComponents", mock.Anything, reservation.ID).Return(f.bundle.Reserve(reservation.ID, 2, time.Now()), nil)
+	inventoryRepo.On("FindByProductIDs", mock.Anything, mock.Anything).
+		Return(map[uuid.UUID]*entity.InventoryItem{f.first.ProductID: f.first}, nil)
+
+	_, err := NewGetOrderReservationUseCase(reservationRepo, inventoryRepo).WithBundles(bundleRepo).
+		Execute(context.Background(), reservation.OrderID)
+	assert.ErrorIs(t, err, domainErrors.ErrInventoryItemNotFound)
+}
@@ -0,0 +1,245 @@
+package usecase
+
+import (
+	"context"
+	goerrors "errors"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+// usesChannels reports whether a count-based reservation failed because the item
+// is allocated to sales channels and channels can reserve it for a channel instead
+func usesChannels(err error, channels repository.ChannelAllocationRepository) bool {
+	return channels != nil && goerrors.Is(err, errors.ErrChannelAllocatedItem)
+}
+
+// validateOptionalChannel checks a sales channel given as input; empty means none
+func validateOptionalChannel(channel string) error {
+	if channel == "" {
+		return nil
+	}
+	return entity.ValidateChannel(channel)
+}
+
+// ChannelAllocationsOutput reports the allocations of an inventory item and the
+// stock every channel can sell
+type ChannelAllocationsOutput struct {
+	Item        *entity.InventoryItem
+	Allocations []entity.ChannelAllocation
+	// Stocks is the stock of every allocated channel, in Allocations order
+	Stocks []entity.ChannelStock
+	// Pool is the stock not set aside for any channel
+	Pool entity.ChannelStock
+}
+
+// channelAllocations computes the stock of every channel the item is allocated to
+func channelAllocations(
+	ctx context.Context,
+	channels repository.ChannelAllocationRepository,
+	item *entity.InventoryItem,
+) (*ChannelAllocationsOutput, error) {
+	allocations, err := channels.FindByInventoryItemID(ctx, item.ID)
+	if err != nil {
+		return nil, err
+	}
+	reserved, err := channels.ReservedByChannel(ctx, item.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	stocks, pool := entity.ChannelStocks(item, allocations, reserved)
+	return &ChannelAllocationsOutput{
+		Item:        item,
+		Allocations: allocations,
+		Stocks:      stocks,
+		Pool:        pool,
+	}, nil
+}
+
+// GetChannelAllocationsUseCase reports the channel allocations of a product
+type GetChannelAllocationsUseCase struct {
+	inventoryRepo repository.InventoryRepository
+	channels      repository.ChannelAllocationRepository
+}
+
+// NewGetChannelAllocationsUseCase creates a new instance
+func NewGetChannelAllocationsUseCase(
+	inventoryRepo repository.InventoryRepository,
+	channels repository.ChannelAllocationRepository,
+) *GetChannelAllocationsUseCase {
+	if inventoryRepo == nil {
+		panic("inventoryRepo cannot be nil")
+	}
+	if channels == nil {
+		panic("channels cannot be nil")
+	}
+
+	return &GetChannelAllocationsUseCase{
+		inventoryRepo: inventoryRepo,
+		channels:      channels,
+	}
+}
+
+// Execute returns the allocations of the product and the stock of every channel
+func (uc *GetChannelAllocationsUseCase) Execute(ctx context.Context, productID uuid.UUID) (*ChannelAllocationsOutput, error) {
+	item, err := uc.inventoryRepo.FindByProductID(ctx, productID)
+	if err != nil {
+		return nil, errors.ErrInventoryItemNotFound.WithDetails(err.Error())
+	}
+
+	return channelAllocations(ctx, uc.channels, item)
+}
+
+// ChannelAllocationInput represents the stock set aside for a sales channel
+type ChannelAllocationInput struct {
+	Channel string
+	Mode    string
+	Quota   int // fixed allocations only
+	Percent int // percentage allocations only
+}
+
+// SetChannelAllocationsInput represents the allocations of a product, replacing
+// the previous ones; none makes the whole stock a shared pool again
+type SetChannelAllocationsInput struct {
+	ProductID   uuid.UUID
+	Allocations []ChannelAllocationInput
+}
+
+// SetChannelAllocationsUseCase replaces the channel allocations of a product
+type SetChannelAllocationsUseCase struct {
+	inventoryRepo repository.InventoryRepository
+	channels      repository.ChannelAllocationRepository
+}
+
+// NewSetChannelAllocationsUseCase creates a new instance
+func NewSetChannelAllocationsUseCase(
+	inventoryRepo repository.InventoryRepository,
+	channels repository.ChannelAllocationRepository,
+) *SetChannelAllocationsUseCase {
+	if inventoryRepo == nil {
+		panic("inventoryRepo cannot be nil")
+	}
+	if channels == nil {
+		panic("channels cannot be nil")
+	}
+
+	return &SetChannelAllocationsUseCase{
+		inventoryRepo: inventoryRepo,
+		channels:      channels,
+	}
+}
+
+// Execute validates the allocations and stores them. Pending reservations keep
+// their units; a channel left holding more than its new allocation cannot reserve
+// until it is back under it.
+func (uc *SetChannelAllocationsUseCase) Execute(ctx context.Context, input SetChannelAllocationsInput) (*ChannelAllocationsOutput, error) {
+	item, err := uc.inventoryRepo.FindByProductID(ctx, input.ProductID)
+	if err != nil {
+		return nil, errors.ErrInventoryItemNotFound.WithDetails(err.Error())
+	}
+
+	allocations := make([]entity.ChannelAllocation, 0, len(input.Allocations))
+	for _, allocationInput := range input.Allocations {
+		allocation, err := newChannelAllocation(item, allocationInput)
+		if err != nil {
+			return nil, err
+		}
+		allocations = append(allocations, allocation)
+	}
+	if err := entity.ValidateChannelAllocations(allocations); err != nil {
+		return nil, err
+	}
+
+	item, err = uc.channels.Replace(ctx, item.ID, allocations)
+	if err != nil {
+		return nil, err
+	}
+
+	return channelAllocations(ctx, uc.channels, item)
+}
+
+// newChannelAllocation builds an allocation from its input, rejecting a quota
+// or percent given for another mode
+func newChannelAllocation(item *entity.InventoryItem, input ChannelAllocationInput) (entity.ChannelAllocation, error) {
+	mode, err := entity.ParseAllocationMode(input.Mode)
+	if err != nil {
+		return entity.ChannelAllocation{}, err
+	}
+
+	var value int
+	switch mode {
+	case entity.AllocationFixed:
+		value = input.Quota
+		if input.Percent != 0 {
+			return entity.ChannelAllocation{}, errors.ErrInvalidAllocation.WithDetails("fixed allocations take a quota, not a percent")
+		}
+	case entity.AllocationPercentage:
+		value = input.Percent
+		if input.Quota != 0 {
+			return entity.ChannelAllocation{}, errors.ErrInvalidAllocation.WithDetails("percentage allocations take a percent, not a quota")
+		}
+	default:
+		value = input.Quota + input.Percent
+	}
+
+	return entity.NewChannelAllocation(item, input.Channel, mode, value)
+}
+
+// RebalanceChannelAllocationsInput represents units of quota, or percentage
+// points, moved from one channel to another; an empty channel is the shared pool
+type RebalanceChannelAllocationsInput struct {
+	ProductID uuid.UUID
+	From      string
+	To        string
+	Quantity  int
+}
+
+// RebalanceChannelAllocationsUseCase moves allocated stock between the channels of a product
+type RebalanceChannelAllocationsUseCase struct {
+	inventoryRepo repository.InventoryRepository
+	channels      repository.ChannelAllocationRepository
+}
+
+// NewRebalanceChannelAllocationsUseCase creates a new instance
+func NewRebalanceChannelAllocationsUseCase(
+	inventoryRepo repository.InventoryRepository,
+	channels repository.ChannelAllocationRepository,
+) *RebalanceChannelAllocationsUseCase {
+	if inventoryRepo == nil {
+		panic("inventoryRepo cannot be nil")
+	}
+	if channels == nil {
+		panic("channels cannot be nil")
+	}
+
+	return &RebalanceChannelAllocationsUseCase{
+		inventoryRepo: inventoryRepo,
+		channels:      channels,
+	}
+}
+
+// Execute moves the allocation and returns the stock of every channel afterwards
+func (uc *RebalanceChannelAllocationsUseCase) Execute(
+	ctx context.Context,
+	input RebalanceChannelAllocationsInput,
+) (*ChannelAllocationsOutput, error) {
+	for _, channel := range []string{input.From, input.To} {
+		if err := validateOptionalChannel(channel); err != nil {
+			return nil, err
+		}
+	}
+
+	item, err := uc.inventoryRepo.FindByProductID(ctx, input.ProductID)
+	if err != nil {
+		return nil, errors.ErrInventoryItemNotFound.WithDetails(err.Error())
+	}
+
+	if _, err := uc.channels.Rebalance(ctx, item.ID, input.From, input.To, input.Quantity); err != nil {
+		return nil, err
+	}
+
+	return channelAllocations(ctx, uc.channels, item)
+}
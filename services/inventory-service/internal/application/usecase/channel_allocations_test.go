@@ -0,0 +1,339 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	domainErrors "github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/events"
+)
+
+// MockChannelAllocationRepository is a mock implementation of ChannelAllocationRepository
+type MockChannelAllocationRepository struct {
+	mock.Mock
+}
+
+func (m *MockChannelAllocationRepository) FindByInventoryItemID(ctx context.Context, inventoryItemID uuid.UUID) ([]entity.ChannelAllocation, error) {
+	args := m.Called(ctx, inventoryItemID)
+	allocations, _ := args.Get(0).([]entity.ChannelAllocation)
+	return allocations, args.Error(1)
+}
+
+func (m *MockChannelAllocationRepository) Replace(
+	ctx context.Context,
+	inventoryItemID uuid.UUID,
+	allocations []entity.ChannelAllocation,
+) (*entity.InventoryItem, error) {
+	args := m.Called(ctx, inventoryItemID, allocations)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.InventoryItem), args.Error(1)
+}
+
+func (m *MockChannelAllocationRepository) Rebalance(
+	ctx context.Context,
+	inventoryItemID uuid.UUID,
+	from, to string,
+	quantity int,
+) ([]entity.ChannelAllocation, error) {
+	args := m.Called(ctx, inventoryItemID, from, to, quantity)
+	allocations, _ := args.Get(0).([]entity.ChannelAllocation)
+	return allocations, args.Error(1)
+}
+
+func (m *MockChannelAllocationRepository) ReservedByChannel(ctx context.Context, inventoryItemID uuid.UUID) (map[string]int, error) {
+	args := m.Called(ctx, inventoryItemID)
+	reserved, _ := args.Get(0).(map[string]int)
+	return reserved, args.Error(1)
+}
+
+func (m *MockChannelAllocationRepository) Reserve(ctx context.Context, reservation *entity.Reservation, productID uuid.UUID) (*entity.InventoryItem, error) {
+	args := m.Called(ctx, reservation, productID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.InventoryItem), args.Error(1)
+}
+
+// allocatedItem returns an item of 100 units with 30 reserved, allocated to
+// marketplace (20 units), b2b (10%) and web (shared)
+func allocatedItem(t *testing.T) (*entity.InventoryItem, []entity.ChannelAllocation) {
+	t.Helper()
+	item, err := entity.NewInventoryItem(uuid.New(), 100)
+	require.NoError(t, err)
+	require.NoError(t, item.Reserve(30))
+	item.ChannelAllocated = true
+
+	return item, []entity.ChannelAllocation{
+		{InventoryItemID: item.ID, Channel: "b2b", Mode: entity.AllocationPercentage, Percent: 10},
+		{InventoryItemID: item.ID, Channel: "marketplace", Mode: entity.AllocationFixed, Quota: 20},
+		{InventoryItemID: item.ID, Channel: "web", Mode: entity.AllocationShared},
+	}
+}
+
+// reservedPerChannel leaves marketplace 5 units and the shared pool 65
+var reservedPerChannel = map[string]int{"marketplace": 15, "b2b": 10, "web": 5}
+
+func TestNewChannelAllocationUseCases_NilDependencies_Panic(t *testing.T) {
+	assert.Panics(t, func() { NewGetChannelAllocationsUseCase(nil, new(MockChannelAllocationRepository)) })
+	assert.Panics(t, func() { NewGetChannelAllocationsUseCase(new(MockInventoryRepository), nil) })
+	assert.Panics(t, func() { NewSetChannelAllocationsUseCase(nil, new(MockChannelAllocationRepository)) })
+	assert.Panics(t, func() { NewSetChannelAllocationsUseCase(new(MockInventoryRepository), nil) })
+	assert.Panics(t, func() { NewRebalanceChannelAllocationsUseCase(nil, new(MockChannelAllocationRepository)) })
+	assert.Panics(t, func() { NewRebalanceChannelAllocationsUseCase(new(MockInventoryRepository), nil) })
+}
+
+func TestGetChannelAllocationsUseCase_Execute(t *testing.T) {
+	item, allocations := allocatedItem(t)
+	inventoryRepo := new(MockInventoryRepository)
+	channelRepo := new(MockChannelAllocationRepository)
+	inventoryRepo.On("FindByProductID", mock.Anything, item.ProductID).Return(item, nil)
+	channelRepo.On("FindByInventoryItemID", mock.Anything, item.ID).Return(allocations, nil)
+	channelRepo.On("ReservedByChannel", mock.Anything, item.ID).Return(reservedPerChannel, nil)
+
+	output, err := NewGetChannelAllocationsUseCase(inventoryRepo, channelRepo).Execute(context.Background(), item.ProductID)
+
+	require.NoError(t, err)
+	assert.Equal(t, allocations, output.Allocations)
+	require.Len(t, output.Stocks, 3)
+	assert.Equal(t, 5, output.Stocks[1].Available)
+	assert.Equal(t, 65, output.Pool.Available)
+
+	inventoryRepo.On("FindByProductID", mock.Anything, mock.Anything).Return(nil, errors.New("record not found"))
+	_, err = NewGetChannelAllocationsUseCase(inventoryRepo, channelRepo).Execute(context.Background(), uuid.New())
+	assert.ErrorIs(t, err, domainErrors.ErrInventoryItemNotFound)
+}
+
+func TestSetChannelAllocationsUseCase_Execute(t *testing.T) {
+	item, err := entity.NewInventoryItem(uuid.New(), 100)
+	require.NoError(t, err)
+
+	t.Run("should replace the allocations", func(t *testing.T) {
+		inventoryRepo := new(MockInventoryRepository)
+		channelRepo := new(MockChannelAllocationRepository)
+		allocated := *item
+		allocated.ChannelAllocated = true
+		inventoryRepo.On("FindByProductID", mock.Anything, item.ProductID).Return(item, nil)
+		channelRepo.On("Replace", mock.Anything, item.ID, mock.MatchedBy(func(allocations []entity.ChannelAllocation) bool {
+			return len(allocations) == 2 && allocations[0].Quota == 20 && allocations[1].Percent == 25
+		})).Return(&allocated, nil)
+		channelRepo.On("FindByInventoryItemID", mock.Anything, item.ID).Return([]entity.ChannelAllocation(nil), nil)
+		channelRepo.On("ReservedByChannel", mock.Anything, item.ID).Return(map[string]int{}, nil)
+
+		output, err := NewSetChannelAllocationsUseCase(inventoryRepo, channelRepo).Execute(context.Background(), SetChannelAllocationsInput{
+			ProductID: item.ProductID,
+			Allocations: []ChannelAllocationInput{
+				{Channel: "marketplace", Mode: "fixed", Quota: 20},
+				{Channel: "b2b", Mode: "percentage", Percent: 25},
+			},
+		})
+
+		require.NoError(t, err)
+		assert.True(t, output.Item.ChannelAllocated)
+		channelRepo.AssertExpectations(t)
+	})
+
+	t.Run("should reject invalid allocations", func(t *testing.T) {
+		tests := []struct {
+			name       string
+			allocation ChannelAllocationInput
+			wantErr    error
+		}{
+			{"unknown mode", ChannelAllocationInput{Channel: "web", Mode: "quota", Quota: 1}, domainErrors.ErrInvalidAllocation},
+			{"percent of fixed", ChannelAllocationInput{Channel: "web", Mode: "fixed", Quota: 1, Percent: 1}, domainErrors.ErrInvalidAllocation},
+			{"quota of percentage", ChannelAllocationInput{Channel: "web", Mode: "percentage", Quota: 1, Percent: 1}, domainErrors.ErrInvalidAllocation},
+			{"value of shared", ChannelAllocationInput{Channel: "web", Mode: "shared", Quota: 1}, domainErrors.ErrInvalidAllocation},
+			{"invalid channel", ChannelAllocationInput{Channel: "Web", Mode: "shared"}, domainErrors.ErrInvalidChannel},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				inventoryRepo := new(MockInventoryRepository)
+				channelRepo := new(MockChannelAllocationRepository)
+				inventoryRepo.On("FindByProductID", mock.Anything, item.ProductID).Return(item, nil)
+
+				_, err := NewSetChannelAllocationsUseCase(inventoryRepo, channelRepo).Execute(context.Background(), SetChannelAllocationsInput{
+					ProductID:   item.ProductID,
+					Allocations: []ChannelAllocationInput{tt.allocation},
+				})
+
+				assert.ErrorIs(t, err, tt.wantErr)
+				channelRepo.AssertNotCalled(t, "Replace", mock.Anything, mock.Anything, mock.Anything)
+			})
+		}
+	})
+}
+
+func TestRebalanceChannelAllocationsUseCase_Execute(t *testing.T) {
+	item, allocations := allocatedItem(t)
+
+	t.Run("should move the allocation and report the stock afterwards", func(t *testing.T) {
+		inventoryRepo := new(MockInventoryRepository)
+		channelRepo := new(MockChannelAllocationRepository)
+		inventoryRepo.On("FindByProductID", mock.Anything, item.ProductID).Return(item, nil)
+		channelRepo.On("Rebalance", mock.Anything, item.ID, "marketplace", entity.SharedPool, 5).Return(allocations, nil)
+		channelRepo.On("FindByInventoryItemID", mock.Anything, item.ID).Return(allocations, nil)
+		channelRepo.On("ReservedByChannel", mock.Anything, item.ID).Return(reservedPerChannel, nil)
+
+		output, err := NewRebalanceChannelAllocationsUseCase(inventoryRepo, channelRepo).Execute(context.Background(), RebalanceChannelAllocationsInput{
+			ProductID: item.ProductID,
+			From:      "marketplace",
+			Quantity:  5,
+		})
+
+		require.NoError(t, err)
+		assert.Len(t, output.Stocks, 3)
+		channelRepo.AssertExpectations(t)
+	})
+
+	t.Run("should reject invalid channels", func(t *testing.T) {
+		channelRepo := new(MockChannelAllocationRepository)
+
+		_, err := NewRebalanceChannelAllocationsUseCase(new(MockInventoryRepository), channelRepo).Execute(context.Background(), RebalanceChannelAllocationsInput{
+			ProductID: item.ProductID,
+			From:      "market place",
+			To:        "web",
+			Quantity:  5,
+		})
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidChannel)
+		channelRepo.AssertNotCalled(t, "Rebalance", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestCheckAvailabilityUseCase_Execute_WithChannels(t *testing.T) {
+	item, allocations := allocatedItem(t)
+	inventoryRepo := new(MockInventoryRepository)
+	channelRepo := new(MockChannelAllocationRepository)
+	inventoryRepo.On("FindByProductID", mock.Anything, item.ProductID).Return(item, nil)
+	channelRepo.On("FindByInventoryItemID", mock.Anything, item.ID).Return(allocations, nil)
+	channelRepo.On("ReservedByChannel", mock.Anything, item.ID).Return(reservedPerChannel, nil)
+	uc := NewCheckAvailabilityUseCase(inventoryRepo).WithChannels(channelRepo)
+
+	output, err := uc.Execute(context.Background(), CheckAvailabilityInput{ProductID: item.ProductID, Quantity: 6, Channel: "marketplace"})
+	require.NoError(t, err)
+	assert.False(t, output.IsAvailable)
+	assert.Equal(t, 5, output.AvailableQuantity)
+	assert.Equal(t, "marketplace", output.Channel)
+	assert.Equal(t, 100, output.TotalStock)
+
+	output, err = uc.Execute(context.Background(), CheckAvailabilityInput{ProductID: item.ProductID, Quantity: 6})
+	require.NoError(t, err)
+	assert.True(t, output.IsAvailable)
+	assert.Equal(t, 65, output.AvailableQuantity, "no channel checks the shared pool")
+
+	_, err = uc.Execute(context.Background(), CheckAvailabilityInput{ProductID: item.ProductID, Quantity: 1, Channel: "web store"})
+	assert.ErrorIs(t, err, domainErrors.ErrInvalidChannel)
+}
+
+func TestReserveStockUseCase_Execute_WithChannels(t *testing.T) {
+	item, _ := allocatedItem(t)
+
+	t.Run("should reserve from the channel's allocation", func(t *testing.T) {
+		inventoryRepo := new(MockInventoryRepository)
+		reservationRepo := new(MockReservationRepository)
+		publisher := new(MockPublisher)
+		channelRepo := new(MockChannelAllocationRepository)
+		stored := *item
+		stored.Reserved = 35
+
+		reservationRepo.On("ExistsByOrderID", mock.Anything, mock.Anything).Return(false, nil)
+		inventoryRepo.On("FindByProductID", mock.Anything, item.ProductID).Return(item, nil)
+		channelRepo.On("Reserve", mock.Anything, mock.MatchedBy(func(r *entity.Reservation) bool {
+			return r.Channel == "marketplace" && r.Quantity == 5
+		}), item.ProductID).Return(&stored, nil)
+		publisher.On("PublishStockReserved", mock.Anything, mock.MatchedBy(func(event events.StockReservedEvent) bool {
+			return event.Payload.Channel == "marketplace"
+		})).Return(nil)
+
+		uc := NewReserveStockUseCase(inventoryRepo, reservationRepo, publisher).WithChannels(channelRepo)
+		output, err := uc.Execute(context.Background(), ReserveStockInput{
+			ProductID: item.ProductID,
+			OrderID:   uuid.New(),
+			Quantity:  5,
+			Channel:   "marketplace",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "marketplace", output.Channel)
+		assert.Equal(t, 65, output.RemainingStock)
+		reservationRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+		inventoryRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		publisher.AssertExpectations(t)
+	})
+
+	t.Run("should fail without channels", func(t *testing.T) {
+		inventoryRepo := new(MockInventoryRepository)
+		reservationRepo := new(MockReservationRepository)
+		reservationRepo.On("ExistsByOrderID", mock.Anything, mock.Anything).Return(false, nil)
+		inventoryRepo.On("FindByProductID", mock.Anything, item.ProductID).Return(item, nil)
+
+		_, err := NewReserveStockUseCase(inventoryRepo, reservationRepo, new(MockPublisher)).
+			Execute(context.Background(), ReserveStockInput{ProductID: item.ProductID, OrderID: uuid.New(), Quantity: 1, Channel: "web"})
+
+		assert.ErrorIs(t, err, domainErrors.ErrChannelAllocatedItem)
+		reservationRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should store the channel of items without allocations", func(t *testing.T) {
+		plain, err := entity.NewInventoryItem(uuid.New(), 10)
+		require.NoError(t, err)
+		inventoryRepo := new(MockInventoryRepository)
+		reservationRepo := new(MockReservationRepository)
+		publisher := new(MockPublisher)
+		reservationRepo.On("ExistsByOrderID", mock.Anything, mock.Anything).Return(false, nil)
+		inventoryRepo.On("FindByProductID", mock.Anything, plain.ProductID).Return(plain, nil)
+		inventoryRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+		reservationRepo.On("Save", mock.Anything, mock.MatchedBy(func(r *entity.Reservation) bool {
+			return r.Channel == "b2b"
+		})).Return(nil)
+		publisher.On("PublishStockReserved", mock.Anything, mock.Anything).Return(nil)
+
+		_, err = NewReserveStockUseCase(inventoryRepo, reservationRepo, publisher).WithChannels(new(MockChannelAllocationRepository)).
+			Execute(context.Background(), ReserveStockInput{ProductID: plain.ProductID, OrderID: uuid.New(), Quantity: 1, Channel: "b2b"})
+
+		require.NoError(t, err)
+		reservationRepo.AssertExpectations(t)
+	})
+
+	t.Run("should reject invalid channels", func(t *testing.T) {
+		reservationRepo := new(MockReservationRepository)
+
+		_, err := NewReserveStockUseCase(new(MockInventoryRepository), reservationRepo, new(MockPublisher)).
+			Execute(context.Background(), ReserveStockInput{ProductID: item.ProductID, OrderID: uuid.New(), Quantity: 1, Channel: "Web"})
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidChannel)
+		reservationRepo.AssertNotCalled(t, "ExistsByOrderID", mock.Anything, mock.Anything)
+	})
+}
+
+func TestConfirmReservationUseCase_Execute_PublishesChannel(t *testing.T) {
+	item, _ := allocatedItem(t)
+	inventoryRepo := new(MockInventoryRepository)
+	reservationRepo := new(MockReservationRepository)
+	publisher := new(MockPublisher)
+	reservation, err := entity.NewReservation(item.ID, uuid.New(), 10)
+	require.NoError(t, err)
+	reservation.Channel = "marketplace"
+
+	reservationRepo.On("FindByID", mock.Anything, reservation.ID).Return(reservation, nil)
+	inventoryRepo.On("FindByID", mock.Anything, item.ID).Return(item, nil)
+	inventoryRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+	reservationRepo.On("Update", mock.Anything, reservation).Return(nil)
+	publisher.On("PublishStockConfirmed", mock.Anything, mock.MatchedBy(func(event events.StockConfirmedEvent) bool {
+		return event.Payload.Channel == "marketplace"
+	})).Return(nil)
+
+	_, err = NewConfirmReservationUseCase(inventoryRepo, reservationRepo, publisher).
+		Execute(context.Background(), ConfirmReservationInput{ReservationID: reservation.ID})
+
+	require.NoError(t, err)
+	publisher.AssertExpectations(t)
+}
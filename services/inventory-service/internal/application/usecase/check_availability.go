@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
 	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
 	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/repository"
 	"github.com/google/uuid"
@@ -12,6 +13,7 @@ import (
 type CheckAvailabilityInput struct {
 	ProductID uuid.UUID
 	Quantity  int
+	Channel   string // Optional sales channel; empty checks the shared pool
 }
 
 // CheckAvailabilityOutput represents the result of availability check
@@ -22,11 +24,19 @@ type CheckAvailabilityOutput struct {
 	AvailableQuantity int
 	TotalStock        int
 	ReservedQuantity  int
+
+	// Components report the stock of every component, for bundles; nil without WithBundles
+	Components []ComponentAvailability
+
+	// Channel is the sales channel AvailableQuantity was computed for
+	Channel string
 }
 
 // CheckAvailabilityUseCase handles checking if sufficient stock is available for a product
 type CheckAvailabilityUseCase struct {
 	inventoryRepo repository.InventoryRepository
+	bundles       repository.BundleRepository
+	channels      repository.ChannelAllocationRepository
 }
 
 // NewCheckAvailabilityUseCase creates a new instance of CheckAvailabilityUseCase
@@ -36,13 +46,33 @@ func NewCheckAvailabilityUseCase(inventoryRepo repository.InventoryRepository) *
 	}
 }
 
+// WithBundles makes the use case derive the availability of bundles from their
+// components; without it a bundle reports no stock
+func (uc *CheckAvailabilityUseCase) WithBundles(bundles repository.BundleRepository) *CheckAvailabilityUseCase {
+	uc.bundles = bundles
+	return uc
+}
+
+// WithChannels makes the use case report the stock the input's channel can
+// reserve of products allocated to sales channels; without it they report their
+// whole stock
+func (uc *CheckAvailabilityUseCase) WithChannels(channels repository.ChannelAllocationRepository) *CheckAvailabilityUseCase {
+	uc.channels = channels
+	return uc
+}
+
 // Execute checks if the requested quantity is available for the given product
-// It considers both total stock and reserved quantities
+// It considers both total stock and reserved quantities. The stock of a bundle
+// is the minimum number of bundles its components make up, and the stock of a
+// product allocated to sales channels what the input's channel can reserve.
 func (uc *CheckAvailabilityUseCase) Execute(ctx context.Context, input CheckAvailabilityInput) (*CheckAvailabilityOutput, error) {
 	// Validate input
 	if input.Quantity <= 0 {
 		return nil, errors.ErrInvalidQuantity
 	}
+	if err := validateOptionalChannel(input.Channel); err != nil {
+		return nil, err
+	}
 
 	// Find inventory item by product ID
 	item, err := uc.inventoryRepo.FindByProductID(ctx, input.ProductID)
@@ -50,6 +80,49 @@ func (uc *CheckAvailabilityUseCase) Execute(ctx context.Context, input CheckAvai
 		return nil, errors.ErrInventoryItemNotFound.WithDetails(err.Error())
 	}
 
+	if item.Bundle && uc.bundles != nil {
+		bundle, err := uc.bundles.FindByInventoryItemID(ctx, item.ID)
+		if err != nil {
+			return nil, err
+		}
+		availability, err := bundleAvailability(ctx, uc.inventoryRepo, item, bundle)
+		if err != nil {
+			return nil, err
+		}
+
+		return &CheckAvailabilityOutput{
+			ProductID:         input.ProductID,
+			IsAvailable:       availability.AvailableQuantity >= input.Quantity,
+			RequestedQuantity: input.Quantity,
+			AvailableQuantity: availability.AvailableQuantity,
+			TotalStock:        availability.TotalStock,
+			ReservedQuantity:  availability.TotalStock - availability.AvailableQuantity,
+			Components:        availability.Components,
+		}, nil
+	}
+
+	if item.ChannelAllocated && uc.channels != nil {
+		allocations, err := uc.channels.FindByInventoryItemID(ctx, item.ID)
+		if err != nil {
+			return nil, err
+		}
+		reserved, err := uc.channels.ReservedByChannel(ctx, item.ID)
+		if err != nil {
+			return nil, err
+		}
+		available := entity.ChannelAvailable(item, allocations, reserved, input.Channel)
+
+		return &CheckAvailabilityOutput{
+			ProductID:         input.ProductID,
+			IsAvailable:       available >= input.Quantity,
+			RequestedQuantity: input.Quantity,
+			AvailableQuantity: available,
+			TotalStock:        item.Quantity,
+			ReservedQuantity:  item.Reserved,
+			Channel:           input.Channel,
+		}, nil
+	}
+
 	// Calculate available quantity (total - reserved)
 	availableQty := item.Available()
 
@@ -63,5 +136,6 @@ func (uc *CheckAvailabilityUseCase) Execute(ctx context.Context, input CheckAvai
 		AvailableQuantity: availableQty,
 		TotalStock:        item.Quantity,
 		ReservedQuantity:  item.Reserved,
+		Channel:           input.Channel,
 	}, nil
 }
@@ -5,15 +5,18 @@ import (
 	"log"
 	"time"
 
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
 	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
 	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/events"
 	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/repository"
 	"github.com/google/uuid"
 )
 
-// ConfirmReservationInput represents the input for confirming a reservation
+// ConfirmReservationInput represents the input for confirming a reservation.
+// The reservation is looked up by ReservationID, or by OrderID when ReservationID is not set.
 type ConfirmReservationInput struct {
 	ReservationID uuid.UUID
+	OrderID       uuid.UUID
 }
 
 // ConfirmReservationOutput represents the result of confirming a reservation
@@ -24,6 +27,21 @@ type ConfirmReservationOutput struct {
 	QuantityConfirmed int
 	FinalStock        int
 	ReservedStock     int
+
+	// Lots are the lot allocations consumed by the confirmation; nil without WithLots
+	Lots []*entity.LotAllocation
+
+	// Serials are the serial numbers of the units sold, for serial-tracked products
+	Serials []string
+
+	// Components are the units of every component sold, for bundles
+	Components []*entity.ReservationComponent
+	// ComponentItems are the component items as stored after the change, in Components order
+	ComponentItems []*entity.InventoryItem
+
+	// Reservation and Item are the stored state after the change
+	Reservation *entity.Reservation
+	Item        *entity.InventoryItem
 }
 
 // ConfirmReservationUseCase handles confirming reservations and decrementing actual stock
@@ -35,6 +53,11 @@ type ConfirmReservationUseCase struct {
 	inventoryRepo   repository.InventoryRepository
 	reservationRepo repository.ReservationRepository
 	publisher       events.Publisher
+	retry           RetryPolicy
+	atomicStock     repository.AtomicStockRepository
+	lots            repository.LotRepository
+	serials         repository.SerialUnitRepository
+	bundles         repository.BundleRepository
 }
 
 // NewConfirmReservationUseCase creates a new instance of ConfirmReservationUseCase
@@ -47,24 +70,64 @@ func NewConfirmReservationUseCase(
 		inventoryRepo:   inventoryRepo,
 		reservationRepo: reservationRepo,
 		publisher:       publisher,
+		retry:           DefaultRetryPolicy(),
 	}
 }
 
+// WithRetryPolicy replaces the policy used to retry optimistic-lock conflicts
+func (uc *ConfirmReservationUseCase) WithRetryPolicy(policy RetryPolicy) *ConfirmReservationUseCase {
+	uc.retry = policy
+	return uc
+}
+
+// WithAtomicStock makes the use case confirm reservations with a single conditional
+// statement instead of the optimistic-lock read-modify-write cycle
+func (uc *ConfirmReservationUseCase) WithAtomicStock(atomicStock repository.AtomicStockRepository) *ConfirmReservationUseCase {
+	uc.atomicStock = atomicStock
+	return uc
+}
+
+// WithLots makes the use case record the lots consumed by every confirmed reservation
+func (uc *ConfirmReservationUseCase) WithLots(lots repository.LotRepository) *ConfirmReservationUseCase {
+	uc.lots = lots
+	return uc
+}
+
+// WithSerials makes the use case sell the units reserved for serial-tracked
+// products, which otherwise fail with ErrSerialTrackedItem
+func (uc *ConfirmReservationUseCase) WithSerials(serials repository.SerialUnitRepository) *ConfirmReservationUseCase {
+	uc.serials = serials
+	return uc
+}
+
+// WithBundles makes the use case sell the components reserved for bundles, which
+// otherwise fail with ErrBundleItem
+func (uc *ConfirmReservationUseCase) WithBundles(bundles repository.BundleRepository) *ConfirmReservationUseCase {
+	uc.bundles = bundles
+	return uc
+}
+
 // Execute confirms a reservation and decrements stock
 // This operation should be atomic (wrapped in a transaction in the infrastructure layer)
 // Steps:
-// 1. Find reservation by ID
+// 1. Find reservation by ID (or by order ID)
 // 2. Validate reservation can be confirmed (pending, not expired)
-// 3. Find inventory item
-// 4. Confirm reservation on inventory (decrements Reserved and Quantity)
-// 5. Update reservation status to confirmed
+// 3. Mark reservation as confirmed (in memory)
+// 4. Find inventory item
+// 5. Confirm reservation on inventory (decrements Reserved and Quantity)
 // 6. Update inventory with optimistic locking
 // 7. Update reservation
+// 8. Consume the lots allocated to the reservation when configured with WithLots
+//
+// Steps 4-6 are retried according to the RetryPolicy when another writer
+// bumps the Version first; a *ContentionError is returned once attempts run out.
+// For serial-tracked products, steps 4-6 sell the reserved units through
+// WithSerials instead, and for bundles the reserved components through WithBundles.
 func (uc *ConfirmReservationUseCase) Execute(ctx context.Context, input ConfirmReservationInput) (*ConfirmReservationOutput, error) {
 	// Find reservation
-	reservation, err := uc.reservationRepo.FindByID(ctx, input.ReservationID)
+	reservation, err := findReservation(ctx, uc.reservationRepo, input.ReservationID, input.OrderID)
 	if err != nil {
-		return nil, errors.ErrReservationNotFound.WithDetails(err.Error())
+		return nil, err
 	}
 
 	// Validate reservation can be confirmed
@@ -75,29 +138,53 @@ func (uc *ConfirmReservationUseCase) Execute(ctx context.Context, input ConfirmR
 		return nil, errors.ErrReservationNotPending
 	}
 
-	// Find inventory item
-	item, err := uc.inventoryRepo.FindByID(ctx, reservation.InventoryItemID)
-	if err != nil {
-		return nil, errors.ErrInventoryItemNotFound.WithDetails(err.Error())
-	}
-
-	// Confirm reservation on inventory entity
-	// This decrements both Reserved and Quantity
-	if err := item.ConfirmReservation(reservation.Quantity); err != nil {
-		return nil, err
-	}
-
-	// Mark reservation as confirmed
+	// Mark reservation as confirmed in memory; it is only persisted once the
+	// inventory update below succeeds
 	if err := reservation.Confirm(); err != nil {
-		// Rollback in-memory changes
-		item.Reserve(reservation.Quantity)
 		return nil, err
 	}
 
-	// Update inventory with optimistic locking
-	if err := uc.inventoryRepo.Update(ctx, item); err != nil {
+	// Apply the change with a conditional statement when configured; otherwise
+	// read, apply and write the inventory item, re-reading it after every
+	// optimistic-lock conflict so the change applies to the latest version
+	var item *entity.InventoryItem
+	err = uc.retry.run(ctx, OperationConfirm, func() (uuid.UUID, error) {
+		if uc.atomicStock != nil {
+			stored, err := uc.atomicStock.ConfirmStock(ctx, reservation.InventoryItemID, reservation.Quantity)
+			if err != nil {
+				return uuid.Nil, err
+			}
+			item = stored
+			return item.ProductID, nil
+		}
+
+		var findErr error
+		item, findErr = uc.inventoryRepo.FindByID(ctx, reservation.InventoryItemID)
+		if findErr != nil {
+			return uuid.Nil, errors.ErrInventoryItemNotFound.WithDetails(findErr.Error())
+		}
+
+		if err := item.ConfirmReservation(reservation.Quantity); err != nil {
+			return item.ProductID, err
+		}
+
+		// Update inventory with optimistic locking
+		return item.ProductID, uc.inventoryRepo.Update(ctx, item)
+	})
+	var units []*entity.SerialUnit
+	if usesSerials(err, uc.serials) {
+		item, units, err = uc.serials.Confirm(ctx, reservation.ID, reservation.InventoryItemID, reservation.Quantity)
+	}
+	var change *repository.BundleStockChange
+	if usesBundles(err, uc.bundles) {
+		change, err = uc.bundles.Confirm(ctx, reservation.ID, reservation.InventoryItemID)
+	}
+	if err != nil {
 		return nil, err
 	}
+	if change != nil {
+		item = change.Bundle
+	}
 
 	// Update reservation status
 	if err := uc.reservationRepo.Update(ctx, reservation); err != nil {
@@ -107,13 +194,23 @@ func (uc *ConfirmReservationUseCase) Execute(ctx context.Context, input ConfirmR
 		return nil, err
 	}
 
+	// Record the lots the reservation consumed (don't fail the confirmation: the
+	// stock already left the inventory item, and reconcile settles the lots)
+	var consumed []*entity.LotAllocation
+	if uc.lots != nil {
+		consumed, err = uc.lots.Consume(ctx, reservation.ID)
+		if err != nil {
+			log.Printf("Failed to consume lots of reservation %s: %v", reservation.ID, err)
+		}
+	}
+
 	// Publish StockConfirmed event (don't fail transaction if event publication fails)
 	stockConfirmedEvent := events.StockConfirmedEvent{
 		BaseEvent: events.BaseEvent{
 			EventID:   uuid.New().String(),
 			EventType: events.RoutingKeyStockConfirmed,
 			Timestamp: time.Now().Format(time.RFC3339),
-			Version:   events.EventVersion,
+			Version:   events.StockConfirmedVersion,
 			Source:    events.SourceInventoryService,
 		},
 		Payload: events.StockConfirmedPayload{
@@ -121,8 +218,11 @@ func (uc *ConfirmReservationUseCase) Execute(ctx context.Context, input ConfirmR
 			ProductID:     item.ProductID.String(),
 			Quantity:      reservation.Quantity,
 			OrderID:       reservation.OrderID.String(),
-			UserID:        "", // TODO: Get from context when auth is implemented
+			UserID:        reservation.UserID,
 			ConfirmedAt:   time.Now(),
+			Serials:       entity.SerialNumbers(units),
+			Components:    componentQuantities(changedComponents(change)),
+			Channel:       reservation.Channel,
 		},
 	}
 
@@ -131,22 +231,26 @@ func (uc *ConfirmReservationUseCase) Execute(ctx context.Context, input ConfirmR
 		log.Printf("Failed to publish StockConfirmed event: %v", err)
 	}
 
-	// Publish StockDepleted event if available quantity reached zero
-	if item.Available() == 0 {
+	// Publish StockDepleted events for the items whose available quantity reached zero
+	for _, stock := range stockHeld(item, reservation.Quantity, change) {
+		if stock.item.Available() != 0 {
+			continue
+		}
+
 		stockDepletedEvent := events.StockDepletedEvent{
 			BaseEvent: events.BaseEvent{
 				EventID:   uuid.New().String(),
 				EventType: events.RoutingKeyStockDepleted,
 				Timestamp: time.Now().Format(time.RFC3339),
-				Version:   events.EventVersion,
+				Version:   events.StockDepletedVersion,
 				Source:    events.SourceInventoryService,
 			},
 			Payload: events.StockDepletedPayload{
-				ProductID:    item.ProductID.String(),
+				ProductID:    stock.item.ProductID.String(),
 				OrderID:      reservation.OrderID.String(),
-				UserID:       "", // TODO: Get from context when auth is implemented
+				UserID:       reservation.UserID,
 				DepletedAt:   time.Now(),
-				LastQuantity: reservation.Quantity,
+				LastQuantity: stock.quantity,
 			},
 		}
 
@@ -156,12 +260,24 @@ func (uc *ConfirmReservationUseCase) Execute(ctx context.Context, input ConfirmR
 		}
 	}
 
+	finalStock, reservedStock := item.Quantity, item.Reserved
+	if change != nil {
+		available, inStock := bundleStock(change, reservation.Quantity)
+		finalStock, reservedStock = inStock, inStock-available
+	}
+
 	return &ConfirmReservationOutput{
 		ReservationID:     reservation.ID,
 		InventoryItemID:   item.ID,
 		OrderID:           reservation.OrderID,
 		QuantityConfirmed: reservation.Quantity,
-		FinalStock:        item.Quantity,
-		ReservedStock:     item.Reserved,
+		FinalStock:        finalStock,
+		ReservedStock:     reservedStock,
+		Lots:              consumed,
+		Serials:           entity.SerialNumbers(units),
+		Components:        changedComponents(change),
+		ComponentItems:    changedComponentItems(change),
+		Reservation:       reservation,
+		Item:              item,
 	}, nil
 }
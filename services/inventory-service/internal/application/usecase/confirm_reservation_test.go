@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	goerrors "errors"
 	"testing"
 	"time"
 
@@ -347,12 +348,14 @@ func TestConfirmReservationUseCase_Execute_InventoryErrors(t *testing.T) {
 		mockReservationRepo.AssertExpectations(t)
 	})
 
-	t.Run("should return error when inventory update fails due to optimistic lock", func(t *testing.T) {
+	t.Run("should return error when inventory update keeps failing due to optimistic lock", func(t *testing.T) {
 		// Arrange
 		mockInventoryRepo := new(MockInventoryRepository)
 		mockReservationRepo := new(MockReservationRepository)
 		mockPublisher := new(MockPublisher)
-		uc := NewConfirmReservationUseCase(mockInventoryRepo, mockReservationRepo, mockPublisher)
+		observer := newRecordingObserver()
+		uc := NewConfirmReservationUseCase(mockInventoryRepo, mockReservationRepo, mockPublisher).
+			WithRetryPolicy(noBackoff(3, observer))
 
 		productID := uuid.New()
 		orderID := uuid.New()
@@ -363,8 +366,8 @@ func TestConfirmReservationUseCase_Execute_InventoryErrors(t *testing.T) {
 		reservation, _ := entity.NewReservation(item.ID, orderID, 50)
 
 		mockReservationRepo.On("FindByID", mock.Anything, reservation.ID).Return(reservation, nil)
-		mockInventoryRepo.On("FindByID", mock.Anything, item.ID).Return(item, nil)
-		mockInventoryRepo.On("Update", mock.Anything, mock.AnythingOfType("*entity.InventoryItem")).Return(errors.ErrOptimisticLockFailure)
+		expectFreshReads(mockInventoryRepo, "FindByID", item.ID, item, 3)
+		mockInventoryRepo.On("Update", mock.Anything, mock.AnythingOfType("*entity.InventoryItem")).Return(errors.ErrOptimisticLockFailure).Times(3)
 
 		input := ConfirmReservationInput{
 			ReservationID: reservation.ID,
@@ -376,7 +379,12 @@ func TestConfirmReservationUseCase_Execute_InventoryErrors(t *testing.T) {
 		// Assert
 		assert.Error(t, err)
 		assert.Nil(t, output)
-		assert.Equal(t, errors.ErrOptimisticLockFailure, err)
+		var contention *ContentionError
+		require.True(t, goerrors.As(err, &contention))
+		assert.Equal(t, productID, contention.ProductID)
+		assert.Equal(t, 3, contention.Attempts)
+		assert.Equal(t, 3, observer.conflicts[OperationConfirm])
+		assert.Equal(t, 1, observer.exhausted[OperationConfirm])
 
 		mockInventoryRepo.AssertExpectations(t)
 		mockReservationRepo.AssertExpectations(t)
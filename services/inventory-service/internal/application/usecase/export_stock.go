@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+// StockLevel is the stock of one inventory item as exported
+type StockLevel struct {
+	ProductID       uuid.UUID
+	SKU             string // empty; kept so exports can be edited and imported again
+	InventoryItemID uuid.UUID
+	Quantity        int
+	Reserved        int
+	Available       int
+	Version         int
+	UpdatedAt       time.Time
+}
+
+// StockLevelWriter receives the exported stock levels in product ID order
+type StockLevelWriter interface {
+	Write(level StockLevel) error
+}
+
+// ExportStockUseCase streams the current stock of every inventory item
+type ExportStockUseCase struct {
+	bulkRepo  repository.BulkStockRepository
+	batchSize int
+}
+
+// NewExportStockUseCase creates a new instance of ExportStockUseCase
+func NewExportStockUseCase(bulkRepo repository.BulkStockRepository) *ExportStockUseCase {
+	if bulkRepo == nil {
+		panic("bulkRepo cannot be nil")
+	}
+
+	return &ExportStockUseCase{
+		bulkRepo:  bulkRepo,
+		batchSize: 500,
+	}
+}
+
+// Execute writes the stock level of every item and returns how many were written
+func (uc *ExportStockUseCase) Execute(ctx context.Context, writer StockLevelWriter) (int, error) {
+	count := 0
+	err := uc.bulkRepo.StreamAll(ctx, uc.batchSize, func(item *entity.InventoryItem) error {
+		count++
+		return writer.Write(StockLevel{
+			ProductID:       item.ProductID,
+			InventoryItemID: item.ID,
+			Quantity:        item.Quantity,
+			Reserved:        item.Reserved,
+			Available:       item.Available(),
+			Version:         item.Version,
+			UpdatedAt:       item.UpdatedAt,
+		})
+	})
+	if err != nil {
+		return count, fmt.Errorf("failed to export stock: %w", err)
+	}
+
+	return count, nil
+}
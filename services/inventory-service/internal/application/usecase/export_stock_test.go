@@ -0,0 +1,59 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingStockWriter keeps the exported stock levels
+type recordingStockWriter struct {
+	levels []StockLevel
+	err    error
+}
+
+func (w *recordingStockWriter) Write(level StockLevel) error {
+	w.levels = append(w.levels, level)
+	return w.err
+}
+
+func TestExportStockUseCase_Execute(t *testing.T) {
+	bulkRepo := new(MockBulkStockRepository)
+	item := stockItem(uuid.New(), 10, 3)
+	item.Version = 4
+	item.UpdatedAt = time.Date(2025, 11, 10, 12, 0, 0, 0, time.UTC)
+	bulkRepo.On("StreamAll", mock.Anything, 500).Return([]*entity.InventoryItem{item}, nil)
+	writer := &recordingStockWriter{}
+
+	count, err := NewExportStockUseCase(bulkRepo).Execute(context.Background(), writer)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, []StockLevel{{
+		ProductID:       item.ProductID,
+		InventoryItemID: item.ID,
+		Quantity:        10,
+		Reserved:        3,
+		Available:       7,
+		Version:         4,
+		UpdatedAt:       item.UpdatedAt,
+	}}, writer.levels)
+}
+
+func TestExportStockUseCase_WriterError(t *testing.T) {
+	bulkRepo := new(MockBulkStockRepository)
+	bulkRepo.On("StreamAll", mock.Anything, mock.Anything).Return([]*entity.InventoryItem{stockItem(uuid.New(), 1, 0), stockItem(uuid.New(), 1, 0)}, nil)
+	writer := &recordingStockWriter{err: errors.New("broken pipe")}
+
+	count, err := NewExportStockUseCase(bulkRepo).Execute(context.Background(), writer)
+
+	assert.ErrorContains(t, err, "broken pipe")
+	assert.Equal(t, 1, count)
+}
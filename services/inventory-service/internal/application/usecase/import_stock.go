@@ -0,0 +1,343 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+// OperationStockImport is the operation name reported to the ContentionObserver
+const OperationStockImport = "stock_import"
+
+// DefaultStockImportChunkSize is the number of rows written per transaction
+const DefaultStockImportChunkSize = 100
+
+// StockImportStatus describes what an import did with a row
+type StockImportStatus string
+
+// Stock import statuses
+const (
+	StockImportChanged   StockImportStatus = "changed"   // the quantity was (or in a dry run would be) updated
+	StockImportUnchanged StockImportStatus = "unchanged" // the item already had the requested quantity
+	StockImportFailed    StockImportStatus = "failed"    // the row was rejected; see Error
+)
+
+// StockImportRow is one row of a stock count file. Exactly one of Quantity
+// (absolute count) and Delta (relative adjustment) must be set.
+type StockImportRow struct {
+	Line      int // line of the row in the source file, for the report
+	ProductID uuid.UUID
+	SKU       string // informational only; inventory items are keyed by product ID
+	Quantity  *int
+	Delta     *int
+	Invalid   string // set by the reader when the row could not be decoded
+}
+
+// ImportStockInput represents the input for a stock import
+type ImportStockInput struct {
+	Rows   []StockImportRow
+	DryRun bool // validate and preview the changes without writing them
+}
+
+// StockImportRowResult is the outcome of one row
+type StockImportRowResult struct {
+	Line            int               `json:"line"`
+	ProductID       uuid.UUID         `json:"product_id,omitzero"`
+	SKU             string            `json:"sku,omitempty"`
+	InventoryItemID uuid.UUID         `json:"inventory_item_id,omitzero"`
+	Status          StockImportStatus `json:"status"`
+	Before          int               `json:"before"`
+	After           int               `json:"after"`
+	Reserved        int               `json:"reserved"`
+	Error           string            `json:"error,omitempty"`
+}
+
+// ImportStockOutput is the per-row report of an import
+type ImportStockOutput struct {
+	DryRun    bool                   `json:"dry_run"`
+	Total     int                    `json:"total"`
+	Changed   int                    `json:"changed"`
+	Unchanged int                    `json:"unchanged"`
+	Failed    int                    `json:"failed"`
+	Rows      []StockImportRowResult `json:"rows"`
+}
+
+// ChangedItemIDs returns the inventory items whose quantity was changed
+func (o *ImportStockOutput) ChangedItemIDs() []string {
+	ids := make([]string, 0, o.Changed)
+	for _, row := range o.Rows {
+		if row.Status == StockImportChanged {
+			ids = append(ids, row.InventoryItemID.String())
+		}
+	}
+	return ids
+}
+
+// ImportStockUseCase applies stock counts from a file. Every row is validated
+// first; valid rows are then written in chunks, one transaction per chunk.
+// When a chunk loses an optimistic-lock race it is retried row by row on top
+// of the latest version, so one busy item does not fail its neighbours.
+type ImportStockUseCase struct {
+	inventoryRepo repository.InventoryRepository
+	bulkRepo      repository.BulkStockRepository
+	atomicStock   repository.AtomicStockRepository
+	chunkSize     int
+	retry         RetryPolicy
+	notifier      WaitlistNotifier
+}
+
+// NewImportStockUseCase creates a new instance of ImportStockUseCase
+func NewImportStockUseCase(inventoryRepo repository.InventoryRepository, bulkRepo repository.BulkStockRepository) *ImportStockUseCase {
+	if inventoryRepo == nil {
+		panic("inventoryRepo cannot be nil")
+	}
+	if bulkRepo == nil {
+		panic("bulkRepo cannot be nil")
+	}
+
+	return &ImportStockUseCase{
+		inventoryRepo: inventoryRepo,
+		bulkRepo:      bulkRepo,
+		chunkSize:     DefaultStockImportChunkSize,
+		retry:         DefaultRetryPolicy(),
+	}
+}
+
+// WithChunkSize sets the number of rows written per transaction
+func (uc *ImportStockUseCase) WithChunkSize(size int) *ImportStockUseCase {
+	if size > 0 {
+		uc.chunkSize = size
+	}
+	return uc
+}
+
+// WithRetryPolicy replaces the policy used to retry optimistic-lock conflicts
+func (uc *ImportStockUseCase) WithRetryPolicy(policy RetryPolicy) *ImportStockUseCase {
+	uc.retry = policy
+	return uc
+}
+
+// WithAtomicStock makes the use case apply delta rows with a single conditional
+// statement instead of the optimistic-lock read-modify-write cycle. Rows with an
+// absolute quantity still use the version check: they set a count taken at one
+// point in time and must not overwrite a change made after it.
+func (uc *ImportStockUseCase) WithAtomicStock(atomicStock repository.AtomicStockRepository) *ImportStockUseCase {
+	uc.atomicStock = atomicStock
+	return uc
+}
+
+// WithWaitlist makes the use case serve the waitlist of every product whose
+// quantity an import raised
+func (uc *ImportStockUseCase) WithWaitlist(notifier WaitlistNotifier) *ImportStockUseCase {
+	uc.notifier = notifier
+	return uc
+}
+
+// pendingRow is a valid row together with the item it updates
+type pendingRow struct {
+	row    *StockImportRow
+	result *StockImportRowResult
+	item   *entity.InventoryItem
+}
+
+// Execute validates every row and, unless DryRun is set, applies the valid ones
+func (uc *ImportStockUseCase) Execute(ctx context.Context, input ImportStockInput) (*ImportStockOutput, error) {
+	if len(input.Rows) == 0 {
+		return nil, errors.ErrInvalidInput.WithDetails("the file has no rows")
+	}
+
+	output := &ImportStockOutput{
+		DryRun: input.DryRun,
+		Total:  len(input.Rows),
+		Rows:   make([]StockImportRowResult, len(input.Rows)),
+	}
+
+	valid := uc.validate(input.Rows, output.Rows)
+
+	for start := 0; start < len(valid); start += uc.chunkSize {
+		chunk := valid[start:min(start+uc.chunkSize, len(valid))]
+		if err := uc.prepare(ctx, chunk); err != nil {
+			return nil, err
+		}
+		if !input.DryRun {
+			uc.apply(ctx, chunk)
+		}
+	}
+
+	for _, result := range output.Rows {
+		switch result.Status {
+		case StockImportChanged:
+			output.Changed++
+		case StockImportUnchanged:
+			output.Unchanged++
+		case StockImportFailed:
+			output.Failed++
+		}
+		if !input.DryRun && result.Status == StockImportChanged && result.After > result.Before {
+			notifyStockAvailable(uc.notifier, result.ProductID)
+		}
+	}
+
+	return output, nil
+}
+
+// validate checks the rows on their own and returns the ones worth loading
+func (uc *ImportStockUseCase) validate(rows []StockImportRow, results []StockImportRowResult) []*pendingRow {
+	firstLine := make(map[uuid.UUID]int, len(rows))
+	valid := make([]*pendingRow, 0, len(rows))
+
+	for i := range rows {
+		row, result := &rows[i], &results[i]
+		*result = StockImportRowResult{Line: row.Line, ProductID: row.ProductID, SKU: row.SKU}
+
+		var problem string
+		switch {
+		case row.Invalid != "":
+			problem = row.Invalid
+		case row.ProductID == uuid.Nil:
+			problem = "product_id is required"
+		case (row.Quantity == nil) == (row.Delta == nil):
+			problem = "exactly one of quantity and delta is required"
+		case row.Quantity != nil && *row.Quantity < 0:
+			problem = fmt.Sprintf("quantity must be >= 0, got %d", *row.Quantity)
+		}
+		if problem == "" {
+			if line, seen := firstLine[row.ProductID]; seen {
+				problem = fmt.Sprintf("duplicate product_id, first seen on line %d", line)
+			}
+		}
+
+		if problem != "" {
+			result.Status, result.Error = StockImportFailed, problem
+			continue
+		}
+		firstLine[row.ProductID] = row.Line
+		valid = append(valid, &pendingRow{row: row, result: result})
+	}
+
+	return valid
+}
+
+// prepare loads the items of a chunk and computes their new quantities
+func (uc *ImportStockUseCase) prepare(ctx context.Context, chunk []*pendingRow) error {
+	productIDs := make([]uuid.UUID, len(chunk))
+	for i, pending := range chunk {
+		productIDs[i] = pending.row.ProductID
+	}
+
+	items, err := uc.inventoryRepo.FindByProductIDs(ctx, productIDs)
+	if err != nil {
+		return fmt.Errorf("failed to load inventory items: %w", err)
+	}
+
+	for _, pending := range chunk {
+		item, ok := items[pending.row.ProductID]
+		if !ok {
+			pending.result.Status, pending.result.Error = StockImportFailed, errors.ErrInventoryItemNotFound.Error()
+			continue
+		}
+		pending.item = item
+		uc.compute(pending)
+	}
+
+	return nil
+}
+
+// compute applies the row to its loaded item and records the diff
+func (uc *ImportStockUseCase) compute(pending *pendingRow) {
+	item, result := pending.item, pending.result
+	result.InventoryItemID = item.ID
+	result.Before, result.After, result.Reserved = item.Quantity, item.Quantity, item.Reserved
+	result.Status, result.Error = StockImportUnchanged, ""
+
+	target := item.Quantity
+	if pending.row.Quantity != nil {
+		target = *pending.row.Quantity
+	} else {
+		target += *pending.row.Delta
+	}
+	result.After = target
+	if target == item.Quantity {
+		return
+	}
+
+	if err := item.SetQuantity(target); err != nil {
+		result.Status, result.Error = StockImportFailed, err.Error()
+		return
+	}
+	result.Status = StockImportChanged
+}
+
+// apply writes the changed rows of a chunk in one transaction, falling back to
+// one row at a time when the transaction fails. With WithAtomicStock, delta rows
+// are adjusted one at a time instead.
+func (uc *ImportStockUseCase) apply(ctx context.Context, chunk []*pendingRow) {
+	changed := make([]*pendingRow, 0, len(chunk))
+	items := make([]*entity.InventoryItem, 0, len(chunk))
+	for _, pending := range chunk {
+		if pending.result.Status != StockImportChanged {
+			continue
+		}
+		if uc.atomicStock != nil && pending.row.Delta != nil {
+			uc.adjustRow(ctx, pending)
+			continue
+		}
+		changed = append(changed, pending)
+		items = append(items, pending.item)
+	}
+	if len(items) == 0 {
+		return
+	}
+
+	err := uc.bulkRepo.UpdateQuantities(ctx, items)
+	if err == nil {
+		return
+	}
+
+	for _, pending := range changed {
+		uc.applyRow(ctx, pending)
+	}
+}
+
+// applyRow re-reads the item and writes a single row with retries
+func (uc *ImportStockUseCase) applyRow(ctx context.Context, pending *pendingRow) {
+	productID := pending.row.ProductID
+	err := uc.retry.run(ctx, OperationStockImport, func() (uuid.UUID, error) {
+		item, err := uc.inventoryRepo.FindByProductID(ctx, productID)
+		if err != nil {
+			return productID, err
+		}
+		pending.item = item
+		uc.compute(pending)
+		if pending.result.Status != StockImportChanged {
+			return productID, nil
+		}
+		return productID, uc.inventoryRepo.Update(ctx, item)
+	})
+	if err != nil {
+		pending.result.Status, pending.result.Error = StockImportFailed, err.Error()
+	}
+}
+
+// adjustRow adds the delta of a row to the stored quantity with a conditional
+// statement, so concurrent reservations cannot make it conflict
+func (uc *ImportStockUseCase) adjustRow(ctx context.Context, pending *pendingRow) {
+	productID, delta := pending.row.ProductID, *pending.row.Delta
+	err := uc.retry.run(ctx, OperationStockImport, func() (uuid.UUID, error) {
+		stored, err := uc.atomicStock.AdjustStock(ctx, productID, delta)
+		if err != nil {
+			return productID, err
+		}
+		pending.item = stored
+		result := pending.result
+		result.Before, result.After, result.Reserved = stored.Quantity-delta, stored.Quantity, stored.Reserved
+		return productID, nil
+	})
+	if err != nil {
+		pending.result.Status, pending.result.Error = StockImportFailed, err.Error()
+	}
+}
@@ -0,0 +1,225 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	domainErrors "github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockBulkStockRepository is a mock implementation of BulkStockRepository
+type MockBulkStockRepository struct {
+	mock.Mock
+}
+
+func (m *MockBulkStockRepository) UpdateQuantities(ctx context.Context, items []*entity.InventoryItem) error {
+	args := m.Called(ctx, items)
+	return args.Error(0)
+}
+
+func (m *MockBulkStockRepository) StreamAll(ctx context.Context, batchSize int, fn func(item *entity.InventoryItem) error) error {
+	args := m.Called(ctx, batchSize)
+	if items, ok := args.Get(0).([]*entity.InventoryItem); ok {
+		for _, item := range items {
+			if err := fn(item); err != nil {
+				return err
+			}
+		}
+	}
+	return args.Error(1)
+}
+
+func intPtr(n int) *int { return &n }
+
+func stockItem(productID uuid.UUID, quantity, reserved int) *entity.InventoryItem {
+	item, _ := entity.NewInventoryItem(productID, quantity)
+	item.Reserved = reserved
+	return item
+}
+
+func TestNewImportStockUseCase_NilRepos_Panic(t *testing.T) {
+	assert.Panics(t, func() { NewImportStockUseCase(nil, new(MockBulkStockRepository)) })
+	assert.Panics(t, func() { NewImportStockUseCase(new(MockInventoryRepository), nil) })
+}
+
+func TestImportStockUseCase_NoRows(t *testing.T) {
+	uc := NewImportStockUseCase(new(MockInventoryRepository), new(MockBulkStockRepository))
+
+	output, err := uc.Execute(context.Background(), ImportStockInput{})
+
+	assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+	assert.Nil(t, output)
+}
+
+func TestImportStockUseCase_DryRun(t *testing.T) {
+	inventoryRepo := new(MockInventoryRepository)
+	bulkRepo := new(MockBulkStockRepository)
+	counted, adjusted, same, busy, unknown := uuid.New(), uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	inventoryRepo.On("FindByProductIDs", mock.Anything, []uuid.UUID{counted, adjusted, same, busy, unknown}).Return(map[uuid.UUID]*entity.InventoryItem{
+		counted:  stockItem(counted, 100, 10),
+		adjusted: stockItem(adjusted, 50, 0),
+		same:     stockItem(same, 7, 0),
+		busy:     stockItem(busy, 20, 15),
+	}, nil)
+
+	rows := []StockImportRow{
+		{Line: 2, ProductID: counted, SKU: "SKU-1", Quantity: intPtr(80)},
+		{Line: 3, ProductID: adjusted, Delta: intPtr(-5)},
+		{Line: 4, ProductID: same, Quantity: intPtr(7)},
+		{Line: 5, ProductID: busy, Quantity: intPtr(10)},
+		{Line: 6, ProductID: unknown, Delta: intPtr(1)},
+		{Line: 7, ProductID: counted, Quantity: intPtr(1)},
+		{Line: 8, ProductID: uuid.New(), Quantity: intPtr(1), Delta: intPtr(1)},
+		{Line: 9, Quantity: intPtr(1)},
+		{Line: 10, ProductID: uuid.New(), Quantity: intPtr(-1)},
+		{Line: 11, Invalid: "quantity \"x\" is not an integer"},
+	}
+
+	output, err := NewImportStockUseCase(inventoryRepo, bulkRepo).Execute(context.Background(), ImportStockInput{Rows: rows, DryRun: true})
+
+	require.NoError(t, err)
+	assert.True(t, output.DryRun)
+	assert.Equal(t, 10, output.Total)
+	assert.Equal(t, 2, output.Changed)
+	assert.Equal(t, 1, output.Unchanged)
+	assert.Equal(t, 7, output.Failed)
+
+	assert.Equal(t, StockImportChanged, output.Rows[0].Status)
+	assert.Equal(t, 100, output.Rows[0].Before)
+	assert.Equal(t, 80, output.Rows[0].After)
+	assert.Equal(t, 10, output.Rows[0].Reserved)
+	assert.Equal(t, "SKU-1", output.Rows[0].SKU)
+	assert.Equal(t, 45, output.Rows[1].After)
+	assert.Equal(t, StockImportUnchanged, output.Rows[2].Status)
+	assert.Contains(t, output.Rows[3].Error, "below the 15 reserved units")
+	assert.Equal(t, domainErrors.ErrInventoryItemNotFound.Error(), output.Rows[4].Error)
+	assert.Equal(t, "duplicate product_id, first seen on line 2", output.Rows[5].Error)
+	assert.Equal(t, "exactly one of quantity and delta is required", output.Rows[6].Error)
+	assert.Equal(t, "product_id is required", output.Rows[7].Error)
+	assert.Equal(t, "quantity must be >= 0, got -1", output.Rows[8].Error)
+	assert.Equal(t, 11, output.Rows[9].Line)
+	assert.Equal(t, "quantity \"x\" is not an integer", output.Rows[9].Error)
+
+	bulkRepo.AssertNotCalled(t, "UpdateQuantities", mock.Anything, mock.Anything)
+	inventoryRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestImportStockUseCase_AppliesInChunks(t *testing.T) {
+	inventoryRepo := new(MockInventoryRepository)
+	bulkRepo := new(MockBulkStockRepository)
+	ids := []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+	items := map[uuid.UUID]*entity.InventoryItem{ids[0]: stockItem(ids[0], 10, 0), ids[1]: stockItem(ids[1], 10, 0), ids[2]: stockItem(ids[2], 10, 0)}
+	inventoryRepo.On("FindByProductIDs", mock.Anything, ids[:2]).Return(map[uuid.UUID]*entity.InventoryItem{ids[0]: items[ids[0]], ids[1]: items[ids[1]]}, nil)
+	inventoryRepo.On("FindByProductIDs", mock.Anything, ids[2:]).Return(map[uuid.UUID]*entity.InventoryItem{ids[2]: items[ids[2]]}, nil)
+	bulkRepo.On("UpdateQuantities", mock.Anything, []*entity.InventoryItem{items[ids[0]]}).Return(nil).Once()
+	bulkRepo.On("UpdateQuantities", mock.Anything, []*entity.InventoryItem{items[ids[2]]}).Return(nil).Once()
+
+	rows := []StockImportRow{
+		{Line: 1, ProductID: ids[0], Quantity: intPtr(12)},
+		{Line: 2, ProductID: ids[1], Delta: intPtr(0)},
+		{Line: 3, ProductID: ids[2], Delta: intPtr(3)},
+	}
+	output, err := NewImportStockUseCase(inventoryRepo, bulkRepo).WithChunkSize(2).Execute(context.Background(), ImportStockInput{Rows: rows})
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, output.Changed)
+	assert.Equal(t, 1, output.Unchanged)
+	assert.Equal(t, 12, items[ids[0]].Quantity)
+	assert.Equal(t, 13, items[ids[2]].Quantity)
+	assert.Equal(t, []string{items[ids[0]].ID.String(), items[ids[2]].ID.String()}, output.ChangedItemIDs())
+	bulkRepo.AssertExpectations(t)
+}
+
+func TestImportStockUseCase_ConflictFallsBackToRows(t *testing.T) {
+	inventoryRepo := new(MockInventoryRepository)
+	bulkRepo := new(MockBulkStockRepository)
+	counted, adjusted := uuid.New(), uuid.New()
+	inventoryRepo.On("FindByProductIDs", mock.Anything, mock.Anything).Return(map[uuid.UUID]*entity.InventoryItem{
+		counted:  stockItem(counted, 10, 0),
+		adjusted: stockItem(adjusted, 10, 0),
+	}, nil)
+	bulkRepo.On("UpdateQuantities", mock.Anything, mock.Anything).Return(&repository.BulkUpdateError{Index: 1, Err: domainErrors.ErrOptimisticLockFailure})
+
+	// Concurrent reservations changed both items in the meantime
+	latestCounted := stockItem(counted, 10, 4)
+	inventoryRepo.On("FindByProductID", mock.Anything, counted).Return(latestCounted, nil)
+	inventoryRepo.On("FindByProductID", mock.Anything, adjusted).Return(stockItem(adjusted, 8, 0), nil).Once()
+	inventoryRepo.On("FindByProductID", mock.Anything, adjusted).Return(stockItem(adjusted, 8, 0), nil).Once()
+	inventoryRepo.On("Update", mock.Anything, latestCounted).Return(nil)
+	inventoryRepo.On("Update", mock.Anything, mock.MatchedBy(func(item *entity.InventoryItem) bool {
+		return item.ProductID == adjusted
+	})).Return(domainErrors.ErrOptimisticLockFailure)
+
+	rows := []StockImportRow{
+		{Line: 1, ProductID: counted, Quantity: intPtr(20)},
+		{Line: 2, ProductID: adjusted, Delta: intPtr(5)},
+	}
+	uc := NewImportStockUseCase(inventoryRepo, bulkRepo).WithRetryPolicy(RetryPolicy{MaxAttempts: 2})
+	output, err := uc.Execute(context.Background(), ImportStockInput{Rows: rows})
+
+	require.NoError(t, err)
+	assert.Equal(t, StockImportChanged, output.Rows[0].Status)
+	assert.Equal(t, 4, output.Rows[0].Reserved, "report shows the state that was written")
+	assert.Equal(t, 20, latestCounted.Quantity)
+	assert.Equal(t, StockImportFailed, output.Rows[1].Status)
+	assert.Equal(t, 13, output.Rows[1].After, "delta is applied on top of the latest quantity")
+	assert.Contains(t, output.Rows[1].Error, "gave up after 2 conflicting attempts")
+	inventoryRepo.AssertNumberOfCalls(t, "FindByProductID", 3)
+}
+
+func TestImportStockUseCase_AtomicStock(t *testing.T) {
+	inventoryRepo := new(MockInventoryRepository)
+	bulkRepo := new(MockBulkStockRepository)
+	atomicStock := new(MockAtomicStockRepository)
+	counted, adjusted, rejected := uuid.New(), uuid.New(), uuid.New()
+	items := map[uuid.UUID]*entity.InventoryItem{
+		counted:  stockItem(counted, 10, 0),
+		adjusted: stockItem(adjusted, 10, 0),
+		rejected: stockItem(rejected, 10, 0),
+	}
+	inventoryRepo.On("FindByProductIDs", mock.Anything, mock.Anything).Return(items, nil)
+	bulkRepo.On("UpdateQuantities", mock.Anything, []*entity.InventoryItem{items[counted]}).Return(nil).Once()
+
+	// A reservation and a sale landed on the adjusted item after it was loaded
+	atomicStock.On("AdjustStock", mock.Anything, adjusted, 5).Return(stockItem(adjusted, 13, 4), nil).Once()
+	atomicStock.On("AdjustStock", mock.Anything, rejected, -2).Return(nil, domainErrors.ErrInsufficientStock).Once()
+
+	rows := []StockImportRow{
+		{Line: 1, ProductID: counted, Quantity: intPtr(20)},
+		{Line: 2, ProductID: adjusted, Delta: intPtr(5)},
+		{Line: 3, ProductID: rejected, Delta: intPtr(-2)},
+	}
+	uc := NewImportStockUseCase(inventoryRepo, bulkRepo).WithAtomicStock(atomicStock)
+	output, err := uc.Execute(context.Background(), ImportStockInput{Rows: rows})
+
+	require.NoError(t, err)
+	assert.Equal(t, StockImportChanged, output.Rows[0].Status)
+	assert.Equal(t, StockImportChanged, output.Rows[1].Status)
+	assert.Equal(t, 8, output.Rows[1].Before, "report shows the quantity the delta was applied to")
+	assert.Equal(t, 13, output.Rows[1].After)
+	assert.Equal(t, 4, output.Rows[1].Reserved)
+	assert.Equal(t, StockImportFailed, output.Rows[2].Status)
+	assert.Equal(t, domainErrors.ErrInsufficientStock.Error(), output.Rows[2].Error)
+	bulkRepo.AssertExpectations(t)
+	atomicStock.AssertExpectations(t)
+	inventoryRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestImportStockUseCase_LoadError(t *testing.T) {
+	inventoryRepo := new(MockInventoryRepository)
+	inventoryRepo.On("FindByProductIDs", mock.Anything, mock.Anything).Return(nil, errors.New("connection refused"))
+
+	output, err := NewImportStockUseCase(inventoryRepo, new(MockBulkStockRepository)).Execute(context.Background(), ImportStockInput{
+		Rows: []StockImportRow{{Line: 1, ProductID: uuid.New(), Quantity: intPtr(1)}},
+	})
+
+	assert.ErrorContains(t, err, "connection refused")
+	assert.Nil(t, output)
+}
@@ -0,0 +1,109 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+// ListArchivedReservationsInput represents the filters for querying the reservation archive
+type ListArchivedReservationsInput struct {
+	OrderID         uuid.UUID
+	InventoryItemID uuid.UUID
+	Status          string
+	From            time.Time
+	To              time.Time
+	Limit           int
+	Offset          int
+}
+
+// ListArchivedReservationsOutput represents a page of archived reservations
+type ListArchivedReservationsOutput struct {
+	Reservations []*entity.ArchivedReservation
+	TotalCount   int64
+	Limit        int
+	Offset       int
+}
+
+// ListArchivedReservationsUseCase handles querying the reservation archive
+type ListArchivedReservationsUseCase struct {
+	archiveRepo repository.ReservationArchiveRepository
+}
+
+// NewListArchivedReservationsUseCase creates a new instance
+func NewListArchivedReservationsUseCase(archiveRepo repository.ReservationArchiveRepository) *ListArchivedReservationsUseCase {
+	if archiveRepo == nil {
+		panic("archiveRepo cannot be nil")
+	}
+
+	return &ListArchivedReservationsUseCase{
+		archiveRepo: archiveRepo,
+	}
+}
+
+// Execute lists archived reservations, newest first, with pagination
+func (uc *ListArchivedReservationsUseCase) Execute(ctx context.Context, input ListArchivedReservationsInput) (*ListArchivedReservationsOutput, error) {
+	// Validate pagination params
+	if input.Limit <= 0 {
+		input.Limit = 50 // default
+	}
+	if input.Limit > 500 {
+		input.Limit = 500 // max
+	}
+	if input.Offset < 0 {
+		input.Offset = 0
+	}
+
+	status := entity.ReservationStatus(input.Status)
+	if status != "" && !entity.IsTerminalReservationStatus(status) {
+		return nil, errors.ErrInvalidInput.WithDetails("status must be confirmed, released or expired")
+	}
+	if !input.From.IsZero() && !input.To.IsZero() && !input.From.Before(input.To) {
+		return nil, errors.ErrInvalidInput.WithDetails("from must be before to")
+	}
+
+	reservations, total, err := uc.archiveRepo.ListArchived(ctx, repository.ArchivedReservationFilter{
+		OrderID:         input.OrderID,
+		InventoryItemID: input.InventoryItemID,
+		Status:          status,
+		From:            input.From,
+		To:              input.To,
+		Limit:           input.Limit,
+		Offset:          input.Offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListArchivedReservationsOutput{
+		Reservations: reservations,
+		TotalCount:   total,
+		Limit:        input.Limit,
+		Offset:       input.Offset,
+	}, nil
+}
+
+// GetArchivedReservationUseCase retrieves one archived reservation
+type GetArchivedReservationUseCase struct {
+	archiveRepo repository.ReservationArchiveRepository
+}
+
+// NewGetArchivedReservationUseCase creates a new instance
+func NewGetArchivedReservationUseCase(archiveRepo repository.ReservationArchiveRepository) *GetArchivedReservationUseCase {
+	if archiveRepo == nil {
+		panic("archiveRepo cannot be nil")
+	}
+
+	return &GetArchivedReservationUseCase{
+		archiveRepo: archiveRepo,
+	}
+}
+
+// Execute returns the archived reservation, or ErrReservationNotFound
+func (uc *GetArchivedReservationUseCase) Execute(ctx context.Context, id uuid.UUID) (*entity.ArchivedReservation, error) {
+	return uc.archiveRepo.FindArchivedByID(ctx, id)
+}
@@ -0,0 +1,95 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	domainErrors "github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewListArchivedReservationsUseCase_NilRepo_Panics(t *testing.T) {
+	assert.Panics(t, func() { NewListArchivedReservationsUseCase(nil) })
+	assert.Panics(t, func() { NewGetArchivedReservationUseCase(nil) })
+}
+
+func TestListArchivedReservationsUseCase_Execute_PassesFilters(t *testing.T) {
+	repo := new(MockReservationArchiveRepository)
+	orderID := uuid.New()
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	archived := []*entity.ArchivedReservation{{Reservation: entity.Reservation{ID: uuid.New(), OrderID: orderID}}}
+	repo.On("ListArchived", mock.Anything, repository.ArchivedReservationFilter{
+		OrderID: orderID,
+		Status:  entity.ReservationConfirmed,
+		From:    from,
+		Limit:   500,
+	}).Return(archived, int64(1), nil)
+
+	output, err := NewListArchivedReservationsUseCase(repo).Execute(context.Background(), ListArchivedReservationsInput{
+		OrderID: orderID,
+		Status:  "confirmed",
+		From:    from,
+		Limit:   1000,
+		Offset:  -1,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, archived, output.Reservations)
+	assert.Equal(t, int64(1), output.TotalCount)
+	assert.Equal(t, 500, output.Limit)
+	assert.Zero(t, output.Offset)
+	repo.AssertExpectations(t)
+}
+
+func TestListArchivedReservationsUseCase_Execute_InvalidFilters(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name  string
+		input ListArchivedReservationsInput
+	}{
+		{"pending is never archived", ListArchivedReservationsInput{Status: "pending"}},
+		{"unknown status", ListArchivedReservationsInput{Status: "lost"}},
+		{"empty range", ListArchivedReservationsInput{From: now, To: now}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockReservationArchiveRepository)
+			_, err := NewListArchivedReservationsUseCase(repo).Execute(context.Background(), tt.input)
+			assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+			repo.AssertNotCalled(t, "ListArchived", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestListArchivedReservationsUseCase_Execute_RepositoryError(t *testing.T) {
+	repo := new(MockReservationArchiveRepository)
+	repo.On("ListArchived", mock.Anything, mock.Anything).Return(nil, int64(0), errors.New("database error"))
+
+	_, err := NewListArchivedReservationsUseCase(repo).Execute(context.Background(), ListArchivedReservationsInput{})
+
+	assert.EqualError(t, err, "database error")
+}
+
+func TestGetArchivedReservationUseCase_Execute(t *testing.T) {
+	repo := new(MockReservationArchiveRepository)
+	found := &entity.ArchivedReservation{Reservation: entity.Reservation{ID: uuid.New()}}
+	missing := uuid.New()
+	repo.On("FindArchivedByID", mock.Anything, found.ID).Return(found, nil)
+	repo.On("FindArchivedByID", mock.Anything, missing).Return(nil, domainErrors.ErrReservationNotFound)
+	uc := NewGetArchivedReservationUseCase(repo)
+
+	result, err := uc.Execute(context.Background(), found.ID)
+	require.NoError(t, err)
+	assert.Same(t, found, result)
+
+	_, err = uc.Execute(context.Background(), missing)
+	assert.ErrorIs(t, err, domainErrors.ErrReservationNotFound)
+}
@@ -0,0 +1,143 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+// Inventory item states accepted by ListInventoryItemsInput.Status
+const (
+	InventoryStatusActive   = "active"
+	InventoryStatusArchived = "archived"
+)
+
+// maxListProductIDs bounds the product_id filter of an inventory listing
+const maxListProductIDs = 100
+
+// ListInventoryItemsInput represents the filters and page of an inventory listing
+type ListInventoryItemsInput struct {
+	ProductIDs    []uuid.UUID
+	Status        string // active, archived or empty for both
+	LowStockBelow int    // available quantity below this; 0 disables the filter
+	CreatedFrom   time.Time
+	CreatedTo     time.Time
+
+	Sort   string // created_at (default) or updated_at
+	Order  string // desc (default) or asc
+	Cursor string // NextCursor of the previous page
+	Limit  int
+	Count  string // estimate (default), exact or none
+}
+
+// ListInventoryItemsOutput represents a page of inventory items
+type ListInventoryItemsOutput struct {
+	Items      []*entity.InventoryItem
+	NextCursor string // empty on the last page
+	TotalCount int64
+	Count      CountMode // how TotalCount was computed
+	Limit      int
+}
+
+// ListInventoryItemsUseCase lists inventory items with keyset pagination
+type ListInventoryItemsUseCase struct {
+	listingRepo repository.InventoryListingRepository
+}
+
+// NewListInventoryItemsUseCase creates a new instance
+func NewListInventoryItemsUseCase(listingRepo repository.InventoryListingRepository) *ListInventoryItemsUseCase {
+	if listingRepo == nil {
+		panic("listingRepo cannot be nil")
+	}
+
+	return &ListInventoryItemsUseCase{
+		listingRepo: listingRepo,
+	}
+}
+
+// Execute returns one page of inventory items
+func (uc *ListInventoryItemsUseCase) Execute(ctx context.Context, input ListInventoryItemsInput) (*ListInventoryItemsOutput, error) {
+	filter, page, err := uc.buildFilter(input)
+	if err != nil {
+		return nil, err
+	}
+
+	// One extra row tells whether another page follows
+	filter.Limit = page.limit + 1
+	items, err := uc.listingRepo.ListItems(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &ListInventoryItemsOutput{Items: items, Count: page.count, Limit: page.limit}
+	if len(items) > page.limit {
+		output.Items = items[:page.limit]
+		last := output.Items[page.limit-1]
+		sortValue := last.CreatedAt
+		if filter.SortBy == repository.InventorySortUpdatedAt {
+			sortValue = last.UpdatedAt
+		}
+		output.NextCursor = encodeListCursor(string(filter.SortBy), filter.Direction, sortValue, last.ID)
+	}
+
+	output.TotalCount, err = countListing(ctx, page.count,
+		func(ctx context.Context) (int64, error) { return uc.listingRepo.CountItems(ctx, filter) },
+		func(ctx context.Context) (int64, error) { return uc.listingRepo.EstimateItems(ctx, filter) },
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+// buildFilter validates the input and converts it to a repository filter
+func (uc *ListInventoryItemsUseCase) buildFilter(input ListInventoryItemsInput) (repository.InventoryListFilter, listPagination, error) {
+	filter := repository.InventoryListFilter{
+		ProductIDs:    input.ProductIDs,
+		LowStockBelow: input.LowStockBelow,
+		CreatedFrom:   input.CreatedFrom,
+		CreatedTo:     input.CreatedTo,
+	}
+
+	page, err := parseListPagination(input.Limit, input.Order, input.Count)
+	if err != nil {
+		return filter, page, err
+	}
+	filter.Direction = page.direction
+
+	switch repository.InventorySortField(input.Sort) {
+	case "", repository.InventorySortCreatedAt:
+		filter.SortBy = repository.InventorySortCreatedAt
+	case repository.InventorySortUpdatedAt:
+		filter.SortBy = repository.InventorySortUpdatedAt
+	default:
+		return filter, page, errors.ErrInvalidInput.WithDetails("sort must be created_at or updated_at")
+	}
+
+	switch input.Status {
+	case "":
+	case InventoryStatusActive, InventoryStatusArchived:
+		archived := input.Status == InventoryStatusArchived
+		filter.Archived = &archived
+	default:
+		return filter, page, errors.ErrInvalidInput.WithDetails("status must be active or archived")
+	}
+
+	if len(input.ProductIDs) > maxListProductIDs {
+		return filter, page, errors.ErrInvalidInput.WithDetails("at most 100 product IDs can be listed at once")
+	}
+	if input.LowStockBelow < 0 {
+		return filter, page, errors.ErrInvalidInput.WithDetails("low_stock must not be negative")
+	}
+	if err := validateListRange("created", input.CreatedFrom, input.CreatedTo); err != nil {
+		return filter, page, err
+	}
+
+	filter.After, err = decodeListCursor(input.Cursor, string(filter.SortBy), filter.Direction)
+	return filter, page, err
+}
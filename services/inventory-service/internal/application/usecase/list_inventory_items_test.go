@@ -0,0 +1,169 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	domainErrors "github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockInventoryListingRepository is a mock implementation of InventoryListingRepository
+type MockInventoryListingRepository struct {
+	mock.Mock
+}
+
+func (m *MockInventoryListingRepository) ListItems(ctx context.Context, filter repository.InventoryListFilter) ([]*entity.InventoryItem, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.InventoryItem), args.Error(1)
+}
+
+func (m *MockInventoryListingRepository) CountItems(ctx context.Context, filter repository.InventoryListFilter) (int64, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockInventoryListingRepository) EstimateItems(ctx context.Context, filter repository.InventoryListFilter) (int64, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// listedItems builds n items created one minute apart, newest first
+func listedItems(n int) []*entity.InventoryItem {
+	base := time.Date(2025, 11, 1, 12, 0, 0, 0, time.UTC)
+	items := make([]*entity.InventoryItem, n)
+	for i := range items {
+		items[i] = &entity.InventoryItem{
+			ID:        uuid.New(),
+			ProductID: uuid.New(),
+			CreatedAt: base.Add(-time.Duration(i) * time.Minute),
+			UpdatedAt: base.Add(time.Duration(i) * time.Hour),
+		}
+	}
+	return items
+}
+
+func TestNewListInventoryItemsUseCase_NilRepo_Panics(t *testing.T) {
+	assert.Panics(t, func() { NewListInventoryItemsUseCase(nil) })
+}
+
+func TestListInventoryItemsUseCase_Execute_FirstPage(t *testing.T) {
+	repo := new(MockInventoryListingRepository)
+	items := listedItems(3)
+	archived := false
+	repo.On("ListItems", mock.Anything, repository.InventoryListFilter{
+		Archived:      &archived,
+		LowStockBelow: 5,
+		SortBy:        repository.InventorySortCreatedAt,
+		Direction:     repository.SortDescending,
+		Limit:         3,
+	}).Return(items, nil)
+	repo.On("EstimateItems", mock.Anything, mock.Anything).Return(int64(1200), nil)
+
+	output, err := NewListInventoryItemsUseCase(repo).Execute(context.Background(), ListInventoryItemsInput{
+		Status:        "active",
+		LowStockBelow: 5,
+		Limit:         2,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, items[:2], output.Items)
+	assert.Equal(t, int64(1200), output.TotalCount)
+	assert.Equal(t, CountEstimate, output.Count)
+	require.NotEmpty(t, output.NextCursor)
+
+	cursor, err := decodeListCursor(output.NextCursor, "created_at", repository.SortDescending)
+	require.NoError(t, err)
+	assert.Equal(t, items[1].ID, cursor.ID)
+	assert.True(t, items[1].CreatedAt.Equal(cursor.SortValue))
+	repo.AssertNotCalled(t, "CountItems", mock.Anything, mock.Anything)
+}
+
+func TestListInventoryItemsUseCase_Execute_NextPage(t *testing.T) {
+	repo := new(MockInventoryListingRepository)
+	items := listedItems(2)
+	token := encodeListCursor("updated_at", repository.SortAscending, items[0].UpdatedAt, items[0].ID)
+	repo.On("ListItems", mock.Anything, mock.MatchedBy(func(filter repository.InventoryListFilter) bool {
+		return filter.SortBy == repository.InventorySortUpdatedAt &&
+			filter.Direction == repository.SortAscending &&
+			filter.After != nil && filter.After.ID == items[0].ID
+	})).Return(items[1:], nil)
+	repo.On("CountItems", mock.Anything, mock.Anything).Return(int64(2), nil)
+
+	output, err := NewListInventoryItemsUseCase(repo).Execute(context.Background(), ListInventoryItemsInput{
+		Sort:   "updated_at",
+		Order:  "asc",
+		Cursor: token,
+		Count:  "exact",
+	})
+
+	require.NoError(t, err)
+	assert.Len(t, output.Items, 1)
+	assert.Empty(t, output.NextCursor, "last page")
+	assert.Equal(t, int64(2), output.TotalCount)
+	assert.Equal(t, CountExact, output.Count)
+	repo.AssertExpectations(t)
+}
+
+func TestListInventoryItemsUseCase_Execute_NoCount(t *testing.T) {
+	repo := new(MockInventoryListingRepository)
+	repo.On("ListItems", mock.Anything, mock.Anything).Return([]*entity.InventoryItem{}, nil)
+
+	output, err := NewListInventoryItemsUseCase(repo).Execute(context.Background(), ListInventoryItemsInput{Count: "none"})
+
+	require.NoError(t, err)
+	assert.Zero(t, output.TotalCount)
+	repo.AssertNotCalled(t, "EstimateItems", mock.Anything, mock.Anything)
+}
+
+func TestListInventoryItemsUseCase_Execute_InvalidInput(t *testing.T) {
+	now := time.Now()
+	tooMany := make([]uuid.UUID, maxListProductIDs+1)
+	tests := []struct {
+		name  string
+		input ListInventoryItemsInput
+	}{
+		{"unknown sort", ListInventoryItemsInput{Sort: "quantity"}},
+		{"unknown status", ListInventoryItemsInput{Status: "deleted"}},
+		{"negative low stock", ListInventoryItemsInput{LowStockBelow: -1}},
+		{"too many products", ListInventoryItemsInput{ProductIDs: tooMany}},
+		{"empty created range", ListInventoryItemsInput{CreatedFrom: now, CreatedTo: now}},
+		{"cursor of another sort", ListInventoryItemsInput{
+			Sort:   "updated_at",
+			Cursor: encodeListCursor("created_at", repository.SortDescending, now, uuid.New()),
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockInventoryListingRepository)
+			_, err := NewListInventoryItemsUseCase(repo).Execute(context.Background(), tt.input)
+			assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+			repo.AssertNotCalled(t, "ListItems", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestListInventoryItemsUseCase_Execute_RepositoryErrors(t *testing.T) {
+	repo := new(MockInventoryListingRepository)
+	repo.On("ListItems", mock.Anything, mock.Anything).Return(nil, errors.New("database error")).Once()
+
+	_, err := NewListInventoryItemsUseCase(repo).Execute(context.Background(), ListInventoryItemsInput{})
+	assert.EqualError(t, err, "database error")
+
+	repo.On("ListItems", mock.Anything, mock.Anything).Return([]*entity.InventoryItem{}, nil)
+	repo.On("EstimateItems", mock.Anything, mock.Anything).Return(int64(0), errors.New("explain failed"))
+
+	_, err = NewListInventoryItemsUseCase(repo).Execute(context.Background(), ListInventoryItemsInput{})
+	assert.EqualError(t, err, "explain failed")
+}
@@ -0,0 +1,136 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+// ListReservationsInput represents the filters and page of a reservation listing
+type ListReservationsInput struct {
+	Status          string
+	ProductID       uuid.UUID
+	OrderID         uuid.UUID
+	InventoryItemID uuid.UUID
+	ExpiresFrom     time.Time
+	ExpiresTo       time.Time
+	CreatedFrom     time.Time
+	CreatedTo       time.Time
+
+	Sort   string // created_at (default), updated_at or expires_at
+	Order  string // desc (default) or asc
+	Cursor string // NextCursor of the previous page
+	Limit  int
+	Count  string // estimate (default), exact or none
+}
+
+// ListReservationsOutput represents a page of reservations
+type ListReservationsOutput struct {
+	Reservations []*entity.Reservation
+	NextCursor   string // empty on the last page
+	TotalCount   int64
+	Count        CountMode // how TotalCount was computed
+	Limit        int
+}
+
+// ListReservationsUseCase lists live reservations with keyset pagination.
+// Archived reservations are listed by ListArchivedReservationsUseCase.
+type ListReservationsUseCase struct {
+	listingRepo repository.ReservationListingRepository
+}
+
+// NewListReservationsUseCase creates a new instance
+func NewListReservationsUseCase(listingRepo repository.ReservationListingRepository) *ListReservationsUseCase {
+	if listingRepo == nil {
+		panic("listingRepo cannot be nil")
+	}
+
+	return &ListReservationsUseCase{
+		listingRepo: listingRepo,
+	}
+}
+
+// Execute returns one page of reservations
+func (uc *ListReservationsUseCase) Execute(ctx context.Context, input ListReservationsInput) (*ListReservationsOutput, error) {
+	filter, page, err := uc.buildFilter(input)
+	if err != nil {
+		return nil, err
+	}
+
+	// One extra row tells whether another page follows
+	filter.Limit = page.limit + 1
+	reservations, err := uc.listingRepo.ListReservations(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &ListReservationsOutput{Reservations: reservations, Count: page.count, Limit: page.limit}
+	if len(reservations) > page.limit {
+		output.Reservations = reservations[:page.limit]
+		last := output.Reservations[page.limit-1]
+		sortValue := last.CreatedAt
+		switch filter.SortBy {
+		case repository.ReservationSortUpdatedAt:
+			sortValue = last.UpdatedAt
+		case repository.ReservationSortExpiresAt:
+			sortValue = last.ExpiresAt
+		}
+		output.NextCursor = encodeListCursor(string(filter.SortBy), filter.Direction, sortValue, last.ID)
+	}
+
+	output.TotalCount, err = countListing(ctx, page.count,
+		func(ctx context.Context) (int64, error) { return uc.listingRepo.CountReservations(ctx, filter) },
+		func(ctx context.Context) (int64, error) { return uc.listingRepo.EstimateReservations(ctx, filter) },
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+// buildFilter validates the input and converts it to a repository filter
+func (uc *ListReservationsUseCase) buildFilter(input ListReservationsInput) (repository.ReservationListFilter, listPagination, error) {
+	filter := repository.ReservationListFilter{
+		Status:          entity.ReservationStatus(input.Status),
+		ProductID:       input.ProductID,
+		OrderID:         input.OrderID,
+		InventoryItemID: input.InventoryItemID,
+		ExpiresFrom:     input.ExpiresFrom,
+		ExpiresTo:       input.ExpiresTo,
+		CreatedFrom:     input.CreatedFrom,
+		CreatedTo:       input.CreatedTo,
+	}
+
+	page, err := parseListPagination(input.Limit, input.Order, input.Count)
+	if err != nil {
+		return filter, page, err
+	}
+	filter.Direction = page.direction
+
+	switch repository.ReservationSortField(input.Sort) {
+	case "", repository.ReservationSortCreatedAt:
+		filter.SortBy = repository.ReservationSortCreatedAt
+	case repository.ReservationSortUpdatedAt, repository.ReservationSortExpiresAt:
+		filter.SortBy = repository.ReservationSortField(input.Sort)
+	default:
+		return filter, page, errors.ErrInvalidInput.WithDetails("sort must be created_at, updated_at or expires_at")
+	}
+
+	if filter.Status != "" && filter.Status != entity.ReservationPending && !entity.IsTerminalReservationStatus(filter.Status) {
+		return filter, page, errors.ErrInvalidInput.WithDetails("status must be pending, confirmed, released or expired")
+	}
+	if err := validateListRange("expires", input.ExpiresFrom, input.ExpiresTo); err != nil {
+		return filter, page, err
+	}
+	if err := validateListRange("created", input.CreatedFrom, input.CreatedTo); err != nil {
+		return filter, page, err
+	}
+
+	filter.After, err = decodeListCursor(input.Cursor, string(filter.SortBy), filter.Direction)
+	return filter, page, err
+}
@@ -0,0 +1,102 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	domainErrors "github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockReservationListingRepository is a mock implementation of ReservationListingRepository
+type MockReservationListingRepository struct {
+	mock.Mock
+}
+
+func (m *MockReservationListingRepository) ListReservations(ctx context.Context, filter repository.ReservationListFilter) ([]*entity.Reservation, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Reservation), args.Error(1)
+}
+
+func (m *MockReservationListingRepository) CountReservations(ctx context.Context, filter repository.ReservationListFilter) (int64, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockReservationListingRepository) EstimateReservations(ctx context.Context, filter repository.ReservationListFilter) (int64, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func TestNewListReservationsUseCase_NilRepo_Panics(t *testing.T) {
+	assert.Panics(t, func() { NewListReservationsUseCase(nil) })
+}
+
+func TestListReservationsUseCase_Execute_SortsByExpiry(t *testing.T) {
+	repo := new(MockReservationListingRepository)
+	productID := uuid.New()
+	expiresFrom := time.Date(2025, 11, 20, 0, 0, 0, 0, time.UTC)
+	base := expiresFrom.Add(time.Hour)
+	reservations := []*entity.Reservation{
+		{ID: uuid.New(), Status: entity.ReservationPending, ExpiresAt: base},
+		{ID: uuid.New(), Status: entity.ReservationPending, ExpiresAt: base.Add(time.Minute)},
+	}
+	repo.On("ListReservations", mock.Anything, repository.ReservationListFilter{
+		Status:      entity.ReservationPending,
+		ProductID:   productID,
+		ExpiresFrom: expiresFrom,
+		SortBy:      repository.ReservationSortExpiresAt,
+		Direction:   repository.SortAscending,
+		Limit:       2,
+	}).Return(reservations, nil)
+	repo.On("EstimateReservations", mock.Anything, mock.Anything).Return(int64(40), nil)
+
+	output, err := NewListReservationsUseCase(repo).Execute(context.Background(), ListReservationsInput{
+		Status:      "pending",
+		ProductID:   productID,
+		ExpiresFrom: expiresFrom,
+		Sort:        "expires_at",
+		Order:       "asc",
+		Limit:       1,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, reservations[:1], output.Reservations)
+	cursor, err := decodeListCursor(output.NextCursor, "expires_at", repository.SortAscending)
+	require.NoError(t, err)
+	assert.Equal(t, reservations[0].ID, cursor.ID)
+	assert.True(t, base.Equal(cursor.SortValue))
+	repo.AssertExpectations(t)
+}
+
+func TestListReservationsUseCase_Execute_InvalidInput(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name  string
+		input ListReservationsInput
+	}{
+		{"unknown sort", ListReservationsInput{Sort: "quantity"}},
+		{"unknown status", ListReservationsInput{Status: "lost"}},
+		{"empty expiry range", ListReservationsInput{ExpiresFrom: now, ExpiresTo: now.Add(-time.Minute)}},
+		{"empty created range", ListReservationsInput{CreatedFrom: now, CreatedTo: now}},
+		{"garbage cursor", ListReservationsInput{Cursor: "garbage"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockReservationListingRepository)
+			_, err := NewListReservationsUseCase(repo).Execute(context.Background(), tt.input)
+			assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+			repo.AssertNotCalled(t, "ListReservations", mock.Anything, mock.Anything)
+		})
+	}
+}
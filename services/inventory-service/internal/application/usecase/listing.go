@@ -0,0 +1,125 @@
+package usecase
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+// Page sizes of the cursor-paginated listings
+const (
+	DefaultListLimit = 50
+	MaxListLimit     = 500
+)
+
+// CountMode selects how a listing computes its total
+type CountMode string
+
+const (
+	// CountEstimate uses the query planner's estimate, cheap on large tables
+	CountEstimate CountMode = "estimate"
+	// CountExact runs COUNT(*) with the listing filters
+	CountExact CountMode = "exact"
+	// CountNone skips the total
+	CountNone CountMode = "none"
+)
+
+// listCursor is the content of an opaque page cursor. Sort and direction are
+// kept so a cursor cannot be replayed against a different ordering.
+type listCursor struct {
+	Sort      string                   `json:"s"`
+	Direction repository.SortDirection `json:"d"`
+	Value     time.Time                `json:"v"`
+	ID        uuid.UUID                `json:"id"`
+}
+
+// encodeListCursor builds the cursor that continues after the given row
+func encodeListCursor(sort string, direction repository.SortDirection, value time.Time, id uuid.UUID) string {
+	data, _ := json.Marshal(listCursor{Sort: sort, Direction: direction, Value: value.UTC(), ID: id})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeListCursor parses a cursor issued for the same sort and direction.
+// An empty token is the first page.
+func decodeListCursor(token, sort string, direction repository.SortDirection) (*repository.KeysetCursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, errors.ErrInvalidInput.WithDetails("cursor is invalid")
+	}
+	var cursor listCursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ID == uuid.Nil {
+		return nil, errors.ErrInvalidInput.WithDetails("cursor is invalid")
+	}
+	if cursor.Sort != sort || cursor.Direction != direction {
+		return nil, errors.ErrInvalidInput.WithDetails("cursor was issued for a different sort order")
+	}
+
+	return &repository.KeysetCursor{SortValue: cursor.Value, ID: cursor.ID}, nil
+}
+
+// listPagination holds the validated paging parameters shared by the listings
+type listPagination struct {
+	limit     int
+	direction repository.SortDirection
+	count     CountMode
+}
+
+// parseListPagination validates the limit, order and count parameters.
+// The order defaults to descending and the count to an estimate.
+func parseListPagination(limit int, order, count string) (listPagination, error) {
+	p := listPagination{limit: limit, direction: repository.SortDescending, count: CountEstimate}
+
+	if p.limit <= 0 {
+		p.limit = DefaultListLimit
+	}
+	if p.limit > MaxListLimit {
+		p.limit = MaxListLimit
+	}
+
+	switch repository.SortDirection(order) {
+	case "":
+	case repository.SortAscending, repository.SortDescending:
+		p.direction = repository.SortDirection(order)
+	default:
+		return p, errors.ErrInvalidInput.WithDetails("order must be asc or desc")
+	}
+
+	switch CountMode(count) {
+	case "":
+	case CountEstimate, CountExact, CountNone:
+		p.count = CountMode(count)
+	default:
+		return p, errors.ErrInvalidInput.WithDetails("count must be estimate, exact or none")
+	}
+
+	return p, nil
+}
+
+// countListing computes the total of a listing in the requested mode
+func countListing(ctx context.Context, mode CountMode, exact, estimate func(ctx context.Context) (int64, error)) (int64, error) {
+	switch mode {
+	case CountExact:
+		return exact(ctx)
+	case CountEstimate:
+		return estimate(ctx)
+	default:
+		return 0, nil
+	}
+}
+
+// validateListRange checks an optional [from, to) range
+func validateListRange(name string, from, to time.Time) error {
+	if !from.IsZero() && !to.IsZero() && !from.Before(to) {
+		return errors.ErrInvalidInput.WithDetails(name + "_from must be before " + name + "_to")
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package usecase
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	domainErrors "github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListCursor_RoundTrip(t *testing.T) {
+	id := uuid.New()
+	value := time.Date(2025, 11, 20, 10, 30, 0, 123456000, time.FixedZone("ART", -3*60*60))
+
+	token := encodeListCursor("created_at", repository.SortDescending, value, id)
+	cursor, err := decodeListCursor(token, "created_at", repository.SortDescending)
+
+	require.NoError(t, err)
+	assert.Equal(t, id, cursor.ID)
+	assert.True(t, value.Equal(cursor.SortValue), "microseconds survive the round trip")
+}
+
+func TestListCursor_Decode_Invalid(t *testing.T) {
+	valid := encodeListCursor("created_at", repository.SortDescending, time.Now(), uuid.New())
+
+	tests := []struct {
+		name      string
+		token     string
+		sort      string
+		direction repository.SortDirection
+	}{
+		{"not base64", "%%%", "created_at", repository.SortDescending},
+		{"not json", base64.RawURLEncoding.EncodeToString([]byte("nope")), "created_at", repository.SortDescending},
+		{"missing id", base64.RawURLEncoding.EncodeToString([]byte(`{"s":"created_at","d":"desc"}`)), "created_at", repository.SortDescending},
+		{"other sort", valid, "updated_at", repository.SortDescending},
+		{"other direction", valid, "created_at", repository.SortAscending},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := decodeListCursor(tt.token, tt.sort, tt.direction)
+			assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		})
+	}
+}
+
+func TestListCursor_Decode_EmptyIsFirstPage(t *testing.T) {
+	cursor, err := decodeListCursor("", "created_at", repository.SortDescending)
+	require.NoError(t, err)
+	assert.Nil(t, cursor)
+}
+
+func TestParseListPagination(t *testing.T) {
+	page, err := parseListPagination(0, "", "")
+	require.NoError(t, err)
+	assert.Equal(t, listPagination{limit: DefaultListLimit, direction: repository.SortDescending, count: CountEstimate}, page)
+
+	page, err = parseListPagination(10000, "asc", "exact")
+	require.NoError(t, err)
+	assert.Equal(t, listPagination{limit: MaxListLimit, direction: repository.SortAscending, count: CountExact}, page)
+
+	_, err = parseListPagination(10, "up", "")
+	assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+	_, err = parseListPagination(10, "", "all")
+	assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+}
@@ -0,0 +1,224 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/events"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+// ReceiveLotInput represents a lot of goods received for a product
+type ReceiveLotInput struct {
+	ProductID  uuid.UUID
+	LotNumber  string
+	Quantity   int
+	ReceivedAt time.Time  // Optional: now if zero
+	BestBefore *time.Time // Optional: nil if the goods do not expire
+}
+
+// ReceiveLotOutput represents the stored lot and inventory item after a receipt
+type ReceiveLotOutput struct {
+	Lot  *entity.Lot
+	Item *entity.InventoryItem
+}
+
+// ReceiveLotUseCase records a lot received for a product and adds its units to the stock
+type ReceiveLotUseCase struct {
+	inventoryRepo repository.InventoryRepository
+	lotRepo       repository.LotRepository
+	notifier      WaitlistNotifier
+}
+
+// NewReceiveLotUseCase creates a new instance
+func NewReceiveLotUseCase(inventoryRepo repository.InventoryRepository, lotRepo repository.LotRepository) *ReceiveLotUseCase {
+	if inventoryRepo == nil {
+		panic("inventoryRepo cannot be nil")
+	}
+	if lotRepo == nil {
+		panic("lotRepo cannot be nil")
+	}
+
+	return &ReceiveLotUseCase{
+		inventoryRepo: inventoryRepo,
+		lotRepo:       lotRepo,
+	}
+}
+
+// WithWaitlist makes the use case serve the product's waitlist after every receipt
+func (uc *ReceiveLotUseCase) WithWaitlist(notifier WaitlistNotifier) *ReceiveLotUseCase {
+	uc.notifier = notifier
+	return uc
+}
+
+// Execute validates the lot, saves it and adds its units to the product's inventory item
+func (uc *ReceiveLotUseCase) Execute(ctx context.Context, input ReceiveLotInput) (*ReceiveLotOutput, error) {
+	item, err := uc.inventoryRepo.FindByProductID(ctx, input.ProductID)
+	if err != nil {
+		return nil, errors.ErrInventoryItemNotFound.WithDetails(err.Error())
+	}
+
+	lot, err := entity.NewLot(item.ID, input.LotNumber, input.Quantity, input.ReceivedAt, input.BestBefore)
+	if err != nil {
+		return nil, err
+	}
+
+	stored, err := uc.lotRepo.Receive(ctx, lot)
+	if err != nil {
+		return nil, err
+	}
+
+	notifyStockAvailable(uc.notifier, input.ProductID)
+	return &ReceiveLotOutput{Lot: lot, Item: stored}, nil
+}
+
+// ListLotsUseCase lists the lots of a product
+type ListLotsUseCase struct {
+	inventoryRepo repository.InventoryRepository
+	lotRepo       repository.LotRepository
+}
+
+// NewListLotsUseCase creates a new instance
+func NewListLotsUseCase(inventoryRepo repository.InventoryRepository, lotRepo repository.LotRepository) *ListLotsUseCase {
+	if inventoryRepo == nil {
+		panic("inventoryRepo cannot be nil")
+	}
+	if lotRepo == nil {
+		panic("lotRepo cannot be nil")
+	}
+
+	return &ListLotsUseCase{
+		inventoryRepo: inventoryRepo,
+		lotRepo:       lotRepo,
+	}
+}
+
+// Execute returns the lots of the product in first-expired-first-out order
+func (uc *ListLotsUseCase) Execute(ctx context.Context, productID uuid.UUID) ([]*entity.Lot, error) {
+	item, err := uc.inventoryRepo.FindByProductID(ctx, productID)
+	if err != nil {
+		return nil, errors.ErrInventoryItemNotFound.WithDetails(err.Error())
+	}
+
+	return uc.lotRepo.FindByInventoryItemID(ctx, item.ID)
+}
+
+// GetReservationLotsUseCase tells which lots a reservation took
+type GetReservationLotsUseCase struct {
+	reservationRepo repository.ReservationRepository
+	lotRepo         repository.LotRepository
+}
+
+// NewGetReservationLotsUseCase creates a new instance
+func NewGetReservationLotsUseCase(reservationRepo repository.ReservationRepository, lotRepo repository.LotRepository) *GetReservationLotsUseCase {
+	if reservationRepo == nil {
+		panic("reservationRepo cannot be nil")
+	}
+	if lotRepo == nil {
+		panic("lotRepo cannot be nil")
+	}
+
+	return &GetReservationLotsUseCase{
+		reservationRepo: reservationRepo,
+		lotRepo:         lotRepo,
+	}
+}
+
+// Execute returns the lot allocations of the reservation. Allocations outlive
+// archived reservations, so the reservation is only looked up when it has none.
+func (uc *GetReservationLotsUseCase) Execute(ctx context.Context, reservationID uuid.UUID) ([]*entity.LotAllocation, error) {
+	allocations, err := uc.lotRepo.FindAllocations(ctx, reservationID)
+	if err != nil {
+		return nil, err
+	}
+	if len(allocations) > 0 {
+		return allocations, nil
+	}
+
+	if _, err := findReservation(ctx, uc.reservationRepo, reservationID, uuid.Nil); err != nil {
+		return nil, err
+	}
+	return allocations, nil
+}
+
+// QuarantineExpiredLotsOutput reports a quarantine run
+type QuarantineExpiredLotsOutput struct {
+	Lots  int `json:"lots"`
+	Units int `json:"units"`
+}
+
+// QuarantineExpiredLotsUseCase takes the units of lots past their best-before date
+// out of the available stock and publishes a LotQuarantined event per lot
+type QuarantineExpiredLotsUseCase struct {
+	lotRepo   repository.LotRepository
+	publisher events.Publisher
+	now       func() time.Time
+}
+
+// NewQuarantineExpiredLotsUseCase creates a new instance
+func NewQuarantineExpiredLotsUseCase(lotRepo repository.LotRepository, publisher events.Publisher) *QuarantineExpiredLotsUseCase {
+	if lotRepo == nil {
+		panic("lotRepo cannot be nil")
+	}
+	if publisher == nil {
+		panic("publisher cannot be nil")
+	}
+
+	return &QuarantineExpiredLotsUseCase{
+		lotRepo:   lotRepo,
+		publisher: publisher,
+		now:       time.Now,
+	}
+}
+
+// Execute quarantines the expired lots. Events are published for the lots
+// quarantined before an error too, since their units already left the stock.
+func (uc *QuarantineExpiredLotsUseCase) Execute(ctx context.Context) (*QuarantineExpiredLotsOutput, error) {
+	quarantined, err := uc.lotRepo.QuarantineExpired(ctx, uc.now().UTC())
+
+	output := &QuarantineExpiredLotsOutput{Lots: len(quarantined)}
+	for _, q := range quarantined {
+		output.Units += q.Units
+		uc.publish(ctx, q)
+	}
+	if err != nil {
+		return output, err
+	}
+	return output, nil
+}
+
+// publish publishes the LotQuarantined event of a lot (don't fail the run if publication fails)
+func (uc *QuarantineExpiredLotsUseCase) publish(ctx context.Context, q repository.QuarantinedLot) {
+	quarantinedAt := q.Lot.UpdatedAt
+	bestBefore := ""
+	if q.Lot.BestBefore != nil {
+		bestBefore = q.Lot.BestBefore.Format(time.DateOnly)
+	}
+
+	event := events.LotQuarantinedEvent{
+		BaseEvent: events.BaseEvent{
+			EventID:   uuid.New().String(),
+			EventType: events.RoutingKeyLotQuarantined,
+			Timestamp: time.Now().Format(time.RFC3339),
+			Version:   events.LotQuarantinedVersion,
+			Source:    events.SourceInventoryService,
+		},
+		Payload: events.LotQuarantinedPayload{
+			LotID:           q.Lot.ID.String(),
+			LotNumber:       q.Lot.LotNumber,
+			ProductID:       q.ProductID.String(),
+			InventoryItemID: q.Lot.InventoryItemID.String(),
+			Quantity:        q.Units,
+			BestBefore:      bestBefore,
+			QuarantinedAt:   quarantinedAt,
+		},
+	}
+
+	if err := uc.publisher.PublishLotQuarantined(ctx, event); err != nil {
+		log.Printf("Failed to publish LotQuarantined event for lot %s: %v", q.Lot.ID, err)
+	}
+}
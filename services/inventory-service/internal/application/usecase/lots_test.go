@@ -0,0 +1,356 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	domainErrors "github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/events"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/repository"
+)
+
+// MockLotRepository is a mock implementation of LotRepository
+type MockLotRepository struct {
+	mock.Mock
+}
+
+func (m *MockLotRepository) Receive(ctx context.Context, lot *entity.Lot) (*entity.InventoryItem, error) {
+	args := m.Called(ctx, lot)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.InventoryItem), args.Error(1)
+}
+
+func (m *MockLotRepository) FindByInventoryItemID(ctx context.Context, inventoryItemID uuid.UUID) ([]*entity.Lot, error) {
+	args := m.Called(ctx, inventoryItemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Lot), args.Error(1)
+}
+
+func (m *MockLotRepository) Allocate(ctx context.Context, reservationID, inventoryItemID uuid.UUID, quantity int, at time.Time) ([]*entity.LotAllocation, error) {
+	args := m.Called(ctx, reservationID, inventoryItemID, quantity, at)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.LotAllocation), args.Error(1)
+}
+
+func (m *MockLotRepository) Consume(ctx context.Context, reservationID uuid.UUID) ([]*entity.LotAllocation, error) {
+	args := m.Called(ctx, reservationID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.LotAllocation), args.Error(1)
+}
+
+func (m *MockLotRepository) Release(ctx context.Context, reservationID uuid.UUID) ([]*entity.LotAllocation, error) {
+	args := m.Called(ctx, reservationID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.LotAllocation), args.Error(1)
+}
+
+func (m *MockLotRepository) FindAllocations(ctx context.Context, reservationID uuid.UUID) ([]*entity.LotAllocation, error) {
+	args := m.Called(ctx, reservationID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.LotAllocation), args.Error(1)
+}
+
+func (m *MockLotRepository) QuarantineExpired(ctx context.Context, at time.Time) ([]repository.QuarantinedLot, error) {
+	args := m.Called(ctx, at)
+	quarantined, _ := args.Get(0).([]repository.QuarantinedLot)
+	return quarantined, args.Error(1)
+}
+
+func TestNewLotUseCases_NilDependencies_Panic(t *testing.T) {
+	assert.Panics(t, func() { NewReceiveLotUseCase(nil, new(MockLotRepository)) })
+	assert.Panics(t, func() { NewReceiveLotUseCase(new(MockInventoryRepository), nil) })
+	assert.Panics(t, func() { NewListLotsUseCase(nil, new(MockLotRepository)) })
+	assert.Panics(t, func() { NewListLotsUseCase(new(MockInventoryRepository), nil) })
+	assert.Panics(t, func() { NewGetReservationLotsUseCase(nil, new(MockLotRepository)) })
+	assert.Panics(t, func() { NewGetReservationLotsUseCase(new(MockReservationRepository), nil) })
+	assert.Panics(t, func() { NewQuarantineExpiredLotsUseCase(nil, new(MockPublisher)) })
+	assert.Panics(t, func() { NewQuarantineExpiredLotsUseCase(new(MockLotRepository), nil) })
+}
+
+func TestReceiveLotUseCase_Execute(t *testing.T) {
+	inventoryRepo := new(MockInventoryRepository)
+	lotRepo := new(MockLotRepository)
+	item, _ := entity.NewInventoryItem(uuid.New(), 10)
+	stored := *item
+	stored.Quantity = 34
+	inventoryRepo.On("FindByProductID", mock.Anything, item.ProductID).Return(item, nil)
+	lotRepo.On("Receive", mock.Anything, mock.MatchedBy(func(lot *entity.Lot) bool {
+		return lot.InventoryItemID == item.ID && lot.LotNumber == "L-7" && lot.Quantity == 24 &&
+			lot.BestBefore.Equal(time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC))
+	})).Return(&stored, nil)
+
+	bestBefore := time.Date(2026, 3, 31, 15, 0, 0, 0, time.UTC)
+	output, err := NewReceiveLotUseCase(inventoryRepo, lotRepo).Execute(context.Background(), ReceiveLotInput{
+		ProductID:  item.ProductID,
+		LotNumber:  " L-7 ",
+		Quantity:   24,
+		ReceivedAt: time.Date(2025, 12, 1, 8, 0, 0, 0, time.UTC),
+		BestBefore: &bestBefore,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "L-7", output.Lot.LotNumber)
+	assert.Equal(t, 34, output.Item.Quantity)
+	lotRepo.AssertExpectations(t)
+}
+
+func TestReceiveLotUseCase_Execute_Errors(t *testing.T) {
+	t.Run("should return not found for unknown products", func(t *testing.T) {
+		inventoryRepo := new(MockInventoryRepository)
+		lotRepo := new(MockLotRepository)
+		inventoryRepo.On("FindByProductID", mock.Anything, mock.Anything).Return(nil, domainErrors.ErrInventoryItemNotFound)
+
+		_, err := NewReceiveLotUseCase(inventoryRepo, lotRepo).Execute(context.Background(), ReceiveLotInput{ProductID: uuid.New(), LotNumber: "L-1", Quantity: 1})
+
+		assert.ErrorIs(t, err, domainErrors.ErrInventoryItemNotFound)
+		lotRepo.AssertNotCalled(t, "Receive", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should reject invalid lots before saving", func(t *testing.T) {
+		inventoryRepo := new(MockInventoryRepository)
+		lotRepo := new(MockLotRepository)
+		item, _ := entity.NewInventoryItem(uuid.New(), 10)
+		inventoryRepo.On("FindByProductID", mock.Anything, item.ProductID).Return(item, nil)
+
+		_, err := NewReceiveLotUseCase(inventoryRepo, lotRepo).Execute(context.Background(), ReceiveLotInput{ProductID: item.ProductID, Quantity: 5})
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		lotRepo.AssertNotCalled(t, "Receive", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return duplicate lot numbers", func(t *testing.T) {
+		inventoryRepo := new(MockInventoryRepository)
+		lotRepo := new(MockLotRepository)
+		item, _ := entity.NewInventoryItem(uuid.New(), 10)
+		inventoryRepo.On("FindByProductID", mock.Anything, item.ProductID).Return(item, nil)
+		lotRepo.On("Receive", mock.Anything, mock.Anything).Return(nil, domainErrors.ErrLotAlreadyExists)
+
+		_, err := NewReceiveLotUseCase(inventoryRepo, lotRepo).Execute(context.Background(), ReceiveLotInput{ProductID: item.ProductID, LotNumber: "L-1", Quantity: 5})
+
+		assert.ErrorIs(t, err, domainErrors.ErrLotAlreadyExists)
+	})
+}
+
+func TestListLotsUseCase_Execute(t *testing.T) {
+	inventoryRepo := new(MockInventoryRepository)
+	lotRepo := new(MockLotRepository)
+	item, _ := entity.NewInventoryItem(uuid.New(), 10)
+	lots := []*entity.Lot{{ID: uuid.New(), InventoryItemID: item.ID, LotNumber: "L-1"}}
+	inventoryRepo.On("FindByProductID", mock.Anything, item.ProductID).Return(item, nil)
+	lotRepo.On("FindByInventoryItemID", mock.Anything, item.ID).Return(lots, nil)
+
+	result, err := NewListLotsUseCase(inventoryRepo, lotRepo).Execute(context.Background(), item.ProductID)
+
+	require.NoError(t, err)
+	assert.Equal(t, lots, result)
+
+	inventoryRepo = new(MockInventoryRepository)
+	inventoryRepo.On("FindByProductID", mock.Anything, mock.Anything).Return(nil, errors.New("record not found"))
+	_, err = NewListLotsUseCase(inventoryRepo, lotRepo).Execute(context.Background(), uuid.New())
+	assert.ErrorIs(t, err, domainErrors.ErrInventoryItemNotFound)
+}
+
+func TestGetReservationLotsUseCase_Execute(t *testing.T) {
+	reservationID := uuid.New()
+
+	t.Run("should return the allocations without looking up the reservation", func(t *testing.T) {
+		reservationRepo := new(MockReservationRepository)
+		lotRepo := new(MockLotRepository)
+		allocations := []*entity.LotAllocation{{ReservationID: reservationID, LotID: uuid.New(), Quantity: 2, Status: entity.LotConsumed}}
+		lotRepo.On("FindAllocations", mock.Anything, reservationID).Return(allocations, nil)
+
+		result, err := NewGetReservationLotsUseCase(reservationRepo, lotRepo).Execute(context.Background(), reservationID)
+
+		require.NoError(t, err)
+		assert.Equal(t, allocations, result)
+		reservationRepo.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return no allocations for reservations from untracked stock", func(t *testing.T) {
+		reservationRepo := new(MockReservationRepository)
+		lotRepo := new(MockLotRepository)
+		reservation, _ := entity.NewReservation(uuid.New(), uuid.New(), 3)
+		lotRepo.On("FindAllocations", mock.Anything, reservationID).Return([]*entity.LotAllocation{}, nil)
+		reservationRepo.On("FindByID", mock.Anything, reservationID).Return(reservation, nil)
+
+		result, err := NewGetReservationLotsUseCase(reservationRepo, lotRepo).Execute(context.Background(), reservationID)
+
+		require.NoError(t, err)
+		assert.Empty(t, result)
+	})
+
+	t.Run("should return not found for unknown reservations", func(t *testing.T) {
+		reservationRepo := new(MockReservationRepository)
+		lotRepo := new(MockLotRepository)
+		lotRepo.On("FindAllocations", mock.Anything, reservationID).Return([]*entity.LotAllocation{}, nil)
+		reservationRepo.On("FindByID", mock.Anything, reservationID).Return(nil, errors.New("record not found"))
+
+		_, err := NewGetReservationLotsUseCase(reservationRepo, lotRepo).Execute(context.Background(), reservationID)
+
+		assert.ErrorIs(t, err, domainErrors.ErrReservationNotFound)
+	})
+}
+
+func TestQuarantineExpiredLotsUseCase_Execute(t *testing.T) {
+	now := time.Date(2025, 12, 2, 0, 5, 0, 0, time.UTC)
+	bestBefore := time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)
+	productID := uuid.New()
+	first := &entity.Lot{ID: uuid.New(), InventoryItemID: uuid.New(), LotNumber: "L-1", BestBefore: &bestBefore, UpdatedAt: now}
+	second := &entity.Lot{ID: uuid.New(), InventoryItemID: uuid.New(), LotNumber: "L-2", BestBefore: &bestBefore, UpdatedAt: now}
+
+	lotRepo := new(MockLotRepository)
+	publisher := new(MockPublisher)
+	lotRepo.On("QuarantineExpired", mock.Anything, now).Return([]repository.QuarantinedLot{
+		{Lot: first, ProductID: productID, Units: 4},
+		{Lot: second, ProductID: productID, Units: 1},
+	}, errors.New("lock timeout"))
+	publisher.On("PublishLotQuarantined", mock.Anything, mock.MatchedBy(func(event events.LotQuarantinedEvent) bool {
+		return event.EventType == events.RoutingKeyLotQuarantined &&
+			event.Version == events.LotQuarantinedVersion &&
+			event.Payload.LotID == first.ID.String() &&
+			event.Payload.LotNumber == "L-1" &&
+			event.Payload.ProductID == productID.String() &&
+			event.Payload.InventoryItemID == first.InventoryItemID.String() &&
+			event.Payload.Quantity == 4 &&
+			event.Payload.BestBefore == "2025-12-01" &&
+			event.Payload.QuarantinedAt.Equal(now)
+	})).Return(nil).Once()
+	publisher.On("PublishLotQuarantined", mock.Anything, mock.MatchedBy(func(event events.LotQuarantinedEvent) bool {
+		return event.Payload.LotID == second.ID.String()
+	})).Return(errors.New("broker down")).Once()
+
+	uc := NewQuarantineExpiredLotsUseCase(lotRepo, publisher)
+	uc.now = func() time.Time { return now }
+	output, err := uc.Execute(context.Background())
+
+	assert.EqualError(t, err, "lock timeout")
+	assert.Equal(t, &QuarantineExpiredLotsOutput{Lots: 2, Units: 5}, output)
+	publisher.AssertExpectations(t)
+}
+
+func TestReserveStockUseCase_Execute_WithLots(t *testing.T) {
+	inventoryRepo := new(MockInventoryRepository)
+	reservationRepo := new(MockReservationRepository)
+	publisher := new(MockPublisher)
+	lotRepo := new(MockLotRepository)
+	item, _ := entity.NewInventoryItem(uuid.New(), 100)
+	orderID := uuid.New()
+	allocations := []*entity.LotAllocation{{LotID: uuid.New(), Quantity: 5, Status: entity.LotAllocated}}
+
+	reservationRepo.On("ExistsByOrderID", mock.Anything, mock.Anything).Return(false, nil)
+	inventoryRepo.On("FindByProductID", mock.Anything, item.ProductID).Return(item, nil)
+	inventoryRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+	reservationRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+	publisher.On("PublishStockReserved", mock.Anything, mock.Anything).Return(nil)
+	lotRepo.On("Allocate", mock.Anything, mock.Anything, item.ID, 5, mock.Anything).Return(allocations, nil)
+
+	uc := NewReserveStockUseCase(inventoryRepo, reservationRepo, publisher).WithLots(lotRepo)
+	output, err := uc.Execute(context.Background(), ReserveStockInput{ProductID: item.ProductID, OrderID: orderID, Quantity: 5})
+
+	require.NoError(t, err)
+	assert.Equal(t, allocations, output.Lots)
+	lotRepo.AssertCalled(t, "Allocate", mock.Anything, output.ReservationID, item.ID, 5, mock.Anything)
+
+	// An allocation failure does not fail the reservation
+	lotRepo = new(MockLotRepository)
+	lotRepo.On("Allocate", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("deadlock"))
+	output, err = NewReserveStockUseCase(inventoryRepo, reservationRepo, publisher).WithLots(lotRepo).
+		Execute(context.Background(), ReserveStockInput{ProductID: item.ProductID, OrderID: uuid.New(), Quantity: 5})
+	require.NoError(t, err)
+	assert.Nil(t, output.Lots)
+}
+
+func TestConfirmReservationUseCase_Execute_WithLots(t *testing.T) {
+	inventoryRepo := new(MockInventoryRepository)
+	reservationRepo := new(MockReservationRepository)
+	publisher := new(MockPublisher)
+	lotRepo := new(MockLotRepository)
+	item, _ := entity.NewInventoryItem(uuid.New(), 100)
+	require.NoError(t, item.Reserve(10))
+	reservation, _ := entity.NewReservation(item.ID, uuid.New(), 10)
+	consumed := []*entity.LotAllocation{{ReservationID: reservation.ID, LotID: uuid.New(), Quantity: 10, Status: entity.LotConsumed}}
+
+	reservationRepo.On("FindByID", mock.Anything, reservation.ID).Return(reservation, nil)
+	inventoryRepo.On("FindByID", mock.Anything, item.ID).Return(item, nil)
+	inventoryRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+	reservationRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+	publisher.On("PublishStockConfirmed", mock.Anything, mock.Anything).Return(nil)
+	lotRepo.On("Consume", mock.Anything, reservation.ID).Return(consumed, nil)
+
+	uc := NewConfirmReservationUseCase(inventoryRepo, reservationRepo, publisher).WithLots(lotRepo)
+	output, err := uc.Execute(context.Background(), ConfirmReservationInput{ReservationID: reservation.ID})
+
+	require.NoError(t, err)
+	assert.Equal(t, consumed, output.Lots)
+	lotRepo.AssertExpectations(t)
+}
+
+func TestReleaseReservationUseCases_WithLots(t *testing.T) {
+	t.Run("should release the lots of a released reservation", func(t *testing.T) {
+		inventoryRepo := new(MockInventoryRepository)
+		reservationRepo := new(MockReservationRepository)
+		publisher := new(MockPublisher)
+		lotRepo := new(MockLotRepository)
+		item, _ := entity.NewInventoryItem(uuid.New(), 100)
+		require.NoError(t, item.Reserve(10))
+		reservation, _ := entity.NewReservation(item.ID, uuid.New(), 10)
+
+		reservationRepo.On("FindByID", mock.Anything, reservation.ID).Return(reservation, nil)
+		inventoryRepo.On("FindByID", mock.Anything, item.ID).Return(item, nil)
+		inventoryRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+		reservationRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+		publisher.On("PublishStockReleased", mock.Anything, mock.Anything).Return(nil)
+		lotRepo.On("Release", mock.Anything, reservation.ID).Return(nil, errors.New("deadlock"))
+
+		uc := NewReleaseReservationUseCase(inventoryRepo, reservationRepo, publisher).WithLots(lotRepo)
+		_, err := uc.Execute(context.Background(), ReleaseReservationInput{ReservationID: reservation.ID})
+
+		require.NoError(t, err, "lot failures do not fail the release")
+		lotRepo.AssertExpectations(t)
+	})
+
+	t.Run("should release the lots of an expired reservation", func(t *testing.T) {
+		inventoryRepo := new(MockInventoryRepository)
+		reservationRepo := new(MockReservationRepository)
+		publisher := new(MockPublisher)
+		lotRepo := new(MockLotRepository)
+		item, _ := entity.NewInventoryItem(uuid.New(), 100)
+		require.NoError(t, item.Reserve(10))
+		reservation, _ := entity.NewReservation(item.ID, uuid.New(), 10)
+		reservation.ExpiresAt = time.Now().Add(-time.Minute)
+
+		reservationRepo.On("FindExpired", mock.Anything, mock.Anything).Return([]*entity.Reservation{reservation}, nil)
+		inventoryRepo.On("FindByID", mock.Anything, item.ID).Return(item, nil)
+		inventoryRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+		reservationRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+		publisher.On("PublishStockReleased", mock.Anything, mock.Anything).Return(nil)
+		lotRepo.On("Release", mock.Anything, reservation.ID).Return([]*entity.LotAllocation{}, nil)
+
+		uc := NewReleaseExpiredReservationsUseCase(inventoryRepo, reservationRepo, publisher).WithLots(lotRepo)
+		output, err := uc.Execute(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, output.TotalReleased)
+		lotRepo.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,158 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+// OrderReservationOutput represents the reservation held for an order together
+// with the current stock of the product it reserves and, for bundles, of its components
+type OrderReservationOutput struct {
+	Reservation *entity.Reservation
+	// TimeUntilExpiry is the remaining TTL of a pending reservation; zero once it
+	// has expired or left the pending status
+	TimeUntilExpiry time.Duration
+	Item            *entity.InventoryItem
+	// Serials are the serial numbers of the units the reservation holds or sold,
+	// for serial-tracked products
+	Serials []string
+	// Components are the units of every component the reservation holds, sold
+	// or released, for bundles
+	Components []*entity.ReservationComponent
+	// ComponentItems are the current stock of the components, in Components order
+	ComponentItems []*entity.InventoryItem
+}
+
+// GetOrderReservationUseCase handles looking up a reservation by the order it belongs to
+type GetOrderReservationUseCase struct {
+	reservationRepo repository.ReservationRepository
+	inventoryRepo   repository.InventoryRepository
+	serials         repository.SerialUnitRepository
+	bundles         repository.BundleRepository
+}
+
+// NewGetOrderReservationUseCase creates a new instance of GetOrderReservationUseCase
+func NewGetOrderReservationUseCase(
+	reservationRepo repository.ReservationRepository,
+	inventoryRepo repository.InventoryRepository,
+) *GetOrderReservationUseCase {
+	if reservationRepo == nil {
+		panic("reservationRepo cannot be nil")
+	}
+	if inventoryRepo == nil {
+		panic("inventoryRepo cannot be nil")
+	}
+
+	return &GetOrderReservationUseCase{
+		reservationRepo: reservationRepo,
+		inventoryRepo:   inventoryRepo,
+	}
+}
+
+// WithSerials makes the use case list the units reserved for serial-tracked products
+func (uc *GetOrderReservationUseCase) WithSerials(serials repository.SerialUnitRepository) *GetOrderReservationUseCase {
+	uc.serials = serials
+	return uc
+}
+
+// WithBundles makes the use case list the components reserved for bundles
+func (uc *GetOrderReservationUseCase) WithBundles(bundles repository.BundleRepository) *GetOrderReservationUseCase {
+	uc.bundles = bundles
+	return uc
+}
+
+// Execute returns the reservation of an order, its remaining TTL and the
+// availability of the reserved product, and of its components for bundles
+func (uc *GetOrderReservationUseCase) Execute(ctx context.Context, orderID uuid.UUID) (*OrderReservationOutput, error) {
+	reservation, err := findReservation(ctx, uc.reservationRepo, uuid.Nil, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	item, err := uc.inventoryRepo.FindByID(ctx, reservation.InventoryItemID)
+	if err != nil {
+		return nil, errors.ErrInventoryItemNotFound.WithDetails(err.Error())
+	}
+
+	output := NewOrderReservationOutput(reservation, item)
+	if uc.serials != nil && item.SerialTracked {
+		units, err := uc.serials.FindByReservationID(ctx, reservation.ID)
+		if err != nil {
+			return nil, err
+		}
+		output.Serials = entity.SerialNumbers(units)
+	}
+	if uc.bundles != nil && item.Bundle {
+		output.Components, err = uc.bundles.FindComponents(ctx, reservation.ID)
+		if err != nil {
+			return nil, err
+		}
+		output.ComponentItems, err = uc.findComponentItems(ctx, output.Components)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return output, nil
+}
+
+// findComponentItems loads the inventory item of every component, in the same order
+func (uc *GetOrderReservationUseCase) findComponentItems(ctx context.Context, components []*entity.ReservationComponent) ([]*entity.InventoryItem, error) {
+	if len(components) == 0 {
+		return nil, nil
+	}
+	productIDs := make([]uuid.UUID, len(components))
+	for i, component := range components {
+		productIDs[i] = component.ProductID
+	}
+
+	found, err := uc.inventoryRepo.FindByProductIDs(ctx, productIDs)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]*entity.InventoryItem, len(components))
+	for i, component := range components {
+		item, ok := found[component.ProductID]
+		if !ok {
+			return nil, errors.ErrInventoryItemNotFound.WithDetails("component " + component.ProductID.String())
+		}
+		items[i] = item
+	}
+	return items, nil
+}
+
+// NewOrderReservationOutput builds the order view of a reservation and its inventory item
+func NewOrderReservationOutput(reservation *entity.Reservation, item *entity.InventoryItem) *OrderReservationOutput {
+	output := &OrderReservationOutput{
+		Reservation: reservation,
+		Item:        item,
+	}
+	if reservation.IsPending() {
+		output.TimeUntilExpiry = reservation.TimeUntilExpiry()
+	}
+	return output
+}
+
+// findReservation loads a reservation by ID, or by order ID when no ID is given
+func findReservation(ctx context.Context, repo repository.ReservationRepository, reservationID, orderID uuid.UUID) (*entity.Reservation, error) {
+	var (
+		reservation *entity.Reservation
+		err         error
+	)
+	switch {
+	case reservationID != uuid.Nil:
+		reservation, err = repo.FindByID(ctx, reservationID)
+	case orderID != uuid.Nil:
+		reservation, err = repo.FindByOrderID(ctx, orderID)
+	default:
+		return nil, errors.ErrInvalidInput.WithDetails("reservation ID or order ID is required")
+	}
+	if err != nil {
+		return nil, errors.ErrReservationNotFound.WithDetails(err.Error())
+	}
+	return reservation, nil
+}
@@ -0,0 +1,161 @@
+package usecase
+
+import (
+	"context"
+	goerrors "errors"
+	"testing"
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewGetOrderReservationUseCase_NilRepos_Panic(t *testing.T) {
+	assert.Panics(t, func() { NewGetOrderReservationUseCase(nil, new(MockInventoryRepository)) })
+	assert.Panics(t, func() { NewGetOrderReservationUseCase(new(MockReservationRepository), nil) })
+}
+
+func TestGetOrderReservationUseCase_Execute_Pending(t *testing.T) {
+	mockInventoryRepo := new(MockInventoryRepository)
+	mockReservationRepo := new(MockReservationRepository)
+	uc := NewGetOrderReservationUseCase(mockReservationRepo, mockInventoryRepo)
+
+	item, _ := entity.NewInventoryItem(uuid.New(), 100)
+	require.NoError(t, item.Reserve(10))
+	orderID := uuid.New()
+	reservation, _ := entity.NewReservation(item.ID, orderID, 10)
+
+	mockReservationRepo.On("FindByOrderID", mock.Anything, orderID).Return(reservation, nil)
+	mockInventoryRepo.On("FindByID", mock.Anything, item.ID).Return(item, nil)
+
+	output, err := uc.Execute(context.Background(), orderID)
+
+	require.NoError(t, err)
+	assert.Same(t, reservation, output.Reservation)
+	assert.Same(t, item, output.Item)
+	assert.Positive(t, output.TimeUntilExpiry)
+	assert.LessOrEqual(t, output.TimeUntilExpiry, entity.DefaultReservationDuration)
+	mockReservationRepo.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
+}
+
+func TestGetOrderReservationUseCase_Execute_ConfirmedHasNoTTL(t *testing.T) {
+	mockInventoryRepo := new(MockInventoryRepository)
+	mockReservationRepo := new(MockReservationRepository)
+	uc := NewGetOrderReservationUseCase(mockReservationRepo, mockInventoryRepo)
+
+	item, _ := entity.NewInventoryItem(uuid.New(), 100)
+	orderID := uuid.New()
+	reservation, _ := entity.NewReservation(item.ID, orderID, 10)
+	require.NoError(t, reservation.Confirm())
+
+	mockReservationRepo.On("FindByOrderID", mock.Anything, orderID).Return(reservation, nil)
+	mockInventoryRepo.On("FindByID", mock.Anything, item.ID).Return(item, nil)
+
+	output, err := uc.Execute(context.Background(), orderID)
+
+	require.NoError(t, err)
+	assert.Zero(t, output.TimeUntilExpiry)
+}
+
+func TestGetOrderReservationUseCase_Execute_NotFound(t *testing.T) {
+	mockInventoryRepo := new(MockInventoryRepository)
+	mockReservationRepo := new(MockReservationRepository)
+	uc := NewGetOrderReservationUseCase(mockReservationRepo, mockInventoryRepo)
+
+	orderID := uuid.New()
+	mockReservationRepo.On("FindByOrderID", mock.Anything, orderID).Return(nil, errors.ErrReservationNotFound)
+
+	_, err := uc.Execute(context.Background(), orderID)
+
+	assert.ErrorIs(t, err, errors.ErrReservationNotFound)
+	mockInventoryRepo.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
+}
+
+func TestGetOrderReservationUseCase_Execute_ItemMissing(t *testing.T) {
+	mockInventoryRepo := new(MockInventoryRepository)
+	mockReservationRepo := new(MockReservationRepository)
+	uc := NewGetOrderReservationUseCase(mockReservationRepo, mockInventoryRepo)
+
+	orderID := uuid.New()
+	reservation, _ := entity.NewReservation(uuid.New(), orderID, 1)
+	mockReservationRepo.On("FindByOrderID", mock.Anything, orderID).Return(reservation, nil)
+	mockInventoryRepo.On("FindByID", mock.Anything, reservation.InventoryItemID).Return(nil, goerrors.New("record not found"))
+
+	_, err := uc.Execute(context.Background(), orderID)
+
+	assert.ErrorIs(t, err, errors.ErrInventoryItemNotFound)
+}
+
+func TestConfirmReservationUseCase_Execute_ByOrderID(t *testing.T) {
+	mockInventoryRepo := new(MockInventoryRepository)
+	mockReservationRepo := new(MockReservationRepository)
+	mockPublisher := new(MockPublisher)
+	uc := NewConfirmReservationUseCase(mockInventoryRepo, mockReservationRepo, mockPublisher)
+
+	item, _ := entity.NewInventoryItem(uuid.New(), 100)
+	require.NoError(t, item.Reserve(20))
+	orderID := uuid.New()
+	reservation, _ := entity.NewReservation(item.ID, orderID, 20)
+
+	mockReservationRepo.On("FindByOrderID", mock.Anything, orderID).Return(reservation, nil)
+	mockInventoryRepo.On("FindByID", mock.Anything, item.ID).Return(item, nil)
+	mockInventoryRepo.On("Update", mock.Anything, mock.AnythingOfType("*entity.InventoryItem")).Return(nil)
+	mockReservationRepo.On("Update", mock.Anything, mock.AnythingOfType("*entity.Reservation")).Return(nil)
+	mockPublisher.On("PublishStockConfirmed", mock.Anything, mock.Anything).Return(nil)
+
+	output, err := uc.Execute(context.Background(), ConfirmReservationInput{OrderID: orderID})
+
+	require.NoError(t, err)
+	assert.Equal(t, reservation.ID, output.ReservationID)
+	assert.Equal(t, 80, output.FinalStock)
+	mockReservationRepo.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
+}
+
+func TestReleaseReservationUseCase_Execute_ByOrderID(t *testing.T) {
+	mockInventoryRepo := new(MockInventoryRepository)
+	mockReservationRepo := new(MockReservationRepository)
+	mockPublisher := new(MockPublisher)
+	uc := NewReleaseReservationUseCase(mockInventoryRepo, mockReservationRepo, mockPublisher)
+
+	item, _ := entity.NewInventoryItem(uuid.New(), 100)
+	require.NoError(t, item.Reserve(20))
+	orderID := uuid.New()
+	reservation, _ := entity.NewReservation(item.ID, orderID, 20)
+
+	mockReservationRepo.On("FindByOrderID", mock.Anything, orderID).Return(reservation, nil)
+	mockInventoryRepo.On("FindByID", mock.Anything, item.ID).Return(item, nil)
+	mockInventoryRepo.On("Update", mock.Anything, mock.AnythingOfType("*entity.InventoryItem")).Return(nil)
+	mockReservationRepo.On("Update", mock.Anything, mock.AnythingOfType("*entity.Reservation")).Return(nil)
+	mockPublisher.On("PublishStockReleased", mock.Anything, mock.Anything).Return(nil)
+
+	output, err := uc.Execute(context.Background(), ReleaseReservationInput{OrderID: orderID})
+
+	require.NoError(t, err)
+	assert.Equal(t, reservation.ID, output.ReservationID)
+	assert.Equal(t, 100, output.AvailableStock)
+}
+
+func TestFindReservation_RequiresAnIdentifier(t *testing.T) {
+	mockReservationRepo := new(MockReservationRepository)
+
+	_, err := findReservation(context.Background(), mockReservationRepo, uuid.Nil, uuid.Nil)
+
+	assert.ErrorIs(t, err, errors.ErrInvalidInput)
+	mockReservationRepo.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
+}
+
+func TestFindReservation_PrefersReservationID(t *testing.T) {
+	mockReservationRepo := new(MockReservationRepository)
+	reservation := &entity.Reservation{ID: uuid.New(), ExpiresAt: time.Now().Add(time.Minute)}
+	mockReservationRepo.On("FindByID", mock.Anything, reservation.ID).Return(reservation, nil)
+
+	found, err := findReservation(context.Background(), mockReservationRepo, reservation.ID, uuid.New())
+
+	require.NoError(t, err)
+	assert.Same(t, reservation, found)
+	mockReservationRepo.AssertNotCalled(t, "FindByOrderID", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,165 @@
+package usecase
+
+import (
+	"context"
+	goerrors "errors"
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+// reserveWithinPurchaseLimits runs reserve unless the order or the customer would
+// reserve more of the product than its limits allow, returning ErrPurchaseLimitExceeded.
+// For products limited per customer, what the customer reserved is summed and
+// reserve runs under the customer's purchase lock, so that concurrent reservations
+// of theirs cannot all pass the check; a held lock is retried with the policy.
+// Products limited per customer are not reserved for requests that name no user.
+func reserveWithinPurchaseLimits(
+	ctx context.Context,
+	limits repository.PurchaseLimitRepository,
+	retry RetryPolicy,
+	productID uuid.UUID,
+	quantity int,
+	userID string,
+	reserve func() error,
+) error {
+	limit, err := limits.FindByProductID(ctx, productID)
+	if goerrors.Is(err, errors.ErrPurchaseLimitNotFound) {
+		return reserve()
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := limit.CheckOrder(quantity); err != nil {
+		return err
+	}
+	if !limit.LimitsCustomers() {
+		return reserve()
+	}
+	if userID == "" {
+		return errors.ErrPurchaseLimitExceeded.WithDetails("the product is limited per customer and the request names no user")
+	}
+
+	return retry.run(ctx, OperationReserve, func() (uuid.UUID, error) {
+		return productID, limits.LockCustomer(ctx, limit.InventoryItemID, userID, func() error {
+			bought, err := limits.CustomerQuantity(ctx, limit.InventoryItemID, userID, limit.WindowStart(time.Now()))
+			if err != nil {
+				return err
+			}
+			if err := limit.CheckCustomer(quantity, bought); err != nil {
+				return err
+			}
+			return reserve()
+		})
+	})
+}
+
+// GetPurchaseLimitUseCase reports the purchase limits of a product
+type GetPurchaseLimitUseCase struct {
+	limits repository.PurchaseLimitRepository
+}
+
+// NewGetPurchaseLimitUseCase creates a new instance
+func NewGetPurchaseLimitUseCase(limits repository.PurchaseLimitRepository) *GetPurchaseLimitUseCase {
+	if limits == nil {
+		panic("limits cannot be nil")
+	}
+
+	return &GetPurchaseLimitUseCase{
+		limits: limits,
+	}
+}
+
+// Execute returns the limits of the product, ErrPurchaseLimitNotFound if it has none
+func (uc *GetPurchaseLimitUseCase) Execute(ctx context.Context, productID uuid.UUID) (*entity.PurchaseLimit, error) {
+	return uc.limits.FindByProductID(ctx, productID)
+}
+
+// SetPurchaseLimitInput represents the purchase limits of a product, replacing
+// the previous ones. A zero limit is not enforced.
+type SetPurchaseLimitInput struct {
+	ProductID      uuid.UUID
+	MaxPerOrder    int
+	MaxPerCustomer int
+	Window         time.Duration // customer limits only
+}
+
+// SetPurchaseLimitUseCase sets the purchase limits of a product
+type SetPurchaseLimitUseCase struct {
+	inventoryRepo repository.InventoryRepository
+	limits        repository.PurchaseLimitRepository
+}
+
+// NewSetPurchaseLimitUseCase creates a new instance
+func NewSetPurchaseLimitUseCase(
+	inventoryRepo repository.InventoryRepository,
+	limits repository.PurchaseLimitRepository,
+) *SetPurchaseLimitUseCase {
+	if inventoryRepo == nil {
+		panic("inventoryRepo cannot be nil")
+	}
+	if limits == nil {
+		panic("limits cannot be nil")
+	}
+
+	return &SetPurchaseLimitUseCase{
+		inventoryRepo: inventoryRepo,
+		limits:        limits,
+	}
+}
+
+// Execute validates the limits and stores them. Reservations made before apply
+// to customer limits but are never undone.
+func (uc *SetPurchaseLimitUseCase) Execute(ctx context.Context, input SetPurchaseLimitInput) (*entity.PurchaseLimit, error) {
+	item, err := uc.inventoryRepo.FindByProductID(ctx, input.ProductID)
+	if err != nil {
+		return nil, errors.ErrInventoryItemNotFound.WithDetails(err.Error())
+	}
+
+	limit, err := entity.NewPurchaseLimit(item, input.MaxPerOrder, input.MaxPerCustomer, input.Window)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.limits.Save(ctx, limit); err != nil {
+		return nil, err
+	}
+	return limit, nil
+}
+
+// DeletePurchaseLimitUseCase removes the purchase limits of a product
+type DeletePurchaseLimitUseCase struct {
+	inventoryRepo repository.InventoryRepository
+	limits        repository.PurchaseLimitRepository
+}
+
+// NewDeletePurchaseLimitUseCase creates a new instance
+func NewDeletePurchaseLimitUseCase(
+	inventoryRepo repository.InventoryRepository,
+	limits repository.PurchaseLimitRepository,
+) *DeletePurchaseLimitUseCase {
+	if inventoryRepo == nil {
+		panic("inventoryRepo cannot be nil")
+	}
+	if limits == nil {
+		panic("limits cannot be nil")
+	}
+
+	return &DeletePurchaseLimitUseCase{
+		inventoryRepo: inventoryRepo,
+		limits:        limits,
+	}
+}
+
+// Execute removes the limits, ErrPurchaseLimitNotFound if the product has none
+func (uc *DeletePurchaseLimitUseCase) Execute(ctx context.Context, productID uuid.UUID) error {
+	item, err := uc.inventoryRepo.FindByProductID(ctx, productID)
+	if err != nil {
+		return errors.ErrInventoryItemNotFound.WithDetails(err.Error())
+	}
+
+	return uc.limits.Delete(ctx, item.ID)
+}
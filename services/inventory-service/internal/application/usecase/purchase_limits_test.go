@@ -0,0 +1,370 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	domainErrors "github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/events"
+)
+
+// MockPurchaseLimitRepository is a mock implementation of PurchaseLimitRepository
+type MockPurchaseLimitRepository struct {
+	mock.Mock
+}
+
+func (m *MockPurchaseLimitRepository) FindByProductID(ctx context.Context, productID uuid.UUID) (*entity.PurchaseLimit, error) {
+	args := m.Called(ctx, productID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.PurchaseLimit), args.Error(1)
+}
+
+func (m *MockPurchaseLimitRepository) Save(ctx context.Context, limit *entity.PurchaseLimit) error {
+	args := m.Called(ctx, limit)
+	return args.Error(0)
+}
+
+func (m *MockPurchaseLimitRepository) Delete(ctx context.Context, inventoryItemID uuid.UUID) error {
+	args := m.Called(ctx, inventoryItemID)
+	return args.Error(0)
+}
+
+func (m *MockPurchaseLimitRepository) CustomerQuantity(
+	ctx context.Context,
+	inventoryItemID uuid.UUID,
+	userID string,
+	since time.Time,
+) (int, error) {
+	args := m.Called(ctx, inventoryItemID, userID, since)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockPurchaseLimitRepository) LockCustomer(
+	ctx context.Context,
+	inventoryItemID uuid.UUID,
+	userID string,
+	fn func() error,
+) error {
+	args := m.Called(ctx, inventoryItemID, userID)
+	if err := args.Error(0); err != nil {
+		return err
+	}
+	return fn()
+}
+
+func TestNewPurchaseLimitUseCases_NilDependencies_Panic(t *testing.T) {
+	assert.Panics(t, func() { NewGetPurchaseLimitUseCase(nil) })
+	assert.Panics(t, func() { NewSetPurchaseLimitUseCase(nil, new(MockPurchaseLimitRepository)) })
+	assert.Panics(t, func() { NewSetPurchaseLimitUseCase(new(MockInventoryRepository), nil) })
+	assert.Panics(t, func() { NewDeletePurchaseLimitUseCase(nil, new(MockPurchaseLimitRepository)) })
+	assert.Panics(t, func() { NewDeletePurchaseLimitUseCase(new(MockInventoryRepository), nil) })
+}
+
+func TestGetPurchaseLimitUseCase_Execute(t *testing.T) {
+	productID := uuid.New()
+	limitRepo := new(MockPurchaseLimitRepository)
+	limitRepo.On("FindByProductID", mock.Anything, productID).Return(nil, domainErrors.ErrPurchaseLimitNotFound)
+
+	_, err := NewGetPurchaseLimitUseCase(limitRepo).Execute(context.Background(), productID)
+
+	assert.ErrorIs(t, err, domainErrors.ErrPurchaseLimitNotFound)
+}
+
+func TestSetPurchaseLimitUseCase_Execute(t *testing.T) {
+	item, err := entity.NewInventoryItem(uuid.New(), 100)
+	require.NoError(t, err)
+
+	t.Run("should store the limits", func(t *testing.T) {
+		inventoryRepo := new(MockInventoryRepository)
+		limitRepo := new(MockPurchaseLimitRepository)
+		inventoryRepo.On("FindByProductID", mock.Anything, item.ProductID).Return(item, nil)
+		limitRepo.On("Save", mock.Anything, mock.MatchedBy(func(limit *entity.PurchaseLimit) bool {
+			return limit.InventoryItemID == item.ID && limit.MaxPerOrder == 2 && limit.MaxPerCustomer == 4
+		})).Return(nil)
+
+		limit, err := NewSetPurchaseLimitUseCase(inventoryRepo, limitRepo).Execute(context.Background(), SetPurchaseLimitInput{
+			ProductID:      item.ProductID,
+			MaxPerOrder:    2,
+			MaxPerCustomer: 4,
+			Window:         24 * time.Hour,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 24*time.Hour, limit.Window)
+		limitRepo.AssertExpectations(t)
+	})
+
+	t.Run("should reject invalid limits", func(t *testing.T) {
+		inventoryRepo := new(MockInventoryRepository)
+		limitRepo := new(MockPurchaseLimitRepository)
+		inventoryRepo.On("FindByProductID", mock.Anything, item.ProductID).Return(item, nil)
+
+		_, err := NewSetPurchaseLimitUseCase(inventoryRepo, limitRepo).Execute(context.Background(), SetPurchaseLimitInput{
+			ProductID:      item.ProductID,
+			MaxPerCustomer: 4,
+		})
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidPurchaseLimit)
+		limitRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should fail for unknown products", func(t *testing.T) {
+		inventoryRepo := new(MockInventoryRepository)
+		inventoryRepo.On("FindByProductID", mock.Anything, mock.Anything).Return(nil, errors.New("record not found"))
+
+		_, err := NewSetPurchaseLimitUseCase(inventoryRepo, new(MockPurchaseLimitRepository)).
+			Execute(context.Background(), SetPurchaseLimitInput{ProductID: uuid.New(), MaxPerOrder: 1})
+
+		assert.ErrorIs(t, err, domainErrors.ErrInventoryItemNotFound)
+	})
+}
+
+func TestDeletePurchaseLimitUseCase_Execute(t *testing.T) {
+	item, err := entity.NewInventoryItem(uuid.New(), 100)
+	require.NoError(t, err)
+	inventoryRepo := new(MockInventoryRepository)
+	limitRepo := new(MockPurchaseLimitRepository)
+	inventoryRepo.On("FindByProductID", mock.Anything, item.ProductID).Return(item, nil)
+	limitRepo.On("Delete", mock.Anything, item.ID).Return(nil)
+
+	require.NoError(t, NewDeletePurchaseLimitUseCase(inventoryRepo, limitRepo).Execute(context.Background(), item.ProductID))
+	limitRepo.AssertExpectations(t)
+}
+
+func TestReserveStockUseCase_Execute_WithPurchaseLimits(t *testing.T) {
+	item, err := entity.NewInventoryItem(uuid.New(), 100)
+	require.NoError(t, err)
+	limit := &entity.PurchaseLimit{InventoryItemID: item.ID, MaxPerOrder: 3, MaxPerCustomer: 4, Window: 24 * time.Hour}
+	ctx := ContextWithUserID(context.Background(), "user-42")
+
+	t.Run("should reserve within the limits for the user of the context", func(t *testing.T) {
+		inventoryRepo := new(MockInventoryRepository)
+		reservationRepo := new(MockReservationRepository)
+		publisher := new(MockPublisher)
+		limitRepo := new(MockPurchaseLimitRepository)
+		current := *item
+
+		reservationRepo.On("ExistsByOrderID", mock.Anything, mock.Anything).Return(false, nil)
+		limitRepo.On("FindByProductID", mock.Anything, item.ProductID).Return(limit, nil)
+		limitRepo.On("CustomerQuantity", mock.Anything, item.ID, "user-42", mock.MatchedBy(func(since time.Time) bool {
+			return time.Since(since) >= 24*time.Hour && time.Since(since) < 25*time.Hour
+		})).Return(1, nil)
+		limitRepo.On("LockCustomer", mock.Anything, item.ID, "user-42").Return(nil)
+		inventoryRepo.On("FindByProductID", mock.Anything, item.ProductID).Return(&current, nil)
+		inventoryRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+		reservationRepo.On("Save", mock.Anything, mock.MatchedBy(func(r *entity.Reservation) bool {
+			return r.UserID == "user-42"
+		})).Return(nil)
+		publisher.On("PublishStockReserved", mock.Anything, mock.MatchedBy(func(event events.StockReservedEvent) bool {
+			return event.Payload.UserID == "user-42"
+		})).Return(nil)
+
+		uc := NewReserveStockUseCase(inventoryRepo, reservationRepo, publisher).WithPurchaseLimits(limitRepo)
+		_, err := uc.Execute(ctx, ReserveStockInput{ProductID: item.ProductID, OrderID: uuid.New(), Quantity: 3})
+
+		require.NoError(t, err)
+		reservationRepo.AssertExpectations(t)
+		publisher.AssertExpectations(t)
+	})
+
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		quantity    int
+		bought      int
+		wantDetails string
+	}{
+		{"order limit", ctx, 4, 0, "at most 3 per order"},
+		{"customer limit", ctx, 2, 3, "at most 4 per customer"},
+		{"no user", context.Background(), 1, 0, "names no user"},
+	}
+	for _, tt := range tests {
+		t.Run("should reject reservations over the "+tt.name, func(t *testing.T) {
+			inventoryRepo := new(MockInventoryRepository)
+			reservationRepo := new(MockReservationRepository)
+			publisher := new(MockPublisher)
+			limitRepo := new(MockPurchaseLimitRepository)
+			orderID := uuid.New()
+
+			reservationRepo.On("ExistsByOrderID", mock.Anything, mock.Anything).Return(false, nil)
+			limitRepo.On("FindByProductID", mock.Anything, item.ProductID).Return(limit, nil)
+			limitRepo.On("CustomerQuantity", mock.Anything, item.ID, "user-42", mock.Anything).Return(tt.bought, nil)
+			limitRepo.On("LockCustomer", mock.Anything, item.ID, "user-42").Return(nil).Maybe()
+			publisher.On("PublishStockFailed", mock.Anything, mock.MatchedBy(func(event events.StockFailedEvent) bool {
+				return event.EventType == events.RoutingKeyStockFailed &&
+					event.Payload.OperationType == OperationReserve &&
+					event.Payload.OrderID == orderID.String() &&
+					*event.Payload.Quantity == tt.quantity &&
+					event.Payload.UserID == UserIDFromContext(tt.ctx) &&
+					event.Payload.ErrorCode == "PURCHASE_LIMIT_EXCEEDED"
+			})).Return(errors.New("broker down"))
+
+			uc := NewReserveStockUseCase(inventoryRepo, reservationRepo, publisher).WithPurchaseLimits(limitRepo)
+			_, err := uc.Execute(tt.ctx, ReserveStockInput{ProductID: item.ProductID, OrderID: orderID, Quantity: tt.quantity})
+
+			assert.ErrorIs(t, err, domainErrors.ErrPurchaseLimitExceeded)
+			assert.Contains(t, err.Error(), tt.wantDetails)
+			publisher.AssertExpectations(t)
+			inventoryRepo.AssertNotCalled(t, "FindByProductID", mock.Anything, mock.Anything)
+			reservationRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+		})
+	}
+
+	t.Run("should reserve under the purchase lock of the customer", func(t *testing.T) {
+		inventoryRepo := new(MockInventoryRepository)
+		reservationRepo := new(MockReservationRepository)
+		publisher := new(MockPublisher)
+		limitRepo := new(MockPurchaseLimitRepository)
+		current := *item
+		locked := false
+
+		reservationRepo.On("ExistsByOrderID", mock.Anything, mock.Anything).Return(false, nil)
+		limitRepo.On("FindByProductID", mock.Anything, item.ProductID).Return(limit, nil)
+		limitRepo.On("LockCustomer", mock.Anything, item.ID, "user-42").
+			Return(domainErrors.ErrOptimisticLockFailure).Once()
+		limitRepo.On("LockCustomer", mock.Anything, item.ID, "user-42").
+			Run(func(mock.Arguments) { locked = true }).Return(nil).Once()
+		limitRepo.On("CustomerQuantity", mock.Anything, item.ID, "user-42", mock.Anything).
+			Run(func(mock.Arguments) { assert.True(t, locked, "summed under the lock") }).Return(0, nil)
+		inventoryRepo.On("FindByProductID", mock.Anything, item.ProductID).Return(&current, nil)
+		inventoryRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+		reservationRepo.On("Save", mock.Anything, mock.Anything).
+			Run(func(mock.Arguments) { assert.True(t, locked, "saved under the lock") }).Return(nil)
+		publisher.On("PublishStockReserved", mock.Anything, mock.Anything).Return(nil)
+
+		uc := NewReserveStockUseCase(inventoryRepo, reservationRepo, publisher).
+			WithPurchaseLimits(limitRepo).
+			WithRetryPolicy(RetryPolicy{MaxAttempts: 2})
+		_, err := uc.Execute(ctx, ReserveStockInput{ProductID: item.ProductID, OrderID: uuid.New(), Quantity: 1})
+
+		require.NoError(t, err)
+		limitRepo.AssertExpectations(t)
+		reservationRepo.AssertExpectations(t)
+	})
+
+	t.Run("should report contention while the purchase lock stays held", func(t *testing.T) {
+		reservationRepo := new(MockReservationRepository)
+		limitRepo := new(MockPurchaseLimitRepository)
+		inventoryRepo := new(MockInventoryRepository)
+		reservationRepo.On("ExistsByOrderID", mock.Anything, mock.Anything).Return(false, nil)
+		limitRepo.On("FindByProductID", mock.Anything, item.ProductID).Return(limit, nil)
+		limitRepo.On("LockCustomer", mock.Anything, item.ID, "user-42").Return(domainErrors.ErrOptimisticLockFailure)
+
+		uc := NewReserveStockUseCase(inventoryRepo, reservationRepo, new(MockPublisher)).
+			WithPurchaseLimits(limitRepo).
+			WithRetryPolicy(RetryPolicy{MaxAttempts: 2})
+		_, err := uc.Execute(ctx, ReserveStockInput{ProductID: item.ProductID, OrderID: uuid.New(), Quantity: 1})
+
+		var contention *ContentionError
+		require.ErrorAs(t, err, &contention)
+		limitRepo.AssertNumberOfCalls(t, "LockCustomer", 2)
+		inventoryRepo.AssertNotCalled(t, "FindByProductID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should reserve products without limits", func(t *testing.T) {
+		inventoryRepo := new(MockInventoryRepository)
+		reservationRepo := new(MockReservationRepository)
+		publisher := new(MockPublisher)
+		limitRepo := new(MockPurchaseLimitRepository)
+		current := *item
+
+		reservationRepo.On("ExistsByOrderID", mock.Anything, mock.Anything).Return(false, nil)
+		limitRepo.On("FindByProductID", mock.Anything, item.ProductID).Return(nil, domainErrors.ErrPurchaseLimitNotFound)
+		inventoryRepo.On("FindByProductID", mock.Anything, item.ProductID).Return(&current, nil)
+		inventoryRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+		reservationRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+		publisher.On("PublishStockReserved", mock.Anything, mock.Anything).Return(nil)
+
+		uc := NewReserveStockUseCase(inventoryRepo, reservationRepo, publisher).WithPurchaseLimits(limitRepo)
+		_, err := uc.Execute(context.Background(), ReserveStockInput{ProductID: item.ProductID, OrderID: uuid.New(), Quantity: 50})
+
+		require.NoError(t, err)
+	})
+
+	t.Run("should fail without an event when the limits cannot be read", func(t *testing.T) {
+		reservationRepo := new(MockReservationRepository)
+		publisher := new(MockPublisher)
+		limitRepo := new(MockPurchaseLimitRepository)
+		reservationRepo.On("ExistsByOrderID", mock.Anything, mock.Anything).Return(false, nil)
+		limitRepo.On("FindByProductID", mock.Anything, item.ProductID).Return(nil, errors.New("connection refused"))
+
+		uc := NewReserveStockUseCase(new(MockInventoryRepository), reservationRepo, publisher).WithPurchaseLimits(limitRepo)
+		_, err := uc.Execute(ctx, ReserveStockInput{ProductID: item.ProductID, OrderID: uuid.New(), Quantity: 1})
+
+		assert.EqualError(t, err, "connection refused")
+		publisher.AssertNotCalled(t, "PublishStockFailed", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should reject invalid user ids", func(t *testing.T) {
+		uc := NewReserveStockUseCase(new(MockInventoryRepository), new(MockReservationRepository), new(MockPublisher))
+		_, err := uc.Execute(ContextWithUserID(context.Background(), "user 42"),
+			ReserveStockInput{ProductID: item.ProductID, OrderID: uuid.New(), Quantity: 1})
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidUserID)
+	})
+}
+
+func TestConfirmAndReleaseEvents_CarryReservationUser(t *testing.T) {
+	item, err := entity.NewInventoryItem(uuid.New(), 10)
+	require.NoError(t, err)
+	require.NoError(t, item.Reserve(2))
+
+	newReservation := func() *entity.Reservation {
+		reservation, err := entity.NewReservation(item.ID, uuid.New(), 1)
+		require.NoError(t, err)
+		reservation.UserID = "user-42"
+		return reservation
+	}
+
+	t.Run("confirm", func(t *testing.T) {
+		reservation := newReservation()
+		inventoryRepo := new(MockInventoryRepository)
+		reservationRepo := new(MockReservationRepository)
+		publisher := new(MockPublisher)
+		current := *item
+		reservationRepo.On("FindByID", mock.Anything, reservation.ID).Return(reservation, nil)
+		inventoryRepo.On("FindByID", mock.Anything, item.ID).Return(&current, nil)
+		inventoryRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+		reservationRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+		publisher.On("PublishStockConfirmed", mock.Anything, mock.MatchedBy(func(event events.StockConfirmedEvent) bool {
+			return event.Payload.UserID == "user-42"
+		})).Return(nil)
+
+		_, err := NewConfirmReservationUseCase(inventoryRepo, reservationRepo, publisher).
+			Execute(context.Background(), ConfirmReservationInput{ReservationID: reservation.ID})
+
+		require.NoError(t, err)
+		publisher.AssertExpectations(t)
+	})
+
+	t.Run("release", func(t *testing.T) {
+		reservation := newReservation()
+		inventoryRepo := new(MockInventoryRepository)
+		reservationRepo := new(MockReservationRepository)
+		publisher := new(MockPublisher)
+		current := *item
+		reservationRepo.On("FindByID", mock.Anything, reservation.ID).Return(reservation, nil)
+		inventoryRepo.On("FindByID", mock.Anything, item.ID).Return(&current, nil)
+		inventoryRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+		reservationRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+		publisher.On("PublishStockReleased", mock.Anything, mock.MatchedBy(func(event events.StockReleasedEvent) bool {
+			return event.Payload.UserID == "user-42"
+		})).Return(nil)
+
+		_, err := NewReleaseReservationUseCase(inventoryRepo, reservationRepo, publisher).
+			Execute(context.Background(), ReleaseReservationInput{ReservationID: reservation.ID})
+
+		require.NoError(t, err)
+		publisher.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,301 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+// Audit fields of the entries written for each repair.
+// Routes look like "reconcile/reserved_mismatch" so the admin audit log can filter by kind.
+const (
+	ReconcileAuditActor  = "reconciler"
+	ReconcileAuditMethod = "RECONCILE"
+	reconcileAuditRoute  = "reconcile/"
+)
+
+// DriftOutcome describes what reconciliation did with a drift
+type DriftOutcome string
+
+// Drift outcomes
+const (
+	DriftDetected DriftOutcome = "detected" // reported only, repair was not requested
+	DriftRepaired DriftOutcome = "repaired" // fixed and audited
+	DriftResolved DriftOutcome = "resolved" // gone by the time the repair ran
+	DriftFailed   DriftOutcome = "failed"   // the repair returned an error
+)
+
+// CatalogProduct is an active product of the catalog
+type CatalogProduct struct {
+	ID  uuid.UUID
+	SKU string
+}
+
+// CatalogSource lists the active products of the catalog
+type CatalogSource interface {
+	ActiveProducts(ctx context.Context) ([]CatalogProduct, error)
+}
+
+// DriftObserver receives the report of every reconciliation run
+type DriftObserver interface {
+	ObserveReconciliation(report *ReconcileInventoryOutput)
+}
+
+// ReconcileInventoryInput represents the input for a reconciliation run
+type ReconcileInventoryInput struct {
+	Repair bool   // fix what was found; otherwise only report
+	Actor  string // recorded in the audit log; defaults to ReconcileAuditActor
+}
+
+// DriftReport is a drift together with what the run did about it
+type DriftReport struct {
+	*entity.InventoryDrift
+	Outcome DriftOutcome `json:"outcome"`
+	Error   string       `json:"error,omitempty"`
+}
+
+// ReconcileInventoryOutput is the machine-readable drift report of a run
+type ReconcileInventoryOutput struct {
+	StartedAt      time.Time                `json:"started_at"`
+	DurationMillis int64                    `json:"duration_ms"`
+	Repair         bool                     `json:"repair"`
+	Counts         map[entity.DriftKind]int `json:"counts"`
+	Repaired       int                      `json:"repaired"`
+	Failed         int                      `json:"failed"`
+	SkippedChecks  []entity.DriftKind       `json:"skipped_checks,omitempty"`
+	Drifts         []DriftReport            `json:"drifts"`
+}
+
+// Remaining returns the number of drifts that are still present after the run
+func (o *ReconcileInventoryOutput) Remaining() int {
+	remaining := 0
+	for _, drift := range o.Drifts {
+		if drift.Outcome == DriftDetected || drift.Outcome == DriftFailed {
+			remaining++
+		}
+	}
+	return remaining
+}
+
+// ReconcileInventoryUseCase detects drift between inventory items, reservations
+// and the product catalog, and optionally repairs it. Each repair runs in its own
+// transaction together with an admin audit entry.
+type ReconcileInventoryUseCase struct {
+	reconciliationRepo repository.ReconciliationRepository
+	grace              time.Duration
+	catalog            CatalogSource
+	stockPolicy        InitialStockPolicy
+	lots               bool
+	observer           DriftObserver
+	now                func() time.Time
+}
+
+// NewReconcileInventoryUseCase creates a new instance.
+// Rows changed within grace are left alone, and reservations count as stuck
+// once they have been expired for longer than grace.
+func NewReconcileInventoryUseCase(reconciliationRepo repository.ReconciliationRepository, grace time.Duration) *ReconcileInventoryUseCase {
+	if reconciliationRepo == nil {
+		panic("reconciliationRepo cannot be nil")
+	}
+
+	return &ReconcileInventoryUseCase{
+		reconciliationRepo: reconciliationRepo,
+		grace:              grace,
+		now:                time.Now,
+	}
+}
+
+// WithCatalog enables the missing item check. Missing items are created with
+// stock from the policy, like catalog sync does.
+func (uc *ReconcileInventoryUseCase) WithCatalog(catalog CatalogSource, policy InitialStockPolicy) *ReconcileInventoryUseCase {
+	uc.catalog = catalog
+	uc.stockPolicy = policy
+	return uc
+}
+
+// WithLots enables the lot allocation checks, for deployments where reservations
+// allocate lots. Reservations and releases settle their lots after the stock
+// change commits, so a failure there leaves allocations these checks repair.
+func (uc *ReconcileInventoryUseCase) WithLots() *ReconcileInventoryUseCase {
+	uc.lots = true
+	return uc
+}
+
+// WithObserver reports every run to the observer
+func (uc *ReconcileInventoryUseCase) WithObserver(observer DriftObserver) *ReconcileInventoryUseCase {
+	uc.observer = observer
+	return uc
+}
+
+// Execute runs every check and, when requested, repairs what it found
+func (uc *ReconcileInventoryUseCase) Execute(ctx context.Context, input ReconcileInventoryInput) (*ReconcileInventoryOutput, error) {
+	startedAt := uc.now()
+	settledBefore := startedAt.Add(-uc.grace)
+	if input.Actor == "" {
+		input.Actor = ReconcileAuditActor
+	}
+
+	output := &ReconcileInventoryOutput{
+		StartedAt: startedAt.UTC(),
+		Repair:    input.Repair,
+		Counts:    make(map[entity.DriftKind]int, len(entity.DriftKinds)),
+		Drifts:    make([]DriftReport, 0),
+	}
+
+	for _, kind := range entity.DriftKinds {
+		if (kind == entity.DriftMissingItem && uc.catalog == nil) || (isLotDrift(kind) && !uc.lots) {
+			output.SkippedChecks = append(output.SkippedChecks, kind)
+			continue
+		}
+
+		drifts, err := uc.find(ctx, kind, settledBefore)
+		if err != nil {
+			return nil, err
+		}
+		output.Counts[kind] = len(drifts)
+
+		for _, drift := range drifts {
+			report := DriftReport{InventoryDrift: drift, Outcome: DriftDetected}
+			if input.Repair {
+				uc.repair(ctx, &report, input.Actor, settledBefore)
+				switch report.Outcome {
+				case DriftRepaired:
+					output.Repaired++
+				case DriftFailed:
+					output.Failed++
+				}
+			}
+			output.Drifts = append(output.Drifts, report)
+		}
+	}
+
+	output.DurationMillis = uc.now().Sub(startedAt).Milliseconds()
+	if uc.observer != nil {
+		uc.observer.ObserveReconciliation(output)
+	}
+
+	return output, nil
+}
+
+// find runs the check for one drift kind
+func (uc *ReconcileInventoryUseCase) find(ctx context.Context, kind entity.DriftKind, settledBefore time.Time) ([]*entity.InventoryDrift, error) {
+	switch kind {
+	case entity.DriftReservedMismatch:
+		return uc.reconciliationRepo.FindReservedMismatches(ctx, settledBefore)
+	case entity.DriftOrphanReservation:
+		return uc.reconciliationRepo.FindOrphanReservations(ctx, settledBefore)
+	case entity.DriftStuckReservation:
+		return uc.reconciliationRepo.FindStuckReservations(ctx, settledBefore)
+	case entity.DriftStaleLotAllocation:
+		return uc.reconciliationRepo.FindStaleLotAllocations(ctx, settledBefore)
+	case entity.DriftMissingLotAllocation:
+		return uc.reconciliationRepo.FindMissingLotAllocations(ctx, settledBefore)
+	case entity.DriftMissingItem:
+		return uc.findMissingItems(ctx)
+	}
+	return nil, fmt.Errorf("unknown drift kind %q", kind)
+}
+
+// isLotDrift reports whether the kind is checked against lot allocations
+func isLotDrift(kind entity.DriftKind) bool {
+	return kind == entity.DriftStaleLotAllocation || kind == entity.DriftMissingLotAllocation
+}
+
+// findMissingItems compares the active catalog with the inventory
+func (uc *ReconcileInventoryUseCase) findMissingItems(ctx context.Context) ([]*entity.InventoryDrift, error) {
+	products, err := uc.catalog.ActiveProducts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list catalog products: %w", err)
+	}
+
+	skus := make(map[uuid.UUID]string, len(products))
+	productIDs := make([]uuid.UUID, 0, len(products))
+	for _, product := range products {
+		skus[product.ID] = product.SKU
+		productIDs = append(productIDs, product.ID)
+	}
+
+	missing, err := uc.reconciliationRepo.FindMissingProducts(ctx, productIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	drifts := make([]*entity.InventoryDrift, 0, len(missing))
+	for _, productID := range missing {
+		drifts = append(drifts, &entity.InventoryDrift{
+			Kind:      entity.DriftMissingItem,
+			ProductID: productID,
+			Expected:  uc.stockPolicy.QuantityFor(skus[productID]),
+			Detail:    "SKU " + skus[productID],
+		})
+	}
+
+	return drifts, nil
+}
+
+// repair fixes one drift and records the outcome on the report
+func (uc *ReconcileInventoryUseCase) repair(ctx context.Context, report *DriftReport, actor string, settledBefore time.Time) {
+	started := uc.now()
+	drift := report.InventoryDrift
+
+	applied, err := func() (bool, error) {
+		switch drift.Kind {
+		case entity.DriftStuckReservation:
+			return uc.reconciliationRepo.ExpireStuckReservation(ctx, drift.ReservationID, settledBefore, uc.auditEntry(actor, drift, started))
+		case entity.DriftOrphanReservation:
+			return uc.reconciliationRepo.ReleaseOrphanReservation(ctx, drift.ReservationID, uc.auditEntry(actor, drift, started))
+		case entity.DriftStaleLotAllocation:
+			return uc.reconciliationRepo.SettleStaleLotAllocations(ctx, drift.ReservationID, drift.InventoryItemID, settledBefore, uc.auditEntry(actor, drift, started))
+		case entity.DriftMissingLotAllocation:
+			return uc.reconciliationRepo.AllocateMissingLots(ctx, drift.ReservationID, drift.InventoryItemID, settledBefore, uc.auditEntry(actor, drift, started))
+		case entity.DriftReservedMismatch:
+			return uc.reconciliationRepo.RecomputeReserved(ctx, drift.InventoryItemID, settledBefore, uc.auditEntry(actor, drift, started))
+		case entity.DriftMissingItem:
+			item, err := entity.NewInventoryItem(drift.ProductID, drift.Expected)
+			if err != nil {
+				return false, err
+			}
+			drift.InventoryItemID = item.ID
+			applied, err := uc.reconciliationRepo.CreateMissingItem(ctx, item, uc.auditEntry(actor, drift, started))
+			if !applied {
+				drift.InventoryItemID = uuid.Nil
+			}
+			return applied, err
+		}
+		return false, fmt.Errorf("unknown drift kind %q", drift.Kind)
+	}()
+
+	switch {
+	case err != nil:
+		report.Outcome, report.Error = DriftFailed, err.Error()
+	case applied:
+		report.Outcome = DriftRepaired
+	default:
+		report.Outcome = DriftResolved
+	}
+}
+
+// auditEntry builds the admin audit entry that is written with a repair
+func (uc *ReconcileInventoryUseCase) auditEntry(actor string, drift *entity.InventoryDrift, started time.Time) *entity.AdminAuditEntry {
+	entry := &entity.AdminAuditEntry{
+		ID:     uuid.New(),
+		Actor:  actor,
+		Method: ReconcileAuditMethod,
+		Route:  reconcileAuditRoute + string(drift.Kind),
+		Path:   reconcileAuditRoute + string(drift.Kind),
+		Parameters: map[string]string{
+			"recorded": strconv.Itoa(drift.Recorded),
+			"expected": strconv.Itoa(drift.Expected),
+		},
+		AffectedIDs: drift.EntityIDs(),
+		CreatedAt:   uc.now().UTC(),
+	}
+	entry.Complete(http.StatusOK, uc.now().Sub(started))
+	return entry
+}
@@ -0,0 +1,246 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockReconciliationRepository is a mock implementation of ReconciliationRepository
+type MockReconciliationRepository struct {
+	mock.Mock
+}
+
+func (m *MockReconciliationRepository) drifts(args mock.Arguments) ([]*entity.InventoryDrift, error) {
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.InventoryDrift), args.Error(1)
+}
+
+func (m *MockReconciliationRepository) FindReservedMismatches(ctx context.Context, settledBefore time.Time) ([]*entity.InventoryDrift, error) {
+	return m.drifts(m.Called(ctx, settledBefore))
+}
+
+func (m *MockReconciliationRepository) FindOrphanReservations(ctx context.Context, settledBefore time.Time) ([]*entity.InventoryDrift, error) {
+	return m.drifts(m.Called(ctx, settledBefore))
+}
+
+func (m *MockReconciliationRepository) FindStuckReservations(ctx context.Context, expiredBefore time.Time) ([]*entity.InventoryDrift, error) {
+	return m.drifts(m.Called(ctx, expiredBefore))
+}
+
+func (m *MockReconciliationRepository) FindStaleLotAllocations(ctx context.Context, settledBefore time.Time) ([]*entity.InventoryDrift, error) {
+	return m.drifts(m.Called(ctx, settledBefore))
+}
+
+func (m *MockReconciliationRepository) FindMissingLotAllocations(ctx context.Context, settledBefore time.Time) ([]*entity.InventoryDrift, error) {
+	return m.drifts(m.Called(ctx, settledBefore))
+}
+
+func (m *MockReconciliationRepository) FindMissingProducts(ctx context.Context, productIDs []uuid.UUID) ([]uuid.UUID, error) {
+	args := m.Called(ctx, productIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+func (m *MockReconciliationRepository) RecomputeReserved(ctx context.Context, itemID uuid.UUID, settledBefore time.Time, audit *entity.AdminAuditEntry) (bool, error) {
+	args := m.Called(ctx, itemID, settledBefore, audit)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockReconciliationRepository) ReleaseOrphanReservation(ctx context.Context, reservationID uuid.UUID, audit *entity.AdminAuditEntry) (bool, error) {
+	args := m.Called(ctx, reservationID, audit)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockReconciliationRepository) ExpireStuckReservation(ctx context.Context, reservationID uuid.UUID, expiredBefore time.Time, audit *entity.AdminAuditEntry) (bool, error) {
+	args := m.Called(ctx, reservationID, expiredBefore, audit)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockReconciliationRepository) SettleStaleLotAllocations(ctx context.Context, reservationID, itemID uuid.UUID, settledBefore time.Time, audit *entity.AdminAuditEntry) (bool, error) {
+	args := m.Called(ctx, reservationID, itemID, settledBefore, audit)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockReconciliationRepository) AllocateMissingLots(ctx context.Context, reservationID, itemID uuid.UUID, settledBefore time.Time, audit *entity.AdminAuditEntry) (bool, error) {
+	args := m.Called(ctx, reservationID, itemID, settledBefore, audit)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockReconciliationRepository) CreateMissingItem(ctx context.Context, item *entity.InventoryItem, audit *entity.AdminAuditEntry) (bool, error) {
+	args := m.Called(ctx, item, audit)
+	return args.Bool(0), args.Error(1)
+}
+
+// staticCatalog is a CatalogSource backed by a slice
+type staticCatalog []CatalogProduct
+
+func (c staticCatalog) ActiveProducts(ctx context.Context) ([]CatalogProduct, error) {
+	return c, nil
+}
+
+// recordingDriftObserver keeps the last report
+type recordingDriftObserver struct {
+	report *ReconcileInventoryOutput
+}
+
+func (o *recordingDriftObserver) ObserveReconciliation(report *ReconcileInventoryOutput) {
+	o.report = report
+}
+
+var reconcileNow = time.Date(2025, 11, 10, 12, 0, 0, 0, time.UTC)
+
+func newReconcileUseCase(repo *MockReconciliationRepository) *ReconcileInventoryUseCase {
+	uc := NewReconcileInventoryUseCase(repo, 5*time.Minute)
+	uc.now = func() time.Time { return reconcileNow }
+	return uc
+}
+
+func TestNewReconcileInventoryUseCase_NilRepo_Panics(t *testing.T) {
+	assert.Panics(t, func() {
+		NewReconcileInventoryUseCase(nil, time.Minute)
+	})
+}
+
+func TestReconcileInventoryUseCase_ReportOnly(t *testing.T) {
+	repo := new(MockReconciliationRepository)
+	settled := reconcileNow.Add(-5 * time.Minute)
+	mismatch := &entity.InventoryDrift{Kind: entity.DriftReservedMismatch, InventoryItemID: uuid.New(), Recorded: 7, Expected: 5}
+	stuck := &entity.InventoryDrift{Kind: entity.DriftStuckReservation, ReservationID: uuid.New(), Recorded: 2}
+	repo.On("FindStuckReservations", mock.Anything, settled).Return([]*entity.InventoryDrift{stuck}, nil)
+	repo.On("FindOrphanReservations", mock.Anything, settled).Return([]*entity.InventoryDrift{}, nil)
+	repo.On("FindReservedMismatches", mock.Anything, settled).Return([]*entity.InventoryDrift{mismatch}, nil)
+	observer := &recordingDriftObserver{}
+
+	output, err := newReconcileUseCase(repo).WithObserver(observer).Execute(context.Background(), ReconcileInventoryInput{})
+
+	require.NoError(t, err)
+	assert.False(t, output.Repair)
+	assert.Equal(t, map[entity.DriftKind]int{
+		entity.DriftStuckReservation:  1,
+		entity.DriftOrphanReservation: 0,
+		entity.DriftReservedMismatch:  1,
+	}, output.Counts)
+	assert.Equal(t, []entity.DriftKind{entity.DriftStaleLotAllocation, entity.DriftMissingLotAllocation, entity.DriftMissingItem},
+		output.SkippedChecks, "no lots nor catalog configured")
+	require.Len(t, output.Drifts, 2)
+	assert.Equal(t, stuck, output.Drifts[0].InventoryDrift, "reservation statuses come before counters")
+	assert.Equal(t, DriftDetected, output.Drifts[1].Outcome)
+	assert.Equal(t, 2, output.Remaining())
+	assert.Same(t, output, observer.report)
+	repo.AssertNotCalled(t, "RecomputeReserved", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestReconcileInventoryUseCase_Repair(t *testing.T) {
+	repo := new(MockReconciliationRepository)
+	settled := reconcileNow.Add(-5 * time.Minute)
+	stuck := &entity.InventoryDrift{Kind: entity.DriftStuckReservation, InventoryItemID: uuid.New(), ReservationID: uuid.New(), Recorded: 2}
+	orphan := &entity.InventoryDrift{Kind: entity.DriftOrphanReservation, ReservationID: uuid.New(), Recorded: 1}
+	mismatch := &entity.InventoryDrift{Kind: entity.DriftReservedMismatch, InventoryItemID: uuid.New(), Recorded: 7, Expected: 5}
+	repo.On("FindStuckReservations", mock.Anything, settled).Return([]*entity.InventoryDrift{stuck}, nil)
+	repo.On("FindOrphanReservations", mock.Anything, settled).Return([]*entity.InventoryDrift{orphan}, nil)
+	repo.On("FindReservedMismatches", mock.Anything, settled).Return([]*entity.InventoryDrift{mismatch}, nil)
+
+	repo.On("ExpireStuckReservation", mock.Anything, stuck.ReservationID, settled, mock.MatchedBy(func(audit *entity.AdminAuditEntry) bool {
+		return audit.Actor == "ops" && audit.Method == ReconcileAuditMethod && audit.Route == "reconcile/stuck_reservation" &&
+			audit.Outcome == entity.AdminAuditSuccess &&
+			assert.ObjectsAreEqual([]string{stuck.InventoryItemID.String(), stuck.ReservationID.String()}, audit.AffectedIDs)
+	})).Return(true, nil)
+	repo.On("ReleaseOrphanReservation", mock.Anything, orphan.ReservationID, mock.Anything).Return(false, nil)
+	repo.On("RecomputeReserved", mock.Anything, mismatch.InventoryItemID, settled, mock.MatchedBy(func(audit *entity.AdminAuditEntry) bool {
+		return audit.Parameters["recorded"] == "7" && audit.Parameters["expected"] == "5"
+	})).Return(false, errors.New("chk_reserved_not_exceed"))
+
+	output, err := newReconcileUseCase(repo).Execute(context.Background(), ReconcileInventoryInput{Repair: true, Actor: "ops"})
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, output.Repaired)
+	assert.Equal(t, 1, output.Failed)
+	assert.Equal(t, DriftRepaired, output.Drifts[0].Outcome)
+	assert.Equal(t, DriftResolved, output.Drifts[1].Outcome, "fixed concurrently")
+	assert.Equal(t, DriftFailed, output.Drifts[2].Outcome)
+	assert.Contains(t, output.Drifts[2].Error, "chk_reserved_not_exceed")
+	assert.Equal(t, 1, output.Remaining())
+	repo.AssertExpectations(t)
+}
+
+func TestReconcileInventoryUseCase_MissingItems(t *testing.T) {
+	repo := new(MockReconciliationRepository)
+	stocked, preorder, existing := uuid.New(), uuid.New(), uuid.New()
+	catalog := staticCatalog{{ID: stocked, SKU: "SKU-1"}, {ID: preorder, SKU: "PRE-1"}, {ID: existing, SKU: "SKU-2"}}
+	policy := InitialStockPolicy{DefaultQuantity: 10, Rules: []InitialStockRule{{SKUPrefix: "PRE-", Quantity: 0}}}
+	repo.On("FindStuckReservations", mock.Anything, mock.Anything).Return([]*entity.InventoryDrift{}, nil)
+	repo.On("FindOrphanReservations", mock.Anything, mock.Anything).Return([]*entity.InventoryDrift{}, nil)
+	repo.On("FindReservedMismatches", mock.Anything, mock.Anything).Return([]*entity.InventoryDrift{}, nil)
+	repo.On("FindStaleLotAllocations", mock.Anything, mock.Anything).Return([]*entity.InventoryDrift{}, nil)
+	repo.On("FindMissingLotAllocations", mock.Anything, mock.Anything).Return([]*entity.InventoryDrift{}, nil)
+	repo.On("FindMissingProducts", mock.Anything, []uuid.UUID{stocked, preorder, existing}).Return([]uuid.UUID{stocked, preorder}, nil)
+	repo.On("CreateMissingItem", mock.Anything, mock.MatchedBy(func(item *entity.InventoryItem) bool {
+		return item.ProductID == stocked && item.Quantity == 10
+	}), mock.Anything).Return(true, nil)
+	repo.On("CreateMissingItem", mock.Anything, mock.MatchedBy(func(item *entity.InventoryItem) bool {
+		return item.ProductID == preorder && item.Quantity == 0
+	}), mock.Anything).Return(false, nil)
+
+	output, err := newReconcileUseCase(repo).WithLots().WithCatalog(catalog, policy).Execute(context.Background(), ReconcileInventoryInput{Repair: true})
+
+	require.NoError(t, err)
+	assert.Empty(t, output.SkippedChecks)
+	assert.Equal(t, 2, output.Counts[entity.DriftMissingItem])
+	require.Len(t, output.Drifts, 2)
+	assert.Equal(t, DriftRepaired, output.Drifts[0].Outcome)
+	assert.NotEqual(t, uuid.Nil, output.Drifts[0].InventoryItemID, "created item is reported")
+	assert.Equal(t, DriftResolved, output.Drifts[1].Outcome)
+	assert.Equal(t, uuid.Nil, output.Drifts[1].InventoryItemID)
+	repo.AssertExpectations(t)
+}
+
+func TestReconcileInventoryUseCase_LotAllocations(t *testing.T) {
+	repo := new(MockReconciliationRepository)
+	settled := reconcileNow.Add(-5 * time.Minute)
+	stale := &entity.InventoryDrift{Kind: entity.DriftStaleLotAllocation, InventoryItemID: uuid.New(), ReservationID: uuid.New(), Recorded: 3, Detail: "reservation released"}
+	missing := &entity.InventoryDrift{Kind: entity.DriftMissingLotAllocation, InventoryItemID: uuid.New(), ReservationID: uuid.New(), Expected: 2}
+	repo.On("FindStuckReservations", mock.Anything, settled).Return([]*entity.InventoryDrift{}, nil)
+	repo.On("FindOrphanReservations", mock.Anything, settled).Return([]*entity.InventoryDrift{}, nil)
+	repo.On("FindStaleLotAllocations", mock.Anything, settled).Return([]*entity.InventoryDrift{stale}, nil)
+	repo.On("FindMissingLotAllocations", mock.Anything, settled).Return([]*entity.InventoryDrift{missing}, nil)
+	repo.On("FindReservedMismatches", mock.Anything, settled).Return([]*entity.InventoryDrift{}, nil)
+	repo.On("SettleStaleLotAllocations", mock.Anything, stale.ReservationID, stale.InventoryItemID, settled, mock.MatchedBy(func(audit *entity.AdminAuditEntry) bool {
+		return audit.Route == "reconcile/stale_lot_allocation" && audit.Parameters["recorded"] == "3"
+	})).Return(true, nil)
+	repo.On("AllocateMissingLots", mock.Anything, missing.ReservationID, missing.InventoryItemID, settled, mock.MatchedBy(func(audit *entity.AdminAuditEntry) bool {
+		return audit.Route == "reconcile/missing_lot_allocation" && audit.Parameters["expected"] == "2"
+	})).Return(false, nil)
+
+	output, err := newReconcileUseCase(repo).WithLots().Execute(context.Background(), ReconcileInventoryInput{Repair: true})
+
+	require.NoError(t, err)
+	assert.Equal(t, []entity.DriftKind{entity.DriftMissingItem}, output.SkippedChecks)
+	assert.Equal(t, 1, output.Counts[entity.DriftStaleLotAllocation])
+	assert.Equal(t, 1, output.Counts[entity.DriftMissingLotAllocation])
+	require.Len(t, output.Drifts, 2)
+	assert.Equal(t, DriftRepaired, output.Drifts[0].Outcome)
+	assert.Equal(t, DriftResolved, output.Drifts[1].Outcome, "allocated concurrently")
+	repo.AssertExpectations(t)
+}
+
+func TestReconcileInventoryUseCase_CheckError(t *testing.T) {
+	repo := new(MockReconciliationRepository)
+	repo.On("FindStuckReservations", mock.Anything, mock.Anything).Return(nil, errors.New("connection refused"))
+
+	output, err := newReconcileUseCase(repo).Execute(context.Background(), ReconcileInventoryInput{})
+
+	assert.Error(t, err)
+	assert.Nil(t, output)
+}
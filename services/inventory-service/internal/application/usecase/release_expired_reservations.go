@@ -34,6 +34,12 @@ type ReleaseExpiredReservationsUseCase struct {
 	inventoryRepo   repository.InventoryRepository
 	reservationRepo repository.ReservationRepository
 	publisher       events.Publisher
+	retry           RetryPolicy
+	atomicStock     repository.AtomicStockRepository
+	lots            repository.LotRepository
+	serials         repository.SerialUnitRepository
+	bundles         repository.BundleRepository
+	notifier        WaitlistNotifier
 }
 
 // NewReleaseExpiredReservationsUseCase creates a new instance
@@ -46,9 +52,50 @@ func NewReleaseExpiredReservationsUseCase(
 		inventoryRepo:   inventoryRepo,
 		reservationRepo: reservationRepo,
 		publisher:       publisher,
+		retry:           DefaultRetryPolicy(),
 	}
 }
 
+// WithRetryPolicy replaces the policy used to retry optimistic-lock conflicts
+func (uc *ReleaseExpiredReservationsUseCase) WithRetryPolicy(policy RetryPolicy) *ReleaseExpiredReservationsUseCase {
+	uc.retry = policy
+	return uc
+}
+
+// WithAtomicStock makes the use case release reservations with a single conditional
+// statement instead of the optimistic-lock read-modify-write cycle
+func (uc *ReleaseExpiredReservationsUseCase) WithAtomicStock(atomicStock repository.AtomicStockRepository) *ReleaseExpiredReservationsUseCase {
+	uc.atomicStock = atomicStock
+	return uc
+}
+
+// WithLots makes the use case return the lots allocated to every expired reservation
+func (uc *ReleaseExpiredReservationsUseCase) WithLots(lots repository.LotRepository) *ReleaseExpiredReservationsUseCase {
+	uc.lots = lots
+	return uc
+}
+
+// WithSerials makes the use case release the units reserved for serial-tracked
+// products, which otherwise fail with ErrSerialTrackedItem
+func (uc *ReleaseExpiredReservationsUseCase) WithSerials(serials repository.SerialUnitRepository) *ReleaseExpiredReservationsUseCase {
+	uc.serials = serials
+	return uc
+}
+
+// WithBundles makes the use case release the components reserved for bundles,
+// which otherwise fail with ErrBundleItem
+func (uc *ReleaseExpiredReservationsUseCase) WithBundles(bundles repository.BundleRepository) *ReleaseExpiredReservationsUseCase {
+	uc.bundles = bundles
+	return uc
+}
+
+// WithWaitlist makes the use case serve the waitlists of the products that
+// expired reservations return stock to
+func (uc *ReleaseExpiredReservationsUseCase) WithWaitlist(notifier WaitlistNotifier) *ReleaseExpiredReservationsUseCase {
+	uc.notifier = notifier
+	return uc
+}
+
 // Execute releases all expired reservations
 // This operation:
 //  1. Finds all expired reservations (status=pending and expiresAt < now)
@@ -128,27 +175,61 @@ func (uc *ReleaseExpiredReservationsUseCase) releaseReservation(
 	ctx context.Context,
 	reservation *entity.Reservation,
 ) error {
-	// Find inventory item
-	item, err := uc.inventoryRepo.FindByID(ctx, reservation.InventoryItemID)
-	if err != nil {
-		return fmt.Errorf("inventory item not found: %w", err)
-	}
-
-	// Release reservation on inventory entity
-	if err := item.ReleaseReservation(reservation.Quantity); err != nil {
-		return fmt.Errorf("failed to release reservation on inventory: %w", err)
-	}
-
-	// Mark reservation as released
+	// Mark reservation as released in memory; it is only persisted once the
+	// inventory update below succeeds
 	if err := reservation.Release(); err != nil {
-		// Rollback in-memory changes
-		item.Reserve(reservation.Quantity)
 		return fmt.Errorf("failed to mark reservation as released: %w", err)
 	}
 
-	// Update inventory with optimistic locking
-	if err := uc.inventoryRepo.Update(ctx, item); err != nil {
-		return fmt.Errorf("failed to update inventory: %w", err)
+	// Apply the change with a conditional statement when configured; otherwise
+	// read, apply and write the inventory item, re-reading it after every
+	// optimistic-lock conflict so the release applies to the latest version
+	var item *entity.InventoryItem
+	err := uc.retry.run(ctx, OperationReleaseExpire, func() (uuid.UUID, error) {
+		if uc.atomicStock != nil {
+			stored, err := uc.atomicStock.ReleaseStock(ctx, reservation.InventoryItemID, reservation.Quantity)
+			if err != nil {
+				return uuid.Nil, fmt.Errorf("failed to update inventory: %w", err)
+			}
+			item = stored
+			return item.ProductID, nil
+		}
+
+		var findErr error
+		item, findErr = uc.inventoryRepo.FindByID(ctx, reservation.InventoryItemID)
+		if findErr != nil {
+			return uuid.Nil, fmt.Errorf("inventory item not found: %w", findErr)
+		}
+
+		if err := item.ReleaseReservation(reservation.Quantity); err != nil {
+			return item.ProductID, fmt.Errorf("failed to release reservation on inventory: %w", err)
+		}
+
+		// Update inventory with optimistic locking
+		if err := uc.inventoryRepo.Update(ctx, item); err != nil {
+			return item.ProductID, fmt.Errorf("failed to update inventory: %w", err)
+		}
+		return item.ProductID, nil
+	})
+	var units []*entity.SerialUnit
+	if usesSerials(err, uc.serials) {
+		item, units, err = uc.serials.Release(ctx, reservation.ID, reservation.InventoryItemID, reservation.Quantity)
+		if err != nil {
+			err = fmt.Errorf("failed to release serial units: %w", err)
+		}
+	}
+	var change *repository.BundleStockChange
+	if usesBundles(err, uc.bundles) {
+		change, err = uc.bundles.Release(ctx, reservation.ID, reservation.InventoryItemID)
+		if err != nil {
+			err = fmt.Errorf("failed to release bundle components: %w", err)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if change != nil {
+		item = change.Bundle
 	}
 
 	// Update reservation status
@@ -156,13 +237,22 @@ func (uc *ReleaseExpiredReservationsUseCase) releaseReservation(
 		return fmt.Errorf("failed to update reservation: %w", err)
 	}
 
+	// Return the reservation's lot units (don't fail the release: reconcile
+	// settles the lots)
+	if uc.lots != nil {
+		if _, err := uc.lots.Release(ctx, reservation.ID); err != nil {
+			log.Printf("[ReleaseExpiredReservations] WARNING: Failed to release lots of reservation %s: %v",
+				reservation.ID, err)
+		}
+	}
+
 	// Publish StockReleased event (don't fail if event publication fails)
 	stockReleasedEvent := events.StockReleasedEvent{
 		BaseEvent: events.BaseEvent{
 			EventID:   uuid.New().String(),
 			EventType: events.RoutingKeyStockReleased,
 			Timestamp: time.Now().Format(time.RFC3339),
-			Version:   events.EventVersion,
+			Version:   events.StockReleasedVersion,
 			Source:    events.SourceInventoryService,
 		},
 		Payload: events.StockReleasedPayload{
@@ -170,9 +260,12 @@ func (uc *ReleaseExpiredReservationsUseCase) releaseReservation(
 			ProductID:     item.ProductID.String(),
 			Quantity:      reservation.Quantity,
 			OrderID:       reservation.OrderID.String(),
-			UserID:        "", // Not available in expired context
+			UserID:        reservation.UserID,
 			Reason:        "reservation_expired",
 			ReleasedAt:    time.Now(),
+			Serials:       entity.SerialNumbers(units),
+			Components:    componentQuantities(changedComponents(change)),
+			Channel:       reservation.Channel,
 		},
 	}
 
@@ -182,5 +275,6 @@ func (uc *ReleaseExpiredReservationsUseCase) releaseReservation(
 			reservation.ID, err)
 	}
 
+	notifyStockAvailable(uc.notifier, freedProducts(item, change)...)
 	return nil
 }
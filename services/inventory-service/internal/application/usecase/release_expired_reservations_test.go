@@ -6,10 +6,12 @@ import (
 	"time"
 
 	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
 	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/events"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // Test: Constructor
@@ -354,3 +356,53 @@ func createExpiredReservation(reservationID, itemID, orderID uuid.UUID, quantity
 		CreatedAt:       time.Now().Add(-16 * time.Minute),
 	}
 }
+
+// Test: Execute - Optimistic lock conflicts are retried, exhausted ones are reported as failures
+func TestReleaseExpiredReservationsUseCase_Execute_OptimisticLockConflicts(t *testing.T) {
+	t.Run("should record a contention failure when every attempt conflicts", func(t *testing.T) {
+		mockInventoryRepo := new(MockInventoryRepository)
+		mockReservationRepo := new(MockReservationRepository)
+		mockPublisher := new(MockPublisher)
+		observer := newRecordingObserver()
+
+		uc := NewReleaseExpiredReservationsUseCase(mockInventoryRepo, mockReservationRepo, mockPublisher).
+			WithRetryPolicy(noBackoff(2, observer))
+
+		item := &entity.InventoryItem{
+			ID:        uuid.New(),
+			ProductID: uuid.New(),
+			Quantity:  100,
+			Reserved:  5,
+			Version:   1,
+		}
+		expiredReservation := &entity.Reservation{
+			ID:              uuid.New(),
+			InventoryItemID: item.ID,
+			OrderID:         uuid.New(),
+			Quantity:        5,
+			Status:          "pending",
+			ExpiresAt:       time.Now().Add(-1 * time.Minute),
+			CreatedAt:       time.Now().Add(-16 * time.Minute),
+		}
+
+		mockReservationRepo.On("FindExpired", mock.Anything, mock.Anything).
+			Return([]*entity.Reservation{expiredReservation}, nil)
+		expectFreshReads(mockInventoryRepo, "FindByID", item.ID, item, 2)
+		mockInventoryRepo.On("Update", mock.Anything, mock.AnythingOfType("*entity.InventoryItem")).
+			Return(errors.ErrOptimisticLockFailure).Times(2)
+
+		output, err := uc.Execute(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, 0, output.TotalReleased)
+		require.Len(t, output.FailedReservations, 1)
+		assert.Contains(t, output.FailedReservations[0].Reason, "gave up after 2 conflicting attempts")
+		assert.Equal(t, 2, observer.conflicts[OperationReleaseExpire])
+		assert.Equal(t, 1, observer.exhausted[OperationReleaseExpire])
+		assert.Equal(t, item.ProductID, observer.products[0])
+
+		mockInventoryRepo.AssertExpectations(t)
+		mockReservationRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		mockPublisher.AssertNotCalled(t, "PublishStockReleased", mock.Anything, mock.Anything)
+	})
+}
@@ -5,15 +5,18 @@ import (
 	"log"
 	"time"
 
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
 	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
 	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/events"
 	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/repository"
 	"github.com/google/uuid"
 )
 
-// ReleaseReservationInput represents the input for releasing a reservation
+// ReleaseReservationInput represents the input for releasing a reservation.
+// The reservation is looked up by ReservationID, or by OrderID when ReservationID is not set.
 type ReleaseReservationInput struct {
 	ReservationID uuid.UUID
+	OrderID       uuid.UUID
 }
 
 // ReleaseReservationOutput represents the result of releasing a reservation
@@ -24,6 +27,19 @@ type ReleaseReservationOutput struct {
 	QuantityReleased int
 	AvailableStock   int
 	ReservedStock    int
+
+	// Serials are the serial numbers of the units made available again, for
+	// serial-tracked products
+	Serials []string
+
+	// Components are the units of every component made available again, for bundles
+	Components []*entity.ReservationComponent
+	// ComponentItems are the component items as stored after the change, in Components order
+	ComponentItems []*entity.InventoryItem
+
+	// Reservation and Item are the stored state after the change
+	Reservation *entity.Reservation
+	Item        *entity.InventoryItem
 }
 
 // ReleaseReservationUseCase handles canceling reservations and releasing stock back to available
@@ -35,6 +51,12 @@ type ReleaseReservationUseCase struct {
 	inventoryRepo   repository.InventoryRepository
 	reservationRepo repository.ReservationRepository
 	publisher       events.Publisher
+	retry           RetryPolicy
+	atomicStock     repository.AtomicStockRepository
+	lots            repository.LotRepository
+	serials         repository.SerialUnitRepository
+	bundles         repository.BundleRepository
+	notifier        WaitlistNotifier
 }
 
 // NewReleaseReservationUseCase creates a new instance of ReleaseReservationUseCase
@@ -47,24 +69,72 @@ func NewReleaseReservationUseCase(
 		inventoryRepo:   inventoryRepo,
 		reservationRepo: reservationRepo,
 		publisher:       publisher,
+		retry:           DefaultRetryPolicy(),
 	}
 }
 
+// WithRetryPolicy replaces the policy used to retry optimistic-lock conflicts
+func (uc *ReleaseReservationUseCase) WithRetryPolicy(policy RetryPolicy) *ReleaseReservationUseCase {
+	uc.retry = policy
+	return uc
+}
+
+// WithAtomicStock makes the use case release reservations with a single conditional
+// statement instead of the optimistic-lock read-modify-write cycle
+func (uc *ReleaseReservationUseCase) WithAtomicStock(atomicStock repository.AtomicStockRepository) *ReleaseReservationUseCase {
+	uc.atomicStock = atomicStock
+	return uc
+}
+
+// WithLots makes the use case return the lots allocated to every released reservation
+func (uc *ReleaseReservationUseCase) WithLots(lots repository.LotRepository) *ReleaseReservationUseCase {
+	uc.lots = lots
+	return uc
+}
+
+// WithSerials makes the use case release the units reserved for serial-tracked
+// products, which otherwise fail with ErrSerialTrackedItem
+func (uc *ReleaseReservationUseCase) WithSerials(serials repository.SerialUnitRepository) *ReleaseReservationUseCase {
+	uc.serials = serials
+	return uc
+}
+
+// WithBundles makes the use case release the components reserved for bundles,
+// which otherwise fail with ErrBundleItem
+func (uc *ReleaseReservationUseCase) WithBundles(bundles repository.BundleRepository) *ReleaseReservationUseCase {
+	uc.bundles = bundles
+	return uc
+}
+
+// WithWaitlist makes the use case serve the waitlists of the released products
+func (uc *ReleaseReservationUseCase) WithWaitlist(notifier WaitlistNotifier) *ReleaseReservationUseCase {
+	uc.notifier = notifier
+	return uc
+}
+
 // Execute releases a reservation and makes the stock available again
 // This operation should be atomic (wrapped in a transaction in the infrastructure layer)
 // Steps:
-// 1. Find reservation by ID
+// 1. Find reservation by ID (or by order ID)
 // 2. Validate reservation can be released (pending status)
-// 3. Find inventory item
-// 4. Release reservation on inventory (decrements Reserved only)
-// 5. Update reservation status to released
+// 3. Mark reservation as released (in memory)
+// 4. Find inventory item
+// 5. Release reservation on inventory (decrements Reserved only)
 // 6. Update inventory with optimistic locking
 // 7. Update reservation
+// 8. Release the lots allocated to the reservation when configured with WithLots
+// 9. Serve the waitlists of the freed products when configured with WithWaitlist
+//
+// Steps 4-6 are retried according to the RetryPolicy when another writer
+// bumps the Version first; a *ContentionError is returned once attempts run out.
+// For serial-tracked products, steps 4-6 make the reserved units available
+// through WithSerials instead, and for bundles the reserved components through
+// WithBundles.
 func (uc *ReleaseReservationUseCase) Execute(ctx context.Context, input ReleaseReservationInput) (*ReleaseReservationOutput, error) {
 	// Find reservation
-	reservation, err := uc.reservationRepo.FindByID(ctx, input.ReservationID)
+	reservation, err := findReservation(ctx, uc.reservationRepo, input.ReservationID, input.OrderID)
 	if err != nil {
-		return nil, errors.ErrReservationNotFound.WithDetails(err.Error())
+		return nil, err
 	}
 
 	// Validate reservation can be released
@@ -72,29 +142,53 @@ func (uc *ReleaseReservationUseCase) Execute(ctx context.Context, input ReleaseR
 		return nil, errors.ErrReservationNotPending
 	}
 
-	// Find inventory item
-	item, err := uc.inventoryRepo.FindByID(ctx, reservation.InventoryItemID)
-	if err != nil {
-		return nil, errors.ErrInventoryItemNotFound.WithDetails(err.Error())
-	}
-
-	// Release reservation on inventory entity
-	// This decrements Reserved but NOT Quantity
-	if err := item.ReleaseReservation(reservation.Quantity); err != nil {
-		return nil, err
-	}
-
-	// Mark reservation as released
+	// Mark reservation as released in memory; it is only persisted once the
+	// inventory update below succeeds
 	if err := reservation.Release(); err != nil {
-		// Rollback in-memory changes
-		item.Reserve(reservation.Quantity)
 		return nil, err
 	}
 
-	// Update inventory with optimistic locking
-	if err := uc.inventoryRepo.Update(ctx, item); err != nil {
+	// Apply the change with a conditional statement when configured; otherwise
+	// read, apply and write the inventory item, re-reading it after every
+	// optimistic-lock conflict so the change applies to the latest version
+	var item *entity.InventoryItem
+	err = uc.retry.run(ctx, OperationRelease, func() (uuid.UUID, error) {
+		if uc.atomicStock != nil {
+			stored, err := uc.atomicStock.ReleaseStock(ctx, reservation.InventoryItemID, reservation.Quantity)
+			if err != nil {
+				return uuid.Nil, err
+			}
+			item = stored
+			return item.ProductID, nil
+		}
+
+		var findErr error
+		item, findErr = uc.inventoryRepo.FindByID(ctx, reservation.InventoryItemID)
+		if findErr != nil {
+			return uuid.Nil, errors.ErrInventoryItemNotFound.WithDetails(findErr.Error())
+		}
+
+		if err := item.ReleaseReservation(reservation.Quantity); err != nil {
+			return item.ProductID, err
+		}
+
+		// Update inventory with optimistic locking
+		return item.ProductID, uc.inventoryRepo.Update(ctx, item)
+	})
+	var units []*entity.SerialUnit
+	if usesSerials(err, uc.serials) {
+		item, units, err = uc.serials.Release(ctx, reservation.ID, reservation.InventoryItemID, reservation.Quantity)
+	}
+	var change *repository.BundleStockChange
+	if usesBundles(err, uc.bundles) {
+		change, err = uc.bundles.Release(ctx, reservation.ID, reservation.InventoryItemID)
+	}
+	if err != nil {
 		return nil, err
 	}
+	if change != nil {
+		item = change.Bundle
+	}
 
 	// Update reservation status
 	if err := uc.reservationRepo.Update(ctx, reservation); err != nil {
@@ -104,13 +198,21 @@ func (uc *ReleaseReservationUseCase) Execute(ctx context.Context, input ReleaseR
 		return nil, err
 	}
 
+	// Return the reservation's lot units (don't fail the release: reconcile
+	// settles the lots)
+	if uc.lots != nil {
+		if _, err := uc.lots.Release(ctx, reservation.ID); err != nil {
+			log.Printf("Failed to release lots of reservation %s: %v", reservation.ID, err)
+		}
+	}
+
 	// Publish StockReleased event (don't fail transaction if event publication fails)
 	stockReleasedEvent := events.StockReleasedEvent{
 		BaseEvent: events.BaseEvent{
 			EventID:   uuid.New().String(),
 			EventType: events.RoutingKeyStockReleased,
 			Timestamp: time.Now().Format(time.RFC3339),
-			Version:   events.EventVersion,
+			Version:   events.StockReleasedVersion,
 			Source:    events.SourceInventoryService,
 		},
 		Payload: events.StockReleasedPayload{
@@ -118,9 +220,12 @@ func (uc *ReleaseReservationUseCase) Execute(ctx context.Context, input ReleaseR
 			ProductID:     item.ProductID.String(),
 			Quantity:      reservation.Quantity,
 			OrderID:       reservation.OrderID.String(),
-			UserID:        "",               // TODO: Get from context when auth is implemented
+			UserID:        reservation.UserID,
 			Reason:        "manual_release", // TODO: Get actual reason from input
 			ReleasedAt:    time.Now(),
+			Serials:       entity.SerialNumbers(units),
+			Components:    componentQuantities(changedComponents(change)),
+			Channel:       reservation.Channel,
 		},
 	}
 
@@ -129,12 +234,25 @@ func (uc *ReleaseReservationUseCase) Execute(ctx context.Context, input ReleaseR
 		log.Printf("Failed to publish StockReleased event: %v", err)
 	}
 
+	notifyStockAvailable(uc.notifier, freedProducts(item, change)...)
+
+	availableStock, reservedStock := item.Available(), item.Reserved
+	if change != nil {
+		available, inStock := bundleStock(change, reservation.Quantity)
+		availableStock, reservedStock = available, inStock-available
+	}
+
 	return &ReleaseReservationOutput{
 		ReservationID:    reservation.ID,
 		InventoryItemID:  item.ID,
 		OrderID:          reservation.OrderID,
 		QuantityReleased: reservation.Quantity,
-		AvailableStock:   item.Available(),
-		ReservedStock:    item.Reserved,
+		AvailableStock:   availableStock,
+		ReservedStock:    reservedStock,
+		Serials:          entity.SerialNumbers(units),
+		Components:       changedComponents(change),
+		ComponentItems:   changedComponentItems(change),
+		Reservation:      reservation,
+		Item:             item,
 	}, nil
 }
@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	goerrors "errors"
 	"testing"
 
 	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
@@ -352,12 +353,14 @@ func TestReleaseReservationUseCase_Execute_InventoryErrors(t *testing.T) {
 		mockReservationRepo.AssertExpectations(t)
 	})
 
-	t.Run("should return error when inventory update fails due to optimistic lock", func(t *testing.T) {
+	t.Run("should return error when inventory update keeps failing due to optimistic lock", func(t *testing.T) {
 		// Arrange
 		mockInventoryRepo := new(MockInventoryRepository)
 		mockReservationRepo := new(MockReservationRepository)
 		mockPublisher := new(MockPublisher)
-		uc := NewReleaseReservationUseCase(mockInventoryRepo, mockReservationRepo, mockPublisher)
+		observer := newRecordingObserver()
+		uc := NewReleaseReservationUseCase(mockInventoryRepo, mockReservationRepo, mockPublisher).
+			WithRetryPolicy(noBackoff(3, observer))
 
 		productID := uuid.New()
 		orderID := uuid.New()
@@ -368,8 +371,8 @@ func TestReleaseReservationUseCase_Execute_InventoryErrors(t *testing.T) {
 		reservation, _ := entity.NewReservation(item.ID, orderID, 50)
 
 		mockReservationRepo.On("FindByID", mock.Anything, reservation.ID).Return(reservation, nil)
-		mockInventoryRepo.On("FindByID", mock.Anything, item.ID).Return(item, nil)
-		mockInventoryRepo.On("Update", mock.Anything, mock.AnythingOfType("*entity.InventoryItem")).Return(errors.ErrOptimisticLockFailure)
+		expectFreshReads(mockInventoryRepo, "FindByID", item.ID, item, 3)
+		mockInventoryRepo.On("Update", mock.Anything, mock.AnythingOfType("*entity.InventoryItem")).Return(errors.ErrOptimisticLockFailure).Times(3)
 
 		input := ReleaseReservationInput{
 			ReservationID: reservation.ID,
@@ -381,7 +384,12 @@ func TestReleaseReservationUseCase_Execute_InventoryErrors(t *testing.T) {
 		// Assert
 		assert.Error(t, err)
 		assert.Nil(t, output)
-		assert.Equal(t, errors.ErrOptimisticLockFailure, err)
+		var contention *ContentionError
+		require.True(t, goerrors.As(err, &contention))
+		assert.Equal(t, productID, contention.ProductID)
+		assert.Equal(t, 3, contention.Attempts)
+		assert.Equal(t, 3, observer.conflicts[OperationRelease])
+		assert.Equal(t, 1, observer.exhausted[OperationRelease])
 
 		mockInventoryRepo.AssertExpectations(t)
 		mockReservationRepo.AssertExpectations(t)
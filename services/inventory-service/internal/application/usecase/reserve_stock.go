@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	goerrors "errors"
 	"log"
 	"time"
 
@@ -18,6 +19,7 @@ type ReserveStockInput struct {
 	OrderID   uuid.UUID
 	Quantity  int
 	Duration  *time.Duration // Optional: if nil, uses default 15 minutes
+	Channel   string         // Optional sales channel; empty reserves from the shared pool
 }
 
 // ReserveStockOutput represents the result of stock reservation
@@ -29,6 +31,10 @@ type ReserveStockOutput struct {
 	ExpiresAt            time.Time
 	RemainingStock       int
 	ReservationCreatedAt time.Time
+	Lots                 []*entity.LotAllocation        // nil without WithLots
+	Serials              []string                       // serial-tracked products only
+	Components           []*entity.ReservationComponent // bundles only
+	Channel              string
 }
 
 // ReserveStockUseCase handles creating temporary stock reservations
@@ -36,6 +42,13 @@ type ReserveStockUseCase struct {
 	inventoryRepo   repository.InventoryRepository
 	reservationRepo repository.ReservationRepository
 	publisher       events.Publisher
+	retry           RetryPolicy
+	atomicStock     repository.AtomicStockRepository
+	lots            repository.LotRepository
+	serials         repository.SerialUnitRepository
+	bundles         repository.BundleRepository
+	channels        repository.ChannelAllocationRepository
+	limits          repository.PurchaseLimitRepository
 }
 
 // NewReserveStockUseCase creates a new instance of ReserveStockUseCase
@@ -48,23 +61,94 @@ func NewReserveStockUseCase(
 		inventoryRepo:   inventoryRepo,
 		reservationRepo: reservationRepo,
 		publisher:       publisher,
+		retry:           DefaultRetryPolicy(),
 	}
 }
 
+// WithRetryPolicy replaces the policy used to retry optimistic-lock conflicts
+func (uc *ReserveStockUseCase) WithRetryPolicy(policy RetryPolicy) *ReserveStockUseCase {
+	uc.retry = policy
+	return uc
+}
+
+// WithAtomicStock makes the use case reserve stock with a single conditional
+// statement instead of the optimistic-lock read-modify-write cycle
+func (uc *ReserveStockUseCase) WithAtomicStock(atomicStock repository.AtomicStockRepository) *ReserveStockUseCase {
+	uc.atomicStock = atomicStock
+	return uc
+}
+
+// WithLots makes the use case allocate the item's lots to every reservation,
+// first-expired-first-out
+func (uc *ReserveStockUseCase) WithLots(lots repository.LotRepository) *ReserveStockUseCase {
+	uc.lots = lots
+	return uc
+}
+
+// WithSerials makes the use case reserve concrete units of serial-tracked products,
+// which otherwise fail with ErrSerialTrackedItem
+func (uc *ReserveStockUseCase) WithSerials(serials repository.SerialUnitRepository) *ReserveStockUseCase {
+	uc.serials = serials
+	return uc
+}
+
+// WithBundles makes the use case reserve the components of bundles, which
+// otherwise fail with ErrBundleItem
+func (uc *ReserveStockUseCase) WithBundles(bundles repository.BundleRepository) *ReserveStockUseCase {
+	uc.bundles = bundles
+	return uc
+}
+
+// WithChannels makes the use case reserve products allocated to sales channels
+// from the allocation of the reservation's channel, which otherwise fail with
+// ErrChannelAllocatedItem
+func (uc *ReserveStockUseCase) WithChannels(channels repository.ChannelAllocationRepository) *ReserveStockUseCase {
+	uc.channels = channels
+	return uc
+}
+
+// WithPurchaseLimits makes the use case enforce the purchase limits of products,
+// rejecting reservations over them with ErrPurchaseLimitExceeded and a StockFailed event
+func (uc *ReserveStockUseCase) WithPurchaseLimits(limits repository.PurchaseLimitRepository) *ReserveStockUseCase {
+	uc.limits = limits
+	return uc
+}
+
 // Execute creates a temporary stock reservation with optimistic locking
 // It performs the following steps:
 // 1. Validates input
-// 2. Finds inventory item by product ID
-// 3. Checks if sufficient stock is available
-// 4. Reserves stock (increments Reserved field)
-// 5. Creates reservation entity
+// 2. Creates reservation entity for the end user of the context
+// 3. Finds inventory item by product ID
+// 4. Checks if sufficient stock is available
+// 5. Reserves stock (increments Reserved field)
 // 6. Updates inventory with optimistic locking (Version check)
 // 7. Saves reservation
+// 8. Allocates lots first-expired-first-out when configured with WithLots
+//
+// Steps 3-6 are retried according to the RetryPolicy when another writer
+// bumps the Version first; a *ContentionError is returned once attempts run out.
+// With WithAtomicStock, steps 3-6 are a single conditional UPDATE instead.
+// For serial-tracked products, steps 3-6 reserve the oldest available units
+// through WithSerials instead, and for bundles the units of every component
+// through WithBundles, all of them or none. For products allocated to sales
+// channels, steps 3-7 reserve from the allocation of the channel through
+// WithChannels instead, in one transaction. With WithPurchaseLimits, steps 3-7
+// only run within the product's purchase limits, under the purchase lock of the
+// customer for products limited per customer.
 func (uc *ReserveStockUseCase) Execute(ctx context.Context, input ReserveStockInput) (*ReserveStockOutput, error) {
 	// Validate input
 	if input.Quantity <= 0 {
 		return nil, errors.ErrInvalidQuantity
 	}
+	if err := validateOptionalChannel(input.Channel); err != nil {
+		return nil, err
+	}
+	userID := UserIDFromContext(ctx)
+	if userID != "" {
+		if err := entity.ValidateUserID(userID); err != nil {
+			return nil, err
+		}
+	}
 
 	// Check if reservation already exists for this order
 	exists, err := uc.reservationRepo.ExistsByOrderID(ctx, input.OrderID)
@@ -75,59 +159,89 @@ func (uc *ReserveStockUseCase) Execute(ctx context.Context, input ReserveStockIn
 		return nil, errors.ErrReservationAlreadyExists.WithDetails("order_id: " + input.OrderID.String())
 	}
 
-	// Find inventory item by product ID
-	item, err := uc.inventoryRepo.FindByProductID(ctx, input.ProductID)
-	if err != nil {
-		return nil, errors.ErrInventoryItemNotFound.WithDetails(err.Error())
-	}
-
-	// Reserve stock (this checks availability and updates Reserved field)
-	if err := item.Reserve(input.Quantity); err != nil {
-		return nil, err
-	}
-
-	// Create reservation entity
+	// Create reservation entity up front so invalid input never touches stock;
+	// it is linked to the inventory item once the stock is reserved
 	var reservation *entity.Reservation
 	if input.Duration != nil {
-		reservation, err = entity.NewReservationWithDuration(
-			item.ID,
-			input.OrderID,
-			input.Quantity,
-			*input.Duration,
-		)
+		reservation, err = entity.NewReservationWithDuration(uuid.Nil, input.OrderID, input.Quantity, *input.Duration)
 	} else {
-		reservation, err = entity.NewReservation(
-			item.ID,
-			input.OrderID,
-			input.Quantity,
-		)
+		reservation, err = entity.NewReservation(uuid.Nil, input.OrderID, input.Quantity)
 	}
 	if err != nil {
-		// Rollback the reservation in memory (domain entity)
-		item.ReleaseReservation(input.Quantity)
 		return nil, err
 	}
+	reservation.Channel = input.Channel
+	reservation.UserID = userID
 
-	// Update inventory with optimistic locking
-	// The Update method should check Version field and increment it
-	if err := uc.inventoryRepo.Update(ctx, item); err != nil {
-		return nil, err
-	}
+	var item *entity.InventoryItem
+	var units []*entity.SerialUnit
+	var change *repository.BundleStockChange
+	reserve := func() error {
+		var err error
+		item, err = uc.reserveItem(ctx, input.ProductID, input.Quantity)
+		channelReserved := usesChannels(err, uc.channels)
+		if channelReserved {
+			item, err = uc.channels.Reserve(ctx, reservation, input.ProductID)
+		}
+		if usesSerials(err, uc.serials) {
+			item, units, err = uc.serials.Reserve(ctx, reservation.ID, input.ProductID, input.Quantity)
+		}
+		if usesBundles(err, uc.bundles) {
+			change, err = uc.bundles.Reserve(ctx, reservation.ID, input.ProductID, input.Quantity)
+		}
+		if err != nil {
+			return err
+		}
+		if change != nil {
+			item = change.Bundle
+		}
+		reservation.InventoryItemID = item.ID
 
-	// Save reservation
-	if err := uc.reservationRepo.Save(ctx, reservation); err != nil {
+		// Save reservation, unless the channel allocations saved it with the stock
+		if channelReserved {
+			return nil
+		}
 		// Note: In a real system, this should be wrapped in a transaction
 		// or use compensating actions to rollback the inventory update
+		return uc.reservationRepo.Save(ctx, reservation)
+	}
+
+	if uc.limits != nil {
+		err = reserveWithinPurchaseLimits(ctx, uc.limits, uc.retry, input.ProductID, input.Quantity, userID, reserve)
+		if goerrors.Is(err, errors.ErrPurchaseLimitExceeded) {
+			uc.publishReserveFailed(ctx, input, userID, err)
+		}
+	} else {
+		err = reserve()
+	}
+	if err != nil {
 		return nil, err
 	}
 
+	// Allocate lots of every item the reservation holds (don't fail the
+	// reservation: units not covered by a lot are untracked stock, and reconcile
+	// allocates the lots the reservation is missing)
+	var allocations []*entity.LotAllocation
+	held := stockHeld(item, reservation.Quantity, change)
+	if uc.lots != nil {
+		now := time.Now()
+		for _, stock := range held {
+			allocated, err := uc.lots.Allocate(ctx, reservation.ID, stock.item.ID, stock.quantity, now)
+			if err != nil {
+				log.Printf("Failed to allocate lots to reservation %s: %v", reservation.ID, err)
+				continue
+			}
+			allocations = append(allocations, allocated...)
+		}
+	}
+
 	// Publish StockReserved event (don't fail transaction if event publication fails)
 	stockReservedEvent := events.StockReservedEvent{
 		BaseEvent: events.BaseEvent{
 			EventID:   uuid.New().String(),
 			EventType: events.RoutingKeyStockReserved,
 			Timestamp: time.Now().Format(time.RFC3339),
-			Version:   events.EventVersion,
+			Version:   events.StockReservedVersion,
 			Source:    events.SourceInventoryService,
 		},
 		Payload: events.StockReservedPayload{
@@ -135,9 +249,12 @@ func (uc *ReserveStockUseCase) Execute(ctx context.Context, input ReserveStockIn
 			ProductID:     input.ProductID.String(),
 			Quantity:      input.Quantity,
 			OrderID:       input.OrderID.String(),
-			UserID:        "", // TODO: Get from context when auth is implemented
+			UserID:        reservation.UserID,
 			ExpiresAt:     reservation.ExpiresAt,
 			ReservedAt:    reservation.CreatedAt,
+			Serials:       entity.SerialNumbers(units),
+			Components:    componentQuantities(changedComponents(change)),
+			Channel:       reservation.Channel,
 		},
 	}
 
@@ -146,22 +263,26 @@ func (uc *ReserveStockUseCase) Execute(ctx context.Context, input ReserveStockIn
 		log.Printf("Failed to publish StockReserved event: %v", err)
 	}
 
-	// Publish StockDepleted event if available quantity reached zero
-	if item.Available() == 0 {
+	// Publish StockDepleted events for the items whose available quantity reached zero
+	for _, stock := range held {
+		if stock.item.Available() != 0 {
+			continue
+		}
+
 		stockDepletedEvent := events.StockDepletedEvent{
 			BaseEvent: events.BaseEvent{
 				EventID:   uuid.New().String(),
 				EventType: events.RoutingKeyStockDepleted,
 				Timestamp: time.Now().Format(time.RFC3339),
-				Version:   events.EventVersion,
+				Version:   events.StockDepletedVersion,
 				Source:    events.SourceInventoryService,
 			},
 			Payload: events.StockDepletedPayload{
-				ProductID:    input.ProductID.String(),
+				ProductID:    stock.item.ProductID.String(),
 				OrderID:      input.OrderID.String(),
-				UserID:       "", // TODO: Get from context when auth is implemented
+				UserID:       reservation.UserID,
 				DepletedAt:   time.Now(),
-				LastQuantity: input.Quantity,
+				LastQuantity: stock.quantity,
 			},
 		}
 
@@ -171,13 +292,87 @@ func (uc *ReserveStockUseCase) Execute(ctx context.Context, input ReserveStockIn
 		}
 	}
 
+	remaining := item.Available()
+	if change != nil {
+		remaining, _ = bundleStock(change, input.Quantity)
+	}
+
 	return &ReserveStockOutput{
 		ReservationID:        reservation.ID,
 		ProductID:            input.ProductID,
 		OrderID:              input.OrderID,
 		Quantity:             input.Quantity,
 		ExpiresAt:            reservation.ExpiresAt,
-		RemainingStock:       item.Available(),
+		RemainingStock:       remaining,
 		ReservationCreatedAt: reservation.CreatedAt,
+		Lots:                 allocations,
+		Serials:              entity.SerialNumbers(units),
+		Components:           changedComponents(change),
+		Channel:              reservation.Channel,
 	}, nil
 }
+
+// publishReserveFailed publishes a StockFailed event for a rejected reservation
+// (don't fail on publication errors: the caller gets the rejection anyway)
+func (uc *ReserveStockUseCase) publishReserveFailed(ctx context.Context, input ReserveStockInput, userID string, err error) {
+	errorCode := "UNKNOWN"
+	var domainErr *errors.DomainError
+	if goerrors.As(err, &domainErr) {
+		errorCode = domainErr.Code
+	}
+	quantity := input.Quantity
+
+	stockFailedEvent := events.StockFailedEvent{
+		BaseEvent: events.BaseEvent{
+			EventID:   uuid.New().String(),
+			EventType: events.RoutingKeyStockFailed,
+			Timestamp: time.Now().Format(time.RFC3339),
+			Version:   events.StockFailedVersion,
+			Source:    events.SourceInventoryService,
+		},
+		Payload: events.StockFailedPayload{
+			OperationType: OperationReserve,
+			ProductID:     input.ProductID.String(),
+			Quantity:      &quantity,
+			OrderID:       input.OrderID.String(),
+			UserID:        userID,
+			ErrorCode:     errorCode,
+			ErrorMessage:  err.Error(),
+			FailedAt:      time.Now(),
+		},
+	}
+
+	if err := uc.publisher.PublishStockFailed(ctx, stockFailedEvent); err != nil {
+		log.Printf("Failed to publish StockFailed event: %v", err)
+	}
+}
+
+// reserveItem reserves quantity on the product's inventory item and returns the
+// item as stored afterwards
+func (uc *ReserveStockUseCase) reserveItem(ctx context.Context, productID uuid.UUID, quantity int) (*entity.InventoryItem, error) {
+	var item *entity.InventoryItem
+	err := uc.retry.run(ctx, OperationReserve, func() (uuid.UUID, error) {
+		var err error
+		if uc.atomicStock != nil {
+			item, err = uc.atomicStock.ReserveStock(ctx, productID, quantity)
+			return productID, err
+		}
+
+		// Read, reserve and write the inventory item, re-reading it after every
+		// optimistic-lock conflict so the reservation applies to the latest version
+		item, err = uc.inventoryRepo.FindByProductID(ctx, productID)
+		if err != nil {
+			return productID, errors.ErrInventoryItemNotFound.WithDetails(err.Error())
+		}
+
+		// Reserve stock (this checks availability and updates Reserved field)
+		if err := item.Reserve(quantity); err != nil {
+			return productID, err
+		}
+
+		// Update inventory with optimistic locking
+		// The Update method checks the Version field and increments it
+		return productID, uc.inventoryRepo.Update(ctx, item)
+	})
+	return item, err
+}
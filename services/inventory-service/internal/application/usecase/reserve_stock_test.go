@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	goerrors "errors"
 	"testing"
 	"time"
 
@@ -44,6 +45,16 @@ func (m *MockPublisher) PublishStockDepleted(ctx context.Context, event events.S
 	return args.Error(0)
 }
 
+func (m *MockPublisher) PublishLotQuarantined(ctx context.Context, event events.LotQuarantinedEvent) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
+
+func (m *MockPublisher) PublishWaitlistFulfilled(ctx context.Context, event events.WaitlistFulfilledEvent) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
+
 func (m *MockPublisher) Close() error {
 	args := m.Called()
 	return args.Error(0)
@@ -585,20 +596,22 @@ func TestReserveStockUseCase_Execute_RepositoryErrors(t *testing.T) {
 		mockReservationRepo.AssertExpectations(t)
 	})
 
-	t.Run("should return error when update fails due to optimistic lock", func(t *testing.T) {
+	t.Run("should return error when update keeps failing due to optimistic lock", func(t *testing.T) {
 		// Arrange
 		mockInventoryRepo := new(MockInventoryRepository)
 		mockReservationRepo := new(MockReservationRepository)
 		mockPublisher := new(MockPublisher)
-		uc := NewReserveStockUseCase(mockInventoryRepo, mockReservationRepo, mockPublisher)
+		observer := newRecordingObserver()
+		uc := NewReserveStockUseCase(mockInventoryRepo, mockReservationRepo, mockPublisher).
+			WithRetryPolicy(noBackoff(3, observer))
 
 		productID := uuid.New()
 		orderID := uuid.New()
 		item, _ := entity.NewInventoryItem(productID, 100)
 
 		mockReservationRepo.On("ExistsByOrderID", mock.Anything, orderID).Return(false, nil)
-		mockInventoryRepo.On("FindByProductID", mock.Anything, productID).Return(item, nil)
-		mockInventoryRepo.On("Update", mock.Anything, mock.AnythingOfType("*entity.InventoryItem")).Return(errors.ErrOptimisticLockFailure)
+		expectFreshReads(mockInventoryRepo, "FindByProductID", productID, item, 3)
+		mockInventoryRepo.On("Update", mock.Anything, mock.AnythingOfType("*entity.InventoryItem")).Return(errors.ErrOptimisticLockFailure).Times(3)
 
 		input := ReserveStockInput{
 			ProductID: productID,
@@ -612,9 +625,95 @@ func TestReserveStockUseCase_Execute_RepositoryErrors(t *testing.T) {
 		// Assert
 		assert.Error(t, err)
 		assert.Nil(t, output)
-		assert.Equal(t, errors.ErrOptimisticLockFailure, err)
+		var contention *ContentionError
+		require.True(t, goerrors.As(err, &contention))
+		assert.Equal(t, productID, contention.ProductID)
+		assert.Equal(t, 3, contention.Attempts)
+		assert.Equal(t, 3, observer.conflicts[OperationReserve])
+		assert.Equal(t, 1, observer.exhausted[OperationReserve])
+
+		mockInventoryRepo.AssertExpectations(t)
+		mockReservationRepo.AssertExpectations(t)
+	})
+}
+
+func TestReserveStockUseCase_Execute_RetriesOptimisticLockConflicts(t *testing.T) {
+	t.Run("should re-read the item and succeed after a conflict", func(t *testing.T) {
+		// Arrange
+		mockInventoryRepo := new(MockInventoryRepository)
+		mockReservationRepo := new(MockReservationRepository)
+		mockPublisher := new(MockPublisher)
+		observer := newRecordingObserver()
+		uc := NewReserveStockUseCase(mockInventoryRepo, mockReservationRepo, mockPublisher).
+			WithRetryPolicy(noBackoff(3, observer))
+
+		productID := uuid.New()
+		orderID := uuid.New()
+		stale, _ := entity.NewInventoryItem(productID, 100)
+
+		// A concurrent checkout reserved 30 units between our read and write
+		latest := *stale
+		latest.Reserved = 30
+		latest.Version = stale.Version + 1
+
+		mockReservationRepo.On("ExistsByOrderID", mock.Anything, orderID).Return(false, nil)
+		mockInventoryRepo.On("FindByProductID", mock.Anything, productID).Return(stale, nil).Once()
+		mockInventoryRepo.On("FindByProductID", mock.Anything, productID).Return(&latest, nil).Once()
+		mockInventoryRepo.On("Update", mock.Anything, mock.AnythingOfType("*entity.InventoryItem")).Return(errors.ErrOptimisticLockFailure).Once()
+		mockInventoryRepo.On("Update", mock.Anything, mock.AnythingOfType("*entity.InventoryItem")).Return(nil).Once()
+		mockReservationRepo.On("Save", mock.Anything, mock.AnythingOfType("*entity.Reservation")).Return(nil).Once()
+		mockPublisher.On("PublishStockReserved", mock.Anything, mock.AnythingOfType("events.StockReservedEvent")).Return(nil)
+
+		input := ReserveStockInput{
+			ProductID: productID,
+			OrderID:   orderID,
+			Quantity:  50,
+		}
+
+		// Act
+		output, err := uc.Execute(context.Background(), input)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 20, output.RemainingStock) // 100 - 30 concurrent - 50 ours
+		assert.Equal(t, 80, latest.Reserved)
+		assert.Equal(t, 1, observer.conflicts[OperationReserve])
+		assert.Zero(t, observer.exhausted[OperationReserve])
 
 		mockInventoryRepo.AssertExpectations(t)
 		mockReservationRepo.AssertExpectations(t)
 	})
+
+	t.Run("should surface insufficient stock discovered on re-read", func(t *testing.T) {
+		// Arrange
+		mockInventoryRepo := new(MockInventoryRepository)
+		mockReservationRepo := new(MockReservationRepository)
+		mockPublisher := new(MockPublisher)
+		uc := NewReserveStockUseCase(mockInventoryRepo, mockReservationRepo, mockPublisher).
+			WithRetryPolicy(noBackoff(3, nil))
+
+		productID := uuid.New()
+		orderID := uuid.New()
+		stale, _ := entity.NewInventoryItem(productID, 100)
+		latest := *stale
+		latest.Reserved = 90
+
+		mockReservationRepo.On("ExistsByOrderID", mock.Anything, orderID).Return(false, nil)
+		mockInventoryRepo.On("FindByProductID", mock.Anything, productID).Return(stale, nil).Once()
+		mockInventoryRepo.On("FindByProductID", mock.Anything, productID).Return(&latest, nil).Once()
+		mockInventoryRepo.On("Update", mock.Anything, mock.AnythingOfType("*entity.InventoryItem")).Return(errors.ErrOptimisticLockFailure).Once()
+
+		// Act
+		output, err := uc.Execute(context.Background(), ReserveStockInput{
+			ProductID: productID,
+			OrderID:   orderID,
+			Quantity:  50,
+		})
+
+		// Assert
+		assert.Nil(t, output)
+		assert.Equal(t, errors.ErrInsufficientStock, err)
+		mockReservationRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+		mockInventoryRepo.AssertExpectations(t)
+	})
 }
@@ -0,0 +1,131 @@
+package usecase
+
+import (
+	"context"
+	goerrors "errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+	"github.com/google/uuid"
+)
+
+// Operation names reported to the ContentionObserver
+const (
+	OperationReserve       = "reserve"
+	OperationConfirm       = "confirm"
+	OperationRelease       = "release"
+	OperationReleaseExpire = "release_expired"
+)
+
+// ContentionObserver receives optimistic-lock contention signals per product.
+// Implementations must be safe for concurrent use.
+type ContentionObserver interface {
+	// ObserveConflict is called every time an attempt loses the version race
+	ObserveConflict(operation string, productID uuid.UUID)
+	// ObserveExhausted is called when all attempts lost the version race
+	ObserveExhausted(operation string, productID uuid.UUID)
+}
+
+// RetryPolicy bounds how read-modify-write cycles are retried after an
+// optimistic-lock conflict. Every attempt re-reads the inventory item so the
+// change is applied on top of the latest version.
+type RetryPolicy struct {
+	MaxAttempts int           // total attempts including the first one
+	BaseDelay   time.Duration // backoff before the second attempt, doubled afterwards
+	MaxDelay    time.Duration // upper bound for a single backoff
+	RetryAfter  time.Duration // hint returned to clients once attempts are exhausted
+	Observer    ContentionObserver
+}
+
+// DefaultRetryPolicy returns the policy used when none is configured
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 5,
+		BaseDelay:   10 * time.Millisecond,
+		MaxDelay:    200 * time.Millisecond,
+		RetryAfter:  time.Second,
+	}
+}
+
+// ContentionError is returned when every attempt of an operation hit an
+// optimistic-lock conflict. It unwraps to errors.ErrConcurrentModification so
+// transports that only know domain errors still classify it as a conflict.
+type ContentionError struct {
+	Operation  string
+	ProductID  uuid.UUID
+	Attempts   int
+	RetryAfter time.Duration
+}
+
+// Error implements the error interface
+func (e *ContentionError) Error() string {
+	return fmt.Sprintf("%s on product %s gave up after %d conflicting attempts", e.Operation, e.ProductID, e.Attempts)
+}
+
+// Unwrap exposes the underlying domain error
+func (e *ContentionError) Unwrap() error {
+	return errors.ErrConcurrentModification.WithDetails(e.Error())
+}
+
+// run executes fn until it succeeds, fails with an error other than an
+// optimistic-lock conflict, or MaxAttempts is reached. fn returns the product
+// it touched so contention can be reported per product.
+func (p RetryPolicy) run(ctx context.Context, operation string, fn func() (uuid.UUID, error)) error {
+	attempts := p.MaxAttempts
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	var productID uuid.UUID
+	for attempt := 1; ; attempt++ {
+		var err error
+		productID, err = fn()
+		if err == nil {
+			return nil
+		}
+		if !goerrors.Is(err, errors.ErrOptimisticLockFailure) {
+			return err
+		}
+		if p.Observer != nil {
+			p.Observer.ObserveConflict(operation, productID)
+		}
+		if attempt >= attempts {
+			break
+		}
+
+		timer := time.NewTimer(p.backoff(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	if p.Observer != nil {
+		p.Observer.ObserveExhausted(operation, productID)
+	}
+	return &ContentionError{
+		Operation:  operation,
+		ProductID:  productID,
+		Attempts:   attempts,
+		RetryAfter: p.RetryAfter,
+	}
+}
+
+// backoff returns a full-jitter exponential delay for the given failed attempt
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	if p.BaseDelay <= 0 {
+		return 0
+	}
+	ceiling := p.BaseDelay << (attempt - 1)
+	if ceiling <= 0 || (p.MaxDelay > 0 && ceiling > p.MaxDelay) {
+		ceiling = p.MaxDelay
+	}
+	if ceiling <= 0 {
+		return 0
+	}
+	return rand.N(ceiling + 1)
+}
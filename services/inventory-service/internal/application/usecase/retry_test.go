@@ -0,0 +1,161 @@
+package usecase
+
+import (
+	"context"
+	goerrors "errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingObserver counts contention signals per operation
+type recordingObserver struct {
+	mu        sync.Mutex
+	conflicts map[string]int
+	exhausted map[string]int
+	products  []uuid.UUID
+}
+
+func newRecordingObserver() *recordingObserver {
+	return &recordingObserver{conflicts: map[string]int{}, exhausted: map[string]int{}}
+}
+
+func (o *recordingObserver) ObserveConflict(operation string, productID uuid.UUID) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.conflicts[operation]++
+	o.products = append(o.products, productID)
+}
+
+func (o *recordingObserver) ObserveExhausted(operation string, productID uuid.UUID) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.exhausted[operation]++
+}
+
+// noBackoff returns a policy that retries immediately so tests do not sleep
+func noBackoff(attempts int, observer ContentionObserver) RetryPolicy {
+	return RetryPolicy{MaxAttempts: attempts, RetryAfter: 2 * time.Second, Observer: observer}
+}
+
+// expectFreshReads makes the given finder return an independent copy of item on
+// each of n calls, the way a real repository hands out a new entity per read
+func expectFreshReads(m *MockInventoryRepository, method string, key interface{}, item *entity.InventoryItem, n int) {
+	for i := 0; i < n; i++ {
+		copied := *item
+		m.On(method, mock.Anything, key).Return(&copied, nil).Once()
+	}
+}
+
+func TestRetryPolicy_Run(t *testing.T) {
+	productID := uuid.New()
+
+	t.Run("should return immediately on success", func(t *testing.T) {
+		calls := 0
+		err := noBackoff(3, nil).run(context.Background(), OperationReserve, func() (uuid.UUID, error) {
+			calls++
+			return productID, nil
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("should not retry errors other than optimistic lock failures", func(t *testing.T) {
+		calls := 0
+		err := noBackoff(3, nil).run(context.Background(), OperationReserve, func() (uuid.UUID, error) {
+			calls++
+			return productID, errors.ErrInsufficientStock
+		})
+
+		assert.Equal(t, errors.ErrInsufficientStock, err)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("should succeed once the conflict clears", func(t *testing.T) {
+		observer := newRecordingObserver()
+		calls := 0
+		err := noBackoff(3, observer).run(context.Background(), OperationConfirm, func() (uuid.UUID, error) {
+			calls++
+			if calls < 3 {
+				return productID, errors.ErrOptimisticLockFailure
+			}
+			return productID, nil
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, calls)
+		assert.Equal(t, 2, observer.conflicts[OperationConfirm])
+		assert.Zero(t, observer.exhausted[OperationConfirm])
+	})
+
+	t.Run("should return a contention error when attempts run out", func(t *testing.T) {
+		observer := newRecordingObserver()
+		calls := 0
+		err := noBackoff(4, observer).run(context.Background(), OperationRelease, func() (uuid.UUID, error) {
+			calls++
+			return productID, errors.ErrOptimisticLockFailure
+		})
+
+		var contention *ContentionError
+		require.True(t, goerrors.As(err, &contention))
+		assert.Equal(t, 4, calls)
+		assert.Equal(t, OperationRelease, contention.Operation)
+		assert.Equal(t, productID, contention.ProductID)
+		assert.Equal(t, 4, contention.Attempts)
+		assert.Equal(t, 2*time.Second, contention.RetryAfter)
+		assert.True(t, goerrors.Is(err, errors.ErrConcurrentModification))
+		assert.True(t, errors.IsConflictError(goerrors.Unwrap(err)))
+		assert.Equal(t, 4, observer.conflicts[OperationRelease])
+		assert.Equal(t, 1, observer.exhausted[OperationRelease])
+		assert.Equal(t, []uuid.UUID{productID, productID, productID, productID}, observer.products)
+	})
+
+	t.Run("should stop waiting when the context is cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		policy := RetryPolicy{MaxAttempts: 5, BaseDelay: time.Hour, MaxDelay: time.Hour}
+		calls := 0
+		err := policy.run(ctx, OperationReserve, func() (uuid.UUID, error) {
+			calls++
+			cancel()
+			return productID, errors.ErrOptimisticLockFailure
+		})
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("should run once when max attempts is not positive", func(t *testing.T) {
+		calls := 0
+		err := RetryPolicy{}.run(context.Background(), OperationReserve, func() (uuid.UUID, error) {
+			calls++
+			return productID, errors.ErrOptimisticLockFailure
+		})
+
+		var contention *ContentionError
+		require.True(t, goerrors.As(err, &contention))
+		assert.Equal(t, 1, calls)
+		assert.Equal(t, 1, contention.Attempts)
+	})
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := RetryPolicy{BaseDelay: 10 * time.Millisecond, MaxDelay: 40 * time.Millisecond}
+
+	for i := 0; i < 100; i++ {
+		assert.LessOrEqual(t, policy.backoff(1), 10*time.Millisecond)
+		assert.LessOrEqual(t, policy.backoff(2), 20*time.Millisecond)
+		assert.LessOrEqual(t, policy.backoff(5), 40*time.Millisecond)
+		assert.LessOrEqual(t, policy.backoff(70), 40*time.Millisecond)
+		assert.GreaterOrEqual(t, policy.backoff(3), time.Duration(0))
+	}
+
+	assert.Zero(t, RetryPolicy{}.backoff(3))
+}
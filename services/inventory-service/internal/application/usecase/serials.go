@@ -0,0 +1,294 @@
+package usecase
+
+import (
+	"context"
+	goerrors "errors"
+	"strings"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+// MaxSerialsPerRegistration caps the serial numbers registered in one request
+const MaxSerialsPerRegistration = 1000
+
+// usesSerials reports whether a count-based stock change failed because the item
+// is serial-tracked and serials can apply it to the item's units instead
+func usesSerials(err error, serials repository.SerialUnitRepository) bool {
+	return serials != nil && goerrors.Is(err, errors.ErrSerialTrackedItem)
+}
+
+// SetSerialTrackingUseCase turns serial tracking of a product on or off
+type SetSerialTrackingUseCase struct {
+	inventoryRepo repository.InventoryRepository
+}
+
+// NewSetSerialTrackingUseCase creates a new instance
+func NewSetSerialTrackingUseCase(inventoryRepo repository.InventoryRepository) *SetSerialTrackingUseCase {
+	if inventoryRepo == nil {
+		panic("inventoryRepo cannot be nil")
+	}
+
+	return &SetSerialTrackingUseCase{inventoryRepo: inventoryRepo}
+}
+
+// Execute sets whether the product's stock is tracked per serial unit and returns
+// the stored item. Tracking can only change while the item has no stock, since
+// count-based stock has no units and units cannot become anonymous.
+func (uc *SetSerialTrackingUseCase) Execute(ctx context.Context, productID uuid.UUID, enabled bool) (*entity.InventoryItem, error) {
+	item, err := uc.inventoryRepo.FindByProductID(ctx, productID)
+	if err != nil {
+		return nil, errors.ErrInventoryItemNotFound.WithDetails(err.Error())
+	}
+	if item.SerialTracked == enabled {
+		return item, nil
+	}
+
+	if enabled {
+		err = item.EnableSerialTracking()
+	} else {
+		err = item.DisableSerialTracking()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.inventoryRepo.Update(ctx, item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// RegisterSerialsInput represents serialized units received for a product
+type RegisterSerialsInput struct {
+	ProductID     uuid.UUID
+	SerialNumbers []string
+}
+
+// RegisterSerialsOutput represents the stored units and inventory item after a registration
+type RegisterSerialsOutput struct {
+	Units []*entity.SerialUnit
+	Item  *entity.InventoryItem
+}
+
+// RegisterSerialsUseCase adds serialized units to the stock of a serial-tracked product
+type RegisterSerialsUseCase struct {
+	inventoryRepo repository.InventoryRepository
+	serialRepo    repository.SerialUnitRepository
+	notifier      WaitlistNotifier
+}
+
+// NewRegisterSerialsUseCase creates a new instance
+func NewRegisterSerialsUseCase(inventoryRepo repository.InventoryRepository, serialRepo repository.SerialUnitRepository) *RegisterSerialsUseCase {
+	if inventoryRepo == nil {
+		panic("inventoryRepo cannot be nil")
+	}
+	if serialRepo == nil {
+		panic("serialRepo cannot be nil")
+	}
+
+	return &RegisterSerialsUseCase{
+		inventoryRepo: inventoryRepo,
+		serialRepo:    serialRepo,
+	}
+}
+
+// WithWaitlist makes the use case serve the product's waitlist once new units are registered
+func (uc *RegisterSerialsUseCase) WithWaitlist(notifier WaitlistNotifier) *RegisterSerialsUseCase {
+	uc.notifier = notifier
+	return uc
+}
+
+// Execute validates the serial numbers and saves them as available units of the
+// product. Either every unit is registered or none is.
+func (uc *RegisterSerialsUseCase) Execute(ctx context.Context, input RegisterSerialsInput) (*RegisterSerialsOutput, error) {
+	if len(input.SerialNumbers) == 0 {
+		return nil, errors.ErrInvalidInput.WithDetails("serial_numbers is required")
+	}
+	if len(input.SerialNumbers) > MaxSerialsPerRegistration {
+		return nil, errors.ErrInvalidInput.WithDetails("too many serial numbers")
+	}
+
+	item, err := uc.inventoryRepo.FindByProductID(ctx, input.ProductID)
+	if err != nil {
+		return nil, errors.ErrInventoryItemNotFound.WithDetails(err.Error())
+	}
+	if !item.SerialTracked {
+		return nil, errors.ErrNotSerialTracked
+	}
+
+	units := make([]*entity.SerialUnit, 0, len(input.SerialNumbers))
+	seen := make(map[string]bool, len(input.SerialNumbers))
+	for _, serialNumber := range input.SerialNumbers {
+		unit, err := entity.NewSerialUnit(item.ID, serialNumber)
+		if err != nil {
+			return nil, err
+		}
+		if seen[unit.SerialNumber] {
+			return nil, errors.ErrInvalidInput.WithDetails("duplicate serial number: " + unit.SerialNumber)
+		}
+		seen[unit.SerialNumber] = true
+		units = append(units, unit)
+	}
+
+	stored, err := uc.serialRepo.Register(ctx, item.ID, units)
+	if err != nil {
+		return nil, err
+	}
+
+	notifyStockAvailable(uc.notifier, input.ProductID)
+	return &RegisterSerialsOutput{Units: units, Item: stored}, nil
+}
+
+// ListSerialsUseCase lists the serialized units of a product
+type ListSerialsUseCase struct {
+	inventoryRepo repository.InventoryRepository
+	serialRepo    repository.SerialUnitRepository
+}
+
+// NewListSerialsUseCase creates a new instance
+func NewListSerialsUseCase(inventoryRepo repository.InventoryRepository, serialRepo repository.SerialUnitRepository) *ListSerialsUseCase {
+	if inventoryRepo == nil {
+		panic("inventoryRepo cannot be nil")
+	}
+	if serialRepo == nil {
+		panic("serialRepo cannot be nil")
+	}
+
+	return &ListSerialsUseCase{
+		inventoryRepo: inventoryRepo,
+		serialRepo:    serialRepo,
+	}
+}
+
+// Execute returns the product's units in the given status (every status if empty), oldest first
+func (uc *ListSerialsUseCase) Execute(ctx context.Context, productID uuid.UUID, status entity.SerialUnitStatus) ([]*entity.SerialUnit, error) {
+	item, err := uc.inventoryRepo.FindByProductID(ctx, productID)
+	if err != nil {
+		return nil, errors.ErrInventoryItemNotFound.WithDetails(err.Error())
+	}
+
+	return uc.serialRepo.FindByInventoryItemID(ctx, item.ID, status)
+}
+
+// GetReservationSerialsUseCase tells which serialized units a reservation took
+type GetReservationSerialsUseCase struct {
+	reservationRepo repository.ReservationRepository
+	serialRepo      repository.SerialUnitRepository
+}
+
+// NewGetReservationSerialsUseCase creates a new instance
+func NewGetReservationSerialsUseCase(reservationRepo repository.ReservationRepository, serialRepo repository.SerialUnitRepository) *GetReservationSerialsUseCase {
+	if reservationRepo == nil {
+		panic("reservationRepo cannot be nil")
+	}
+	if serialRepo == nil {
+		panic("serialRepo cannot be nil")
+	}
+
+	return &GetReservationSerialsUseCase{
+		reservationRepo: reservationRepo,
+		serialRepo:      serialRepo,
+	}
+}
+
+// Execute returns the units the reservation holds or sold. Sold units outlive
+// archived reservations, so the reservation is only looked up when it has none.
+func (uc *GetReservationSerialsUseCase) Execute(ctx context.Context, reservationID uuid.UUID) ([]*entity.SerialUnit, error) {
+	units, err := uc.serialRepo.FindByReservationID(ctx, reservationID)
+	if err != nil {
+		return nil, err
+	}
+	if len(units) > 0 {
+		return units, nil
+	}
+
+	if _, err := findReservation(ctx, uc.reservationRepo, reservationID, uuid.Nil); err != nil {
+		return nil, err
+	}
+	return units, nil
+}
+
+// ReturnSerialUseCase records a sold unit coming back from a customer
+type ReturnSerialUseCase struct {
+	inventoryRepo repository.InventoryRepository
+	serialRepo    repository.SerialUnitRepository
+}
+
+// NewReturnSerialUseCase creates a new instance
+func NewReturnSerialUseCase(inventoryRepo repository.InventoryRepository, serialRepo repository.SerialUnitRepository) *ReturnSerialUseCase {
+	if inventoryRepo == nil {
+		panic("inventoryRepo cannot be nil")
+	}
+	if serialRepo == nil {
+		panic("serialRepo cannot be nil")
+	}
+
+	return &ReturnSerialUseCase{
+		inventoryRepo: inventoryRepo,
+		serialRepo:    serialRepo,
+	}
+}
+
+// Execute marks the product's sold unit returned. The unit stays out of stock
+// until it is restocked.
+func (uc *ReturnSerialUseCase) Execute(ctx context.Context, productID uuid.UUID, serialNumber string) (*entity.SerialUnit, error) {
+	item, err := uc.inventoryRepo.FindByProductID(ctx, productID)
+	if err != nil {
+		return nil, errors.ErrInventoryItemNotFound.WithDetails(err.Error())
+	}
+
+	return uc.serialRepo.Return(ctx, item.ID, strings.TrimSpace(serialNumber))
+}
+
+// RestockSerialOutput represents the restocked unit and the stored inventory item
+type RestockSerialOutput struct {
+	Unit *entity.SerialUnit
+	Item *entity.InventoryItem
+}
+
+// RestockSerialUseCase puts a returned unit back in stock
+type RestockSerialUseCase struct {
+	inventoryRepo repository.InventoryRepository
+	serialRepo    repository.SerialUnitRepository
+	notifier      WaitlistNotifier
+}
+
+// NewRestockSerialUseCase creates a new instance
+func NewRestockSerialUseCase(inventoryRepo repository.InventoryRepository, serialRepo repository.SerialUnitRepository) *RestockSerialUseCase {
+	if inventoryRepo == nil {
+		panic("inventoryRepo cannot be nil")
+	}
+	if serialRepo == nil {
+		panic("serialRepo cannot be nil")
+	}
+
+	return &RestockSerialUseCase{
+		inventoryRepo: inventoryRepo,
+		serialRepo:    serialRepo,
+	}
+}
+
+// WithWaitlist makes the use case serve the product's waitlist once a unit is back in stock
+func (uc *RestockSerialUseCase) WithWaitlist(notifier WaitlistNotifier) *RestockSerialUseCase {
+	uc.notifier = notifier
+	return uc
+}
+
+// Execute makes the product's returned unit available and adds it to the stock
+func (uc *RestockSerialUseCase) Execute(ctx context.Context, productID uuid.UUID, serialNumber string) (*RestockSerialOutput, error) {
+	item, err := uc.inventoryRepo.FindByProductID(ctx, productID)
+	if err != nil {
+		return nil, errors.ErrInventoryItemNotFound.WithDetails(err.Error())
+	}
+
+	stored, unit, err := uc.serialRepo.Restock(ctx, item.ID, strings.TrimSpace(serialNumber))
+	if err != nil {
+		return nil, err
+	}
+
+	notifyStockAvailable(uc.notifier, productID)
+	return &RestockSerialOutput{Unit: unit, Item: stored}, nil
+}
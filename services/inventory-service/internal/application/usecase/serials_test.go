@@ -0,0 +1,493 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	domainErrors "github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/events"
+)
+
+// MockSerialUnitRepository is a mock implementation of SerialUnitRepository
+type MockSerialUnitRepository struct {
+	mock.Mock
+}
+
+func (m *MockSerialUnitRepository) Register(ctx context.Context, inventoryItemID uuid.UUID, units []*entity.SerialUnit) (*entity.InventoryItem, error) {
+	args := m.Called(ctx, inventoryItemID, units)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.InventoryItem), args.Error(1)
+}
+
+func (m *MockSerialUnitRepository) Reserve(ctx context.Context, reservationID, productID uuid.UUID, quantity int) (*entity.InventoryItem, []*entity.SerialUnit, error) {
+	args := m.Called(ctx, reservationID, productID, quantity)
+	return m.stockResult(args)
+}
+
+func (m *MockSerialUnitRepository) Confirm(ctx context.Context, reservationID, inventoryItemID uuid.UUID, quantity int) (*entity.InventoryItem, []*entity.SerialUnit, error) {
+	args := m.Called(ctx, reservationID, inventoryItemID, quantity)
+	return m.stockResult(args)
+}
+
+func (m *MockSerialUnitRepository) Release(ctx context.Context, reservationID, inventoryItemID uuid.UUID, quantity int) (*entity.InventoryItem, []*entity.SerialUnit, error) {
+	args := m.Called(ctx, reservationID, inventoryItemID, quantity)
+	return m.stockResult(args)
+}
+
+func (m *MockSerialUnitRepository) Return(ctx context.Context, inventoryItemID uuid.UUID, serialNumber string) (*entity.SerialUnit, error) {
+	args := m.Called(ctx, inventoryItemID, serialNumber)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.SerialUnit), args.Error(1)
+}
+
+func (m *MockSerialUnitRepository) Restock(ctx context.Context, inventoryItemID uuid.UUID, serialNumber string) (*entity.InventoryItem, *entity.SerialUnit, error) {
+	args := m.Called(ctx, inventoryItemID, serialNumber)
+	item, _ := args.Get(0).(*entity.InventoryItem)
+	unit, _ := args.Get(1).(*entity.SerialUnit)
+	return item, unit, args.Error(2)
+}
+
+func (m *MockSerialUnitRepository) FindByInventoryItemID(ctx context.Context, inventoryItemID uuid.UUID, status entity.SerialUnitStatus) ([]*entity.SerialUnit, error) {
+	args := m.Called(ctx, inventoryItemID, status)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.SerialUnit), args.Error(1)
+}
+
+func (m *MockSerialUnitRepository) FindByReservationID(ctx context.Context, reservationID uuid.UUID) ([]*entity.SerialUnit, error) {
+	args := m.Called(ctx, reservationID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.SerialUnit), args.Error(1)
+}
+
+func (m *MockSerialUnitRepository) stockResult(args mock.Arguments) (*entity.InventoryItem, []*entity.SerialUnit, error) {
+	item, _ := args.Get(0).(*entity.InventoryItem)
+	units, _ := args.Get(1).([]*entity.SerialUnit)
+	return item, units, args.Error(2)
+}
+
+// serialTrackedItem returns a serial-tracked inventory item with quantity units,
+// reserved of them reserved
+func serialTrackedItem(t *testing.T, quantity, reserved int) *entity.InventoryItem {
+	t.Helper()
+	item, err := entity.NewInventoryItem(uuid.New(), 0)
+	require.NoError(t, err)
+	require.NoError(t, item.EnableSerialTracking())
+	item.Quantity = quantity
+	item.Reserved = reserved
+	return item
+}
+
+func serialUnitsOf(item *entity.InventoryItem, status entity.SerialUnitStatus, serialNumbers ...string) []*entity.SerialUnit {
+	units := make([]*entity.SerialUnit, len(serialNumbers))
+	for i, serialNumber := range serialNumbers {
+		units[i] = &entity.SerialUnit{ID: uuid.New(), InventoryItemID: item.ID, SerialNumber: serialNumber, Status: status}
+	}
+	return units
+}
+
+func TestNewSerialUseCases_NilDependencies_Panic(t *testing.T) {
+	assert.Panics(t, func() { NewSetSerialTrackingUseCase(nil) })
+	assert.Panics(t, func() { NewRegisterSerialsUseCase(nil, new(MockSerialUnitRepository)) })
+	assert.Panics(t, func() { NewRegisterSerialsUseCase(new(MockInventoryRepository), nil) })
+	assert.Panics(t, func() { NewListSerialsUseCase(nil, new(MockSerialUnitRepository)) })
+	assert.Panics(t, func() { NewListSerialsUseCase(new(MockInventoryRepository), nil) })
+	assert.Panics(t, func() { NewGetReservationSerialsUseCase(nil, new(MockSerialUnitRepository)) })
+	assert.Panics(t, func() { NewGetReservationSerialsUseCase(new(MockReservationRepository), nil) })
+	assert.Panics(t, func() { NewReturnSerialUseCase(nil, new(MockSerialUnitRepository)) })
+	assert.Panics(t, func() { NewReturnSerialUseCase(new(MockInventoryRepository), nil) })
+	assert.Panics(t, func() { NewRestockSerialUseCase(nil, new(MockSerialUnitRepository)) })
+	assert.Panics(t, func() { NewRestockSerialUseCase(new(MockInventoryRepository), nil) })
+}
+
+func TestSetSerialTrackingUseCase_Execute(t *testing.T) {
+	t.Run("should enable tracking of an item without stock", func(t *testing.T) {
+		inventoryRepo := new(MockInventoryRepository)
+		item, _ := entity.NewInventoryItem(uuid.New(), 0)
+		inventoryRepo.On("FindByProductID", mock.Anything, item.ProductID).Return(item, nil)
+		inventoryRepo.On("Update", mock.Anything, item).Return(nil)
+
+		stored, err := NewSetSerialTrackingUseCase(inventoryRepo).Execute(context.Background(), item.ProductID, true)
+
+		require.NoError(t, err)
+		assert.True(t, stored.SerialTracked)
+		inventoryRepo.AssertExpectations(t)
+	})
+
+	t.Run("should not write an unchanged item", func(t *testing.T) {
+		inventoryRepo := new(MockInventoryRepository)
+		item := serialTrackedItem(t, 3, 0)
+		inventoryRepo.On("FindByProductID", mock.Anything, item.ProductID).Return(item, nil)
+
+		stored, err := NewSetSerialTrackingUseCase(inventoryRepo).Execute(context.Background(), item.ProductID, true)
+
+		require.NoError(t, err)
+		assert.Same(t, item, stored)
+		inventoryRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should reject changes of items with stock", func(t *testing.T) {
+		inventoryRepo := new(MockInventoryRepository)
+		item := serialTrackedItem(t, 3, 0)
+		inventoryRepo.On("FindByProductID", mock.Anything, item.ProductID).Return(item, nil)
+
+		_, err := NewSetSerialTrackingUseCase(inventoryRepo).Execute(context.Background(), item.ProductID, false)
+
+		assert.ErrorIs(t, err, domainErrors.ErrSerialTrackingChange)
+		inventoryRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return not found for unknown products", func(t *testing.T) {
+		inventoryRepo := new(MockInventoryRepository)
+		inventoryRepo.On("FindByProductID", mock.Anything, mock.Anything).Return(nil, errors.New("record not found"))
+
+		_, err := NewSetSerialTrackingUseCase(inventoryRepo).Execute(context.Background(), uuid.New(), true)
+
+		assert.ErrorIs(t, err, domainErrors.ErrInventoryItemNotFound)
+	})
+}
+
+func TestRegisterSerialsUseCase_Execute(t *testing.T) {
+	item := serialTrackedItem(t, 0, 0)
+
+	t.Run("should register trimmed serial numbers", func(t *testing.T) {
+		inventoryRepo := new(MockInventoryRepository)
+		serialRepo := new(MockSerialUnitRepository)
+		stored := serialTrackedItem(t, 2, 0)
+		inventoryRepo.On("FindByProductID", mock.Anything, item.ProductID).Return(item, nil)
+		serialRepo.On("Register", mock.Anything, item.ID, mock.MatchedBy(func(units []*entity.SerialUnit) bool {
+			return assert.ObjectsAreEqual([]string{"SN-1", "SN-2"}, entity.SerialNumbers(units)) &&
+				units[0].InventoryItemID == item.ID && units[0].Status == entity.SerialAvailable
+		})).Return(stored, nil)
+
+		output, err := NewRegisterSerialsUseCase(inventoryRepo, serialRepo).Execute(context.Background(), RegisterSerialsInput{
+			ProductID:     item.ProductID,
+			SerialNumbers: []string{" SN-1", "SN-2 "},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"SN-1", "SN-2"}, entity.SerialNumbers(output.Units))
+		assert.Same(t, stored, output.Item)
+	})
+
+	t.Run("should reject invalid input before touching the store", func(t *testing.T) {
+		tests := []struct {
+			name          string
+			serialNumbers []string
+		}{
+			{"no serial numbers", nil},
+			{"blank serial number", []string{"SN-1", " "}},
+			{"duplicate serial number", []string{"SN-1", " SN-1"}},
+			{"too many serial numbers", make([]string, MaxSerialsPerRegistration+1)},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				inventoryRepo := new(MockInventoryRepository)
+				serialRepo := new(MockSerialUnitRepository)
+				inventoryRepo.On("FindByProductID", mock.Anything, item.ProductID).Return(item, nil)
+
+				_, err := NewRegisterSerialsUseCase(inventoryRepo, serialRepo).Execute(context.Background(), RegisterSerialsInput{
+					ProductID:     item.ProductID,
+					SerialNumbers: tt.serialNumbers,
+				})
+
+				assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+				serialRepo.AssertNotCalled(t, "Register", mock.Anything, mock.Anything, mock.Anything)
+			})
+		}
+	})
+
+	t.Run("should reject items that are not serial-tracked", func(t *testing.T) {
+		inventoryRepo := new(MockInventoryRepository)
+		untracked, _ := entity.NewInventoryItem(uuid.New(), 0)
+		inventoryRepo.On("FindByProductID", mock.Anything, untracked.ProductID).Return(untracked, nil)
+
+		_, err := NewRegisterSerialsUseCase(inventoryRepo, new(MockSerialUnitRepository)).Execute(context.Background(), RegisterSerialsInput{
+			ProductID:     untracked.ProductID,
+			SerialNumbers: []string{"SN-1"},
+		})
+
+		assert.ErrorIs(t, err, domainErrors.ErrNotSerialTracked)
+	})
+}
+
+func TestListSerialsUseCase_Execute(t *testing.T) {
+	inventoryRepo := new(MockInventoryRepository)
+	serialRepo := new(MockSerialUnitRepository)
+	item := serialTrackedItem(t, 1, 0)
+	units := serialUnitsOf(item, entity.SerialAvailable, "SN-1")
+	inventoryRepo.On("FindByProductID", mock.Anything, item.ProductID).Return(item, nil)
+	serialRepo.On("FindByInventoryItemID", mock.Anything, item.ID, entity.SerialAvailable).Return(units, nil)
+
+	listed, err := NewListSerialsUseCase(inventoryRepo, serialRepo).Execute(context.Background(), item.ProductID, entity.SerialAvailable)
+
+	require.NoError(t, err)
+	assert.Equal(t, units, listed)
+
+	inventoryRepo = new(MockInventoryRepository)
+	inventoryRepo.On("FindByProductID", mock.Anything, mock.Anything).Return(nil, errors.New("record not found"))
+	_, err = NewListSerialsUseCase(inventoryRepo, serialRepo).Execute(context.Background(), uuid.New(), "")
+	assert.ErrorIs(t, err, domainErrors.ErrInventoryItemNotFound)
+}
+
+func TestGetReservationSerialsUseCase_Execute(t *testing.T) {
+	reservationID := uuid.New()
+
+	t.Run("should return the units of the reservation", func(t *testing.T) {
+		reservationRepo := new(MockReservationRepository)
+		serialRepo := new(MockSerialUnitRepository)
+		units := []*entity.SerialUnit{{SerialNumber: "SN-1", Status: entity.SerialSold, ReservationID: &reservationID}}
+		serialRepo.On("FindByReservationID", mock.Anything, reservationID).Return(units, nil)
+
+		found, err := NewGetReservationSerialsUseCase(reservationRepo, serialRepo).Execute(context.Background(), reservationID)
+
+		require.NoError(t, err)
+		assert.Equal(t, units, found)
+		reservationRepo.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return an empty list for reservations without units", func(t *testing.T) {
+		reservationRepo := new(MockReservationRepository)
+		serialRepo := new(MockSerialUnitRepository)
+		reservation, _ := entity.NewReservation(uuid.New(), uuid.New(), 1)
+		serialRepo.On("FindByReservationID", mock.Anything, reservationID).Return([]*entity.SerialUnit{}, nil)
+		reservationRepo.On("FindByID", mock.Anything, reservationID).Return(reservation, nil)
+
+		found, err := NewGetReservationSerialsUseCase(reservationRepo, serialRepo).Execute(context.Background(), reservationID)
+
+		require.NoError(t, err)
+		assert.Empty(t, found)
+	})
+
+	t.Run("should return not found for unknown reservations", func(t *testing.T) {
+		reservationRepo := new(MockReservationRepository)
+		serialRepo := new(MockSerialUnitRepository)
+		serialRepo.On("FindByReservationID", mock.Anything, reservationID).Return([]*entity.SerialUnit{}, nil)
+		reservationRepo.On("FindByID", mock.Anything, reservationID).Return(nil, errors.New("record not found"))
+
+		_, err := NewGetReservationSerialsUseCase(reservationRepo, serialRepo).Execute(context.Background(), reservationID)
+
+		assert.ErrorIs(t, err, domainErrors.ErrReservationNotFound)
+	})
+}
+
+func TestReturnAndRestockSerialUseCases_Execute(t *testing.T) {
+	item := serialTrackedItem(t, 0, 0)
+	stored := serialTrackedItem(t, 1, 0)
+	returned := serialUnitsOf(item, entity.SerialReturned, "SN-1")[0]
+	available := serialUnitsOf(item, entity.SerialAvailable, "SN-1")[0]
+
+	inventoryRepo := new(MockInventoryRepository)
+	serialRepo := new(MockSerialUnitRepository)
+	inventoryRepo.On("FindByProductID", mock.Anything, item.ProductID).Return(item, nil)
+	serialRepo.On("Return", mock.Anything, item.ID, "SN-1").Return(returned, nil)
+	serialRepo.On("Restock", mock.Anything, item.ID, "SN-1").Return(stored, available, nil)
+	serialRepo.On("Return", mock.Anything, item.ID, "SN-404").Return(nil, domainErrors.ErrSerialUnitNotFound)
+
+	unit, err := NewReturnSerialUseCase(inventoryRepo, serialRepo).Execute(context.Background(), item.ProductID, " SN-1")
+	require.NoError(t, err)
+	assert.Same(t, returned, unit)
+
+	output, err := NewRestockSerialUseCase(inventoryRepo, serialRepo).Execute(context.Background(), item.ProductID, "SN-1")
+	require.NoError(t, err)
+	assert.Same(t, available, output.Unit)
+	assert.Same(t, stored, output.Item)
+
+	_, err = NewReturnSerialUseCase(inventoryRepo, serialRepo).Execute(context.Background(), item.ProductID, "SN-404")
+	assert.ErrorIs(t, err, domainErrors.ErrSerialUnitNotFound)
+}
+
+func TestReserveStockUseCase_Execute_WithSerials(t *testing.T) {
+	item := serialTrackedItem(t, 3, 0)
+
+	t.Run("should reserve concrete units of serial-tracked products", func(t *testing.T) {
+		inventoryRepo := new(MockInventoryRepository)
+		reservationRepo := new(MockReservationRepository)
+		publisher := new(MockPublisher)
+		serialRepo := new(MockSerialUnitRepository)
+		stored := serialTrackedItem(t, 3, 2)
+		stored.ProductID = item.ProductID
+		units := serialUnitsOf(item, entity.SerialReserved, "SN-1", "SN-2")
+
+		reservationRepo.On("ExistsByOrderID", mock.Anything, mock.Anything).Return(false, nil)
+		inventoryRepo.On("FindByProductID", mock.Anything, item.ProductID).Return(item, nil)
+		serialRepo.On("Reserve", mock.Anything, mock.Anything, item.ProductID, 2).Return(stored, units, nil)
+		reservationRepo.On("Save", mock.Anything, mock.MatchedBy(func(r *entity.Reservation) bool {
+			return r.InventoryItemID == stored.ID
+		})).Return(nil)
+		publisher.On("PublishStockReserved", mock.Anything, mock.MatchedBy(func(event events.StockReservedEvent) bool {
+			return event.Version == events.StockReservedVersion &&
+				assert.ObjectsAreEqual([]string{"SN-1", "SN-2"}, event.Payload.Serials)
+		})).Return(nil)
+
+		uc := NewReserveStockUseCase(inventoryRepo, reservationRepo, publisher).WithSerials(serialRepo)
+		output, err := uc.Execute(context.Background(), ReserveStockInput{ProductID: item.ProductID, OrderID: uuid.New(), Quantity: 2})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"SN-1", "SN-2"}, output.Serials)
+		assert.Equal(t, 1, output.RemainingStock)
+		serialRepo.AssertCalled(t, "Reserve", mock.Anything, output.ReservationID, item.ProductID, 2)
+		inventoryRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		publisher.AssertExpectations(t)
+	})
+
+	t.Run("should fail without serials", func(t *testing.T) {
+		inventoryRepo := new(MockInventoryRepository)
+		reservationRepo := new(MockReservationRepository)
+		reservationRepo.On("ExistsByOrderID", mock.Anything, mock.Anything).Return(false, nil)
+		inventoryRepo.On("FindByProductID", mock.Anything, item.ProductID).Return(item, nil)
+
+		_, err := NewReserveStockUseCase(inventoryRepo, reservationRepo, new(MockPublisher)).
+			Execute(context.Background(), ReserveStockInput{ProductID: item.ProductID, OrderID: uuid.New(), Quantity: 2})
+
+		assert.ErrorIs(t, err, domainErrors.ErrSerialTrackedItem)
+		reservationRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should not save the reservation when the units run out", func(t *testing.T) {
+		inventoryRepo := new(MockInventoryRepository)
+		reservationRepo := new(MockReservationRepository)
+		serialRepo := new(MockSerialUnitRepository)
+		reservationRepo.On("ExistsByOrderID", mock.Anything, mock.Anything).Return(false, nil)
+		inventoryRepo.On("FindByProductID", mock.Anything, item.ProductID).Return(item, nil)
+		serialRepo.On("Reserve", mock.Anything, mock.Anything, item.ProductID, 5).Return(nil, nil, domainErrors.ErrInsufficientStock)
+
+		_, err := NewReserveStockUseCase(inventoryRepo, reservationRepo, new(MockPublisher)).WithSerials(serialRepo).
+			Execute(context.Background(), ReserveStockInput{ProductID: item.ProductID, OrderID: uuid.New(), Quantity: 5})
+
+		assert.ErrorIs(t, err, domainErrors.ErrInsufficientStock)
+		reservationRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+}
+
+func TestConfirmReservationUseCase_Execute_WithSerials(t *testing.T) {
+	inventoryRepo := new(MockInventoryRepository)
+	reservationRepo := new(MockReservationRepository)
+	publisher := new(MockPublisher)
+	serialRepo := new(MockSerialUnitRepository)
+	item := serialTrackedItem(t, 3, 2)
+	stored := serialTrackedItem(t, 1, 0)
+	reservation, _ := entity.NewReservation(item.ID, uuid.New(), 2)
+	units := serialUnitsOf(item, entity.SerialSold, "SN-1", "SN-2")
+
+	reservationRepo.On("FindByID", mock.Anything, reservation.ID).Return(reservation, nil)
+	inventoryRepo.On("FindByID", mock.Anything, item.ID).Return(item, nil)
+	serialRepo.On("Confirm", mock.Anything, reservation.ID, item.ID, 2).Return(stored, units, nil)
+	reservationRepo.On("Update", mock.Anything, reservation).Return(nil)
+	publisher.On("PublishStockConfirmed", mock.Anything, mock.MatchedBy(func(event events.StockConfirmedEvent) bool {
+		return assert.ObjectsAreEqual([]string{"SN-1", "SN-2"}, event.Payload.Serials)
+	})).Return(nil)
+
+	uc := NewConfirmReservationUseCase(inventoryRepo, reservationRepo, publisher).WithSerials(serialRepo)
+	output, err := uc.Execute(context.Background(), ConfirmReservationInput{ReservationID: reservation.ID})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"SN-1", "SN-2"}, output.Serials)
+	assert.Equal(t, 1, output.FinalStock)
+	assert.Equal(t, entity.ReservationConfirmed, output.Reservation.Status)
+	inventoryRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	publisher.AssertExpectations(t)
+}
+
+func TestReleaseReservationUseCases_WithSerials(t *testing.T) {
+	t.Run("should release the units of a released reservation", func(t *testing.T) {
+		inventoryRepo := new(MockInventoryRepository)
+		reservationRepo := new(MockReservationRepository)
+		publisher := new(MockPublisher)
+		serialRepo := new(MockSerialUnitRepository)
+		item := serialTrackedItem(t, 3, 2)
+		stored := serialTrackedItem(t, 3, 0)
+		reservation, _ := entity.NewReservation(item.ID, uuid.New(), 2)
+		units := serialUnitsOf(item, entity.SerialAvailable, "SN-1", "SN-2")
+
+		reservationRepo.On("FindByID", mock.Anything, reservation.ID).Return(reservation, nil)
+		inventoryRepo.On("FindByID", mock.Anything, item.ID).Return(item, nil)
+		serialRepo.On("Release", mock.Anything, reservation.ID, item.ID, 2).Return(stored, units, nil)
+		reservationRepo.On("Update", mock.Anything, reservation).Return(nil)
+		publisher.On("PublishStockReleased", mock.Anything, mock.MatchedBy(func(event events.StockReleasedEvent) bool {
+			return assert.ObjectsAreEqual([]string{"SN-1", "SN-2"}, event.Payload.Serials)
+		})).Return(nil)
+
+		uc := NewReleaseReservationUseCase(inventoryRepo, reservationRepo, publisher).WithSerials(serialRepo)
+		output, err := uc.Execute(context.Background(), ReleaseReservationInput{ReservationID: reservation.ID})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"SN-1", "SN-2"}, output.Serials)
+		assert.Equal(t, 3, output.AvailableStock)
+		publisher.AssertExpectations(t)
+	})
+
+	t.Run("should release the units of an expired reservation", func(t *testing.T) {
+		inventoryRepo := new(MockInventoryRepository)
+		reservationRepo := new(MockReservationRepository)
+		publisher := new(MockPublisher)
+		serialRepo := new(MockSerialUnitRepository)
+		item := serialTrackedItem(t, 3, 2)
+		reservation, _ := entity.NewReservation(item.ID, uuid.New(), 2)
+		reservation.ExpiresAt = time.Now().Add(-time.Minute)
+		failing, _ := entity.NewReservation(item.ID, uuid.New(), 1)
+		failing.ExpiresAt = time.Now().Add(-time.Minute)
+
+		reservationRepo.On("FindExpired", mock.Anything, mock.Anything).Return([]*entity.Reservation{reservation, failing}, nil)
+		inventoryRepo.On("FindByID", mock.Anything, item.ID).Return(item, nil)
+		serialRepo.On("Release", mock.Anything, reservation.ID, item.ID, 2).
+			Return(serialTrackedItem(t, 3, 0), serialUnitsOf(item, entity.SerialAvailable, "SN-1", "SN-2"), nil)
+		serialRepo.On("Release", mock.Anything, failing.ID, item.ID, 1).Return(nil, nil, domainErrors.ErrInvalidReservationRelease)
+		reservationRepo.On("Update", mock.Anything, reservation).Return(nil)
+		publisher.On("PublishStockReleased", mock.Anything, mock.MatchedBy(func(event events.StockReleasedEvent) bool {
+			return event.Payload.Reason == "reservation_expired" &&
+				assert.ObjectsAreEqual([]string{"SN-1", "SN-2"}, event.Payload.Serials)
+		})).Return(nil).Once()
+
+		uc := NewReleaseExpiredReservationsUseCase(inventoryRepo, reservationRepo, publisher).WithSerials(serialRepo)
+		output, err := uc.Execute(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{reservation.ID}, output.ReleasedReservationIDs)
+		require.Len(t, output.FailedReservations, 1)
+		assert.Contains(t, output.FailedReservations[0].Reason, "failed to release serial units")
+		publisher.AssertExpectations(t)
+	})
+}
+
+func TestGetOrderReservationUseCase_Execute_WithSerials(t *testing.T) {
+	inventoryRepo := new(MockInventoryRepository)
+	reservationRepo := new(MockReservationRepository)
+	serialRepo := new(MockSerialUnitRepository)
+	item := serialTrackedItem(t, 3, 2)
+	untracked, _ := entity.NewInventoryItem(uuid.New(), 10)
+	reservation, _ := entity.NewReservation(item.ID, uuid.New(), 2)
+	other, _ := entity.NewReservation(untracked.ID, uuid.New(), 1)
+
+	reservationRepo.On("FindByOrderID", mock.Anything, reservation.OrderID).Return(reservation, nil)
+	reservationRepo.On("FindByOrderID", mock.Anything, other.OrderID).Return(other, nil)
+	inventoryRepo.On("FindByID", mock.Anything, item.ID).Return(item, nil)
+	inventoryRepo.On("FindByID", mock.Anything, untracked.ID).Return(untracked, nil)
+	serialRepo.On("FindByReservationID", mock.Anything, reservation.ID).
+		Return(serialUnitsOf(item, entity.SerialReserved, "SN-1", "SN-2"), nil)
+
+	uc := NewGetOrderReservationUseCase(reservationRepo, inventoryRepo).WithSerials(serialRepo)
+	output, err := uc.Execute(context.Background(), reservation.OrderID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"SN-1", "SN-2"}, output.Serials)
+
+	output, err = uc.Execute(context.Background(), other.OrderID)
+	require.NoError(t, err)
+	assert.Nil(t, output.Serials)
+	serialRepo.AssertNotCalled(t, "FindByReservationID", mock.Anything, other.ID)
+}
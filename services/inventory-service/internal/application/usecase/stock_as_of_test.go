@@ -0,0 +1,152 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	domainErrors "github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockStockHistoryRepository is a mock implementation of StockHistoryRepository.
+// StreamAsOf feeds the positions returned for it to the callback.
+type MockStockHistoryRepository struct {
+	mock.Mock
+}
+
+func (m *MockStockHistoryRepository) TakeSnapshot(ctx context.Context, at time.Time) (int, bool, error) {
+	args := m.Called(ctx, at)
+	return args.Int(0), args.Bool(1), args.Error(2)
+}
+
+func (m *MockStockHistoryRepository) PositionAsOf(ctx context.Context, productID uuid.UUID, at time.Time) (*entity.StockPosition, error) {
+	args := m.Called(ctx, productID, at)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.StockPosition), args.Error(1)
+}
+
+func (m *MockStockHistoryRepository) StreamAsOf(ctx context.Context, at time.Time, fn func(position *entity.StockPosition) error) error {
+	args := m.Called(ctx, at)
+	if positions, ok := args.Get(0).([]*entity.StockPosition); ok {
+		for _, position := range positions {
+			if err := fn(position); err != nil {
+				return err
+			}
+		}
+	}
+	return args.Error(1)
+}
+
+func (m *MockStockHistoryRepository) Prune(ctx context.Context, before time.Time) (int, int64, error) {
+	args := m.Called(ctx, before)
+	return args.Int(0), args.Get(1).(int64), args.Error(2)
+}
+
+var asOfNow = time.Date(2025, 12, 1, 9, 0, 0, 0, time.UTC)
+
+func TestNewStockAsOfUseCases_NilRepo_Panics(t *testing.T) {
+	assert.Panics(t, func() { NewGetStockAsOfUseCase(nil) })
+	assert.Panics(t, func() { NewExportStockAsOfUseCase(nil) })
+}
+
+func TestGetStockAsOfUseCase_Execute(t *testing.T) {
+	repo := new(MockStockHistoryRepository)
+	productID := uuid.New()
+	at := asOfNow.AddDate(0, -1, 0)
+	position := &entity.StockPosition{ProductID: productID, Quantity: 12, Reserved: 2}
+	repo.On("PositionAsOf", mock.Anything, productID, at).Return(position, nil)
+
+	uc := NewGetStockAsOfUseCase(repo)
+	uc.now = func() time.Time { return asOfNow }
+	result, err := uc.Execute(context.Background(), productID, at)
+
+	require.NoError(t, err)
+	assert.Same(t, position, result)
+}
+
+func TestGetStockAsOfUseCase_Execute_InvalidTime(t *testing.T) {
+	for name, at := range map[string]time.Time{
+		"missing": {},
+		"future":  asOfNow.Add(time.Minute),
+	} {
+		t.Run(name, func(t *testing.T) {
+			repo := new(MockStockHistoryRepository)
+			uc := NewGetStockAsOfUseCase(repo)
+			uc.now = func() time.Time { return asOfNow }
+
+			_, err := uc.Execute(context.Background(), uuid.New(), at)
+
+			assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+			repo.AssertNotCalled(t, "PositionAsOf", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestGetStockAsOfUseCase_Execute_HistoryUnavailable(t *testing.T) {
+	repo := new(MockStockHistoryRepository)
+	repo.On("PositionAsOf", mock.Anything, mock.Anything, mock.Anything).Return(nil, domainErrors.ErrStockHistoryUnavailable)
+
+	uc := NewGetStockAsOfUseCase(repo)
+	_, err := uc.Execute(context.Background(), uuid.New(), time.Now().AddDate(-2, 0, 0))
+
+	assert.ErrorIs(t, err, domainErrors.ErrStockHistoryUnavailable)
+}
+
+func TestExportStockAsOfUseCase_Execute(t *testing.T) {
+	repo := new(MockStockHistoryRepository)
+	at := asOfNow.AddDate(0, 0, -1)
+	position := &entity.StockPosition{
+		InventoryItemID: uuid.New(),
+		ProductID:       uuid.New(),
+		Quantity:        10,
+		Reserved:        4,
+		ChangedAt:       at.Add(-time.Hour),
+	}
+	repo.On("StreamAsOf", mock.Anything, at).Return([]*entity.StockPosition{position}, nil)
+	writer := &recordingStockWriter{}
+
+	uc := NewExportStockAsOfUseCase(repo)
+	uc.now = func() time.Time { return asOfNow }
+	count, err := uc.Execute(context.Background(), at, writer)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, []StockLevel{{
+		ProductID:       position.ProductID,
+		InventoryItemID: position.InventoryItemID,
+		Quantity:        10,
+		Reserved:        4,
+		Available:       6,
+		UpdatedAt:       position.ChangedAt,
+	}}, writer.levels)
+}
+
+func TestExportStockAsOfUseCase_Execute_Errors(t *testing.T) {
+	at := asOfNow.AddDate(0, 0, -1)
+
+	repo := new(MockStockHistoryRepository)
+	repo.On("StreamAsOf", mock.Anything, at).Return(nil, domainErrors.ErrStockHistoryUnavailable)
+	uc := NewExportStockAsOfUseCase(repo)
+	uc.now = func() time.Time { return asOfNow }
+	_, err := uc.Execute(context.Background(), at, &recordingStockWriter{})
+	assert.ErrorIs(t, err, domainErrors.ErrStockHistoryUnavailable)
+
+	repo = new(MockStockHistoryRepository)
+	repo.On("StreamAsOf", mock.Anything, at).Return([]*entity.StockPosition{{}, {}}, nil)
+	uc = NewExportStockAsOfUseCase(repo)
+	uc.now = func() time.Time { return asOfNow }
+	count, err := uc.Execute(context.Background(), at, &recordingStockWriter{err: errors.New("broken pipe")})
+	assert.ErrorContains(t, err, "broken pipe")
+	assert.Equal(t, 1, count)
+
+	_, err = uc.Execute(context.Background(), asOfNow.Add(time.Hour), &recordingStockWriter{})
+	assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+}
@@ -0,0 +1,222 @@
+package usecase
+
+import (
+	"context"
+	goerrors "errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+// OperationCatalogSync is the operation name reported to the ContentionObserver
+const OperationCatalogSync = "catalog_sync"
+
+// CatalogSyncAction describes what a catalog change did to the inventory
+type CatalogSyncAction string
+
+// Catalog sync actions
+const (
+	CatalogSyncCreated   CatalogSyncAction = "created"   // a new inventory item was created
+	CatalogSyncRestored  CatalogSyncAction = "restored"  // an archived item was made reservable again
+	CatalogSyncArchived  CatalogSyncAction = "archived"  // the item was archived
+	CatalogSyncUnchanged CatalogSyncAction = "unchanged" // the inventory already matched the catalog
+	CatalogSyncStale     CatalogSyncAction = "stale"     // the change was overtaken by a later one and dropped
+)
+
+// InitialStockRule assigns an initial quantity to products whose SKU starts with SKUPrefix
+type InitialStockRule struct {
+	SKUPrefix string
+	Quantity  int
+}
+
+// InitialStockPolicy decides the stock of inventory items created from catalog events
+type InitialStockPolicy struct {
+	DefaultQuantity int
+	Rules           []InitialStockRule
+}
+
+// QuantityFor returns the initial quantity for a SKU. The rule with the longest
+// matching prefix wins; DefaultQuantity applies when no rule matches.
+func (p InitialStockPolicy) QuantityFor(sku string) int {
+	quantity, longest := p.DefaultQuantity, -1
+	for _, rule := range p.Rules {
+		if strings.HasPrefix(sku, rule.SKUPrefix) && len(rule.SKUPrefix) > longest {
+			quantity, longest = rule.Quantity, len(rule.SKUPrefix)
+		}
+	}
+	return quantity
+}
+
+// Validate checks that no quantity is negative
+func (p InitialStockPolicy) Validate() error {
+	if p.DefaultQuantity < 0 {
+		return fmt.Errorf("default initial stock must be >= 0, got %d", p.DefaultQuantity)
+	}
+	for _, rule := range p.Rules {
+		if rule.Quantity < 0 {
+			return fmt.Errorf("initial stock for SKU prefix %q must be >= 0, got %d", rule.SKUPrefix, rule.Quantity)
+		}
+	}
+	return nil
+}
+
+// SyncProductInput is the state of a product in the catalog
+type SyncProductInput struct {
+	ProductID uuid.UUID
+	SKU       string
+	Active    bool
+	ChangedAt time.Time // when the catalog changed; defaults to now
+}
+
+// SyncProductOutput represents the result of applying a catalog change
+type SyncProductOutput struct {
+	ProductID       uuid.UUID
+	InventoryItemID uuid.UUID // uuid.Nil when the product has no inventory item
+	Action          CatalogSyncAction
+	Quantity        int
+}
+
+// SyncCatalogUseCase keeps inventory items in line with the product catalog:
+// active products get an item (created with stock from the InitialStockPolicy or
+// restored if archived) and deactivated products get their item archived.
+// Every operation is idempotent, so redelivered events are harmless, and changes
+// older than the latest one applied to the item are dropped, so events delivered
+// out of order cannot undo a later change.
+type SyncCatalogUseCase struct {
+	inventoryRepo repository.InventoryRepository
+	policy        InitialStockPolicy
+	retry         RetryPolicy
+}
+
+// NewSyncCatalogUseCase creates a new instance of SyncCatalogUseCase
+func NewSyncCatalogUseCase(inventoryRepo repository.InventoryRepository, policy InitialStockPolicy) *SyncCatalogUseCase {
+	return &SyncCatalogUseCase{
+		inventoryRepo: inventoryRepo,
+		policy:        policy,
+		retry:         DefaultRetryPolicy(),
+	}
+}
+
+// WithRetryPolicy replaces the policy used to retry optimistic-lock conflicts
+func (uc *SyncCatalogUseCase) WithRetryPolicy(policy RetryPolicy) *SyncCatalogUseCase {
+	uc.retry = policy
+	return uc
+}
+
+// Execute applies the catalog state of a product to its inventory item
+func (uc *SyncCatalogUseCase) Execute(ctx context.Context, input SyncProductInput) (*SyncProductOutput, error) {
+	if input.ProductID == uuid.Nil {
+		return nil, errors.ErrInvalidInput.WithDetails("product ID is required")
+	}
+	if input.ChangedAt.IsZero() {
+		input.ChangedAt = time.Now()
+	}
+
+	var output *SyncProductOutput
+	err := uc.retry.run(ctx, OperationCatalogSync, func() (uuid.UUID, error) {
+		var err error
+		if input.Active {
+			output, err = uc.activate(ctx, input)
+		} else {
+			output, err = uc.archive(ctx, input)
+		}
+		return input.ProductID, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+// Deactivate archives the inventory item of a product removed from the catalog
+func (uc *SyncCatalogUseCase) Deactivate(ctx context.Context, productID uuid.UUID, at time.Time) (*SyncProductOutput, error) {
+	return uc.Execute(ctx, SyncProductInput{ProductID: productID, Active: false, ChangedAt: at})
+}
+
+// activate makes sure an active product has a reservable inventory item
+func (uc *SyncCatalogUseCase) activate(ctx context.Context, input SyncProductInput) (*SyncProductOutput, error) {
+	item, err := uc.inventoryRepo.FindByProductID(ctx, input.ProductID)
+	if err != nil && !isItemNotFound(err) {
+		return nil, err
+	}
+
+	if item == nil {
+		item, err = entity.NewInventoryItem(input.ProductID, uc.policy.QuantityFor(input.SKU))
+		if err != nil {
+			return nil, err
+		}
+		item.ApplyCatalogChange(input.ChangedAt)
+		if err := uc.inventoryRepo.Save(ctx, item); err != nil {
+			if goerrors.Is(err, errors.ErrInventoryItemAlreadyExists) {
+				// Another delivery created it first: re-read and restore if needed
+				return nil, errors.ErrOptimisticLockFailure
+			}
+			return nil, err
+		}
+		return outputFor(item, CatalogSyncCreated), nil
+	}
+
+	if item.IsStaleCatalogChange(input.ChangedAt) {
+		return outputFor(item, CatalogSyncStale), nil
+	}
+
+	action := CatalogSyncRestored
+	if !item.IsArchived() {
+		// Record the activation anyway, so that an older deactivation still in
+		// flight is dropped; redeliveries change nothing
+		if item.CatalogChangedAt != nil && !input.ChangedAt.After(*item.CatalogChangedAt) {
+			return outputFor(item, CatalogSyncUnchanged), nil
+		}
+		action = CatalogSyncUnchanged
+	}
+
+	item.Restore()
+	item.ApplyCatalogChange(input.ChangedAt)
+	if err := uc.inventoryRepo.Update(ctx, item); err != nil {
+		return nil, err
+	}
+	return outputFor(item, action), nil
+}
+
+// archive archives the inventory item of an inactive product, if it has one
+func (uc *SyncCatalogUseCase) archive(ctx context.Context, input SyncProductInput) (*SyncProductOutput, error) {
+	item, err := uc.inventoryRepo.FindByProductID(ctx, input.ProductID)
+	if err != nil {
+		if isItemNotFound(err) {
+			return &SyncProductOutput{ProductID: input.ProductID, Action: CatalogSyncUnchanged}, nil
+		}
+		return nil, err
+	}
+
+	if item.IsStaleCatalogChange(input.ChangedAt) {
+		return outputFor(item, CatalogSyncStale), nil
+	}
+	if item.IsArchived() {
+		return outputFor(item, CatalogSyncUnchanged), nil
+	}
+
+	item.Archive(input.ChangedAt)
+	item.ApplyCatalogChange(input.ChangedAt)
+	if err := uc.inventoryRepo.Update(ctx, item); err != nil {
+		return nil, err
+	}
+	return outputFor(item, CatalogSyncArchived), nil
+}
+
+func isItemNotFound(err error) bool {
+	return goerrors.Is(err, errors.ErrInventoryItemNotFound) || goerrors.Is(err, errors.ErrNotFound)
+}
+
+func outputFor(item *entity.InventoryItem, action CatalogSyncAction) *SyncProductOutput {
+	return &SyncProductOutput{
+		ProductID:       item.ProductID,
+		InventoryItemID: item.ID,
+		Action:          action,
+		Quantity:        item.Quantity,
+	}
+}
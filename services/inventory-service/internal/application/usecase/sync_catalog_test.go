@@ -0,0 +1,245 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	domainErrors "github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInitialStockPolicy_QuantityFor(t *testing.T) {
+	policy := InitialStockPolicy{
+		DefaultQuantity: 100,
+		Rules: []InitialStockRule{
+			{SKUPrefix: "PRE-", Quantity: 0},
+			{SKUPrefix: "ELEC-", Quantity: 20},
+			{SKUPrefix: "ELEC-TV-", Quantity: 5},
+		},
+	}
+
+	assert.Equal(t, 100, policy.QuantityFor("BOOK-001"))
+	assert.Equal(t, 0, policy.QuantityFor("PRE-123"))
+	assert.Equal(t, 20, policy.QuantityFor("ELEC-PHONE-1"))
+	assert.Equal(t, 5, policy.QuantityFor("ELEC-TV-55"), "the longest prefix wins")
+	assert.Equal(t, 100, policy.QuantityFor(""))
+}
+
+func TestInitialStockPolicy_Validate(t *testing.T) {
+	assert.NoError(t, InitialStockPolicy{DefaultQuantity: 0}.Validate())
+	assert.Error(t, InitialStockPolicy{DefaultQuantity: -1}.Validate())
+	assert.Error(t, InitialStockPolicy{Rules: []InitialStockRule{{SKUPrefix: "X", Quantity: -5}}}.Validate())
+}
+
+func TestSyncCatalogUseCase_Execute(t *testing.T) {
+	ctx := context.Background()
+	policy := InitialStockPolicy{DefaultQuantity: 50, Rules: []InitialStockRule{{SKUPrefix: "PRE-", Quantity: 0}}}
+
+	t.Run("creates item for new active product with policy quantity", func(t *testing.T) {
+		repo := new(MockInventoryRepository)
+		productID := uuid.New()
+		repo.On("FindByProductID", mock.Anything, productID).Return(nil, domainErrors.ErrInventoryItemNotFound)
+		repo.On("Save", mock.Anything, mock.MatchedBy(func(item *entity.InventoryItem) bool {
+			return item.ProductID == productID && item.Quantity == 0 && !item.IsArchived()
+		})).Return(nil)
+
+		output, err := NewSyncCatalogUseCase(repo, policy).Execute(ctx, SyncProductInput{ProductID: productID, SKU: "PRE-1", Active: true})
+
+		require.NoError(t, err)
+		assert.Equal(t, CatalogSyncCreated, output.Action)
+		assert.Equal(t, 0, output.Quantity)
+		assert.NotEqual(t, uuid.Nil, output.InventoryItemID)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("leaves existing active item untouched", func(t *testing.T) {
+		repo := new(MockInventoryRepository)
+		item, _ := entity.NewInventoryItem(uuid.New(), 7)
+		changedAt := time.Now().Add(-time.Minute)
+		item.ApplyCatalogChange(changedAt)
+		repo.On("FindByProductID", mock.Anything, item.ProductID).Return(item, nil)
+
+		output, err := NewSyncCatalogUseCase(repo, policy).Execute(ctx, SyncProductInput{ProductID: item.ProductID, SKU: "X", Active: true, ChangedAt: changedAt})
+
+		require.NoError(t, err)
+		assert.Equal(t, CatalogSyncUnchanged, output.Action)
+		assert.Equal(t, 7, output.Quantity, "existing stock is never overwritten by the policy")
+		repo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+		repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("records the activation of an active item", func(t *testing.T) {
+		repo := new(MockInventoryRepository)
+		item, _ := entity.NewInventoryItem(uuid.New(), 7)
+		changedAt := time.Now().Add(-time.Minute)
+		repo.On("FindByProductID", mock.Anything, item.ProductID).Return(item, nil)
+		repo.On("Update", mock.Anything, mock.MatchedBy(func(i *entity.InventoryItem) bool {
+			return !i.IsArchived() && i.Quantity == 7 && i.CatalogChangedAt.Equal(changedAt)
+		})).Return(nil)
+
+		output, err := NewSyncCatalogUseCase(repo, policy).Execute(ctx, SyncProductInput{ProductID: item.ProductID, SKU: "X", Active: true, ChangedAt: changedAt})
+
+		require.NoError(t, err)
+		assert.Equal(t, CatalogSyncUnchanged, output.Action)
+		assert.Equal(t, 7, output.Quantity, "existing stock is never overwritten by the policy")
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("restores archived item of reactivated product", func(t *testing.T) {
+		repo := new(MockInventoryRepository)
+		item, _ := entity.NewInventoryItem(uuid.New(), 7)
+		item.Archive(time.Now())
+		repo.On("FindByProductID", mock.Anything, item.ProductID).Return(item, nil)
+		repo.On("Update", mock.Anything, mock.MatchedBy(func(i *entity.InventoryItem) bool { return !i.IsArchived() })).Return(nil)
+
+		output, err := NewSyncCatalogUseCase(repo, policy).Execute(ctx, SyncProductInput{ProductID: item.ProductID, Active: true})
+
+		require.NoError(t, err)
+		assert.Equal(t, CatalogSyncRestored, output.Action)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("archives item of inactive product", func(t *testing.T) {
+		repo := new(MockInventoryRepository)
+		item, _ := entity.NewInventoryItem(uuid.New(), 7)
+		changedAt := time.Now().Add(-time.Minute)
+		repo.On("FindByProductID", mock.Anything, item.ProductID).Return(item, nil)
+		repo.On("Update", mock.Anything, mock.MatchedBy(func(i *entity.InventoryItem) bool {
+			return i.IsArchived() && i.ArchivedAt.Equal(changedAt)
+		})).Return(nil)
+
+		output, err := NewSyncCatalogUseCase(repo, policy).Execute(ctx, SyncProductInput{ProductID: item.ProductID, Active: false, ChangedAt: changedAt})
+
+		require.NoError(t, err)
+		assert.Equal(t, CatalogSyncArchived, output.Action)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("does not create items for inactive products", func(t *testing.T) {
+		repo := new(MockInventoryRepository)
+		productID := uuid.New()
+		repo.On("FindByProductID", mock.Anything, productID).Return(nil, domainErrors.ErrInventoryItemNotFound)
+
+		output, err := NewSyncCatalogUseCase(repo, policy).Execute(ctx, SyncProductInput{ProductID: productID, Active: false})
+
+		require.NoError(t, err)
+		assert.Equal(t, CatalogSyncUnchanged, output.Action)
+		assert.Equal(t, uuid.Nil, output.InventoryItemID)
+		repo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects missing product ID", func(t *testing.T) {
+		_, err := NewSyncCatalogUseCase(new(MockInventoryRepository), policy).Execute(ctx, SyncProductInput{Active: true})
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+	})
+
+	t.Run("re-reads when a concurrent delivery created the item first", func(t *testing.T) {
+		repo := new(MockInventoryRepository)
+		existing, _ := entity.NewInventoryItem(uuid.New(), 9)
+		changedAt := time.Now().Add(-time.Minute)
+		existing.ApplyCatalogChange(changedAt)
+		repo.On("FindByProductID", mock.Anything, existing.ProductID).Return(nil, domainErrors.ErrInventoryItemNotFound).Once()
+		repo.On("Save", mock.Anything, mock.Anything).Return(domainErrors.ErrInventoryItemAlreadyExists).Once()
+		repo.On("FindByProductID", mock.Anything, existing.ProductID).Return(existing, nil).Once()
+
+		output, err := NewSyncCatalogUseCase(repo, policy).
+			WithRetryPolicy(RetryPolicy{MaxAttempts: 2}).
+			Execute(ctx, SyncProductInput{ProductID: existing.ProductID, Active: true, ChangedAt: changedAt})
+
+		require.NoError(t, err)
+		assert.Equal(t, CatalogSyncUnchanged, output.Action)
+		assert.Equal(t, existing.ID, output.InventoryItemID)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("propagates repository errors", func(t *testing.T) {
+		repo := new(MockInventoryRepository)
+		productID := uuid.New()
+		dbErr := errors.New("connection reset")
+		repo.On("FindByProductID", mock.Anything, productID).Return(nil, dbErr)
+
+		_, err := NewSyncCatalogUseCase(repo, policy).Execute(ctx, SyncProductInput{ProductID: productID, Active: true})
+
+		assert.ErrorIs(t, err, dbErr)
+	})
+}
+
+func TestSyncCatalogUseCase_Deactivate(t *testing.T) {
+	repo := new(MockInventoryRepository)
+	item, _ := entity.NewInventoryItem(uuid.New(), 3)
+	item.Archive(time.Now().Add(-time.Hour))
+	repo.On("FindByProductID", mock.Anything, item.ProductID).Return(item, nil)
+
+	output, err := NewSyncCatalogUseCase(repo, InitialStockPolicy{}).Deactivate(context.Background(), item.ProductID, time.Now())
+
+	require.NoError(t, err)
+	assert.Equal(t, CatalogSyncUnchanged, output.Action, "deactivating twice is a no-op")
+	repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestSyncCatalogUseCase_Execute_OutOfOrder(t *testing.T) {
+	ctx := context.Background()
+	deactivatedAt := time.Now().Add(-2 * time.Minute)
+	reactivatedAt := time.Now().Add(-time.Minute)
+
+	t.Run("drops a deactivation delivered after a later activation", func(t *testing.T) {
+		repo := new(MockInventoryRepository)
+		item, _ := entity.NewInventoryItem(uuid.New(), 7)
+		repo.On("FindByProductID", mock.Anything, item.ProductID).Return(item, nil)
+		repo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+		uc := NewSyncCatalogUseCase(repo, InitialStockPolicy{})
+
+		output, err := uc.Execute(ctx, SyncProductInput{ProductID: item.ProductID, Active: true, ChangedAt: reactivatedAt})
+		require.NoError(t, err)
+		assert.Equal(t, CatalogSyncUnchanged, output.Action)
+
+		output, err = uc.Deactivate(ctx, item.ProductID, deactivatedAt)
+		require.NoError(t, err)
+		assert.Equal(t, CatalogSyncStale, output.Action)
+		assert.False(t, item.IsArchived())
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("drops an activation delivered after a later deactivation", func(t *testing.T) {
+		repo := new(MockInventoryRepository)
+		item, _ := entity.NewInventoryItem(uuid.New(), 7)
+		repo.On("FindByProductID", mock.Anything, item.ProductID).Return(item, nil)
+		repo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+		uc := NewSyncCatalogUseCase(repo, InitialStockPolicy{})
+
+		output, err := uc.Deactivate(ctx, item.ProductID, reactivatedAt)
+		require.NoError(t, err)
+		assert.Equal(t, CatalogSyncArchived, output.Action)
+
+		output, err = uc.Execute(ctx, SyncProductInput{ProductID: item.ProductID, Active: true, ChangedAt: deactivatedAt})
+		require.NoError(t, err)
+		assert.Equal(t, CatalogSyncStale, output.Action)
+		assert.True(t, item.IsArchived())
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("applies changes in order", func(t *testing.T) {
+		repo := new(MockInventoryRepository)
+		item, _ := entity.NewInventoryItem(uuid.New(), 7)
+		repo.On("FindByProductID", mock.Anything, item.ProductID).Return(item, nil)
+		repo.On("Update", mock.Anything, mock.Anything).Return(nil).Twice()
+		uc := NewSyncCatalogUseCase(repo, InitialStockPolicy{})
+
+		output, err := uc.Deactivate(ctx, item.ProductID, deactivatedAt)
+		require.NoError(t, err)
+		assert.Equal(t, CatalogSyncArchived, output.Action)
+
+		output, err = uc.Execute(ctx, SyncProductInput{ProductID: item.ProductID, Active: true, ChangedAt: reactivatedAt})
+		require.NoError(t, err)
+		assert.Equal(t, CatalogSyncRestored, output.Action)
+		assert.True(t, item.CatalogChangedAt.Equal(reactivatedAt))
+		repo.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/repository"
+)
+
+// DefaultStockSnapshotSettle is the default StockHistoryPolicy.Settle
+const DefaultStockSnapshotSettle = 5 * time.Minute
+
+// StockHistoryPolicy controls the stock snapshots and how long the history is kept
+type StockHistoryPolicy struct {
+	// Settle is how far behind the clock snapshots are taken, so that transactions
+	// still in flight when the snapshot runs are not left out of it
+	Settle time.Duration
+	// Retention is how far back the history stays queryable, 0 to keep all of it
+	Retention time.Duration
+}
+
+// withDefaults fills the zero fields
+func (p StockHistoryPolicy) withDefaults() StockHistoryPolicy {
+	if p.Settle <= 0 {
+		p.Settle = DefaultStockSnapshotSettle
+	}
+	return p
+}
+
+// TakeStockSnapshotOutput reports a snapshot run
+type TakeStockSnapshotOutput struct {
+	TakenAt         time.Time `json:"taken_at"`
+	Taken           bool      `json:"taken"` // false when a snapshot at or after TakenAt already existed or a transaction from before it is still open
+	Items           int       `json:"items"`
+	SnapshotsPruned int       `json:"snapshots_pruned"`
+	MovementsPruned int64     `json:"movements_pruned"`
+}
+
+// TakeStockSnapshotUseCase stores the stock of every item so that point-in-time
+// queries only replay the movements since the latest snapshot, and prunes the
+// history older than the retention period
+type TakeStockSnapshotUseCase struct {
+	historyRepo repository.StockHistoryRepository
+	policy      StockHistoryPolicy
+	now         func() time.Time
+}
+
+// NewTakeStockSnapshotUseCase creates a new instance
+func NewTakeStockSnapshotUseCase(historyRepo repository.StockHistoryRepository, policy StockHistoryPolicy) *TakeStockSnapshotUseCase {
+	if historyRepo == nil {
+		panic("historyRepo cannot be nil")
+	}
+
+	return &TakeStockSnapshotUseCase{
+		historyRepo: historyRepo,
+		policy:      policy.withDefaults(),
+		now:         time.Now,
+	}
+}
+
+// Execute takes one snapshot and prunes the expired history
+func (uc *TakeStockSnapshotUseCase) Execute(ctx context.Context) (*TakeStockSnapshotOutput, error) {
+	now := uc.now()
+	output := &TakeStockSnapshotOutput{
+		TakenAt: now.Add(-uc.policy.Settle).UTC().Truncate(time.Second),
+	}
+
+	var err error
+	output.Items, output.Taken, err = uc.historyRepo.TakeSnapshot(ctx, output.TakenAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if uc.policy.Retention > 0 {
+		output.SnapshotsPruned, output.MovementsPruned, err = uc.historyRepo.Prune(ctx, now.Add(-uc.policy.Retention).UTC())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return output, nil
+}
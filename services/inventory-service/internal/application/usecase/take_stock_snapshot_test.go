@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var snapshotNow = time.Date(2025, 12, 1, 0, 10, 30, 500, time.UTC)
+
+func newTakeStockSnapshotUseCase(repo *MockStockHistoryRepository, policy StockHistoryPolicy) *TakeStockSnapshotUseCase {
+	uc := NewTakeStockSnapshotUseCase(repo, policy)
+	uc.now = func() time.Time { return snapshotNow }
+	return uc
+}
+
+func TestNewTakeStockSnapshotUseCase(t *testing.T) {
+	assert.Panics(t, func() { NewTakeStockSnapshotUseCase(nil, StockHistoryPolicy{}) })
+
+	uc := NewTakeStockSnapshotUseCase(new(MockStockHistoryRepository), StockHistoryPolicy{})
+	assert.Equal(t, StockHistoryPolicy{Settle: DefaultStockSnapshotSettle}, uc.policy)
+}
+
+func TestTakeStockSnapshotUseCase_Execute_TakesSettledSnapshotAndPrunes(t *testing.T) {
+	repo := new(MockStockHistoryRepository)
+	takenAt := time.Date(2025, 12, 1, 0, 5, 30, 0, time.UTC)
+	repo.On("TakeSnapshot", mock.Anything, takenAt).Return(42, true, nil)
+	repo.On("Prune", mock.Anything, snapshotNow.AddDate(0, 0, -30)).Return(3, int64(900), nil)
+
+	output, err := newTakeStockSnapshotUseCase(repo, StockHistoryPolicy{Retention: 30 * 24 * time.Hour}).Execute(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, &TakeStockSnapshotOutput{
+		TakenAt:         takenAt,
+		Taken:           true,
+		Items:           42,
+		SnapshotsPruned: 3,
+		MovementsPruned: 900,
+	}, output)
+	repo.AssertExpectations(t)
+}
+
+func TestTakeStockSnapshotUseCase_Execute_KeepsHistoryWithoutRetention(t *testing.T) {
+	repo := new(MockStockHistoryRepository)
+	repo.On("TakeSnapshot", mock.Anything, mock.Anything).Return(0, false, nil)
+
+	output, err := newTakeStockSnapshotUseCase(repo, StockHistoryPolicy{Settle: time.Minute}).Execute(context.Background())
+
+	require.NoError(t, err)
+	assert.False(t, output.Taken)
+	assert.Equal(t, time.Date(2025, 12, 1, 0, 9, 30, 0, time.UTC), output.TakenAt)
+	repo.AssertNotCalled(t, "Prune", mock.Anything, mock.Anything)
+}
+
+func TestTakeStockSnapshotUseCase_Execute_SnapshotFailureSkipsPrune(t *testing.T) {
+	repo := new(MockStockHistoryRepository)
+	repo.On("TakeSnapshot", mock.Anything, mock.Anything).Return(0, false, errors.New("lock timeout"))
+
+	_, err := newTakeStockSnapshotUseCase(repo, StockHistoryPolicy{Retention: time.Hour}).Execute(context.Background())
+
+	assert.EqualError(t, err, "lock timeout")
+	repo.AssertNotCalled(t, "Prune", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,20 @@
+package usecase
+
+import "context"
+
+// userIDKey is the context key for the end user a request is made for
+type userIDKey struct{}
+
+// ContextWithUserID returns a copy of ctx carrying the end user the request is
+// made for. Transports set it from the verified token or a trusted header;
+// use cases record it on reservations and events.
+func ContextWithUserID(ctx context.Context, userID string) context.Context {
+	return context.WithValue(ctx, userIDKey{}, userID)
+}
+
+// UserIDFromContext returns the end user the request is made for, empty when
+// the caller did not identify one
+func UserIDFromContext(ctx context.Context) string {
+	userID, _ := ctx.Value(userIDKey{}).(string)
+	return userID
+}
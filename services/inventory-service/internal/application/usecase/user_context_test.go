@@ -0,0 +1,14 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserIDFromContext(t *testing.T) {
+	ctx := context.Background()
+	assert.Empty(t, UserIDFromContext(ctx))
+	assert.Equal(t, "user-42", UserIDFromContext(ContextWithUserID(ctx, "user-42")))
+}
@@ -0,0 +1,559 @@
+package usecase
+
+import (
+	"context"
+	goerrors "errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/events"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+// WaitlistServeBatchSize is the number of waiting entries of a product read per serve
+const WaitlistServeBatchSize = 100
+
+// WaitlistNotifier is told about products whose available stock may have grown,
+// so that their waitlists are served. It must not block.
+type WaitlistNotifier interface {
+	StockAvailable(productID uuid.UUID)
+}
+
+// notifyStockAvailable tells the notifier, when configured, about every product
+func notifyStockAvailable(notifier WaitlistNotifier, productIDs ...uuid.UUID) {
+	if notifier == nil {
+		return
+	}
+	for _, productID := range productIDs {
+		notifier.StockAvailable(productID)
+	}
+}
+
+// freedProducts returns the products whose stock a release made available:
+// the item's, and every component's for bundles
+func freedProducts(item *entity.InventoryItem, change *repository.BundleStockChange) []uuid.UUID {
+	productIDs := []uuid.UUID{item.ProductID}
+	for _, component := range changedComponents(change) {
+		productIDs = append(productIDs, component.ProductID)
+	}
+	return productIDs
+}
+
+// StockReserver creates the reservations of served waitlist entries
+type StockReserver interface {
+	Execute(ctx context.Context, input ReserveStockInput) (*ReserveStockOutput, error)
+}
+
+// ReservationReleaser releases reservations created for entries that left the waitlist meanwhile
+type ReservationReleaser interface {
+	Execute(ctx context.Context, input ReleaseReservationInput) (*ReleaseReservationOutput, error)
+}
+
+// JoinWaitlistInput represents an order waiting for stock of a product
+type JoinWaitlistInput struct {
+	ProductID uuid.UUID
+	OrderID   uuid.UUID
+	Quantity  int
+	Priority  int            // 0..entity.MaxWaitlistPriority, higher is served first
+	TTL       *time.Duration // Optional: if nil, uses the default TTL
+	Channel   string         // Optional sales channel to reserve from
+}
+
+// JoinWaitlistUseCase puts an order on the waitlist of a product
+type JoinWaitlistUseCase struct {
+	inventoryRepo   repository.InventoryRepository
+	reservationRepo repository.ReservationRepository
+	waitlist        repository.WaitlistRepository
+	defaultTTL      time.Duration
+	maxTTL          time.Duration
+	notifier        WaitlistNotifier
+}
+
+// NewJoinWaitlistUseCase creates a new instance
+func NewJoinWaitlistUseCase(
+	inventoryRepo repository.InventoryRepository,
+	reservationRepo repository.ReservationRepository,
+	waitlist repository.WaitlistRepository,
+) *JoinWaitlistUseCase {
+	if inventoryRepo == nil {
+		panic("inventoryRepo cannot be nil")
+	}
+	if reservationRepo == nil {
+		panic("reservationRepo cannot be nil")
+	}
+	if waitlist == nil {
+		panic("waitlist cannot be nil")
+	}
+
+	return &JoinWaitlistUseCase{
+		inventoryRepo:   inventoryRepo,
+		reservationRepo: reservationRepo,
+		waitlist:        waitlist,
+		defaultTTL:      entity.DefaultWaitlistTTL,
+		maxTTL:          entity.MaxWaitlistTTL,
+	}
+}
+
+// WithTTL sets the TTL of entries that name none and the longest TTL accepted
+func (uc *JoinWaitlistUseCase) WithTTL(defaultTTL, maxTTL time.Duration) *JoinWaitlistUseCase {
+	if defaultTTL > 0 {
+		uc.defaultTTL = defaultTTL
+	}
+	if maxTTL > 0 {
+		uc.maxTTL = maxTTL
+	}
+	return uc
+}
+
+// WithWaitlist makes the use case serve the product's waitlist after every join,
+// in case stock freed up since the caller's reservation was rejected
+func (uc *JoinWaitlistUseCase) WithWaitlist(notifier WaitlistNotifier) *JoinWaitlistUseCase {
+	uc.notifier = notifier
+	return uc
+}
+
+// Execute validates the entry and saves it for the end user of the context.
+// Orders that already have a reservation are rejected with
+// ErrReservationAlreadyExists, orders already waiting with ErrWaitlistEntryAlreadyExists.
+func (uc *JoinWaitlistUseCase) Execute(ctx context.Context, input JoinWaitlistInput) (*entity.WaitlistEntry, error) {
+	if err := validateOptionalChannel(input.Channel); err != nil {
+		return nil, err
+	}
+	userID := UserIDFromContext(ctx)
+	if userID != "" {
+		if err := entity.ValidateUserID(userID); err != nil {
+			return nil, err
+		}
+	}
+	ttl := uc.defaultTTL
+	if input.TTL != nil {
+		ttl = *input.TTL
+	}
+	if ttl > uc.maxTTL {
+		return nil, errors.ErrInvalidDuration.WithDetails(fmt.Sprintf("ttl cannot exceed %s", uc.maxTTL))
+	}
+
+	item, err := uc.inventoryRepo.FindByProductID(ctx, input.ProductID)
+	if err != nil {
+		return nil, errors.ErrInventoryItemNotFound.WithDetails(err.Error())
+	}
+
+	entry, err := entity.NewWaitlistEntry(item.ID, input.OrderID, input.Quantity, input.Priority, ttl)
+	if err != nil {
+		return nil, err
+	}
+	entry.Channel = input.Channel
+	entry.UserID = userID
+
+	exists, err := uc.reservationRepo.ExistsByOrderID(ctx, input.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, errors.ErrReservationAlreadyExists.WithDetails("order_id: " + input.OrderID.String())
+	}
+
+	if err := uc.waitlist.Save(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	notifyStockAvailable(uc.notifier, input.ProductID)
+	return entry, nil
+}
+
+// GetWaitlistEntryUseCase reports a waitlist entry
+type GetWaitlistEntryUseCase struct {
+	waitlist repository.WaitlistRepository
+}
+
+// NewGetWaitlistEntryUseCase creates a new instance
+func NewGetWaitlistEntryUseCase(waitlist repository.WaitlistRepository) *GetWaitlistEntryUseCase {
+	if waitlist == nil {
+		panic("waitlist cannot be nil")
+	}
+
+	return &GetWaitlistEntryUseCase{
+		waitlist: waitlist,
+	}
+}
+
+// Execute returns the entry, ErrWaitlistEntryNotFound if it doesn't exist
+func (uc *GetWaitlistEntryUseCase) Execute(ctx context.Context, id uuid.UUID) (*entity.WaitlistEntry, error) {
+	return uc.waitlist.FindByID(ctx, id)
+}
+
+// CancelWaitlistEntryUseCase takes an order off the waitlist
+type CancelWaitlistEntryUseCase struct {
+	waitlist repository.WaitlistRepository
+}
+
+// NewCancelWaitlistEntryUseCase creates a new instance
+func NewCancelWaitlistEntryUseCase(waitlist repository.WaitlistRepository) *CancelWaitlistEntryUseCase {
+	if waitlist == nil {
+		panic("waitlist cannot be nil")
+	}
+
+	return &CancelWaitlistEntryUseCase{
+		waitlist: waitlist,
+	}
+}
+
+// Execute cancels a waiting entry. Entries that already left the waitlist fail
+// with ErrWaitlistEntryNotWaiting; the reservation of a fulfilled entry is
+// released through the reservation endpoints.
+func (uc *CancelWaitlistEntryUseCase) Execute(ctx context.Context, id uuid.UUID) (*entity.WaitlistEntry, error) {
+	entry, err := uc.waitlist.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := entry.Cancel(); err != nil {
+		return nil, err
+	}
+	if err := uc.waitlist.Update(ctx, entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// ListWaitlistOutput reports the waitlist of an inventory item
+type ListWaitlistOutput struct {
+	Item    *entity.InventoryItem
+	Entries []*entity.WaitlistEntry
+}
+
+// ListWaitlistUseCase lists the waitlist of a product
+type ListWaitlistUseCase struct {
+	inventoryRepo repository.InventoryRepository
+	waitlist      repository.WaitlistRepository
+}
+
+// NewListWaitlistUseCase creates a new instance
+func NewListWaitlistUseCase(inventoryRepo repository.InventoryRepository, waitlist repository.WaitlistRepository) *ListWaitlistUseCase {
+	if inventoryRepo == nil {
+		panic("inventoryRepo cannot be nil")
+	}
+	if waitlist == nil {
+		panic("waitlist cannot be nil")
+	}
+
+	return &ListWaitlistUseCase{
+		inventoryRepo: inventoryRepo,
+		waitlist:      waitlist,
+	}
+}
+
+// Execute returns the product's entries in the given status (every status if empty), in serving order
+func (uc *ListWaitlistUseCase) Execute(ctx context.Context, productID uuid.UUID, status entity.WaitlistStatus) (*ListWaitlistOutput, error) {
+	item, err := uc.inventoryRepo.FindByProductID(ctx, productID)
+	if err != nil {
+		return nil, errors.ErrInventoryItemNotFound.WithDetails(err.Error())
+	}
+
+	entries, err := uc.waitlist.ListByInventoryItemID(ctx, item.ID, status)
+	if err != nil {
+		return nil, err
+	}
+	return &ListWaitlistOutput{Item: item, Entries: entries}, nil
+}
+
+// ServeWaitlistOutput reports the entries a serve resolved
+type ServeWaitlistOutput struct {
+	ProductID uuid.UUID
+	Fulfilled []*entity.WaitlistEntry
+	Failed    []*entity.WaitlistEntry
+}
+
+// ServeWaitlistUseCase reserves stock for the waiting entries of a product
+type ServeWaitlistUseCase struct {
+	inventoryRepo   repository.InventoryRepository
+	reservationRepo repository.ReservationRepository
+	waitlist        repository.WaitlistRepository
+	reserver        StockReserver
+	releaser        ReservationReleaser
+	publisher       events.Publisher
+	reservationTTL  time.Duration
+}
+
+// NewServeWaitlistUseCase creates a new instance
+func NewServeWaitlistUseCase(
+	inventoryRepo repository.InventoryRepository,
+	reservationRepo repository.ReservationRepository,
+	waitlist repository.WaitlistRepository,
+	reserver StockReserver,
+	releaser ReservationReleaser,
+	publisher events.Publisher,
+) *ServeWaitlistUseCase {
+	if inventoryRepo == nil {
+		panic("inventoryRepo cannot be nil")
+	}
+	if reservationRepo == nil {
+		panic("reservationRepo cannot be nil")
+	}
+	if waitlist == nil {
+		panic("waitlist cannot be nil")
+	}
+	if reserver == nil {
+		panic("reserver cannot be nil")
+	}
+	if releaser == nil {
+		panic("releaser cannot be nil")
+	}
+	if publisher == nil {
+		panic("publisher cannot be nil")
+	}
+
+	return &ServeWaitlistUseCase{
+		inventoryRepo:   inventoryRepo,
+		reservationRepo: reservationRepo,
+		waitlist:        waitlist,
+		reserver:        reserver,
+		releaser:        releaser,
+		publisher:       publisher,
+	}
+}
+
+// WithReservationTTL sets how long the reservations of served entries last;
+// the reserver's default applies otherwise
+func (uc *ServeWaitlistUseCase) WithReservationTTL(ttl time.Duration) *ServeWaitlistUseCase {
+	uc.reservationTTL = ttl
+	return uc
+}
+
+// Execute serves the product's unexpired waiting entries by descending priority,
+// then first come, first served:
+// 1. Reserves the entry's quantity from its channel for the entry's end user
+// 2. Marks the entry fulfilled with the reservation
+// 3. Publishes a WaitlistFulfilled event
+//
+// An entry the stock cannot cover stops the serve of its channel, so smaller
+// entries never overtake it. Entries rejected for another business rule, such
+// as a purchase limit, are marked failed. A reservation created for an entry
+// that was cancelled meanwhile is released again. Optimistic-lock contention
+// and infrastructure errors stop the serve; the entries stay waiting.
+func (uc *ServeWaitlistUseCase) Execute(ctx context.Context, productID uuid.UUID) (*ServeWaitlistOutput, error) {
+	item, err := uc.inventoryRepo.FindByProductID(ctx, productID)
+	if err != nil {
+		return nil, errors.ErrInventoryItemNotFound.WithDetails(err.Error())
+	}
+
+	entries, err := uc.waitlist.ListWaiting(ctx, item.ID, WaitlistServeBatchSize)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &ServeWaitlistOutput{ProductID: productID}
+	blocked := make(map[string]bool)
+	for _, entry := range entries {
+		if blocked[entry.Channel] || entry.IsExpired() {
+			continue
+		}
+
+		input := ReserveStockInput{
+			ProductID: productID,
+			OrderID:   entry.OrderID,
+			Quantity:  entry.Quantity,
+			Channel:   entry.Channel,
+		}
+		if uc.reservationTTL > 0 {
+			input.Duration = &uc.reservationTTL
+		}
+
+		reserved, err := uc.reserver.Execute(ContextWithUserID(ctx, entry.UserID), input)
+		switch {
+		case err == nil:
+			if uc.fulfill(ctx, entry, productID, reserved.ReservationID, reserved.ExpiresAt, true) {
+				output.Fulfilled = append(output.Fulfilled, entry)
+			}
+		case goerrors.Is(err, errors.ErrInsufficientStock):
+			blocked[entry.Channel] = true
+		case goerrors.Is(err, errors.ErrConcurrentModification):
+			return output, err
+		case goerrors.Is(err, errors.ErrReservationAlreadyExists):
+			if uc.adopt(ctx, entry, productID) {
+				output.Fulfilled = append(output.Fulfilled, entry)
+			} else if uc.fail(ctx, entry, err) {
+				output.Failed = append(output.Failed, entry)
+			}
+		default:
+			var domainErr *errors.DomainError
+			if !goerrors.As(err, &domainErr) {
+				return output, err
+			}
+			if uc.fail(ctx, entry, err) {
+				output.Failed = append(output.Failed, entry)
+			}
+		}
+	}
+
+	return output, nil
+}
+
+// fulfill marks the entry served by the reservation and publishes the
+// WaitlistFulfilled event. A reservation created here for an entry that left
+// the waitlist meanwhile is released, unless another server fulfilled the
+// entry with it. Returns whether the entry was fulfilled.
+func (uc *ServeWaitlistUseCase) fulfill(
+	ctx context.Context,
+	entry *entity.WaitlistEntry,
+	productID, reservationID uuid.UUID,
+	expiresAt time.Time,
+	created bool,
+) bool {
+	if err := entry.Fulfill(reservationID); err != nil {
+		return false
+	}
+
+	if err := uc.waitlist.Update(ctx, entry); err != nil {
+		if !goerrors.Is(err, errors.ErrWaitlistEntryNotWaiting) {
+			log.Printf("[ServeWaitlist] ERROR: Failed to mark entry %s fulfilled with reservation %s: %v",
+				entry.ID, reservationID, err)
+			return false
+		}
+		if created && !uc.fulfilledWith(ctx, entry.ID, reservationID) {
+			log.Printf("[ServeWaitlist] Entry %s left the waitlist, releasing reservation %s", entry.ID, reservationID)
+			if _, err := uc.releaser.Execute(ctx, ReleaseReservationInput{ReservationID: reservationID}); err != nil {
+				log.Printf("[ServeWaitlist] ERROR: Failed to release reservation %s: %v", reservationID, err)
+			}
+		}
+		return false
+	}
+
+	// Publish WaitlistFulfilled event (don't fail the serve if event publication fails)
+	waitlistFulfilledEvent := events.WaitlistFulfilledEvent{
+		BaseEvent: events.BaseEvent{
+			EventID:   uuid.New().String(),
+			EventType: events.RoutingKeyWaitlistFulfilled,
+			Timestamp: time.Now().Format(time.RFC3339),
+			Version:   events.WaitlistFulfilledVersion,
+			Source:    events.SourceInventoryService,
+		},
+		Payload: events.WaitlistFulfilledPayload{
+			EntryID:       entry.ID.String(),
+			ReservationID: reservationID.String(),
+			ProductID:     productID.String(),
+			OrderID:       entry.OrderID.String(),
+			UserID:        entry.UserID,
+			Quantity:      entry.Quantity,
+			Priority:      entry.Priority,
+			Channel:       entry.Channel,
+			JoinedAt:      entry.CreatedAt,
+			ExpiresAt:     expiresAt,
+			FulfilledAt:   *entry.FulfilledAt,
+		},
+	}
+
+	if err := uc.publisher.PublishWaitlistFulfilled(ctx, waitlistFulfilledEvent); err != nil {
+		log.Printf("Failed to publish WaitlistFulfilled event: %v", err)
+	}
+	return true
+}
+
+// fulfilledWith reports whether the stored entry was fulfilled with the reservation
+func (uc *ServeWaitlistUseCase) fulfilledWith(ctx context.Context, entryID, reservationID uuid.UUID) bool {
+	stored, err := uc.waitlist.FindByID(ctx, entryID)
+	if err != nil {
+		log.Printf("[ServeWaitlist] ERROR: Failed to read entry %s: %v", entryID, err)
+		return true // don't release a reservation that may be in use
+	}
+	return stored.Status == entity.WaitlistFulfilled && stored.ReservationID != nil && *stored.ReservationID == reservationID
+}
+
+// adopt fulfills the entry with the pending reservation its order got for the
+// same item after joining, by another server or directly. Returns whether the
+// entry was fulfilled.
+func (uc *ServeWaitlistUseCase) adopt(ctx context.Context, entry *entity.WaitlistEntry, productID uuid.UUID) bool {
+	reservation, err := uc.reservationRepo.FindByOrderID(ctx, entry.OrderID)
+	if err != nil {
+		log.Printf("[ServeWaitlist] ERROR: Failed to read the reservation of order %s: %v", entry.OrderID, err)
+		return false
+	}
+	if reservation.InventoryItemID != entry.InventoryItemID ||
+		reservation.Status != entity.ReservationPending ||
+		reservation.CreatedAt.Before(entry.CreatedAt) {
+		return false
+	}
+	return uc.fulfill(ctx, entry, productID, reservation.ID, reservation.ExpiresAt, false)
+}
+
+// fail marks the entry failed for the reservation error. Returns whether it was.
+func (uc *ServeWaitlistUseCase) fail(ctx context.Context, entry *entity.WaitlistEntry, reason error) bool {
+	if !entry.IsWaiting() {
+		return false
+	}
+	if err := entry.Fail(reason.Error()); err != nil {
+		return false
+	}
+	if err := uc.waitlist.Update(ctx, entry); err != nil {
+		if !goerrors.Is(err, errors.ErrWaitlistEntryNotWaiting) {
+			log.Printf("[ServeWaitlist] ERROR: Failed to mark entry %s failed: %v", entry.ID, err)
+		}
+		return false
+	}
+	return true
+}
+
+// SweepWaitlistsOutput reports a sweep of every waitlist
+type SweepWaitlistsOutput struct {
+	Expired   int
+	Products  int
+	Fulfilled int
+	Failed    int
+}
+
+// SweepWaitlistsUseCase expires the waitlist entries past their TTL and serves
+// every waitlist, catching stock that freed up without a notification
+type SweepWaitlistsUseCase struct {
+	waitlist repository.WaitlistRepository
+	serve    *ServeWaitlistUseCase
+}
+
+// NewSweepWaitlistsUseCase creates a new instance
+func NewSweepWaitlistsUseCase(waitlist repository.WaitlistRepository, serve *ServeWaitlistUseCase) *SweepWaitlistsUseCase {
+	if waitlist == nil {
+		panic("waitlist cannot be nil")
+	}
+	if serve == nil {
+		panic("serve cannot be nil")
+	}
+
+	return &SweepWaitlistsUseCase{
+		waitlist: waitlist,
+		serve:    serve,
+	}
+}
+
+// Execute expires the entries past their TTL, then serves every product that
+// still has waiting entries. A product that fails to be served is logged and
+// does not stop the sweep.
+func (uc *SweepWaitlistsUseCase) Execute(ctx context.Context) (*SweepWaitlistsOutput, error) {
+	now := time.Now()
+
+	expired, err := uc.waitlist.ExpireWaiting(ctx, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to expire waitlist entries: %w", err)
+	}
+
+	productIDs, err := uc.waitlist.ProductsWaiting(ctx, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find waitlisted products: %w", err)
+	}
+
+	output := &SweepWaitlistsOutput{Expired: expired, Products: len(productIDs)}
+	for _, productID := range productIDs {
+		served, err := uc.serve.Execute(ctx, productID)
+		if served != nil {
+			output.Fulfilled += len(served.Fulfilled)
+			output.Failed += len(served.Failed)
+		}
+		if err != nil {
+			log.Printf("[SweepWaitlists] ERROR: Failed to serve the waitlist of product %s: %v", productID, err)
+		}
+	}
+
+	return output, nil
+}
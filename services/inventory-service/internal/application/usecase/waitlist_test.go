@@ -0,0 +1,540 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	domainErrors "github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/events"
+)
+
+// MockWaitlistRepository is a mock implementation of WaitlistRepository
+type MockWaitlistRepository struct {
+	mock.Mock
+}
+
+func (m *MockWaitlistRepository) Save(ctx context.Context, entry *entity.WaitlistEntry) error {
+	args := m.Called(ctx, entry)
+	return args.Error(0)
+}
+
+func (m *MockWaitlistRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.WaitlistEntry, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.WaitlistEntry), args.Error(1)
+}
+
+func (m *MockWaitlistRepository) Update(ctx context.Context, entry *entity.WaitlistEntry) error {
+	args := m.Called(ctx, entry)
+	return args.Error(0)
+}
+
+func (m *MockWaitlistRepository) ListWaiting(ctx context.Context, inventoryItemID uuid.UUID, limit int) ([]*entity.WaitlistEntry, error) {
+	args := m.Called(ctx, inventoryItemID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.WaitlistEntry), args.Error(1)
+}
+
+func (m *MockWaitlistRepository) ListByInventoryItemID(
+	ctx context.Context,
+	inventoryItemID uuid.UUID,
+	status entity.WaitlistStatus,
+) ([]*entity.WaitlistEntry, error) {
+	args := m.Called(ctx, inventoryItemID, status)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.WaitlistEntry), args.Error(1)
+}
+
+func (m *MockWaitlistRepository) ProductsWaiting(ctx context.Context, now time.Time) ([]uuid.UUID, error) {
+	args := m.Called(ctx, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+func (m *MockWaitlistRepository) ExpireWaiting(ctx context.Context, now time.Time) (int, error) {
+	args := m.Called(ctx, now)
+	return args.Int(0), args.Error(1)
+}
+
+// MockStockReserver is a mock implementation of StockReserver
+type MockStockReserver struct {
+	mock.Mock
+}
+
+func (m *MockStockReserver) Execute(ctx context.Context, input ReserveStockInput) (*ReserveStockOutput, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ReserveStockOutput), args.Error(1)
+}
+
+// MockReservationReleaser is a mock implementation of ReservationReleaser
+type MockReservationReleaser struct {
+	mock.Mock
+}
+
+func (m *MockReservationReleaser) Execute(ctx context.Context, input ReleaseReservationInput) (*ReleaseReservationOutput, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ReleaseReservationOutput), args.Error(1)
+}
+
+// recordingNotifier records the products it is told about
+type recordingNotifier struct {
+	productIDs []uuid.UUID
+}
+
+func (n *recordingNotifier) StockAvailable(productID uuid.UUID) {
+	n.productIDs = append(n.productIDs, productID)
+}
+
+func waitlistEntry(t *testing.T, itemID uuid.UUID, quantity int, channel string) *entity.WaitlistEntry {
+	t.Helper()
+	entry, err := entity.NewWaitlistEntry(itemID, uuid.New(), quantity, 0, time.Hour)
+	require.NoError(t, err)
+	entry.Channel = channel
+	entry.UserID = "user-42"
+	return entry
+}
+
+// forOrder matches the reservation input of a waitlist entry
+func forOrder(entry *entity.WaitlistEntry) interface{} {
+	return mock.MatchedBy(func(input ReserveStockInput) bool {
+		return input.OrderID == entry.OrderID && input.Quantity == entry.Quantity && input.Channel == entry.Channel
+	})
+}
+
+func TestNewWaitlistUseCases_NilDependencies_Panic(t *testing.T) {
+	inventoryRepo, reservationRepo, waitlist := new(MockInventoryRepository), new(MockReservationRepository), new(MockWaitlistRepository)
+	reserver, releaser, publisher := new(MockStockReserver), new(MockReservationReleaser), new(MockPublisher)
+
+	assert.Panics(t, func() { NewJoinWaitlistUseCase(nil, reservationRepo, waitlist) })
+	assert.Panics(t, func() { NewJoinWaitlistUseCase(inventoryRepo, nil, waitlist) })
+	assert.Panics(t, func() { NewJoinWaitlistUseCase(inventoryRepo, reservationRepo, nil) })
+	assert.Panics(t, func() { NewGetWaitlistEntryUseCase(nil) })
+	assert.Panics(t, func() { NewCancelWaitlistEntryUseCase(nil) })
+	assert.Panics(t, func() { NewListWaitlistUseCase(nil, waitlist) })
+	assert.Panics(t, func() { NewListWaitlistUseCase(inventoryRepo, nil) })
+	assert.Panics(t, func() { NewServeWaitlistUseCase(nil, reservationRepo, waitlist, reserver, releaser, publisher) })
+	assert.Panics(t, func() { NewServeWaitlistUseCase(inventoryRepo, nil, waitlist, reserver, releaser, publisher) })
+	assert.Panics(t, func() { NewServeWaitlistUseCase(inventoryRepo, reservationRepo, nil, reserver, releaser, publisher) })
+	assert.Panics(t, func() { NewServeWaitlistUseCase(inventoryRepo, reservationRepo, waitlist, nil, releaser, publisher) })
+	assert.Panics(t, func() { NewServeWaitlistUseCase(inventoryRepo, reservationRepo, waitlist, reserver, nil, publisher) })
+	assert.Panics(t, func() { NewServeWaitlistUseCase(inventoryRepo, reservationRepo, waitlist, reserver, releaser, nil) })
+	assert.Panics(t, func() { NewSweepWaitlistsUseCase(nil, &ServeWaitlistUseCase{}) })
+	assert.Panics(t, func() { NewSweepWaitlistsUseCase(waitlist, nil) })
+}
+
+func TestJoinWaitlistUseCase_Execute(t *testing.T) {
+	item, err := entity.NewInventoryItem(uuid.New(), 10)
+	require.NoError(t, err)
+	ctx := ContextWithUserID(context.Background(), "user-42")
+
+	t.Run("should save the entry for the user of the context and serve the waitlist", func(t *testing.T) {
+		inventoryRepo, reservationRepo, waitlist := new(MockInventoryRepository), new(MockReservationRepository), new(MockWaitlistRepository)
+		notifier := &recordingNotifier{}
+		orderID := uuid.New()
+		inventoryRepo.On("FindByProductID", mock.Anything, item.ProductID).Return(item, nil)
+		reservationRepo.On("ExistsByOrderID", mock.Anything, orderID).Return(false, nil)
+		waitlist.On("Save", mock.Anything, mock.MatchedBy(func(entry *entity.WaitlistEntry) bool {
+			return entry.InventoryItemID == item.ID && entry.OrderID == orderID && entry.UserID == "user-42" &&
+				entry.Channel == "web" && entry.Priority == 5
+		})).Return(nil)
+
+		uc := NewJoinWaitlistUseCase(inventoryRepo, reservationRepo, waitlist).WithTTL(2*time.Hour, 4*time.Hour).WithWaitlist(notifier)
+		entry, err := uc.Execute(ctx, JoinWaitlistInput{
+			ProductID: item.ProductID,
+			OrderID:   orderID,
+			Quantity:  3,
+			Priority:  5,
+			Channel:   "web",
+		})
+
+		require.NoError(t, err)
+		assert.WithinDuration(t, time.Now().Add(2*time.Hour), entry.ExpiresAt, time.Second)
+		assert.Equal(t, []uuid.UUID{item.ProductID}, notifier.productIDs)
+		waitlist.AssertExpectations(t)
+	})
+
+	long := 5 * time.Hour
+	tests := []struct {
+		name      string
+		input     JoinWaitlistInput
+		setup     func(inventoryRepo *MockInventoryRepository, reservationRepo *MockReservationRepository, waitlist *MockWaitlistRepository)
+		wantError error
+	}{
+		{
+			name:      "ttl over the maximum",
+			input:     JoinWaitlistInput{ProductID: item.ProductID, OrderID: uuid.New(), Quantity: 1, TTL: &long},
+			wantError: domainErrors.ErrInvalidDuration,
+		},
+		{
+			name:      "invalid channel",
+			input:     JoinWaitlistInput{ProductID: item.ProductID, OrderID: uuid.New(), Quantity: 1, Channel: "Web Shop"},
+			wantError: domainErrors.ErrInvalidChannel,
+		},
+		{
+			name:  "unknown product",
+			input: JoinWaitlistInput{ProductID: uuid.New(), OrderID: uuid.New(), Quantity: 1},
+			setup: func(inventoryRepo *MockInventoryRepository, _ *MockReservationRepository, _ *MockWaitlistRepository) {
+				inventoryRepo.On("FindByProductID", mock.Anything, mock.Anything).Return(nil, errors.New("record not found"))
+			},
+			wantError: domainErrors.ErrInventoryItemNotFound,
+		},
+		{
+			name:  "invalid quantity",
+			input: JoinWaitlistInput{ProductID: item.ProductID, OrderID: uuid.New()},
+			setup: func(inventoryRepo *MockInventoryRepository, _ *MockReservationRepository, _ *MockWaitlistRepository) {
+				inventoryRepo.On("FindByProductID", mock.Anything, item.ProductID).Return(item, nil)
+			},
+			wantError: domainErrors.ErrInvalidQuantity,
+		},
+		{
+			name:  "order already reserved",
+			input: JoinWaitlistInput{ProductID: item.ProductID, OrderID: uuid.New(), Quantity: 1},
+			setup: func(inventoryRepo *MockInventoryRepository, reservationRepo *MockReservationRepository, _ *MockWaitlistRepository) {
+				inventoryRepo.On("FindByProductID", mock.Anything, item.ProductID).Return(item, nil)
+				reservationRepo.On("ExistsByOrderID", mock.Anything, mock.Anything).Return(true, nil)
+			},
+			wantError: domainErrors.ErrReservationAlreadyExists,
+		},
+		{
+			name:  "order already waiting",
+			input: JoinWaitlistInput{ProductID: item.ProductID, OrderID: uuid.New(), Quantity: 1},
+			setup: func(inventoryRepo *MockInventoryRepository, reservationRepo *MockReservationRepository, waitlist *MockWaitlistRepository) {
+				inventoryRepo.On("FindByProductID", mock.Anything, item.ProductID).Return(item, nil)
+				reservationRepo.On("ExistsByOrderID", mock.Anything, mock.Anything).Return(false, nil)
+				waitlist.On("Save", mock.Anything, mock.Anything).Return(domainErrors.ErrWaitlistEntryAlreadyExists)
+			},
+			wantError: domainErrors.ErrWaitlistEntryAlreadyExists,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inventoryRepo, reservationRepo, waitlist := new(MockInventoryRepository), new(MockReservationRepository), new(MockWaitlistRepository)
+			notifier := &recordingNotifier{}
+			if tt.setup != nil {
+				tt.setup(inventoryRepo, reservationRepo, waitlist)
+			}
+
+			uc := NewJoinWaitlistUseCase(inventoryRepo, reservationRepo, waitlist).WithTTL(time.Hour, 4*time.Hour).WithWaitlist(notifier)
+			_, err := uc.Execute(ctx, tt.input)
+
+			assert.ErrorIs(t, err, tt.wantError)
+			assert.Empty(t, notifier.productIDs)
+		})
+	}
+}
+
+func TestCancelWaitlistEntryUseCase_Execute(t *testing.T) {
+	t.Run("should cancel a waiting entry", func(t *testing.T) {
+		waitlist := new(MockWaitlistRepository)
+		entry := waitlistEntry(t, uuid.New(), 1, "")
+		waitlist.On("FindByID", mock.Anything, entry.ID).Return(entry, nil)
+		waitlist.On("Update", mock.Anything, entry).Return(nil)
+
+		cancelled, err := NewCancelWaitlistEntryUseCase(waitlist).Execute(context.Background(), entry.ID)
+
+		require.NoError(t, err)
+		assert.Equal(t, entity.WaitlistCancelled, cancelled.Status)
+		waitlist.AssertExpectations(t)
+	})
+
+	t.Run("should reject entries that left the waitlist", func(t *testing.T) {
+		waitlist := new(MockWaitlistRepository)
+		entry := waitlistEntry(t, uuid.New(), 1, "")
+		require.NoError(t, entry.Fulfill(uuid.New()))
+		waitlist.On("FindByID", mock.Anything, entry.ID).Return(entry, nil)
+
+		_, err := NewCancelWaitlistEntryUseCase(waitlist).Execute(context.Background(), entry.ID)
+
+		assert.ErrorIs(t, err, domainErrors.ErrWaitlistEntryNotWaiting)
+		waitlist.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+}
+
+func TestListWaitlistUseCase_Execute(t *testing.T) {
+	inventoryRepo, waitlist := new(MockInventoryRepository), new(MockWaitlistRepository)
+	item, _ := entity.NewInventoryItem(uuid.New(), 0)
+	entries := []*entity.WaitlistEntry{waitlistEntry(t, item.ID, 1, "")}
+	inventoryRepo.On("FindByProductID", mock.Anything, item.ProductID).Return(item, nil)
+	waitlist.On("ListByInventoryItemID", mock.Anything, item.ID, entity.WaitlistWaiting).Return(entries, nil)
+
+	output, err := NewListWaitlistUseCase(inventoryRepo, waitlist).Execute(context.Background(), item.ProductID, entity.WaitlistWaiting)
+
+	require.NoError(t, err)
+	assert.Equal(t, item, output.Item)
+	assert.Equal(t, entries, output.Entries)
+}
+
+type serveMocks struct {
+	inventoryRepo   *MockInventoryRepository
+	reservationRepo *MockReservationRepository
+	waitlist        *MockWaitlistRepository
+	reserver        *MockStockReserver
+	releaser        *MockReservationReleaser
+	publisher       *MockPublisher
+}
+
+func setupServeWaitlist(t *testing.T, item *entity.InventoryItem, entries ...*entity.WaitlistEntry) (*ServeWaitlistUseCase, *serveMocks) {
+	t.Helper()
+	m := &serveMocks{
+		inventoryRepo:   new(MockInventoryRepository),
+		reservationRepo: new(MockReservationRepository),
+		waitlist:        new(MockWaitlistRepository),
+		reserver:        new(MockStockReserver),
+		releaser:        new(MockReservationReleaser),
+		publisher:       new(MockPublisher),
+	}
+	m.inventoryRepo.On("FindByProductID", mock.Anything, item.ProductID).Return(item, nil)
+	m.waitlist.On("ListWaiting", mock.Anything, item.ID, WaitlistServeBatchSize).Return(entries, nil)
+	uc := NewServeWaitlistUseCase(m.inventoryRepo, m.reservationRepo, m.waitlist, m.reserver, m.releaser, m.publisher)
+	return uc, m
+}
+
+func TestServeWaitlistUseCase_Execute(t *testing.T) {
+	item, err := entity.NewInventoryItem(uuid.New(), 10)
+	require.NoError(t, err)
+
+	t.Run("should reserve for the entry's user and publish WaitlistFulfilled", func(t *testing.T) {
+		entry := waitlistEntry(t, item.ID, 3, "web")
+		uc, m := setupServeWaitlist(t, item, entry)
+		reservationID, expiresAt := uuid.New(), time.Now().Add(30*time.Minute)
+		ttl := 30 * time.Minute
+		m.reserver.On("Execute", mock.MatchedBy(func(ctx context.Context) bool {
+			return UserIDFromContext(ctx) == "user-42"
+		}), ReserveStockInput{
+			ProductID: item.ProductID,
+			OrderID:   entry.OrderID,
+			Quantity:  3,
+			Duration:  &ttl,
+			Channel:   "web",
+		}).Return(&ReserveStockOutput{ReservationID: reservationID, ExpiresAt: expiresAt}, nil)
+		m.waitlist.On("Update", mock.Anything, entry).Return(nil)
+		m.publisher.On("PublishWaitlistFulfilled", mock.Anything, mock.MatchedBy(func(event events.WaitlistFulfilledEvent) bool {
+			p := event.Payload
+			return event.EventType == events.RoutingKeyWaitlistFulfilled && p.EntryID == entry.ID.String() &&
+				p.ReservationID == reservationID.String() && p.ProductID == item.ProductID.String() &&
+				p.UserID == "user-42" && p.Quantity == 3 && p.Channel == "web" &&
+				p.JoinedAt.Equal(entry.CreatedAt) && p.ExpiresAt.Equal(expiresAt)
+		})).Return(nil)
+
+		output, err := uc.WithReservationTTL(ttl).Execute(context.Background(), item.ProductID)
+
+		require.NoError(t, err)
+		assert.Equal(t, []*entity.WaitlistEntry{entry}, output.Fulfilled)
+		assert.Equal(t, entity.WaitlistFulfilled, entry.Status)
+		assert.Equal(t, reservationID, *entry.ReservationID)
+		m.publisher.AssertExpectations(t)
+	})
+
+	t.Run("should not let later entries of a channel overtake one the stock cannot cover", func(t *testing.T) {
+		large, small, other := waitlistEntry(t, item.ID, 8, "web"), waitlistEntry(t, item.ID, 1, "web"), waitlistEntry(t, item.ID, 1, "")
+		uc, m := setupServeWaitlist(t, item, large, small, other)
+		m.reserver.On("Execute", mock.Anything, forOrder(large)).Return(nil, domainErrors.ErrInsufficientStock)
+		m.reserver.On("Execute", mock.Anything, forOrder(other)).Return(&ReserveStockOutput{ReservationID: uuid.New()}, nil)
+		m.waitlist.On("Update", mock.Anything, other).Return(nil)
+		m.publisher.On("PublishWaitlistFulfilled", mock.Anything, mock.Anything).Return(nil)
+
+		output, err := uc.Execute(context.Background(), item.ProductID)
+
+		require.NoError(t, err)
+		assert.Equal(t, []*entity.WaitlistEntry{other}, output.Fulfilled)
+		assert.True(t, large.IsWaiting())
+		assert.True(t, small.IsWaiting())
+		m.reserver.AssertNotCalled(t, "Execute", mock.Anything, forOrder(small))
+	})
+
+	t.Run("should fail entries rejected by a business rule", func(t *testing.T) {
+		limited, next := waitlistEntry(t, item.ID, 5, ""), waitlistEntry(t, item.ID, 1, "")
+		uc, m := setupServeWaitlist(t, item, limited, next)
+		m.reserver.On("Execute", mock.Anything, forOrder(limited)).Return(nil, domainErrors.ErrPurchaseLimitExceeded)
+		m.reserver.On("Execute", mock.Anything, forOrder(next)).Return(&ReserveStockOutput{ReservationID: uuid.New()}, nil)
+		m.waitlist.On("Update", mock.Anything, mock.Anything).Return(nil)
+		m.publisher.On("PublishWaitlistFulfilled", mock.Anything, mock.Anything).Return(nil)
+
+		output, err := uc.Execute(context.Background(), item.ProductID)
+
+		require.NoError(t, err)
+		assert.Equal(t, []*entity.WaitlistEntry{limited}, output.Failed)
+		assert.Equal(t, []*entity.WaitlistEntry{next}, output.Fulfilled)
+		assert.Equal(t, entity.WaitlistFailed, limited.Status)
+		assert.Contains(t, limited.FailureReason, "purchase limit")
+	})
+
+	t.Run("should release the reservation of an entry cancelled meanwhile", func(t *testing.T) {
+		entry := waitlistEntry(t, item.ID, 1, "")
+		uc, m := setupServeWaitlist(t, item, entry)
+		reservationID := uuid.New()
+		stored := *entry
+		stored.Status = entity.WaitlistCancelled
+		m.reserver.On("Execute", mock.Anything, forOrder(entry)).Return(&ReserveStockOutput{ReservationID: reservationID}, nil)
+		m.waitlist.On("Update", mock.Anything, entry).Return(domainErrors.ErrWaitlistEntryNotWaiting)
+		m.waitlist.On("FindByID", mock.Anything, entry.ID).Return(&stored, nil)
+		m.releaser.On("Execute", mock.Anything, ReleaseReservationInput{ReservationID: reservationID}).Return(&ReleaseReservationOutput{}, nil)
+
+		output, err := uc.Execute(context.Background(), item.ProductID)
+
+		require.NoError(t, err)
+		assert.Empty(t, output.Fulfilled)
+		m.releaser.AssertExpectations(t)
+		m.publisher.AssertNotCalled(t, "PublishWaitlistFulfilled", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should adopt a reservation the order got after joining", func(t *testing.T) {
+		entry := waitlistEntry(t, item.ID, 1, "")
+		uc, m := setupServeWaitlist(t, item, entry)
+		reservation, err := entity.NewReservation(item.ID, entry.OrderID, 1)
+		require.NoError(t, err)
+		m.reserver.On("Execute", mock.Anything, forOrder(entry)).Return(nil, domainErrors.ErrReservationAlreadyExists)
+		m.reservationRepo.On("FindByOrderID", mock.Anything, entry.OrderID).Return(reservation, nil)
+		m.waitlist.On("Update", mock.Anything, entry).Return(nil)
+		m.publisher.On("PublishWaitlistFulfilled", mock.Anything, mock.Anything).Return(nil)
+
+		output, err := uc.Execute(context.Background(), item.ProductID)
+
+		require.NoError(t, err)
+		assert.Equal(t, []*entity.WaitlistEntry{entry}, output.Fulfilled)
+		assert.Equal(t, reservation.ID, *entry.ReservationID)
+	})
+
+	t.Run("should stop on contention and leave the entries waiting", func(t *testing.T) {
+		first, second := waitlistEntry(t, item.ID, 1, ""), waitlistEntry(t, item.ID, 1, "")
+		uc, m := setupServeWaitlist(t, item, first, second)
+		m.reserver.On("Execute", mock.Anything, forOrder(first)).
+			Return(nil, &ContentionError{Operation: OperationReserve, Attempts: 3})
+
+		_, err := uc.Execute(context.Background(), item.ProductID)
+
+		assert.ErrorIs(t, err, domainErrors.ErrConcurrentModification)
+		assert.True(t, first.IsWaiting())
+		m.reserver.AssertNumberOfCalls(t, "Execute", 1)
+	})
+
+	t.Run("should stop on infrastructure errors", func(t *testing.T) {
+		entry := waitlistEntry(t, item.ID, 1, "")
+		uc, m := setupServeWaitlist(t, item, entry)
+		m.reserver.On("Execute", mock.Anything, mock.Anything).Return(nil, errors.New("connection refused"))
+
+		_, err := uc.Execute(context.Background(), item.ProductID)
+
+		assert.EqualError(t, err, "connection refused")
+		assert.True(t, entry.IsWaiting())
+	})
+}
+
+func TestSweepWaitlistsUseCase_Execute(t *testing.T) {
+	item, err := entity.NewInventoryItem(uuid.New(), 10)
+	require.NoError(t, err)
+	entry := waitlistEntry(t, item.ID, 1, "")
+	serve, m := setupServeWaitlist(t, item, entry)
+	unknown := uuid.New()
+	m.waitlist.On("ExpireWaiting", mock.Anything, mock.Anything).Return(2, nil)
+	m.waitlist.On("ProductsWaiting", mock.Anything, mock.Anything).Return([]uuid.UUID{unknown, item.ProductID}, nil)
+	m.inventoryRepo.On("FindByProductID", mock.Anything, unknown).Return(nil, errors.New("record not found"))
+	m.reserver.On("Execute", mock.Anything, forOrder(entry)).Return(&ReserveStockOutput{ReservationID: uuid.New()}, nil)
+	m.waitlist.On("Update", mock.Anything, entry).Return(nil)
+	m.publisher.On("PublishWaitlistFulfilled", mock.Anything, mock.Anything).Return(nil)
+
+	output, err := NewSweepWaitlistsUseCase(m.waitlist, serve).Execute(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, &SweepWaitlistsOutput{Expired: 2, Products: 2, Fulfilled: 1}, output)
+}
+
+func TestStockFreeingUseCases_WithWaitlist(t *testing.T) {
+	t.Run("should serve the waitlist of a released product", func(t *testing.T) {
+		inventoryRepo, reservationRepo, publisher := new(MockInventoryRepository), new(MockReservationRepository), new(MockPublisher)
+		notifier := &recordingNotifier{}
+		item, _ := entity.NewInventoryItem(uuid.New(), 100)
+		require.NoError(t, item.Reserve(10))
+		reservation, _ := entity.NewReservation(item.ID, uuid.New(), 10)
+		reservationRepo.On("FindByID", mock.Anything, reservation.ID).Return(reservation, nil)
+		inventoryRepo.On("FindByID", mock.Anything, item.ID).Return(item, nil)
+		inventoryRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+		reservationRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+		publisher.On("PublishStockReleased", mock.Anything, mock.Anything).Return(nil)
+
+		uc := NewReleaseReservationUseCase(inventoryRepo, reservationRepo, publisher).WithWaitlist(notifier)
+		_, err := uc.Execute(context.Background(), ReleaseReservationInput{ReservationID: reservation.ID})
+
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{item.ProductID}, notifier.productIDs)
+	})
+
+	t.Run("should serve the waitlist of a product an expired reservation returns stock to", func(t *testing.T) {
+		inventoryRepo, reservationRepo, publisher := new(MockInventoryRepository), new(MockReservationRepository), new(MockPublisher)
+		notifier := &recordingNotifier{}
+		item, _ := entity.NewInventoryItem(uuid.New(), 100)
+		require.NoError(t, item.Reserve(10))
+		reservation, _ := entity.NewReservation(item.ID, uuid.New(), 10)
+		reservation.ExpiresAt = time.Now().Add(-time.Minute)
+		reservationRepo.On("FindExpired", mock.Anything, mock.Anything).Return([]*entity.Reservation{reservation}, nil)
+		inventoryRepo.On("FindByID", mock.Anything, item.ID).Return(item, nil)
+		inventoryRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+		reservationRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+		publisher.On("PublishStockReleased", mock.Anything, mock.Anything).Return(nil)
+
+		uc := NewReleaseExpiredReservationsUseCase(inventoryRepo, reservationRepo, publisher).WithWaitlist(notifier)
+		_, err := uc.Execute(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{item.ProductID}, notifier.productIDs)
+	})
+
+	t.Run("should serve the waitlists of products an import raised", func(t *testing.T) {
+		inventoryRepo, bulkRepo := new(MockInventoryRepository), new(MockBulkStockRepository)
+		notifier := &recordingNotifier{}
+		raised, lowered := uuid.New(), uuid.New()
+		inventoryRepo.On("FindByProductIDs", mock.Anything, mock.Anything).Return(map[uuid.UUID]*entity.InventoryItem{
+			raised:  stockItem(raised, 10, 0),
+			lowered: stockItem(lowered, 10, 0),
+		}, nil)
+		bulkRepo.On("UpdateQuantities", mock.Anything, mock.Anything).Return(nil)
+		rows := []StockImportRow{
+			{Line: 1, ProductID: raised, Delta: intPtr(5)},
+			{Line: 2, ProductID: lowered, Quantity: intPtr(4)},
+		}
+
+		uc := NewImportStockUseCase(inventoryRepo, bulkRepo).WithWaitlist(notifier)
+		_, err := uc.Execute(context.Background(), ImportStockInput{Rows: rows})
+		require.NoError(t, err)
+		_, err = uc.Execute(context.Background(), ImportStockInput{Rows: rows[:1], DryRun: true})
+		require.NoError(t, err)
+
+		assert.Equal(t, []uuid.UUID{raised}, notifier.productIDs)
+	})
+
+	t.Run("should serve the waitlist of a product receiving a lot", func(t *testing.T) {
+		inventoryRepo, lotRepo := new(MockInventoryRepository), new(MockLotRepository)
+		notifier := &recordingNotifier{}
+		item, _ := entity.NewInventoryItem(uuid.New(), 0)
+		inventoryRepo.On("FindByProductID", mock.Anything, item.ProductID).Return(item, nil)
+		lotRepo.On("Receive", mock.Anything, mock.Anything).Return(item, nil)
+
+		uc := NewReceiveLotUseCase(inventoryRepo, lotRepo).WithWaitlist(notifier)
+		_, err := uc.Execute(context.Background(), ReceiveLotInput{ProductID: item.ProductID, LotNumber: "L-1", Quantity: 5})
+
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{item.ProductID}, notifier.productIDs)
+	})
+}
@@ -0,0 +1,92 @@
+package entity
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+	"github.com/google/uuid"
+)
+
+// AdminAuditOutcome represents the result of an administrative operation
+type AdminAuditOutcome string
+
+const (
+	// AdminAuditSuccess indicates the operation completed (2xx/3xx)
+	AdminAuditSuccess AdminAuditOutcome = "success"
+	// AdminAuditFailure indicates the operation was attempted but failed (4xx/5xx)
+	AdminAuditFailure AdminAuditOutcome = "failure"
+	// AdminAuditDenied indicates the caller was authenticated but lacked permission (403)
+	AdminAuditDenied AdminAuditOutcome = "denied"
+)
+
+// AnonymousActor is recorded when an admin operation runs without service authentication
+const AnonymousActor = "anonymous"
+
+// AdminAuditEntry records who triggered an administrative mutation and what it changed.
+// Entries are append-only.
+type AdminAuditEntry struct {
+	ID             uuid.UUID         `json:"id"`
+	Actor          string            `json:"actor"`
+	KeyID          string            `json:"key_id,omitempty"`
+	TokenID        string            `json:"token_id,omitempty"`
+	Method         string            `json:"method"`
+	Route          string            `json:"route"`
+	Path           string            `json:"path"`
+	Parameters     map[string]string `json:"parameters,omitempty"`
+	Outcome        AdminAuditOutcome `json:"outcome"`
+	StatusCode     int               `json:"status_code"`
+	AffectedIDs    []string          `json:"affected_ids,omitempty"`
+	ErrorMessage   string            `json:"error_message,omitempty"`
+	RemoteAddr     string            `json:"remote_addr,omitempty"`
+	DurationMillis int64             `json:"duration_ms"`
+	CreatedAt      time.Time         `json:"created_at"`
+}
+
+// NewAdminAuditEntry creates an audit entry for an admin operation.
+// An empty actor is recorded as AnonymousActor.
+// Returns an error if the method or route is missing.
+func NewAdminAuditEntry(actor, method, route string) (*AdminAuditEntry, error) {
+	if method == "" || route == "" {
+		return nil, errors.ErrInvalidInput.WithDetails("method and route are required")
+	}
+	if actor == "" {
+		actor = AnonymousActor
+	}
+
+	return &AdminAuditEntry{
+		ID:        uuid.New(),
+		Actor:     actor,
+		Method:    method,
+		Route:     route,
+		CreatedAt: time.Now().UTC(),
+	}, nil
+}
+
+// Complete sets the outcome of the operation from its HTTP status code
+func (e *AdminAuditEntry) Complete(statusCode int, duration time.Duration) {
+	e.StatusCode = statusCode
+	e.DurationMillis = duration.Milliseconds()
+	e.Outcome = AdminAuditOutcomeForStatus(statusCode)
+}
+
+// AdminAuditOutcomeForStatus maps an HTTP status code to an audit outcome
+func AdminAuditOutcomeForStatus(statusCode int) AdminAuditOutcome {
+	switch {
+	case statusCode == http.StatusForbidden || statusCode == http.StatusUnauthorized:
+		return AdminAuditDenied
+	case statusCode >= http.StatusBadRequest:
+		return AdminAuditFailure
+	default:
+		return AdminAuditSuccess
+	}
+}
+
+// IsValidAdminAuditOutcome reports whether the outcome is a known value
+func IsValidAdminAuditOutcome(outcome AdminAuditOutcome) bool {
+	switch outcome {
+	case AdminAuditSuccess, AdminAuditFailure, AdminAuditDenied:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,74 @@
+package entity
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAdminAuditEntry(t *testing.T) {
+	t.Run("should create entry for actor and route", func(t *testing.T) {
+		entry, err := NewAdminAuditEntry("ops-console", http.MethodPost, "/admin/dlq/:id/retry")
+
+		require.NoError(t, err)
+		assert.NotEqual(t, uuid.Nil, entry.ID)
+		assert.Equal(t, "ops-console", entry.Actor)
+		assert.Equal(t, http.MethodPost, entry.Method)
+		assert.Equal(t, "/admin/dlq/:id/retry", entry.Route)
+		assert.False(t, entry.CreatedAt.IsZero())
+	})
+
+	t.Run("should record anonymous actor when authentication is disabled", func(t *testing.T) {
+		entry, err := NewAdminAuditEntry("", http.MethodPost, "/admin/reservations/release-expired")
+
+		require.NoError(t, err)
+		assert.Equal(t, AnonymousActor, entry.Actor)
+	})
+
+	t.Run("should reject missing method or route", func(t *testing.T) {
+		_, err := NewAdminAuditEntry("ops-console", "", "/admin/dlq")
+		assert.ErrorIs(t, err, errors.ErrInvalidInput)
+
+		_, err = NewAdminAuditEntry("ops-console", http.MethodPost, "")
+		assert.ErrorIs(t, err, errors.ErrInvalidInput)
+	})
+}
+
+func TestAdminAuditEntry_Complete(t *testing.T) {
+	tests := []struct {
+		statusCode int
+		expected   AdminAuditOutcome
+	}{
+		{http.StatusOK, AdminAuditSuccess},
+		{http.StatusAccepted, AdminAuditSuccess},
+		{http.StatusBadRequest, AdminAuditFailure},
+		{http.StatusInternalServerError, AdminAuditFailure},
+		{http.StatusUnauthorized, AdminAuditDenied},
+		{http.StatusForbidden, AdminAuditDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.statusCode), func(t *testing.T) {
+			entry, err := NewAdminAuditEntry("ops-console", http.MethodPost, "/admin/dlq/:id/retry")
+			require.NoError(t, err)
+
+			entry.Complete(tt.statusCode, 250*time.Millisecond)
+
+			assert.Equal(t, tt.expected, entry.Outcome)
+			assert.Equal(t, tt.statusCode, entry.StatusCode)
+			assert.Equal(t, int64(250), entry.DurationMillis)
+			assert.True(t, IsValidAdminAuditOutcome(entry.Outcome))
+		})
+	}
+}
+
+func TestIsValidAdminAuditOutcome(t *testing.T) {
+	assert.True(t, IsValidAdminAuditOutcome(AdminAuditDenied))
+	assert.False(t, IsValidAdminAuditOutcome(""))
+	assert.False(t, IsValidAdminAuditOutcome("maybe"))
+}
@@ -0,0 +1,28 @@
+package entity
+
+import "time"
+
+// TerminalReservationStatuses lists the statuses a reservation never leaves.
+// Only reservations in these statuses are moved to the archive.
+var TerminalReservationStatuses = []ReservationStatus{
+	ReservationConfirmed,
+	ReservationReleased,
+	ReservationExpired,
+}
+
+// IsTerminalReservationStatus returns true if a reservation in this status can no longer change
+func IsTerminalReservationStatus(status ReservationStatus) bool {
+	for _, terminal := range TerminalReservationStatuses {
+		if status == terminal {
+			return true
+		}
+	}
+	return false
+}
+
+// ArchivedReservation is a terminal reservation moved out of the active
+// reservations by the retention job. It is read-only history.
+type ArchivedReservation struct {
+	Reservation
+	ArchivedAt time.Time `json:"archived_at"`
+}
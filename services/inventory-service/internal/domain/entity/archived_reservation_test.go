@@ -0,0 +1,15 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsTerminalReservationStatus(t *testing.T) {
+	assert.False(t, IsTerminalReservationStatus(ReservationPending))
+	assert.True(t, IsTerminalReservationStatus(ReservationConfirmed))
+	assert.True(t, IsTerminalReservationStatus(ReservationReleased))
+	assert.True(t, IsTerminalReservationStatus(ReservationExpired))
+	assert.False(t, IsTerminalReservationStatus("cancelled"))
+}
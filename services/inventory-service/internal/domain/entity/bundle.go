@@ -0,0 +1,148 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+	"github.com/google/uuid"
+)
+
+// MaxBundleComponents caps the products a bundle is made of
+const MaxBundleComponents = 50
+
+// BundleComponent is a product that goes into every unit of a bundle
+type BundleComponent struct {
+	InventoryItemID uuid.UUID `json:"inventory_item_id"`
+	ProductID       uuid.UUID `json:"product_id"`
+	Quantity        int       `json:"quantity"` // units per bundle
+}
+
+// NewBundleComponent makes the item a component of a bundle, quantity units per bundle.
+// Returns an error if:
+// - quantity is negative or zero
+// - the item is a bundle itself (bundles do not nest)
+// - the item is serial-tracked
+func NewBundleComponent(item *InventoryItem, quantity int) (BundleComponent, error) {
+	if quantity <= 0 {
+		return BundleComponent{}, errors.ErrInvalidQuantity
+	}
+
+	if item.Bundle {
+		return BundleComponent{}, errors.ErrInvalidBundle.WithDetails(
+			fmt.Sprintf("component %s is a bundle", item.ProductID))
+	}
+
+	if item.SerialTracked {
+		return BundleComponent{}, errors.ErrInvalidBundle.WithDetails(
+			fmt.Sprintf("component %s is serial-tracked", item.ProductID))
+	}
+
+	return BundleComponent{
+		InventoryItemID: item.ID,
+		ProductID:       item.ProductID,
+		Quantity:        quantity,
+	}, nil
+}
+
+// BundlesAvailable returns how many bundles the available units of the
+// component's item make up. Items whose units cannot be reserved count as empty.
+func (c BundleComponent) BundlesAvailable(item *InventoryItem) int {
+	if item.IsArchived() || item.checkCountedStock() != nil || item.Available() <= 0 {
+		return 0
+	}
+	return item.Available() / c.Quantity
+}
+
+// BundlesInStock returns how many bundles the units in stock of the component's
+// item make up, reserved or not
+func (c BundleComponent) BundlesInStock(item *InventoryItem) int {
+	if item.checkCountedStock() != nil || item.Quantity <= 0 {
+		return 0
+	}
+	return item.Quantity / c.Quantity
+}
+
+// Bundle is a product sold as a fixed set of other products. Its inventory item
+// holds no stock: a bundle is available as long as every component is, and a
+// reservation of a bundle reserves the units of every component.
+type Bundle struct {
+	InventoryItemID uuid.UUID         `json:"inventory_item_id"`
+	ProductID       uuid.UUID         `json:"product_id"`
+	Components      []BundleComponent `json:"components"`
+}
+
+// NewBundle defines the item as a bundle of the components.
+// Returns ErrInvalidBundle if:
+// - there are no components or more than MaxBundleComponents
+// - a product is listed twice
+// - the bundle lists itself
+func NewBundle(item *InventoryItem, components []BundleComponent) (*Bundle, error) {
+	if len(components) == 0 {
+		return nil, errors.ErrInvalidBundle.WithDetails("a bundle needs at least one component")
+	}
+	if len(components) > MaxBundleComponents {
+		return nil, errors.ErrInvalidBundle.WithDetails(
+			fmt.Sprintf("a bundle has at most %d components", MaxBundleComponents))
+	}
+
+	seen := make(map[uuid.UUID]bool, len(components))
+	for _, component := range components {
+		if component.InventoryItemID == item.ID {
+			return nil, errors.ErrInvalidBundle.WithDetails("a bundle cannot contain itself")
+		}
+		if seen[component.InventoryItemID] {
+			return nil, errors.ErrInvalidBundle.WithDetails(
+				fmt.Sprintf("component %s is listed twice", component.ProductID))
+		}
+		seen[component.InventoryItemID] = true
+	}
+
+	return &Bundle{
+		InventoryItemID: item.ID,
+		ProductID:       item.ProductID,
+		Components:      components,
+	}, nil
+}
+
+// Reserve returns the components a reservation of quantity bundles holds
+func (b *Bundle) Reserve(reservationID uuid.UUID, quantity int, at time.Time) []*ReservationComponent {
+	reserved := make([]*ReservationComponent, len(b.Components))
+	for i, component := range b.Components {
+		reserved[i] = &ReservationComponent{
+			ReservationID:   reservationID,
+			BundleItemID:    b.InventoryItemID,
+			InventoryItemID: component.InventoryItemID,
+			ProductID:       component.ProductID,
+			Quantity:        component.Quantity * quantity,
+			Status:          ComponentReserved,
+			CreatedAt:       at,
+			UpdatedAt:       at,
+		}
+	}
+	return reserved
+}
+
+// ReservationComponentStatus represents the status of the units of a component held by a bundle reservation
+type ReservationComponentStatus string
+
+const (
+	// ComponentReserved indicates the units are held for a pending reservation
+	ComponentReserved ReservationComponentStatus = "reserved"
+	// ComponentConfirmed indicates the reservation was confirmed and the units left the stock
+	ComponentConfirmed ReservationComponentStatus = "confirmed"
+	// ComponentReleased indicates the reservation was released or expired and the units returned to the stock
+	ComponentReleased ReservationComponentStatus = "released"
+)
+
+// ReservationComponent records the units of a component taken by a bundle reservation
+type ReservationComponent struct {
+	ReservationID   uuid.UUID                  `json:"reservation_id"`
+	BundleItemID    uuid.UUID                  `json:"bundle_item_id"`
+	InventoryItemID uuid.UUID                  `json:"inventory_item_id"`
+	ProductID       uuid.UUID                  `json:"product_id"`
+	Quantity        int                        `json:"quantity"` // units for the whole reservation
+	Status          ReservationComponentStatus `json:"status"`
+	CreatedAt       time.Time                  `json:"created_at"`
+	UpdatedAt       time.Time                  `json:"updated_at"`
+}
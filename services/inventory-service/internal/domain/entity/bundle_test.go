@@ -0,0 +1,115 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+)
+
+func newComponentItem(t *testing.T, quantity, reserved int) *InventoryItem {
+	item, err := NewInventoryItem(uuid.New(), quantity)
+	require.NoError(t, err)
+	item.Reserved = reserved
+	return item
+}
+
+func TestNewBundleComponent(t *testing.T) {
+	t.Run("should create a component", func(t *testing.T) {
+		item := newComponentItem(t, 10, 0)
+
+		component, err := NewBundleComponent(item, 2)
+
+		require.NoError(t, err)
+		assert.Equal(t, item.ID, component.InventoryItemID)
+		assert.Equal(t, item.ProductID, component.ProductID)
+		assert.Equal(t, 2, component.Quantity)
+	})
+
+	t.Run("should reject invalid quantities", func(t *testing.T) {
+		_, err := NewBundleComponent(newComponentItem(t, 10, 0), 0)
+
+		assert.ErrorIs(t, err, errors.ErrInvalidQuantity)
+	})
+
+	t.Run("should reject bundles and serial-tracked items", func(t *testing.T) {
+		bundle := newComponentItem(t, 0, 0)
+		require.NoError(t, bundle.MakeBundle())
+		_, err := NewBundleComponent(bundle, 1)
+		assert.ErrorIs(t, err, errors.ErrInvalidBundle)
+
+		tracked := newComponentItem(t, 0, 0)
+		require.NoError(t, tracked.EnableSerialTracking())
+		_, err = NewBundleComponent(tracked, 1)
+		assert.ErrorIs(t, err, errors.ErrInvalidBundle)
+	})
+}
+
+func TestBundleComponent_Bundles(t *testing.T) {
+	item := newComponentItem(t, 11, 4)
+	component := BundleComponent{InventoryItemID: item.ID, ProductID: item.ProductID, Quantity: 3}
+
+	assert.Equal(t, 2, component.BundlesAvailable(item), "7 available units make 2 bundles of 3")
+	assert.Equal(t, 3, component.BundlesInStock(item))
+
+	item.Archive(time.Now())
+	assert.Equal(t, 0, component.BundlesAvailable(item), "archived items cannot be reserved")
+	assert.Equal(t, 3, component.BundlesInStock(item))
+}
+
+func TestNewBundle(t *testing.T) {
+	item := newComponentItem(t, 0, 0)
+	first := newComponentItem(t, 10, 0)
+	second := newComponentItem(t, 10, 0)
+	firstComponent, err := NewBundleComponent(first, 1)
+	require.NoError(t, err)
+	secondComponent, err := NewBundleComponent(second, 2)
+	require.NoError(t, err)
+
+	t.Run("should create a bundle", func(t *testing.T) {
+		bundle, err := NewBundle(item, []BundleComponent{firstComponent, secondComponent})
+
+		require.NoError(t, err)
+		assert.Equal(t, item.ID, bundle.InventoryItemID)
+		assert.Equal(t, item.ProductID, bundle.ProductID)
+		assert.Len(t, bundle.Components, 2)
+	})
+
+	t.Run("should reject invalid definitions", func(t *testing.T) {
+		itself := BundleComponent{InventoryItemID: item.ID, ProductID: item.ProductID, Quantity: 1}
+		tooMany := make([]BundleComponent, MaxBundleComponents+1)
+
+		for name, components := range map[string][]BundleComponent{
+			"empty":     nil,
+			"too many":  tooMany,
+			"duplicate": {firstComponent, firstComponent},
+			"itself":    {firstComponent, itself},
+		} {
+			_, err := NewBundle(item, components)
+			assert.ErrorIs(t, err, errors.ErrInvalidBundle, name)
+		}
+	})
+
+	t.Run("should reserve every component", func(t *testing.T) {
+		bundle, err := NewBundle(item, []BundleComponent{firstComponent, secondComponent})
+		require.NoError(t, err)
+		reservationID := uuid.New()
+		at := time.Now()
+
+		reserved := bundle.Reserve(reservationID, 3, at)
+
+		require.Len(t, reserved, 2)
+		assert.Equal(t, reservationID, reserved[0].ReservationID)
+		assert.Equal(t, item.ID, reserved[0].BundleItemID)
+		assert.Equal(t, first.ID, reserved[0].InventoryItemID)
+		assert.Equal(t, 3, reserved[0].Quantity)
+		assert.Equal(t, second.ProductID, reserved[1].ProductID)
+		assert.Equal(t, 6, reserved[1].Quantity)
+		assert.Equal(t, ComponentReserved, reserved[1].Status)
+		assert.Equal(t, at, reserved[1].CreatedAt)
+	})
+}
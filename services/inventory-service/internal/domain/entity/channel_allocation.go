@@ -0,0 +1,343 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+	"github.com/google/uuid"
+)
+
+// MaxChannelLength caps the length of a sales channel name
+const MaxChannelLength = 32
+
+// MaxChannelAllocations caps the sales channels an inventory item is allocated to
+const MaxChannelAllocations = 20
+
+// SharedPool names the stock not set aside for any channel in allocation reports
+// and rebalances. Reservations without a channel are made from it.
+const SharedPool = ""
+
+// ValidateChannel checks a sales channel name: 1 to MaxChannelLength lowercase
+// letters, digits, '-' or '_'
+func ValidateChannel(channel string) error {
+	if channel == "" {
+		return errors.ErrInvalidChannel.WithDetails("channel is required")
+	}
+	if len(channel) > MaxChannelLength {
+		return errors.ErrInvalidChannel.WithDetails(
+			fmt.Sprintf("channel must be at most %d characters", MaxChannelLength))
+	}
+	for _, r := range channel {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' && r != '_' {
+			return errors.ErrInvalidChannel.WithDetails(
+				fmt.Sprintf("channel %q may only contain lowercase letters, digits, '-' and '_'", channel))
+		}
+	}
+	return nil
+}
+
+// AllocationMode is how stock is set aside for a sales channel
+type AllocationMode string
+
+const (
+	// AllocationFixed sets aside a fixed number of units
+	AllocationFixed AllocationMode = "fixed"
+	// AllocationPercentage sets aside a share of the units in stock
+	AllocationPercentage AllocationMode = "percentage"
+	// AllocationShared sets nothing aside: the channel sells from the shared pool
+	AllocationShared AllocationMode = "shared"
+)
+
+// ParseAllocationMode converts a string into an AllocationMode
+func ParseAllocationMode(mode string) (AllocationMode, error) {
+	switch AllocationMode(mode) {
+	case AllocationFixed, AllocationPercentage, AllocationShared:
+		return AllocationMode(mode), nil
+	default:
+		return "", errors.ErrInvalidAllocation.WithDetails(
+			fmt.Sprintf("mode must be fixed, percentage or shared, got %q", mode))
+	}
+}
+
+// ChannelAllocation sets aside stock of an inventory item for a sales channel.
+// Units set aside for a channel are only reserved by that channel; the rest of
+// the stock is the shared pool, reserved by shared channels and reservations
+// without a channel. A channel with an allocation cannot reserve more than it
+// was allocated.
+type ChannelAllocation struct {
+	InventoryItemID uuid.UUID      `json:"inventory_item_id"`
+	Channel         string         `json:"channel"`
+	Mode            AllocationMode `json:"mode"`
+	Quota           int            `json:"quota,omitempty"`   // units, fixed allocations only
+	Percent         int            `json:"percent,omitempty"` // share of the units in stock, percentage allocations only
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+}
+
+// NewChannelAllocation allocates stock of the item to the channel. value is the
+// quota of fixed allocations and the percentage of percentage allocations, and
+// must be zero for shared ones.
+// Returns ErrInvalidChannel for invalid channel names and ErrInvalidAllocation if:
+// - the value is out of range for the mode
+// - the item is serial-tracked or a bundle
+func NewChannelAllocation(item *InventoryItem, channel string, mode AllocationMode, value int) (ChannelAllocation, error) {
+	if err := ValidateChannel(channel); err != nil {
+		return ChannelAllocation{}, err
+	}
+
+	if item.SerialTracked || item.Bundle {
+		return ChannelAllocation{}, errors.ErrInvalidAllocation.WithDetails(
+			"only stock counted per unit can be allocated to channels")
+	}
+
+	now := time.Now()
+	allocation := ChannelAllocation{
+		InventoryItemID: item.ID,
+		Channel:         channel,
+		Mode:            mode,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	switch mode {
+	case AllocationFixed:
+		if value < 0 {
+			return ChannelAllocation{}, errors.ErrInvalidAllocation.WithDetails("quota must not be negative")
+		}
+		allocation.Quota = value
+	case AllocationPercentage:
+		if value <= 0 || value > 100 {
+			return ChannelAllocation{}, errors.ErrInvalidAllocation.WithDetails("percent must be between 1 and 100")
+		}
+		allocation.Percent = value
+	case AllocationShared:
+		if value != 0 {
+			return ChannelAllocation{}, errors.ErrInvalidAllocation.WithDetails("shared allocations take no value")
+		}
+	default:
+		_, err := ParseAllocationMode(string(mode))
+		return ChannelAllocation{}, err
+	}
+	return allocation, nil
+}
+
+// Dedicated reports whether units are set aside for the channel
+func (a ChannelAllocation) Dedicated() bool {
+	return a.Mode != AllocationShared
+}
+
+// Allocated returns the units of the item set aside for the channel
+func (a ChannelAllocation) Allocated(item *InventoryItem) int {
+	switch a.Mode {
+	case AllocationFixed:
+		return a.Quota
+	case AllocationPercentage:
+		return max(item.Quantity, 0) * a.Percent / 100
+	default:
+		return 0
+	}
+}
+
+// ValidateChannelAllocations checks the allocations of an inventory item as a whole.
+// Returns ErrInvalidAllocation if:
+// - there are more than MaxChannelAllocations
+// - a channel is listed twice
+// - the percentages add up to more than 100
+func ValidateChannelAllocations(allocations []ChannelAllocation) error {
+	if len(allocations) > MaxChannelAllocations {
+		return errors.ErrInvalidAllocation.WithDetails(
+			fmt.Sprintf("at most %d channels can be allocated", MaxChannelAllocations))
+	}
+
+	seen := make(map[string]bool, len(allocations))
+	percent := 0
+	for _, allocation := range allocations {
+		if seen[allocation.Channel] {
+			return errors.ErrInvalidAllocation.WithDetails(
+				fmt.Sprintf("channel %s is listed twice", allocation.Channel))
+		}
+		seen[allocation.Channel] = true
+		percent += allocation.Percent
+	}
+
+	if percent > 100 {
+		return errors.ErrInvalidAllocation.WithDetails(
+			fmt.Sprintf("percentages add up to %d", percent))
+	}
+	return nil
+}
+
+// ChannelStock reports the stock of an inventory item a sales channel can sell
+type ChannelStock struct {
+	Channel string         `json:"channel"`
+	Mode    AllocationMode `json:"mode"`
+	// Allocated is the units set aside for the channel; for the shared pool and
+	// shared channels, the units not set aside for any channel
+	Allocated int `json:"allocated"`
+	// Reserved is the units held by the channel's pending reservations
+	Reserved int `json:"reserved"`
+	// Available is the units the channel can reserve
+	Available int `json:"available"`
+}
+
+// ChannelStocks computes the stock of every allocated channel and of the shared
+// pool, given the units reserved per channel. Units set aside for a channel and
+// not reserved by it are held back from the shared pool; no channel can reserve
+// more than the item has available. Archived items have nothing available.
+func ChannelStocks(item *InventoryItem, allocations []ChannelAllocation, reserved map[string]int) ([]ChannelStock, ChannelStock) {
+	available := max(item.Available(), 0)
+	if item.IsArchived() {
+		available = 0
+	}
+
+	pool := ChannelStock{Channel: SharedPool, Mode: AllocationShared, Allocated: max(item.Quantity, 0), Reserved: item.Reserved}
+	heldBack := 0
+	for _, allocation := range allocations {
+		if !allocation.Dedicated() {
+			continue
+		}
+		allocated := allocation.Allocated(item)
+		heldBack += max(allocated-reserved[allocation.Channel], 0)
+		pool.Allocated -= allocated
+		pool.Reserved -= reserved[allocation.Channel]
+	}
+	pool.Allocated = max(pool.Allocated, 0)
+	pool.Reserved = max(pool.Reserved, 0)
+	pool.Available = max(available-heldBack, 0)
+
+	stocks := make([]ChannelStock, len(allocations))
+	for i, allocation := range allocations {
+		stock := ChannelStock{
+			Channel:   allocation.Channel,
+			Mode:      allocation.Mode,
+			Allocated: pool.Allocated,
+			Reserved:  reserved[allocation.Channel],
+			Available: pool.Available,
+		}
+		if allocation.Dedicated() {
+			stock.Allocated = allocation.Allocated(item)
+			stock.Available = min(max(stock.Allocated-stock.Reserved, 0), available)
+		}
+		stocks[i] = stock
+	}
+	return stocks, pool
+}
+
+// ChannelAvailable returns the units of the item the channel can reserve. Channels
+// without an allocation, and reservations without a channel, reserve from the
+// shared pool.
+func ChannelAvailable(item *InventoryItem, allocations []ChannelAllocation, reserved map[string]int, channel string) int {
+	stocks, pool := ChannelStocks(item, allocations, reserved)
+	for _, stock := range stocks {
+		if stock.Channel == channel {
+			return stock.Available
+		}
+	}
+	return pool.Available
+}
+
+// ReserveForChannel reserves quantity units of an item with channel allocations,
+// given the units the channel can reserve (see ChannelAvailable).
+// Returns an error if:
+// - quantity is negative or zero
+// - the item is serial-tracked or a bundle
+// - the item is archived
+// - the channel has less than quantity available
+func (i *InventoryItem) ReserveForChannel(quantity, available int) error {
+	if quantity <= 0 {
+		return errors.ErrInvalidQuantity
+	}
+
+	if err := i.checkCountedStock(); err != nil {
+		return err
+	}
+
+	if i.IsArchived() {
+		return errors.ErrInventoryItemArchived
+	}
+
+	if quantity > available {
+		return errors.ErrInsufficientStock
+	}
+
+	i.Reserved += quantity
+	i.UpdatedAt = time.Now()
+	return nil
+}
+
+// MoveAllocation moves quantity units of quota, or percentage points, from one
+// channel to another and returns the allocations afterwards. Either channel may
+// be the SharedPool, to grow or shrink the other one.
+// Returns ErrInvalidAllocation if:
+// - quantity is not positive or both channels are the same
+// - a channel has no fixed or percentage allocation, or they differ in mode
+// - the source channel has less than quantity allocated
+// - the percentages would add up to more than 100
+func MoveAllocation(allocations []ChannelAllocation, from, to string, quantity int, at time.Time) ([]ChannelAllocation, error) {
+	if quantity <= 0 {
+		return nil, errors.ErrInvalidAllocation.WithDetails("quantity must be greater than zero")
+	}
+	if from == to {
+		return nil, errors.ErrInvalidAllocation.WithDetails("from and to must be different channels")
+	}
+
+	moved := make([]ChannelAllocation, len(allocations))
+	copy(moved, allocations)
+
+	var mode AllocationMode
+	find := func(channel string) (*ChannelAllocation, error) {
+		if channel == SharedPool {
+			return nil, nil
+		}
+		for i := range moved {
+			if moved[i].Channel != channel {
+				continue
+			}
+			if !moved[i].Dedicated() {
+				return nil, errors.ErrInvalidAllocation.WithDetails(
+					fmt.Sprintf("channel %s sells from the shared pool", channel))
+			}
+			if mode != "" && moved[i].Mode != mode {
+				return nil, errors.ErrInvalidAllocation.WithDetails("allocations can only move between channels of the same mode")
+			}
+			mode = moved[i].Mode
+			return &moved[i], nil
+		}
+		return nil, errors.ErrInvalidAllocation.WithDetails(fmt.Sprintf("channel %s has no allocation", channel))
+	}
+
+	source, err := find(from)
+	if err != nil {
+		return nil, err
+	}
+	target, err := find(to)
+	if err != nil {
+		return nil, err
+	}
+
+	if source != nil {
+		value := &source.Quota
+		if source.Mode == AllocationPercentage {
+			value = &source.Percent
+		}
+		if *value < quantity {
+			return nil, errors.ErrInvalidAllocation.WithDetails(
+				fmt.Sprintf("channel %s has only %d allocated", from, *value))
+		}
+		*value -= quantity
+		source.UpdatedAt = at
+	}
+	if target != nil {
+		if target.Mode == AllocationPercentage {
+			target.Percent += quantity
+		} else {
+			target.Quota += quantity
+		}
+		target.UpdatedAt = at
+	}
+
+	if err := ValidateChannelAllocations(moved); err != nil {
+		return nil, err
+	}
+	return moved, nil
+}
@@ -0,0 +1,219 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+)
+
+func TestValidateChannel(t *testing.T) {
+	for _, channel := range []string{"web", "b2b", "amazon-eu", "market_place2", strings.Repeat("a", MaxChannelLength)} {
+		assert.NoError(t, ValidateChannel(channel), channel)
+	}
+	for _, channel := range []string{"", "Web", "web store", "web/eu", strings.Repeat("a", MaxChannelLength+1)} {
+		assert.ErrorIs(t, ValidateChannel(channel), errors.ErrInvalidChannel, channel)
+	}
+}
+
+func TestParseAllocationMode(t *testing.T) {
+	mode, err := ParseAllocationMode("percentage")
+	require.NoError(t, err)
+	assert.Equal(t, AllocationPercentage, mode)
+
+	_, err = ParseAllocationMode("quota")
+	assert.ErrorIs(t, err, errors.ErrInvalidAllocation)
+}
+
+func TestNewChannelAllocation(t *testing.T) {
+	item, err := NewInventoryItem(uuid.New(), 100)
+	require.NoError(t, err)
+
+	t.Run("should create allocations of every mode", func(t *testing.T) {
+		fixed, err := NewChannelAllocation(item, "marketplace", AllocationFixed, 20)
+		require.NoError(t, err)
+		assert.Equal(t, item.ID, fixed.InventoryItemID)
+		assert.Equal(t, 20, fixed.Quota)
+		assert.Equal(t, 20, fixed.Allocated(item))
+
+		percentage, err := NewChannelAllocation(item, "b2b", AllocationPercentage, 25)
+		require.NoError(t, err)
+		assert.Equal(t, 25, percentage.Allocated(item))
+
+		shared, err := NewChannelAllocation(item, "web", AllocationShared, 0)
+		require.NoError(t, err)
+		assert.False(t, shared.Dedicated())
+		assert.Zero(t, shared.Allocated(item))
+	})
+
+	t.Run("should reject values out of range", func(t *testing.T) {
+		tests := []struct {
+			mode  AllocationMode
+			value int
+		}{
+			{AllocationFixed, -1},
+			{AllocationPercentage, 0},
+			{AllocationPercentage, 101},
+			{AllocationShared, 5},
+			{AllocationMode("quota"), 5},
+		}
+		for _, tt := range tests {
+			_, err := NewChannelAllocation(item, "web", tt.mode, tt.value)
+			assert.ErrorIs(t, err, errors.ErrInvalidAllocation, "%s %d", tt.mode, tt.value)
+		}
+	})
+
+	t.Run("should reject invalid channels", func(t *testing.T) {
+		_, err := NewChannelAllocation(item, "Web", AllocationFixed, 1)
+		assert.ErrorIs(t, err, errors.ErrInvalidChannel)
+	})
+
+	t.Run("should reject items not counted per unit", func(t *testing.T) {
+		_, err := NewChannelAllocation(&InventoryItem{Bundle: true}, "web", AllocationFixed, 1)
+		assert.ErrorIs(t, err, errors.ErrInvalidAllocation)
+		_, err = NewChannelAllocation(&InventoryItem{SerialTracked: true}, "web", AllocationFixed, 1)
+		assert.ErrorIs(t, err, errors.ErrInvalidAllocation)
+	})
+}
+
+func TestValidateChannelAllocations(t *testing.T) {
+	assert.NoError(t, ValidateChannelAllocations(nil))
+	assert.NoError(t, ValidateChannelAllocations([]ChannelAllocation{
+		{Channel: "b2b", Mode: AllocationPercentage, Percent: 60},
+		{Channel: "marketplace", Mode: AllocationPercentage, Percent: 40},
+	}))
+
+	err := ValidateChannelAllocations([]ChannelAllocation{
+		{Channel: "b2b", Mode: AllocationPercentage, Percent: 60},
+		{Channel: "marketplace", Mode: AllocationPercentage, Percent: 41},
+	})
+	assert.ErrorIs(t, err, errors.ErrInvalidAllocation)
+
+	err = ValidateChannelAllocations([]ChannelAllocation{
+		{Channel: "web", Mode: AllocationShared},
+		{Channel: "web", Mode: AllocationFixed, Quota: 1},
+	})
+	assert.ErrorIs(t, err, errors.ErrInvalidAllocation)
+
+	tooMany := make([]ChannelAllocation, MaxChannelAllocations+1)
+	for i := range tooMany {
+		tooMany[i] = ChannelAllocation{Channel: strings.Repeat("c", i+1), Mode: AllocationShared}
+	}
+	assert.ErrorIs(t, ValidateChannelAllocations(tooMany), errors.ErrInvalidAllocation)
+}
+
+func TestChannelStocks(t *testing.T) {
+	item := &InventoryItem{Quantity: 100, Reserved: 30}
+	allocations := []ChannelAllocation{
+		{Channel: "marketplace", Mode: AllocationFixed, Quota: 20},
+		{Channel: "b2b", Mode: AllocationPercentage, Percent: 10},
+		{Channel: "web", Mode: AllocationShared},
+	}
+	// marketplace holds 15 of its 20, b2b 10 of its 10, the shared pool 5
+	reserved := map[string]int{"marketplace": 15, "b2b": 10, "web": 2, "": 3}
+
+	stocks, pool := ChannelStocks(item, allocations, reserved)
+
+	assert.Equal(t, []ChannelStock{
+		{Channel: "marketplace", Mode: AllocationFixed, Allocated: 20, Reserved: 15, Available: 5},
+		{Channel: "b2b", Mode: AllocationPercentage, Allocated: 10, Reserved: 10, Available: 0},
+		{Channel: "web", Mode: AllocationShared, Allocated: 70, Reserved: 2, Available: 65},
+	}, stocks)
+	assert.Equal(t, ChannelStock{Channel: SharedPool, Mode: AllocationShared, Allocated: 70, Reserved: 5, Available: 65}, pool)
+
+	assert.Equal(t, 5, ChannelAvailable(item, allocations, reserved, "marketplace"))
+	assert.Equal(t, 65, ChannelAvailable(item, allocations, reserved, "web"))
+	assert.Equal(t, 65, ChannelAvailable(item, allocations, reserved, "pos"), "channels without allocation use the shared pool")
+	assert.Equal(t, 65, ChannelAvailable(item, allocations, reserved, SharedPool))
+
+	t.Run("should not allocate more than is available", func(t *testing.T) {
+		short := &InventoryItem{Quantity: 12, Reserved: 10}
+
+		stocks, pool := ChannelStocks(short, allocations, map[string]int{"marketplace": 10})
+
+		assert.Equal(t, 2, stocks[0].Available)
+		assert.Equal(t, 0, pool.Available, "the units left are set aside for marketplace")
+	})
+
+	t.Run("should have nothing available when archived", func(t *testing.T) {
+		archived := &InventoryItem{Quantity: 100}
+		archived.Archive(time.Now())
+
+		stocks, pool := ChannelStocks(archived, allocations, nil)
+
+		assert.Zero(t, stocks[0].Available)
+		assert.Zero(t, pool.Available)
+	})
+}
+
+func TestInventoryItem_ReserveForChannel(t *testing.T) {
+	item := &InventoryItem{Quantity: 100, ChannelAllocated: true}
+
+	require.NoError(t, item.ReserveForChannel(5, 5))
+	assert.Equal(t, 5, item.Reserved)
+
+	assert.ErrorIs(t, item.ReserveForChannel(6, 5), errors.ErrInsufficientStock)
+	assert.ErrorIs(t, item.ReserveForChannel(0, 5), errors.ErrInvalidQuantity)
+	assert.ErrorIs(t, (&InventoryItem{Quantity: 10, Bundle: true}).ReserveForChannel(1, 10), errors.ErrBundleItem)
+
+	item.Archive(time.Now())
+	assert.ErrorIs(t, item.ReserveForChannel(1, 5), errors.ErrInventoryItemArchived)
+	assert.Equal(t, 5, item.Reserved)
+}
+
+func TestMoveAllocation(t *testing.T) {
+	at := time.Now()
+	allocations := []ChannelAllocation{
+		{Channel: "marketplace", Mode: AllocationFixed, Quota: 20},
+		{Channel: "wholesale", Mode: AllocationFixed, Quota: 5},
+		{Channel: "b2b", Mode: AllocationPercentage, Percent: 60},
+		{Channel: "web", Mode: AllocationShared},
+	}
+
+	t.Run("should move quota between channels", func(t *testing.T) {
+		moved, err := MoveAllocation(allocations, "marketplace", "wholesale", 15, at)
+
+		require.NoError(t, err)
+		assert.Equal(t, 5, moved[0].Quota)
+		assert.Equal(t, 20, moved[1].Quota)
+		assert.Equal(t, at, moved[1].UpdatedAt)
+		assert.Equal(t, 20, allocations[0].Quota, "the allocations passed in are left unchanged")
+	})
+
+	t.Run("should move to and from the shared pool", func(t *testing.T) {
+		moved, err := MoveAllocation(allocations, "marketplace", SharedPool, 20, at)
+		require.NoError(t, err)
+		assert.Equal(t, 0, moved[0].Quota)
+
+		moved, err = MoveAllocation(allocations, SharedPool, "b2b", 40, at)
+		require.NoError(t, err)
+		assert.Equal(t, 100, moved[2].Percent)
+	})
+
+	t.Run("should reject invalid moves", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			from, to string
+			quantity int
+		}{
+			{"zero quantity", "marketplace", "wholesale", 0},
+			{"same channel", "marketplace", "marketplace", 1},
+			{"more than allocated", "wholesale", "marketplace", 6},
+			{"different modes", "marketplace", "b2b", 1},
+			{"shared channel", "web", "marketplace", 1},
+			{"unknown channel", "pos", "marketplace", 1},
+			{"over 100 percent", SharedPool, "b2b", 41},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := MoveAllocation(allocations, tt.from, tt.to, tt.quantity, at)
+				assert.ErrorIs(t, err, errors.ErrInvalidAllocation)
+			})
+		}
+	})
+}
@@ -0,0 +1,74 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+)
+
+// DriftKind classifies an inconsistency found by inventory reconciliation
+type DriftKind string
+
+const (
+	// DriftReservedMismatch is an inventory item whose reserved counter differs
+	// from the sum of its pending reservations
+	DriftReservedMismatch DriftKind = "reserved_mismatch"
+	// DriftOrphanReservation is a pending reservation whose inventory item no longer exists
+	DriftOrphanReservation DriftKind = "orphan_reservation"
+	// DriftStuckReservation is a pending reservation the expiration scheduler never released
+	DriftStuckReservation DriftKind = "stuck_reservation"
+	// DriftMissingItem is an active catalog product without an inventory item
+	DriftMissingItem DriftKind = "missing_item"
+	// DriftStaleLotAllocation is a lot allocation still holding units of a
+	// reservation that was confirmed, released or expired
+	DriftStaleLotAllocation DriftKind = "stale_lot_allocation"
+	// DriftMissingLotAllocation is a pending reservation holding units of an item
+	// whose lots have free units, without any allocation on them
+	DriftMissingLotAllocation DriftKind = "missing_lot_allocation"
+)
+
+// DriftKinds lists every drift kind in the order reconciliation repairs them.
+// Reservation statuses are fixed before the reserved counters and lot
+// allocations they feed.
+var DriftKinds = []DriftKind{
+	DriftStuckReservation,
+	DriftOrphanReservation,
+	DriftStaleLotAllocation,
+	DriftMissingLotAllocation,
+	DriftMissingItem,
+	DriftReservedMismatch,
+}
+
+// InventoryDrift describes one inconsistency between inventory items,
+// reservations and the product catalog.
+// Recorded and Expected are the reserved quantities currently held and the
+// ones the data implies; for missing items Expected is the stock to create.
+type InventoryDrift struct {
+	Kind            DriftKind `json:"kind"`
+	InventoryItemID uuid.UUID `json:"inventory_item_id,omitzero"`
+	ProductID       uuid.UUID `json:"product_id,omitzero"`
+	ReservationID   uuid.UUID `json:"reservation_id,omitzero"`
+	OrderID         uuid.UUID `json:"order_id,omitzero"`
+	Recorded        int       `json:"recorded"`
+	Expected        int       `json:"expected"`
+	Detail          string    `json:"detail,omitempty"`
+}
+
+// EntityIDs returns the non-empty identifiers the drift refers to
+func (d *InventoryDrift) EntityIDs() []string {
+	ids := make([]string, 0, 4)
+	for _, id := range []uuid.UUID{d.InventoryItemID, d.ProductID, d.ReservationID, d.OrderID} {
+		if id != uuid.Nil {
+			ids = append(ids, id.String())
+		}
+	}
+	return ids
+}
+
+// IsValidDriftKind reports whether the kind is a known value
+func IsValidDriftKind(kind DriftKind) bool {
+	for _, known := range DriftKinds {
+		if kind == known {
+			return true
+		}
+	}
+	return false
+}
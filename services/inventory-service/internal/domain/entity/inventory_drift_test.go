@@ -0,0 +1,46 @@
+package entity
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInventoryDrift_EntityIDs(t *testing.T) {
+	itemID := uuid.New()
+	reservationID := uuid.New()
+
+	drift := &InventoryDrift{Kind: DriftStuckReservation, InventoryItemID: itemID, ReservationID: reservationID}
+
+	assert.Equal(t, []string{itemID.String(), reservationID.String()}, drift.EntityIDs())
+}
+
+func TestInventoryDrift_JSONOmitsMissingIDs(t *testing.T) {
+	productID := uuid.New()
+	drift := &InventoryDrift{Kind: DriftMissingItem, ProductID: productID, Expected: 10}
+
+	data, err := json.Marshal(drift)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{"kind":"missing_item","product_id":"`+productID.String()+`","recorded":0,"expected":10}`, string(data))
+}
+
+func TestIsValidDriftKind(t *testing.T) {
+	for _, kind := range DriftKinds {
+		assert.True(t, IsValidDriftKind(kind), kind)
+	}
+	assert.False(t, IsValidDriftKind("unknown"))
+}
+
+func TestDriftKinds_LotAllocationsAfterReservationStatuses(t *testing.T) {
+	index := make(map[DriftKind]int, len(DriftKinds))
+	for i, kind := range DriftKinds {
+		index[kind] = i
+	}
+
+	assert.Less(t, index[DriftStuckReservation], index[DriftStaleLotAllocation], "expiring a stuck reservation settles its lots")
+	assert.Less(t, index[DriftStaleLotAllocation], index[DriftMissingLotAllocation], "units of stale allocations can be allocated again")
+}
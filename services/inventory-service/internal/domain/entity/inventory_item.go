@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
@@ -10,6 +11,13 @@ import (
 // InventoryItem represents a product inventory record in the system.
 // It tracks the total quantity, reserved quantity, and computed available quantity.
 // Uses optimistic locking via Version field to handle concurrent updates safely.
+// The stock of a serial-tracked item is held as SerialUnit records instead, and
+// Quantity and Reserved are kept equal to the counts of its units by the serial
+// unit repository. A bundle has no stock of its own: its availability is derived
+// from its components, which the bundle repository reserves together. The stock
+// methods below reject both kinds of items. The stock of an item with channel
+// allocations is reserved per sales channel by the channel allocation repository,
+// so Reserve rejects it too.
 type InventoryItem struct {
 	ID        uuid.UUID `json:"id"`
 	ProductID uuid.UUID `json:"product_id"`
@@ -18,6 +26,16 @@ type InventoryItem struct {
 	Version   int       `json:"version"`  // Optimistic locking version
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+	// ArchivedAt is set when the product is deactivated in the catalog
+	ArchivedAt *time.Time `json:"archived_at,omitempty"`
+	// CatalogChangedAt is the catalog time of the latest product change applied to the item
+	CatalogChangedAt *time.Time `json:"catalog_changed_at,omitempty"`
+	// SerialTracked is set when every unit in stock is tracked by serial number
+	SerialTracked bool `json:"serial_tracked"`
+	// Bundle is set when the product is sold as a set of other products
+	Bundle bool `json:"bundle"`
+	// ChannelAllocated is set when the stock is allocated to sales channels
+	ChannelAllocated bool `json:"channel_allocated"`
 }
 
 // NewInventoryItem creates a new inventory item for a product with initial quantity.
@@ -54,6 +72,9 @@ func (i *InventoryItem) CanReserve(quantity int) bool {
 // Reserve reserves a quantity for a pending order.
 // Returns an error if:
 // - quantity is negative or zero
+// - the item is serial-tracked or a bundle
+// - the item's stock is allocated to sales channels
+// - the item is archived
 // - insufficient stock available
 // Updates Reserved field. Version is managed by repository layer for optimistic locking.
 func (i *InventoryItem) Reserve(quantity int) error {
@@ -61,6 +82,18 @@ func (i *InventoryItem) Reserve(quantity int) error {
 		return errors.ErrInvalidQuantity
 	}
 
+	if err := i.checkCountedStock(); err != nil {
+		return err
+	}
+
+	if i.ChannelAllocated {
+		return errors.ErrChannelAllocatedItem
+	}
+
+	if i.IsArchived() {
+		return errors.ErrInventoryItemArchived
+	}
+
 	if !i.CanReserve(quantity) {
 		return errors.ErrInsufficientStock
 	}
@@ -74,6 +107,7 @@ func (i *InventoryItem) Reserve(quantity int) error {
 // Used when an order is cancelled or a reservation expires.
 // Returns an error if:
 // - quantity is negative or zero
+// - the item is serial-tracked or a bundle
 // - trying to release more than currently reserved
 // Version is managed by repository layer for optimistic locking.
 func (i *InventoryItem) ReleaseReservation(quantity int) error {
@@ -81,6 +115,10 @@ func (i *InventoryItem) ReleaseReservation(quantity int) error {
 		return errors.ErrInvalidQuantity
 	}
 
+	if err := i.checkCountedStock(); err != nil {
+		return err
+	}
+
 	if i.Reserved < quantity {
 		return errors.ErrInvalidReservationRelease
 	}
@@ -95,6 +133,7 @@ func (i *InventoryItem) ReleaseReservation(quantity int) error {
 // The quantity moves from Reserved to permanent deduction from Quantity.
 // Returns an error if:
 // - quantity is negative or zero
+// - the item is serial-tracked or a bundle
 // - trying to confirm more than currently reserved
 // - resulting Quantity would be negative
 // Version is managed by repository layer for optimistic locking.
@@ -103,6 +142,10 @@ func (i *InventoryItem) ConfirmReservation(quantity int) error {
 		return errors.ErrInvalidQuantity
 	}
 
+	if err := i.checkCountedStock(); err != nil {
+		return err
+	}
+
 	if i.Reserved < quantity {
 		return errors.ErrInvalidReservationConfirm
 	}
@@ -119,13 +162,17 @@ func (i *InventoryItem) ConfirmReservation(quantity int) error {
 
 // AddStock increases the total quantity of inventory.
 // Used for restocking operations.
-// Returns an error if quantity is negative or zero.
+// Returns an error if quantity is negative or zero, or the item is serial-tracked or a bundle.
 // Version is managed by repository layer for optimistic locking.
 func (i *InventoryItem) AddStock(quantity int) error {
 	if quantity <= 0 {
 		return errors.ErrInvalidQuantity
 	}
 
+	if err := i.checkCountedStock(); err != nil {
+		return err
+	}
+
 	i.Quantity += quantity
 	i.UpdatedAt = time.Now()
 	return nil
@@ -135,6 +182,7 @@ func (i *InventoryItem) AddStock(quantity int) error {
 // Used for direct sales or manual adjustments.
 // Returns an error if:
 // - quantity is negative or zero
+// - the item is serial-tracked or a bundle
 // - insufficient available stock
 // Version is managed by repository layer for optimistic locking.
 func (i *InventoryItem) DecrementStock(quantity int) error {
@@ -142,6 +190,10 @@ func (i *InventoryItem) DecrementStock(quantity int) error {
 		return errors.ErrInvalidQuantity
 	}
 
+	if err := i.checkCountedStock(); err != nil {
+		return err
+	}
+
 	if !i.CanReserve(quantity) {
 		return errors.ErrInsufficientStock
 	}
@@ -151,8 +203,157 @@ func (i *InventoryItem) DecrementStock(quantity int) error {
 	return nil
 }
 
+// SetQuantity replaces the total quantity, e.g. with a physical stock count.
+// Returns an error if:
+// - quantity is negative
+// - the item is serial-tracked or a bundle
+// - quantity is lower than the reserved units
+// Version is managed by repository layer for optimistic locking.
+func (i *InventoryItem) SetQuantity(quantity int) error {
+	if quantity < 0 {
+		return errors.ErrNegativeQuantity
+	}
+
+	if err := i.checkCountedStock(); err != nil {
+		return err
+	}
+
+	if quantity < i.Reserved {
+		return errors.ErrInsufficientStock.WithDetails(
+			fmt.Sprintf("quantity %d is below the %d reserved units", quantity, i.Reserved))
+	}
+
+	i.Quantity = quantity
+	i.UpdatedAt = time.Now()
+	return nil
+}
+
 // IsStockAvailable checks if at least the minimum quantity is available.
 // Helper method for quick stock checks.
 func (i *InventoryItem) IsStockAvailable(minQuantity int) bool {
 	return i.Available() >= minQuantity
 }
+
+// IsArchived reports whether the product was deactivated in the catalog.
+func (i *InventoryItem) IsArchived() bool {
+	return i.ArchivedAt != nil
+}
+
+// Archive marks the item as belonging to a deactivated product.
+// Stock and existing reservations are kept so pending orders can still be
+// confirmed or released; new reservations are rejected.
+// Archiving an archived item keeps the original timestamp.
+func (i *InventoryItem) Archive(at time.Time) {
+	if i.IsArchived() {
+		return
+	}
+	i.ArchivedAt = &at
+	i.UpdatedAt = at
+}
+
+// IsStaleCatalogChange reports whether a catalog change at the given time is older
+// than the latest one applied to the item, and so was overtaken by it.
+func (i *InventoryItem) IsStaleCatalogChange(at time.Time) bool {
+	return i.CatalogChangedAt != nil && at.Before(*i.CatalogChangedAt)
+}
+
+// ApplyCatalogChange records the time of a catalog change applied to the item,
+// unless a later one was already applied.
+func (i *InventoryItem) ApplyCatalogChange(at time.Time) {
+	if i.CatalogChangedAt != nil && !at.After(*i.CatalogChangedAt) {
+		return
+	}
+	i.CatalogChangedAt = &at
+}
+
+// Restore makes an archived item reservable again, e.g. when the product is reactivated.
+func (i *InventoryItem) Restore() {
+	if !i.IsArchived() {
+		return
+	}
+	i.ArchivedAt = nil
+	i.UpdatedAt = time.Now()
+}
+
+// EnableSerialTracking makes the item track its stock by serial unit.
+// Returns ErrSerialTrackingChange if the item has stock, which would not be
+// backed by serial units, or channel allocations, and ErrBundleItem if the item
+// is a bundle.
+func (i *InventoryItem) EnableSerialTracking() error {
+	return i.setSerialTracking(true)
+}
+
+// DisableSerialTracking makes the item track its stock by count again.
+// Returns ErrSerialTrackingChange if the item has serial units in stock.
+func (i *InventoryItem) DisableSerialTracking() error {
+	return i.setSerialTracking(false)
+}
+
+func (i *InventoryItem) setSerialTracking(enabled bool) error {
+	if i.SerialTracked == enabled {
+		return nil
+	}
+
+	if i.Bundle {
+		return errors.ErrBundleItem
+	}
+
+	if i.Quantity != 0 {
+		return errors.ErrSerialTrackingChange.WithDetails(
+			fmt.Sprintf("the item has %d units in stock", i.Quantity))
+	}
+
+	if i.ChannelAllocated {
+		return errors.ErrSerialTrackingChange.WithDetails("the item has channel allocations")
+	}
+
+	i.SerialTracked = enabled
+	i.UpdatedAt = time.Now()
+	return nil
+}
+
+// MakeBundle makes the item a bundle whose stock is the stock of its components.
+// Returns ErrBundleChange if the item has stock of its own, channel allocations
+// or is serial-tracked.
+func (i *InventoryItem) MakeBundle() error {
+	if i.Bundle {
+		return nil
+	}
+
+	if i.SerialTracked {
+		return errors.ErrBundleChange.WithDetails("the item is serial-tracked")
+	}
+
+	if i.Quantity != 0 {
+		return errors.ErrBundleChange.WithDetails(
+			fmt.Sprintf("the item has %d units in stock", i.Quantity))
+	}
+
+	if i.ChannelAllocated {
+		return errors.ErrBundleChange.WithDetails("the item has channel allocations")
+	}
+
+	i.Bundle = true
+	i.UpdatedAt = time.Now()
+	return nil
+}
+
+// Unbundle makes the item hold stock of its own again.
+func (i *InventoryItem) Unbundle() {
+	if !i.Bundle {
+		return
+	}
+	i.Bundle = false
+	i.UpdatedAt = time.Now()
+}
+
+// checkCountedStock returns why the item's stock cannot change by count, if it cannot
+func (i *InventoryItem) checkCountedStock() error {
+	if i.SerialTracked {
+		return errors.ErrSerialTrackedItem
+	}
+	if i.Bundle {
+		return errors.ErrBundleItem
+	}
+	return nil
+}
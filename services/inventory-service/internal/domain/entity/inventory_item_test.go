@@ -2,6 +2,7 @@ package entity
 
 import (
 	"testing"
+	"time"
 
 	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
 	"github.com/google/uuid"
@@ -123,6 +124,16 @@ func TestInventoryItem_Reserve(t *testing.T) {
 		assert.Equal(t, 30, item.Reserved) // Unchanged
 	})
 
+	t.Run("should reject reservation of archived item", func(t *testing.T) {
+		item, _ := NewInventoryItem(productID, 100)
+		item.Archive(time.Now())
+
+		err := item.Reserve(10)
+
+		assert.Equal(t, errors.ErrInventoryItemArchived, err)
+		assert.Equal(t, 0, item.Reserved)
+	})
+
 	t.Run("should reject zero quantity", func(t *testing.T) {
 		item, _ := NewInventoryItem(productID, 100)
 
@@ -418,3 +429,201 @@ func TestInventoryItem_VersionIncrement(t *testing.T) {
 		// See postgres_e2e_test.go for actual optimistic locking tests with DB
 	})
 }
+
+func TestInventoryItem_SetQuantity(t *testing.T) {
+	productID := uuid.New()
+
+	t.Run("should replace quantity with a stock count", func(t *testing.T) {
+		item, _ := NewInventoryItem(productID, 100)
+		item.Reserve(30)
+
+		err := item.SetQuantity(40)
+
+		require.NoError(t, err)
+		assert.Equal(t, 40, item.Quantity)
+		assert.Equal(t, 10, item.Available())
+	})
+
+	t.Run("should fail below reserved units", func(t *testing.T) {
+		item, _ := NewInventoryItem(productID, 100)
+		item.Reserve(30)
+
+		err := item.SetQuantity(29)
+
+		assert.ErrorIs(t, err, errors.ErrInsufficientStock)
+		assert.Contains(t, err.Error(), "30 reserved")
+		assert.Equal(t, 100, item.Quantity)
+	})
+
+	t.Run("should fail with negative quantity", func(t *testing.T) {
+		item, _ := NewInventoryItem(productID, 100)
+
+		assert.ErrorIs(t, item.SetQuantity(-1), errors.ErrNegativeQuantity)
+	})
+}
+
+func TestInventoryItem_Archive(t *testing.T) {
+	productID := uuid.New()
+
+	t.Run("should archive and keep stock", func(t *testing.T) {
+		item, _ := NewInventoryItem(productID, 100)
+		require.NoError(t, item.Reserve(10))
+		at := time.Now().Add(-time.Minute)
+
+		item.Archive(at)
+
+		assert.True(t, item.IsArchived())
+		assert.Equal(t, at, *item.ArchivedAt)
+		assert.Equal(t, 100, item.Quantity)
+		assert.Equal(t, 10, item.Reserved)
+		require.NoError(t, item.ReleaseReservation(5), "pending reservations can still be released")
+		require.NoError(t, item.ConfirmReservation(5), "pending reservations can still be confirmed")
+	})
+
+	t.Run("should keep the first archive timestamp", func(t *testing.T) {
+		item, _ := NewInventoryItem(productID, 100)
+		first := time.Now().Add(-time.Hour)
+
+		item.Archive(first)
+		item.Archive(time.Now())
+
+		assert.Equal(t, first, *item.ArchivedAt)
+	})
+
+	t.Run("should restore archived item", func(t *testing.T) {
+		item, _ := NewInventoryItem(productID, 100)
+		item.Archive(time.Now())
+
+		item.Restore()
+
+		assert.False(t, item.IsArchived())
+		assert.Nil(t, item.ArchivedAt)
+		assert.NoError(t, item.Reserve(10))
+	})
+}
+
+func TestInventoryItem_CatalogChanges(t *testing.T) {
+	item, _ := NewInventoryItem(uuid.New(), 100)
+	earlier := time.Now().Add(-time.Hour)
+	later := time.Now()
+
+	assert.False(t, item.IsStaleCatalogChange(earlier), "items never synced accept any change")
+
+	item.ApplyCatalogChange(later)
+	item.ApplyCatalogChange(earlier)
+
+	assert.Equal(t, later, *item.CatalogChangedAt, "the latest change is kept")
+	assert.True(t, item.IsStaleCatalogChange(earlier))
+	assert.False(t, item.IsStaleCatalogChange(later), "redeliveries are not stale")
+}
+
+func TestInventoryItem_SerialTracking(t *testing.T) {
+	productID := uuid.New()
+
+	t.Run("should switch tracking only without stock", func(t *testing.T) {
+		item, _ := NewInventoryItem(productID, 0)
+
+		require.NoError(t, item.EnableSerialTracking())
+		assert.True(t, item.SerialTracked)
+		require.NoError(t, item.EnableSerialTracking(), "enabling twice is a no-op")
+
+		item.Quantity = 2
+		err := item.DisableSerialTracking()
+		assert.ErrorIs(t, err, errors.ErrSerialTrackingChange)
+		assert.True(t, item.SerialTracked)
+
+		item.Quantity = 0
+		require.NoError(t, item.DisableSerialTracking())
+		assert.False(t, item.SerialTracked)
+	})
+
+	t.Run("should reject enabling with untracked stock", func(t *testing.T) {
+		item, _ := NewInventoryItem(productID, 5)
+
+		err := item.EnableSerialTracking()
+
+		assert.ErrorIs(t, err, errors.ErrSerialTrackingChange)
+		assert.False(t, item.SerialTracked)
+	})
+
+	t.Run("should reject count-based stock changes", func(t *testing.T) {
+		item := &InventoryItem{ProductID: productID, Quantity: 5, Reserved: 2, SerialTracked: true}
+
+		assert.ErrorIs(t, item.Reserve(1), errors.ErrSerialTrackedItem)
+		assert.ErrorIs(t, item.ReleaseReservation(1), errors.ErrSerialTrackedItem)
+		assert.ErrorIs(t, item.ConfirmReservation(1), errors.ErrSerialTrackedItem)
+		assert.ErrorIs(t, item.AddStock(1), errors.ErrSerialTrackedItem)
+		assert.ErrorIs(t, item.DecrementStock(1), errors.ErrSerialTrackedItem)
+		assert.ErrorIs(t, item.SetQuantity(7), errors.ErrSerialTrackedItem)
+		assert.Equal(t, 5, item.Quantity)
+		assert.Equal(t, 2, item.Reserved)
+	})
+}
+
+func TestInventoryItem_Bundle(t *testing.T) {
+	productID := uuid.New()
+
+	t.Run("should make a bundle only without stock", func(t *testing.T) {
+		item, _ := NewInventoryItem(productID, 3)
+
+		err := item.MakeBundle()
+		assert.ErrorIs(t, err, errors.ErrBundleChange)
+		assert.False(t, item.Bundle)
+
+		item.Quantity = 0
+		require.NoError(t, item.MakeBundle())
+		assert.True(t, item.Bundle)
+		require.NoError(t, item.MakeBundle(), "making a bundle twice is a no-op")
+
+		item.Unbundle()
+		assert.False(t, item.Bundle)
+	})
+
+	t.Run("should reject serial-tracked items", func(t *testing.T) {
+		item, _ := NewInventoryItem(productID, 0)
+		require.NoError(t, item.EnableSerialTracking())
+
+		assert.ErrorIs(t, item.MakeBundle(), errors.ErrBundleChange)
+
+		require.NoError(t, item.DisableSerialTracking())
+		require.NoError(t, item.MakeBundle())
+		assert.ErrorIs(t, item.EnableSerialTracking(), errors.ErrBundleItem)
+	})
+
+	t.Run("should reject count-based stock changes", func(t *testing.T) {
+		item := &InventoryItem{ProductID: productID, Bundle: true}
+
+		assert.ErrorIs(t, item.Reserve(1), errors.ErrBundleItem)
+		assert.ErrorIs(t, item.ReleaseReservation(1), errors.ErrBundleItem)
+		assert.ErrorIs(t, item.ConfirmReservation(1), errors.ErrBundleItem)
+		assert.ErrorIs(t, item.AddStock(1), errors.ErrBundleItem)
+		assert.ErrorIs(t, item.DecrementStock(1), errors.ErrBundleItem)
+		assert.ErrorIs(t, item.SetQuantity(7), errors.ErrBundleItem)
+		assert.Equal(t, 0, item.Quantity)
+	})
+}
+
+func TestInventoryItem_ChannelAllocated(t *testing.T) {
+	productID := uuid.New()
+
+	t.Run("should only reject reservations by count", func(t *testing.T) {
+		item, _ := NewInventoryItem(productID, 10)
+		item.Reserved = 2
+		item.ChannelAllocated = true
+
+		assert.ErrorIs(t, item.Reserve(1), errors.ErrChannelAllocatedItem)
+		assert.Equal(t, 2, item.Reserved)
+		require.NoError(t, item.ReleaseReservation(1))
+		require.NoError(t, item.ConfirmReservation(1))
+		require.NoError(t, item.AddStock(5))
+		assert.Equal(t, 14, item.Quantity)
+	})
+
+	t.Run("should not become a bundle or serial-tracked", func(t *testing.T) {
+		item, _ := NewInventoryItem(productID, 0)
+		item.ChannelAllocated = true
+
+		assert.ErrorIs(t, item.MakeBundle(), errors.ErrBundleChange)
+		assert.ErrorIs(t, item.EnableSerialTracking(), errors.ErrSerialTrackingChange)
+	})
+}
@@ -0,0 +1,201 @@
+package entity
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+	"github.com/google/uuid"
+)
+
+// MaxLotNumberLength is the longest lot number that can be stored
+const MaxLotNumberLength = 100
+
+// Lot is a batch of units of an inventory item received together, with a lot
+// number and an optional best-before date.
+// Quantity counts the units of the lot that are part of the item's Quantity and
+// Reserved the part of them allocated to pending reservations. Units of an expired
+// lot are moved from Quantity to Quarantined so they can no longer be sold.
+type Lot struct {
+	ID              uuid.UUID  `json:"id"`
+	InventoryItemID uuid.UUID  `json:"inventory_item_id"`
+	LotNumber       string     `json:"lot_number"`
+	ReceivedAt      time.Time  `json:"received_at"`
+	BestBefore      *time.Time `json:"best_before,omitempty"` // date at midnight UTC; nil if the goods do not expire
+	Quantity        int        `json:"quantity"`
+	Reserved        int        `json:"reserved"`
+	Quarantined     int        `json:"quarantined"`
+	QuarantinedAt   *time.Time `json:"quarantined_at,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+// NewLot creates a lot of quantity units received at receivedAt (now if zero).
+// Only the date of bestBefore is kept.
+// Returns an error if:
+// - quantity is negative or zero
+// - the lot number is empty or too long
+// - the lot expires before it was received
+func NewLot(inventoryItemID uuid.UUID, lotNumber string, quantity int, receivedAt time.Time, bestBefore *time.Time) (*Lot, error) {
+	if quantity <= 0 {
+		return nil, errors.ErrInvalidQuantity
+	}
+
+	lotNumber = strings.TrimSpace(lotNumber)
+	if lotNumber == "" {
+		return nil, errors.ErrInvalidInput.WithDetails("lot_number is required")
+	}
+	if len(lotNumber) > MaxLotNumberLength {
+		return nil, errors.ErrInvalidInput.WithDetails("lot_number is too long")
+	}
+
+	now := time.Now().UTC()
+	if receivedAt.IsZero() {
+		receivedAt = now
+	}
+	if bestBefore != nil {
+		date := startOfDay(*bestBefore)
+		if date.Before(startOfDay(receivedAt)) {
+			return nil, errors.ErrInvalidInput.WithDetails("best_before must not be before received_at")
+		}
+		bestBefore = &date
+	}
+
+	return &Lot{
+		ID:              uuid.New(),
+		InventoryItemID: inventoryItemID,
+		LotNumber:       lotNumber,
+		ReceivedAt:      receivedAt.UTC(),
+		BestBefore:      bestBefore,
+		Quantity:        quantity,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}, nil
+}
+
+// Available returns the units of the lot that can still be allocated
+func (l *Lot) Available() int {
+	return l.Quantity - l.Reserved
+}
+
+// IsExpired reports whether the best-before date has passed at the given time.
+// A lot can be sold until the end of its best-before day.
+func (l *Lot) IsExpired(at time.Time) bool {
+	return l.BestBefore != nil && l.BestBefore.Before(startOfDay(at))
+}
+
+// IsQuarantined reports whether units of the lot were taken out of the stock
+func (l *Lot) IsQuarantined() bool {
+	return l.QuarantinedAt != nil
+}
+
+// Quarantine moves up to max free units of the lot to Quarantined and returns how
+// many were moved. Units allocated to pending reservations stay in the lot; they
+// are quarantined by a later call if those reservations are released.
+func (l *Lot) Quarantine(max int, at time.Time) int {
+	units := l.Available()
+	if units > max {
+		units = max
+	}
+	if units <= 0 {
+		return 0
+	}
+
+	l.Quantity -= units
+	l.Quarantined += units
+	if l.QuarantinedAt == nil {
+		l.QuarantinedAt = &at
+	}
+	l.UpdatedAt = at
+	return units
+}
+
+// LotAllocationStatus represents the status of the units of a lot allocated to a reservation
+type LotAllocationStatus string
+
+const (
+	// LotAllocated indicates the units are held for a pending reservation
+	LotAllocated LotAllocationStatus = "allocated"
+	// LotConsumed indicates the reservation was confirmed and the units left the stock
+	LotConsumed LotAllocationStatus = "consumed"
+	// LotReleased indicates the reservation was released or expired and the units returned to the lot
+	LotReleased LotAllocationStatus = "released"
+)
+
+// LotAllocation records units of a lot taken by a reservation
+type LotAllocation struct {
+	ReservationID uuid.UUID           `json:"reservation_id"`
+	LotID         uuid.UUID           `json:"lot_id"`
+	LotNumber     string              `json:"lot_number"`
+	BestBefore    *time.Time          `json:"best_before,omitempty"`
+	Quantity      int                 `json:"quantity"`
+	Status        LotAllocationStatus `json:"status"`
+	AllocatedAt   time.Time           `json:"allocated_at"`
+	UpdatedAt     time.Time           `json:"updated_at"`
+}
+
+// AllocateFEFO allocates up to quantity units of lots to a reservation,
+// first-expired-first-out: lots with the earliest best-before date go first, lots
+// without one last, and ties go to the lot received first. Lots expired at the
+// given time are skipped. Reserved is incremented on every lot used.
+// Fewer units than quantity are allocated when the lots do not cover it.
+func AllocateFEFO(lots []*Lot, reservationID uuid.UUID, quantity int, at time.Time) []*LotAllocation {
+	ordered := make([]*Lot, len(lots))
+	copy(ordered, lots)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return fefoLess(ordered[i], ordered[j])
+	})
+
+	var allocations []*LotAllocation
+	for _, lot := range ordered {
+		if quantity == 0 {
+			break
+		}
+		if lot.IsExpired(at) || lot.Available() <= 0 {
+			continue
+		}
+
+		units := lot.Available()
+		if units > quantity {
+			units = quantity
+		}
+		lot.Reserved += units
+		lot.UpdatedAt = at
+		quantity -= units
+
+		allocations = append(allocations, &LotAllocation{
+			ReservationID: reservationID,
+			LotID:         lot.ID,
+			LotNumber:     lot.LotNumber,
+			BestBefore:    lot.BestBefore,
+			Quantity:      units,
+			Status:        LotAllocated,
+			AllocatedAt:   at,
+			UpdatedAt:     at,
+		})
+	}
+	return allocations
+}
+
+// fefoLess orders lots by best-before date (none last), received date and lot number
+func fefoLess(a, b *Lot) bool {
+	switch {
+	case a.BestBefore == nil && b.BestBefore != nil:
+		return false
+	case a.BestBefore != nil && b.BestBefore == nil:
+		return true
+	case a.BestBefore != nil && !a.BestBefore.Equal(*b.BestBefore):
+		return a.BestBefore.Before(*b.BestBefore)
+	case !a.ReceivedAt.Equal(b.ReceivedAt):
+		return a.ReceivedAt.Before(b.ReceivedAt)
+	default:
+		return a.LotNumber < b.LotNumber
+	}
+}
+
+// startOfDay returns midnight of the UTC day of t
+func startOfDay(t time.Time) time.Time {
+	year, month, day := t.UTC().Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
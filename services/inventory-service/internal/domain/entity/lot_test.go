@@ -0,0 +1,145 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func date(year int, month time.Month, day int) *time.Time {
+	d := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	return &d
+}
+
+func TestNewLot(t *testing.T) {
+	itemID := uuid.New()
+	receivedAt := time.Date(2025, 11, 3, 14, 30, 0, 0, time.UTC)
+
+	t.Run("should keep only the date of best-before", func(t *testing.T) {
+		bestBefore := time.Date(2026, 1, 31, 18, 0, 0, 0, time.UTC)
+		lot, err := NewLot(itemID, "  L-2025-11-03 ", 40, receivedAt, &bestBefore)
+
+		require.NoError(t, err)
+		assert.NotEqual(t, uuid.Nil, lot.ID)
+		assert.Equal(t, itemID, lot.InventoryItemID)
+		assert.Equal(t, "L-2025-11-03", lot.LotNumber)
+		assert.Equal(t, 40, lot.Quantity)
+		assert.Equal(t, receivedAt, lot.ReceivedAt)
+		assert.Equal(t, date(2026, 1, 31), lot.BestBefore)
+		assert.False(t, lot.IsQuarantined())
+	})
+
+	t.Run("should default received date and accept lots that do not expire", func(t *testing.T) {
+		lot, err := NewLot(itemID, "L1", 1, time.Time{}, nil)
+
+		require.NoError(t, err)
+		assert.False(t, lot.ReceivedAt.IsZero())
+		assert.Nil(t, lot.BestBefore)
+	})
+
+	t.Run("should reject invalid lots", func(t *testing.T) {
+		_, err := NewLot(itemID, "L1", 0, receivedAt, nil)
+		assert.ErrorIs(t, err, errors.ErrInvalidQuantity)
+
+		_, err = NewLot(itemID, " ", 1, receivedAt, nil)
+		assert.ErrorIs(t, err, errors.ErrInvalidInput)
+
+		_, err = NewLot(itemID, string(make([]byte, MaxLotNumberLength+1)), 1, receivedAt, nil)
+		assert.ErrorIs(t, err, errors.ErrInvalidInput)
+
+		_, err = NewLot(itemID, "L1", 1, receivedAt, date(2025, 11, 2))
+		assert.ErrorIs(t, err, errors.ErrInvalidInput)
+	})
+}
+
+func TestLot_IsExpired(t *testing.T) {
+	lot := &Lot{BestBefore: date(2025, 12, 1)}
+
+	assert.False(t, lot.IsExpired(time.Date(2025, 12, 1, 23, 59, 0, 0, time.UTC)), "sellable through its best-before day")
+	assert.True(t, lot.IsExpired(time.Date(2025, 12, 2, 0, 0, 0, 0, time.UTC)))
+	assert.False(t, (&Lot{}).IsExpired(time.Now()), "lots without best-before never expire")
+}
+
+func TestLot_Quarantine(t *testing.T) {
+	at := time.Date(2025, 12, 2, 1, 0, 0, 0, time.UTC)
+
+	t.Run("should move free units and keep allocated ones", func(t *testing.T) {
+		lot := &Lot{Quantity: 30, Reserved: 10}
+
+		assert.Equal(t, 20, lot.Quarantine(100, at))
+		assert.Equal(t, 10, lot.Quantity)
+		assert.Equal(t, 20, lot.Quarantined)
+		require.True(t, lot.IsQuarantined())
+		assert.Equal(t, at, *lot.QuarantinedAt)
+
+		// Released allocations are quarantined by a later call; the first time is kept
+		lot.Reserved = 0
+		assert.Equal(t, 10, lot.Quarantine(100, at.Add(time.Hour)))
+		assert.Equal(t, 0, lot.Quantity)
+		assert.Equal(t, 30, lot.Quarantined)
+		assert.Equal(t, at, *lot.QuarantinedAt)
+	})
+
+	t.Run("should cap at max", func(t *testing.T) {
+		lot := &Lot{Quantity: 30}
+
+		assert.Equal(t, 5, lot.Quarantine(5, at))
+		assert.Equal(t, 25, lot.Quantity)
+	})
+
+	t.Run("should not mark lots without free units", func(t *testing.T) {
+		lot := &Lot{Quantity: 10, Reserved: 10}
+
+		assert.Zero(t, lot.Quarantine(100, at))
+		assert.False(t, lot.IsQuarantined())
+	})
+}
+
+func TestAllocateFEFO(t *testing.T) {
+	at := time.Date(2025, 12, 1, 12, 0, 0, 0, time.UTC)
+	received := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	reservationID := uuid.New()
+
+	noExpiry := &Lot{ID: uuid.New(), LotNumber: "NOEXP", ReceivedAt: received, Quantity: 100}
+	expired := &Lot{ID: uuid.New(), LotNumber: "EXPIRED", ReceivedAt: received, BestBefore: date(2025, 11, 30), Quantity: 100}
+	january := &Lot{ID: uuid.New(), LotNumber: "JAN", ReceivedAt: received, BestBefore: date(2026, 1, 15), Quantity: 10, Reserved: 4}
+	december := &Lot{ID: uuid.New(), LotNumber: "DEC", ReceivedAt: received, BestBefore: date(2025, 12, 1), Quantity: 5}
+	januaryLater := &Lot{ID: uuid.New(), LotNumber: "JAN-2", ReceivedAt: received.Add(time.Hour), BestBefore: date(2026, 1, 15), Quantity: 10}
+	empty := &Lot{ID: uuid.New(), LotNumber: "EMPTY", ReceivedAt: received, BestBefore: date(2025, 12, 5), Quantity: 3, Reserved: 3}
+
+	allocations := AllocateFEFO([]*Lot{noExpiry, expired, januaryLater, january, december, empty}, reservationID, 23, at)
+
+	require.Len(t, allocations, 4)
+	assert.Equal(t, "DEC", allocations[0].LotNumber)
+	assert.Equal(t, 5, allocations[0].Quantity)
+	assert.Equal(t, "JAN", allocations[1].LotNumber)
+	assert.Equal(t, 6, allocations[1].Quantity)
+	assert.Equal(t, "JAN-2", allocations[2].LotNumber)
+	assert.Equal(t, 10, allocations[2].Quantity)
+	assert.Equal(t, "NOEXP", allocations[3].LotNumber)
+	assert.Equal(t, 2, allocations[3].Quantity, "lots without best-before go last")
+	for _, allocation := range allocations {
+		assert.Equal(t, reservationID, allocation.ReservationID)
+		assert.Equal(t, LotAllocated, allocation.Status)
+	}
+
+	assert.Equal(t, 5, december.Reserved)
+	assert.Equal(t, 10, january.Reserved)
+	assert.Equal(t, 10, januaryLater.Reserved)
+	assert.Equal(t, 2, noExpiry.Reserved)
+	assert.Zero(t, expired.Reserved)
+}
+
+func TestAllocateFEFO_LotsDoNotCover(t *testing.T) {
+	lot := &Lot{ID: uuid.New(), LotNumber: "L1", Quantity: 3}
+
+	allocations := AllocateFEFO([]*Lot{lot}, uuid.New(), 10, time.Now())
+
+	require.Len(t, allocations, 1)
+	assert.Equal(t, 3, allocations[0].Quantity, "the rest comes from untracked stock")
+	assert.Empty(t, AllocateFEFO(nil, uuid.New(), 10, time.Now()))
+}
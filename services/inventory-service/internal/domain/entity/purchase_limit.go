@@ -0,0 +1,90 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+	"github.com/google/uuid"
+)
+
+// MaxPurchaseLimitWindow caps the period customer limits count reservations over
+const MaxPurchaseLimitWindow = 365 * 24 * time.Hour
+
+// PurchaseLimit caps the quantity of a product one order, and one customer over a
+// rolling window, can reserve. A zero limit is not enforced.
+type PurchaseLimit struct {
+	InventoryItemID uuid.UUID     `json:"inventory_item_id"`
+	MaxPerOrder     int           `json:"max_per_order,omitempty"`
+	MaxPerCustomer  int           `json:"max_per_customer,omitempty"`
+	Window          time.Duration `json:"window,omitempty"` // customer limits only
+	CreatedAt       time.Time     `json:"created_at"`
+	UpdatedAt       time.Time     `json:"updated_at"`
+}
+
+// NewPurchaseLimit limits the quantity of the item an order and a customer can reserve.
+// Returns ErrInvalidPurchaseLimit if:
+// - a limit is negative or both are zero
+// - a customer limit has no window, or the window is under a minute or over MaxPurchaseLimitWindow
+// - a window is given without a customer limit
+// - the order limit is above the customer limit, which no order could reach
+func NewPurchaseLimit(item *InventoryItem, maxPerOrder, maxPerCustomer int, window time.Duration) (*PurchaseLimit, error) {
+	if maxPerOrder < 0 || maxPerCustomer < 0 {
+		return nil, errors.ErrInvalidPurchaseLimit.WithDetails("limits must not be negative")
+	}
+	if maxPerOrder == 0 && maxPerCustomer == 0 {
+		return nil, errors.ErrInvalidPurchaseLimit.WithDetails("at least one of the order and customer limits is required")
+	}
+
+	if maxPerCustomer == 0 && window != 0 {
+		return nil, errors.ErrInvalidPurchaseLimit.WithDetails("a window applies to customer limits only")
+	}
+	if maxPerCustomer > 0 && (window < time.Minute || window > MaxPurchaseLimitWindow) {
+		return nil, errors.ErrInvalidPurchaseLimit.WithDetails(
+			fmt.Sprintf("customer limits need a window between 1m and %s", MaxPurchaseLimitWindow))
+	}
+	if maxPerOrder > 0 && maxPerCustomer > 0 && maxPerOrder > maxPerCustomer {
+		return nil, errors.ErrInvalidPurchaseLimit.WithDetails(
+			fmt.Sprintf("order limit %d is above customer limit %d", maxPerOrder, maxPerCustomer))
+	}
+
+	now := time.Now()
+	return &PurchaseLimit{
+		InventoryItemID: item.ID,
+		MaxPerOrder:     maxPerOrder,
+		MaxPerCustomer:  maxPerCustomer,
+		Window:          window.Truncate(time.Second),
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}, nil
+}
+
+// LimitsCustomers reports whether the reservations of a customer are limited
+func (l *PurchaseLimit) LimitsCustomers() bool {
+	return l.MaxPerCustomer > 0
+}
+
+// WindowStart returns when the window counting the reservations of a customer at now began
+func (l *PurchaseLimit) WindowStart(now time.Time) time.Time {
+	return now.Add(-l.Window)
+}
+
+// CheckOrder returns ErrPurchaseLimitExceeded if an order reserves more than MaxPerOrder
+func (l *PurchaseLimit) CheckOrder(quantity int) error {
+	if l.MaxPerOrder > 0 && quantity > l.MaxPerOrder {
+		return errors.ErrPurchaseLimitExceeded.WithDetails(
+			fmt.Sprintf("at most %d per order, requested %d", l.MaxPerOrder, quantity))
+	}
+	return nil
+}
+
+// CheckCustomer returns ErrPurchaseLimitExceeded if a customer who reserved bought
+// units within the window reserving quantity more would exceed MaxPerCustomer
+func (l *PurchaseLimit) CheckCustomer(quantity, bought int) error {
+	if l.LimitsCustomers() && bought+quantity > l.MaxPerCustomer {
+		return errors.ErrPurchaseLimitExceeded.WithDetails(
+			fmt.Sprintf("at most %d per customer every %s, %d already reserved, requested %d",
+				l.MaxPerCustomer, l.Window, bought, quantity))
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPurchaseLimit(t *testing.T) {
+	item, err := NewInventoryItem(uuid.New(), 100)
+	require.NoError(t, err)
+
+	t.Run("should create order and customer limits", func(t *testing.T) {
+		limit, err := NewPurchaseLimit(item, 2, 4, 24*time.Hour+time.Millisecond)
+		require.NoError(t, err)
+		assert.Equal(t, item.ID, limit.InventoryItemID)
+		assert.Equal(t, 2, limit.MaxPerOrder)
+		assert.Equal(t, 4, limit.MaxPerCustomer)
+		assert.Equal(t, 24*time.Hour, limit.Window, "windows are whole seconds")
+		assert.True(t, limit.LimitsCustomers())
+
+		orderOnly, err := NewPurchaseLimit(item, 5, 0, 0)
+		require.NoError(t, err)
+		assert.False(t, orderOnly.LimitsCustomers())
+	})
+
+	tests := []struct {
+		name           string
+		maxPerOrder    int
+		maxPerCustomer int
+		window         time.Duration
+	}{
+		{"negative order limit", -1, 0, 0},
+		{"negative customer limit", 0, -1, time.Hour},
+		{"no limits", 0, 0, 0},
+		{"window without customer limit", 2, 0, time.Hour},
+		{"customer limit without window", 0, 4, 0},
+		{"window under a minute", 0, 4, time.Second},
+		{"window over a year", 0, 4, MaxPurchaseLimitWindow + time.Hour},
+		{"order limit above customer limit", 5, 4, time.Hour},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewPurchaseLimit(item, tt.maxPerOrder, tt.maxPerCustomer, tt.window)
+			assert.ErrorIs(t, err, errors.ErrInvalidPurchaseLimit)
+		})
+	}
+}
+
+func TestPurchaseLimit_CheckOrder(t *testing.T) {
+	limit := &PurchaseLimit{MaxPerOrder: 2}
+	assert.NoError(t, limit.CheckOrder(2))
+
+	err := limit.CheckOrder(3)
+	assert.ErrorIs(t, err, errors.ErrPurchaseLimitExceeded)
+	assert.Contains(t, err.Error(), "at most 2 per order")
+
+	assert.NoError(t, (&PurchaseLimit{MaxPerCustomer: 1, Window: time.Hour}).CheckOrder(100), "no order limit")
+}
+
+func TestPurchaseLimit_CheckCustomer(t *testing.T) {
+	limit := &PurchaseLimit{MaxPerCustomer: 4, Window: 24 * time.Hour}
+	assert.NoError(t, limit.CheckCustomer(4, 0))
+	assert.NoError(t, limit.CheckCustomer(1, 3))
+
+	err := limit.CheckCustomer(2, 3)
+	assert.ErrorIs(t, err, errors.ErrPurchaseLimitExceeded)
+	assert.Contains(t, err.Error(), "at most 4 per customer every 24h0m0s, 3 already reserved, requested 2")
+
+	assert.NoError(t, (&PurchaseLimit{MaxPerOrder: 1}).CheckCustomer(100, 100), "no customer limit")
+}
+
+func TestPurchaseLimit_WindowStart(t *testing.T) {
+	now := time.Date(2026, 1, 2, 12, 0, 0, 0, time.UTC)
+	limit := &PurchaseLimit{MaxPerCustomer: 1, Window: 36 * time.Hour}
+	assert.Equal(t, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), limit.WindowStart(now))
+}
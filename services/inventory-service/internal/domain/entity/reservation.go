@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
@@ -35,6 +36,33 @@ type Reservation struct {
 	ExpiresAt       time.Time         `json:"expires_at"`
 	CreatedAt       time.Time         `json:"created_at"`
 	UpdatedAt       time.Time         `json:"updated_at"`
+	// Channel is the sales channel the reservation was made for, empty when made
+	// from the shared pool without one
+	Channel string `json:"channel,omitempty"`
+	// UserID is the end user the reservation was made for, empty when the caller
+	// did not identify one
+	UserID string `json:"user_id,omitempty"`
+}
+
+// MaxUserIDLength caps the length of an end-user ID
+const MaxUserIDLength = 128
+
+// ValidateUserID checks an end-user ID: 1 to MaxUserIDLength printable ASCII
+// characters without spaces
+func ValidateUserID(userID string) error {
+	if userID == "" {
+		return errors.ErrInvalidUserID.WithDetails("user id is required")
+	}
+	if len(userID) > MaxUserIDLength {
+		return errors.ErrInvalidUserID.WithDetails(
+			fmt.Sprintf("user id must be at most %d characters", MaxUserIDLength))
+	}
+	for _, r := range userID {
+		if r <= ' ' || r > '~' {
+			return errors.ErrInvalidUserID.WithDetails("user id may only contain printable ASCII characters without spaces")
+		}
+	}
+	return nil
 }
 
 // NewReservation creates a new pending reservation for an order.
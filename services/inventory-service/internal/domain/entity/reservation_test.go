@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -472,3 +473,12 @@ func TestDefaultReservationDuration(t *testing.T) {
 		assert.Equal(t, 15*time.Minute, DefaultReservationDuration)
 	})
 }
+
+func TestValidateUserID(t *testing.T) {
+	for _, userID := range []string{"42", "990e8400-e29b-41d4-a716-446655440004", "auth0|5f7c8ec7", strings.Repeat("u", MaxUserIDLength)} {
+		assert.NoError(t, ValidateUserID(userID), userID)
+	}
+	for _, userID := range []string{"", "user 42", "user\n42", "usér", strings.Repeat("u", MaxUserIDLength+1)} {
+		assert.ErrorIs(t, ValidateUserID(userID), errors.ErrInvalidUserID, userID)
+	}
+}
@@ -0,0 +1,139 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+	"github.com/google/uuid"
+)
+
+// MaxSerialNumberLength is the longest serial number that can be stored
+const MaxSerialNumberLength = 100
+
+// SerialUnitStatus represents the state of a serialized unit
+type SerialUnitStatus string
+
+const (
+	// SerialAvailable indicates the unit is in stock and can be reserved
+	SerialAvailable SerialUnitStatus = "available"
+	// SerialReserved indicates the unit is held for a pending reservation
+	SerialReserved SerialUnitStatus = "reserved"
+	// SerialSold indicates the reservation holding the unit was confirmed
+	SerialSold SerialUnitStatus = "sold"
+	// SerialReturned indicates a sold unit came back and awaits inspection before restocking
+	SerialReturned SerialUnitStatus = "returned"
+)
+
+// ParseSerialUnitStatus converts a string to a SerialUnitStatus
+func ParseSerialUnitStatus(s string) (SerialUnitStatus, error) {
+	status := SerialUnitStatus(s)
+	switch status {
+	case SerialAvailable, SerialReserved, SerialSold, SerialReturned:
+		return status, nil
+	default:
+		return "", errors.ErrInvalidInput.WithDetails("unknown serial unit status: " + s)
+	}
+}
+
+// SerialUnit is one serialized unit of a serial-tracked inventory item.
+// Available and reserved units are counted in the item's Quantity, and reserved
+// units in its Reserved. ReservationID is the reservation holding a reserved unit
+// and is kept once the unit is sold, as the record of which order took it.
+type SerialUnit struct {
+	ID              uuid.UUID        `json:"id"`
+	InventoryItemID uuid.UUID        `json:"inventory_item_id"`
+	SerialNumber    string           `json:"serial_number"`
+	Status          SerialUnitStatus `json:"status"`
+	ReservationID   *uuid.UUID       `json:"reservation_id,omitempty"`
+	CreatedAt       time.Time        `json:"created_at"`
+	UpdatedAt       time.Time        `json:"updated_at"`
+}
+
+// NewSerialUnit creates an available unit of an inventory item.
+// Returns an error if the serial number is empty or too long.
+func NewSerialUnit(inventoryItemID uuid.UUID, serialNumber string) (*SerialUnit, error) {
+	serialNumber = strings.TrimSpace(serialNumber)
+	if serialNumber == "" {
+		return nil, errors.ErrInvalidInput.WithDetails("serial_number is required")
+	}
+	if len(serialNumber) > MaxSerialNumberLength {
+		return nil, errors.ErrInvalidInput.WithDetails("serial_number is too long")
+	}
+
+	now := time.Now().UTC()
+	return &SerialUnit{
+		ID:              uuid.New(),
+		InventoryItemID: inventoryItemID,
+		SerialNumber:    serialNumber,
+		Status:          SerialAvailable,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}, nil
+}
+
+// IsInStock reports whether the unit is counted in the quantity of its item
+func (u *SerialUnit) IsInStock() bool {
+	return u.Status == SerialAvailable || u.Status == SerialReserved
+}
+
+// Reserve holds an available unit for a reservation
+func (u *SerialUnit) Reserve(reservationID uuid.UUID, at time.Time) error {
+	if err := u.transition(SerialAvailable, SerialReserved, at); err != nil {
+		return err
+	}
+	u.ReservationID = &reservationID
+	return nil
+}
+
+// Release makes a reserved unit available again
+func (u *SerialUnit) Release(at time.Time) error {
+	if err := u.transition(SerialReserved, SerialAvailable, at); err != nil {
+		return err
+	}
+	u.ReservationID = nil
+	return nil
+}
+
+// Sell marks a reserved unit sold, keeping the reservation that took it
+func (u *SerialUnit) Sell(at time.Time) error {
+	return u.transition(SerialReserved, SerialSold, at)
+}
+
+// Return marks a sold unit returned by the customer
+func (u *SerialUnit) Return(at time.Time) error {
+	return u.transition(SerialSold, SerialReturned, at)
+}
+
+// Restock puts a returned unit back in stock
+func (u *SerialUnit) Restock(at time.Time) error {
+	if err := u.transition(SerialReturned, SerialAvailable, at); err != nil {
+		return err
+	}
+	u.ReservationID = nil
+	return nil
+}
+
+// transition moves the unit from one state to another
+func (u *SerialUnit) transition(from, to SerialUnitStatus, at time.Time) error {
+	if u.Status != from {
+		return errors.ErrInvalidSerialTransition.WithDetails(
+			fmt.Sprintf("serial %s is %s, not %s", u.SerialNumber, u.Status, from))
+	}
+	u.Status = to
+	u.UpdatedAt = at
+	return nil
+}
+
+// SerialNumbers returns the serial numbers of units, in order
+func SerialNumbers(units []*SerialUnit) []string {
+	if units == nil {
+		return nil
+	}
+	numbers := make([]string, len(units))
+	for i, unit := range units {
+		numbers[i] = unit.SerialNumber
+	}
+	return numbers
+}
@@ -0,0 +1,109 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSerialUnit(t *testing.T) {
+	itemID := uuid.New()
+
+	t.Run("should create an available unit", func(t *testing.T) {
+		unit, err := NewSerialUnit(itemID, "  SN-0001 ")
+
+		require.NoError(t, err)
+		assert.NotEqual(t, uuid.Nil, unit.ID)
+		assert.Equal(t, itemID, unit.InventoryItemID)
+		assert.Equal(t, "SN-0001", unit.SerialNumber)
+		assert.Equal(t, SerialAvailable, unit.Status)
+		assert.Nil(t, unit.ReservationID)
+		assert.True(t, unit.IsInStock())
+	})
+
+	t.Run("should reject invalid serial numbers", func(t *testing.T) {
+		_, err := NewSerialUnit(itemID, " ")
+		assert.ErrorIs(t, err, errors.ErrInvalidInput)
+
+		_, err = NewSerialUnit(itemID, strings.Repeat("9", MaxSerialNumberLength+1))
+		assert.ErrorIs(t, err, errors.ErrInvalidInput)
+	})
+}
+
+func TestParseSerialUnitStatus(t *testing.T) {
+	for _, s := range []string{"available", "reserved", "sold", "returned"} {
+		status, err := ParseSerialUnitStatus(s)
+		require.NoError(t, err)
+		assert.Equal(t, SerialUnitStatus(s), status)
+	}
+
+	_, err := ParseSerialUnitStatus("lost")
+	assert.ErrorIs(t, err, errors.ErrInvalidInput)
+}
+
+func TestSerialUnit_Lifecycle(t *testing.T) {
+	unit, err := NewSerialUnit(uuid.New(), "SN-1")
+	require.NoError(t, err)
+	reservationID := uuid.New()
+	at := time.Date(2025, 12, 15, 10, 0, 0, 0, time.UTC)
+
+	require.NoError(t, unit.Reserve(reservationID, at))
+	assert.Equal(t, SerialReserved, unit.Status)
+	assert.Equal(t, reservationID, *unit.ReservationID)
+	assert.Equal(t, at, unit.UpdatedAt)
+	assert.True(t, unit.IsInStock())
+
+	require.NoError(t, unit.Release(at))
+	assert.Equal(t, SerialAvailable, unit.Status)
+	assert.Nil(t, unit.ReservationID)
+
+	require.NoError(t, unit.Reserve(reservationID, at))
+	require.NoError(t, unit.Sell(at))
+	assert.Equal(t, SerialSold, unit.Status)
+	assert.Equal(t, reservationID, *unit.ReservationID, "sold units keep the reservation that took them")
+	assert.False(t, unit.IsInStock())
+
+	require.NoError(t, unit.Return(at))
+	assert.Equal(t, SerialReturned, unit.Status)
+	assert.False(t, unit.IsInStock())
+
+	require.NoError(t, unit.Restock(at))
+	assert.Equal(t, SerialAvailable, unit.Status)
+	assert.Nil(t, unit.ReservationID)
+}
+
+func TestSerialUnit_InvalidTransitions(t *testing.T) {
+	at := time.Now()
+	tests := []struct {
+		name   string
+		status SerialUnitStatus
+		move   func(u *SerialUnit) error
+	}{
+		{"reserve reserved", SerialReserved, func(u *SerialUnit) error { return u.Reserve(uuid.New(), at) }},
+		{"reserve sold", SerialSold, func(u *SerialUnit) error { return u.Reserve(uuid.New(), at) }},
+		{"release available", SerialAvailable, func(u *SerialUnit) error { return u.Release(at) }},
+		{"sell available", SerialAvailable, func(u *SerialUnit) error { return u.Sell(at) }},
+		{"return reserved", SerialReserved, func(u *SerialUnit) error { return u.Return(at) }},
+		{"restock sold", SerialSold, func(u *SerialUnit) error { return u.Restock(at) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			unit := &SerialUnit{SerialNumber: "SN-1", Status: tt.status}
+
+			err := tt.move(unit)
+
+			assert.ErrorIs(t, err, errors.ErrInvalidSerialTransition)
+			assert.Equal(t, tt.status, unit.Status)
+		})
+	}
+}
+
+func TestSerialNumbers(t *testing.T) {
+	assert.Nil(t, SerialNumbers(nil))
+	assert.Equal(t, []string{"A", "B"}, SerialNumbers([]*SerialUnit{{SerialNumber: "A"}, {SerialNumber: "B"}}))
+}
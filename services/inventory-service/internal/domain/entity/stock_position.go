@@ -0,0 +1,23 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// StockPosition is the stock of an inventory item at a point in time, rebuilt
+// from the stock history. ChangedAt is the time of the last change at or before
+// that point.
+type StockPosition struct {
+	InventoryItemID uuid.UUID `json:"inventory_item_id"`
+	ProductID       uuid.UUID `json:"product_id"`
+	Quantity        int       `json:"quantity"`
+	Reserved        int       `json:"reserved"`
+	ChangedAt       time.Time `json:"changed_at"`
+}
+
+// Available returns the quantity that was free to reserve
+func (p *StockPosition) Available() int {
+	return p.Quantity - p.Reserved
+}
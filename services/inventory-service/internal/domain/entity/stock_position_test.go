@@ -0,0 +1,12 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStockPosition_Available(t *testing.T) {
+	position := &StockPosition{Quantity: 40, Reserved: 15}
+	assert.Equal(t, 25, position.Available())
+}
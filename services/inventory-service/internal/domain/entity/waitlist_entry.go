@@ -0,0 +1,146 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+	"github.com/google/uuid"
+)
+
+// WaitlistStatus represents the status of a waitlist entry
+type WaitlistStatus string
+
+const (
+	// WaitlistWaiting indicates the entry is queued for stock
+	WaitlistWaiting WaitlistStatus = "waiting"
+	// WaitlistFulfilled indicates a reservation was created for the entry
+	WaitlistFulfilled WaitlistStatus = "fulfilled"
+	// WaitlistCancelled indicates the caller left the waitlist
+	WaitlistCancelled WaitlistStatus = "cancelled"
+	// WaitlistExpired indicates the entry's TTL passed before stock freed up
+	WaitlistExpired WaitlistStatus = "expired"
+	// WaitlistFailed indicates the reservation for the entry was rejected for a
+	// reason other than the stock, e.g. a purchase limit
+	WaitlistFailed WaitlistStatus = "failed"
+)
+
+// Waitlist TTL bounds used when none are configured
+const (
+	DefaultWaitlistTTL = 24 * time.Hour
+	MaxWaitlistTTL     = 7 * 24 * time.Hour
+)
+
+// MaxWaitlistPriority caps the priority of a waitlist entry
+const MaxWaitlistPriority = 100
+
+// WaitlistEntry represents an order waiting for stock of an inventory item.
+// Waiting entries are served by descending Priority, then first come, first served.
+type WaitlistEntry struct {
+	ID              uuid.UUID      `json:"id"`
+	InventoryItemID uuid.UUID      `json:"inventory_item_id"`
+	OrderID         uuid.UUID      `json:"order_id"`
+	Quantity        int            `json:"quantity"`
+	Priority        int            `json:"priority"`
+	Channel         string         `json:"channel,omitempty"`
+	UserID          string         `json:"user_id,omitempty"`
+	Status          WaitlistStatus `json:"status"`
+	ExpiresAt       time.Time      `json:"expires_at"`
+	// ReservationID is the reservation created for a fulfilled entry
+	ReservationID *uuid.UUID `json:"reservation_id,omitempty"`
+	// FailureReason explains why a failed entry could not be reserved
+	FailureReason string     `json:"failure_reason,omitempty"`
+	FulfilledAt   *time.Time `json:"fulfilled_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// NewWaitlistEntry creates a waiting entry that expires after ttl.
+// Returns ErrInvalidQuantity for a non-positive quantity, ErrInvalidDuration for
+// a non-positive ttl and ErrInvalidInput for a priority outside 0..MaxWaitlistPriority.
+func NewWaitlistEntry(inventoryItemID, orderID uuid.UUID, quantity, priority int, ttl time.Duration) (*WaitlistEntry, error) {
+	if quantity <= 0 {
+		return nil, errors.ErrInvalidQuantity
+	}
+	if ttl <= 0 {
+		return nil, errors.ErrInvalidDuration
+	}
+	if priority < 0 || priority > MaxWaitlistPriority {
+		return nil, errors.ErrInvalidInput.WithDetails(
+			fmt.Sprintf("priority must be between 0 and %d", MaxWaitlistPriority))
+	}
+
+	now := time.Now()
+	return &WaitlistEntry{
+		ID:              uuid.New(),
+		InventoryItemID: inventoryItemID,
+		OrderID:         orderID,
+		Quantity:        quantity,
+		Priority:        priority,
+		Status:          WaitlistWaiting,
+		ExpiresAt:       now.Add(ttl),
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}, nil
+}
+
+// IsWaiting returns true if the entry is still queued for stock
+func (e *WaitlistEntry) IsWaiting() bool {
+	return e.Status == WaitlistWaiting
+}
+
+// IsExpired checks if the entry has passed its expiration time
+func (e *WaitlistEntry) IsExpired() bool {
+	return time.Now().After(e.ExpiresAt)
+}
+
+// Fulfill marks the entry as served by the given reservation
+func (e *WaitlistEntry) Fulfill(reservationID uuid.UUID) error {
+	if err := e.transition(WaitlistFulfilled); err != nil {
+		return err
+	}
+	fulfilledAt := e.UpdatedAt
+	e.ReservationID = &reservationID
+	e.FulfilledAt = &fulfilledAt
+	return nil
+}
+
+// Cancel marks the entry as cancelled by the caller
+func (e *WaitlistEntry) Cancel() error {
+	return e.transition(WaitlistCancelled)
+}
+
+// Expire marks the entry as expired
+func (e *WaitlistEntry) Expire() error {
+	return e.transition(WaitlistExpired)
+}
+
+// Fail marks the entry as failed for the given reason
+func (e *WaitlistEntry) Fail(reason string) error {
+	if err := e.transition(WaitlistFailed); err != nil {
+		return err
+	}
+	e.FailureReason = reason
+	return nil
+}
+
+// transition moves a waiting entry to a final status
+func (e *WaitlistEntry) transition(status WaitlistStatus) error {
+	if !e.IsWaiting() {
+		return errors.ErrWaitlistEntryNotWaiting.WithDetails("status: " + string(e.Status))
+	}
+	e.Status = status
+	e.UpdatedAt = time.Now()
+	return nil
+}
+
+// ParseWaitlistStatus converts a string to a WaitlistStatus
+func ParseWaitlistStatus(s string) (WaitlistStatus, error) {
+	status := WaitlistStatus(s)
+	switch status {
+	case WaitlistWaiting, WaitlistFulfilled, WaitlistCancelled, WaitlistExpired, WaitlistFailed:
+		return status, nil
+	default:
+		return "", errors.ErrInvalidInput.WithDetails("unknown waitlist status: " + s)
+	}
+}
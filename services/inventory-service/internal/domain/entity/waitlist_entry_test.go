@@ -0,0 +1,105 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewWaitlistEntry(t *testing.T) {
+	itemID, orderID := uuid.New(), uuid.New()
+
+	t.Run("should create a waiting entry", func(t *testing.T) {
+		entry, err := NewWaitlistEntry(itemID, orderID, 3, 10, time.Hour)
+		require.NoError(t, err)
+		assert.NotEqual(t, uuid.Nil, entry.ID)
+		assert.Equal(t, itemID, entry.InventoryItemID)
+		assert.Equal(t, orderID, entry.OrderID)
+		assert.Equal(t, 3, entry.Quantity)
+		assert.Equal(t, 10, entry.Priority)
+		assert.Equal(t, WaitlistWaiting, entry.Status)
+		assert.WithinDuration(t, time.Now().Add(time.Hour), entry.ExpiresAt, time.Second)
+		assert.True(t, entry.IsWaiting())
+		assert.False(t, entry.IsExpired())
+	})
+
+	tests := []struct {
+		name     string
+		quantity int
+		priority int
+		ttl      time.Duration
+		wantErr  error
+	}{
+		{"zero quantity", 0, 0, time.Hour, errors.ErrInvalidQuantity},
+		{"zero ttl", 1, 0, 0, errors.ErrInvalidDuration},
+		{"negative priority", 1, -1, time.Hour, errors.ErrInvalidInput},
+		{"priority too high", 1, MaxWaitlistPriority + 1, time.Hour, errors.ErrInvalidInput},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewWaitlistEntry(itemID, orderID, tt.quantity, tt.priority, tt.ttl)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestWaitlistEntry_Transitions(t *testing.T) {
+	newEntry := func(t *testing.T) *WaitlistEntry {
+		entry, err := NewWaitlistEntry(uuid.New(), uuid.New(), 1, 0, time.Hour)
+		require.NoError(t, err)
+		return entry
+	}
+
+	t.Run("should fulfill a waiting entry", func(t *testing.T) {
+		entry := newEntry(t)
+		reservationID := uuid.New()
+
+		require.NoError(t, entry.Fulfill(reservationID))
+		assert.Equal(t, WaitlistFulfilled, entry.Status)
+		require.NotNil(t, entry.ReservationID)
+		assert.Equal(t, reservationID, *entry.ReservationID)
+		require.NotNil(t, entry.FulfilledAt)
+	})
+
+	t.Run("should record why an entry failed", func(t *testing.T) {
+		entry := newEntry(t)
+
+		require.NoError(t, entry.Fail("purchase limit exceeded"))
+		assert.Equal(t, WaitlistFailed, entry.Status)
+		assert.Equal(t, "purchase limit exceeded", entry.FailureReason)
+	})
+
+	t.Run("should cancel and expire waiting entries", func(t *testing.T) {
+		cancelled, expired := newEntry(t), newEntry(t)
+
+		require.NoError(t, cancelled.Cancel())
+		require.NoError(t, expired.Expire())
+		assert.Equal(t, WaitlistCancelled, cancelled.Status)
+		assert.Equal(t, WaitlistExpired, expired.Status)
+	})
+
+	t.Run("should only change waiting entries", func(t *testing.T) {
+		entry := newEntry(t)
+		require.NoError(t, entry.Cancel())
+
+		assert.ErrorIs(t, entry.Fulfill(uuid.New()), errors.ErrWaitlistEntryNotWaiting)
+		assert.ErrorIs(t, entry.Cancel(), errors.ErrWaitlistEntryNotWaiting)
+		assert.ErrorIs(t, entry.Expire(), errors.ErrWaitlistEntryNotWaiting)
+		assert.ErrorIs(t, entry.Fail("late"), errors.ErrWaitlistEntryNotWaiting)
+		assert.Nil(t, entry.ReservationID)
+		assert.Empty(t, entry.FailureReason)
+	})
+}
+
+func TestParseWaitlistStatus(t *testing.T) {
+	status, err := ParseWaitlistStatus("fulfilled")
+	require.NoError(t, err)
+	assert.Equal(t, WaitlistFulfilled, status)
+
+	_, err = ParseWaitlistStatus("served")
+	assert.ErrorIs(t, err, errors.ErrInvalidInput)
+}
@@ -84,6 +84,156 @@ var (
 		Message: "inventory item already exists for this product",
 	}
 
+	// ErrInventoryItemArchived is returned when reserving stock of a product deactivated in the catalog.
+	ErrInventoryItemArchived = &DomainError{
+		Code:    "INVENTORY_ITEM_ARCHIVED",
+		Message: "inventory item is archived because the product is no longer active",
+	}
+
+	// ErrStockHistoryUnavailable is returned when the stock is requested at a moment
+	// before the earliest retained stock snapshot.
+	ErrStockHistoryUnavailable = &DomainError{
+		Code:    "STOCK_HISTORY_UNAVAILABLE",
+		Message: "no stock history is retained for the requested time",
+	}
+
+	// ErrLotAlreadyExists is returned when receiving a lot number the inventory item already has.
+	ErrLotAlreadyExists = &DomainError{
+		Code:    "LOT_ALREADY_EXISTS",
+		Message: "lot already exists for this inventory item",
+	}
+
+	// ErrSerialTrackedItem is returned when changing the stock of a serial-tracked item by count
+	// instead of through its serial units.
+	ErrSerialTrackedItem = &DomainError{
+		Code:    "SERIAL_TRACKED_ITEM",
+		Message: "the stock of a serial-tracked item only changes through its serial units",
+	}
+
+	// ErrNotSerialTracked is returned when using serial units of an item that is not serial-tracked.
+	ErrNotSerialTracked = &DomainError{
+		Code:    "NOT_SERIAL_TRACKED",
+		Message: "inventory item is not serial-tracked",
+	}
+
+	// ErrSerialTrackingChange is returned when switching serial tracking of an item that has stock.
+	ErrSerialTrackingChange = &DomainError{
+		Code:    "SERIAL_TRACKING_CHANGE",
+		Message: "serial tracking can only be switched while the inventory item has no stock",
+	}
+
+	// ErrSerialUnitAlreadyExists is returned when registering a serial number the inventory item already has.
+	ErrSerialUnitAlreadyExists = &DomainError{
+		Code:    "SERIAL_UNIT_ALREADY_EXISTS",
+		Message: "serial number already exists for this inventory item",
+	}
+
+	// ErrSerialUnitNotFound is returned when a serial unit doesn't exist.
+	ErrSerialUnitNotFound = &DomainError{
+		Code:    "SERIAL_UNIT_NOT_FOUND",
+		Message: "serial unit not found",
+	}
+
+	// ErrInvalidSerialTransition is returned when a serial unit cannot move to the requested state.
+	ErrInvalidSerialTransition = &DomainError{
+		Code:    "INVALID_SERIAL_TRANSITION",
+		Message: "serial unit cannot move to the requested state",
+	}
+
+	// ErrBundleItem is returned when changing the stock of a bundle by count instead of
+	// through its components.
+	ErrBundleItem = &DomainError{
+		Code:    "BUNDLE_ITEM",
+		Message: "the stock of a bundle is the stock of its components",
+	}
+
+	// ErrBundleNotFound is returned when an inventory item is not a bundle.
+	ErrBundleNotFound = &DomainError{
+		Code:    "BUNDLE_NOT_FOUND",
+		Message: "bundle not found",
+	}
+
+	// ErrInvalidBundle is returned when a bundle definition is invalid.
+	ErrInvalidBundle = &DomainError{
+		Code:    "INVALID_BUNDLE",
+		Message: "invalid bundle definition",
+	}
+
+	// ErrBundleChange is returned when making a bundle of an item that has stock of its own.
+	ErrBundleChange = &DomainError{
+		Code:    "BUNDLE_CHANGE",
+		Message: "only an inventory item without stock can be made a bundle",
+	}
+
+	// ErrBundleInUse is returned when removing a bundle that has pending reservations.
+	ErrBundleInUse = &DomainError{
+		Code:    "BUNDLE_IN_USE",
+		Message: "bundle has pending reservations",
+	}
+
+	// ErrChannelAllocatedItem is returned when reserving stock of an item with channel
+	// allocations without checking the allocation of the sales channel.
+	ErrChannelAllocatedItem = &DomainError{
+		Code:    "CHANNEL_ALLOCATED_ITEM",
+		Message: "the stock of an inventory item with channel allocations is reserved per sales channel",
+	}
+
+	// ErrInvalidChannel is returned when a sales channel name is invalid.
+	ErrInvalidChannel = &DomainError{
+		Code:    "INVALID_CHANNEL",
+		Message: "invalid sales channel",
+	}
+
+	// ErrInvalidAllocation is returned when channel allocation rules are invalid.
+	ErrInvalidAllocation = &DomainError{
+		Code:    "INVALID_ALLOCATION",
+		Message: "invalid channel allocation",
+	}
+
+	// ErrInvalidPurchaseLimit is returned when purchase limit rules are invalid.
+	ErrInvalidPurchaseLimit = &DomainError{
+		Code:    "INVALID_PURCHASE_LIMIT",
+		Message: "invalid purchase limit",
+	}
+
+	// ErrPurchaseLimitNotFound is returned when a product has no purchase limits.
+	ErrPurchaseLimitNotFound = &DomainError{
+		Code:    "PURCHASE_LIMIT_NOT_FOUND",
+		Message: "purchase limit not found",
+	}
+
+	// ErrPurchaseLimitExceeded is returned when a reservation exceeds the quantity a
+	// product can be bought in per order or per customer.
+	ErrPurchaseLimitExceeded = &DomainError{
+		Code:    "PURCHASE_LIMIT_EXCEEDED",
+		Message: "purchase limit exceeded",
+	}
+
+	// ErrInvalidUserID is returned when the end-user ID of a request is invalid.
+	ErrInvalidUserID = &DomainError{
+		Code:    "INVALID_USER_ID",
+		Message: "invalid user id",
+	}
+
+	// ErrWaitlistEntryNotFound is returned when a waitlist entry doesn't exist.
+	ErrWaitlistEntryNotFound = &DomainError{
+		Code:    "WAITLIST_ENTRY_NOT_FOUND",
+		Message: "waitlist entry not found",
+	}
+
+	// ErrWaitlistEntryAlreadyExists is returned when an order is already waiting for stock.
+	ErrWaitlistEntryAlreadyExists = &DomainError{
+		Code:    "WAITLIST_ENTRY_ALREADY_EXISTS",
+		Message: "order is already on a waitlist",
+	}
+
+	// ErrWaitlistEntryNotWaiting is returned when cancelling a waitlist entry that
+	// was already fulfilled, cancelled, expired or failed.
+	ErrWaitlistEntryNotWaiting = &DomainError{
+		Code:    "WAITLIST_ENTRY_NOT_WAITING",
+		Message: "waitlist entry is not waiting",
+	}
+
 	// ErrOptimisticLockFailure is returned when an optimistic locking conflict occurs.
 	// This happens when the Version field has changed since the entity was read.
 	ErrOptimisticLockFailure = &DomainError{
@@ -208,13 +358,17 @@ func GetCategory(err error) ErrorCategory {
 	}
 
 	switch de.Code {
-	case "INVALID_QUANTITY", "INVALID_DURATION", "INVALID_INPUT", "NEGATIVE_QUANTITY":
+	case "INVALID_QUANTITY", "INVALID_DURATION", "INVALID_INPUT", "NEGATIVE_QUANTITY", "INVALID_BUNDLE", "INVALID_CHANNEL", "INVALID_ALLOCATION",
+		"INVALID_PURCHASE_LIMIT", "INVALID_USER_ID":
 		return CategoryValidation
-	case "PRODUCT_NOT_FOUND", "INVENTORY_ITEM_NOT_FOUND", "RESERVATION_NOT_FOUND", "NOT_FOUND":
+	case "PRODUCT_NOT_FOUND", "INVENTORY_ITEM_NOT_FOUND", "RESERVATION_NOT_FOUND", "SERIAL_UNIT_NOT_FOUND", "BUNDLE_NOT_FOUND", "STOCK_HISTORY_UNAVAILABLE", "PURCHASE_LIMIT_NOT_FOUND", "WAITLIST_ENTRY_NOT_FOUND",
+		"NOT_FOUND":
 		return CategoryNotFound
-	case "INVENTORY_ITEM_ALREADY_EXISTS", "RESERVATION_ALREADY_EXISTS", "ALREADY_EXISTS", "OPTIMISTIC_LOCK_FAILURE", "CONCURRENT_MODIFICATION":
+	case "INVENTORY_ITEM_ALREADY_EXISTS", "LOT_ALREADY_EXISTS", "SERIAL_UNIT_ALREADY_EXISTS", "RESERVATION_ALREADY_EXISTS", "WAITLIST_ENTRY_ALREADY_EXISTS", "ALREADY_EXISTS", "BUNDLE_IN_USE", "OPTIMISTIC_LOCK_FAILURE", "CONCURRENT_MODIFICATION":
 		return CategoryConflict
-	case "INSUFFICIENT_STOCK", "INVALID_RESERVATION_RELEASE", "INVALID_RESERVATION_CONFIRM", "RESERVATION_NOT_PENDING":
+	case "INSUFFICIENT_STOCK", "INVENTORY_ITEM_ARCHIVED", "SERIAL_TRACKED_ITEM", "NOT_SERIAL_TRACKED", "SERIAL_TRACKING_CHANGE",
+		"INVALID_SERIAL_TRANSITION", "BUNDLE_ITEM", "BUNDLE_CHANGE", "CHANNEL_ALLOCATED_ITEM", "PURCHASE_LIMIT_EXCEEDED", "INVALID_RESERVATION_RELEASE", "INVALID_RESERVATION_CONFIRM", "RESERVATION_NOT_PENDING",
+		"WAITLIST_ENTRY_NOT_WAITING":
 		return CategoryBusinessRule
 	case "RESERVATION_EXPIRED", "RESERVATION_NOT_EXPIRED":
 		return CategoryExpired
@@ -91,6 +91,30 @@ func TestInventoryErrors(t *testing.T) {
 			{"ProductNotFound", ErrProductNotFound, "PRODUCT_NOT_FOUND", "product not found in inventory"},
 			{"InventoryItemNotFound", ErrInventoryItemNotFound, "INVENTORY_ITEM_NOT_FOUND", "inventory item not found"},
 			{"InventoryItemAlreadyExists", ErrInventoryItemAlreadyExists, "INVENTORY_ITEM_ALREADY_EXISTS", "inventory item already exists for this product"},
+			{"InventoryItemArchived", ErrInventoryItemArchived, "INVENTORY_ITEM_ARCHIVED", "inventory item is archived because the product is no longer active"},
+			{"StockHistoryUnavailable", ErrStockHistoryUnavailable, "STOCK_HISTORY_UNAVAILABLE", "no stock history is retained for the requested time"},
+			{"LotAlreadyExists", ErrLotAlreadyExists, "LOT_ALREADY_EXISTS", "lot already exists for this inventory item"},
+			{"SerialTrackedItem", ErrSerialTrackedItem, "SERIAL_TRACKED_ITEM", "the stock of a serial-tracked item only changes through its serial units"},
+			{"NotSerialTracked", ErrNotSerialTracked, "NOT_SERIAL_TRACKED", "inventory item is not serial-tracked"},
+			{"SerialTrackingChange", ErrSerialTrackingChange, "SERIAL_TRACKING_CHANGE", "serial tracking can only be switched while the inventory item has no stock"},
+			{"SerialUnitAlreadyExists", ErrSerialUnitAlreadyExists, "SERIAL_UNIT_ALREADY_EXISTS", "serial number already exists for this inventory item"},
+			{"SerialUnitNotFound", ErrSerialUnitNotFound, "SERIAL_UNIT_NOT_FOUND", "serial unit not found"},
+			{"InvalidSerialTransition", ErrInvalidSerialTransition, "INVALID_SERIAL_TRANSITION", "serial unit cannot move to the requested state"},
+			{"BundleItem", ErrBundleItem, "BUNDLE_ITEM", "the stock of a bundle is the stock of its components"},
+			{"BundleNotFound", ErrBundleNotFound, "BUNDLE_NOT_FOUND", "bundle not found"},
+			{"InvalidBundle", ErrInvalidBundle, "INVALID_BUNDLE", "invalid bundle definition"},
+			{"BundleChange", ErrBundleChange, "BUNDLE_CHANGE", "only an inventory item without stock can be made a bundle"},
+			{"BundleInUse", ErrBundleInUse, "BUNDLE_IN_USE", "bundle has pending reservations"},
+			{"ChannelAllocatedItem", ErrChannelAllocatedItem, "CHANNEL_ALLOCATED_ITEM", "the stock of an inventory item with channel allocations is reserved per sales channel"},
+			{"InvalidChannel", ErrInvalidChannel, "INVALID_CHANNEL", "invalid sales channel"},
+			{"InvalidAllocation", ErrInvalidAllocation, "INVALID_ALLOCATION", "invalid channel allocation"},
+			{"InvalidPurchaseLimit", ErrInvalidPurchaseLimit, "INVALID_PURCHASE_LIMIT", "invalid purchase limit"},
+			{"PurchaseLimitNotFound", ErrPurchaseLimitNotFound, "PURCHASE_LIMIT_NOT_FOUND", "purchase limit not found"},
+			{"PurchaseLimitExceeded", ErrPurchaseLimitExceeded, "PURCHASE_LIMIT_EXCEEDED", "purchase limit exceeded"},
+			{"InvalidUserID", ErrInvalidUserID, "INVALID_USER_ID", "invalid user id"},
+			{"WaitlistEntryNotFound", ErrWaitlistEntryNotFound, "WAITLIST_ENTRY_NOT_FOUND", "waitlist entry not found"},
+			{"WaitlistEntryAlreadyExists", ErrWaitlistEntryAlreadyExists, "WAITLIST_ENTRY_ALREADY_EXISTS", "order is already on a waitlist"},
+			{"WaitlistEntryNotWaiting", ErrWaitlistEntryNotWaiting, "WAITLIST_ENTRY_NOT_WAITING", "waitlist entry is not waiting"},
 			{"OptimisticLockFailure", ErrOptimisticLockFailure, "OPTIMISTIC_LOCK_FAILURE", "the item has been modified by another transaction, please retry"},
 		}
 
@@ -171,25 +195,49 @@ func TestGetCategory(t *testing.T) {
 		{"InvalidDuration", ErrInvalidDuration, CategoryValidation},
 		{"InvalidInput", ErrInvalidInput, CategoryValidation},
 		{"NegativeQuantity", ErrNegativeQuantity, CategoryValidation},
+		{"InvalidBundle", ErrInvalidBundle, CategoryValidation},
+		{"InvalidChannel", ErrInvalidChannel, CategoryValidation},
+		{"InvalidAllocation", ErrInvalidAllocation, CategoryValidation},
+		{"InvalidPurchaseLimit", ErrInvalidPurchaseLimit, CategoryValidation},
+		{"InvalidUserID", ErrInvalidUserID, CategoryValidation},
 
 		// NotFound errors
 		{"ProductNotFound", ErrProductNotFound, CategoryNotFound},
 		{"InventoryItemNotFound", ErrInventoryItemNotFound, CategoryNotFound},
 		{"ReservationNotFound", ErrReservationNotFound, CategoryNotFound},
+		{"SerialUnitNotFound", ErrSerialUnitNotFound, CategoryNotFound},
+		{"BundleNotFound", ErrBundleNotFound, CategoryNotFound},
+		{"PurchaseLimitNotFound", ErrPurchaseLimitNotFound, CategoryNotFound},
+		{"WaitlistEntryNotFound", ErrWaitlistEntryNotFound, CategoryNotFound},
+		{"StockHistoryUnavailable", ErrStockHistoryUnavailable, CategoryNotFound},
 		{"NotFound", ErrNotFound, CategoryNotFound},
 
 		// Conflict errors
 		{"InventoryItemAlreadyExists", ErrInventoryItemAlreadyExists, CategoryConflict},
+		{"LotAlreadyExists", ErrLotAlreadyExists, CategoryConflict},
+		{"SerialUnitAlreadyExists", ErrSerialUnitAlreadyExists, CategoryConflict},
 		{"ReservationAlreadyExists", ErrReservationAlreadyExists, CategoryConflict},
+		{"WaitlistEntryAlreadyExists", ErrWaitlistEntryAlreadyExists, CategoryConflict},
+		{"BundleInUse", ErrBundleInUse, CategoryConflict},
 		{"AlreadyExists", ErrAlreadyExists, CategoryConflict},
 		{"OptimisticLockFailure", ErrOptimisticLockFailure, CategoryConflict},
 		{"ConcurrentModification", ErrConcurrentModification, CategoryConflict},
 
 		// BusinessRule errors
 		{"InsufficientStock", ErrInsufficientStock, CategoryBusinessRule},
+		{"InventoryItemArchived", ErrInventoryItemArchived, CategoryBusinessRule},
+		{"SerialTrackedItem", ErrSerialTrackedItem, CategoryBusinessRule},
+		{"NotSerialTracked", ErrNotSerialTracked, CategoryBusinessRule},
+		{"SerialTrackingChange", ErrSerialTrackingChange, CategoryBusinessRule},
+		{"InvalidSerialTransition", ErrInvalidSerialTransition, CategoryBusinessRule},
+		{"BundleItem", ErrBundleItem, CategoryBusinessRule},
+		{"BundleChange", ErrBundleChange, CategoryBusinessRule},
+		{"ChannelAllocatedItem", ErrChannelAllocatedItem, CategoryBusinessRule},
+		{"PurchaseLimitExceeded", ErrPurchaseLimitExceeded, CategoryBusinessRule},
 		{"InvalidReservationRelease", ErrInvalidReservationRelease, CategoryBusinessRule},
 		{"InvalidReservationConfirm", ErrInvalidReservationConfirm, CategoryBusinessRule},
 		{"ReservationNotPending", ErrReservationNotPending, CategoryBusinessRule},
+		{"WaitlistEntryNotWaiting", ErrWaitlistEntryNotWaiting, CategoryBusinessRule},
 
 		// Expired errors
 		{"ReservationExpired", ErrReservationExpired, CategoryExpired},
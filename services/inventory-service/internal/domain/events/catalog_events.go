@@ -0,0 +1,42 @@
+package events
+
+import "time"
+
+// ProductPayload contains the catalog data of a product event. The orders
+// service owns the catalog; the inventory service only reacts to it.
+type ProductPayload struct {
+	ProductID string    `json:"productId"`
+	SKU       string    `json:"sku"`
+	Name      string    `json:"name"`
+	IsActive  bool      `json:"isActive"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// ProductEvent represents a product.created, product.updated or product.deactivated event
+type ProductEvent struct {
+	BaseEvent
+	Payload ProductPayload `json:"payload"`
+}
+
+// Catalog event routing keys, published by the orders service on ExchangeOrdersEvents
+const (
+	RoutingKeyProductCreated     = "product.created"
+	RoutingKeyProductUpdated     = "product.updated"
+	RoutingKeyProductDeactivated = "product.deactivated"
+)
+
+// ExchangeOrdersEvents is the exchange the orders service publishes on
+const ExchangeOrdersEvents = "orders.events"
+
+// QueueCatalogEvents is the queue the inventory service consumes catalog events from
+const QueueCatalogEvents = "inventory.catalog_events"
+
+// IsProductEvent reports whether the routing key is one of the catalog events
+func IsProductEvent(routingKey string) bool {
+	switch routingKey {
+	case RoutingKeyProductCreated, RoutingKeyProductUpdated, RoutingKeyProductDeactivated:
+		return true
+	default:
+		return false
+	}
+}
@@ -14,13 +14,22 @@ type BaseEvent struct {
 
 // StockReservedPayload contains the data for a stock reserved event
 type StockReservedPayload struct {
-	ReservationID string    `json:"reservationId"`
-	ProductID     string    `json:"productId"`
-	Quantity      int       `json:"quantity"`
-	OrderID       string    `json:"orderId"`
-	UserID        string    `json:"userId"`
-	ExpiresAt     time.Time `json:"expiresAt"`
-	ReservedAt    time.Time `json:"reservedAt"`
+	ReservationID string              `json:"reservationId"`
+	ProductID     string              `json:"productId"`
+	Quantity      int                 `json:"quantity"`
+	OrderID       string              `json:"orderId"`
+	UserID        string              `json:"userId"`
+	ExpiresAt     time.Time           `json:"expiresAt"`
+	ReservedAt    time.Time           `json:"reservedAt"`
+	Serials       []string            `json:"serials,omitempty"`    // serial-tracked products only, since 1.1.0
+	Components    []ComponentQuantity `json:"components,omitempty"` // bundles only, since 1.2.0
+	Channel       string              `json:"channel,omitempty"`    // sales channel, since 1.3.0
+}
+
+// ComponentQuantity contains the units of a bundle component held by a reservation
+type ComponentQuantity struct {
+	ProductID string `json:"productId"`
+	Quantity  int    `json:"quantity"`
 }
 
 // StockReservedEvent represents a stock reservation event
@@ -31,12 +40,15 @@ type StockReservedEvent struct {
 
 // StockConfirmedPayload contains the data for a stock confirmed event
 type StockConfirmedPayload struct {
-	ReservationID string    `json:"reservationId"`
-	ProductID     string    `json:"productId"`
-	Quantity      int       `json:"quantity"`
-	OrderID       string    `json:"orderId"`
-	UserID        string    `json:"userId"`
-	ConfirmedAt   time.Time `json:"confirmedAt"`
+	ReservationID string              `json:"reservationId"`
+	ProductID     string              `json:"productId"`
+	Quantity      int                 `json:"quantity"`
+	OrderID       string              `json:"orderId"`
+	UserID        string              `json:"userId"`
+	ConfirmedAt   time.Time           `json:"confirmedAt"`
+	Serials       []string            `json:"serials,omitempty"`    // serial-tracked products only, since 1.1.0
+	Components    []ComponentQuantity `json:"components,omitempty"` // bundles only, since 1.2.0
+	Channel       string              `json:"channel,omitempty"`    // sales channel, since 1.3.0
 }
 
 // StockConfirmedEvent represents a stock confirmation event
@@ -47,13 +59,16 @@ type StockConfirmedEvent struct {
 
 // StockReleasedPayload contains the data for a stock released event
 type StockReleasedPayload struct {
-	ReservationID string    `json:"reservationId"`
-	ProductID     string    `json:"productId"`
-	Quantity      int       `json:"quantity"`
-	OrderID       string    `json:"orderId"`
-	UserID        string    `json:"userId"`
-	Reason        string    `json:"reason"` // "order_cancelled", "reservation_expired", "manual_release"
-	ReleasedAt    time.Time `json:"releasedAt"`
+	ReservationID string              `json:"reservationId"`
+	ProductID     string              `json:"productId"`
+	Quantity      int                 `json:"quantity"`
+	OrderID       string              `json:"orderId"`
+	UserID        string              `json:"userId"`
+	Reason        string              `json:"reason"` // "order_cancelled", "reservation_expired", "manual_release"
+	ReleasedAt    time.Time           `json:"releasedAt"`
+	Serials       []string            `json:"serials,omitempty"`    // serial-tracked products only, since 1.1.0
+	Components    []ComponentQuantity `json:"components,omitempty"` // bundles only, since 1.2.0
+	Channel       string              `json:"channel,omitempty"`    // sales channel, since 1.3.0
 }
 
 // StockReleasedEvent represents a stock release event
@@ -96,13 +111,53 @@ type StockDepletedEvent struct {
 	Payload StockDepletedPayload `json:"payload"`
 }
 
+// LotQuarantinedPayload contains the data for a lot quarantined event
+type LotQuarantinedPayload struct {
+	LotID           string    `json:"lotId"`
+	LotNumber       string    `json:"lotNumber"`
+	ProductID       string    `json:"productId"`
+	InventoryItemID string    `json:"inventoryItemId"`
+	Quantity        int       `json:"quantity"`   // Units taken out of the available stock
+	BestBefore      string    `json:"bestBefore"` // Date, YYYY-MM-DD
+	QuarantinedAt   time.Time `json:"quarantinedAt"`
+}
+
+// LotQuarantinedEvent represents expired units of a lot taken out of the stock
+type LotQuarantinedEvent struct {
+	BaseEvent
+	Payload LotQuarantinedPayload `json:"payload"`
+}
+
+// WaitlistFulfilledPayload contains the data for a waitlist fulfilled event
+type WaitlistFulfilledPayload struct {
+	EntryID       string    `json:"entryId"`
+	ReservationID string    `json:"reservationId"`
+	ProductID     string    `json:"productId"`
+	OrderID       string    `json:"orderId"`
+	UserID        string    `json:"userId"`
+	Quantity      int       `json:"quantity"`
+	Priority      int       `json:"priority"`
+	Channel       string    `json:"channel,omitempty"` // Sales channel of the reservation, omitted for the shared pool
+	JoinedAt      time.Time `json:"joinedAt"`
+	ExpiresAt     time.Time `json:"expiresAt"` // When the reservation expires unless confirmed
+	FulfilledAt   time.Time `json:"fulfilledAt"`
+}
+
+// WaitlistFulfilledEvent represents a reservation created automatically for a waitlist entry
+type WaitlistFulfilledEvent struct {
+	BaseEvent
+	Payload WaitlistFulfilledPayload `json:"payload"`
+}
+
 // Event routing keys
 const (
-	RoutingKeyStockReserved  = "inventory.stock.reserved"
-	RoutingKeyStockConfirmed = "inventory.stock.confirmed"
-	RoutingKeyStockReleased  = "inventory.stock.released"
-	RoutingKeyStockFailed    = "inventory.stock.failed"
-	RoutingKeyStockDepleted  = "inventory.stock.depleted"
+	RoutingKeyStockReserved     = "inventory.stock.reserved"
+	RoutingKeyStockConfirmed    = "inventory.stock.confirmed"
+	RoutingKeyStockReleased     = "inventory.stock.released"
+	RoutingKeyStockFailed       = "inventory.stock.failed"
+	RoutingKeyStockDepleted     = "inventory.stock.depleted"
+	RoutingKeyLotQuarantined    = "inventory.lot.quarantined"
+	RoutingKeyWaitlistFulfilled = "inventory.waitlist.fulfilled"
 )
 
 // Exchange name
@@ -111,5 +166,41 @@ const ExchangeInventoryEvents = "inventory.events"
 // Event source
 const SourceInventoryService = "inventory-service"
 
-// Event version
+// Schema versions per event type. Bump the version of a type (and add its
+// schema under infrastructure/messaging/schema/schemas) whenever its payload changes.
+const (
+	StockReservedVersion     = "1.3.0"
+	StockConfirmedVersion    = "1.3.0"
+	StockReleasedVersion     = "1.3.0"
+	StockFailedVersion       = "1.0.0"
+	StockDepletedVersion     = "1.0.0"
+	LotQuarantinedVersion    = "1.0.0"
+	WaitlistFulfilledVersion = "1.0.0"
+)
+
+// EventVersion is the version every event shared before versions were tracked per type.
+//
+// Deprecated: use the per-type version constants or VersionOf.
 const EventVersion = "1.0.0"
+
+// VersionOf returns the current schema version of an event type, or "" if unknown
+func VersionOf(eventType string) string {
+	switch eventType {
+	case RoutingKeyStockReserved:
+		return StockReservedVersion
+	case RoutingKeyStockConfirmed:
+		return StockConfirmedVersion
+	case RoutingKeyStockReleased:
+		return StockReleasedVersion
+	case RoutingKeyStockFailed:
+		return StockFailedVersion
+	case RoutingKeyStockDepleted:
+		return StockDepletedVersion
+	case RoutingKeyLotQuarantined:
+		return LotQuarantinedVersion
+	case RoutingKeyWaitlistFulfilled:
+		return WaitlistFulfilledVersion
+	default:
+		return ""
+	}
+}
@@ -20,6 +20,12 @@ type Publisher interface {
 	// PublishStockDepleted publishes a stock depleted event (when quantity reaches 0)
 	PublishStockDepleted(ctx context.Context, event StockDepletedEvent) error
 
+	// PublishLotQuarantined publishes a lot quarantined event (expired units taken out of the stock)
+	PublishLotQuarantined(ctx context.Context, event LotQuarantinedEvent) error
+
+	// PublishWaitlistFulfilled publishes a waitlist fulfilled event (a reservation created for a waiting order)
+	PublishWaitlistFulfilled(ctx context.Context, event WaitlistFulfilledEvent) error
+
 	// Close closes the publisher and releases resources
 	Close() error
 }
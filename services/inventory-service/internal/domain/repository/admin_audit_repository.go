@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+)
+
+// AdminAuditFilter narrows an admin audit log query.
+// Zero values are ignored.
+type AdminAuditFilter struct {
+	Actor    string
+	Route    string
+	Outcome  entity.AdminAuditOutcome
+	EntityID string // matches entries whose affected IDs contain this value
+	From     time.Time
+	To       time.Time
+	Limit    int
+	Offset   int
+}
+
+// AdminAuditRepository defines the contract for the append-only admin audit log.
+type AdminAuditRepository interface {
+	// Save appends an entry to the audit log.
+	Save(ctx context.Context, entry *entity.AdminAuditEntry) error
+
+	// List returns entries matching the filter, newest first, and the total number of matches.
+	List(ctx context.Context, filter AdminAuditFilter) ([]*entity.AdminAuditEntry, int64, error)
+}
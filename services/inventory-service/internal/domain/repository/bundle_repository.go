@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	"github.com/google/uuid"
+)
+
+// BundleRepository defines the contract for bundle persistence operations.
+// A bundle reservation holds units of every component of the bundle. The methods
+// that change them lock the component items and update every one of them, or
+// none, in one transaction, incrementing their Version.
+type BundleRepository interface {
+	// Save makes the bundle's item a bundle of its components, replacing the
+	// components of an existing bundle. Pending reservations keep the components
+	// they were made with. Returns the bundle's item as stored afterwards,
+	// ErrInventoryItemNotFound if an item does not exist, ErrBundleChange if the
+	// bundle's item has stock of its own and ErrInvalidBundle if it is a component
+	// of another bundle or a component is a bundle or serial-tracked.
+	Save(ctx context.Context, bundle *entity.Bundle) (*entity.InventoryItem, error)
+
+	// FindByInventoryItemID retrieves the bundle sold as the inventory item.
+	// Returns ErrBundleNotFound if the item is not a bundle.
+	FindByInventoryItemID(ctx context.Context, inventoryItemID uuid.UUID) (*entity.Bundle, error)
+
+	// Delete removes the components of the bundle and makes its item hold stock of
+	// its own again. Returns the item as stored afterwards, ErrBundleNotFound if
+	// the item is not a bundle and ErrBundleInUse if reservations still hold units
+	// of its components.
+	Delete(ctx context.Context, inventoryItemID uuid.UUID) (*entity.InventoryItem, error)
+
+	// Reserve reserves the units of every component of quantity bundles of the
+	// product for the reservation. Returns ErrBundleNotFound if the product is not
+	// a bundle, and ErrInventoryItemArchived or ErrInsufficientStock, naming the
+	// component in the details, if the bundle or one of its components cannot be
+	// reserved, in which case nothing is reserved.
+	Reserve(ctx context.Context, reservationID, productID uuid.UUID, quantity int) (*BundleStockChange, error)
+
+	// Confirm removes the units the reservation holds on the components of the
+	// bundle from their stock. Returns ErrInvalidReservationConfirm if the
+	// reservation holds no units.
+	Confirm(ctx context.Context, reservationID, inventoryItemID uuid.UUID) (*BundleStockChange, error)
+
+	// Release returns the units the reservation holds on the components of the
+	// bundle to their available stock. Returns ErrInvalidReservationRelease if the
+	// reservation holds no units.
+	Release(ctx context.Context, reservationID, inventoryItemID uuid.UUID) (*BundleStockChange, error)
+
+	// FindComponents retrieves the components a reservation holds, sold or released
+	FindComponents(ctx context.Context, reservationID uuid.UUID) ([]*entity.ReservationComponent, error)
+}
+
+// BundleStockChange reports the stock of the components of a bundle reservation after a change
+type BundleStockChange struct {
+	Bundle     *entity.InventoryItem // the bundle's item
+	Components []*entity.ReservationComponent
+	Items      []*entity.InventoryItem // the component items as stored afterwards, in Components order
+}
@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	"github.com/google/uuid"
+)
+
+// ChannelAllocationRepository defines the contract for channel allocation
+// persistence operations. The units a channel holds are the quantities of the
+// pending reservations made for it, so the methods that depend on them lock the
+// inventory item for the whole transaction.
+type ChannelAllocationRepository interface {
+	// FindByInventoryItemID retrieves the allocations of an inventory item, ordered
+	// by channel. Items without allocations return an empty slice.
+	FindByInventoryItemID(ctx context.Context, inventoryItemID uuid.UUID) ([]entity.ChannelAllocation, error)
+
+	// Replace replaces the allocations of an inventory item and marks the item as
+	// allocated to channels while it has any, incrementing its Version. Returns the
+	// item as stored afterwards, ErrInventoryItemNotFound if it does not exist and
+	// ErrInvalidAllocation if it is serial-tracked or a bundle or the allocations
+	// are invalid as a whole.
+	Replace(ctx context.Context, inventoryItemID uuid.UUID, allocations []entity.ChannelAllocation) (*entity.InventoryItem, error)
+
+	// Rebalance moves quantity units of quota, or percentage points, from one
+	// channel to another (see entity.MoveAllocation) and returns the allocations
+	// afterwards. Returns ErrInvalidAllocation if the move is invalid.
+	Rebalance(ctx context.Context, inventoryItemID uuid.UUID, from, to string, quantity int) ([]entity.ChannelAllocation, error)
+
+	// ReservedByChannel returns the units held by the pending reservations of an
+	// inventory item per channel; reservations without a channel are under
+	// entity.SharedPool
+	ReservedByChannel(ctx context.Context, inventoryItemID uuid.UUID) (map[string]int, error)
+
+	// Reserve reserves the reservation's units on the product's inventory item for
+	// the reservation's channel and saves the reservation, linked to the item, in
+	// the same transaction. Returns the item as stored afterwards,
+	// ErrInventoryItemNotFound if it does not exist, ErrInventoryItemArchived if it
+	// is archived, ErrInsufficientStock, naming the channel in the details, if the
+	// channel has less available, and ErrReservationAlreadyExists if the order
+	// already has a reservation.
+	Reserve(ctx context.Context, reservation *entity.Reservation, productID uuid.UUID) (*entity.InventoryItem, error)
+}
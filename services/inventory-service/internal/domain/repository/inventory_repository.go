@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
 	"github.com/google/uuid"
@@ -58,3 +59,54 @@ type InventoryRepository interface {
 	// Returns the new version number.
 	IncrementVersion(ctx context.Context, id uuid.UUID) (int, error)
 }
+
+// AtomicStockRepository applies stock changes as single conditional statements so
+// the stock invariants are enforced by the database instead of a read-modify-write
+// cycle guarded by the Version field. Each method increments Version and returns
+// the item as stored after the change.
+type AtomicStockRepository interface {
+	// ReserveStock increments Reserved if the product has at least quantity available.
+	// Returns ErrInventoryItemNotFound if no item exists for the product and
+	// ErrInsufficientStock if the available stock is lower than quantity.
+	ReserveStock(ctx context.Context, productID uuid.UUID, quantity int) (*entity.InventoryItem, error)
+
+	// ReleaseStock decrements Reserved of the item.
+	// Returns ErrInvalidReservationRelease if less than quantity is reserved.
+	ReleaseStock(ctx context.Context, id uuid.UUID, quantity int) (*entity.InventoryItem, error)
+
+	// ConfirmStock decrements both Reserved and Quantity of the item.
+	// Returns ErrInvalidReservationConfirm if less than quantity is reserved.
+	ConfirmStock(ctx context.Context, id uuid.UUID, quantity int) (*entity.InventoryItem, error)
+
+	// AdjustStock adds delta (positive or negative) to Quantity of the product.
+	// Returns ErrInsufficientStock if the result would drop below Reserved.
+	AdjustStock(ctx context.Context, productID uuid.UUID, delta int) (*entity.InventoryItem, error)
+}
+
+// BulkStockRepository reads and writes the stock of many items at once, for
+// stock count imports and exports.
+type BulkStockRepository interface {
+	// UpdateQuantities saves Quantity of every item in one transaction, using the
+	// Version field for optimistic locking. Either all items are written or none;
+	// on failure the error is a *BulkUpdateError naming the item that failed.
+	// Versions of the items are incremented on success.
+	UpdateQuantities(ctx context.Context, items []*entity.InventoryItem) error
+
+	// StreamAll calls fn for every inventory item ordered by product ID, reading
+	// batchSize items at a time. Iteration stops at the first error returned by fn.
+	StreamAll(ctx context.Context, batchSize int, fn func(item *entity.InventoryItem) error) error
+}
+
+// BulkUpdateError identifies the item that made a bulk update roll back
+type BulkUpdateError struct {
+	Index int // position of the item in the batch
+	Err   error
+}
+
+func (e *BulkUpdateError) Error() string {
+	return fmt.Sprintf("item %d of the batch: %v", e.Index, e.Err)
+}
+
+func (e *BulkUpdateError) Unwrap() error {
+	return e.Err
+}
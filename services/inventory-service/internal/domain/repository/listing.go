@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	"github.com/google/uuid"
+)
+
+// SortDirection orders a listing
+type SortDirection string
+
+const (
+	SortAscending  SortDirection = "asc"
+	SortDescending SortDirection = "desc"
+)
+
+// KeysetCursor marks the last row of a page. Listings continue strictly after
+// (SortValue, ID) in the sort order; the ID breaks ties between equal timestamps.
+type KeysetCursor struct {
+	SortValue time.Time
+	ID        uuid.UUID
+}
+
+// InventorySortField is a column inventory items can be listed by
+type InventorySortField string
+
+const (
+	InventorySortCreatedAt InventorySortField = "created_at"
+	InventorySortUpdatedAt InventorySortField = "updated_at"
+)
+
+// InventoryListFilter selects and orders inventory items. Zero fields do not filter.
+type InventoryListFilter struct {
+	ProductIDs    []uuid.UUID
+	Archived      *bool // nil lists active and archived items
+	LowStockBelow int   // only items whose available quantity (quantity - reserved) is below this
+	CreatedFrom   time.Time
+	CreatedTo     time.Time
+
+	SortBy    InventorySortField
+	Direction SortDirection
+	After     *KeysetCursor // nil for the first page
+	Limit     int
+}
+
+// InventoryListingRepository lists inventory items with keyset pagination
+type InventoryListingRepository interface {
+	// ListItems returns up to filter.Limit items after filter.After in the sort order.
+	ListItems(ctx context.Context, filter InventoryListFilter) ([]*entity.InventoryItem, error)
+
+	// CountItems counts the items matching the filter. The cursor and limit are ignored.
+	CountItems(ctx context.Context, filter InventoryListFilter) (int64, error)
+
+	// EstimateItems returns the query planner's estimate of CountItems, which stays
+	// cheap on large tables. The cursor and limit are ignored.
+	EstimateItems(ctx context.Context, filter InventoryListFilter) (int64, error)
+}
+
+// ReservationSortField is a column reservations can be listed by
+type ReservationSortField string
+
+const (
+	ReservationSortCreatedAt ReservationSortField = "created_at"
+	ReservationSortUpdatedAt ReservationSortField = "updated_at"
+	ReservationSortExpiresAt ReservationSortField = "expires_at"
+)
+
+// ReservationListFilter selects and orders reservations. Zero fields do not filter.
+type ReservationListFilter struct {
+	Status          entity.ReservationStatus
+	ProductID       uuid.UUID
+	OrderID         uuid.UUID
+	InventoryItemID uuid.UUID
+	ExpiresFrom     time.Time
+	ExpiresTo       time.Time
+	CreatedFrom     time.Time
+	CreatedTo       time.Time
+
+	SortBy    ReservationSortField
+	Direction SortDirection
+	After     *KeysetCursor // nil for the first page
+	Limit     int
+}
+
+// ReservationListingRepository lists reservations with keyset pagination
+type ReservationListingRepository interface {
+	// ListReservations returns up to filter.Limit reservations after filter.After in the sort order.
+	ListReservations(ctx context.Context, filter ReservationListFilter) ([]*entity.Reservation, error)
+
+	// CountReservations counts the reservations matching the filter. The cursor and limit are ignored.
+	CountReservations(ctx context.Context, filter ReservationListFilter) (int64, error)
+
+	// EstimateReservations returns the query planner's estimate of CountReservations.
+	// The cursor and limit are ignored.
+	EstimateReservations(ctx context.Context, filter ReservationListFilter) (int64, error)
+}
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	"github.com/google/uuid"
+)
+
+// LotRepository defines the contract for lot persistence operations.
+// The units of a lot are part of the quantity of its inventory item, so the
+// methods that change them keep both in step in one transaction.
+type LotRepository interface {
+	// Receive saves a new lot and adds its units to the quantity of its inventory
+	// item. Returns the item as stored afterwards, ErrInventoryItemNotFound if the
+	// item does not exist, ErrSerialTrackedItem if its stock is tracked by serial
+	// unit, ErrBundleItem if it is a bundle and ErrLotAlreadyExists if it already
+	// has the lot number.
+	Receive(ctx context.Context, lot *entity.Lot) (*entity.InventoryItem, error)
+
+	// FindByInventoryItemID retrieves the lots of an inventory item in
+	// first-expired-first-out order, quarantined and empty lots included.
+	FindByInventoryItemID(ctx context.Context, inventoryItemID uuid.UUID) ([]*entity.Lot, error)
+
+	// Allocate allocates up to quantity units of the item's lots that are not
+	// expired at the given time to the reservation, with entity.AllocateFEFO.
+	// Fewer units are allocated when the lots do not cover quantity; the rest of
+	// the reservation comes from untracked stock.
+	Allocate(ctx context.Context, reservationID, inventoryItemID uuid.UUID, quantity int, at time.Time) ([]*entity.LotAllocation, error)
+
+	// Consume marks the allocations of a confirmed reservation consumed and removes
+	// their units from the lots. Returns the consumed allocations.
+	Consume(ctx context.Context, reservationID uuid.UUID) ([]*entity.LotAllocation, error)
+
+	// Release marks the allocations of a released or expired reservation released
+	// and returns their units to the lots. Returns the released allocations.
+	Release(ctx context.Context, reservationID uuid.UUID) ([]*entity.LotAllocation, error)
+
+	// FindAllocations retrieves every allocation of a reservation, whatever its status.
+	FindAllocations(ctx context.Context, reservationID uuid.UUID) ([]*entity.LotAllocation, error)
+
+	// QuarantineExpired moves the free units of every lot expired at the given time
+	// out of the lot and out of the quantity of its inventory item, one lot per
+	// transaction. Returns the lots that had units quarantined, including those
+	// processed before an error.
+	QuarantineExpired(ctx context.Context, at time.Time) ([]QuarantinedLot, error)
+}
+
+// QuarantinedLot reports the units of an expired lot taken out of the stock
+type QuarantinedLot struct {
+	Lot       *entity.Lot // the lot as stored afterwards
+	ProductID uuid.UUID
+	Units     int
+}
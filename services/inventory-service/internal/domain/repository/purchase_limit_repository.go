@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	"github.com/google/uuid"
+)
+
+// PurchaseLimitRepository defines the contract for purchase limit persistence operations
+type PurchaseLimitRepository interface {
+	// FindByProductID retrieves the limits of a product.
+	// Returns ErrPurchaseLimitNotFound if the product has none.
+	FindByProductID(ctx context.Context, productID uuid.UUID) (*entity.PurchaseLimit, error)
+
+	// Save creates or replaces the limits of an inventory item
+	Save(ctx context.Context, limit *entity.PurchaseLimit) error
+
+	// Delete removes the limits of an inventory item.
+	// Returns ErrPurchaseLimitNotFound if it has none.
+	Delete(ctx context.Context, inventoryItemID uuid.UUID) error
+
+	// CustomerQuantity returns the units of an inventory item held by the pending
+	// and confirmed reservations of a customer created since the given time,
+	// archived reservations included
+	CustomerQuantity(ctx context.Context, inventoryItemID uuid.UUID, userID string, since time.Time) (int, error)
+
+	// LockCustomer runs fn holding the purchase lock of a customer on an inventory
+	// item, released when fn returns, so that checking what the customer reserved
+	// and reserving more is not interleaved with another reservation of theirs.
+	// Returns ErrOptimisticLockFailure without running fn if the lock is held.
+	LockCustomer(ctx context.Context, inventoryItemID uuid.UUID, userID string, fn func() error) error
+}
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	"github.com/google/uuid"
+)
+
+// ReconciliationRepository defines the contract for detecting and repairing
+// drift between inventory items and reservations.
+// Rows changed after settledBefore are ignored, so writes still in flight are not reported.
+// Every repair runs in one transaction with the audit entry that records it and
+// returns false, without writing the entry, when there was nothing left to fix.
+type ReconciliationRepository interface {
+	// FindReservedMismatches returns items whose reserved counter differs from
+	// the sum of their pending reservations.
+	FindReservedMismatches(ctx context.Context, settledBefore time.Time) ([]*entity.InventoryDrift, error)
+
+	// FindOrphanReservations returns pending reservations whose inventory item no longer exists.
+	FindOrphanReservations(ctx context.Context, settledBefore time.Time) ([]*entity.InventoryDrift, error)
+
+	// FindStuckReservations returns pending reservations that expired before expiredBefore.
+	FindStuckReservations(ctx context.Context, expiredBefore time.Time) ([]*entity.InventoryDrift, error)
+
+	// FindStaleLotAllocations returns, per reservation and item, the lot units still
+	// allocated to reservations that are no longer pending.
+	FindStaleLotAllocations(ctx context.Context, settledBefore time.Time) ([]*entity.InventoryDrift, error)
+
+	// FindMissingLotAllocations returns the pending reservations holding units of an
+	// item with free lot units, that have no allocation on the item's lots.
+	FindMissingLotAllocations(ctx context.Context, settledBefore time.Time) ([]*entity.InventoryDrift, error)
+
+	// FindMissingProducts returns the product IDs that have no inventory item.
+	FindMissingProducts(ctx context.Context, productIDs []uuid.UUID) ([]uuid.UUID, error)
+
+	// RecomputeReserved sets the reserved counter of an item to the sum of its pending reservations.
+	RecomputeReserved(ctx context.Context, itemID uuid.UUID, settledBefore time.Time, audit *entity.AdminAuditEntry) (bool, error)
+
+	// ReleaseOrphanReservation marks a pending reservation without inventory item as released.
+	ReleaseOrphanReservation(ctx context.Context, reservationID uuid.UUID, audit *entity.AdminAuditEntry) (bool, error)
+
+	// ExpireStuckReservation marks a stuck reservation as expired, returns its quantity to the item,
+	// makes the serial units it holds available again and releases its lot allocations.
+	ExpireStuckReservation(ctx context.Context, reservationID uuid.UUID, expiredBefore time.Time, audit *entity.AdminAuditEntry) (bool, error)
+
+	// SettleStaleLotAllocations consumes the lot allocations of a confirmed reservation on
+	// the item's lots, or releases them when the reservation was released or expired.
+	SettleStaleLotAllocations(ctx context.Context, reservationID, itemID uuid.UUID, settledBefore time.Time, audit *entity.AdminAuditEntry) (bool, error)
+
+	// AllocateMissingLots allocates the item's lots first-expired-first-out to a pending
+	// reservation that has no allocation on them.
+	AllocateMissingLots(ctx context.Context, reservationID, itemID uuid.UUID, settledBefore time.Time, audit *entity.AdminAuditEntry) (bool, error)
+
+	// CreateMissingItem creates the inventory item of a product unless one already exists.
+	CreateMissingItem(ctx context.Context, item *entity.InventoryItem, audit *entity.AdminAuditEntry) (bool, error)
+}
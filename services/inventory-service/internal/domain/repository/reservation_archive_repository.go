@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	"github.com/google/uuid"
+)
+
+// ArchivedReservationFilter narrows an archived reservation query.
+// Zero values are ignored; From and To bound the creation time.
+type ArchivedReservationFilter struct {
+	OrderID         uuid.UUID
+	InventoryItemID uuid.UUID
+	Status          entity.ReservationStatus
+	From            time.Time
+	To              time.Time
+	Limit           int
+	Offset          int
+}
+
+// ReservationArchiveRepository defines the contract for the reservation history.
+// Terminal reservations are moved, not copied: once archived they are no longer
+// visible through ReservationRepository.
+type ReservationArchiveRepository interface {
+	// ArchiveTerminal moves up to limit confirmed, released or expired reservations
+	// last updated before cutoff to the archive, oldest first, in one transaction.
+	// Returns the number of reservations moved.
+	ArchiveTerminal(ctx context.Context, cutoff time.Time, limit int) (int, error)
+
+	// ListArchived returns archived reservations matching the filter, newest first,
+	// and the total number of matches.
+	ListArchived(ctx context.Context, filter ArchivedReservationFilter) ([]*entity.ArchivedReservation, int64, error)
+
+	// FindArchivedByID retrieves an archived reservation.
+	// Returns ErrReservationNotFound if it is not in the archive.
+	FindArchivedByID(ctx context.Context, id uuid.UUID) (*entity.ArchivedReservation, error)
+}
+
+// ReservationPartitionRepository defines the contract for maintaining the monthly
+// partitions of the reservations table. Partitions cover whole UTC months of created_at.
+type ReservationPartitionRepository interface {
+	// EnsurePartitions creates the missing partitions from the month of from up to
+	// monthsAhead months later and returns the names of the partitions created.
+	// A month whose rows already landed in the default partition is skipped.
+	EnsurePartitions(ctx context.Context, from time.Time, monthsAhead int) ([]string, error)
+
+	// DropEmptyPartitions drops the partitions that end before cutoff and hold no rows,
+	// and returns their names.
+	DropEmptyPartitions(ctx context.Context, cutoff time.Time) ([]string, error)
+
+	// CountDefaultPartitionRows returns the number of reservations outside the monthly partitions.
+	CountDefaultPartitionRows(ctx context.Context) (int64, error)
+}
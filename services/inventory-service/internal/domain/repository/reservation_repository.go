@@ -69,6 +69,9 @@ type ReservationRepository interface {
 
 	// DeleteExpired removes all expired pending reservations from the repository.
 	// Returns the number of deleted reservations.
-	// This is typically used by cleanup jobs.
+	//
+	// Deprecated: deleting destroys the reservation history. Expired reservations
+	// are released by ReleaseExpiredReservationsUseCase and moved to the archive by
+	// ReservationArchiveRepository.ArchiveTerminal once the retention period passes.
 	DeleteExpired(ctx context.Context) (int64, error)
 }
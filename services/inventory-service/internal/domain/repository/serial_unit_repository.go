@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	"github.com/google/uuid"
+)
+
+// SerialUnitRepository defines the contract for serial unit persistence operations.
+// The stock of a serial-tracked inventory item only changes through these
+// methods, which lock the item and keep its Quantity and Reserved equal to the
+// counts of its units in one transaction, incrementing its Version.
+// Every method that changes units returns ErrInventoryItemNotFound if their item
+// does not exist and ErrNotSerialTracked if it is not serial-tracked.
+type SerialUnitRepository interface {
+	// Register saves new available units of the item and adds them to its quantity.
+	// Returns the item as stored afterwards, and ErrSerialUnitAlreadyExists if the
+	// item already has one of the serial numbers, in which case nothing is saved.
+	Register(ctx context.Context, inventoryItemID uuid.UUID, units []*entity.SerialUnit) (*entity.InventoryItem, error)
+
+	// Reserve reserves quantity available units of the product's item for the
+	// reservation, oldest first. Returns the item as stored afterwards and the units,
+	// ErrInventoryItemArchived if the item is archived and ErrInsufficientStock if
+	// fewer units are available.
+	Reserve(ctx context.Context, reservationID, productID uuid.UUID, quantity int) (*entity.InventoryItem, []*entity.SerialUnit, error)
+
+	// Confirm sells the quantity units the reservation holds on the item and removes
+	// them from its stock. Returns ErrInvalidReservationConfirm if the reservation
+	// holds a different number of units.
+	Confirm(ctx context.Context, reservationID, inventoryItemID uuid.UUID, quantity int) (*entity.InventoryItem, []*entity.SerialUnit, error)
+
+	// Release makes the quantity units the reservation holds on the item available
+	// again. Returns ErrInvalidReservationRelease if the reservation holds a
+	// different number of units.
+	Release(ctx context.Context, reservationID, inventoryItemID uuid.UUID, quantity int) (*entity.InventoryItem, []*entity.SerialUnit, error)
+
+	// Return marks a sold unit of the item returned. Returned units are not in stock.
+	// Returns ErrSerialUnitNotFound if the item has no such serial number and
+	// ErrInvalidSerialTransition if the unit is not sold.
+	Return(ctx context.Context, inventoryItemID uuid.UUID, serialNumber string) (*entity.SerialUnit, error)
+
+	// Restock makes a returned unit of the item available and adds it to its
+	// quantity. Returns the item as stored afterwards and the unit,
+	// ErrSerialUnitNotFound if the item has no such serial number and
+	// ErrInvalidSerialTransition if the unit is not returned.
+	Restock(ctx context.Context, inventoryItemID uuid.UUID, serialNumber string) (*entity.InventoryItem, *entity.SerialUnit, error)
+
+	// FindByInventoryItemID retrieves the units of an inventory item in the given
+	// status (every status if empty), oldest first.
+	FindByInventoryItemID(ctx context.Context, inventoryItemID uuid.UUID, status entity.SerialUnitStatus) ([]*entity.SerialUnit, error)
+
+	// FindByReservationID retrieves the units held or sold by a reservation
+	FindByReservationID(ctx context.Context, reservationID uuid.UUID) ([]*entity.SerialUnit, error)
+}
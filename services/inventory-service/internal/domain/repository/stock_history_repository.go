@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	"github.com/google/uuid"
+)
+
+// StockHistoryRepository defines the contract for the stock history: a log of
+// every stock movement plus periodic snapshots of every item. The stock at a
+// moment is the latest snapshot at or before it plus the movements in between.
+type StockHistoryRepository interface {
+	// TakeSnapshot stores the stock of every item at the given time, computed from
+	// the previous snapshot and the movements since. Returns the number of items in
+	// the snapshot, or taken=false when a snapshot at or after that time exists or
+	// movements up to that time may still be uncommitted.
+	TakeSnapshot(ctx context.Context, at time.Time) (items int, taken bool, err error)
+
+	// PositionAsOf rebuilds the stock of a product at the given time.
+	// Returns ErrStockHistoryUnavailable if no snapshot is retained at or before it,
+	// and ErrInventoryItemNotFound if the product had no inventory item then.
+	PositionAsOf(ctx context.Context, productID uuid.UUID, at time.Time) (*entity.StockPosition, error)
+
+	// StreamAsOf calls fn with the stock of every item at the given time, ordered by
+	// product ID. Iteration stops at the first error returned by fn.
+	// Returns ErrStockHistoryUnavailable if no snapshot is retained at or before it.
+	StreamAsOf(ctx context.Context, at time.Time, fn func(position *entity.StockPosition) error) error
+
+	// Prune deletes the snapshots older than the latest one taken at or before
+	// before, and the movements that snapshot already accounts for. History stays
+	// queryable from that snapshot on. Returns the snapshots and movements deleted.
+	Prune(ctx context.Context, before time.Time) (snapshots int, movements int64, err error)
+}
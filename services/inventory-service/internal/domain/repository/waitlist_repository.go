@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	"github.com/google/uuid"
+)
+
+// WaitlistRepository defines the contract for waitlist persistence operations
+type WaitlistRepository interface {
+	// Save creates a new waiting entry.
+	// Returns ErrWaitlistEntryAlreadyExists if the order is already waiting.
+	Save(ctx context.Context, entry *entity.WaitlistEntry) error
+
+	// FindByID retrieves an entry by its ID.
+	// Returns ErrWaitlistEntryNotFound if the entry doesn't exist.
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.WaitlistEntry, error)
+
+	// Update stores the status of an entry that was waiting. Returns
+	// ErrWaitlistEntryNotWaiting if the stored entry already left the waitlist, so
+	// two servers never resolve the same entry twice.
+	Update(ctx context.Context, entry *entity.WaitlistEntry) error
+
+	// ListWaiting retrieves up to limit unexpired waiting entries of an inventory
+	// item in serving order: highest priority first, then oldest first
+	ListWaiting(ctx context.Context, inventoryItemID uuid.UUID, limit int) ([]*entity.WaitlistEntry, error)
+
+	// ListByInventoryItemID retrieves the entries of an inventory item in serving
+	// order, optionally filtered by status (empty returns every status)
+	ListByInventoryItemID(ctx context.Context, inventoryItemID uuid.UUID, status entity.WaitlistStatus) ([]*entity.WaitlistEntry, error)
+
+	// ProductsWaiting returns the products with unexpired waiting entries
+	ProductsWaiting(ctx context.Context, now time.Time) ([]uuid.UUID, error)
+
+	// ExpireWaiting marks the waiting entries that expired before now as expired
+	// and returns how many there were
+	ExpireWaiting(ctx context.Context, now time.Time) (int, error)
+}
@@ -0,0 +1,95 @@
+package auth
+
+import (
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// DefaultDenialAuditSize is the number of denials kept when no size is configured
+const DefaultDenialAuditSize = 500
+
+var deniedRequestsTotal = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: "inventory",
+		Subsystem: "auth",
+		Name:      "denied_requests_total",
+		Help:      "Total number of requests denied by service authentication",
+	},
+	[]string{"transport", "reason"},
+)
+
+// Denial describes a rejected request
+type Denial struct {
+	Time           time.Time `json:"time"`
+	Transport      string    `json:"transport"` // http or grpc
+	Operation      string    `json:"operation"` // "METHOD /route" or gRPC full method
+	Reason         string    `json:"reason"`
+	Detail         string    `json:"detail,omitempty"`
+	Service        string    `json:"service,omitempty"` // known only when the token itself was valid
+	KeyID          string    `json:"key_id,omitempty"`
+	RequiredScopes []string  `json:"required_scopes,omitempty"`
+	RemoteAddr     string    `json:"remote_addr,omitempty"`
+}
+
+// DenialAudit keeps the most recent denied requests in memory, logs each one
+// and counts them in Prometheus
+type DenialAudit struct {
+	mu      sync.Mutex
+	entries []Denial
+	next    int
+	full    bool
+}
+
+// NewDenialAudit creates a DenialAudit keeping up to capacity entries
+func NewDenialAudit(capacity int) *DenialAudit {
+	if capacity <= 0 {
+		capacity = DefaultDenialAuditSize
+	}
+	return &DenialAudit{entries: make([]Denial, capacity)}
+}
+
+// Record stores a denial, overwriting the oldest entry once full
+func (a *DenialAudit) Record(d Denial) {
+	if d.Time.IsZero() {
+		d.Time = time.Now().UTC()
+	}
+
+	deniedRequestsTotal.WithLabelValues(d.Transport, d.Reason).Inc()
+	log.Printf("🚫 Auth denied: transport=%s operation=%q reason=%s service=%q kid=%q required=%s remote=%s detail=%q",
+		d.Transport, d.Operation, d.Reason, d.Service, d.KeyID, strings.Join(d.RequiredScopes, ","), d.RemoteAddr, d.Detail)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.entries[a.next] = d
+	a.next = (a.next + 1) % len(a.entries)
+	if a.next == 0 {
+		a.full = true
+	}
+}
+
+// Recent returns up to limit denials, newest first (limit <= 0 returns all)
+func (a *DenialAudit) Recent(limit int) []Denial {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	count := a.next
+	if a.full {
+		count = len(a.entries)
+	}
+	if limit <= 0 || limit > count {
+		limit = count
+	}
+
+	result := make([]Denial, 0, limit)
+	for i := 0; i < limit; i++ {
+		idx := (a.next - 1 - i + len(a.entries)) % len(a.entries)
+		result = append(result, a.entries[idx])
+	}
+	return result
+}
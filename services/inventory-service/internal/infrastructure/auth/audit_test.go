@@ -0,0 +1,32 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDenialAudit_RecentNewestFirst(t *testing.T) {
+	audit := NewDenialAudit(3)
+
+	assert.Empty(t, audit.Recent(10))
+
+	for _, op := range []string{"a", "b", "c", "d"} {
+		audit.Record(Denial{Transport: "http", Operation: op, Reason: ReasonMissingToken})
+	}
+
+	recent := audit.Recent(0)
+	require.Len(t, recent, 3)
+	assert.Equal(t, "d", recent[0].Operation)
+	assert.Equal(t, "c", recent[1].Operation)
+	assert.Equal(t, "b", recent[2].Operation, "oldest entry is overwritten once full")
+	assert.False(t, recent[0].Time.IsZero())
+
+	assert.Len(t, audit.Recent(2), 2)
+}
+
+func TestNewDenialAudit_DefaultCapacity(t *testing.T) {
+	audit := NewDenialAudit(0)
+	assert.Len(t, audit.entries, DefaultDenialAuditSize)
+}
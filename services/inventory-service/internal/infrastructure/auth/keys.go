@@ -0,0 +1,98 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Supported token signing algorithms
+const (
+	AlgorithmHS256 = "HS256"
+	AlgorithmEdDSA = "EdDSA"
+)
+
+// MinHMACSecretLength is the minimum HS256 secret length in bytes
+const MinHMACSecretLength = 32
+
+// Key is a token verification key identified by the JWT "kid" header.
+// NotBefore and NotAfter bound the period in which tokens signed with the key are accepted;
+// overlapping windows between the old and the new key allow rotation without downtime.
+type Key struct {
+	ID        string
+	Algorithm string
+	Secret    []byte            // HS256 shared secret
+	PublicKey ed25519.PublicKey // EdDSA public key
+	NotBefore time.Time         // zero means no lower bound
+	NotAfter  time.Time         // zero means no upper bound
+}
+
+// DecodeKey builds a Key from its configuration form.
+// material is base64 encoded (standard or URL alphabet, with or without padding):
+// the shared secret for HS256 or the 32-byte public key for EdDSA.
+func DecodeKey(id, algorithm, material string, notBefore, notAfter time.Time) (Key, error) {
+	key := Key{ID: id, Algorithm: algorithm, NotBefore: notBefore, NotAfter: notAfter}
+
+	if strings.TrimSpace(id) == "" {
+		return Key{}, fmt.Errorf("key id is required")
+	}
+
+	decoded, err := decodeBase64(material)
+	if err != nil {
+		return Key{}, fmt.Errorf("key %q: material is not valid base64: %w", id, err)
+	}
+
+	switch algorithm {
+	case AlgorithmHS256:
+		if len(decoded) < MinHMACSecretLength {
+			return Key{}, fmt.Errorf("key %q: HS256 secret must be at least %d bytes (got %d)", id, MinHMACSecretLength, len(decoded))
+		}
+		key.Secret = decoded
+	case AlgorithmEdDSA:
+		if len(decoded) != ed25519.PublicKeySize {
+			return Key{}, fmt.Errorf("key %q: EdDSA public key must be %d bytes (got %d)", id, ed25519.PublicKeySize, len(decoded))
+		}
+		key.PublicKey = ed25519.PublicKey(decoded)
+	default:
+		return Key{}, fmt.Errorf("key %q: unsupported algorithm %q (expected %s or %s)", id, algorithm, AlgorithmHS256, AlgorithmEdDSA)
+	}
+
+	if !notBefore.IsZero() && !notAfter.IsZero() && !notAfter.After(notBefore) {
+		return Key{}, fmt.Errorf("key %q: not_after must be after not_before", id)
+	}
+
+	return key, nil
+}
+
+// ActiveAt reports whether the key accepts tokens at the given time
+func (k Key) ActiveAt(t time.Time) bool {
+	if !k.NotBefore.IsZero() && t.Before(k.NotBefore) {
+		return false
+	}
+	if !k.NotAfter.IsZero() && t.After(k.NotAfter) {
+		return false
+	}
+	return true
+}
+
+// verificationKey returns the key material in the form expected by the JWT library
+func (k Key) verificationKey() interface{} {
+	if k.Algorithm == AlgorithmEdDSA {
+		return k.PublicKey
+	}
+	return k.Secret
+}
+
+func decodeBase64(value string) ([]byte, error) {
+	value = strings.TrimSpace(value)
+	for _, encoding := range []*base64.Encoding{
+		base64.StdEncoding, base64.RawStdEncoding, base64.URLEncoding, base64.RawURLEncoding,
+	} {
+		if decoded, err := encoding.DecodeString(value); err == nil {
+			return decoded, nil
+		}
+	}
+	return nil, fmt.Errorf("illegal base64 data")
+}
@@ -0,0 +1,22 @@
+package auth
+
+// Scopes granted to service tokens. Routes and gRPC methods declare the scopes they require.
+const (
+	// ScopeInventoryRead allows reading stock levels and availability
+	ScopeInventoryRead = "inventory:read"
+
+	// ScopeInventoryReserve allows reserving, confirming and releasing stock
+	ScopeInventoryReserve = "inventory:reserve"
+
+	// ScopeAdminReservations allows listing reservations and reservation maintenance (e.g. manual release of expired reservations)
+	ScopeAdminReservations = "admin:reservations"
+
+	// ScopeAdminDLQ allows listing and retrying dead-letter queue messages
+	ScopeAdminDLQ = "admin:dlq"
+
+	// ScopeAdminAudit allows reading the authentication denial audit
+	ScopeAdminAudit = "admin:audit"
+
+	// ScopeAdminStock allows listing inventory items and bulk import and export of stock levels
+	ScopeAdminStock = "admin:stock"
+)
@@ -0,0 +1,200 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Claims are the JWT claims of a service token.
+// The subject ("sub") is the calling service name; "user_id" optionally names
+// the end user the service calls on behalf of.
+type Claims struct {
+	Scopes []string `json:"scopes"`
+	UserID string   `json:"user_id,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// Principal is the authenticated caller of a request
+type Principal struct {
+	Service   string
+	UserID    string // end user the service calls on behalf of, if the token names one
+	Scopes    []string
+	KeyID     string
+	TokenID   string
+	ExpiresAt time.Time
+}
+
+// HasScope reports whether the principal was granted the scope
+func (p *Principal) HasScope(scope string) bool {
+	for _, s := range p.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// MissingScopes returns the required scopes the principal was not granted
+func (p *Principal) MissingScopes(required ...string) []string {
+	var missing []string
+	for _, scope := range required {
+		if !p.HasScope(scope) {
+			missing = append(missing, scope)
+		}
+	}
+	return missing
+}
+
+// Verification errors. Reason maps them to the short codes used in the denial audit.
+var (
+	ErrMissingToken      = errors.New("missing service token")
+	ErrMissingKeyID      = errors.New("token has no key id")
+	ErrUnknownKey        = errors.New("token signed with unknown key")
+	ErrKeyNotActive      = errors.New("token signed with a key outside its validity window")
+	ErrAlgorithmMismatch = errors.New("token algorithm does not match key")
+	ErrMissingSubject    = errors.New("token has no subject")
+	ErrInsufficientScope = errors.New("token lacks required scope")
+)
+
+// Denial reasons recorded in the audit
+const (
+	ReasonMissingToken      = "missing_token"
+	ReasonMalformedToken    = "malformed_token"
+	ReasonUnknownKey        = "unknown_key"
+	ReasonKeyNotActive      = "key_not_active"
+	ReasonInvalidSignature  = "invalid_signature"
+	ReasonExpired           = "expired"
+	ReasonInvalidClaims     = "invalid_claims"
+	ReasonInsufficientScope = "insufficient_scope"
+)
+
+// Reason returns the audit reason code for a verification error
+func Reason(err error) string {
+	switch {
+	case errors.Is(err, ErrMissingToken):
+		return ReasonMissingToken
+	case errors.Is(err, ErrInsufficientScope):
+		return ReasonInsufficientScope
+	case errors.Is(err, ErrMissingKeyID), errors.Is(err, ErrUnknownKey):
+		return ReasonUnknownKey
+	case errors.Is(err, ErrKeyNotActive):
+		return ReasonKeyNotActive
+	case errors.Is(err, jwt.ErrTokenMalformed):
+		return ReasonMalformedToken
+	case errors.Is(err, ErrAlgorithmMismatch), errors.Is(err, jwt.ErrTokenSignatureInvalid), errors.Is(err, jwt.ErrTokenUnverifiable):
+		return ReasonInvalidSignature
+	case errors.Is(err, jwt.ErrTokenExpired):
+		return ReasonExpired
+	default:
+		return ReasonInvalidClaims
+	}
+}
+
+// VerifierOptions configures claim validation
+type VerifierOptions struct {
+	Audience string        // required "aud" value
+	Issuer   string        // required "iss" value (empty accepts any issuer)
+	Leeway   time.Duration // allowed clock skew for exp/nbf/iat
+}
+
+// Verifier validates signed service tokens against a set of keys
+type Verifier struct {
+	keys   map[string]Key
+	opts   VerifierOptions
+	parser *jwt.Parser
+	now    func() time.Time
+}
+
+// NewVerifier creates a Verifier for the given keys.
+// Several keys may be active at once to support rotation.
+func NewVerifier(keys []Key, opts VerifierOptions) (*Verifier, error) {
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("at least one token key is required")
+	}
+
+	keysByID := make(map[string]Key, len(keys))
+	for _, key := range keys {
+		if _, exists := keysByID[key.ID]; exists {
+			return nil, fmt.Errorf("duplicate token key id %q", key.ID)
+		}
+		keysByID[key.ID] = key
+	}
+
+	parserOpts := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{AlgorithmHS256, AlgorithmEdDSA}),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(opts.Leeway),
+	}
+	if opts.Audience != "" {
+		parserOpts = append(parserOpts, jwt.WithAudience(opts.Audience))
+	}
+	if opts.Issuer != "" {
+		parserOpts = append(parserOpts, jwt.WithIssuer(opts.Issuer))
+	}
+
+	v := &Verifier{
+		keys: keysByID,
+		opts: opts,
+		now:  time.Now,
+	}
+	v.parser = jwt.NewParser(append(parserOpts, jwt.WithTimeFunc(func() time.Time { return v.now() }))...)
+	return v, nil
+}
+
+// Verify parses and validates a token and returns its principal
+func (v *Verifier) Verify(tokenString string) (*Principal, error) {
+	if tokenString == "" {
+		return nil, ErrMissingToken
+	}
+
+	claims := &Claims{}
+	token, err := v.parser.ParseWithClaims(tokenString, claims, v.keyFunc)
+	if err != nil {
+		return nil, err
+	}
+
+	if claims.Subject == "" {
+		return nil, ErrMissingSubject
+	}
+
+	principal := &Principal{
+		Service: claims.Subject,
+		UserID:  claims.UserID,
+		Scopes:  claims.Scopes,
+		TokenID: claims.ID,
+	}
+	if kid, ok := token.Header["kid"].(string); ok {
+		principal.KeyID = kid
+	}
+	if claims.ExpiresAt != nil {
+		principal.ExpiresAt = claims.ExpiresAt.Time
+	}
+
+	return principal, nil
+}
+
+// keyFunc selects the verification key by "kid" and enforces its algorithm and validity window
+func (v *Verifier) keyFunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		return nil, ErrMissingKeyID
+	}
+
+	key, ok := v.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownKey, kid)
+	}
+
+	if token.Method.Alg() != key.Algorithm {
+		return nil, fmt.Errorf("%w: key %q expects %s, token uses %s", ErrAlgorithmMismatch, kid, key.Algorithm, token.Method.Alg())
+	}
+
+	if !key.ActiveAt(v.now()) {
+		return nil, fmt.Errorf("%w: %q", ErrKeyNotActive, kid)
+	}
+
+	return key.verificationKey(), nil
+}
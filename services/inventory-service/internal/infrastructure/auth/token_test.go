@@ -0,0 +1,213 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testSecret = []byte("0123456789abcdef0123456789abcdef")
+
+var testOpts = VerifierOptions{Audience: "inventory-service", Leeway: time.Second}
+
+func hmacKey(id string) Key {
+	return Key{ID: id, Algorithm: AlgorithmHS256, Secret: testSecret}
+}
+
+func newVerifier(t *testing.T, keys ...Key) *Verifier {
+	t.Helper()
+	v, err := NewVerifier(keys, testOpts)
+	require.NoError(t, err)
+	return v
+}
+
+func TestVerifier_HMAC(t *testing.T) {
+	v := newVerifier(t, hmacKey("k1"))
+	signer := NewHMACSigner("k1", testSecret, SignerOptions{Audience: "inventory-service"})
+
+	token, err := signer.Sign("orders-service", []string{ScopeInventoryRead, ScopeInventoryReserve}, time.Hour)
+	require.NoError(t, err)
+
+	principal, err := v.Verify(token)
+	require.NoError(t, err)
+	assert.Equal(t, "orders-service", principal.Service)
+	assert.Equal(t, "k1", principal.KeyID)
+	assert.NotEmpty(t, principal.TokenID)
+	assert.True(t, principal.HasScope(ScopeInventoryReserve))
+	assert.False(t, principal.HasScope(ScopeAdminDLQ))
+	assert.Equal(t, []string{ScopeAdminDLQ}, principal.MissingScopes(ScopeInventoryRead, ScopeAdminDLQ))
+	assert.Empty(t, principal.UserID)
+}
+
+func TestVerifier_UserClaim(t *testing.T) {
+	v := newVerifier(t, hmacKey("k1"))
+	token, err := NewHMACSigner("k1", testSecret, SignerOptions{Audience: "inventory-service"}).
+		SignForUser("orders-service", "user-42", []string{ScopeInventoryReserve}, time.Hour)
+	require.NoError(t, err)
+
+	principal, err := v.Verify(token)
+	require.NoError(t, err)
+	assert.Equal(t, "orders-service", principal.Service)
+	assert.Equal(t, "user-42", principal.UserID)
+}
+
+func TestVerifier_Ed25519(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	key, err := DecodeKey("ed1", AlgorithmEdDSA, base64.StdEncoding.EncodeToString(publicKey), time.Time{}, time.Time{})
+	require.NoError(t, err)
+	v := newVerifier(t, key)
+
+	token, err := NewEd25519Signer("ed1", privateKey, SignerOptions{Audience: "inventory-service"}).
+		Sign("orders-service", []string{ScopeInventoryRead}, time.Hour)
+	require.NoError(t, err)
+
+	principal, err := v.Verify(token)
+	require.NoError(t, err)
+	assert.Equal(t, "orders-service", principal.Service)
+}
+
+func TestVerifier_Rejections(t *testing.T) {
+	v := newVerifier(t, hmacKey("k1"))
+	validSigner := NewHMACSigner("k1", testSecret, SignerOptions{Audience: "inventory-service"})
+
+	sign := func(t *testing.T, s *Signer, service string, ttl time.Duration) string {
+		t.Helper()
+		token, err := s.Sign(service, []string{ScopeInventoryRead}, ttl)
+		require.NoError(t, err)
+		return token
+	}
+
+	expired := NewHMACSigner("k1", testSecret, SignerOptions{Audience: "inventory-service"})
+	expired.now = func() time.Time { return time.Now().Add(-2 * time.Hour) }
+
+	testCases := []struct {
+		name   string
+		token  string
+		reason string
+	}{
+		{"missing token", "", ReasonMissingToken},
+		{"garbage", "not-a-jwt", ReasonMalformedToken},
+		{"unknown key id", sign(t, NewHMACSigner("k2", testSecret, SignerOptions{Audience: "inventory-service"}), "orders-service", time.Hour), ReasonUnknownKey},
+		{"wrong secret", sign(t, NewHMACSigner("k1", []byte(strings.Repeat("x", 32)), SignerOptions{Audience: "inventory-service"}), "orders-service", time.Hour), ReasonInvalidSignature},
+		{"wrong audience", sign(t, NewHMACSigner("k1", testSecret, SignerOptions{Audience: "orders-service"}), "orders-service", time.Hour), ReasonInvalidClaims},
+		{"expired", sign(t, expired, "orders-service", time.Hour), ReasonExpired},
+		{"tampered payload", tamper(sign(t, validSigner, "orders-service", time.Hour)), ReasonInvalidSignature},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := v.Verify(tc.token)
+			require.Error(t, err)
+			assert.Equal(t, tc.reason, Reason(err))
+		})
+	}
+}
+
+func TestVerifier_RejectsAlgorithmConfusion(t *testing.T) {
+	publicKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	v := newVerifier(t, Key{ID: "ed1", Algorithm: AlgorithmEdDSA, PublicKey: publicKey})
+
+	// HS256 token signed with the public key bytes as secret must not verify against an EdDSA key
+	token, err := NewHMACSigner("ed1", publicKey, SignerOptions{Audience: "inventory-service"}).
+		Sign("attacker", []string{ScopeAdminDLQ}, time.Hour)
+	require.NoError(t, err)
+
+	_, err = v.Verify(token)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrAlgorithmMismatch)
+}
+
+func TestVerifier_RejectsNoneAlgorithm(t *testing.T) {
+	v := newVerifier(t, hmacKey("k1"))
+
+	token := jwt.NewWithClaims(jwt.SigningMethodNone, Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   "attacker",
+			Audience:  jwt.ClaimStrings{"inventory-service"},
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+	})
+	token.Header["kid"] = "k1"
+	signed, err := token.SignedString(jwt.UnsafeAllowNoneSignatureType)
+	require.NoError(t, err)
+
+	_, err = v.Verify(signed)
+	assert.Error(t, err)
+}
+
+func TestVerifier_KeyRotation(t *testing.T) {
+	now := time.Now()
+	oldKey := hmacKey("2024-01")
+	oldKey.NotAfter = now.Add(time.Hour)
+	newKey := Key{ID: "2024-02", Algorithm: AlgorithmHS256, Secret: []byte(strings.Repeat("n", 32)), NotBefore: now.Add(-time.Minute)}
+
+	v := newVerifier(t, oldKey, newKey)
+	oldToken, err := NewHMACSigner("2024-01", oldKey.Secret, SignerOptions{Audience: "inventory-service"}).Sign("orders-service", nil, 3*time.Hour)
+	require.NoError(t, err)
+	newToken, err := NewHMACSigner("2024-02", newKey.Secret, SignerOptions{Audience: "inventory-service"}).Sign("orders-service", nil, 3*time.Hour)
+	require.NoError(t, err)
+
+	// Overlap: both keys accepted
+	_, err = v.Verify(oldToken)
+	assert.NoError(t, err)
+	_, err = v.Verify(newToken)
+	assert.NoError(t, err)
+
+	// After the old key's window ends, only the new key is accepted
+	v.now = func() time.Time { return now.Add(2 * time.Hour) }
+	_, err = v.Verify(oldToken)
+	assert.Equal(t, ReasonKeyNotActive, Reason(err))
+	_, err = v.Verify(newToken)
+	assert.NoError(t, err)
+}
+
+func TestNewVerifier_Validation(t *testing.T) {
+	_, err := NewVerifier(nil, testOpts)
+	assert.Error(t, err)
+
+	_, err = NewVerifier([]Key{hmacKey("k1"), hmacKey("k1")}, testOpts)
+	assert.ErrorContains(t, err, "duplicate")
+}
+
+func TestDecodeKey(t *testing.T) {
+	secret := base64.StdEncoding.EncodeToString(testSecret)
+
+	key, err := DecodeKey("k1", AlgorithmHS256, secret, time.Time{}, time.Time{})
+	require.NoError(t, err)
+	assert.Equal(t, testSecret, key.Secret)
+
+	_, err = DecodeKey("k1", AlgorithmHS256, base64.StdEncoding.EncodeToString([]byte("short")), time.Time{}, time.Time{})
+	assert.ErrorContains(t, err, "at least")
+
+	_, err = DecodeKey("k1", "RS256", secret, time.Time{}, time.Time{})
+	assert.ErrorContains(t, err, "unsupported algorithm")
+
+	_, err = DecodeKey("", AlgorithmHS256, secret, time.Time{}, time.Time{})
+	assert.Error(t, err)
+
+	_, err = DecodeKey("k1", AlgorithmHS256, "%%%", time.Time{}, time.Time{})
+	assert.ErrorContains(t, err, "base64")
+
+	now := time.Now()
+	_, err = DecodeKey("k1", AlgorithmHS256, secret, now, now.Add(-time.Hour))
+	assert.ErrorContains(t, err, "not_after")
+}
+
+// tamper flips the payload so the signature no longer matches
+func tamper(token string) string {
+	parts := strings.Split(token, ".")
+	payload, _ := base64.RawURLEncoding.DecodeString(parts[1])
+	payload = []byte(strings.Replace(string(payload), "orders-service", "admin-service", 1))
+	parts[1] = base64.RawURLEncoding.EncodeToString(payload)
+	return strings.Join(parts, ".")
+}
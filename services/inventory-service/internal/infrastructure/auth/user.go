@@ -0,0 +1,24 @@
+package auth
+
+import "errors"
+
+// UserIDHeader carries the end user a service calls on behalf of, as an HTTP
+// header or gRPC metadata (lowercased). It is trusted because only services
+// holding a valid token reach the routes that read it.
+const UserIDHeader = "X-User-ID"
+
+// ErrUserIDMismatch is returned when a request asserts another end user than its token names
+var ErrUserIDMismatch = errors.New("the asserted user id does not match the token's user_id claim")
+
+// UserID returns the end user a request is made for: the token's user_id claim,
+// or the user the calling service asserts when the token names none. principal
+// is nil when authentication is disabled; an empty result means no end user.
+func UserID(principal *Principal, asserted string) (string, error) {
+	if principal == nil || principal.UserID == "" {
+		return asserted, nil
+	}
+	if asserted != "" && asserted != principal.UserID {
+		return "", ErrUserIDMismatch
+	}
+	return principal.UserID, nil
+}
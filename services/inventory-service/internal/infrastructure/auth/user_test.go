@@ -0,0 +1,33 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserID(t *testing.T) {
+	tests := []struct {
+		name      string
+		principal *Principal
+		asserted  string
+		want      string
+	}{
+		{"authentication disabled", nil, "user-1", "user-1"},
+		{"service token", &Principal{Service: "orders-service"}, "user-1", "user-1"},
+		{"no end user", &Principal{Service: "orders-service"}, "", ""},
+		{"user claim", &Principal{Service: "orders-service", UserID: "user-2"}, "", "user-2"},
+		{"matching claim", &Principal{Service: "orders-service", UserID: "user-2"}, "user-2", "user-2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userID, err := UserID(tt.principal, tt.asserted)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, userID)
+		})
+	}
+
+	_, err := UserID(&Principal{Service: "orders-service", UserID: "user-2"}, "user-1")
+	assert.ErrorIs(t, err, ErrUserIDMismatch)
+}
@@ -159,6 +159,11 @@ func (r *RedisClient) Increment(ctx context.Context, key string, window time.Dur
 	return incrCmd.Val(), nil
 }
 
+// Scripter exposes the underlying client for Lua scripts (e.g. the rate limiter)
+func (r *RedisClient) Scripter() redis.Scripter {
+	return r.client
+}
+
 // Close closes the Redis connection
 func (r *RedisClient) Close() error {
 	if err := r.client.Close(); err != nil {
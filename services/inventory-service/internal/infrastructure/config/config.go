@@ -1,74 +1,520 @@
 package config
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"log"
+	"os"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/kelseyhightower/envconfig"
+	"gopkg.in/yaml.v3"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/infrastructure/auth"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/infrastructure/ratelimit"
 )
 
-// Config contiene toda la configuración de la aplicación
+// Config contiene toda la configuración de la aplicación.
+//
+// Precedencia (de menor a mayor): valores por defecto (Default), archivo YAML opcional
+// y variables de entorno. Los tags `envconfig` no declaran `default` a propósito:
+// un default de envconfig pisaría el valor cargado desde el YAML.
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	Redis    RedisConfig
-	Logger   LoggerConfig
+	Server       ServerConfig       `yaml:"server"`
+	Database     DatabaseConfig     `yaml:"database"`
+	Redis        RedisConfig        `yaml:"redis"`
+	Publisher    PublisherConfig    `yaml:"publisher"`
+	NATS         NATSConfig         `yaml:"nats"`
+	CatalogSync  CatalogSyncConfig  `yaml:"catalog_sync"`
+	Scheduler    SchedulerConfig    `yaml:"scheduler"`
+	Reconcile    ReconcileConfig    `yaml:"reconcile"`
+	Retention    RetentionConfig    `yaml:"retention"`
+	StockHistory StockHistoryConfig `yaml:"stock_history"`
+	Lots         LotsConfig         `yaml:"lots"`
+	Waitlist     WaitlistConfig     `yaml:"waitlist"`
+	Reservation  ReservationConfig  `yaml:"reservation"`
+	RateLimit    RateLimitConfig    `yaml:"rate_limit"`
+	Auth         AuthConfig         `yaml:"auth"`
+	Health       HealthConfig       `yaml:"health"`
+	Logger       LoggerConfig       `yaml:"logger"`
 }
 
 // ServerConfig configuración del servidor HTTP
 type ServerConfig struct {
-	Port            string `envconfig:"PORT" default:"8080"`
-	Environment     string `envconfig:"ENVIRONMENT" default:"development"`
-	ReadTimeout     int    `envconfig:"READ_TIMEOUT" default:"10"`
-	WriteTimeout    int    `envconfig:"WRITE_TIMEOUT" default:"10"`
-	ShutdownTimeout int    `envconfig:"SHUTDOWN_TIMEOUT" default:"5"`
+	Port            string `envconfig:"PORT" yaml:"port"`
+	Environment     string `envconfig:"ENVIRONMENT" yaml:"environment"`
+	GinMode         string `envconfig:"GIN_MODE" yaml:"gin_mode"`
+	ReadTimeout     int    `envconfig:"READ_TIMEOUT" yaml:"read_timeout"`         // seconds
+	WriteTimeout    int    `envconfig:"WRITE_TIMEOUT" yaml:"write_timeout"`       // seconds
+	IdleTimeout     int    `envconfig:"IDLE_TIMEOUT" yaml:"idle_timeout"`         // seconds
+	ShutdownTimeout int    `envconfig:"SHUTDOWN_TIMEOUT" yaml:"shutdown_timeout"` // seconds
+	GRPCEnabled     bool   `envconfig:"GRPC_ENABLED" yaml:"grpc_enabled"`
+	GRPCPort        string `envconfig:"GRPC_PORT" yaml:"grpc_port"`
 }
 
 // DatabaseConfig configuración de PostgreSQL
 type DatabaseConfig struct {
-	Host     string `envconfig:"DB_HOST" required:"true"`
-	Port     int    `envconfig:"DB_PORT" default:"5432"`
-	User     string `envconfig:"DB_USER" required:"true"`
-	Password string `envconfig:"DB_PASSWORD" required:"true"`
-	Database string `envconfig:"DB_NAME" required:"true"`
-	SSLMode  string `envconfig:"DB_SSLMODE" default:"disable"`
-	MaxConns int    `envconfig:"DB_MAX_CONNS" default:"25"`
-	MinConns int    `envconfig:"DB_MIN_CONNS" default:"5"`
+	Host     string `envconfig:"DB_HOST" yaml:"host"`
+	Port     int    `envconfig:"DB_PORT" yaml:"port"`
+	User     string `envconfig:"DB_USER" yaml:"user"`
+	Password string `envconfig:"DB_PASSWORD" yaml:"password"`
+	Database string `envconfig:"DB_NAME" yaml:"name"`
+	SSLMode  string `envconfig:"DB_SSLMODE" yaml:"sslmode"`
+	MaxConns int    `envconfig:"DB_MAX_CONNS" yaml:"max_conns"`
+	MinConns int    `envconfig:"DB_MIN_CONNS" yaml:"min_conns"`
 }
 
-// RedisConfig configuración de Redis
+// RedisConfig configuración de Redis.
+// Redis es opcional: si no está disponible el servicio arranca sin cache ni rate limiting distribuido.
 type RedisConfig struct {
-	Host     string `envconfig:"REDIS_HOST" required:"true"`
-	Port     int    `envconfig:"REDIS_PORT" default:"6379"`
-	Password string `envconfig:"REDIS_PASSWORD"`
-	DB       int    `envconfig:"REDIS_DB" default:"0"`
+	Enabled         bool   `envconfig:"REDIS_ENABLED" yaml:"enabled"`
+	Host            string `envconfig:"REDIS_HOST" yaml:"host"`
+	Port            int    `envconfig:"REDIS_PORT" yaml:"port"`
+	Password        string `envconfig:"REDIS_PASSWORD" yaml:"password"`
+	DB              int    `envconfig:"REDIS_DB" yaml:"db"`
+	CacheTTLSeconds int    `envconfig:"REDIS_CACHE_TTL_SECONDS" yaml:"cache_ttl_seconds"`
+}
+
+// Modos de validación de eventos contra su JSON Schema antes de publicarlos
+const (
+	// SchemaValidationAuto valida en todos los entornos salvo producción
+	SchemaValidationAuto = "auto"
+	// SchemaValidationOn valida siempre
+	SchemaValidationOn = "on"
+	// SchemaValidationOff nunca valida
+	SchemaValidationOff = "off"
+)
+
+// Transportes de eventos
+const (
+	// EventTransportAuto usa RabbitMQ si RABBITMQ_URL está configurada y noop si no
+	EventTransportAuto     = "auto"
+	EventTransportRabbitMQ = "rabbitmq"
+	EventTransportNATS     = "nats"
+	// EventTransportMemory publica en un bus en memoria (tests y demos en un solo binario)
+	EventTransportMemory = "memory"
+	EventTransportNoop   = "noop"
+)
+
+// Formatos de los eventos publicados
+const (
+	// EventFormatLegacy publica el envelope BaseEvent con el payload anidado
+	EventFormatLegacy = "legacy"
+	// EventFormatCloudEventsBinary publica el payload como body y los atributos CloudEvents como headers AMQP
+	EventFormatCloudEventsBinary = "cloudevents-binary"
+	// EventFormatCloudEventsStructured publica un documento CloudEvents JSON como body
+	EventFormatCloudEventsStructured = "cloudevents-structured"
+)
+
+// PublisherConfig configuración del publisher de eventos.
+// Transport elige la implementación; con auto y URL vacía los eventos no se publican.
+type PublisherConfig struct {
+	Transport        string `envconfig:"EVENT_TRANSPORT" yaml:"transport"` // auto | rabbitmq | nats | memory | noop
+	URL              string `envconfig:"RABBITMQ_URL" yaml:"url"`
+	Exchange         string `envconfig:"RABBITMQ_EXCHANGE" yaml:"exchange"`
+	ExchangeType     string `envconfig:"RABBITMQ_EXCHANGE_TYPE" yaml:"exchange_type"`
+	MaxRetries       int    `envconfig:"RABBITMQ_MAX_RETRIES" yaml:"max_retries"`
+	RetryDelayMs     int    `envconfig:"RABBITMQ_RETRY_DELAY_MS" yaml:"retry_delay_ms"`
+	SchemaValidation string `envconfig:"EVENT_SCHEMA_VALIDATION" yaml:"schema_validation"` // auto | on | off
+	EventFormat      string `envconfig:"RABBITMQ_EVENT_FORMAT" yaml:"event_format"`        // legacy | cloudevents-binary | cloudevents-structured
+}
+
+// NATSConfig configuración del transporte NATS JetStream (EVENT_TRANSPORT=nats).
+// Los routing keys se usan como subjects.
+type NATSConfig struct {
+	URL              string `envconfig:"NATS_URL" yaml:"url"`
+	Stream           string `envconfig:"NATS_STREAM" yaml:"stream"`
+	PublishTimeoutMs int    `envconfig:"NATS_PUBLISH_TIMEOUT_MS" yaml:"publish_timeout_ms"`
+}
+
+// CatalogSyncConfig configuración de la sincronización del catálogo por eventos.
+// Consume product.created, product.updated y product.deactivated desde RabbitMQ
+// (usa RABBITMQ_URL): crea el inventory item de los productos activos y archiva el
+// de los desactivados. cmd/sync queda solo para backfills puntuales.
+type CatalogSyncConfig struct {
+	Enabled           bool              `envconfig:"CATALOG_SYNC_ENABLED" yaml:"enabled"`
+	Exchange          string            `envconfig:"CATALOG_SYNC_EXCHANGE" yaml:"exchange"`
+	Queue             string            `envconfig:"CATALOG_SYNC_QUEUE" yaml:"queue"`
+	Prefetch          int               `envconfig:"CATALOG_SYNC_PREFETCH" yaml:"prefetch"`
+	InitialStock      int               `envconfig:"CATALOG_INITIAL_STOCK" yaml:"initial_stock"`             // stock de los items nuevos
+	InitialStockRules InitialStockRules `envconfig:"CATALOG_INITIAL_STOCK_RULES" yaml:"initial_stock_rules"` // sobrescribe InitialStock por prefijo de SKU
+}
+
+// InitialStockRule asigna el stock inicial de los productos cuyo SKU empieza con SKUPrefix.
+// Gana el prefijo más largo.
+type InitialStockRule struct {
+	SKUPrefix string `json:"sku_prefix" yaml:"sku_prefix"`
+	Quantity  int    `json:"quantity" yaml:"quantity"`
+}
+
+// InitialStockRules lista de reglas de stock inicial.
+// En variables de entorno se expresa como un array JSON (CATALOG_INITIAL_STOCK_RULES).
+type InitialStockRules []InitialStockRule
+
+// Decode implementa envconfig.Decoder para leer las reglas como JSON
+func (r *InitialStockRules) Decode(value string) error {
+	if strings.TrimSpace(value) == "" {
+		*r = nil
+		return nil
+	}
+	var rules []InitialStockRule
+	if err := json.Unmarshal([]byte(value), &rules); err != nil {
+		return fmt.Errorf("CATALOG_INITIAL_STOCK_RULES must be a JSON array of rules: %w", err)
+	}
+	*r = rules
+	return nil
+}
+
+// SchedulerConfig configuración del scheduler de reservas expiradas
+type SchedulerConfig struct {
+	Enabled         bool `envconfig:"SCHEDULER_ENABLED" yaml:"enabled"`
+	IntervalMinutes int  `envconfig:"SCHEDULER_INTERVAL_MINUTES" yaml:"interval_minutes"`
+}
+
+// ReconcileConfig configuración del job de reconciliación de inventario.
+// Sin Repair solo reporta el drift encontrado; GraceMinutes protege las filas
+// modificadas recientemente y define cuándo una reserva vencida está trabada.
+type ReconcileConfig struct {
+	Enabled         bool `envconfig:"RECONCILE_ENABLED" yaml:"enabled"`
+	IntervalMinutes int  `envconfig:"RECONCILE_INTERVAL_MINUTES" yaml:"interval_minutes"`
+	Repair          bool `envconfig:"RECONCILE_REPAIR" yaml:"repair"`
+	GraceMinutes    int  `envconfig:"RECONCILE_GRACE_MINUTES" yaml:"grace_minutes"`
+}
+
+// RetentionConfig configuración del job de retención de reservas. Las reservas
+// terminadas (confirmed, released, expired) con más de Days días sin cambios se
+// mueven a reservations_archive en lotes de BatchSize; MaxBatches (0 = sin límite)
+// acota el trabajo por corrida. PartitionsAhead es la cantidad de particiones
+// mensuales futuras que se mantienen creadas.
+type RetentionConfig struct {
+	Enabled         bool `envconfig:"RETENTION_ENABLED" yaml:"enabled"`
+	IntervalMinutes int  `envconfig:"RETENTION_INTERVAL_MINUTES" yaml:"interval_minutes"`
+	Days            int  `envconfig:"RETENTION_DAYS" yaml:"days"`
+	BatchSize       int  `envconfig:"RETENTION_BATCH_SIZE" yaml:"batch_size"`
+	MaxBatches      int  `envconfig:"RETENTION_MAX_BATCHES" yaml:"max_batches"`
+	PartitionsAhead int  `envconfig:"RETENTION_PARTITIONS_AHEAD" yaml:"partitions_ahead"`
+}
+
+// StockHistoryConfig configuración de los snapshots de stock usados por las consultas
+// "stock a una fecha". Cada SnapshotIntervalMinutes se guarda el stock de todos los
+// items tal como estaba SettleMinutes atrás (para no dejar afuera transacciones en
+// curso). Los snapshots y movimientos con más de RetentionDays días se borran
+// (0 = se conserva todo el historial).
+type StockHistoryConfig struct {
+	Enabled                 bool `envconfig:"STOCK_HISTORY_ENABLED" yaml:"enabled"`
+	SnapshotIntervalMinutes int  `envconfig:"STOCK_HISTORY_SNAPSHOT_INTERVAL_MINUTES" yaml:"snapshot_interval_minutes"`
+	SettleMinutes           int  `envconfig:"STOCK_HISTORY_SETTLE_MINUTES" yaml:"settle_minutes"`
+	RetentionDays           int  `envconfig:"STOCK_HISTORY_RETENTION_DAYS" yaml:"retention_days"`
+}
+
+// LotsConfig configuración del seguimiento de stock por lote. Con Enabled las
+// reservas toman unidades de los lotes en orden FEFO (primero el que vence antes),
+// las confirmaciones registran los lotes consumidos y cada QuarantineIntervalMinutes
+// se ponen en cuarentena las unidades libres de los lotes vencidos.
+type LotsConfig struct {
+	Enabled                   bool `envconfig:"LOTS_ENABLED" yaml:"enabled"`
+	QuarantineIntervalMinutes int  `envconfig:"LOTS_QUARANTINE_INTERVAL_MINUTES" yaml:"quarantine_interval_minutes"`
+}
+
+// WaitlistConfig configuración de las listas de espera. Con Enabled, cuando se libera
+// stock de un producto (liberación, expiración, reposición o ajuste) las entradas en
+// espera se atienden por prioridad y orden de llegada creando reservas que duran
+// ReservationTTLMinutes. Cada SweepIntervalMinutes se expiran las entradas vencidas
+// y se reintentan los productos con entradas pendientes.
+type WaitlistConfig struct {
+	Enabled               bool `envconfig:"WAITLIST_ENABLED" yaml:"enabled"`
+	SweepIntervalMinutes  int  `envconfig:"WAITLIST_SWEEP_INTERVAL_MINUTES" yaml:"sweep_interval_minutes"`
+	DefaultTTLMinutes     int  `envconfig:"WAITLIST_DEFAULT_TTL_MINUTES" yaml:"default_ttl_minutes"`
+	MaxTTLMinutes         int  `envconfig:"WAITLIST_MAX_TTL_MINUTES" yaml:"max_ttl_minutes"`
+	ReservationTTLMinutes int  `envconfig:"WAITLIST_RESERVATION_TTL_MINUTES" yaml:"reservation_ttl_minutes"`
+}
+
+// Estrategias para aplicar cambios de stock
+const (
+	// StockUpdateOptimistic lee la fila, la modifica en Go y la escribe con chequeo de versión
+	StockUpdateOptimistic = "optimistic"
+	// StockUpdateConditional aplica el cambio con un único UPDATE condicional en SQL
+	StockUpdateConditional = "conditional"
+)
+
+// ReservationConfig configuración de TTLs de reservas, de la estrategia de actualización
+// de stock y de reintentos ante conflictos de bloqueo optimista (reservar, confirmar,
+// liberar y expirar).
+type ReservationConfig struct {
+	DefaultTTLMinutes         int    `envconfig:"RESERVATION_DEFAULT_TTL_MINUTES" yaml:"default_ttl_minutes"`
+	MaxTTLMinutes             int    `envconfig:"RESERVATION_MAX_TTL_MINUTES" yaml:"max_ttl_minutes"`
+	StockUpdateStrategy       string `envconfig:"RESERVATION_STOCK_UPDATE_STRATEGY" yaml:"stock_update_strategy"`               // optimistic | conditional
+	ConflictMaxAttempts       int    `envconfig:"RESERVATION_CONFLICT_MAX_ATTEMPTS" yaml:"conflict_max_attempts"`               // intentos totales, incluido el primero
+	ConflictBaseDelayMs       int    `envconfig:"RESERVATION_CONFLICT_BASE_DELAY_MS" yaml:"conflict_base_delay_ms"`             // backoff inicial, se duplica con jitter
+	ConflictMaxDelayMs        int    `envconfig:"RESERVATION_CONFLICT_MAX_DELAY_MS" yaml:"conflict_max_delay_ms"`               // tope de cada backoff
+	ConflictRetryAfterSeconds int    `envconfig:"RESERVATION_CONFLICT_RETRY_AFTER_SECONDS" yaml:"conflict_retry_after_seconds"` // Retry-After devuelto al agotar los intentos
+}
+
+// RateLimitConfig configuración de cuotas de rate limiting (token bucket por servicio autenticado).
+// GetLimit/WriteLimit son las cuotas por defecto de cada servicio; Quotas las sobrescribe
+// por servicio y/o ruta. Si Redis no está disponible se aplican los mismos límites en memoria.
+type RateLimitConfig struct {
+	Enabled         bool            `envconfig:"RATE_LIMIT_ENABLED" yaml:"enabled"`
+	WindowSeconds   int             `envconfig:"RATE_LIMIT_WINDOW_SECONDS" yaml:"window_seconds"`
+	GetLimit        int64           `envconfig:"RATE_LIMIT_GET" yaml:"get_limit"`     // requests per window for GET/HEAD
+	WriteLimit      int64           `envconfig:"RATE_LIMIT_WRITE" yaml:"write_limit"` // requests per window for POST/PUT/PATCH/DELETE
+	Quotas          RateLimitQuotas `envconfig:"RATE_LIMIT_QUOTAS" yaml:"quotas"`
+	RedisTimeoutMs  int             `envconfig:"RATE_LIMIT_REDIS_TIMEOUT_MS" yaml:"redis_timeout_ms"`
+	ProbeIntervalMs int             `envconfig:"RATE_LIMIT_PROBE_INTERVAL_MS" yaml:"probe_interval_ms"` // retry Redis after a failure
+}
+
+// RateLimitQuota sobrescribe la cuota por defecto para un servicio, una ruta o ambos.
+// Los campos vacíos aplican a cualquier valor; gana la regla más específica.
+type RateLimitQuota struct {
+	Service       string `json:"service,omitempty" yaml:"service,omitempty"`               // token subject
+	Method        string `json:"method,omitempty" yaml:"method,omitempty"`                 // HTTP method
+	Route         string `json:"route,omitempty" yaml:"route,omitempty"`                   // route template or gRPC full method
+	Limit         int64  `json:"limit" yaml:"limit"`                                       // requests per window
+	WindowSeconds int    `json:"window_seconds,omitempty" yaml:"window_seconds,omitempty"` // 0 uses the default window
+}
+
+// RateLimitQuotas lista de cuotas específicas.
+// En variables de entorno se expresa como un array JSON (RATE_LIMIT_QUOTAS).
+type RateLimitQuotas []RateLimitQuota
+
+// Decode implementa envconfig.Decoder para leer las cuotas como JSON
+func (q *RateLimitQuotas) Decode(value string) error {
+	if strings.TrimSpace(value) == "" {
+		*q = nil
+		return nil
+	}
+	var quotas []RateLimitQuota
+	if err := json.Unmarshal([]byte(value), &quotas); err != nil {
+		return fmt.Errorf("RATE_LIMIT_QUOTAS must be a JSON array of quotas: %w", err)
+	}
+	*q = quotas
+	return nil
+}
+
+// AuthConfig configuración de autenticación service-to-service con tokens firmados.
+// Sin claves configuradas la autenticación queda deshabilitada (solo fuera de producción).
+type AuthConfig struct {
+	TokenKeys        TokenKeys `envconfig:"SERVICE_TOKEN_KEYS" yaml:"token_keys"`
+	TokenAudience    string    `envconfig:"SERVICE_TOKEN_AUDIENCE" yaml:"token_audience"`
+	TokenIssuer      string    `envconfig:"SERVICE_TOKEN_ISSUER" yaml:"token_issuer"` // empty accepts any issuer
+	ClockSkewSeconds int       `envconfig:"SERVICE_TOKEN_CLOCK_SKEW_SECONDS" yaml:"clock_skew_seconds"`
+	DenialAuditSize  int       `envconfig:"AUTH_DENIAL_AUDIT_SIZE" yaml:"denial_audit_size"`
+}
+
+// TokenKeyConfig describe una clave de verificación de tokens.
+// Durante una rotación se configuran la clave vieja y la nueva con ventanas superpuestas.
+type TokenKeyConfig struct {
+	ID        string    `json:"id" yaml:"id"`
+	Algorithm string    `json:"algorithm" yaml:"algorithm"`                       // HS256 or EdDSA
+	Secret    string    `json:"secret,omitempty" yaml:"secret,omitempty"`         // base64, HS256 only
+	PublicKey string    `json:"public_key,omitempty" yaml:"public_key,omitempty"` // base64, EdDSA only
+	NotBefore time.Time `json:"not_before,omitzero" yaml:"not_before,omitempty"`
+	NotAfter  time.Time `json:"not_after,omitzero" yaml:"not_after,omitempty"`
+}
+
+// TokenKeys lista de claves de verificación.
+// En variables de entorno se expresa como un array JSON (SERVICE_TOKEN_KEYS).
+type TokenKeys []TokenKeyConfig
+
+// Decode implementa envconfig.Decoder para leer las claves como JSON
+func (k *TokenKeys) Decode(value string) error {
+	if strings.TrimSpace(value) == "" {
+		*k = nil
+		return nil
+	}
+	var keys []TokenKeyConfig
+	if err := json.Unmarshal([]byte(value), &keys); err != nil {
+		return fmt.Errorf("SERVICE_TOKEN_KEYS must be a JSON array of keys: %w", err)
+	}
+	*k = keys
+	return nil
+}
+
+// HealthConfig configuración de los probes de readiness
+type HealthConfig struct {
+	CheckTimeoutMs int `envconfig:"HEALTH_CHECK_TIMEOUT_MS" yaml:"check_timeout_ms"`
 }
 
 // LoggerConfig configuración del sistema de logs
 type LoggerConfig struct {
-	Level  string `envconfig:"LOG_LEVEL" default:"info"`
-	Format string `envconfig:"LOG_FORMAT" default:"json"`
+	Level  string `envconfig:"LOG_LEVEL" yaml:"level"`
+	Format string `envconfig:"LOG_FORMAT" yaml:"format"`
 }
 
-// Load carga la configuración desde .env y variables de sistema
-func Load() (*Config, error) {
+// Default retorna la configuración con todos los valores por defecto
+func Default() *Config {
+	return &Config{
+		Server: ServerConfig{
+			Port:            "8080",
+			Environment:     "development",
+			GinMode:         "debug",
+			ReadTimeout:     10,
+			WriteTimeout:    10,
+			IdleTimeout:     120,
+			ShutdownTimeout: 5,
+			GRPCEnabled:     true,
+			GRPCPort:        "9090",
+		},
+		Database: DatabaseConfig{
+			Port:     5432,
+			SSLMode:  "disable",
+			MaxConns: 25,
+			MinConns: 5,
+		},
+		Redis: RedisConfig{
+			Enabled:         true,
+			Host:            "localhost",
+			Port:            6379,
+			DB:              0,
+			CacheTTLSeconds: 300,
+		},
+		Publisher: PublisherConfig{
+			Transport:        EventTransportAuto,
+			Exchange:         "inventory.events",
+			ExchangeType:     "topic",
+			MaxRetries:       3,
+			RetryDelayMs:     1000,
+			SchemaValidation: SchemaValidationAuto,
+			EventFormat:      EventFormatLegacy,
+		},
+		NATS: NATSConfig{
+			Stream:           "INVENTORY_EVENTS",
+			PublishTimeoutMs: 5000,
+		},
+		CatalogSync: CatalogSyncConfig{
+			Enabled:  false,
+			Exchange: "orders.events",
+			Queue:    "inventory.catalog_events",
+			Prefetch: 10,
+		},
+		Scheduler: SchedulerConfig{
+			Enabled:         true,
+			IntervalMinutes: 10,
+		},
+		Reconcile: ReconcileConfig{
+			Enabled:         false,
+			IntervalMinutes: 60,
+			Repair:          false,
+			GraceMinutes:    5,
+		},
+		Retention: RetentionConfig{
+			Enabled:         false,
+			IntervalMinutes: 360,
+			Days:            90,
+			BatchSize:       1000,
+			MaxBatches:      100,
+			PartitionsAhead: 3,
+		},
+		StockHistory: StockHistoryConfig{
+			Enabled:                 true,
+			SnapshotIntervalMinutes: 1440,
+			SettleMinutes:           5,
+			RetentionDays:           400,
+		},
+		Lots: LotsConfig{
+			Enabled:                   true,
+			QuarantineIntervalMinutes: 60,
+		},
+		Waitlist: WaitlistConfig{
+			Enabled:               true,
+			SweepIntervalMinutes:  1,
+			DefaultTTLMinutes:     1440,
+			MaxTTLMinutes:         10080,
+			ReservationTTLMinutes: 60,
+		},
+		Reservation: ReservationConfig{
+			DefaultTTLMinutes:         15,
+			MaxTTLMinutes:             60,
+			StockUpdateStrategy:       StockUpdateOptimistic,
+			ConflictMaxAttempts:       5,
+			ConflictBaseDelayMs:       10,
+			ConflictMaxDelayMs:        200,
+			ConflictRetryAfterSeconds: 1,
+		},
+		RateLimit: RateLimitConfig{
+			Enabled:         true,
+			WindowSeconds:   60,
+			GetLimit:        200,
+			WriteLimit:      100,
+			RedisTimeoutMs:  50,
+			ProbeIntervalMs: 5000,
+		},
+		Auth: AuthConfig{
+			TokenAudience:    "inventory-service",
+			ClockSkewSeconds: 30,
+			DenialAuditSize:  500,
+		},
+		Health: HealthConfig{
+			CheckTimeoutMs: 2000,
+		},
+		Logger: LoggerConfig{
+			Level:  "info",
+			Format: "json",
+		},
+	}
+}
+
+// Load carga la configuración (defaults, YAML opcional, .env y variables de sistema) y la valida.
+// Si path está vacío no se lee ningún archivo YAML.
+func Load(path string) (*Config, error) {
+	cfg, err := LoadUnvalidated(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// LoadUnvalidated carga la configuración sin validarla.
+// Útil para --print-config, donde se quiere mostrar la configuración aunque sea inválida.
+func LoadUnvalidated(path string) (*Config, error) {
 	// Intentar cargar .env (opcional en producción)
 	if err := godotenv.Load(); err != nil {
 		log.Println("⚠️  No .env file found, using system environment variables")
 	}
 
-	var cfg Config
-	if err := envconfig.Process("", &cfg); err != nil {
+	cfg := Default()
+
+	if path != "" {
+		if err := cfg.loadFile(path); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := envconfig.Process("", cfg); err != nil {
 		return nil, fmt.Errorf("failed to process config: %w", err)
 	}
 
-	// Validaciones custom
-	if cfg.Database.MaxConns < cfg.Database.MinConns {
-		return nil, fmt.Errorf("DB_MAX_CONNS must be >= DB_MIN_CONNS")
+	return cfg, nil
+}
+
+// loadFile aplica el archivo YAML sobre la configuración actual.
+// Las claves desconocidas son un error para detectar typos temprano.
+func (c *Config) loadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file %s: %w", path, err)
 	}
 
-	return &cfg, nil
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(c); err != nil {
+		return fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// IsProduction retorna true si el servicio corre en producción
+func (c *Config) IsProduction() bool {
+	return c.Server.Environment == "production"
 }
 
 // GetDSN construye el Data Source Name para PostgreSQL
@@ -83,3 +529,213 @@ func (d *DatabaseConfig) GetDSN() string {
 func (r *RedisConfig) GetRedisAddr() string {
 	return fmt.Sprintf("%s:%d", r.Host, r.Port)
 }
+
+// CacheTTL retorna el TTL de cache como time.Duration
+func (r *RedisConfig) CacheTTL() time.Duration {
+	return time.Duration(r.CacheTTLSeconds) * time.Second
+}
+
+// IsEnabled retorna true si hay un broker configurado
+func (p *PublisherConfig) IsEnabled() bool {
+	return p.URL != ""
+}
+
+// RetryDelay retorna el delay entre reintentos como time.Duration
+func (p *PublisherConfig) RetryDelay() time.Duration {
+	return time.Duration(p.RetryDelayMs) * time.Millisecond
+}
+
+// TransportName retorna el transporte efectivo, resolviendo auto
+func (p *PublisherConfig) TransportName() string {
+	if p.Transport != EventTransportAuto && p.Transport != "" {
+		return p.Transport
+	}
+	if p.IsEnabled() {
+		return EventTransportRabbitMQ
+	}
+	return EventTransportNoop
+}
+
+// PublishTimeout retorna la espera máxima del ack de JetStream como time.Duration
+func (n *NATSConfig) PublishTimeout() time.Duration {
+	return time.Duration(n.PublishTimeoutMs) * time.Millisecond
+}
+
+// ValidatesEventSchemas retorna true si los eventos se validan contra su schema antes
+// de publicarse. En modo auto solo se valida fuera de producción.
+func (c *Config) ValidatesEventSchemas() bool {
+	switch c.Publisher.SchemaValidation {
+	case SchemaValidationOn:
+		return true
+	case SchemaValidationOff:
+		return false
+	default:
+		return !c.IsProduction()
+	}
+}
+
+// Interval retorna el intervalo del scheduler como time.Duration
+func (s *SchedulerConfig) Interval() time.Duration {
+	return time.Duration(s.IntervalMinutes) * time.Minute
+}
+
+// Interval retorna el intervalo entre reconciliaciones
+func (r *ReconcileConfig) Interval() time.Duration {
+	return time.Duration(r.IntervalMinutes) * time.Minute
+}
+
+// Grace retorna la antigüedad mínima de las filas que se reconcilian
+func (r *ReconcileConfig) Grace() time.Duration {
+	return time.Duration(r.GraceMinutes) * time.Minute
+}
+
+// Interval retorna el intervalo entre corridas de retención
+func (r *RetentionConfig) Interval() time.Duration {
+	return time.Duration(r.IntervalMinutes) * time.Minute
+}
+
+// Retention retorna la antigüedad a partir de la cual se archiva una reserva terminada
+func (r *RetentionConfig) Retention() time.Duration {
+	return time.Duration(r.Days) * 24 * time.Hour
+}
+
+// SnapshotInterval retorna el intervalo entre snapshots de stock
+func (s *StockHistoryConfig) SnapshotInterval() time.Duration {
+	return time.Duration(s.SnapshotIntervalMinutes) * time.Minute
+}
+
+// Settle retorna cuánto atrás del reloj se toma cada snapshot
+func (s *StockHistoryConfig) Settle() time.Duration {
+	return time.Duration(s.SettleMinutes) * time.Minute
+}
+
+// Retention retorna cuánto tiempo se conserva el historial de stock (0 = sin límite)
+func (s *StockHistoryConfig) Retention() time.Duration {
+	return time.Duration(s.RetentionDays) * 24 * time.Hour
+}
+
+// QuarantineInterval retorna el intervalo entre corridas de cuarentena de lotes vencidos
+func (l *LotsConfig) QuarantineInterval() time.Duration {
+	return time.Duration(l.QuarantineIntervalMinutes) * time.Minute
+}
+
+// SweepInterval retorna el intervalo entre barridos de las listas de espera
+func (w *WaitlistConfig) SweepInterval() time.Duration {
+	return time.Duration(w.SweepIntervalMinutes) * time.Minute
+}
+
+// DefaultTTL retorna el TTL de las entradas que no indican uno
+func (w *WaitlistConfig) DefaultTTL() time.Duration {
+	return time.Duration(w.DefaultTTLMinutes) * time.Minute
+}
+
+// MaxTTL retorna el TTL máximo permitido para una entrada
+func (w *WaitlistConfig) MaxTTL() time.Duration {
+	return time.Duration(w.MaxTTLMinutes) * time.Minute
+}
+
+// ReservationTTL retorna la duración de las reservas creadas al atender una entrada
+func (w *WaitlistConfig) ReservationTTL() time.Duration {
+	return time.Duration(w.ReservationTTLMinutes) * time.Minute
+}
+
+// DefaultTTL retorna el TTL por defecto de una reserva
+func (r *ReservationConfig) DefaultTTL() time.Duration {
+	return time.Duration(r.DefaultTTLMinutes) * time.Minute
+}
+
+// MaxTTL retorna el TTL máximo permitido para una reserva
+func (r *ReservationConfig) MaxTTL() time.Duration {
+	return time.Duration(r.MaxTTLMinutes) * time.Minute
+}
+
+// UsesConditionalUpdates retorna true si los cambios de stock se aplican con UPDATE condicional
+func (r *ReservationConfig) UsesConditionalUpdates() bool {
+	return r.StockUpdateStrategy == StockUpdateConditional
+}
+
+// ConflictBaseDelay retorna el backoff inicial ante un conflicto de versión
+func (r *ReservationConfig) ConflictBaseDelay() time.Duration {
+	return time.Duration(r.ConflictBaseDelayMs) * time.Millisecond
+}
+
+// ConflictMaxDelay retorna el backoff máximo ante un conflicto de versión
+func (r *ReservationConfig) ConflictMaxDelay() time.Duration {
+	return time.Duration(r.ConflictMaxDelayMs) * time.Millisecond
+}
+
+// ConflictRetryAfter retorna la espera sugerida al cliente cuando se agotan los reintentos
+func (r *ReservationConfig) ConflictRetryAfter() time.Duration {
+	return time.Duration(r.ConflictRetryAfterSeconds) * time.Second
+}
+
+// Window retorna la ventana de rate limiting como time.Duration
+func (r *RateLimitConfig) Window() time.Duration {
+	return time.Duration(r.WindowSeconds) * time.Second
+}
+
+// Policy construye la política de cuotas
+func (r *RateLimitConfig) Policy() *ratelimit.Policy {
+	rules := make([]ratelimit.Rule, 0, len(r.Quotas))
+	for _, q := range r.Quotas {
+		rules = append(rules, ratelimit.Rule{
+			Service: q.Service,
+			Method:  strings.ToUpper(q.Method),
+			Route:   q.Route,
+			Limit:   q.Limit,
+			Window:  time.Duration(q.WindowSeconds) * time.Second,
+		})
+	}
+	return &ratelimit.Policy{
+		ReadLimit:  r.GetLimit,
+		WriteLimit: r.WriteLimit,
+		Window:     r.Window(),
+		Rules:      rules,
+	}
+}
+
+// RedisTimeout retorna el timeout de cada consulta a Redis como time.Duration
+func (r *RateLimitConfig) RedisTimeout() time.Duration {
+	return time.Duration(r.RedisTimeoutMs) * time.Millisecond
+}
+
+// ProbeInterval retorna cada cuánto se reintenta Redis tras un fallo
+func (r *RateLimitConfig) ProbeInterval() time.Duration {
+	return time.Duration(r.ProbeIntervalMs) * time.Millisecond
+}
+
+// Enabled retorna true si hay claves de verificación configuradas
+func (a *AuthConfig) Enabled() bool {
+	return len(a.TokenKeys) > 0
+}
+
+// Keys decodifica las claves configuradas
+func (a *AuthConfig) Keys() ([]auth.Key, error) {
+	keys := make([]auth.Key, 0, len(a.TokenKeys))
+	for _, k := range a.TokenKeys {
+		material := k.Secret
+		if k.Algorithm == auth.AlgorithmEdDSA {
+			material = k.PublicKey
+		}
+		key, err := auth.DecodeKey(k.ID, k.Algorithm, material, k.NotBefore, k.NotAfter)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// VerifierOptions retorna las opciones de validación de claims
+func (a *AuthConfig) VerifierOptions() auth.VerifierOptions {
+	return auth.VerifierOptions{
+		Audience: a.TokenAudience,
+		Issuer:   a.TokenIssuer,
+		Leeway:   time.Duration(a.ClockSkewSeconds) * time.Second,
+	}
+}
+
+// CheckTimeout retorna el timeout de cada probe como time.Duration
+func (h *HealthConfig) CheckTimeout() time.Duration {
+	return time.Duration(h.CheckTimeoutMs) * time.Millisecond
+}
@@ -0,0 +1,216 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ValidationError agrupa todos los problemas de configuración encontrados,
+// para poder corregirlos en un solo intento en lugar de uno por arranque.
+type ValidationError struct {
+	Problems []string
+}
+
+// Error implements the error interface.
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid configuration (%d problems):\n  - %s", len(e.Problems), strings.Join(e.Problems, "\n  - "))
+}
+
+// validator acumula problemas de validación
+type validator struct {
+	problems []string
+}
+
+func (v *validator) check(ok bool, format string, args ...interface{}) {
+	if !ok {
+		v.problems = append(v.problems, fmt.Sprintf(format, args...))
+	}
+}
+
+var (
+	validEnvironments = []string{"development", "test", "staging", "production"}
+	validGinModes     = []string{"debug", "release", "test"}
+	validLogLevels    = []string{"debug", "info", "warn", "error"}
+	validLogFormats   = []string{"json", "text"}
+	validExchanges    = []string{"topic", "direct", "fanout", "headers"}
+	validStockUpdates = []string{StockUpdateOptimistic, StockUpdateConditional}
+	validSchemaModes  = []string{SchemaValidationAuto, SchemaValidationOn, SchemaValidationOff}
+	validEventFormats = []string{EventFormatLegacy, EventFormatCloudEventsBinary, EventFormatCloudEventsStructured}
+	validTransports   = []string{EventTransportAuto, EventTransportRabbitMQ, EventTransportNATS, EventTransportMemory, EventTransportNoop}
+)
+
+// Validate verifica la configuración completa y retorna un *ValidationError
+// con todos los problemas encontrados, o nil si es válida.
+func (c *Config) Validate() error {
+	v := &validator{}
+
+	// Server
+	port, err := strconv.Atoi(c.Server.Port)
+	v.check(err == nil && port > 0 && port <= 65535, "PORT must be a number between 1 and 65535 (got %q)", c.Server.Port)
+	v.check(contains(validEnvironments, c.Server.Environment), "ENVIRONMENT must be one of %v (got %q)", validEnvironments, c.Server.Environment)
+	v.check(contains(validGinModes, c.Server.GinMode), "GIN_MODE must be one of %v (got %q)", validGinModes, c.Server.GinMode)
+	v.check(c.Server.ReadTimeout > 0, "READ_TIMEOUT must be positive")
+	v.check(c.Server.WriteTimeout > 0, "WRITE_TIMEOUT must be positive")
+	v.check(c.Server.IdleTimeout > 0, "IDLE_TIMEOUT must be positive")
+	v.check(c.Server.ShutdownTimeout > 0, "SHUTDOWN_TIMEOUT must be positive")
+	if c.Server.GRPCEnabled {
+		grpcPort, err := strconv.Atoi(c.Server.GRPCPort)
+		v.check(err == nil && grpcPort > 0 && grpcPort <= 65535, "GRPC_PORT must be a number between 1 and 65535 (got %q)", c.Server.GRPCPort)
+		v.check(c.Server.GRPCPort != c.Server.Port, "GRPC_PORT must differ from PORT")
+	}
+
+	// Database
+	v.check(c.Database.Host != "", "DB_HOST is required")
+	v.check(c.Database.User != "", "DB_USER is required")
+	v.check(c.Database.Password != "", "DB_PASSWORD is required")
+	v.check(c.Database.Database != "", "DB_NAME is required")
+	v.check(c.Database.Port > 0 && c.Database.Port <= 65535, "DB_PORT must be between 1 and 65535")
+	v.check(c.Database.MinConns >= 0, "DB_MIN_CONNS must not be negative")
+	v.check(c.Database.MaxConns >= c.Database.MinConns, "DB_MAX_CONNS must be >= DB_MIN_CONNS")
+
+	// Redis (opcional)
+	if c.Redis.Enabled {
+		v.check(c.Redis.Host != "", "REDIS_HOST is required when REDIS_ENABLED=true")
+		v.check(c.Redis.Port > 0 && c.Redis.Port <= 65535, "REDIS_PORT must be between 1 and 65535")
+		v.check(c.Redis.DB >= 0, "REDIS_DB must not be negative")
+		v.check(c.Redis.CacheTTLSeconds > 0, "REDIS_CACHE_TTL_SECONDS must be positive")
+	}
+
+	// Publisher (opcional)
+	if c.Publisher.IsEnabled() {
+		v.check(c.Publisher.Exchange != "", "RABBITMQ_EXCHANGE is required when RABBITMQ_URL is set")
+		v.check(contains(validExchanges, c.Publisher.ExchangeType), "RABBITMQ_EXCHANGE_TYPE must be one of %v (got %q)", validExchanges, c.Publisher.ExchangeType)
+		v.check(c.Publisher.MaxRetries >= 0, "RABBITMQ_MAX_RETRIES must not be negative")
+		v.check(c.Publisher.RetryDelayMs > 0, "RABBITMQ_RETRY_DELAY_MS must be positive")
+		v.check(contains(validEventFormats, c.Publisher.EventFormat), "RABBITMQ_EVENT_FORMAT must be one of %v (got %q)", validEventFormats, c.Publisher.EventFormat)
+	}
+	v.check(contains(validTransports, c.Publisher.Transport), "EVENT_TRANSPORT must be one of %v (got %q)", validTransports, c.Publisher.Transport)
+	if c.Publisher.Transport == EventTransportRabbitMQ {
+		v.check(c.Publisher.IsEnabled(), "RABBITMQ_URL is required when EVENT_TRANSPORT=rabbitmq")
+	}
+	if c.Publisher.Transport == EventTransportNATS {
+		v.check(c.NATS.URL != "", "NATS_URL is required when EVENT_TRANSPORT=nats")
+		v.check(c.NATS.Stream != "", "NATS_STREAM is required when EVENT_TRANSPORT=nats")
+		v.check(c.NATS.PublishTimeoutMs > 0, "NATS_PUBLISH_TIMEOUT_MS must be positive")
+	}
+	v.check(contains(validSchemaModes, c.Publisher.SchemaValidation), "EVENT_SCHEMA_VALIDATION must be one of %v (got %q)", validSchemaModes, c.Publisher.SchemaValidation)
+
+	// Catalog sync (opcional)
+	if c.CatalogSync.Enabled {
+		v.check(c.Publisher.IsEnabled(), "RABBITMQ_URL is required when CATALOG_SYNC_ENABLED=true")
+		v.check(c.CatalogSync.Exchange != "", "CATALOG_SYNC_EXCHANGE is required when CATALOG_SYNC_ENABLED=true")
+		v.check(c.CatalogSync.Queue != "", "CATALOG_SYNC_QUEUE is required when CATALOG_SYNC_ENABLED=true")
+		v.check(c.CatalogSync.Prefetch > 0, "CATALOG_SYNC_PREFETCH must be positive")
+	}
+	v.check(c.CatalogSync.InitialStock >= 0, "CATALOG_INITIAL_STOCK must not be negative")
+	for i, rule := range c.CatalogSync.InitialStockRules {
+		v.check(rule.SKUPrefix != "", "CATALOG_INITIAL_STOCK_RULES[%d]: sku_prefix is required", i)
+		v.check(rule.Quantity >= 0, "CATALOG_INITIAL_STOCK_RULES[%d]: quantity must not be negative", i)
+	}
+
+	// Scheduler
+	if c.Scheduler.Enabled {
+		v.check(c.Scheduler.IntervalMinutes > 0, "SCHEDULER_INTERVAL_MINUTES must be positive")
+	}
+
+	// Reconcile
+	if c.Reconcile.Enabled {
+		v.check(c.Reconcile.IntervalMinutes > 0, "RECONCILE_INTERVAL_MINUTES must be positive")
+	}
+	v.check(c.Reconcile.GraceMinutes >= 0, "RECONCILE_GRACE_MINUTES must not be negative")
+
+	// Retention
+	if c.Retention.Enabled {
+		v.check(c.Retention.IntervalMinutes > 0, "RETENTION_INTERVAL_MINUTES must be positive")
+	}
+	v.check(c.Retention.Days > 0, "RETENTION_DAYS must be positive")
+	v.check(c.Retention.BatchSize > 0, "RETENTION_BATCH_SIZE must be positive")
+	v.check(c.Retention.MaxBatches >= 0, "RETENTION_MAX_BATCHES must be >= 0")
+	v.check(c.Retention.PartitionsAhead > 0, "RETENTION_PARTITIONS_AHEAD must be positive")
+
+	// Stock history
+	if c.StockHistory.Enabled {
+		v.check(c.StockHistory.SnapshotIntervalMinutes > 0, "STOCK_HISTORY_SNAPSHOT_INTERVAL_MINUTES must be positive")
+	}
+	v.check(c.StockHistory.SettleMinutes > 0, "STOCK_HISTORY_SETTLE_MINUTES must be positive")
+	v.check(c.StockHistory.RetentionDays >= 0, "STOCK_HISTORY_RETENTION_DAYS must be >= 0")
+
+	// Lots
+	if c.Lots.Enabled {
+		v.check(c.Lots.QuarantineIntervalMinutes > 0, "LOTS_QUARANTINE_INTERVAL_MINUTES must be positive")
+	}
+
+	// Waitlist
+	if c.Waitlist.Enabled {
+		v.check(c.Waitlist.SweepIntervalMinutes > 0, "WAITLIST_SWEEP_INTERVAL_MINUTES must be positive")
+		v.check(c.Waitlist.DefaultTTLMinutes > 0, "WAITLIST_DEFAULT_TTL_MINUTES must be positive")
+		v.check(c.Waitlist.MaxTTLMinutes >= c.Waitlist.DefaultTTLMinutes, "WAITLIST_MAX_TTL_MINUTES must be >= WAITLIST_DEFAULT_TTL_MINUTES")
+		v.check(c.Waitlist.ReservationTTLMinutes > 0, "WAITLIST_RESERVATION_TTL_MINUTES must be positive")
+		v.check(c.Waitlist.ReservationTTLMinutes <= c.Reservation.MaxTTLMinutes, "WAITLIST_RESERVATION_TTL_MINUTES must be <= RESERVATION_MAX_TTL_MINUTES")
+	}
+
+	// Reservation
+	v.check(c.Reservation.DefaultTTLMinutes > 0, "RESERVATION_DEFAULT_TTL_MINUTES must be positive")
+	v.check(c.Reservation.MaxTTLMinutes >= c.Reservation.DefaultTTLMinutes, "RESERVATION_MAX_TTL_MINUTES must be >= RESERVATION_DEFAULT_TTL_MINUTES")
+	v.check(contains(validStockUpdates, c.Reservation.StockUpdateStrategy), "RESERVATION_STOCK_UPDATE_STRATEGY must be one of %v (got %q)", validStockUpdates, c.Reservation.StockUpdateStrategy)
+	v.check(c.Reservation.ConflictMaxAttempts > 0, "RESERVATION_CONFLICT_MAX_ATTEMPTS must be positive")
+	v.check(c.Reservation.ConflictBaseDelayMs >= 0, "RESERVATION_CONFLICT_BASE_DELAY_MS must not be negative")
+	v.check(c.Reservation.ConflictMaxDelayMs >= c.Reservation.ConflictBaseDelayMs, "RESERVATION_CONFLICT_MAX_DELAY_MS must be >= RESERVATION_CONFLICT_BASE_DELAY_MS")
+	v.check(c.Reservation.ConflictRetryAfterSeconds > 0, "RESERVATION_CONFLICT_RETRY_AFTER_SECONDS must be positive")
+
+	// Rate limiting
+	if c.RateLimit.Enabled {
+		v.check(c.RateLimit.WindowSeconds > 0, "RATE_LIMIT_WINDOW_SECONDS must be positive")
+		v.check(c.RateLimit.GetLimit > 0, "RATE_LIMIT_GET must be positive")
+		v.check(c.RateLimit.WriteLimit > 0, "RATE_LIMIT_WRITE must be positive")
+		v.check(c.RateLimit.RedisTimeoutMs > 0, "RATE_LIMIT_REDIS_TIMEOUT_MS must be positive")
+		v.check(c.RateLimit.ProbeIntervalMs > 0, "RATE_LIMIT_PROBE_INTERVAL_MS must be positive")
+		for i, q := range c.RateLimit.Quotas {
+			v.check(q.Service != "" || q.Method != "" || q.Route != "", "RATE_LIMIT_QUOTAS[%d]: service, method or route is required", i)
+			v.check(q.Limit > 0, "RATE_LIMIT_QUOTAS[%d]: limit must be positive", i)
+			v.check(q.WindowSeconds >= 0, "RATE_LIMIT_QUOTAS[%d]: window_seconds must not be negative", i)
+			v.check(q.Route == "" || strings.HasPrefix(q.Route, "/"), "RATE_LIMIT_QUOTAS[%d]: route must start with / (got %q)", i, q.Route)
+		}
+	}
+
+	// Auth
+	if c.IsProduction() {
+		v.check(c.Auth.Enabled(), "SERVICE_TOKEN_KEYS must be configured in production environment")
+	}
+	if c.Auth.Enabled() {
+		v.check(c.Auth.TokenAudience != "", "SERVICE_TOKEN_AUDIENCE is required when SERVICE_TOKEN_KEYS is set")
+		seen := make(map[string]bool)
+		for _, k := range c.Auth.TokenKeys {
+			v.check(!seen[k.ID], "SERVICE_TOKEN_KEYS: duplicate key id %q", k.ID)
+			seen[k.ID] = true
+		}
+		if _, err := c.Auth.Keys(); err != nil {
+			v.check(false, "SERVICE_TOKEN_KEYS: %v", err)
+		}
+	}
+	v.check(c.Auth.ClockSkewSeconds >= 0, "SERVICE_TOKEN_CLOCK_SKEW_SECONDS must not be negative")
+	v.check(c.Auth.DenialAuditSize > 0, "AUTH_DENIAL_AUDIT_SIZE must be positive")
+
+	// Health
+	v.check(c.Health.CheckTimeoutMs > 0, "HEALTH_CHECK_TIMEOUT_MS must be positive")
+
+	// Logger
+	v.check(contains(validLogLevels, c.Logger.Level), "LOG_LEVEL must be one of %v (got %q)", validLogLevels, c.Logger.Level)
+	v.check(contains(validLogFormats, c.Logger.Format), "LOG_FORMAT must be one of %v (got %q)", validLogFormats, c.Logger.Format)
+
+	if len(v.problems) > 0 {
+		return &ValidationError{Problems: v.problems}
+	}
+	return nil
+}
+
+// contains checks if a value is in a list of allowed values
+func contains(allowed []string, value string) bool {
+	for _, a := range allowed {
+		if a == value {
+			return true
+		}
+	}
+	return false
+}
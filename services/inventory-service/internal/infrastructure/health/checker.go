@@ -0,0 +1,167 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Status represents the aggregated health of the service or of a single component
+type Status string
+
+const (
+	// StatusOK indicates every dependency responded successfully
+	StatusOK Status = "ok"
+	// StatusDegraded indicates an optional dependency failed; the service can still serve traffic
+	StatusDegraded Status = "degraded"
+	// StatusUnavailable indicates a critical dependency failed; the service should not receive traffic
+	StatusUnavailable Status = "unavailable"
+)
+
+// DefaultTimeout is the maximum time a single component probe may take
+const DefaultTimeout = 2 * time.Second
+
+// CheckFunc probes a single dependency and returns an error if it is unhealthy
+type CheckFunc func(ctx context.Context) error
+
+// Component describes a dependency registered in the Checker
+type Component struct {
+	Name     string
+	Critical bool // Critical components make the service unavailable when they fail
+	Check    CheckFunc
+}
+
+// ComponentResult is the outcome of probing a single component
+type ComponentResult struct {
+	Name      string `json:"name"`
+	Status    Status `json:"status"`
+	Critical  bool   `json:"critical"`
+	LatencyMs int64  `json:"latency_ms"`
+	Error     string `json:"error,omitempty"`
+}
+
+// Report is the aggregated result of a readiness check
+type Report struct {
+	Status     Status            `json:"status"`
+	Components []ComponentResult `json:"components"`
+	CheckedAt  time.Time         `json:"checked_at"`
+}
+
+// IsReady returns true if the service can receive traffic (ok or degraded)
+func (r *Report) IsReady() bool {
+	return r.Status != StatusUnavailable
+}
+
+// Checker runs bounded-time probes against all registered dependencies
+type Checker struct {
+	mu         sync.RWMutex
+	components []Component
+	timeout    time.Duration
+}
+
+// NewChecker creates a new Checker. If timeout is zero, DefaultTimeout is used.
+func NewChecker(timeout time.Duration) *Checker {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	return &Checker{
+		timeout: timeout,
+	}
+}
+
+// Register adds a component to be probed on every readiness check
+func (c *Checker) Register(name string, critical bool, check CheckFunc) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.components = append(c.components, Component{
+		Name:     name,
+		Critical: critical,
+		Check:    check,
+	})
+}
+
+// Check probes all registered components concurrently and aggregates the result.
+// Each probe is bounded by the checker timeout, so a hung dependency cannot block the probe.
+func (c *Checker) Check(ctx context.Context) *Report {
+	c.mu.RLock()
+	components := make([]Component, len(c.components))
+	copy(components, c.components)
+	c.mu.RUnlock()
+
+	results := make([]ComponentResult, len(components))
+
+	var wg sync.WaitGroup
+	for i, component := range components {
+		wg.Add(1)
+		go func(i int, component Component) {
+			defer wg.Done()
+			results[i] = c.probe(ctx, component)
+		}(i, component)
+	}
+	wg.Wait()
+
+	return &Report{
+		Status:     aggregate(results),
+		Components: results,
+		CheckedAt:  time.Now().UTC(),
+	}
+}
+
+// probe runs a single component check with the configured timeout
+func (c *Checker) probe(ctx context.Context, component Component) ComponentResult {
+	probeCtx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	start := time.Now()
+	errChan := make(chan error, 1)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				errChan <- fmt.Errorf("check panicked: %v", r)
+			}
+		}()
+		errChan <- component.Check(probeCtx)
+	}()
+
+	var err error
+	select {
+	case err = <-errChan:
+	case <-probeCtx.Done():
+		err = fmt.Errorf("check timed out after %s", c.timeout)
+	}
+
+	result := ComponentResult{
+		Name:      component.Name,
+		Status:    StatusOK,
+		Critical:  component.Critical,
+		LatencyMs: time.Since(start).Milliseconds(),
+	}
+
+	if err != nil {
+		result.Error = err.Error()
+		if component.Critical {
+			result.Status = StatusUnavailable
+		} else {
+			result.Status = StatusDegraded
+		}
+	}
+
+	return result
+}
+
+// aggregate computes the overall status from individual component results
+func aggregate(results []ComponentResult) Status {
+	status := StatusOK
+	for _, result := range results {
+		switch result.Status {
+		case StatusUnavailable:
+			return StatusUnavailable
+		case StatusDegraded:
+			status = StatusDegraded
+		}
+	}
+	return status
+}
@@ -0,0 +1,151 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func okCheck(ctx context.Context) error { return nil }
+
+func failingCheck(ctx context.Context) error { return errors.New("connection refused") }
+
+func TestChecker_AllHealthy(t *testing.T) {
+	checker := NewChecker(time.Second)
+	checker.Register("postgres", true, okCheck)
+	checker.Register("redis", false, okCheck)
+
+	report := checker.Check(context.Background())
+
+	assert.Equal(t, StatusOK, report.Status)
+	assert.True(t, report.IsReady())
+	require.Len(t, report.Components, 2)
+	assert.Equal(t, "postgres", report.Components[0].Name)
+	assert.True(t, report.Components[0].Critical)
+	assert.Equal(t, StatusOK, report.Components[0].Status)
+	assert.Empty(t, report.Components[0].Error)
+	assert.Equal(t, "redis", report.Components[1].Name)
+	assert.False(t, report.Components[1].Critical)
+}
+
+func TestChecker_OptionalFailureIsDegraded(t *testing.T) {
+	checker := NewChecker(time.Second)
+	checker.Register("postgres", true, okCheck)
+	checker.Register("redis", false, failingCheck)
+
+	report := checker.Check(context.Background())
+
+	assert.Equal(t, StatusDegraded, report.Status)
+	assert.True(t, report.IsReady())
+	assert.Equal(t, StatusDegraded, report.Components[1].Status)
+	assert.Equal(t, "connection refused", report.Components[1].Error)
+}
+
+func TestChecker_DisconnectedOptionalDependency(t *testing.T) {
+	checker := NewChecker(time.Second)
+	checker.Register("postgres", true, okCheck)
+	checker.Register("redis", false, PingCheck(nil))
+
+	report := checker.Check(context.Background())
+
+	assert.Equal(t, StatusDegraded, report.Status)
+	assert.True(t, report.IsReady())
+	assert.Equal(t, "redis", report.Components[1].Name)
+	assert.Equal(t, StatusDegraded, report.Components[1].Status)
+	assert.Equal(t, "not connected", report.Components[1].Error)
+}
+
+func TestChecker_CriticalFailureIsUnavailable(t *testing.T) {
+	checker := NewChecker(time.Second)
+	checker.Register("postgres", true, failingCheck)
+	checker.Register("redis", false, failingCheck)
+
+	report := checker.Check(context.Background())
+
+	assert.Equal(t, StatusUnavailable, report.Status)
+	assert.False(t, report.IsReady())
+	assert.Equal(t, StatusUnavailable, report.Components[0].Status)
+}
+
+func TestChecker_TimeoutIsBounded(t *testing.T) {
+	checker := NewChecker(50 * time.Millisecond)
+	checker.Register("hung", true, func(ctx context.Context) error {
+		// Ignores the context on purpose to simulate a driver that never returns
+		time.Sleep(time.Second)
+		return nil
+	})
+
+	start := time.Now()
+	report := checker.Check(context.Background())
+
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+	assert.Equal(t, StatusUnavailable, report.Status)
+	assert.Contains(t, report.Components[0].Error, "timed out")
+}
+
+func TestChecker_RecoversFromPanic(t *testing.T) {
+	checker := NewChecker(time.Second)
+	checker.Register("broken", false, func(ctx context.Context) error {
+		panic("nil pointer")
+	})
+
+	report := checker.Check(context.Background())
+
+	assert.Equal(t, StatusDegraded, report.Status)
+	assert.Contains(t, report.Components[0].Error, "panicked")
+}
+
+func TestChecker_NoComponents(t *testing.T) {
+	checker := NewChecker(0)
+
+	report := checker.Check(context.Background())
+
+	assert.Equal(t, StatusOK, report.Status)
+	assert.Empty(t, report.Components)
+	assert.Equal(t, DefaultTimeout, checker.timeout)
+}
+
+type fakePinger struct {
+	err error
+}
+
+func (f *fakePinger) Ping(ctx context.Context) error { return f.err }
+
+type fakeHeartbeat struct {
+	last time.Time
+}
+
+func (f *fakeHeartbeat) LastHeartbeat() time.Time { return f.last }
+
+func TestPingCheck(t *testing.T) {
+	assert.NoError(t, PingCheck(&fakePinger{})(context.Background()))
+	assert.Error(t, PingCheck(&fakePinger{err: errors.New("down")})(context.Background()))
+	assert.Error(t, PingCheck(nil)(context.Background()))
+}
+
+func TestHeartbeatCheck(t *testing.T) {
+	t.Run("should pass with a recent heartbeat", func(t *testing.T) {
+		check := HeartbeatCheck(&fakeHeartbeat{last: time.Now()}, time.Minute)
+		assert.NoError(t, check(context.Background()))
+	})
+
+	t.Run("should fail when heartbeat is stale", func(t *testing.T) {
+		check := HeartbeatCheck(&fakeHeartbeat{last: time.Now().Add(-time.Hour)}, time.Minute)
+		err := check(context.Background())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "exceeds")
+	})
+
+	t.Run("should fail when worker never started", func(t *testing.T) {
+		check := HeartbeatCheck(&fakeHeartbeat{}, time.Minute)
+		assert.Error(t, check(context.Background()))
+	})
+}
+
+func TestDatabaseCheck_NilDB(t *testing.T) {
+	assert.Error(t, DatabaseCheck(nil)(context.Background()))
+}
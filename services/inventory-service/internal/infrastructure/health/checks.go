@@ -0,0 +1,71 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Pinger is implemented by any dependency that can report its reachability
+// (e.g. cache.RedisClient, rabbitmq.Publisher)
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// HeartbeatSource is implemented by background workers that record when they last ran
+type HeartbeatSource interface {
+	LastHeartbeat() time.Time
+}
+
+// DatabaseCheck returns a CheckFunc that pings the PostgreSQL connection pool
+func DatabaseCheck(db *gorm.DB) CheckFunc {
+	return func(ctx context.Context) error {
+		if db == nil {
+			return fmt.Errorf("database not initialized")
+		}
+
+		sqlDB, err := db.DB()
+		if err != nil {
+			return fmt.Errorf("failed to get underlying sql.DB: %w", err)
+		}
+
+		if err := sqlDB.PingContext(ctx); err != nil {
+			return fmt.Errorf("failed to ping PostgreSQL: %w", err)
+		}
+
+		return nil
+	}
+}
+
+// PingCheck returns a CheckFunc that delegates to the dependency's Ping method
+func PingCheck(pinger Pinger) CheckFunc {
+	return func(ctx context.Context) error {
+		if pinger == nil {
+			return fmt.Errorf("not connected")
+		}
+		return pinger.Ping(ctx)
+	}
+}
+
+// HeartbeatCheck returns a CheckFunc that fails when the worker has not reported
+// a heartbeat within maxAge (e.g. the scheduler goroutine is stuck or has died)
+func HeartbeatCheck(source HeartbeatSource, maxAge time.Duration) CheckFunc {
+	return func(ctx context.Context) error {
+		if source == nil {
+			return fmt.Errorf("worker not initialized")
+		}
+
+		last := source.LastHeartbeat()
+		if last.IsZero() {
+			return fmt.Errorf("worker has not started")
+		}
+
+		if age := time.Since(last); age > maxAge {
+			return fmt.Errorf("last heartbeat %s ago exceeds %s", age.Round(time.Second), maxAge)
+		}
+
+		return nil
+	}
+}
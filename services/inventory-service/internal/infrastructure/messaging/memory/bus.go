@@ -0,0 +1,244 @@
+// Package memory provides an in-process events.Publisher. It records every
+// published event so tests can assert on them, and delivers events to in-process
+// subscribers so the service can run as a single binary without a broker.
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/events"
+)
+
+// ErrClosed is returned when publishing to a closed bus
+var ErrClosed = errors.New("memory bus is closed")
+
+// Message is a published event as seen by subscribers and assertions
+type Message struct {
+	RoutingKey  string
+	Event       interface{} // the typed event, e.g. events.StockReservedEvent
+	Body        []byte      // the event as JSON, exactly as a broker would carry it
+	PublishedAt time.Time
+}
+
+// Decode unmarshals the message body into target
+func (m Message) Decode(target interface{}) error {
+	return json.Unmarshal(m.Body, target)
+}
+
+// Handler receives the messages matching a subscription
+type Handler func(ctx context.Context, msg Message)
+
+type subscription struct {
+	id      int
+	pattern string
+	handler Handler
+}
+
+// Bus is an in-memory events.Publisher. Handlers run synchronously on the
+// publishing goroutine, in subscription order.
+type Bus struct {
+	mu            sync.Mutex
+	messages      []Message
+	subscriptions []subscription
+	nextID        int
+	closed        bool
+	published     chan struct{} // closed and replaced on every publish to wake waiters
+}
+
+// Compile-time check
+var _ events.Publisher = (*Bus)(nil)
+
+// NewBus creates an empty Bus
+func NewBus() *Bus {
+	return &Bus{published: make(chan struct{})}
+}
+
+// PublishStockReserved records and delivers a stock reserved event
+func (b *Bus) PublishStockReserved(ctx context.Context, event events.StockReservedEvent) error {
+	return b.publish(ctx, events.RoutingKeyStockReserved, event)
+}
+
+// PublishStockConfirmed records and delivers a stock confirmed event
+func (b *Bus) PublishStockConfirmed(ctx context.Context, event events.StockConfirmedEvent) error {
+	return b.publish(ctx, events.RoutingKeyStockConfirmed, event)
+}
+
+// PublishStockReleased records and delivers a stock released event
+func (b *Bus) PublishStockReleased(ctx context.Context, event events.StockReleasedEvent) error {
+	return b.publish(ctx, events.RoutingKeyStockReleased, event)
+}
+
+// PublishStockFailed records and delivers a stock failure event
+func (b *Bus) PublishStockFailed(ctx context.Context, event events.StockFailedEvent) error {
+	return b.publish(ctx, events.RoutingKeyStockFailed, event)
+}
+
+// PublishStockDepleted records and delivers a stock depleted event
+func (b *Bus) PublishStockDepleted(ctx context.Context, event events.StockDepletedEvent) error {
+	return b.publish(ctx, events.RoutingKeyStockDepleted, event)
+}
+
+// PublishLotQuarantined records and delivers a lot quarantined event
+func (b *Bus) PublishLotQuarantined(ctx context.Context, event events.LotQuarantinedEvent) error {
+	return b.publish(ctx, events.RoutingKeyLotQuarantined, event)
+}
+
+// PublishWaitlistFulfilled records and delivers a waitlist fulfilled event
+func (b *Bus) PublishWaitlistFulfilled(ctx context.Context, event events.WaitlistFulfilledEvent) error {
+	return b.publish(ctx, events.RoutingKeyWaitlistFulfilled, event)
+}
+
+func (b *Bus) publish(ctx context.Context, routingKey string, event interface{}) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+	msg := Message{RoutingKey: routingKey, Event: event, Body: body, PublishedAt: time.Now()}
+
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return ErrClosed
+	}
+	b.messages = append(b.messages, msg)
+	var handlers []Handler
+	for _, sub := range b.subscriptions {
+		if MatchRoutingKey(sub.pattern, routingKey) {
+			handlers = append(handlers, sub.handler)
+		}
+	}
+	close(b.published)
+	b.published = make(chan struct{})
+	b.mu.Unlock()
+
+	for _, handler := range handlers {
+		handler(ctx, msg)
+	}
+	return nil
+}
+
+// Subscribe delivers every message whose routing key matches pattern to handler.
+// Patterns follow AMQP topic semantics: "*" matches one word, "#" zero or more.
+// The returned function cancels the subscription.
+func (b *Bus) Subscribe(pattern string, handler Handler) (unsubscribe func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.nextID++
+	id := b.nextID
+	b.subscriptions = append(b.subscriptions, subscription{id: id, pattern: pattern, handler: handler})
+
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		for i, sub := range b.subscriptions {
+			if sub.id == id {
+				b.subscriptions = append(b.subscriptions[:i], b.subscriptions[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// Messages returns every message published so far, oldest first
+func (b *Bus) Messages() []Message {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]Message(nil), b.messages...)
+}
+
+// MessagesFor returns the messages whose routing key matches pattern
+func (b *Bus) MessagesFor(pattern string) []Message {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.matching(pattern)
+}
+
+func (b *Bus) matching(pattern string) []Message {
+	var matched []Message
+	for _, msg := range b.messages {
+		if MatchRoutingKey(pattern, msg.RoutingKey) {
+			matched = append(matched, msg)
+		}
+	}
+	return matched
+}
+
+// WaitFor blocks until at least n messages match pattern and returns them,
+// or returns the context error
+func (b *Bus) WaitFor(ctx context.Context, pattern string, n int) ([]Message, error) {
+	for {
+		b.mu.Lock()
+		matched := b.matching(pattern)
+		published := b.published
+		b.mu.Unlock()
+
+		if len(matched) >= n {
+			return matched, nil
+		}
+		select {
+		case <-ctx.Done():
+			return matched, fmt.Errorf("waiting for %d %q messages, got %d: %w", n, pattern, len(matched), ctx.Err())
+		case <-published:
+		}
+	}
+}
+
+// Reset forgets the recorded messages; subscriptions are kept
+func (b *Bus) Reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.messages = nil
+}
+
+// Close rejects further publishes and drops all subscriptions
+func (b *Bus) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+	b.subscriptions = nil
+	return nil
+}
+
+// Ping reports whether the bus still accepts events
+func (b *Bus) Ping(ctx context.Context) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return ErrClosed
+	}
+	return ctx.Err()
+}
+
+// MatchRoutingKey reports whether key matches an AMQP topic pattern
+func MatchRoutingKey(pattern, key string) bool {
+	return matchWords(strings.Split(pattern, "."), strings.Split(key, "."))
+}
+
+func matchWords(pattern, key []string) bool {
+	if len(pattern) == 0 {
+		return len(key) == 0
+	}
+	switch pattern[0] {
+	case "#":
+		for i := 0; i <= len(key); i++ {
+			if matchWords(pattern[1:], key[i:]) {
+				return true
+			}
+		}
+		return false
+	case "*":
+		return len(key) > 0 && matchWords(pattern[1:], key[1:])
+	default:
+		return len(key) > 0 && pattern[0] == key[0] && matchWords(pattern[1:], key[1:])
+	}
+}
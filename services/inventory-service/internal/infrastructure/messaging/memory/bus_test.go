@@ -0,0 +1,170 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/events"
+)
+
+func reservedEvent(id string) events.StockReservedEvent {
+	return events.StockReservedEvent{
+		BaseEvent: events.BaseEvent{EventID: id, EventType: events.RoutingKeyStockReserved, Version: events.StockReservedVersion},
+		Payload:   events.StockReservedPayload{ProductID: "p-1", Quantity: 2},
+	}
+}
+
+func TestBus_RecordsPublishedEvents(t *testing.T) {
+	bus := NewBus()
+	ctx := context.Background()
+
+	require.NoError(t, bus.PublishStockReserved(ctx, reservedEvent("e-1")))
+	require.NoError(t, bus.PublishStockConfirmed(ctx, events.StockConfirmedEvent{BaseEvent: events.BaseEvent{EventID: "e-2"}}))
+	require.NoError(t, bus.PublishStockReleased(ctx, events.StockReleasedEvent{BaseEvent: events.BaseEvent{EventID: "e-3"}}))
+	require.NoError(t, bus.PublishStockFailed(ctx, events.StockFailedEvent{BaseEvent: events.BaseEvent{EventID: "e-4"}}))
+	require.NoError(t, bus.PublishStockDepleted(ctx, events.StockDepletedEvent{BaseEvent: events.BaseEvent{EventID: "e-5"}}))
+
+	messages := bus.Messages()
+	require.Len(t, messages, 5)
+	assert.Equal(t, []string{
+		events.RoutingKeyStockReserved,
+		events.RoutingKeyStockConfirmed,
+		events.RoutingKeyStockReleased,
+		events.RoutingKeyStockFailed,
+		events.RoutingKeyStockDepleted,
+	}, []string{messages[0].RoutingKey, messages[1].RoutingKey, messages[2].RoutingKey, messages[3].RoutingKey, messages[4].RoutingKey})
+
+	reserved, ok := messages[0].Event.(events.StockReservedEvent)
+	require.True(t, ok, "the typed event is kept for assertions")
+	assert.Equal(t, 2, reserved.Payload.Quantity)
+
+	var decoded events.StockReservedEvent
+	require.NoError(t, messages[0].Decode(&decoded))
+	assert.Equal(t, reserved, decoded)
+
+	assert.Len(t, bus.MessagesFor("inventory.stock.re*"), 0, "wildcards match whole words only")
+	assert.Len(t, bus.MessagesFor("inventory.stock.*"), 5)
+	assert.Len(t, bus.MessagesFor(events.RoutingKeyStockFailed), 1)
+
+	bus.Reset()
+	assert.Empty(t, bus.Messages())
+}
+
+func TestBus_Subscribe(t *testing.T) {
+	bus := NewBus()
+	ctx := context.Background()
+
+	var reserved, all []string
+	bus.Subscribe(events.RoutingKeyStockReserved, func(ctx context.Context, msg Message) {
+		reserved = append(reserved, msg.RoutingKey)
+	})
+	unsubscribe := bus.Subscribe("inventory.#", func(ctx context.Context, msg Message) {
+		all = append(all, msg.RoutingKey)
+	})
+
+	require.NoError(t, bus.PublishStockReserved(ctx, reservedEvent("e-1")))
+	require.NoError(t, bus.PublishStockFailed(ctx, events.StockFailedEvent{}))
+	unsubscribe()
+	require.NoError(t, bus.PublishStockReserved(ctx, reservedEvent("e-2")))
+
+	assert.Equal(t, []string{events.RoutingKeyStockReserved, events.RoutingKeyStockReserved}, reserved)
+	assert.Equal(t, []string{events.RoutingKeyStockReserved, events.RoutingKeyStockFailed}, all)
+}
+
+func TestBus_HandlersMayPublish(t *testing.T) {
+	bus := NewBus()
+	ctx := context.Background()
+
+	bus.Subscribe(events.RoutingKeyStockReserved, func(ctx context.Context, msg Message) {
+		require.NoError(t, bus.PublishStockDepleted(ctx, events.StockDepletedEvent{}))
+	})
+
+	require.NoError(t, bus.PublishStockReserved(ctx, reservedEvent("e-1")))
+
+	assert.Len(t, bus.Messages(), 2)
+}
+
+func TestBus_WaitFor(t *testing.T) {
+	t.Run("should return once enough messages arrived", func(t *testing.T) {
+		bus := NewBus()
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 3; i++ {
+				_ = bus.PublishStockReserved(context.Background(), reservedEvent("e"))
+			}
+		}()
+
+		messages, err := bus.WaitFor(ctx, events.RoutingKeyStockReserved, 3)
+		wg.Wait()
+
+		require.NoError(t, err)
+		assert.Len(t, messages, 3)
+	})
+
+	t.Run("should give up when the context ends", func(t *testing.T) {
+		bus := NewBus()
+		require.NoError(t, bus.PublishStockReserved(context.Background(), reservedEvent("e-1")))
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		messages, err := bus.WaitFor(ctx, events.RoutingKeyStockReserved, 2)
+
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Len(t, messages, 1)
+	})
+}
+
+func TestBus_Close(t *testing.T) {
+	bus := NewBus()
+	require.NoError(t, bus.Ping(context.Background()))
+
+	require.NoError(t, bus.Close())
+
+	assert.ErrorIs(t, bus.PublishStockReserved(context.Background(), reservedEvent("e-1")), ErrClosed)
+	assert.ErrorIs(t, bus.Ping(context.Background()), ErrClosed)
+	assert.Empty(t, bus.Messages())
+}
+
+func TestBus_CancelledContext(t *testing.T) {
+	bus := NewBus()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	assert.ErrorIs(t, bus.PublishStockReserved(ctx, reservedEvent("e-1")), context.Canceled)
+	assert.Empty(t, bus.Messages())
+}
+
+func TestMatchRoutingKey(t *testing.T) {
+	tests := []struct {
+		pattern string
+		key     string
+		match   bool
+	}{
+		{"inventory.stock.reserved", "inventory.stock.reserved", true},
+		{"inventory.stock.reserved", "inventory.stock.released", false},
+		{"inventory.*.reserved", "inventory.stock.reserved", true},
+		{"inventory.*", "inventory.stock.reserved", false},
+		{"inventory.#", "inventory.stock.reserved", true},
+		{"inventory.#", "inventory", true},
+		{"#", "inventory.stock.reserved", true},
+		{"#.reserved", "inventory.stock.reserved", true},
+		{"inventory.#.reserved", "inventory.reserved", true},
+		{"orders.#", "inventory.stock.reserved", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+"~"+tt.key, func(t *testing.T) {
+			assert.Equal(t, tt.match, MatchRoutingKey(tt.pattern, tt.key))
+		})
+	}
+}
@@ -0,0 +1,179 @@
+// Package natsjs implements events.Publisher on NATS JetStream. Routing keys are
+// used verbatim as subjects, so "inventory.stock.reserved" is published on the
+// subject of the same name and consumers keep their AMQP-style filters
+// ("*" stays "*", "#" becomes ">").
+package natsjs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/events"
+)
+
+// PublisherConfig holds the configuration for the JetStream publisher
+type PublisherConfig struct {
+	URL             string        // NATS server URL
+	Stream          string        // Stream capturing the inventory subjects
+	Subjects        []string      // Subjects bound to the stream
+	PublishTimeout  time.Duration // Timeout waiting for the JetStream ack
+	DuplicateWindow time.Duration // Window in which a repeated event ID is dropped
+}
+
+// Defaults applied by NewPublisher
+const (
+	DefaultStream          = "INVENTORY_EVENTS"
+	DefaultPublishTimeout  = 5 * time.Second
+	DefaultDuplicateWindow = 2 * time.Minute
+)
+
+// DefaultSubjects captures every inventory routing key
+var DefaultSubjects = []string{"inventory.>"}
+
+// streamPublisher is the part of jetstream.JetStream the publisher uses
+type streamPublisher interface {
+	PublishMsg(ctx context.Context, msg *nats.Msg, opts ...jetstream.PublishOpt) (*jetstream.PubAck, error)
+}
+
+// Publisher implements the events.Publisher interface for NATS JetStream
+type Publisher struct {
+	config PublisherConfig
+	conn   *nats.Conn
+	js     streamPublisher
+}
+
+// Compile-time check
+var _ events.Publisher = (*Publisher)(nil)
+
+// NewPublisher connects to NATS and creates or updates the stream
+func NewPublisher(ctx context.Context, config PublisherConfig) (*Publisher, error) {
+	config = withDefaults(config)
+
+	conn, err := nats.Connect(config.URL, nats.Name(events.SourceInventoryService))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to NATS: %w", err)
+	}
+
+	js, err := jetstream.New(conn)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to open JetStream context: %w", err)
+	}
+
+	_, err = js.CreateOrUpdateStream(ctx, jetstream.StreamConfig{
+		Name:       config.Stream,
+		Subjects:   config.Subjects,
+		Storage:    jetstream.FileStorage,
+		Duplicates: config.DuplicateWindow,
+	})
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to declare stream %s: %w", config.Stream, err)
+	}
+
+	return &Publisher{config: config, conn: conn, js: js}, nil
+}
+
+func withDefaults(config PublisherConfig) PublisherConfig {
+	if config.Stream == "" {
+		config.Stream = DefaultStream
+	}
+	if len(config.Subjects) == 0 {
+		config.Subjects = DefaultSubjects
+	}
+	if config.PublishTimeout == 0 {
+		config.PublishTimeout = DefaultPublishTimeout
+	}
+	if config.DuplicateWindow == 0 {
+		config.DuplicateWindow = DefaultDuplicateWindow
+	}
+	return config
+}
+
+// PublishStockReserved publishes a stock reserved event
+func (p *Publisher) PublishStockReserved(ctx context.Context, event events.StockReservedEvent) error {
+	return p.publish(ctx, events.RoutingKeyStockReserved, event.EventID, event)
+}
+
+// PublishStockConfirmed publishes a stock confirmed event
+func (p *Publisher) PublishStockConfirmed(ctx context.Context, event events.StockConfirmedEvent) error {
+	return p.publish(ctx, events.RoutingKeyStockConfirmed, event.EventID, event)
+}
+
+// PublishStockReleased publishes a stock released event
+func (p *Publisher) PublishStockReleased(ctx context.Context, event events.StockReleasedEvent) error {
+	return p.publish(ctx, events.RoutingKeyStockReleased, event.EventID, event)
+}
+
+// PublishStockFailed publishes a stock operation failure event
+func (p *Publisher) PublishStockFailed(ctx context.Context, event events.StockFailedEvent) error {
+	return p.publish(ctx, events.RoutingKeyStockFailed, event.EventID, event)
+}
+
+// PublishStockDepleted publishes a stock depleted event
+func (p *Publisher) PublishStockDepleted(ctx context.Context, event events.StockDepletedEvent) error {
+	return p.publish(ctx, events.RoutingKeyStockDepleted, event.EventID, event)
+}
+
+// PublishLotQuarantined publishes a lot quarantined event
+func (p *Publisher) PublishLotQuarantined(ctx context.Context, event events.LotQuarantinedEvent) error {
+	return p.publish(ctx, events.RoutingKeyLotQuarantined, event.EventID, event)
+}
+
+// PublishWaitlistFulfilled publishes a waitlist fulfilled event
+func (p *Publisher) PublishWaitlistFulfilled(ctx context.Context, event events.WaitlistFulfilledEvent) error {
+	return p.publish(ctx, events.RoutingKeyWaitlistFulfilled, event.EventID, event)
+}
+
+// publish sends the event on the subject named by its routing key and waits for
+// the stream ack. The event ID doubles as the JetStream message ID, so a retried
+// publish within the duplicate window is stored once.
+func (p *Publisher) publish(ctx context.Context, routingKey, eventID string, event interface{}) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	msg := nats.NewMsg(routingKey)
+	msg.Data = body
+	msg.Header.Set("Content-Type", "application/json")
+
+	ctx, cancel := context.WithTimeout(ctx, p.config.PublishTimeout)
+	defer cancel()
+
+	opts := []jetstream.PublishOpt{jetstream.WithExpectStream(p.config.Stream)}
+	if eventID != "" {
+		opts = append(opts, jetstream.WithMsgID(eventID))
+	}
+	if _, err := p.js.PublishMsg(ctx, msg, opts...); err != nil {
+		return fmt.Errorf("failed to publish %s: %w", routingKey, err)
+	}
+	return nil
+}
+
+// Ping checks that the NATS connection is up
+func (p *Publisher) Ping(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if p.conn == nil || !p.conn.IsConnected() {
+		return fmt.Errorf("NATS connection is not connected")
+	}
+	return nil
+}
+
+// Close flushes pending messages and closes the connection
+func (p *Publisher) Close() error {
+	if p.conn == nil {
+		return nil
+	}
+	if err := p.conn.Drain(); err != nil {
+		return fmt.Errorf("failed to drain NATS connection: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+package natsjs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/events"
+)
+
+// fakeStream records the messages handed to JetStream
+type fakeStream struct {
+	messages []*nats.Msg
+	deadline time.Time
+	err      error
+}
+
+func (f *fakeStream) PublishMsg(ctx context.Context, msg *nats.Msg, opts ...jetstream.PublishOpt) (*jetstream.PubAck, error) {
+	f.messages = append(f.messages, msg)
+	f.deadline, _ = ctx.Deadline()
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &jetstream.PubAck{Stream: DefaultStream}, nil
+}
+
+func newTestPublisher(stream *fakeStream) *Publisher {
+	return &Publisher{config: withDefaults(PublisherConfig{PublishTimeout: time.Second}), js: stream}
+}
+
+func TestPublisher_UsesRoutingKeysAsSubjects(t *testing.T) {
+	stream := &fakeStream{}
+	publisher := newTestPublisher(stream)
+	ctx := context.Background()
+
+	require.NoError(t, publisher.PublishStockReserved(ctx, events.StockReservedEvent{BaseEvent: events.BaseEvent{EventID: "e-1"}}))
+	require.NoError(t, publisher.PublishStockConfirmed(ctx, events.StockConfirmedEvent{}))
+	require.NoError(t, publisher.PublishStockReleased(ctx, events.StockReleasedEvent{}))
+	require.NoError(t, publisher.PublishStockFailed(ctx, events.StockFailedEvent{}))
+	require.NoError(t, publisher.PublishStockDepleted(ctx, events.StockDepletedEvent{}))
+	require.NoError(t, publisher.PublishLotQuarantined(ctx, events.LotQuarantinedEvent{}))
+	require.NoError(t, publisher.PublishWaitlistFulfilled(ctx, events.WaitlistFulfilledEvent{}))
+
+	subjects := make([]string, len(stream.messages))
+	for i, msg := range stream.messages {
+		subjects[i] = msg.Subject
+	}
+	assert.Equal(t, []string{
+		events.RoutingKeyStockReserved,
+		events.RoutingKeyStockConfirmed,
+		events.RoutingKeyStockReleased,
+		events.RoutingKeyStockFailed,
+		events.RoutingKeyStockDepleted,
+		events.RoutingKeyLotQuarantined,
+		events.RoutingKeyWaitlistFulfilled,
+	}, subjects)
+}
+
+func TestPublisher_PublishesJSONBody(t *testing.T) {
+	stream := &fakeStream{}
+	event := events.StockReservedEvent{
+		BaseEvent: events.BaseEvent{EventID: "e-1", EventType: events.RoutingKeyStockReserved},
+		Payload:   events.StockReservedPayload{ProductID: "p-1", Quantity: 3},
+	}
+
+	require.NoError(t, newTestPublisher(stream).PublishStockReserved(context.Background(), event))
+
+	require.Len(t, stream.messages, 1)
+	msg := stream.messages[0]
+	assert.Equal(t, "application/json", msg.Header.Get("Content-Type"))
+	var decoded events.StockReservedEvent
+	require.NoError(t, json.Unmarshal(msg.Data, &decoded))
+	assert.Equal(t, event, decoded)
+	assert.WithinDuration(t, time.Now().Add(time.Second), stream.deadline, 500*time.Millisecond, "publish waits at most PublishTimeout for the ack")
+}
+
+func TestPublisher_WrapsPublishErrors(t *testing.T) {
+	stream := &fakeStream{err: errors.New("no responders")}
+
+	err := newTestPublisher(stream).PublishStockFailed(context.Background(), events.StockFailedEvent{})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to publish inventory.stock.failed: no responders")
+}
+
+func TestPublisher_WithDefaults(t *testing.T) {
+	config := withDefaults(PublisherConfig{URL: "nats://localhost:4222"})
+
+	assert.Equal(t, DefaultStream, config.Stream)
+	assert.Equal(t, []string{"inventory.>"}, config.Subjects)
+	assert.Equal(t, DefaultPublishTimeout, config.PublishTimeout)
+	assert.Equal(t, DefaultDuplicateWindow, config.DuplicateWindow)
+}
+
+func TestPublisher_NotConnected(t *testing.T) {
+	publisher := &Publisher{}
+
+	assert.Error(t, publisher.Ping(context.Background()))
+	assert.NoError(t, publisher.Close())
+}
+
+// TestNATSPublisher_Integration publishes to a real JetStream server and reads the
+// events back through a filtered consumer
+func TestNATSPublisher_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	ctx := context.Background()
+	natsURL := startNATSContainer(t, ctx)
+
+	publisher, err := NewPublisher(ctx, PublisherConfig{URL: natsURL})
+	require.NoError(t, err)
+	defer publisher.Close()
+	require.NoError(t, publisher.Ping(ctx))
+
+	event := events.StockReservedEvent{
+		BaseEvent: events.BaseEvent{EventID: "5f1c7e0a-4b7d-4a43-9a51-0c1b2d3e4f50", EventType: events.RoutingKeyStockReserved},
+		Payload:   events.StockReservedPayload{ProductID: "p-1", Quantity: 2},
+	}
+	require.NoError(t, publisher.PublishStockReserved(ctx, event))
+	require.NoError(t, publisher.PublishStockReserved(ctx, event), "a duplicate publish is acknowledged")
+	require.NoError(t, publisher.PublishStockFailed(ctx, events.StockFailedEvent{BaseEvent: events.BaseEvent{EventID: "other"}}))
+
+	conn, err := nats.Connect(natsURL)
+	require.NoError(t, err)
+	defer conn.Close()
+	js, err := jetstream.New(conn)
+	require.NoError(t, err)
+
+	stream, err := js.Stream(ctx, DefaultStream)
+	require.NoError(t, err)
+	info, err := stream.Info(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), info.State.Msgs, "the duplicate event ID is stored once")
+
+	consumer, err := stream.CreateConsumer(ctx, jetstream.ConsumerConfig{FilterSubject: "inventory.stock.reserved"})
+	require.NoError(t, err)
+	batch, err := consumer.Fetch(1, jetstream.FetchMaxWait(5*time.Second))
+	require.NoError(t, err)
+
+	var received []jetstream.Msg
+	for msg := range batch.Messages() {
+		received = append(received, msg)
+	}
+	require.Len(t, received, 1)
+	assert.Equal(t, events.RoutingKeyStockReserved, received[0].Subject())
+
+	var decoded events.StockReservedEvent
+	require.NoError(t, json.Unmarshal(received[0].Data(), &decoded))
+	assert.Equal(t, event, decoded)
+}
+
+func startNATSContainer(t *testing.T, ctx context.Context) string {
+	t.Helper()
+
+	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        "nats:2.10-alpine",
+			Cmd:          []string{"-js"},
+			ExposedPorts: []string{"4222/tcp"},
+			WaitingFor:   wait.ForLog("Server is ready").WithStartupTimeout(60 * time.Second),
+		},
+		Started: true,
+	})
+	require.NoError(t, err, "Failed to start NATS container")
+	t.Cleanup(func() { _ = container.Terminate(context.Background()) })
+
+	host, err := container.Host(ctx)
+	require.NoError(t, err)
+	port, err := container.MappedPort(ctx, "4222")
+	require.NoError(t, err)
+
+	return "nats://" + host + ":" + port.Port()
+}
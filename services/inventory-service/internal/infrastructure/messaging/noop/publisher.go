@@ -0,0 +1,59 @@
+package noop
+
+import (
+	"context"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/events"
+)
+
+// Publisher is an events.Publisher that discards every event.
+// It is used when no broker is configured, so use cases never receive a nil publisher.
+type Publisher struct{}
+
+// Compile-time check
+var _ events.Publisher = (*Publisher)(nil)
+
+// NewPublisher creates a new no-op Publisher
+func NewPublisher() *Publisher {
+	return &Publisher{}
+}
+
+// PublishStockReserved discards the event
+func (p *Publisher) PublishStockReserved(ctx context.Context, event events.StockReservedEvent) error {
+	return nil
+}
+
+// PublishStockConfirmed discards the event
+func (p *Publisher) PublishStockConfirmed(ctx context.Context, event events.StockConfirmedEvent) error {
+	return nil
+}
+
+// PublishStockReleased discards the event
+func (p *Publisher) PublishStockReleased(ctx context.Context, event events.StockReleasedEvent) error {
+	return nil
+}
+
+// PublishStockFailed discards the event
+func (p *Publisher) PublishStockFailed(ctx context.Context, event events.StockFailedEvent) error {
+	return nil
+}
+
+// PublishStockDepleted discards the event
+func (p *Publisher) PublishStockDepleted(ctx context.Context, event events.StockDepletedEvent) error {
+	return nil
+}
+
+// PublishLotQuarantined discards the event
+func (p *Publisher) PublishLotQuarantined(ctx context.Context, event events.LotQuarantinedEvent) error {
+	return nil
+}
+
+// PublishWaitlistFulfilled discards the event
+func (p *Publisher) PublishWaitlistFulfilled(ctx context.Context, event events.WaitlistFulfilledEvent) error {
+	return nil
+}
+
+// Close is a no-op
+func (p *Publisher) Close() error {
+	return nil
+}
@@ -0,0 +1,223 @@
+package rabbitmq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// ErrUnprocessable marks a message that will never succeed (malformed payload,
+// unknown event type). Handlers wrap it to dead-letter the message right away.
+var ErrUnprocessable = errors.New("unprocessable message")
+
+// Consume outcomes reported by the consumed_total metric
+const (
+	outcomeAck        = "ack"
+	outcomeRequeue    = "requeue"
+	outcomeDeadLetter = "dead_letter"
+)
+
+var consumedTotal = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: "inventory",
+		Subsystem: "events",
+		Name:      "consumed_total",
+		Help:      "Total number of events consumed from RabbitMQ by outcome",
+	},
+	[]string{"queue", "routing_key", "outcome"},
+)
+
+// Handler processes a decoded message. A nil error acks it; ErrUnprocessable
+// dead-letters it; any other error requeues it once and dead-letters it if the
+// redelivery fails again.
+type Handler func(ctx context.Context, routingKey string, envelope *Envelope) error
+
+// ConsumerConfig holds the configuration for the RabbitMQ consumer
+type ConsumerConfig struct {
+	URL         string   // RabbitMQ connection URL
+	Exchange    string   // Topic exchange the queue is bound to
+	Queue       string   // Queue name; its DLX and DLQ are "<queue>.dlx" and "<queue>.dlq"
+	BindingKeys []string // Routing keys bound to the queue
+	Prefetch    int      // Unacknowledged messages in flight (default 10)
+	ConsumerTag string   // Consumer tag shown in the management UI
+}
+
+// Consumer delivers the messages of one queue to a Handler
+type Consumer struct {
+	config  ConsumerConfig
+	handler Handler
+	conn    *amqp.Connection
+	ch      *amqp.Channel
+}
+
+// NewConsumer connects to RabbitMQ and declares the queue, its dead-letter
+// exchange and queue, and the bindings. The arguments match
+// scripts/setup-rabbitmq.sh so either can create the topology first.
+func NewConsumer(config ConsumerConfig, handler Handler) (*Consumer, error) {
+	if config.Queue == "" || config.Exchange == "" {
+		return nil, fmt.Errorf("consumer queue and exchange are required")
+	}
+	if handler == nil {
+		return nil, fmt.Errorf("consumer handler cannot be nil")
+	}
+	if config.Prefetch <= 0 {
+		config.Prefetch = 10
+	}
+
+	conn, err := amqp.Dial(config.URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to RabbitMQ: %w", err)
+	}
+
+	ch, err := conn.Channel()
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to open channel: %w", err)
+	}
+
+	if err := declareConsumerTopology(ch, config); err != nil {
+		ch.Close()
+		conn.Close()
+		return nil, err
+	}
+
+	if err := ch.Qos(config.Prefetch, 0, false); err != nil {
+		ch.Close()
+		conn.Close()
+		return nil, fmt.Errorf("failed to set prefetch: %w", err)
+	}
+
+	return &Consumer{config: config, handler: handler, conn: conn, ch: ch}, nil
+}
+
+func declareConsumerTopology(ch *amqp.Channel, config ConsumerConfig) error {
+	dlx, dlq := config.Queue+".dlx", config.Queue+".dlq"
+
+	if err := ch.ExchangeDeclare(config.Exchange, "topic", true, false, false, false, nil); err != nil {
+		return fmt.Errorf("failed to declare exchange %s: %w", config.Exchange, err)
+	}
+	if err := ch.ExchangeDeclare(dlx, "direct", true, false, false, false, nil); err != nil {
+		return fmt.Errorf("failed to declare exchange %s: %w", dlx, err)
+	}
+	if _, err := ch.QueueDeclare(dlq, true, false, false, false, amqp.Table{
+		"x-queue-type":  "classic",
+		"x-message-ttl": int32(604800000),
+	}); err != nil {
+		return fmt.Errorf("failed to declare queue %s: %w", dlq, err)
+	}
+	if err := ch.QueueBind(dlq, config.Queue, dlx, false, nil); err != nil {
+		return fmt.Errorf("failed to bind %s to %s: %w", dlq, dlx, err)
+	}
+
+	if _, err := ch.QueueDeclare(config.Queue, true, false, false, false, amqp.Table{
+		"x-queue-type":              "classic",
+		"x-dead-letter-exchange":    dlx,
+		"x-dead-letter-routing-key": config.Queue,
+		"x-max-length":              int32(10000),
+		"x-overflow":                "reject-publish",
+	}); err != nil {
+		return fmt.Errorf("failed to declare queue %s: %w", config.Queue, err)
+	}
+	for _, key := range config.BindingKeys {
+		if err := ch.QueueBind(config.Queue, key, config.Exchange, false, nil); err != nil {
+			return fmt.Errorf("failed to bind %s to %s with %s: %w", config.Queue, config.Exchange, key, err)
+		}
+	}
+	return nil
+}
+
+// Run consumes until ctx is cancelled (returning nil) or the channel closes
+// (returning an error). Messages are handled one at a time in arrival order.
+func (c *Consumer) Run(ctx context.Context) error {
+	deliveries, err := c.ch.Consume(c.config.Queue, c.config.ConsumerTag, false, false, false, false, nil)
+	if err != nil {
+		return fmt.Errorf("failed to consume %s: %w", c.config.Queue, err)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case delivery, ok := <-deliveries:
+			if !ok {
+				if ctx.Err() != nil {
+					return nil
+				}
+				return fmt.Errorf("delivery channel of %s closed", c.config.Queue)
+			}
+			c.handle(ctx, delivery)
+		}
+	}
+}
+
+// handle decodes and dispatches one delivery and settles it
+func (c *Consumer) handle(ctx context.Context, delivery amqp.Delivery) {
+	outcome := c.process(ctx, delivery)
+	consumedTotal.WithLabelValues(c.config.Queue, delivery.RoutingKey, outcome).Inc()
+
+	var err error
+	switch outcome {
+	case outcomeAck:
+		err = delivery.Ack(false)
+	case outcomeRequeue:
+		err = delivery.Nack(false, true)
+	default:
+		err = delivery.Nack(false, false)
+	}
+	if err != nil {
+		log.Printf("Failed to settle message %s from %s: %v", delivery.MessageId, c.config.Queue, err)
+	}
+}
+
+func (c *Consumer) process(ctx context.Context, delivery amqp.Delivery) string {
+	envelope, err := DecodeDelivery(delivery)
+	if err != nil {
+		log.Printf("Dead-lettering undecodable message from %s (routing key %s): %v", c.config.Queue, delivery.RoutingKey, err)
+		return outcomeDeadLetter
+	}
+
+	err = c.handler(ctx, delivery.RoutingKey, envelope)
+	switch {
+	case err == nil:
+		return outcomeAck
+	case errors.Is(err, ErrUnprocessable):
+		log.Printf("Dead-lettering event %s (%s): %v", envelope.EventID, delivery.RoutingKey, err)
+		return outcomeDeadLetter
+	case delivery.Redelivered:
+		log.Printf("Dead-lettering event %s (%s) after redelivery failed: %v", envelope.EventID, delivery.RoutingKey, err)
+		return outcomeDeadLetter
+	default:
+		log.Printf("Requeueing event %s (%s): %v", envelope.EventID, delivery.RoutingKey, err)
+		return outcomeRequeue
+	}
+}
+
+// Ping checks that the AMQP connection and channel are still open
+func (c *Consumer) Ping(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if c.conn == nil || c.conn.IsClosed() {
+		return fmt.Errorf("RabbitMQ connection is closed")
+	}
+	if c.ch == nil || c.ch.IsClosed() {
+		return fmt.Errorf("RabbitMQ channel is closed")
+	}
+	return nil
+}
+
+// Close stops consuming and closes the connection
+func (c *Consumer) Close() error {
+	if c.conn == nil {
+		return nil
+	}
+	if err := c.conn.Close(); err != nil && !errors.Is(err, amqp.ErrClosed) {
+		return fmt.Errorf("failed to close connection: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package rabbitmq_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/events"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/infrastructure/messaging/rabbitmq"
+)
+
+// TestRabbitMQConsumer_Integration consumes catalog events from a real broker and
+// checks that failed messages end up in the dead-letter queue
+func TestRabbitMQConsumer_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	rabbitmqC, amqpURL := startRabbitMQContainer(t, ctx)
+	defer func() { _ = rabbitmqC.Terminate(context.Background()) }()
+
+	var mu sync.Mutex
+	handled := make(map[string]int)
+	consumer, err := rabbitmq.NewConsumer(rabbitmq.ConsumerConfig{
+		URL:         amqpURL,
+		Exchange:    events.ExchangeOrdersEvents,
+		Queue:       events.QueueCatalogEvents,
+		BindingKeys: []string{"product.*"},
+	}, func(ctx context.Context, routingKey string, envelope *rabbitmq.Envelope) error {
+		mu.Lock()
+		handled[envelope.EventID]++
+		mu.Unlock()
+		if envelope.EventID == "poison" {
+			return fmt.Errorf("%w: rejected by test", rabbitmq.ErrUnprocessable)
+		}
+		if envelope.EventID == "flaky" {
+			return errors.New("temporary failure")
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	defer consumer.Close()
+	require.NoError(t, consumer.Ping(ctx))
+
+	go func() { _ = consumer.Run(ctx) }()
+
+	conn, err := amqp.Dial(amqpURL)
+	require.NoError(t, err)
+	defer conn.Close()
+	ch, err := conn.Channel()
+	require.NoError(t, err)
+
+	for _, id := range []string{"ok", "poison", "flaky"} {
+		body, err := json.Marshal(events.ProductEvent{BaseEvent: events.BaseEvent{EventID: id, EventType: events.RoutingKeyProductCreated, Version: "1.0.0"}})
+		require.NoError(t, err)
+		require.NoError(t, ch.PublishWithContext(ctx, events.ExchangeOrdersEvents, events.RoutingKeyProductCreated, false, false,
+			amqp.Publishing{ContentType: "application/json", Body: body}))
+	}
+
+	assert.Eventually(t, func() bool {
+		queue, err := ch.QueueDeclarePassive(events.QueueCatalogEvents+".dlq", true, false, false, false, amqp.Table{
+			"x-queue-type":  "classic",
+			"x-message-ttl": int32(604800000),
+		})
+		return err == nil && queue.Messages == 2
+	}, 15*time.Second, 200*time.Millisecond, "poison and flaky messages are dead-lettered")
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, 1, handled["ok"])
+	assert.Equal(t, 1, handled["poison"], "unprocessable messages are not retried")
+	assert.Equal(t, 2, handled["flaky"], "transient failures are retried once")
+}
@@ -0,0 +1,121 @@
+package rabbitmq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeAcknowledger records how a delivery was settled
+type fakeAcknowledger struct {
+	acked    bool
+	nacked   bool
+	requeued bool
+}
+
+func (f *fakeAcknowledger) Ack(tag uint64, multiple bool) error {
+	f.acked = true
+	return nil
+}
+
+func (f *fakeAcknowledger) Nack(tag uint64, multiple, requeue bool) error {
+	f.nacked, f.requeued = true, requeue
+	return nil
+}
+
+func (f *fakeAcknowledger) Reject(tag uint64, requeue bool) error {
+	return f.Nack(tag, false, requeue)
+}
+
+func testDelivery(t *testing.T, redelivered bool) (amqp.Delivery, *fakeAcknowledger) {
+	t.Helper()
+	msg, err := encodeMessage(FormatLegacy, sampleReservedEvent())
+	require.NoError(t, err)
+
+	ack := &fakeAcknowledger{}
+	return amqp.Delivery{
+		Acknowledger: ack,
+		DeliveryTag:  1,
+		RoutingKey:   "product.created",
+		ContentType:  msg.ContentType,
+		Body:         msg.Body,
+		Redelivered:  redelivered,
+	}, ack
+}
+
+func TestConsumer_Handle(t *testing.T) {
+	transient := errors.New("database unavailable")
+
+	tests := []struct {
+		name        string
+		redelivered bool
+		handlerErr  error
+		acked       bool
+		requeued    bool
+	}{
+		{name: "acks handled messages", acked: true},
+		{name: "requeues the first failure", handlerErr: transient, requeued: true},
+		{name: "dead-letters a failed redelivery", handlerErr: transient, redelivered: true},
+		{name: "dead-letters unprocessable messages", handlerErr: fmt.Errorf("%w: bad product ID", ErrUnprocessable)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var received *Envelope
+			consumer := &Consumer{
+				config: ConsumerConfig{Queue: "test.queue"},
+				handler: func(ctx context.Context, routingKey string, envelope *Envelope) error {
+					assert.Equal(t, "product.created", routingKey)
+					received = envelope
+					return tt.handlerErr
+				},
+			}
+			delivery, ack := testDelivery(t, tt.redelivered)
+
+			consumer.handle(context.Background(), delivery)
+
+			require.NotNil(t, received)
+			assert.Equal(t, sampleReservedEvent().EventID, received.EventID)
+			assert.Equal(t, tt.acked, ack.acked)
+			assert.Equal(t, !tt.acked, ack.nacked)
+			assert.Equal(t, tt.requeued, ack.requeued)
+		})
+	}
+}
+
+func TestConsumer_HandleUndecodable(t *testing.T) {
+	called := false
+	consumer := &Consumer{
+		config:  ConsumerConfig{Queue: "test.queue"},
+		handler: func(context.Context, string, *Envelope) error { called = true; return nil },
+	}
+	ack := &fakeAcknowledger{}
+
+	consumer.handle(context.Background(), amqp.Delivery{Acknowledger: ack, ContentType: "application/json", Body: []byte("not json")})
+
+	assert.False(t, called)
+	assert.True(t, ack.nacked)
+	assert.False(t, ack.requeued, "malformed messages go to the DLQ")
+}
+
+func TestNewConsumer_ValidatesConfig(t *testing.T) {
+	handler := func(context.Context, string, *Envelope) error { return nil }
+
+	_, err := NewConsumer(ConsumerConfig{Exchange: "orders.events"}, handler)
+	assert.Error(t, err)
+
+	_, err = NewConsumer(ConsumerConfig{Exchange: "orders.events", Queue: "q"}, nil)
+	assert.Error(t, err)
+}
+
+func TestConsumer_NotConnected(t *testing.T) {
+	consumer := &Consumer{}
+
+	assert.Error(t, consumer.Ping(context.Background()))
+	assert.NoError(t, consumer.Close())
+}
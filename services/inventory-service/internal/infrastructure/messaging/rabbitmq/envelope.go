@@ -0,0 +1,237 @@
+package rabbitmq
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"strings"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/events"
+)
+
+// Wire formats for published events
+const (
+	// FormatLegacy publishes the BaseEvent JSON envelope with the payload nested in it
+	FormatLegacy = "legacy"
+	// FormatCloudEventsBinary publishes the payload as body and the CloudEvents
+	// attributes as AMQP application properties
+	FormatCloudEventsBinary = "cloudevents-binary"
+	// FormatCloudEventsStructured publishes a CloudEvents JSON document as body
+	FormatCloudEventsStructured = "cloudevents-structured"
+)
+
+// CloudEvents 1.0 constants (AMQP protocol binding and JSON event format)
+const (
+	CloudEventsSpecVersion  = "1.0"
+	CloudEventsContentType  = "application/cloudevents+json"
+	cloudEventsHeaderPrefix = "cloudEvents:"
+	// cloudEventsJMSHeaderPrefix is the alternative prefix allowed by the AMQP binding
+	cloudEventsJMSHeaderPrefix = "cloudEvents_"
+	jsonContentType            = "application/json"
+	// defaultEventVersion is assumed for cloud events without the eventversion
+	// extension, which only producers predating per-type versions omit
+	defaultEventVersion = "1.0.0"
+)
+
+// CloudEvents extension attributes carrying BaseEvent fields with no core attribute
+const (
+	ExtensionCorrelationID = "correlationid"
+	ExtensionEventVersion  = "eventversion"
+)
+
+// Envelope is a decoded event in the legacy shape, whatever format it arrived in
+type Envelope struct {
+	events.BaseEvent
+	Payload json.RawMessage `json:"payload"`
+}
+
+// LegacyJSON returns the event as the legacy JSON envelope, ready for
+// schema.Registry validation or upcasting
+func (e *Envelope) LegacyJSON() ([]byte, error) {
+	return json.Marshal(e)
+}
+
+// cloudEvent is the structured-mode JSON document
+type cloudEvent struct {
+	SpecVersion     string          `json:"specversion"`
+	ID              string          `json:"id"`
+	Type            string          `json:"type"`
+	Source          string          `json:"source"`
+	Time            string          `json:"time,omitempty"`
+	DataContentType string          `json:"datacontenttype,omitempty"`
+	CorrelationID   string          `json:"correlationid,omitempty"`
+	EventVersion    string          `json:"eventversion,omitempty"`
+	Data            json.RawMessage `json:"data"`
+}
+
+// encodeMessage renders an event as an AMQP message in the given format
+func encodeMessage(format string, event interface{}) (amqp.Publishing, error) {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return amqp.Publishing{}, fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	msg := amqp.Publishing{
+		ContentType:  jsonContentType,
+		DeliveryMode: amqp.Persistent, // Persist messages to disk
+		Timestamp:    time.Now(),
+	}
+
+	if format == "" || format == FormatLegacy {
+		msg.Body = body
+		return msg, nil
+	}
+
+	var envelope Envelope
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return amqp.Publishing{}, fmt.Errorf("failed to read event envelope: %w", err)
+	}
+	msg.MessageId = envelope.EventID
+	msg.Type = envelope.EventType
+
+	switch format {
+	case FormatCloudEventsBinary:
+		headers := amqp.Table{
+			cloudEventsHeaderPrefix + "specversion": CloudEventsSpecVersion,
+			cloudEventsHeaderPrefix + "id":          envelope.EventID,
+			cloudEventsHeaderPrefix + "type":        envelope.EventType,
+			cloudEventsHeaderPrefix + "source":      envelope.Source,
+			cloudEventsHeaderPrefix + "time":        envelope.Timestamp,
+		}
+		if envelope.Version != "" {
+			headers[cloudEventsHeaderPrefix+ExtensionEventVersion] = envelope.Version
+		}
+		if envelope.CorrelationID != nil {
+			headers[cloudEventsHeaderPrefix+ExtensionCorrelationID] = *envelope.CorrelationID
+		}
+		msg.Headers = headers
+		msg.Body = envelope.Payload
+	case FormatCloudEventsStructured:
+		ce := cloudEvent{
+			SpecVersion:     CloudEventsSpecVersion,
+			ID:              envelope.EventID,
+			Type:            envelope.EventType,
+			Source:          envelope.Source,
+			Time:            envelope.Timestamp,
+			DataContentType: jsonContentType,
+			EventVersion:    envelope.Version,
+			Data:            envelope.Payload,
+		}
+		if envelope.CorrelationID != nil {
+			ce.CorrelationID = *envelope.CorrelationID
+		}
+		if msg.Body, err = json.Marshal(ce); err != nil {
+			return amqp.Publishing{}, fmt.Errorf("failed to marshal cloud event: %w", err)
+		}
+		msg.ContentType = CloudEventsContentType
+	default:
+		return amqp.Publishing{}, fmt.Errorf("unknown event format %q", format)
+	}
+	return msg, nil
+}
+
+// DecodeMessage reads an event delivered in any supported format: CloudEvents
+// structured (content type application/cloudevents+json), CloudEvents binary
+// (cloudEvents: or cloudEvents_ application properties) or the legacy envelope.
+// Consumers use it while producers migrate between formats.
+func DecodeMessage(contentType string, headers amqp.Table, body []byte) (*Envelope, error) {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+
+	switch {
+	case mediaType == CloudEventsContentType:
+		return decodeStructured(body)
+	case hasCloudEventsHeaders(headers):
+		return decodeBinary(headers, body)
+	default:
+		var envelope Envelope
+		if err := json.Unmarshal(body, &envelope); err != nil {
+			return nil, fmt.Errorf("invalid event JSON: %w", err)
+		}
+		if envelope.EventID == "" || envelope.EventType == "" {
+			return nil, errors.New("event must carry eventId and eventType")
+		}
+		return &envelope, nil
+	}
+}
+
+// DecodeDelivery is DecodeMessage for a consumed AMQP delivery
+func DecodeDelivery(d amqp.Delivery) (*Envelope, error) {
+	return DecodeMessage(d.ContentType, d.Headers, d.Body)
+}
+
+func decodeStructured(body []byte) (*Envelope, error) {
+	var ce cloudEvent
+	if err := json.Unmarshal(body, &ce); err != nil {
+		return nil, fmt.Errorf("invalid cloud event JSON: %w", err)
+	}
+	if err := checkRequired(ce.SpecVersion, ce.ID, ce.Type, ce.Source); err != nil {
+		return nil, err
+	}
+	if ce.DataContentType != "" && ce.DataContentType != jsonContentType {
+		return nil, fmt.Errorf("unsupported datacontenttype %q", ce.DataContentType)
+	}
+	return newEnvelope(ce.ID, ce.Type, ce.Source, ce.Time, ce.EventVersion, ce.CorrelationID, ce.Data), nil
+}
+
+func decodeBinary(headers amqp.Table, body []byte) (*Envelope, error) {
+	attr := func(name string) string {
+		for _, prefix := range []string{cloudEventsHeaderPrefix, cloudEventsJMSHeaderPrefix} {
+			if value, ok := headers[prefix+name].(string); ok {
+				return value
+			}
+		}
+		return ""
+	}
+
+	if err := checkRequired(attr("specversion"), attr("id"), attr("type"), attr("source")); err != nil {
+		return nil, err
+	}
+	if !json.Valid(body) {
+		return nil, errors.New("cloud event data must be JSON")
+	}
+	return newEnvelope(attr("id"), attr("type"), attr("source"), attr("time"),
+		attr(ExtensionEventVersion), attr(ExtensionCorrelationID), body), nil
+}
+
+func hasCloudEventsHeaders(headers amqp.Table) bool {
+	for key := range headers {
+		if strings.HasPrefix(key, cloudEventsHeaderPrefix) || strings.HasPrefix(key, cloudEventsJMSHeaderPrefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func checkRequired(specVersion, id, eventType, source string) error {
+	if specVersion != CloudEventsSpecVersion {
+		return fmt.Errorf("unsupported cloud events specversion %q", specVersion)
+	}
+	if id == "" || eventType == "" || source == "" {
+		return errors.New("cloud event must carry id, type and source")
+	}
+	return nil
+}
+
+func newEnvelope(id, eventType, source, timestamp, version, correlationID string, data json.RawMessage) *Envelope {
+	envelope := &Envelope{
+		BaseEvent: events.BaseEvent{
+			EventID:   id,
+			EventType: eventType,
+			Timestamp: timestamp,
+			Version:   version,
+			Source:    source,
+		},
+		Payload: data,
+	}
+	if envelope.Version == "" {
+		envelope.Version = defaultEventVersion
+	}
+	if correlationID != "" {
+		envelope.CorrelationID = &correlationID
+	}
+	return envelope
+}
@@ -0,0 +1,184 @@
+package rabbitmq
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/events"
+)
+
+func sampleReservedEvent() events.StockReservedEvent {
+	correlationID := "0b6c4a3e-5f0d-4b8a-9c1e-2d3f4a5b6c7d"
+	now := time.Date(2025, 1, 15, 10, 30, 0, 0, time.UTC)
+	return events.StockReservedEvent{
+		BaseEvent: events.BaseEvent{
+			EventID:       "123e4567-e89b-42d3-a456-426614174000",
+			EventType:     events.RoutingKeyStockReserved,
+			Timestamp:     now.Format(time.RFC3339),
+			Version:       events.StockReservedVersion,
+			CorrelationID: &correlationID,
+			Source:        events.SourceInventoryService,
+		},
+		Payload: events.StockReservedPayload{
+			ReservationID: "9f8e7d6c-5b4a-4321-8fed-cba987654321",
+			ProductID:     "c0ffee00-1234-4567-89ab-cdef01234567",
+			Quantity:      5,
+			OrderID:       "a1b2c3d4-e5f6-4789-8abc-def012345678",
+			ExpiresAt:     now.Add(15 * time.Minute),
+			ReservedAt:    now,
+		},
+	}
+}
+
+func TestEncodeMessage_Legacy(t *testing.T) {
+	event := sampleReservedEvent()
+
+	for _, format := range []string{"", FormatLegacy} {
+		msg, err := encodeMessage(format, event)
+		require.NoError(t, err)
+
+		expected, _ := json.Marshal(event)
+		assert.Equal(t, "application/json", msg.ContentType)
+		assert.Equal(t, amqp.Persistent, msg.DeliveryMode)
+		assert.JSONEq(t, string(expected), string(msg.Body))
+		assert.Empty(t, msg.Headers)
+	}
+}
+
+func TestEncodeMessage_CloudEventsBinary(t *testing.T) {
+	event := sampleReservedEvent()
+
+	msg, err := encodeMessage(FormatCloudEventsBinary, event)
+	require.NoError(t, err)
+
+	payload, _ := json.Marshal(event.Payload)
+	assert.Equal(t, "application/json", msg.ContentType)
+	assert.Equal(t, event.EventID, msg.MessageId)
+	assert.Equal(t, event.EventType, msg.Type)
+	assert.JSONEq(t, string(payload), string(msg.Body), "binary mode carries only the data in the body")
+	assert.Equal(t, amqp.Table{
+		"cloudEvents:specversion":   "1.0",
+		"cloudEvents:id":            event.EventID,
+		"cloudEvents:type":          "inventory.stock.reserved",
+		"cloudEvents:source":        "inventory-service",
+		"cloudEvents:time":          "2025-01-15T10:30:00Z",
+		"cloudEvents:eventversion":  "1.3.0",
+		"cloudEvents:correlationid": *event.CorrelationID,
+	}, msg.Headers)
+}
+
+func TestEncodeMessage_CloudEventsStructured(t *testing.T) {
+	event := sampleReservedEvent()
+
+	msg, err := encodeMessage(FormatCloudEventsStructured, event)
+	require.NoError(t, err)
+
+	payload, _ := json.Marshal(event.Payload)
+	assert.Equal(t, "application/cloudevents+json", msg.ContentType)
+	assert.Empty(t, msg.Headers)
+	assert.JSONEq(t, `{
+		"specversion": "1.0",
+		"id": "123e4567-e89b-42d3-a456-426614174000",
+		"type": "inventory.stock.reserved",
+		"source": "inventory-service",
+		"time": "2025-01-15T10:30:00Z",
+		"datacontenttype": "application/json",
+		"correlationid": "0b6c4a3e-5f0d-4b8a-9c1e-2d3f4a5b6c7d",
+		"eventversion": "1.3.0",
+		"data": `+string(payload)+`
+	}`, string(msg.Body))
+}
+
+func TestEncodeMessage_UnknownFormat(t *testing.T) {
+	_, err := encodeMessage("avro", sampleReservedEvent())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown event format "avro"`)
+}
+
+func TestDecodeMessage_RoundTrip(t *testing.T) {
+	event := sampleReservedEvent()
+	legacy, _ := json.Marshal(event)
+
+	for _, format := range []string{FormatLegacy, FormatCloudEventsBinary, FormatCloudEventsStructured} {
+		t.Run(format, func(t *testing.T) {
+			msg, err := encodeMessage(format, event)
+			require.NoError(t, err)
+
+			envelope, err := DecodeDelivery(amqp.Delivery{ContentType: msg.ContentType, Headers: msg.Headers, Body: msg.Body})
+			require.NoError(t, err)
+
+			assert.Equal(t, event.BaseEvent, envelope.BaseEvent)
+			decoded, err := envelope.LegacyJSON()
+			require.NoError(t, err)
+			assert.JSONEq(t, string(legacy), string(decoded), "every format decodes to the legacy envelope")
+		})
+	}
+}
+
+func TestDecodeMessage_CloudEventsVariants(t *testing.T) {
+	t.Run("should accept the JMS header prefix and default the version", func(t *testing.T) {
+		envelope, err := DecodeMessage("application/json", amqp.Table{
+			"cloudEvents_specversion": "1.0",
+			"cloudEvents_id":          "evt-1",
+			"cloudEvents_type":        "order.created",
+			"cloudEvents_source":      "orders-service",
+		}, []byte(`{"orderId": "o-1"}`))
+
+		require.NoError(t, err)
+		assert.Equal(t, "evt-1", envelope.EventID)
+		assert.Equal(t, "order.created", envelope.EventType)
+		assert.Equal(t, "orders-service", envelope.Source)
+		assert.Equal(t, "1.0.0", envelope.Version)
+		assert.Nil(t, envelope.CorrelationID)
+		assert.JSONEq(t, `{"orderId": "o-1"}`, string(envelope.Payload))
+	})
+
+	t.Run("should accept a structured content type with parameters", func(t *testing.T) {
+		envelope, err := DecodeMessage("application/cloudevents+json; charset=utf-8", nil,
+			[]byte(`{"specversion": "1.0", "id": "evt-2", "type": "order.cancelled", "source": "orders-service", "eventversion": "2.1.0", "data": {}}`))
+
+		require.NoError(t, err)
+		assert.Equal(t, "order.cancelled", envelope.EventType)
+		assert.Equal(t, "2.1.0", envelope.Version)
+	})
+}
+
+func TestDecodeMessage_Errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		headers     amqp.Table
+		body        string
+		errMsg      string
+	}{
+		{"legacy not JSON", "application/json", nil, `nope`, "invalid event JSON"},
+		{"legacy without id", "application/json", nil, `{"eventType": "order.created"}`, "eventId and eventType"},
+		{"structured wrong specversion", CloudEventsContentType, nil, `{"specversion": "0.3", "id": "1", "type": "t", "source": "s"}`, "specversion"},
+		{"structured without source", CloudEventsContentType, nil, `{"specversion": "1.0", "id": "1", "type": "t"}`, "id, type and source"},
+		{"structured non JSON data", CloudEventsContentType, nil, `{"specversion": "1.0", "id": "1", "type": "t", "source": "s", "datacontenttype": "text/plain"}`, "datacontenttype"},
+		{"binary without type", "application/json", amqp.Table{"cloudEvents:specversion": "1.0", "cloudEvents:id": "1", "cloudEvents:source": "s"}, `{}`, "id, type and source"},
+		{"binary non JSON data", "text/plain", amqp.Table{"cloudEvents:specversion": "1.0", "cloudEvents:id": "1", "cloudEvents:type": "t", "cloudEvents:source": "s"}, `plain`, "must be JSON"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := DecodeMessage(tt.contentType, tt.headers, []byte(tt.body))
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
+
+func TestNewPublisher_RejectsUnknownFormat(t *testing.T) {
+	_, err := NewPublisher(PublisherConfig{URL: "amqp://localhost:5672/", Exchange: "test", Format: "avro"})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown event format "avro"`)
+}
@@ -2,7 +2,6 @@ package rabbitmq
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"time"
 
@@ -18,6 +17,7 @@ type PublisherConfig struct {
 	ExchangeType string        // Exchange type (topic, fanout, direct)
 	MaxRetries   int           // Maximum number of retry attempts
 	RetryDelay   time.Duration // Delay between retries
+	Format       string        // Wire format: FormatLegacy (default), FormatCloudEventsBinary or FormatCloudEventsStructured
 }
 
 // Publisher implements the events.Publisher interface for RabbitMQ
@@ -40,6 +40,13 @@ func NewPublisher(config PublisherConfig) (*Publisher, error) {
 	if config.ExchangeType == "" {
 		config.ExchangeType = "topic"
 	}
+	switch config.Format {
+	case "":
+		config.Format = FormatLegacy
+	case FormatLegacy, FormatCloudEventsBinary, FormatCloudEventsStructured:
+	default:
+		return nil, fmt.Errorf("unknown event format %q", config.Format)
+	}
 
 	// Connect to RabbitMQ
 	conn, err := amqp.Dial(config.URL)
@@ -105,6 +112,16 @@ func (p *Publisher) PublishStockDepleted(ctx context.Context, event events.Stock
 	return p.publish(ctx, events.RoutingKeyStockDepleted, event)
 }
 
+// PublishLotQuarantined publishes a lot quarantined event (expired units taken out of the stock)
+func (p *Publisher) PublishLotQuarantined(ctx context.Context, event events.LotQuarantinedEvent) error {
+	return p.publish(ctx, events.RoutingKeyLotQuarantined, event)
+}
+
+// PublishWaitlistFulfilled publishes a waitlist fulfilled event (a reservation created for a waiting order)
+func (p *Publisher) PublishWaitlistFulfilled(ctx context.Context, event events.WaitlistFulfilledEvent) error {
+	return p.publish(ctx, events.RoutingKeyWaitlistFulfilled, event)
+}
+
 // publish is the internal method that handles the actual publishing with retry logic
 func (p *Publisher) publish(ctx context.Context, routingKey string, event interface{}) error {
 	startTime := time.Now()
@@ -116,20 +133,12 @@ func (p *Publisher) publish(ctx context.Context, routingKey string, event interf
 		p.metrics.PublishDuration.WithLabelValues(eventType, routingKey).Observe(duration)
 	}()
 
-	// Marshal event to JSON
-	body, err := json.Marshal(event)
+	// Render the event in the configured wire format
+	msg, err := encodeMessage(p.config.Format, event)
 	if err != nil {
 		p.metrics.PublishErrorsTotal.WithLabelValues(eventType, routingKey, "marshal_error").Inc()
 		p.metrics.EventsPublishedTotal.WithLabelValues(eventType, routingKey, "failed").Inc()
-		return fmt.Errorf("failed to marshal event: %w", err)
-	}
-
-	// Prepare AMQP message
-	msg := amqp.Publishing{
-		ContentType:  "application/json",
-		Body:         body,
-		DeliveryMode: amqp.Persistent, // Persist messages to disk
-		Timestamp:    time.Now(),
+		return err
 	}
 
 	// Publish with retry logic
@@ -200,11 +209,29 @@ func getEventType(event interface{}) string {
 		return "stock_failed"
 	case events.StockDepletedEvent:
 		return "stock_depleted"
+	case events.LotQuarantinedEvent:
+		return "lot_quarantined"
+	case events.WaitlistFulfilledEvent:
+		return "waitlist_fulfilled"
 	default:
 		return "unknown"
 	}
 }
 
+// Ping checks that the AMQP connection and channel are still open
+func (p *Publisher) Ping(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if p.conn == nil || p.conn.IsClosed() {
+		return fmt.Errorf("RabbitMQ connection is closed")
+	}
+	if p.ch == nil || p.ch.IsClosed() {
+		return fmt.Errorf("RabbitMQ channel is closed")
+	}
+	return nil
+}
+
 // Close closes the publisher and releases resources
 func (p *Publisher) Close() error {
 	var errs []error
@@ -64,6 +64,19 @@ func TestPublisher_Close_NilSafety(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+// TestPublisher_Ping_NotConnected tests that Ping reports a missing connection
+func TestPublisher_Ping_NotConnected(t *testing.T) {
+	publisher := &Publisher{
+		config: PublisherConfig{},
+		conn:   nil,
+		ch:     nil,
+	}
+
+	err := publisher.Ping(context.Background())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "connection is closed")
+}
+
 // TestEventStructs_JSONMarshaling tests that all event types can be marshaled to JSON
 func TestEventStructs_JSONMarshaling(t *testing.T) {
 	now := time.Now()
@@ -208,6 +221,8 @@ func TestEventConstants(t *testing.T) {
 		{"RoutingKeyStockReleased", events.RoutingKeyStockReleased, "inventory.stock.released"},
 		{"RoutingKeyStockFailed", events.RoutingKeyStockFailed, "inventory.stock.failed"},
 		{"RoutingKeyStockDepleted", events.RoutingKeyStockDepleted, "inventory.stock.depleted"},
+		{"RoutingKeyLotQuarantined", events.RoutingKeyLotQuarantined, "inventory.lot.quarantined"},
+		{"RoutingKeyWaitlistFulfilled", events.RoutingKeyWaitlistFulfilled, "inventory.waitlist.fulfilled"},
 		{"ExchangeInventoryEvents", events.ExchangeInventoryEvents, "inventory.events"},
 		{"SourceInventoryService", events.SourceInventoryService, "inventory-service"},
 		{"EventVersion", events.EventVersion, "1.0.0"},
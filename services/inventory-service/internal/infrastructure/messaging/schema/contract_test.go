@@ -0,0 +1,243 @@
+package schema
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/events"
+)
+
+// Regenerate the golden files after an intentional contract change with:
+//
+//	go test ./internal/infrastructure/messaging/schema -run TestContract -update
+var update = flag.Bool("update", false, "rewrite golden event files")
+
+var (
+	sampleTime        = time.Date(2025, 1, 15, 10, 30, 0, 0, time.UTC)
+	sampleTimestamp   = sampleTime.Format(time.RFC3339)
+	sampleCorrelation = "0b6c4a3e-5f0d-4b8a-9c1e-2d3f4a5b6c7d"
+	sampleReservation = "9f8e7d6c-5b4a-4321-8fed-cba987654321"
+	sampleOrder       = "a1b2c3d4-e5f6-4789-8abc-def012345678"
+	sampleProduct     = "c0ffee00-1234-4567-89ab-cdef01234567"
+	sampleQuantity    = 5
+	sampleComponents  = []events.ComponentQuantity{
+		{ProductID: "d1e2f3a4-b5c6-4d7e-8f90-a1b2c3d4e5f6", Quantity: 5},
+		{ProductID: "e2f3a4b5-c6d7-4e8f-90a1-b2c3d4e5f6a7", Quantity: 10},
+	}
+)
+
+func sampleBase(eventType, version string) events.BaseEvent {
+	return events.BaseEvent{
+		EventID:       "123e4567-e89b-42d3-a456-426614174000",
+		EventType:     eventType,
+		Timestamp:     sampleTimestamp,
+		Version:       version,
+		CorrelationID: &sampleCorrelation,
+		Source:        events.SourceInventoryService,
+	}
+}
+
+// sampleEvents returns one fully populated event per event type, keyed by type.
+// Optional fields are set so the golden files cover the whole contract.
+func sampleEvents() map[string]interface{} {
+	return map[string]interface{}{
+		events.RoutingKeyStockReserved: events.StockReservedEvent{
+			BaseEvent: sampleBase(events.RoutingKeyStockReserved, events.StockReservedVersion),
+			Payload: events.StockReservedPayload{
+				ReservationID: sampleReservation,
+				ProductID:     sampleProduct,
+				Quantity:      sampleQuantity,
+				OrderID:       sampleOrder,
+				ExpiresAt:     sampleTime.Add(15 * time.Minute),
+				ReservedAt:    sampleTime,
+				Serials:       []string{"SN-0001", "SN-0002"},
+				Components:    sampleComponents,
+				Channel:       "marketplace",
+			},
+		},
+		events.RoutingKeyStockConfirmed: events.StockConfirmedEvent{
+			BaseEvent: sampleBase(events.RoutingKeyStockConfirmed, events.StockConfirmedVersion),
+			Payload: events.StockConfirmedPayload{
+				ReservationID: sampleReservation,
+				ProductID:     sampleProduct,
+				Quantity:      sampleQuantity,
+				OrderID:       sampleOrder,
+				ConfirmedAt:   sampleTime,
+				Serials:       []string{"SN-0001", "SN-0002"},
+				Components:    sampleComponents,
+				Channel:       "marketplace",
+			},
+		},
+		events.RoutingKeyStockReleased: events.StockReleasedEvent{
+			BaseEvent: sampleBase(events.RoutingKeyStockReleased, events.StockReleasedVersion),
+			Payload: events.StockReleasedPayload{
+				ReservationID: sampleReservation,
+				ProductID:     sampleProduct,
+				Quantity:      sampleQuantity,
+				OrderID:       sampleOrder,
+				Reason:        "order_cancelled",
+				ReleasedAt:    sampleTime,
+				Serials:       []string{"SN-0001", "SN-0002"},
+				Components:    sampleComponents,
+				Channel:       "marketplace",
+			},
+		},
+		events.RoutingKeyStockFailed: events.StockFailedEvent{
+			BaseEvent: sampleBase(events.RoutingKeyStockFailed, events.StockFailedVersion),
+			Payload: events.StockFailedPayload{
+				OperationType: "reserve",
+				ProductID:     sampleProduct,
+				Quantity:      &sampleQuantity,
+				OrderID:       sampleOrder,
+				ReservationID: &sampleReservation,
+				ErrorCode:     "INSUFFICIENT_STOCK",
+				ErrorMessage:  "insufficient stock available",
+				FailedAt:      sampleTime,
+			},
+		},
+		events.RoutingKeyStockDepleted: events.StockDepletedEvent{
+			BaseEvent: sampleBase(events.RoutingKeyStockDepleted, events.StockDepletedVersion),
+			Payload: events.StockDepletedPayload{
+				ProductID:    sampleProduct,
+				OrderID:      sampleOrder,
+				DepletedAt:   sampleTime,
+				LastQuantity: sampleQuantity,
+			},
+		},
+		events.RoutingKeyLotQuarantined: events.LotQuarantinedEvent{
+			BaseEvent: sampleBase(events.RoutingKeyLotQuarantined, events.LotQuarantinedVersion),
+			Payload: events.LotQuarantinedPayload{
+				LotID:           "5d4c3b2a-1f0e-4d9c-8b7a-6f5e4d3c2b1a",
+				LotNumber:       "L-2025-001",
+				ProductID:       sampleProduct,
+				InventoryItemID: "e7d6c5b4-a3f2-4e1d-9c8b-7a6f5e4d3c2b",
+				Quantity:        sampleQuantity,
+				BestBefore:      "2025-01-14",
+				QuarantinedAt:   sampleTime,
+			},
+		},
+		events.RoutingKeyWaitlistFulfilled: events.WaitlistFulfilledEvent{
+			BaseEvent: sampleBase(events.RoutingKeyWaitlistFulfilled, events.WaitlistFulfilledVersion),
+			Payload: events.WaitlistFulfilledPayload{
+				EntryID:       "4c3b2a1f-0e9d-4c8b-a7f6-e5d4c3b2a1f0",
+				ReservationID: sampleReservation,
+				ProductID:     sampleProduct,
+				OrderID:       sampleOrder,
+				UserID:        "user-42",
+				Quantity:      sampleQuantity,
+				Priority:      10,
+				Channel:       "web",
+				JoinedAt:      sampleTime.Add(-2 * time.Hour),
+				ExpiresAt:     sampleTime.Add(time.Hour),
+				FulfilledAt:   sampleTime,
+			},
+		},
+	}
+}
+
+// TestContract_GoldenEvents fails when the JSON emitted for an event type changes
+// without its golden file (and therefore its documented contract) being updated
+func TestContract_GoldenEvents(t *testing.T) {
+	registry := Default()
+
+	for eventType, event := range sampleEvents() {
+		t.Run(eventType, func(t *testing.T) {
+			version := events.VersionOf(eventType)
+			body, err := json.MarshalIndent(event, "", "  ")
+			require.NoError(t, err)
+			body = append(body, '\n')
+
+			golden := filepath.Join("testdata", "golden", eventType+".v"+version+".json")
+			if *update {
+				require.NoError(t, os.MkdirAll(filepath.Dir(golden), 0o755))
+				require.NoError(t, os.WriteFile(golden, body, 0o644))
+			}
+
+			expected, err := os.ReadFile(golden)
+			require.NoError(t, err, "missing golden file, run with -update after reviewing the change")
+			assert.JSONEq(t, string(expected), string(body), "event JSON changed; bump its version or run with -update")
+
+			assert.NoError(t, registry.Validate(eventType, version, expected), "golden file must satisfy its schema")
+		})
+	}
+}
+
+// TestContract_SchemaMatchesStructs keeps the schema properties and the Go json
+// fields in lockstep, optional fields included
+func TestContract_SchemaMatchesStructs(t *testing.T) {
+	registry := Default()
+
+	for eventType, event := range sampleEvents() {
+		t.Run(eventType, func(t *testing.T) {
+			document, ok := registry.Schema(eventType, events.VersionOf(eventType))
+			require.True(t, ok, "no schema for the current version")
+
+			var schemaDoc struct {
+				Properties map[string]struct {
+					Properties map[string]json.RawMessage `json:"properties"`
+				} `json:"properties"`
+			}
+			require.NoError(t, json.Unmarshal(document, &schemaDoc))
+
+			envelope, payload := jsonFields(reflect.TypeOf(event))
+			assert.Equal(t, envelope, sortedKeys(schemaDoc.Properties), "envelope fields")
+			assert.Equal(t, payload, sortedKeys(schemaDoc.Properties["payload"].Properties), "payload fields")
+		})
+	}
+}
+
+// TestContract_EveryEventTypeHasASchema ensures a new event type cannot ship without a schema
+func TestContract_EveryEventTypeHasASchema(t *testing.T) {
+	assert.Len(t, sampleEvents(), len(Default().EventTypes()))
+
+	for _, eventType := range Default().EventTypes() {
+		assert.Equal(t, events.VersionOf(eventType), Default().Latest(eventType),
+			"%s: the Go version constant must point at the newest schema", eventType)
+	}
+}
+
+// jsonFields returns the sorted json names of the envelope (including "payload")
+// and of the payload struct of an event type
+func jsonFields(t reflect.Type) (envelope, payload []string) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous {
+			nested, _ := jsonFields(field.Type)
+			envelope = append(envelope, nested...)
+			continue
+		}
+		envelope = append(envelope, jsonName(field))
+		if jsonName(field) == "payload" {
+			for j := 0; j < field.Type.NumField(); j++ {
+				payload = append(payload, jsonName(field.Type.Field(j)))
+			}
+		}
+	}
+	sort.Strings(envelope)
+	sort.Strings(payload)
+	return envelope, payload
+}
+
+func jsonName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	return name
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,95 @@
+package schema
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/events"
+)
+
+// ValidatingPublisher is an events.Publisher decorator that checks every event
+// against its JSON Schema before handing it to the wrapped publisher. Events that
+// break their contract are rejected instead of reaching consumers.
+type ValidatingPublisher struct {
+	next     events.Publisher
+	registry *Registry
+}
+
+// Compile-time check
+var _ events.Publisher = (*ValidatingPublisher)(nil)
+
+// NewValidatingPublisher wraps next so events are validated with registry
+func NewValidatingPublisher(next events.Publisher, registry *Registry) *ValidatingPublisher {
+	return &ValidatingPublisher{next: next, registry: registry}
+}
+
+// PublishStockReserved validates and publishes a stock reserved event
+func (p *ValidatingPublisher) PublishStockReserved(ctx context.Context, event events.StockReservedEvent) error {
+	if err := p.validate(event.EventType, event.Version, event); err != nil {
+		return err
+	}
+	return p.next.PublishStockReserved(ctx, event)
+}
+
+// PublishStockConfirmed validates and publishes a stock confirmed event
+func (p *ValidatingPublisher) PublishStockConfirmed(ctx context.Context, event events.StockConfirmedEvent) error {
+	if err := p.validate(event.EventType, event.Version, event); err != nil {
+		return err
+	}
+	return p.next.PublishStockConfirmed(ctx, event)
+}
+
+// PublishStockReleased validates and publishes a stock released event
+func (p *ValidatingPublisher) PublishStockReleased(ctx context.Context, event events.StockReleasedEvent) error {
+	if err := p.validate(event.EventType, event.Version, event); err != nil {
+		return err
+	}
+	return p.next.PublishStockReleased(ctx, event)
+}
+
+// PublishStockFailed validates and publishes a stock failure event
+func (p *ValidatingPublisher) PublishStockFailed(ctx context.Context, event events.StockFailedEvent) error {
+	if err := p.validate(event.EventType, event.Version, event); err != nil {
+		return err
+	}
+	return p.next.PublishStockFailed(ctx, event)
+}
+
+// PublishStockDepleted validates and publishes a stock depleted event
+func (p *ValidatingPublisher) PublishStockDepleted(ctx context.Context, event events.StockDepletedEvent) error {
+	if err := p.validate(event.EventType, event.Version, event); err != nil {
+		return err
+	}
+	return p.next.PublishStockDepleted(ctx, event)
+}
+
+// PublishLotQuarantined validates and publishes a lot quarantined event
+func (p *ValidatingPublisher) PublishLotQuarantined(ctx context.Context, event events.LotQuarantinedEvent) error {
+	if err := p.validate(event.EventType, event.Version, event); err != nil {
+		return err
+	}
+	return p.next.PublishLotQuarantined(ctx, event)
+}
+
+// PublishWaitlistFulfilled validates and publishes a waitlist fulfilled event
+func (p *ValidatingPublisher) PublishWaitlistFulfilled(ctx context.Context, event events.WaitlistFulfilledEvent) error {
+	if err := p.validate(event.EventType, event.Version, event); err != nil {
+		return err
+	}
+	return p.next.PublishWaitlistFulfilled(ctx, event)
+}
+
+// Close closes the wrapped publisher
+func (p *ValidatingPublisher) Close() error {
+	return p.next.Close()
+}
+
+// validate marshals the event the same way the broker publisher does and checks it
+func (p *ValidatingPublisher) validate(eventType, version string, event interface{}) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+	return p.registry.Validate(eventType, version, body)
+}
@@ -0,0 +1,172 @@
+package schema
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/events"
+)
+
+// recordingPublisher records the event types it receives
+type recordingPublisher struct {
+	published []string
+	closed    bool
+}
+
+func (p *recordingPublisher) PublishStockReserved(ctx context.Context, event events.StockReservedEvent) error {
+	p.published = append(p.published, event.EventType)
+	return nil
+}
+
+func (p *recordingPublisher) PublishStockConfirmed(ctx context.Context, event events.StockConfirmedEvent) error {
+	p.published = append(p.published, event.EventType)
+	return nil
+}
+
+func (p *recordingPublisher) PublishStockReleased(ctx context.Context, event events.StockReleasedEvent) error {
+	p.published = append(p.published, event.EventType)
+	return nil
+}
+
+func (p *recordingPublisher) PublishStockFailed(ctx context.Context, event events.StockFailedEvent) error {
+	p.published = append(p.published, event.EventType)
+	return nil
+}
+
+func (p *recordingPublisher) PublishStockDepleted(ctx context.Context, event events.StockDepletedEvent) error {
+	p.published = append(p.published, event.EventType)
+	return nil
+}
+
+func (p *recordingPublisher) PublishLotQuarantined(ctx context.Context, event events.LotQuarantinedEvent) error {
+	p.published = append(p.published, event.EventType)
+	return nil
+}
+
+func (p *recordingPublisher) PublishWaitlistFulfilled(ctx context.Context, event events.WaitlistFulfilledEvent) error {
+	p.published = append(p.published, event.EventType)
+	return nil
+}
+
+func (p *recordingPublisher) Close() error {
+	p.closed = true
+	return nil
+}
+
+func TestValidatingPublisher_PublishesValidEvents(t *testing.T) {
+	next := &recordingPublisher{}
+	publisher := NewValidatingPublisher(next, Default())
+	samples := sampleEvents()
+	ctx := context.Background()
+
+	require.NoError(t, publisher.PublishStockReserved(ctx, samples[events.RoutingKeyStockReserved].(events.StockReservedEvent)))
+	require.NoError(t, publisher.PublishStockConfirmed(ctx, samples[events.RoutingKeyStockConfirmed].(events.StockConfirmedEvent)))
+	require.NoError(t, publisher.PublishStockReleased(ctx, samples[events.RoutingKeyStockReleased].(events.StockReleasedEvent)))
+	require.NoError(t, publisher.PublishStockFailed(ctx, samples[events.RoutingKeyStockFailed].(events.StockFailedEvent)))
+	require.NoError(t, publisher.PublishStockDepleted(ctx, samples[events.RoutingKeyStockDepleted].(events.StockDepletedEvent)))
+	require.NoError(t, publisher.PublishLotQuarantined(ctx, samples[events.RoutingKeyLotQuarantined].(events.LotQuarantinedEvent)))
+	require.NoError(t, publisher.PublishWaitlistFulfilled(ctx, samples[events.RoutingKeyWaitlistFulfilled].(events.WaitlistFulfilledEvent)))
+
+	assert.Equal(t, []string{
+		events.RoutingKeyStockReserved,
+		events.RoutingKeyStockConfirmed,
+		events.RoutingKeyStockReleased,
+		events.RoutingKeyStockFailed,
+		events.RoutingKeyStockDepleted,
+		events.RoutingKeyLotQuarantined,
+		events.RoutingKeyWaitlistFulfilled,
+	}, next.published)
+}
+
+func TestValidatingPublisher_RejectsInvalidEvents(t *testing.T) {
+	samples := sampleEvents()
+
+	tests := []struct {
+		name    string
+		publish func(p *ValidatingPublisher) error
+		path    string
+	}{
+		{
+			name: "zero quantity",
+			publish: func(p *ValidatingPublisher) error {
+				event := samples[events.RoutingKeyStockReserved].(events.StockReservedEvent)
+				event.Payload.Quantity = 0
+				return p.PublishStockReserved(context.Background(), event)
+			},
+			path: "/payload/quantity",
+		},
+		{
+			name: "missing event id",
+			publish: func(p *ValidatingPublisher) error {
+				event := samples[events.RoutingKeyStockConfirmed].(events.StockConfirmedEvent)
+				event.EventID = ""
+				return p.PublishStockConfirmed(context.Background(), event)
+			},
+			path: "/eventId",
+		},
+		{
+			name: "unknown release reason",
+			publish: func(p *ValidatingPublisher) error {
+				event := samples[events.RoutingKeyStockReleased].(events.StockReleasedEvent)
+				event.Payload.Reason = "lost"
+				return p.PublishStockReleased(context.Background(), event)
+			},
+			path: "/payload/reason",
+		},
+		{
+			name: "unknown operation",
+			publish: func(p *ValidatingPublisher) error {
+				event := samples[events.RoutingKeyStockFailed].(events.StockFailedEvent)
+				event.Payload.OperationType = "adjust"
+				return p.PublishStockFailed(context.Background(), event)
+			},
+			path: "/payload/operationType",
+		},
+		{
+			name: "wrong source",
+			publish: func(p *ValidatingPublisher) error {
+				event := samples[events.RoutingKeyStockDepleted].(events.StockDepletedEvent)
+				event.Source = "orders-service"
+				return p.PublishStockDepleted(context.Background(), event)
+			},
+			path: "/source",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := &recordingPublisher{}
+
+			err := tt.publish(NewValidatingPublisher(next, Default()))
+
+			var validationErr *ValidationError
+			require.True(t, errors.As(err, &validationErr), "got %v", err)
+			require.Len(t, validationErr.Violations, 1)
+			assert.Equal(t, tt.path, validationErr.Violations[0].Path)
+			assert.Empty(t, next.published, "invalid events must not reach the broker")
+		})
+	}
+}
+
+func TestValidatingPublisher_RejectsUnknownVersions(t *testing.T) {
+	next := &recordingPublisher{}
+	event := sampleEvents()[events.RoutingKeyStockReserved].(events.StockReservedEvent)
+	event.Version = "2.0.0"
+
+	err := NewValidatingPublisher(next, Default()).PublishStockReserved(context.Background(), event)
+
+	assert.ErrorIs(t, err, ErrUnknownSchema)
+	assert.Empty(t, next.published)
+}
+
+func TestValidatingPublisher_Close(t *testing.T) {
+	next := &recordingPublisher{}
+
+	require.NoError(t, NewValidatingPublisher(next, Default()).Close())
+
+	assert.True(t, next.closed)
+}
@@ -0,0 +1,324 @@
+// Package schema holds the versioned JSON Schemas of the events published by
+// the inventory service, validates events against them and upcasts older event
+// versions for consumers. The schemas are embedded in the binary and are the
+// machine-readable form of docs/EVENT_SCHEMAS.md.
+package schema
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+//go:embed schemas/*.json
+var embedded embed.FS
+
+// ErrUnknownSchema is returned when no schema is registered for an event type and version
+var ErrUnknownSchema = errors.New("unknown event schema")
+
+// Upcaster rewrites a decoded event of one version into the shape of the next
+// version. It may rename, add or drop fields; the registry updates "version".
+// Numbers are decoded as json.Number.
+type Upcaster func(event map[string]interface{}) error
+
+type schemaKey struct {
+	eventType string
+	version   string
+}
+
+type upcastStep struct {
+	to string
+	fn Upcaster
+}
+
+// Registry indexes event schemas by event type and version
+type Registry struct {
+	mu        sync.RWMutex
+	schemas   map[schemaKey]*compiled
+	documents map[schemaKey][]byte
+	upcasters map[schemaKey]upcastStep
+}
+
+var (
+	defaultOnce     sync.Once
+	defaultRegistry *Registry
+)
+
+// Default returns the registry built from the embedded schemas, with the upcasters
+// between their versions. It panics if an embedded schema or upcaster is invalid,
+// which the package tests rule out.
+func Default() *Registry {
+	defaultOnce.Do(func() {
+		registry, err := Load(embedded)
+		if err != nil {
+			panic(fmt.Sprintf("invalid embedded event schemas: %v", err))
+		}
+		if err := registerBuiltinUpcasters(registry); err != nil {
+			panic(fmt.Sprintf("invalid built-in upcasters: %v", err))
+		}
+		defaultRegistry = registry
+	})
+	return defaultRegistry
+}
+
+// Load builds a registry from every schemas/<eventType>.v<version>.json file in fsys
+func Load(fsys fs.FS) (*Registry, error) {
+	files, err := fs.Glob(fsys, "schemas/*.json")
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Registry{
+		schemas:   make(map[schemaKey]*compiled),
+		documents: make(map[schemaKey][]byte),
+		upcasters: make(map[schemaKey]upcastStep),
+	}
+	for _, file := range files {
+		key, err := parseFileName(path.Base(file))
+		if err != nil {
+			return nil, err
+		}
+		data, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, err
+		}
+		c, err := compile(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		r.schemas[key] = c
+		r.documents[key] = data
+	}
+	return r, nil
+}
+
+// parseFileName splits "inventory.stock.reserved.v1.0.0.json" into type and version
+func parseFileName(name string) (schemaKey, error) {
+	base := strings.TrimSuffix(name, ".json")
+	idx := strings.LastIndex(base, ".v")
+	if idx <= 0 {
+		return schemaKey{}, fmt.Errorf("schema file %q must be named <eventType>.v<version>.json", name)
+	}
+	key := schemaKey{eventType: base[:idx], version: base[idx+2:]}
+	if _, err := parseVersion(key.version); err != nil {
+		return schemaKey{}, fmt.Errorf("schema file %q: %w", name, err)
+	}
+	return key, nil
+}
+
+// EventTypes returns every event type with at least one schema, sorted
+func (r *Registry) EventTypes() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	seen := make(map[string]bool)
+	var types []string
+	for key := range r.schemas {
+		if !seen[key.eventType] {
+			seen[key.eventType] = true
+			types = append(types, key.eventType)
+		}
+	}
+	sort.Strings(types)
+	return types
+}
+
+// Versions returns the schema versions of an event type, oldest first
+func (r *Registry) Versions(eventType string) []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var versions []string
+	for key := range r.schemas {
+		if key.eventType == eventType {
+			versions = append(versions, key.version)
+		}
+	}
+	sort.Slice(versions, func(i, j int) bool { return compareVersions(versions[i], versions[j]) < 0 })
+	return versions
+}
+
+// Latest returns the newest schema version of an event type, or "" if unknown
+func (r *Registry) Latest(eventType string) string {
+	versions := r.Versions(eventType)
+	if len(versions) == 0 {
+		return ""
+	}
+	return versions[len(versions)-1]
+}
+
+// Schema returns the raw JSON Schema document of an event type and version
+func (r *Registry) Schema(eventType, version string) ([]byte, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	data, ok := r.documents[schemaKey{eventType, version}]
+	return data, ok
+}
+
+// Validate checks body against the schema of eventType at version.
+// Returns a *ValidationError listing every violation, or ErrUnknownSchema.
+func (r *Registry) Validate(eventType, version string, body []byte) error {
+	r.mu.RLock()
+	c, ok := r.schemas[schemaKey{eventType, version}]
+	r.mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("%w: %s v%s", ErrUnknownSchema, eventType, version)
+	}
+
+	violations, err := c.validate(body)
+	if err != nil {
+		return err
+	}
+	if len(violations) > 0 {
+		return &ValidationError{EventType: eventType, Version: version, Violations: violations}
+	}
+	return nil
+}
+
+// ValidateEvent validates an event against the schema named by its own
+// eventType and version fields
+func (r *Registry) ValidateEvent(body []byte) error {
+	eventType, version, err := readEnvelope(body)
+	if err != nil {
+		return err
+	}
+	return r.Validate(eventType, version, body)
+}
+
+// RegisterUpcaster registers the step that turns eventType at version from into
+// version to. Both versions must have a schema and each version has at most one step.
+func (r *Registry) RegisterUpcaster(eventType, from, to string, fn Upcaster) error {
+	if fn == nil {
+		return errors.New("upcaster cannot be nil")
+	}
+	if compareVersions(from, to) >= 0 {
+		return fmt.Errorf("upcaster must move forward, got %s -> %s", from, to)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, v := range []string{from, to} {
+		if _, ok := r.schemas[schemaKey{eventType, v}]; !ok {
+			return fmt.Errorf("%w: %s v%s", ErrUnknownSchema, eventType, v)
+		}
+	}
+	key := schemaKey{eventType, from}
+	if _, exists := r.upcasters[key]; exists {
+		return fmt.Errorf("upcaster for %s v%s already registered", eventType, from)
+	}
+	r.upcasters[key] = upcastStep{to: to, fn: fn}
+	return nil
+}
+
+// Upcast rewrites an event of any registered version into the latest version of
+// its type by chaining upcasters, and validates the result. Events already at the
+// latest version are validated and returned unchanged.
+func (r *Registry) Upcast(body []byte) ([]byte, error) {
+	eventType, version, err := readEnvelope(body)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.Validate(eventType, version, body); err != nil {
+		return nil, err
+	}
+
+	latest := r.Latest(eventType)
+	if version == latest {
+		return body, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var event map[string]interface{}
+	if err := decoder.Decode(&event); err != nil {
+		return nil, fmt.Errorf("invalid event JSON: %w", err)
+	}
+
+	for version != latest {
+		r.mu.RLock()
+		step, ok := r.upcasters[schemaKey{eventType, version}]
+		r.mu.RUnlock()
+		if !ok {
+			return nil, fmt.Errorf("no upcaster registered for %s v%s", eventType, version)
+		}
+		if err := step.fn(event); err != nil {
+			return nil, fmt.Errorf("upcasting %s v%s to v%s: %w", eventType, version, step.to, err)
+		}
+		version = step.to
+		event["version"] = version
+	}
+
+	upcasted, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.Validate(eventType, version, upcasted); err != nil {
+		return nil, err
+	}
+	return upcasted, nil
+}
+
+// readEnvelope extracts eventType and version from an event document
+func readEnvelope(body []byte) (string, string, error) {
+	var envelope struct {
+		EventType string `json:"eventType"`
+		Version   string `json:"version"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return "", "", fmt.Errorf("invalid event JSON: %w", err)
+	}
+	if envelope.EventType == "" || envelope.Version == "" {
+		return "", "", errors.New("event must carry eventType and version")
+	}
+	return envelope.EventType, envelope.Version, nil
+}
+
+// parseVersion parses a MAJOR.MINOR.PATCH version
+func parseVersion(version string) ([3]int, error) {
+	var parsed [3]int
+	parts := strings.Split(version, ".")
+	if len(parts) != 3 {
+		return parsed, fmt.Errorf("version %q must be MAJOR.MINOR.PATCH", version)
+	}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return parsed, fmt.Errorf("version %q must be MAJOR.MINOR.PATCH", version)
+		}
+		parsed[i] = n
+	}
+	return parsed, nil
+}
+
+// compareVersions orders two versions; unparsable versions sort first
+func compareVersions(a, b string) int {
+	va, errA := parseVersion(a)
+	vb, errB := parseVersion(b)
+	switch {
+	case errA != nil && errB != nil:
+		return strings.Compare(a, b)
+	case errA != nil:
+		return -1
+	case errB != nil:
+		return 1
+	}
+	for i := range va {
+		if va[i] != vb[i] {
+			if va[i] < vb[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,235 @@
+package schema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/events"
+)
+
+// widgetSchema builds a test schema for "test.widget" at version with the given payload properties
+func widgetSchema(version, payloadRequired, payloadProperties string) string {
+	return fmt.Sprintf(`{
+  "type": "object",
+  "required": ["eventType", "version", "payload"],
+  "additionalProperties": false,
+  "properties": {
+    "eventType": {"type": "string", "const": "test.widget"},
+    "version": {"type": "string", "const": %q},
+    "payload": {
+      "type": "object",
+      "required": [%s],
+      "additionalProperties": false,
+      "properties": {%s}
+    }
+  }
+}`, version, payloadRequired, payloadProperties)
+}
+
+// versionedRegistry has three versions of test.widget: v1 has "qty", v2 renames it
+// to "quantity" and v3 adds a required "unit"
+func versionedRegistry(t *testing.T) *Registry {
+	t.Helper()
+	registry, err := Load(fstest.MapFS{
+		"schemas/test.widget.v1.0.0.json":  {Data: []byte(widgetSchema("1.0.0", `"qty"`, `"qty": {"type": "integer"}`))},
+		"schemas/test.widget.v2.0.0.json":  {Data: []byte(widgetSchema("2.0.0", `"quantity"`, `"quantity": {"type": "integer"}`))},
+		"schemas/test.widget.v10.0.0.json": {Data: []byte(widgetSchema("10.0.0", `"quantity", "unit"`, `"quantity": {"type": "integer"}, "unit": {"type": "string"}`))},
+	})
+	require.NoError(t, err)
+	return registry
+}
+
+func TestDefault_LoadsEmbeddedSchemas(t *testing.T) {
+	registry := Default()
+
+	assert.Equal(t, []string{
+		events.RoutingKeyLotQuarantined,
+		events.RoutingKeyStockConfirmed,
+		events.RoutingKeyStockDepleted,
+		events.RoutingKeyStockFailed,
+		events.RoutingKeyStockReleased,
+		events.RoutingKeyStockReserved,
+		events.RoutingKeyWaitlistFulfilled,
+	}, registry.EventTypes())
+	assert.Equal(t, []string{"1.0.0", "1.1.0", "1.2.0", "1.3.0"}, registry.Versions(events.RoutingKeyStockReserved))
+
+	document, ok := registry.Schema(events.RoutingKeyStockReserved, events.StockReservedVersion)
+	require.True(t, ok)
+	assert.True(t, json.Valid(document))
+
+	_, ok = registry.Schema(events.RoutingKeyStockReserved, "9.9.9")
+	assert.False(t, ok)
+}
+
+func TestLoad_RejectsBadFiles(t *testing.T) {
+	tests := []struct {
+		name   string
+		files  fstest.MapFS
+		errMsg string
+	}{
+		{"missing version", fstest.MapFS{"schemas/test.widget.json": {Data: []byte(`{}`)}}, "must be named"},
+		{"bad version", fstest.MapFS{"schemas/test.widget.v1.json": {Data: []byte(`{}`)}}, "MAJOR.MINOR.PATCH"},
+		{"bad schema", fstest.MapFS{"schemas/test.widget.v1.0.0.json": {Data: []byte(`{"oneOf": []}`)}}, "unsupported keyword"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load(tt.files)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
+
+func TestRegistry_Versions(t *testing.T) {
+	registry := versionedRegistry(t)
+
+	assert.Equal(t, []string{"1.0.0", "2.0.0", "10.0.0"}, registry.Versions("test.widget"), "versions sort numerically")
+	assert.Equal(t, "10.0.0", registry.Latest("test.widget"))
+	assert.Empty(t, registry.Latest("test.unknown"))
+}
+
+func TestRegistry_Validate(t *testing.T) {
+	registry := versionedRegistry(t)
+
+	t.Run("should accept a valid event", func(t *testing.T) {
+		err := registry.ValidateEvent([]byte(`{"eventType": "test.widget", "version": "1.0.0", "payload": {"qty": 2}}`))
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("should return a validation error naming the schema", func(t *testing.T) {
+		err := registry.ValidateEvent([]byte(`{"eventType": "test.widget", "version": "2.0.0", "payload": {"qty": 2}}`))
+
+		var validationErr *ValidationError
+		require.True(t, errors.As(err, &validationErr))
+		assert.Equal(t, "test.widget", validationErr.EventType)
+		assert.Equal(t, "2.0.0", validationErr.Version)
+		assert.Len(t, validationErr.Violations, 2)
+	})
+
+	t.Run("should reject unknown versions", func(t *testing.T) {
+		err := registry.Validate("test.widget", "3.0.0", []byte(`{}`))
+
+		assert.ErrorIs(t, err, ErrUnknownSchema)
+	})
+
+	t.Run("should require an envelope", func(t *testing.T) {
+		err := registry.ValidateEvent([]byte(`{"payload": {}}`))
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "eventType and version")
+	})
+}
+
+func TestRegistry_RegisterUpcaster(t *testing.T) {
+	noop := func(map[string]interface{}) error { return nil }
+
+	t.Run("should reject unknown versions", func(t *testing.T) {
+		err := versionedRegistry(t).RegisterUpcaster("test.widget", "1.0.0", "3.0.0", noop)
+
+		assert.ErrorIs(t, err, ErrUnknownSchema)
+	})
+
+	t.Run("should reject downgrades", func(t *testing.T) {
+		err := versionedRegistry(t).RegisterUpcaster("test.widget", "2.0.0", "1.0.0", noop)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "must move forward")
+	})
+
+	t.Run("should reject a second step from the same version", func(t *testing.T) {
+		registry := versionedRegistry(t)
+		require.NoError(t, registry.RegisterUpcaster("test.widget", "1.0.0", "2.0.0", noop))
+
+		err := registry.RegisterUpcaster("test.widget", "1.0.0", "10.0.0", noop)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "already registered")
+	})
+
+	t.Run("should reject a nil upcaster", func(t *testing.T) {
+		assert.Error(t, versionedRegistry(t).RegisterUpcaster("test.widget", "1.0.0", "2.0.0", nil))
+	})
+}
+
+func TestRegistry_Upcast(t *testing.T) {
+	renameQty := func(event map[string]interface{}) error {
+		payload := event["payload"].(map[string]interface{})
+		payload["quantity"] = payload["qty"]
+		delete(payload, "qty")
+		return nil
+	}
+	addUnit := func(event map[string]interface{}) error {
+		event["payload"].(map[string]interface{})["unit"] = "each"
+		return nil
+	}
+
+	t.Run("should chain upcasters to the latest version", func(t *testing.T) {
+		registry := versionedRegistry(t)
+		require.NoError(t, registry.RegisterUpcaster("test.widget", "1.0.0", "2.0.0", renameQty))
+		require.NoError(t, registry.RegisterUpcaster("test.widget", "2.0.0", "10.0.0", addUnit))
+
+		upcasted, err := registry.Upcast([]byte(`{"eventType": "test.widget", "version": "1.0.0", "payload": {"qty": 12345678901}}`))
+
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"eventType": "test.widget", "version": "10.0.0", "payload": {"quantity": 12345678901, "unit": "each"}}`, string(upcasted))
+	})
+
+	t.Run("should return latest events unchanged", func(t *testing.T) {
+		body := []byte(`{"eventType": "test.widget", "version": "10.0.0", "payload": {"quantity": 1, "unit": "kg"}}`)
+
+		upcasted, err := versionedRegistry(t).Upcast(body)
+
+		require.NoError(t, err)
+		assert.Equal(t, body, upcasted)
+	})
+
+	t.Run("should fail when a step is missing", func(t *testing.T) {
+		registry := versionedRegistry(t)
+		require.NoError(t, registry.RegisterUpcaster("test.widget", "1.0.0", "2.0.0", renameQty))
+
+		_, err := registry.Upcast([]byte(`{"eventType": "test.widget", "version": "1.0.0", "payload": {"qty": 1}}`))
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no upcaster registered for test.widget v2.0.0")
+	})
+
+	t.Run("should validate the upcasted event", func(t *testing.T) {
+		registry := versionedRegistry(t)
+		require.NoError(t, registry.RegisterUpcaster("test.widget", "1.0.0", "2.0.0", renameQty))
+		require.NoError(t, registry.RegisterUpcaster("test.widget", "2.0.0", "10.0.0", func(map[string]interface{}) error { return nil }))
+
+		_, err := registry.Upcast([]byte(`{"eventType": "test.widget", "version": "1.0.0", "payload": {"qty": 1}}`))
+
+		var validationErr *ValidationError
+		require.True(t, errors.As(err, &validationErr))
+		assert.Equal(t, "10.0.0", validationErr.Version)
+	})
+
+	t.Run("should reject input that breaks its own version", func(t *testing.T) {
+		_, err := versionedRegistry(t).Upcast([]byte(`{"eventType": "test.widget", "version": "1.0.0", "payload": {}}`))
+
+		var validationErr *ValidationError
+		require.True(t, errors.As(err, &validationErr))
+		assert.Equal(t, "1.0.0", validationErr.Version)
+	})
+
+	t.Run("should surface upcaster errors", func(t *testing.T) {
+		registry := versionedRegistry(t)
+		require.NoError(t, registry.RegisterUpcaster("test.widget", "1.0.0", "2.0.0", func(map[string]interface{}) error {
+			return errors.New("boom")
+		}))
+
+		_, err := registry.Upcast([]byte(`{"eventType": "test.widget", "version": "1.0.0", "payload": {"qty": 1}}`))
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "upcasting test.widget v1.0.0 to v2.0.0: boom")
+	})
+}
@@ -0,0 +1,33 @@
+package schema
+
+import (
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/events"
+)
+
+// registerBuiltinUpcasters registers the upcasters between the embedded schema versions
+func registerBuiltinUpcasters(r *Registry) error {
+	// 1.1.0 adds the optional serial numbers of serial-tracked products, 1.2.0 the
+	// optional components of bundles and 1.3.0 the optional sales channel, so
+	// older events are already valid
+	for _, eventType := range []string{
+		events.RoutingKeyStockReserved,
+		events.RoutingKeyStockConfirmed,
+		events.RoutingKeyStockReleased,
+	} {
+		if err := r.RegisterUpcaster(eventType, "1.0.0", "1.1.0", unchanged); err != nil {
+			return err
+		}
+		if err := r.RegisterUpcaster(eventType, "1.1.0", "1.2.0", unchanged); err != nil {
+			return err
+		}
+		if err := r.RegisterUpcaster(eventType, "1.2.0", "1.3.0", unchanged); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// unchanged is the upcaster of versions that only add optional fields
+func unchanged(map[string]interface{}) error {
+	return nil
+}
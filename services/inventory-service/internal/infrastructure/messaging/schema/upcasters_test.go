@@ -0,0 +1,38 @@
+package schema
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/events"
+)
+
+func TestBuiltinUpcasters(t *testing.T) {
+	for _, eventType := range []string{
+		events.RoutingKeyStockReserved,
+		events.RoutingKeyStockConfirmed,
+		events.RoutingKeyStockReleased,
+	} {
+		for _, version := range []string{"1.0.0", "1.1.0", "1.2.0"} {
+			t.Run(eventType+"/"+version, func(t *testing.T) {
+				old, err := os.ReadFile(filepath.Join("testdata", "golden", eventType+".v"+version+".json"))
+				require.NoError(t, err)
+
+				upcasted, err := Default().Upcast(old)
+				require.NoError(t, err)
+
+				var event, original map[string]interface{}
+				require.NoError(t, json.Unmarshal(upcasted, &event))
+				require.NoError(t, json.Unmarshal(old, &original))
+				assert.Equal(t, events.VersionOf(eventType), event["version"])
+				assert.Equal(t, original["payload"], event["payload"], "older payloads need no changes")
+				assert.NotContains(t, event["payload"], "channel")
+			})
+		}
+	}
+}
@@ -0,0 +1,291 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// node is the subset of JSON Schema (draft 2020-12) used by the event schemas:
+// type, const, enum, required, properties, additionalProperties, items, minimum,
+// minLength, format (uuid, date-time, date) and local $ref into $defs. Unknown
+// keywords are rejected when the schema is compiled so a schema never silently
+// promises more than is enforced.
+type node struct {
+	Ref                  string           `json:"$ref,omitempty"`
+	Type                 string           `json:"type,omitempty"`
+	Const                *json.RawMessage `json:"const,omitempty"`
+	Enum                 []string         `json:"enum,omitempty"`
+	Required             []string         `json:"required,omitempty"`
+	Properties           map[string]*node `json:"properties,omitempty"`
+	AdditionalProperties *bool            `json:"additionalProperties,omitempty"`
+	Items                *node            `json:"items,omitempty"`
+	Minimum              *int64           `json:"minimum,omitempty"`
+	MinLength            *int             `json:"minLength,omitempty"`
+	Format               string           `json:"format,omitempty"`
+	Defs                 map[string]*node `json:"$defs,omitempty"`
+}
+
+// supportedKeywords lists every keyword compile accepts, annotations included
+var supportedKeywords = map[string]bool{
+	"$schema": true, "$id": true, "$ref": true, "$defs": true, "title": true, "description": true,
+	"type": true, "const": true, "enum": true, "required": true, "properties": true,
+	"additionalProperties": true, "items": true, "minimum": true, "minLength": true, "format": true,
+}
+
+var supportedTypes = map[string]bool{"object": true, "array": true, "string": true, "integer": true, "boolean": true}
+
+var supportedFormats = map[string]bool{"": true, "uuid": true, "date-time": true, "date": true}
+
+// compiled is a schema ready to validate documents
+type compiled struct {
+	root *node
+}
+
+// compile parses a schema document and checks it only uses supported keywords
+func compile(data []byte) (*compiled, error) {
+	if err := checkKeywords(data, "#"); err != nil {
+		return nil, err
+	}
+
+	var root node
+	if err := json.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("invalid schema: %w", err)
+	}
+	c := &compiled{root: &root}
+	if err := c.checkRefs(&root, "#"); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func checkKeywords(data []byte, path string) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("%s: schema must be an object: %w", path, err)
+	}
+	for keyword, value := range raw {
+		if !supportedKeywords[keyword] {
+			return fmt.Errorf("%s: unsupported keyword %q", path, keyword)
+		}
+		if keyword == "properties" || keyword == "$defs" {
+			var children map[string]json.RawMessage
+			if err := json.Unmarshal(value, &children); err != nil {
+				return fmt.Errorf("%s/%s: %w", path, keyword, err)
+			}
+			for name, child := range children {
+				if err := checkKeywords(child, path+"/"+keyword+"/"+name); err != nil {
+					return err
+				}
+			}
+		}
+		if keyword == "items" {
+			if err := checkKeywords(value, path+"/items"); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (c *compiled) checkRefs(n *node, path string) error {
+	if n.Ref != "" {
+		if _, err := c.resolve(n.Ref); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	if n.Type != "" && !supportedTypes[n.Type] {
+		return fmt.Errorf("%s: unsupported type %q", path, n.Type)
+	}
+	if !supportedFormats[n.Format] {
+		return fmt.Errorf("%s: unsupported format %q", path, n.Format)
+	}
+	for name, child := range n.Properties {
+		if err := c.checkRefs(child, path+"/properties/"+name); err != nil {
+			return err
+		}
+	}
+	if n.Items != nil {
+		if err := c.checkRefs(n.Items, path+"/items"); err != nil {
+			return err
+		}
+	}
+	for name, child := range n.Defs {
+		if err := c.checkRefs(child, path+"/$defs/"+name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *compiled) resolve(ref string) (*node, error) {
+	name, ok := strings.CutPrefix(ref, "#/$defs/")
+	if !ok {
+		return nil, fmt.Errorf("only local #/$defs references are supported, got %q", ref)
+	}
+	target, ok := c.root.Defs[name]
+	if !ok {
+		return nil, fmt.Errorf("unresolved reference %q", ref)
+	}
+	return target, nil
+}
+
+// Violation describes one way a document breaks its schema
+type Violation struct {
+	Path    string // JSON pointer to the offending value
+	Message string
+}
+
+// ValidationError lists every violation found in a document
+type ValidationError struct {
+	EventType  string
+	Version    string
+	Violations []Violation
+}
+
+// Error implements the error interface
+func (e *ValidationError) Error() string {
+	parts := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		parts[i] = v.Path + ": " + v.Message
+	}
+	return fmt.Sprintf("event %s v%s does not match its schema: %s", e.EventType, e.Version, strings.Join(parts, "; "))
+}
+
+// validate checks a JSON document and returns all violations
+func (c *compiled) validate(data []byte) ([]Violation, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var doc interface{}
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	var violations []Violation
+	c.check(c.root, doc, "", &violations)
+	return violations, nil
+}
+
+func (c *compiled) check(n *node, value interface{}, path string, out *[]Violation) {
+	fail := func(format string, args ...interface{}) {
+		p := path
+		if p == "" {
+			p = "/"
+		}
+		*out = append(*out, Violation{Path: p, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if n.Ref != "" {
+		target, err := c.resolve(n.Ref)
+		if err != nil {
+			fail("%v", err)
+			return
+		}
+		c.check(target, value, path, out)
+	}
+
+	if n.Const != nil {
+		var expected interface{}
+		if err := json.Unmarshal(*n.Const, &expected); err == nil && fmt.Sprint(expected) != fmt.Sprint(value) {
+			fail("must be %s", string(*n.Const))
+		}
+	}
+
+	switch n.Type {
+	case "object":
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			fail("must be an object")
+			return
+		}
+		for _, name := range n.Required {
+			if _, present := obj[name]; !present {
+				fail("missing required property %q", name)
+			}
+		}
+		names := make([]string, 0, len(obj))
+		for name := range obj {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			child, known := n.Properties[name]
+			if !known {
+				if n.AdditionalProperties != nil && !*n.AdditionalProperties {
+					fail("unexpected property %q", name)
+				}
+				continue
+			}
+			c.check(child, obj[name], path+"/"+name, out)
+		}
+	case "array":
+		arr, ok := value.([]interface{})
+		if !ok {
+			fail("must be an array")
+			return
+		}
+		if n.Items != nil {
+			for i, item := range arr {
+				c.check(n.Items, item, fmt.Sprintf("%s/%d", path, i), out)
+			}
+		}
+	case "string":
+		s, ok := value.(string)
+		if !ok {
+			fail("must be a string")
+			return
+		}
+		if n.MinLength != nil && len(s) < *n.MinLength {
+			fail("must be at least %d characters", *n.MinLength)
+		}
+		if len(n.Enum) > 0 && !contains(n.Enum, s) {
+			fail("must be one of %v", n.Enum)
+		}
+		switch n.Format {
+		case "uuid":
+			if len(s) != 36 || uuid.Validate(s) != nil {
+				fail("must be a UUID")
+			}
+		case "date-time":
+			if _, err := time.Parse(time.RFC3339Nano, s); err != nil {
+				fail("must be an RFC 3339 date-time")
+			}
+		case "date":
+			if _, err := time.Parse(time.DateOnly, s); err != nil {
+				fail("must be an RFC 3339 full-date")
+			}
+		}
+	case "integer":
+		num, ok := value.(json.Number)
+		if !ok {
+			fail("must be an integer")
+			return
+		}
+		i, err := num.Int64()
+		if err != nil {
+			fail("must be an integer")
+			return
+		}
+		if n.Minimum != nil && i < *n.Minimum {
+			fail("must be >= %d", *n.Minimum)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			fail("must be a boolean")
+		}
+	}
+}
+
+func contains(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,158 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSchema = `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "type": "object",
+  "required": ["id", "kind", "count"],
+  "additionalProperties": false,
+  "properties": {
+    "id": {"$ref": "#/$defs/uuid"},
+    "kind": {"type": "string", "enum": ["a", "b"]},
+    "count": {"type": "integer", "minimum": 1},
+    "name": {"type": "string", "minLength": 2},
+    "at": {"type": "string", "format": "date-time"},
+    "on": {"type": "string", "format": "date"},
+    "fixed": {"type": "string", "const": "x"},
+    "flag": {"type": "boolean"},
+    "tags": {"type": "array", "items": {"type": "string", "minLength": 1}}
+  },
+  "$defs": {
+    "uuid": {"type": "string", "format": "uuid"}
+  }
+}`
+
+func TestCompile_RejectsUnsupportedSchemas(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+		errMsg string
+	}{
+		{"unknown keyword", `{"type": "object", "pattern": "x"}`, `unsupported keyword "pattern"`},
+		{"nested unknown keyword", `{"type": "object", "properties": {"a": {"maxLength": 3}}}`, `#/properties/a: unsupported keyword "maxLength"`},
+		{"unknown keyword in items", `{"type": "array", "items": {"maxLength": 3}}`, `#/items: unsupported keyword "maxLength"`},
+		{"unknown type in items", `{"type": "array", "items": {"type": "number"}}`, `#/items: unsupported type "number"`},
+		{"unknown type", `{"type": "number"}`, `unsupported type "number"`},
+		{"unknown format", `{"type": "string", "format": "email"}`, `unsupported format "email"`},
+		{"remote reference", `{"$ref": "https://example.com/s.json"}`, "only local"},
+		{"unresolved reference", `{"$ref": "#/$defs/missing"}`, "unresolved reference"},
+		{"not an object", `[]`, "schema must be an object"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := compile([]byte(tt.schema))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
+
+func TestCompiled_Validate(t *testing.T) {
+	c, err := compile([]byte(testSchema))
+	require.NoError(t, err)
+
+	t.Run("should accept a valid document", func(t *testing.T) {
+		violations, err := c.validate([]byte(`{
+			"id": "7c9e6679-7425-40de-944b-e07fc1f90ae7",
+			"kind": "a",
+			"count": 3,
+			"name": "ok",
+			"at": "2025-01-02T03:04:05.123Z",
+			"on": "2025-01-02",
+			"fixed": "x",
+			"flag": true,
+			"tags": ["a", "b"]
+		}`))
+
+		require.NoError(t, err)
+		assert.Empty(t, violations)
+	})
+
+	t.Run("should report every violation with its path", func(t *testing.T) {
+		violations, err := c.validate([]byte(`{
+			"id": "not-a-uuid",
+			"kind": "c",
+			"count": 0,
+			"name": "x",
+			"at": "yesterday",
+			"on": "2025-01-02T03:04:05Z",
+			"fixed": "y",
+			"flag": "yes",
+			"tags": ["a", ""],
+			"extra": 1
+		}`))
+
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []Violation{
+			{Path: "/", Message: `unexpected property "extra"`},
+			{Path: "/id", Message: "must be a UUID"},
+			{Path: "/kind", Message: "must be one of [a b]"},
+			{Path: "/count", Message: "must be >= 1"},
+			{Path: "/name", Message: "must be at least 2 characters"},
+			{Path: "/at", Message: "must be an RFC 3339 date-time"},
+			{Path: "/on", Message: "must be an RFC 3339 full-date"},
+			{Path: "/fixed", Message: `must be "x"`},
+			{Path: "/flag", Message: "must be a boolean"},
+			{Path: "/tags/1", Message: "must be at least 1 characters"},
+		}, violations)
+	})
+
+	t.Run("should report missing required properties", func(t *testing.T) {
+		violations, err := c.validate([]byte(`{"kind": "a"}`))
+
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []Violation{
+			{Path: "/", Message: `missing required property "id"`},
+			{Path: "/", Message: `missing required property "count"`},
+		}, violations)
+	})
+
+	t.Run("should reject non-integer numbers", func(t *testing.T) {
+		violations, err := c.validate([]byte(`{"id": "7c9e6679-7425-40de-944b-e07fc1f90ae7", "kind": "a", "count": 1.5}`))
+
+		require.NoError(t, err)
+		assert.Equal(t, []Violation{{Path: "/count", Message: "must be an integer"}}, violations)
+	})
+
+	t.Run("should reject an array of the wrong shape", func(t *testing.T) {
+		violations, err := c.validate([]byte(`{"id": "7c9e6679-7425-40de-944b-e07fc1f90ae7", "kind": "a", "count": 1, "tags": "a"}`))
+
+		require.NoError(t, err)
+		assert.Equal(t, []Violation{{Path: "/tags", Message: "must be an array"}}, violations)
+	})
+
+	t.Run("should reject the wrong JSON type", func(t *testing.T) {
+		violations, err := c.validate([]byte(`"text"`))
+
+		require.NoError(t, err)
+		assert.Equal(t, []Violation{{Path: "/", Message: "must be an object"}}, violations)
+	})
+
+	t.Run("should fail on malformed JSON", func(t *testing.T) {
+		_, err := c.validate([]byte(`{"id":`))
+
+		assert.Error(t, err)
+	})
+}
+
+func TestValidationError_Error(t *testing.T) {
+	err := &ValidationError{
+		EventType: "inventory.stock.reserved",
+		Version:   "1.0.0",
+		Violations: []Violation{
+			{Path: "/payload/quantity", Message: "must be >= 1"},
+			{Path: "/", Message: `unexpected property "extra"`},
+		},
+	}
+
+	assert.Equal(t,
+		`event inventory.stock.reserved v1.0.0 does not match its schema: /payload/quantity: must be >= 1; /: unexpected property "extra"`,
+		err.Error())
+}
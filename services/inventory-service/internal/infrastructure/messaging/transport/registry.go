@@ -0,0 +1,79 @@
+// Package transport selects the events.Publisher implementation by name, so the
+// broker is a configuration choice rather than a code change.
+package transport
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/events"
+)
+
+// Transport names
+const (
+	Noop     = "noop"
+	Memory   = "memory"
+	RabbitMQ = "rabbitmq"
+	NATS     = "nats"
+)
+
+// Factory opens a publisher for one transport
+type Factory func(ctx context.Context) (events.Publisher, error)
+
+// Registry maps transport names to factories
+type Registry struct {
+	mu        sync.RWMutex
+	factories map[string]Factory
+}
+
+// NewRegistry creates an empty Registry
+func NewRegistry() *Registry {
+	return &Registry{factories: make(map[string]Factory)}
+}
+
+// Register adds a factory under name. Registering a name twice is an error.
+func (r *Registry) Register(name string, factory Factory) error {
+	if factory == nil {
+		return fmt.Errorf("transport %q: factory cannot be nil", name)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.factories[name]; exists {
+		return fmt.Errorf("transport %q already registered", name)
+	}
+	r.factories[name] = factory
+	return nil
+}
+
+// Open creates the publisher registered under name
+func (r *Registry) Open(ctx context.Context, name string) (events.Publisher, error) {
+	r.mu.RLock()
+	factory, ok := r.factories[name]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown event transport %q (registered: %v)", name, r.Names())
+	}
+
+	publisher, err := factory(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s transport: %w", name, err)
+	}
+	return publisher, nil
+}
+
+// Names returns the registered transport names, sorted
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.factories))
+	for name := range r.factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
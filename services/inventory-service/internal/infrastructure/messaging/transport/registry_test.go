@@ -0,0 +1,57 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/events"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/infrastructure/messaging/memory"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/infrastructure/messaging/noop"
+)
+
+func TestRegistry_Open(t *testing.T) {
+	registry := NewRegistry()
+	bus := memory.NewBus()
+	require.NoError(t, registry.Register(Noop, func(context.Context) (events.Publisher, error) { return noop.NewPublisher(), nil }))
+	require.NoError(t, registry.Register(Memory, func(context.Context) (events.Publisher, error) { return bus, nil }))
+
+	publisher, err := registry.Open(context.Background(), Memory)
+
+	require.NoError(t, err)
+	assert.Same(t, bus, publisher)
+	assert.Equal(t, []string{Memory, Noop}, registry.Names())
+}
+
+func TestRegistry_OpenUnknown(t *testing.T) {
+	registry := NewRegistry()
+	require.NoError(t, registry.Register(Noop, func(context.Context) (events.Publisher, error) { return noop.NewPublisher(), nil }))
+
+	_, err := registry.Open(context.Background(), "kafka")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown event transport "kafka" (registered: [noop])`)
+}
+
+func TestRegistry_OpenFactoryError(t *testing.T) {
+	registry := NewRegistry()
+	dialErr := errors.New("connection refused")
+	require.NoError(t, registry.Register(NATS, func(context.Context) (events.Publisher, error) { return nil, dialErr }))
+
+	_, err := registry.Open(context.Background(), NATS)
+
+	assert.ErrorIs(t, err, dialErr)
+	assert.Contains(t, err.Error(), "failed to open nats transport")
+}
+
+func TestRegistry_Register(t *testing.T) {
+	registry := NewRegistry()
+	factory := func(context.Context) (events.Publisher, error) { return noop.NewPublisher(), nil }
+
+	require.NoError(t, registry.Register(RabbitMQ, factory))
+	assert.Error(t, registry.Register(RabbitMQ, factory), "names are unique")
+	assert.Error(t, registry.Register(NATS, nil))
+}
@@ -0,0 +1,44 @@
+package metrics
+
+import (
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	optimisticLockConflicts = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "inventory_optimistic_lock_conflicts_total",
+			Help: "Inventory writes that lost the optimistic-lock version race, by operation and product",
+		},
+		[]string{"operation", "product_id"},
+	)
+
+	optimisticLockExhausted = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "inventory_optimistic_lock_retries_exhausted_total",
+			Help: "Operations that gave up after every retry hit an optimistic-lock conflict, by operation and product",
+		},
+		[]string{"operation", "product_id"},
+	)
+)
+
+// ContentionMetrics exports optimistic-lock contention per product to Prometheus.
+// It satisfies usecase.ContentionObserver.
+type ContentionMetrics struct{}
+
+// NewContentionMetrics creates a ContentionMetrics
+func NewContentionMetrics() *ContentionMetrics {
+	return &ContentionMetrics{}
+}
+
+// ObserveConflict counts a lost version race
+func (ContentionMetrics) ObserveConflict(operation string, productID uuid.UUID) {
+	optimisticLockConflicts.WithLabelValues(operation, productID.String()).Inc()
+}
+
+// ObserveExhausted counts an operation that ran out of attempts
+func (ContentionMetrics) ObserveExhausted(operation string, productID uuid.UUID) {
+	optimisticLockExhausted.WithLabelValues(operation, productID.String()).Inc()
+}
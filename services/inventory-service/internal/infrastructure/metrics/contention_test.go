@@ -0,0 +1,25 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContentionMetrics(t *testing.T) {
+	m := NewContentionMetrics()
+	productID := uuid.New()
+	other := uuid.New()
+
+	m.ObserveConflict("reserve", productID)
+	m.ObserveConflict("reserve", productID)
+	m.ObserveConflict("confirm", other)
+	m.ObserveExhausted("reserve", productID)
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(optimisticLockConflicts.WithLabelValues("reserve", productID.String())))
+	assert.Equal(t, 1.0, testutil.ToFloat64(optimisticLockConflicts.WithLabelValues("confirm", other.String())))
+	assert.Equal(t, 1.0, testutil.ToFloat64(optimisticLockExhausted.WithLabelValues("reserve", productID.String())))
+	assert.Equal(t, 0.0, testutil.ToFloat64(optimisticLockExhausted.WithLabelValues("confirm", other.String())))
+}
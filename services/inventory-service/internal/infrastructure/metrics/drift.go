@@ -0,0 +1,56 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/application/usecase"
+)
+
+var (
+	inventoryDrift = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "inventory_drift_detected",
+			Help: "Drift found by the last reconciliation run, by kind",
+		},
+		[]string{"kind"},
+	)
+
+	inventoryDriftRepairs = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "inventory_drift_repairs_total",
+			Help: "Drift repairs attempted by reconciliation, by kind and outcome",
+		},
+		[]string{"kind", "outcome"},
+	)
+
+	reconciliationLastRun = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "inventory_reconciliation_last_run_timestamp_seconds",
+			Help: "Unix time of the last completed reconciliation run",
+		},
+	)
+)
+
+// DriftMetrics exports reconciliation reports to Prometheus.
+// It satisfies usecase.DriftObserver.
+type DriftMetrics struct{}
+
+// NewDriftMetrics creates a DriftMetrics
+func NewDriftMetrics() *DriftMetrics {
+	return &DriftMetrics{}
+}
+
+// ObserveReconciliation records the drift counts and repair outcomes of a run.
+// Checks that were skipped keep their previous value.
+func (DriftMetrics) ObserveReconciliation(report *usecase.ReconcileInventoryOutput) {
+	for kind, count := range report.Counts {
+		inventoryDrift.WithLabelValues(string(kind)).Set(float64(count))
+	}
+	for _, drift := range report.Drifts {
+		if drift.Outcome != usecase.DriftDetected {
+			inventoryDriftRepairs.WithLabelValues(string(drift.Kind), string(drift.Outcome)).Inc()
+		}
+	}
+	reconciliationLastRun.Set(float64(report.StartedAt.Unix()))
+}
@@ -0,0 +1,35 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/application/usecase"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+)
+
+func TestDriftMetrics(t *testing.T) {
+	startedAt := time.Date(2025, 11, 10, 12, 0, 0, 0, time.UTC)
+	mismatch := &entity.InventoryDrift{Kind: entity.DriftReservedMismatch}
+	stuck := &entity.InventoryDrift{Kind: entity.DriftStuckReservation}
+
+	NewDriftMetrics().ObserveReconciliation(&usecase.ReconcileInventoryOutput{
+		StartedAt: startedAt,
+		Counts:    map[entity.DriftKind]int{entity.DriftReservedMismatch: 2, entity.DriftStuckReservation: 1},
+		Drifts: []usecase.DriftReport{
+			{InventoryDrift: mismatch, Outcome: usecase.DriftRepaired},
+			{InventoryDrift: mismatch, Outcome: usecase.DriftFailed},
+			{InventoryDrift: stuck, Outcome: usecase.DriftDetected},
+		},
+	})
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(inventoryDrift.WithLabelValues("reserved_mismatch")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(inventoryDrift.WithLabelValues("stuck_reservation")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(inventoryDriftRepairs.WithLabelValues("reserved_mismatch", "repaired")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(inventoryDriftRepairs.WithLabelValues("reserved_mismatch", "failed")))
+	assert.Equal(t, 0.0, testutil.ToFloat64(inventoryDriftRepairs.WithLabelValues("stuck_reservation", "detected")))
+	assert.Equal(t, float64(startedAt.Unix()), testutil.ToFloat64(reconciliationLastRun))
+}
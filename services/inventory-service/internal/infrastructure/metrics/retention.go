@@ -0,0 +1,86 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/application/usecase"
+)
+
+var (
+	reservationsArchived = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "inventory_reservations_archived_total",
+			Help: "Terminal reservations moved to the archive",
+		},
+	)
+
+	retentionRuns = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "inventory_retention_runs_total",
+			Help: "Reservation retention runs, by outcome",
+		},
+		[]string{"outcome"},
+	)
+
+	reservationPartitionsCreated = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "inventory_reservation_partitions_created_total",
+			Help: "Monthly reservation partitions created by retention runs",
+		},
+	)
+
+	reservationPartitionsDropped = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "inventory_reservation_partitions_dropped_total",
+			Help: "Empty monthly reservation partitions dropped by retention runs",
+		},
+	)
+
+	retentionLastRun = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "inventory_retention_last_run_timestamp_seconds",
+			Help: "Unix time of the last successful retention run",
+		},
+	)
+
+	retentionLastDuration = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "inventory_retention_last_run_duration_seconds",
+			Help: "Duration of the last retention run",
+		},
+	)
+
+	reservationDefaultPartitionRows = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "inventory_reservation_default_partition_rows",
+			Help: "Reservations stored in the default partition; anything above zero needs a manual partition split",
+		},
+	)
+)
+
+// RetentionMetrics exports reservation retention runs to Prometheus.
+// It satisfies usecase.RetentionObserver.
+type RetentionMetrics struct{}
+
+// NewRetentionMetrics creates a RetentionMetrics
+func NewRetentionMetrics() *RetentionMetrics {
+	return &RetentionMetrics{}
+}
+
+// ObserveRetention records what a run archived, created and dropped.
+// A failed run still counts the work committed before the error.
+func (RetentionMetrics) ObserveRetention(report *usecase.ArchiveReservationsOutput, err error) {
+	reservationsArchived.Add(float64(report.Archived))
+	reservationPartitionsCreated.Add(float64(len(report.PartitionsCreated)))
+	reservationPartitionsDropped.Add(float64(len(report.PartitionsDropped)))
+	retentionLastDuration.Set(float64(report.DurationMillis) / 1000)
+
+	if err != nil {
+		retentionRuns.WithLabelValues("failed").Inc()
+		return
+	}
+	retentionRuns.WithLabelValues("succeeded").Inc()
+	retentionLastRun.Set(float64(report.StartedAt.Unix()))
+	reservationDefaultPartitionRows.Set(float64(report.DefaultPartitionRows))
+}
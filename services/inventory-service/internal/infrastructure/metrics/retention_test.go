@@ -0,0 +1,43 @@
+package metrics
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/application/usecase"
+)
+
+func TestRetentionMetrics(t *testing.T) {
+	startedAt := time.Date(2025, 11, 24, 3, 0, 0, 0, time.UTC)
+	m := NewRetentionMetrics()
+
+	m.ObserveRetention(&usecase.ArchiveReservationsOutput{
+		StartedAt:            startedAt,
+		DurationMillis:       1500,
+		Archived:             120,
+		PartitionsCreated:    []string{"reservations_p202602"},
+		PartitionsDropped:    []string{"reservations_p202507", "reservations_p202508"},
+		DefaultPartitionRows: 4,
+	}, nil)
+	m.ObserveRetention(&usecase.ArchiveReservationsOutput{
+		StartedAt:         startedAt.Add(time.Hour),
+		DurationMillis:    200,
+		Archived:          30,
+		PartitionsCreated: []string{},
+		PartitionsDropped: []string{},
+	}, errors.New("connection reset"))
+
+	assert.Equal(t, 150.0, testutil.ToFloat64(reservationsArchived))
+	assert.Equal(t, 1.0, testutil.ToFloat64(retentionRuns.WithLabelValues("succeeded")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(retentionRuns.WithLabelValues("failed")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(reservationPartitionsCreated))
+	assert.Equal(t, 2.0, testutil.ToFloat64(reservationPartitionsDropped))
+	assert.Equal(t, 0.2, testutil.ToFloat64(retentionLastDuration))
+	// Failed runs leave the last success and the default partition gauge alone
+	assert.Equal(t, float64(startedAt.Unix()), testutil.ToFloat64(retentionLastRun))
+	assert.Equal(t, 4.0, testutil.ToFloat64(reservationDefaultPartitionRows))
+}
@@ -0,0 +1,126 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+)
+
+// AuditParameters stores request parameters as a JSONB object
+type AuditParameters map[string]string
+
+// Value implements driver.Valuer
+func (p AuditParameters) Value() (driver.Value, error) {
+	if p == nil {
+		return "{}", nil
+	}
+	data, err := json.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// Scan implements sql.Scanner
+func (p *AuditParameters) Scan(value interface{}) error {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		*p = nil
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("cannot scan %T into AuditParameters", value)
+	}
+	return json.Unmarshal(data, p)
+}
+
+// AdminAuditEntryModel is the GORM model for the admin_audit_log table.
+// It maps to the domain entity AdminAuditEntry for persistence.
+type AdminAuditEntryModel struct {
+	ID           uuid.UUID       `gorm:"type:uuid;primaryKey"`
+	Actor        string          `gorm:"type:varchar(255);not null;index:idx_admin_audit_actor"`
+	KeyID        string          `gorm:"type:varchar(255)"`
+	TokenID      string          `gorm:"type:varchar(255)"`
+	Method       string          `gorm:"type:varchar(10);not null"`
+	Route        string          `gorm:"type:varchar(255);not null;index:idx_admin_audit_route"`
+	Path         string          `gorm:"type:text;not null"`
+	Parameters   AuditParameters `gorm:"type:jsonb;not null;default:'{}'"`
+	Outcome      string          `gorm:"type:varchar(20);not null"`
+	StatusCode   int             `gorm:"not null"`
+	AffectedIDs  pq.StringArray  `gorm:"type:text[];not null;default:'{}'"`
+	ErrorMessage string          `gorm:"type:text"`
+	RemoteAddr   string          `gorm:"type:varchar(255)"`
+	DurationMs   int64           `gorm:"not null;default:0"`
+	CreatedAt    time.Time       `gorm:"not null;index:idx_admin_audit_created_at"`
+}
+
+// TableName specifies the table name for AdminAuditEntryModel
+func (AdminAuditEntryModel) TableName() string {
+	return "admin_audit_log"
+}
+
+// BeforeCreate GORM hook - generates UUID and timestamp if not set
+func (m *AdminAuditEntryModel) BeforeCreate(tx *gorm.DB) error {
+	if m.ID == uuid.Nil {
+		m.ID = uuid.New()
+	}
+	if m.CreatedAt.IsZero() {
+		m.CreatedAt = time.Now().UTC()
+	}
+	if m.AffectedIDs == nil {
+		m.AffectedIDs = pq.StringArray{}
+	}
+	return nil
+}
+
+// ToEntity converts GORM model to domain entity
+func (m *AdminAuditEntryModel) ToEntity() *entity.AdminAuditEntry {
+	return &entity.AdminAuditEntry{
+		ID:             m.ID,
+		Actor:          m.Actor,
+		KeyID:          m.KeyID,
+		TokenID:        m.TokenID,
+		Method:         m.Method,
+		Route:          m.Route,
+		Path:           m.Path,
+		Parameters:     map[string]string(m.Parameters),
+		Outcome:        entity.AdminAuditOutcome(m.Outcome),
+		StatusCode:     m.StatusCode,
+		AffectedIDs:    []string(m.AffectedIDs),
+		ErrorMessage:   m.ErrorMessage,
+		RemoteAddr:     m.RemoteAddr,
+		DurationMillis: m.DurationMs,
+		CreatedAt:      m.CreatedAt,
+	}
+}
+
+// NewAdminAuditEntryModelFromEntity creates a new GORM model from domain entity
+func NewAdminAuditEntryModelFromEntity(entry *entity.AdminAuditEntry) *AdminAuditEntryModel {
+	return &AdminAuditEntryModel{
+		ID:           entry.ID,
+		Actor:        entry.Actor,
+		KeyID:        entry.KeyID,
+		TokenID:      entry.TokenID,
+		Method:       entry.Method,
+		Route:        entry.Route,
+		Path:         entry.Path,
+		Parameters:   AuditParameters(entry.Parameters),
+		Outcome:      string(entry.Outcome),
+		StatusCode:   entry.StatusCode,
+		AffectedIDs:  pq.StringArray(entry.AffectedIDs),
+		ErrorMessage: entry.ErrorMessage,
+		RemoteAddr:   entry.RemoteAddr,
+		DurationMs:   entry.DurationMillis,
+		CreatedAt:    entry.CreatedAt,
+	}
+}
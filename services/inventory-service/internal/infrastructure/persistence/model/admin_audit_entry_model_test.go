@@ -0,0 +1,72 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminAuditEntryModel_TableName(t *testing.T) {
+	model := AdminAuditEntryModel{}
+	assert.Equal(t, "admin_audit_log", model.TableName())
+}
+
+func TestAdminAuditEntryModel_RoundTrip(t *testing.T) {
+	// Arrange
+	entry := &entity.AdminAuditEntry{
+		ID:             uuid.New(),
+		Actor:          "ops-console",
+		KeyID:          "2025-01",
+		TokenID:        "jti-1",
+		Method:         "POST",
+		Route:          "/admin/dlq/:id/retry",
+		Path:           "/admin/dlq/msg-1/retry",
+		Parameters:     map[string]string{"id": "msg-1"},
+		Outcome:        entity.AdminAuditSuccess,
+		StatusCode:     200,
+		AffectedIDs:    []string{"msg-1"},
+		RemoteAddr:     "10.0.0.1",
+		DurationMillis: 42,
+		CreatedAt:      time.Now().UTC(),
+	}
+
+	// Act
+	model := NewAdminAuditEntryModelFromEntity(entry)
+	result := model.ToEntity()
+
+	// Assert
+	assert.Equal(t, pq.StringArray{"msg-1"}, model.AffectedIDs)
+	assert.Equal(t, entry, result)
+}
+
+func TestAdminAuditEntryModel_BeforeCreate(t *testing.T) {
+	model := &AdminAuditEntryModel{Actor: "ops-console"}
+
+	require.NoError(t, model.BeforeCreate(nil))
+
+	assert.NotEqual(t, uuid.Nil, model.ID)
+	assert.False(t, model.CreatedAt.IsZero())
+	assert.NotNil(t, model.AffectedIDs)
+}
+
+func TestAuditParameters_ValueAndScan(t *testing.T) {
+	params := AuditParameters{"id": "msg-1", "force": "true"}
+
+	value, err := params.Value()
+	require.NoError(t, err)
+
+	var scanned AuditParameters
+	require.NoError(t, scanned.Scan([]byte(value.(string))))
+	assert.Equal(t, params, scanned)
+
+	empty, err := AuditParameters(nil).Value()
+	require.NoError(t, err)
+	assert.Equal(t, "{}", empty)
+
+	assert.Error(t, scanned.Scan(42))
+}
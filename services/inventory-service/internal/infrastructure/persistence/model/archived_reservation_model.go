@@ -0,0 +1,48 @@
+package model
+
+import (
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	"github.com/google/uuid"
+)
+
+// ArchivedReservationModel is the GORM model for the reservations_archive table.
+// Rows are written by the retention job with SQL; the model is only read.
+type ArchivedReservationModel struct {
+	ID              uuid.UUID `gorm:"type:uuid;primaryKey"`
+	InventoryItemID uuid.UUID `gorm:"type:uuid;not null;index:idx_reservations_archive_item"`
+	OrderID         uuid.UUID `gorm:"type:uuid;not null;index:idx_reservations_archive_order"`
+	Quantity        int       `gorm:"not null"`
+	Status          string    `gorm:"type:varchar(20);not null"`
+	ExpiresAt       time.Time `gorm:"not null"`
+	CreatedAt       time.Time `gorm:"not null;index:idx_reservations_archive_created_at"`
+	UpdatedAt       time.Time `gorm:"not null"`
+	ArchivedAt      time.Time `gorm:"not null"`
+	Channel         string    `gorm:"type:varchar(32);not null;default:''"`
+	UserID          string    `gorm:"type:varchar(128);not null;default:''"`
+}
+
+// TableName specifies the table name for ArchivedReservationModel
+func (ArchivedReservationModel) TableName() string {
+	return "reservations_archive"
+}
+
+// ToEntity converts GORM model to domain entity
+func (m *ArchivedReservationModel) ToEntity() *entity.ArchivedReservation {
+	return &entity.ArchivedReservation{
+		Reservation: entity.Reservation{
+			ID:              m.ID,
+			InventoryItemID: m.InventoryItemID,
+			OrderID:         m.OrderID,
+			Quantity:        m.Quantity,
+			Status:          entity.ReservationStatus(m.Status),
+			ExpiresAt:       m.ExpiresAt,
+			CreatedAt:       m.CreatedAt,
+			UpdatedAt:       m.UpdatedAt,
+			Channel:         m.Channel,
+			UserID:          m.UserID,
+		},
+		ArchivedAt: m.ArchivedAt,
+	}
+}
@@ -0,0 +1,49 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArchivedReservationModel_TableName(t *testing.T) {
+	model := ArchivedReservationModel{}
+	assert.Equal(t, "reservations_archive", model.TableName())
+}
+
+func TestArchivedReservationModel_ToEntity(t *testing.T) {
+	// Arrange
+	createdAt := time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC)
+	model := &ArchivedReservationModel{
+		ID:              uuid.New(),
+		InventoryItemID: uuid.New(),
+		OrderID:         uuid.New(),
+		Quantity:        3,
+		Status:          string(entity.ReservationConfirmed),
+		ExpiresAt:       createdAt.Add(15 * time.Minute),
+		CreatedAt:       createdAt,
+		UpdatedAt:       createdAt.Add(5 * time.Minute),
+		ArchivedAt:      createdAt.AddDate(0, 3, 0),
+		Channel:         "marketplace",
+		UserID:          "user-42",
+	}
+
+	// Act
+	archived := model.ToEntity()
+
+	// Assert
+	assert.Equal(t, model.ID, archived.ID)
+	assert.Equal(t, model.InventoryItemID, archived.InventoryItemID)
+	assert.Equal(t, model.OrderID, archived.OrderID)
+	assert.Equal(t, 3, archived.Quantity)
+	assert.Equal(t, entity.ReservationConfirmed, archived.Status)
+	assert.Equal(t, model.ExpiresAt, archived.ExpiresAt)
+	assert.Equal(t, model.CreatedAt, archived.CreatedAt)
+	assert.Equal(t, model.UpdatedAt, archived.UpdatedAt)
+	assert.Equal(t, model.ArchivedAt, archived.ArchivedAt)
+	assert.Equal(t, "marketplace", archived.Channel)
+	assert.Equal(t, "user-42", archived.UserID)
+}
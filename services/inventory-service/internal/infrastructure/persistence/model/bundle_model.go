@@ -0,0 +1,93 @@
+package model
+
+import (
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	"github.com/google/uuid"
+)
+
+// BundleComponentModel is the GORM model for the bundle_components table.
+// ProductID is read from the joined component item.
+type BundleComponentModel struct {
+	BundleItemID    uuid.UUID `gorm:"type:uuid;primaryKey"`
+	ComponentItemID uuid.UUID `gorm:"type:uuid;primaryKey;index:idx_bundle_components_component"`
+	Quantity        int       `gorm:"not null"`
+	CreatedAt       time.Time `gorm:"not null"`
+	ProductID       uuid.UUID `gorm:"->"`
+}
+
+// TableName specifies the table name for BundleComponentModel
+func (BundleComponentModel) TableName() string {
+	return "bundle_components"
+}
+
+// ToEntity converts GORM model to domain entity
+func (m *BundleComponentModel) ToEntity() entity.BundleComponent {
+	return entity.BundleComponent{
+		InventoryItemID: m.ComponentItemID,
+		ProductID:       m.ProductID,
+		Quantity:        m.Quantity,
+	}
+}
+
+// NewBundleComponentModels creates the GORM models of the components of a bundle
+func NewBundleComponentModels(bundle *entity.Bundle, createdAt time.Time) []*BundleComponentModel {
+	models := make([]*BundleComponentModel, len(bundle.Components))
+	for i, component := range bundle.Components {
+		models[i] = &BundleComponentModel{
+			BundleItemID:    bundle.InventoryItemID,
+			ComponentItemID: component.InventoryItemID,
+			Quantity:        component.Quantity,
+			CreatedAt:       createdAt,
+			ProductID:       component.ProductID,
+		}
+	}
+	return models
+}
+
+// ReservationComponentModel is the GORM model for the reservation_components table.
+// ProductID is read from the joined component item.
+type ReservationComponentModel struct {
+	ReservationID   uuid.UUID `gorm:"type:uuid;primaryKey"`
+	BundleItemID    uuid.UUID `gorm:"type:uuid;not null"`
+	InventoryItemID uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Quantity        int       `gorm:"not null"`
+	Status          string    `gorm:"type:varchar(20);not null;default:'reserved'"`
+	CreatedAt       time.Time `gorm:"not null"`
+	UpdatedAt       time.Time `gorm:"not null"`
+	ProductID       uuid.UUID `gorm:"->"`
+}
+
+// TableName specifies the table name for ReservationComponentModel
+func (ReservationComponentModel) TableName() string {
+	return "reservation_components"
+}
+
+// ToEntity converts GORM model to domain entity
+func (m *ReservationComponentModel) ToEntity() *entity.ReservationComponent {
+	return &entity.ReservationComponent{
+		ReservationID:   m.ReservationID,
+		BundleItemID:    m.BundleItemID,
+		InventoryItemID: m.InventoryItemID,
+		ProductID:       m.ProductID,
+		Quantity:        m.Quantity,
+		Status:          entity.ReservationComponentStatus(m.Status),
+		CreatedAt:       m.CreatedAt,
+		UpdatedAt:       m.UpdatedAt,
+	}
+}
+
+// NewReservationComponentModelFromEntity creates a new GORM model from domain entity
+func NewReservationComponentModelFromEntity(component *entity.ReservationComponent) *ReservationComponentModel {
+	return &ReservationComponentModel{
+		ReservationID:   component.ReservationID,
+		BundleItemID:    component.BundleItemID,
+		InventoryItemID: component.InventoryItemID,
+		Quantity:        component.Quantity,
+		Status:          string(component.Status),
+		CreatedAt:       component.CreatedAt,
+		UpdatedAt:       component.UpdatedAt,
+		ProductID:       component.ProductID,
+	}
+}
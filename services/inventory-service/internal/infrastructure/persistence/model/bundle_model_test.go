@@ -0,0 +1,56 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBundleModel_TableNames(t *testing.T) {
+	assert.Equal(t, "bundle_components", BundleComponentModel{}.TableName())
+	assert.Equal(t, "reservation_components", ReservationComponentModel{}.TableName())
+}
+
+func TestBundleComponentModel_RoundTrip(t *testing.T) {
+	createdAt := time.Date(2025, 12, 22, 9, 0, 0, 0, time.UTC)
+	bundle := &entity.Bundle{
+		InventoryItemID: uuid.New(),
+		ProductID:       uuid.New(),
+		Components: []entity.BundleComponent{
+			{InventoryItemID: uuid.New(), ProductID: uuid.New(), Quantity: 1},
+			{InventoryItemID: uuid.New(), ProductID: uuid.New(), Quantity: 3},
+		},
+	}
+
+	models := NewBundleComponentModels(bundle, createdAt)
+
+	require.Len(t, models, 2)
+	for i, model := range models {
+		assert.Equal(t, bundle.InventoryItemID, model.BundleItemID)
+		assert.Equal(t, createdAt, model.CreatedAt)
+		assert.Equal(t, bundle.Components[i], model.ToEntity())
+	}
+}
+
+func TestReservationComponentModel_RoundTrip(t *testing.T) {
+	at := time.Date(2025, 12, 22, 9, 0, 0, 0, time.UTC)
+	component := &entity.ReservationComponent{
+		ReservationID:   uuid.New(),
+		BundleItemID:    uuid.New(),
+		InventoryItemID: uuid.New(),
+		ProductID:       uuid.New(),
+		Quantity:        4,
+		Status:          entity.ComponentConfirmed,
+		CreatedAt:       at,
+		UpdatedAt:       at.Add(time.Minute),
+	}
+
+	model := NewReservationComponentModelFromEntity(component)
+
+	assert.Equal(t, "confirmed", model.Status)
+	assert.Equal(t, component, model.ToEntity())
+}
@@ -0,0 +1,50 @@
+package model
+
+import (
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	"github.com/google/uuid"
+)
+
+// ChannelAllocationModel is the GORM model for the channel_allocations table
+type ChannelAllocationModel struct {
+	InventoryItemID uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Channel         string    `gorm:"type:varchar(32);primaryKey"`
+	Mode            string    `gorm:"type:varchar(20);not null"`
+	Quota           int       `gorm:"not null;default:0"`
+	Percent         int       `gorm:"not null;default:0"`
+	CreatedAt       time.Time `gorm:"not null"`
+	UpdatedAt       time.Time `gorm:"not null"`
+}
+
+// TableName specifies the table name for ChannelAllocationModel
+func (ChannelAllocationModel) TableName() string {
+	return "channel_allocations"
+}
+
+// ToEntity converts GORM model to domain entity
+func (m *ChannelAllocationModel) ToEntity() entity.ChannelAllocation {
+	return entity.ChannelAllocation{
+		InventoryItemID: m.InventoryItemID,
+		Channel:         m.Channel,
+		Mode:            entity.AllocationMode(m.Mode),
+		Quota:           m.Quota,
+		Percent:         m.Percent,
+		CreatedAt:       m.CreatedAt,
+		UpdatedAt:       m.UpdatedAt,
+	}
+}
+
+// NewChannelAllocationModel creates a new GORM model from domain entity
+func NewChannelAllocationModel(allocation entity.ChannelAllocation) *ChannelAllocationModel {
+	return &ChannelAllocationModel{
+		InventoryItemID: allocation.InventoryItemID,
+		Channel:         allocation.Channel,
+		Mode:            string(allocation.Mode),
+		Quota:           allocation.Quota,
+		Percent:         allocation.Percent,
+		CreatedAt:       allocation.CreatedAt,
+		UpdatedAt:       allocation.UpdatedAt,
+	}
+}
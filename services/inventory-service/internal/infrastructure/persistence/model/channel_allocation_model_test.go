@@ -0,0 +1,29 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChannelAllocationModel_TableName(t *testing.T) {
+	assert.Equal(t, "channel_allocations", ChannelAllocationModel{}.TableName())
+}
+
+func TestChannelAllocationModel_RoundTrip(t *testing.T) {
+	at := time.Date(2025, 12, 29, 9, 0, 0, 0, time.UTC)
+	allocations := []entity.ChannelAllocation{
+		{InventoryItemID: uuid.New(), Channel: "marketplace", Mode: entity.AllocationFixed, Quota: 20, CreatedAt: at, UpdatedAt: at},
+		{InventoryItemID: uuid.New(), Channel: "b2b", Mode: entity.AllocationPercentage, Percent: 25, CreatedAt: at, UpdatedAt: at.Add(time.Hour)},
+		{InventoryItemID: uuid.New(), Channel: "web", Mode: entity.AllocationShared, CreatedAt: at, UpdatedAt: at},
+	}
+
+	for _, allocation := range allocations {
+		model := NewChannelAllocationModel(allocation)
+		assert.Equal(t, string(allocation.Mode), model.Mode)
+		assert.Equal(t, allocation, model.ToEntity())
+	}
+}
@@ -18,6 +18,16 @@ type InventoryItemModel struct {
 	Version   int       `gorm:"not null;default:1"`
 	CreatedAt time.Time `gorm:"not null"`
 	UpdatedAt time.Time `gorm:"not null"`
+	// ArchivedAt is set while the product is deactivated in the catalog
+	ArchivedAt *time.Time `gorm:"index:idx_inventory_archived_at,where:archived_at IS NOT NULL"`
+	// CatalogChangedAt is the catalog time of the latest product change applied
+	CatalogChangedAt *time.Time
+	// SerialTracked is set when the stock is tracked per unit in serial_units
+	SerialTracked bool `gorm:"not null;default:false"`
+	// Bundle is set when the product is a bundle of the products in bundle_components
+	Bundle bool `gorm:"not null;default:false"`
+	// ChannelAllocated is set when the stock is allocated to the channels in channel_allocations
+	ChannelAllocated bool `gorm:"not null;default:false"`
 }
 
 // TableName specifies the table name for InventoryItemModel
@@ -48,13 +58,18 @@ func (m *InventoryItemModel) BeforeUpdate(tx *gorm.DB) error {
 // ToEntity converts GORM model to domain entity
 func (m *InventoryItemModel) ToEntity() *entity.InventoryItem {
 	return &entity.InventoryItem{
-		ID:        m.ID,
-		ProductID: m.ProductID,
-		Quantity:  m.Quantity,
-		Reserved:  m.Reserved,
-		Version:   m.Version,
-		CreatedAt: m.CreatedAt,
-		UpdatedAt: m.UpdatedAt,
+		ID:               m.ID,
+		ProductID:        m.ProductID,
+		Quantity:         m.Quantity,
+		Reserved:         m.Reserved,
+		Version:          m.Version,
+		CreatedAt:        m.CreatedAt,
+		UpdatedAt:        m.UpdatedAt,
+		ArchivedAt:       m.ArchivedAt,
+		CatalogChangedAt: m.CatalogChangedAt,
+		SerialTracked:    m.SerialTracked,
+		Bundle:           m.Bundle,
+		ChannelAllocated: m.ChannelAllocated,
 	}
 }
 
@@ -67,6 +82,11 @@ func (m *InventoryItemModel) FromEntity(item *entity.InventoryItem) {
 	m.Version = item.Version
 	m.CreatedAt = item.CreatedAt
 	m.UpdatedAt = item.UpdatedAt
+	m.ArchivedAt = item.ArchivedAt
+	m.CatalogChangedAt = item.CatalogChangedAt
+	m.SerialTracked = item.SerialTracked
+	m.Bundle = item.Bundle
+	m.ChannelAllocated = item.ChannelAllocated
 }
 
 // NewInventoryItemModelFromEntity creates a new GORM model from domain entity
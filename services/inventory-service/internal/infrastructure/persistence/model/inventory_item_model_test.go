@@ -107,3 +107,48 @@ func TestInventoryItemModel_Conversion_RoundTrip(t *testing.T) {
 		assert.Equal(t, originalItem.Available(), convertedItem.Available())
 	})
 }
+
+func TestInventoryItemModel_ArchivedAt(t *testing.T) {
+	item, err := entity.NewInventoryItem(uuid.New(), 10)
+	require.NoError(t, err)
+	archivedAt := time.Now().UTC()
+	item.Archive(archivedAt)
+
+	model := NewInventoryItemModelFromEntity(item)
+	require.NotNil(t, model.ArchivedAt)
+	assert.Equal(t, archivedAt, *model.ArchivedAt)
+
+	restored := model.ToEntity()
+	assert.True(t, restored.IsArchived())
+	assert.Equal(t, archivedAt, *restored.ArchivedAt)
+}
+
+func TestInventoryItemModel_SerialTracked(t *testing.T) {
+	item, err := entity.NewInventoryItem(uuid.New(), 0)
+	require.NoError(t, err)
+	require.NoError(t, item.EnableSerialTracking())
+
+	model := NewInventoryItemModelFromEntity(item)
+	assert.True(t, model.SerialTracked)
+	assert.True(t, model.ToEntity().SerialTracked)
+}
+
+func TestInventoryItemModel_Bundle(t *testing.T) {
+	item, err := entity.NewInventoryItem(uuid.New(), 0)
+	require.NoError(t, err)
+	require.NoError(t, item.MakeBundle())
+
+	model := NewInventoryItemModelFromEntity(item)
+	assert.True(t, model.Bundle)
+	assert.True(t, model.ToEntity().Bundle)
+}
+
+func TestInventoryItemModel_ChannelAllocated(t *testing.T) {
+	item, err := entity.NewInventoryItem(uuid.New(), 10)
+	require.NoError(t, err)
+	item.ChannelAllocated = true
+
+	model := NewInventoryItemModelFromEntity(item)
+	assert.True(t, model.ChannelAllocated)
+	assert.True(t, model.ToEntity().ChannelAllocated)
+}
@@ -0,0 +1,114 @@
+package model
+
+import (
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	"github.com/google/uuid"
+)
+
+// LotModel is the GORM model for the lots table.
+// It maps to the domain entity Lot for persistence.
+type LotModel struct {
+	ID              uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	InventoryItemID uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:uq_lots_item_lot_number"`
+	LotNumber       string     `gorm:"type:varchar(100);not null;uniqueIndex:uq_lots_item_lot_number"`
+	ReceivedAt      time.Time  `gorm:"not null"`
+	BestBefore      *time.Time `gorm:"type:date"`
+	Quantity        int        `gorm:"not null"`
+	Reserved        int        `gorm:"not null;default:0"`
+	Quarantined     int        `gorm:"not null;default:0"`
+	QuarantinedAt   *time.Time
+	CreatedAt       time.Time `gorm:"not null"`
+	UpdatedAt       time.Time `gorm:"not null"`
+}
+
+// TableName specifies the table name for LotModel
+func (LotModel) TableName() string {
+	return "lots"
+}
+
+// ToEntity converts GORM model to domain entity
+func (m *LotModel) ToEntity() *entity.Lot {
+	return &entity.Lot{
+		ID:              m.ID,
+		InventoryItemID: m.InventoryItemID,
+		LotNumber:       m.LotNumber,
+		ReceivedAt:      m.ReceivedAt,
+		BestBefore:      m.BestBefore,
+		Quantity:        m.Quantity,
+		Reserved:        m.Reserved,
+		Quarantined:     m.Quarantined,
+		QuarantinedAt:   m.QuarantinedAt,
+		CreatedAt:       m.CreatedAt,
+		UpdatedAt:       m.UpdatedAt,
+	}
+}
+
+// FromEntity converts domain entity to GORM model
+func (m *LotModel) FromEntity(lot *entity.Lot) {
+	m.ID = lot.ID
+	m.InventoryItemID = lot.InventoryItemID
+	m.LotNumber = lot.LotNumber
+	m.ReceivedAt = lot.ReceivedAt
+	m.BestBefore = lot.BestBefore
+	m.Quantity = lot.Quantity
+	m.Reserved = lot.Reserved
+	m.Quarantined = lot.Quarantined
+	m.QuarantinedAt = lot.QuarantinedAt
+	m.CreatedAt = lot.CreatedAt
+	m.UpdatedAt = lot.UpdatedAt
+}
+
+// NewLotModelFromEntity creates a new GORM model from domain entity
+func NewLotModelFromEntity(lot *entity.Lot) *LotModel {
+	model := &LotModel{}
+	model.FromEntity(lot)
+	return model
+}
+
+// LotAllocationModel is the GORM model for the reservation_lots table.
+// LotNumber and BestBefore are read from the joined lot.
+type LotAllocationModel struct {
+	ReservationID uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	LotID         uuid.UUID  `gorm:"type:uuid;primaryKey;index:idx_reservation_lots_lot"`
+	Quantity      int        `gorm:"not null"`
+	Status        string     `gorm:"type:varchar(20);not null;default:'allocated'"`
+	AllocatedAt   time.Time  `gorm:"not null"`
+	UpdatedAt     time.Time  `gorm:"not null"`
+	LotNumber     string     `gorm:"->"`
+	BestBefore    *time.Time `gorm:"->"`
+}
+
+// TableName specifies the table name for LotAllocationModel
+func (LotAllocationModel) TableName() string {
+	return "reservation_lots"
+}
+
+// ToEntity converts GORM model to domain entity
+func (m *LotAllocationModel) ToEntity() *entity.LotAllocation {
+	return &entity.LotAllocation{
+		ReservationID: m.ReservationID,
+		LotID:         m.LotID,
+		LotNumber:     m.LotNumber,
+		BestBefore:    m.BestBefore,
+		Quantity:      m.Quantity,
+		Status:        entity.LotAllocationStatus(m.Status),
+		AllocatedAt:   m.AllocatedAt,
+		UpdatedAt:     m.UpdatedAt,
+	}
+}
+
+// NewLotAllocationModelFromEntity creates a new GORM model from domain entity
+func NewLotAllocationModelFromEntity(allocation *entity.LotAllocation) *LotAllocationModel {
+	return &LotAllocationModel{
+		ReservationID: allocation.ReservationID,
+		LotID:         allocation.LotID,
+		Quantity:      allocation.Quantity,
+		Status:        string(allocation.Status),
+		AllocatedAt:   allocation.AllocatedAt,
+		UpdatedAt:     allocation.UpdatedAt,
+		LotNumber:     allocation.LotNumber,
+		BestBefore:    allocation.BestBefore,
+	}
+}
@@ -0,0 +1,64 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLotModel_TableNames(t *testing.T) {
+	assert.Equal(t, "lots", LotModel{}.TableName())
+	assert.Equal(t, "reservation_lots", LotAllocationModel{}.TableName())
+}
+
+func TestLotModel_RoundTrip(t *testing.T) {
+	// Arrange
+	receivedAt := time.Date(2025, 11, 3, 14, 30, 0, 0, time.UTC)
+	bestBefore := time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)
+	quarantinedAt := bestBefore.AddDate(0, 0, 1)
+	lot := &entity.Lot{
+		ID:              uuid.New(),
+		InventoryItemID: uuid.New(),
+		LotNumber:       "L-2025-11-03",
+		ReceivedAt:      receivedAt,
+		BestBefore:      &bestBefore,
+		Quantity:        10,
+		Reserved:        4,
+		Quarantined:     6,
+		QuarantinedAt:   &quarantinedAt,
+		CreatedAt:       receivedAt,
+		UpdatedAt:       quarantinedAt,
+	}
+
+	// Act
+	model := NewLotModelFromEntity(lot)
+
+	// Assert
+	assert.Equal(t, lot, model.ToEntity())
+}
+
+func TestLotAllocationModel_RoundTrip(t *testing.T) {
+	// Arrange
+	allocatedAt := time.Date(2025, 12, 1, 10, 0, 0, 0, time.UTC)
+	bestBefore := time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)
+	allocation := &entity.LotAllocation{
+		ReservationID: uuid.New(),
+		LotID:         uuid.New(),
+		LotNumber:     "L1",
+		BestBefore:    &bestBefore,
+		Quantity:      3,
+		Status:        entity.LotConsumed,
+		AllocatedAt:   allocatedAt,
+		UpdatedAt:     allocatedAt.Add(time.Minute),
+	}
+
+	// Act
+	model := NewLotAllocationModelFromEntity(allocation)
+
+	// Assert
+	assert.Equal(t, "consumed", model.Status)
+	assert.Equal(t, allocation, model.ToEntity())
+}
@@ -0,0 +1,47 @@
+package model
+
+import (
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	"github.com/google/uuid"
+)
+
+// PurchaseLimitModel is the GORM model for the purchase_limits table
+type PurchaseLimitModel struct {
+	InventoryItemID uuid.UUID `gorm:"type:uuid;primaryKey"`
+	MaxPerOrder     int       `gorm:"not null;default:0"`
+	MaxPerCustomer  int       `gorm:"not null;default:0"`
+	WindowSeconds   int64     `gorm:"not null;default:0"`
+	CreatedAt       time.Time `gorm:"not null"`
+	UpdatedAt       time.Time `gorm:"not null"`
+}
+
+// TableName specifies the table name for PurchaseLimitModel
+func (PurchaseLimitModel) TableName() string {
+	return "purchase_limits"
+}
+
+// ToEntity converts GORM model to domain entity
+func (m *PurchaseLimitModel) ToEntity() *entity.PurchaseLimit {
+	return &entity.PurchaseLimit{
+		InventoryItemID: m.InventoryItemID,
+		MaxPerOrder:     m.MaxPerOrder,
+		MaxPerCustomer:  m.MaxPerCustomer,
+		Window:          time.Duration(m.WindowSeconds) * time.Second,
+		CreatedAt:       m.CreatedAt,
+		UpdatedAt:       m.UpdatedAt,
+	}
+}
+
+// NewPurchaseLimitModel creates a new GORM model from domain entity
+func NewPurchaseLimitModel(limit *entity.PurchaseLimit) *PurchaseLimitModel {
+	return &PurchaseLimitModel{
+		InventoryItemID: limit.InventoryItemID,
+		MaxPerOrder:     limit.MaxPerOrder,
+		MaxPerCustomer:  limit.MaxPerCustomer,
+		WindowSeconds:   int64(limit.Window / time.Second),
+		CreatedAt:       limit.CreatedAt,
+		UpdatedAt:       limit.UpdatedAt,
+	}
+}
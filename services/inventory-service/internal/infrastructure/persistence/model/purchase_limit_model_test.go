@@ -0,0 +1,28 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPurchaseLimitModel_TableName(t *testing.T) {
+	assert.Equal(t, "purchase_limits", PurchaseLimitModel{}.TableName())
+}
+
+func TestPurchaseLimitModel_RoundTrip(t *testing.T) {
+	at := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+	limits := []*entity.PurchaseLimit{
+		{InventoryItemID: uuid.New(), MaxPerOrder: 2, MaxPerCustomer: 4, Window: 7 * 24 * time.Hour, CreatedAt: at, UpdatedAt: at},
+		{InventoryItemID: uuid.New(), MaxPerOrder: 1, CreatedAt: at, UpdatedAt: at.Add(time.Hour)},
+	}
+
+	for _, limit := range limits {
+		model := NewPurchaseLimitModel(limit)
+		assert.Equal(t, int64(limit.Window/time.Second), model.WindowSeconds)
+		assert.Equal(t, limit, model.ToEntity())
+	}
+}
@@ -19,6 +19,8 @@ type ReservationModel struct {
 	ExpiresAt       time.Time `gorm:"not null;index:idx_reservations_expires_at"`
 	CreatedAt       time.Time `gorm:"not null"`
 	UpdatedAt       time.Time `gorm:"not null"`
+	Channel         string    `gorm:"type:varchar(32);not null;default:''"`
+	UserID          string    `gorm:"type:varchar(128);not null;default:''"`
 }
 
 // TableName specifies the table name for ReservationModel
@@ -57,6 +59,8 @@ func (m *ReservationModel) ToEntity() *entity.Reservation {
 		ExpiresAt:       m.ExpiresAt,
 		CreatedAt:       m.CreatedAt,
 		UpdatedAt:       m.UpdatedAt,
+		Channel:         m.Channel,
+		UserID:          m.UserID,
 	}
 }
 
@@ -70,6 +74,8 @@ func (m *ReservationModel) FromEntity(reservation *entity.Reservation) {
 	m.ExpiresAt = reservation.ExpiresAt
 	m.CreatedAt = reservation.CreatedAt
 	m.UpdatedAt = reservation.UpdatedAt
+	m.Channel = reservation.Channel
+	m.UserID = reservation.UserID
 }
 
 // NewReservationModelFromEntity creates a new GORM model from domain entity
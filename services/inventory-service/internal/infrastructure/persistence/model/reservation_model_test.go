@@ -98,6 +98,8 @@ func TestReservationModel_Conversion_RoundTrip(t *testing.T) {
 		orderID := uuid.New()
 		originalReservation, err := entity.NewReservation(inventoryItemID, orderID, 40)
 		require.NoError(t, err)
+		originalReservation.Channel = "web"
+		originalReservation.UserID = "user-42"
 
 		// Act - Convert to model and back
 		model := NewReservationModelFromEntity(originalReservation)
@@ -110,6 +112,8 @@ func TestReservationModel_Conversion_RoundTrip(t *testing.T) {
 		assert.Equal(t, originalReservation.Quantity, convertedReservation.Quantity)
 		assert.Equal(t, originalReservation.Status, convertedReservation.Status)
 		assert.Equal(t, originalReservation.ExpiresAt.Unix(), convertedReservation.ExpiresAt.Unix())
+		assert.Equal(t, "web", convertedReservation.Channel)
+		assert.Equal(t, "user-42", convertedReservation.UserID)
 	})
 
 	t.Run("should preserve confirmed status in round-trip conversion", func(t *testing.T) {
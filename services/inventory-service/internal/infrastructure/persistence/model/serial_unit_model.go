@@ -0,0 +1,56 @@
+package model
+
+import (
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	"github.com/google/uuid"
+)
+
+// SerialUnitModel is the GORM model for the serial_units table.
+// It maps to the domain entity SerialUnit for persistence.
+type SerialUnitModel struct {
+	ID              uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	InventoryItemID uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:uq_serial_units_item_serial"`
+	SerialNumber    string     `gorm:"type:varchar(100);not null;uniqueIndex:uq_serial_units_item_serial"`
+	Status          string     `gorm:"type:varchar(20);not null;default:'available'"`
+	ReservationID   *uuid.UUID `gorm:"type:uuid"`
+	CreatedAt       time.Time  `gorm:"not null"`
+	UpdatedAt       time.Time  `gorm:"not null"`
+}
+
+// TableName specifies the table name for SerialUnitModel
+func (SerialUnitModel) TableName() string {
+	return "serial_units"
+}
+
+// ToEntity converts GORM model to domain entity
+func (m *SerialUnitModel) ToEntity() *entity.SerialUnit {
+	return &entity.SerialUnit{
+		ID:              m.ID,
+		InventoryItemID: m.InventoryItemID,
+		SerialNumber:    m.SerialNumber,
+		Status:          entity.SerialUnitStatus(m.Status),
+		ReservationID:   m.ReservationID,
+		CreatedAt:       m.CreatedAt,
+		UpdatedAt:       m.UpdatedAt,
+	}
+}
+
+// FromEntity converts domain entity to GORM model
+func (m *SerialUnitModel) FromEntity(unit *entity.SerialUnit) {
+	m.ID = unit.ID
+	m.InventoryItemID = unit.InventoryItemID
+	m.SerialNumber = unit.SerialNumber
+	m.Status = string(unit.Status)
+	m.ReservationID = unit.ReservationID
+	m.CreatedAt = unit.CreatedAt
+	m.UpdatedAt = unit.UpdatedAt
+}
+
+// NewSerialUnitModelFromEntity creates a new GORM model from domain entity
+func NewSerialUnitModelFromEntity(unit *entity.SerialUnit) *SerialUnitModel {
+	model := &SerialUnitModel{}
+	model.FromEntity(unit)
+	return model
+}
@@ -0,0 +1,36 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSerialUnitModel_TableName(t *testing.T) {
+	assert.Equal(t, "serial_units", SerialUnitModel{}.TableName())
+}
+
+func TestSerialUnitModel_RoundTrip(t *testing.T) {
+	// Arrange
+	createdAt := time.Date(2025, 12, 15, 9, 0, 0, 0, time.UTC)
+	reservationID := uuid.New()
+	unit := &entity.SerialUnit{
+		ID:              uuid.New(),
+		InventoryItemID: uuid.New(),
+		SerialNumber:    "SN-0001",
+		Status:          entity.SerialSold,
+		ReservationID:   &reservationID,
+		CreatedAt:       createdAt,
+		UpdatedAt:       createdAt.Add(time.Hour),
+	}
+
+	// Act
+	model := NewSerialUnitModelFromEntity(unit)
+
+	// Assert
+	assert.Equal(t, "sold", model.Status)
+	assert.Equal(t, unit, model.ToEntity())
+}
@@ -0,0 +1,77 @@
+package model
+
+import (
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	"github.com/google/uuid"
+)
+
+// WaitlistEntryModel is the GORM model for the waitlist_entries table.
+// It maps to the domain entity WaitlistEntry for persistence.
+type WaitlistEntryModel struct {
+	ID              uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	InventoryItemID uuid.UUID  `gorm:"type:uuid;not null"`
+	OrderID         uuid.UUID  `gorm:"type:uuid;not null"`
+	Quantity        int        `gorm:"not null"`
+	Priority        int        `gorm:"not null;default:0"`
+	Channel         string     `gorm:"type:varchar(32);not null;default:''"`
+	UserID          string     `gorm:"type:varchar(128);not null;default:''"`
+	Status          string     `gorm:"type:varchar(20);not null;default:'waiting'"`
+	ExpiresAt       time.Time  `gorm:"not null"`
+	ReservationID   *uuid.UUID `gorm:"type:uuid"`
+	FailureReason   string     `gorm:"type:text;not null;default:''"`
+	FulfilledAt     *time.Time
+	CreatedAt       time.Time `gorm:"not null"`
+	UpdatedAt       time.Time `gorm:"not null"`
+}
+
+// TableName specifies the table name for WaitlistEntryModel
+func (WaitlistEntryModel) TableName() string {
+	return "waitlist_entries"
+}
+
+// ToEntity converts GORM model to domain entity
+func (m *WaitlistEntryModel) ToEntity() *entity.WaitlistEntry {
+	return &entity.WaitlistEntry{
+		ID:              m.ID,
+		InventoryItemID: m.InventoryItemID,
+		OrderID:         m.OrderID,
+		Quantity:        m.Quantity,
+		Priority:        m.Priority,
+		Channel:         m.Channel,
+		UserID:          m.UserID,
+		Status:          entity.WaitlistStatus(m.Status),
+		ExpiresAt:       m.ExpiresAt,
+		ReservationID:   m.ReservationID,
+		FailureReason:   m.FailureReason,
+		FulfilledAt:     m.FulfilledAt,
+		CreatedAt:       m.CreatedAt,
+		UpdatedAt:       m.UpdatedAt,
+	}
+}
+
+// FromEntity converts domain entity to GORM model
+func (m *WaitlistEntryModel) FromEntity(entry *entity.WaitlistEntry) {
+	m.ID = entry.ID
+	m.InventoryItemID = entry.InventoryItemID
+	m.OrderID = entry.OrderID
+	m.Quantity = entry.Quantity
+	m.Priority = entry.Priority
+	m.Channel = entry.Channel
+	m.UserID = entry.UserID
+	m.Status = string(entry.Status)
+	m.ExpiresAt = entry.ExpiresAt
+	m.ReservationID = entry.ReservationID
+	m.FailureReason = entry.FailureReason
+	m.FulfilledAt = entry.FulfilledAt
+	m.CreatedAt = entry.CreatedAt
+	m.UpdatedAt = entry.UpdatedAt
+}
+
+// NewWaitlistEntryModelFromEntity creates a new GORM model from domain entity
+func NewWaitlistEntryModelFromEntity(entry *entity.WaitlistEntry) *WaitlistEntryModel {
+	model := &WaitlistEntryModel{}
+	model.FromEntity(entry)
+	return model
+}
@@ -0,0 +1,43 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWaitlistEntryModel_TableName(t *testing.T) {
+	assert.Equal(t, "waitlist_entries", WaitlistEntryModel{}.TableName())
+}
+
+func TestWaitlistEntryModel_RoundTrip(t *testing.T) {
+	// Arrange
+	createdAt := time.Date(2026, 1, 12, 9, 0, 0, 0, time.UTC)
+	fulfilledAt := createdAt.Add(time.Hour)
+	reservationID := uuid.New()
+	entry := &entity.WaitlistEntry{
+		ID:              uuid.New(),
+		InventoryItemID: uuid.New(),
+		OrderID:         uuid.New(),
+		Quantity:        3,
+		Priority:        10,
+		Channel:         "web",
+		UserID:          "user-42",
+		Status:          entity.WaitlistFulfilled,
+		ExpiresAt:       createdAt.Add(24 * time.Hour),
+		ReservationID:   &reservationID,
+		FulfilledAt:     &fulfilledAt,
+		CreatedAt:       createdAt,
+		UpdatedAt:       fulfilledAt,
+	}
+
+	// Act
+	model := NewWaitlistEntryModelFromEntity(entry)
+
+	// Assert
+	assert.Equal(t, "fulfilled", model.Status)
+	assert.Equal(t, entry, model.ToEntity())
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	domainRepository "github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/repository"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/infrastructure/persistence/model"
+	"gorm.io/gorm"
+)
+
+// AdminAuditRepositoryImpl is the GORM implementation of AdminAuditRepository
+type AdminAuditRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewAdminAuditRepository creates a new instance of AdminAuditRepositoryImpl
+func NewAdminAuditRepository(db *gorm.DB) *AdminAuditRepositoryImpl {
+	return &AdminAuditRepositoryImpl{
+		db: db,
+	}
+}
+
+// Save appends an entry to the audit log
+func (r *AdminAuditRepositoryImpl) Save(ctx context.Context, entry *entity.AdminAuditEntry) error {
+	entryModel := model.NewAdminAuditEntryModelFromEntity(entry)
+
+	if err := r.db.WithContext(ctx).Create(entryModel).Error; err != nil {
+		return fmt.Errorf("failed to save admin audit entry: %w", err)
+	}
+
+	return nil
+}
+
+// List returns entries matching the filter, newest first, and the total number of matches
+func (r *AdminAuditRepositoryImpl) List(ctx context.Context, filter domainRepository.AdminAuditFilter) ([]*entity.AdminAuditEntry, int64, error) {
+	query := r.db.WithContext(ctx).Model(&model.AdminAuditEntryModel{})
+
+	if filter.Actor != "" {
+		query = query.Where("actor = ?", filter.Actor)
+	}
+	if filter.Route != "" {
+		query = query.Where("route = ?", filter.Route)
+	}
+	if filter.Outcome != "" {
+		query = query.Where("outcome = ?", string(filter.Outcome))
+	}
+	if filter.EntityID != "" {
+		query = query.Where("? = ANY(affected_ids)", filter.EntityID)
+	}
+	if !filter.From.IsZero() {
+		query = query.Where("created_at >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("created_at < ?", filter.To)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count admin audit entries: %w", err)
+	}
+
+	var models []model.AdminAuditEntryModel
+	query = query.Order("created_at DESC").Offset(filter.Offset)
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+	if err := query.Find(&models).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to list admin audit entries: %w", err)
+	}
+
+	entries := make([]*entity.AdminAuditEntry, len(models))
+	for i := range models {
+		entries[i] = models[i].ToEntity()
+	}
+
+	return entries, total, nil
+}
@@ -0,0 +1,424 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	domainErrors "github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/repository"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/infrastructure/persistence/model"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Bundle tables, see migration 011. Every transaction locks the bundle's item
+// before the component items, and the component items in ID order, so bundle
+// reservations sharing components wait for each other instead of deadlocking.
+// Reservations only share-lock the bundle's item, so they do not wait for each
+// other on it, only for changes of the bundle definition.
+const (
+	// bundleComponentsSQL reads the components of a bundle with their product
+	bundleComponentsSQL = `SELECT bc.bundle_item_id, bc.component_item_id, bc.quantity, bc.created_at, i.product_id
+		FROM bundle_components bc
+		JOIN inventory_items i ON i.id = bc.component_item_id
+		WHERE bc.bundle_item_id = ?
+		ORDER BY bc.component_item_id`
+
+	// reservationComponentsSQL reads the components of a reservation with their product.
+	// %s narrows them to a status.
+	reservationComponentsSQL = `SELECT rc.reservation_id, rc.bundle_item_id, rc.inventory_item_id, rc.quantity, rc.status,
+			rc.created_at, rc.updated_at, i.product_id
+		FROM reservation_components rc
+		JOIN inventory_items i ON i.id = rc.inventory_item_id
+		WHERE rc.reservation_id = ?%s
+		ORDER BY rc.inventory_item_id`
+
+	reservedComponentsFilterSQL = " AND rc.status = 'reserved'"
+
+	settleComponentsSQL = `UPDATE reservation_components
+		SET status = ?, updated_at = ?
+		WHERE reservation_id = ? AND status = 'reserved'`
+
+	isComponentSQL = `SELECT EXISTS (SELECT 1 FROM bundle_components WHERE component_item_id = ?)`
+
+	// bundleInUseSQL checks for reservations holding components of a bundle, also
+	// while the reservation itself is still being saved
+	bundleInUseSQL = `SELECT EXISTS (SELECT 1 FROM reservation_components WHERE bundle_item_id = ? AND status = 'reserved')
+		OR EXISTS (SELECT 1 FROM reservations WHERE inventory_item_id = ? AND status = 'pending')`
+
+	setBundleSQL = `UPDATE inventory_items
+		SET bundle = ?, version = version + 1, updated_at = ?
+		WHERE id = ?
+		RETURNING *`
+)
+
+// BundleRepositoryImpl is the GORM implementation of BundleRepository
+type BundleRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewBundleRepository creates a new instance of BundleRepositoryImpl
+func NewBundleRepository(db *gorm.DB) *BundleRepositoryImpl {
+	return &BundleRepositoryImpl{
+		db: db,
+	}
+}
+
+// Save makes the bundle's item a bundle of its components, replacing existing ones
+func (r *BundleRepositoryImpl) Save(ctx context.Context, bundle *entity.Bundle) (*entity.InventoryItem, error) {
+	var updated *entity.InventoryItem
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		item, err := lockInventoryItem(tx, bundle.InventoryItemID)
+		if err != nil {
+			return err
+		}
+		if err := item.MakeBundle(); err != nil {
+			return err
+		}
+
+		var isComponent bool
+		if err := tx.Raw(isComponentSQL, item.ID).Scan(&isComponent).Error; err != nil {
+			return fmt.Errorf("failed to check bundle components: %w", err)
+		}
+		if isComponent {
+			return domainErrors.ErrInvalidBundle.WithDetails("the item is a component of another bundle")
+		}
+
+		ids := make([]uuid.UUID, len(bundle.Components))
+		for i, component := range bundle.Components {
+			ids[i] = component.InventoryItemID
+		}
+		items, err := lockItems(tx, ids, "SHARE")
+		if err != nil {
+			return err
+		}
+		for _, component := range bundle.Components {
+			componentItem, ok := items[component.InventoryItemID]
+			if !ok {
+				return domainErrors.ErrInventoryItemNotFound.WithDetails("component " + component.ProductID.String())
+			}
+			if _, err := entity.NewBundleComponent(componentItem, component.Quantity); err != nil {
+				return err
+			}
+		}
+
+		now := time.Now().UTC()
+		if err := tx.Where("bundle_item_id = ?", item.ID).Delete(&model.BundleComponentModel{}).Error; err != nil {
+			return fmt.Errorf("failed to delete bundle components: %w", err)
+		}
+		if err := tx.Create(model.NewBundleComponentModels(bundle, now)).Error; err != nil {
+			return fmt.Errorf("failed to save bundle components: %w", err)
+		}
+
+		updated, err = setBundle(tx, item.ID, true, now)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// FindByInventoryItemID retrieves the bundle sold as the inventory item
+func (r *BundleRepositoryImpl) FindByInventoryItemID(ctx context.Context, inventoryItemID uuid.UUID) (*entity.Bundle, error) {
+	var itemModel model.InventoryItemModel
+	result := r.db.WithContext(ctx).Where("id = ?", inventoryItemID).First(&itemModel)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, domainErrors.ErrBundleNotFound
+		}
+		return nil, fmt.Errorf("failed to find inventory item: %w", result.Error)
+	}
+	if !itemModel.Bundle {
+		return nil, domainErrors.ErrBundleNotFound
+	}
+
+	return findBundle(r.db.WithContext(ctx), itemModel.ToEntity())
+}
+
+// Delete removes the components of the bundle and makes its item hold stock again
+func (r *BundleRepositoryImpl) Delete(ctx context.Context, inventoryItemID uuid.UUID) (*entity.InventoryItem, error) {
+	var updated *entity.InventoryItem
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		item, err := lockInventoryItem(tx, inventoryItemID)
+		if err != nil {
+			return err
+		}
+		if !item.Bundle {
+			return domainErrors.ErrBundleNotFound
+		}
+
+		var inUse bool
+		if err := tx.Raw(bundleInUseSQL, item.ID, item.ID).Scan(&inUse).Error; err != nil {
+			return fmt.Errorf("failed to check bundle reservations: %w", err)
+		}
+		if inUse {
+			return domainErrors.ErrBundleInUse
+		}
+
+		if err := tx.Where("bundle_item_id = ?", item.ID).Delete(&model.BundleComponentModel{}).Error; err != nil {
+			return fmt.Errorf("failed to delete bundle components: %w", err)
+		}
+
+		updated, err = setBundle(tx, item.ID, false, time.Now().UTC())
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// Reserve reserves the units of every component of quantity bundles for the reservation
+func (r *BundleRepositoryImpl) Reserve(ctx context.Context, reservationID, productID uuid.UUID, quantity int) (*repository.BundleStockChange, error) {
+	if quantity <= 0 {
+		return nil, domainErrors.ErrInvalidQuantity
+	}
+
+	var change *repository.BundleStockChange
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		bundleItem, err := lockBundleItem(tx, "product_id = ?", productID)
+		if err != nil {
+			return err
+		}
+		if bundleItem.IsArchived() {
+			return domainErrors.ErrInventoryItemArchived
+		}
+
+		bundle, err := findBundle(tx, bundleItem)
+		if err != nil {
+			return err
+		}
+
+		components := bundle.Reserve(reservationID, quantity, time.Now().UTC())
+		items, err := applyToComponents(tx, components, 0, func(item *entity.InventoryItem, units int) error {
+			return item.Reserve(units)
+		})
+		if err != nil {
+			return err
+		}
+
+		componentModels := make([]*model.ReservationComponentModel, len(components))
+		for i, component := range components {
+			componentModels[i] = model.NewReservationComponentModelFromEntity(component)
+		}
+		if err := tx.Create(&componentModels).Error; err != nil {
+			return fmt.Errorf("failed to save reservation components: %w", err)
+		}
+
+		change = &repository.BundleStockChange{Bundle: bundleItem, Components: components, Items: items}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return change, nil
+}
+
+// Confirm removes the units the reservation holds on the components from their stock
+func (r *BundleRepositoryImpl) Confirm(ctx context.Context, reservationID, inventoryItemID uuid.UUID) (*repository.BundleStockChange, error) {
+	return r.settle(ctx, reservationID, inventoryItemID, entity.ComponentConfirmed, domainErrors.ErrInvalidReservationConfirm, -1,
+		func(item *entity.InventoryItem, units int) error { return item.ConfirmReservation(units) })
+}
+
+// Release returns the units the reservation holds on the components to their available stock
+func (r *BundleRepositoryImpl) Release(ctx context.Context, reservationID, inventoryItemID uuid.UUID) (*repository.BundleStockChange, error) {
+	return r.settle(ctx, reservationID, inventoryItemID, entity.ComponentReleased, domainErrors.ErrInvalidReservationRelease, 0,
+		func(item *entity.InventoryItem, units int) error { return item.ReleaseReservation(units) })
+}
+
+// settle moves the components a reservation holds to status, removes their units
+// from the reserved units of the component items and adds quantitySign times
+// them to their quantity. mismatch is returned when the reservation holds none.
+func (r *BundleRepositoryImpl) settle(
+	ctx context.Context,
+	reservationID, inventoryItemID uuid.UUID,
+	status entity.ReservationComponentStatus,
+	mismatch *domainErrors.DomainError,
+	quantitySign int,
+	apply func(item *entity.InventoryItem, units int) error,
+) (*repository.BundleStockChange, error) {
+	var change *repository.BundleStockChange
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		bundleItem, err := lockBundleItem(tx, "id = ?", inventoryItemID)
+		if err != nil {
+			return err
+		}
+
+		var componentModels []model.ReservationComponentModel
+		query := fmt.Sprintf(reservationComponentsSQL, reservedComponentsFilterSQL) + " FOR UPDATE OF rc"
+		if err := tx.Raw(query, reservationID).Scan(&componentModels).Error; err != nil {
+			return fmt.Errorf("failed to lock reservation components: %w", err)
+		}
+		if len(componentModels) == 0 {
+			return mismatch.WithDetails("the reservation holds no bundle components")
+		}
+		components := toReservationComponents(componentModels)
+
+		items, err := applyToComponents(tx, components, quantitySign, apply)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now().UTC()
+		if err := tx.Exec(settleComponentsSQL, string(status), now, reservationID).Error; err != nil {
+			return fmt.Errorf("failed to update reservation components: %w", err)
+		}
+		for _, component := range components {
+			component.Status = status
+			component.UpdatedAt = now
+		}
+
+		change = &repository.BundleStockChange{Bundle: bundleItem, Components: components, Items: items}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return change, nil
+}
+
+// FindComponents retrieves the components a reservation holds, sold or released
+func (r *BundleRepositoryImpl) FindComponents(ctx context.Context, reservationID uuid.UUID) ([]*entity.ReservationComponent, error) {
+	var componentModels []model.ReservationComponentModel
+	if err := r.db.WithContext(ctx).Raw(fmt.Sprintf(reservationComponentsSQL, ""), reservationID).Scan(&componentModels).Error; err != nil {
+		return nil, fmt.Errorf("failed to find reservation components: %w", err)
+	}
+	return toReservationComponents(componentModels), nil
+}
+
+// applyToComponents locks the component items, checks the change with apply on
+// every one of them and writes it: the units of each component are added to
+// reserved when quantitySign is 0 (a reservation), and otherwise removed from
+// reserved and added quantitySign times to quantity. Returns the items as stored
+// afterwards, in components order.
+func applyToComponents(
+	tx *gorm.DB,
+	components []*entity.ReservationComponent,
+	quantitySign int,
+	apply func(item *entity.InventoryItem, units int) error,
+) ([]*entity.InventoryItem, error) {
+	ids := make([]uuid.UUID, len(components))
+	for i, component := range components {
+		ids[i] = component.InventoryItemID
+	}
+	locked, err := lockItems(tx, ids, "UPDATE")
+	if err != nil {
+		return nil, err
+	}
+
+	reservedSign := -1
+	if quantitySign == 0 {
+		reservedSign = 1
+	}
+
+	items := make([]*entity.InventoryItem, len(components))
+	for i, component := range components {
+		item, ok := locked[component.InventoryItemID]
+		if !ok {
+			return nil, domainErrors.ErrInventoryItemNotFound.WithDetails("component " + component.ProductID.String())
+		}
+		if err := apply(item, component.Quantity); err != nil {
+			return nil, componentError(err, component.ProductID)
+		}
+
+		items[i], err = adjustItemStock(tx, item.ID, quantitySign*component.Quantity, reservedSign*component.Quantity)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return items, nil
+}
+
+// componentError names the component a domain error is about in its details
+func componentError(err error, productID uuid.UUID) error {
+	var domainErr *domainErrors.DomainError
+	if !errors.As(err, &domainErr) {
+		return err
+	}
+
+	details := "component " + productID.String()
+	if domainErr.Details != "" {
+		details += ": " + domainErr.Details
+	}
+	return domainErr.WithDetails(details)
+}
+
+// lockBundleItem share-locks the inventory item matching where for the rest of
+// the transaction and checks it is a bundle
+func lockBundleItem(tx *gorm.DB, where string, key uuid.UUID) (*entity.InventoryItem, error) {
+	var itemModel model.InventoryItemModel
+
+	result := tx.Clauses(clause.Locking{Strength: "SHARE"}).Where(where, key).First(&itemModel)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, domainErrors.ErrInventoryItemNotFound
+		}
+		return nil, fmt.Errorf("failed to lock inventory item: %w", result.Error)
+	}
+	if !itemModel.Bundle {
+		return nil, domainErrors.ErrBundleNotFound
+	}
+	return itemModel.ToEntity(), nil
+}
+
+// lockItems locks the inventory items with the given IDs in ID order
+func lockItems(tx *gorm.DB, ids []uuid.UUID, strength string) (map[uuid.UUID]*entity.InventoryItem, error) {
+	var itemModels []model.InventoryItemModel
+	result := tx.Clauses(clause.Locking{Strength: strength}).
+		Where("id IN ?", ids).
+		Order("id").
+		Find(&itemModels)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to lock inventory items: %w", result.Error)
+	}
+
+	items := make(map[uuid.UUID]*entity.InventoryItem, len(itemModels))
+	for i := range itemModels {
+		items[itemModels[i].ID] = itemModels[i].ToEntity()
+	}
+	return items, nil
+}
+
+// findBundle reads the components of a bundle's item
+func findBundle(tx *gorm.DB, item *entity.InventoryItem) (*entity.Bundle, error) {
+	var componentModels []model.BundleComponentModel
+	if err := tx.Raw(bundleComponentsSQL, item.ID).Scan(&componentModels).Error; err != nil {
+		return nil, fmt.Errorf("failed to find bundle components: %w", err)
+	}
+
+	components := make([]entity.BundleComponent, len(componentModels))
+	for i := range componentModels {
+		components[i] = componentModels[i].ToEntity()
+	}
+	return &entity.Bundle{
+		InventoryItemID: item.ID,
+		ProductID:       item.ProductID,
+		Components:      components,
+	}, nil
+}
+
+// setBundle sets the bundle flag of a locked item and returns the item as stored afterwards
+func setBundle(tx *gorm.DB, inventoryItemID uuid.UUID, bundle bool, at time.Time) (*entity.InventoryItem, error) {
+	var updated model.InventoryItemModel
+	if err := tx.Raw(setBundleSQL, bundle, at, inventoryItemID).Scan(&updated).Error; err != nil {
+		return nil, fmt.Errorf("failed to update inventory item: %w", err)
+	}
+	return updated.ToEntity(), nil
+}
+
+func toReservationComponents(componentModels []model.ReservationComponentModel) []*entity.ReservationComponent {
+	components := make([]*entity.ReservationComponent, len(componentModels))
+	for i := range componentModels {
+		components[i] = componentModels[i].ToEntity()
+	}
+	return components
+}
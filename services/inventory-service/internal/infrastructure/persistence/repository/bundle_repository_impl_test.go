@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	domainErrors "github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+)
+
+// insertBundle writes a bundle of one unit of first and two of second
+func insertBundle(t *testing.T, db *gorm.DB, first, second *entity.InventoryItem) *entity.InventoryItem {
+	item := insertLotItem(t, db, 0)
+	firstComponent, err := entity.NewBundleComponent(first, 1)
+	require.NoError(t, err)
+	secondComponent, err := entity.NewBundleComponent(second, 2)
+	require.NoError(t, err)
+	bundle, err := entity.NewBundle(item, []entity.BundleComponent{firstComponent, secondComponent})
+	require.NoError(t, err)
+
+	saved, err := NewBundleRepository(db).Save(context.Background(), bundle)
+	require.NoError(t, err)
+	return saved
+}
+
+func TestBundleRepositoryImpl_SaveFindDelete(t *testing.T) {
+	db, cleanup := setupMigratedTestDB(t)
+	defer cleanup()
+
+	repo := NewBundleRepository(db)
+	ctx := context.Background()
+	first := insertLotItem(t, db, 10)
+	second := insertLotItem(t, db, 10)
+
+	item := insertBundle(t, db, first, second)
+	assert.True(t, item.Bundle)
+
+	bundle, err := repo.FindByInventoryItemID(ctx, item.ID)
+	require.NoError(t, err)
+	require.Len(t, bundle.Components, 2)
+	quantities := map[uuid.UUID]int{}
+	for _, component := range bundle.Components {
+		quantities[component.ProductID] = component.Quantity
+	}
+	assert.Equal(t, map[uuid.UUID]int{first.ProductID: 1, second.ProductID: 2}, quantities)
+
+	_, err = repo.FindByInventoryItemID(ctx, first.ID)
+	assert.ErrorIs(t, err, domainErrors.ErrBundleNotFound)
+
+	nested, err := entity.NewBundle(first, []entity.BundleComponent{{InventoryItemID: second.ID, ProductID: second.ProductID, Quantity: 1}})
+	require.NoError(t, err)
+	_, err = repo.Save(ctx, nested)
+	assert.ErrorIs(t, err, domainErrors.ErrBundleChange, "items with stock cannot become bundles")
+
+	empty := insertLotItem(t, db, 0)
+	nested, err = entity.NewBundle(empty, []entity.BundleComponent{{InventoryItemID: item.ID, ProductID: item.ProductID, Quantity: 1}})
+	require.NoError(t, err)
+	_, err = repo.Save(ctx, nested)
+	assert.ErrorIs(t, err, domainErrors.ErrInvalidBundle, "bundles cannot be components")
+
+	_, err = repo.Reserve(ctx, uuid.New(), item.ProductID, 1)
+	require.NoError(t, err)
+	_, err = repo.Delete(ctx, item.ID)
+	assert.ErrorIs(t, err, domainErrors.ErrBundleInUse)
+
+	require.NoError(t, db.Exec("UPDATE reservation_components SET status = 'released'").Error)
+	deleted, err := repo.Delete(ctx, item.ID)
+	require.NoError(t, err)
+	assert.False(t, deleted.Bundle)
+	_, err = repo.FindByInventoryItemID(ctx, item.ID)
+	assert.ErrorIs(t, err, domainErrors.ErrBundleNotFound)
+}
+
+func TestBundleRepositoryImpl_ReserveConfirmRelease(t *testing.T) {
+	db, cleanup := setupMigratedTestDB(t)
+	defer cleanup()
+
+	repo := NewBundleRepository(db)
+	ctx := context.Background()
+	first := insertLotItem(t, db, 10)
+	second := insertLotItem(t, db, 5)
+	item := insertBundle(t, db, first, second)
+
+	confirmed := uuid.New()
+	change, err := repo.Reserve(ctx, confirmed, item.ProductID, 2)
+	require.NoError(t, err)
+	require.Len(t, change.Components, 2)
+	require.Len(t, change.Items, 2)
+	for i, component := range change.Components {
+		assert.Equal(t, component.InventoryItemID, change.Items[i].ID)
+	}
+	stock := componentStock(t, db, first, second)
+	assert.Equal(t, [2]int{2, 4}, stock.reserved)
+
+	_, err = repo.Reserve(ctx, uuid.New(), item.ProductID, 1)
+	assert.ErrorIs(t, err, domainErrors.ErrInsufficientStock)
+	assert.Contains(t, err.Error(), second.ProductID.String())
+	assert.Equal(t, stock, componentStock(t, db, first, second), "failed reservations reserve nothing")
+
+	change, err = repo.Confirm(ctx, confirmed, item.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.ComponentConfirmed, change.Components[0].Status)
+	stock = componentStock(t, db, first, second)
+	assert.Equal(t, [2]int{8, 1}, stock.quantity)
+	assert.Equal(t, [2]int{0, 0}, stock.reserved)
+
+	_, err = repo.Confirm(ctx, confirmed, item.ID)
+	assert.ErrorIs(t, err, domainErrors.ErrInvalidReservationConfirm)
+
+	released := uuid.New()
+	_, err = repo.Reserve(ctx, released, item.ProductID, 1)
+	assert.ErrorIs(t, err, domainErrors.ErrInsufficientStock)
+	require.NoError(t, db.Exec("UPDATE inventory_items SET quantity = 5 WHERE id = ?", second.ID).Error)
+	_, err = repo.Reserve(ctx, released, item.ProductID, 1)
+	require.NoError(t, err)
+	_, err = repo.Release(ctx, released, item.ID)
+	require.NoError(t, err)
+	stock = componentStock(t, db, first, second)
+	assert.Equal(t, [2]int{8, 5}, stock.quantity)
+	assert.Equal(t, [2]int{0, 0}, stock.reserved)
+
+	_, err = repo.Release(ctx, released, item.ID)
+	assert.ErrorIs(t, err, domainErrors.ErrInvalidReservationRelease)
+
+	components, err := repo.FindComponents(ctx, released)
+	require.NoError(t, err)
+	require.Len(t, components, 2)
+	assert.Equal(t, entity.ComponentReleased, components[0].Status)
+}
+
+type bundleComponentStock struct {
+	quantity [2]int
+	reserved [2]int
+}
+
+func componentStock(t *testing.T, db *gorm.DB, first, second *entity.InventoryItem) bundleComponentStock {
+	var stock bundleComponentStock
+	for i, item := range []*entity.InventoryItem{first, second} {
+		stored, err := NewInventoryRepository(db).FindByID(context.Background(), item.ID)
+		require.NoError(t, err)
+		stock.quantity[i] = stored.Quantity
+		stock.reserved[i] = stored.Reserved
+	}
+	return stock
+}
+
+func TestInventoryRepositoryImpl_AtomicStock_Bundle(t *testing.T) {
+	db, cleanup := setupMigratedTestDB(t)
+	defer cleanup()
+
+	repo := NewInventoryRepository(db)
+	ctx := context.Background()
+	item := insertBundle(t, db, insertLotItem(t, db, 10), insertLotItem(t, db, 10))
+
+	_, err := repo.ReserveStock(ctx, item.ProductID, 1)
+	assert.ErrorIs(t, err, domainErrors.ErrBundleItem)
+
+	_, err = repo.AdjustStock(ctx, item.ProductID, 5)
+	assert.ErrorIs(t, err, domainErrors.ErrBundleItem)
+}
@@ -0,0 +1,229 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	domainErrors "github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/infrastructure/persistence/model"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Channel allocation tables, see migration 012. Reservations and changes of the
+// allocations lock the inventory item for update, so the units reserved per
+// channel cannot change while a channel's availability is checked.
+const (
+	// reservedByChannelSQL sums the pending reservations of an item per channel
+	reservedByChannelSQL = `SELECT channel, COALESCE(SUM(quantity), 0) AS quantity
+		FROM reservations
+		WHERE inventory_item_id = ? AND status = 'pending'
+		GROUP BY channel`
+
+	setChannelAllocatedSQL = `UPDATE inventory_items
+		SET channel_allocated = ?, version = version + 1, updated_at = ?
+		WHERE id = ?
+		RETURNING *`
+
+	reserveForChannelSQL = `UPDATE inventory_items
+		SET reserved = ?, version = version + 1, updated_at = ?
+		WHERE id = ?
+		RETURNING *`
+)
+
+// ChannelAllocationRepositoryImpl is the GORM implementation of ChannelAllocationRepository
+type ChannelAllocationRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewChannelAllocationRepository creates a new instance of ChannelAllocationRepositoryImpl
+func NewChannelAllocationRepository(db *gorm.DB) *ChannelAllocationRepositoryImpl {
+	return &ChannelAllocationRepositoryImpl{
+		db: db,
+	}
+}
+
+// FindByInventoryItemID retrieves the allocations of an inventory item, ordered by channel
+func (r *ChannelAllocationRepositoryImpl) FindByInventoryItemID(ctx context.Context, inventoryItemID uuid.UUID) ([]entity.ChannelAllocation, error) {
+	return findChannelAllocations(r.db.WithContext(ctx), inventoryItemID)
+}
+
+// Replace replaces the allocations of an inventory item
+func (r *ChannelAllocationRepositoryImpl) Replace(
+	ctx context.Context,
+	inventoryItemID uuid.UUID,
+	allocations []entity.ChannelAllocation,
+) (*entity.InventoryItem, error) {
+	if err := entity.ValidateChannelAllocations(allocations); err != nil {
+		return nil, err
+	}
+
+	var updated *entity.InventoryItem
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		item, err := lockInventoryItem(tx, inventoryItemID)
+		if err != nil {
+			return err
+		}
+		if item.SerialTracked || item.Bundle {
+			return domainErrors.ErrInvalidAllocation.WithDetails("only stock counted per unit can be allocated to channels")
+		}
+
+		if err := tx.Where("inventory_item_id = ?", item.ID).Delete(&model.ChannelAllocationModel{}).Error; err != nil {
+			return fmt.Errorf("failed to delete channel allocations: %w", err)
+		}
+		if len(allocations) > 0 {
+			allocationModels := make([]*model.ChannelAllocationModel, len(allocations))
+			for i, allocation := range allocations {
+				allocation.InventoryItemID = item.ID
+				allocationModels[i] = model.NewChannelAllocationModel(allocation)
+			}
+			if err := tx.Create(&allocationModels).Error; err != nil {
+				return fmt.Errorf("failed to save channel allocations: %w", err)
+			}
+		}
+
+		var updatedModel model.InventoryItemModel
+		if err := tx.Raw(setChannelAllocatedSQL, len(allocations) > 0, time.Now().UTC(), item.ID).Scan(&updatedModel).Error; err != nil {
+			return fmt.Errorf("failed to update inventory item: %w", err)
+		}
+		updated = updatedModel.ToEntity()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// Rebalance moves allocated units or percentage points from one channel to another
+func (r *ChannelAllocationRepositoryImpl) Rebalance(
+	ctx context.Context,
+	inventoryItemID uuid.UUID,
+	from, to string,
+	quantity int,
+) ([]entity.ChannelAllocation, error) {
+	var moved []entity.ChannelAllocation
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if _, err := lockInventoryItem(tx, inventoryItemID); err != nil {
+			return err
+		}
+
+		allocations, err := findChannelAllocations(tx, inventoryItemID)
+		if err != nil {
+			return err
+		}
+		moved, err = entity.MoveAllocation(allocations, from, to, quantity, time.Now().UTC())
+		if err != nil {
+			return err
+		}
+
+		for i := range moved {
+			if moved[i].UpdatedAt.Equal(allocations[i].UpdatedAt) {
+				continue
+			}
+			if err := tx.Save(model.NewChannelAllocationModel(moved[i])).Error; err != nil {
+				return fmt.Errorf("failed to save channel allocation: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return moved, nil
+}
+
+// ReservedByChannel returns the units held by the pending reservations of an item per channel
+func (r *ChannelAllocationRepositoryImpl) ReservedByChannel(ctx context.Context, inventoryItemID uuid.UUID) (map[string]int, error) {
+	return reservedByChannel(r.db.WithContext(ctx), inventoryItemID)
+}
+
+// Reserve reserves the reservation's units for its channel and saves the reservation
+func (r *ChannelAllocationRepositoryImpl) Reserve(ctx context.Context, reservation *entity.Reservation, productID uuid.UUID) (*entity.InventoryItem, error) {
+	var updated *entity.InventoryItem
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var itemModel model.InventoryItemModel
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("product_id = ?", productID).First(&itemModel)
+		if result.Error != nil {
+			if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+				return domainErrors.ErrInventoryItemNotFound
+			}
+			return fmt.Errorf("failed to lock inventory item: %w", result.Error)
+		}
+		item := itemModel.ToEntity()
+
+		allocations, err := findChannelAllocations(tx, item.ID)
+		if err != nil {
+			return err
+		}
+		reserved, err := reservedByChannel(tx, item.ID)
+		if err != nil {
+			return err
+		}
+
+		available := entity.ChannelAvailable(item, allocations, reserved, reservation.Channel)
+		if err := item.ReserveForChannel(reservation.Quantity, available); err != nil {
+			if errors.Is(err, domainErrors.ErrInsufficientStock) {
+				return domainErrors.ErrInsufficientStock.WithDetails(
+					fmt.Sprintf("channel %q has %d available", reservation.Channel, available))
+			}
+			return err
+		}
+
+		var updatedModel model.InventoryItemModel
+		if err := tx.Raw(reserveForChannelSQL, item.Reserved, time.Now().UTC(), item.ID).Scan(&updatedModel).Error; err != nil {
+			return fmt.Errorf("failed to update inventory item: %w", err)
+		}
+
+		reservation.InventoryItemID = item.ID
+		if err := createReservation(tx, reservation); err != nil {
+			return err
+		}
+
+		updated = updatedModel.ToEntity()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// findChannelAllocations reads the allocations of an item, ordered by channel
+func findChannelAllocations(tx *gorm.DB, inventoryItemID uuid.UUID) ([]entity.ChannelAllocation, error) {
+	var allocationModels []model.ChannelAllocationModel
+	result := tx.Where("inventory_item_id = ?", inventoryItemID).Order("channel").Find(&allocationModels)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to find channel allocations: %w", result.Error)
+	}
+
+	allocations := make([]entity.ChannelAllocation, len(allocationModels))
+	for i := range allocationModels {
+		allocations[i] = allocationModels[i].ToEntity()
+	}
+	return allocations, nil
+}
+
+// reservedByChannel sums the pending reservations of an item per channel
+func reservedByChannel(tx *gorm.DB, inventoryItemID uuid.UUID) (map[string]int, error) {
+	var rows []struct {
+		Channel  string
+		Quantity int
+	}
+	if err := tx.Raw(reservedByChannelSQL, inventoryItemID).Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to sum reservations per channel: %w", err)
+	}
+
+	reserved := make(map[string]int, len(rows))
+	for _, row := range rows {
+		reserved[row.Channel] = row.Quantity
+	}
+	return reserved, nil
+}
@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	domainErrors "github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+)
+
+// allocateChannels gives marketplace a quota of 20 and lets web sell from the shared pool
+func allocateChannels(t *testing.T, db *gorm.DB, item *entity.InventoryItem) *entity.InventoryItem {
+	marketplace, err := entity.NewChannelAllocation(item, "marketplace", entity.AllocationFixed, 20)
+	require.NoError(t, err)
+	web, err := entity.NewChannelAllocation(item, "web", entity.AllocationShared, 0)
+	require.NoError(t, err)
+
+	updated, err := NewChannelAllocationRepository(db).Replace(context.Background(), item.ID, []entity.ChannelAllocation{web, marketplace})
+	require.NoError(t, err)
+	return updated
+}
+
+func channelReservation(t *testing.T, channel string, quantity int) *entity.Reservation {
+	reservation, err := entity.NewReservation(uuid.Nil, uuid.New(), quantity)
+	require.NoError(t, err)
+	reservation.Channel = channel
+	return reservation
+}
+
+func TestChannelAllocationRepositoryImpl_ReplaceFind(t *testing.T) {
+	db, cleanup := setupMigratedTestDB(t)
+	defer cleanup()
+
+	repo := NewChannelAllocationRepository(db)
+	ctx := context.Background()
+	item := insertLotItem(t, db, 100)
+
+	updated := allocateChannels(t, db, item)
+	assert.True(t, updated.ChannelAllocated)
+	assert.Greater(t, updated.Version, item.Version)
+
+	allocations, err := repo.FindByInventoryItemID(ctx, item.ID)
+	require.NoError(t, err)
+	require.Len(t, allocations, 2)
+	assert.Equal(t, "marketplace", allocations[0].Channel)
+	assert.Equal(t, 20, allocations[0].Quota)
+	assert.Equal(t, entity.AllocationShared, allocations[1].Mode)
+
+	_, err = NewInventoryRepository(db).ReserveStock(ctx, item.ProductID, 1)
+	assert.ErrorIs(t, err, domainErrors.ErrChannelAllocatedItem, "allocated items are not reserved from the whole stock")
+
+	updated, err = repo.Replace(ctx, item.ID, nil)
+	require.NoError(t, err)
+	assert.False(t, updated.ChannelAllocated)
+	allocations, err = repo.FindByInventoryItemID(ctx, item.ID)
+	require.NoError(t, err)
+	assert.Empty(t, allocations)
+
+	bundle := insertBundle(t, db, insertLotItem(t, db, 10), insertLotItem(t, db, 10))
+	_, err = repo.Replace(ctx, bundle.ID, []entity.ChannelAllocation{{Channel: "web", Mode: entity.AllocationShared}})
+	assert.ErrorIs(t, err, domainErrors.ErrInvalidAllocation)
+
+	_, err = repo.Replace(ctx, uuid.New(), nil)
+	assert.ErrorIs(t, err, domainErrors.ErrInventoryItemNotFound)
+}
+
+func TestChannelAllocationRepositoryImpl_Reserve(t *testing.T) {
+	db, cleanup := setupMigratedTestDB(t)
+	defer cleanup()
+
+	repo := NewChannelAllocationRepository(db)
+	ctx := context.Background()
+	item := allocateChannels(t, db, insertLotItem(t, db, 30))
+
+	reservation := channelReservation(t, "marketplace", 15)
+	updated, err := repo.Reserve(ctx, reservation, item.ProductID)
+	require.NoError(t, err)
+	assert.Equal(t, 15, updated.Reserved)
+	assert.Equal(t, item.ID, reservation.InventoryItemID)
+
+	stored, err := NewReservationRepository(db).FindByID(ctx, reservation.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "marketplace", stored.Channel)
+
+	_, err = repo.Reserve(ctx, channelReservation(t, "marketplace", 6), item.ProductID)
+	assert.ErrorIs(t, err, domainErrors.ErrInsufficientStock)
+	assert.Contains(t, err.Error(), "marketplace")
+
+	// 5 units stay set aside for marketplace, 10 are left in the shared pool
+	_, err = repo.Reserve(ctx, channelReservation(t, "web", 11), item.ProductID)
+	assert.ErrorIs(t, err, domainErrors.ErrInsufficientStock)
+	_, err = repo.Reserve(ctx, channelReservation(t, entity.SharedPool, 10), item.ProductID)
+	require.NoError(t, err)
+
+	reserved, err := repo.ReservedByChannel(ctx, item.ID)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"marketplace": 15, entity.SharedPool: 10}, reserved)
+
+	duplicate := channelReservation(t, "marketplace", 1)
+	duplicate.OrderID = reservation.OrderID
+	_, err = repo.Reserve(ctx, duplicate, item.ProductID)
+	assert.ErrorIs(t, err, domainErrors.ErrReservationAlreadyExists)
+	current, err := NewInventoryRepository(db).FindByID(ctx, item.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 25, current.Reserved, "failed reservations reserve nothing")
+
+	_, err = repo.Reserve(ctx, channelReservation(t, "web", 1), uuid.New())
+	assert.ErrorIs(t, err, domainErrors.ErrInventoryItemNotFound)
+}
+
+func TestChannelAllocationRepositoryImpl_Rebalance(t *testing.T) {
+	db, cleanup := setupMigratedTestDB(t)
+	defer cleanup()
+
+	repo := NewChannelAllocationRepository(db)
+	ctx := context.Background()
+	item := allocateChannels(t, db, insertLotItem(t, db, 100))
+
+	moved, err := repo.Rebalance(ctx, item.ID, "marketplace", entity.SharedPool, 5)
+	require.NoError(t, err)
+	assert.Equal(t, 15, moved[0].Quota)
+
+	allocations, err := repo.FindByInventoryItemID(ctx, item.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 15, allocations[0].Quota)
+
+	_, err = repo.Rebalance(ctx, item.ID, "web", "marketplace", 1)
+	assert.ErrorIs(t, err, domainErrors.ErrInvalidAllocation)
+}
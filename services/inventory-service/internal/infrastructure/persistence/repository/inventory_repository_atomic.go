@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	domainErrors "github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/infrastructure/persistence/model"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Conditional stock statements. The WHERE clause carries the invariant, so a
+// concurrent writer can never push the row into an invalid state and no version
+// check is needed; version is still bumped for optimistic-lock readers.
+// Serial-tracked items and bundles are excluded: their stock only changes through
+// the serial unit and bundle repositories, and the entity rules report
+// ErrSerialTrackedItem and ErrBundleItem for them. Items with channel allocations
+// are only reserved through the channel allocation repository.
+const (
+	reserveStockSQL = `UPDATE inventory_items
+		SET reserved = reserved + ?, version = version + 1, updated_at = ?
+		WHERE product_id = ? AND quantity - reserved >= ? AND archived_at IS NULL AND NOT serial_tracked AND NOT bundle
+			AND NOT channel_allocated
+		RETURNING *`
+
+	releaseStockSQL = `UPDATE inventory_items
+		SET reserved = reserved - ?, version = version + 1, updated_at = ?
+		WHERE id = ? AND reserved >= ? AND NOT serial_tracked AND NOT bundle
+		RETURNING *`
+
+	confirmStockSQL = `UPDATE inventory_items
+		SET reserved = reserved - ?, quantity = quantity - ?, version = version + 1, updated_at = ?
+		WHERE id = ? AND reserved >= ? AND quantity >= ? AND NOT serial_tracked AND NOT bundle
+		RETURNING *`
+
+	adjustStockSQL = `UPDATE inventory_items
+		SET quantity = quantity + ?, version = version + 1, updated_at = ?
+		WHERE product_id = ? AND quantity + ? >= reserved AND NOT serial_tracked AND NOT bundle
+		RETURNING *`
+)
+
+// ReserveStock increments reserved in a single statement when enough stock is available
+func (r *InventoryRepositoryImpl) ReserveStock(ctx context.Context, productID uuid.UUID, quantity int) (*entity.InventoryItem, error) {
+	if quantity <= 0 {
+		return nil, domainErrors.ErrInvalidQuantity
+	}
+
+	return r.conditionalUpdate(ctx, "product_id = ?", productID,
+		func(item *entity.InventoryItem) error { return item.Reserve(quantity) },
+		reserveStockSQL, quantity, time.Now().UTC(), productID, quantity)
+}
+
+// ReleaseStock decrements reserved in a single statement
+func (r *InventoryRepositoryImpl) ReleaseStock(ctx context.Context, id uuid.UUID, quantity int) (*entity.InventoryItem, error) {
+	if quantity <= 0 {
+		return nil, domainErrors.ErrInvalidQuantity
+	}
+
+	return r.conditionalUpdate(ctx, "id = ?", id,
+		func(item *entity.InventoryItem) error { return item.ReleaseReservation(quantity) },
+		releaseStockSQL, quantity, time.Now().UTC(), id, quantity)
+}
+
+// ConfirmStock decrements reserved and quantity in a single statement
+func (r *InventoryRepositoryImpl) ConfirmStock(ctx context.Context, id uuid.UUID, quantity int) (*entity.InventoryItem, error) {
+	if quantity <= 0 {
+		return nil, domainErrors.ErrInvalidQuantity
+	}
+
+	return r.conditionalUpdate(ctx, "id = ?", id,
+		func(item *entity.InventoryItem) error { return item.ConfirmReservation(quantity) },
+		confirmStockSQL, quantity, quantity, time.Now().UTC(), id, quantity, quantity)
+}
+
+// AdjustStock adds delta to quantity in a single statement without dropping below reserved
+func (r *InventoryRepositoryImpl) AdjustStock(ctx context.Context, productID uuid.UUID, delta int) (*entity.InventoryItem, error) {
+	if delta == 0 {
+		return nil, domainErrors.ErrInvalidQuantity
+	}
+
+	return r.conditionalUpdate(ctx, "product_id = ?", productID,
+		func(item *entity.InventoryItem) error {
+			if delta > 0 {
+				return item.AddStock(delta)
+			}
+			return item.DecrementStock(-delta)
+		},
+		adjustStockSQL, delta, time.Now().UTC(), productID, delta)
+}
+
+// conditionalUpdate runs an UPDATE ... RETURNING statement. When the guard rejects
+// the change it re-reads the row (located by where/key) and lets the entity rules
+// explain why, so callers get the same domain errors as the read-modify-write path.
+func (r *InventoryRepositoryImpl) conditionalUpdate(
+	ctx context.Context,
+	where string,
+	key uuid.UUID,
+	explain func(*entity.InventoryItem) error,
+	query string,
+	args ...interface{},
+) (*entity.InventoryItem, error) {
+	var updated model.InventoryItemModel
+	if err := r.db.WithContext(ctx).Raw(query, args...).Scan(&updated).Error; err != nil {
+		return nil, fmt.Errorf("failed to update inventory item: %w", err)
+	}
+	if updated.ID != uuid.Nil {
+		return updated.ToEntity(), nil
+	}
+
+	var current model.InventoryItemModel
+	if err := r.db.WithContext(ctx).Where(where, key).First(&current).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domainErrors.ErrInventoryItemNotFound
+		}
+		return nil, fmt.Errorf("failed to find inventory item: %w", err)
+	}
+
+	if err := explain(current.ToEntity()); err != nil {
+		return nil, err
+	}
+	// The row satisfied the guard by the time it was re-read: another writer
+	// changed it in between, so report it like a lost version race and let the
+	// caller retry
+	return nil, domainErrors.ErrOptimisticLockFailure
+}
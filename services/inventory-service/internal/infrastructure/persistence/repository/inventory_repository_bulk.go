@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	domainErrors "github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+	domainRepository "github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/repository"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/infrastructure/persistence/model"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// UpdateQuantities saves the quantity of every item in one transaction with optimistic locking
+func (r *InventoryRepositoryImpl) UpdateQuantities(ctx context.Context, items []*entity.InventoryItem) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for i, item := range items {
+			result := tx.Model(&model.InventoryItemModel{}).
+				Where("id = ? AND version = ?", item.ID, item.Version).
+				Updates(map[string]interface{}{
+					"quantity":   item.Quantity,
+					"version":    gorm.Expr("version + 1"),
+					"updated_at": item.UpdatedAt,
+				})
+			if result.Error != nil {
+				return &domainRepository.BulkUpdateError{Index: i, Err: result.Error}
+			}
+			if result.RowsAffected == 0 {
+				// Deleted items surface as conflicts too; the caller re-reads them
+				return &domainRepository.BulkUpdateError{Index: i, Err: domainErrors.ErrOptimisticLockFailure}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		item.Version++
+	}
+	return nil
+}
+
+// StreamAll calls fn for every inventory item ordered by product ID using keyset pagination
+func (r *InventoryRepositoryImpl) StreamAll(ctx context.Context, batchSize int, fn func(item *entity.InventoryItem) error) error {
+	if batchSize <= 0 {
+		batchSize = 500
+	}
+
+	after := uuid.Nil
+	for {
+		var itemModels []model.InventoryItemModel
+		result := r.db.WithContext(ctx).
+			Where("product_id > ?", after).
+			Order("product_id").
+			Limit(batchSize).
+			Find(&itemModels)
+		if result.Error != nil {
+			return fmt.Errorf("failed to stream inventory items: %w", result.Error)
+		}
+
+		for _, itemModel := range itemModels {
+			if err := fn(itemModel.ToEntity()); err != nil {
+				return err
+			}
+		}
+
+		if len(itemModels) < batchSize {
+			return nil
+		}
+		after = itemModels[len(itemModels)-1].ProductID
+	}
+}
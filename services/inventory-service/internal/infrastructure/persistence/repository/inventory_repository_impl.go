@@ -96,11 +96,16 @@ func (r *InventoryRepositoryImpl) Update(ctx context.Context, item *entity.Inven
 		Model(&model.InventoryItemModel{}).
 		Where("id = ? AND version = ?", item.ID, item.Version).
 		Updates(map[string]interface{}{
-			"product_id": itemModel.ProductID,
-			"quantity":   itemModel.Quantity,
-			"reserved":   itemModel.Reserved,
-			"version":    gorm.Expr("version + 1"),
-			"updated_at": itemModel.UpdatedAt,
+			"product_id":         itemModel.ProductID,
+			"quantity":           itemModel.Quantity,
+			"reserved":           itemModel.Reserved,
+			"version":            gorm.Expr("version + 1"),
+			"updated_at":         itemModel.UpdatedAt,
+			"archived_at":        itemModel.ArchivedAt,
+			"catalog_changed_at": itemModel.CatalogChangedAt,
+			"serial_tracked":     itemModel.SerialTracked,
+			"bundle":             itemModel.Bundle,
+			"channel_allocated":  itemModel.ChannelAllocated,
 		})
 
 	if result.Error != nil {
@@ -2,11 +2,13 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
 	domainErrors "github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+	domainRepository "github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/repository"
 	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/infrastructure/persistence/model"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -253,6 +255,22 @@ func TestInventoryRepositoryImpl_Update(t *testing.T) {
 	err = repo.Update(ctx, nonExistingItem)
 	assert.Error(t, err)
 	assert.Equal(t, domainErrors.ErrInventoryItemNotFound, err)
+
+	// Test: Archive and restore are persisted
+	item.Archive(time.Now().UTC())
+	require.NoError(t, repo.Update(ctx, item))
+	found, err = repo.FindByID(ctx, item.ID)
+	require.NoError(t, err)
+	assert.True(t, found.IsArchived())
+
+	_, err = repo.ReserveStock(ctx, item.ProductID, 1)
+	assert.Equal(t, domainErrors.ErrInventoryItemArchived, err, "archived items cannot be reserved atomically")
+
+	found.Restore()
+	require.NoError(t, repo.Update(ctx, found))
+	found, err = repo.FindByID(ctx, item.ID)
+	require.NoError(t, err)
+	assert.False(t, found.IsArchived())
 }
 
 func TestInventoryRepositoryImpl_Delete(t *testing.T) {
@@ -533,3 +551,78 @@ func TestInventoryRepositoryImpl_IncrementVersion(t *testing.T) {
 	assert.Error(t, err)
 	assert.Equal(t, domainErrors.ErrInventoryItemNotFound, err)
 }
+
+func TestInventoryRepositoryImpl_UpdateQuantities(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewInventoryRepository(db)
+	ctx := context.Background()
+
+	first, _ := entity.NewInventoryItem(uuid.New(), 10)
+	second, _ := entity.NewInventoryItem(uuid.New(), 20)
+	require.NoError(t, repo.Save(ctx, first))
+	require.NoError(t, repo.Save(ctx, second))
+
+	t.Run("should update every item and increment versions", func(t *testing.T) {
+		first.Quantity, second.Quantity = 15, 25
+
+		err := repo.UpdateQuantities(ctx, []*entity.InventoryItem{first, second})
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, first.Version)
+		found, err := repo.FindByID(ctx, second.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 25, found.Quantity)
+		assert.Equal(t, 2, found.Version)
+	})
+
+	t.Run("should roll back the batch on a version conflict", func(t *testing.T) {
+		stale := *second
+		stale.Version = 1
+		first.Quantity, stale.Quantity = 99, 99
+
+		err := repo.UpdateQuantities(ctx, []*entity.InventoryItem{first, &stale})
+
+		var bulkErr *domainRepository.BulkUpdateError
+		require.ErrorAs(t, err, &bulkErr)
+		assert.Equal(t, 1, bulkErr.Index)
+		assert.ErrorIs(t, err, domainErrors.ErrOptimisticLockFailure)
+		found, err := repo.FindByID(ctx, first.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 15, found.Quantity, "first item is rolled back")
+		assert.Equal(t, 2, first.Version, "versions are unchanged on failure")
+	})
+}
+
+func TestInventoryRepositoryImpl_StreamAll(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewInventoryRepository(db)
+	ctx := context.Background()
+
+	for i := 0; i < 5; i++ {
+		item, _ := entity.NewInventoryItem(uuid.New(), i)
+		require.NoError(t, repo.Save(ctx, item))
+	}
+
+	var productIDs []string
+	err := repo.StreamAll(ctx, 2, func(item *entity.InventoryItem) error {
+		productIDs = append(productIDs, item.ProductID.String())
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Len(t, productIDs, 5)
+	assert.IsIncreasing(t, productIDs, "items are streamed in product ID order")
+
+	stop := errors.New("stop")
+	calls := 0
+	err = repo.StreamAll(ctx, 2, func(item *entity.InventoryItem) error {
+		calls++
+		return stop
+	})
+	assert.ErrorIs(t, err, stop)
+	assert.Equal(t, 1, calls)
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	domainRepository "github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/repository"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/infrastructure/persistence/model"
+	"gorm.io/gorm"
+)
+
+// ListItems returns a page of inventory items using keyset pagination
+func (r *InventoryRepositoryImpl) ListItems(ctx context.Context, filter domainRepository.InventoryListFilter) ([]*entity.InventoryItem, error) {
+	query := applyKeyset(r.inventoryListQuery(ctx, filter), inventorySortColumn(filter.SortBy), filter.Direction, filter.After, filter.Limit)
+
+	var itemModels []model.InventoryItemModel
+	if err := query.Find(&itemModels).Error; err != nil {
+		return nil, fmt.Errorf("failed to list inventory items: %w", err)
+	}
+
+	items := make([]*entity.InventoryItem, len(itemModels))
+	for i, itemModel := range itemModels {
+		items[i] = itemModel.ToEntity()
+	}
+
+	return items, nil
+}
+
+// CountItems counts the inventory items matching the filter
+func (r *InventoryRepositoryImpl) CountItems(ctx context.Context, filter domainRepository.InventoryListFilter) (int64, error) {
+	var count int64
+	if err := r.inventoryListQuery(ctx, filter).Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count inventory items: %w", err)
+	}
+	return count, nil
+}
+
+// EstimateItems returns the planner's estimate of the inventory items matching the filter
+func (r *InventoryRepositoryImpl) EstimateItems(ctx context.Context, filter domainRepository.InventoryListFilter) (int64, error) {
+	count, err := estimateRows(ctx, r.db, r.inventoryListQuery(ctx, filter), &[]model.InventoryItemModel{})
+	if err != nil {
+		return 0, fmt.Errorf("failed to estimate inventory items: %w", err)
+	}
+	return count, nil
+}
+
+// inventoryListQuery applies the filters of a listing, without cursor or order
+func (r *InventoryRepositoryImpl) inventoryListQuery(ctx context.Context, filter domainRepository.InventoryListFilter) *gorm.DB {
+	query := r.db.WithContext(ctx).Model(&model.InventoryItemModel{})
+
+	if len(filter.ProductIDs) > 0 {
+		query = query.Where("product_id IN ?", filter.ProductIDs)
+	}
+	if filter.Archived != nil {
+		if *filter.Archived {
+			query = query.Where("archived_at IS NOT NULL")
+		} else {
+			query = query.Where("archived_at IS NULL")
+		}
+	}
+	if filter.LowStockBelow > 0 {
+		// Matches the idx_inventory_available expression index
+		query = query.Where("(quantity - reserved) < ?", filter.LowStockBelow)
+	}
+	if !filter.CreatedFrom.IsZero() {
+		query = query.Where("created_at >= ?", filter.CreatedFrom.UTC())
+	}
+	if !filter.CreatedTo.IsZero() {
+		query = query.Where("created_at < ?", filter.CreatedTo.UTC())
+	}
+
+	return query
+}
+
+// inventorySortColumn maps a sort field to its column
+func inventorySortColumn(field domainRepository.InventorySortField) string {
+	switch field {
+	case domainRepository.InventorySortUpdatedAt:
+		return "updated_at"
+	default:
+		return "created_at"
+	}
+}
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	domainRepository "github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+// applyKeyset orders query by (column, id) and continues after the cursor.
+// column must be a trusted column name, never user input; listings map their
+// sort fields to columns with a switch. Each sort column has a matching
+// (column, id) index, see migration 007.
+func applyKeyset(query *gorm.DB, column string, direction domainRepository.SortDirection, after *domainRepository.KeysetCursor, limit int) *gorm.DB {
+	comparison, order := ">", "ASC"
+	if direction == domainRepository.SortDescending {
+		comparison, order = "<", "DESC"
+	}
+
+	if after != nil {
+		query = query.Where(fmt.Sprintf("(%s, id) %s (?, ?)", column, comparison), after.SortValue.UTC(), after.ID)
+	}
+	query = query.Order(fmt.Sprintf("%s %s, id %s", column, order, order))
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	return query
+}
+
+// explainPlan is the part of EXPLAIN (FORMAT JSON) output read by estimateRows
+type explainPlan struct {
+	Plan struct {
+		PlanRows float64 `json:"Plan Rows"`
+	} `json:"Plan"`
+}
+
+// estimateRows returns the planner's row estimate for query without running it.
+// The estimate comes from table statistics, so it is approximate and may lag
+// behind recent writes until the next ANALYZE.
+func estimateRows(ctx context.Context, db *gorm.DB, query *gorm.DB, dest interface{}) (int64, error) {
+	stmt := query.Session(&gorm.Session{DryRun: true}).Find(dest).Statement
+
+	var raw string
+	if err := db.WithContext(ctx).Raw("EXPLAIN (FORMAT JSON) "+stmt.SQL.String(), stmt.Vars...).Row().Scan(&raw); err != nil {
+		return 0, err
+	}
+
+	var plans []explainPlan
+	if err := json.Unmarshal([]byte(raw), &plans); err != nil {
+		return 0, fmt.Errorf("unexpected EXPLAIN output: %w", err)
+	}
+	if len(plans) == 0 {
+		return 0, fmt.Errorf("unexpected EXPLAIN output: no plan")
+	}
+	return int64(plans[0].Plan.PlanRows), nil
+}
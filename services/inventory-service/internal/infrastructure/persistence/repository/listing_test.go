@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	domainRepository "github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/repository"
+)
+
+func TestInventoryRepositoryImpl_ListItems(t *testing.T) {
+	db, cleanup := setupMigratedTestDB(t)
+	defer cleanup()
+
+	repo := NewInventoryRepository(db)
+	ctx := context.Background()
+	base := time.Now().UTC().Truncate(time.Second)
+
+	// Five items sharing two timestamps, so ties are broken by ID
+	var ids []uuid.UUID
+	for i := 0; i < 5; i++ {
+		id := uuid.New()
+		createdAt := base.Add(time.Duration(i/2) * time.Minute)
+		require.NoError(t, db.Exec(`INSERT INTO inventory_items (id, product_id, quantity, reserved, version, created_at, updated_at)
+			VALUES (?, ?, ?, 0, 1, ?, ?)`, id, uuid.New(), i*10, createdAt, createdAt).Error)
+		ids = append(ids, id)
+	}
+	require.NoError(t, db.Exec(`UPDATE inventory_items SET archived_at = NOW() WHERE id = ?`, ids[4]).Error)
+
+	// Walk every page of two
+	filter := domainRepository.InventoryListFilter{
+		SortBy:    domainRepository.InventorySortCreatedAt,
+		Direction: domainRepository.SortAscending,
+		Limit:     2,
+	}
+	var seen []uuid.UUID
+	for {
+		page, err := repo.ListItems(ctx, filter)
+		require.NoError(t, err)
+		for _, item := range page {
+			seen = append(seen, item.ID)
+		}
+		if len(page) < filter.Limit {
+			break
+		}
+		last := page[len(page)-1]
+		filter.After = &domainRepository.KeysetCursor{SortValue: last.CreatedAt, ID: last.ID}
+	}
+	assert.ElementsMatch(t, ids, seen)
+	assert.Len(t, seen, 5, "no item repeated or skipped")
+
+	// Filters
+	active := false
+	filter = domainRepository.InventoryListFilter{Archived: &active, LowStockBelow: 25, Limit: 10}
+	items, err := repo.ListItems(ctx, filter)
+	require.NoError(t, err)
+	assert.Len(t, items, 3)
+
+	count, err := repo.CountItems(ctx, filter)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+
+	_, err = repo.EstimateItems(ctx, filter)
+	assert.NoError(t, err)
+}
+
+func TestReservationRepositoryImpl_ListReservations(t *testing.T) {
+	db, cleanup := setupMigratedTestDB(t)
+	defer cleanup()
+
+	repo := NewReservationRepository(db)
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	productID := uuid.New()
+	itemID := uuid.New()
+	otherItemID := uuid.New()
+	for id, product := range map[uuid.UUID]uuid.UUID{itemID: productID, otherItemID: uuid.New()} {
+		require.NoError(t, db.Exec(`INSERT INTO inventory_items (id, product_id, quantity, reserved, version, created_at, updated_at)
+			VALUES (?, ?, 100, 0, 1, ?, ?)`, id, product, now, now).Error)
+	}
+
+	first := insertReservationRow(t, db, itemID, entity.ReservationPending, now.Add(-2*time.Hour), now)
+	second := insertReservationRow(t, db, itemID, entity.ReservationPending, now.Add(-time.Hour), now)
+	insertReservationRow(t, db, itemID, entity.ReservationConfirmed, now, now)
+	insertReservationRow(t, db, otherItemID, entity.ReservationPending, now, now)
+
+	filter := domainRepository.ReservationListFilter{
+		Status:    entity.ReservationPending,
+		ProductID: productID,
+		SortBy:    domainRepository.ReservationSortExpiresAt,
+		Direction: domainRepository.SortDescending,
+		Limit:     1,
+	}
+	page, err := repo.ListReservations(ctx, filter)
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	assert.Equal(t, second, page[0].ID)
+
+	filter.After = &domainRepository.KeysetCursor{SortValue: page[0].ExpiresAt, ID: page[0].ID}
+	page, err = repo.ListReservations(ctx, filter)
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	assert.Equal(t, first, page[0].ID)
+
+	count, err := repo.CountReservations(ctx, filter)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count, "the cursor does not change the total")
+
+	_, err = repo.EstimateReservations(ctx, filter)
+	assert.NoError(t, err)
+}
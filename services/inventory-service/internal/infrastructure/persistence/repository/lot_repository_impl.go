@@ -0,0 +1,358 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	domainErrors "github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/repository"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/infrastructure/persistence/model"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Lot tables, see migration 009. Every transaction that touches both locks the
+// inventory item before its lots so receiving, allocating and quarantining cannot
+// deadlock each other.
+const (
+	fefoOrderSQL = "best_before ASC NULLS LAST, received_at ASC, lot_number ASC"
+
+	receiveLotStockSQL = `UPDATE inventory_items
+		SET quantity = quantity + ?, version = version + 1, updated_at = ?
+		WHERE id = ?
+		RETURNING *`
+
+	quarantineLotStockSQL = `UPDATE inventory_items
+		SET quantity = quantity - ?, version = version + 1, updated_at = ?
+		WHERE id = ?`
+
+	// allocationsSQL reads the allocations of a reservation with their lot.
+	// %s narrows them to a status.
+	allocationsSQL = `SELECT rl.reservation_id, rl.lot_id, rl.quantity, rl.status,
+			rl.allocated_at, rl.updated_at, l.lot_number, l.best_before
+		FROM reservation_lots rl
+		JOIN lots l ON l.id = rl.lot_id
+		WHERE rl.reservation_id = ?%s
+		ORDER BY l.best_before ASC NULLS LAST, l.received_at ASC, l.lot_number ASC`
+
+	allocatedFilterSQL = " AND rl.status = 'allocated'"
+
+	consumeLotSQL = `UPDATE lots
+		SET quantity = quantity - ?, reserved = reserved - ?, updated_at = ?
+		WHERE id = ?`
+
+	releaseLotSQL = `UPDATE lots
+		SET reserved = reserved - ?, updated_at = ?
+		WHERE id = ?`
+
+	settleAllocationsSQL = `UPDATE reservation_lots
+		SET status = ?, updated_at = ?
+		WHERE reservation_id = ? AND status = 'allocated'`
+
+	expiredLotsSQL = `SELECT id FROM lots
+		WHERE best_before < ? AND quantity > reserved
+		ORDER BY best_before ASC, id ASC`
+)
+
+// LotRepositoryImpl is the GORM implementation of LotRepository
+type LotRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewLotRepository creates a new instance of LotRepositoryImpl
+func NewLotRepository(db *gorm.DB) *LotRepositoryImpl {
+	return &LotRepositoryImpl{
+		db: db,
+	}
+}
+
+// Receive saves a new lot and adds its units to the quantity of its inventory item
+func (r *LotRepositoryImpl) Receive(ctx context.Context, lot *entity.Lot) (*entity.InventoryItem, error) {
+	var updated model.InventoryItemModel
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		item, err := lockInventoryItem(tx, lot.InventoryItemID)
+		if err != nil {
+			return err
+		}
+		if item.SerialTracked {
+			return domainErrors.ErrSerialTrackedItem
+		}
+		if item.Bundle {
+			return domainErrors.ErrBundleItem
+		}
+
+		if err := tx.Create(model.NewLotModelFromEntity(lot)).Error; err != nil {
+			if containsLotConstraintViolation(err.Error()) {
+				return domainErrors.ErrLotAlreadyExists.WithDetails(lot.LotNumber)
+			}
+			return fmt.Errorf("failed to save lot: %w", err)
+		}
+
+		if err := tx.Raw(receiveLotStockSQL, lot.Quantity, time.Now().UTC(), lot.InventoryItemID).Scan(&updated).Error; err != nil {
+			return fmt.Errorf("failed to add lot to inventory item: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated.ToEntity(), nil
+}
+
+// containsLotConstraintViolation checks if error message contains PostgreSQL duplicate key constraint for lots
+func containsLotConstraintViolation(errMsg string) bool {
+	return strings.Contains(errMsg, "duplicate key value violates unique constraint") &&
+		(strings.Contains(errMsg, "uq_lots_item_lot_number") || strings.Contains(errMsg, "SQLSTATE 23505"))
+}
+
+// FindByInventoryItemID retrieves the lots of an inventory item in FEFO order
+func (r *LotRepositoryImpl) FindByInventoryItemID(ctx context.Context, inventoryItemID uuid.UUID) ([]*entity.Lot, error) {
+	var lotModels []model.LotModel
+
+	result := r.db.WithContext(ctx).
+		Where("inventory_item_id = ?", inventoryItemID).
+		Order(fefoOrderSQL).
+		Find(&lotModels)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to find lots by inventory item ID: %w", result.Error)
+	}
+
+	lots := make([]*entity.Lot, len(lotModels))
+	for i := range lotModels {
+		lots[i] = lotModels[i].ToEntity()
+	}
+	return lots, nil
+}
+
+// Allocate allocates up to quantity units of the item's lots to the reservation, FEFO
+func (r *LotRepositoryImpl) Allocate(ctx context.Context, reservationID, inventoryItemID uuid.UUID, quantity int, at time.Time) ([]*entity.LotAllocation, error) {
+	if quantity <= 0 {
+		return nil, domainErrors.ErrInvalidQuantity
+	}
+	at = at.UTC()
+
+	var allocations []*entity.LotAllocation
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if _, err := lockInventoryItem(tx, inventoryItemID); err != nil {
+			return err
+		}
+
+		var err error
+		allocations, err = allocateLots(tx, reservationID, inventoryItemID, quantity, at)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return allocations, nil
+}
+
+// allocateLots locks the item's lots with free units and allocates them to the
+// reservation with entity.AllocateFEFO. The caller holds the lock on the item.
+func allocateLots(tx *gorm.DB, reservationID, inventoryItemID uuid.UUID, quantity int, at time.Time) ([]*entity.LotAllocation, error) {
+	var lotModels []model.LotModel
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("inventory_item_id = ? AND quantity > reserved", inventoryItemID).
+		Order(fefoOrderSQL).
+		Find(&lotModels).Error; err != nil {
+		return nil, fmt.Errorf("failed to lock lots: %w", err)
+	}
+
+	lots := make([]*entity.Lot, len(lotModels))
+	for i := range lotModels {
+		lots[i] = lotModels[i].ToEntity()
+	}
+	allocations := entity.AllocateFEFO(lots, reservationID, quantity, at)
+
+	for _, allocation := range allocations {
+		if err := tx.Model(&model.LotModel{}).
+			Where("id = ?", allocation.LotID).
+			Updates(map[string]interface{}{
+				"reserved":   gorm.Expr("reserved + ?", allocation.Quantity),
+				"updated_at": at,
+			}).Error; err != nil {
+			return nil, fmt.Errorf("failed to reserve lot: %w", err)
+		}
+		if err := tx.Create(model.NewLotAllocationModelFromEntity(allocation)).Error; err != nil {
+			return nil, fmt.Errorf("failed to save lot allocation: %w", err)
+		}
+	}
+	return allocations, nil
+}
+
+// Consume marks the allocations of a confirmed reservation consumed and removes their units from the lots
+func (r *LotRepositoryImpl) Consume(ctx context.Context, reservationID uuid.UUID) ([]*entity.LotAllocation, error) {
+	return r.settle(ctx, reservationID, entity.LotConsumed, func(tx *gorm.DB, allocation *entity.LotAllocation, at time.Time) error {
+		return tx.Exec(consumeLotSQL, allocation.Quantity, allocation.Quantity, at, allocation.LotID).Error
+	})
+}
+
+// Release marks the allocations of a released reservation released and returns their units to the lots
+func (r *LotRepositoryImpl) Release(ctx context.Context, reservationID uuid.UUID) ([]*entity.LotAllocation, error) {
+	return r.settle(ctx, reservationID, entity.LotReleased, func(tx *gorm.DB, allocation *entity.LotAllocation, at time.Time) error {
+		return tx.Exec(releaseLotSQL, allocation.Quantity, at, allocation.LotID).Error
+	})
+}
+
+// settle applies apply to every pending allocation of a reservation and moves
+// them to status. Allocations already consumed or released are left alone, so
+// settling twice is a no-op.
+func (r *LotRepositoryImpl) settle(
+	ctx context.Context,
+	reservationID uuid.UUID,
+	status entity.LotAllocationStatus,
+	apply func(tx *gorm.DB, allocation *entity.LotAllocation, at time.Time) error,
+) ([]*entity.LotAllocation, error) {
+	at := time.Now().UTC()
+
+	var allocations []*entity.LotAllocation
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var allocationModels []model.LotAllocationModel
+		query := fmt.Sprintf(allocationsSQL, allocatedFilterSQL) + " FOR UPDATE OF rl"
+		if err := tx.Raw(query, reservationID).Scan(&allocationModels).Error; err != nil {
+			return fmt.Errorf("failed to lock lot allocations: %w", err)
+		}
+
+		for i := range allocationModels {
+			allocation := allocationModels[i].ToEntity()
+			if err := apply(tx, allocation, at); err != nil {
+				return fmt.Errorf("failed to update lot: %w", err)
+			}
+			allocation.Status = status
+			allocation.UpdatedAt = at
+			allocations = append(allocations, allocation)
+		}
+
+		if len(allocations) == 0 {
+			return nil
+		}
+		if err := tx.Exec(settleAllocationsSQL, string(status), at, reservationID).Error; err != nil {
+			return fmt.Errorf("failed to update lot allocations: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return allocations, nil
+}
+
+// FindAllocations retrieves every allocation of a reservation, whatever its status
+func (r *LotRepositoryImpl) FindAllocations(ctx context.Context, reservationID uuid.UUID) ([]*entity.LotAllocation, error) {
+	var allocationModels []model.LotAllocationModel
+
+	if err := r.db.WithContext(ctx).Raw(fmt.Sprintf(allocationsSQL, ""), reservationID).Scan(&allocationModels).Error; err != nil {
+		return nil, fmt.Errorf("failed to find lot allocations: %w", err)
+	}
+
+	allocations := make([]*entity.LotAllocation, len(allocationModels))
+	for i := range allocationModels {
+		allocations[i] = allocationModels[i].ToEntity()
+	}
+	return allocations, nil
+}
+
+// QuarantineExpired moves the free units of every lot expired at the given time
+// out of the stock, one lot per transaction
+func (r *LotRepositoryImpl) QuarantineExpired(ctx context.Context, at time.Time) ([]repository.QuarantinedLot, error) {
+	at = at.UTC()
+
+	var lotIDs []uuid.UUID
+	if err := r.db.WithContext(ctx).Raw(expiredLotsSQL, lotDay(at)).Scan(&lotIDs).Error; err != nil {
+		return nil, fmt.Errorf("failed to find expired lots: %w", err)
+	}
+
+	var quarantined []repository.QuarantinedLot
+	for _, lotID := range lotIDs {
+		if err := ctx.Err(); err != nil {
+			return quarantined, err
+		}
+
+		lot, err := r.quarantineLot(ctx, lotID, at)
+		if err != nil {
+			return quarantined, fmt.Errorf("failed to quarantine lot %s: %w", lotID, err)
+		}
+		if lot != nil {
+			quarantined = append(quarantined, *lot)
+		}
+	}
+	return quarantined, nil
+}
+
+// quarantineLot quarantines the free units of one expired lot, never more than the
+// item has available: units reserved from untracked stock stay sellable. Returns
+// nil when nothing could be moved.
+func (r *LotRepositoryImpl) quarantineLot(ctx context.Context, lotID uuid.UUID, at time.Time) (*repository.QuarantinedLot, error) {
+	var quarantined *repository.QuarantinedLot
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var lotModel model.LotModel
+		if err := tx.Where("id = ?", lotID).First(&lotModel).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			return err
+		}
+
+		item, err := lockInventoryItem(tx, lotModel.InventoryItemID)
+		if err != nil {
+			return err
+		}
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", lotID).First(&lotModel).Error; err != nil {
+			return err
+		}
+
+		lot := lotModel.ToEntity()
+		if !lot.IsExpired(at) {
+			return nil
+		}
+		units := lot.Quarantine(item.Available(), at)
+		if units == 0 {
+			return nil
+		}
+
+		if err := tx.Save(model.NewLotModelFromEntity(lot)).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec(quarantineLotStockSQL, units, at, item.ID).Error; err != nil {
+			return err
+		}
+
+		quarantined = &repository.QuarantinedLot{Lot: lot, ProductID: item.ProductID, Units: units}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return quarantined, nil
+}
+
+// lotDay returns the start of the day of t: lots with an earlier best-before date are expired at t
+func lotDay(t time.Time) time.Time {
+	year, month, day := t.UTC().Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// lockInventoryItem locks an inventory item row for the rest of the transaction
+func lockInventoryItem(tx *gorm.DB, id uuid.UUID) (*entity.InventoryItem, error) {
+	var itemModel model.InventoryItemModel
+
+	result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&itemModel)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, domainErrors.ErrInventoryItemNotFound
+		}
+		return nil, fmt.Errorf("failed to lock inventory item: %w", result.Error)
+	}
+	return itemModel.ToEntity(), nil
+}
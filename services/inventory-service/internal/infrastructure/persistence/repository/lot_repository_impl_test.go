@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	domainErrors "github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+)
+
+// insertLotItem writes an inventory item with untracked stock
+func insertLotItem(t *testing.T, db *gorm.DB, quantity int) *entity.InventoryItem {
+	item, err := entity.NewInventoryItem(uuid.New(), quantity)
+	require.NoError(t, err)
+	require.NoError(t, NewInventoryRepository(db).Save(context.Background(), item))
+	return item
+}
+
+func receiveLot(t *testing.T, repo *LotRepositoryImpl, itemID uuid.UUID, number string, quantity int, receivedAt time.Time, bestBefore *time.Time) *entity.Lot {
+	lot, err := entity.NewLot(itemID, number, quantity, receivedAt, bestBefore)
+	require.NoError(t, err)
+	_, err = repo.Receive(context.Background(), lot)
+	require.NoError(t, err)
+	return lot
+}
+
+func date(year int, month time.Month, day int) *time.Time {
+	d := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	return &d
+}
+
+func TestLotRepositoryImpl_Receive(t *testing.T) {
+	db, cleanup := setupMigratedTestDB(t)
+	defer cleanup()
+
+	repo := NewLotRepository(db)
+	ctx := context.Background()
+	item := insertLotItem(t, db, 10)
+
+	lot, err := entity.NewLot(item.ID, "L-001", 25, time.Now(), date(2030, time.January, 31))
+	require.NoError(t, err)
+	updated, err := repo.Receive(ctx, lot)
+	require.NoError(t, err)
+	assert.Equal(t, 35, updated.Quantity)
+	assert.Equal(t, item.Version+1, updated.Version)
+
+	duplicate, err := entity.NewLot(item.ID, "L-001", 5, time.Now(), nil)
+	require.NoError(t, err)
+	_, err = repo.Receive(ctx, duplicate)
+	assert.ErrorIs(t, err, domainErrors.ErrLotAlreadyExists)
+
+	orphan, err := entity.NewLot(uuid.New(), "L-002", 5, time.Now(), nil)
+	require.NoError(t, err)
+	_, err = repo.Receive(ctx, orphan)
+	assert.ErrorIs(t, err, domainErrors.ErrInventoryItemNotFound)
+
+	stored, err := NewInventoryRepository(db).FindByID(ctx, item.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 35, stored.Quantity, "failed receipts add nothing")
+
+	lots, err := repo.FindByInventoryItemID(ctx, item.ID)
+	require.NoError(t, err)
+	require.Len(t, lots, 1)
+	assert.Equal(t, "L-001", lots[0].LotNumber)
+	assert.True(t, date(2030, time.January, 31).Equal(*lots[0].BestBefore))
+}
+
+func TestLotRepositoryImpl_AllocateConsumeRelease(t *testing.T) {
+	db, cleanup := setupMigratedTestDB(t)
+	defer cleanup()
+
+	repo := NewLotRepository(db)
+	ctx := context.Background()
+	item := insertLotItem(t, db, 0)
+	received := time.Now().Add(-48 * time.Hour)
+	late := receiveLot(t, repo, item.ID, "LATE", 10, received, date(2031, time.June, 1))
+	early := receiveLot(t, repo, item.ID, "EARLY", 4, received, date(2030, time.June, 1))
+	expired := receiveLot(t, repo, item.ID, "EXPIRED", 3, time.Date(1999, time.December, 1, 0, 0, 0, 0, time.UTC), date(2000, time.January, 1))
+
+	confirmed := uuid.New()
+	allocations, err := repo.Allocate(ctx, confirmed, item.ID, 6, time.Now())
+	require.NoError(t, err)
+	require.Len(t, allocations, 2)
+	assert.Equal(t, early.ID, allocations[0].LotID)
+	assert.Equal(t, 4, allocations[0].Quantity)
+	assert.Equal(t, late.ID, allocations[1].LotID)
+	assert.Equal(t, 2, allocations[1].Quantity)
+
+	released := uuid.New()
+	allocations, err = repo.Allocate(ctx, released, item.ID, 20, time.Now())
+	require.NoError(t, err)
+	require.Len(t, allocations, 1, "expired and exhausted lots are skipped")
+	assert.Equal(t, 8, allocations[0].Quantity)
+
+	consumed, err := repo.Consume(ctx, confirmed)
+	require.NoError(t, err)
+	assert.Len(t, consumed, 2)
+	again, err := repo.Consume(ctx, confirmed)
+	require.NoError(t, err)
+	assert.Empty(t, again, "settled allocations are not consumed twice")
+
+	_, err = repo.Release(ctx, released)
+	require.NoError(t, err)
+
+	lots, err := repo.FindByInventoryItemID(ctx, item.ID)
+	require.NoError(t, err)
+	byID := map[uuid.UUID]*entity.Lot{}
+	for _, lot := range lots {
+		byID[lot.ID] = lot
+	}
+	assert.Equal(t, 0, byID[early.ID].Quantity)
+	assert.Equal(t, 8, byID[late.ID].Quantity)
+	assert.Equal(t, 0, byID[late.ID].Reserved)
+	assert.Equal(t, 3, byID[expired.ID].Quantity)
+
+	history, err := repo.FindAllocations(ctx, confirmed)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, "EARLY", history[0].LotNumber)
+	assert.Equal(t, entity.LotConsumed, history[0].Status)
+
+	history, err = repo.FindAllocations(ctx, released)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	assert.Equal(t, entity.LotReleased, history[0].Status)
+}
+
+func TestLotRepositoryImpl_QuarantineExpired(t *testing.T) {
+	db, cleanup := setupMigratedTestDB(t)
+	defer cleanup()
+
+	repo := NewLotRepository(db)
+	ctx := context.Background()
+	item := insertLotItem(t, db, 0)
+	received := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	expired := receiveLot(t, repo, item.ID, "EXPIRED", 10, received, date(2025, time.March, 1))
+	receiveLot(t, repo, item.ID, "FRESH", 5, received, date(2099, time.March, 1))
+
+	// A pending reservation holds 4 units of the expired lot
+	_, err := repo.Allocate(ctx, uuid.New(), item.ID, 4, date(2025, time.February, 1).Add(time.Hour))
+	require.NoError(t, err)
+	require.NoError(t, db.Exec(`UPDATE inventory_items SET reserved = 4 WHERE id = ?`, item.ID).Error)
+
+	at := time.Date(2025, time.March, 2, 3, 0, 0, 0, time.UTC)
+	quarantined, err := repo.QuarantineExpired(ctx, at)
+	require.NoError(t, err)
+	require.Len(t, quarantined, 1)
+	assert.Equal(t, expired.ID, quarantined[0].Lot.ID)
+	assert.Equal(t, item.ProductID, quarantined[0].ProductID)
+	assert.Equal(t, 6, quarantined[0].Units)
+	assert.Equal(t, 4, quarantined[0].Lot.Quantity)
+	assert.Equal(t, 6, quarantined[0].Lot.Quarantined)
+	assert.True(t, quarantined[0].Lot.IsQuarantined())
+
+	stored, err := NewInventoryRepository(db).FindByID(ctx, item.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 9, stored.Quantity)
+
+	again, err := repo.QuarantineExpired(ctx, at)
+	require.NoError(t, err)
+	assert.Empty(t, again, "reserved units stay until their reservation is settled")
+}
@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	domainErrors "github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/infrastructure/persistence/model"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Purchase limit tables, see migration 013
+const (
+	findPurchaseLimitByProductSQL = `SELECT pl.* FROM purchase_limits pl
+		JOIN inventory_items i ON i.id = pl.inventory_item_id
+		WHERE i.product_id = ?`
+
+	// customerQuantitySQL sums what a customer reserved within a window; confirmed
+	// reservations may already be archived, released and expired ones bought nothing
+	customerQuantitySQL = `SELECT COALESCE(SUM(quantity), 0) FROM (
+			SELECT quantity FROM reservations
+			WHERE inventory_item_id = ? AND user_id = ? AND created_at >= ? AND status IN ('pending', 'confirmed')
+			UNION ALL
+			SELECT quantity FROM reservations_archive
+			WHERE inventory_item_id = ? AND user_id = ? AND created_at >= ? AND status = 'confirmed'
+		) customer`
+
+	// tryLockCustomerSQL takes the purchase lock of a customer on an item until the
+	// transaction ends, without waiting for other holders
+	tryLockCustomerSQL = `SELECT pg_try_advisory_xact_lock(hashtextextended(? || ':' || ?, 0))`
+)
+
+// PurchaseLimitRepositoryImpl is the GORM implementation of PurchaseLimitRepository
+type PurchaseLimitRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewPurchaseLimitRepository creates a new instance of PurchaseLimitRepositoryImpl
+func NewPurchaseLimitRepository(db *gorm.DB) *PurchaseLimitRepositoryImpl {
+	return &PurchaseLimitRepositoryImpl{
+		db: db,
+	}
+}
+
+// FindByProductID retrieves the limits of a product
+func (r *PurchaseLimitRepositoryImpl) FindByProductID(ctx context.Context, productID uuid.UUID) (*entity.PurchaseLimit, error) {
+	var limitModel model.PurchaseLimitModel
+	result := r.db.WithContext(ctx).Raw(findPurchaseLimitByProductSQL, productID).Scan(&limitModel)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to find purchase limit: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, domainErrors.ErrPurchaseLimitNotFound
+	}
+	return limitModel.ToEntity(), nil
+}
+
+// Save creates or replaces the limits of an inventory item
+func (r *PurchaseLimitRepositoryImpl) Save(ctx context.Context, limit *entity.PurchaseLimit) error {
+	limitModel := model.NewPurchaseLimitModel(limit)
+	result := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "inventory_item_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"max_per_order", "max_per_customer", "window_seconds", "updated_at"}),
+	}).Create(limitModel)
+	if result.Error != nil {
+		return fmt.Errorf("failed to save purchase limit: %w", result.Error)
+	}
+	return nil
+}
+
+// Delete removes the limits of an inventory item
+func (r *PurchaseLimitRepositoryImpl) Delete(ctx context.Context, inventoryItemID uuid.UUID) error {
+	result := r.db.WithContext(ctx).Where("inventory_item_id = ?", inventoryItemID).Delete(&model.PurchaseLimitModel{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete purchase limit: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domainErrors.ErrPurchaseLimitNotFound
+	}
+	return nil
+}
+
+// CustomerQuantity returns the units of an inventory item a customer reserved since the given time
+func (r *PurchaseLimitRepositoryImpl) CustomerQuantity(
+	ctx context.Context,
+	inventoryItemID uuid.UUID,
+	userID string,
+	since time.Time,
+) (int, error) {
+	since = since.UTC()
+	var quantity int
+	err := r.db.WithContext(ctx).
+		Raw(customerQuantitySQL, inventoryItemID, userID, since, inventoryItemID, userID, since).
+		Scan(&quantity).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to sum customer reservations: %w", err)
+	}
+	return quantity, nil
+}
+
+// LockCustomer runs fn holding the purchase lock of a customer on an inventory item.
+// The advisory lock lives in a transaction of its own that stays open while fn runs,
+// so fn may write through other repositories. Waiting for the lock would hold a
+// pooled connection per blocked request, so a lock held elsewhere fails instead.
+func (r *PurchaseLimitRepositoryImpl) LockCustomer(
+	ctx context.Context,
+	inventoryItemID uuid.UUID,
+	userID string,
+	fn func() error,
+) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var locked bool
+		if err := tx.Raw(tryLockCustomerSQL, inventoryItemID.String(), userID).Scan(&locked).Error; err != nil {
+			return fmt.Errorf("failed to lock customer purchases: %w", err)
+		}
+		if !locked {
+			return domainErrors.ErrOptimisticLockFailure.WithDetails("another reservation of the customer holds the purchase lock")
+		}
+		return fn()
+	})
+}
@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	domainErrors "github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/infrastructure/persistence/model"
+)
+
+func TestPurchaseLimitRepositoryImpl_SaveFindDelete(t *testing.T) {
+	db, cleanup := setupMigratedTestDB(t)
+	defer cleanup()
+
+	repo := NewPurchaseLimitRepository(db)
+	ctx := context.Background()
+	item := insertLotItem(t, db, 100)
+
+	_, err := repo.FindByProductID(ctx, item.ProductID)
+	assert.ErrorIs(t, err, domainErrors.ErrPurchaseLimitNotFound)
+
+	limit, err := entity.NewPurchaseLimit(item, 2, 4, 24*time.Hour)
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, limit))
+
+	found, err := repo.FindByProductID(ctx, item.ProductID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, found.MaxPerOrder)
+	assert.Equal(t, 24*time.Hour, found.Window)
+
+	replaced, err := entity.NewPurchaseLimit(item, 1, 0, 0)
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, replaced))
+	found, err = repo.FindByProductID(ctx, item.ProductID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, found.MaxPerOrder)
+	assert.False(t, found.LimitsCustomers())
+
+	require.NoError(t, repo.Delete(ctx, item.ID))
+	assert.ErrorIs(t, repo.Delete(ctx, item.ID), domainErrors.ErrPurchaseLimitNotFound)
+}
+
+func customerReservation(t *testing.T, db *gorm.DB, item *entity.InventoryItem, userID string, quantity int, status entity.ReservationStatus) {
+	reservation, err := entity.NewReservation(item.ID, uuid.New(), quantity)
+	require.NoError(t, err)
+	reservation.UserID = userID
+	reservation.Status = status
+	require.NoError(t, NewReservationRepository(db).Save(context.Background(), reservation))
+}
+
+func TestPurchaseLimitRepositoryImpl_CustomerQuantity(t *testing.T) {
+	db, cleanup := setupMigratedTestDB(t)
+	defer cleanup()
+
+	repo := NewPurchaseLimitRepository(db)
+	ctx := context.Background()
+	item := insertLotItem(t, db, 100)
+	hourAgo := time.Now().Add(-time.Hour)
+
+	customerReservation(t, db, item, "user-1", 2, entity.ReservationPending)
+	customerReservation(t, db, item, "user-1", 1, entity.ReservationConfirmed)
+	customerReservation(t, db, item, "user-1", 5, entity.ReservationReleased)
+	customerReservation(t, db, item, "user-2", 7, entity.ReservationPending)
+	customerReservation(t, db, insertLotItem(t, db, 10), "user-1", 9, entity.ReservationPending)
+
+	// Confirmed reservations still count once archived, unless made before the window
+	archivedAt := time.Now().UTC()
+	for _, createdAt := range []time.Time{hourAgo.Add(time.Minute), hourAgo.Add(-time.Minute)} {
+		require.NoError(t, db.Create(&model.ArchivedReservationModel{
+			ID: uuid.New(), InventoryItemID: item.ID, OrderID: uuid.New(), Quantity: 3,
+			Status: string(entity.ReservationConfirmed), ExpiresAt: createdAt, CreatedAt: createdAt.UTC(),
+			UpdatedAt: createdAt.UTC(), ArchivedAt: archivedAt, UserID: "user-1",
+		}).Error)
+	}
+
+	quantity, err := repo.CustomerQuantity(ctx, item.ID, "user-1", hourAgo)
+	require.NoError(t, err)
+	assert.Equal(t, 6, quantity)
+
+	quantity, err = repo.CustomerQuantity(ctx, item.ID, "user-3", hourAgo)
+	require.NoError(t, err)
+	assert.Zero(t, quantity)
+}
+
+func TestPurchaseLimitRepositoryImpl_LockCustomer(t *testing.T) {
+	db, cleanup := setupMigratedTestDB(t)
+	defer cleanup()
+
+	repo := NewPurchaseLimitRepository(db)
+	ctx := context.Background()
+	item := insertLotItem(t, db, 100)
+
+	err := repo.LockCustomer(ctx, item.ID, "user-1", func() error {
+		assert.ErrorIs(t, repo.LockCustomer(ctx, item.ID, "user-1", func() error {
+			t.Error("the lock is held")
+			return nil
+		}), domainErrors.ErrOptimisticLockFailure)
+		assert.NoError(t, repo.LockCustomer(ctx, item.ID, "user-2", func() error { return nil }), "other customers are not locked")
+		return nil
+	})
+	require.NoError(t, err)
+	assert.NoError(t, repo.LockCustomer(ctx, item.ID, "user-1", func() error { return nil }), "the lock is released with fn")
+}
+
+func TestPurchaseLimitRepositoryImpl_LockCustomer_ConcurrentReservations(t *testing.T) {
+	db, cleanup := setupMigratedTestDB(t)
+	defer cleanup()
+
+	repo := NewPurchaseLimitRepository(db)
+	reservations := NewReservationRepository(db)
+	ctx := context.Background()
+	item := insertLotItem(t, db, 100)
+	limit, err := entity.NewPurchaseLimit(item, 0, 4, time.Hour)
+	require.NoError(t, err)
+
+	// Every request checks what the customer reserved and reserves one more unit
+	var wg sync.WaitGroup
+	var reserved, rejected atomic.Int64
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				err := repo.LockCustomer(ctx, item.ID, "user-1", func() error {
+					bought, err := repo.CustomerQuantity(ctx, item.ID, "user-1", limit.WindowStart(time.Now()))
+					if err != nil {
+						return err
+					}
+					if err := limit.CheckCustomer(1, bought); err != nil {
+						return err
+					}
+					reservation, err := entity.NewReservation(item.ID, uuid.New(), 1)
+					if err != nil {
+						return err
+					}
+					reservation.UserID = "user-1"
+					return reservations.Save(ctx, reservation)
+				})
+				switch {
+				case err == nil:
+					reserved.Add(1)
+				case errors.Is(err, domainErrors.ErrPurchaseLimitExceeded):
+					rejected.Add(1)
+				case errors.Is(err, domainErrors.ErrOptimisticLockFailure):
+					time.Sleep(time.Millisecond)
+					continue
+				default:
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int64(4), reserved.Load())
+	assert.Equal(t, int64(16), rejected.Load())
+	quantity, err := repo.CustomerQuantity(ctx, item.ID, "user-1", limit.WindowStart(time.Now()))
+	require.NoError(t, err)
+	assert.Equal(t, 4, quantity, "the customer never reserves over the limit")
+}
@@ -0,0 +1,442 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/infrastructure/persistence/model"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Drift queries. Pending reservations are the source of truth for reserved;
+// rows touched after the settle time are skipped because the optimistic path
+// updates the item and the reservation in separate statements.
+const (
+	// pendingReservedSQL lists the units pending reservations hold per item: their
+	// own quantity, or for reservations of a bundle the components still reserved
+	pendingReservedSQL = `SELECT r.inventory_item_id, r.quantity
+			FROM reservations r
+			JOIN inventory_items b ON b.id = r.inventory_item_id
+			WHERE r.status = 'pending' AND NOT b.bundle
+			UNION ALL
+			SELECT rc.inventory_item_id, rc.quantity
+			FROM reservation_components rc
+			JOIN reservations r ON r.id = rc.reservation_id
+			WHERE r.status = 'pending' AND rc.status = 'reserved'`
+
+	// pendingHoldingsSQL lists the units every pending reservation holds per item,
+	// like pendingReservedSQL, with the reservation
+	pendingHoldingsSQL = `SELECT r.id AS reservation_id, r.inventory_item_id, r.quantity, r.order_id, r.created_at, r.updated_at
+			FROM reservations r
+			JOIN inventory_items b ON b.id = r.inventory_item_id
+			WHERE r.status = 'pending' AND NOT b.bundle
+			UNION ALL
+			SELECT r.id, rc.inventory_item_id, rc.quantity, r.order_id, r.created_at, r.updated_at
+			FROM reservation_components rc
+			JOIN reservations r ON r.id = rc.reservation_id
+			WHERE r.status = 'pending' AND rc.status = 'reserved'`
+
+	// recentlyReservedSQL matches items with reservations touched after the settle time
+	recentlyReservedSQL = `EXISTS (SELECT 1 FROM reservations r WHERE r.inventory_item_id = i.id AND r.updated_at >= ?)
+			OR EXISTS (SELECT 1 FROM reservation_components rc WHERE rc.inventory_item_id = i.id AND rc.updated_at >= ?)`
+
+	findReservedMismatchesSQL = `SELECT i.id AS inventory_item_id, i.product_id, i.reserved AS recorded, COALESCE(p.total, 0) AS expected
+		FROM inventory_items i
+		LEFT JOIN (
+			SELECT inventory_item_id, SUM(quantity) AS total
+			FROM (` + pendingReservedSQL + `) pending
+			GROUP BY inventory_item_id
+		) p ON p.inventory_item_id = i.id
+		WHERE i.reserved <> COALESCE(p.total, 0)
+			AND i.updated_at < ?
+			AND NOT (` + recentlyReservedSQL + `)
+		ORDER BY i.product_id`
+
+	findOrphanReservationsSQL = `SELECT r.id AS reservation_id, r.inventory_item_id, r.order_id, r.quantity AS recorded, 0 AS expected
+		FROM reservations r
+		WHERE r.status = 'pending'
+			AND r.updated_at < ?
+			AND NOT EXISTS (SELECT 1 FROM inventory_items i WHERE i.id = r.inventory_item_id)
+		ORDER BY r.created_at`
+
+	findStuckReservationsSQL = `SELECT r.id AS reservation_id, r.inventory_item_id, i.product_id, r.order_id, r.quantity AS recorded, 0 AS expected, r.expires_at
+		FROM reservations r
+		JOIN inventory_items i ON i.id = r.inventory_item_id
+		WHERE r.status = 'pending' AND r.expires_at < ?
+		ORDER BY r.expires_at`
+
+	// reservationStatusSQL reads the status of a reservation, archived or not
+	reservationStatusSQL = `SELECT status, updated_at FROM reservations WHERE id = ?
+		UNION ALL
+		SELECT status, updated_at FROM reservations_archive WHERE id = ?
+		LIMIT 1`
+
+	// findStaleLotAllocationsSQL groups, per reservation and item, the allocations
+	// still holding lot units of reservations that were settled before the settle
+	// time, or are gone altogether
+	findStaleLotAllocationsSQL = `SELECT rl.reservation_id, l.inventory_item_id, i.product_id, s.order_id,
+			SUM(rl.quantity) AS recorded, 0 AS expected, s.status AS reservation_status
+		FROM reservation_lots rl
+		JOIN lots l ON l.id = rl.lot_id
+		JOIN inventory_items i ON i.id = l.inventory_item_id
+		CROSS JOIN LATERAL (
+			SELECT COALESCE(r.order_id, a.order_id) AS order_id,
+				COALESCE(r.status, a.status, 'missing') AS status,
+				COALESCE(r.updated_at, a.updated_at, rl.updated_at) AS updated_at
+			FROM (SELECT 1) one
+			LEFT JOIN reservations r ON r.id = rl.reservation_id
+			LEFT JOIN reservations_archive a ON a.id = rl.reservation_id
+		) s
+		WHERE rl.status = 'allocated' AND rl.updated_at < ?
+			AND s.status <> 'pending' AND s.updated_at < ?
+		GROUP BY rl.reservation_id, l.inventory_item_id, i.product_id, s.order_id, s.status
+		ORDER BY i.product_id, rl.reservation_id`
+
+	// staleAllocationsSQL locks the allocations of a reservation on an item's lots
+	staleAllocationsSQL = `SELECT rl.lot_id, rl.quantity
+		FROM reservation_lots rl
+		JOIN lots l ON l.id = rl.lot_id
+		WHERE rl.reservation_id = ? AND l.inventory_item_id = ? AND rl.status = 'allocated' AND rl.updated_at < ?
+		FOR UPDATE OF rl`
+
+	settleLotAllocationSQL = `UPDATE reservation_lots
+		SET status = ?, updated_at = ?
+		WHERE reservation_id = ? AND lot_id = ?`
+
+	// freeLotsSQL sums the free units of the lots of every item not expired on the given day
+	freeLotsSQL = `SELECT inventory_item_id, SUM(quantity - reserved) AS free
+			FROM lots
+			WHERE quantity > reserved AND (best_before IS NULL OR best_before >= ?)
+			GROUP BY inventory_item_id`
+
+	// lotAllocationExistsSQL matches holdings with an allocation on the item's lots, whatever its status
+	lotAllocationExistsSQL = `EXISTS (SELECT 1 FROM reservation_lots rl
+			JOIN lots l ON l.id = rl.lot_id
+			WHERE rl.reservation_id = p.reservation_id AND l.inventory_item_id = p.inventory_item_id)`
+
+	findMissingLotAllocationsSQL = `SELECT p.reservation_id, p.inventory_item_id, i.product_id, p.order_id,
+			0 AS recorded, LEAST(p.quantity, f.free) AS expected
+		FROM (` + pendingHoldingsSQL + `) p
+		JOIN inventory_items i ON i.id = p.inventory_item_id
+		JOIN (` + freeLotsSQL + `) f ON f.inventory_item_id = p.inventory_item_id
+		WHERE p.updated_at < ? AND NOT ` + lotAllocationExistsSQL + `
+		ORDER BY i.product_id, p.created_at`
+
+	// unallocatedHoldingSQL reads the units a pending reservation without allocation holds of an item
+	unallocatedHoldingSQL = `SELECT p.quantity
+		FROM (` + pendingHoldingsSQL + `) p
+		WHERE p.reservation_id = ? AND p.inventory_item_id = ? AND p.updated_at < ?
+			AND NOT ` + lotAllocationExistsSQL
+
+	recomputeReservedSQL = `UPDATE inventory_items i
+		SET reserved = p.total, version = i.version + 1, updated_at = ?
+		FROM (
+			SELECT COALESCE(SUM(quantity), 0) AS total
+			FROM (` + pendingReservedSQL + `) pending
+			WHERE inventory_item_id = ?
+		) p
+		WHERE i.id = ? AND i.reserved <> p.total
+			AND i.updated_at < ?
+			AND NOT (` + recentlyReservedSQL + `)`
+
+	releaseOrphanReservationSQL = `UPDATE reservations r
+		SET status = 'released', updated_at = ?
+		WHERE r.id = ? AND r.status = 'pending'
+			AND NOT EXISTS (SELECT 1 FROM inventory_items i WHERE i.id = r.inventory_item_id)`
+
+	expireStuckReservationSQL = `UPDATE reservations
+		SET status = 'expired', updated_at = ?
+		WHERE id = ? AND status = 'pending' AND expires_at < ?
+		RETURNING inventory_item_id, quantity`
+
+	returnReservedSQL = `UPDATE inventory_items
+		SET reserved = GREATEST(reserved - ?, 0), version = version + 1, updated_at = ?
+		WHERE id = ? AND NOT bundle`
+
+	// returnReservedComponentsSQL returns the units a reservation of a bundle holds to its components
+	returnReservedComponentsSQL = `UPDATE inventory_items i
+		SET reserved = GREATEST(i.reserved - rc.quantity, 0), version = i.version + 1, updated_at = ?
+		FROM reservation_components rc
+		WHERE rc.reservation_id = ? AND rc.status = 'reserved' AND i.id = rc.inventory_item_id`
+
+	// releaseSerialUnitsSQL makes the serial units a reservation holds available again
+	releaseSerialUnitsSQL = `UPDATE serial_units
+		SET status = 'available', reservation_id = NULL, updated_at = ?
+		WHERE reservation_id = ? AND status = 'reserved'`
+
+	// releaseReservationLotsSQL returns the lot units a reservation holds to its lots
+	releaseReservationLotsSQL = `UPDATE lots l
+		SET reserved = l.reserved - rl.quantity, updated_at = ?
+		FROM reservation_lots rl
+		WHERE rl.reservation_id = ? AND rl.status = 'allocated' AND l.id = rl.lot_id`
+)
+
+// missingProductsBatchSize keeps the IN list well below the PostgreSQL parameter limit
+const missingProductsBatchSize = 1000
+
+// ReconciliationRepositoryImpl is the GORM implementation of ReconciliationRepository
+type ReconciliationRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewReconciliationRepository creates a new instance of ReconciliationRepositoryImpl
+func NewReconciliationRepository(db *gorm.DB) *ReconciliationRepositoryImpl {
+	return &ReconciliationRepositoryImpl{
+		db: db,
+	}
+}
+
+// driftRow is the shape returned by the drift queries
+type driftRow struct {
+	InventoryItemID   uuid.UUID
+	ProductID         uuid.UUID
+	ReservationID     uuid.UUID
+	OrderID           uuid.UUID
+	Recorded          int
+	Expected          int
+	ExpiresAt         *time.Time
+	ReservationStatus string
+}
+
+// FindReservedMismatches returns items whose reserved counter differs from their pending reservations
+func (r *ReconciliationRepositoryImpl) FindReservedMismatches(ctx context.Context, settledBefore time.Time) ([]*entity.InventoryDrift, error) {
+	return r.findDrift(ctx, entity.DriftReservedMismatch, findReservedMismatchesSQL, settledBefore, settledBefore, settledBefore)
+}
+
+// FindOrphanReservations returns pending reservations whose inventory item no longer exists
+func (r *ReconciliationRepositoryImpl) FindOrphanReservations(ctx context.Context, settledBefore time.Time) ([]*entity.InventoryDrift, error) {
+	return r.findDrift(ctx, entity.DriftOrphanReservation, findOrphanReservationsSQL, settledBefore)
+}
+
+// FindStuckReservations returns pending reservations that expired before expiredBefore
+func (r *ReconciliationRepositoryImpl) FindStuckReservations(ctx context.Context, expiredBefore time.Time) ([]*entity.InventoryDrift, error) {
+	return r.findDrift(ctx, entity.DriftStuckReservation, findStuckReservationsSQL, expiredBefore)
+}
+
+// FindStaleLotAllocations returns the lot units still allocated to reservations that are no longer pending
+func (r *ReconciliationRepositoryImpl) FindStaleLotAllocations(ctx context.Context, settledBefore time.Time) ([]*entity.InventoryDrift, error) {
+	return r.findDrift(ctx, entity.DriftStaleLotAllocation, findStaleLotAllocationsSQL, settledBefore, settledBefore)
+}
+
+// FindMissingLotAllocations returns pending reservations without allocation on lots with free units
+func (r *ReconciliationRepositoryImpl) FindMissingLotAllocations(ctx context.Context, settledBefore time.Time) ([]*entity.InventoryDrift, error) {
+	return r.findDrift(ctx, entity.DriftMissingLotAllocation, findMissingLotAllocationsSQL, lotDay(time.Now()), settledBefore)
+}
+
+// findDrift runs a drift query and maps its rows to entities
+func (r *ReconciliationRepositoryImpl) findDrift(ctx context.Context, kind entity.DriftKind, query string, args ...interface{}) ([]*entity.InventoryDrift, error) {
+	var rows []driftRow
+	if err := r.db.WithContext(ctx).Raw(query, args...).Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to find %s drift: %w", kind, err)
+	}
+
+	drifts := make([]*entity.InventoryDrift, 0, len(rows))
+	for _, row := range rows {
+		drift := &entity.InventoryDrift{
+			Kind:            kind,
+			InventoryItemID: row.InventoryItemID,
+			ProductID:       row.ProductID,
+			ReservationID:   row.ReservationID,
+			OrderID:         row.OrderID,
+			Recorded:        row.Recorded,
+			Expected:        row.Expected,
+		}
+		if row.ExpiresAt != nil {
+			drift.Detail = "pending since expiring at " + row.ExpiresAt.UTC().Format(time.RFC3339)
+		}
+		if row.ReservationStatus != "" {
+			drift.Detail = "reservation " + row.ReservationStatus
+		}
+		drifts = append(drifts, drift)
+	}
+
+	return drifts, nil
+}
+
+// FindMissingProducts returns the product IDs that have no inventory item
+func (r *ReconciliationRepositoryImpl) FindMissingProducts(ctx context.Context, productIDs []uuid.UUID) ([]uuid.UUID, error) {
+	existing := make(map[uuid.UUID]bool, len(productIDs))
+	for start := 0; start < len(productIDs); start += missingProductsBatchSize {
+		end := min(start+missingProductsBatchSize, len(productIDs))
+
+		var found []uuid.UUID
+		err := r.db.WithContext(ctx).
+			Model(&model.InventoryItemModel{}).
+			Where("product_id IN ?", productIDs[start:end]).
+			Pluck("product_id", &found).Error
+		if err != nil {
+			return nil, fmt.Errorf("failed to find inventory items by product: %w", err)
+		}
+		for _, id := range found {
+			existing[id] = true
+		}
+	}
+
+	missing := make([]uuid.UUID, 0)
+	for _, id := range productIDs {
+		if !existing[id] {
+			missing = append(missing, id)
+		}
+	}
+
+	return missing, nil
+}
+
+// RecomputeReserved sets the reserved counter of an item to the sum of its pending reservations
+func (r *ReconciliationRepositoryImpl) RecomputeReserved(ctx context.Context, itemID uuid.UUID, settledBefore time.Time, audit *entity.AdminAuditEntry) (bool, error) {
+	return r.repair(ctx, audit, func(tx *gorm.DB) (bool, error) {
+		result := tx.Exec(recomputeReservedSQL, time.Now().UTC(), itemID, itemID, settledBefore, settledBefore, settledBefore)
+		return result.RowsAffected > 0, result.Error
+	})
+}
+
+// ReleaseOrphanReservation marks a pending reservation without inventory item as released
+func (r *ReconciliationRepositoryImpl) ReleaseOrphanReservation(ctx context.Context, reservationID uuid.UUID, audit *entity.AdminAuditEntry) (bool, error) {
+	return r.repair(ctx, audit, func(tx *gorm.DB) (bool, error) {
+		result := tx.Exec(releaseOrphanReservationSQL, time.Now().UTC(), reservationID)
+		return result.RowsAffected > 0, result.Error
+	})
+}
+
+// ExpireStuckReservation marks a stuck reservation as expired and returns its quantity to the item,
+// or to the components of a bundle, making the serial units it holds available again and
+// releasing its lot allocations
+func (r *ReconciliationRepositoryImpl) ExpireStuckReservation(ctx context.Context, reservationID uuid.UUID, expiredBefore time.Time, audit *entity.AdminAuditEntry) (bool, error) {
+	return r.repair(ctx, audit, func(tx *gorm.DB) (bool, error) {
+		now := time.Now().UTC()
+
+		var expired []struct {
+			InventoryItemID uuid.UUID
+			Quantity        int
+		}
+		if err := tx.Raw(expireStuckReservationSQL, now, reservationID, expiredBefore).Scan(&expired).Error; err != nil {
+			return false, err
+		}
+		if len(expired) == 0 {
+			return false, nil
+		}
+
+		if err := tx.Exec(returnReservedSQL, expired[0].Quantity, now, expired[0].InventoryItemID).Error; err != nil {
+			return false, err
+		}
+		if err := tx.Exec(returnReservedComponentsSQL, now, reservationID).Error; err != nil {
+			return false, err
+		}
+		if err := tx.Exec(releaseSerialUnitsSQL, now, reservationID).Error; err != nil {
+			return false, err
+		}
+		if err := tx.Exec(releaseReservationLotsSQL, now, reservationID).Error; err != nil {
+			return false, err
+		}
+		if err := tx.Exec(settleAllocationsSQL, string(entity.LotReleased), now, reservationID).Error; err != nil {
+			return false, err
+		}
+		return true, tx.Exec(settleComponentsSQL, string(entity.ComponentReleased), now, reservationID).Error
+	})
+}
+
+// SettleStaleLotAllocations consumes the allocations of a confirmed reservation on the
+// item's lots and releases those of any other settled reservation
+func (r *ReconciliationRepositoryImpl) SettleStaleLotAllocations(ctx context.Context, reservationID, itemID uuid.UUID, settledBefore time.Time, audit *entity.AdminAuditEntry) (bool, error) {
+	return r.repair(ctx, audit, func(tx *gorm.DB) (bool, error) {
+		var reservations []struct {
+			Status    string
+			UpdatedAt time.Time
+		}
+		if err := tx.Raw(reservationStatusSQL, reservationID, reservationID).Scan(&reservations).Error; err != nil {
+			return false, err
+		}
+		status := entity.LotReleased
+		if len(reservations) > 0 {
+			switch {
+			case reservations[0].Status == string(entity.ReservationPending), !reservations[0].UpdatedAt.Before(settledBefore):
+				return false, nil
+			case reservations[0].Status == string(entity.ReservationConfirmed):
+				status = entity.LotConsumed
+			}
+		}
+
+		var allocations []struct {
+			LotID    uuid.UUID
+			Quantity int
+		}
+		if err := tx.Raw(staleAllocationsSQL, reservationID, itemID, settledBefore).Scan(&allocations).Error; err != nil {
+			return false, err
+		}
+
+		now := time.Now().UTC()
+		for _, allocation := range allocations {
+			var err error
+			if status == entity.LotConsumed {
+				err = tx.Exec(consumeLotSQL, allocation.Quantity, allocation.Quantity, now, allocation.LotID).Error
+			} else {
+				err = tx.Exec(releaseLotSQL, allocation.Quantity, now, allocation.LotID).Error
+			}
+			if err != nil {
+				return false, err
+			}
+			if err := tx.Exec(settleLotAllocationSQL, string(status), now, reservationID, allocation.LotID).Error; err != nil {
+				return false, err
+			}
+		}
+		return len(allocations) > 0, nil
+	})
+}
+
+// AllocateMissingLots allocates the item's lots to a pending reservation that holds
+// units of the item without any allocation on its lots
+func (r *ReconciliationRepositoryImpl) AllocateMissingLots(ctx context.Context, reservationID, itemID uuid.UUID, settledBefore time.Time, audit *entity.AdminAuditEntry) (bool, error) {
+	return r.repair(ctx, audit, func(tx *gorm.DB) (bool, error) {
+		if _, err := lockInventoryItem(tx, itemID); err != nil {
+			return false, err
+		}
+		// Releasing the reservation waits for the allocation, and then settles it
+		if err := tx.Exec("SELECT 1 FROM reservations WHERE id = ? FOR UPDATE", reservationID).Error; err != nil {
+			return false, err
+		}
+
+		var quantities []int
+		if err := tx.Raw(unallocatedHoldingSQL, reservationID, itemID, settledBefore).Scan(&quantities).Error; err != nil {
+			return false, err
+		}
+		if len(quantities) == 0 {
+			return false, nil
+		}
+
+		allocations, err := allocateLots(tx, reservationID, itemID, quantities[0], time.Now().UTC())
+		return len(allocations) > 0, err
+	})
+}
+
+// CreateMissingItem creates the inventory item of a product unless one already exists
+func (r *ReconciliationRepositoryImpl) CreateMissingItem(ctx context.Context, item *entity.InventoryItem, audit *entity.AdminAuditEntry) (bool, error) {
+	return r.repair(ctx, audit, func(tx *gorm.DB) (bool, error) {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(model.NewInventoryItemModelFromEntity(item))
+		return result.RowsAffected > 0, result.Error
+	})
+}
+
+// repair runs fix and, when it changed something, appends the audit entry in the same transaction
+func (r *ReconciliationRepositoryImpl) repair(ctx context.Context, audit *entity.AdminAuditEntry, fix func(tx *gorm.DB) (bool, error)) (bool, error) {
+	applied := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		changed, err := fix(tx)
+		if err != nil || !changed {
+			return err
+		}
+		if err := tx.Create(model.NewAdminAuditEntryModelFromEntity(audit)).Error; err != nil {
+			return fmt.Errorf("failed to save audit entry: %w", err)
+		}
+		applied = true
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to repair drift: %w", err)
+	}
+
+	return applied, nil
+}
@@ -0,0 +1,219 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+)
+
+// insertStuckReservation writes a pending reservation that expired an hour ago
+func insertStuckReservation(t *testing.T, db *gorm.DB, id, itemID uuid.UUID, quantity int) {
+	now := time.Now().UTC()
+	err := db.Exec(`INSERT INTO reservations (id, inventory_item_id, order_id, quantity, status, expires_at, created_at, updated_at)
+		VALUES (?, ?, ?, ?, 'pending', ?, ?, ?)`,
+		id, itemID, uuid.New(), quantity, now.Add(-time.Hour), now.Add(-2*time.Hour), now.Add(-2*time.Hour)).Error
+	require.NoError(t, err)
+}
+
+func reconcileAudit() *entity.AdminAuditEntry {
+	return &entity.AdminAuditEntry{
+		ID:        uuid.New(),
+		Actor:     "reconciler",
+		Method:    "RECONCILE",
+		Route:     "reconcile/stuck_reservation",
+		Path:      "reconcile/stuck_reservation",
+		CreatedAt: time.Now().UTC(),
+	}
+}
+
+func TestReconciliationRepositoryImpl_ExpireStuckReservation_SerialUnits(t *testing.T) {
+	db, cleanup := setupMigratedTestDB(t)
+	defer cleanup()
+
+	repo := NewReconciliationRepository(db)
+	serials := NewSerialUnitRepository(db)
+	ctx := context.Background()
+	item := insertSerialTrackedItem(t, db, "SN-1", "SN-2", "SN-3")
+
+	reservationID := uuid.New()
+	_, _, err := serials.Reserve(ctx, reservationID, item.ProductID, 2)
+	require.NoError(t, err)
+	insertStuckReservation(t, db, reservationID, item.ID, 2)
+
+	applied, err := repo.ExpireStuckReservation(ctx, reservationID, time.Now().UTC(), reconcileAudit())
+	require.NoError(t, err)
+	assert.True(t, applied)
+
+	stored, err := NewInventoryRepository(db).FindByID(ctx, item.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 0, stored.Reserved)
+
+	available, err := serials.FindByInventoryItemID(ctx, item.ID, entity.SerialAvailable)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"SN-1", "SN-2", "SN-3"}, entity.SerialNumbers(available), "the units match the reserved count again")
+	for _, unit := range available {
+		assert.Nil(t, unit.ReservationID)
+	}
+
+	_, units, err := serials.Reserve(ctx, uuid.New(), item.ProductID, 3)
+	require.NoError(t, err, "the released units can be reserved again")
+	assert.Len(t, units, 3)
+}
+
+// insertSettledReservation writes a reservation with the given status, last touched an hour ago
+func insertSettledReservation(t *testing.T, db *gorm.DB, id, itemID uuid.UUID, quantity int, status entity.ReservationStatus) {
+	now := time.Now().UTC()
+	err := db.Exec(`INSERT INTO reservations (id, inventory_item_id, order_id, quantity, status, expires_at, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		id, itemID, uuid.New(), quantity, string(status), now.Add(time.Hour), now.Add(-2*time.Hour), now.Add(-time.Hour)).Error
+	require.NoError(t, err)
+}
+
+func lotsByID(t *testing.T, repo *LotRepositoryImpl, itemID uuid.UUID) map[uuid.UUID]*entity.Lot {
+	lots, err := repo.FindByInventoryItemID(context.Background(), itemID)
+	require.NoError(t, err)
+	byID := make(map[uuid.UUID]*entity.Lot, len(lots))
+	for _, lot := range lots {
+		byID[lot.ID] = lot
+	}
+	return byID
+}
+
+func TestReconciliationRepositoryImpl_ExpireStuckReservation_Lots(t *testing.T) {
+	db, cleanup := setupMigratedTestDB(t)
+	defer cleanup()
+
+	repo := NewReconciliationRepository(db)
+	lots := NewLotRepository(db)
+	ctx := context.Background()
+	item := insertLotItem(t, db, 0)
+	lot := receiveLot(t, lots, item.ID, "L-001", 10, time.Now().Add(-48*time.Hour), nil)
+
+	reservationID := uuid.New()
+	_, err := lots.Allocate(ctx, reservationID, item.ID, 4, time.Now())
+	require.NoError(t, err)
+	insertStuckReservation(t, db, reservationID, item.ID, 4)
+
+	applied, err := repo.ExpireStuckReservation(ctx, reservationID, time.Now().UTC(), reconcileAudit())
+	require.NoError(t, err)
+	assert.True(t, applied)
+
+	stored := lotsByID(t, lots, item.ID)[lot.ID]
+	assert.Equal(t, 10, stored.Quantity)
+	assert.Equal(t, 0, stored.Reserved, "the lot units are returned with the reservation")
+
+	history, err := lots.FindAllocations(ctx, reservationID)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	assert.Equal(t, entity.LotReleased, history[0].Status)
+}
+
+func TestReconciliationRepositoryImpl_StaleLotAllocations(t *testing.T) {
+	db, cleanup := setupMigratedTestDB(t)
+	defer cleanup()
+
+	repo := NewReconciliationRepository(db)
+	lots := NewLotRepository(db)
+	ctx := context.Background()
+	item := insertLotItem(t, db, 0)
+	lot := receiveLot(t, lots, item.ID, "L-001", 10, time.Now().Add(-48*time.Hour), nil)
+	allocatedAt := time.Now().Add(-2 * time.Hour)
+
+	// The lot calls after the stock transactions failed, so every allocation is still held
+	confirmed, released, pending := uuid.New(), uuid.New(), uuid.New()
+	for _, id := range []uuid.UUID{confirmed, released, pending} {
+		_, err := lots.Allocate(ctx, id, item.ID, 2, allocatedAt)
+		require.NoError(t, err)
+	}
+	insertSettledReservation(t, db, confirmed, item.ID, 2, entity.ReservationConfirmed)
+	insertSettledReservation(t, db, released, item.ID, 2, entity.ReservationReleased)
+	insertSettledReservation(t, db, pending, item.ID, 2, entity.ReservationPending)
+
+	settledBefore := time.Now().UTC().Add(-time.Minute)
+	drifts, err := repo.FindStaleLotAllocations(ctx, settledBefore)
+	require.NoError(t, err)
+	require.Len(t, drifts, 2, "allocations of pending reservations are not stale")
+	details := map[uuid.UUID]string{}
+	for _, drift := range drifts {
+		assert.Equal(t, item.ID, drift.InventoryItemID)
+		assert.Equal(t, 2, drift.Recorded)
+		details[drift.ReservationID] = drift.Detail
+	}
+	assert.Equal(t, "reservation confirmed", details[confirmed])
+	assert.Equal(t, "reservation released", details[released])
+
+	for _, drift := range drifts {
+		applied, err := repo.SettleStaleLotAllocations(ctx, drift.ReservationID, drift.InventoryItemID, settledBefore, reconcileAudit())
+		require.NoError(t, err)
+		assert.True(t, applied)
+	}
+	applied, err := repo.SettleStaleLotAllocations(ctx, pending, item.ID, settledBefore, reconcileAudit())
+	require.NoError(t, err)
+	assert.False(t, applied, "pending reservations keep their lots")
+
+	stored := lotsByID(t, lots, item.ID)[lot.ID]
+	assert.Equal(t, 8, stored.Quantity, "the confirmed units left the lot")
+	assert.Equal(t, 2, stored.Reserved, "only the pending reservation holds lot units")
+
+	history, err := lots.FindAllocations(ctx, confirmed)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	assert.Equal(t, entity.LotConsumed, history[0].Status)
+	history, err = lots.FindAllocations(ctx, released)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	assert.Equal(t, entity.LotReleased, history[0].Status)
+
+	drifts, err = repo.FindStaleLotAllocations(ctx, settledBefore)
+	require.NoError(t, err)
+	assert.Empty(t, drifts)
+}
+
+func TestReconciliationRepositoryImpl_MissingLotAllocations(t *testing.T) {
+	db, cleanup := setupMigratedTestDB(t)
+	defer cleanup()
+
+	repo := NewReconciliationRepository(db)
+	lots := NewLotRepository(db)
+	ctx := context.Background()
+	item := insertLotItem(t, db, 0)
+	early := receiveLot(t, lots, item.ID, "EARLY", 3, time.Now().Add(-48*time.Hour), date(2030, time.June, 1))
+	late := receiveLot(t, lots, item.ID, "LATE", 10, time.Now().Add(-48*time.Hour), date(2031, time.June, 1))
+
+	// The allocation after the stock transaction failed
+	unallocated, allocated := uuid.New(), uuid.New()
+	insertSettledReservation(t, db, unallocated, item.ID, 5, entity.ReservationPending)
+	insertSettledReservation(t, db, allocated, item.ID, 1, entity.ReservationPending)
+	_, err := lots.Allocate(ctx, allocated, item.ID, 1, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+
+	settledBefore := time.Now().UTC().Add(-time.Minute)
+	drifts, err := repo.FindMissingLotAllocations(ctx, settledBefore)
+	require.NoError(t, err)
+	require.Len(t, drifts, 1)
+	assert.Equal(t, unallocated, drifts[0].ReservationID)
+	assert.Equal(t, 0, drifts[0].Recorded)
+	assert.Equal(t, 5, drifts[0].Expected)
+
+	applied, err := repo.AllocateMissingLots(ctx, unallocated, item.ID, settledBefore, reconcileAudit())
+	require.NoError(t, err)
+	assert.True(t, applied)
+	applied, err = repo.AllocateMissingLots(ctx, unallocated, item.ID, settledBefore, reconcileAudit())
+	require.NoError(t, err)
+	assert.False(t, applied, "the reservation is allocated once")
+
+	byID := lotsByID(t, lots, item.ID)
+	assert.Equal(t, 3, byID[early.ID].Reserved, "lots are allocated FEFO")
+	assert.Equal(t, 3, byID[late.ID].Reserved)
+
+	drifts, err = repo.FindMissingLotAllocations(ctx, settledBefore)
+	require.NoError(t, err)
+	assert.Empty(t, drifts)
+}
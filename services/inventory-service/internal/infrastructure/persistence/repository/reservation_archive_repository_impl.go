@@ -0,0 +1,277 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	domainErrors "github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+	domainRepository "github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/repository"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/infrastructure/persistence/model"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Partitions of the reservations table, see migration 006.
+// Monthly partitions are named after the UTC month they hold, e.g. reservations_p202511.
+const (
+	reservationPartitionPrefix = "reservations_p"
+	reservationPartitionLayout = "200601"
+	reservationDefaultTable    = "reservations_default"
+
+	// partitionLockTimeout bounds how long creating or dropping a partition waits for
+	// the lock on reservations, so maintenance never queues behind the hot path for long
+	partitionLockTimeout = "5s"
+)
+
+const (
+	// archiveTerminalSQL moves one batch of terminal reservations in a single statement.
+	// SKIP LOCKED leaves rows that a concurrent writer holds for the next batch.
+	archiveTerminalSQL = `WITH batch AS (
+			SELECT id, created_at FROM reservations
+			WHERE status <> 'pending' AND updated_at < ?
+			ORDER BY updated_at
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		), moved AS (
+			DELETE FROM reservations r USING batch b
+			WHERE r.id = b.id AND r.created_at = b.created_at
+			RETURNING r.id, r.inventory_item_id, r.order_id, r.quantity, r.status, r.expires_at, r.created_at, r.updated_at, r.channel, r.user_id
+		)
+		INSERT INTO reservations_archive (id, inventory_item_id, order_id, quantity, status, expires_at, created_at, updated_at, channel, user_id, archived_at)
+		SELECT id, inventory_item_id, order_id, quantity, status, expires_at, created_at, updated_at, channel, user_id, ?
+		FROM moved`
+
+	listReservationPartitionsSQL = `SELECT c.relname FROM pg_inherits i
+		JOIN pg_class c ON c.oid = i.inhrelid
+		WHERE i.inhparent = 'reservations'::regclass`
+
+	// Partition DDL does not accept bind parameters; names and bounds are built
+	// by ReservationPartitionName and formatPartitionBound only
+	createReservationPartitionSQL = `CREATE TABLE IF NOT EXISTS %s PARTITION OF reservations FOR VALUES FROM ('%s') TO ('%s')`
+	dropReservationPartitionSQL   = `DROP TABLE IF EXISTS %s`
+)
+
+// ReservationArchiveRepositoryImpl is the GORM implementation of
+// ReservationArchiveRepository and ReservationPartitionRepository
+type ReservationArchiveRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewReservationArchiveRepository creates a new instance of ReservationArchiveRepositoryImpl
+func NewReservationArchiveRepository(db *gorm.DB) *ReservationArchiveRepositoryImpl {
+	return &ReservationArchiveRepositoryImpl{
+		db: db,
+	}
+}
+
+// ArchiveTerminal moves up to limit terminal reservations last updated before cutoff to the archive
+func (r *ReservationArchiveRepositoryImpl) ArchiveTerminal(ctx context.Context, cutoff time.Time, limit int) (int, error) {
+	if limit <= 0 {
+		return 0, nil
+	}
+
+	result := r.db.WithContext(ctx).Exec(archiveTerminalSQL, cutoff.UTC(), limit, time.Now().UTC())
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to archive reservations: %w", result.Error)
+	}
+
+	return int(result.RowsAffected), nil
+}
+
+// ListArchived returns archived reservations matching the filter, newest first, and the total number of matches
+func (r *ReservationArchiveRepositoryImpl) ListArchived(ctx context.Context, filter domainRepository.ArchivedReservationFilter) ([]*entity.ArchivedReservation, int64, error) {
+	query := r.db.WithContext(ctx).Model(&model.ArchivedReservationModel{})
+
+	if filter.OrderID != uuid.Nil {
+		query = query.Where("order_id = ?", filter.OrderID)
+	}
+	if filter.InventoryItemID != uuid.Nil {
+		query = query.Where("inventory_item_id = ?", filter.InventoryItemID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", string(filter.Status))
+	}
+	if !filter.From.IsZero() {
+		query = query.Where("created_at >= ?", filter.From.UTC())
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("created_at < ?", filter.To.UTC())
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count archived reservations: %w", err)
+	}
+
+	var models []model.ArchivedReservationModel
+	query = query.Order("created_at DESC").Offset(filter.Offset)
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+	if err := query.Find(&models).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to list archived reservations: %w", err)
+	}
+
+	reservations := make([]*entity.ArchivedReservation, len(models))
+	for i := range models {
+		reservations[i] = models[i].ToEntity()
+	}
+
+	return reservations, total, nil
+}
+
+// FindArchivedByID retrieves an archived reservation
+func (r *ReservationArchiveRepositoryImpl) FindArchivedByID(ctx context.Context, id uuid.UUID) (*entity.ArchivedReservation, error) {
+	var archived model.ArchivedReservationModel
+
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&archived).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domainErrors.ErrReservationNotFound
+		}
+		return nil, fmt.Errorf("failed to find archived reservation: %w", err)
+	}
+
+	return archived.ToEntity(), nil
+}
+
+// EnsurePartitions creates the missing monthly partitions from the month of from up to monthsAhead months later
+func (r *ReservationArchiveRepositoryImpl) EnsurePartitions(ctx context.Context, from time.Time, monthsAhead int) ([]string, error) {
+	existing, err := r.monthlyPartitions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	created := make([]string, 0)
+	first := monthStart(from)
+	for i := 0; i <= monthsAhead; i++ {
+		month := first.AddDate(0, i, 0)
+		name := ReservationPartitionName(month)
+		if _, ok := existing[name]; ok {
+			continue
+		}
+
+		// PostgreSQL refuses to create a partition while the default partition holds
+		// rows of its range; those rows stay where they are and the month is skipped
+		var stranded bool
+		err := r.db.WithContext(ctx).
+			Raw("SELECT EXISTS (SELECT 1 FROM "+reservationDefaultTable+" WHERE created_at >= ? AND created_at < ?)", month, month.AddDate(0, 1, 0)).
+			Scan(&stranded).Error
+		if err != nil {
+			return created, fmt.Errorf("failed to check the default reservation partition: %w", err)
+		}
+		if stranded {
+			continue
+		}
+
+		ddl := fmt.Sprintf(createReservationPartitionSQL, name, formatPartitionBound(month), formatPartitionBound(month.AddDate(0, 1, 0)))
+		if err := r.withLockTimeout(ctx, ddl); err != nil {
+			return created, fmt.Errorf("failed to create reservation partition %s: %w", name, err)
+		}
+		created = append(created, name)
+	}
+
+	return created, nil
+}
+
+// DropEmptyPartitions drops the monthly partitions that end before cutoff and hold no rows
+func (r *ReservationArchiveRepositoryImpl) DropEmptyPartitions(ctx context.Context, cutoff time.Time) ([]string, error) {
+	existing, err := r.monthlyPartitions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(existing))
+	for name, month := range existing {
+		if !month.AddDate(0, 1, 0).After(cutoff) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	dropped := make([]string, 0)
+	for _, name := range names {
+		var hasRows bool
+		if err := r.db.WithContext(ctx).Raw("SELECT EXISTS (SELECT 1 FROM " + name + ")").Scan(&hasRows).Error; err != nil {
+			return dropped, fmt.Errorf("failed to check reservation partition %s: %w", name, err)
+		}
+		if hasRows {
+			continue
+		}
+
+		if err := r.withLockTimeout(ctx, fmt.Sprintf(dropReservationPartitionSQL, name)); err != nil {
+			return dropped, fmt.Errorf("failed to drop reservation partition %s: %w", name, err)
+		}
+		dropped = append(dropped, name)
+	}
+
+	return dropped, nil
+}
+
+// CountDefaultPartitionRows returns the number of reservations outside the monthly partitions
+func (r *ReservationArchiveRepositoryImpl) CountDefaultPartitionRows(ctx context.Context) (int64, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Raw("SELECT COUNT(*) FROM " + reservationDefaultTable).Scan(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count the default reservation partition: %w", err)
+	}
+	return count, nil
+}
+
+// monthlyPartitions returns the monthly partitions of reservations and the month each one holds
+func (r *ReservationArchiveRepositoryImpl) monthlyPartitions(ctx context.Context) (map[string]time.Time, error) {
+	var names []string
+	if err := r.db.WithContext(ctx).Raw(listReservationPartitionsSQL).Scan(&names).Error; err != nil {
+		return nil, fmt.Errorf("failed to list reservation partitions: %w", err)
+	}
+
+	partitions := make(map[string]time.Time, len(names))
+	for _, name := range names {
+		if month, ok := parseReservationPartitionName(name); ok {
+			partitions[name] = month
+		}
+	}
+	return partitions, nil
+}
+
+// withLockTimeout runs a DDL statement in a transaction that gives up waiting for locks after partitionLockTimeout
+func (r *ReservationArchiveRepositoryImpl) withLockTimeout(ctx context.Context, ddl string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SET LOCAL lock_timeout = '" + partitionLockTimeout + "'").Error; err != nil {
+			return err
+		}
+		return tx.Exec(ddl).Error
+	})
+}
+
+// ReservationPartitionName returns the name of the partition holding the reservations created in the UTC month of t
+func ReservationPartitionName(t time.Time) string {
+	return reservationPartitionPrefix + monthStart(t).Format(reservationPartitionLayout)
+}
+
+// parseReservationPartitionName returns the month held by a monthly partition
+func parseReservationPartitionName(name string) (time.Time, bool) {
+	suffix, ok := strings.CutPrefix(name, reservationPartitionPrefix)
+	if !ok || len(suffix) != len(reservationPartitionLayout) {
+		return time.Time{}, false
+	}
+	month, err := time.Parse(reservationPartitionLayout, suffix)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return month, true
+}
+
+// monthStart returns the first instant of the UTC month of t
+func monthStart(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// formatPartitionBound formats a partition bound as a created_at literal
+func formatPartitionBound(t time.Time) string {
+	return t.Format("2006-01-02 15:04:05")
+}
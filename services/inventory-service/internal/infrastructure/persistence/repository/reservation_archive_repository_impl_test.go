@@ -0,0 +1,236 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang-migrate/migrate/v4"
+	_ "github.com/golang-migrate/migrate/v4/database/postgres"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	testcontainerspostgres "github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	domainErrors "github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+	domainRepository "github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/repository"
+)
+
+func TestReservationPartitionName(t *testing.T) {
+	assert.Equal(t, "reservations_p202511", ReservationPartitionName(time.Date(2025, 11, 30, 23, 59, 0, 0, time.UTC)))
+	// Months are UTC months
+	buenosAires := time.FixedZone("ART", -3*60*60)
+	assert.Equal(t, "reservations_p202601", ReservationPartitionName(time.Date(2025, 12, 31, 22, 0, 0, 0, buenosAires)))
+
+	month, ok := parseReservationPartitionName("reservations_p202602")
+	require.True(t, ok)
+	assert.Equal(t, time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), month)
+
+	for _, name := range []string{"reservations_default", "reservations_p2026", "reservations_p202613", "reservations_archive"} {
+		_, ok := parseReservationPartitionName(name)
+		assert.False(t, ok, name)
+	}
+}
+
+// setupMigratedTestDB starts PostgreSQL and applies the SQL migrations, which
+// create the partitioned reservations table
+func setupMigratedTestDB(t *testing.T) (*gorm.DB, func()) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+	ctx := context.Background()
+
+	container, err := testcontainerspostgres.Run(ctx,
+		"postgres:16-alpine",
+		testcontainerspostgres.WithDatabase("testdb"),
+		testcontainerspostgres.WithUsername("testuser"),
+		testcontainerspostgres.WithPassword("testpass"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").WithOccurrence(2),
+		),
+	)
+	require.NoError(t, err)
+
+	connStr, err := container.ConnectionString(ctx, "sslmode=disable")
+	require.NoError(t, err)
+
+	m, err := migrate.New("file://../../../../migrations", connStr)
+	require.NoError(t, err)
+	require.NoError(t, m.Up())
+	_, _ = m.Close()
+
+	db, err := gorm.Open(postgres.Open(connStr), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+
+	cleanup := func() {
+		if sqlDB, _ := db.DB(); sqlDB != nil {
+			sqlDB.Close()
+		}
+		container.Terminate(ctx)
+	}
+	return db, cleanup
+}
+
+// insertReservationRow writes a reservation with explicit timestamps
+func insertReservationRow(t *testing.T, db *gorm.DB, itemID uuid.UUID, status entity.ReservationStatus, createdAt, updatedAt time.Time) uuid.UUID {
+	id := uuid.New()
+	err := db.Exec(`INSERT INTO reservations (id, inventory_item_id, order_id, quantity, status, expires_at, created_at, updated_at)
+		VALUES (?, ?, ?, 1, ?, ?, ?, ?)`, id, itemID, uuid.New(), string(status), createdAt.Add(15*time.Minute), createdAt, updatedAt).Error
+	require.NoError(t, err)
+	return id
+}
+
+func TestReservationArchiveRepositoryImpl_ArchiveTerminal(t *testing.T) {
+	db, cleanup := setupMigratedTestDB(t)
+	defer cleanup()
+
+	repo := NewReservationArchiveRepository(db)
+	reservations := NewReservationRepository(db)
+	ctx := context.Background()
+	now := time.Now().UTC()
+	old := now.AddDate(0, 0, -120)
+
+	itemID := uuid.New()
+	require.NoError(t, db.Exec(`INSERT INTO inventory_items (id, product_id, quantity, reserved, version, created_at, updated_at)
+		VALUES (?, ?, 100, 1, 1, ?, ?)`, itemID, uuid.New(), old, old).Error)
+	_, err := repo.EnsurePartitions(ctx, old, 5)
+	require.NoError(t, err)
+
+	confirmed := insertReservationRow(t, db, itemID, entity.ReservationConfirmed, old, old)
+	released := insertReservationRow(t, db, itemID, entity.ReservationReleased, old, old.Add(time.Hour))
+	expired := insertReservationRow(t, db, itemID, entity.ReservationExpired, old, old.Add(2*time.Hour))
+	recent := insertReservationRow(t, db, itemID, entity.ReservationConfirmed, now, now)
+	pending := insertReservationRow(t, db, itemID, entity.ReservationPending, old, old)
+
+	cutoff := now.AddDate(0, 0, -90)
+
+	// Act: oldest first, bounded by the limit
+	moved, err := repo.ArchiveTerminal(ctx, cutoff, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 2, moved)
+	moved, err = repo.ArchiveTerminal(ctx, cutoff, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 1, moved)
+	moved, err = repo.ArchiveTerminal(ctx, cutoff, 2)
+	require.NoError(t, err)
+	assert.Zero(t, moved)
+
+	// Assert: moved out of reservations and into the archive
+	for _, id := range []uuid.UUID{confirmed, released, expired} {
+		_, err := reservations.FindByID(ctx, id)
+		assert.ErrorIs(t, err, domainErrors.ErrReservationNotFound)
+
+		archived, err := repo.FindArchivedByID(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, itemID, archived.InventoryItemID)
+		assert.False(t, archived.ArchivedAt.IsZero())
+	}
+	for _, id := range []uuid.UUID{recent, pending} {
+		_, err := reservations.FindByID(ctx, id)
+		assert.NoError(t, err, "recent and pending reservations stay")
+	}
+
+	_, err = repo.FindArchivedByID(ctx, recent)
+	assert.ErrorIs(t, err, domainErrors.ErrReservationNotFound)
+
+	list, total, err := repo.ListArchived(ctx, domainRepository.ArchivedReservationFilter{
+		InventoryItemID: itemID,
+		Status:          entity.ReservationReleased,
+		Limit:           10,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	require.Len(t, list, 1)
+	assert.Equal(t, released, list[0].ID)
+
+	// The order ID of an archived reservation is free again
+	archived, err := repo.FindArchivedByID(ctx, confirmed)
+	require.NoError(t, err)
+	again, err := entity.NewReservation(itemID, archived.OrderID, 1)
+	require.NoError(t, err)
+	assert.NoError(t, reservations.Save(ctx, again))
+}
+
+func TestReservationArchiveRepositoryImpl_OrderIDStaysUnique(t *testing.T) {
+	db, cleanup := setupMigratedTestDB(t)
+	defer cleanup()
+
+	reservations := NewReservationRepository(db)
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	itemID := uuid.New()
+	require.NoError(t, db.Exec(`INSERT INTO inventory_items (id, product_id, quantity, reserved, version, created_at, updated_at)
+		VALUES (?, ?, 100, 0, 1, ?, ?)`, itemID, uuid.New(), now, now).Error)
+
+	orderID := uuid.New()
+	first, err := entity.NewReservation(itemID, orderID, 1)
+	require.NoError(t, err)
+	require.NoError(t, reservations.Save(ctx, first))
+
+	// A second reservation for the same order lands in another partition and is still rejected
+	second, err := entity.NewReservation(itemID, orderID, 1)
+	require.NoError(t, err)
+	second.CreatedAt = now.AddDate(0, -1, 0)
+	err = db.Exec(`INSERT INTO reservations (id, inventory_item_id, order_id, quantity, status, expires_at, created_at, updated_at)
+		VALUES (?, ?, ?, 1, 'pending', ?, ?, ?)`, second.ID, itemID, orderID, second.ExpiresAt, second.CreatedAt, second.CreatedAt).Error
+	assert.Error(t, err)
+
+	// Deleting frees the order ID
+	require.NoError(t, reservations.Delete(ctx, first.ID))
+	assert.NoError(t, reservations.Save(ctx, second))
+}
+
+func TestReservationArchiveRepositoryImpl_Partitions(t *testing.T) {
+	db, cleanup := setupMigratedTestDB(t)
+	defer cleanup()
+
+	repo := NewReservationArchiveRepository(db)
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	// The migration already covers the current month and three more
+	created, err := repo.EnsurePartitions(ctx, now, 3)
+	require.NoError(t, err)
+	assert.Empty(t, created)
+
+	created, err = repo.EnsurePartitions(ctx, now, 5)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		ReservationPartitionName(now.AddDate(0, 4, 0)),
+		ReservationPartitionName(now.AddDate(0, 5, 0)),
+	}, created)
+
+	// A month whose rows went to the default partition is skipped and reported
+	itemID := uuid.New()
+	require.NoError(t, db.Exec(`INSERT INTO inventory_items (id, product_id, quantity, reserved, version, created_at, updated_at)
+		VALUES (?, ?, 100, 0, 1, ?, ?)`, itemID, uuid.New(), now, now).Error)
+	stranded := monthStart(now).AddDate(1, 0, 0)
+	insertReservationRow(t, db, itemID, entity.ReservationConfirmed, stranded, stranded)
+	count, err := repo.CountDefaultPartitionRows(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+	created, err = repo.EnsurePartitions(ctx, stranded, 0)
+	require.NoError(t, err)
+	assert.Empty(t, created)
+
+	// Old empty partitions are dropped; partitions with rows are kept
+	old := monthStart(now).AddDate(0, -3, 0)
+	created, err = repo.EnsurePartitions(ctx, old, 1)
+	require.NoError(t, err)
+	assert.Len(t, created, 2)
+	insertReservationRow(t, db, itemID, entity.ReservationPending, old, old)
+
+	dropped, err := repo.DropEmptyPartitions(ctx, monthStart(now))
+	require.NoError(t, err)
+	assert.Equal(t, []string{ReservationPartitionName(old.AddDate(0, 1, 0))}, dropped)
+}
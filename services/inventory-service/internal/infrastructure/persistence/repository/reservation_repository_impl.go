@@ -59,9 +59,14 @@ func (r *ReservationRepositoryImpl) FindByOrderID(ctx context.Context, orderID u
 
 // Save creates a new reservation in the repository
 func (r *ReservationRepositoryImpl) Save(ctx context.Context, reservation *entity.Reservation) error {
+	return createReservation(r.db.WithContext(ctx), reservation)
+}
+
+// createReservation inserts a reservation, also within a transaction
+func createReservation(db *gorm.DB, reservation *entity.Reservation) error {
 	reservationModel := model.NewReservationModelFromEntity(reservation)
 
-	result := r.db.WithContext(ctx).Create(reservationModel)
+	result := db.Create(reservationModel)
 	if result.Error != nil {
 		// Check for unique constraint violation on order_id (PostgreSQL error code 23505)
 		var pgErr *pgconn.PgError
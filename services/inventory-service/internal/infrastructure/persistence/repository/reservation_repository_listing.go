@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	domainRepository "github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/repository"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/infrastructure/persistence/model"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ListReservations returns a page of reservations using keyset pagination
+func (r *ReservationRepositoryImpl) ListReservations(ctx context.Context, filter domainRepository.ReservationListFilter) ([]*entity.Reservation, error) {
+	query := applyKeyset(r.reservationListQuery(ctx, filter), reservationSortColumn(filter.SortBy), filter.Direction, filter.After, filter.Limit)
+
+	var reservationModels []model.ReservationModel
+	if err := query.Find(&reservationModels).Error; err != nil {
+		return nil, fmt.Errorf("failed to list reservations: %w", err)
+	}
+
+	reservations := make([]*entity.Reservation, len(reservationModels))
+	for i, reservationModel := range reservationModels {
+		reservations[i] = reservationModel.ToEntity()
+	}
+
+	return reservations, nil
+}
+
+// CountReservations counts the reservations matching the filter
+func (r *ReservationRepositoryImpl) CountReservations(ctx context.Context, filter domainRepository.ReservationListFilter) (int64, error) {
+	var count int64
+	if err := r.reservationListQuery(ctx, filter).Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count reservations: %w", err)
+	}
+	return count, nil
+}
+
+// EstimateReservations returns the planner's estimate of the reservations matching the filter
+func (r *ReservationRepositoryImpl) EstimateReservations(ctx context.Context, filter domainRepository.ReservationListFilter) (int64, error) {
+	count, err := estimateRows(ctx, r.db, r.reservationListQuery(ctx, filter), &[]model.ReservationModel{})
+	if err != nil {
+		return 0, fmt.Errorf("failed to estimate reservations: %w", err)
+	}
+	return count, nil
+}
+
+// reservationListQuery applies the filters of a listing, without cursor or order.
+// Bounds on created_at also prune the monthly partitions.
+func (r *ReservationRepositoryImpl) reservationListQuery(ctx context.Context, filter domainRepository.ReservationListFilter) *gorm.DB {
+	query := r.db.WithContext(ctx).Model(&model.ReservationModel{})
+
+	if filter.Status != "" {
+		query = query.Where("status = ?", string(filter.Status))
+	}
+	if filter.OrderID != uuid.Nil {
+		query = query.Where("order_id = ?", filter.OrderID)
+	}
+	if filter.InventoryItemID != uuid.Nil {
+		query = query.Where("inventory_item_id = ?", filter.InventoryItemID)
+	}
+	if filter.ProductID != uuid.Nil {
+		// product_id is unique, so this resolves to one inventory_item_id lookup
+		query = query.Where("inventory_item_id = (SELECT id FROM inventory_items WHERE product_id = ?)", filter.ProductID)
+	}
+	if !filter.ExpiresFrom.IsZero() {
+		query = query.Where("expires_at >= ?", filter.ExpiresFrom.UTC())
+	}
+	if !filter.ExpiresTo.IsZero() {
+		query = query.Where("expires_at < ?", filter.ExpiresTo.UTC())
+	}
+	if !filter.CreatedFrom.IsZero() {
+		query = query.Where("created_at >= ?", filter.CreatedFrom.UTC())
+	}
+	if !filter.CreatedTo.IsZero() {
+		query = query.Where("created_at < ?", filter.CreatedTo.UTC())
+	}
+
+	return query
+}
+
+// reservationSortColumn maps a sort field to its column
+func reservationSortColumn(field domainRepository.ReservationSortField) string {
+	switch field {
+	case domainRepository.ReservationSortUpdatedAt:
+		return "updated_at"
+	case domainRepository.ReservationSortExpiresAt:
+		return "expires_at"
+	default:
+		return "created_at"
+	}
+}
@@ -0,0 +1,361 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	domainErrors "github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/infrastructure/persistence/model"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Serial unit table, see migration 010. Every transaction locks the inventory item
+// before its units, so concurrent reservations of the same item are serialized and
+// its counts always match the states of its units.
+const (
+	serialOrderSQL = "created_at ASC, serial_number ASC"
+
+	// itemStockSQL adds deltas to quantity and reserved of a locked item
+	itemStockSQL = `UPDATE inventory_items
+		SET quantity = quantity + ?, reserved = reserved + ?, version = version + 1, updated_at = ?
+		WHERE id = ?
+		RETURNING *`
+)
+
+// SerialUnitRepositoryImpl is the GORM implementation of SerialUnitRepository
+type SerialUnitRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewSerialUnitRepository creates a new instance of SerialUnitRepositoryImpl
+func NewSerialUnitRepository(db *gorm.DB) *SerialUnitRepositoryImpl {
+	return &SerialUnitRepositoryImpl{
+		db: db,
+	}
+}
+
+// Register saves new available units and adds them to the quantity of their item
+func (r *SerialUnitRepositoryImpl) Register(ctx context.Context, inventoryItemID uuid.UUID, units []*entity.SerialUnit) (*entity.InventoryItem, error) {
+	if len(units) == 0 {
+		return nil, domainErrors.ErrInvalidQuantity
+	}
+
+	var updated *entity.InventoryItem
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if _, err := lockSerialTrackedItem(tx, "id = ?", inventoryItemID); err != nil {
+			return err
+		}
+
+		serialNumbers := entity.SerialNumbers(units)
+		var existing []string
+		if err := tx.Model(&model.SerialUnitModel{}).
+			Where("inventory_item_id = ? AND serial_number IN ?", inventoryItemID, serialNumbers).
+			Order("serial_number").
+			Pluck("serial_number", &existing).Error; err != nil {
+			return fmt.Errorf("failed to check serial numbers: %w", err)
+		}
+		if len(existing) > 0 {
+			return domainErrors.ErrSerialUnitAlreadyExists.WithDetails(strings.Join(existing, ", "))
+		}
+
+		unitModels := make([]*model.SerialUnitModel, len(units))
+		for i, unit := range units {
+			unitModels[i] = model.NewSerialUnitModelFromEntity(unit)
+		}
+		if err := tx.Create(&unitModels).Error; err != nil {
+			if containsSerialConstraintViolation(err.Error()) {
+				return domainErrors.ErrSerialUnitAlreadyExists
+			}
+			return fmt.Errorf("failed to save serial units: %w", err)
+		}
+
+		var err error
+		updated, err = adjustItemStock(tx, inventoryItemID, len(units), 0)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// containsSerialConstraintViolation checks if error message contains PostgreSQL duplicate key constraint for serial units
+func containsSerialConstraintViolation(errMsg string) bool {
+	return strings.Contains(errMsg, "duplicate key value violates unique constraint") &&
+		(strings.Contains(errMsg, "uq_serial_units_item_serial") || strings.Contains(errMsg, "SQLSTATE 23505"))
+}
+
+// Reserve reserves the oldest available units of the product's item for the reservation
+func (r *SerialUnitRepositoryImpl) Reserve(ctx context.Context, reservationID, productID uuid.UUID, quantity int) (*entity.InventoryItem, []*entity.SerialUnit, error) {
+	if quantity <= 0 {
+		return nil, nil, domainErrors.ErrInvalidQuantity
+	}
+
+	var updated *entity.InventoryItem
+	var units []*entity.SerialUnit
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		item, err := lockSerialTrackedItem(tx, "product_id = ?", productID)
+		if err != nil {
+			return err
+		}
+		if item.IsArchived() {
+			return domainErrors.ErrInventoryItemArchived
+		}
+
+		units, err = lockUnits(tx.Limit(quantity), "inventory_item_id = ? AND status = ?", item.ID, entity.SerialAvailable)
+		if err != nil {
+			return err
+		}
+		if len(units) < quantity {
+			return domainErrors.ErrInsufficientStock
+		}
+
+		now := time.Now().UTC()
+		if err := saveUnitStates(tx, units, func(unit *entity.SerialUnit) error {
+			return unit.Reserve(reservationID, now)
+		}); err != nil {
+			return err
+		}
+
+		updated, err = adjustItemStock(tx, item.ID, 0, quantity)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return updated, units, nil
+}
+
+// Confirm sells the units held by the reservation and removes them from the stock
+func (r *SerialUnitRepositoryImpl) Confirm(ctx context.Context, reservationID, inventoryItemID uuid.UUID, quantity int) (*entity.InventoryItem, []*entity.SerialUnit, error) {
+	return r.settle(ctx, reservationID, inventoryItemID, quantity, domainErrors.ErrInvalidReservationConfirm, -quantity,
+		func(unit *entity.SerialUnit, at time.Time) error { return unit.Sell(at) })
+}
+
+// Release makes the units held by the reservation available again
+func (r *SerialUnitRepositoryImpl) Release(ctx context.Context, reservationID, inventoryItemID uuid.UUID, quantity int) (*entity.InventoryItem, []*entity.SerialUnit, error) {
+	return r.settle(ctx, reservationID, inventoryItemID, quantity, domainErrors.ErrInvalidReservationRelease, 0,
+		func(unit *entity.SerialUnit, at time.Time) error { return unit.Release(at) })
+}
+
+// settle moves the units a reservation holds out of the reserved state, removes
+// them from the item's reserved units and adds quantityDelta to its quantity.
+// mismatch is returned when the reservation does not hold exactly quantity units.
+func (r *SerialUnitRepositoryImpl) settle(
+	ctx context.Context,
+	reservationID, inventoryItemID uuid.UUID,
+	quantity int,
+	mismatch *domainErrors.DomainError,
+	quantityDelta int,
+	move func(unit *entity.SerialUnit, at time.Time) error,
+) (*entity.InventoryItem, []*entity.SerialUnit, error) {
+	if quantity <= 0 {
+		return nil, nil, domainErrors.ErrInvalidQuantity
+	}
+
+	var updated *entity.InventoryItem
+	var units []*entity.SerialUnit
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if _, err := lockSerialTrackedItem(tx, "id = ?", inventoryItemID); err != nil {
+			return err
+		}
+
+		var err error
+		units, err = lockUnits(tx, "inventory_item_id = ? AND reservation_id = ? AND status = ?",
+			inventoryItemID, reservationID, entity.SerialReserved)
+		if err != nil {
+			return err
+		}
+		if len(units) != quantity {
+			return mismatch.WithDetails(fmt.Sprintf("the reservation holds %d serial units, not %d", len(units), quantity))
+		}
+
+		now := time.Now().UTC()
+		if err := saveUnitStates(tx, units, func(unit *entity.SerialUnit) error {
+			return move(unit, now)
+		}); err != nil {
+			return err
+		}
+
+		updated, err = adjustItemStock(tx, inventoryItemID, quantityDelta, -quantity)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return updated, units, nil
+}
+
+// Return marks a sold unit returned
+func (r *SerialUnitRepositoryImpl) Return(ctx context.Context, inventoryItemID uuid.UUID, serialNumber string) (*entity.SerialUnit, error) {
+	var unit *entity.SerialUnit
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		unit, err = lockUnit(tx, inventoryItemID, serialNumber)
+		if err != nil {
+			return err
+		}
+
+		return saveUnitStates(tx, []*entity.SerialUnit{unit}, func(unit *entity.SerialUnit) error {
+			return unit.Return(time.Now().UTC())
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return unit, nil
+}
+
+// Restock makes a returned unit available and adds it to the quantity of its item
+func (r *SerialUnitRepositoryImpl) Restock(ctx context.Context, inventoryItemID uuid.UUID, serialNumber string) (*entity.InventoryItem, *entity.SerialUnit, error) {
+	var updated *entity.InventoryItem
+	var unit *entity.SerialUnit
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		unit, err = lockUnit(tx, inventoryItemID, serialNumber)
+		if err != nil {
+			return err
+		}
+
+		if err := saveUnitStates(tx, []*entity.SerialUnit{unit}, func(unit *entity.SerialUnit) error {
+			return unit.Restock(time.Now().UTC())
+		}); err != nil {
+			return err
+		}
+
+		updated, err = adjustItemStock(tx, inventoryItemID, 1, 0)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return updated, unit, nil
+}
+
+// FindByInventoryItemID retrieves the units of an inventory item, oldest first
+func (r *SerialUnitRepositoryImpl) FindByInventoryItemID(ctx context.Context, inventoryItemID uuid.UUID, status entity.SerialUnitStatus) ([]*entity.SerialUnit, error) {
+	query := r.db.WithContext(ctx).Where("inventory_item_id = ?", inventoryItemID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var unitModels []model.SerialUnitModel
+	if err := query.Order(serialOrderSQL).Find(&unitModels).Error; err != nil {
+		return nil, fmt.Errorf("failed to find serial units by inventory item ID: %w", err)
+	}
+	return toSerialUnits(unitModels), nil
+}
+
+// FindByReservationID retrieves the units held or sold by a reservation
+func (r *SerialUnitRepositoryImpl) FindByReservationID(ctx context.Context, reservationID uuid.UUID) ([]*entity.SerialUnit, error) {
+	var unitModels []model.SerialUnitModel
+	result := r.db.WithContext(ctx).
+		Where("reservation_id = ?", reservationID).
+		Order(serialOrderSQL).
+		Find(&unitModels)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to find serial units by reservation ID: %w", result.Error)
+	}
+	return toSerialUnits(unitModels), nil
+}
+
+// lockSerialTrackedItem locks the inventory item matching where for the rest of
+// the transaction and checks it is serial-tracked
+func lockSerialTrackedItem(tx *gorm.DB, where string, key uuid.UUID) (*entity.InventoryItem, error) {
+	var itemModel model.InventoryItemModel
+
+	result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where(where, key).First(&itemModel)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, domainErrors.ErrInventoryItemNotFound
+		}
+		return nil, fmt.Errorf("failed to lock inventory item: %w", result.Error)
+	}
+	if !itemModel.SerialTracked {
+		return nil, domainErrors.ErrNotSerialTracked
+	}
+	return itemModel.ToEntity(), nil
+}
+
+// lockUnits locks the units matching the conditions, oldest first
+func lockUnits(tx *gorm.DB, where string, args ...interface{}) ([]*entity.SerialUnit, error) {
+	var unitModels []model.SerialUnitModel
+	result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where(where, args...).
+		Order(serialOrderSQL).
+		Find(&unitModels)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to lock serial units: %w", result.Error)
+	}
+	return toSerialUnits(unitModels), nil
+}
+
+// lockUnit locks the serial-tracked item and one of its units by serial number
+func lockUnit(tx *gorm.DB, inventoryItemID uuid.UUID, serialNumber string) (*entity.SerialUnit, error) {
+	if _, err := lockSerialTrackedItem(tx, "id = ?", inventoryItemID); err != nil {
+		return nil, err
+	}
+
+	units, err := lockUnits(tx, "inventory_item_id = ? AND serial_number = ?", inventoryItemID, serialNumber)
+	if err != nil {
+		return nil, err
+	}
+	if len(units) == 0 {
+		return nil, domainErrors.ErrSerialUnitNotFound.WithDetails(serialNumber)
+	}
+	return units[0], nil
+}
+
+// saveUnitStates applies the same state change to every unit and writes their
+// new status, reservation and timestamp in one statement
+func saveUnitStates(tx *gorm.DB, units []*entity.SerialUnit, move func(unit *entity.SerialUnit) error) error {
+	ids := make([]uuid.UUID, len(units))
+	for i, unit := range units {
+		if err := move(unit); err != nil {
+			return err
+		}
+		ids[i] = unit.ID
+	}
+
+	first := units[0]
+	result := tx.Model(&model.SerialUnitModel{}).
+		Where("id IN ?", ids).
+		Updates(map[string]interface{}{
+			"status":         string(first.Status),
+			"reservation_id": first.ReservationID,
+			"updated_at":     first.UpdatedAt,
+		})
+	if result.Error != nil {
+		return fmt.Errorf("failed to update serial units: %w", result.Error)
+	}
+	return nil
+}
+
+// adjustSerialStock adds deltas to quantity and reserved of an inventory item and
+// returns the item as stored afterwards
+func adjustItemStock(tx *gorm.DB, inventoryItemID uuid.UUID, quantityDelta, reservedDelta int) (*entity.InventoryItem, error) {
+	var updated model.InventoryItemModel
+	if err := tx.Raw(itemStockSQL, quantityDelta, reservedDelta, time.Now().UTC(), inventoryItemID).Scan(&updated).Error; err != nil {
+		return nil, fmt.Errorf("failed to update inventory item stock: %w", err)
+	}
+	return updated.ToEntity(), nil
+}
+
+func toSerialUnits(unitModels []model.SerialUnitModel) []*entity.SerialUnit {
+	units := make([]*entity.SerialUnit, len(unitModels))
+	for i := range unitModels {
+		units[i] = unitModels[i].ToEntity()
+	}
+	return units
+}
@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	domainErrors "github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+)
+
+// insertSerialTrackedItem writes a serial-tracked inventory item with the given units
+func insertSerialTrackedItem(t *testing.T, db *gorm.DB, serialNumbers ...string) *entity.InventoryItem {
+	item, err := entity.NewInventoryItem(uuid.New(), 0)
+	require.NoError(t, err)
+	require.NoError(t, item.EnableSerialTracking())
+	require.NoError(t, NewInventoryRepository(db).Save(context.Background(), item))
+
+	if len(serialNumbers) > 0 {
+		_, err = NewSerialUnitRepository(db).Register(context.Background(), item.ID, newSerialUnits(t, item.ID, serialNumbers...))
+		require.NoError(t, err)
+	}
+	return item
+}
+
+func newSerialUnits(t *testing.T, itemID uuid.UUID, serialNumbers ...string) []*entity.SerialUnit {
+	units := make([]*entity.SerialUnit, len(serialNumbers))
+	for i, serialNumber := range serialNumbers {
+		unit, err := entity.NewSerialUnit(itemID, serialNumber)
+		require.NoError(t, err)
+		units[i] = unit
+	}
+	return units
+}
+
+func TestSerialUnitRepositoryImpl_Register(t *testing.T) {
+	db, cleanup := setupMigratedTestDB(t)
+	defer cleanup()
+
+	repo := NewSerialUnitRepository(db)
+	ctx := context.Background()
+	item := insertSerialTrackedItem(t, db)
+
+	updated, err := repo.Register(ctx, item.ID, newSerialUnits(t, item.ID, "SN-1", "SN-2"))
+	require.NoError(t, err)
+	assert.Equal(t, 2, updated.Quantity)
+	assert.Equal(t, item.Version+1, updated.Version)
+
+	_, err = repo.Register(ctx, item.ID, newSerialUnits(t, item.ID, "SN-3", "SN-2"))
+	assert.ErrorIs(t, err, domainErrors.ErrSerialUnitAlreadyExists)
+	assert.Contains(t, err.Error(), "SN-2")
+
+	untracked := insertLotItem(t, db, 0)
+	_, err = repo.Register(ctx, untracked.ID, newSerialUnits(t, untracked.ID, "SN-1"))
+	assert.ErrorIs(t, err, domainErrors.ErrNotSerialTracked)
+
+	units, err := repo.FindByInventoryItemID(ctx, item.ID, "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"SN-1", "SN-2"}, entity.SerialNumbers(units), "failed registrations save nothing")
+}
+
+func TestSerialUnitRepositoryImpl_ReserveConfirmRelease(t *testing.T) {
+	db, cleanup := setupMigratedTestDB(t)
+	defer cleanup()
+
+	repo := NewSerialUnitRepository(db)
+	ctx := context.Background()
+	item := insertSerialTrackedItem(t, db, "SN-1", "SN-2", "SN-3")
+
+	confirmed := uuid.New()
+	updated, units, err := repo.Reserve(ctx, confirmed, item.ProductID, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"SN-1", "SN-2"}, entity.SerialNumbers(units), "oldest units first")
+	assert.Equal(t, 3, updated.Quantity)
+	assert.Equal(t, 2, updated.Reserved)
+
+	_, _, err = repo.Reserve(ctx, uuid.New(), item.ProductID, 2)
+	assert.ErrorIs(t, err, domainErrors.ErrInsufficientStock)
+
+	released := uuid.New()
+	_, units, err = repo.Reserve(ctx, released, item.ProductID, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"SN-3"}, entity.SerialNumbers(units))
+
+	_, _, err = repo.Confirm(ctx, confirmed, item.ID, 1)
+	assert.ErrorIs(t, err, domainErrors.ErrInvalidReservationConfirm, "the reservation holds 2 units")
+
+	updated, units, err = repo.Confirm(ctx, confirmed, item.ID, 2)
+	require.NoError(t, err)
+	assert.Equal(t, entity.SerialSold, units[0].Status)
+	assert.Equal(t, 1, updated.Quantity)
+	assert.Equal(t, 1, updated.Reserved)
+
+	updated, units, err = repo.Release(ctx, released, item.ID, 1)
+	require.NoError(t, err)
+	assert.Equal(t, entity.SerialAvailable, units[0].Status)
+	assert.Nil(t, units[0].ReservationID)
+	assert.Equal(t, 1, updated.Quantity)
+	assert.Equal(t, 0, updated.Reserved)
+
+	sold, err := repo.FindByReservationID(ctx, confirmed)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"SN-1", "SN-2"}, entity.SerialNumbers(sold))
+
+	available, err := repo.FindByInventoryItemID(ctx, item.ID, entity.SerialAvailable)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"SN-3"}, entity.SerialNumbers(available))
+
+	stored, err := NewInventoryRepository(db).FindByID(ctx, item.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, stored.Quantity)
+	assert.Equal(t, 0, stored.Reserved)
+}
+
+func TestSerialUnitRepositoryImpl_ReturnRestock(t *testing.T) {
+	db, cleanup := setupMigratedTestDB(t)
+	defer cleanup()
+
+	repo := NewSerialUnitRepository(db)
+	ctx := context.Background()
+	item := insertSerialTrackedItem(t, db, "SN-1")
+	reservationID := uuid.New()
+	_, _, err := repo.Reserve(ctx, reservationID, item.ProductID, 1)
+	require.NoError(t, err)
+
+	_, err = repo.Return(ctx, item.ID, "SN-1")
+	assert.ErrorIs(t, err, domainErrors.ErrInvalidSerialTransition, "reserved units cannot be returned")
+
+	_, _, err = repo.Confirm(ctx, reservationID, item.ID, 1)
+	require.NoError(t, err)
+
+	unit, err := repo.Return(ctx, item.ID, "SN-1")
+	require.NoError(t, err)
+	assert.Equal(t, entity.SerialReturned, unit.Status)
+
+	_, err = repo.Return(ctx, item.ID, "SN-404")
+	assert.ErrorIs(t, err, domainErrors.ErrSerialUnitNotFound)
+
+	updated, unit, err := repo.Restock(ctx, item.ID, "SN-1")
+	require.NoError(t, err)
+	assert.Equal(t, entity.SerialAvailable, unit.Status)
+	assert.Nil(t, unit.ReservationID)
+	assert.Equal(t, 1, updated.Quantity)
+}
+
+func TestInventoryRepositoryImpl_AtomicStock_SerialTracked(t *testing.T) {
+	db, cleanup := setupMigratedTestDB(t)
+	defer cleanup()
+
+	repo := NewInventoryRepository(db)
+	ctx := context.Background()
+	item := insertSerialTrackedItem(t, db, "SN-1")
+
+	_, err := repo.ReserveStock(ctx, item.ProductID, 1)
+	assert.ErrorIs(t, err, domainErrors.ErrSerialTrackedItem)
+
+	_, err = repo.AdjustStock(ctx, item.ProductID, 5)
+	assert.ErrorIs(t, err, domainErrors.ErrSerialTrackedItem)
+
+	lot, err := entity.NewLot(item.ID, "L-1", 5, item.CreatedAt, nil)
+	require.NoError(t, err)
+	_, err = NewLotRepository(db).Receive(ctx, lot)
+	assert.ErrorIs(t, err, domainErrors.ErrSerialTrackedItem)
+}
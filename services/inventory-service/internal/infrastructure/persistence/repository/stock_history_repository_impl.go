@@ -0,0 +1,250 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	domainErrors "github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Stock history tables, see migration 008. stock_movements is written by a
+// trigger on inventory_items; snapshots are written here only.
+const (
+	// positionsAsOfSQL rebuilds the stock of every item at a moment from the snapshot
+	// taken at base plus the movements after it. %[1]s narrows both sides to a
+	// product. Arguments: base, [product], base, at, [product].
+	positionsAsOfSQL = `SELECT
+			COALESCE(s.inventory_item_id, m.inventory_item_id) AS inventory_item_id,
+			COALESCE(s.product_id, m.product_id) AS product_id,
+			COALESCE(s.quantity, 0) + COALESCE(m.quantity_delta, 0) AS quantity,
+			COALESCE(s.reserved, 0) + COALESCE(m.reserved_delta, 0) AS reserved,
+			COALESCE(m.changed_at, s.changed_at) AS changed_at
+		FROM (
+			SELECT inventory_item_id, product_id, quantity, reserved, changed_at
+			FROM stock_snapshots
+			WHERE taken_at = ?%[1]s
+		) s
+		FULL JOIN (
+			SELECT inventory_item_id, product_id,
+				SUM(quantity_delta) AS quantity_delta,
+				SUM(reserved_delta) AS reserved_delta,
+				MAX(occurred_at) AS changed_at
+			FROM stock_movements
+			WHERE occurred_at > ? AND occurred_at <= ?%[1]s
+			GROUP BY inventory_item_id, product_id
+		) m ON m.inventory_item_id = s.inventory_item_id`
+
+	productFilterSQL = " AND product_id = ?"
+
+	latestSnapshotSQL       = `SELECT MAX(taken_at) FROM stock_snapshot_runs`
+	latestSnapshotBeforeSQL = `SELECT MAX(taken_at) FROM stock_snapshot_runs WHERE taken_at <= ?`
+
+	// Exclusive mode still lets readers in but serializes snapshot writers
+	lockSnapshotRunsSQL   = `LOCK TABLE stock_snapshot_runs IN EXCLUSIVE MODE`
+	insertSnapshotRunSQL  = `INSERT INTO stock_snapshot_runs (taken_at, items) VALUES (?, 0)`
+	updateSnapshotRunSQL  = `UPDATE stock_snapshot_runs SET items = ? WHERE taken_at = ?`
+	insertSnapshotSQL     = `INSERT INTO stock_snapshots (taken_at, inventory_item_id, product_id, quantity, reserved, changed_at) SELECT ?::timestamp, p.* FROM (%s) p`
+	deleteOldSnapshotsSQL = `DELETE FROM stock_snapshot_runs WHERE taken_at < ?`
+	deleteOldMovementsSQL = `DELETE FROM stock_movements WHERE occurred_at <= ?`
+
+	// snapshotBoundarySQL reads the database clock and the start of the oldest other
+	// transaction that already wrote something. Movements are stamped when written,
+	// not when committed, so until that transaction commits its movements are
+	// missing from the snapshot even if they occurred before it. pg_stat_activity
+	// only shows other roles' transactions to superusers, but every writer of
+	// inventory_items connects as the service role.
+	snapshotBoundarySQL = `SELECT clock_timestamp() AT TIME ZONE 'UTC' AS now,
+			(SELECT MIN(xact_start) AT TIME ZONE 'UTC'
+			FROM pg_stat_activity
+			WHERE datname = current_database() AND pid <> pg_backend_pid() AND backend_xid IS NOT NULL) AS oldest_write`
+)
+
+// StockHistoryRepositoryImpl is the GORM implementation of StockHistoryRepository
+type StockHistoryRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewStockHistoryRepository creates a new instance of StockHistoryRepositoryImpl
+func NewStockHistoryRepository(db *gorm.DB) *StockHistoryRepositoryImpl {
+	return &StockHistoryRepositoryImpl{
+		db: db,
+	}
+}
+
+// stockPositionRow is the shape returned by positionsAsOfSQL
+type stockPositionRow struct {
+	InventoryItemID uuid.UUID
+	ProductID       uuid.UUID
+	Quantity        int
+	Reserved        int
+	ChangedAt       time.Time
+}
+
+func (row *stockPositionRow) toEntity() *entity.StockPosition {
+	return &entity.StockPosition{
+		InventoryItemID: row.InventoryItemID,
+		ProductID:       row.ProductID,
+		Quantity:        row.Quantity,
+		Reserved:        row.Reserved,
+		ChangedAt:       row.ChangedAt,
+	}
+}
+
+// TakeSnapshot stores the stock of every item at the given time. The snapshot is
+// skipped while the time is not past on the database clock or a transaction that
+// started at or before it is still writing, because the movements it has not
+// committed yet would be lost; the next run tries again.
+func (r *StockHistoryRepositoryImpl) TakeSnapshot(ctx context.Context, at time.Time) (int, bool, error) {
+	at = at.UTC()
+	items := 0
+	taken := false
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(lockSnapshotRunsSQL).Error; err != nil {
+			return err
+		}
+
+		var latest sql.NullTime
+		if err := tx.Raw(latestSnapshotSQL).Row().Scan(&latest); err != nil {
+			return err
+		}
+		if latest.Valid && !latest.Time.Before(at) {
+			return nil
+		}
+		var boundary struct {
+			Now         time.Time
+			OldestWrite sql.NullTime
+		}
+		if err := tx.Raw(snapshotBoundarySQL).Scan(&boundary).Error; err != nil {
+			return err
+		}
+		if !at.Before(boundary.Now) || (boundary.OldestWrite.Valid && !boundary.OldestWrite.Time.After(at)) {
+			return nil
+		}
+
+		// Without any snapshot the zero time replays every movement
+		base := latest.Time
+
+		if err := tx.Exec(insertSnapshotRunSQL, at).Error; err != nil {
+			return err
+		}
+		result := tx.Exec(fmt.Sprintf(insertSnapshotSQL, fmt.Sprintf(positionsAsOfSQL, "")), at, base, base, at)
+		if result.Error != nil {
+			return result.Error
+		}
+		items = int(result.RowsAffected)
+		taken = true
+		return tx.Exec(updateSnapshotRunSQL, items, at).Error
+	})
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to take stock snapshot: %w", err)
+	}
+
+	return items, taken, nil
+}
+
+// PositionAsOf rebuilds the stock of a product at the given time
+func (r *StockHistoryRepositoryImpl) PositionAsOf(ctx context.Context, productID uuid.UUID, at time.Time) (*entity.StockPosition, error) {
+	at = at.UTC()
+	base, err := r.snapshotBefore(ctx, at)
+	if err != nil {
+		return nil, err
+	}
+
+	// A product maps to one item at a time; if it was deleted and created again,
+	// the item changed last is the one that held its stock
+	var rows []stockPositionRow
+	query := fmt.Sprintf(positionsAsOfSQL, productFilterSQL) + " ORDER BY changed_at DESC LIMIT 1"
+	if err := r.db.WithContext(ctx).Raw(query, base, productID, base, at, productID).Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to rebuild stock position: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, domainErrors.ErrInventoryItemNotFound
+	}
+
+	return rows[0].toEntity(), nil
+}
+
+// StreamAsOf calls fn with the stock of every item at the given time, ordered by product ID
+func (r *StockHistoryRepositoryImpl) StreamAsOf(ctx context.Context, at time.Time, fn func(position *entity.StockPosition) error) error {
+	at = at.UTC()
+	base, err := r.snapshotBefore(ctx, at)
+	if err != nil {
+		return err
+	}
+
+	// The positions are computed in one pass, so rows are streamed from a single
+	// query instead of being read in keyset batches
+	db := r.db.WithContext(ctx)
+	rows, err := db.Raw(fmt.Sprintf(positionsAsOfSQL, "")+" ORDER BY product_id", base, base, at).Rows()
+	if err != nil {
+		return fmt.Errorf("failed to stream stock positions: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row stockPositionRow
+		if err := db.ScanRows(rows, &row); err != nil {
+			return fmt.Errorf("failed to stream stock positions: %w", err)
+		}
+		if err := fn(row.toEntity()); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to stream stock positions: %w", err)
+	}
+
+	return nil
+}
+
+// Prune deletes the snapshots and movements older than the latest snapshot taken at or before before
+func (r *StockHistoryRepositoryImpl) Prune(ctx context.Context, before time.Time) (int, int64, error) {
+	var snapshots int
+	var movements int64
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var keep sql.NullTime
+		if err := tx.Raw(latestSnapshotBeforeSQL, before.UTC()).Row().Scan(&keep); err != nil {
+			return err
+		}
+		if !keep.Valid {
+			return nil
+		}
+
+		result := tx.Exec(deleteOldSnapshotsSQL, keep.Time)
+		if result.Error != nil {
+			return result.Error
+		}
+		snapshots = int(result.RowsAffected)
+
+		result = tx.Exec(deleteOldMovementsSQL, keep.Time)
+		if result.Error != nil {
+			return result.Error
+		}
+		movements = result.RowsAffected
+		return nil
+	})
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to prune stock history: %w", err)
+	}
+
+	return snapshots, movements, nil
+}
+
+// snapshotBefore returns the time of the latest snapshot at or before at
+func (r *StockHistoryRepositoryImpl) snapshotBefore(ctx context.Context, at time.Time) (time.Time, error) {
+	var base sql.NullTime
+	if err := r.db.WithContext(ctx).Raw(latestSnapshotBeforeSQL, at).Row().Scan(&base); err != nil {
+		return time.Time{}, fmt.Errorf("failed to find stock snapshot: %w", err)
+	}
+	if !base.Valid {
+		return time.Time{}, domainErrors.ErrStockHistoryUnavailable
+	}
+	return base.Time, nil
+}
@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	domainErrors "github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+)
+
+// dbClock returns the database clock, which stamps the stock movements
+func dbClock(t *testing.T, db *gorm.DB) time.Time {
+	var now time.Time
+	require.NoError(t, db.Raw(`SELECT clock_timestamp() AT TIME ZONE 'UTC'`).Row().Scan(&now))
+	return now
+}
+
+func TestStockHistoryRepositoryImpl_AsOf(t *testing.T) {
+	db, cleanup := setupMigratedTestDB(t)
+	defer cleanup()
+
+	repo := NewStockHistoryRepository(db)
+	ctx := context.Background()
+	beforeHistory := dbClock(t, db).Add(-time.Hour)
+
+	beforeInsert := dbClock(t, db)
+	productID := uuid.New()
+	itemID := uuid.New()
+	require.NoError(t, db.Exec(`INSERT INTO inventory_items (id, product_id, quantity, reserved, version, created_at, updated_at)
+		VALUES (?, ?, 100, 0, 1, now(), now())`, itemID, productID).Error)
+	afterInsert := dbClock(t, db)
+
+	require.NoError(t, db.Exec(`UPDATE inventory_items SET reserved = 30, version = 2 WHERE id = ?`, itemID).Error)
+	afterReserve := dbClock(t, db)
+
+	// Version-only updates are not movements
+	require.NoError(t, db.Exec(`UPDATE inventory_items SET version = 3 WHERE id = ?`, itemID).Error)
+
+	// Snapshot in the middle of the history; later movements are replayed on top of it
+	items, taken, err := repo.TakeSnapshot(ctx, afterReserve)
+	require.NoError(t, err)
+	assert.True(t, taken)
+	assert.Equal(t, 1, items)
+	_, taken, err = repo.TakeSnapshot(ctx, afterInsert)
+	require.NoError(t, err)
+	assert.False(t, taken, "a newer snapshot already exists")
+
+	require.NoError(t, db.Exec(`UPDATE inventory_items SET quantity = 70, reserved = 0, version = 4 WHERE id = ?`, itemID).Error)
+	afterConfirm := dbClock(t, db)
+
+	var movements int64
+	require.NoError(t, db.Raw(`SELECT COUNT(*) FROM stock_movements WHERE inventory_item_id = ?`, itemID).Scan(&movements).Error)
+	assert.Equal(t, int64(3), movements)
+
+	tests := []struct {
+		name     string
+		at       time.Time
+		quantity int
+		reserved int
+	}{
+		{"after insert", afterInsert, 100, 0},
+		{"after reserve", afterReserve, 100, 30},
+		{"after confirm", afterConfirm, 70, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			position, err := repo.PositionAsOf(ctx, productID, tt.at)
+			require.NoError(t, err)
+			assert.Equal(t, itemID, position.InventoryItemID)
+			assert.Equal(t, tt.quantity, position.Quantity)
+			assert.Equal(t, tt.reserved, position.Reserved)
+			assert.False(t, position.ChangedAt.After(tt.at))
+		})
+	}
+
+	_, err = repo.PositionAsOf(ctx, uuid.New(), afterConfirm)
+	assert.ErrorIs(t, err, domainErrors.ErrInventoryItemNotFound)
+	_, err = repo.PositionAsOf(ctx, productID, beforeHistory)
+	assert.ErrorIs(t, err, domainErrors.ErrStockHistoryUnavailable)
+
+	var streamed []*entity.StockPosition
+	require.NoError(t, repo.StreamAsOf(ctx, afterReserve, func(position *entity.StockPosition) error {
+		streamed = append(streamed, position)
+		return nil
+	}))
+	require.Len(t, streamed, 1)
+	assert.Equal(t, 30, streamed[0].Reserved)
+	// Only the migration's baseline snapshot, taken before the product existed, covers this
+	require.NoError(t, repo.StreamAsOf(ctx, beforeInsert, func(position *entity.StockPosition) error {
+		t.Fatalf("product did not exist yet: %+v", position)
+		return nil
+	}))
+
+	// Pruning up to the mid-history snapshot keeps it and everything after
+	snapshots, pruned, err := repo.Prune(ctx, afterConfirm)
+	require.NoError(t, err)
+	assert.Equal(t, 1, snapshots, "the baseline snapshot")
+	assert.Equal(t, int64(2), pruned, "insert and reserve are in the kept snapshot")
+
+	position, err := repo.PositionAsOf(ctx, productID, afterConfirm)
+	require.NoError(t, err)
+	assert.Equal(t, 70, position.Quantity)
+	_, err = repo.PositionAsOf(ctx, productID, afterInsert)
+	assert.ErrorIs(t, err, domainErrors.ErrStockHistoryUnavailable)
+}
+
+func TestStockHistoryRepositoryImpl_TakeSnapshot_WaitsForOpenTransactions(t *testing.T) {
+	db, cleanup := setupMigratedTestDB(t)
+	defer cleanup()
+
+	repo := NewStockHistoryRepository(db)
+	ctx := context.Background()
+	productID := uuid.New()
+	itemID := uuid.New()
+	require.NoError(t, db.Exec(`INSERT INTO inventory_items (id, product_id, quantity, reserved, version, created_at, updated_at)
+		VALUES (?, ?, 100, 0, 1, now(), now())`, itemID, productID).Error)
+
+	// The movement is stamped now but only committed after the snapshot time
+	tx := db.Begin()
+	defer tx.Rollback()
+	require.NoError(t, tx.Exec(`UPDATE inventory_items SET reserved = 30, version = 2 WHERE id = ?`, itemID).Error)
+	afterReserve := dbClock(t, db)
+
+	_, taken, err := repo.TakeSnapshot(ctx, afterReserve)
+	require.NoError(t, err)
+	assert.False(t, taken, "the open transaction's movement would be lost")
+	_, taken, err = repo.TakeSnapshot(ctx, dbClock(t, db).Add(time.Hour))
+	require.NoError(t, err)
+	assert.False(t, taken, "movements can still occur before a future time")
+
+	require.NoError(t, tx.Commit().Error)
+	_, taken, err = repo.TakeSnapshot(ctx, afterReserve)
+	require.NoError(t, err)
+	assert.True(t, taken)
+
+	var reserved int
+	require.NoError(t, db.Raw(`SELECT reserved FROM stock_snapshots WHERE taken_at = ? AND inventory_item_id = ?`,
+		afterReserve, itemID).Scan(&reserved).Error)
+	assert.Equal(t, 30, reserved)
+}
@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	domainErrors "github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/infrastructure/persistence/model"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Waitlist table, see migration 014
+const (
+	// waitlistServingOrder serves the highest priority first, then the oldest entry
+	waitlistServingOrder = "priority DESC, created_at ASC, id ASC"
+
+	productsWaitingSQL = `SELECT DISTINCT i.product_id FROM waitlist_entries w
+		JOIN inventory_items i ON i.id = w.inventory_item_id
+		WHERE w.status = 'waiting' AND w.expires_at > ?`
+
+	expireWaitingSQL = `UPDATE waitlist_entries SET status = 'expired', updated_at = ?
+		WHERE status = 'waiting' AND expires_at <= ?`
+)
+
+// WaitlistRepositoryImpl is the GORM implementation of WaitlistRepository
+type WaitlistRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewWaitlistRepository creates a new instance of WaitlistRepositoryImpl
+func NewWaitlistRepository(db *gorm.DB) *WaitlistRepositoryImpl {
+	return &WaitlistRepositoryImpl{
+		db: db,
+	}
+}
+
+// Save creates a new waiting entry
+func (r *WaitlistRepositoryImpl) Save(ctx context.Context, entry *entity.WaitlistEntry) error {
+	if err := r.db.WithContext(ctx).Create(model.NewWaitlistEntryModelFromEntity(entry)).Error; err != nil {
+		if containsWaitlistConstraintViolation(err.Error()) {
+			return domainErrors.ErrWaitlistEntryAlreadyExists.WithDetails("order_id: " + entry.OrderID.String())
+		}
+		return fmt.Errorf("failed to save waitlist entry: %w", err)
+	}
+	return nil
+}
+
+// containsWaitlistConstraintViolation checks if error message contains PostgreSQL duplicate key constraint for waiting orders
+func containsWaitlistConstraintViolation(errMsg string) bool {
+	return strings.Contains(errMsg, "duplicate key value violates unique constraint") &&
+		(strings.Contains(errMsg, "uq_waitlist_entries_waiting_order") || strings.Contains(errMsg, "SQLSTATE 23505"))
+}
+
+// FindByID retrieves an entry by its ID
+func (r *WaitlistRepositoryImpl) FindByID(ctx context.Context, id uuid.UUID) (*entity.WaitlistEntry, error) {
+	var entryModel model.WaitlistEntryModel
+	result := r.db.WithContext(ctx).Where("id = ?", id).Limit(1).Find(&entryModel)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to find waitlist entry: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, domainErrors.ErrWaitlistEntryNotFound
+	}
+	return entryModel.ToEntity(), nil
+}
+
+// Update stores the status of an entry, provided the stored entry is still waiting
+func (r *WaitlistRepositoryImpl) Update(ctx context.Context, entry *entity.WaitlistEntry) error {
+	entryModel := model.NewWaitlistEntryModelFromEntity(entry)
+	result := r.db.WithContext(ctx).Model(&model.WaitlistEntryModel{}).
+		Where("id = ? AND status = ?", entry.ID, entity.WaitlistWaiting).
+		Updates(map[string]interface{}{
+			"status":         entryModel.Status,
+			"reservation_id": entryModel.ReservationID,
+			"failure_reason": entryModel.FailureReason,
+			"fulfilled_at":   entryModel.FulfilledAt,
+			"updated_at":     entryModel.UpdatedAt,
+		})
+	if result.Error != nil {
+		return fmt.Errorf("failed to update waitlist entry: %w", result.Error)
+	}
+	if result.RowsAffected > 0 {
+		return nil
+	}
+
+	stored, err := r.FindByID(ctx, entry.ID)
+	if err != nil {
+		return err
+	}
+	return domainErrors.ErrWaitlistEntryNotWaiting.WithDetails("status: " + string(stored.Status))
+}
+
+// ListWaiting retrieves up to limit unexpired waiting entries of an inventory item in serving order
+func (r *WaitlistRepositoryImpl) ListWaiting(ctx context.Context, inventoryItemID uuid.UUID, limit int) ([]*entity.WaitlistEntry, error) {
+	var entryModels []*model.WaitlistEntryModel
+	err := r.db.WithContext(ctx).
+		Where("inventory_item_id = ? AND status = ? AND expires_at > ?", inventoryItemID, entity.WaitlistWaiting, time.Now().UTC()).
+		Order(waitlistServingOrder).
+		Limit(limit).
+		Find(&entryModels).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list waiting entries: %w", err)
+	}
+	return waitlistEntriesToEntities(entryModels), nil
+}
+
+// ListByInventoryItemID retrieves the entries of an inventory item in serving order, optionally filtered by status
+func (r *WaitlistRepositoryImpl) ListByInventoryItemID(
+	ctx context.Context,
+	inventoryItemID uuid.UUID,
+	status entity.WaitlistStatus,
+) ([]*entity.WaitlistEntry, error) {
+	query := r.db.WithContext(ctx).Where("inventory_item_id = ?", inventoryItemID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var entryModels []*model.WaitlistEntryModel
+	if err := query.Order(waitlistServingOrder).Find(&entryModels).Error; err != nil {
+		return nil, fmt.Errorf("failed to list waitlist entries: %w", err)
+	}
+	return waitlistEntriesToEntities(entryModels), nil
+}
+
+// ProductsWaiting returns the products with unexpired waiting entries
+func (r *WaitlistRepositoryImpl) ProductsWaiting(ctx context.Context, now time.Time) ([]uuid.UUID, error) {
+	var productIDs []uuid.UUID
+	if err := r.db.WithContext(ctx).Raw(productsWaitingSQL, now.UTC()).Scan(&productIDs).Error; err != nil {
+		return nil, fmt.Errorf("failed to find waitlisted products: %w", err)
+	}
+	return productIDs, nil
+}
+
+// ExpireWaiting marks the waiting entries that expired before now as expired
+func (r *WaitlistRepositoryImpl) ExpireWaiting(ctx context.Context, now time.Time) (int, error) {
+	now = now.UTC()
+	result := r.db.WithContext(ctx).Exec(expireWaitingSQL, now, now)
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to expire waitlist entries: %w", result.Error)
+	}
+	return int(result.RowsAffected), nil
+}
+
+// waitlistEntriesToEntities converts GORM models to domain entities
+func waitlistEntriesToEntities(entryModels []*model.WaitlistEntryModel) []*entity.WaitlistEntry {
+	entries := make([]*entity.WaitlistEntry, len(entryModels))
+	for i, entryModel := range entryModels {
+		entries[i] = entryModel.ToEntity()
+	}
+	return entries
+}
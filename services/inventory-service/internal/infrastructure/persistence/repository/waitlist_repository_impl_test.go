@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	domainErrors "github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+)
+
+func saveWaitlistEntry(t *testing.T, repo *WaitlistRepositoryImpl, itemID uuid.UUID, priority int, ttl time.Duration) *entity.WaitlistEntry {
+	entry, err := entity.NewWaitlistEntry(itemID, uuid.New(), 1, priority, ttl)
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(context.Background(), entry))
+	return entry
+}
+
+func TestWaitlistRepositoryImpl_SaveFindUpdate(t *testing.T) {
+	db, cleanup := setupMigratedTestDB(t)
+	defer cleanup()
+
+	repo := NewWaitlistRepository(db)
+	ctx := context.Background()
+	item := insertLotItem(t, db, 0)
+
+	entry := saveWaitlistEntry(t, repo, item.ID, 0, time.Hour)
+
+	t.Run("should reject an order that is already waiting", func(t *testing.T) {
+		again, err := entity.NewWaitlistEntry(item.ID, entry.OrderID, 1, 0, time.Hour)
+		require.NoError(t, err)
+		assert.ErrorIs(t, repo.Save(ctx, again), domainErrors.ErrWaitlistEntryAlreadyExists)
+	})
+
+	t.Run("should resolve a waiting entry only once", func(t *testing.T) {
+		reservationID := uuid.New()
+		require.NoError(t, entry.Fulfill(reservationID))
+		require.NoError(t, repo.Update(ctx, entry))
+
+		found, err := repo.FindByID(ctx, entry.ID)
+		require.NoError(t, err)
+		assert.Equal(t, entity.WaitlistFulfilled, found.Status)
+		assert.Equal(t, reservationID, *found.ReservationID)
+		require.NotNil(t, found.FulfilledAt)
+
+		stale := *entry
+		stale.Status = entity.WaitlistCancelled
+		assert.ErrorIs(t, repo.Update(ctx, &stale), domainErrors.ErrWaitlistEntryNotWaiting)
+	})
+
+	t.Run("should let a served order wait again", func(t *testing.T) {
+		again, err := entity.NewWaitlistEntry(item.ID, entry.OrderID, 1, 0, time.Hour)
+		require.NoError(t, err)
+		require.NoError(t, repo.Save(ctx, again))
+	})
+
+	t.Run("should report unknown entries", func(t *testing.T) {
+		_, err := repo.FindByID(ctx, uuid.New())
+		assert.ErrorIs(t, err, domainErrors.ErrWaitlistEntryNotFound)
+	})
+}
+
+func TestWaitlistRepositoryImpl_ServingOrderAndExpiry(t *testing.T) {
+	db, cleanup := setupMigratedTestDB(t)
+	defer cleanup()
+
+	repo := NewWaitlistRepository(db)
+	ctx := context.Background()
+	item, other := insertLotItem(t, db, 0), insertLotItem(t, db, 0)
+
+	first := saveWaitlistEntry(t, repo, item.ID, 0, time.Hour)
+	urgent := saveWaitlistEntry(t, repo, item.ID, 50, time.Hour)
+	second := saveWaitlistEntry(t, repo, item.ID, 0, time.Hour)
+	expired := saveWaitlistEntry(t, repo, item.ID, 100, time.Millisecond)
+	saveWaitlistEntry(t, repo, other.ID, 0, time.Hour)
+	time.Sleep(10 * time.Millisecond)
+
+	waiting, err := repo.ListWaiting(ctx, item.ID, 10)
+	require.NoError(t, err)
+	require.Len(t, waiting, 3)
+	assert.Equal(t, []uuid.UUID{urgent.ID, first.ID, second.ID}, []uuid.UUID{waiting[0].ID, waiting[1].ID, waiting[2].ID})
+
+	limited, err := repo.ListWaiting(ctx, item.ID, 1)
+	require.NoError(t, err)
+	assert.Len(t, limited, 1)
+
+	products, err := repo.ProductsWaiting(ctx, time.Now())
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []uuid.UUID{item.ProductID, other.ProductID}, products)
+
+	count, err := repo.ExpireWaiting(ctx, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	expiredEntries, err := repo.ListByInventoryItemID(ctx, item.ID, entity.WaitlistExpired)
+	require.NoError(t, err)
+	require.Len(t, expiredEntries, 1)
+	assert.Equal(t, expired.ID, expiredEntries[0].ID)
+
+	all, err := repo.ListByInventoryItemID(ctx, item.ID, "")
+	require.NoError(t, err)
+	assert.Len(t, all, 4)
+}
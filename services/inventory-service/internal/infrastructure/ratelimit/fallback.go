@@ -0,0 +1,100 @@
+package ratelimit
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// DefaultProbeInterval is how long the fallback stays active before Redis is tried again
+const DefaultProbeInterval = 5 * time.Second
+
+var fallbackDecisions = promauto.NewCounter(prometheus.CounterOpts{
+	Name: "inventory_rate_limit_fallback_decisions_total",
+	Help: "Rate limit decisions made by the in-process limiter because Redis was unavailable",
+})
+
+// FallbackLimiter uses the primary (Redis) limiter and switches to the fallback
+// (in-process) limiter when the primary fails, so protection is never disabled.
+// While degraded, the primary is retried once per probe interval.
+type FallbackLimiter struct {
+	primary       Limiter
+	fallback      Limiter
+	probeInterval time.Duration
+
+	mu            sync.Mutex
+	degradedUntil time.Time
+	degraded      bool
+	now           func() time.Time
+}
+
+// NewFallbackLimiter creates a limiter that falls back when primary is unavailable
+func NewFallbackLimiter(primary, fallback Limiter, probeInterval time.Duration) *FallbackLimiter {
+	if primary == nil || fallback == nil {
+		panic("primary and fallback limiters cannot be nil")
+	}
+	if probeInterval <= 0 {
+		probeInterval = DefaultProbeInterval
+	}
+
+	return &FallbackLimiter{
+		primary:       primary,
+		fallback:      fallback,
+		probeInterval: probeInterval,
+		now:           time.Now,
+	}
+}
+
+// Allow checks the primary limiter, or the fallback while the primary is unavailable
+func (f *FallbackLimiter) Allow(ctx context.Context, key string, quota Quota) (Decision, error) {
+	if f.skipPrimary() {
+		fallbackDecisions.Inc()
+		return f.fallback.Allow(ctx, key, quota)
+	}
+
+	decision, err := f.primary.Allow(ctx, key, quota)
+	if err == nil {
+		f.markHealthy()
+		return decision, nil
+	}
+
+	f.markDegraded(err)
+	fallbackDecisions.Inc()
+	return f.fallback.Allow(ctx, key, quota)
+}
+
+// Degraded reports whether the fallback limiter is in use
+func (f *FallbackLimiter) Degraded() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.degraded
+}
+
+func (f *FallbackLimiter) skipPrimary() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.degraded && f.now().Before(f.degradedUntil)
+}
+
+func (f *FallbackLimiter) markHealthy() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.degraded {
+		f.degraded = false
+		log.Println("✅ Rate limiter recovered: using Redis again")
+	}
+}
+
+func (f *FallbackLimiter) markDegraded(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if !f.degraded {
+		log.Printf("⚠️  Rate limiter: Redis unavailable, using in-process limits: %v", err)
+	}
+	f.degraded = true
+	f.degradedUntil = f.now().Add(f.probeInterval)
+}
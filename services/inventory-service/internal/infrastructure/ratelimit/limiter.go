@@ -0,0 +1,67 @@
+// Package ratelimit implements token-bucket rate limiting shared through Redis,
+// with an in-process limiter used while Redis is unavailable.
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// Quota is the number of requests allowed per window.
+// Tokens refill continuously at Limit/Window, and up to Limit requests may burst.
+type Quota struct {
+	Limit  int64
+	Window time.Duration
+}
+
+// refillPerMillisecond returns the token refill rate
+func (q Quota) refillPerMillisecond() float64 {
+	return float64(q.Limit) / float64(q.Window.Milliseconds())
+}
+
+// Valid reports whether the quota can be enforced
+func (q Quota) Valid() bool {
+	return q.Limit > 0 && q.Window >= time.Millisecond
+}
+
+// Decision is the result of a rate limit check
+type Decision struct {
+	Allowed    bool
+	Limit      int64
+	Remaining  int64
+	ResetAfter time.Duration // until the bucket is full again
+	RetryAfter time.Duration // until the next request is allowed (zero when allowed)
+}
+
+// Limiter checks and consumes one request from the bucket identified by key
+type Limiter interface {
+	Allow(ctx context.Context, key string, quota Quota) (Decision, error)
+}
+
+// take applies the token-bucket algorithm to a bucket state and returns the new token count.
+// It mirrors the Redis script so both limiters make the same decisions.
+func take(tokens float64, elapsed time.Duration, quota Quota) (float64, Decision) {
+	rate := quota.refillPerMillisecond()
+	capacity := float64(quota.Limit)
+
+	if elapsed > 0 {
+		tokens = math.Min(capacity, tokens+float64(elapsed.Milliseconds())*rate)
+	}
+
+	decision := Decision{Limit: quota.Limit}
+	if tokens >= 1 {
+		tokens--
+		decision.Allowed = true
+	} else {
+		decision.RetryAfter = millis(math.Ceil((1 - tokens) / rate))
+	}
+	decision.Remaining = int64(math.Floor(tokens))
+	decision.ResetAfter = millis(math.Ceil((capacity - tokens) / rate))
+
+	return tokens, decision
+}
+
+func millis(ms float64) time.Duration {
+	return time.Duration(ms) * time.Millisecond
+}
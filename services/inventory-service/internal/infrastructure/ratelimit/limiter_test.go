@@ -0,0 +1,208 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRedisLimiter(t *testing.T) (*RedisLimiter, *miniredis.Miniredis) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return NewRedisLimiter(client, time.Second), server
+}
+
+func TestRedisLimiter_AllowsBurstThenRejects(t *testing.T) {
+	limiter, _ := newTestRedisLimiter(t)
+	quota := Quota{Limit: 3, Window: time.Minute}
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		decision, err := limiter.Allow(ctx, "bucket", quota)
+		require.NoError(t, err)
+		assert.True(t, decision.Allowed, "request %d should be allowed", i+1)
+		assert.Equal(t, int64(2-i), decision.Remaining)
+	}
+
+	decision, err := limiter.Allow(ctx, "bucket", quota)
+	require.NoError(t, err)
+	assert.False(t, decision.Allowed)
+	assert.Equal(t, int64(0), decision.Remaining)
+	assert.Equal(t, int64(3), decision.Limit)
+	// One token refills every 20s
+	assert.InDelta(t, 20*time.Second, decision.RetryAfter, float64(time.Second))
+	assert.InDelta(t, time.Minute, decision.ResetAfter, float64(time.Second))
+}
+
+func TestRedisLimiter_RefillsOverTime(t *testing.T) {
+	limiter, server := newTestRedisLimiter(t)
+	quota := Quota{Limit: 2, Window: 2 * time.Second}
+	ctx := context.Background()
+	start := time.Now()
+	server.SetTime(start)
+
+	for i := 0; i < 2; i++ {
+		_, err := limiter.Allow(ctx, "bucket", quota)
+		require.NoError(t, err)
+	}
+	decision, err := limiter.Allow(ctx, "bucket", quota)
+	require.NoError(t, err)
+	require.False(t, decision.Allowed)
+
+	server.SetTime(start.Add(1100 * time.Millisecond))
+	decision, err = limiter.Allow(ctx, "bucket", quota)
+	require.NoError(t, err)
+	assert.True(t, decision.Allowed)
+}
+
+func TestRedisLimiter_KeysAreIndependent(t *testing.T) {
+	limiter, _ := newTestRedisLimiter(t)
+	quota := Quota{Limit: 1, Window: time.Minute}
+	ctx := context.Background()
+
+	first, err := limiter.Allow(ctx, "orders-service", quota)
+	require.NoError(t, err)
+	other, err := limiter.Allow(ctx, "payments-service", quota)
+	require.NoError(t, err)
+
+	assert.True(t, first.Allowed)
+	assert.True(t, other.Allowed)
+}
+
+func TestRedisLimiter_RedisDown(t *testing.T) {
+	limiter, server := newTestRedisLimiter(t)
+	server.Close()
+
+	_, err := limiter.Allow(context.Background(), "bucket", Quota{Limit: 1, Window: time.Minute})
+
+	assert.Error(t, err)
+}
+
+func TestLocalLimiter_MatchesTokenBucket(t *testing.T) {
+	limiter := NewLocalLimiter()
+	now := time.Now()
+	limiter.now = func() time.Time { return now }
+	quota := Quota{Limit: 2, Window: 2 * time.Second}
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		decision, err := limiter.Allow(ctx, "bucket", quota)
+		require.NoError(t, err)
+		assert.True(t, decision.Allowed)
+	}
+	decision, _ := limiter.Allow(ctx, "bucket", quota)
+	assert.False(t, decision.Allowed)
+	assert.Equal(t, time.Second, decision.RetryAfter)
+
+	now = now.Add(time.Second)
+	decision, _ = limiter.Allow(ctx, "bucket", quota)
+	assert.True(t, decision.Allowed)
+}
+
+func TestLocalLimiter_SweepsIdleBuckets(t *testing.T) {
+	limiter := NewLocalLimiter()
+	now := time.Now()
+	limiter.now = func() time.Time { return now }
+	quota := Quota{Limit: 5, Window: time.Second}
+
+	_, _ = limiter.Allow(context.Background(), "a", quota)
+	_, _ = limiter.Allow(context.Background(), "b", quota)
+	require.Equal(t, 2, limiter.Len())
+
+	now = now.Add(2 * localSweepInterval)
+	_, _ = limiter.Allow(context.Background(), "c", quota)
+
+	assert.Equal(t, 1, limiter.Len())
+}
+
+type failingLimiter struct {
+	calls int
+	err   error
+}
+
+func (f *failingLimiter) Allow(ctx context.Context, key string, quota Quota) (Decision, error) {
+	f.calls++
+	if f.err != nil {
+		return Decision{}, f.err
+	}
+	return Decision{Allowed: true, Limit: quota.Limit, Remaining: 99}, nil
+}
+
+func TestFallbackLimiter_UsesFallbackWhileDegraded(t *testing.T) {
+	primary := &failingLimiter{err: errors.New("connection refused")}
+	fallback := NewLocalLimiter()
+	limiter := NewFallbackLimiter(primary, fallback, 5*time.Second)
+	now := time.Now()
+	limiter.now = func() time.Time { return now }
+	quota := Quota{Limit: 1, Window: time.Minute}
+	ctx := context.Background()
+
+	decision, err := limiter.Allow(ctx, "bucket", quota)
+	require.NoError(t, err)
+	assert.True(t, decision.Allowed)
+	assert.True(t, limiter.Degraded())
+
+	// Protection stays on: the in-process bucket is exhausted
+	decision, err = limiter.Allow(ctx, "bucket", quota)
+	require.NoError(t, err)
+	assert.False(t, decision.Allowed)
+	assert.Equal(t, 1, primary.calls, "primary is not retried before the probe interval")
+
+	// After the probe interval the primary is tried again and recovers
+	primary.err = nil
+	now = now.Add(6 * time.Second)
+	decision, err = limiter.Allow(ctx, "bucket", quota)
+	require.NoError(t, err)
+	assert.Equal(t, int64(99), decision.Remaining)
+	assert.False(t, limiter.Degraded())
+	assert.Equal(t, 2, primary.calls)
+}
+
+func TestPolicy_Resolve(t *testing.T) {
+	policy := &Policy{
+		ReadLimit:  200,
+		WriteLimit: 100,
+		Window:     time.Minute,
+		Rules: []Rule{
+			{Service: "orders-service", Limit: 1000},
+			{Method: "POST", Route: "/admin/reservations/release-expired", Limit: 5},
+			{Service: "orders-service", Route: "/inventory.v1.InventoryService/ReserveStock", Limit: 500, Window: time.Second},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		service        string
+		method         string
+		route          string
+		write          bool
+		expectedQuota  Quota
+		expectedBucket string
+	}{
+		{"default read", "reporting-service", "GET", "/admin/dlq", false, Quota{200, time.Minute}, "read"},
+		{"default write", "reporting-service", "POST", "/admin/dlq/:id/retry", true, Quota{100, time.Minute}, "write"},
+		{"service override", "orders-service", "GET", "/admin/dlq", false, Quota{1000, time.Minute}, "read"},
+		{"route override", "orders-service", "POST", "/admin/reservations/release-expired", true, Quota{5, time.Minute}, "POST /admin/reservations/release-expired"},
+		{"route rule method mismatch", "reporting-service", "GET", "/admin/reservations/release-expired", false, Quota{200, time.Minute}, "read"},
+		{"service and route override", "orders-service", "", "/inventory.v1.InventoryService/ReserveStock", true, Quota{500, time.Second}, "/inventory.v1.InventoryService/ReserveStock"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quota, bucket := policy.Resolve(tt.service, tt.method, tt.route, tt.write)
+			assert.Equal(t, tt.expectedQuota, quota)
+			assert.Equal(t, tt.expectedBucket, bucket)
+		})
+	}
+}
+
+func TestQuota_String(t *testing.T) {
+	assert.Equal(t, "100;w=60", Quota{Limit: 100, Window: time.Minute}.String())
+}
@@ -0,0 +1,83 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// localSweepInterval controls how often idle buckets are dropped
+const localSweepInterval = time.Minute
+
+type localBucket struct {
+	tokens   float64
+	last     time.Time
+	idleTTL  time.Duration
+	lastSeen time.Time
+}
+
+// LocalLimiter is an in-process token-bucket limiter.
+// Buckets are per instance, so the effective limit is multiplied by the number of replicas.
+type LocalLimiter struct {
+	mu        sync.Mutex
+	buckets   map[string]*localBucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// NewLocalLimiter creates an in-process limiter
+func NewLocalLimiter() *LocalLimiter {
+	return &LocalLimiter{
+		buckets: make(map[string]*localBucket),
+		now:     time.Now,
+	}
+}
+
+// Allow consumes one token from the bucket identified by key
+func (l *LocalLimiter) Allow(ctx context.Context, key string, quota Quota) (Decision, error) {
+	if !quota.Valid() {
+		return Decision{Allowed: true, Limit: quota.Limit}, nil
+	}
+
+	now := l.now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.sweep(now)
+
+	bucket, ok := l.buckets[key]
+	if !ok {
+		bucket = &localBucket{tokens: float64(quota.Limit), last: now}
+		l.buckets[key] = bucket
+	}
+
+	tokens, decision := take(bucket.tokens, now.Sub(bucket.last), quota)
+	bucket.tokens = tokens
+	bucket.last = now
+	bucket.lastSeen = now
+	bucket.idleTTL = quota.Window
+
+	return decision, nil
+}
+
+// Len returns the number of tracked buckets
+func (l *LocalLimiter) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.buckets)
+}
+
+// sweep drops buckets that have been idle long enough to be full again
+func (l *LocalLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < localSweepInterval {
+		return
+	}
+	l.lastSweep = now
+
+	for key, bucket := range l.buckets {
+		if now.Sub(bucket.lastSeen) > bucket.idleTTL {
+			delete(l.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package ratelimit
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Rule overrides the default quota for a service, a route, or both.
+// Empty fields match anything; the most specific matching rule wins
+// (service+route, then route, then service), ties go to the first rule.
+type Rule struct {
+	Service string        // calling service (token subject)
+	Method  string        // HTTP method; empty matches any method and gRPC calls
+	Route   string        // route template (/admin/dlq/:id/retry) or gRPC full method name
+	Limit   int64         // requests per window
+	Window  time.Duration // zero uses the policy window
+}
+
+// Policy resolves the quota that applies to a request
+type Policy struct {
+	ReadLimit  int64
+	WriteLimit int64
+	Window     time.Duration
+	Rules      []Rule
+}
+
+// Resolve returns the quota for a request and the bucket name it is counted in.
+// Requests matching a route rule get their own bucket; the rest share the
+// per-caller read or write bucket.
+func (p *Policy) Resolve(service, method, route string, write bool) (Quota, string) {
+	best := -1
+	bestScore := 0
+	for i, rule := range p.Rules {
+		score, ok := rule.match(service, method, route)
+		if ok && score > bestScore {
+			best, bestScore = i, score
+		}
+	}
+
+	class := "read"
+	quota := Quota{Limit: p.ReadLimit, Window: p.Window}
+	if write {
+		class = "write"
+		quota.Limit = p.WriteLimit
+	}
+
+	if best < 0 {
+		return quota, class
+	}
+
+	rule := p.Rules[best]
+	quota.Limit = rule.Limit
+	if rule.Window > 0 {
+		quota.Window = rule.Window
+	}
+	if rule.Route == "" {
+		return quota, class
+	}
+	return quota, strings.TrimSpace(rule.Method + " " + rule.Route)
+}
+
+// String describes the quota as used in the RateLimit-Policy header ("100;w=60")
+func (q Quota) String() string {
+	return fmt.Sprintf("%d;w=%d", q.Limit, int64(q.Window.Seconds()))
+}
+
+// match reports whether the rule applies and how specific it is
+func (r Rule) match(service, method, route string) (int, bool) {
+	score := 1
+	if r.Service != "" {
+		if r.Service != service {
+			return 0, false
+		}
+		score += 2
+	}
+	if r.Route != "" {
+		if r.Route != route {
+			return 0, false
+		}
+		score += 4
+	}
+	if r.Method != "" {
+		if !strings.EqualFold(r.Method, method) {
+			return 0, false
+		}
+		score++
+	}
+	return score, true
+}
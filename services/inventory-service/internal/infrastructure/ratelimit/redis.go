@@ -0,0 +1,107 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// tokenBucketScript refills and consumes a token atomically.
+// The bucket is a hash {tokens, ts}; Redis TIME is used so all pods share one clock.
+// Returns {allowed, tokens, retry_after_ms, reset_after_ms}.
+var tokenBucketScript = redis.NewScript(`
+local capacity = tonumber(ARGV[1])
+local rate = tonumber(ARGV[2])
+
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1])
+local ts = tonumber(state[2])
+if tokens == nil or ts == nil then
+  tokens = capacity
+  ts = now
+end
+
+local elapsed = now - ts
+if elapsed > 0 then
+  tokens = math.min(capacity, tokens + elapsed * rate)
+end
+
+local allowed = 0
+local retry_after = 0
+if tokens >= 1 then
+  tokens = tokens - 1
+  allowed = 1
+else
+  retry_after = math.ceil((1 - tokens) / rate)
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', now)
+redis.call('PEXPIRE', KEYS[1], math.ceil(capacity / rate) + 1000)
+
+return {allowed, tostring(tokens), retry_after, math.ceil((capacity - tokens) / rate)}
+`)
+
+// RedisLimiter is a token-bucket limiter shared by every instance through Redis
+type RedisLimiter struct {
+	client  redis.Scripter
+	timeout time.Duration
+}
+
+// NewRedisLimiter creates a Redis limiter. Each check is bounded by timeout so an
+// unresponsive Redis fails fast and the caller can fall back.
+func NewRedisLimiter(client redis.Scripter, timeout time.Duration) *RedisLimiter {
+	if client == nil {
+		panic("redis client cannot be nil")
+	}
+
+	return &RedisLimiter{
+		client:  client,
+		timeout: timeout,
+	}
+}
+
+// Allow consumes one token from the bucket stored at key
+func (l *RedisLimiter) Allow(ctx context.Context, key string, quota Quota) (Decision, error) {
+	if !quota.Valid() {
+		return Decision{}, fmt.Errorf("invalid rate limit quota: %d per %s", quota.Limit, quota.Window)
+	}
+
+	if l.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, l.timeout)
+		defer cancel()
+	}
+
+	result, err := tokenBucketScript.Run(ctx, l.client, []string{key},
+		quota.Limit, strconv.FormatFloat(quota.refillPerMillisecond(), 'f', -1, 64)).Slice()
+	if err != nil {
+		return Decision{}, fmt.Errorf("failed to evaluate rate limit for %s: %w", key, err)
+	}
+	if len(result) != 4 {
+		return Decision{}, fmt.Errorf("unexpected rate limit script result: %v", result)
+	}
+
+	allowed, _ := result[0].(int64)
+	tokensStr, _ := result[1].(string)
+	retryAfter, _ := result[2].(int64)
+	resetAfter, _ := result[3].(int64)
+
+	tokens, err := strconv.ParseFloat(tokensStr, 64)
+	if err != nil {
+		return Decision{}, fmt.Errorf("unexpected token count %q: %w", tokensStr, err)
+	}
+
+	return Decision{
+		Allowed:    allowed == 1,
+		Limit:      quota.Limit,
+		Remaining:  int64(tokens),
+		RetryAfter: time.Duration(retryAfter) * time.Millisecond,
+		ResetAfter: time.Duration(resetAfter) * time.Millisecond,
+	}, nil
+}
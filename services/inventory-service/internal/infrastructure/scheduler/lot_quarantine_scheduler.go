@@ -0,0 +1,75 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/application/usecase"
+)
+
+// QuarantineExpiredLotsExecutor interface for the use case
+type QuarantineExpiredLotsExecutor interface {
+	Execute(ctx context.Context) (*usecase.QuarantineExpiredLotsOutput, error)
+}
+
+// LotQuarantineScheduler periodically takes the units of expired lots out of the
+// available stock
+type LotQuarantineScheduler struct {
+	quarantineUseCase QuarantineExpiredLotsExecutor
+	interval          time.Duration
+	stopChan          chan bool
+}
+
+// NewLotQuarantineScheduler creates a new scheduler instance
+func NewLotQuarantineScheduler(quarantineUseCase QuarantineExpiredLotsExecutor, interval time.Duration) *LotQuarantineScheduler {
+	return &LotQuarantineScheduler{
+		quarantineUseCase: quarantineUseCase,
+		interval:          interval,
+		stopChan:          make(chan bool),
+	}
+}
+
+// Start begins the scheduler loop in a goroutine.
+// The first run happens right away so lots that expired while the service was down are not sold.
+func (s *LotQuarantineScheduler) Start() {
+	log.Printf("[LotQuarantineScheduler] Starting with interval: %s", s.interval)
+
+	go func() {
+		s.runQuarantine()
+
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				s.runQuarantine()
+			case <-s.stopChan:
+				log.Println("[LotQuarantineScheduler] Stopped")
+				return
+			}
+		}
+	}()
+}
+
+// Stop gracefully stops the scheduler
+func (s *LotQuarantineScheduler) Stop() {
+	log.Println("[LotQuarantineScheduler] Stopping...")
+	s.stopChan <- true
+	close(s.stopChan)
+}
+
+// runQuarantine executes one quarantine run and logs the result
+func (s *LotQuarantineScheduler) runQuarantine() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	output, err := s.quarantineUseCase.Execute(ctx)
+	if output != nil && output.Lots > 0 {
+		log.Printf("[LotQuarantineScheduler] Quarantined %d unit(s) of %d expired lot(s)", output.Units, output.Lots)
+	}
+	if err != nil {
+		log.Printf("[LotQuarantineScheduler] ERROR: Quarantine run failed: %v", err)
+	}
+}
@@ -0,0 +1,61 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/application/usecase"
+)
+
+// MockQuarantineExpiredLotsUseCase mocks the use case
+type MockQuarantineExpiredLotsUseCase struct {
+	mock.Mock
+}
+
+func (m *MockQuarantineExpiredLotsUseCase) Execute(ctx context.Context) (*usecase.QuarantineExpiredLotsOutput, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.QuarantineExpiredLotsOutput), args.Error(1)
+}
+
+func TestLotQuarantineScheduler_RunsOnStart(t *testing.T) {
+	mockUseCase := &MockQuarantineExpiredLotsUseCase{}
+	executed := make(chan struct{}, 10)
+	mockUseCase.On("Execute", mock.Anything).
+		Return(&usecase.QuarantineExpiredLotsOutput{Lots: 2, Units: 15}, nil).
+		Run(func(mock.Arguments) { executed <- struct{}{} })
+
+	// The interval is long, so only the initial run can happen
+	scheduler := NewLotQuarantineScheduler(mockUseCase, time.Hour)
+	scheduler.Start()
+
+	select {
+	case <-executed:
+	case <-time.After(time.Second):
+		t.Fatal("quarantine did not run")
+	}
+	scheduler.Stop()
+
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestLotQuarantineScheduler_HandlesErrors(t *testing.T) {
+	mockUseCase := &MockQuarantineExpiredLotsUseCase{}
+	mockUseCase.On("Execute", mock.Anything).
+		Return(&usecase.QuarantineExpiredLotsOutput{Lots: 1, Units: 3}, errors.New("lock timeout")).Maybe()
+
+	scheduler := NewLotQuarantineScheduler(mockUseCase, 50*time.Millisecond)
+	scheduler.Start()
+	time.Sleep(120 * time.Millisecond)
+	scheduler.Stop()
+
+	// Should not panic despite errors
+	assert.True(t, true)
+}
@@ -0,0 +1,88 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/application/usecase"
+)
+
+// ReconcileInventoryExecutor interface for the use case
+type ReconcileInventoryExecutor interface {
+	Execute(ctx context.Context, input usecase.ReconcileInventoryInput) (*usecase.ReconcileInventoryOutput, error)
+}
+
+// ReconciliationScheduler periodically checks inventory for drift and,
+// when repair is enabled, fixes it
+type ReconciliationScheduler struct {
+	reconcileUseCase ReconcileInventoryExecutor
+	interval         time.Duration
+	repair           bool
+	stopChan         chan bool
+}
+
+// NewReconciliationScheduler creates a new scheduler instance
+func NewReconciliationScheduler(
+	reconcileUseCase ReconcileInventoryExecutor,
+	interval time.Duration,
+	repair bool,
+) *ReconciliationScheduler {
+	return &ReconciliationScheduler{
+		reconcileUseCase: reconcileUseCase,
+		interval:         interval,
+		repair:           repair,
+		stopChan:         make(chan bool),
+	}
+}
+
+// Start begins the scheduler loop in a goroutine
+func (s *ReconciliationScheduler) Start() {
+	log.Printf("[ReconciliationScheduler] Starting with interval: %s (repair: %v)", s.interval, s.repair)
+
+	go func() {
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				s.runReconcile()
+			case <-s.stopChan:
+				log.Println("[ReconciliationScheduler] Stopped")
+				return
+			}
+		}
+	}()
+}
+
+// Stop gracefully stops the scheduler
+func (s *ReconciliationScheduler) Stop() {
+	log.Println("[ReconciliationScheduler] Stopping...")
+	s.stopChan <- true
+	close(s.stopChan)
+}
+
+// runReconcile executes one reconciliation run and logs the drift report
+func (s *ReconciliationScheduler) runReconcile() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	output, err := s.reconcileUseCase.Execute(ctx, usecase.ReconcileInventoryInput{Repair: s.repair})
+	if err != nil {
+		log.Printf("[ReconciliationScheduler] ERROR: Reconciliation failed: %v", err)
+		return
+	}
+
+	if len(output.Drifts) == 0 {
+		log.Printf("[ReconciliationScheduler] No drift found (%dms)", output.DurationMillis)
+		return
+	}
+
+	log.Printf("[ReconciliationScheduler] WARNING: %d drift(s) found, %d repaired, %d failed, %d remaining",
+		len(output.Drifts), output.Repaired, output.Failed, output.Remaining())
+	if report, err := json.Marshal(output); err == nil {
+		log.Printf("[ReconciliationScheduler] Drift report: %s", report)
+	}
+}
@@ -3,6 +3,7 @@ package scheduler
 import (
 	"context"
 	"log"
+	"sync/atomic"
 	"time"
 
 	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/application/usecase"
@@ -18,6 +19,7 @@ type ReservationScheduler struct {
 	releaseExpiredUseCase ReleaseExpiredReservationsExecutor
 	interval              time.Duration
 	stopChan              chan bool
+	lastHeartbeat         atomic.Int64 // Unix nanoseconds of the last loop iteration
 }
 
 // NewReservationScheduler creates a new scheduler instance
@@ -36,6 +38,8 @@ func NewReservationScheduler(
 func (s *ReservationScheduler) Start() {
 	log.Printf("[ReservationScheduler] Starting with interval: %s", s.interval)
 
+	s.beat()
+
 	go func() {
 		ticker := time.NewTicker(s.interval)
 		defer ticker.Stop()
@@ -43,6 +47,7 @@ func (s *ReservationScheduler) Start() {
 		for {
 			select {
 			case <-ticker.C:
+				s.beat()
 				s.runReleaseExpired()
 			case <-s.stopChan:
 				log.Println("[ReservationScheduler] Stopped")
@@ -59,6 +64,27 @@ func (s *ReservationScheduler) Stop() {
 	close(s.stopChan)
 }
 
+// Interval returns the configured interval between runs
+func (s *ReservationScheduler) Interval() time.Duration {
+	return s.interval
+}
+
+// LastHeartbeat returns the time of the last scheduler loop iteration.
+// Returns the zero time if the scheduler has not been started.
+// Used by readiness probes to detect a stuck or dead scheduler goroutine.
+func (s *ReservationScheduler) LastHeartbeat() time.Time {
+	nanos := s.lastHeartbeat.Load()
+	if nanos == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, nanos)
+}
+
+// beat records a heartbeat for the current loop iteration
+func (s *ReservationScheduler) beat() {
+	s.lastHeartbeat.Store(time.Now().UnixNano())
+}
+
 // runReleaseExpired executes the release expired reservations use case
 func (s *ReservationScheduler) runReleaseExpired() {
 	log.Println("[ReservationScheduler] Running release expired reservations task")
@@ -167,3 +167,27 @@ func TestReservationScheduler_LogsFailedReservations(t *testing.T) {
 	// Should handle and log failures without crashing
 	assert.True(t, true)
 }
+
+func TestReservationScheduler_Heartbeat(t *testing.T) {
+	mockUseCase := &MockReleaseExpiredReservationsUseCase{}
+	mockUseCase.On("Execute", mock.Anything).Return(&usecase.ReleaseExpiredReservationsOutput{}, nil).Maybe()
+
+	scheduler := NewReservationScheduler(
+		mockUseCase,
+		50*time.Millisecond,
+	)
+
+	// No heartbeat before start
+	assert.True(t, scheduler.LastHeartbeat().IsZero())
+	assert.Equal(t, 50*time.Millisecond, scheduler.Interval())
+
+	scheduler.Start()
+	first := scheduler.LastHeartbeat()
+	assert.False(t, first.IsZero())
+
+	// Heartbeat advances on each tick
+	time.Sleep(120 * time.Millisecond)
+	assert.True(t, scheduler.LastHeartbeat().After(first))
+
+	scheduler.Stop()
+}
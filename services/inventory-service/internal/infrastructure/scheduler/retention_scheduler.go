@@ -0,0 +1,82 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/application/usecase"
+)
+
+// ArchiveReservationsExecutor interface for the use case
+type ArchiveReservationsExecutor interface {
+	Execute(ctx context.Context) (*usecase.ArchiveReservationsOutput, error)
+}
+
+// RetentionScheduler periodically archives old terminal reservations and
+// maintains the monthly reservation partitions
+type RetentionScheduler struct {
+	archiveUseCase ArchiveReservationsExecutor
+	interval       time.Duration
+	stopChan       chan bool
+}
+
+// NewRetentionScheduler creates a new scheduler instance
+func NewRetentionScheduler(archiveUseCase ArchiveReservationsExecutor, interval time.Duration) *RetentionScheduler {
+	return &RetentionScheduler{
+		archiveUseCase: archiveUseCase,
+		interval:       interval,
+		stopChan:       make(chan bool),
+	}
+}
+
+// Start begins the scheduler loop in a goroutine.
+// The first run happens right away so partitions exist before the first tick.
+func (s *RetentionScheduler) Start() {
+	log.Printf("[RetentionScheduler] Starting with interval: %s", s.interval)
+
+	go func() {
+		s.runRetention()
+
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				s.runRetention()
+			case <-s.stopChan:
+				log.Println("[RetentionScheduler] Stopped")
+				return
+			}
+		}
+	}()
+}
+
+// Stop gracefully stops the scheduler
+func (s *RetentionScheduler) Stop() {
+	log.Println("[RetentionScheduler] Stopping...")
+	s.stopChan <- true
+	close(s.stopChan)
+}
+
+// runRetention executes one retention run and logs the result
+func (s *RetentionScheduler) runRetention() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	output, err := s.archiveUseCase.Execute(ctx)
+	if err != nil {
+		log.Printf("[RetentionScheduler] ERROR: Retention run failed: %v", err)
+		return
+	}
+
+	log.Printf("[RetentionScheduler] Archived %d reservation(s) in %d batch(es), %d partition(s) created, %d dropped (%dms)",
+		output.Archived, output.Batches, len(output.PartitionsCreated), len(output.PartitionsDropped), output.DurationMillis)
+	if !output.Complete {
+		log.Println("[RetentionScheduler] Batch limit reached, the rest is left for the next run")
+	}
+	if output.DefaultPartitionRows > 0 {
+		log.Printf("[RetentionScheduler] WARNING: %d reservation(s) in the default partition", output.DefaultPartitionRows)
+	}
+}
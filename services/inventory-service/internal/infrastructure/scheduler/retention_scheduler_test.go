@@ -0,0 +1,60 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/application/usecase"
+)
+
+// MockArchiveReservationsUseCase mocks the use case
+type MockArchiveReservationsUseCase struct {
+	mock.Mock
+}
+
+func (m *MockArchiveReservationsUseCase) Execute(ctx context.Context) (*usecase.ArchiveReservationsOutput, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.ArchiveReservationsOutput), args.Error(1)
+}
+
+func TestRetentionScheduler_RunsOnStart(t *testing.T) {
+	mockUseCase := &MockArchiveReservationsUseCase{}
+	executed := make(chan struct{}, 10)
+	mockUseCase.On("Execute", mock.Anything).
+		Return(&usecase.ArchiveReservationsOutput{Archived: 3, Batches: 1, Complete: true, DefaultPartitionRows: 2}, nil).
+		Run(func(mock.Arguments) { executed <- struct{}{} })
+
+	// The interval is long, so only the initial run can happen
+	scheduler := NewRetentionScheduler(mockUseCase, time.Hour)
+	scheduler.Start()
+
+	select {
+	case <-executed:
+	case <-time.After(time.Second):
+		t.Fatal("retention did not run")
+	}
+	scheduler.Stop()
+
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestRetentionScheduler_HandlesErrors(t *testing.T) {
+	mockUseCase := &MockArchiveReservationsUseCase{}
+	mockUseCase.On("Execute", mock.Anything).Return(nil, errors.New("database error")).Maybe()
+
+	scheduler := NewRetentionScheduler(mockUseCase, 50*time.Millisecond)
+	scheduler.Start()
+	time.Sleep(120 * time.Millisecond)
+	scheduler.Stop()
+
+	// Should not panic despite errors
+	assert.True(t, true)
+}
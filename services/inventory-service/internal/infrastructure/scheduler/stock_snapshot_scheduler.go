@@ -0,0 +1,82 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/application/usecase"
+)
+
+// TakeStockSnapshotExecutor interface for the use case
+type TakeStockSnapshotExecutor interface {
+	Execute(ctx context.Context) (*usecase.TakeStockSnapshotOutput, error)
+}
+
+// StockSnapshotScheduler periodically snapshots the stock of every item so that
+// point-in-time queries have a recent starting point, and prunes old history
+type StockSnapshotScheduler struct {
+	snapshotUseCase TakeStockSnapshotExecutor
+	interval        time.Duration
+	stopChan        chan bool
+}
+
+// NewStockSnapshotScheduler creates a new scheduler instance
+func NewStockSnapshotScheduler(snapshotUseCase TakeStockSnapshotExecutor, interval time.Duration) *StockSnapshotScheduler {
+	return &StockSnapshotScheduler{
+		snapshotUseCase: snapshotUseCase,
+		interval:        interval,
+		stopChan:        make(chan bool),
+	}
+}
+
+// Start begins the scheduler loop in a goroutine.
+// The first run happens right away; it is a no-op if a newer snapshot exists.
+func (s *StockSnapshotScheduler) Start() {
+	log.Printf("[StockSnapshotScheduler] Starting with interval: %s", s.interval)
+
+	go func() {
+		s.runSnapshot()
+
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				s.runSnapshot()
+			case <-s.stopChan:
+				log.Println("[StockSnapshotScheduler] Stopped")
+				return
+			}
+		}
+	}()
+}
+
+// Stop gracefully stops the scheduler
+func (s *StockSnapshotScheduler) Stop() {
+	log.Println("[StockSnapshotScheduler] Stopping...")
+	s.stopChan <- true
+	close(s.stopChan)
+}
+
+// runSnapshot executes one snapshot run and logs the result
+func (s *StockSnapshotScheduler) runSnapshot() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	output, err := s.snapshotUseCase.Execute(ctx)
+	if err != nil {
+		log.Printf("[StockSnapshotScheduler] ERROR: Snapshot run failed: %v", err)
+		return
+	}
+
+	if output.Taken {
+		log.Printf("[StockSnapshotScheduler] Snapshot of %d item(s) taken at %s", output.Items, output.TakenAt.Format(time.RFC3339))
+	} else {
+		log.Printf("[StockSnapshotScheduler] Snapshot at %s skipped: a newer one exists or older transactions are still open", output.TakenAt.Format(time.RFC3339))
+	}
+	if output.SnapshotsPruned > 0 || output.MovementsPruned > 0 {
+		log.Printf("[StockSnapshotScheduler] Pruned %d snapshot(s) and %d movement(s)", output.SnapshotsPruned, output.MovementsPruned)
+	}
+}
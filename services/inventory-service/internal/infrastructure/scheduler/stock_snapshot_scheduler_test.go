@@ -0,0 +1,60 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/application/usecase"
+)
+
+// MockTakeStockSnapshotUseCase mocks the use case
+type MockTakeStockSnapshotUseCase struct {
+	mock.Mock
+}
+
+func (m *MockTakeStockSnapshotUseCase) Execute(ctx context.Context) (*usecase.TakeStockSnapshotOutput, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.TakeStockSnapshotOutput), args.Error(1)
+}
+
+func TestStockSnapshotScheduler_RunsOnStart(t *testing.T) {
+	mockUseCase := &MockTakeStockSnapshotUseCase{}
+	executed := make(chan struct{}, 10)
+	mockUseCase.On("Execute", mock.Anything).
+		Return(&usecase.TakeStockSnapshotOutput{TakenAt: time.Now(), Taken: true, Items: 5, MovementsPruned: 12}, nil).
+		Run(func(mock.Arguments) { executed <- struct{}{} })
+
+	// The interval is long, so only the initial run can happen
+	scheduler := NewStockSnapshotScheduler(mockUseCase, time.Hour)
+	scheduler.Start()
+
+	select {
+	case <-executed:
+	case <-time.After(time.Second):
+		t.Fatal("snapshot did not run")
+	}
+	scheduler.Stop()
+
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestStockSnapshotScheduler_HandlesErrors(t *testing.T) {
+	mockUseCase := &MockTakeStockSnapshotUseCase{}
+	mockUseCase.On("Execute", mock.Anything).Return(nil, errors.New("database error")).Maybe()
+
+	scheduler := NewStockSnapshotScheduler(mockUseCase, 50*time.Millisecond)
+	scheduler.Start()
+	time.Sleep(120 * time.Millisecond)
+	scheduler.Stop()
+
+	// Should not panic despite errors
+	assert.True(t, true)
+}
@@ -0,0 +1,118 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/application/usecase"
+	"github.com/google/uuid"
+)
+
+// waitlistNotificationBuffer is the number of products waiting to be served
+// before further notifications are dropped for the next sweep
+const waitlistNotificationBuffer = 256
+
+// SweepWaitlistsExecutor interface for the sweep use case
+type SweepWaitlistsExecutor interface {
+	Execute(ctx context.Context) (*usecase.SweepWaitlistsOutput, error)
+}
+
+// ServeWaitlistExecutor interface for the serve use case
+type ServeWaitlistExecutor interface {
+	Execute(ctx context.Context, productID uuid.UUID) (*usecase.ServeWaitlistOutput, error)
+}
+
+// WaitlistScheduler serves the waitlist of every product whose stock freed up,
+// and periodically sweeps every waitlist to expire old entries and catch stock
+// freed without a notification. Products are served one at a time.
+type WaitlistScheduler struct {
+	sweepUseCase SweepWaitlistsExecutor
+	serveUseCase ServeWaitlistExecutor
+	interval     time.Duration
+	notified     chan uuid.UUID
+	stopChan     chan bool
+}
+
+// NewWaitlistScheduler creates a new scheduler instance
+func NewWaitlistScheduler(sweepUseCase SweepWaitlistsExecutor, serveUseCase ServeWaitlistExecutor, interval time.Duration) *WaitlistScheduler {
+	return &WaitlistScheduler{
+		sweepUseCase: sweepUseCase,
+		serveUseCase: serveUseCase,
+		interval:     interval,
+		notified:     make(chan uuid.UUID, waitlistNotificationBuffer),
+		stopChan:     make(chan bool),
+	}
+}
+
+// StockAvailable queues the product's waitlist to be served. It never blocks:
+// when the queue is full the product is served by the next sweep.
+func (s *WaitlistScheduler) StockAvailable(productID uuid.UUID) {
+	select {
+	case s.notified <- productID:
+	default:
+		log.Printf("[WaitlistScheduler] Queue full, product %s is left to the next sweep", productID)
+	}
+}
+
+// Start begins the scheduler loop in a goroutine.
+// The first sweep happens right away so stock freed while the service was down is served.
+func (s *WaitlistScheduler) Start() {
+	log.Printf("[WaitlistScheduler] Starting with interval: %s", s.interval)
+
+	go func() {
+		s.runSweep()
+
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case productID := <-s.notified:
+				s.runServe(productID)
+			case <-ticker.C:
+				s.runSweep()
+			case <-s.stopChan:
+				log.Println("[WaitlistScheduler] Stopped")
+				return
+			}
+		}
+	}()
+}
+
+// Stop gracefully stops the scheduler
+func (s *WaitlistScheduler) Stop() {
+	log.Println("[WaitlistScheduler] Stopping...")
+	s.stopChan <- true
+	close(s.stopChan)
+}
+
+// runSweep executes one sweep and logs the result
+func (s *WaitlistScheduler) runSweep() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	output, err := s.sweepUseCase.Execute(ctx)
+	if output != nil && (output.Expired > 0 || output.Fulfilled > 0 || output.Failed > 0) {
+		log.Printf("[WaitlistScheduler] Swept %d waitlist(s): %d fulfilled, %d failed, %d expired",
+			output.Products, output.Fulfilled, output.Failed, output.Expired)
+	}
+	if err != nil {
+		log.Printf("[WaitlistScheduler] ERROR: Sweep failed: %v", err)
+	}
+}
+
+// runServe serves the waitlist of one product and logs the result
+func (s *WaitlistScheduler) runServe(productID uuid.UUID) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	output, err := s.serveUseCase.Execute(ctx, productID)
+	if output != nil && (len(output.Fulfilled) > 0 || len(output.Failed) > 0) {
+		log.Printf("[WaitlistScheduler] Served the waitlist of product %s: %d fulfilled, %d failed",
+			productID, len(output.Fulfilled), len(output.Failed))
+	}
+	if err != nil {
+		log.Printf("[WaitlistScheduler] ERROR: Failed to serve the waitlist of product %s: %v", productID, err)
+	}
+}
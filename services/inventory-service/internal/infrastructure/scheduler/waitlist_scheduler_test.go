@@ -0,0 +1,101 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/application/usecase"
+)
+
+// MockSweepWaitlistsUseCase mocks the sweep use case
+type MockSweepWaitlistsUseCase struct {
+	mock.Mock
+}
+
+func (m *MockSweepWaitlistsUseCase) Execute(ctx context.Context) (*usecase.SweepWaitlistsOutput, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.SweepWaitlistsOutput), args.Error(1)
+}
+
+// MockServeWaitlistUseCase mocks the serve use case
+type MockServeWaitlistUseCase struct {
+	mock.Mock
+}
+
+func (m *MockServeWaitlistUseCase) Execute(ctx context.Context, productID uuid.UUID) (*usecase.ServeWaitlistOutput, error) {
+	args := m.Called(ctx, productID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.ServeWaitlistOutput), args.Error(1)
+}
+
+func TestWaitlistScheduler_SweepsOnStart(t *testing.T) {
+	sweep, serve := &MockSweepWaitlistsUseCase{}, &MockServeWaitlistUseCase{}
+	executed := make(chan struct{}, 10)
+	sweep.On("Execute", mock.Anything).
+		Return(&usecase.SweepWaitlistsOutput{Expired: 1, Products: 2, Fulfilled: 3}, nil).
+		Run(func(mock.Arguments) { executed <- struct{}{} })
+
+	// The interval is long, so only the initial sweep can happen
+	scheduler := NewWaitlistScheduler(sweep, serve, time.Hour)
+	scheduler.Start()
+
+	select {
+	case <-executed:
+	case <-time.After(time.Second):
+		t.Fatal("sweep did not run")
+	}
+	scheduler.Stop()
+
+	sweep.AssertExpectations(t)
+}
+
+func TestWaitlistScheduler_ServesNotifiedProducts(t *testing.T) {
+	sweep, serve := &MockSweepWaitlistsUseCase{}, &MockServeWaitlistUseCase{}
+	productID := uuid.New()
+	served := make(chan uuid.UUID, 10)
+	sweep.On("Execute", mock.Anything).Return(&usecase.SweepWaitlistsOutput{}, nil)
+	serve.On("Execute", mock.Anything, productID).
+		Return(&usecase.ServeWaitlistOutput{ProductID: productID}, errors.New("lock timeout")).
+		Run(func(args mock.Arguments) { served <- args.Get(1).(uuid.UUID) })
+
+	scheduler := NewWaitlistScheduler(sweep, serve, time.Hour)
+	scheduler.StockAvailable(productID)
+	scheduler.Start()
+
+	select {
+	case got := <-served:
+		assert.Equal(t, productID, got)
+	case <-time.After(time.Second):
+		t.Fatal("waitlist was not served")
+	}
+	scheduler.Stop()
+}
+
+func TestWaitlistScheduler_StockAvailableNeverBlocks(t *testing.T) {
+	scheduler := NewWaitlistScheduler(&MockSweepWaitlistsUseCase{}, &MockServeWaitlistUseCase{}, time.Hour)
+
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < waitlistNotificationBuffer+10; i++ {
+			scheduler.StockAvailable(uuid.New())
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("StockAvailable blocked on a full queue")
+	}
+}
@@ -0,0 +1,305 @@
+// Package stockfile reads stock count files and writes stock exports as CSV or
+// JSON Lines. CSV files start with a header naming their columns; JSON Lines
+// files hold one object per line with the same field names.
+package stockfile
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/application/usecase"
+	"github.com/google/uuid"
+)
+
+// Format is the encoding of a stock file
+type Format string
+
+// Supported formats
+const (
+	FormatCSV   Format = "csv"
+	FormatJSONL Format = "jsonl"
+)
+
+// Column names shared by both formats
+const (
+	ColumnProductID = "product_id"
+	ColumnSKU       = "sku"
+	ColumnQuantity  = "quantity"
+	ColumnDelta     = "delta"
+)
+
+// exportColumns is the CSV header of an export
+var exportColumns = []string{ColumnProductID, ColumnSKU, ColumnQuantity, "reserved", "available", "inventory_item_id", "version", "updated_at"}
+
+// maxLineBytes bounds a single JSON Lines row
+const maxLineBytes = 64 * 1024
+
+// utf8BOM is prepended by spreadsheet tools when saving CSV as UTF-8
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// ParseFormat returns the format with the given name ("csv", "jsonl" or "ndjson")
+func ParseFormat(name string) (Format, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "csv":
+		return FormatCSV, nil
+	case "jsonl", "ndjson":
+		return FormatJSONL, nil
+	}
+	return "", fmt.Errorf("unsupported stock file format %q, use csv or jsonl", name)
+}
+
+// FormatFromFilename guesses the format from the file extension, defaulting to CSV
+func FormatFromFilename(name string) Format {
+	if format, err := ParseFormat(strings.TrimPrefix(filepath.Ext(name), ".")); err == nil {
+		return format
+	}
+	return FormatCSV
+}
+
+// ContentType returns the MIME type of the format
+func (f Format) ContentType() string {
+	if f == FormatJSONL {
+		return "application/x-ndjson"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// Read decodes every row of a stock count file. Rows that cannot be decoded are
+// returned with Invalid set so they appear in the import report; an error is
+// returned only when the file as a whole is unreadable.
+func Read(r io.Reader, format Format) ([]usecase.StockImportRow, error) {
+	if format == FormatJSONL {
+		return readJSONL(r)
+	}
+	return readCSV(r)
+}
+
+// readCSV decodes a CSV file whose first line names the columns
+func readCSV(r io.Reader) ([]usecase.StockImportRow, error) {
+	reader := csv.NewReader(skipBOM(r))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, errors.New("the file is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if _, duplicate := columns[name]; duplicate {
+			return nil, fmt.Errorf("column %q appears twice in the CSV header", name)
+		}
+		columns[name] = i
+	}
+	if _, ok := columns[ColumnProductID]; !ok {
+		return nil, fmt.Errorf("the CSV header has no %s column", ColumnProductID)
+	}
+	_, hasQuantity := columns[ColumnQuantity]
+	_, hasDelta := columns[ColumnDelta]
+	if !hasQuantity && !hasDelta {
+		return nil, fmt.Errorf("the CSV header needs a %s or %s column", ColumnQuantity, ColumnDelta)
+	}
+
+	rows := make([]usecase.StockImportRow, 0)
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CSV: %w", err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		if len(record) != len(header) {
+			rows = append(rows, usecase.StockImportRow{
+				Line:    line,
+				Invalid: fmt.Sprintf("expected %d fields, got %d", len(header), len(record)),
+			})
+			continue
+		}
+
+		field := func(name string) string {
+			if i, ok := columns[name]; ok {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		rows = append(rows, decodeFields(line, field(ColumnProductID), field(ColumnSKU), field(ColumnQuantity), field(ColumnDelta)))
+	}
+}
+
+// decodeFields builds a row from the text of its fields; empty quantities are absent
+func decodeFields(line int, productID, sku, quantity, delta string) usecase.StockImportRow {
+	row := usecase.StockImportRow{Line: line, SKU: sku}
+
+	var problems []string
+	if productID != "" {
+		id, err := uuid.Parse(productID)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s %q is not a UUID", ColumnProductID, productID))
+		}
+		row.ProductID = id
+	}
+	for _, f := range []struct {
+		name  string
+		text  string
+		value **int
+	}{{ColumnQuantity, quantity, &row.Quantity}, {ColumnDelta, delta, &row.Delta}} {
+		if f.text == "" {
+			continue
+		}
+		n, err := strconv.Atoi(f.text)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s %q is not an integer", f.name, f.text))
+			continue
+		}
+		*f.value = &n
+	}
+
+	row.Invalid = strings.Join(problems, "; ")
+	return row
+}
+
+// jsonRow is one line of a JSON Lines stock count file
+type jsonRow struct {
+	ProductID string `json:"product_id"`
+	SKU       string `json:"sku"`
+	Quantity  *int   `json:"quantity"`
+	Delta     *int   `json:"delta"`
+}
+
+// readJSONL decodes a JSON Lines file, skipping blank lines
+func readJSONL(r io.Reader) ([]usecase.StockImportRow, error) {
+	scanner := bufio.NewScanner(skipBOM(r))
+	scanner.Buffer(make([]byte, 0, 4096), maxLineBytes)
+
+	rows := make([]usecase.StockImportRow, 0)
+	for line := 1; scanner.Scan(); line++ {
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+
+		// Other fields, such as the read-only ones of an export, are ignored
+		var decoded jsonRow
+		if err := json.Unmarshal(text, &decoded); err != nil {
+			rows = append(rows, usecase.StockImportRow{Line: line, Invalid: "invalid JSON: " + err.Error()})
+			continue
+		}
+
+		row := decodeFields(line, strings.TrimSpace(decoded.ProductID), decoded.SKU, "", "")
+		row.Quantity, row.Delta = decoded.Quantity, decoded.Delta
+		rows = append(rows, row)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read JSON Lines: %w", err)
+	}
+
+	return rows, nil
+}
+
+// skipBOM drops a leading UTF-8 byte order mark
+func skipBOM(r io.Reader) io.Reader {
+	buffered := bufio.NewReader(r)
+	if prefix, err := buffered.Peek(len(utf8BOM)); err == nil && bytes.Equal(prefix, utf8BOM) {
+		_, _ = buffered.Discard(len(utf8BOM))
+	}
+	return buffered
+}
+
+// Writer encodes exported stock levels. It implements usecase.StockLevelWriter.
+type Writer struct {
+	format      Format
+	csv         *csv.Writer
+	json        *json.Encoder
+	wroteHeader bool
+}
+
+// NewWriter creates a writer that encodes stock levels to w
+func NewWriter(w io.Writer, format Format) *Writer {
+	writer := &Writer{format: format}
+	if format == FormatJSONL {
+		writer.json = json.NewEncoder(w)
+	} else {
+		writer.csv = csv.NewWriter(w)
+	}
+	return writer
+}
+
+// exportRow is one line of a JSON Lines export
+type exportRow struct {
+	ProductID       uuid.UUID `json:"product_id"`
+	SKU             string    `json:"sku"`
+	Quantity        int       `json:"quantity"`
+	Reserved        int       `json:"reserved"`
+	Available       int       `json:"available"`
+	InventoryItemID uuid.UUID `json:"inventory_item_id"`
+	Version         int       `json:"version"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// Write encodes one stock level
+func (w *Writer) Write(level usecase.StockLevel) error {
+	if w.format == FormatJSONL {
+		return w.json.Encode(exportRow{
+			ProductID:       level.ProductID,
+			SKU:             level.SKU,
+			Quantity:        level.Quantity,
+			Reserved:        level.Reserved,
+			Available:       level.Available,
+			InventoryItemID: level.InventoryItemID,
+			Version:         level.Version,
+			UpdatedAt:       level.UpdatedAt.UTC(),
+		})
+	}
+
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+	return w.csv.Write([]string{
+		level.ProductID.String(),
+		level.SKU,
+		strconv.Itoa(level.Quantity),
+		strconv.Itoa(level.Reserved),
+		strconv.Itoa(level.Available),
+		level.InventoryItemID.String(),
+		strconv.Itoa(level.Version),
+		level.UpdatedAt.UTC().Format(time.RFC3339),
+	})
+}
+
+// Flush writes any buffered data. An export without rows still gets its CSV header.
+func (w *Writer) Flush() error {
+	if w.csv == nil {
+		return nil
+	}
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+	w.csv.Flush()
+	return w.csv.Error()
+}
+
+// writeHeader writes the CSV header before the first row
+func (w *Writer) writeHeader() error {
+	if w.wroteHeader {
+		return nil
+	}
+	w.wroteHeader = true
+	return w.csv.Write(exportColumns)
+}
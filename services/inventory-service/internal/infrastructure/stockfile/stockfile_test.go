@@ -0,0 +1,130 @@
+package stockfile
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/application/usecase"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func intPtr(n int) *int { return &n }
+
+func TestParseFormat(t *testing.T) {
+	format, err := ParseFormat("CSV")
+	require.NoError(t, err)
+	assert.Equal(t, FormatCSV, format)
+
+	format, err = ParseFormat("ndjson")
+	require.NoError(t, err)
+	assert.Equal(t, FormatJSONL, format)
+
+	_, err = ParseFormat("xlsx")
+	assert.Error(t, err)
+
+	assert.Equal(t, FormatJSONL, FormatFromFilename("counts.jsonl"))
+	assert.Equal(t, FormatCSV, FormatFromFilename("counts.txt"))
+}
+
+func TestRead_CSV(t *testing.T) {
+	id1, id2 := uuid.New(), uuid.New()
+	file := "\xEF\xBB\xBFProduct_ID, SKU ,quantity,delta\n" +
+		id1.String() + ",SKU-1,40,\n" +
+		id2.String() + ",SKU-2,,-3\n" +
+		"not-a-uuid,SKU-3,ten,\n" +
+		id1.String() + ",SKU-4\n"
+
+	rows, err := Read(strings.NewReader(file), FormatCSV)
+
+	require.NoError(t, err)
+	require.Len(t, rows, 4)
+	assert.Equal(t, usecase.StockImportRow{Line: 2, ProductID: id1, SKU: "SKU-1", Quantity: intPtr(40)}, rows[0])
+	assert.Equal(t, usecase.StockImportRow{Line: 3, ProductID: id2, SKU: "SKU-2", Delta: intPtr(-3)}, rows[1])
+	assert.Equal(t, 4, rows[2].Line)
+	assert.Contains(t, rows[2].Invalid, `product_id "not-a-uuid" is not a UUID`)
+	assert.Contains(t, rows[2].Invalid, `quantity "ten" is not an integer`)
+	assert.Equal(t, "expected 4 fields, got 2", rows[3].Invalid)
+}
+
+func TestRead_CSV_BadHeader(t *testing.T) {
+	_, err := Read(strings.NewReader(""), FormatCSV)
+	assert.EqualError(t, err, "the file is empty")
+
+	_, err = Read(strings.NewReader("sku,quantity\n"), FormatCSV)
+	assert.ErrorContains(t, err, "no product_id column")
+
+	_, err = Read(strings.NewReader("product_id,sku\n"), FormatCSV)
+	assert.ErrorContains(t, err, "quantity or delta")
+
+	_, err = Read(strings.NewReader("product_id,quantity,Quantity\n"), FormatCSV)
+	assert.ErrorContains(t, err, "appears twice")
+}
+
+func TestRead_JSONL(t *testing.T) {
+	id := uuid.New()
+	file := `{"product_id":"` + id.String() + `","sku":"SKU-1","quantity":5}` + "\n" +
+		"\n" +
+		`{"product_id":"` + id.String() + `","delta":"2"}` + "\n" +
+		`{"product_id":"abc","delta":1}` + "\n" +
+		`{"product_id":`
+
+	rows, err := Read(strings.NewReader(file), FormatJSONL)
+
+	require.NoError(t, err)
+	require.Len(t, rows, 4)
+	assert.Equal(t, usecase.StockImportRow{Line: 1, ProductID: id, SKU: "SKU-1", Quantity: intPtr(5)}, rows[0])
+	assert.Equal(t, 3, rows[1].Line, "blank lines still count")
+	assert.Contains(t, rows[1].Invalid, "invalid JSON")
+	assert.Contains(t, rows[2].Invalid, "is not a UUID")
+	assert.Contains(t, rows[3].Invalid, "invalid JSON")
+}
+
+func TestWriter_CSV(t *testing.T) {
+	var buf bytes.Buffer
+	level := usecase.StockLevel{
+		ProductID:       uuid.MustParse("11111111-1111-1111-1111-111111111111"),
+		InventoryItemID: uuid.MustParse("22222222-2222-2222-2222-222222222222"),
+		Quantity:        10,
+		Reserved:        3,
+		Available:       7,
+		Version:         4,
+		UpdatedAt:       time.Date(2025, 11, 10, 12, 0, 0, 0, time.UTC),
+	}
+
+	writer := NewWriter(&buf, FormatCSV)
+	require.NoError(t, writer.Write(level))
+	require.NoError(t, writer.Flush())
+
+	assert.Equal(t, "product_id,sku,quantity,reserved,available,inventory_item_id,version,updated_at\n"+
+		"11111111-1111-1111-1111-111111111111,,10,3,7,22222222-2222-2222-2222-222222222222,4,2025-11-10T12:00:00Z\n", buf.String())
+
+	rows, err := Read(&buf, FormatCSV)
+	require.NoError(t, err)
+	assert.Equal(t, []usecase.StockImportRow{{Line: 2, ProductID: level.ProductID, Quantity: intPtr(10)}}, rows, "exports can be imported again")
+}
+
+func TestWriter_CSV_Empty(t *testing.T) {
+	var buf bytes.Buffer
+
+	require.NoError(t, NewWriter(&buf, FormatCSV).Flush())
+
+	assert.Equal(t, "product_id,sku,quantity,reserved,available,inventory_item_id,version,updated_at\n", buf.String())
+}
+
+func TestWriter_JSONL(t *testing.T) {
+	var buf bytes.Buffer
+	level := usecase.StockLevel{ProductID: uuid.New(), InventoryItemID: uuid.New(), Quantity: 10, Reserved: 3, Available: 7}
+
+	writer := NewWriter(&buf, FormatJSONL)
+	require.NoError(t, writer.Write(level))
+	require.NoError(t, writer.Flush())
+
+	assert.Contains(t, buf.String(), `"quantity":10,"reserved":3,"available":7`)
+	rows, err := Read(&buf, FormatJSONL)
+	require.NoError(t, err)
+	assert.Equal(t, []usecase.StockImportRow{{Line: 1, ProductID: level.ProductID, Quantity: intPtr(10)}}, rows, "exports can be imported again")
+}
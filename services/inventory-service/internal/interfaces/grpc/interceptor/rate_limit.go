@@ -0,0 +1,110 @@
+package interceptor
+
+import (
+	"context"
+	"log"
+	"math"
+	"net"
+	"strconv"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/infrastructure/ratelimit"
+)
+
+// rateLimitKeyPrefix namespaces rate limit buckets; shared with the HTTP middleware
+// so a service has one quota across both transports.
+const rateLimitKeyPrefix = "rate_limit:bucket:"
+
+// QuotaLimiter consumes one request from a rate limit bucket
+type QuotaLimiter interface {
+	Allow(ctx context.Context, key string, quota ratelimit.Quota) (ratelimit.Decision, error)
+}
+
+// RateLimit enforces token-bucket quotas per calling service on gRPC methods.
+// It must be chained after ServiceAuth so the caller is known; otherwise callers
+// are keyed by peer IP. Rejected calls return ResourceExhausted with RetryInfo.
+type RateLimit struct {
+	limiter      QuotaLimiter
+	policy       *ratelimit.Policy
+	writeMethods map[string]bool
+}
+
+// NewRateLimit creates a RateLimit.
+// writeMethods lists the full method names counted against the write quota.
+func NewRateLimit(limiter QuotaLimiter, policy *ratelimit.Policy, writeMethods map[string]bool) *RateLimit {
+	return &RateLimit{
+		limiter:      limiter,
+		policy:       policy,
+		writeMethods: writeMethods,
+	}
+}
+
+// Unary returns the unary server interceptor
+func (r *RateLimit) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if isPublic(info.FullMethod) {
+			return handler(ctx, req)
+		}
+		if err := r.allow(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// allow checks the quota and sets the ratelimit-* response headers
+func (r *RateLimit) allow(ctx context.Context, fullMethod string) error {
+	service := SourceService(ctx)
+	subject := "service:" + service
+	if service == "" {
+		subject = "ip:" + peerHost(ctx)
+	}
+
+	quota, bucket := r.policy.Resolve(service, "", fullMethod, r.writeMethods[fullMethod])
+	decision, err := r.limiter.Allow(ctx, rateLimitKeyPrefix+subject+":"+bucket, quota)
+	if err != nil {
+		log.Printf("⚠️  Rate limit check failed for %s %s: %v", subject, fullMethod, err)
+		return nil
+	}
+
+	md := metadata.Pairs(
+		"ratelimit-limit", strconv.FormatInt(decision.Limit, 10),
+		"ratelimit-remaining", strconv.FormatInt(decision.Remaining, 10),
+		"ratelimit-reset", strconv.FormatInt(ceilSeconds(decision.ResetAfter), 10),
+		"ratelimit-policy", quota.String(),
+	)
+	if !decision.Allowed {
+		md.Set("retry-after", strconv.FormatInt(ceilSeconds(decision.RetryAfter), 10))
+	}
+	_ = grpc.SetHeader(ctx, md)
+
+	if decision.Allowed {
+		return nil
+	}
+
+	st := status.Newf(codes.ResourceExhausted, "rate limit of %d requests per %s exceeded", quota.Limit, quota.Window)
+	if detailed, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(decision.RetryAfter)}); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
+func peerHost(ctx context.Context) string {
+	addr := remoteAddr(ctx)
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host
+	}
+	return addr
+}
+
+// ceilSeconds rounds a duration up to whole seconds
+func ceilSeconds(d time.Duration) int64 {
+	return int64(math.Ceil(d.Seconds()))
+}
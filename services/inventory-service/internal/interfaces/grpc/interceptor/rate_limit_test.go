@@ -0,0 +1,103 @@
+package interceptor
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/infrastructure/auth"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/infrastructure/ratelimit"
+)
+
+func callRateLimited(t *testing.T, rl *RateLimit, ctx context.Context, method string) error {
+	t.Helper()
+	_, err := rl.Unary()(ctx, "req", &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	})
+	return err
+}
+
+func authenticatedContext(service string) context.Context {
+	return context.WithValue(context.Background(), principalKey{}, &auth.Principal{Service: service})
+}
+
+func TestRateLimit_Unary(t *testing.T) {
+	policy := &ratelimit.Policy{ReadLimit: 2, WriteLimit: 1, Window: time.Minute}
+	rl := NewRateLimit(ratelimit.NewLocalLimiter(), policy, map[string]bool{reserveMethod: true})
+	orders := authenticatedContext("orders-service")
+
+	// Write quota
+	require.NoError(t, callRateLimited(t, rl, orders, reserveMethod))
+	err := callRateLimited(t, rl, orders, reserveMethod)
+	require.Error(t, err)
+
+	st := status.Convert(err)
+	assert.Equal(t, codes.ResourceExhausted, st.Code())
+	require.Len(t, st.Details(), 1)
+	retryInfo, ok := st.Details()[0].(*errdetails.RetryInfo)
+	require.True(t, ok)
+	assert.Equal(t, time.Minute, retryInfo.RetryDelay.AsDuration())
+
+	// Read quota is separate, and other services have their own buckets
+	assert.NoError(t, callRateLimited(t, rl, orders, readMethod))
+	assert.NoError(t, callRateLimited(t, rl, authenticatedContext("payments-service"), reserveMethod))
+}
+
+func TestRateLimit_PublicMethodsAreNotLimited(t *testing.T) {
+	policy := &ratelimit.Policy{ReadLimit: 1, WriteLimit: 1, Window: time.Minute}
+	rl := NewRateLimit(ratelimit.NewLocalLimiter(), policy, nil)
+
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, callRateLimited(t, rl, context.Background(), "/grpc.health.v1.Health/Check"))
+	}
+}
+
+func TestRateLimit_UnauthenticatedKeyedByPeer(t *testing.T) {
+	policy := &ratelimit.Policy{ReadLimit: 1, WriteLimit: 1, Window: time.Minute}
+	rl := NewRateLimit(ratelimit.NewLocalLimiter(), policy, nil)
+	peerCtx := func(ip string) context.Context {
+		return peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(ip), Port: 5000}})
+	}
+
+	assert.NoError(t, callRateLimited(t, rl, peerCtx("10.0.0.1"), readMethod))
+	assert.Equal(t, codes.ResourceExhausted, status.Code(callRateLimited(t, rl, peerCtx("10.0.0.1"), readMethod)))
+	assert.NoError(t, callRateLimited(t, rl, peerCtx("10.0.0.2"), readMethod))
+}
+
+func TestRateLimit_SetsHeaders(t *testing.T) {
+	policy := &ratelimit.Policy{ReadLimit: 5, WriteLimit: 1, Window: time.Minute}
+	rl := NewRateLimit(ratelimit.NewLocalLimiter(), policy, nil)
+	stream := &headerCapturingStream{}
+	ctx := grpc.NewContextWithServerTransportStream(authenticatedContext("orders-service"), stream)
+
+	require.NoError(t, callRateLimited(t, rl, ctx, readMethod))
+
+	assert.Equal(t, []string{"5"}, stream.header.Get("ratelimit-limit"))
+	assert.Equal(t, []string{"4"}, stream.header.Get("ratelimit-remaining"))
+	assert.Equal(t, []string{"5;w=60"}, stream.header.Get("ratelimit-policy"))
+}
+
+type headerCapturingStream struct {
+	header metadata.MD
+}
+
+func (s *headerCapturingStream) Method() string { return readMethod }
+
+func (s *headerCapturingStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+func (s *headerCapturingStream) SendHeader(md metadata.MD) error { return s.SetHeader(md) }
+
+func (s *headerCapturingStream) SetTrailer(md metadata.MD) error { return nil }
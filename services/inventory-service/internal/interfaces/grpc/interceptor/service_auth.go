@@ -0,0 +1,179 @@
+package interceptor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/infrastructure/auth"
+)
+
+// principalKey is the context key for the authenticated caller
+type principalKey struct{}
+
+// publicServices are exempt from authentication (probes and API discovery)
+var publicServices = []string{
+	"/grpc.health.v1.Health/",
+	"/grpc.reflection.v1.ServerReflection/",
+	"/grpc.reflection.v1alpha.ServerReflection/",
+}
+
+// TokenVerifier validates a signed service token
+type TokenVerifier interface {
+	Verify(token string) (*auth.Principal, error)
+}
+
+// DenialRecorder records rejected requests for auditing
+type DenialRecorder interface {
+	Record(denial auth.Denial)
+}
+
+// ServiceAuth validates signed service tokens sent as "authorization: Bearer <token>" metadata
+// and enforces the scopes each method declares. Methods without declared scopes are rejected.
+type ServiceAuth struct {
+	verifier     TokenVerifier
+	audit        DenialRecorder
+	methodScopes map[string][]string
+}
+
+// NewServiceAuth creates a ServiceAuth.
+// methodScopes maps gRPC full method names to the scopes they require.
+func NewServiceAuth(verifier TokenVerifier, audit DenialRecorder, methodScopes map[string][]string) *ServiceAuth {
+	return &ServiceAuth{
+		verifier:     verifier,
+		audit:        audit,
+		methodScopes: methodScopes,
+	}
+}
+
+// Unary returns the unary server interceptor
+func (a *ServiceAuth) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if isPublic(info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		ctx, err := a.authorize(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// Stream returns the stream server interceptor
+func (a *ServiceAuth) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if isPublic(info.FullMethod) {
+			return handler(srv, ss)
+		}
+
+		ctx, err := a.authorize(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// authorize verifies the token and the method scopes, returning a context carrying the principal
+func (a *ServiceAuth) authorize(ctx context.Context, fullMethod string) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	principal, err := a.verifier.Verify(bearerToken(firstValue(md, "authorization")))
+	if err != nil {
+		a.audit.Record(auth.Denial{
+			Transport:  "grpc",
+			Operation:  fullMethod,
+			Reason:     auth.Reason(err),
+			Detail:     err.Error(),
+			RemoteAddr: remoteAddr(ctx),
+		})
+		return nil, status.Error(codes.Unauthenticated, "a valid service token is required: provide authorization: Bearer <token> metadata")
+	}
+
+	required, declared := a.methodScopes[fullMethod]
+	missing := principal.MissingScopes(required...)
+	if !declared || len(missing) > 0 {
+		detail := ""
+		if !declared {
+			detail = "method declares no scopes"
+		}
+		a.audit.Record(auth.Denial{
+			Transport:      "grpc",
+			Operation:      fullMethod,
+			Reason:         auth.ReasonInsufficientScope,
+			Detail:         detail,
+			Service:        principal.Service,
+			KeyID:          principal.KeyID,
+			RequiredScopes: required,
+			RemoteAddr:     remoteAddr(ctx),
+		})
+		return nil, status.Error(codes.PermissionDenied, fmt.Sprintf("the service token lacks required scopes: %s", strings.Join(missing, ", ")))
+	}
+
+	return context.WithValue(ctx, principalKey{}, principal), nil
+}
+
+// PrincipalFromContext returns the authenticated caller stored by the interceptor
+func PrincipalFromContext(ctx context.Context) (*auth.Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(*auth.Principal)
+	return principal, ok
+}
+
+// SourceService returns the calling service name taken from the token subject
+func SourceService(ctx context.Context) string {
+	if principal, ok := PrincipalFromContext(ctx); ok {
+		return principal.Service
+	}
+	return ""
+}
+
+// authenticatedStream overrides the stream context with the authenticated one
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
+
+func isPublic(fullMethod string) bool {
+	for _, prefix := range publicServices {
+		if strings.HasPrefix(fullMethod, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// bearerToken extracts the token from "Bearer <token>"; anything else yields an empty token
+func bearerToken(value string) string {
+	parts := strings.SplitN(value, " ", 2)
+	if len(parts) != 2 || !strings.EqualFold(parts[0], "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(parts[1])
+}
+
+func firstValue(md metadata.MD, key string) string {
+	values := md.Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+func remoteAddr(ctx context.Context) string {
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		return p.Addr.String()
+	}
+	return ""
+}
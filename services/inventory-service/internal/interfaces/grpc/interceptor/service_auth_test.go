@@ -0,0 +1,146 @@
+package interceptor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/infrastructure/auth"
+)
+
+const (
+	readMethod    = "/inventory.v1.InventoryService/CheckAvailability"
+	reserveMethod = "/inventory.v1.InventoryService/ReserveStock"
+)
+
+var testTokenSecret = []byte("0123456789abcdef0123456789abcdef")
+
+func newTestAuth(t *testing.T) (*ServiceAuth, *auth.DenialAudit) {
+	t.Helper()
+	verifier, err := auth.NewVerifier(
+		[]auth.Key{{ID: "k1", Algorithm: auth.AlgorithmHS256, Secret: testTokenSecret}},
+		auth.VerifierOptions{Audience: "inventory-service"},
+	)
+	require.NoError(t, err)
+
+	audit := auth.NewDenialAudit(10)
+	return NewServiceAuth(verifier, audit, map[string][]string{
+		readMethod:    {auth.ScopeInventoryRead},
+		reserveMethod: {auth.ScopeInventoryReserve},
+	}), audit
+}
+
+func signTestToken(t *testing.T, service string, scopes ...string) string {
+	t.Helper()
+	token, err := auth.NewHMACSigner("k1", testTokenSecret, auth.SignerOptions{Audience: "inventory-service"}).
+		Sign(service, scopes, time.Hour)
+	require.NoError(t, err)
+	return token
+}
+
+func TestServiceAuth_Unary(t *testing.T) {
+	readToken := signTestToken(t, "orders-service", auth.ScopeInventoryRead)
+
+	tests := []struct {
+		name           string
+		method         string
+		md             metadata.MD
+		expectedCode   codes.Code
+		expectedSource string
+		expectedReason string
+	}{
+		{"valid token and scope", readMethod, metadata.Pairs("authorization", "Bearer "+readToken), codes.OK, "orders-service", ""},
+		{"lowercase bearer scheme", readMethod, metadata.Pairs("authorization", "bearer "+readToken), codes.OK, "orders-service", ""},
+		{"missing metadata", readMethod, nil, codes.Unauthenticated, "", auth.ReasonMissingToken},
+		{"legacy api key header", readMethod, metadata.Pairs("x-api-key", "inventory-service-key-dev-12345"), codes.Unauthenticated, "", auth.ReasonMissingToken},
+		{"garbage token", readMethod, metadata.Pairs("authorization", "Bearer garbage"), codes.Unauthenticated, "", auth.ReasonMalformedToken},
+		{"missing scope", reserveMethod, metadata.Pairs("authorization", "Bearer "+readToken), codes.PermissionDenied, "", auth.ReasonInsufficientScope},
+		{"undeclared method fails closed", "/inventory.v1.InventoryService/Unknown", metadata.Pairs("authorization", "Bearer "+readToken), codes.PermissionDenied, "", auth.ReasonInsufficientScope},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serviceAuth, audit := newTestAuth(t)
+			ctx := context.Background()
+			if tt.md != nil {
+				ctx = metadata.NewIncomingContext(ctx, tt.md)
+			}
+
+			var source string
+			_, err := serviceAuth.Unary()(ctx, "req", &grpc.UnaryServerInfo{FullMethod: tt.method}, func(ctx context.Context, req interface{}) (interface{}, error) {
+				source = SourceService(ctx)
+				return "ok", nil
+			})
+
+			assert.Equal(t, tt.expectedCode, status.Code(err))
+			assert.Equal(t, tt.expectedSource, source)
+
+			denials := audit.Recent(0)
+			if tt.expectedReason == "" {
+				assert.Empty(t, denials)
+				return
+			}
+			require.Len(t, denials, 1)
+			assert.Equal(t, tt.expectedReason, denials[0].Reason)
+			assert.Equal(t, "grpc", denials[0].Transport)
+			assert.Equal(t, tt.method, denials[0].Operation)
+		})
+	}
+}
+
+func TestServiceAuth_PublicServicesSkipAuth(t *testing.T) {
+	serviceAuth, audit := newTestAuth(t)
+
+	for _, method := range []string{
+		"/grpc.health.v1.Health/Check",
+		"/grpc.reflection.v1.ServerReflection/ServerReflectionInfo",
+	} {
+		called := false
+		_, err := serviceAuth.Unary()(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, req interface{}) (interface{}, error) {
+			called = true
+			return nil, nil
+		})
+		require.NoError(t, err, method)
+		assert.True(t, called, method)
+	}
+	assert.Empty(t, audit.Recent(0))
+}
+
+// fakeStream is a minimal grpc.ServerStream carrying a context
+type fakeStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (f *fakeStream) Context() context.Context { return f.ctx }
+
+func TestServiceAuth_Stream(t *testing.T) {
+	serviceAuth, _ := newTestAuth(t)
+	info := &grpc.StreamServerInfo{FullMethod: readMethod}
+
+	t.Run("rejects missing token", func(t *testing.T) {
+		err := serviceAuth.Stream()(nil, &fakeStream{ctx: context.Background()}, info, func(srv interface{}, ss grpc.ServerStream) error {
+			t.Fatal("handler must not be called")
+			return nil
+		})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+
+	t.Run("propagates principal", func(t *testing.T) {
+		md := metadata.Pairs("authorization", "Bearer "+signTestToken(t, "orders-service", auth.ScopeInventoryRead))
+		err := serviceAuth.Stream()(nil, &fakeStream{ctx: metadata.NewIncomingContext(context.Background(), md)}, info, func(srv interface{}, ss grpc.ServerStream) error {
+			principal, ok := PrincipalFromContext(ss.Context())
+			require.True(t, ok)
+			assert.Equal(t, "orders-service", principal.Service)
+			return nil
+		})
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,65 @@
+package interceptor
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/application/usecase"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/infrastructure/auth"
+)
+
+// UserIdentity resolves the end user a call is made for (see auth.UserID) from the
+// token's user_id claim or the x-user-id metadata, and stores it in the context for
+// the use cases. It must be chained after ServiceAuth when authentication is enabled.
+type UserIdentity struct{}
+
+// NewUserIdentity creates a UserIdentity
+func NewUserIdentity() *UserIdentity {
+	return &UserIdentity{}
+}
+
+// Unary returns the unary server interceptor
+func (u *UserIdentity) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := u.identify(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// Stream returns the stream server interceptor
+func (u *UserIdentity) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := u.identify(ss.Context())
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// identify returns a context carrying the end user of the call, if it names one
+func (u *UserIdentity) identify(ctx context.Context) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	principal, _ := PrincipalFromContext(ctx)
+
+	userID, err := auth.UserID(principal, strings.TrimSpace(firstValue(md, strings.ToLower(auth.UserIDHeader))))
+	if err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+	if userID == "" {
+		return ctx, nil
+	}
+	if err := entity.ValidateUserID(userID); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return usecase.ContextWithUserID(ctx, userID), nil
+}
@@ -0,0 +1,71 @@
+package interceptor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/application/usecase"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/infrastructure/auth"
+)
+
+func TestUserIdentity_Unary(t *testing.T) {
+	serviceToken := signTestToken(t, "orders-service", auth.ScopeInventoryReserve)
+	userToken, err := auth.NewHMACSigner("k1", testTokenSecret, auth.SignerOptions{Audience: "inventory-service"}).
+		SignForUser("orders-service", "user-2", []string{auth.ScopeInventoryReserve}, time.Hour)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		md       metadata.MD
+		wantCode codes.Code
+		wantUser string
+	}{
+		{"metadata from an authenticated service", metadata.Pairs("authorization", "Bearer "+serviceToken, "x-user-id", "user-1"), codes.OK, "user-1"},
+		{"no end user", metadata.Pairs("authorization", "Bearer "+serviceToken), codes.OK, ""},
+		{"token claim", metadata.Pairs("authorization", "Bearer "+userToken), codes.OK, "user-2"},
+		{"metadata contradicting the claim", metadata.Pairs("authorization", "Bearer "+userToken, "x-user-id", "user-1"), codes.PermissionDenied, ""},
+		{"invalid metadata", metadata.Pairs("authorization", "Bearer "+serviceToken, "x-user-id", "user 1"), codes.InvalidArgument, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serviceAuth, _ := newTestAuth(t)
+			var userID string
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				userID = usecase.UserIDFromContext(ctx)
+				return "ok", nil
+			}
+			info := &grpc.UnaryServerInfo{FullMethod: reserveMethod}
+			ctx := metadata.NewIncomingContext(context.Background(), tt.md)
+
+			// UserIdentity runs after ServiceAuth, as chained by the server
+			_, err := serviceAuth.Unary()(ctx, "req", info, func(ctx context.Context, req interface{}) (interface{}, error) {
+				return NewUserIdentity().Unary()(ctx, req, info, handler)
+			})
+
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			assert.Equal(t, tt.wantUser, userID)
+		})
+	}
+}
+
+func TestUserIdentity_WithoutAuthentication(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-user-id", "user-1"))
+
+	var userID string
+	_, err := NewUserIdentity().Unary()(ctx, "req", &grpc.UnaryServerInfo{FullMethod: reserveMethod}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		userID = usecase.UserIDFromContext(ctx)
+		return "ok", nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", userID)
+}
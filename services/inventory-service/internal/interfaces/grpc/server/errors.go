@@ -0,0 +1,75 @@
+package server
+
+import (
+	goerrors "errors"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/application/usecase"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+)
+
+// errorDomain identifies this service in google.rpc.ErrorInfo details
+const errorDomain = "inventory-service"
+
+// toStatus maps domain errors to gRPC status errors.
+// The domain error code is attached as google.rpc.ErrorInfo reason so clients can
+// branch on it without parsing messages. Unknown errors become codes.Internal
+// without leaking their message.
+func toStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var domainErr *errors.DomainError
+	if !goerrors.As(err, &domainErr) {
+		return status.Error(codes.Internal, "internal server error")
+	}
+
+	st := status.New(codeFor(domainErr), domainErr.Error())
+	details := []protoadapt.MessageV1{&errdetails.ErrorInfo{
+		Reason: domainErr.Code,
+		Domain: errorDomain,
+	}}
+
+	// Exhausted optimistic-lock retries carry a hint for when to try again
+	var contention *usecase.ContentionError
+	if goerrors.As(err, &contention) {
+		details = append(details, &errdetails.RetryInfo{RetryDelay: durationpb.New(contention.RetryAfter)})
+	}
+
+	withInfo, detailErr := st.WithDetails(details...)
+	if detailErr != nil {
+		return st.Err()
+	}
+	return withInfo.Err()
+}
+
+// codeFor returns the gRPC code for a domain error based on its category
+func codeFor(domainErr *errors.DomainError) codes.Code {
+	switch errors.GetCategory(domainErr) {
+	case errors.CategoryValidation:
+		return codes.InvalidArgument
+	case errors.CategoryNotFound:
+		return codes.NotFound
+	case errors.CategoryConflict:
+		// Optimistic locking conflicts are retryable by the caller
+		if domainErr.Is(errors.ErrOptimisticLockFailure) || domainErr.Is(errors.ErrConcurrentModification) {
+			return codes.Aborted
+		}
+		return codes.AlreadyExists
+	case errors.CategoryBusinessRule, errors.CategoryExpired:
+		return codes.FailedPrecondition
+	default:
+		return codes.Internal
+	}
+}
+
+// invalidArgument builds an InvalidArgument status with an INVALID_INPUT reason
+func invalidArgument(message string) error {
+	return toStatus(errors.ErrInvalidInput.WithDetails(message))
+}
@@ -0,0 +1,44 @@
+package server
+
+import (
+	"google.golang.org/grpc"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+
+	inventoryv1 "github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/api/proto/inventory/v1"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/infrastructure/auth"
+)
+
+// MethodScopes returns the scopes required by each InventoryService method
+func MethodScopes() map[string][]string {
+	return map[string][]string{
+		inventoryv1.InventoryService_CheckAvailability_FullMethodName:      {auth.ScopeInventoryRead},
+		inventoryv1.InventoryService_BatchCheckAvailability_FullMethodName: {auth.ScopeInventoryRead},
+		inventoryv1.InventoryService_ReserveStock_FullMethodName:           {auth.ScopeInventoryReserve},
+		inventoryv1.InventoryService_ConfirmReservation_FullMethodName:     {auth.ScopeInventoryReserve},
+		inventoryv1.InventoryService_ReleaseReservation_FullMethodName:     {auth.ScopeInventoryReserve},
+	}
+}
+
+// WriteMethods returns the methods counted against the write rate limit quota
+func WriteMethods() map[string]bool {
+	return map[string]bool{
+		inventoryv1.InventoryService_ReserveStock_FullMethodName:       true,
+		inventoryv1.InventoryService_ConfirmReservation_FullMethodName: true,
+		inventoryv1.InventoryService_ReleaseReservation_FullMethodName: true,
+	}
+}
+
+// NewGRPCServer creates a *grpc.Server with the InventoryService, the standard
+// health service and server reflection registered.
+// Interceptors (e.g. authentication) are passed through opts.
+func NewGRPCServer(inventoryServer inventoryv1.InventoryServiceServer, healthServer *grpchealth.Server, opts ...grpc.ServerOption) *grpc.Server {
+	srv := grpc.NewServer(opts...)
+
+	inventoryv1.RegisterInventoryServiceServer(srv, inventoryServer)
+	healthpb.RegisterHealthServer(srv, healthServer)
+	reflection.Register(srv)
+
+	return srv
+}
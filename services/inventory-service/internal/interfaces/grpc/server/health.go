@@ -0,0 +1,46 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	inventoryv1 "github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/api/proto/inventory/v1"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/infrastructure/health"
+)
+
+// ReadinessChecker runs the readiness probes shared with GET /health/ready
+type ReadinessChecker interface {
+	Check(ctx context.Context) *health.Report
+}
+
+// WatchReadiness keeps the gRPC health service in sync with the readiness checker.
+// Both the overall status ("") and the InventoryService entry are updated every interval
+// until ctx is cancelled, at which point they are set to NOT_SERVING.
+func WatchReadiness(ctx context.Context, checker ReadinessChecker, healthServer *grpchealth.Server, interval time.Duration) {
+	update := func() {
+		servingStatus := healthpb.HealthCheckResponse_NOT_SERVING
+		if checker.Check(ctx).IsReady() {
+			servingStatus = healthpb.HealthCheckResponse_SERVING
+		}
+		healthServer.SetServingStatus("", servingStatus)
+		healthServer.SetServingStatus(inventoryv1.InventoryService_ServiceDesc.ServiceName, servingStatus)
+	}
+
+	update()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			healthServer.Shutdown()
+			return
+		case <-ticker.C:
+			update()
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/infrastructure/health"
+)
+
+// stubReadinessChecker returns a fixed status
+type stubReadinessChecker struct {
+	status health.Status
+}
+
+func (s *stubReadinessChecker) Check(ctx context.Context) *health.Report {
+	return &health.Report{Status: s.status, CheckedAt: time.Now()}
+}
+
+func servingStatus(t *testing.T, healthServer *grpchealth.Server) healthpb.HealthCheckResponse_ServingStatus {
+	t.Helper()
+	resp, err := healthServer.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "inventory.v1.InventoryService"})
+	require.NoError(t, err)
+	return resp.Status
+}
+
+func TestWatchReadiness(t *testing.T) {
+	testCases := []struct {
+		name     string
+		status   health.Status
+		expected healthpb.HealthCheckResponse_ServingStatus
+	}{
+		{"ready", health.StatusOK, healthpb.HealthCheckResponse_SERVING},
+		{"degraded is still serving", health.StatusDegraded, healthpb.HealthCheckResponse_SERVING},
+		{"critical dependency down", health.StatusUnavailable, healthpb.HealthCheckResponse_NOT_SERVING},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			healthServer := grpchealth.NewServer()
+			ctx, cancel := context.WithCancel(context.Background())
+			done := make(chan struct{})
+
+			go func() {
+				WatchReadiness(ctx, &stubReadinessChecker{status: tc.status}, healthServer, time.Hour)
+				close(done)
+			}()
+
+			assert.Eventually(t, func() bool {
+				resp, err := healthServer.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "inventory.v1.InventoryService"})
+				return err == nil && resp.Status == tc.expected
+			}, time.Second, 10*time.Millisecond)
+
+			cancel()
+			<-done
+			assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, servingStatus(t, healthServer))
+		})
+	}
+}
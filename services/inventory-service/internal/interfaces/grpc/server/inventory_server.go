@@ -0,0 +1,273 @@
+package server
+
+import (
+	"context"
+	goerrors "errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	inventoryv1 "github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/api/proto/inventory/v1"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/application/usecase"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+)
+
+// MaxBatchSize is the maximum number of items accepted by BatchCheckAvailability
+const MaxBatchSize = 100
+
+// CheckAvailabilityExecutor defines the interface for executing stock availability checks
+type CheckAvailabilityExecutor interface {
+	Execute(ctx context.Context, input usecase.CheckAvailabilityInput) (*usecase.CheckAvailabilityOutput, error)
+}
+
+// ReserveStockExecutor defines the interface for executing stock reservations
+type ReserveStockExecutor interface {
+	Execute(ctx context.Context, input usecase.ReserveStockInput) (*usecase.ReserveStockOutput, error)
+}
+
+// ConfirmReservationExecutor defines the interface for confirming reservations
+type ConfirmReservationExecutor interface {
+	Execute(ctx context.Context, input usecase.ConfirmReservationInput) (*usecase.ConfirmReservationOutput, error)
+}
+
+// ReleaseReservationExecutor defines the interface for releasing reservations
+type ReleaseReservationExecutor interface {
+	Execute(ctx context.Context, input usecase.ReleaseReservationInput) (*usecase.ReleaseReservationOutput, error)
+}
+
+// InventoryServer implements inventoryv1.InventoryServiceServer on top of the same
+// use cases used by the HTTP InventoryHandler
+type InventoryServer struct {
+	inventoryv1.UnimplementedInventoryServiceServer
+
+	checkAvailability  CheckAvailabilityExecutor
+	reserveStock       ReserveStockExecutor
+	confirmReservation ConfirmReservationExecutor
+	releaseReservation ReleaseReservationExecutor
+	maxReservationTTL  time.Duration
+}
+
+// NewInventoryServer creates a new InventoryServer.
+// maxReservationTTL bounds the ttl_seconds accepted by ReserveStock (0 means no limit).
+func NewInventoryServer(
+	checkAvailability CheckAvailabilityExecutor,
+	reserveStock ReserveStockExecutor,
+	confirmReservation ConfirmReservationExecutor,
+	releaseReservation ReleaseReservationExecutor,
+	maxReservationTTL time.Duration,
+) *InventoryServer {
+	if checkAvailability == nil || reserveStock == nil || confirmReservation == nil || releaseReservation == nil {
+		panic("all inventory use cases are required")
+	}
+
+	return &InventoryServer{
+		checkAvailability:  checkAvailability,
+		reserveStock:       reserveStock,
+		confirmReservation: confirmReservation,
+		releaseReservation: releaseReservation,
+		maxReservationTTL:  maxReservationTTL,
+	}
+}
+
+// CheckAvailability returns the stock availability of a single product
+func (s *InventoryServer) CheckAvailability(ctx context.Context, req *inventoryv1.CheckAvailabilityRequest) (*inventoryv1.CheckAvailabilityResponse, error) {
+	productID, err := uuid.Parse(req.GetProductId())
+	if err != nil {
+		return nil, invalidArgument("invalid product_id format, expected UUID")
+	}
+
+	output, err := s.checkAvailability.Execute(ctx, usecase.CheckAvailabilityInput{
+		ProductID: productID,
+		Quantity:  quantityOrDefault(req.GetQuantity()),
+		Channel:   req.GetChannel(),
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &inventoryv1.CheckAvailabilityResponse{
+		ProductId:         output.ProductID.String(),
+		IsAvailable:       output.IsAvailable,
+		RequestedQuantity: int32(output.RequestedQuantity),
+		AvailableQuantity: int32(output.AvailableQuantity),
+		TotalStock:        int32(output.TotalStock),
+		ReservedQuantity:  int32(output.ReservedQuantity),
+		Channel:           output.Channel,
+	}, nil
+}
+
+// BatchCheckAvailability checks several products in one call.
+// Item-level domain errors (e.g. unknown product) are reported in the result;
+// only malformed requests and infrastructure errors fail the whole call.
+func (s *InventoryServer) BatchCheckAvailability(ctx context.Context, req *inventoryv1.BatchCheckAvailabilityRequest) (*inventoryv1.BatchCheckAvailabilityResponse, error) {
+	items := req.GetItems()
+	if len(items) == 0 {
+		return nil, invalidArgument("items must not be empty")
+	}
+	if len(items) > MaxBatchSize {
+		return nil, invalidArgument(fmt.Sprintf("at most %d items are allowed per batch", MaxBatchSize))
+	}
+
+	// Validate every ID before hitting the database
+	productIDs := make([]uuid.UUID, len(items))
+	for i, item := range items {
+		productID, err := uuid.Parse(item.GetProductId())
+		if err != nil {
+			return nil, invalidArgument(fmt.Sprintf("items[%d]: invalid product_id format, expected UUID", i))
+		}
+		productIDs[i] = productID
+	}
+
+	response := &inventoryv1.BatchCheckAvailabilityResponse{
+		Results:      make([]*inventoryv1.AvailabilityResult, 0, len(items)),
+		AllAvailable: true,
+	}
+
+	for i, item := range items {
+		quantity := quantityOrDefault(item.GetQuantity())
+		result := &inventoryv1.AvailabilityResult{
+			ProductId:         productIDs[i].String(),
+			RequestedQuantity: int32(quantity),
+			Channel:           item.GetChannel(),
+		}
+
+		output, err := s.checkAvailability.Execute(ctx, usecase.CheckAvailabilityInput{
+			ProductID: productIDs[i],
+			Quantity:  quantity,
+			Channel:   item.GetChannel(),
+		})
+		if err != nil {
+			var domainErr *errors.DomainError
+			if !goerrors.As(err, &domainErr) {
+				return nil, toStatus(err)
+			}
+			result.ErrorCode = domainErr.Code
+		} else {
+			result.IsAvailable = output.IsAvailable
+			result.AvailableQuantity = int32(output.AvailableQuantity)
+			result.TotalStock = int32(output.TotalStock)
+			result.ReservedQuantity = int32(output.ReservedQuantity)
+		}
+
+		if !result.IsAvailable {
+			response.AllAvailable = false
+		}
+		response.Results = append(response.Results, result)
+	}
+
+	return response, nil
+}
+
+// ReserveStock creates a temporary stock reservation for an order
+func (s *InventoryServer) ReserveStock(ctx context.Context, req *inventoryv1.ReserveStockRequest) (*inventoryv1.ReserveStockResponse, error) {
+	productID, err := uuid.Parse(req.GetProductId())
+	if err != nil {
+		return nil, invalidArgument("invalid product_id format, expected UUID")
+	}
+
+	orderID, err := uuid.Parse(req.GetOrderId())
+	if err != nil {
+		return nil, invalidArgument("invalid order_id format, expected UUID")
+	}
+
+	if req.GetTtlSeconds() < 0 {
+		return nil, toStatus(errors.ErrInvalidDuration)
+	}
+
+	var duration *time.Duration
+	if req.GetTtlSeconds() > 0 {
+		ttl := time.Duration(req.GetTtlSeconds()) * time.Second
+		if s.maxReservationTTL > 0 && ttl > s.maxReservationTTL {
+			return nil, invalidArgument(fmt.Sprintf("ttl_seconds must not exceed %d", int(s.maxReservationTTL.Seconds())))
+		}
+		duration = &ttl
+	}
+
+	output, err := s.reserveStock.Execute(ctx, usecase.ReserveStockInput{
+		ProductID: productID,
+		OrderID:   orderID,
+		Quantity:  int(req.GetQuantity()),
+		Duration:  duration,
+		Channel:   req.GetChannel(),
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &inventoryv1.ReserveStockResponse{
+		ReservationId:  output.ReservationID.String(),
+		ProductId:      output.ProductID.String(),
+		OrderId:        output.OrderID.String(),
+		Quantity:       int32(output.Quantity),
+		ExpiresAt:      timestamppb.New(output.ExpiresAt),
+		RemainingStock: int32(output.RemainingStock),
+		Channel:        output.Channel,
+	}, nil
+}
+
+// ConfirmReservation confirms a pending reservation and decrements actual stock
+func (s *InventoryServer) ConfirmReservation(ctx context.Context, req *inventoryv1.ConfirmReservationRequest) (*inventoryv1.ConfirmReservationResponse, error) {
+	reservationID, err := uuid.Parse(req.GetReservationId())
+	if err != nil {
+		return nil, invalidArgument("invalid reservation_id format, expected UUID")
+	}
+
+	output, err := s.confirmReservation.Execute(ctx, usecase.ConfirmReservationInput{
+		ReservationID: reservationID,
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &inventoryv1.ConfirmReservationResponse{
+		ReservationId:     output.ReservationID.String(),
+		OrderId:           output.OrderID.String(),
+		QuantityConfirmed: int32(output.QuantityConfirmed),
+		FinalStock:        int32(output.FinalStock),
+		ReservedStock:     int32(output.ReservedStock),
+		Channel:           reservationChannel(output.Reservation),
+	}, nil
+}
+
+// ReleaseReservation cancels a pending reservation and returns its stock to available
+func (s *InventoryServer) ReleaseReservation(ctx context.Context, req *inventoryv1.ReleaseReservationRequest) (*inventoryv1.ReleaseReservationResponse, error) {
+	reservationID, err := uuid.Parse(req.GetReservationId())
+	if err != nil {
+		return nil, invalidArgument("invalid reservation_id format, expected UUID")
+	}
+
+	output, err := s.releaseReservation.Execute(ctx, usecase.ReleaseReservationInput{
+		ReservationID: reservationID,
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &inventoryv1.ReleaseReservationResponse{
+		ReservationId:    output.ReservationID.String(),
+		OrderId:          output.OrderID.String(),
+		QuantityReleased: int32(output.QuantityReleased),
+		AvailableStock:   int32(output.AvailableStock),
+		ReservedStock:    int32(output.ReservedStock),
+		Channel:          reservationChannel(output.Reservation),
+	}, nil
+}
+
+// quantityOrDefault treats an omitted quantity as 1, like GET /api/inventory/:productId
+func quantityOrDefault(quantity int32) int {
+	if quantity == 0 {
+		return 1
+	}
+	return int(quantity)
+}
+
+// reservationChannel returns the sales channel of a reservation, empty without one
+func reservationChannel(reservation *entity.Reservation) string {
+	if reservation == nil {
+		return ""
+	}
+	return reservation.Channel
+}
@@ -0,0 +1,519 @@
+package server_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	inventoryv1 "github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/api/proto/inventory/v1"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/application/usecase"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/infrastructure/auth"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/interfaces/grpc/interceptor"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/interfaces/grpc/server"
+)
+
+var testTokenSecret = []byte("0123456789abcdef0123456789abcdef")
+
+// MockCheckAvailabilityUseCase is a mock of CheckAvailabilityUseCase
+type MockCheckAvailabilityUseCase struct {
+	mock.Mock
+}
+
+func (m *MockCheckAvailabilityUseCase) Execute(ctx context.Context, input usecase.CheckAvailabilityInput) (*usecase.CheckAvailabilityOutput, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.CheckAvailabilityOutput), args.Error(1)
+}
+
+// MockReserveStockUseCase is a mock of ReserveStockUseCase
+type MockReserveStockUseCase struct {
+	mock.Mock
+}
+
+func (m *MockReserveStockUseCase) Execute(ctx context.Context, input usecase.ReserveStockInput) (*usecase.ReserveStockOutput, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.ReserveStockOutput), args.Error(1)
+}
+
+// MockConfirmReservationUseCase is a mock of ConfirmReservationUseCase
+type MockConfirmReservationUseCase struct {
+	mock.Mock
+}
+
+func (m *MockConfirmReservationUseCase) Execute(ctx context.Context, input usecase.ConfirmReservationInput) (*usecase.ConfirmReservationOutput, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.ConfirmReservationOutput), args.Error(1)
+}
+
+// MockReleaseReservationUseCase is a mock of ReleaseReservationUseCase
+type MockReleaseReservationUseCase struct {
+	mock.Mock
+}
+
+func (m *MockReleaseReservationUseCase) Execute(ctx context.Context, input usecase.ReleaseReservationInput) (*usecase.ReleaseReservationOutput, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.ReleaseReservationOutput), args.Error(1)
+}
+
+type testEnv struct {
+	client      inventoryv1.InventoryServiceClient
+	health      healthpb.HealthClient
+	check       *MockCheckAvailabilityUseCase
+	reserve     *MockReserveStockUseCase
+	confirm     *MockConfirmReservationUseCase
+	release     *MockReleaseReservationUseCase
+	healthState *grpchealth.Server
+}
+
+// setupServer starts an in-memory gRPC server with auth enabled and returns connected clients
+func setupServer(t *testing.T) *testEnv {
+	t.Helper()
+
+	env := &testEnv{
+		check:       new(MockCheckAvailabilityUseCase),
+		reserve:     new(MockReserveStockUseCase),
+		confirm:     new(MockConfirmReservationUseCase),
+		release:     new(MockReleaseReservationUseCase),
+		healthState: grpchealth.NewServer(),
+	}
+
+	verifier, err := auth.NewVerifier(
+		[]auth.Key{{ID: "k1", Algorithm: auth.AlgorithmHS256, Secret: testTokenSecret}},
+		auth.VerifierOptions{Audience: "inventory-service"},
+	)
+	require.NoError(t, err)
+	serviceAuth := interceptor.NewServiceAuth(verifier, auth.NewDenialAudit(10), server.MethodScopes())
+	inventoryServer := server.NewInventoryServer(env.check, env.reserve, env.confirm, env.release, time.Hour)
+	srv := server.NewGRPCServer(inventoryServer, env.healthState,
+		grpc.ChainUnaryInterceptor(serviceAuth.Unary()),
+		grpc.ChainStreamInterceptor(serviceAuth.Stream()),
+	)
+
+	listener := bufconn.Listen(1024 * 1024)
+	go func() { _ = srv.Serve(listener) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	env.client = inventoryv1.NewInventoryServiceClient(conn)
+	env.health = healthpb.NewHealthClient(conn)
+	return env
+}
+
+// authContext returns a context carrying a token with every inventory scope
+func authContext(t *testing.T) context.Context {
+	t.Helper()
+	token, err := auth.NewHMACSigner("k1", testTokenSecret, auth.SignerOptions{Audience: "inventory-service"}).
+		Sign("orders-service", []string{auth.ScopeInventoryRead, auth.ScopeInventoryReserve}, time.Hour)
+	require.NoError(t, err)
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+}
+
+// errorReason extracts the google.rpc.ErrorInfo reason from a status error
+func errorReason(t *testing.T, err error) string {
+	t.Helper()
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			return info.Reason
+		}
+	}
+	return ""
+}
+
+func TestCheckAvailability_Success(t *testing.T) {
+	env := setupServer(t)
+	productID := uuid.New()
+
+	env.check.On("Execute", mock.Anything, usecase.CheckAvailabilityInput{ProductID: productID, Quantity: 1}).
+		Return(&usecase.CheckAvailabilityOutput{
+			ProductID:         productID,
+			IsAvailable:       true,
+			RequestedQuantity: 1,
+			AvailableQuantity: 100,
+			TotalStock:        150,
+			ReservedQuantity:  50,
+		}, nil)
+
+	resp, err := env.client.CheckAvailability(authContext(t), &inventoryv1.CheckAvailabilityRequest{ProductId: productID.String()})
+
+	require.NoError(t, err)
+	assert.True(t, resp.IsAvailable)
+	assert.Equal(t, int32(100), resp.AvailableQuantity)
+	assert.Equal(t, int32(150), resp.TotalStock)
+	assert.Equal(t, int32(50), resp.ReservedQuantity)
+	env.check.AssertExpectations(t)
+}
+
+func TestCheckAvailability_Channel(t *testing.T) {
+	env := setupServer(t)
+	productID := uuid.New()
+
+	env.check.On("Execute", mock.Anything, usecase.CheckAvailabilityInput{ProductID: productID, Quantity: 2, Channel: "marketplace"}).
+		Return(&usecase.CheckAvailabilityOutput{
+			ProductID:         productID,
+			IsAvailable:       true,
+			RequestedQuantity: 2,
+			AvailableQuantity: 30,
+			TotalStock:        100,
+			Channel:           "marketplace",
+		}, nil)
+
+	resp, err := env.client.CheckAvailability(authContext(t), &inventoryv1.CheckAvailabilityRequest{
+		ProductId: productID.String(),
+		Quantity:  2,
+		Channel:   "marketplace",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, int32(30), resp.AvailableQuantity, "availability of the channel's allocation")
+	assert.Equal(t, "marketplace", resp.Channel)
+	env.check.AssertExpectations(t)
+}
+
+func TestCheckAvailability_InvalidProductID(t *testing.T) {
+	env := setupServer(t)
+
+	_, err := env.client.CheckAvailability(authContext(t), &inventoryv1.CheckAvailabilityRequest{ProductId: "not-a-uuid"})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, "INVALID_INPUT", errorReason(t, err))
+	env.check.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything)
+}
+
+func TestBatchCheckAvailability(t *testing.T) {
+	env := setupServer(t)
+	available := uuid.New()
+	missing := uuid.New()
+
+	env.check.On("Execute", mock.Anything, usecase.CheckAvailabilityInput{ProductID: available, Quantity: 5, Channel: "web"}).
+		Return(&usecase.CheckAvailabilityOutput{
+			ProductID:         available,
+			IsAvailable:       true,
+			RequestedQuantity: 5,
+			AvailableQuantity: 10,
+			TotalStock:        10,
+		}, nil)
+	env.check.On("Execute", mock.Anything, usecase.CheckAvailabilityInput{ProductID: missing, Quantity: 1}).
+		Return(nil, errors.ErrInventoryItemNotFound.WithDetails("record not found"))
+
+	resp, err := env.client.BatchCheckAvailability(authContext(t), &inventoryv1.BatchCheckAvailabilityRequest{
+		Items: []*inventoryv1.AvailabilityItem{
+			{ProductId: available.String(), Quantity: 5, Channel: "web"},
+			{ProductId: missing.String()},
+		},
+	})
+
+	require.NoError(t, err)
+	assert.False(t, resp.AllAvailable)
+	require.Len(t, resp.Results, 2)
+	assert.True(t, resp.Results[0].IsAvailable)
+	assert.Equal(t, "web", resp.Results[0].Channel)
+	assert.Empty(t, resp.Results[0].ErrorCode)
+	assert.False(t, resp.Results[1].IsAvailable)
+	assert.Equal(t, "INVENTORY_ITEM_NOT_FOUND", resp.Results[1].ErrorCode)
+}
+
+func TestBatchCheckAvailability_Validation(t *testing.T) {
+	env := setupServer(t)
+
+	t.Run("empty batch", func(t *testing.T) {
+		_, err := env.client.BatchCheckAvailability(authContext(t), &inventoryv1.BatchCheckAvailabilityRequest{})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("batch too large", func(t *testing.T) {
+		items := make([]*inventoryv1.AvailabilityItem, server.MaxBatchSize+1)
+		for i := range items {
+			items[i] = &inventoryv1.AvailabilityItem{ProductId: uuid.NewString()}
+		}
+		_, err := env.client.BatchCheckAvailability(authContext(t), &inventoryv1.BatchCheckAvailabilityRequest{Items: items})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("invalid id fails the whole batch", func(t *testing.T) {
+		_, err := env.client.BatchCheckAvailability(authContext(t), &inventoryv1.BatchCheckAvailabilityRequest{
+			Items: []*inventoryv1.AvailabilityItem{{ProductId: uuid.NewString()}, {ProductId: "bad"}},
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Contains(t, status.Convert(err).Message(), "items[1]")
+	})
+
+	env.check.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything)
+}
+
+func TestReserveStock_Success(t *testing.T) {
+	env := setupServer(t)
+	productID := uuid.New()
+	orderID := uuid.New()
+	expiresAt := time.Now().Add(30 * time.Minute).UTC().Truncate(time.Second)
+	ttl := 30 * time.Minute
+
+	env.reserve.On("Execute", mock.Anything, usecase.ReserveStockInput{
+		ProductID: productID,
+		OrderID:   orderID,
+		Quantity:  3,
+		Duration:  &ttl,
+	}).Return(&usecase.ReserveStockOutput{
+		ReservationID:  uuid.New(),
+		ProductID:      productID,
+		OrderID:        orderID,
+		Quantity:       3,
+		ExpiresAt:      expiresAt,
+		RemainingStock: 7,
+	}, nil)
+
+	resp, err := env.client.ReserveStock(authContext(t), &inventoryv1.ReserveStockRequest{
+		ProductId:  productID.String(),
+		OrderId:    orderID.String(),
+		Quantity:   3,
+		TtlSeconds: int32(ttl.Seconds()),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), resp.Quantity)
+	assert.Equal(t, int32(7), resp.RemainingStock)
+	assert.True(t, expiresAt.Equal(resp.ExpiresAt.AsTime()))
+	env.reserve.AssertExpectations(t)
+}
+
+func TestReserveStock_Channel(t *testing.T) {
+	env := setupServer(t)
+	productID := uuid.New()
+	orderID := uuid.New()
+
+	env.reserve.On("Execute", mock.Anything, usecase.ReserveStockInput{
+		ProductID: productID,
+		OrderID:   orderID,
+		Quantity:  1,
+		Channel:   "marketplace",
+	}).Return(&usecase.ReserveStockOutput{
+		ReservationID:  uuid.New(),
+		ProductID:      productID,
+		OrderID:        orderID,
+		Quantity:       1,
+		ExpiresAt:      time.Now().Add(15 * time.Minute),
+		RemainingStock: 4,
+		Channel:        "marketplace",
+	}, nil)
+
+	resp, err := env.client.ReserveStock(authContext(t), &inventoryv1.ReserveStockRequest{
+		ProductId: productID.String(),
+		OrderId:   orderID.String(),
+		Quantity:  1,
+		Channel:   "marketplace",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "marketplace", resp.Channel)
+	env.reserve.AssertExpectations(t)
+}
+
+func TestReserveStock_TTLExceedsMaximum(t *testing.T) {
+	env := setupServer(t)
+
+	_, err := env.client.ReserveStock(authContext(t), &inventoryv1.ReserveStockRequest{
+		ProductId:  uuid.NewString(),
+		OrderId:    uuid.NewString(),
+		Quantity:   1,
+		TtlSeconds: int32((2 * time.Hour).Seconds()),
+	})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	env.reserve.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything)
+}
+
+func TestDomainErrorMapping(t *testing.T) {
+	testCases := []struct {
+		name         string
+		err          error
+		expectedCode codes.Code
+	}{
+		{"insufficient stock", errors.ErrInsufficientStock, codes.FailedPrecondition},
+		{"archived product", errors.ErrInventoryItemArchived, codes.FailedPrecondition},
+		{"invalid quantity", errors.ErrInvalidQuantity, codes.InvalidArgument},
+		{"product not found", errors.ErrInventoryItemNotFound.WithDetails("id"), codes.NotFound},
+		{"duplicate reservation", errors.ErrReservationAlreadyExists, codes.AlreadyExists},
+		{"optimistic lock", errors.ErrOptimisticLockFailure, codes.Aborted},
+		{"reservation expired", errors.ErrReservationExpired, codes.FailedPrecondition},
+		{"invalid channel", errors.ErrInvalidChannel.WithDetails("Web!"), codes.InvalidArgument},
+		{"channel allocated product", errors.ErrChannelAllocatedItem, codes.FailedPrecondition},
+		{"non domain error", assert.AnError, codes.Internal},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			env := setupServer(t)
+			env.reserve.On("Execute", mock.Anything, mock.Anything).Return(nil, tc.err)
+
+			_, err := env.client.ReserveStock(authContext(t), &inventoryv1.ReserveStockRequest{
+				ProductId: uuid.NewString(),
+				OrderId:   uuid.NewString(),
+				Quantity:  1,
+			})
+
+			assert.Equal(t, tc.expectedCode, status.Code(err))
+			if domainErr, ok := tc.err.(*errors.DomainError); ok {
+				assert.Equal(t, domainErr.Code, errorReason(t, err))
+			} else {
+				assert.NotContains(t, status.Convert(err).Message(), assert.AnError.Error())
+			}
+		})
+	}
+}
+
+func TestContentionErrorMapping(t *testing.T) {
+	env := setupServer(t)
+	env.reserve.On("Execute", mock.Anything, mock.Anything).Return(nil, &usecase.ContentionError{
+		Operation:  usecase.OperationReserve,
+		ProductID:  uuid.New(),
+		Attempts:   5,
+		RetryAfter: 2 * time.Second,
+	})
+
+	_, err := env.client.ReserveStock(authContext(t), &inventoryv1.ReserveStockRequest{
+		ProductId: uuid.NewString(),
+		OrderId:   uuid.NewString(),
+		Quantity:  1,
+	})
+
+	assert.Equal(t, codes.Aborted, status.Code(err))
+	assert.Equal(t, errors.ErrConcurrentModification.Code, errorReason(t, err))
+
+	var retryInfo *errdetails.RetryInfo
+	for _, detail := range status.Convert(err).Details() {
+		if info, ok := detail.(*errdetails.RetryInfo); ok {
+			retryInfo = info
+		}
+	}
+	require.NotNil(t, retryInfo)
+	assert.Equal(t, 2*time.Second, retryInfo.RetryDelay.AsDuration())
+}
+
+func TestConfirmReservation_Success(t *testing.T) {
+	env := setupServer(t)
+	reservationID := uuid.New()
+	orderID := uuid.New()
+
+	env.confirm.On("Execute", mock.Anything, usecase.ConfirmReservationInput{ReservationID: reservationID}).
+		Return(&usecase.ConfirmReservationOutput{
+			ReservationID:     reservationID,
+			OrderID:           orderID,
+			QuantityConfirmed: 2,
+			FinalStock:        8,
+			ReservedStock:     0,
+		}, nil)
+
+	resp, err := env.client.ConfirmReservation(authContext(t), &inventoryv1.ConfirmReservationRequest{ReservationId: reservationID.String()})
+
+	require.NoError(t, err)
+	assert.Equal(t, orderID.String(), resp.OrderId)
+	assert.Equal(t, int32(2), resp.QuantityConfirmed)
+	assert.Equal(t, int32(8), resp.FinalStock)
+}
+
+func TestConfirmAndReleaseReservation_Channel(t *testing.T) {
+	env := setupServer(t)
+	reservation, err := entity.NewReservation(uuid.New(), uuid.New(), 2)
+	require.NoError(t, err)
+	reservation.Channel = "marketplace"
+
+	env.confirm.On("Execute", mock.Anything, usecase.ConfirmReservationInput{ReservationID: reservation.ID}).
+		Return(&usecase.ConfirmReservationOutput{ReservationID: reservation.ID, OrderID: reservation.OrderID, Reservation: reservation}, nil)
+	env.release.On("Execute", mock.Anything, usecase.ReleaseReservationInput{ReservationID: reservation.ID}).
+		Return(&usecase.ReleaseReservationOutput{ReservationID: reservation.ID, OrderID: reservation.OrderID, Reservation: reservation}, nil)
+
+	confirmed, err := env.client.ConfirmReservation(authContext(t), &inventoryv1.ConfirmReservationRequest{ReservationId: reservation.ID.String()})
+	require.NoError(t, err)
+	assert.Equal(t, "marketplace", confirmed.Channel)
+
+	released, err := env.client.ReleaseReservation(authContext(t), &inventoryv1.ReleaseReservationRequest{ReservationId: reservation.ID.String()})
+	require.NoError(t, err)
+	assert.Equal(t, "marketplace", released.Channel)
+}
+
+func TestReleaseReservation_NotFound(t *testing.T) {
+	env := setupServer(t)
+	reservationID := uuid.New()
+
+	env.release.On("Execute", mock.Anything, usecase.ReleaseReservationInput{ReservationID: reservationID}).
+		Return(nil, errors.ErrReservationNotFound)
+
+	_, err := env.client.ReleaseReservation(authContext(t), &inventoryv1.ReleaseReservationRequest{ReservationId: reservationID.String()})
+
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Equal(t, "RESERVATION_NOT_FOUND", errorReason(t, err))
+}
+
+func TestAuthentication(t *testing.T) {
+	env := setupServer(t)
+
+	t.Run("missing api key", func(t *testing.T) {
+		_, err := env.client.CheckAvailability(context.Background(), &inventoryv1.CheckAvailabilityRequest{ProductId: uuid.NewString()})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer wrong-key")
+		_, err := env.client.CheckAvailability(ctx, &inventoryv1.CheckAvailabilityRequest{ProductId: uuid.NewString()})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+
+	t.Run("read-only token cannot reserve", func(t *testing.T) {
+		token, err := auth.NewHMACSigner("k1", testTokenSecret, auth.SignerOptions{Audience: "inventory-service"}).
+			Sign("reporting-service", []string{auth.ScopeInventoryRead}, time.Hour)
+		require.NoError(t, err)
+		ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+
+		_, err = env.client.ReserveStock(ctx, &inventoryv1.ReserveStockRequest{ProductId: uuid.NewString(), OrderId: uuid.NewString(), Quantity: 1})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("health service is public", func(t *testing.T) {
+		resp, err := env.health.Check(context.Background(), &healthpb.HealthCheckRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.Status)
+	})
+}
+
+func TestNewInventoryServer_PanicsOnNilUseCase(t *testing.T) {
+	assert.Panics(t, func() {
+		server.NewInventoryServer(nil, nil, nil, nil, 0)
+	})
+}
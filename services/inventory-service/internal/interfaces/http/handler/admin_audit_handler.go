@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/application/usecase"
+	domainErrors "github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+)
+
+// ListAdminAuditLogExecutor interface for querying the admin audit log
+type ListAdminAuditLogExecutor interface {
+	Execute(ctx context.Context, input usecase.ListAdminAuditLogInput) (*usecase.ListAdminAuditLogOutput, error)
+}
+
+// AdminAuditHandler exposes the admin audit log
+type AdminAuditHandler struct {
+	listAuditLogUC ListAdminAuditLogExecutor
+}
+
+// NewAdminAuditHandler creates a new AdminAuditHandler
+func NewAdminAuditHandler(listAuditLogUC ListAdminAuditLogExecutor) *AdminAuditHandler {
+	if listAuditLogUC == nil {
+		panic("listAuditLogUC cannot be nil")
+	}
+
+	return &AdminAuditHandler{listAuditLogUC: listAuditLogUC}
+}
+
+// AdminAuditEntryResponse represents an audit entry in API response
+type AdminAuditEntryResponse struct {
+	ID             string            `json:"id"`
+	Actor          string            `json:"actor"`
+	KeyID          string            `json:"key_id,omitempty"`
+	TokenID        string            `json:"token_id,omitempty"`
+	Method         string            `json:"method"`
+	Route          string            `json:"route"`
+	Path           string            `json:"path"`
+	Parameters     map[string]string `json:"parameters,omitempty"`
+	Outcome        string            `json:"outcome"`
+	StatusCode     int               `json:"status_code"`
+	AffectedIDs    []string          `json:"affected_ids"`
+	ErrorMessage   string            `json:"error_message,omitempty"`
+	RemoteAddr     string            `json:"remote_addr,omitempty"`
+	DurationMillis int64             `json:"duration_ms"`
+	CreatedAt      string            `json:"created_at"`
+}
+
+// ListAdminAuditLogResponse represents the response for querying the audit log
+type ListAdminAuditLogResponse struct {
+	Entries    []AdminAuditEntryResponse `json:"entries"`
+	TotalCount int64                     `json:"total_count"`
+	Limit      int                       `json:"limit"`
+	Offset     int                       `json:"offset"`
+}
+
+// ListAuditLog handles GET /admin/audit
+// Supported filters: actor, route, outcome, entity_id, from, to (RFC3339), limit, offset
+func (h *AdminAuditHandler) ListAuditLog(c *gin.Context) {
+	input := usecase.ListAdminAuditLogInput{
+		Actor:    c.Query("actor"),
+		Route:    c.Query("route"),
+		Outcome:  c.Query("outcome"),
+		EntityID: c.Query("entity_id"),
+	}
+
+	var err error
+	if input.Limit, err = strconv.Atoi(c.DefaultQuery("limit", "50")); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_limit", "message": "limit must be an integer"})
+		return
+	}
+	if input.Offset, err = strconv.Atoi(c.DefaultQuery("offset", "0")); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_offset", "message": "offset must be an integer"})
+		return
+	}
+	if input.From, err = parseQueryTime(c, "from"); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_from", "message": "from must be an RFC3339 timestamp"})
+		return
+	}
+	if input.To, err = parseQueryTime(c, "to"); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_to", "message": "to must be an RFC3339 timestamp"})
+		return
+	}
+
+	output, err := h.listAuditLogUC.Execute(c.Request.Context(), input)
+	if err != nil {
+		if errors.Is(err, domainErrors.ErrInvalidInput) {
+			var domainErr *domainErrors.DomainError
+			errors.As(err, &domainErr)
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_filter", "message": domainErr.Details})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "internal_server_error",
+			"message": "Failed to retrieve admin audit log",
+		})
+		return
+	}
+
+	entries := make([]AdminAuditEntryResponse, len(output.Entries))
+	for i, entry := range output.Entries {
+		affectedIDs := entry.AffectedIDs
+		if affectedIDs == nil {
+			affectedIDs = []string{}
+		}
+		entries[i] = AdminAuditEntryResponse{
+			ID:             entry.ID.String(),
+			Actor:          entry.Actor,
+			KeyID:          entry.KeyID,
+			TokenID:        entry.TokenID,
+			Method:         entry.Method,
+			Route:          entry.Route,
+			Path:           entry.Path,
+			Parameters:     entry.Parameters,
+			Outcome:        string(entry.Outcome),
+			StatusCode:     entry.StatusCode,
+			AffectedIDs:    affectedIDs,
+			ErrorMessage:   entry.ErrorMessage,
+			RemoteAddr:     entry.RemoteAddr,
+			DurationMillis: entry.DurationMillis,
+			CreatedAt:      entry.CreatedAt.Format(time.RFC3339),
+		}
+	}
+
+	c.JSON(http.StatusOK, ListAdminAuditLogResponse{
+		Entries:    entries,
+		TotalCount: output.TotalCount,
+		Limit:      output.Limit,
+		Offset:     output.Offset,
+	})
+}
+
+// parseQueryTime parses an optional RFC3339 query parameter
+func parseQueryTime(c *gin.Context, key string) (time.Time, error) {
+	value := c.Query(key)
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
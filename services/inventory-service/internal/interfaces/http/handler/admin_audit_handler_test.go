@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/application/usecase"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	domainErrors "github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+)
+
+// MockListAdminAuditLogUseCase mocks the list admin audit log use case
+type MockListAdminAuditLogUseCase struct {
+	mock.Mock
+}
+
+func (m *MockListAdminAuditLogUseCase) Execute(ctx context.Context, input usecase.ListAdminAuditLogInput) (*usecase.ListAdminAuditLogOutput, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.ListAdminAuditLogOutput), args.Error(1)
+}
+
+func setupAdminAuditRouter(uc ListAdminAuditLogExecutor) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/admin/audit", NewAdminAuditHandler(uc).ListAuditLog)
+	return router
+}
+
+func TestNewAdminAuditHandler_NilUseCase_Panics(t *testing.T) {
+	assert.Panics(t, func() {
+		NewAdminAuditHandler(nil)
+	})
+}
+
+func TestAdminAuditHandler_ListAuditLog_Success(t *testing.T) {
+	mockUC := new(MockListAdminAuditLogUseCase)
+	entry, err := entity.NewAdminAuditEntry("ops-console", http.MethodPost, "/admin/dlq/:id/retry")
+	require.NoError(t, err)
+	entry.AffectedIDs = []string{"msg-1"}
+	entry.Complete(http.StatusOK, 12*time.Millisecond)
+
+	from := time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC)
+	mockUC.On("Execute", mock.Anything, usecase.ListAdminAuditLogInput{
+		Actor:    "ops-console",
+		Outcome:  "success",
+		EntityID: "msg-1",
+		From:     from,
+		Limit:    10,
+		Offset:   0,
+	}).Return(&usecase.ListAdminAuditLogOutput{
+		Entries:    []*entity.AdminAuditEntry{entry},
+		TotalCount: 1,
+		Limit:      10,
+	}, nil)
+
+	w := httptest.NewRecorder()
+	setupAdminAuditRouter(mockUC).ServeHTTP(w, httptest.NewRequest(http.MethodGet,
+		"/admin/audit?actor=ops-console&outcome=success&entity_id=msg-1&from=2025-11-01T00:00:00Z&limit=10", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response ListAdminAuditLogResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, int64(1), response.TotalCount)
+	require.Len(t, response.Entries, 1)
+	assert.Equal(t, "ops-console", response.Entries[0].Actor)
+	assert.Equal(t, "success", response.Entries[0].Outcome)
+	assert.Equal(t, []string{"msg-1"}, response.Entries[0].AffectedIDs)
+	assert.Equal(t, int64(12), response.Entries[0].DurationMillis)
+	mockUC.AssertExpectations(t)
+}
+
+func TestAdminAuditHandler_ListAuditLog_InvalidQuery(t *testing.T) {
+	tests := []struct {
+		query         string
+		expectedError string
+	}{
+		{"limit=abc", "invalid_limit"},
+		{"offset=abc", "invalid_offset"},
+		{"from=yesterday", "invalid_from"},
+		{"to=2025-13-01", "invalid_to"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			mockUC := new(MockListAdminAuditLogUseCase)
+
+			w := httptest.NewRecorder()
+			setupAdminAuditRouter(mockUC).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/audit?"+tt.query, nil))
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Contains(t, w.Body.String(), tt.expectedError)
+			mockUC.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestAdminAuditHandler_ListAuditLog_InvalidFilter(t *testing.T) {
+	mockUC := new(MockListAdminAuditLogUseCase)
+	mockUC.On("Execute", mock.Anything, mock.Anything).
+		Return(nil, domainErrors.ErrInvalidInput.WithDetails("outcome must be success, failure or denied"))
+
+	w := httptest.NewRecorder()
+	setupAdminAuditRouter(mockUC).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/audit?outcome=maybe", nil))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "invalid_filter")
+	assert.Contains(t, w.Body.String(), "outcome must be")
+}
+
+func TestAdminAuditHandler_ListAuditLog_UseCaseError(t *testing.T) {
+	mockUC := new(MockListAdminAuditLogUseCase)
+	mockUC.On("Execute", mock.Anything, mock.Anything).Return(nil, errors.New("database unavailable"))
+
+	w := httptest.NewRecorder()
+	setupAdminAuditRouter(mockUC).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/audit", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.NotContains(t, w.Body.String(), "database unavailable")
+}
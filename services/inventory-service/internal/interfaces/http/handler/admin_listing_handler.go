@@ -0,0 +1,304 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/application/usecase"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	domainErrors "github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+)
+
+// ListInventoryItemsExecutor interface for listing inventory items
+type ListInventoryItemsExecutor interface {
+	Execute(ctx context.Context, input usecase.ListInventoryItemsInput) (*usecase.ListInventoryItemsOutput, error)
+}
+
+// ListReservationsExecutor interface for listing reservations
+type ListReservationsExecutor interface {
+	Execute(ctx context.Context, input usecase.ListReservationsInput) (*usecase.ListReservationsOutput, error)
+}
+
+// AdminListingHandler exposes cursor-paginated listings of inventory items and reservations
+type AdminListingHandler struct {
+	listItemsUC        ListInventoryItemsExecutor
+	listReservationsUC ListReservationsExecutor
+}
+
+// NewAdminListingHandler creates a new AdminListingHandler
+func NewAdminListingHandler(listItemsUC ListInventoryItemsExecutor, listReservationsUC ListReservationsExecutor) *AdminListingHandler {
+	if listItemsUC == nil {
+		panic("listItemsUC cannot be nil")
+	}
+	if listReservationsUC == nil {
+		panic("listReservationsUC cannot be nil")
+	}
+
+	return &AdminListingHandler{
+		listItemsUC:        listItemsUC,
+		listReservationsUC: listReservationsUC,
+	}
+}
+
+// InventoryItemResponse represents an inventory item in API response
+type InventoryItemResponse struct {
+	ID         string `json:"id"`
+	ProductID  string `json:"product_id"`
+	Quantity   int    `json:"quantity"`
+	Reserved   int    `json:"reserved"`
+	Available  int    `json:"available"`
+	Version    int    `json:"version"`
+	CreatedAt  string `json:"created_at"`
+	UpdatedAt  string `json:"updated_at"`
+	ArchivedAt string `json:"archived_at,omitempty"`
+}
+
+// ReservationResponse represents a reservation in API response
+type ReservationResponse struct {
+	ID              string `json:"id"`
+	InventoryItemID string `json:"inventory_item_id"`
+	OrderID         string `json:"order_id"`
+	Quantity        int    `json:"quantity"`
+	Status          string `json:"status"`
+	Channel         string `json:"channel,omitempty"`
+	ExpiresAt       string `json:"expires_at"`
+	CreatedAt       string `json:"created_at"`
+	UpdatedAt       string `json:"updated_at"`
+}
+
+// ListPageResponse holds the paging fields of a cursor-paginated listing.
+// TotalCount is omitted when count=none; unless TotalCountExact it is the
+// planner's estimate.
+type ListPageResponse struct {
+	NextCursor      string `json:"next_cursor,omitempty"`
+	HasMore         bool   `json:"has_more"`
+	Limit           int    `json:"limit"`
+	TotalCount      *int64 `json:"total_count,omitempty"`
+	TotalCountExact bool   `json:"total_count_exact"`
+}
+
+// ListInventoryItemsResponse represents a page of inventory items
+type ListInventoryItemsResponse struct {
+	Items []InventoryItemResponse `json:"items"`
+	ListPageResponse
+}
+
+// ListReservationsResponse represents a page of reservations
+type ListReservationsResponse struct {
+	Reservations []ReservationResponse `json:"reservations"`
+	ListPageResponse
+}
+
+// ListInventory handles GET /admin/inventory
+// Filters: product_id (repeatable or comma separated), status (active|archived),
+// low_stock (available below N), created_from, created_to (RFC3339).
+// Paging: sort (created_at|updated_at), order (asc|desc), cursor, limit, count (estimate|exact|none).
+func (h *AdminListingHandler) ListInventory(c *gin.Context) {
+	input := usecase.ListInventoryItemsInput{Status: c.Query("status")}
+
+	var err error
+	if input.ProductIDs, err = parseQueryUUIDList(c, "product_id"); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_product_id", "message": "product_id must be a UUID"})
+		return
+	}
+	if input.LowStockBelow, err = strconv.Atoi(c.DefaultQuery("low_stock", "0")); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_low_stock", "message": "low_stock must be an integer"})
+		return
+	}
+	if input.CreatedFrom, err = parseQueryTime(c, "created_from"); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_created_from", "message": "created_from must be an RFC3339 timestamp"})
+		return
+	}
+	if input.CreatedTo, err = parseQueryTime(c, "created_to"); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_created_to", "message": "created_to must be an RFC3339 timestamp"})
+		return
+	}
+	paging, ok := bindListPaging(c)
+	if !ok {
+		return
+	}
+	input.Sort, input.Order, input.Cursor, input.Limit, input.Count = paging.sort, paging.order, paging.cursor, paging.limit, paging.count
+
+	output, err := h.listItemsUC.Execute(c.Request.Context(), input)
+	if err != nil {
+		respondListError(c, err, "Failed to list inventory items")
+		return
+	}
+
+	items := make([]InventoryItemResponse, len(output.Items))
+	for i, item := range output.Items {
+		items[i] = toInventoryItemResponse(item)
+	}
+
+	c.JSON(http.StatusOK, ListInventoryItemsResponse{
+		Items:            items,
+		ListPageResponse: listPage(output.NextCursor, output.Limit, output.TotalCount, output.Count),
+	})
+}
+
+// ListReservations handles GET /admin/reservations
+// Filters: status, product_id, order_id, inventory_item_id, expires_from, expires_to,
+// created_from, created_to (RFC3339).
+// Paging: sort (created_at|updated_at|expires_at), order (asc|desc), cursor, limit, count (estimate|exact|none).
+func (h *AdminListingHandler) ListReservations(c *gin.Context) {
+	input := usecase.ListReservationsInput{Status: c.Query("status")}
+
+	var err error
+	for _, param := range []struct {
+		key  string
+		dest *uuid.UUID
+	}{
+		{"product_id", &input.ProductID},
+		{"order_id", &input.OrderID},
+		{"inventory_item_id", &input.InventoryItemID},
+	} {
+		if *param.dest, err = parseQueryUUID(c, param.key); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_" + param.key, "message": param.key + " must be a UUID"})
+			return
+		}
+	}
+	for _, param := range []struct {
+		key  string
+		dest *time.Time
+	}{
+		{"expires_from", &input.ExpiresFrom},
+		{"expires_to", &input.ExpiresTo},
+		{"created_from", &input.CreatedFrom},
+		{"created_to", &input.CreatedTo},
+	} {
+		if *param.dest, err = parseQueryTime(c, param.key); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_" + param.key, "message": param.key + " must be an RFC3339 timestamp"})
+			return
+		}
+	}
+	paging, ok := bindListPaging(c)
+	if !ok {
+		return
+	}
+	input.Sort, input.Order, input.Cursor, input.Limit, input.Count = paging.sort, paging.order, paging.cursor, paging.limit, paging.count
+
+	output, err := h.listReservationsUC.Execute(c.Request.Context(), input)
+	if err != nil {
+		respondListError(c, err, "Failed to list reservations")
+		return
+	}
+
+	reservations := make([]ReservationResponse, len(output.Reservations))
+	for i, reservation := range output.Reservations {
+		reservations[i] = toReservationResponse(reservation)
+	}
+
+	c.JSON(http.StatusOK, ListReservationsResponse{
+		Reservations:     reservations,
+		ListPageResponse: listPage(output.NextCursor, output.Limit, output.TotalCount, output.Count),
+	})
+}
+
+// listPaging holds the paging query parameters shared by the listings
+type listPaging struct {
+	sort, order, cursor, count string
+	limit                      int
+}
+
+// bindListPaging reads the paging parameters; on error it writes a 400 and returns false
+func bindListPaging(c *gin.Context) (listPaging, bool) {
+	paging := listPaging{
+		sort:   c.Query("sort"),
+		order:  c.Query("order"),
+		cursor: c.Query("cursor"),
+		count:  c.Query("count"),
+	}
+
+	var err error
+	if paging.limit, err = strconv.Atoi(c.DefaultQuery("limit", "50")); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_limit", "message": "limit must be an integer"})
+		return paging, false
+	}
+	return paging, true
+}
+
+// respondListError maps listing errors: invalid filters and cursors are 400
+func respondListError(c *gin.Context, err error, message string) {
+	if errors.Is(err, domainErrors.ErrInvalidInput) {
+		var domainErr *domainErrors.DomainError
+		errors.As(err, &domainErr)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_filter", "message": domainErr.Details})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{
+		"error":   "internal_server_error",
+		"message": message,
+	})
+}
+
+// listPage builds the paging fields of a listing response
+func listPage(nextCursor string, limit int, total int64, count usecase.CountMode) ListPageResponse {
+	page := ListPageResponse{
+		NextCursor:      nextCursor,
+		HasMore:         nextCursor != "",
+		Limit:           limit,
+		TotalCountExact: count == usecase.CountExact,
+	}
+	if count != usecase.CountNone {
+		page.TotalCount = &total
+	}
+	return page
+}
+
+// toInventoryItemResponse converts an inventory item to its API shape
+func toInventoryItemResponse(item *entity.InventoryItem) InventoryItemResponse {
+	response := InventoryItemResponse{
+		ID:        item.ID.String(),
+		ProductID: item.ProductID.String(),
+		Quantity:  item.Quantity,
+		Reserved:  item.Reserved,
+		Available: item.Available(),
+		Version:   item.Version,
+		CreatedAt: item.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: item.UpdatedAt.Format(time.RFC3339),
+	}
+	if item.ArchivedAt != nil {
+		response.ArchivedAt = item.ArchivedAt.Format(time.RFC3339)
+	}
+	return response
+}
+
+// toReservationResponse converts a reservation to its API shape
+func toReservationResponse(reservation *entity.Reservation) ReservationResponse {
+	return ReservationResponse{
+		ID:              reservation.ID.String(),
+		InventoryItemID: reservation.InventoryItemID.String(),
+		OrderID:         reservation.OrderID.String(),
+		Quantity:        reservation.Quantity,
+		Status:          string(reservation.Status),
+		Channel:         reservation.Channel,
+		ExpiresAt:       reservation.ExpiresAt.Format(time.RFC3339),
+		CreatedAt:       reservation.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:       reservation.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+// parseQueryUUIDList parses a UUID query parameter that may be repeated or comma separated
+func parseQueryUUIDList(c *gin.Context, key string) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	for _, value := range c.QueryArray(key) {
+		for _, part := range strings.Split(value, ",") {
+			if part = strings.TrimSpace(part); part == "" {
+				continue
+			}
+			id, err := uuid.Parse(part)
+			if err != nil {
+				return nil, err
+			}
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
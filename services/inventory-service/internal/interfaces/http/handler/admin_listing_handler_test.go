@@ -0,0 +1,233 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/application/usecase"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	domainErrors "github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+)
+
+// MockListInventoryItemsUseCase mocks the list inventory items use case
+type MockListInventoryItemsUseCase struct {
+	mock.Mock
+}
+
+func (m *MockListInventoryItemsUseCase) Execute(ctx context.Context, input usecase.ListInventoryItemsInput) (*usecase.ListInventoryItemsOutput, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.ListInventoryItemsOutput), args.Error(1)
+}
+
+// MockListReservationsUseCase mocks the list reservations use case
+type MockListReservationsUseCase struct {
+	mock.Mock
+}
+
+func (m *MockListReservationsUseCase) Execute(ctx context.Context, input usecase.ListReservationsInput) (*usecase.ListReservationsOutput, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.ListReservationsOutput), args.Error(1)
+}
+
+func setupAdminListingRouter() (*gin.Engine, *MockListInventoryItemsUseCase, *MockListReservationsUseCase) {
+	gin.SetMode(gin.TestMode)
+	listItems := new(MockListInventoryItemsUseCase)
+	listReservations := new(MockListReservationsUseCase)
+	h := NewAdminListingHandler(listItems, listReservations)
+
+	router := gin.New()
+	router.GET("/admin/inventory", h.ListInventory)
+	router.GET("/admin/reservations", h.ListReservations)
+	return router, listItems, listReservations
+}
+
+func TestNewAdminListingHandler_NilUseCases_Panic(t *testing.T) {
+	assert.Panics(t, func() { NewAdminListingHandler(nil, new(MockListReservationsUseCase)) })
+	assert.Panics(t, func() { NewAdminListingHandler(new(MockListInventoryItemsUseCase), nil) })
+}
+
+func TestAdminListingHandler_ListInventory_Success(t *testing.T) {
+	router, listItems, _ := setupAdminListingRouter()
+	first, second, third := uuid.New(), uuid.New(), uuid.New()
+	archivedAt := time.Date(2025, 11, 2, 0, 0, 0, 0, time.UTC)
+	item := &entity.InventoryItem{
+		ID:         uuid.New(),
+		ProductID:  first,
+		Quantity:   10,
+		Reserved:   4,
+		Version:    3,
+		CreatedAt:  time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC),
+		UpdatedAt:  time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC),
+		ArchivedAt: &archivedAt,
+	}
+	listItems.On("Execute", mock.Anything, usecase.ListInventoryItemsInput{
+		ProductIDs:    []uuid.UUID{first, second, third},
+		Status:        "archived",
+		LowStockBelow: 10,
+		CreatedFrom:   time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC),
+		Sort:          "updated_at",
+		Order:         "asc",
+		Cursor:        "abc",
+		Limit:         25,
+		Count:         "exact",
+	}).Return(&usecase.ListInventoryItemsOutput{
+		Items:      []*entity.InventoryItem{item},
+		NextCursor: "next",
+		TotalCount: 7,
+		Count:      usecase.CountExact,
+		Limit:      25,
+	}, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/inventory?product_id="+first.String()+","+second.String()+
+		"&product_id="+third.String()+"&status=archived&low_stock=10&created_from=2025-10-01T00:00:00Z"+
+		"&sort=updated_at&order=asc&cursor=abc&limit=25&count=exact", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response ListInventoryItemsResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response.Items, 1)
+	assert.Equal(t, 6, response.Items[0].Available)
+	assert.Equal(t, "2025-11-02T00:00:00Z", response.Items[0].ArchivedAt)
+	assert.Equal(t, "next", response.NextCursor)
+	assert.True(t, response.HasMore)
+	require.NotNil(t, response.TotalCount)
+	assert.Equal(t, int64(7), *response.TotalCount)
+	assert.True(t, response.TotalCountExact)
+	listItems.AssertExpectations(t)
+}
+
+func TestAdminListingHandler_ListInventory_LastPageWithoutCount(t *testing.T) {
+	router, listItems, _ := setupAdminListingRouter()
+	listItems.On("Execute", mock.Anything, mock.Anything).Return(&usecase.ListInventoryItemsOutput{
+		Items: []*entity.InventoryItem{},
+		Count: usecase.CountNone,
+		Limit: 50,
+	}, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/inventory?count=none", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"items":[],"has_more":false,"limit":50,"total_count_exact":false}`, w.Body.String())
+}
+
+func TestAdminListingHandler_ListInventory_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		query  string
+		ucErr  error
+		status int
+		code   string
+	}{
+		{"bad product", "product_id=abc", nil, http.StatusBadRequest, "invalid_product_id"},
+		{"bad low stock", "low_stock=few", nil, http.StatusBadRequest, "invalid_low_stock"},
+		{"bad created_from", "created_from=today", nil, http.StatusBadRequest, "invalid_created_from"},
+		{"bad limit", "limit=all", nil, http.StatusBadRequest, "invalid_limit"},
+		{"invalid filter", "sort=quantity", domainErrors.ErrInvalidInput.WithDetails("sort must be created_at or updated_at"), http.StatusBadRequest, "invalid_filter"},
+		{"repository failure", "", errors.New("database error"), http.StatusInternalServerError, "internal_server_error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, listItems, _ := setupAdminListingRouter()
+			if tt.ucErr != nil {
+				listItems.On("Execute", mock.Anything, mock.Anything).Return(nil, tt.ucErr)
+			}
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/inventory?"+tt.query, nil))
+
+			assert.Equal(t, tt.status, w.Code)
+			assert.Contains(t, w.Body.String(), tt.code)
+		})
+	}
+}
+
+func TestAdminListingHandler_ListReservations_Success(t *testing.T) {
+	router, _, listReservations := setupAdminListingRouter()
+	orderID := uuid.New()
+	reservation := &entity.Reservation{
+		ID:              uuid.New(),
+		InventoryItemID: uuid.New(),
+		OrderID:         orderID,
+		Quantity:        1,
+		Status:          entity.ReservationPending,
+		ExpiresAt:       time.Date(2025, 11, 20, 10, 15, 0, 0, time.UTC),
+		CreatedAt:       time.Date(2025, 11, 20, 10, 0, 0, 0, time.UTC),
+		UpdatedAt:       time.Date(2025, 11, 20, 10, 0, 0, 0, time.UTC),
+	}
+	listReservations.On("Execute", mock.Anything, usecase.ListReservationsInput{
+		Status:      "pending",
+		OrderID:     orderID,
+		ExpiresFrom: time.Date(2025, 11, 20, 0, 0, 0, 0, time.UTC),
+		ExpiresTo:   time.Date(2025, 11, 21, 0, 0, 0, 0, time.UTC),
+		Sort:        "expires_at",
+		Limit:       50,
+	}).Return(&usecase.ListReservationsOutput{
+		Reservations: []*entity.Reservation{reservation},
+		TotalCount:   1,
+		Count:        usecase.CountEstimate,
+		Limit:        50,
+	}, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/reservations?status=pending&order_id="+orderID.String()+
+		"&expires_from=2025-11-20T00:00:00Z&expires_to=2025-11-21T00:00:00Z&sort=expires_at", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response ListReservationsResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response.Reservations, 1)
+	assert.Equal(t, orderID.String(), response.Reservations[0].OrderID)
+	assert.Equal(t, "2025-11-20T10:15:00Z", response.Reservations[0].ExpiresAt)
+	assert.False(t, response.HasMore)
+	require.NotNil(t, response.TotalCount)
+	assert.False(t, response.TotalCountExact, "estimated")
+	listReservations.AssertExpectations(t)
+}
+
+func TestAdminListingHandler_ListReservations_InvalidParams(t *testing.T) {
+	tests := []struct {
+		query string
+		code  string
+	}{
+		{"product_id=x", "invalid_product_id"},
+		{"order_id=x", "invalid_order_id"},
+		{"inventory_item_id=x", "invalid_inventory_item_id"},
+		{"expires_from=x", "invalid_expires_from"},
+		{"expires_to=x", "invalid_expires_to"},
+		{"created_from=x", "invalid_created_from"},
+		{"created_to=x", "invalid_created_to"},
+		{"limit=x", "invalid_limit"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			router, _, listReservations := setupAdminListingRouter()
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/reservations?"+tt.query, nil))
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Contains(t, w.Body.String(), tt.code)
+			listReservations.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything)
+		})
+	}
+}
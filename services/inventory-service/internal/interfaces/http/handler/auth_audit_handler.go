@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/infrastructure/auth"
+)
+
+// DenialLister returns the most recent authentication denials
+type DenialLister interface {
+	Recent(limit int) []auth.Denial
+}
+
+// AuthAuditHandler exposes the authentication denial audit
+type AuthAuditHandler struct {
+	denials DenialLister
+}
+
+// NewAuthAuditHandler creates a new AuthAuditHandler
+func NewAuthAuditHandler(denials DenialLister) *AuthAuditHandler {
+	if denials == nil {
+		panic("denials cannot be nil")
+	}
+
+	return &AuthAuditHandler{denials: denials}
+}
+
+// ListDenialsResponse represents the response for listing denied requests
+type ListDenialsResponse struct {
+	Denials []auth.Denial `json:"denials"`
+	Count   int           `json:"count"`
+	Limit   int           `json:"limit"`
+}
+
+// ListDenials handles GET /admin/auth/denials
+// It returns the most recent denied requests, newest first
+func (h *AuthAuditHandler) ListDenials(c *gin.Context) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "100"))
+	if err != nil || limit <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_limit",
+			"message": "limit must be a positive integer",
+		})
+		return
+	}
+
+	denials := h.denials.Recent(limit)
+
+	c.JSON(http.StatusOK, ListDenialsResponse{
+		Denials: denials,
+		Count:   len(denials),
+		Limit:   limit,
+	})
+}
@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/infrastructure/auth"
+)
+
+func setupAuthAuditRouter(audit *auth.DenialAudit) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/admin/auth/denials", NewAuthAuditHandler(audit).ListDenials)
+	return router
+}
+
+func TestAuthAuditHandler_ListDenials(t *testing.T) {
+	audit := auth.NewDenialAudit(10)
+	audit.Record(auth.Denial{Transport: "http", Operation: "GET /admin/dlq", Reason: auth.ReasonMissingToken})
+	audit.Record(auth.Denial{Transport: "grpc", Operation: "/inventory.v1.InventoryService/ReserveStock", Reason: auth.ReasonInsufficientScope, Service: "reporting-service"})
+	router := setupAuthAuditRouter(audit)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/auth/denials?limit=1", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response ListDenialsResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 1, response.Count)
+	require.Len(t, response.Denials, 1)
+	assert.Equal(t, "reporting-service", response.Denials[0].Service)
+	assert.Equal(t, auth.ReasonInsufficientScope, response.Denials[0].Reason)
+}
+
+func TestAuthAuditHandler_InvalidLimit(t *testing.T) {
+	router := setupAuthAuditRouter(auth.NewDenialAudit(10))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/auth/denials?limit=abc", nil))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "invalid_limit")
+}
+
+func TestNewAuthAuditHandler_PanicsOnNil(t *testing.T) {
+	assert.Panics(t, func() {
+		NewAuthAuditHandler(nil)
+	})
+}
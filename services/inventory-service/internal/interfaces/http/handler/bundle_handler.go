@@ -0,0 +1,296 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/application/usecase"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	domainErrors "github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+)
+
+// DefineBundleExecutor interface for defining the components of a bundle
+type DefineBundleExecutor interface {
+	Execute(ctx context.Context, input usecase.DefineBundleInput) (*usecase.DefineBundleOutput, error)
+}
+
+// GetBundleExecutor interface for looking up a bundle and its availability
+type GetBundleExecutor interface {
+	Execute(ctx context.Context, productID uuid.UUID) (*usecase.BundleOutput, error)
+}
+
+// DeleteBundleExecutor interface for turning a bundle back into a product with stock
+type DeleteBundleExecutor interface {
+	Execute(ctx context.Context, productID uuid.UUID) (*entity.InventoryItem, error)
+}
+
+// BundleHandler handles bundle definitions
+type BundleHandler struct {
+	defineUC DefineBundleExecutor
+	getUC    GetBundleExecutor
+	deleteUC DeleteBundleExecutor
+}
+
+// NewBundleHandler creates a new BundleHandler
+func NewBundleHandler(defineUC DefineBundleExecutor, getUC GetBundleExecutor, deleteUC DeleteBundleExecutor) *BundleHandler {
+	if defineUC == nil {
+		panic("defineUC cannot be nil")
+	}
+	if getUC == nil {
+		panic("getUC cannot be nil")
+	}
+	if deleteUC == nil {
+		panic("deleteUC cannot be nil")
+	}
+
+	return &BundleHandler{
+		defineUC: defineUC,
+		getUC:    getUC,
+		deleteUC: deleteUC,
+	}
+}
+
+// BundleComponentRequest represents a product that goes into a bundle
+type BundleComponentRequest struct {
+	ProductID string `json:"product_id" binding:"required"`
+	Quantity  int    `json:"quantity" binding:"required,min=1"`
+}
+
+// DefineBundleRequest represents the components of a bundle
+type DefineBundleRequest struct {
+	Components []BundleComponentRequest `json:"components" binding:"required,dive"`
+}
+
+// BundleComponentResponse represents a component of a bundle and, when looked
+// up, its stock
+type BundleComponentResponse struct {
+	ProductID         string `json:"product_id"`
+	Quantity          int    `json:"quantity"`
+	AvailableQuantity *int   `json:"available_quantity,omitempty"`
+	BundlesAvailable  *int   `json:"bundles_available,omitempty"`
+}
+
+// BundleResponse represents a bundle and, when looked up, the stock its components make up
+type BundleResponse struct {
+	ProductID         string                    `json:"product_id"`
+	Components        []BundleComponentResponse `json:"components"`
+	AvailableQuantity *int                      `json:"available_quantity,omitempty"`
+	TotalStock        *int                      `json:"total_stock,omitempty"`
+}
+
+// BundleStockResponse represents a product after its bundle definition was removed
+type BundleStockResponse struct {
+	ProductID string `json:"product_id"`
+	Bundle    bool   `json:"bundle"`
+	Quantity  int    `json:"quantity"`
+	Reserved  int    `json:"reserved"`
+	Available int    `json:"available"`
+}
+
+// ReservationComponentResponse represents the units of a component a bundle reservation takes
+type ReservationComponentResponse struct {
+	ProductID string `json:"product_id"`
+	Quantity  int    `json:"quantity"`
+	Status    string `json:"status"`
+}
+
+// DefineBundle handles PUT /admin/inventory/:productId/bundle
+// @Summary Define the components of a bundle
+// @Description Makes the product a bundle of the listed products, replacing its previous components.
+// @Description A bundle holds no stock of its own: it is available as long as every component is.
+// @Description Only products without stock can become bundles; pending reservations keep their components.
+// @Tags Admin, Inventory
+// @Accept json
+// @Produce json
+// @Param productId path string true "Product ID (UUID)"
+// @Param request body DefineBundleRequest true "Components"
+// @Success 200 {object} BundleResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /admin/inventory/{productId}/bundle [put]
+func (h *BundleHandler) DefineBundle(c *gin.Context) {
+	productID, ok := parseProductIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req DefineBundleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	components := make([]usecase.BundleComponentInput, len(req.Components))
+	for i, component := range req.Components {
+		componentID, err := uuid.Parse(component.ProductID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "invalid_product_id",
+				"message": "Invalid component product ID format. Expected UUID.",
+			})
+			return
+		}
+		components[i] = usecase.BundleComponentInput{ProductID: componentID, Quantity: component.Quantity}
+	}
+
+	output, err := h.defineUC.Execute(c.Request.Context(), usecase.DefineBundleInput{
+		ProductID:  productID,
+		Components: components,
+	})
+	if err != nil {
+		respondBundleError(c, err, "Failed to define bundle")
+		return
+	}
+
+	c.JSON(http.StatusOK, toBundleResponse(output.Bundle, nil))
+}
+
+// GetBundle handles GET /admin/inventory/:productId/bundle
+// @Summary Get a bundle and its availability
+// @Description Returns the components of the bundle and how many bundles their stock makes up.
+// @Tags Admin, Inventory
+// @Produce json
+// @Param productId path string true "Product ID (UUID)"
+// @Success 200 {object} BundleResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/inventory/{productId}/bundle [get]
+func (h *BundleHandler) GetBundle(c *gin.Context) {
+	productID, ok := parseProductIDParam(c)
+	if !ok {
+		return
+	}
+
+	output, err := h.getUC.Execute(c.Request.Context(), productID)
+	if err != nil {
+		respondBundleError(c, err, "Failed to get bundle")
+		return
+	}
+
+	c.JSON(http.StatusOK, toBundleResponse(output.Bundle, output.Availability))
+}
+
+// DeleteBundle handles DELETE /admin/inventory/:productId/bundle
+// @Summary Remove the bundle definition of a product
+// @Description Turns the bundle back into a product with stock of its own, starting empty.
+// @Description Bundles with pending reservations cannot be removed.
+// @Tags Admin, Inventory
+// @Produce json
+// @Param productId path string true "Product ID (UUID)"
+// @Success 200 {object} BundleStockResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /admin/inventory/{productId}/bundle [delete]
+func (h *BundleHandler) DeleteBundle(c *gin.Context) {
+	productID, ok := parseProductIDParam(c)
+	if !ok {
+		return
+	}
+
+	item, err := h.deleteUC.Execute(c.Request.Context(), productID)
+	if err != nil {
+		respondBundleError(c, err, "Failed to delete bundle")
+		return
+	}
+
+	c.JSON(http.StatusOK, BundleStockResponse{
+		ProductID: item.ProductID.String(),
+		Bundle:    item.Bundle,
+		Quantity:  item.Quantity,
+		Reserved:  item.Reserved,
+		Available: item.Available(),
+	})
+}
+
+func toBundleResponse(bundle *entity.Bundle, availability *usecase.BundleAvailability) BundleResponse {
+	response := BundleResponse{
+		ProductID:  bundle.ProductID.String(),
+		Components: make([]BundleComponentResponse, len(bundle.Components)),
+	}
+	for i, component := range bundle.Components {
+		response.Components[i] = BundleComponentResponse{
+			ProductID: component.ProductID.String(),
+			Quantity:  component.Quantity,
+		}
+	}
+	if availability == nil {
+		return response
+	}
+
+	response.AvailableQuantity = &availability.AvailableQuantity
+	response.TotalStock = &availability.TotalStock
+	response.Components = toComponentAvailabilityResponses(availability.Components)
+	return response
+}
+
+func toComponentAvailabilityResponses(components []usecase.ComponentAvailability) []BundleComponentResponse {
+	responses := make([]BundleComponentResponse, len(components))
+	for i := range components {
+		responses[i] = BundleComponentResponse{
+			ProductID:         components[i].ProductID.String(),
+			Quantity:          components[i].Quantity,
+			AvailableQuantity: &components[i].AvailableQuantity,
+			BundlesAvailable:  &components[i].BundlesAvailable,
+		}
+	}
+	return responses
+}
+
+func toReservationComponentResponses(components []*entity.ReservationComponent) []ReservationComponentResponse {
+	if len(components) == 0 {
+		return nil
+	}
+
+	responses := make([]ReservationComponentResponse, len(components))
+	for i, component := range components {
+		responses[i] = ReservationComponentResponse{
+			ProductID: component.ProductID.String(),
+			Quantity:  component.Quantity,
+			Status:    string(component.Status),
+		}
+	}
+	return responses
+}
+
+// respondBundleError maps bundle errors to HTTP responses
+func respondBundleError(c *gin.Context, err error, message string) {
+	var domainErr *domainErrors.DomainError
+	switch {
+	case errors.Is(err, domainErrors.ErrInvalidBundle) && errors.As(err, &domainErr):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_bundle", "message": domainErr.Error()})
+	case errors.Is(err, domainErrors.ErrInvalidQuantity):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_bundle", "message": "component quantity must be greater than zero"})
+	case errors.Is(err, domainErrors.ErrInventoryItemNotFound) && errors.As(err, &domainErr):
+		c.JSON(http.StatusNotFound, gin.H{"error": "product_not_found", "message": domainErr.Error()})
+	case errors.Is(err, domainErrors.ErrBundleNotFound):
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "bundle_not_found",
+			"message": "Product is not a bundle",
+		})
+	case errors.Is(err, domainErrors.ErrBundleChange):
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "bundle_change",
+			"message": "Only products without stock can become bundles",
+		})
+	case errors.Is(err, domainErrors.ErrBundleInUse):
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "bundle_in_use",
+			"message": "Reservations still hold units of the bundle's components",
+		})
+	default:
+		_ = c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "internal_server_error",
+			"message": message,
+		})
+	}
+}
@@ -0,0 +1,238 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/application/usecase"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	domainErrors "github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+)
+
+// MockDefineBundleUseCase is a mock for DefineBundleExecutor
+type MockDefineBundleUseCase struct {
+	mock.Mock
+}
+
+func (m *MockDefineBundleUseCase) Execute(ctx context.Context, input usecase.DefineBundleInput) (*usecase.DefineBundleOutput, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.DefineBundleOutput), args.Error(1)
+}
+
+// MockGetBundleUseCase is a mock for GetBundleExecutor
+type MockGetBundleUseCase struct {
+	mock.Mock
+}
+
+func (m *MockGetBundleUseCase) Execute(ctx context.Context, productID uuid.UUID) (*usecase.BundleOutput, error) {
+	args := m.Called(ctx, productID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.BundleOutput), args.Error(1)
+}
+
+// MockDeleteBundleUseCase is a mock for DeleteBundleExecutor
+type MockDeleteBundleUseCase struct {
+	mock.Mock
+}
+
+func (m *MockDeleteBundleUseCase) Execute(ctx context.Context, productID uuid.UUID) (*entity.InventoryItem, error) {
+	args := m.Called(ctx, productID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.InventoryItem), args.Error(1)
+}
+
+type bundleMocks struct {
+	define *MockDefineBundleUseCase
+	get    *MockGetBundleUseCase
+	delete *MockDeleteBundleUseCase
+}
+
+func setupBundleRouter() (*gin.Engine, *bundleMocks) {
+	gin.SetMode(gin.TestMode)
+	m := &bundleMocks{
+		define: new(MockDefineBundleUseCase),
+		get:    new(MockGetBundleUseCase),
+		delete: new(MockDeleteBundleUseCase),
+	}
+	h := NewBundleHandler(m.define, m.get, m.delete)
+	router := gin.New()
+	router.PUT("/admin/inventory/:productId/bundle", h.DefineBundle)
+	router.GET("/admin/inventory/:productId/bundle", h.GetBundle)
+	router.DELETE("/admin/inventory/:productId/bundle", h.DeleteBundle)
+	return router, m
+}
+
+// testBundle returns a bundle of productID made of one unit of first and two of second
+func testBundle(productID, first, second uuid.UUID) *entity.Bundle {
+	return &entity.Bundle{
+		InventoryItemID: uuid.New(),
+		ProductID:       productID,
+		Components: []entity.BundleComponent{
+			{InventoryItemID: uuid.New(), ProductID: first, Quantity: 1},
+			{InventoryItemID: uuid.New(), ProductID: second, Quantity: 2},
+		},
+	}
+}
+
+func TestNewBundleHandler_NilUseCases_Panic(t *testing.T) {
+	_, m := setupBundleRouter()
+	assert.Panics(t, func() { NewBundleHandler(nil, m.get, m.delete) })
+	assert.Panics(t, func() { NewBundleHandler(m.define, nil, m.delete) })
+	assert.Panics(t, func() { NewBundleHandler(m.define, m.get, nil) })
+}
+
+func TestBundleHandler_DefineBundle(t *testing.T) {
+	productID, first, second := uuid.New(), uuid.New(), uuid.New()
+	router, m := setupBundleRouter()
+	m.define.On("Execute", mock.Anything, usecase.DefineBundleInput{
+		ProductID: productID,
+		Components: []usecase.BundleComponentInput{
+			{ProductID: first, Quantity: 1},
+			{ProductID: second, Quantity: 2},
+		},
+	}).Return(&usecase.DefineBundleOutput{Bundle: testBundle(productID, first, second)}, nil)
+
+	body := `{"components":[{"product_id":"` + first.String() + `","quantity":1},{"product_id":"` + second.String() + `","quantity":2}]}`
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/admin/inventory/"+productID.String()+"/bundle", strings.NewReader(body)))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var response BundleResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, productID.String(), response.ProductID)
+	assert.Equal(t, []BundleComponentResponse{
+		{ProductID: first.String(), Quantity: 1},
+		{ProductID: second.String(), Quantity: 2},
+	}, response.Components)
+	assert.Nil(t, response.AvailableQuantity)
+	assert.NotContains(t, w.Body.String(), "available_quantity")
+}
+
+func TestBundleHandler_DefineBundle_Errors(t *testing.T) {
+	productID := uuid.New().String()
+	path := "/admin/inventory/" + productID + "/bundle"
+	valid := `{"components":[{"product_id":"` + uuid.New().String() + `","quantity":1}]}`
+	tests := []struct {
+		name       string
+		path       string
+		body       string
+		ucErr      error
+		wantStatus int
+		wantError  string
+	}{
+		{"invalid product", "/admin/inventory/abc/bundle", valid, nil, http.StatusBadRequest, "invalid_product_id"},
+		{"missing components", path, `{}`, nil, http.StatusBadRequest, "invalid_request"},
+		{"zero quantity", path, `{"components":[{"product_id":"` + productID + `","quantity":0}]}`, nil, http.StatusBadRequest, "invalid_request"},
+		{"invalid component", path, `{"components":[{"product_id":"abc","quantity":1}]}`, nil, http.StatusBadRequest, "invalid_product_id"},
+		{"invalid bundle", path, valid, domainErrors.ErrInvalidBundle.WithDetails("the bundle lists itself"), http.StatusBadRequest, "lists itself"},
+		{"no inventory", path, valid, domainErrors.ErrInventoryItemNotFound.WithDetails("component x"), http.StatusNotFound, "product_not_found"},
+		{"item with stock", path, valid, domainErrors.ErrBundleChange, http.StatusConflict, "bundle_change"},
+		{"database", path, valid, errors.New("connection refused"), http.StatusInternalServerError, "internal_server_error"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, m := setupBundleRouter()
+			m.define.On("Execute", mock.Anything, mock.Anything).Return(nil, tt.ucErr)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodPut, tt.path, strings.NewReader(tt.body)))
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Contains(t, w.Body.String(), tt.wantError)
+		})
+	}
+}
+
+func TestBundleHandler_GetBundle(t *testing.T) {
+	productID, first, second := uuid.New(), uuid.New(), uuid.New()
+	path := "/admin/inventory/" + productID.String() + "/bundle"
+
+	t.Run("should report the availability of every component", func(t *testing.T) {
+		router, m := setupBundleRouter()
+		m.get.On("Execute", mock.Anything, productID).Return(&usecase.BundleOutput{
+			Bundle: testBundle(productID, first, second),
+			Availability: &usecase.BundleAvailability{
+				AvailableQuantity: 3,
+				TotalStock:        5,
+				Components: []usecase.ComponentAvailability{
+					{ProductID: first, Quantity: 1, AvailableQuantity: 8, BundlesAvailable: 8},
+					{ProductID: second, Quantity: 2, AvailableQuantity: 7, BundlesAvailable: 3},
+				},
+			},
+		}, nil)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var response BundleResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.NotNil(t, response.AvailableQuantity)
+		assert.Equal(t, 3, *response.AvailableQuantity)
+		assert.Equal(t, 5, *response.TotalStock)
+		require.Len(t, response.Components, 2)
+		assert.Equal(t, second.String(), response.Components[1].ProductID)
+		assert.Equal(t, 7, *response.Components[1].AvailableQuantity)
+		assert.Equal(t, 3, *response.Components[1].BundlesAvailable)
+	})
+
+	t.Run("should answer 404 for products that are not bundles", func(t *testing.T) {
+		router, m := setupBundleRouter()
+		m.get.On("Execute", mock.Anything, productID).Return(nil, domainErrors.ErrBundleNotFound)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Contains(t, w.Body.String(), "bundle_not_found")
+	})
+}
+
+func TestBundleHandler_DeleteBundle(t *testing.T) {
+	productID := uuid.New()
+	path := "/admin/inventory/" + productID.String() + "/bundle"
+
+	t.Run("should return the product with stock of its own", func(t *testing.T) {
+		router, m := setupBundleRouter()
+		item, err := entity.NewInventoryItem(productID, 0)
+		require.NoError(t, err)
+		m.delete.On("Execute", mock.Anything, productID).Return(item, nil)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, path, nil))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var response BundleStockResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, BundleStockResponse{ProductID: productID.String()}, response)
+	})
+
+	t.Run("should reject bundles with pending reservations", func(t *testing.T) {
+		router, m := setupBundleRouter()
+		m.delete.On("Execute", mock.Anything, productID).Return(nil, domainErrors.ErrBundleInUse)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, path, nil))
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Contains(t, w.Body.String(), "bundle_in_use")
+	})
+}
@@ -0,0 +1,277 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/application/usecase"
+	domainErrors "github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+)
+
+// GetChannelAllocationsExecutor interface for looking up the channel allocations of a product
+type GetChannelAllocationsExecutor interface {
+	Execute(ctx context.Context, productID uuid.UUID) (*usecase.ChannelAllocationsOutput, error)
+}
+
+// SetChannelAllocationsExecutor interface for replacing the channel allocations of a product
+type SetChannelAllocationsExecutor interface {
+	Execute(ctx context.Context, input usecase.SetChannelAllocationsInput) (*usecase.ChannelAllocationsOutput, error)
+}
+
+// RebalanceChannelAllocationsExecutor interface for moving allocated stock between channels
+type RebalanceChannelAllocationsExecutor interface {
+	Execute(ctx context.Context, input usecase.RebalanceChannelAllocationsInput) (*usecase.ChannelAllocationsOutput, error)
+}
+
+// ChannelAllocationHandler handles the allocation of stock to sales channels
+type ChannelAllocationHandler struct {
+	getUC       GetChannelAllocationsExecutor
+	setUC       SetChannelAllocationsExecutor
+	rebalanceUC RebalanceChannelAllocationsExecutor
+}
+
+// NewChannelAllocationHandler creates a new ChannelAllocationHandler
+func NewChannelAllocationHandler(
+	getUC GetChannelAllocationsExecutor,
+	setUC SetChannelAllocationsExecutor,
+	rebalanceUC RebalanceChannelAllocationsExecutor,
+) *ChannelAllocationHandler {
+	if getUC == nil {
+		panic("getUC cannot be nil")
+	}
+	if setUC == nil {
+		panic("setUC cannot be nil")
+	}
+	if rebalanceUC == nil {
+		panic("rebalanceUC cannot be nil")
+	}
+
+	return &ChannelAllocationHandler{
+		getUC:       getUC,
+		setUC:       setUC,
+		rebalanceUC: rebalanceUC,
+	}
+}
+
+// ChannelAllocationRequest represents the stock set aside for a sales channel.
+// Quota applies to fixed allocations and Percent to percentage ones.
+type ChannelAllocationRequest struct {
+	Channel string `json:"channel" binding:"required"`
+	Mode    string `json:"mode" binding:"required"`
+	Quota   int    `json:"quota"`
+	Percent int    `json:"percent"`
+}
+
+// SetChannelAllocationsRequest represents the allocations of a product; an empty
+// list makes its whole stock a shared pool again
+type SetChannelAllocationsRequest struct {
+	Allocations []ChannelAllocationRequest `json:"allocations" binding:"dive"`
+}
+
+// RebalanceChannelAllocationsRequest represents units of quota, or percentage
+// points, moved from one channel to another; an empty channel is the shared pool
+type RebalanceChannelAllocationsRequest struct {
+	From     string `json:"from"`
+	To       string `json:"to"`
+	Quantity int    `json:"quantity" binding:"required,min=1"`
+}
+
+// ChannelStockResponse represents the stock a sales channel can sell
+type ChannelStockResponse struct {
+	Channel   string `json:"channel"`
+	Mode      string `json:"mode"`
+	Quota     int    `json:"quota,omitempty"`
+	Percent   int    `json:"percent,omitempty"`
+	Allocated int    `json:"allocated"`
+	Reserved  int    `json:"reserved"`
+	Available int    `json:"available"`
+}
+
+// SharedPoolResponse represents the stock not set aside for any channel
+type SharedPoolResponse struct {
+	Allocated int `json:"allocated"`
+	Reserved  int `json:"reserved"`
+	Available int `json:"available"`
+}
+
+// ChannelAllocationsResponse represents the channel allocations of a product
+type ChannelAllocationsResponse struct {
+	ProductID        string                 `json:"product_id"`
+	ChannelAllocated bool                   `json:"channel_allocated"`
+	Quantity         int                    `json:"quantity"`
+	Reserved         int                    `json:"reserved"`
+	Channels         []ChannelStockResponse `json:"channels"`
+	SharedPool       SharedPoolResponse     `json:"shared_pool"`
+}
+
+// GetChannelAllocations handles GET /admin/inventory/:productId/channels
+// @Summary Get the channel allocations of a product
+// @Description Returns the stock set aside for every sales channel and what every channel can still reserve.
+// @Tags Admin, Inventory
+// @Produce json
+// @Param productId path string true "Product ID (UUID)"
+// @Success 200 {object} ChannelAllocationsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/inventory/{productId}/channels [get]
+func (h *ChannelAllocationHandler) GetChannelAllocations(c *gin.Context) {
+	productID, ok := parseProductIDParam(c)
+	if !ok {
+		return
+	}
+
+	output, err := h.getUC.Execute(c.Request.Context(), productID)
+	if err != nil {
+		respondChannelAllocationError(c, err, "Failed to get channel allocations")
+		return
+	}
+
+	c.JSON(http.StatusOK, toChannelAllocationsResponse(output))
+}
+
+// SetChannelAllocations handles PUT /admin/inventory/:productId/channels
+// @Summary Replace the channel allocations of a product
+// @Description Sets aside stock per sales channel: a fixed quota of units, a percentage of the units in stock,
+// @Description or nothing for channels selling from the shared pool. Channels without an allocation, and
+// @Description reservations without a channel, reserve from the shared pool.
+// @Tags Admin, Inventory
+// @Accept json
+// @Produce json
+// @Param productId path string true "Product ID (UUID)"
+// @Param request body SetChannelAllocationsRequest true "Allocations"
+// @Success 200 {object} ChannelAllocationsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/inventory/{productId}/channels [put]
+func (h *ChannelAllocationHandler) SetChannelAllocations(c *gin.Context) {
+	productID, ok := parseProductIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req SetChannelAllocationsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	allocations := make([]usecase.ChannelAllocationInput, len(req.Allocations))
+	for i, allocation := range req.Allocations {
+		allocations[i] = usecase.ChannelAllocationInput{
+			Channel: allocation.Channel,
+			Mode:    allocation.Mode,
+			Quota:   allocation.Quota,
+			Percent: allocation.Percent,
+		}
+	}
+
+	output, err := h.setUC.Execute(c.Request.Context(), usecase.SetChannelAllocationsInput{
+		ProductID:   productID,
+		Allocations: allocations,
+	})
+	if err != nil {
+		respondChannelAllocationError(c, err, "Failed to set channel allocations")
+		return
+	}
+
+	c.JSON(http.StatusOK, toChannelAllocationsResponse(output))
+}
+
+// RebalanceChannelAllocations handles POST /admin/inventory/:productId/channels/rebalance
+// @Summary Move allocated stock between sales channels
+// @Description Moves units of quota, or percentage points, from one channel to another of the same mode.
+// @Description Leave from or to empty to shrink or grow a channel against the shared pool.
+// @Tags Admin, Inventory
+// @Accept json
+// @Produce json
+// @Param productId path string true "Product ID (UUID)"
+// @Param request body RebalanceChannelAllocationsRequest true "Move"
+// @Success 200 {object} ChannelAllocationsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/inventory/{productId}/channels/rebalance [post]
+func (h *ChannelAllocationHandler) RebalanceChannelAllocations(c *gin.Context) {
+	productID, ok := parseProductIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req RebalanceChannelAllocationsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	output, err := h.rebalanceUC.Execute(c.Request.Context(), usecase.RebalanceChannelAllocationsInput{
+		ProductID: productID,
+		From:      req.From,
+		To:        req.To,
+		Quantity:  req.Quantity,
+	})
+	if err != nil {
+		respondChannelAllocationError(c, err, "Failed to rebalance channel allocations")
+		return
+	}
+
+	c.JSON(http.StatusOK, toChannelAllocationsResponse(output))
+}
+
+func toChannelAllocationsResponse(output *usecase.ChannelAllocationsOutput) ChannelAllocationsResponse {
+	response := ChannelAllocationsResponse{
+		ProductID:        output.Item.ProductID.String(),
+		ChannelAllocated: output.Item.ChannelAllocated,
+		Quantity:         output.Item.Quantity,
+		Reserved:         output.Item.Reserved,
+		Channels:         make([]ChannelStockResponse, len(output.Allocations)),
+		SharedPool: SharedPoolResponse{
+			Allocated: output.Pool.Allocated,
+			Reserved:  output.Pool.Reserved,
+			Available: output.Pool.Available,
+		},
+	}
+	for i, allocation := range output.Allocations {
+		stock := output.Stocks[i]
+		response.Channels[i] = ChannelStockResponse{
+			Channel:   allocation.Channel,
+			Mode:      string(allocation.Mode),
+			Quota:     allocation.Quota,
+			Percent:   allocation.Percent,
+			Allocated: stock.Allocated,
+			Reserved:  stock.Reserved,
+			Available: stock.Available,
+		}
+	}
+	return response
+}
+
+// respondChannelAllocationError maps channel allocation errors to HTTP responses
+func respondChannelAllocationError(c *gin.Context, err error, message string) {
+	var domainErr *domainErrors.DomainError
+	switch {
+	case errors.Is(err, domainErrors.ErrInvalidAllocation) && errors.As(err, &domainErr):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_allocation", "message": domainErr.Error()})
+	case errors.Is(err, domainErrors.ErrInvalidChannel) && errors.As(err, &domainErr):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_channel", "message": domainErr.Error()})
+	case errors.Is(err, domainErrors.ErrInventoryItemNotFound):
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "product_not_found",
+			"message": "Product not found in inventory",
+		})
+	default:
+		_ = c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "internal_server_error",
+			"message": message,
+		})
+	}
+}
@@ -0,0 +1,221 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/application/usecase"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	domainErrors "github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+)
+
+// MockGetChannelAllocationsUseCase is a mock for GetChannelAllocationsExecutor
+type MockGetChannelAllocationsUseCase struct {
+	mock.Mock
+}
+
+func (m *MockGetChannelAllocationsUseCase) Execute(ctx context.Context, productID uuid.UUID) (*usecase.ChannelAllocationsOutput, error) {
+	args := m.Called(ctx, productID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.ChannelAllocationsOutput), args.Error(1)
+}
+
+// MockSetChannelAllocationsUseCase is a mock for SetChannelAllocationsExecutor
+type MockSetChannelAllocationsUseCase struct {
+	mock.Mock
+}
+
+func (m *MockSetChannelAllocationsUseCase) Execute(ctx context.Context, input usecase.SetChannelAllocationsInput) (*usecase.ChannelAllocationsOutput, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.ChannelAllocationsOutput), args.Error(1)
+}
+
+// MockRebalanceChannelAllocationsUseCase is a mock for RebalanceChannelAllocationsExecutor
+type MockRebalanceChannelAllocationsUseCase struct {
+	mock.Mock
+}
+
+func (m *MockRebalanceChannelAllocationsUseCase) Execute(
+	ctx context.Context,
+	input usecase.RebalanceChannelAllocationsInput,
+) (*usecase.ChannelAllocationsOutput, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.ChannelAllocationsOutput), args.Error(1)
+}
+
+type channelAllocationMocks struct {
+	get       *MockGetChannelAllocationsUseCase
+	set       *MockSetChannelAllocationsUseCase
+	rebalance *MockRebalanceChannelAllocationsUseCase
+}
+
+func setupChannelAllocationRouter() (*gin.Engine, *channelAllocationMocks) {
+	gin.SetMode(gin.TestMode)
+	m := &channelAllocationMocks{
+		get:       new(MockGetChannelAllocationsUseCase),
+		set:       new(MockSetChannelAllocationsUseCase),
+		rebalance: new(MockRebalanceChannelAllocationsUseCase),
+	}
+	h := NewChannelAllocationHandler(m.get, m.set, m.rebalance)
+	router := gin.New()
+	router.GET("/admin/inventory/:productId/channels", h.GetChannelAllocations)
+	router.PUT("/admin/inventory/:productId/channels", h.SetChannelAllocations)
+	router.POST("/admin/inventory/:productId/channels/rebalance", h.RebalanceChannelAllocations)
+	return router, m
+}
+
+// testChannelAllocations returns 100 units of productID with 20 set aside for
+// marketplace, 15 of them reserved, and web selling from the shared pool
+func testChannelAllocations(productID uuid.UUID) *usecase.ChannelAllocationsOutput {
+	item := &entity.InventoryItem{ID: uuid.New(), ProductID: productID, Quantity: 100, Reserved: 20, ChannelAllocated: true}
+	allocations := []entity.ChannelAllocation{
+		{InventoryItemID: item.ID, Channel: "marketplace", Mode: entity.AllocationFixed, Quota: 20},
+		{InventoryItemID: item.ID, Channel: "web", Mode: entity.AllocationShared},
+	}
+	stocks, pool := entity.ChannelStocks(item, allocations, map[string]int{"marketplace": 15, "web": 5})
+	return &usecase.ChannelAllocationsOutput{Item: item, Allocations: allocations, Stocks: stocks, Pool: pool}
+}
+
+func TestNewChannelAllocationHandler_NilUseCases_Panic(t *testing.T) {
+	_, m := setupChannelAllocationRouter()
+	assert.Panics(t, func() { NewChannelAllocationHandler(nil, m.set, m.rebalance) })
+	assert.Panics(t, func() { NewChannelAllocationHandler(m.get, nil, m.rebalance) })
+	assert.Panics(t, func() { NewChannelAllocationHandler(m.get, m.set, nil) })
+}
+
+func TestChannelAllocationHandler_GetChannelAllocations(t *testing.T) {
+	productID := uuid.New()
+	router, m := setupChannelAllocationRouter()
+	m.get.On("Execute", mock.Anything, productID).Return(testChannelAllocations(productID), nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/inventory/"+productID.String()+"/channels", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var response ChannelAllocationsResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, ChannelAllocationsResponse{
+		ProductID:        productID.String(),
+		ChannelAllocated: true,
+		Quantity:         100,
+		Reserved:         20,
+		Channels: []ChannelStockResponse{
+			{Channel: "marketplace", Mode: "fixed", Quota: 20, Allocated: 20, Reserved: 15, Available: 5},
+			{Channel: "web", Mode: "shared", Allocated: 80, Reserved: 5, Available: 75},
+		},
+		SharedPool: SharedPoolResponse{Allocated: 80, Reserved: 5, Available: 75},
+	}, response)
+}
+
+func TestChannelAllocationHandler_SetChannelAllocations(t *testing.T) {
+	productID := uuid.New()
+	path := "/admin/inventory/" + productID.String() + "/channels"
+
+	t.Run("should replace the allocations", func(t *testing.T) {
+		router, m := setupChannelAllocationRouter()
+		m.set.On("Execute", mock.Anything, usecase.SetChannelAllocationsInput{
+			ProductID: productID,
+			Allocations: []usecase.ChannelAllocationInput{
+				{Channel: "marketplace", Mode: "fixed", Quota: 20},
+				{Channel: "web", Mode: "shared"},
+			},
+		}).Return(testChannelAllocations(productID), nil)
+
+		body := `{"allocations":[{"channel":"marketplace","mode":"fixed","quota":20},{"channel":"web","mode":"shared"}]}`
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPut, path, strings.NewReader(body)))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var response ChannelAllocationsResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Len(t, response.Channels, 2)
+	})
+
+	tests := []struct {
+		name       string
+		path       string
+		body       string
+		ucErr      error
+		wantStatus int
+		wantError  string
+	}{
+		{"invalid product", "/admin/inventory/abc/channels", `{"allocations":[]}`, nil, http.StatusBadRequest, "invalid_product_id"},
+		{"missing mode", path, `{"allocations":[{"channel":"web"}]}`, nil, http.StatusBadRequest, "invalid_request"},
+		{"invalid allocation", path, `{"allocations":[]}`, domainErrors.ErrInvalidAllocation.WithDetails("percentages add up to 120"), http.StatusBadRequest, "add up to 120"},
+		{"invalid channel", path, `{"allocations":[]}`, domainErrors.ErrInvalidChannel.WithDetails("channel is required"), http.StatusBadRequest, "invalid_channel"},
+		{"no inventory", path, `{"allocations":[]}`, domainErrors.ErrInventoryItemNotFound, http.StatusNotFound, "product_not_found"},
+		{"database", path, `{"allocations":[]}`, errors.New("connection refused"), http.StatusInternalServerError, "internal_server_error"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, m := setupChannelAllocationRouter()
+			m.set.On("Execute", mock.Anything, mock.Anything).Return(nil, tt.ucErr)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodPut, tt.path, strings.NewReader(tt.body)))
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Contains(t, w.Body.String(), tt.wantError)
+		})
+	}
+}
+
+func TestChannelAllocationHandler_RebalanceChannelAllocations(t *testing.T) {
+	productID := uuid.New()
+	path := "/admin/inventory/" + productID.String() + "/channels/rebalance"
+
+	t.Run("should move the allocation", func(t *testing.T) {
+		router, m := setupChannelAllocationRouter()
+		m.rebalance.On("Execute", mock.Anything, usecase.RebalanceChannelAllocationsInput{
+			ProductID: productID,
+			From:      "marketplace",
+			Quantity:  5,
+		}).Return(testChannelAllocations(productID), nil)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, path, strings.NewReader(`{"from":"marketplace","quantity":5}`)))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("should reject a missing quantity", func(t *testing.T) {
+		router, m := setupChannelAllocationRouter()
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, path, strings.NewReader(`{"from":"marketplace"}`)))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		m.rebalance.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should reject invalid moves", func(t *testing.T) {
+		router, m := setupChannelAllocationRouter()
+		m.rebalance.On("Execute", mock.Anything, mock.Anything).
+			Return(nil, domainErrors.ErrInvalidAllocation.WithDetails("channel web sells from the shared pool"))
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, path, strings.NewReader(`{"from":"web","to":"marketplace","quantity":1}`)))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "invalid_allocation")
+	})
+}
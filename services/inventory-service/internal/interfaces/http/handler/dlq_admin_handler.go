@@ -2,12 +2,14 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
 
 	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/application/usecase"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/interfaces/http/middleware"
 )
 
 // ListDLQMessagesExecutor interface for listing DLQ messages
@@ -162,6 +164,7 @@ func (h *DLQAdminHandler) RetryMessage(c *gin.Context) {
 
 	output, err := h.retryMessageUC.Execute(c.Request.Context(), input)
 	if err != nil {
+		_ = c.Error(err)
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error":   "internal_server_error",
 			"message": "Failed to retry message",
@@ -170,8 +173,11 @@ func (h *DLQAdminHandler) RetryMessage(c *gin.Context) {
 	}
 
 	statusCode := http.StatusOK
-	if !output.Retried {
+	if output.Retried {
+		middleware.SetAuditAffectedIDs(c, output.MessageID)
+	} else {
 		statusCode = http.StatusBadRequest
+		_ = c.Error(errors.New(output.Message))
 	}
 
 	c.JSON(statusCode, RetryMessageResponse{
@@ -14,6 +14,7 @@ import (
 	"github.com/stretchr/testify/mock"
 
 	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/application/usecase"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/interfaces/http/middleware"
 )
 
 // MockListDLQMessagesUseCase mocks the list DLQ use case
@@ -289,3 +290,26 @@ func TestDLQAdminHandler_RetryMessage_InvalidID(t *testing.T) {
 
 	mockRetryUC.AssertExpectations(t)
 }
+
+func TestDLQAdminHandler_RetryMessage_ReportsAuditAffectedID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockRetryUC := new(MockRetryDLQMessageUseCase)
+	handler := NewDLQAdminHandler(new(MockListDLQMessagesUseCase), new(MockGetDLQCountUseCase), mockRetryUC)
+
+	messageID := uuid.New().String()
+	mockRetryUC.On("Execute", mock.Anything, usecase.RetryDLQMessageInput{MessageID: messageID}).
+		Return(&usecase.RetryDLQMessageOutput{MessageID: messageID, Retried: true}, nil)
+
+	var affectedIDs []string
+	router := gin.New()
+	router.POST("/admin/dlq/:id/retry", func(c *gin.Context) {
+		c.Next()
+		affectedIDs = c.GetStringSlice(middleware.AuditAffectedIDsKey)
+	}, handler.RetryMessage)
+
+	req, _ := http.NewRequest(http.MethodPost, "/admin/dlq/"+messageID+"/retry", nil)
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.Equal(t, []string{messageID}, affectedIDs)
+}
@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/infrastructure/health"
+)
+
+// ReadinessChecker interface for dependency injection
+type ReadinessChecker interface {
+	Check(ctx context.Context) *health.Report
+}
+
+// HealthHandler exposes liveness and readiness probes suitable for Kubernetes
+type HealthHandler struct {
+	checker ReadinessChecker
+	service string
+	version string
+}
+
+// NewHealthHandler creates a new health handler
+func NewHealthHandler(checker ReadinessChecker, service, version string) *HealthHandler {
+	if checker == nil {
+		panic("checker cannot be nil")
+	}
+
+	return &HealthHandler{
+		checker: checker,
+		service: service,
+		version: version,
+	}
+}
+
+// ReadinessResponse represents the response of the readiness probe
+type ReadinessResponse struct {
+	Status     health.Status            `json:"status"`
+	Service    string                   `json:"service"`
+	Version    string                   `json:"version"`
+	Timestamp  string                   `json:"timestamp"`
+	Components []health.ComponentResult `json:"components"`
+}
+
+// Live handles GET /health/live (and the legacy GET /health)
+// It only reports that the process is running and able to serve HTTP;
+// it never touches dependencies so a slow database cannot trigger a restart loop.
+func (h *HealthHandler) Live(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"status":    health.StatusOK,
+		"service":   h.service,
+		"version":   h.version,
+		"timestamp": time.Now().UTC().Format(time.RFC3339),
+	})
+}
+
+// Ready handles GET /health/ready
+// Returns 200 when all dependencies are ok or only optional ones failed (degraded),
+// and 503 when a critical dependency is unavailable.
+func (h *HealthHandler) Ready(c *gin.Context) {
+	report := h.checker.Check(c.Request.Context())
+
+	statusCode := http.StatusOK
+	if !report.IsReady() {
+		statusCode = http.StatusServiceUnavailable
+	}
+
+	c.JSON(statusCode, ReadinessResponse{
+		Status:     report.Status,
+		Service:    h.service,
+		Version:    h.version,
+		Timestamp:  report.CheckedAt.Format(time.RFC3339),
+		Components: report.Components,
+	})
+}
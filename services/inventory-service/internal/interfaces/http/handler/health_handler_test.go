@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/infrastructure/health"
+)
+
+// stubReadinessChecker returns a fixed report
+type stubReadinessChecker struct {
+	report *health.Report
+}
+
+func (s *stubReadinessChecker) Check(ctx context.Context) *health.Report {
+	return s.report
+}
+
+func setupHealthRouter(checker ReadinessChecker) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	h := NewHealthHandler(checker, "inventory-service", "0.1.0")
+	router.GET("/health/live", h.Live)
+	router.GET("/health/ready", h.Ready)
+	return router
+}
+
+func TestHealthHandler_Live(t *testing.T) {
+	router := setupHealthRouter(&stubReadinessChecker{})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/health/live", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "ok", response["status"])
+	assert.Equal(t, "inventory-service", response["service"])
+}
+
+func TestHealthHandler_Ready(t *testing.T) {
+	testCases := []struct {
+		name         string
+		status       health.Status
+		expectedCode int
+	}{
+		{"all dependencies ok", health.StatusOK, http.StatusOK},
+		{"optional dependency down", health.StatusDegraded, http.StatusOK},
+		{"critical dependency down", health.StatusUnavailable, http.StatusServiceUnavailable},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			checker := &stubReadinessChecker{report: &health.Report{
+				Status: tc.status,
+				Components: []health.ComponentResult{
+					{Name: "postgres", Status: tc.status, Critical: true, LatencyMs: 3},
+				},
+				CheckedAt: time.Now(),
+			}}
+			router := setupHealthRouter(checker)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/health/ready", nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedCode, w.Code)
+
+			var response ReadinessResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tc.status, response.Status)
+			require.Len(t, response.Components, 1)
+			assert.Equal(t, "postgres", response.Components[0].Name)
+			assert.Equal(t, int64(3), response.Components[0].LatencyMs)
+		})
+	}
+}
+
+func TestNewHealthHandler_PanicsOnNilChecker(t *testing.T) {
+	assert.Panics(t, func() {
+		NewHealthHandler(nil, "inventory-service", "0.1.0")
+	})
+}
@@ -2,10 +2,13 @@ package handler
 
 import (
 	goerrors "errors"
+	"math"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/application/usecase"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
 	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -55,7 +58,8 @@ func NewInventoryHandler(
 }
 
 // GetByProductID handles GET /api/inventory/:productId
-// It returns the stock availability for a specific product
+// It returns the stock availability for a specific product; ?channel= reports
+// what that sales channel can reserve of products allocated to channels
 func (h *InventoryHandler) GetByProductID(c *gin.Context) {
 	// Parse product ID from URL parameter
 	productIDStr := c.Param("productId")
@@ -72,6 +76,7 @@ func (h *InventoryHandler) GetByProductID(c *gin.Context) {
 	input := usecase.CheckAvailabilityInput{
 		ProductID: productID,
 		Quantity:  1,
+		Channel:   c.Query("channel"),
 	}
 
 	output, err := h.checkAvailability.Execute(c.Request.Context(), input)
@@ -81,13 +86,18 @@ func (h *InventoryHandler) GetByProductID(c *gin.Context) {
 	}
 
 	// Return success response
-	c.JSON(http.StatusOK, gin.H{
+	response := gin.H{
 		"product_id":         output.ProductID.String(),
 		"is_available":       output.IsAvailable,
 		"available_quantity": output.AvailableQuantity,
 		"total_stock":        output.TotalStock,
 		"reserved_quantity":  output.ReservedQuantity,
-	})
+	}
+	if len(output.Components) > 0 {
+		response["components"] = toComponentAvailabilityResponses(output.Components)
+	}
+	addChannel(response, output.Channel)
+	c.JSON(http.StatusOK, response)
 }
 
 // ReserveStockRequest represents the request body for reserving stock
@@ -95,6 +105,7 @@ type ReserveStockRequest struct {
 	ProductID string `json:"product_id" binding:"required"`
 	OrderID   string `json:"order_id" binding:"required"`
 	Quantity  int    `json:"quantity" binding:"required,min=1"`
+	Channel   string `json:"channel"` // Optional sales channel, e.g. "web" or "marketplace"
 }
 
 // ReserveStock handles POST /api/inventory/reserve
@@ -137,6 +148,7 @@ func (h *InventoryHandler) ReserveStock(c *gin.Context) {
 		OrderID:   orderID,
 		Quantity:  req.Quantity,
 		Duration:  nil, // Use default 15 minutes
+		Channel:   req.Channel,
 	}
 
 	output, err := h.reserveStock.Execute(c.Request.Context(), input)
@@ -146,14 +158,18 @@ func (h *InventoryHandler) ReserveStock(c *gin.Context) {
 	}
 
 	// Return success response with 201 Created
-	c.JSON(http.StatusCreated, gin.H{
+	response := gin.H{
 		"reservation_id":  output.ReservationID.String(),
 		"product_id":      output.ProductID.String(),
 		"order_id":        output.OrderID.String(),
 		"quantity":        output.Quantity,
 		"expires_at":      output.ExpiresAt.Format(time.RFC3339),
 		"remaining_stock": output.RemainingStock,
-	})
+	}
+	addSerials(response, output.Serials)
+	addComponents(response, output.Components)
+	addChannel(response, output.Channel)
+	c.JSON(http.StatusCreated, response)
 }
 
 // ConfirmReservation handles POST /api/inventory/confirm/:reservationId
@@ -182,13 +198,19 @@ func (h *InventoryHandler) ConfirmReservation(c *gin.Context) {
 	}
 
 	// Return success response
-	c.JSON(http.StatusOK, gin.H{
+	response := gin.H{
 		"reservation_id":     output.ReservationID.String(),
 		"order_id":           output.OrderID.String(),
 		"quantity_confirmed": output.QuantityConfirmed,
 		"final_stock":        output.FinalStock,
 		"reserved_stock":     output.ReservedStock,
-	})
+	}
+	addSerials(response, output.Serials)
+	addComponents(response, output.Components)
+	if output.Reservation != nil {
+		addChannel(response, output.Reservation.Channel)
+	}
+	c.JSON(http.StatusOK, response)
 }
 
 // ReleaseReservation handles DELETE /api/inventory/reserve/:reservationId
@@ -217,22 +239,62 @@ func (h *InventoryHandler) ReleaseReservation(c *gin.Context) {
 	}
 
 	// Return success response
-	c.JSON(http.StatusOK, gin.H{
+	response := gin.H{
 		"reservation_id":    output.ReservationID.String(),
 		"order_id":          output.OrderID.String(),
 		"quantity_released": output.QuantityReleased,
 		"available_stock":   output.AvailableStock,
 		"reserved_stock":    output.ReservedStock,
-	})
+	}
+	addSerials(response, output.Serials)
+	addComponents(response, output.Components)
+	if output.Reservation != nil {
+		addChannel(response, output.Reservation.Channel)
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// addSerials adds the serial numbers of serial-tracked products to a response
+func addSerials(response gin.H, serials []string) {
+	if len(serials) > 0 {
+		response["serials"] = serials
+	}
+}
+
+// addComponents adds the units of every component a bundle reservation takes to a response
+func addComponents(response gin.H, components []*entity.ReservationComponent) {
+	if len(components) > 0 {
+		response["components"] = toReservationComponentResponses(components)
+	}
+}
+
+// addChannel adds the sales channel of a reservation or availability check to a response
+func addChannel(response gin.H, channel string) {
+	if channel != "" {
+		response["channel"] = channel
+	}
 }
 
 // handleError maps domain errors to appropriate HTTP responses
 func (h *InventoryHandler) handleError(c *gin.Context, err error) {
+	respondInventoryError(c, err)
+}
+
+// respondInventoryError writes the HTTP response for an error returned by the
+// stock and reservation use cases
+func respondInventoryError(c *gin.Context, err error) {
 	var statusCode int
 	var errorCode string
 	var message string
 
+	var contention *usecase.ContentionError
 	switch {
+	case goerrors.As(err, &contention):
+		// Retries were exhausted; tell the client when it is worth trying again
+		c.Header("Retry-After", strconv.Itoa(retryAfterSeconds(contention.RetryAfter)))
+		statusCode = http.StatusConflict
+		errorCode = "concurrent_modification"
+		message = "Inventory is being modified concurrently, please retry"
 	case goerrors.Is(err, errors.ErrInventoryItemNotFound):
 		statusCode = http.StatusNotFound
 		errorCode = "product_not_found"
@@ -245,10 +307,39 @@ func (h *InventoryHandler) handleError(c *gin.Context, err error) {
 		statusCode = http.StatusBadRequest
 		errorCode = "invalid_quantity"
 		message = "Invalid quantity specified"
+	case goerrors.Is(err, errors.ErrInvalidChannel):
+		statusCode = http.StatusBadRequest
+		errorCode = "invalid_channel"
+		message = "Channel may only contain lowercase letters, digits, '-' and '_'"
+	case goerrors.Is(err, errors.ErrInvalidUserID):
+		statusCode = http.StatusBadRequest
+		errorCode = "invalid_user_id"
+		message = "User ID must be 1 to 128 printable characters without spaces"
+	case goerrors.Is(err, errors.ErrPurchaseLimitExceeded):
+		// The details tell the customer how much of the product they can still buy
+		statusCode = http.StatusUnprocessableEntity
+		errorCode = "purchase_limit_exceeded"
+		message = err.Error()
 	case goerrors.Is(err, errors.ErrInsufficientStock):
 		statusCode = http.StatusConflict
 		errorCode = "insufficient_stock"
 		message = "Insufficient stock available"
+	case goerrors.Is(err, errors.ErrInventoryItemArchived):
+		statusCode = http.StatusConflict
+		errorCode = "product_archived"
+		message = "Product is no longer active in the catalog"
+	case goerrors.Is(err, errors.ErrSerialTrackedItem):
+		statusCode = http.StatusConflict
+		errorCode = "serial_tracked_item"
+		message = "Product stock is tracked per serial unit"
+	case goerrors.Is(err, errors.ErrBundleItem):
+		statusCode = http.StatusConflict
+		errorCode = "bundle_item"
+		message = "Product is a bundle; its stock is the stock of its components"
+	case goerrors.Is(err, errors.ErrChannelAllocatedItem):
+		statusCode = http.StatusConflict
+		errorCode = "channel_allocated_item"
+		message = "Product stock is allocated per sales channel"
 	case goerrors.Is(err, errors.ErrReservationNotPending):
 		statusCode = http.StatusConflict
 		errorCode = "reservation_not_pending"
@@ -268,3 +359,8 @@ func (h *InventoryHandler) handleError(c *gin.Context, err error) {
 		"message": message,
 	})
 }
+
+// retryAfterSeconds rounds a retry hint up to whole seconds, as Retry-After requires
+func retryAfterSeconds(d time.Duration) int {
+	return max(1, int(math.Ceil(d.Seconds())))
+}
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/application/usecase"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
 	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
 	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/interfaces/http/handler"
 	"github.com/gin-gonic/gin"
@@ -240,6 +241,7 @@ func TestReserveStock_Success(t *testing.T) {
 	assert.Equal(t, float64(5), response["quantity"])
 	assert.Equal(t, float64(95), response["remaining_stock"])
 	assert.NotEmpty(t, response["expires_at"])
+	assert.NotContains(t, response, "serials", "only serial-tracked products have serials")
 
 	mockReserveUseCase.AssertExpectations(t)
 }
@@ -356,6 +358,299 @@ func TestReserveStock_InsufficientStock(t *testing.T) {
 	mockReserveUseCase.AssertExpectations(t)
 }
 
+func TestReserveStock_ArchivedProduct(t *testing.T) {
+	router := setupRouter()
+	mockReserveUseCase := new(MockReserveStockUseCase)
+	h := handler.NewInventoryHandler(nil, mockReserveUseCase, nil, nil)
+	mockReserveUseCase.On("Execute", mock.Anything, mock.Anything).Return(nil, errors.ErrInventoryItemArchived)
+	router.POST("/api/inventory/reserve", h.ReserveStock)
+
+	bodyBytes, _ := json.Marshal(map[string]interface{}{
+		"product_id": uuid.New().String(),
+		"order_id":   uuid.New().String(),
+		"quantity":   1,
+	})
+	req := httptest.NewRequest(http.MethodPost, "/api/inventory/reserve", bytes.NewBuffer(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	var response map[string]interface{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "product_archived", response["error"])
+}
+
+func TestReserveStock_SerialTrackedProduct(t *testing.T) {
+	router := setupRouter()
+	mockReserveUseCase := new(MockReserveStockUseCase)
+	h := handler.NewInventoryHandler(nil, mockReserveUseCase, nil, nil)
+	productID := uuid.New()
+	mockReserveUseCase.On("Execute", mock.Anything, mock.MatchedBy(func(input usecase.ReserveStockInput) bool {
+		return input.Quantity == 2
+	})).Return(&usecase.ReserveStockOutput{
+		ReservationID: uuid.New(),
+		ProductID:     productID,
+		OrderID:       uuid.New(),
+		Quantity:      2,
+		Serials:       []string{"SN-1", "SN-2"},
+	}, nil)
+	mockReserveUseCase.On("Execute", mock.Anything, mock.Anything).Return(nil, errors.ErrSerialTrackedItem)
+	router.POST("/api/inventory/reserve", h.ReserveStock)
+
+	reserve := func(quantity int) *httptest.ResponseRecorder {
+		bodyBytes, _ := json.Marshal(map[string]interface{}{
+			"product_id": productID.String(),
+			"order_id":   uuid.New().String(),
+			"quantity":   quantity,
+		})
+		req := httptest.NewRequest(http.MethodPost, "/api/inventory/reserve", bytes.NewBuffer(bodyBytes))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := reserve(2)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var response map[string]interface{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, []interface{}{"SN-1", "SN-2"}, response["serials"])
+
+	w = reserve(1)
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "serial_tracked_item", response["error"])
+}
+
+func TestGetInventoryByProductID_Bundle(t *testing.T) {
+	router := setupRouter()
+	mockUseCase := new(MockCheckAvailabilityUseCase)
+	h := handler.NewInventoryHandler(mockUseCase, nil, nil, nil)
+	productID, componentID := uuid.New(), uuid.New()
+	mockUseCase.On("Execute", mock.Anything, mock.Anything).Return(&usecase.CheckAvailabilityOutput{
+		ProductID:         productID,
+		IsAvailable:       true,
+		AvailableQuantity: 3,
+		TotalStock:        4,
+		ReservedQuantity:  1,
+		Components: []usecase.ComponentAvailability{
+			{ProductID: componentID, Quantity: 2, AvailableQuantity: 7, BundlesAvailable: 3},
+		},
+	}, nil)
+	router.GET("/api/inventory/:productId", h.GetByProductID)
+
+	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/inventory/%s", productID.String()), nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response map[string]interface{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, []interface{}{map[string]interface{}{
+		"product_id":         componentID.String(),
+		"quantity":           float64(2),
+		"available_quantity": float64(7),
+		"bundles_available":  float64(3),
+	}}, response["components"])
+}
+
+func TestReserveStock_BundleProduct(t *testing.T) {
+	router := setupRouter()
+	mockReserveUseCase := new(MockReserveStockUseCase)
+	h := handler.NewInventoryHandler(nil, mockReserveUseCase, nil, nil)
+	productID, componentID := uuid.New(), uuid.New()
+	mockReserveUseCase.On("Execute", mock.Anything, mock.MatchedBy(func(input usecase.ReserveStockInput) bool {
+		return input.Quantity == 2
+	})).Return(&usecase.ReserveStockOutput{
+		ReservationID: uuid.New(),
+		ProductID:     productID,
+		OrderID:       uuid.New(),
+		Quantity:      2,
+		Components: []*entity.ReservationComponent{
+			{ProductID: componentID, Quantity: 4, Status: entity.ComponentReserved},
+		},
+	}, nil)
+	mockReserveUseCase.On("Execute", mock.Anything, mock.Anything).Return(nil, errors.ErrBundleItem)
+	router.POST("/api/inventory/reserve", h.ReserveStock)
+
+	reserve := func(quantity int) *httptest.ResponseRecorder {
+		bodyBytes, _ := json.Marshal(map[string]interface{}{
+			"product_id": productID.String(),
+			"order_id":   uuid.New().String(),
+			"quantity":   quantity,
+		})
+		req := httptest.NewRequest(http.MethodPost, "/api/inventory/reserve", bytes.NewBuffer(bodyBytes))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := reserve(2)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var response map[string]interface{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, []interface{}{map[string]interface{}{
+		"product_id": componentID.String(),
+		"quantity":   float64(4),
+		"status":     "reserved",
+	}}, response["components"])
+
+	w = reserve(1)
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "bundle_item", response["error"])
+}
+
+func TestGetInventoryByProductID_Channel(t *testing.T) {
+	router := setupRouter()
+	mockUseCase := new(MockCheckAvailabilityUseCase)
+	h := handler.NewInventoryHandler(mockUseCase, nil, nil, nil)
+	productID := uuid.New()
+	mockUseCase.On("Execute", mock.Anything, usecase.CheckAvailabilityInput{
+		ProductID: productID,
+		Quantity:  1,
+		Channel:   "marketplace",
+	}).Return(&usecase.CheckAvailabilityOutput{
+		ProductID:         productID,
+		IsAvailable:       true,
+		AvailableQuantity: 5,
+		TotalStock:        100,
+		ReservedQuantity:  30,
+		Channel:           "marketplace",
+	}, nil)
+	mockUseCase.On("Execute", mock.Anything, mock.Anything).Return(nil, errors.ErrInvalidChannel)
+	router.GET("/api/inventory/:productId", h.GetByProductID)
+
+	get := func(channel string) (*httptest.ResponseRecorder, map[string]interface{}) {
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/inventory/%s?channel=%s", productID.String(), channel), nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		var response map[string]interface{}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return w, response
+	}
+
+	w, response := get("marketplace")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, float64(5), response["available_quantity"])
+	assert.Equal(t, "marketplace", response["channel"])
+
+	w, response = get("Market")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, "invalid_channel", response["error"])
+}
+
+func TestReserveStock_Channel(t *testing.T) {
+	router := setupRouter()
+	mockReserveUseCase := new(MockReserveStockUseCase)
+	h := handler.NewInventoryHandler(nil, mockReserveUseCase, nil, nil)
+	productID := uuid.New()
+	mockReserveUseCase.On("Execute", mock.Anything, mock.MatchedBy(func(input usecase.ReserveStockInput) bool {
+		return input.Channel == "marketplace" && input.Quantity == 2
+	})).Return(&usecase.ReserveStockOutput{
+		ReservationID:  uuid.New(),
+		ProductID:      productID,
+		OrderID:        uuid.New(),
+		Quantity:       2,
+		RemainingStock: 3,
+		Channel:        "marketplace",
+	}, nil)
+	mockReserveUseCase.On("Execute", mock.Anything, mock.Anything).Return(nil, errors.ErrChannelAllocatedItem)
+	router.POST("/api/inventory/reserve", h.ReserveStock)
+
+	reserve := func(quantity int) (*httptest.ResponseRecorder, map[string]interface{}) {
+		bodyBytes, _ := json.Marshal(map[string]interface{}{
+			"product_id": productID.String(),
+			"order_id":   uuid.New().String(),
+			"quantity":   quantity,
+			"channel":    "marketplace",
+		})
+		req := httptest.NewRequest(http.MethodPost, "/api/inventory/reserve", bytes.NewBuffer(bodyBytes))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		var response map[string]interface{}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return w, response
+	}
+
+	w, response := reserve(2)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, "marketplace", response["channel"])
+
+	w, response = reserve(1)
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Equal(t, "channel_allocated_item", response["error"])
+}
+
+func TestReserveStock_PurchaseLimitExceeded(t *testing.T) {
+	router := setupRouter()
+	mockReserveUseCase := new(MockReserveStockUseCase)
+	h := handler.NewInventoryHandler(nil, mockReserveUseCase, nil, nil)
+	mockReserveUseCase.On("Execute", mock.Anything, mock.Anything).
+		Return(nil, errors.ErrPurchaseLimitExceeded.WithDetails("at most 2 per order, requested 3"))
+	router.POST("/api/inventory/reserve", h.ReserveStock)
+
+	bodyBytes, _ := json.Marshal(map[string]interface{}{
+		"product_id": uuid.New().String(),
+		"order_id":   uuid.New().String(),
+		"quantity":   3,
+	})
+	req := httptest.NewRequest(http.MethodPost, "/api/inventory/reserve", bytes.NewBuffer(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	var response map[string]interface{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "purchase_limit_exceeded", response["error"])
+	assert.Contains(t, response["message"], "at most 2 per order")
+}
+
+func TestReserveStock_ConcurrentModification(t *testing.T) {
+	// Arrange
+	router := setupRouter()
+	mockReserveUseCase := new(MockReserveStockUseCase)
+	h := handler.NewInventoryHandler(nil, mockReserveUseCase, nil, nil)
+
+	contention := &usecase.ContentionError{
+		Operation:  usecase.OperationReserve,
+		ProductID:  uuid.New(),
+		Attempts:   5,
+		RetryAfter: 1500 * time.Millisecond,
+	}
+	mockReserveUseCase.On("Execute", mock.Anything, mock.Anything).Return(nil, contention)
+
+	router.POST("/api/inventory/reserve", h.ReserveStock)
+
+	// Act
+	requestBody := map[string]interface{}{
+		"product_id": uuid.New().String(),
+		"order_id":   uuid.New().String(),
+		"quantity":   1,
+	}
+	bodyBytes, _ := json.Marshal(requestBody)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/inventory/reserve", bytes.NewBuffer(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Equal(t, "2", w.Header().Get("Retry-After"))
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "concurrent_modification", response["error"])
+
+	mockReserveUseCase.AssertExpectations(t)
+}
+
 func TestReserveStock_ProductNotFound(t *testing.T) {
 	// Arrange
 	router := setupRouter()
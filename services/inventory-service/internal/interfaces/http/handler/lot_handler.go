@@ -0,0 +1,324 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/application/usecase"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	domainErrors "github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+)
+
+// ReceiveLotExecutor interface for receiving lots
+type ReceiveLotExecutor interface {
+	Execute(ctx context.Context, input usecase.ReceiveLotInput) (*usecase.ReceiveLotOutput, error)
+}
+
+// ListLotsExecutor interface for listing the lots of a product
+type ListLotsExecutor interface {
+	Execute(ctx context.Context, productID uuid.UUID) ([]*entity.Lot, error)
+}
+
+// GetReservationLotsExecutor interface for the lots taken by a reservation
+type GetReservationLotsExecutor interface {
+	Execute(ctx context.Context, reservationID uuid.UUID) ([]*entity.LotAllocation, error)
+}
+
+// LotHandler handles lot receipts and lot lookups
+type LotHandler struct {
+	receiveLotUC         ReceiveLotExecutor
+	listLotsUC           ListLotsExecutor
+	getReservationLotsUC GetReservationLotsExecutor
+	now                  func() time.Time
+}
+
+// NewLotHandler creates a new LotHandler
+func NewLotHandler(receiveLotUC ReceiveLotExecutor, listLotsUC ListLotsExecutor, getReservationLotsUC GetReservationLotsExecutor) *LotHandler {
+	if receiveLotUC == nil {
+		panic("receiveLotUC cannot be nil")
+	}
+	if listLotsUC == nil {
+		panic("listLotsUC cannot be nil")
+	}
+	if getReservationLotsUC == nil {
+		panic("getReservationLotsUC cannot be nil")
+	}
+
+	return &LotHandler{
+		receiveLotUC:         receiveLotUC,
+		listLotsUC:           listLotsUC,
+		getReservationLotsUC: getReservationLotsUC,
+		now:                  time.Now,
+	}
+}
+
+// ReceiveLotRequest represents a lot of goods received for a product
+type ReceiveLotRequest struct {
+	LotNumber  string     `json:"lot_number" binding:"required"`
+	Quantity   int        `json:"quantity" binding:"required"`
+	ReceivedAt *time.Time `json:"received_at,omitempty"` // RFC3339, defaults to now
+	BestBefore string     `json:"best_before,omitempty"` // YYYY-MM-DD, omitted if the goods do not expire
+}
+
+// LotResponse represents a lot of an inventory item
+type LotResponse struct {
+	ID            string     `json:"id"`
+	LotNumber     string     `json:"lot_number"`
+	ReceivedAt    time.Time  `json:"received_at"`
+	BestBefore    string     `json:"best_before,omitempty"`
+	Quantity      int        `json:"quantity"`
+	Reserved      int        `json:"reserved"`
+	Available     int        `json:"available"`
+	Quarantined   int        `json:"quarantined"`
+	QuarantinedAt *time.Time `json:"quarantined_at,omitempty"`
+	Expired       bool       `json:"expired"`
+}
+
+// ReceiveLotResponse represents a received lot and the stock of its product afterwards
+type ReceiveLotResponse struct {
+	ProductID string      `json:"product_id"`
+	Lot       LotResponse `json:"lot"`
+	Quantity  int         `json:"quantity"`
+	Reserved  int         `json:"reserved"`
+	Available int         `json:"available"`
+}
+
+// ListLotsResponse represents the lots of a product
+type ListLotsResponse struct {
+	ProductID string        `json:"product_id"`
+	Lots      []LotResponse `json:"lots"`
+}
+
+// LotAllocationResponse represents units of a lot taken by a reservation
+type LotAllocationResponse struct {
+	LotID       string    `json:"lot_id"`
+	LotNumber   string    `json:"lot_number"`
+	BestBefore  string    `json:"best_before,omitempty"`
+	Quantity    int       `json:"quantity"`
+	Status      string    `json:"status"`
+	AllocatedAt time.Time `json:"allocated_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// ReservationLotsResponse represents the lots taken by a reservation
+type ReservationLotsResponse struct {
+	ReservationID string                  `json:"reservation_id"`
+	Lots          []LotAllocationResponse `json:"lots"`
+}
+
+// ReceiveLot handles POST /admin/inventory/:productId/lots
+// @Summary Receive a lot
+// @Description Records a lot of goods received for a product and adds its units to the stock.
+// @Tags Admin, Inventory
+// @Accept json
+// @Produce json
+// @Param productId path string true "Product ID (UUID)"
+// @Param request body ReceiveLotRequest true "Lot"
+// @Success 201 {object} ReceiveLotResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /admin/inventory/{productId}/lots [post]
+func (h *LotHandler) ReceiveLot(c *gin.Context) {
+	productID, ok := parseProductIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req ReceiveLotRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	input := usecase.ReceiveLotInput{
+		ProductID: productID,
+		LotNumber: req.LotNumber,
+		Quantity:  req.Quantity,
+	}
+	if req.ReceivedAt != nil {
+		input.ReceivedAt = *req.ReceivedAt
+	}
+	if req.BestBefore != "" {
+		bestBefore, err := time.Parse(time.DateOnly, req.BestBefore)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "invalid_request",
+				"message": "best_before must be a date (YYYY-MM-DD)",
+			})
+			return
+		}
+		input.BestBefore = &bestBefore
+	}
+
+	output, err := h.receiveLotUC.Execute(c.Request.Context(), input)
+	if err != nil {
+		respondLotError(c, err, "Failed to receive lot")
+		return
+	}
+
+	c.JSON(http.StatusCreated, ReceiveLotResponse{
+		ProductID: productID.String(),
+		Lot:       h.toLotResponse(output.Lot),
+		Quantity:  output.Item.Quantity,
+		Reserved:  output.Item.Reserved,
+		Available: output.Item.Available(),
+	})
+}
+
+// ListLots handles GET /admin/inventory/:productId/lots
+// @Summary List the lots of a product
+// @Description Returns every lot of the product in first-expired-first-out order, empty and quarantined lots included.
+// @Tags Admin, Inventory
+// @Produce json
+// @Param productId path string true "Product ID (UUID)"
+// @Success 200 {object} ListLotsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/inventory/{productId}/lots [get]
+func (h *LotHandler) ListLots(c *gin.Context) {
+	productID, ok := parseProductIDParam(c)
+	if !ok {
+		return
+	}
+
+	lots, err := h.listLotsUC.Execute(c.Request.Context(), productID)
+	if err != nil {
+		respondLotError(c, err, "Failed to list lots")
+		return
+	}
+
+	response := ListLotsResponse{ProductID: productID.String(), Lots: make([]LotResponse, len(lots))}
+	for i, lot := range lots {
+		response.Lots[i] = h.toLotResponse(lot)
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// GetReservationLots handles GET /admin/reservations/:id/lots
+// @Summary Get the lots taken by a reservation
+// @Description Returns the lots allocated to the reservation, and whether they were consumed by its
+// @Description confirmation or released. Units reserved from stock outside any lot are not listed.
+// @Tags Admin, Reservations
+// @Produce json
+// @Param id path string true "Reservation ID (UUID)"
+// @Success 200 {object} ReservationLotsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/reservations/{id}/lots [get]
+func (h *LotHandler) GetReservationLots(c *gin.Context) {
+	reservationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_reservation_id",
+			"message": "Invalid reservation ID format. Expected UUID.",
+		})
+		return
+	}
+
+	allocations, err := h.getReservationLotsUC.Execute(c.Request.Context(), reservationID)
+	if err != nil {
+		respondLotError(c, err, "Failed to get reservation lots")
+		return
+	}
+
+	response := ReservationLotsResponse{ReservationID: reservationID.String(), Lots: make([]LotAllocationResponse, len(allocations))}
+	for i, allocation := range allocations {
+		response.Lots[i] = LotAllocationResponse{
+			LotID:       allocation.LotID.String(),
+			LotNumber:   allocation.LotNumber,
+			BestBefore:  formatDate(allocation.BestBefore),
+			Quantity:    allocation.Quantity,
+			Status:      string(allocation.Status),
+			AllocatedAt: allocation.AllocatedAt,
+			UpdatedAt:   allocation.UpdatedAt,
+		}
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *LotHandler) toLotResponse(lot *entity.Lot) LotResponse {
+	return LotResponse{
+		ID:            lot.ID.String(),
+		LotNumber:     lot.LotNumber,
+		ReceivedAt:    lot.ReceivedAt,
+		BestBefore:    formatDate(lot.BestBefore),
+		Quantity:      lot.Quantity,
+		Reserved:      lot.Reserved,
+		Available:     lot.Available(),
+		Quarantined:   lot.Quarantined,
+		QuarantinedAt: lot.QuarantinedAt,
+		Expired:       lot.IsExpired(h.now()),
+	}
+}
+
+// formatDate formats an optional date as YYYY-MM-DD, or "" when it is nil
+func formatDate(date *time.Time) string {
+	if date == nil {
+		return ""
+	}
+	return date.Format(time.DateOnly)
+}
+
+// parseProductIDParam parses the productId path parameter, answering 400 when it is not a UUID
+func parseProductIDParam(c *gin.Context) (uuid.UUID, bool) {
+	productID, err := uuid.Parse(c.Param("productId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_product_id",
+			"message": "Invalid product ID format. Expected UUID.",
+		})
+		return uuid.Nil, false
+	}
+	return productID, true
+}
+
+// respondLotError maps lot errors to HTTP responses
+func respondLotError(c *gin.Context, err error, message string) {
+	var domainErr *domainErrors.DomainError
+	switch {
+	case errors.Is(err, domainErrors.ErrInvalidInput) && errors.As(err, &domainErr):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_lot", "message": domainErr.Details})
+	case errors.Is(err, domainErrors.ErrInvalidQuantity):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_lot", "message": "quantity must be greater than zero"})
+	case errors.Is(err, domainErrors.ErrInventoryItemNotFound):
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "product_not_found",
+			"message": "Product has no inventory",
+		})
+	case errors.Is(err, domainErrors.ErrReservationNotFound):
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "reservation_not_found",
+			"message": "Reservation not found",
+		})
+	case errors.Is(err, domainErrors.ErrLotAlreadyExists):
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "lot_already_exists",
+			"message": "The product already has a lot with this number",
+		})
+	case errors.Is(err, domainErrors.ErrSerialTrackedItem):
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "serial_tracked_item",
+			"message": "Product stock is tracked per serial unit",
+		})
+	case errors.Is(err, domainErrors.ErrBundleItem):
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "bundle_item",
+			"message": "Product is a bundle; its stock is the stock of its components",
+		})
+	default:
+		_ = c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "internal_server_error",
+			"message": message,
+		})
+	}
+}
@@ -0,0 +1,217 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/application/usecase"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	domainErrors "github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+)
+
+// MockReceiveLotUseCase is a mock for ReceiveLotExecutor
+type MockReceiveLotUseCase struct {
+	mock.Mock
+}
+
+func (m *MockReceiveLotUseCase) Execute(ctx context.Context, input usecase.ReceiveLotInput) (*usecase.ReceiveLotOutput, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.ReceiveLotOutput), args.Error(1)
+}
+
+// MockListLotsUseCase is a mock for ListLotsExecutor
+type MockListLotsUseCase struct {
+	mock.Mock
+}
+
+func (m *MockListLotsUseCase) Execute(ctx context.Context, productID uuid.UUID) ([]*entity.Lot, error) {
+	args := m.Called(ctx, productID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Lot), args.Error(1)
+}
+
+// MockGetReservationLotsUseCase is a mock for GetReservationLotsExecutor
+type MockGetReservationLotsUseCase struct {
+	mock.Mock
+}
+
+func (m *MockGetReservationLotsUseCase) Execute(ctx context.Context, reservationID uuid.UUID) ([]*entity.LotAllocation, error) {
+	args := m.Called(ctx, reservationID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.LotAllocation), args.Error(1)
+}
+
+var lotHandlerNow = time.Date(2025, 12, 10, 9, 0, 0, 0, time.UTC)
+
+func setupLotRouter(receiveUC *MockReceiveLotUseCase, listUC *MockListLotsUseCase, reservationUC *MockGetReservationLotsUseCase) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	h := NewLotHandler(receiveUC, listUC, reservationUC)
+	h.now = func() time.Time { return lotHandlerNow }
+	router := gin.New()
+	router.POST("/admin/inventory/:productId/lots", h.ReceiveLot)
+	router.GET("/admin/inventory/:productId/lots", h.ListLots)
+	router.GET("/admin/reservations/:id/lots", h.GetReservationLots)
+	return router
+}
+
+func TestNewLotHandler_NilUseCases_Panic(t *testing.T) {
+	assert.Panics(t, func() { NewLotHandler(nil, new(MockListLotsUseCase), new(MockGetReservationLotsUseCase)) })
+	assert.Panics(t, func() { NewLotHandler(new(MockReceiveLotUseCase), nil, new(MockGetReservationLotsUseCase)) })
+	assert.Panics(t, func() { NewLotHandler(new(MockReceiveLotUseCase), new(MockListLotsUseCase), nil) })
+}
+
+func TestLotHandler_ReceiveLot(t *testing.T) {
+	receiveUC := new(MockReceiveLotUseCase)
+	productID := uuid.New()
+	receivedAt := time.Date(2025, 12, 1, 8, 0, 0, 0, time.UTC)
+	bestBefore := time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)
+	item, _ := entity.NewInventoryItem(productID, 34)
+	require.NoError(t, item.Reserve(4))
+	lot := &entity.Lot{ID: uuid.New(), LotNumber: "L-7", ReceivedAt: receivedAt, BestBefore: &bestBefore, Quantity: 24}
+	receiveUC.On("Execute", mock.Anything, mock.MatchedBy(func(input usecase.ReceiveLotInput) bool {
+		return input.ProductID == productID && input.LotNumber == "L-7" && input.Quantity == 24 &&
+			input.ReceivedAt.Equal(receivedAt) && input.BestBefore.Equal(bestBefore)
+	})).Return(&usecase.ReceiveLotOutput{Lot: lot, Item: item}, nil)
+	router := setupLotRouter(receiveUC, new(MockListLotsUseCase), new(MockGetReservationLotsUseCase))
+
+	body := `{"lot_number":"L-7","quantity":24,"received_at":"2025-12-01T08:00:00Z","best_before":"2026-03-31"}`
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/admin/inventory/"+productID.String()+"/lots", strings.NewReader(body)))
+
+	require.Equal(t, http.StatusCreated, w.Code)
+	var response ReceiveLotResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, productID.String(), response.ProductID)
+	assert.Equal(t, "2026-03-31", response.Lot.BestBefore)
+	assert.Equal(t, 24, response.Lot.Available)
+	assert.False(t, response.Lot.Expired)
+	assert.Equal(t, 34, response.Quantity)
+	assert.Equal(t, 30, response.Available)
+}
+
+func TestLotHandler_ReceiveLot_Errors(t *testing.T) {
+	productID := uuid.New().String()
+	valid := `{"lot_number":"L-1","quantity":5}`
+	tests := []struct {
+		name       string
+		path       string
+		body       string
+		ucErr      error
+		wantStatus int
+		wantError  string
+	}{
+		{"invalid product", "/admin/inventory/abc/lots", valid, nil, http.StatusBadRequest, "invalid_product_id"},
+		{"missing lot number", "/admin/inventory/" + productID + "/lots", `{"quantity":5}`, nil, http.StatusBadRequest, "invalid_request"},
+		{"malformed best before", "/admin/inventory/" + productID + "/lots", `{"lot_number":"L-1","quantity":5,"best_before":"31/03/2026"}`, nil, http.StatusBadRequest, "invalid_request"},
+		{"invalid lot", "/admin/inventory/" + productID + "/lots", valid, domainErrors.ErrInvalidInput.WithDetails("best_before must not be before received_at"), http.StatusBadRequest, "invalid_lot"},
+		{"negative quantity", "/admin/inventory/" + productID + "/lots", valid, domainErrors.ErrInvalidQuantity, http.StatusBadRequest, "invalid_lot"},
+		{"no inventory", "/admin/inventory/" + productID + "/lots", valid, domainErrors.ErrInventoryItemNotFound, http.StatusNotFound, "product_not_found"},
+		{"duplicate", "/admin/inventory/" + productID + "/lots", valid, domainErrors.ErrLotAlreadyExists, http.StatusConflict, "lot_already_exists"},
+		{"bundle", "/admin/inventory/" + productID + "/lots", valid, domainErrors.ErrBundleItem, http.StatusConflict, "bundle_item"},
+		{"database", "/admin/inventory/" + productID + "/lots", valid, errors.New("connection refused"), http.StatusInternalServerError, "internal_server_error"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			receiveUC := new(MockReceiveLotUseCase)
+			receiveUC.On("Execute", mock.Anything, mock.Anything).Return(nil, tt.ucErr)
+			router := setupLotRouter(receiveUC, new(MockListLotsUseCase), new(MockGetReservationLotsUseCase))
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body)))
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Contains(t, w.Body.String(), `"error":"`+tt.wantError+`"`)
+			if tt.ucErr == nil {
+				receiveUC.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestLotHandler_ListLots(t *testing.T) {
+	listUC := new(MockListLotsUseCase)
+	productID := uuid.New()
+	expired := time.Date(2025, 12, 9, 0, 0, 0, 0, time.UTC)
+	quarantinedAt := lotHandlerNow.Add(-time.Hour)
+	listUC.On("Execute", mock.Anything, productID).Return([]*entity.Lot{
+		{ID: uuid.New(), LotNumber: "OLD", BestBefore: &expired, Quantity: 2, Reserved: 2, Quarantined: 8, QuarantinedAt: &quarantinedAt},
+		{ID: uuid.New(), LotNumber: "NOEXP", Quantity: 10, Reserved: 3},
+	}, nil)
+	router := setupLotRouter(new(MockReceiveLotUseCase), listUC, new(MockGetReservationLotsUseCase))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/inventory/"+productID.String()+"/lots", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var response ListLotsResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response.Lots, 2)
+	assert.True(t, response.Lots[0].Expired)
+	assert.Equal(t, 8, response.Lots[0].Quarantined)
+	assert.Equal(t, "", response.Lots[1].BestBefore)
+	assert.False(t, response.Lots[1].Expired)
+	assert.Equal(t, 7, response.Lots[1].Available)
+
+	listUC = new(MockListLotsUseCase)
+	listUC.On("Execute", mock.Anything, mock.Anything).Return(nil, domainErrors.ErrInventoryItemNotFound)
+	router = setupLotRouter(new(MockReceiveLotUseCase), listUC, new(MockGetReservationLotsUseCase))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/inventory/"+productID.String()+"/lots", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestLotHandler_GetReservationLots(t *testing.T) {
+	reservationUC := new(MockGetReservationLotsUseCase)
+	reservationID := uuid.New()
+	bestBefore := time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)
+	reservationUC.On("Execute", mock.Anything, reservationID).Return([]*entity.LotAllocation{
+		{ReservationID: reservationID, LotID: uuid.New(), LotNumber: "L-1", BestBefore: &bestBefore, Quantity: 3, Status: entity.LotConsumed},
+	}, nil)
+	router := setupLotRouter(new(MockReceiveLotUseCase), new(MockListLotsUseCase), reservationUC)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/reservations/"+reservationID.String()+"/lots", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var response ReservationLotsResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response.Lots, 1)
+	assert.Equal(t, "L-1", response.Lots[0].LotNumber)
+	assert.Equal(t, "2026-01-31", response.Lots[0].BestBefore)
+	assert.Equal(t, "consumed", response.Lots[0].Status)
+}
+
+func TestLotHandler_GetReservationLots_Errors(t *testing.T) {
+	reservationUC := new(MockGetReservationLotsUseCase)
+	reservationUC.On("Execute", mock.Anything, mock.Anything).Return(nil, domainErrors.ErrReservationNotFound)
+	router := setupLotRouter(new(MockReceiveLotUseCase), new(MockListLotsUseCase), reservationUC)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/reservations/abc/lots", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), `"error":"invalid_reservation_id"`)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/reservations/"+uuid.New().String()+"/lots", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), `"error":"reservation_not_found"`)
+}
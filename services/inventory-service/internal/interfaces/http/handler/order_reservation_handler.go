@@ -0,0 +1,177 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/application/usecase"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+)
+
+// GetOrderReservationExecutor interface for looking up the reservation of an order
+type GetOrderReservationExecutor interface {
+	Execute(ctx context.Context, orderID uuid.UUID) (*usecase.OrderReservationOutput, error)
+}
+
+// OrderConfirmReservationExecutor interface for confirming a reservation addressed by order ID
+type OrderConfirmReservationExecutor interface {
+	Execute(ctx context.Context, input usecase.ConfirmReservationInput) (*usecase.ConfirmReservationOutput, error)
+}
+
+// OrderReleaseReservationExecutor interface for releasing a reservation addressed by order ID
+type OrderReleaseReservationExecutor interface {
+	Execute(ctx context.Context, input usecase.ReleaseReservationInput) (*usecase.ReleaseReservationOutput, error)
+}
+
+// OrderReservationHandler exposes reservations by the order they belong to, for
+// callers such as orders-service that only know order IDs
+type OrderReservationHandler struct {
+	getUC     GetOrderReservationExecutor
+	confirmUC OrderConfirmReservationExecutor
+	releaseUC OrderReleaseReservationExecutor
+}
+
+// NewOrderReservationHandler creates a new OrderReservationHandler
+func NewOrderReservationHandler(
+	getUC GetOrderReservationExecutor,
+	confirmUC OrderConfirmReservationExecutor,
+	releaseUC OrderReleaseReservationExecutor,
+) *OrderReservationHandler {
+	if getUC == nil {
+		panic("getUC cannot be nil")
+	}
+	if confirmUC == nil {
+		panic("confirmUC cannot be nil")
+	}
+	if releaseUC == nil {
+		panic("releaseUC cannot be nil")
+	}
+
+	return &OrderReservationHandler{
+		getUC:     getUC,
+		confirmUC: confirmUC,
+		releaseUC: releaseUC,
+	}
+}
+
+// ProductAvailabilityResponse represents the current stock of a reserved product
+type ProductAvailabilityResponse struct {
+	ProductID       string `json:"product_id"`
+	InventoryItemID string `json:"inventory_item_id"`
+	Quantity        int    `json:"quantity"`
+	Reserved        int    `json:"reserved"`
+	Available       int    `json:"available"`
+	Archived        bool   `json:"archived"`
+}
+
+// OrderReservationResponse represents the reservation of an order in API response.
+// ExpiresInSeconds is zero once the reservation is no longer pending.
+type OrderReservationResponse struct {
+	Reservation      ReservationResponse            `json:"reservation"`
+	ExpiresInSeconds int64                          `json:"expires_in_seconds"`
+	Products         []ProductAvailabilityResponse  `json:"products"`
+	Serials          []string                       `json:"serials,omitempty"`
+	Components       []ReservationComponentResponse `json:"components,omitempty"`
+}
+
+// GetOrderReservation handles GET /api/inventory/orders/:orderId/reservation
+func (h *OrderReservationHandler) GetOrderReservation(c *gin.Context) {
+	orderID, ok := parseOrderIDParam(c)
+	if !ok {
+		return
+	}
+
+	output, err := h.getUC.Execute(c.Request.Context(), orderID)
+	if err != nil {
+		respondInventoryError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toOrderReservationResponse(output))
+}
+
+// ConfirmOrderReservation handles POST /api/inventory/orders/:orderId/reservation/confirm
+func (h *OrderReservationHandler) ConfirmOrderReservation(c *gin.Context) {
+	orderID, ok := parseOrderIDParam(c)
+	if !ok {
+		return
+	}
+
+	output, err := h.confirmUC.Execute(c.Request.Context(), usecase.ConfirmReservationInput{OrderID: orderID})
+	if err != nil {
+		respondInventoryError(c, err)
+		return
+	}
+
+	view := usecase.NewOrderReservationOutput(output.Reservation, output.Item)
+	view.Serials = output.Serials
+	view.Components = output.Components
+	view.ComponentItems = output.ComponentItems
+	c.JSON(http.StatusOK, toOrderReservationResponse(view))
+}
+
+// ReleaseOrderReservation handles DELETE /api/inventory/orders/:orderId/reservation
+func (h *OrderReservationHandler) ReleaseOrderReservation(c *gin.Context) {
+	orderID, ok := parseOrderIDParam(c)
+	if !ok {
+		return
+	}
+
+	output, err := h.releaseUC.Execute(c.Request.Context(), usecase.ReleaseReservationInput{OrderID: orderID})
+	if err != nil {
+		respondInventoryError(c, err)
+		return
+	}
+
+	view := usecase.NewOrderReservationOutput(output.Reservation, output.Item)
+	view.Serials = output.Serials
+	view.Components = output.Components
+	view.ComponentItems = output.ComponentItems
+	c.JSON(http.StatusOK, toOrderReservationResponse(view))
+}
+
+// parseOrderIDParam parses the :orderId path parameter, answering 400 when it is not a UUID
+func parseOrderIDParam(c *gin.Context) (uuid.UUID, bool) {
+	orderID, err := uuid.Parse(c.Param("orderId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_order_id",
+			"message": "Invalid order ID format. Expected UUID.",
+		})
+		return uuid.Nil, false
+	}
+	return orderID, true
+}
+
+// toOrderReservationResponse converts the order view of a reservation to its API shape.
+// Products lists the reserved item first, then every component of a bundle.
+func toOrderReservationResponse(output *usecase.OrderReservationOutput) OrderReservationResponse {
+	products := make([]ProductAvailabilityResponse, 0, 1+len(output.ComponentItems))
+	products = append(products, toProductAvailabilityResponse(output.Item))
+	for _, item := range output.ComponentItems {
+		products = append(products, toProductAvailabilityResponse(item))
+	}
+
+	return OrderReservationResponse{
+		Reservation:      toReservationResponse(output.Reservation),
+		ExpiresInSeconds: int64(output.TimeUntilExpiry.Seconds()),
+		Products:         products,
+		Serials:          output.Serials,
+		Components:       toReservationComponentResponses(output.Components),
+	}
+}
+
+// toProductAvailabilityResponse converts the current stock of an item to its API shape
+func toProductAvailabilityResponse(item *entity.InventoryItem) ProductAvailabilityResponse {
+	return ProductAvailabilityResponse{
+		ProductID:       item.ProductID.String(),
+		InventoryItemID: item.ID.String(),
+		Quantity:        item.Quantity,
+		Reserved:        item.Reserved,
+		Available:       item.Available(),
+		Archived:        item.IsArchived(),
+	}
+}
@@ -0,0 +1,308 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/application/usecase"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	domainErrors "github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+)
+
+// MockGetOrderReservationUseCase mocks the get order reservation use case
+type MockGetOrderReservationUseCase struct {
+	mock.Mock
+}
+
+func (m *MockGetOrderReservationUseCase) Execute(ctx context.Context, orderID uuid.UUID) (*usecase.OrderReservationOutput, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.OrderReservationOutput), args.Error(1)
+}
+
+// MockOrderConfirmReservationUseCase mocks the confirm reservation use case
+type MockOrderConfirmReservationUseCase struct {
+	mock.Mock
+}
+
+func (m *MockOrderConfirmReservationUseCase) Execute(ctx context.Context, input usecase.ConfirmReservationInput) (*usecase.ConfirmReservationOutput, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.ConfirmReservationOutput), args.Error(1)
+}
+
+// MockOrderReleaseReservationUseCase mocks the release reservation use case
+type MockOrderReleaseReservationUseCase struct {
+	mock.Mock
+}
+
+func (m *MockOrderReleaseReservationUseCase) Execute(ctx context.Context, input usecase.ReleaseReservationInput) (*usecase.ReleaseReservationOutput, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.ReleaseReservationOutput), args.Error(1)
+}
+
+type orderReservationMocks struct {
+	get     *MockGetOrderReservationUseCase
+	confirm *MockOrderConfirmReservationUseCase
+	release *MockOrderReleaseReservationUseCase
+}
+
+func setupOrderReservationRouter() (*gin.Engine, orderReservationMocks) {
+	gin.SetMode(gin.TestMode)
+	mocks := orderReservationMocks{
+		get:     new(MockGetOrderReservationUseCase),
+		confirm: new(MockOrderConfirmReservationUseCase),
+		release: new(MockOrderReleaseReservationUseCase),
+	}
+	h := NewOrderReservationHandler(mocks.get, mocks.confirm, mocks.release)
+
+	router := gin.New()
+	router.GET("/api/inventory/orders/:orderId/reservation", h.GetOrderReservation)
+	router.POST("/api/inventory/orders/:orderId/reservation/confirm", h.ConfirmOrderReservation)
+	router.DELETE("/api/inventory/orders/:orderId/reservation", h.ReleaseOrderReservation)
+	return router, mocks
+}
+
+func newOrderReservationFixture(t *testing.T) (*entity.Reservation, *entity.InventoryItem) {
+	item, err := entity.NewInventoryItem(uuid.New(), 100)
+	require.NoError(t, err)
+	require.NoError(t, item.Reserve(4))
+	reservation, err := entity.NewReservationWithDuration(item.ID, uuid.New(), 4, 10*time.Minute)
+	require.NoError(t, err)
+	return reservation, item
+}
+
+func TestNewOrderReservationHandler_NilUseCases_Panic(t *testing.T) {
+	get := new(MockGetOrderReservationUseCase)
+	confirm := new(MockOrderConfirmReservationUseCase)
+	release := new(MockOrderReleaseReservationUseCase)
+
+	assert.Panics(t, func() { NewOrderReservationHandler(nil, confirm, release) })
+	assert.Panics(t, func() { NewOrderReservationHandler(get, nil, release) })
+	assert.Panics(t, func() { NewOrderReservationHandler(get, confirm, nil) })
+}
+
+func TestOrderReservationHandler_GetOrderReservation_Success(t *testing.T) {
+	router, mocks := setupOrderReservationRouter()
+	reservation, item := newOrderReservationFixture(t)
+	mocks.get.On("Execute", mock.Anything, reservation.OrderID).Return(&usecase.OrderReservationOutput{
+		Reservation:     reservation,
+		TimeUntilExpiry: 9*time.Minute + 30*time.Second,
+		Item:            item,
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/inventory/orders/"+reservation.OrderID.String()+"/reservation", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var response OrderReservationResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, reservation.ID.String(), response.Reservation.ID)
+	assert.Equal(t, "pending", response.Reservation.Status)
+	assert.Equal(t, int64(570), response.ExpiresInSeconds)
+	require.Len(t, response.Products, 1)
+	assert.Equal(t, ProductAvailabilityResponse{
+		ProductID:       item.ProductID.String(),
+		InventoryItemID: item.ID.String(),
+		Quantity:        100,
+		Reserved:        4,
+		Available:       96,
+	}, response.Products[0])
+}
+
+func TestOrderReservationHandler_GetOrderReservation_InvalidOrderID(t *testing.T) {
+	router, mocks := setupOrderReservationRouter()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/inventory/orders/not-a-uuid/reservation", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "invalid_order_id")
+	mocks.get.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything)
+}
+
+func TestOrderReservationHandler_GetOrderReservation_NotFound(t *testing.T) {
+	router, mocks := setupOrderReservationRouter()
+	orderID := uuid.New()
+	mocks.get.On("Execute", mock.Anything, orderID).Return(nil, domainErrors.ErrReservationNotFound)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/inventory/orders/"+orderID.String()+"/reservation", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), "reservation_not_found")
+}
+
+func TestOrderReservationHandler_ConfirmOrderReservation_Success(t *testing.T) {
+	router, mocks := setupOrderReservationRouter()
+	reservation, item := newOrderReservationFixture(t)
+	require.NoError(t, item.ConfirmReservation(4))
+	require.NoError(t, reservation.Confirm())
+	mocks.confirm.On("Execute", mock.Anything, usecase.ConfirmReservationInput{OrderID: reservation.OrderID}).
+		Return(&usecase.ConfirmReservationOutput{Reservation: reservation, Item: item}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/inventory/orders/"+reservation.OrderID.String()+"/reservation/confirm", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var response OrderReservationResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "confirmed", response.Reservation.Status)
+	assert.Zero(t, response.ExpiresInSeconds)
+	assert.Equal(t, 96, response.Products[0].Quantity)
+	assert.Equal(t, 96, response.Products[0].Available)
+}
+
+func TestOrderReservationHandler_ConfirmOrderReservation_Serials(t *testing.T) {
+	router, mocks := setupOrderReservationRouter()
+	reservation, item := newOrderReservationFixture(t)
+	require.NoError(t, reservation.Confirm())
+	mocks.confirm.On("Execute", mock.Anything, usecase.ConfirmReservationInput{OrderID: reservation.OrderID}).
+		Return(&usecase.ConfirmReservationOutput{Reservation: reservation, Item: item, Serials: []string{"SN-1", "SN-2"}}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/inventory/orders/"+reservation.OrderID.String()+"/reservation/confirm", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var response OrderReservationResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, []string{"SN-1", "SN-2"}, response.Serials)
+}
+
+func TestOrderReservationHandler_ReleaseOrderReservation_Components(t *testing.T) {
+	router, mocks := setupOrderReservationRouter()
+	reservation, item := newOrderReservationFixture(t)
+	require.NoError(t, reservation.Release())
+	componentID := uuid.New()
+	mocks.release.On("Execute", mock.Anything, usecase.ReleaseReservationInput{OrderID: reservation.OrderID}).
+		Return(&usecase.ReleaseReservationOutput{Reservation: reservation, Item: item, Components: []*entity.ReservationComponent{
+			{ProductID: componentID, Quantity: 8, Status: entity.ComponentReleased},
+		}}, nil)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/inventory/orders/"+reservation.OrderID.String()+"/reservation", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var response OrderReservationResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, []ReservationComponentResponse{
+		{ProductID: componentID.String(), Quantity: 8, Status: "released"},
+	}, response.Components)
+}
+
+func TestOrderReservationHandler_GetOrderReservation_BundleProducts(t *testing.T) {
+	router, mocks := setupOrderReservationRouter()
+	reservation, item := newOrderReservationFixture(t)
+	component, err := entity.NewInventoryItem(uuid.New(), 10)
+	require.NoError(t, err)
+	require.NoError(t, component.Reserve(4))
+	mocks.get.On("Execute", mock.Anything, reservation.OrderID).
+		Return(&usecase.OrderReservationOutput{
+			Reservation:    reservation,
+			Item:           item,
+			Components:     []*entity.ReservationComponent{{ProductID: component.ProductID, Quantity: 4, Status: entity.ComponentReserved}},
+			ComponentItems: []*entity.InventoryItem{component},
+		}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/inventory/orders/"+reservation.OrderID.String()+"/reservation", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var response OrderReservationResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response.Products, 2)
+	assert.Equal(t, item.ProductID.String(), response.Products[0].ProductID)
+	assert.Equal(t, ProductAvailabilityResponse{
+		ProductID:       component.ProductID.String(),
+		InventoryItemID: component.ID.String(),
+		Quantity:        10,
+		Reserved:        4,
+		Available:       6,
+	}, response.Products[1])
+}
+
+func TestOrderReservationHandler_GetOrderReservation_Channel(t *testing.T) {
+	router, mocks := setupOrderReservationRouter()
+	reservation, item := newOrderReservationFixture(t)
+	reservation.Channel = "marketplace"
+	mocks.get.On("Execute", mock.Anything, reservation.OrderID).
+		Return(&usecase.OrderReservationOutput{Reservation: reservation, Item: item}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/inventory/orders/"+reservation.OrderID.String()+"/reservation", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var response OrderReservationResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "marketplace", response.Reservation.Channel)
+}
+
+func TestOrderReservationHandler_ConfirmOrderReservation_Expired(t *testing.T) {
+	router, mocks := setupOrderReservationRouter()
+	orderID := uuid.New()
+	mocks.confirm.On("Execute", mock.Anything, mock.Anything).Return(nil, domainErrors.ErrReservationExpired)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/inventory/orders/"+orderID.String()+"/reservation/confirm", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusGone, w.Code)
+	assert.Contains(t, w.Body.String(), "reservation_expired")
+}
+
+func TestOrderReservationHandler_ReleaseOrderReservation_Success(t *testing.T) {
+	router, mocks := setupOrderReservationRouter()
+	reservation, item := newOrderReservationFixture(t)
+	require.NoError(t, item.ReleaseReservation(4))
+	require.NoError(t, reservation.Release())
+	mocks.release.On("Execute", mock.Anything, usecase.ReleaseReservationInput{OrderID: reservation.OrderID}).
+		Return(&usecase.ReleaseReservationOutput{Reservation: reservation, Item: item}, nil)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/inventory/orders/"+reservation.OrderID.String()+"/reservation", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var response OrderReservationResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "released", response.Reservation.Status)
+	assert.Equal(t, 100, response.Products[0].Available)
+}
+
+func TestOrderReservationHandler_ReleaseOrderReservation_NotPending(t *testing.T) {
+	router, mocks := setupOrderReservationRouter()
+	orderID := uuid.New()
+	mocks.release.On("Execute", mock.Anything, mock.Anything).Return(nil, domainErrors.ErrReservationNotPending)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/inventory/orders/"+orderID.String()+"/reservation", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), "reservation_not_pending")
+}
@@ -0,0 +1,200 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/application/usecase"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	domainErrors "github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+)
+
+// GetPurchaseLimitExecutor interface for looking up the purchase limits of a product
+type GetPurchaseLimitExecutor interface {
+	Execute(ctx context.Context, productID uuid.UUID) (*entity.PurchaseLimit, error)
+}
+
+// SetPurchaseLimitExecutor interface for replacing the purchase limits of a product
+type SetPurchaseLimitExecutor interface {
+	Execute(ctx context.Context, input usecase.SetPurchaseLimitInput) (*entity.PurchaseLimit, error)
+}
+
+// DeletePurchaseLimitExecutor interface for removing the purchase limits of a product
+type DeletePurchaseLimitExecutor interface {
+	Execute(ctx context.Context, productID uuid.UUID) error
+}
+
+// PurchaseLimitHandler handles the purchase limits of products
+type PurchaseLimitHandler struct {
+	getUC    GetPurchaseLimitExecutor
+	setUC    SetPurchaseLimitExecutor
+	deleteUC DeletePurchaseLimitExecutor
+}
+
+// NewPurchaseLimitHandler creates a new PurchaseLimitHandler
+func NewPurchaseLimitHandler(
+	getUC GetPurchaseLimitExecutor,
+	setUC SetPurchaseLimitExecutor,
+	deleteUC DeletePurchaseLimitExecutor,
+) *PurchaseLimitHandler {
+	if getUC == nil {
+		panic("getUC cannot be nil")
+	}
+	if setUC == nil {
+		panic("setUC cannot be nil")
+	}
+	if deleteUC == nil {
+		panic("deleteUC cannot be nil")
+	}
+
+	return &PurchaseLimitHandler{
+		getUC:    getUC,
+		setUC:    setUC,
+		deleteUC: deleteUC,
+	}
+}
+
+// SetPurchaseLimitRequest represents the purchase limits of a product; a zero
+// limit is not enforced. WindowSeconds applies to MaxPerCustomer only.
+type SetPurchaseLimitRequest struct {
+	MaxPerOrder    int   `json:"max_per_order" binding:"min=0"`
+	MaxPerCustomer int   `json:"max_per_customer" binding:"min=0"`
+	WindowSeconds  int64 `json:"window_seconds" binding:"min=0"`
+}
+
+// PurchaseLimitResponse represents the purchase limits of a product
+type PurchaseLimitResponse struct {
+	ProductID      string `json:"product_id"`
+	MaxPerOrder    int    `json:"max_per_order"`
+	MaxPerCustomer int    `json:"max_per_customer"`
+	WindowSeconds  int64  `json:"window_seconds"`
+}
+
+// GetPurchaseLimit handles GET /admin/inventory/:productId/limits
+// @Summary Get the purchase limits of a product
+// @Description Returns the most units of the product an order, and a customer over a time window, can reserve.
+// @Tags Admin, Inventory
+// @Produce json
+// @Param productId path string true "Product ID (UUID)"
+// @Success 200 {object} PurchaseLimitResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/inventory/{productId}/limits [get]
+func (h *PurchaseLimitHandler) GetPurchaseLimit(c *gin.Context) {
+	productID, ok := parseProductIDParam(c)
+	if !ok {
+		return
+	}
+
+	limit, err := h.getUC.Execute(c.Request.Context(), productID)
+	if err != nil {
+		respondPurchaseLimitError(c, err, "Failed to get purchase limits")
+		return
+	}
+
+	c.JSON(http.StatusOK, toPurchaseLimitResponse(productID, limit))
+}
+
+// SetPurchaseLimit handles PUT /admin/inventory/:productId/limits
+// @Summary Replace the purchase limits of a product
+// @Description Limits the units of the product a single order can reserve, and the units a customer can
+// @Description reserve over a rolling window. Customer limits count pending and confirmed reservations and
+// @Description reject reservations that name no user.
+// @Tags Admin, Inventory
+// @Accept json
+// @Produce json
+// @Param productId path string true "Product ID (UUID)"
+// @Param request body SetPurchaseLimitRequest true "Limits"
+// @Success 200 {object} PurchaseLimitResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/inventory/{productId}/limits [put]
+func (h *PurchaseLimitHandler) SetPurchaseLimit(c *gin.Context) {
+	productID, ok := parseProductIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req SetPurchaseLimitRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	limit, err := h.setUC.Execute(c.Request.Context(), usecase.SetPurchaseLimitInput{
+		ProductID:      productID,
+		MaxPerOrder:    req.MaxPerOrder,
+		MaxPerCustomer: req.MaxPerCustomer,
+		Window:         time.Duration(req.WindowSeconds) * time.Second,
+	})
+	if err != nil {
+		respondPurchaseLimitError(c, err, "Failed to set purchase limits")
+		return
+	}
+
+	c.JSON(http.StatusOK, toPurchaseLimitResponse(productID, limit))
+}
+
+// DeletePurchaseLimit handles DELETE /admin/inventory/:productId/limits
+// @Summary Remove the purchase limits of a product
+// @Tags Admin, Inventory
+// @Param productId path string true "Product ID (UUID)"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/inventory/{productId}/limits [delete]
+func (h *PurchaseLimitHandler) DeletePurchaseLimit(c *gin.Context) {
+	productID, ok := parseProductIDParam(c)
+	if !ok {
+		return
+	}
+
+	if err := h.deleteUC.Execute(c.Request.Context(), productID); err != nil {
+		respondPurchaseLimitError(c, err, "Failed to delete purchase limits")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func toPurchaseLimitResponse(productID uuid.UUID, limit *entity.PurchaseLimit) PurchaseLimitResponse {
+	return PurchaseLimitResponse{
+		ProductID:      productID.String(),
+		MaxPerOrder:    limit.MaxPerOrder,
+		MaxPerCustomer: limit.MaxPerCustomer,
+		WindowSeconds:  int64(limit.Window / time.Second),
+	}
+}
+
+// respondPurchaseLimitError maps purchase limit errors to HTTP responses
+func respondPurchaseLimitError(c *gin.Context, err error, message string) {
+	var domainErr *domainErrors.DomainError
+	switch {
+	case errors.Is(err, domainErrors.ErrInvalidPurchaseLimit) && errors.As(err, &domainErr):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_purchase_limit", "message": domainErr.Error()})
+	case errors.Is(err, domainErrors.ErrPurchaseLimitNotFound):
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "purchase_limit_not_found",
+			"message": "Product has no purchase limits",
+		})
+	case errors.Is(err, domainErrors.ErrInventoryItemNotFound):
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "product_not_found",
+			"message": "Product not found in inventory",
+		})
+	default:
+		_ = c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "internal_server_error",
+			"message": message,
+		})
+	}
+}
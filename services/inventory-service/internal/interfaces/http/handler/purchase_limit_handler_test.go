@@ -0,0 +1,202 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/application/usecase"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	domainErrors "github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+)
+
+// MockGetPurchaseLimitUseCase is a mock for GetPurchaseLimitExecutor
+type MockGetPurchaseLimitUseCase struct {
+	mock.Mock
+}
+
+func (m *MockGetPurchaseLimitUseCase) Execute(ctx context.Context, productID uuid.UUID) (*entity.PurchaseLimit, error) {
+	args := m.Called(ctx, productID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.PurchaseLimit), args.Error(1)
+}
+
+// MockSetPurchaseLimitUseCase is a mock for SetPurchaseLimitExecutor
+type MockSetPurchaseLimitUseCase struct {
+	mock.Mock
+}
+
+func (m *MockSetPurchaseLimitUseCase) Execute(ctx context.Context, input usecase.SetPurchaseLimitInput) (*entity.PurchaseLimit, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.PurchaseLimit), args.Error(1)
+}
+
+// MockDeletePurchaseLimitUseCase is a mock for DeletePurchaseLimitExecutor
+type MockDeletePurchaseLimitUseCase struct {
+	mock.Mock
+}
+
+func (m *MockDeletePurchaseLimitUseCase) Execute(ctx context.Context, productID uuid.UUID) error {
+	args := m.Called(ctx, productID)
+	return args.Error(0)
+}
+
+type purchaseLimitMocks struct {
+	get    *MockGetPurchaseLimitUseCase
+	set    *MockSetPurchaseLimitUseCase
+	delete *MockDeletePurchaseLimitUseCase
+}
+
+func setupPurchaseLimitRouter() (*gin.Engine, *purchaseLimitMocks) {
+	gin.SetMode(gin.TestMode)
+	m := &purchaseLimitMocks{
+		get:    new(MockGetPurchaseLimitUseCase),
+		set:    new(MockSetPurchaseLimitUseCase),
+		delete: new(MockDeletePurchaseLimitUseCase),
+	}
+	h := NewPurchaseLimitHandler(m.get, m.set, m.delete)
+	router := gin.New()
+	router.GET("/admin/inventory/:productId/limits", h.GetPurchaseLimit)
+	router.PUT("/admin/inventory/:productId/limits", h.SetPurchaseLimit)
+	router.DELETE("/admin/inventory/:productId/limits", h.DeletePurchaseLimit)
+	return router, m
+}
+
+func TestNewPurchaseLimitHandler_NilUseCases_Panic(t *testing.T) {
+	_, m := setupPurchaseLimitRouter()
+	assert.Panics(t, func() { NewPurchaseLimitHandler(nil, m.set, m.delete) })
+	assert.Panics(t, func() { NewPurchaseLimitHandler(m.get, nil, m.delete) })
+	assert.Panics(t, func() { NewPurchaseLimitHandler(m.get, m.set, nil) })
+}
+
+func TestPurchaseLimitHandler_GetPurchaseLimit(t *testing.T) {
+	productID := uuid.New()
+	path := "/admin/inventory/" + productID.String() + "/limits"
+
+	t.Run("should return the limits", func(t *testing.T) {
+		router, m := setupPurchaseLimitRouter()
+		m.get.On("Execute", mock.Anything, productID).Return(&entity.PurchaseLimit{
+			InventoryItemID: uuid.New(),
+			MaxPerOrder:     2,
+			MaxPerCustomer:  4,
+			Window:          24 * time.Hour,
+		}, nil)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var response PurchaseLimitResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, PurchaseLimitResponse{
+			ProductID:      productID.String(),
+			MaxPerOrder:    2,
+			MaxPerCustomer: 4,
+			WindowSeconds:  86400,
+		}, response)
+	})
+
+	t.Run("should return 404 without limits", func(t *testing.T) {
+		router, m := setupPurchaseLimitRouter()
+		m.get.On("Execute", mock.Anything, productID).Return(nil, domainErrors.ErrPurchaseLimitNotFound)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Contains(t, w.Body.String(), "purchase_limit_not_found")
+	})
+}
+
+func TestPurchaseLimitHandler_SetPurchaseLimit(t *testing.T) {
+	productID := uuid.New()
+	path := "/admin/inventory/" + productID.String() + "/limits"
+
+	t.Run("should replace the limits", func(t *testing.T) {
+		router, m := setupPurchaseLimitRouter()
+		m.set.On("Execute", mock.Anything, usecase.SetPurchaseLimitInput{
+			ProductID:      productID,
+			MaxPerOrder:    2,
+			MaxPerCustomer: 4,
+			Window:         time.Hour,
+		}).Return(&entity.PurchaseLimit{MaxPerOrder: 2, MaxPerCustomer: 4, Window: time.Hour}, nil)
+
+		body := `{"max_per_order":2,"max_per_customer":4,"window_seconds":3600}`
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPut, path, strings.NewReader(body)))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var response PurchaseLimitResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, int64(3600), response.WindowSeconds)
+	})
+
+	tests := []struct {
+		name       string
+		path       string
+		body       string
+		ucErr      error
+		wantStatus int
+		wantError  string
+	}{
+		{"invalid product", "/admin/inventory/abc/limits", `{"max_per_order":1}`, nil, http.StatusBadRequest, "invalid_product_id"},
+		{"negative limit", path, `{"max_per_order":-1}`, nil, http.StatusBadRequest, "invalid_request"},
+		{"invalid limit", path, `{}`, domainErrors.ErrInvalidPurchaseLimit.WithDetails("at least one limit is required"), http.StatusBadRequest, "at least one limit"},
+		{"no inventory", path, `{"max_per_order":1}`, domainErrors.ErrInventoryItemNotFound, http.StatusNotFound, "product_not_found"},
+		{"database", path, `{"max_per_order":1}`, errors.New("connection refused"), http.StatusInternalServerError, "internal_server_error"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, m := setupPurchaseLimitRouter()
+			m.set.On("Execute", mock.Anything, mock.Anything).Return(nil, tt.ucErr)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodPut, tt.path, strings.NewReader(tt.body)))
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Contains(t, w.Body.String(), tt.wantError)
+		})
+	}
+}
+
+func TestPurchaseLimitHandler_DeletePurchaseLimit(t *testing.T) {
+	productID := uuid.New()
+	path := "/admin/inventory/" + productID.String() + "/limits"
+
+	t.Run("should remove the limits", func(t *testing.T) {
+		router, m := setupPurchaseLimitRouter()
+		m.delete.On("Execute", mock.Anything, productID).Return(nil)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, path, nil))
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+
+	t.Run("should return 404 without limits", func(t *testing.T) {
+		router, m := setupPurchaseLimitRouter()
+		m.delete.On("Execute", mock.Anything, productID).Return(domainErrors.ErrPurchaseLimitNotFound)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, path, nil))
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Contains(t, w.Body.String(), "purchase_limit_not_found")
+	})
+}
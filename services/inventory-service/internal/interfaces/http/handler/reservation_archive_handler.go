@@ -0,0 +1,199 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/application/usecase"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	domainErrors "github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/interfaces/http/middleware"
+)
+
+// ListArchivedReservationsExecutor interface for querying the reservation archive
+type ListArchivedReservationsExecutor interface {
+	Execute(ctx context.Context, input usecase.ListArchivedReservationsInput) (*usecase.ListArchivedReservationsOutput, error)
+}
+
+// GetArchivedReservationExecutor interface for reading one archived reservation
+type GetArchivedReservationExecutor interface {
+	Execute(ctx context.Context, id uuid.UUID) (*entity.ArchivedReservation, error)
+}
+
+// RunRetentionExecutor interface for running the retention policy on demand
+type RunRetentionExecutor interface {
+	Execute(ctx context.Context) (*usecase.ArchiveReservationsOutput, error)
+}
+
+// ReservationArchiveHandler exposes the reservation archive and the retention job
+type ReservationArchiveHandler struct {
+	listArchivedUC ListArchivedReservationsExecutor
+	getArchivedUC  GetArchivedReservationExecutor
+	runRetentionUC RunRetentionExecutor
+}
+
+// NewReservationArchiveHandler creates a new ReservationArchiveHandler
+func NewReservationArchiveHandler(
+	listArchivedUC ListArchivedReservationsExecutor,
+	getArchivedUC GetArchivedReservationExecutor,
+	runRetentionUC RunRetentionExecutor,
+) *ReservationArchiveHandler {
+	if listArchivedUC == nil {
+		panic("listArchivedUC cannot be nil")
+	}
+	if getArchivedUC == nil {
+		panic("getArchivedUC cannot be nil")
+	}
+	if runRetentionUC == nil {
+		panic("runRetentionUC cannot be nil")
+	}
+
+	return &ReservationArchiveHandler{
+		listArchivedUC: listArchivedUC,
+		getArchivedUC:  getArchivedUC,
+		runRetentionUC: runRetentionUC,
+	}
+}
+
+// ArchivedReservationResponse represents an archived reservation in API response
+type ArchivedReservationResponse struct {
+	ReservationResponse
+	ArchivedAt string `json:"archived_at"`
+}
+
+// ListArchivedReservationsResponse represents the response for querying the archive
+type ListArchivedReservationsResponse struct {
+	Reservations []ArchivedReservationResponse `json:"reservations"`
+	TotalCount   int64                         `json:"total_count"`
+	Limit        int                           `json:"limit"`
+	Offset       int                           `json:"offset"`
+}
+
+// ListArchived handles GET /admin/reservations/archive
+// Supported filters: order_id, inventory_item_id, status, from, to (RFC3339, on created_at), limit, offset
+func (h *ReservationArchiveHandler) ListArchived(c *gin.Context) {
+	input := usecase.ListArchivedReservationsInput{Status: c.Query("status")}
+
+	var err error
+	if input.OrderID, err = parseQueryUUID(c, "order_id"); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_order_id", "message": "order_id must be a UUID"})
+		return
+	}
+	if input.InventoryItemID, err = parseQueryUUID(c, "inventory_item_id"); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_inventory_item_id", "message": "inventory_item_id must be a UUID"})
+		return
+	}
+	if input.Limit, err = strconv.Atoi(c.DefaultQuery("limit", "50")); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_limit", "message": "limit must be an integer"})
+		return
+	}
+	if input.Offset, err = strconv.Atoi(c.DefaultQuery("offset", "0")); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_offset", "message": "offset must be an integer"})
+		return
+	}
+	if input.From, err = parseQueryTime(c, "from"); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_from", "message": "from must be an RFC3339 timestamp"})
+		return
+	}
+	if input.To, err = parseQueryTime(c, "to"); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_to", "message": "to must be an RFC3339 timestamp"})
+		return
+	}
+
+	output, err := h.listArchivedUC.Execute(c.Request.Context(), input)
+	if err != nil {
+		if errors.Is(err, domainErrors.ErrInvalidInput) {
+			var domainErr *domainErrors.DomainError
+			errors.As(err, &domainErr)
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_filter", "message": domainErr.Details})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "internal_server_error",
+			"message": "Failed to retrieve archived reservations",
+		})
+		return
+	}
+
+	reservations := make([]ArchivedReservationResponse, len(output.Reservations))
+	for i, reservation := range output.Reservations {
+		reservations[i] = toArchivedReservationResponse(reservation)
+	}
+
+	c.JSON(http.StatusOK, ListArchivedReservationsResponse{
+		Reservations: reservations,
+		TotalCount:   output.TotalCount,
+		Limit:        output.Limit,
+		Offset:       output.Offset,
+	})
+}
+
+// GetArchived handles GET /admin/reservations/archive/:id
+func (h *ReservationArchiveHandler) GetArchived(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_reservation_id",
+			"message": "Invalid reservation ID format. Expected UUID.",
+		})
+		return
+	}
+
+	reservation, err := h.getArchivedUC.Execute(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, domainErrors.ErrReservationNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "reservation_not_found", "message": "Archived reservation not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "internal_server_error",
+			"message": "Failed to retrieve archived reservation",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, toArchivedReservationResponse(reservation))
+}
+
+// RunRetention handles POST /admin/reservations/archive/run
+// It applies the retention policy once, like a scheduled run
+func (h *ReservationArchiveHandler) RunRetention(c *gin.Context) {
+	output, err := h.runRetentionUC.Execute(c.Request.Context())
+	if err != nil {
+		_ = c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "retention_failed",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	// Partitions are the only objects a run creates or removes by name
+	affected := make([]string, 0, len(output.PartitionsCreated)+len(output.PartitionsDropped))
+	affected = append(affected, output.PartitionsCreated...)
+	middleware.SetAuditAffectedIDs(c, append(affected, output.PartitionsDropped...)...)
+	c.JSON(http.StatusOK, output)
+}
+
+// toArchivedReservationResponse converts an archived reservation to its API shape
+func toArchivedReservationResponse(reservation *entity.ArchivedReservation) ArchivedReservationResponse {
+	return ArchivedReservationResponse{
+		ReservationResponse: toReservationResponse(&reservation.Reservation),
+		ArchivedAt:          reservation.ArchivedAt.Format(time.RFC3339),
+	}
+}
+
+// parseQueryUUID parses an optional UUID query parameter
+func parseQueryUUID(c *gin.Context, key string) (uuid.UUID, error) {
+	value := c.Query(key)
+	if value == "" {
+		return uuid.Nil, nil
+	}
+	return uuid.Parse(value)
+}
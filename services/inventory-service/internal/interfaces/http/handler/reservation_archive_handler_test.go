@@ -0,0 +1,232 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/application/usecase"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	domainErrors "github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+)
+
+// MockListArchivedReservationsUseCase mocks the list archived reservations use case
+type MockListArchivedReservationsUseCase struct {
+	mock.Mock
+}
+
+func (m *MockListArchivedReservationsUseCase) Execute(ctx context.Context, input usecase.ListArchivedReservationsInput) (*usecase.ListArchivedReservationsOutput, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.ListArchivedReservationsOutput), args.Error(1)
+}
+
+// MockGetArchivedReservationUseCase mocks the get archived reservation use case
+type MockGetArchivedReservationUseCase struct {
+	mock.Mock
+}
+
+func (m *MockGetArchivedReservationUseCase) Execute(ctx context.Context, id uuid.UUID) (*entity.ArchivedReservation, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ArchivedReservation), args.Error(1)
+}
+
+// MockRunRetentionUseCase mocks the archive reservations use case
+type MockRunRetentionUseCase struct {
+	mock.Mock
+}
+
+func (m *MockRunRetentionUseCase) Execute(ctx context.Context) (*usecase.ArchiveReservationsOutput, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.ArchiveReservationsOutput), args.Error(1)
+}
+
+type reservationArchiveMocks struct {
+	list *MockListArchivedReservationsUseCase
+	get  *MockGetArchivedReservationUseCase
+	run  *MockRunRetentionUseCase
+}
+
+func setupReservationArchiveRouter() (*gin.Engine, reservationArchiveMocks) {
+	gin.SetMode(gin.TestMode)
+	mocks := reservationArchiveMocks{
+		list: new(MockListArchivedReservationsUseCase),
+		get:  new(MockGetArchivedReservationUseCase),
+		run:  new(MockRunRetentionUseCase),
+	}
+	h := NewReservationArchiveHandler(mocks.list, mocks.get, mocks.run)
+
+	router := gin.New()
+	router.GET("/admin/reservations/archive", h.ListArchived)
+	router.GET("/admin/reservations/archive/:id", h.GetArchived)
+	router.POST("/admin/reservations/archive/run", h.RunRetention)
+	return router, mocks
+}
+
+func newArchivedReservation() *entity.ArchivedReservation {
+	createdAt := time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC)
+	return &entity.ArchivedReservation{
+		Reservation: entity.Reservation{
+			ID:              uuid.New(),
+			InventoryItemID: uuid.New(),
+			OrderID:         uuid.New(),
+			Quantity:        2,
+			Status:          entity.ReservationConfirmed,
+			ExpiresAt:       createdAt.Add(15 * time.Minute),
+			CreatedAt:       createdAt,
+			UpdatedAt:       createdAt.Add(5 * time.Minute),
+		},
+		ArchivedAt: createdAt.AddDate(0, 3, 0),
+	}
+}
+
+func TestNewReservationArchiveHandler_NilUseCases_Panic(t *testing.T) {
+	list := new(MockListArchivedReservationsUseCase)
+	get := new(MockGetArchivedReservationUseCase)
+	run := new(MockRunRetentionUseCase)
+
+	assert.Panics(t, func() { NewReservationArchiveHandler(nil, get, run) })
+	assert.Panics(t, func() { NewReservationArchiveHandler(list, nil, run) })
+	assert.Panics(t, func() { NewReservationArchiveHandler(list, get, nil) })
+}
+
+func TestReservationArchiveHandler_ListArchived_Success(t *testing.T) {
+	router, mocks := setupReservationArchiveRouter()
+	archived := newArchivedReservation()
+	mocks.list.On("Execute", mock.Anything, usecase.ListArchivedReservationsInput{
+		OrderID: archived.OrderID,
+		Status:  "confirmed",
+		From:    time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		Limit:   20,
+		Offset:  40,
+	}).Return(&usecase.ListArchivedReservationsOutput{
+		Reservations: []*entity.ArchivedReservation{archived},
+		TotalCount:   41,
+		Limit:        20,
+		Offset:       40,
+	}, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet,
+		"/admin/reservations/archive?order_id="+archived.OrderID.String()+"&status=confirmed&from=2025-01-01T00:00:00Z&limit=20&offset=40", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response ListArchivedReservationsResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, int64(41), response.TotalCount)
+	require.Len(t, response.Reservations, 1)
+	assert.Equal(t, archived.ID.String(), response.Reservations[0].ID)
+	assert.Equal(t, "confirmed", response.Reservations[0].Status)
+	assert.Equal(t, "2025-09-01T10:00:00Z", response.Reservations[0].ArchivedAt)
+	mocks.list.AssertExpectations(t)
+}
+
+func TestReservationArchiveHandler_ListArchived_InvalidParams(t *testing.T) {
+	tests := []struct {
+		query string
+		code  string
+	}{
+		{"order_id=abc", "invalid_order_id"},
+		{"inventory_item_id=abc", "invalid_inventory_item_id"},
+		{"limit=ten", "invalid_limit"},
+		{"offset=x", "invalid_offset"},
+		{"from=yesterday", "invalid_from"},
+		{"to=2025-13-01", "invalid_to"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			router, mocks := setupReservationArchiveRouter()
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/reservations/archive?"+tt.query, nil))
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Contains(t, w.Body.String(), tt.code)
+			mocks.list.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestReservationArchiveHandler_ListArchived_Errors(t *testing.T) {
+	router, mocks := setupReservationArchiveRouter()
+	mocks.list.On("Execute", mock.Anything, mock.MatchedBy(func(input usecase.ListArchivedReservationsInput) bool {
+		return input.Status == "pending"
+	})).Return(nil, domainErrors.ErrInvalidInput.WithDetails("status must be confirmed, released or expired"))
+	mocks.list.On("Execute", mock.Anything, mock.Anything).Return(nil, errors.New("database error"))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/reservations/archive?status=pending", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "status must be confirmed, released or expired")
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/reservations/archive", nil))
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestReservationArchiveHandler_GetArchived(t *testing.T) {
+	router, mocks := setupReservationArchiveRouter()
+	archived := newArchivedReservation()
+	missing := uuid.New()
+	mocks.get.On("Execute", mock.Anything, archived.ID).Return(archived, nil)
+	mocks.get.On("Execute", mock.Anything, missing).Return(nil, domainErrors.ErrReservationNotFound)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/reservations/archive/"+archived.ID.String(), nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response ArchivedReservationResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, archived.OrderID.String(), response.OrderID)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/reservations/archive/"+missing.String(), nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), "reservation_not_found")
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/reservations/archive/not-a-uuid", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestReservationArchiveHandler_RunRetention(t *testing.T) {
+	router, mocks := setupReservationArchiveRouter()
+	mocks.run.On("Execute", mock.Anything).Return(&usecase.ArchiveReservationsOutput{
+		Archived:          7,
+		Batches:           1,
+		Complete:          true,
+		PartitionsCreated: []string{"reservations_p202602"},
+		PartitionsDropped: []string{},
+	}, nil).Once()
+	mocks.run.On("Execute", mock.Anything).Return(nil, errors.New("lock timeout")).Once()
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/admin/reservations/archive/run", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response usecase.ArchiveReservationsOutput
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 7, response.Archived)
+	assert.Equal(t, []string{"reservations_p202602"}, response.PartitionsCreated)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/admin/reservations/archive/run", nil))
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Contains(t, w.Body.String(), "retention_failed")
+}
@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/application/usecase"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/interfaces/http/middleware"
 	"github.com/gin-gonic/gin"
 )
 
@@ -53,6 +54,7 @@ func (h *ReservationMaintenanceHandler) ReleaseExpired(c *gin.Context) {
 	// Execute use case
 	output, err := h.releaseExpiredUseCase.Execute(c.Request.Context())
 	if err != nil {
+		_ = c.Error(err)
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error":   "failed to release expired reservations",
 			"message": err.Error(),
@@ -65,6 +67,7 @@ func (h *ReservationMaintenanceHandler) ReleaseExpired(c *gin.Context) {
 	for i, id := range output.ReleasedReservationIDs {
 		releasedIDs[i] = id.String()
 	}
+	middleware.SetAuditAffectedIDs(c, releasedIDs...)
 
 	// Convert failed reservations
 	failedReservations := make([]FailedReservationResponse, len(output.FailedReservations))
@@ -0,0 +1,425 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/application/usecase"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	domainErrors "github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+)
+
+// SetSerialTrackingExecutor interface for turning serial tracking on or off
+type SetSerialTrackingExecutor interface {
+	Execute(ctx context.Context, productID uuid.UUID, enabled bool) (*entity.InventoryItem, error)
+}
+
+// RegisterSerialsExecutor interface for registering serialized units
+type RegisterSerialsExecutor interface {
+	Execute(ctx context.Context, input usecase.RegisterSerialsInput) (*usecase.RegisterSerialsOutput, error)
+}
+
+// ListSerialsExecutor interface for listing the serialized units of a product
+type ListSerialsExecutor interface {
+	Execute(ctx context.Context, productID uuid.UUID, status entity.SerialUnitStatus) ([]*entity.SerialUnit, error)
+}
+
+// GetReservationSerialsExecutor interface for the serialized units taken by a reservation
+type GetReservationSerialsExecutor interface {
+	Execute(ctx context.Context, reservationID uuid.UUID) ([]*entity.SerialUnit, error)
+}
+
+// ReturnSerialExecutor interface for returning a sold unit
+type ReturnSerialExecutor interface {
+	Execute(ctx context.Context, productID uuid.UUID, serialNumber string) (*entity.SerialUnit, error)
+}
+
+// RestockSerialExecutor interface for restocking a returned unit
+type RestockSerialExecutor interface {
+	Execute(ctx context.Context, productID uuid.UUID, serialNumber string) (*usecase.RestockSerialOutput, error)
+}
+
+// SerialHandler handles serial tracking and serialized unit lookups
+type SerialHandler struct {
+	setTrackingUC           SetSerialTrackingExecutor
+	registerUC              RegisterSerialsExecutor
+	listUC                  ListSerialsExecutor
+	getReservationSerialsUC GetReservationSerialsExecutor
+	returnUC                ReturnSerialExecutor
+	restockUC               RestockSerialExecutor
+}
+
+// NewSerialHandler creates a new SerialHandler
+func NewSerialHandler(
+	setTrackingUC SetSerialTrackingExecutor,
+	registerUC RegisterSerialsExecutor,
+	listUC ListSerialsExecutor,
+	getReservationSerialsUC GetReservationSerialsExecutor,
+	returnUC ReturnSerialExecutor,
+	restockUC RestockSerialExecutor,
+) *SerialHandler {
+	if setTrackingUC == nil {
+		panic("setTrackingUC cannot be nil")
+	}
+	if registerUC == nil {
+		panic("registerUC cannot be nil")
+	}
+	if listUC == nil {
+		panic("listUC cannot be nil")
+	}
+	if getReservationSerialsUC == nil {
+		panic("getReservationSerialsUC cannot be nil")
+	}
+	if returnUC == nil {
+		panic("returnUC cannot be nil")
+	}
+	if restockUC == nil {
+		panic("restockUC cannot be nil")
+	}
+
+	return &SerialHandler{
+		setTrackingUC:           setTrackingUC,
+		registerUC:              registerUC,
+		listUC:                  listUC,
+		getReservationSerialsUC: getReservationSerialsUC,
+		returnUC:                returnUC,
+		restockUC:               restockUC,
+	}
+}
+
+// SetSerialTrackingRequest turns serial tracking of a product on or off
+type SetSerialTrackingRequest struct {
+	Enabled *bool `json:"enabled" binding:"required"`
+}
+
+// RegisterSerialsRequest represents serialized units received for a product
+type RegisterSerialsRequest struct {
+	SerialNumbers []string `json:"serial_numbers" binding:"required"`
+}
+
+// SerialUnitResponse represents a serialized unit of an inventory item
+type SerialUnitResponse struct {
+	SerialNumber  string    `json:"serial_number"`
+	Status        string    `json:"status"`
+	ReservationID string    `json:"reservation_id,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// SerialStockResponse represents the stock of a product after a serial operation
+type SerialStockResponse struct {
+	ProductID     string               `json:"product_id"`
+	SerialTracked bool                 `json:"serial_tracked"`
+	Quantity      int                  `json:"quantity"`
+	Reserved      int                  `json:"reserved"`
+	Available     int                  `json:"available"`
+	Serials       []SerialUnitResponse `json:"serials,omitempty"`
+}
+
+// ListSerialsResponse represents the serialized units of a product
+type ListSerialsResponse struct {
+	ProductID string               `json:"product_id"`
+	Serials   []SerialUnitResponse `json:"serials"`
+}
+
+// ReservationSerialsResponse represents the serialized units taken by a reservation
+type ReservationSerialsResponse struct {
+	ReservationID string               `json:"reservation_id"`
+	Serials       []SerialUnitResponse `json:"serials"`
+}
+
+// SetSerialTracking handles PUT /admin/inventory/:productId/serial-tracking
+// @Summary Turn serial tracking of a product on or off
+// @Description Serial-tracked products are reserved and shipped as concrete serialized units.
+// @Description Tracking can only change while the product has no stock.
+// @Tags Admin, Inventory
+// @Accept json
+// @Produce json
+// @Param productId path string true "Product ID (UUID)"
+// @Param request body SetSerialTrackingRequest true "Tracking"
+// @Success 200 {object} SerialStockResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /admin/inventory/{productId}/serial-tracking [put]
+func (h *SerialHandler) SetSerialTracking(c *gin.Context) {
+	productID, ok := parseProductIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req SetSerialTrackingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	item, err := h.setTrackingUC.Execute(c.Request.Context(), productID, *req.Enabled)
+	if err != nil {
+		respondSerialError(c, err, "Failed to set serial tracking")
+		return
+	}
+
+	c.JSON(http.StatusOK, toSerialStockResponse(item, nil))
+}
+
+// RegisterSerials handles POST /admin/inventory/:productId/serials
+// @Summary Register serialized units
+// @Description Adds available units with the given serial numbers to the stock of a serial-tracked
+// @Description product. Either every unit is registered or none is.
+// @Tags Admin, Inventory
+// @Accept json
+// @Produce json
+// @Param productId path string true "Product ID (UUID)"
+// @Param request body RegisterSerialsRequest true "Serial numbers"
+// @Success 201 {object} SerialStockResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /admin/inventory/{productId}/serials [post]
+func (h *SerialHandler) RegisterSerials(c *gin.Context) {
+	productID, ok := parseProductIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req RegisterSerialsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	output, err := h.registerUC.Execute(c.Request.Context(), usecase.RegisterSerialsInput{
+		ProductID:     productID,
+		SerialNumbers: req.SerialNumbers,
+	})
+	if err != nil {
+		respondSerialError(c, err, "Failed to register serials")
+		return
+	}
+
+	c.JSON(http.StatusCreated, toSerialStockResponse(output.Item, output.Units))
+}
+
+// ListSerials handles GET /admin/inventory/:productId/serials
+// @Summary List the serialized units of a product
+// @Description Returns the units of the product, oldest first, optionally only those in one status.
+// @Tags Admin, Inventory
+// @Produce json
+// @Param productId path string true "Product ID (UUID)"
+// @Param status query string false "available, reserved, sold or returned"
+// @Success 200 {object} ListSerialsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/inventory/{productId}/serials [get]
+func (h *SerialHandler) ListSerials(c *gin.Context) {
+	productID, ok := parseProductIDParam(c)
+	if !ok {
+		return
+	}
+
+	var status entity.SerialUnitStatus
+	if raw := c.Query("status"); raw != "" {
+		parsed, err := entity.ParseSerialUnitStatus(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "invalid_status",
+				"message": "status must be available, reserved, sold or returned",
+			})
+			return
+		}
+		status = parsed
+	}
+
+	units, err := h.listUC.Execute(c.Request.Context(), productID, status)
+	if err != nil {
+		respondSerialError(c, err, "Failed to list serials")
+		return
+	}
+
+	c.JSON(http.StatusOK, ListSerialsResponse{ProductID: productID.String(), Serials: toSerialUnitResponses(units)})
+}
+
+// GetReservationSerials handles GET /admin/reservations/:id/serials
+// @Summary Get the serialized units taken by a reservation
+// @Description Returns the units the reservation holds, or sold once it was confirmed.
+// @Tags Admin, Reservations
+// @Produce json
+// @Param id path string true "Reservation ID (UUID)"
+// @Success 200 {object} ReservationSerialsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/reservations/{id}/serials [get]
+func (h *SerialHandler) GetReservationSerials(c *gin.Context) {
+	reservationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_reservation_id",
+			"message": "Invalid reservation ID format. Expected UUID.",
+		})
+		return
+	}
+
+	units, err := h.getReservationSerialsUC.Execute(c.Request.Context(), reservationID)
+	if err != nil {
+		respondSerialError(c, err, "Failed to get reservation serials")
+		return
+	}
+
+	c.JSON(http.StatusOK, ReservationSerialsResponse{ReservationID: reservationID.String(), Serials: toSerialUnitResponses(units)})
+}
+
+// ReturnSerial handles POST /admin/inventory/:productId/serials/:serial/return
+// @Summary Return a sold unit
+// @Description Marks a sold unit returned by the customer. It stays out of stock until restocked.
+// @Tags Admin, Inventory
+// @Produce json
+// @Param productId path string true "Product ID (UUID)"
+// @Param serial path string true "Serial number"
+// @Success 200 {object} SerialUnitResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /admin/inventory/{productId}/serials/{serial}/return [post]
+func (h *SerialHandler) ReturnSerial(c *gin.Context) {
+	productID, ok := parseProductIDParam(c)
+	if !ok {
+		return
+	}
+
+	unit, err := h.returnUC.Execute(c.Request.Context(), productID, c.Param("serial"))
+	if err != nil {
+		respondSerialError(c, err, "Failed to return serial")
+		return
+	}
+
+	c.JSON(http.StatusOK, toSerialUnitResponse(unit))
+}
+
+// RestockSerial handles POST /admin/inventory/:productId/serials/:serial/restock
+// @Summary Restock a returned unit
+// @Description Makes a returned unit available again and adds it to the stock.
+// @Tags Admin, Inventory
+// @Produce json
+// @Param productId path string true "Product ID (UUID)"
+// @Param serial path string true "Serial number"
+// @Success 200 {object} SerialStockResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /admin/inventory/{productId}/serials/{serial}/restock [post]
+func (h *SerialHandler) RestockSerial(c *gin.Context) {
+	productID, ok := parseProductIDParam(c)
+	if !ok {
+		return
+	}
+
+	output, err := h.restockUC.Execute(c.Request.Context(), productID, c.Param("serial"))
+	if err != nil {
+		respondSerialError(c, err, "Failed to restock serial")
+		return
+	}
+
+	c.JSON(http.StatusOK, toSerialStockResponse(output.Item, []*entity.SerialUnit{output.Unit}))
+}
+
+func toSerialStockResponse(item *entity.InventoryItem, units []*entity.SerialUnit) SerialStockResponse {
+	response := SerialStockResponse{
+		ProductID:     item.ProductID.String(),
+		SerialTracked: item.SerialTracked,
+		Quantity:      item.Quantity,
+		Reserved:      item.Reserved,
+		Available:     item.Available(),
+	}
+	if len(units) > 0 {
+		response.Serials = toSerialUnitResponses(units)
+	}
+	return response
+}
+
+func toSerialUnitResponses(units []*entity.SerialUnit) []SerialUnitResponse {
+	responses := make([]SerialUnitResponse, len(units))
+	for i, unit := range units {
+		responses[i] = toSerialUnitResponse(unit)
+	}
+	return responses
+}
+
+func toSerialUnitResponse(unit *entity.SerialUnit) SerialUnitResponse {
+	response := SerialUnitResponse{
+		SerialNumber: unit.SerialNumber,
+		Status:       string(unit.Status),
+		CreatedAt:    unit.CreatedAt,
+		UpdatedAt:    unit.UpdatedAt,
+	}
+	if unit.ReservationID != nil {
+		response.ReservationID = unit.ReservationID.String()
+	}
+	return response
+}
+
+// respondSerialError maps serial tracking errors to HTTP responses
+func respondSerialError(c *gin.Context, err error, message string) {
+	var domainErr *domainErrors.DomainError
+	if !errors.As(err, &domainErr) {
+		_ = c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "internal_server_error",
+			"message": message,
+		})
+		return
+	}
+
+	switch {
+	case errors.Is(err, domainErrors.ErrInvalidInput):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_serial", "message": domainErr.Details})
+	case errors.Is(err, domainErrors.ErrInventoryItemNotFound):
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "product_not_found",
+			"message": "Product has no inventory",
+		})
+	case errors.Is(err, domainErrors.ErrReservationNotFound):
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "reservation_not_found",
+			"message": "Reservation not found",
+		})
+	case errors.Is(err, domainErrors.ErrSerialUnitNotFound):
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "serial_not_found",
+			"message": "The product has no unit with this serial number",
+		})
+	case errors.Is(err, domainErrors.ErrSerialUnitAlreadyExists):
+		c.JSON(http.StatusConflict, gin.H{"error": "serial_already_exists", "message": domainErr.Error()})
+	case errors.Is(err, domainErrors.ErrNotSerialTracked):
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "not_serial_tracked",
+			"message": "Product stock is not tracked per serial unit",
+		})
+	case errors.Is(err, domainErrors.ErrBundleItem):
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "bundle_item",
+			"message": "Product is a bundle; its stock is the stock of its components",
+		})
+	case errors.Is(err, domainErrors.ErrSerialTrackingChange):
+		c.JSON(http.StatusConflict, gin.H{"error": "serial_tracking_change", "message": domainErr.Error()})
+	case errors.Is(err, domainErrors.ErrInvalidSerialTransition):
+		c.JSON(http.StatusConflict, gin.H{"error": "invalid_serial_transition", "message": domainErr.Error()})
+	default:
+		_ = c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "internal_server_error",
+			"message": message,
+		})
+	}
+}
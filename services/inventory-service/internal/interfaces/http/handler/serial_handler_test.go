@@ -0,0 +1,377 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/application/usecase"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	domainErrors "github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+)
+
+// MockSetSerialTrackingUseCase is a mock for SetSerialTrackingExecutor
+type MockSetSerialTrackingUseCase struct {
+	mock.Mock
+}
+
+func (m *MockSetSerialTrackingUseCase) Execute(ctx context.Context, productID uuid.UUID, enabled bool) (*entity.InventoryItem, error) {
+	args := m.Called(ctx, productID, enabled)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.InventoryItem), args.Error(1)
+}
+
+// MockRegisterSerialsUseCase is a mock for RegisterSerialsExecutor
+type MockRegisterSerialsUseCase struct {
+	mock.Mock
+}
+
+func (m *MockRegisterSerialsUseCase) Execute(ctx context.Context, input usecase.RegisterSerialsInput) (*usecase.RegisterSerialsOutput, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.RegisterSerialsOutput), args.Error(1)
+}
+
+// MockListSerialsUseCase is a mock for ListSerialsExecutor
+type MockListSerialsUseCase struct {
+	mock.Mock
+}
+
+func (m *MockListSerialsUseCase) Execute(ctx context.Context, productID uuid.UUID, status entity.SerialUnitStatus) ([]*entity.SerialUnit, error) {
+	args := m.Called(ctx, productID, status)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.SerialUnit), args.Error(1)
+}
+
+// MockGetReservationSerialsUseCase is a mock for GetReservationSerialsExecutor
+type MockGetReservationSerialsUseCase struct {
+	mock.Mock
+}
+
+func (m *MockGetReservationSerialsUseCase) Execute(ctx context.Context, reservationID uuid.UUID) ([]*entity.SerialUnit, error) {
+	args := m.Called(ctx, reservationID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.SerialUnit), args.Error(1)
+}
+
+// MockReturnSerialUseCase is a mock for ReturnSerialExecutor
+type MockReturnSerialUseCase struct {
+	mock.Mock
+}
+
+func (m *MockReturnSerialUseCase) Execute(ctx context.Context, productID uuid.UUID, serialNumber string) (*entity.SerialUnit, error) {
+	args := m.Called(ctx, productID, serialNumber)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.SerialUnit), args.Error(1)
+}
+
+// MockRestockSerialUseCase is a mock for RestockSerialExecutor
+type MockRestockSerialUseCase struct {
+	mock.Mock
+}
+
+func (m *MockRestockSerialUseCase) Execute(ctx context.Context, productID uuid.UUID, serialNumber string) (*usecase.RestockSerialOutput, error) {
+	args := m.Called(ctx, productID, serialNumber)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.RestockSerialOutput), args.Error(1)
+}
+
+type serialMocks struct {
+	setTracking *MockSetSerialTrackingUseCase
+	register    *MockRegisterSerialsUseCase
+	list        *MockListSerialsUseCase
+	reservation *MockGetReservationSerialsUseCase
+	returnUnit  *MockReturnSerialUseCase
+	restock     *MockRestockSerialUseCase
+}
+
+func setupSerialRouter() (*gin.Engine, *serialMocks) {
+	gin.SetMode(gin.TestMode)
+	m := &serialMocks{
+		setTracking: new(MockSetSerialTrackingUseCase),
+		register:    new(MockRegisterSerialsUseCase),
+		list:        new(MockListSerialsUseCase),
+		reservation: new(MockGetReservationSerialsUseCase),
+		returnUnit:  new(MockReturnSerialUseCase),
+		restock:     new(MockRestockSerialUseCase),
+	}
+	h := NewSerialHandler(m.setTracking, m.register, m.list, m.reservation, m.returnUnit, m.restock)
+	router := gin.New()
+	router.PUT("/admin/inventory/:productId/serial-tracking", h.SetSerialTracking)
+	router.POST("/admin/inventory/:productId/serials", h.RegisterSerials)
+	router.GET("/admin/inventory/:productId/serials", h.ListSerials)
+	router.POST("/admin/inventory/:productId/serials/:serial/return", h.ReturnSerial)
+	router.POST("/admin/inventory/:productId/serials/:serial/restock", h.RestockSerial)
+	router.GET("/admin/reservations/:id/serials", h.GetReservationSerials)
+	return router, m
+}
+
+// serialItem returns a serial-tracked item of productID with quantity units, reserved of them reserved
+func serialItem(t *testing.T, productID uuid.UUID, quantity, reserved int) *entity.InventoryItem {
+	item, err := entity.NewInventoryItem(productID, 0)
+	require.NoError(t, err)
+	require.NoError(t, item.EnableSerialTracking())
+	item.Quantity = quantity
+	item.Reserved = reserved
+	return item
+}
+
+func TestNewSerialHandler_NilUseCases_Panic(t *testing.T) {
+	_, m := setupSerialRouter()
+	assert.Panics(t, func() { NewSerialHandler(nil, m.register, m.list, m.reservation, m.returnUnit, m.restock) })
+	assert.Panics(t, func() { NewSerialHandler(m.setTracking, nil, m.list, m.reservation, m.returnUnit, m.restock) })
+	assert.Panics(t, func() { NewSerialHandler(m.setTracking, m.register, nil, m.reservation, m.returnUnit, m.restock) })
+	assert.Panics(t, func() { NewSerialHandler(m.setTracking, m.register, m.list, nil, m.returnUnit, m.restock) })
+	assert.Panics(t, func() { NewSerialHandler(m.setTracking, m.register, m.list, m.reservation, nil, m.restock) })
+	assert.Panics(t, func() { NewSerialHandler(m.setTracking, m.register, m.list, m.reservation, m.returnUnit, nil) })
+}
+
+func TestSerialHandler_SetSerialTracking(t *testing.T) {
+	productID := uuid.New()
+	path := "/admin/inventory/" + productID.String() + "/serial-tracking"
+
+	t.Run("should turn tracking on", func(t *testing.T) {
+		router, m := setupSerialRouter()
+		m.setTracking.On("Execute", mock.Anything, productID, true).Return(serialItem(t, productID, 0, 0), nil)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPut, path, strings.NewReader(`{"enabled":true}`)))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var response SerialStockResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.True(t, response.SerialTracked)
+		assert.Equal(t, productID.String(), response.ProductID)
+	})
+
+	t.Run("should require enabled", func(t *testing.T) {
+		router, m := setupSerialRouter()
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPut, path, strings.NewReader(`{}`)))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		m.setTracking.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should reject changes of items with stock", func(t *testing.T) {
+		router, m := setupSerialRouter()
+		m.setTracking.On("Execute", mock.Anything, productID, false).
+			Return(nil, domainErrors.ErrSerialTrackingChange.WithDetails("the item has 3 units in stock"))
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPut, path, strings.NewReader(`{"enabled":false}`)))
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Contains(t, w.Body.String(), "serial_tracking_change")
+		assert.Contains(t, w.Body.String(), "3 units in stock")
+	})
+}
+
+func TestSerialHandler_RegisterSerials(t *testing.T) {
+	productID := uuid.New()
+	item := serialItem(t, productID, 2, 0)
+	units := []*entity.SerialUnit{
+		{SerialNumber: "SN-1", Status: entity.SerialAvailable},
+		{SerialNumber: "SN-2", Status: entity.SerialAvailable},
+	}
+	router, m := setupSerialRouter()
+	m.register.On("Execute", mock.Anything, usecase.RegisterSerialsInput{ProductID: productID, SerialNumbers: []string{"SN-1", "SN-2"}}).
+		Return(&usecase.RegisterSerialsOutput{Units: units, Item: item}, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/admin/inventory/"+productID.String()+"/serials",
+		strings.NewReader(`{"serial_numbers":["SN-1","SN-2"]}`)))
+
+	require.Equal(t, http.StatusCreated, w.Code)
+	var response SerialStockResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 2, response.Quantity)
+	assert.Equal(t, 2, response.Available)
+	require.Len(t, response.Serials, 2)
+	assert.Equal(t, "SN-2", response.Serials[1].SerialNumber)
+	assert.Equal(t, "available", response.Serials[1].Status)
+}
+
+func TestSerialHandler_RegisterSerials_Errors(t *testing.T) {
+	productID := uuid.New().String()
+	valid := `{"serial_numbers":["SN-1"]}`
+	tests := []struct {
+		name       string
+		path       string
+		body       string
+		ucErr      error
+		wantStatus int
+		wantError  string
+	}{
+		{"invalid product", "/admin/inventory/abc/serials", valid, nil, http.StatusBadRequest, "invalid_product_id"},
+		{"missing serial numbers", "/admin/inventory/" + productID + "/serials", `{}`, nil, http.StatusBadRequest, "invalid_request"},
+		{"invalid serial", "/admin/inventory/" + productID + "/serials", valid, domainErrors.ErrInvalidInput.WithDetails("duplicate serial number: SN-1"), http.StatusBadRequest, "invalid_serial"},
+		{"no inventory", "/admin/inventory/" + productID + "/serials", valid, domainErrors.ErrInventoryItemNotFound, http.StatusNotFound, "product_not_found"},
+		{"not tracked", "/admin/inventory/" + productID + "/serials", valid, domainErrors.ErrNotSerialTracked, http.StatusConflict, "not_serial_tracked"},
+		{"duplicate", "/admin/inventory/" + productID + "/serials", valid, domainErrors.ErrSerialUnitAlreadyExists.WithDetails("SN-1"), http.StatusConflict, "serial_already_exists"},
+		{"bundle", "/admin/inventory/" + productID + "/serials", valid, domainErrors.ErrBundleItem, http.StatusConflict, "bundle_item"},
+		{"database", "/admin/inventory/" + productID + "/serials", valid, errors.New("connection refused"), http.StatusInternalServerError, "internal_server_error"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, m := setupSerialRouter()
+			m.register.On("Execute", mock.Anything, mock.Anything).Return(nil, tt.ucErr)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body)))
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Contains(t, w.Body.String(), tt.wantError)
+		})
+	}
+}
+
+func TestSerialHandler_ListSerials(t *testing.T) {
+	productID := uuid.New()
+	reservationID := uuid.New()
+	path := "/admin/inventory/" + productID.String() + "/serials"
+
+	t.Run("should filter by status", func(t *testing.T) {
+		router, m := setupSerialRouter()
+		m.list.On("Execute", mock.Anything, productID, entity.SerialReserved).Return([]*entity.SerialUnit{
+			{SerialNumber: "SN-1", Status: entity.SerialReserved, ReservationID: &reservationID},
+		}, nil)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path+"?status=reserved", nil))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var response ListSerialsResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Len(t, response.Serials, 1)
+		assert.Equal(t, reservationID.String(), response.Serials[0].ReservationID)
+	})
+
+	t.Run("should list every unit without a status and render an empty list", func(t *testing.T) {
+		router, m := setupSerialRouter()
+		m.list.On("Execute", mock.Anything, productID, entity.SerialUnitStatus("")).Return([]*entity.SerialUnit{}, nil)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"product_id":"`+productID.String()+`","serials":[]}`, w.Body.String())
+	})
+
+	t.Run("should reject unknown statuses", func(t *testing.T) {
+		router, m := setupSerialRouter()
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path+"?status=lost", nil))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "invalid_status")
+		m.list.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestSerialHandler_GetReservationSerials(t *testing.T) {
+	reservationID := uuid.New()
+
+	router, m := setupSerialRouter()
+	m.reservation.On("Execute", mock.Anything, reservationID).Return([]*entity.SerialUnit{
+		{SerialNumber: "SN-1", Status: entity.SerialSold, ReservationID: &reservationID},
+	}, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/reservations/"+reservationID.String()+"/serials", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var response ReservationSerialsResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, reservationID.String(), response.ReservationID)
+	require.Len(t, response.Serials, 1)
+	assert.Equal(t, "sold", response.Serials[0].Status)
+
+	router, m = setupSerialRouter()
+	m.reservation.On("Execute", mock.Anything, mock.Anything).Return(nil, domainErrors.ErrReservationNotFound)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/reservations/"+uuid.New().String()+"/serials", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/reservations/abc/serials", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestSerialHandler_ReturnAndRestockSerial(t *testing.T) {
+	productID := uuid.New()
+	base := "/admin/inventory/" + productID.String() + "/serials/SN-1"
+
+	t.Run("should return a sold unit", func(t *testing.T) {
+		router, m := setupSerialRouter()
+		m.returnUnit.On("Execute", mock.Anything, productID, "SN-1").
+			Return(&entity.SerialUnit{SerialNumber: "SN-1", Status: entity.SerialReturned}, nil)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, base+"/return", nil))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var response SerialUnitResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "returned", response.Status)
+	})
+
+	t.Run("should restock a returned unit", func(t *testing.T) {
+		router, m := setupSerialRouter()
+		m.restock.On("Execute", mock.Anything, productID, "SN-1").Return(&usecase.RestockSerialOutput{
+			Unit: &entity.SerialUnit{SerialNumber: "SN-1", Status: entity.SerialAvailable},
+			Item: serialItem(t, productID, 4, 1),
+		}, nil)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, base+"/restock", nil))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var response SerialStockResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, 3, response.Available)
+		require.Len(t, response.Serials, 1)
+		assert.Equal(t, "available", response.Serials[0].Status)
+	})
+
+	t.Run("should map unit errors", func(t *testing.T) {
+		router, m := setupSerialRouter()
+		m.returnUnit.On("Execute", mock.Anything, productID, "SN-1").
+			Return(nil, domainErrors.ErrInvalidSerialTransition.WithDetails("serial SN-1 is reserved, not sold"))
+		m.restock.On("Execute", mock.Anything, productID, "SN-1").Return(nil, domainErrors.ErrSerialUnitNotFound)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, base+"/return", nil))
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Contains(t, w.Body.String(), "is reserved, not sold")
+
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, base+"/restock", nil))
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Contains(t, w.Body.String(), "serial_not_found")
+	})
+}
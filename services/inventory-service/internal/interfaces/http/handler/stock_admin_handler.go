@@ -0,0 +1,244 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/application/usecase"
+	domainErrors "github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/infrastructure/stockfile"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/interfaces/http/middleware"
+)
+
+// MaxStockImportBytes bounds the size of an uploaded stock count file
+const MaxStockImportBytes = 10 << 20
+
+// ImportStockExecutor interface for importing stock counts
+type ImportStockExecutor interface {
+	Execute(ctx context.Context, input usecase.ImportStockInput) (*usecase.ImportStockOutput, error)
+}
+
+// ExportStockExecutor interface for exporting stock levels
+type ExportStockExecutor interface {
+	Execute(ctx context.Context, writer usecase.StockLevelWriter) (int, error)
+}
+
+// StockAdminHandler handles bulk stock import and export
+type StockAdminHandler struct {
+	importStockUC ImportStockExecutor
+	exportStockUC ExportStockExecutor
+}
+
+// NewStockAdminHandler creates a new StockAdminHandler
+func NewStockAdminHandler(importStockUC ImportStockExecutor, exportStockUC ExportStockExecutor) *StockAdminHandler {
+	if importStockUC == nil {
+		panic("importStockUC cannot be nil")
+	}
+	if exportStockUC == nil {
+		panic("exportStockUC cannot be nil")
+	}
+
+	return &StockAdminHandler{
+		importStockUC: importStockUC,
+		exportStockUC: exportStockUC,
+	}
+}
+
+// StockImportRowResponse represents the outcome of one imported row
+type StockImportRowResponse struct {
+	Line            int    `json:"line"`
+	ProductID       string `json:"productId,omitempty"`
+	SKU             string `json:"sku,omitempty"`
+	InventoryItemID string `json:"inventoryItemId,omitempty"`
+	Status          string `json:"status"`
+	Before          int    `json:"before"`
+	After           int    `json:"after"`
+	Reserved        int    `json:"reserved"`
+	Error           string `json:"error,omitempty"`
+}
+
+// ImportStockResponse represents the per-row report of an import
+type ImportStockResponse struct {
+	DryRun    bool                     `json:"dryRun"`
+	Total     int                      `json:"total"`
+	Changed   int                      `json:"changed"`
+	Unchanged int                      `json:"unchanged"`
+	Failed    int                      `json:"failed"`
+	Rows      []StockImportRowResponse `json:"rows"`
+}
+
+// ImportStock handles POST /admin/inventory/import
+// @Summary Import stock counts
+// @Description Applies absolute quantities or deltas from a CSV or JSON Lines file, sent as the
+// @Description request body or as the "file" field of a multipart form. Every row is validated;
+// @Description with dry_run=true nothing is written and the report previews the changes.
+// @Tags Admin, Inventory
+// @Accept text/csv,application/x-ndjson,multipart/form-data
+// @Produce json
+// @Param format query string false "csv or jsonl; guessed from the upload otherwise"
+// @Param dry_run query bool false "validate and preview without writing"
+// @Success 200 {object} ImportStockResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 413 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/inventory/import [post]
+func (h *StockAdminHandler) ImportStock(c *gin.Context) {
+	dryRun, err := strconv.ParseBool(c.DefaultQuery("dry_run", "false"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_dry_run", "message": "dry_run must be a boolean"})
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, MaxStockImportBytes)
+	body, format, err := stockUpload(c)
+	if err != nil {
+		respondUploadError(c, err)
+		return
+	}
+	defer body.Close()
+
+	rows, err := stockfile.Read(body, format)
+	if err != nil {
+		respondUploadError(c, err)
+		return
+	}
+
+	output, err := h.importStockUC.Execute(c.Request.Context(), usecase.ImportStockInput{Rows: rows, DryRun: dryRun})
+	if err != nil {
+		_ = c.Error(err)
+		if errors.Is(err, domainErrors.ErrInvalidInput) {
+			var domainErr *domainErrors.DomainError
+			errors.As(err, &domainErr)
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_file", "message": domainErr.Details})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "internal_server_error",
+			"message": "Failed to import stock",
+		})
+		return
+	}
+
+	if !output.DryRun {
+		middleware.SetAuditAffectedIDs(c, output.ChangedItemIDs()...)
+	}
+
+	response := ImportStockResponse{
+		DryRun:    output.DryRun,
+		Total:     output.Total,
+		Changed:   output.Changed,
+		Unchanged: output.Unchanged,
+		Failed:    output.Failed,
+		Rows:      make([]StockImportRowResponse, len(output.Rows)),
+	}
+	for i, row := range output.Rows {
+		response.Rows[i] = StockImportRowResponse{
+			Line:     row.Line,
+			SKU:      row.SKU,
+			Status:   string(row.Status),
+			Before:   row.Before,
+			After:    row.After,
+			Reserved: row.Reserved,
+			Error:    row.Error,
+		}
+		if row.ProductID != uuid.Nil {
+			response.Rows[i].ProductID = row.ProductID.String()
+		}
+		if row.InventoryItemID != uuid.Nil {
+			response.Rows[i].InventoryItemID = row.InventoryItemID.String()
+		}
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// respondUploadError reports a file that could not be read
+func respondUploadError(c *gin.Context, err error) {
+	_ = c.Error(err)
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{
+			"error":   "file_too_large",
+			"message": fmt.Sprintf("the file must not exceed %d bytes", MaxStockImportBytes),
+		})
+		return
+	}
+	c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_file", "message": err.Error()})
+}
+
+// stockUpload returns the uploaded file and its format. Multipart forms carry
+// the file in the "file" field; any other request carries it as the body.
+func stockUpload(c *gin.Context) (io.ReadCloser, stockfile.Format, error) {
+	body, name := c.Request.Body, ""
+	mediaType, _, _ := mime.ParseMediaType(c.GetHeader("Content-Type"))
+
+	if mediaType == "multipart/form-data" {
+		file, header, err := c.Request.FormFile("file")
+		if err != nil {
+			return nil, "", fmt.Errorf("the form has no file field: %w", err)
+		}
+		body, name = file, header.Filename
+	}
+
+	if query := c.Query("format"); query != "" {
+		format, err := stockfile.ParseFormat(query)
+		if err != nil {
+			return nil, "", err
+		}
+		return body, format, nil
+	}
+	if mediaType == "application/x-ndjson" || mediaType == "application/jsonl" {
+		return body, stockfile.FormatJSONL, nil
+	}
+	return body, stockfile.FormatFromFilename(name), nil
+}
+
+// ExportStock handles GET /admin/inventory/export
+// @Summary Export stock levels
+// @Description Streams the quantity, reserved and available units of every inventory item,
+// @Description ordered by product ID. The CSV export can be edited and imported again.
+// @Tags Admin, Inventory
+// @Produce text/csv,application/x-ndjson
+// @Param format query string false "csv (default) or jsonl"
+// @Success 200 {string} string
+// @Failure 400 {object} ErrorResponse
+// @Router /admin/inventory/export [get]
+func (h *StockAdminHandler) ExportStock(c *gin.Context) {
+	format, err := stockfile.ParseFormat(c.DefaultQuery("format", string(stockfile.FormatCSV)))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_format", "message": err.Error()})
+		return
+	}
+
+	filename := fmt.Sprintf("stock-%s.%s", time.Now().UTC().Format("20060102-150405"), format)
+	c.Header("Content-Type", format.ContentType())
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Status(http.StatusOK)
+
+	// The status is sent with the first rows, so a failure halfway through can
+	// only be recorded; clients detect it by the truncated body
+	writer := stockfile.NewWriter(c.Writer, format)
+	if _, err := h.exportStockUC.Execute(c.Request.Context(), writer); err != nil {
+		_ = c.Error(err)
+		if !c.Writer.Written() {
+			c.Writer.Header().Del("Content-Disposition")
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error":   "internal_server_error",
+				"message": "Failed to export stock",
+			})
+		}
+		return
+	}
+	if err := writer.Flush(); err != nil {
+		_ = c.Error(err)
+	}
+}
@@ -0,0 +1,217 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/application/usecase"
+	domainErrors "github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/interfaces/http/middleware"
+)
+
+// MockImportStockUseCase is a mock for ImportStockExecutor
+type MockImportStockUseCase struct {
+	mock.Mock
+}
+
+func (m *MockImportStockUseCase) Execute(ctx context.Context, input usecase.ImportStockInput) (*usecase.ImportStockOutput, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.ImportStockOutput), args.Error(1)
+}
+
+// MockExportStockUseCase is a mock for ExportStockExecutor
+type MockExportStockUseCase struct {
+	mock.Mock
+}
+
+func (m *MockExportStockUseCase) Execute(ctx context.Context, writer usecase.StockLevelWriter) (int, error) {
+	args := m.Called(ctx, writer)
+	if levels, ok := args.Get(0).([]usecase.StockLevel); ok {
+		for _, level := range levels {
+			if err := writer.Write(level); err != nil {
+				return 0, err
+			}
+		}
+		return len(levels), args.Error(1)
+	}
+	return 0, args.Error(1)
+}
+
+func setupStockAdminRouter(importUC *MockImportStockUseCase, exportUC *MockExportStockUseCase) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	h := NewStockAdminHandler(importUC, exportUC)
+	router := gin.New()
+	router.POST("/admin/inventory/import", h.ImportStock)
+	router.GET("/admin/inventory/export", h.ExportStock)
+	return router
+}
+
+func TestNewStockAdminHandler_NilUseCases_Panic(t *testing.T) {
+	assert.Panics(t, func() { NewStockAdminHandler(nil, new(MockExportStockUseCase)) })
+	assert.Panics(t, func() { NewStockAdminHandler(new(MockImportStockUseCase), nil) })
+}
+
+func TestStockAdminHandler_ImportStock_CSVBody(t *testing.T) {
+	importUC := new(MockImportStockUseCase)
+	productID, itemID := uuid.New(), uuid.New()
+	importUC.On("Execute", mock.Anything, mock.MatchedBy(func(input usecase.ImportStockInput) bool {
+		return input.DryRun && len(input.Rows) == 2 && input.Rows[0].ProductID == productID && *input.Rows[0].Quantity == 40
+	})).Return(&usecase.ImportStockOutput{
+		DryRun:  true,
+		Total:   2,
+		Changed: 1,
+		Failed:  1,
+		Rows: []usecase.StockImportRowResult{
+			{Line: 2, ProductID: productID, InventoryItemID: itemID, Status: usecase.StockImportChanged, Before: 50, After: 40, Reserved: 5},
+			{Line: 3, Status: usecase.StockImportFailed, Error: "product_id is required"},
+		},
+	}, nil)
+
+	router := setupStockAdminRouter(importUC, new(MockExportStockUseCase))
+	body := "product_id,quantity\n" + productID.String() + ",40\n,3\n"
+	req := httptest.NewRequest(http.MethodPost, "/admin/inventory/import?dry_run=true", strings.NewReader(body))
+	req.Header.Set("Content-Type", "text/csv")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var response ImportStockResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.True(t, response.DryRun)
+	assert.Equal(t, 1, response.Failed)
+	assert.Equal(t, StockImportRowResponse{
+		Line: 2, ProductID: productID.String(), InventoryItemID: itemID.String(), Status: "changed", Before: 50, After: 40, Reserved: 5,
+	}, response.Rows[0])
+	assert.Empty(t, response.Rows[1].ProductID)
+	assert.Equal(t, "product_id is required", response.Rows[1].Error)
+	importUC.AssertExpectations(t)
+}
+
+func TestStockAdminHandler_ImportStock_MultipartJSONL(t *testing.T) {
+	importUC := new(MockImportStockUseCase)
+	productID, itemID := uuid.New(), uuid.New()
+	importUC.On("Execute", mock.Anything, mock.MatchedBy(func(input usecase.ImportStockInput) bool {
+		return !input.DryRun && len(input.Rows) == 1 && *input.Rows[0].Delta == -2
+	})).Return(&usecase.ImportStockOutput{
+		Total:   1,
+		Changed: 1,
+		Rows:    []usecase.StockImportRowResult{{Line: 1, ProductID: productID, InventoryItemID: itemID, Status: usecase.StockImportChanged}},
+	}, nil)
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	part, _ := form.CreateFormFile("file", "counts.jsonl")
+	_, _ = part.Write([]byte(`{"product_id":"` + productID.String() + `","delta":-2}` + "\n"))
+	require.NoError(t, form.Close())
+
+	gin.SetMode(gin.TestMode)
+	h := NewStockAdminHandler(importUC, new(MockExportStockUseCase))
+	var audited []string
+	router := gin.New()
+	router.POST("/admin/inventory/import", func(c *gin.Context) {
+		c.Next()
+		audited = c.GetStringSlice(middleware.AuditAffectedIDsKey)
+	}, h.ImportStock)
+	req := httptest.NewRequest(http.MethodPost, "/admin/inventory/import", &body)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, []string{itemID.String()}, audited)
+	importUC.AssertExpectations(t)
+}
+
+func TestStockAdminHandler_ImportStock_BadRequests(t *testing.T) {
+	tests := []struct {
+		name   string
+		query  string
+		body   string
+		status int
+		errKey string
+	}{
+		{"invalid dry_run", "?dry_run=maybe", "product_id,quantity\n", http.StatusBadRequest, "invalid_dry_run"},
+		{"unknown format", "?format=xlsx", "product_id,quantity\n", http.StatusBadRequest, "invalid_file"},
+		{"missing columns", "", "sku\n", http.StatusBadRequest, "invalid_file"},
+		{"too large", "", "product_id,quantity\n" + strings.Repeat("x", MaxStockImportBytes), http.StatusRequestEntityTooLarge, "file_too_large"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			importUC := new(MockImportStockUseCase)
+			router := setupStockAdminRouter(importUC, new(MockExportStockUseCase))
+
+			req := httptest.NewRequest(http.MethodPost, "/admin/inventory/import"+tt.query, strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.status, w.Code)
+			assert.Contains(t, w.Body.String(), tt.errKey)
+			importUC.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestStockAdminHandler_ImportStock_UseCaseErrors(t *testing.T) {
+	importUC := new(MockImportStockUseCase)
+	importUC.On("Execute", mock.Anything, mock.Anything).Return(nil, domainErrors.ErrInvalidInput.WithDetails("the file has no rows")).Once()
+	importUC.On("Execute", mock.Anything, mock.Anything).Return(nil, errors.New("connection refused")).Once()
+	router := setupStockAdminRouter(importUC, new(MockExportStockUseCase))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/admin/inventory/import", strings.NewReader("product_id,quantity\n")))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "the file has no rows")
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/admin/inventory/import", strings.NewReader("product_id,quantity\n")))
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.NotContains(t, w.Body.String(), "connection refused")
+}
+
+func TestStockAdminHandler_ExportStock(t *testing.T) {
+	exportUC := new(MockExportStockUseCase)
+	productID := uuid.New()
+	exportUC.On("Execute", mock.Anything, mock.Anything).Return([]usecase.StockLevel{{ProductID: productID, Quantity: 10, Reserved: 3, Available: 7}}, nil)
+	router := setupStockAdminRouter(new(MockImportStockUseCase), exportUC)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/inventory/export?format=jsonl", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Header().Get("Content-Disposition"), ".jsonl")
+	assert.Contains(t, w.Body.String(), `"product_id":"`+productID.String()+`"`)
+	assert.Contains(t, w.Body.String(), `"available":7`)
+}
+
+func TestStockAdminHandler_ExportStock_Errors(t *testing.T) {
+	exportUC := new(MockExportStockUseCase)
+	exportUC.On("Execute", mock.Anything, mock.Anything).Return(nil, errors.New("connection refused"))
+	router := setupStockAdminRouter(new(MockImportStockUseCase), exportUC)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/inventory/export?format=xml", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/inventory/export", nil))
+	assert.Equal(t, http.StatusInternalServerError, w.Code, "nothing was streamed yet")
+	assert.Empty(t, w.Header().Get("Content-Disposition"))
+}
@@ -0,0 +1,183 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/application/usecase"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	domainErrors "github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/infrastructure/stockfile"
+)
+
+// GetStockAsOfExecutor interface for point-in-time stock lookups
+type GetStockAsOfExecutor interface {
+	Execute(ctx context.Context, productID uuid.UUID, at time.Time) (*entity.StockPosition, error)
+}
+
+// ExportStockAsOfExecutor interface for point-in-time stock exports
+type ExportStockAsOfExecutor interface {
+	Execute(ctx context.Context, at time.Time, writer usecase.StockLevelWriter) (int, error)
+}
+
+// StockHistoryHandler answers what the stock was at a past moment
+type StockHistoryHandler struct {
+	getAsOfUC    GetStockAsOfExecutor
+	exportAsOfUC ExportStockAsOfExecutor
+}
+
+// NewStockHistoryHandler creates a new StockHistoryHandler
+func NewStockHistoryHandler(getAsOfUC GetStockAsOfExecutor, exportAsOfUC ExportStockAsOfExecutor) *StockHistoryHandler {
+	if getAsOfUC == nil {
+		panic("getAsOfUC cannot be nil")
+	}
+	if exportAsOfUC == nil {
+		panic("exportAsOfUC cannot be nil")
+	}
+
+	return &StockHistoryHandler{
+		getAsOfUC:    getAsOfUC,
+		exportAsOfUC: exportAsOfUC,
+	}
+}
+
+// StockAsOfResponse represents the stock of a product at a past moment
+type StockAsOfResponse struct {
+	ProductID       string    `json:"product_id"`
+	InventoryItemID string    `json:"inventory_item_id"`
+	Quantity        int       `json:"quantity"`
+	Reserved        int       `json:"reserved"`
+	Available       int       `json:"available"`
+	ChangedAt       time.Time `json:"changed_at"`
+	AsOf            time.Time `json:"as_of"`
+}
+
+// GetStockAsOf handles GET /admin/inventory/:productId/as-of
+// @Summary Get the stock of a product at a past moment
+// @Description Rebuilds quantity, reserved and available units at the given time from the
+// @Description latest stock snapshot before it and the stock movements since.
+// @Tags Admin, Inventory
+// @Produce json
+// @Param productId path string true "Product ID (UUID)"
+// @Param ts query string true "RFC3339 timestamp"
+// @Success 200 {object} StockAsOfResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/inventory/{productId}/as-of [get]
+func (h *StockHistoryHandler) GetStockAsOf(c *gin.Context) {
+	productID, err := uuid.Parse(c.Param("productId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_product_id",
+			"message": "Invalid product ID format. Expected UUID.",
+		})
+		return
+	}
+	at, ok := bindAsOf(c)
+	if !ok {
+		return
+	}
+
+	position, err := h.getAsOfUC.Execute(c.Request.Context(), productID, at)
+	if err != nil {
+		respondStockHistoryError(c, err, "Failed to get stock as of the requested time")
+		return
+	}
+
+	c.JSON(http.StatusOK, StockAsOfResponse{
+		ProductID:       position.ProductID.String(),
+		InventoryItemID: position.InventoryItemID.String(),
+		Quantity:        position.Quantity,
+		Reserved:        position.Reserved,
+		Available:       position.Available(),
+		ChangedAt:       position.ChangedAt,
+		AsOf:            at.UTC(),
+	})
+}
+
+// ExportStockAsOf handles GET /admin/inventory/export/as-of
+// @Summary Export stock levels at a past moment
+// @Description Streams the stock of every inventory item at the given time, in the format of
+// @Description the current stock export, for month-end reports. Versions are written as 0.
+// @Tags Admin, Inventory
+// @Produce text/csv,application/x-ndjson
+// @Param ts query string true "RFC3339 timestamp"
+// @Param format query string false "csv (default) or jsonl"
+// @Success 200 {string} string
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/inventory/export/as-of [get]
+func (h *StockHistoryHandler) ExportStockAsOf(c *gin.Context) {
+	format, err := stockfile.ParseFormat(c.DefaultQuery("format", string(stockfile.FormatCSV)))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_format", "message": err.Error()})
+		return
+	}
+	at, ok := bindAsOf(c)
+	if !ok {
+		return
+	}
+
+	filename := fmt.Sprintf("stock-asof-%s.%s", at.UTC().Format("20060102-150405"), format)
+	c.Header("Content-Type", format.ContentType())
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Status(http.StatusOK)
+
+	// Validation and missing history fail before the first row; anything later
+	// can only be recorded, as in ExportStock
+	writer := stockfile.NewWriter(c.Writer, format)
+	if _, err := h.exportAsOfUC.Execute(c.Request.Context(), at, writer); err != nil {
+		if !c.Writer.Written() {
+			c.Writer.Header().Del("Content-Type")
+			c.Writer.Header().Del("Content-Disposition")
+			respondStockHistoryError(c, err, "Failed to export stock as of the requested time")
+			return
+		}
+		_ = c.Error(err)
+		return
+	}
+	if err := writer.Flush(); err != nil {
+		_ = c.Error(err)
+	}
+}
+
+// bindAsOf parses the required ts query parameter, answering 400 when it is missing or malformed
+func bindAsOf(c *gin.Context) (time.Time, bool) {
+	at, err := parseQueryTime(c, "ts")
+	if err != nil || at.IsZero() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_ts", "message": "ts must be an RFC3339 timestamp"})
+		return time.Time{}, false
+	}
+	return at, true
+}
+
+// respondStockHistoryError maps point-in-time query errors to HTTP responses
+func respondStockHistoryError(c *gin.Context, err error, message string) {
+	var domainErr *domainErrors.DomainError
+	switch {
+	case errors.Is(err, domainErrors.ErrInvalidInput) && errors.As(err, &domainErr):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_filter", "message": domainErr.Details})
+	case errors.Is(err, domainErrors.ErrStockHistoryUnavailable):
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "stock_history_unavailable",
+			"message": "No stock history is retained for the requested time",
+		})
+	case errors.Is(err, domainErrors.ErrInventoryItemNotFound):
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "product_not_found",
+			"message": "Product had no inventory at the requested time",
+		})
+	default:
+		_ = c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "internal_server_error",
+			"message": message,
+		})
+	}
+}
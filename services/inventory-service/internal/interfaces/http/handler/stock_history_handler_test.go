@@ -0,0 +1,168 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/application/usecase"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	domainErrors "github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+)
+
+// MockGetStockAsOfUseCase is a mock for GetStockAsOfExecutor
+type MockGetStockAsOfUseCase struct {
+	mock.Mock
+}
+
+func (m *MockGetStockAsOfUseCase) Execute(ctx context.Context, productID uuid.UUID, at time.Time) (*entity.StockPosition, error) {
+	args := m.Called(ctx, productID, at)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.StockPosition), args.Error(1)
+}
+
+// MockExportStockAsOfUseCase is a mock for ExportStockAsOfExecutor
+type MockExportStockAsOfUseCase struct {
+	mock.Mock
+}
+
+func (m *MockExportStockAsOfUseCase) Execute(ctx context.Context, at time.Time, writer usecase.StockLevelWriter) (int, error) {
+	args := m.Called(ctx, at, writer)
+	if levels, ok := args.Get(0).([]usecase.StockLevel); ok {
+		for _, level := range levels {
+			if err := writer.Write(level); err != nil {
+				return 0, err
+			}
+		}
+		return len(levels), args.Error(1)
+	}
+	return 0, args.Error(1)
+}
+
+func setupStockHistoryRouter(getUC *MockGetStockAsOfUseCase, exportUC *MockExportStockAsOfUseCase) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	h := NewStockHistoryHandler(getUC, exportUC)
+	router := gin.New()
+	router.GET("/admin/inventory/:productId/as-of", h.GetStockAsOf)
+	router.GET("/admin/inventory/export/as-of", h.ExportStockAsOf)
+	return router
+}
+
+func TestNewStockHistoryHandler_NilUseCases_Panic(t *testing.T) {
+	assert.Panics(t, func() { NewStockHistoryHandler(nil, new(MockExportStockAsOfUseCase)) })
+	assert.Panics(t, func() { NewStockHistoryHandler(new(MockGetStockAsOfUseCase), nil) })
+}
+
+func TestStockHistoryHandler_GetStockAsOf(t *testing.T) {
+	getUC := new(MockGetStockAsOfUseCase)
+	productID := uuid.New()
+	at := time.Date(2025, 11, 30, 23, 59, 59, 0, time.UTC)
+	changedAt := at.Add(-2 * time.Hour)
+	getUC.On("Execute", mock.Anything, productID, at).Return(&entity.StockPosition{
+		InventoryItemID: uuid.New(),
+		ProductID:       productID,
+		Quantity:        10,
+		Reserved:        4,
+		ChangedAt:       changedAt,
+	}, nil)
+	router := setupStockHistoryRouter(getUC, new(MockExportStockAsOfUseCase))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/inventory/"+productID.String()+"/as-of?ts=2025-11-30T23:59:59Z", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var response StockAsOfResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, productID.String(), response.ProductID)
+	assert.Equal(t, 10, response.Quantity)
+	assert.Equal(t, 4, response.Reserved)
+	assert.Equal(t, 6, response.Available)
+	assert.True(t, changedAt.Equal(response.ChangedAt))
+	assert.True(t, at.Equal(response.AsOf))
+}
+
+func TestStockHistoryHandler_GetStockAsOf_Errors(t *testing.T) {
+	productID := uuid.New().String()
+	tests := []struct {
+		name       string
+		path       string
+		ucErr      error
+		wantStatus int
+		wantError  string
+	}{
+		{"invalid product", "/admin/inventory/abc/as-of?ts=2025-11-30T00:00:00Z", nil, http.StatusBadRequest, "invalid_product_id"},
+		{"missing ts", "/admin/inventory/" + productID + "/as-of", nil, http.StatusBadRequest, "invalid_ts"},
+		{"malformed ts", "/admin/inventory/" + productID + "/as-of?ts=yesterday", nil, http.StatusBadRequest, "invalid_ts"},
+		{"future ts", "/admin/inventory/" + productID + "/as-of?ts=2025-11-30T00:00:00Z", domainErrors.ErrInvalidInput.WithDetails("ts must not be in the future"), http.StatusBadRequest, "invalid_filter"},
+		{"history pruned", "/admin/inventory/" + productID + "/as-of?ts=2025-11-30T00:00:00Z", domainErrors.ErrStockHistoryUnavailable, http.StatusNotFound, "stock_history_unavailable"},
+		{"no inventory", "/admin/inventory/" + productID + "/as-of?ts=2025-11-30T00:00:00Z", domainErrors.ErrInventoryItemNotFound, http.StatusNotFound, "product_not_found"},
+		{"database", "/admin/inventory/" + productID + "/as-of?ts=2025-11-30T00:00:00Z", errors.New("connection refused"), http.StatusInternalServerError, "internal_server_error"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getUC := new(MockGetStockAsOfUseCase)
+			getUC.On("Execute", mock.Anything, mock.Anything, mock.Anything).Return(nil, tt.ucErr)
+			router := setupStockHistoryRouter(getUC, new(MockExportStockAsOfUseCase))
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Contains(t, w.Body.String(), `"error":"`+tt.wantError+`"`)
+			if tt.ucErr == nil {
+				getUC.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestStockHistoryHandler_ExportStockAsOf(t *testing.T) {
+	exportUC := new(MockExportStockAsOfUseCase)
+	productID := uuid.New()
+	at := time.Date(2025, 11, 30, 23, 59, 59, 0, time.UTC)
+	exportUC.On("Execute", mock.Anything, at, mock.Anything).Return([]usecase.StockLevel{{ProductID: productID, Quantity: 10, Reserved: 3, Available: 7}}, nil)
+	router := setupStockHistoryRouter(new(MockGetStockAsOfUseCase), exportUC)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/inventory/export/as-of?ts=2025-11-30T23:59:59Z&format=jsonl", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Header().Get("Content-Disposition"), "stock-asof-20251130-235959.jsonl")
+	assert.Contains(t, w.Body.String(), `"product_id":"`+productID.String()+`"`)
+	assert.Contains(t, w.Body.String(), `"available":7`)
+}
+
+func TestStockHistoryHandler_ExportStockAsOf_Errors(t *testing.T) {
+	exportUC := new(MockExportStockAsOfUseCase)
+	exportUC.On("Execute", mock.Anything, mock.Anything, mock.Anything).Return(nil, domainErrors.ErrStockHistoryUnavailable)
+	router := setupStockHistoryRouter(new(MockGetStockAsOfUseCase), exportUC)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/inventory/export/as-of?ts=2025-11-30T00:00:00Z&format=xml", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/inventory/export/as-of", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), `"error":"invalid_ts"`)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/inventory/export/as-of?ts=2020-01-01T00:00:00Z", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code, "nothing was streamed yet")
+	assert.Contains(t, w.Body.String(), `"error":"stock_history_unavailable"`)
+	assert.Contains(t, w.Header().Get("Content-Type"), "application/json")
+	assert.Empty(t, w.Header().Get("Content-Disposition"))
+}
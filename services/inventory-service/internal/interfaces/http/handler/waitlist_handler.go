@@ -0,0 +1,332 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/application/usecase"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	domainErrors "github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+)
+
+// JoinWaitlistExecutor interface for putting an order on the waitlist of a product
+type JoinWaitlistExecutor interface {
+	Execute(ctx context.Context, input usecase.JoinWaitlistInput) (*entity.WaitlistEntry, error)
+}
+
+// WaitlistEntryExecutor interface for looking up or cancelling a waitlist entry
+type WaitlistEntryExecutor interface {
+	Execute(ctx context.Context, id uuid.UUID) (*entity.WaitlistEntry, error)
+}
+
+// ListWaitlistExecutor interface for listing the waitlist of a product
+type ListWaitlistExecutor interface {
+	Execute(ctx context.Context, productID uuid.UUID, status entity.WaitlistStatus) (*usecase.ListWaitlistOutput, error)
+}
+
+// WaitlistHandler handles the waitlists of products
+type WaitlistHandler struct {
+	joinUC   JoinWaitlistExecutor
+	getUC    WaitlistEntryExecutor
+	cancelUC WaitlistEntryExecutor
+	listUC   ListWaitlistExecutor
+}
+
+// NewWaitlistHandler creates a new WaitlistHandler
+func NewWaitlistHandler(
+	joinUC JoinWaitlistExecutor,
+	getUC WaitlistEntryExecutor,
+	cancelUC WaitlistEntryExecutor,
+	listUC ListWaitlistExecutor,
+) *WaitlistHandler {
+	if joinUC == nil {
+		panic("joinUC cannot be nil")
+	}
+	if getUC == nil {
+		panic("getUC cannot be nil")
+	}
+	if cancelUC == nil {
+		panic("cancelUC cannot be nil")
+	}
+	if listUC == nil {
+		panic("listUC cannot be nil")
+	}
+
+	return &WaitlistHandler{
+		joinUC:   joinUC,
+		getUC:    getUC,
+		cancelUC: cancelUC,
+		listUC:   listUC,
+	}
+}
+
+// JoinWaitlistRequest represents an order waiting for stock of a product.
+// TTLSeconds defaults to the configured waitlist TTL when zero.
+type JoinWaitlistRequest struct {
+	ProductID  string `json:"product_id" binding:"required"`
+	OrderID    string `json:"order_id" binding:"required"`
+	Quantity   int    `json:"quantity" binding:"required,min=1"`
+	Priority   int    `json:"priority" binding:"min=0,max=100"`
+	TTLSeconds int64  `json:"ttl_seconds" binding:"min=0"`
+	Channel    string `json:"channel"`
+}
+
+// WaitlistEntryResponse represents a waitlist entry
+type WaitlistEntryResponse struct {
+	ID              string `json:"id"`
+	InventoryItemID string `json:"inventory_item_id"`
+	OrderID         string `json:"order_id"`
+	Quantity        int    `json:"quantity"`
+	Priority        int    `json:"priority"`
+	Channel         string `json:"channel,omitempty"`
+	UserID          string `json:"user_id,omitempty"`
+	Status          string `json:"status"`
+	ExpiresAt       string `json:"expires_at"`
+	ReservationID   string `json:"reservation_id,omitempty"`
+	FailureReason   string `json:"failure_reason,omitempty"`
+	FulfilledAt     string `json:"fulfilled_at,omitempty"`
+	CreatedAt       string `json:"created_at"`
+}
+
+// ListWaitlistResponse represents the waitlist of a product
+type ListWaitlistResponse struct {
+	ProductID string                  `json:"product_id"`
+	Available int                     `json:"available"`
+	Entries   []WaitlistEntryResponse `json:"entries"`
+}
+
+// JoinWaitlist handles POST /api/inventory/waitlist
+// @Summary Put an order on the waitlist of a product
+// @Description Queues the order until enough stock of the product frees up, then reserves it automatically
+// @Description and publishes inventory.waitlist.fulfilled. Entries are served by descending priority, then
+// @Description first come, first served, for the end user of the request.
+// @Tags Inventory
+// @Accept json
+// @Produce json
+// @Param request body JoinWaitlistRequest true "Waitlist entry"
+// @Success 201 {object} WaitlistEntryResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /api/inventory/waitlist [post]
+func (h *WaitlistHandler) JoinWaitlist(c *gin.Context) {
+	var req JoinWaitlistRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	productID, err := uuid.Parse(req.ProductID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_product_id",
+			"message": "Invalid product ID format. Expected UUID.",
+		})
+		return
+	}
+	orderID, err := uuid.Parse(req.OrderID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_order_id",
+			"message": "Invalid order ID format. Expected UUID.",
+		})
+		return
+	}
+
+	input := usecase.JoinWaitlistInput{
+		ProductID: productID,
+		OrderID:   orderID,
+		Quantity:  req.Quantity,
+		Priority:  req.Priority,
+		Channel:   req.Channel,
+	}
+	if req.TTLSeconds > 0 {
+		ttl := time.Duration(req.TTLSeconds) * time.Second
+		input.TTL = &ttl
+	}
+
+	entry, err := h.joinUC.Execute(c.Request.Context(), input)
+	if err != nil {
+		respondWaitlistError(c, err, "Failed to join the waitlist")
+		return
+	}
+
+	c.JSON(http.StatusCreated, toWaitlistEntryResponse(entry))
+}
+
+// GetWaitlistEntry handles GET /api/inventory/waitlist/:id
+// @Summary Get a waitlist entry
+// @Description Returns the entry's status, and the reservation created for it once fulfilled.
+// @Tags Inventory
+// @Produce json
+// @Param id path string true "Waitlist entry ID (UUID)"
+// @Success 200 {object} WaitlistEntryResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/inventory/waitlist/{id} [get]
+func (h *WaitlistHandler) GetWaitlistEntry(c *gin.Context) {
+	h.executeOnEntry(c, h.getUC, "Failed to get the waitlist entry")
+}
+
+// CancelWaitlistEntry handles DELETE /api/inventory/waitlist/:id
+// @Summary Take an order off the waitlist
+// @Tags Inventory
+// @Produce json
+// @Param id path string true "Waitlist entry ID (UUID)"
+// @Success 200 {object} WaitlistEntryResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /api/inventory/waitlist/{id} [delete]
+func (h *WaitlistHandler) CancelWaitlistEntry(c *gin.Context) {
+	h.executeOnEntry(c, h.cancelUC, "Failed to cancel the waitlist entry")
+}
+
+// executeOnEntry runs an entry use case for the entry in the path and writes the entry
+func (h *WaitlistHandler) executeOnEntry(c *gin.Context, uc WaitlistEntryExecutor, message string) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_waitlist_entry_id",
+			"message": "Invalid waitlist entry ID format. Expected UUID.",
+		})
+		return
+	}
+
+	entry, err := uc.Execute(c.Request.Context(), id)
+	if err != nil {
+		respondWaitlistError(c, err, message)
+		return
+	}
+
+	c.JSON(http.StatusOK, toWaitlistEntryResponse(entry))
+}
+
+// ListWaitlist handles GET /admin/inventory/:productId/waitlist
+// @Summary List the waitlist of a product
+// @Description Returns the product's entries in serving order, optionally filtered by status.
+// @Tags Admin, Inventory
+// @Produce json
+// @Param productId path string true "Product ID (UUID)"
+// @Param status query string false "waiting, fulfilled, cancelled, expired or failed"
+// @Success 200 {object} ListWaitlistResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/inventory/{productId}/waitlist [get]
+func (h *WaitlistHandler) ListWaitlist(c *gin.Context) {
+	productID, ok := parseProductIDParam(c)
+	if !ok {
+		return
+	}
+
+	var status entity.WaitlistStatus
+	if raw := c.Query("status"); raw != "" {
+		parsed, err := entity.ParseWaitlistStatus(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "invalid_status",
+				"message": "status must be waiting, fulfilled, cancelled, expired or failed",
+			})
+			return
+		}
+		status = parsed
+	}
+
+	output, err := h.listUC.Execute(c.Request.Context(), productID, status)
+	if err != nil {
+		respondWaitlistError(c, err, "Failed to list the waitlist")
+		return
+	}
+
+	entries := make([]WaitlistEntryResponse, len(output.Entries))
+	for i, entry := range output.Entries {
+		entries[i] = toWaitlistEntryResponse(entry)
+	}
+	c.JSON(http.StatusOK, ListWaitlistResponse{
+		ProductID: productID.String(),
+		Available: output.Item.Available(),
+		Entries:   entries,
+	})
+}
+
+func toWaitlistEntryResponse(entry *entity.WaitlistEntry) WaitlistEntryResponse {
+	response := WaitlistEntryResponse{
+		ID:              entry.ID.String(),
+		InventoryItemID: entry.InventoryItemID.String(),
+		OrderID:         entry.OrderID.String(),
+		Quantity:        entry.Quantity,
+		Priority:        entry.Priority,
+		Channel:         entry.Channel,
+		UserID:          entry.UserID,
+		Status:          string(entry.Status),
+		ExpiresAt:       entry.ExpiresAt.Format(time.RFC3339),
+		FailureReason:   entry.FailureReason,
+		CreatedAt:       entry.CreatedAt.Format(time.RFC3339),
+	}
+	if entry.ReservationID != nil {
+		response.ReservationID = entry.ReservationID.String()
+	}
+	if entry.FulfilledAt != nil {
+		response.FulfilledAt = entry.FulfilledAt.Format(time.RFC3339)
+	}
+	return response
+}
+
+// respondWaitlistError maps waitlist errors to HTTP responses
+func respondWaitlistError(c *gin.Context, err error, message string) {
+	var domainErr *domainErrors.DomainError
+	switch {
+	case errors.Is(err, domainErrors.ErrWaitlistEntryNotFound):
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "waitlist_entry_not_found",
+			"message": "Waitlist entry not found",
+		})
+	case errors.Is(err, domainErrors.ErrInventoryItemNotFound):
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "product_not_found",
+			"message": "Product not found in inventory",
+		})
+	case errors.Is(err, domainErrors.ErrWaitlistEntryAlreadyExists):
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "waitlist_entry_exists",
+			"message": "Order is already on a waitlist",
+		})
+	case errors.Is(err, domainErrors.ErrReservationAlreadyExists):
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "reservation_exists",
+			"message": "Order already has a reservation",
+		})
+	case errors.Is(err, domainErrors.ErrWaitlistEntryNotWaiting) && errors.As(err, &domainErr):
+		c.JSON(http.StatusConflict, gin.H{"error": "waitlist_entry_not_waiting", "message": domainErr.Error()})
+	case errors.Is(err, domainErrors.ErrInvalidQuantity):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_quantity", "message": "Invalid quantity specified"})
+	case errors.Is(err, domainErrors.ErrInvalidDuration) && errors.As(err, &domainErr):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_ttl", "message": domainErr.Error()})
+	case errors.Is(err, domainErrors.ErrInvalidInput) && errors.As(err, &domainErr):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_waitlist_entry", "message": domainErr.Details})
+	case errors.Is(err, domainErrors.ErrInvalidChannel):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_channel",
+			"message": "Channel may only contain lowercase letters, digits, '-' and '_'",
+		})
+	case errors.Is(err, domainErrors.ErrInvalidUserID):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_user_id",
+			"message": "User ID must be 1 to 128 printable characters without spaces",
+		})
+	default:
+		_ = c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "internal_server_error",
+			"message": message,
+		})
+	}
+}
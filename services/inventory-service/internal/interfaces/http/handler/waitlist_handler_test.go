@@ -0,0 +1,321 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/application/usecase"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	domainErrors "github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+)
+
+// MockJoinWaitlistUseCase is a mock for JoinWaitlistExecutor
+type MockJoinWaitlistUseCase struct {
+	mock.Mock
+}
+
+func (m *MockJoinWaitlistUseCase) Execute(ctx context.Context, input usecase.JoinWaitlistInput) (*entity.WaitlistEntry, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.WaitlistEntry), args.Error(1)
+}
+
+// MockWaitlistEntryUseCase is a mock for WaitlistEntryExecutor
+type MockWaitlistEntryUseCase struct {
+	mock.Mock
+}
+
+func (m *MockWaitlistEntryUseCase) Execute(ctx context.Context, id uuid.UUID) (*entity.WaitlistEntry, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.WaitlistEntry), args.Error(1)
+}
+
+// MockListWaitlistUseCase is a mock for ListWaitlistExecutor
+type MockListWaitlistUseCase struct {
+	mock.Mock
+}
+
+func (m *MockListWaitlistUseCase) Execute(ctx context.Context, productID uuid.UUID, status entity.WaitlistStatus) (*usecase.ListWaitlistOutput, error) {
+	args := m.Called(ctx, productID, status)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.ListWaitlistOutput), args.Error(1)
+}
+
+type waitlistMocks struct {
+	join   *MockJoinWaitlistUseCase
+	get    *MockWaitlistEntryUseCase
+	cancel *MockWaitlistEntryUseCase
+	list   *MockListWaitlistUseCase
+}
+
+func setupWaitlistRouter() (*gin.Engine, *waitlistMocks) {
+	gin.SetMode(gin.TestMode)
+	m := &waitlistMocks{
+		join:   new(MockJoinWaitlistUseCase),
+		get:    new(MockWaitlistEntryUseCase),
+		cancel: new(MockWaitlistEntryUseCase),
+		list:   new(MockListWaitlistUseCase),
+	}
+	h := NewWaitlistHandler(m.join, m.get, m.cancel, m.list)
+	router := gin.New()
+	router.POST("/api/inventory/waitlist", h.JoinWaitlist)
+	router.GET("/api/inventory/waitlist/:id", h.GetWaitlistEntry)
+	router.DELETE("/api/inventory/waitlist/:id", h.CancelWaitlistEntry)
+	router.GET("/admin/inventory/:productId/waitlist", h.ListWaitlist)
+	return router, m
+}
+
+func newTestWaitlistEntry(t *testing.T) *entity.WaitlistEntry {
+	t.Helper()
+	entry, err := entity.NewWaitlistEntry(uuid.New(), uuid.New(), 3, 10, time.Hour)
+	require.NoError(t, err)
+	return entry
+}
+
+func TestNewWaitlistHandler_NilUseCases_Panic(t *testing.T) {
+	_, m := setupWaitlistRouter()
+	assert.Panics(t, func() { NewWaitlistHandler(nil, m.get, m.cancel, m.list) })
+	assert.Panics(t, func() { NewWaitlistHandler(m.join, nil, m.cancel, m.list) })
+	assert.Panics(t, func() { NewWaitlistHandler(m.join, m.get, nil, m.list) })
+	assert.Panics(t, func() { NewWaitlistHandler(m.join, m.get, m.cancel, nil) })
+}
+
+func TestWaitlistHandler_JoinWaitlist(t *testing.T) {
+	productID := uuid.New()
+	orderID := uuid.New()
+
+	t.Run("should put the order on the waitlist", func(t *testing.T) {
+		router, m := setupWaitlistRouter()
+		ttl := 30 * time.Minute
+		entry := newTestWaitlistEntry(t)
+		entry.Channel = "web"
+		m.join.On("Execute", mock.Anything, usecase.JoinWaitlistInput{
+			ProductID: productID,
+			OrderID:   orderID,
+			Quantity:  3,
+			Priority:  10,
+			TTL:       &ttl,
+			Channel:   "web",
+		}).Return(entry, nil)
+
+		body := `{"product_id":"` + productID.String() + `","order_id":"` + orderID.String() +
+			`","quantity":3,"priority":10,"ttl_seconds":1800,"channel":"web"}`
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/inventory/waitlist", strings.NewReader(body)))
+
+		require.Equal(t, http.StatusCreated, w.Code)
+		var response WaitlistEntryResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, entry.ID.String(), response.ID)
+		assert.Equal(t, "waiting", response.Status)
+		assert.Equal(t, 10, response.Priority)
+		assert.Equal(t, "web", response.Channel)
+		assert.Empty(t, response.ReservationID)
+		assert.Empty(t, response.FulfilledAt)
+	})
+
+	t.Run("should leave the ttl to the use case when omitted", func(t *testing.T) {
+		router, m := setupWaitlistRouter()
+		m.join.On("Execute", mock.Anything, usecase.JoinWaitlistInput{
+			ProductID: productID,
+			OrderID:   orderID,
+			Quantity:  1,
+		}).Return(newTestWaitlistEntry(t), nil)
+
+		body := `{"product_id":"` + productID.String() + `","order_id":"` + orderID.String() + `","quantity":1}`
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/inventory/waitlist", strings.NewReader(body)))
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	valid := `{"product_id":"` + productID.String() + `","order_id":"` + orderID.String() + `","quantity":1}`
+	tests := []struct {
+		name       string
+		body       string
+		ucErr      error
+		wantStatus int
+		wantError  string
+	}{
+		{"missing quantity", `{"product_id":"` + productID.String() + `","order_id":"` + orderID.String() + `"}`, nil, http.StatusBadRequest, "invalid_request"},
+		{"priority too high", `{"product_id":"` + productID.String() + `","order_id":"` + orderID.String() + `","quantity":1,"priority":101}`, nil, http.StatusBadRequest, "invalid_request"},
+		{"invalid product", `{"product_id":"abc","order_id":"` + orderID.String() + `","quantity":1}`, nil, http.StatusBadRequest, "invalid_product_id"},
+		{"invalid order", `{"product_id":"` + productID.String() + `","order_id":"abc","quantity":1}`, nil, http.StatusBadRequest, "invalid_order_id"},
+		{"no inventory", valid, domainErrors.ErrInventoryItemNotFound, http.StatusNotFound, "product_not_found"},
+		{"already waiting", valid, domainErrors.ErrWaitlistEntryAlreadyExists, http.StatusConflict, "waitlist_entry_exists"},
+		{"already reserved", valid, domainErrors.ErrReservationAlreadyExists, http.StatusConflict, "reservation_exists"},
+		{"ttl too long", valid, domainErrors.ErrInvalidDuration.WithDetails("ttl cannot exceed 168h0m0s"), http.StatusBadRequest, "ttl cannot exceed"},
+		{"invalid channel", valid, domainErrors.ErrInvalidChannel, http.StatusBadRequest, "invalid_channel"},
+		{"invalid user", valid, domainErrors.ErrInvalidUserID, http.StatusBadRequest, "invalid_user_id"},
+		{"database", valid, errors.New("connection refused"), http.StatusInternalServerError, "internal_server_error"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, m := setupWaitlistRouter()
+			m.join.On("Execute", mock.Anything, mock.Anything).Return(nil, tt.ucErr)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/inventory/waitlist", strings.NewReader(tt.body)))
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Contains(t, w.Body.String(), tt.wantError)
+		})
+	}
+}
+
+func TestWaitlistHandler_GetWaitlistEntry(t *testing.T) {
+	t.Run("should return a fulfilled entry with its reservation", func(t *testing.T) {
+		router, m := setupWaitlistRouter()
+		entry := newTestWaitlistEntry(t)
+		reservationID := uuid.New()
+		require.NoError(t, entry.Fulfill(reservationID))
+		m.get.On("Execute", mock.Anything, entry.ID).Return(entry, nil)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/inventory/waitlist/"+entry.ID.String(), nil))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var response WaitlistEntryResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "fulfilled", response.Status)
+		assert.Equal(t, reservationID.String(), response.ReservationID)
+		assert.NotEmpty(t, response.FulfilledAt)
+	})
+
+	t.Run("should return 404 for an unknown entry", func(t *testing.T) {
+		router, m := setupWaitlistRouter()
+		id := uuid.New()
+		m.get.On("Execute", mock.Anything, id).Return(nil, domainErrors.ErrWaitlistEntryNotFound)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/inventory/waitlist/"+id.String(), nil))
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Contains(t, w.Body.String(), "waitlist_entry_not_found")
+	})
+
+	t.Run("should reject an invalid id", func(t *testing.T) {
+		router, _ := setupWaitlistRouter()
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/inventory/waitlist/abc", nil))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "invalid_waitlist_entry_id")
+	})
+}
+
+func TestWaitlistHandler_CancelWaitlistEntry(t *testing.T) {
+	t.Run("should return the cancelled entry", func(t *testing.T) {
+		router, m := setupWaitlistRouter()
+		entry := newTestWaitlistEntry(t)
+		require.NoError(t, entry.Cancel())
+		m.cancel.On("Execute", mock.Anything, entry.ID).Return(entry, nil)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/api/inventory/waitlist/"+entry.ID.String(), nil))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"status":"cancelled"`)
+	})
+
+	t.Run("should return 409 once the entry left the waitlist", func(t *testing.T) {
+		router, m := setupWaitlistRouter()
+		id := uuid.New()
+		m.cancel.On("Execute", mock.Anything, id).
+			Return(nil, domainErrors.ErrWaitlistEntryNotWaiting.WithDetails("status: fulfilled"))
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/api/inventory/waitlist/"+id.String(), nil))
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Contains(t, w.Body.String(), "waitlist_entry_not_waiting")
+		assert.Contains(t, w.Body.String(), "status: fulfilled")
+	})
+}
+
+func TestWaitlistHandler_ListWaitlist(t *testing.T) {
+	productID := uuid.New()
+	path := "/admin/inventory/" + productID.String() + "/waitlist"
+
+	t.Run("should list the entries with the available stock", func(t *testing.T) {
+		router, m := setupWaitlistRouter()
+		item, err := entity.NewInventoryItem(productID, 5)
+		require.NoError(t, err)
+		first, second := newTestWaitlistEntry(t), newTestWaitlistEntry(t)
+		m.list.On("Execute", mock.Anything, productID, entity.WaitlistWaiting).Return(&usecase.ListWaitlistOutput{
+			Item:    item,
+			Entries: []*entity.WaitlistEntry{first, second},
+		}, nil)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path+"?status=waiting", nil))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var response ListWaitlistResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, productID.String(), response.ProductID)
+		assert.Equal(t, 5, response.Available)
+		require.Len(t, response.Entries, 2)
+		assert.Equal(t, first.ID.String(), response.Entries[0].ID)
+		assert.Equal(t, second.ID.String(), response.Entries[1].ID)
+	})
+
+	t.Run("should list every status without a filter", func(t *testing.T) {
+		router, m := setupWaitlistRouter()
+		item, err := entity.NewInventoryItem(productID, 0)
+		require.NoError(t, err)
+		m.list.On("Execute", mock.Anything, productID, entity.WaitlistStatus("")).
+			Return(&usecase.ListWaitlistOutput{Item: item}, nil)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"entries":[]`)
+	})
+
+	tests := []struct {
+		name       string
+		path       string
+		ucErr      error
+		wantStatus int
+		wantError  string
+	}{
+		{"invalid product", "/admin/inventory/abc/waitlist", nil, http.StatusBadRequest, "invalid_product_id"},
+		{"invalid status", path + "?status=pending", nil, http.StatusBadRequest, "invalid_status"},
+		{"no inventory", path, domainErrors.ErrInventoryItemNotFound, http.StatusNotFound, "product_not_found"},
+		{"database", path, errors.New("connection refused"), http.StatusInternalServerError, "internal_server_error"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, m := setupWaitlistRouter()
+			m.list.On("Execute", mock.Anything, mock.Anything, mock.Anything).Return(nil, tt.ucErr)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Contains(t, w.Body.String(), tt.wantError)
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package middleware
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+)
+
+// AuditAffectedIDsKey holds the IDs of the entities changed by an admin operation
+const AuditAffectedIDsKey = "audit_affected_ids"
+
+// auditWriteTimeout bounds how long recording an entry may delay the response
+const auditWriteTimeout = 5 * time.Second
+
+// AdminOperationRecorder persists admin audit entries
+type AdminOperationRecorder interface {
+	Execute(ctx context.Context, entry *entity.AdminAuditEntry) error
+}
+
+// AdminAuditMiddleware records every admin mutation (any method other than GET, HEAD
+// and OPTIONS) in the audit log: actor, route, parameters, outcome and affected entity IDs.
+// It must run after ServiceAuthMiddleware so the actor is known; requests rejected there
+// are tracked by the authentication denial audit instead.
+//
+// Handlers report the entities they changed with SetAuditAffectedIDs and failures with c.Error.
+// A failure to write the entry is logged and does not change the response.
+func AdminAuditMiddleware(recorder AdminOperationRecorder) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !isMutation(c.Request.Method) {
+			c.Next()
+			return
+		}
+
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = c.Request.URL.Path
+		}
+
+		var actor string
+		principal, ok := PrincipalFromContext(c)
+		if ok {
+			actor = principal.Service
+		}
+
+		entry, err := entity.NewAdminAuditEntry(actor, c.Request.Method, route)
+		if err != nil {
+			log.Printf("⚠️  Failed to build admin audit entry for %s %s: %v", c.Request.Method, route, err)
+			return
+		}
+		if ok {
+			entry.KeyID = principal.KeyID
+			entry.TokenID = principal.TokenID
+		}
+		entry.Path = c.Request.URL.Path
+		entry.Parameters = auditParameters(c)
+		entry.RemoteAddr = c.ClientIP()
+		entry.AffectedIDs = c.GetStringSlice(AuditAffectedIDsKey)
+		if len(c.Errors) > 0 {
+			entry.ErrorMessage = c.Errors.String()
+		}
+		entry.Complete(c.Writer.Status(), time.Since(start))
+
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(c.Request.Context()), auditWriteTimeout)
+		defer cancel()
+		if err := recorder.Execute(ctx, entry); err != nil {
+			log.Printf("⚠️  Failed to record admin audit entry (actor=%s route=%s outcome=%s): %v",
+				entry.Actor, entry.Route, entry.Outcome, err)
+		}
+	}
+}
+
+// SetAuditAffectedIDs reports the IDs of the entities changed by the current admin operation
+func SetAuditAffectedIDs(c *gin.Context, ids ...string) {
+	c.Set(AuditAffectedIDsKey, append(c.GetStringSlice(AuditAffectedIDsKey), ids...))
+}
+
+func isMutation(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	}
+	return true
+}
+
+// auditParameters collects path and query parameters; repeated query values are comma-joined
+func auditParameters(c *gin.Context) map[string]string {
+	params := make(map[string]string, len(c.Params))
+	for _, p := range c.Params {
+		params[p.Key] = p.Value
+	}
+	for key, values := range c.Request.URL.Query() {
+		if _, exists := params[key]; !exists {
+			params[key] = strings.Join(values, ",")
+		}
+	}
+	if len(params) == 0 {
+		return nil
+	}
+	return params
+}
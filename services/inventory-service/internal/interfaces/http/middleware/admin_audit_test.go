@@ -0,0 +1,136 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/infrastructure/auth"
+)
+
+type recordingAuditRecorder struct {
+	entries []*entity.AdminAuditEntry
+	err     error
+}
+
+func (r *recordingAuditRecorder) Execute(ctx context.Context, entry *entity.AdminAuditEntry) error {
+	r.entries = append(r.entries, entry)
+	return r.err
+}
+
+func setupAdminAuditRouter(t *testing.T, recorder AdminOperationRecorder, withAuth bool) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	admin := router.Group("/admin")
+	if withAuth {
+		audit := auth.NewDenialAudit(10)
+		admin.Use(ServiceAuthMiddleware(newTestVerifier(t), audit))
+		admin.Use(AdminAuditMiddleware(recorder))
+		admin.POST("/dlq/:id/retry", RequireScopes(audit, auth.ScopeAdminDLQ), func(c *gin.Context) {
+			SetAuditAffectedIDs(c, c.Param("id"))
+			c.JSON(http.StatusOK, gin.H{"success": true})
+		})
+	} else {
+		admin.Use(AdminAuditMiddleware(recorder))
+		admin.POST("/dlq/:id/retry", func(c *gin.Context) {
+			_ = c.Error(errors.New("message not found in DLQ"))
+			c.JSON(http.StatusBadRequest, gin.H{"success": false})
+		})
+	}
+	admin.GET("/dlq", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"messages": []string{}})
+	})
+
+	return router
+}
+
+// TestAdminAuditMiddleware_RecordsSuccessfulMutation tests that actor, route, parameters and affected IDs are recorded
+func TestAdminAuditMiddleware_RecordsSuccessfulMutation(t *testing.T) {
+	recorder := &recordingAuditRecorder{}
+	router := setupAdminAuditRouter(t, recorder, true)
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/dlq/msg-1/retry?force=true", nil)
+	req.Header.Set("Authorization", "Bearer "+signTestToken(t, "ops-console", auth.ScopeAdminDLQ))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Len(t, recorder.entries, 1)
+	entry := recorder.entries[0]
+	assert.Equal(t, "ops-console", entry.Actor)
+	assert.NotEmpty(t, entry.KeyID)
+	assert.Equal(t, http.MethodPost, entry.Method)
+	assert.Equal(t, "/admin/dlq/:id/retry", entry.Route)
+	assert.Equal(t, "/admin/dlq/msg-1/retry", entry.Path)
+	assert.Equal(t, map[string]string{"id": "msg-1", "force": "true"}, entry.Parameters)
+	assert.Equal(t, entity.AdminAuditSuccess, entry.Outcome)
+	assert.Equal(t, http.StatusOK, entry.StatusCode)
+	assert.Equal(t, []string{"msg-1"}, entry.AffectedIDs)
+}
+
+// TestAdminAuditMiddleware_RecordsDeniedMutation tests that scope rejections are recorded as denied
+func TestAdminAuditMiddleware_RecordsDeniedMutation(t *testing.T) {
+	recorder := &recordingAuditRecorder{}
+	router := setupAdminAuditRouter(t, recorder, true)
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/dlq/msg-1/retry", nil)
+	req.Header.Set("Authorization", "Bearer "+signTestToken(t, "orders-service", auth.ScopeInventoryRead))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusForbidden, w.Code)
+	require.Len(t, recorder.entries, 1)
+	assert.Equal(t, "orders-service", recorder.entries[0].Actor)
+	assert.Equal(t, entity.AdminAuditDenied, recorder.entries[0].Outcome)
+	assert.Empty(t, recorder.entries[0].AffectedIDs)
+}
+
+// TestAdminAuditMiddleware_RecordsFailureWithoutAuth tests anonymous actors and handler errors
+func TestAdminAuditMiddleware_RecordsFailureWithoutAuth(t *testing.T) {
+	recorder := &recordingAuditRecorder{}
+	router := setupAdminAuditRouter(t, recorder, false)
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/dlq/msg-2/retry", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Len(t, recorder.entries, 1)
+	entry := recorder.entries[0]
+	assert.Equal(t, entity.AnonymousActor, entry.Actor)
+	assert.Equal(t, entity.AdminAuditFailure, entry.Outcome)
+	assert.Contains(t, entry.ErrorMessage, "message not found in DLQ")
+}
+
+// TestAdminAuditMiddleware_SkipsReads tests that read-only requests are not recorded
+func TestAdminAuditMiddleware_SkipsReads(t *testing.T) {
+	recorder := &recordingAuditRecorder{}
+	router := setupAdminAuditRouter(t, recorder, false)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/dlq", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, recorder.entries)
+}
+
+// TestAdminAuditMiddleware_RecorderErrorDoesNotChangeResponse tests that audit failures are non-fatal
+func TestAdminAuditMiddleware_RecorderErrorDoesNotChangeResponse(t *testing.T) {
+	recorder := &recordingAuditRecorder{err: errors.New("database unavailable")}
+	router := setupAdminAuditRouter(t, recorder, true)
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/dlq/msg-1/retry", nil)
+	req.Header.Set("Authorization", "Bearer "+signTestToken(t, "ops-console", auth.ScopeAdminDLQ))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Len(t, recorder.entries, 1)
+}
@@ -5,96 +5,143 @@ import (
 	"strings"
 
 	"github.com/gin-gonic/gin"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/infrastructure/auth"
 )
 
-// ServiceAuthMiddleware validates service-to-service authentication using API keys.
-// It supports two authentication methods:
-//  1. X-API-Key header with the API key directly
-//  2. Authorization header with "Bearer <api-key>" format
-//
-// The middleware also extracts the source service name from X-Source-Service header
-// for logging and audit purposes.
-//
-// Parameters:
-//   - validAPIKeys: Comma-separated list of valid API keys (e.g., "key1,key2,key3")
+// Context keys set by ServiceAuthMiddleware
+const (
+	// PrincipalKey holds the *auth.Principal of the authenticated caller
+	PrincipalKey = "principal"
+
+	// SourceServiceKey holds the calling service name taken from the token subject
+	SourceServiceKey = "source_service"
+)
+
+// TokenVerifier validates a signed service token
+type TokenVerifier interface {
+	Verify(token string) (*auth.Principal, error)
+}
+
+// DenialRecorder records rejected requests for auditing
+type DenialRecorder interface {
+	Record(denial auth.Denial)
+}
+
+// ServiceAuthMiddleware validates service-to-service authentication using signed tokens.
+// The token is sent as "Authorization: Bearer <token>"; its subject identifies the calling
+// service and its scopes are checked per route with RequireScopes.
 //
-// Returns:
-//   - gin.HandlerFunc: Middleware function for Gin router
+// The caller identity comes only from the verified token: X-Source-Service is no longer trusted.
 //
 // Example usage:
 //
-//	router := gin.Default()
-//	apiKeys := os.Getenv("SERVICE_API_KEYS")
-//	router.Use(ServiceAuthMiddleware(apiKeys))
-func ServiceAuthMiddleware(validAPIKeys string) gin.HandlerFunc {
-	// Parse valid API keys into a map for O(1) lookup
-	validKeysMap := make(map[string]bool)
-	keys := strings.Split(validAPIKeys, ",")
-	for _, key := range keys {
-		trimmedKey := strings.TrimSpace(key)
-		if trimmedKey != "" {
-			validKeysMap[trimmedKey] = true
-		}
-	}
-
+//	api := router.Group("/api")
+//	api.Use(ServiceAuthMiddleware(verifier, audit))
+//	api.GET("/inventory/:productId", RequireScopes(audit, auth.ScopeInventoryRead), handler)
+func ServiceAuthMiddleware(verifier TokenVerifier, audit DenialRecorder) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// Try to get API key from X-API-Key header first
-		apiKey := c.GetHeader("X-API-Key")
-
-		// If not found, try Authorization header with Bearer scheme
-		if apiKey == "" {
-			authHeader := c.GetHeader("Authorization")
-			if authHeader == "" {
-				c.JSON(http.StatusUnauthorized, gin.H{
-					"error":   "Missing API key",
-					"message": "Authentication required. Provide API key via X-API-Key header or Authorization: Bearer <token>",
-				})
-				c.Abort()
-				return
-			}
-
-			// Extract Bearer token
-			parts := strings.SplitN(authHeader, " ", 2)
-			if len(parts) != 2 {
-				c.JSON(http.StatusUnauthorized, gin.H{
-					"error":   "Invalid API key format",
-					"message": "Authorization header must use Bearer scheme: 'Bearer <api-key>'",
-				})
-				c.Abort()
+		token, err := bearerToken(c.GetHeader("Authorization"))
+		if err == nil {
+			var principal *auth.Principal
+			principal, err = verifier.Verify(token)
+			if err == nil {
+				c.Set(PrincipalKey, principal)
+				c.Set(SourceServiceKey, principal.Service)
+				c.Next()
 				return
 			}
+		}
 
-			scheme := parts[0]
-			if !strings.EqualFold(scheme, "Bearer") {
-				c.JSON(http.StatusUnauthorized, gin.H{
-					"error":   "Invalid API key format",
-					"message": "Only Bearer authentication scheme is supported",
-				})
-				c.Abort()
-				return
-			}
+		audit.Record(auth.Denial{
+			Transport:  "http",
+			Operation:  operation(c),
+			Reason:     auth.Reason(err),
+			Detail:     err.Error(),
+			RemoteAddr: c.ClientIP(),
+		})
 
-			apiKey = parts[1]
-		}
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "A valid service token is required. Provide it via Authorization: Bearer <token>",
+		})
+		c.Abort()
+	}
+}
 
-		// Validate API key
-		if !validKeysMap[apiKey] {
+// RequireScopes rejects requests whose token lacks any of the given scopes.
+// It must run after ServiceAuthMiddleware.
+func RequireScopes(audit DenialRecorder, scopes ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, ok := PrincipalFromContext(c)
+		if !ok {
+			// Misconfigured route: fail closed
+			audit.Record(auth.Denial{
+				Transport:      "http",
+				Operation:      operation(c),
+				Reason:         auth.ReasonMissingToken,
+				RequiredScopes: scopes,
+				RemoteAddr:     c.ClientIP(),
+			})
 			c.JSON(http.StatusUnauthorized, gin.H{
-				"error":   "Invalid API key",
-				"message": "The provided API key is not authorized to access this service",
+				"error":   "unauthorized",
+				"message": "A valid service token is required",
 			})
 			c.Abort()
 			return
 		}
 
-		// Extract source service name for logging/audit (optional)
-		sourceService := c.GetHeader("X-Source-Service")
-		if sourceService == "" {
-			sourceService = "unknown"
+		if missing := principal.MissingScopes(scopes...); len(missing) > 0 {
+			audit.Record(auth.Denial{
+				Transport:      "http",
+				Operation:      operation(c),
+				Reason:         auth.ReasonInsufficientScope,
+				Service:        principal.Service,
+				KeyID:          principal.KeyID,
+				RequiredScopes: scopes,
+				RemoteAddr:     c.ClientIP(),
+			})
+			c.JSON(http.StatusForbidden, gin.H{
+				"error":   "insufficient_scope",
+				"message": "The service token lacks required scopes: " + strings.Join(missing, ", "),
+			})
+			c.Abort()
+			return
 		}
-		c.Set("source_service", sourceService)
 
-		// API key is valid, continue to next handler
 		c.Next()
 	}
 }
+
+// PrincipalFromContext returns the authenticated caller set by ServiceAuthMiddleware
+func PrincipalFromContext(c *gin.Context) (*auth.Principal, bool) {
+	value, exists := c.Get(PrincipalKey)
+	if !exists {
+		return nil, false
+	}
+	principal, ok := value.(*auth.Principal)
+	return principal, ok
+}
+
+// bearerToken extracts the token from an "Authorization: Bearer <token>" header
+func bearerToken(header string) (string, error) {
+	if header == "" {
+		return "", auth.ErrMissingToken
+	}
+
+	parts := strings.SplitN(header, " ", 2)
+	if len(parts) != 2 || !strings.EqualFold(parts[0], "Bearer") || strings.TrimSpace(parts[1]) == "" {
+		return "", auth.ErrMissingToken
+	}
+
+	return strings.TrimSpace(parts[1]), nil
+}
+
+// operation describes the request for the audit using the route template, not the raw path
+func operation(c *gin.Context) string {
+	path := c.FullPath()
+	if path == "" {
+		path = c.Request.URL.Path
+	}
+	return c.Request.Method + " " + path
+}
@@ -4,275 +4,218 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/infrastructure/auth"
 )
 
-func TestServiceAuthMiddleware(t *testing.T) {
-	// Setup
+var testTokenSecret = []byte("0123456789abcdef0123456789abcdef")
+
+func newTestVerifier(t *testing.T) *auth.Verifier {
+	t.Helper()
+	verifier, err := auth.NewVerifier(
+		[]auth.Key{{ID: "k1", Algorithm: auth.AlgorithmHS256, Secret: testTokenSecret}},
+		auth.VerifierOptions{Audience: "inventory-service"},
+	)
+	require.NoError(t, err)
+	return verifier
+}
+
+func signTestToken(t *testing.T, service string, scopes ...string) string {
+	t.Helper()
+	token, err := auth.NewHMACSigner("k1", testTokenSecret, auth.SignerOptions{Audience: "inventory-service"}).
+		Sign(service, scopes, time.Hour)
+	require.NoError(t, err)
+	return token
+}
+
+func setupAuthRouter(t *testing.T, audit *auth.DenialAudit) *gin.Engine {
 	gin.SetMode(gin.TestMode)
-	validAPIKey := "test-service-api-key-12345"
+	router := gin.New()
+
+	// Public routes (no auth required)
+	router.GET("/health", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"status": "ok"})
+	})
+
+	api := router.Group("/api")
+	api.Use(ServiceAuthMiddleware(newTestVerifier(t), audit))
+	api.GET("/inventory/:productId", RequireScopes(audit, auth.ScopeInventoryRead), func(c *gin.Context) {
+		principal, _ := PrincipalFromContext(c)
+		c.JSON(http.StatusOK, gin.H{"message": "success", "service": principal.Service, "source": c.GetString(SourceServiceKey)})
+	})
+	api.POST("/admin/dlq", RequireScopes(audit, auth.ScopeAdminDLQ), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"message": "success"})
+	})
+
+	return router
+}
+
+func TestServiceAuthMiddleware(t *testing.T) {
+	readToken := signTestToken(t, "orders-service", auth.ScopeInventoryRead)
+	wrongKeyToken, err := auth.NewHMACSigner("k1", []byte("ffffffffffffffffffffffffffffffff"), auth.SignerOptions{Audience: "inventory-service"}).
+		Sign("orders-service", []string{auth.ScopeInventoryRead}, time.Hour)
+	require.NoError(t, err)
 
 	tests := []struct {
 		name               string
-		apiKey             string
-		headerName         string
+		method             string
+		path               string
+		authHeader         string
 		expectedStatusCode int
 		expectedBodyPart   string
+		expectedReason     string
 	}{
 		{
-			name:               "Valid API key in X-API-Key header",
-			apiKey:             validAPIKey,
-			headerName:         "X-API-Key",
+			name:               "Valid token with required scope",
+			method:             http.MethodGet,
+			path:               "/api/inventory/123",
+			authHeader:         "Bearer " + readToken,
 			expectedStatusCode: http.StatusOK,
-			expectedBodyPart:   "success",
+			expectedBodyPart:   "orders-service",
 		},
 		{
-			name:               "Valid API key in Authorization header as Bearer",
-			apiKey:             "Bearer " + validAPIKey,
-			headerName:         "Authorization",
+			name:               "Lowercase bearer scheme",
+			method:             http.MethodGet,
+			path:               "/api/inventory/123",
+			authHeader:         "bearer " + readToken,
 			expectedStatusCode: http.StatusOK,
 			expectedBodyPart:   "success",
 		},
 		{
-			name:               "Missing API key",
-			apiKey:             "",
-			headerName:         "X-API-Key",
+			name:               "Missing token",
+			method:             http.MethodGet,
+			path:               "/api/inventory/123",
 			expectedStatusCode: http.StatusUnauthorized,
-			expectedBodyPart:   "Missing API key",
+			expectedBodyPart:   "unauthorized",
+			expectedReason:     auth.ReasonMissingToken,
 		},
 		{
-			name:               "Invalid API key",
-			apiKey:             "invalid-key",
-			headerName:         "X-API-Key",
+			name:               "Non bearer scheme",
+			method:             http.MethodGet,
+			path:               "/api/inventory/123",
+			authHeader:         "Basic " + readToken,
 			expectedStatusCode: http.StatusUnauthorized,
-			expectedBodyPart:   "Invalid API key",
+			expectedBodyPart:   "unauthorized",
+			expectedReason:     auth.ReasonMissingToken,
 		},
 		{
-			name:               "Empty Authorization header",
-			apiKey:             "",
-			headerName:         "Authorization",
+			name:               "Static API key is no longer accepted",
+			method:             http.MethodGet,
+			path:               "/api/inventory/123",
+			authHeader:         "Bearer inventory-service-key-dev-12345",
 			expectedStatusCode: http.StatusUnauthorized,
-			expectedBodyPart:   "Missing API key",
+			expectedBodyPart:   "unauthorized",
+			expectedReason:     auth.ReasonMalformedToken,
 		},
 		{
-			name:               "Invalid Bearer token",
-			apiKey:             "Bearer wrong-key",
-			headerName:         "Authorization",
+			name:               "Token signed with another secret",
+			method:             http.MethodGet,
+			path:               "/api/inventory/123",
+			authHeader:         "Bearer " + wrongKeyToken,
 			expectedStatusCode: http.StatusUnauthorized,
-			expectedBodyPart:   "Invalid API key",
+			expectedBodyPart:   "unauthorized",
+			expectedReason:     auth.ReasonInvalidSignature,
 		},
 		{
-			name:               "Malformed Authorization header (no Bearer prefix)",
-			apiKey:             "SomeOtherScheme token",
-			headerName:         "Authorization",
-			expectedStatusCode: http.StatusUnauthorized,
-			expectedBodyPart:   "Invalid API key format",
+			name:               "Valid token without required scope",
+			method:             http.MethodPost,
+			path:               "/api/admin/dlq",
+			authHeader:         "Bearer " + readToken,
+			expectedStatusCode: http.StatusForbidden,
+			expectedBodyPart:   auth.ScopeAdminDLQ,
+			expectedReason:     auth.ReasonInsufficientScope,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// Setup router with middleware
-			router := gin.New()
-			router.Use(ServiceAuthMiddleware(validAPIKey))
+			audit := auth.NewDenialAudit(10)
+			router := setupAuthRouter(t, audit)
 
-			// Test endpoint
-			router.GET("/test", func(c *gin.Context) {
-				c.JSON(http.StatusOK, gin.H{"message": "success"})
-			})
-
-			// Create request
-			req := httptest.NewRequest(http.MethodGet, "/test", nil)
-			if tt.apiKey != "" {
-				req.Header.Set(tt.headerName, tt.apiKey)
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.authHeader != "" {
+				req.Header.Set("Authorization", tt.authHeader)
 			}
 
-			// Execute request
 			w := httptest.NewRecorder()
 			router.ServeHTTP(w, req)
 
-			// Assertions
 			assert.Equal(t, tt.expectedStatusCode, w.Code)
 			assert.Contains(t, w.Body.String(), tt.expectedBodyPart)
+
+			denials := audit.Recent(0)
+			if tt.expectedReason == "" {
+				assert.Empty(t, denials)
+				return
+			}
+			require.Len(t, denials, 1)
+			assert.Equal(t, tt.expectedReason, denials[0].Reason)
+			assert.Equal(t, "http", denials[0].Transport)
+			assert.Equal(t, tt.method+" "+map[string]string{
+				"/api/inventory/123": "/api/inventory/:productId",
+				"/api/admin/dlq":     "/api/admin/dlq",
+			}[tt.path], denials[0].Operation)
 		})
 	}
 }
 
-func TestServiceAuthMiddleware_MultipleValidKeys(t *testing.T) {
-	gin.SetMode(gin.TestMode)
+func TestServiceAuthMiddleware_IgnoresSourceServiceHeader(t *testing.T) {
+	router := setupAuthRouter(t, auth.NewDenialAudit(10))
 
-	// Multiple valid API keys (comma-separated)
-	validKeys := "key1,key2,key3"
+	req := httptest.NewRequest(http.MethodGet, "/api/inventory/123", nil)
+	req.Header.Set("Authorization", "Bearer "+signTestToken(t, "orders-service", auth.ScopeInventoryRead))
+	req.Header.Set("X-Source-Service", "admin-service")
 
-	tests := []struct {
-		name               string
-		apiKey             string
-		expectedStatusCode int
-	}{
-		{
-			name:               "First valid key",
-			apiKey:             "key1",
-			expectedStatusCode: http.StatusOK,
-		},
-		{
-			name:               "Second valid key",
-			apiKey:             "key2",
-			expectedStatusCode: http.StatusOK,
-		},
-		{
-			name:               "Third valid key",
-			apiKey:             "key3",
-			expectedStatusCode: http.StatusOK,
-		},
-		{
-			name:               "Invalid key",
-			apiKey:             "invalid",
-			expectedStatusCode: http.StatusUnauthorized,
-		},
-	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			router := gin.New()
-			router.Use(ServiceAuthMiddleware(validKeys))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"source":"orders-service"`)
+	assert.NotContains(t, w.Body.String(), "admin-service")
+}
 
-			router.GET("/test", func(c *gin.Context) {
-				c.JSON(http.StatusOK, gin.H{"message": "success"})
-			})
+func TestServiceAuthMiddleware_InsufficientScopeRecordsCaller(t *testing.T) {
+	audit := auth.NewDenialAudit(10)
+	router := setupAuthRouter(t, audit)
 
-			req := httptest.NewRequest(http.MethodGet, "/test", nil)
-			req.Header.Set("X-API-Key", tt.apiKey)
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/dlq", nil)
+	req.Header.Set("Authorization", "Bearer "+signTestToken(t, "orders-service", auth.ScopeInventoryRead))
 
-			w := httptest.NewRecorder()
-			router.ServeHTTP(w, req)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
 
-			assert.Equal(t, tt.expectedStatusCode, w.Code)
-		})
-	}
+	require.Len(t, audit.Recent(0), 1)
+	denial := audit.Recent(0)[0]
+	assert.Equal(t, "orders-service", denial.Service)
+	assert.Equal(t, "k1", denial.KeyID)
+	assert.Equal(t, []string{auth.ScopeAdminDLQ}, denial.RequiredScopes)
 }
 
-func TestServiceAuthMiddleware_PublicEndpoints(t *testing.T) {
+func TestRequireScopes_WithoutAuthenticationFailsClosed(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	validAPIKey := "test-key"
-
+	audit := auth.NewDenialAudit(10)
 	router := gin.New()
-
-	// Public routes (no auth required)
-	router.GET("/health", func(c *gin.Context) {
-		c.JSON(http.StatusOK, gin.H{"status": "ok"})
+	router.GET("/misconfigured", RequireScopes(audit, auth.ScopeInventoryRead), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"message": "success"})
 	})
-	router.GET("/metrics", func(c *gin.Context) {
-		c.JSON(http.StatusOK, gin.H{"metrics": "data"})
-	})
-
-	// Protected routes (auth required)
-	protected := router.Group("/api")
-	protected.Use(ServiceAuthMiddleware(validAPIKey))
-	protected.GET("/inventory", func(c *gin.Context) {
-		c.JSON(http.StatusOK, gin.H{"data": "inventory"})
-	})
-
-	tests := []struct {
-		name               string
-		path               string
-		apiKey             string
-		expectedStatusCode int
-	}{
-		{
-			name:               "Public /health endpoint without API key",
-			path:               "/health",
-			apiKey:             "",
-			expectedStatusCode: http.StatusOK,
-		},
-		{
-			name:               "Public /metrics endpoint without API key",
-			path:               "/metrics",
-			apiKey:             "",
-			expectedStatusCode: http.StatusOK,
-		},
-		{
-			name:               "Protected endpoint without API key",
-			path:               "/api/inventory",
-			apiKey:             "",
-			expectedStatusCode: http.StatusUnauthorized,
-		},
-		{
-			name:               "Protected endpoint with valid API key",
-			path:               "/api/inventory",
-			apiKey:             validAPIKey,
-			expectedStatusCode: http.StatusOK,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
-			if tt.apiKey != "" {
-				req.Header.Set("X-API-Key", tt.apiKey)
-			}
 
-			w := httptest.NewRecorder()
-			router.ServeHTTP(w, req)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/misconfigured", nil))
 
-			require.Equal(t, tt.expectedStatusCode, w.Code, "Status code mismatch for path: %s", tt.path)
-		})
-	}
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Len(t, audit.Recent(0), 1)
 }
 
-func TestServiceAuthMiddleware_SourceServiceHeader(t *testing.T) {
-	gin.SetMode(gin.TestMode)
-	validAPIKey := "test-key"
-
-	router := gin.New()
-	router.Use(ServiceAuthMiddleware(validAPIKey))
-
-	// Endpoint that reads the source service
-	router.GET("/test", func(c *gin.Context) {
-		sourceService := c.GetString("source_service")
-		c.JSON(http.StatusOK, gin.H{"source_service": sourceService})
-	})
-
-	tests := []struct {
-		name                  string
-		apiKey                string
-		sourceServiceHeader   string
-		expectedStatusCode    int
-		expectedSourceService string
-	}{
-		{
-			name:                  "Valid API key with source service",
-			apiKey:                validAPIKey,
-			sourceServiceHeader:   "orders-service",
-			expectedStatusCode:    http.StatusOK,
-			expectedSourceService: "orders-service",
-		},
-		{
-			name:                  "Valid API key without source service header",
-			apiKey:                validAPIKey,
-			sourceServiceHeader:   "",
-			expectedStatusCode:    http.StatusOK,
-			expectedSourceService: "unknown",
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			req := httptest.NewRequest(http.MethodGet, "/test", nil)
-			req.Header.Set("X-API-Key", tt.apiKey)
-			if tt.sourceServiceHeader != "" {
-				req.Header.Set("X-Source-Service", tt.sourceServiceHeader)
-			}
+func TestServiceAuthMiddleware_PublicEndpoints(t *testing.T) {
+	router := setupAuthRouter(t, auth.NewDenialAudit(10))
 
-			w := httptest.NewRecorder()
-			router.ServeHTTP(w, req)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health", nil))
 
-			assert.Equal(t, tt.expectedStatusCode, w.Code)
-			if w.Code == http.StatusOK {
-				assert.Contains(t, w.Body.String(), tt.expectedSourceService)
-			}
-		})
-	}
+	assert.Equal(t, http.StatusOK, w.Code)
 }
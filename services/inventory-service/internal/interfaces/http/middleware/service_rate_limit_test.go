@@ -0,0 +1,135 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/infrastructure/auth"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/infrastructure/ratelimit"
+)
+
+type erroringLimiter struct{}
+
+func (erroringLimiter) Allow(ctx context.Context, key string, quota ratelimit.Quota) (ratelimit.Decision, error) {
+	return ratelimit.Decision{}, errors.New("limiter unavailable")
+}
+
+func setupRateLimitRouter(t *testing.T, limiter QuotaLimiter, policy *ratelimit.Policy, withAuth bool) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	admin := router.Group("/admin")
+	if withAuth {
+		admin.Use(ServiceAuthMiddleware(newTestVerifier(t), auth.NewDenialAudit(10)))
+	}
+	admin.Use(ServiceRateLimitMiddleware(limiter, policy))
+	admin.GET("/dlq", func(c *gin.Context) { c.Status(http.StatusOK) })
+	admin.POST("/dlq/:id/retry", func(c *gin.Context) { c.Status(http.StatusOK) })
+	admin.POST("/reservations/release-expired", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	return router
+}
+
+func doRateLimitedRequest(router *gin.Engine, method, path, token, remoteAddr string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	if remoteAddr != "" {
+		req.RemoteAddr = remoteAddr
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+// TestServiceRateLimitMiddleware_KeyedByService tests that pods of one service share a quota across IPs
+func TestServiceRateLimitMiddleware_KeyedByService(t *testing.T) {
+	policy := &ratelimit.Policy{ReadLimit: 2, WriteLimit: 1, Window: time.Minute}
+	router := setupRateLimitRouter(t, ratelimit.NewLocalLimiter(), policy, true)
+	orders := signTestToken(t, "orders-service", auth.ScopeAdminDLQ)
+	reporting := signTestToken(t, "reporting-service", auth.ScopeAdminDLQ)
+
+	first := doRateLimitedRequest(router, http.MethodGet, "/admin/dlq", orders, "10.0.0.1:1234")
+	second := doRateLimitedRequest(router, http.MethodGet, "/admin/dlq", orders, "10.0.0.2:1234")
+	third := doRateLimitedRequest(router, http.MethodGet, "/admin/dlq", orders, "10.0.0.3:1234")
+	other := doRateLimitedRequest(router, http.MethodGet, "/admin/dlq", reporting, "10.0.0.1:1234")
+
+	assert.Equal(t, http.StatusOK, first.Code)
+	assert.Equal(t, "2", first.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "1", first.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "2;w=60", first.Header().Get("RateLimit-Policy"))
+	assert.Equal(t, http.StatusOK, second.Code)
+	assert.Equal(t, http.StatusTooManyRequests, third.Code)
+	assert.Equal(t, "30", third.Header().Get("Retry-After"))
+	assert.Equal(t, "0", third.Header().Get("RateLimit-Remaining"))
+	assert.Contains(t, third.Body.String(), "rate_limit_exceeded")
+	assert.Equal(t, http.StatusOK, other.Code, "other services have their own bucket")
+}
+
+// TestServiceRateLimitMiddleware_ReadAndWriteBuckets tests that reads and writes are counted separately
+func TestServiceRateLimitMiddleware_ReadAndWriteBuckets(t *testing.T) {
+	policy := &ratelimit.Policy{ReadLimit: 5, WriteLimit: 1, Window: time.Minute}
+	router := setupRateLimitRouter(t, ratelimit.NewLocalLimiter(), policy, true)
+	token := signTestToken(t, "ops-console", auth.ScopeAdminDLQ)
+
+	assert.Equal(t, http.StatusOK, doRateLimitedRequest(router, http.MethodPost, "/admin/dlq/1/retry", token, "").Code)
+	assert.Equal(t, http.StatusTooManyRequests, doRateLimitedRequest(router, http.MethodPost, "/admin/dlq/2/retry", token, "").Code)
+	assert.Equal(t, http.StatusOK, doRateLimitedRequest(router, http.MethodGet, "/admin/dlq", token, "").Code)
+}
+
+// TestServiceRateLimitMiddleware_RouteAndServiceQuotas tests per-route and per-service overrides
+func TestServiceRateLimitMiddleware_RouteAndServiceQuotas(t *testing.T) {
+	policy := &ratelimit.Policy{
+		ReadLimit:  1,
+		WriteLimit: 10,
+		Window:     time.Minute,
+		Rules: []ratelimit.Rule{
+			{Service: "ops-console", Limit: 3},
+			{Method: http.MethodPost, Route: "/admin/reservations/release-expired", Limit: 1},
+		},
+	}
+	router := setupRateLimitRouter(t, ratelimit.NewLocalLimiter(), policy, true)
+	token := signTestToken(t, "ops-console", auth.ScopeAdminDLQ)
+
+	// Route quota has its own bucket
+	assert.Equal(t, http.StatusOK, doRateLimitedRequest(router, http.MethodPost, "/admin/reservations/release-expired", token, "").Code)
+	w := doRateLimitedRequest(router, http.MethodPost, "/admin/reservations/release-expired", token, "")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "60", w.Header().Get("Retry-After"))
+
+	// Service quota raises the read limit for ops-console
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, http.StatusOK, doRateLimitedRequest(router, http.MethodGet, "/admin/dlq", token, "").Code)
+	}
+	assert.Equal(t, http.StatusTooManyRequests, doRateLimitedRequest(router, http.MethodGet, "/admin/dlq", token, "").Code)
+}
+
+// TestServiceRateLimitMiddleware_UnauthenticatedKeyedByIP tests the fallback key when auth is disabled
+func TestServiceRateLimitMiddleware_UnauthenticatedKeyedByIP(t *testing.T) {
+	policy := &ratelimit.Policy{ReadLimit: 1, WriteLimit: 1, Window: time.Minute}
+	router := setupRateLimitRouter(t, ratelimit.NewLocalLimiter(), policy, false)
+
+	assert.Equal(t, http.StatusOK, doRateLimitedRequest(router, http.MethodGet, "/admin/dlq", "", "10.0.0.1:1234").Code)
+	assert.Equal(t, http.StatusTooManyRequests, doRateLimitedRequest(router, http.MethodGet, "/admin/dlq", "", "10.0.0.1:1234").Code)
+	assert.Equal(t, http.StatusOK, doRateLimitedRequest(router, http.MethodGet, "/admin/dlq", "", "10.0.0.2:1234").Code)
+}
+
+// TestServiceRateLimitMiddleware_LimiterErrorAllows tests that limiter errors do not block traffic
+func TestServiceRateLimitMiddleware_LimiterErrorAllows(t *testing.T) {
+	policy := &ratelimit.Policy{ReadLimit: 1, WriteLimit: 1, Window: time.Minute}
+	router := setupRateLimitRouter(t, erroringLimiter{}, policy, false)
+
+	w := doRateLimitedRequest(router, http.MethodGet, "/admin/dlq", "", "")
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("RateLimit-Limit"))
+}
@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/application/usecase"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/infrastructure/auth"
+)
+
+// UserIDKey holds the end user the request is made for, empty without one
+const UserIDKey = "user_id"
+
+// UserIdentityMiddleware resolves the end user a request is made for (see auth.UserID)
+// from the token's user_id claim or the X-User-ID header, and stores it in the request
+// context for the use cases. It must run after ServiceAuthMiddleware when authentication
+// is enabled. Invalid user IDs are rejected with 400 and a header contradicting the
+// token's claim with 403.
+func UserIdentityMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, _ := PrincipalFromContext(c)
+		userID, err := auth.UserID(principal, strings.TrimSpace(c.GetHeader(auth.UserIDHeader)))
+		if err != nil {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error":   "user_id_mismatch",
+				"message": err.Error(),
+			})
+			c.Abort()
+			return
+		}
+
+		if userID != "" {
+			if err := entity.ValidateUserID(userID); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{
+					"error":   "invalid_user_id",
+					"message": err.Error(),
+				})
+				c.Abort()
+				return
+			}
+			c.Set(UserIDKey, userID)
+			c.Request = c.Request.WithContext(usecase.ContextWithUserID(c.Request.Context(), userID))
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/application/usecase"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/infrastructure/auth"
+)
+
+func setupUserIdentityRouter(t *testing.T, authenticated bool) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	if authenticated {
+		router.Use(ServiceAuthMiddleware(newTestVerifier(t), auth.NewDenialAudit(10)))
+	}
+	router.Use(UserIdentityMiddleware())
+	router.GET("/whoami", func(c *gin.Context) {
+		c.String(http.StatusOK, usecase.UserIDFromContext(c.Request.Context())+"|"+c.GetString(UserIDKey))
+	})
+	return router
+}
+
+func TestUserIdentityMiddleware(t *testing.T) {
+	serviceToken := signTestToken(t, "orders-service", auth.ScopeInventoryReserve)
+	userToken, err := auth.NewHMACSigner("k1", testTokenSecret, auth.SignerOptions{Audience: "inventory-service"}).
+		SignForUser("orders-service", "user-2", []string{auth.ScopeInventoryReserve}, time.Hour)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		authenticated bool
+		token         string
+		header        string
+		wantStatus    int
+		wantBody      string
+	}{
+		{"header from an authenticated service", true, serviceToken, "user-1", http.StatusOK, "user-1|user-1"},
+		{"no end user", true, serviceToken, "", http.StatusOK, "|"},
+		{"token claim", true, userToken, "", http.StatusOK, "user-2|user-2"},
+		{"header matching the claim", true, userToken, "user-2", http.StatusOK, "user-2|user-2"},
+		{"header contradicting the claim", true, userToken, "user-1", http.StatusForbidden, "user_id_mismatch"},
+		{"invalid header", true, serviceToken, strings.Repeat("u", 200), http.StatusBadRequest, "invalid_user_id"},
+		{"authentication disabled", false, "", "user-1", http.StatusOK, "user-1|user-1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := setupUserIdentityRouter(t, tt.authenticated)
+			req := httptest.NewRequest(http.MethodGet, "/whoami", nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			if tt.header != "" {
+				req.Header.Set(auth.UserIDHeader, tt.header)
+			}
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Contains(t, w.Body.String(), tt.wantBody)
+		})
+	}
+}
@@ -0,0 +1,98 @@
+// Package messaging adapts broker messages to application use cases, the way
+// interfaces/http and interfaces/grpc adapt requests.
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/application/usecase"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/events"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/infrastructure/messaging/rabbitmq"
+)
+
+// CatalogSyncer applies the catalog state of a product to the inventory
+type CatalogSyncer interface {
+	Execute(ctx context.Context, input usecase.SyncProductInput) (*usecase.SyncProductOutput, error)
+}
+
+// CatalogEventHandler turns product.created, product.updated and
+// product.deactivated events into catalog sync operations
+type CatalogEventHandler struct {
+	syncer CatalogSyncer
+}
+
+// NewCatalogEventHandler creates a new CatalogEventHandler
+func NewCatalogEventHandler(syncer CatalogSyncer) *CatalogEventHandler {
+	return &CatalogEventHandler{syncer: syncer}
+}
+
+// BindingKeys returns the routing keys the handler consumes
+func (h *CatalogEventHandler) BindingKeys() []string {
+	return []string{
+		events.RoutingKeyProductCreated,
+		events.RoutingKeyProductUpdated,
+		events.RoutingKeyProductDeactivated,
+	}
+}
+
+// Handle implements rabbitmq.Handler. Malformed events are reported as
+// rabbitmq.ErrUnprocessable so they are dead-lettered instead of retried.
+func (h *CatalogEventHandler) Handle(ctx context.Context, routingKey string, envelope *rabbitmq.Envelope) error {
+	if !events.IsProductEvent(routingKey) {
+		return fmt.Errorf("%w: unexpected routing key %q", rabbitmq.ErrUnprocessable, routingKey)
+	}
+
+	var payload events.ProductPayload
+	if err := json.Unmarshal(envelope.Payload, &payload); err != nil {
+		return fmt.Errorf("%w: invalid %s payload: %v", rabbitmq.ErrUnprocessable, routingKey, err)
+	}
+	productID, err := uuid.Parse(payload.ProductID)
+	if err != nil {
+		return fmt.Errorf("%w: invalid product ID %q", rabbitmq.ErrUnprocessable, payload.ProductID)
+	}
+
+	input := usecase.SyncProductInput{
+		ProductID: productID,
+		SKU:       payload.SKU,
+		// A deactivation wins over whatever isActive says
+		Active:    payload.IsActive && routingKey != events.RoutingKeyProductDeactivated,
+		ChangedAt: changedAt(payload, envelope),
+	}
+
+	output, err := h.syncer.Execute(ctx, input)
+	if err != nil {
+		if errors.IsValidationError(err) {
+			return fmt.Errorf("%w: %v", rabbitmq.ErrUnprocessable, err)
+		}
+		return fmt.Errorf("failed to sync product %s: %w", productID, err)
+	}
+
+	switch output.Action {
+	case usecase.CatalogSyncUnchanged:
+	case usecase.CatalogSyncStale:
+		log.Printf("Catalog sync: dropped %s for product %s changed at %s, overtaken by a later change",
+			routingKey, productID, input.ChangedAt.Format(time.RFC3339Nano))
+	default:
+		log.Printf("Catalog sync: %s %s inventory item %s for product %s (SKU %s, quantity %d)",
+			routingKey, output.Action, output.InventoryItemID, productID, payload.SKU, output.Quantity)
+	}
+	return nil
+}
+
+// changedAt prefers the catalog timestamp and falls back to the event timestamp
+func changedAt(payload events.ProductPayload, envelope *rabbitmq.Envelope) time.Time {
+	if !payload.UpdatedAt.IsZero() {
+		return payload.UpdatedAt
+	}
+	if ts, err := time.Parse(time.RFC3339Nano, envelope.Timestamp); err == nil {
+		return ts
+	}
+	return time.Now()
+}
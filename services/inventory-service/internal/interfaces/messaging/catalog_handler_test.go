@@ -0,0 +1,116 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/application/usecase"
+	domainErrors "github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/events"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/infrastructure/messaging/rabbitmq"
+)
+
+// fakeSyncer records the inputs it receives
+type fakeSyncer struct {
+	inputs []usecase.SyncProductInput
+	err    error
+}
+
+func (f *fakeSyncer) Execute(ctx context.Context, input usecase.SyncProductInput) (*usecase.SyncProductOutput, error) {
+	f.inputs = append(f.inputs, input)
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &usecase.SyncProductOutput{ProductID: input.ProductID, Action: usecase.CatalogSyncCreated}, nil
+}
+
+func productEnvelope(t *testing.T, routingKey string, payload events.ProductPayload) *rabbitmq.Envelope {
+	t.Helper()
+	body, err := json.Marshal(payload)
+	require.NoError(t, err)
+	return &rabbitmq.Envelope{
+		BaseEvent: events.BaseEvent{EventID: uuid.NewString(), EventType: routingKey, Timestamp: "2025-11-10T12:00:00Z"},
+		Payload:   body,
+	}
+}
+
+func TestCatalogEventHandler_Handle(t *testing.T) {
+	productID := uuid.New()
+	updatedAt := time.Date(2025, 11, 10, 11, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		routingKey string
+		isActive   bool
+		wantActive bool
+	}{
+		{events.RoutingKeyProductCreated, true, true},
+		{events.RoutingKeyProductUpdated, true, true},
+		{events.RoutingKeyProductUpdated, false, false},
+		{events.RoutingKeyProductDeactivated, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.routingKey, func(t *testing.T) {
+			syncer := &fakeSyncer{}
+			envelope := productEnvelope(t, tt.routingKey, events.ProductPayload{
+				ProductID: productID.String(), SKU: "SKU-1", IsActive: tt.isActive, UpdatedAt: updatedAt,
+			})
+
+			require.NoError(t, NewCatalogEventHandler(syncer).Handle(context.Background(), tt.routingKey, envelope))
+
+			require.Len(t, syncer.inputs, 1)
+			assert.Equal(t, usecase.SyncProductInput{ProductID: productID, SKU: "SKU-1", Active: tt.wantActive, ChangedAt: updatedAt}, syncer.inputs[0])
+		})
+	}
+}
+
+func TestCatalogEventHandler_FallsBackToEventTimestamp(t *testing.T) {
+	syncer := &fakeSyncer{}
+	envelope := productEnvelope(t, events.RoutingKeyProductDeactivated, events.ProductPayload{ProductID: uuid.NewString()})
+
+	require.NoError(t, NewCatalogEventHandler(syncer).Handle(context.Background(), events.RoutingKeyProductDeactivated, envelope))
+
+	assert.Equal(t, time.Date(2025, 11, 10, 12, 0, 0, 0, time.UTC), syncer.inputs[0].ChangedAt)
+}
+
+func TestCatalogEventHandler_Unprocessable(t *testing.T) {
+	handler := NewCatalogEventHandler(&fakeSyncer{})
+	ctx := context.Background()
+
+	err := handler.Handle(ctx, "order.created", productEnvelope(t, "order.created", events.ProductPayload{ProductID: uuid.NewString()}))
+	assert.ErrorIs(t, err, rabbitmq.ErrUnprocessable)
+
+	err = handler.Handle(ctx, events.RoutingKeyProductCreated, productEnvelope(t, events.RoutingKeyProductCreated, events.ProductPayload{ProductID: "42"}))
+	assert.ErrorIs(t, err, rabbitmq.ErrUnprocessable)
+
+	err = handler.Handle(ctx, events.RoutingKeyProductCreated, &rabbitmq.Envelope{Payload: json.RawMessage(`"text"`)})
+	assert.ErrorIs(t, err, rabbitmq.ErrUnprocessable)
+
+	err = NewCatalogEventHandler(&fakeSyncer{err: domainErrors.ErrInvalidQuantity}).
+		Handle(ctx, events.RoutingKeyProductCreated, productEnvelope(t, events.RoutingKeyProductCreated, events.ProductPayload{ProductID: uuid.NewString(), IsActive: true}))
+	assert.ErrorIs(t, err, rabbitmq.ErrUnprocessable)
+}
+
+func TestCatalogEventHandler_TransientErrorsAreRetried(t *testing.T) {
+	dbErr := errors.New("connection refused")
+	handler := NewCatalogEventHandler(&fakeSyncer{err: dbErr})
+
+	err := handler.Handle(context.Background(), events.RoutingKeyProductCreated,
+		productEnvelope(t, events.RoutingKeyProductCreated, events.ProductPayload{ProductID: uuid.NewString(), IsActive: true}))
+
+	assert.ErrorIs(t, err, dbErr)
+	assert.NotErrorIs(t, err, rabbitmq.ErrUnprocessable)
+}
+
+func TestCatalogEventHandler_BindingKeys(t *testing.T) {
+	for _, key := range NewCatalogEventHandler(nil).BindingKeys() {
+		assert.True(t, events.IsProductEvent(key), key)
+	}
+}
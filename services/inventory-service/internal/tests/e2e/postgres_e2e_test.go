@@ -28,7 +28,7 @@ const (
 )
 
 // setupTestDB initializes a PostgreSQL container and returns the GORM DB connection
-func setupTestDB(t *testing.T) (*gorm.DB, func()) {
+func setupTestDB(t testing.TB) (*gorm.DB, func()) {
 	ctx := context.Background()
 
 	// Start PostgreSQL container
@@ -0,0 +1,185 @@
+//go:build e2e
+// +build e2e
+
+package e2e
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/application/usecase"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/entity"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/domain/errors"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/infrastructure/messaging/noop"
+	"github.com/ArielDRighi/microservices-ecommerce-system/services/inventory-service/internal/infrastructure/persistence/repository"
+)
+
+// TestAtomicStock_E2E checks the conditional single-statement stock updates
+func TestAtomicStock_E2E(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	repo := repository.NewInventoryRepository(db)
+
+	newItem := func(t *testing.T, quantity int) *entity.InventoryItem {
+		item, err := entity.NewInventoryItem(uuid.New(), quantity)
+		require.NoError(t, err)
+		require.NoError(t, repo.Save(ctx, item))
+		return item
+	}
+
+	t.Run("Reserve, confirm, release and adjust in single statements", func(t *testing.T) {
+		item := newItem(t, 100)
+
+		stored, err := repo.ReserveStock(ctx, item.ProductID, 30)
+		require.NoError(t, err)
+		assert.Equal(t, 30, stored.Reserved)
+		assert.Equal(t, item.Version+1, stored.Version)
+
+		stored, err = repo.ConfirmStock(ctx, item.ID, 10)
+		require.NoError(t, err)
+		assert.Equal(t, 90, stored.Quantity)
+		assert.Equal(t, 20, stored.Reserved)
+
+		stored, err = repo.ReleaseStock(ctx, item.ID, 20)
+		require.NoError(t, err)
+		assert.Equal(t, 0, stored.Reserved)
+
+		stored, err = repo.AdjustStock(ctx, item.ProductID, -40)
+		require.NoError(t, err)
+		assert.Equal(t, 50, stored.Quantity)
+		assert.Equal(t, item.Version+4, stored.Version)
+	})
+
+	t.Run("Guards reject invalid changes with domain errors", func(t *testing.T) {
+		item := newItem(t, 10)
+		_, err := repo.ReserveStock(ctx, item.ProductID, 8)
+		require.NoError(t, err)
+
+		_, err = repo.ReserveStock(ctx, item.ProductID, 3)
+		assert.Equal(t, errors.ErrInsufficientStock, err)
+
+		_, err = repo.ReleaseStock(ctx, item.ID, 9)
+		assert.Equal(t, errors.ErrInvalidReservationRelease, err)
+
+		_, err = repo.ConfirmStock(ctx, item.ID, 9)
+		assert.Equal(t, errors.ErrInvalidReservationConfirm, err)
+
+		_, err = repo.AdjustStock(ctx, item.ProductID, -3)
+		assert.Equal(t, errors.ErrInsufficientStock, err)
+
+		_, err = repo.ReserveStock(ctx, uuid.New(), 1)
+		assert.Equal(t, errors.ErrInventoryItemNotFound, err)
+
+		current, err := repo.FindByID(ctx, item.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 10, current.Quantity)
+		assert.Equal(t, 8, current.Reserved)
+	})
+
+	t.Run("Concurrent reservations never oversell", func(t *testing.T) {
+		item := newItem(t, 50)
+
+		var wg sync.WaitGroup
+		var succeeded, rejected atomic.Int64
+		for i := 0; i < 100; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := repo.ReserveStock(ctx, item.ProductID, 1)
+				switch err {
+				case nil:
+					succeeded.Add(1)
+				case errors.ErrInsufficientStock:
+					rejected.Add(1)
+				default:
+					t.Errorf("unexpected error: %v", err)
+				}
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, int64(50), succeeded.Load())
+		assert.Equal(t, int64(50), rejected.Load())
+
+		current, err := repo.FindByID(ctx, item.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 50, current.Reserved)
+	})
+}
+
+// contentionCounter counts lost version races during a benchmark
+type contentionCounter struct {
+	conflicts atomic.Int64
+	exhausted atomic.Int64
+}
+
+func (c *contentionCounter) ObserveConflict(string, uuid.UUID)  { c.conflicts.Add(1) }
+func (c *contentionCounter) ObserveExhausted(string, uuid.UUID) { c.exhausted.Add(1) }
+
+// BenchmarkReserveUnderContention compares the optimistic-lock read-modify-write
+// path with the conditional single-statement path while all goroutines reserve
+// the same product. Run with:
+//
+//	go test -tags e2e -run '^$' -bench ReserveUnderContention -cpu 1,8,32 ./internal/tests/e2e/
+func BenchmarkReserveUnderContention(b *testing.B) {
+	db, cleanup := setupTestDB(b)
+	defer cleanup()
+
+	ctx := context.Background()
+	inventoryRepo := repository.NewInventoryRepository(db)
+	reservationRepo := repository.NewReservationRepository(db)
+	publisher := noop.NewPublisher()
+
+	strategies := []struct {
+		name   string
+		atomic bool
+	}{
+		{"optimistic_lock", false},
+		{"conditional_update", true},
+	}
+
+	for _, strategy := range strategies {
+		b.Run(strategy.name, func(b *testing.B) {
+			item, err := entity.NewInventoryItem(uuid.New(), 1<<30)
+			require.NoError(b, err)
+			require.NoError(b, inventoryRepo.Save(ctx, item))
+
+			counter := &contentionCounter{}
+			policy := usecase.DefaultRetryPolicy()
+			policy.MaxAttempts = 20
+			policy.Observer = counter
+
+			uc := usecase.NewReserveStockUseCase(inventoryRepo, reservationRepo, publisher).WithRetryPolicy(policy)
+			if strategy.atomic {
+				uc.WithAtomicStock(inventoryRepo)
+			}
+
+			var failed atomic.Int64
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					_, err := uc.Execute(ctx, usecase.ReserveStockInput{
+						ProductID: item.ProductID,
+						OrderID:   uuid.New(),
+						Quantity:  1,
+					})
+					if err != nil {
+						failed.Add(1)
+					}
+				}
+			})
+			b.StopTimer()
+
+			b.ReportMetric(float64(counter.conflicts.Load())/float64(b.N), "conflicts/op")
+			b.ReportMetric(float64(failed.Load())/float64(b.N), "failures/op")
+		})
+	}
+}
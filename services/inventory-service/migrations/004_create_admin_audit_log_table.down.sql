@@ -0,0 +1,6 @@
+-- Migration: Drop admin audit log table
+-- Description: Rollback migration for admin audit log table
+-- Version: 004
+-- Date: 2025-11-03
+
+DROP TABLE IF EXISTS admin_audit_log;
@@ -0,0 +1,51 @@
+-- Migration: Create admin audit log table
+-- Description: Append-only record of administrative operations (actor, route, parameters, outcome, affected entities)
+-- Version: 004
+-- Date: 2025-11-03
+
+CREATE TABLE IF NOT EXISTS admin_audit_log (
+    id UUID PRIMARY KEY,
+    actor VARCHAR(255) NOT NULL,
+    key_id VARCHAR(255),
+    token_id VARCHAR(255),
+    method VARCHAR(10) NOT NULL,
+    route VARCHAR(255) NOT NULL,
+    path TEXT NOT NULL,
+    parameters JSONB NOT NULL DEFAULT '{}',
+    outcome VARCHAR(20) NOT NULL,
+    status_code INT NOT NULL,
+    affected_ids TEXT[] NOT NULL DEFAULT '{}',
+    error_message TEXT,
+    remote_addr VARCHAR(255),
+    duration_ms BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL,
+
+    -- Constraints
+    CONSTRAINT chk_admin_audit_outcome CHECK (outcome IN ('success', 'failure', 'denied'))
+);
+
+-- Index on created_at for chronological listing
+CREATE INDEX IF NOT EXISTS idx_admin_audit_created_at ON admin_audit_log(created_at DESC);
+
+-- Index on actor for "who did what" queries
+CREATE INDEX IF NOT EXISTS idx_admin_audit_actor ON admin_audit_log(actor, created_at DESC);
+
+-- Index on route for per-operation queries
+CREATE INDEX IF NOT EXISTS idx_admin_audit_route ON admin_audit_log(route, created_at DESC);
+
+-- GIN index for finding the operations that touched an entity
+CREATE INDEX IF NOT EXISTS idx_admin_audit_affected_ids ON admin_audit_log USING GIN (affected_ids);
+
+-- Comment on table
+COMMENT ON TABLE admin_audit_log IS 'Append-only audit trail of administrative operations';
+
+-- Comments on columns
+COMMENT ON COLUMN admin_audit_log.actor IS 'Calling service (token subject) or anonymous when authentication is disabled';
+COMMENT ON COLUMN admin_audit_log.key_id IS 'Signing key ID of the service token';
+COMMENT ON COLUMN admin_audit_log.token_id IS 'Token ID (jti) of the service token';
+COMMENT ON COLUMN admin_audit_log.route IS 'Route template (e.g. /admin/dlq/:id/retry)';
+COMMENT ON COLUMN admin_audit_log.path IS 'Concrete request path';
+COMMENT ON COLUMN admin_audit_log.parameters IS 'Path and query parameters of the request';
+COMMENT ON COLUMN admin_audit_log.outcome IS 'Operation outcome: success, failure, denied';
+COMMENT ON COLUMN admin_audit_log.affected_ids IS 'IDs of the entities changed by the operation';
+COMMENT ON COLUMN admin_audit_log.duration_ms IS 'Operation duration in milliseconds';
@@ -0,0 +1,7 @@
+-- Migration: Drop archived_at from inventory_items
+-- Description: Rollback migration for inventory item archiving
+-- Version: 005
+-- Date: 2025-11-10
+
+DROP INDEX IF EXISTS idx_inventory_archived_at;
+ALTER TABLE inventory_items DROP COLUMN IF EXISTS archived_at;
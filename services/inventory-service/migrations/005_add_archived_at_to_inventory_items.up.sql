@@ -0,0 +1,11 @@
+-- Migration: Add archived_at to inventory_items
+-- Description: Soft-archive inventory items whose product was deactivated in the catalog
+-- Version: 005
+-- Date: 2025-11-10
+
+ALTER TABLE inventory_items ADD COLUMN IF NOT EXISTS archived_at TIMESTAMP NULL;
+
+-- Partial index to list archived items without scanning the active ones
+CREATE INDEX IF NOT EXISTS idx_inventory_archived_at ON inventory_items(archived_at) WHERE archived_at IS NOT NULL;
+
+COMMENT ON COLUMN inventory_items.archived_at IS 'Timestamp when the product was deactivated; archived items cannot be reserved';
@@ -0,0 +1,52 @@
+-- Migration: Rollback reservation partitioning and archive
+-- Description: Rebuilds reservations as a regular table with the rows of every partition
+--              and of the archive, then drops the partitions, triggers and archive
+-- Version: 006
+-- Date: 2025-11-24
+
+CREATE TABLE reservations_unpartitioned (
+    id UUID PRIMARY KEY,
+    inventory_item_id UUID NOT NULL,
+    order_id UUID NOT NULL,
+    quantity INT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+
+    CONSTRAINT chk_reservation_quantity_positive_unpartitioned CHECK (quantity > 0),
+    CONSTRAINT chk_reservation_status_unpartitioned CHECK (status IN ('pending', 'confirmed', 'released', 'expired'))
+);
+
+INSERT INTO reservations_unpartitioned (id, inventory_item_id, order_id, quantity, status, expires_at, created_at, updated_at)
+SELECT id, inventory_item_id, order_id, quantity, status, expires_at, created_at, updated_at
+FROM reservations;
+
+-- Archived rows go back only while their item exists and their order has no newer reservation
+INSERT INTO reservations_unpartitioned (id, inventory_item_id, order_id, quantity, status, expires_at, created_at, updated_at)
+SELECT DISTINCT ON (a.order_id) a.id, a.inventory_item_id, a.order_id, a.quantity, a.status, a.expires_at, a.created_at, a.updated_at
+FROM reservations_archive a
+WHERE EXISTS (SELECT 1 FROM inventory_items i WHERE i.id = a.inventory_item_id)
+    AND NOT EXISTS (SELECT 1 FROM reservations r WHERE r.order_id = a.order_id)
+ORDER BY a.order_id, a.created_at DESC;
+
+DROP TABLE IF EXISTS reservations_archive;
+DROP TABLE reservations CASCADE;
+DROP FUNCTION IF EXISTS reservations_claim_order_id();
+DROP FUNCTION IF EXISTS reservations_free_order_id();
+DROP TABLE IF EXISTS reservation_order_ids;
+
+ALTER TABLE reservations_unpartitioned RENAME TO reservations;
+ALTER INDEX reservations_unpartitioned_pkey RENAME TO reservations_pkey;
+ALTER TABLE reservations RENAME CONSTRAINT chk_reservation_quantity_positive_unpartitioned TO chk_reservation_quantity_positive;
+ALTER TABLE reservations RENAME CONSTRAINT chk_reservation_status_unpartitioned TO chk_reservation_status;
+ALTER TABLE reservations ADD CONSTRAINT fk_reservations_inventory_item
+    FOREIGN KEY (inventory_item_id) REFERENCES inventory_items(id) ON DELETE CASCADE;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_reservations_order ON reservations(order_id);
+CREATE INDEX IF NOT EXISTS idx_reservations_inventory_item ON reservations(inventory_item_id);
+CREATE INDEX IF NOT EXISTS idx_reservations_status ON reservations(status);
+CREATE INDEX IF NOT EXISTS idx_reservations_expires_at ON reservations(expires_at);
+CREATE INDEX IF NOT EXISTS idx_reservations_active ON reservations(inventory_item_id, status, expires_at);
+
+COMMENT ON TABLE reservations IS 'Stores temporary stock reservations for orders with expiration time';
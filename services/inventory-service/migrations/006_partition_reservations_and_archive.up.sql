@@ -0,0 +1,122 @@
+-- Migration: Partition reservations by month and add the reservation archive
+-- Description: Rebuilds reservations as a table partitioned by created_at (one partition per month
+--              plus a default one), keeps order_id unique across partitions and creates
+--              reservations_archive for terminal reservations moved out by the retention job
+-- Version: 006
+-- Date: 2025-11-24
+
+-- Unique constraints of a partitioned table must include the partition key, so the
+-- one-reservation-per-order rule moves to this table, kept in sync by triggers
+CREATE TABLE IF NOT EXISTS reservation_order_ids (
+    order_id UUID PRIMARY KEY,
+    reservation_id UUID NOT NULL
+);
+
+ALTER TABLE reservations RENAME TO reservations_unpartitioned;
+ALTER INDEX reservations_pkey RENAME TO reservations_unpartitioned_pkey;
+
+CREATE TABLE reservations (
+    id UUID NOT NULL,
+    inventory_item_id UUID NOT NULL,
+    order_id UUID NOT NULL,
+    quantity INT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+
+    -- Constraints
+    CONSTRAINT reservations_pkey PRIMARY KEY (id, created_at),
+    CONSTRAINT chk_reservation_quantity_positive CHECK (quantity > 0),
+    CONSTRAINT chk_reservation_status CHECK (status IN ('pending', 'confirmed', 'released', 'expired')),
+    CONSTRAINT fk_reservations_inventory_item FOREIGN KEY (inventory_item_id) REFERENCES inventory_items(id) ON DELETE CASCADE
+) PARTITION BY RANGE (created_at);
+
+-- Catches rows outside the monthly partitions; the retention job creates them ahead of time
+CREATE TABLE reservations_default PARTITION OF reservations DEFAULT;
+
+-- Monthly partitions (reservations_pYYYYMM) from the oldest reservation up to three months ahead
+DO $$
+DECLARE
+    month_start TIMESTAMP;
+    last_month TIMESTAMP := date_trunc('month', now() AT TIME ZONE 'UTC') + INTERVAL '3 months';
+BEGIN
+    SELECT date_trunc('month', COALESCE(MIN(created_at), now() AT TIME ZONE 'UTC'))
+        INTO month_start FROM reservations_unpartitioned;
+
+    WHILE month_start <= last_month LOOP
+        EXECUTE format(
+            'CREATE TABLE IF NOT EXISTS %I PARTITION OF reservations FOR VALUES FROM (%L) TO (%L)',
+            'reservations_p' || to_char(month_start, 'YYYYMM'),
+            month_start,
+            month_start + INTERVAL '1 month'
+        );
+        month_start := month_start + INTERVAL '1 month';
+    END LOOP;
+END $$;
+
+INSERT INTO reservations (id, inventory_item_id, order_id, quantity, status, expires_at, created_at, updated_at)
+SELECT id, inventory_item_id, order_id, quantity, status, expires_at, created_at, updated_at
+FROM reservations_unpartitioned;
+
+INSERT INTO reservation_order_ids (order_id, reservation_id)
+SELECT order_id, id FROM reservations_unpartitioned;
+
+DROP TABLE reservations_unpartitioned;
+
+-- Indexes are created on every partition
+CREATE INDEX IF NOT EXISTS idx_reservations_order ON reservations(order_id);
+CREATE INDEX IF NOT EXISTS idx_reservations_inventory_item ON reservations(inventory_item_id);
+CREATE INDEX IF NOT EXISTS idx_reservations_status ON reservations(status);
+CREATE INDEX IF NOT EXISTS idx_reservations_expires_at ON reservations(expires_at);
+CREATE INDEX IF NOT EXISTS idx_reservations_active ON reservations(inventory_item_id, status, expires_at);
+
+-- Terminal reservations by age, for the retention job
+CREATE INDEX IF NOT EXISTS idx_reservations_terminal ON reservations(updated_at) WHERE status <> 'pending';
+
+-- A reservation claims its order ID on insert and frees it when deleted (or archived).
+-- A second reservation for the same order fails with unique_violation, as before.
+CREATE OR REPLACE FUNCTION reservations_claim_order_id() RETURNS TRIGGER AS $$
+BEGIN
+    INSERT INTO reservation_order_ids (order_id, reservation_id) VALUES (NEW.order_id, NEW.id);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION reservations_free_order_id() RETURNS TRIGGER AS $$
+BEGIN
+    DELETE FROM reservation_order_ids WHERE order_id = OLD.order_id AND reservation_id = OLD.id;
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_reservations_claim_order_id
+    BEFORE INSERT ON reservations
+    FOR EACH ROW EXECUTE FUNCTION reservations_claim_order_id();
+
+CREATE TRIGGER trg_reservations_free_order_id
+    AFTER DELETE ON reservations
+    FOR EACH ROW EXECUTE FUNCTION reservations_free_order_id();
+
+-- Archive of confirmed, released and expired reservations. There is no foreign key:
+-- the history outlives the inventory items it refers to.
+CREATE TABLE IF NOT EXISTS reservations_archive (
+    id UUID PRIMARY KEY,
+    inventory_item_id UUID NOT NULL,
+    order_id UUID NOT NULL,
+    quantity INT NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    archived_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_reservations_archive_order ON reservations_archive(order_id);
+CREATE INDEX IF NOT EXISTS idx_reservations_archive_item ON reservations_archive(inventory_item_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_reservations_archive_created_at ON reservations_archive(created_at DESC);
+
+COMMENT ON TABLE reservations IS 'Stores temporary stock reservations for orders, partitioned by month of created_at';
+COMMENT ON TABLE reservation_order_ids IS 'Order IDs with a reservation; enforces one reservation per order across partitions';
+COMMENT ON TABLE reservations_archive IS 'Terminal reservations moved out of reservations by the retention job';
+COMMENT ON COLUMN reservations_archive.archived_at IS 'Timestamp when the reservation was moved to the archive';
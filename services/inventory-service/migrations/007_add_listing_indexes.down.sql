@@ -0,0 +1,13 @@
+-- Migration: Drop listing indexes
+-- Description: Rollback migration for keyset-paginated listings
+-- Version: 007
+-- Date: 2025-11-28
+
+DROP INDEX IF EXISTS idx_reservations_status_created_at_id;
+DROP INDEX IF EXISTS idx_reservations_expires_at_id;
+DROP INDEX IF EXISTS idx_reservations_updated_at_id;
+DROP INDEX IF EXISTS idx_reservations_created_at_id;
+
+DROP INDEX IF EXISTS idx_inventory_available;
+DROP INDEX IF EXISTS idx_inventory_updated_at_id;
+DROP INDEX IF EXISTS idx_inventory_created_at_id;
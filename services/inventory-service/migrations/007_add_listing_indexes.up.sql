@@ -0,0 +1,16 @@
+-- Migration: Add indexes for keyset-paginated listings
+-- Description: (sort column, id) indexes behind GET /admin/inventory and GET /admin/reservations
+-- Version: 007
+-- Date: 2025-11-28
+
+-- Inventory items: sort keys and the low-stock filter
+CREATE INDEX IF NOT EXISTS idx_inventory_created_at_id ON inventory_items(created_at, id);
+CREATE INDEX IF NOT EXISTS idx_inventory_updated_at_id ON inventory_items(updated_at, id);
+CREATE INDEX IF NOT EXISTS idx_inventory_available ON inventory_items((quantity - reserved));
+
+-- Reservations: indexes on the partitioned table are created on every partition,
+-- including the ones the retention job adds later
+CREATE INDEX IF NOT EXISTS idx_reservations_created_at_id ON reservations(created_at, id);
+CREATE INDEX IF NOT EXISTS idx_reservations_updated_at_id ON reservations(updated_at, id);
+CREATE INDEX IF NOT EXISTS idx_reservations_expires_at_id ON reservations(expires_at, id);
+CREATE INDEX IF NOT EXISTS idx_reservations_status_created_at_id ON reservations(status, created_at, id);
@@ -0,0 +1,10 @@
+-- Migration: Drop stock history
+-- Description: Rollback migration for stock movements and snapshots
+-- Version: 008
+-- Date: 2025-12-01
+
+DROP TRIGGER IF EXISTS trg_inventory_items_record_movement ON inventory_items;
+DROP FUNCTION IF EXISTS inventory_items_record_movement();
+DROP TABLE IF EXISTS stock_snapshots;
+DROP TABLE IF EXISTS stock_snapshot_runs;
+DROP TABLE IF EXISTS stock_movements;
@@ -0,0 +1,79 @@
+-- Migration: Create stock history (movements and snapshots)
+-- Description: Records every change of quantity or reserved on inventory_items in stock_movements
+--              and keeps periodic full snapshots, so the stock of any item can be rebuilt at a past
+--              moment as the latest snapshot before it plus the movements in between
+-- Version: 008
+-- Date: 2025-12-01
+
+-- One row per change of an inventory item, written by the trigger below. Deltas are
+-- relative to the previous state: an insert carries the initial stock and a delete
+-- its negation. There is no foreign key: the history outlives deleted items.
+CREATE TABLE IF NOT EXISTS stock_movements (
+    id BIGSERIAL PRIMARY KEY,
+    inventory_item_id UUID NOT NULL,
+    product_id UUID NOT NULL,
+    quantity_delta INT NOT NULL,
+    reserved_delta INT NOT NULL,
+    occurred_at TIMESTAMP NOT NULL DEFAULT (clock_timestamp() AT TIME ZONE 'UTC')
+);
+
+CREATE INDEX IF NOT EXISTS idx_stock_movements_product ON stock_movements(product_id, occurred_at);
+CREATE INDEX IF NOT EXISTS idx_stock_movements_occurred_at ON stock_movements(occurred_at);
+
+-- A snapshot run holds the stock of every item at taken_at. Every run is complete,
+-- so reads only need the latest run at or before the requested moment.
+CREATE TABLE IF NOT EXISTS stock_snapshot_runs (
+    taken_at TIMESTAMP PRIMARY KEY,
+    items INT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT (now() AT TIME ZONE 'UTC')
+);
+
+CREATE TABLE IF NOT EXISTS stock_snapshots (
+    taken_at TIMESTAMP NOT NULL,
+    inventory_item_id UUID NOT NULL,
+    product_id UUID NOT NULL,
+    quantity INT NOT NULL,
+    reserved INT NOT NULL,
+    changed_at TIMESTAMP NOT NULL,
+
+    CONSTRAINT stock_snapshots_pkey PRIMARY KEY (taken_at, inventory_item_id),
+    CONSTRAINT fk_stock_snapshots_run FOREIGN KEY (taken_at) REFERENCES stock_snapshot_runs(taken_at) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_stock_snapshots_product ON stock_snapshots(product_id, taken_at);
+
+CREATE OR REPLACE FUNCTION inventory_items_record_movement() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        INSERT INTO stock_movements (inventory_item_id, product_id, quantity_delta, reserved_delta)
+        VALUES (NEW.id, NEW.product_id, NEW.quantity, NEW.reserved);
+    ELSIF TG_OP = 'UPDATE' THEN
+        IF NEW.quantity <> OLD.quantity OR NEW.reserved <> OLD.reserved THEN
+            INSERT INTO stock_movements (inventory_item_id, product_id, quantity_delta, reserved_delta)
+            VALUES (NEW.id, NEW.product_id, NEW.quantity - OLD.quantity, NEW.reserved - OLD.reserved);
+        END IF;
+    ELSE
+        INSERT INTO stock_movements (inventory_item_id, product_id, quantity_delta, reserved_delta)
+        VALUES (OLD.id, OLD.product_id, -OLD.quantity, -OLD.reserved);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Fires for every write path, including the conditional UPDATEs of the atomic stock strategy
+CREATE TRIGGER trg_inventory_items_record_movement
+    AFTER INSERT OR UPDATE OF quantity, reserved OR DELETE ON inventory_items
+    FOR EACH ROW EXECUTE FUNCTION inventory_items_record_movement();
+
+-- Baseline: the current stock is the first snapshot, history starts here
+INSERT INTO stock_snapshot_runs (taken_at, items)
+SELECT now() AT TIME ZONE 'UTC', COUNT(*) FROM inventory_items;
+
+INSERT INTO stock_snapshots (taken_at, inventory_item_id, product_id, quantity, reserved, changed_at)
+SELECT now() AT TIME ZONE 'UTC', id, product_id, quantity, reserved, updated_at
+FROM inventory_items;
+
+COMMENT ON TABLE stock_movements IS 'Changes of quantity and reserved on inventory_items, recorded by trigger';
+COMMENT ON TABLE stock_snapshot_runs IS 'Points in time with a complete stock snapshot';
+COMMENT ON TABLE stock_snapshots IS 'Stock of every inventory item at the time of a snapshot run';
+COMMENT ON COLUMN stock_snapshots.changed_at IS 'Time of the last movement of the item at or before taken_at';
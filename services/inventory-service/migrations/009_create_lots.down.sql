@@ -0,0 +1,7 @@
+-- Migration: Drop lots
+-- Description: Rollback migration for lots and reservation lot allocations
+-- Version: 009
+-- Date: 2025-12-08
+
+DROP TABLE IF EXISTS reservation_lots;
+DROP TABLE IF EXISTS lots;
@@ -0,0 +1,53 @@
+-- Migration: Create lots
+-- Description: Tracks the stock of inventory items per lot (lot number, received date, best-before
+--              date), the lots allocated to each reservation and the lots its confirmation consumed
+-- Version: 009
+-- Date: 2025-12-08
+
+-- A lot is a batch of units of one inventory item. quantity is the units of the lot
+-- still counted in inventory_items.quantity and reserved the part of them allocated
+-- to pending reservations. Expired units are moved out of quantity into quarantined
+-- by the quarantine job. Stock that is not in any lot stays untracked.
+CREATE TABLE IF NOT EXISTS lots (
+    id UUID PRIMARY KEY,
+    inventory_item_id UUID NOT NULL REFERENCES inventory_items(id) ON DELETE CASCADE,
+    lot_number VARCHAR(100) NOT NULL,
+    received_at TIMESTAMP NOT NULL,
+    best_before DATE,
+    quantity INT NOT NULL CHECK (quantity >= 0),
+    reserved INT NOT NULL DEFAULT 0 CHECK (reserved >= 0 AND reserved <= quantity),
+    quarantined INT NOT NULL DEFAULT 0 CHECK (quarantined >= 0),
+    quarantined_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT (now() AT TIME ZONE 'UTC'),
+    updated_at TIMESTAMP NOT NULL DEFAULT (now() AT TIME ZONE 'UTC'),
+    CONSTRAINT uq_lots_item_lot_number UNIQUE (inventory_item_id, lot_number)
+);
+
+-- First-expired-first-out order of the lots with free units
+CREATE INDEX IF NOT EXISTS idx_lots_fefo ON lots(inventory_item_id, best_before NULLS LAST, received_at)
+    WHERE quantity > reserved;
+
+-- Expired lots that still have free units to quarantine
+CREATE INDEX IF NOT EXISTS idx_lots_best_before ON lots(best_before)
+    WHERE best_before IS NOT NULL AND quantity > reserved;
+
+-- Units of a lot allocated to a reservation. Allocations are consumed when the
+-- reservation is confirmed and released when it is released or expires, and are
+-- kept as the record of which lots an order took. reservations is partitioned, so
+-- reservation_id has no foreign key.
+CREATE TABLE IF NOT EXISTS reservation_lots (
+    reservation_id UUID NOT NULL,
+    lot_id UUID NOT NULL REFERENCES lots(id) ON DELETE CASCADE,
+    quantity INT NOT NULL CHECK (quantity > 0),
+    status VARCHAR(20) NOT NULL DEFAULT 'allocated'
+        CHECK (status IN ('allocated', 'consumed', 'released')),
+    allocated_at TIMESTAMP NOT NULL DEFAULT (now() AT TIME ZONE 'UTC'),
+    updated_at TIMESTAMP NOT NULL DEFAULT (now() AT TIME ZONE 'UTC'),
+    PRIMARY KEY (reservation_id, lot_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_reservation_lots_lot ON reservation_lots(lot_id);
+
+COMMENT ON TABLE lots IS 'Stock of inventory items per lot, allocated first-expired-first-out';
+COMMENT ON COLUMN lots.quarantined IS 'Expired units taken out of the available stock';
+COMMENT ON TABLE reservation_lots IS 'Lots allocated to and consumed by reservations';
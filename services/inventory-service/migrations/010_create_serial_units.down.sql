@@ -0,0 +1,7 @@
+-- Migration: Drop serial units
+-- Description: Rollback migration for serial units and the serial_tracked flag
+-- Version: 010
+-- Date: 2025-12-15
+
+DROP TABLE IF EXISTS serial_units;
+ALTER TABLE inventory_items DROP COLUMN IF EXISTS serial_tracked;
@@ -0,0 +1,39 @@
+-- Migration: Create serial units
+-- Description: Flags inventory items whose stock is tracked per serialized unit and stores
+--              the state of every unit (available, reserved, sold, returned)
+-- Version: 010
+-- Date: 2025-12-15
+
+-- The stock of a serial-tracked item only changes through its serial units:
+-- quantity counts its available and reserved units and reserved the reserved ones.
+ALTER TABLE inventory_items ADD COLUMN IF NOT EXISTS serial_tracked BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- One row per serialized unit. reservation_id is the reservation holding a reserved
+-- unit, and is kept once the unit is sold as the record of which order took it.
+-- reservations is partitioned, so reservation_id has no foreign key.
+CREATE TABLE IF NOT EXISTS serial_units (
+    id UUID PRIMARY KEY,
+    inventory_item_id UUID NOT NULL REFERENCES inventory_items(id) ON DELETE CASCADE,
+    serial_number VARCHAR(100) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'available'
+        CHECK (status IN ('available', 'reserved', 'sold', 'returned')),
+    reservation_id UUID,
+    created_at TIMESTAMP NOT NULL DEFAULT (now() AT TIME ZONE 'UTC'),
+    updated_at TIMESTAMP NOT NULL DEFAULT (now() AT TIME ZONE 'UTC'),
+    CONSTRAINT uq_serial_units_item_serial UNIQUE (inventory_item_id, serial_number),
+    CONSTRAINT chk_serial_units_reservation CHECK (
+        (status = 'reserved' AND reservation_id IS NOT NULL) OR
+        (status IN ('available', 'returned') AND reservation_id IS NULL) OR
+        status = 'sold'
+    )
+);
+
+-- Units of an item by state, oldest first: the order units are picked for reservations
+CREATE INDEX IF NOT EXISTS idx_serial_units_item_status ON serial_units(inventory_item_id, status, created_at);
+
+-- Units held or sold by a reservation
+CREATE INDEX IF NOT EXISTS idx_serial_units_reservation ON serial_units(reservation_id)
+    WHERE reservation_id IS NOT NULL;
+
+COMMENT ON COLUMN inventory_items.serial_tracked IS 'Stock is tracked per serial unit in serial_units';
+COMMENT ON TABLE serial_units IS 'Serialized units of serial-tracked inventory items and their state';
@@ -0,0 +1,8 @@
+-- Migration: Drop bundles
+-- Description: Rollback migration for bundles, their components and reservation components
+-- Version: 011
+-- Date: 2025-12-22
+
+DROP TABLE IF EXISTS reservation_components;
+DROP TABLE IF EXISTS bundle_components;
+ALTER TABLE inventory_items DROP COLUMN IF EXISTS bundle;
@@ -0,0 +1,51 @@
+-- Migration: Create bundles
+-- Description: Flags inventory items sold as bundles (kits) of other products, stores their
+--              components and the units of every component held by bundle reservations
+-- Version: 011
+-- Date: 2025-12-22
+
+-- A bundle has no stock of its own: its quantity and reserved stay at zero and its
+-- availability is derived from its components. Its reservations point at the
+-- bundle's item and hold the units of the components in reservation_components.
+ALTER TABLE inventory_items ADD COLUMN IF NOT EXISTS bundle BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- quantity units of the component go into every unit of the bundle
+CREATE TABLE IF NOT EXISTS bundle_components (
+    bundle_item_id UUID NOT NULL REFERENCES inventory_items(id) ON DELETE CASCADE,
+    component_item_id UUID NOT NULL REFERENCES inventory_items(id) ON DELETE RESTRICT,
+    quantity INT NOT NULL CHECK (quantity > 0),
+    created_at TIMESTAMP NOT NULL DEFAULT (now() AT TIME ZONE 'UTC'),
+    PRIMARY KEY (bundle_item_id, component_item_id),
+    CONSTRAINT chk_bundle_components_not_itself CHECK (bundle_item_id <> component_item_id)
+);
+
+-- Bundles a product is a component of
+CREATE INDEX IF NOT EXISTS idx_bundle_components_component ON bundle_components(component_item_id);
+
+-- Units of a component held by a bundle reservation, counted in the reserved units
+-- of the component's item while reserved. They are confirmed or released with the
+-- reservation and kept as the record of what the order took. reservations is
+-- partitioned, so reservation_id has no foreign key.
+CREATE TABLE IF NOT EXISTS reservation_components (
+    reservation_id UUID NOT NULL,
+    bundle_item_id UUID NOT NULL REFERENCES inventory_items(id) ON DELETE CASCADE,
+    inventory_item_id UUID NOT NULL REFERENCES inventory_items(id) ON DELETE CASCADE,
+    quantity INT NOT NULL CHECK (quantity > 0),
+    status VARCHAR(20) NOT NULL DEFAULT 'reserved'
+        CHECK (status IN ('reserved', 'confirmed', 'released')),
+    created_at TIMESTAMP NOT NULL DEFAULT (now() AT TIME ZONE 'UTC'),
+    updated_at TIMESTAMP NOT NULL DEFAULT (now() AT TIME ZONE 'UTC'),
+    PRIMARY KEY (reservation_id, inventory_item_id)
+);
+
+-- Units of an item held by pending bundle reservations, for reconciliation
+CREATE INDEX IF NOT EXISTS idx_reservation_components_item ON reservation_components(inventory_item_id)
+    WHERE status = 'reserved';
+
+-- Reservations still holding components of a bundle, checked before removing it
+CREATE INDEX IF NOT EXISTS idx_reservation_components_bundle ON reservation_components(bundle_item_id)
+    WHERE status = 'reserved';
+
+COMMENT ON COLUMN inventory_items.bundle IS 'Product sold as a bundle of the products in bundle_components';
+COMMENT ON TABLE bundle_components IS 'Components of bundles and the units of each per bundle';
+COMMENT ON TABLE reservation_components IS 'Units of components held by bundle reservations';
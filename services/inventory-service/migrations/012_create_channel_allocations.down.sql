@@ -0,0 +1,10 @@
+-- Migration: Drop channel allocations
+-- Description: Rollback migration for channel allocations and reservation channels
+-- Version: 012
+-- Date: 2025-12-29
+
+DROP INDEX IF EXISTS idx_reservations_pending_channel;
+ALTER TABLE reservations_archive DROP COLUMN IF EXISTS channel;
+ALTER TABLE reservations DROP COLUMN IF EXISTS channel;
+DROP TABLE IF EXISTS channel_allocations;
+ALTER TABLE inventory_items DROP COLUMN IF EXISTS channel_allocated;
@@ -0,0 +1,34 @@
+-- Migration: Create channel allocations
+-- Description: Allocates the stock of inventory items to sales channels and records the
+--              channel every reservation was made for
+-- Version: 012
+-- Date: 2025-12-29
+
+-- Items with channel allocations are only reserved through the channel allocation
+-- repository, which checks the allocation of the reservation's channel.
+ALTER TABLE inventory_items ADD COLUMN IF NOT EXISTS channel_allocated BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Stock set aside for a sales channel: a fixed quota of units, a percentage of the
+-- units in stock, or nothing for channels selling from the shared pool
+CREATE TABLE IF NOT EXISTS channel_allocations (
+    inventory_item_id UUID NOT NULL REFERENCES inventory_items(id) ON DELETE CASCADE,
+    channel VARCHAR(32) NOT NULL,
+    mode VARCHAR(20) NOT NULL CHECK (mode IN ('fixed', 'percentage', 'shared')),
+    quota INT NOT NULL DEFAULT 0 CHECK (quota >= 0),
+    percent INT NOT NULL DEFAULT 0 CHECK (percent BETWEEN 0 AND 100),
+    created_at TIMESTAMP NOT NULL DEFAULT (now() AT TIME ZONE 'UTC'),
+    updated_at TIMESTAMP NOT NULL DEFAULT (now() AT TIME ZONE 'UTC'),
+    PRIMARY KEY (inventory_item_id, channel)
+);
+
+-- Reservations made without a channel keep the empty channel and use the shared pool
+ALTER TABLE reservations ADD COLUMN IF NOT EXISTS channel VARCHAR(32) NOT NULL DEFAULT '';
+ALTER TABLE reservations_archive ADD COLUMN IF NOT EXISTS channel VARCHAR(32) NOT NULL DEFAULT '';
+
+-- Units held per channel by the pending reservations of an item
+CREATE INDEX IF NOT EXISTS idx_reservations_pending_channel ON reservations(inventory_item_id, channel)
+    WHERE status = 'pending';
+
+COMMENT ON COLUMN inventory_items.channel_allocated IS 'Stock allocated to the sales channels in channel_allocations';
+COMMENT ON TABLE channel_allocations IS 'Stock of inventory items set aside per sales channel';
+COMMENT ON COLUMN reservations.channel IS 'Sales channel the reservation was made for, empty for the shared pool';
@@ -0,0 +1,10 @@
+-- Migration: Drop purchase limits
+-- Description: Rollback migration for purchase limits and reservation users
+-- Version: 013
+-- Date: 2026-01-05
+
+DROP INDEX IF EXISTS idx_reservations_archive_customer;
+DROP INDEX IF EXISTS idx_reservations_customer;
+ALTER TABLE reservations_archive DROP COLUMN IF EXISTS user_id;
+ALTER TABLE reservations DROP COLUMN IF EXISTS user_id;
+DROP TABLE IF EXISTS purchase_limits;
@@ -0,0 +1,32 @@
+-- Migration: Create purchase limits
+-- Description: Limits the quantity of a product one order and one customer can reserve,
+--              and records the end user every reservation was made for
+-- Version: 013
+-- Date: 2026-01-05
+
+-- A zero limit is not enforced. Customer limits count the pending and confirmed
+-- reservations of the customer created within the last window_seconds.
+CREATE TABLE IF NOT EXISTS purchase_limits (
+    inventory_item_id UUID PRIMARY KEY REFERENCES inventory_items(id) ON DELETE CASCADE,
+    max_per_order INT NOT NULL DEFAULT 0 CHECK (max_per_order >= 0),
+    max_per_customer INT NOT NULL DEFAULT 0 CHECK (max_per_customer >= 0),
+    window_seconds BIGINT NOT NULL DEFAULT 0 CHECK (window_seconds >= 0),
+    created_at TIMESTAMP NOT NULL DEFAULT (now() AT TIME ZONE 'UTC'),
+    updated_at TIMESTAMP NOT NULL DEFAULT (now() AT TIME ZONE 'UTC'),
+    CONSTRAINT chk_purchase_limits_set CHECK (max_per_order > 0 OR max_per_customer > 0),
+    CONSTRAINT chk_purchase_limits_window CHECK ((max_per_customer > 0) = (window_seconds > 0))
+);
+
+-- Reservations made without an end user keep the empty user id and are never
+-- counted against a customer limit
+ALTER TABLE reservations ADD COLUMN IF NOT EXISTS user_id VARCHAR(128) NOT NULL DEFAULT '';
+ALTER TABLE reservations_archive ADD COLUMN IF NOT EXISTS user_id VARCHAR(128) NOT NULL DEFAULT '';
+
+-- Units reserved by a customer within a window
+CREATE INDEX IF NOT EXISTS idx_reservations_customer ON reservations(inventory_item_id, user_id, created_at)
+    WHERE user_id <> '';
+CREATE INDEX IF NOT EXISTS idx_reservations_archive_customer ON reservations_archive(inventory_item_id, user_id, created_at)
+    WHERE user_id <> '';
+
+COMMENT ON TABLE purchase_limits IS 'Quantity of a product one order and one customer can reserve';
+COMMENT ON COLUMN reservations.user_id IS 'End user the reservation was made for, empty when the caller did not identify one';
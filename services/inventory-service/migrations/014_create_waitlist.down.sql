@@ -0,0 +1,9 @@
+-- Migration: Drop waitlist
+-- Description: Rollback migration for the waitlist
+-- Version: 014
+-- Date: 2026-01-12
+
+DROP INDEX IF EXISTS idx_waitlist_entries_expiry;
+DROP INDEX IF EXISTS idx_waitlist_entries_serving;
+DROP INDEX IF EXISTS uq_waitlist_entries_waiting_order;
+DROP TABLE IF EXISTS waitlist_entries;
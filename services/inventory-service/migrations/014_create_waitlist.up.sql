@@ -0,0 +1,42 @@
+-- Migration: Create waitlist
+-- Description: Orders waiting for stock of a product, reserved automatically
+--              once stock frees up
+-- Version: 014
+-- Date: 2026-01-12
+
+-- Waiting entries are served by descending priority, then oldest first. An
+-- entry leaves the waitlist once: fulfilled with a reservation, cancelled by
+-- the caller, expired after its TTL or failed for a reason other than stock.
+CREATE TABLE IF NOT EXISTS waitlist_entries (
+    id UUID PRIMARY KEY,
+    inventory_item_id UUID NOT NULL REFERENCES inventory_items(id) ON DELETE CASCADE,
+    order_id UUID NOT NULL,
+    quantity INT NOT NULL CHECK (quantity > 0),
+    priority INT NOT NULL DEFAULT 0 CHECK (priority BETWEEN 0 AND 100),
+    channel VARCHAR(32) NOT NULL DEFAULT '',
+    user_id VARCHAR(128) NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'waiting'
+        CHECK (status IN ('waiting', 'fulfilled', 'cancelled', 'expired', 'failed')),
+    expires_at TIMESTAMP NOT NULL,
+    reservation_id UUID,
+    failure_reason TEXT NOT NULL DEFAULT '',
+    fulfilled_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT (now() AT TIME ZONE 'UTC'),
+    updated_at TIMESTAMP NOT NULL DEFAULT (now() AT TIME ZONE 'UTC')
+);
+
+-- An order waits at most once at a time
+CREATE UNIQUE INDEX IF NOT EXISTS uq_waitlist_entries_waiting_order ON waitlist_entries(order_id)
+    WHERE status = 'waiting';
+
+-- Waiting entries of an item in serving order
+CREATE INDEX IF NOT EXISTS idx_waitlist_entries_serving ON waitlist_entries(inventory_item_id, priority DESC, created_at)
+    WHERE status = 'waiting';
+
+-- Waiting entries past their TTL
+CREATE INDEX IF NOT EXISTS idx_waitlist_entries_expiry ON waitlist_entries(expires_at)
+    WHERE status = 'waiting';
+
+COMMENT ON TABLE waitlist_entries IS 'Orders waiting for stock of an inventory item';
+COMMENT ON COLUMN waitlist_entries.reservation_id IS 'Reservation created for a fulfilled entry';
+COMMENT ON COLUMN waitlist_entries.failure_reason IS 'Why the reservation of a failed entry was rejected';
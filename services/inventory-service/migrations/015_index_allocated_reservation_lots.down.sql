@@ -0,0 +1,6 @@
+-- Migration: Drop allocated reservation lots index
+-- Description: Rollback migration for the allocated reservation lots index
+-- Version: 015
+-- Date: 2026-01-19
+
+DROP INDEX IF EXISTS idx_reservation_lots_allocated;
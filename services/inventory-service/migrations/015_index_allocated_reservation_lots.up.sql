@@ -0,0 +1,10 @@
+-- Migration: Index allocated reservation lots
+-- Description: Lets reconciliation find the lot allocations still held by
+--              reservations that are no longer pending
+-- Version: 015
+-- Date: 2026-01-19
+
+-- Allocations are kept once consumed or released, so only the few still
+-- allocated are indexed
+CREATE INDEX IF NOT EXISTS idx_reservation_lots_allocated ON reservation_lots(reservation_id, lot_id)
+    WHERE status = 'allocated';
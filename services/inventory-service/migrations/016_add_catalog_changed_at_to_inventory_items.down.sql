@@ -0,0 +1,6 @@
+-- Migration: Drop catalog_changed_at from inventory_items
+-- Description: Rollback migration for the catalog change time of inventory items
+-- Version: 016
+-- Date: 2026-01-20
+
+ALTER TABLE inventory_items DROP COLUMN IF EXISTS catalog_changed_at;
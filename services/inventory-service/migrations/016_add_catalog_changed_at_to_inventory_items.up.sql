@@ -0,0 +1,13 @@
+-- Migration: Add catalog_changed_at to inventory_items
+-- Description: Records the catalog time of the latest product change applied to an item,
+--              so that catalog events delivered out of order are dropped
+-- Version: 016
+-- Date: 2026-01-20
+
+ALTER TABLE inventory_items ADD COLUMN IF NOT EXISTS catalog_changed_at TIMESTAMP NULL;
+
+-- Archived items were archived at the catalog time of their deactivation
+UPDATE inventory_items SET catalog_changed_at = archived_at
+WHERE archived_at IS NOT NULL AND catalog_changed_at IS NULL;
+
+COMMENT ON COLUMN inventory_items.catalog_changed_at IS 'Catalog time of the latest product change applied; older changes are dropped';
@@ -62,6 +62,12 @@ export const ORDER_ROUTING_KEYS = {
   ORDER_CONFIRMED: 'order.confirmed',
 } as const;
 
+export const PRODUCT_ROUTING_KEYS = {
+  PRODUCT_CREATED: 'product.created',
+  PRODUCT_UPDATED: 'product.updated',
+  PRODUCT_DEACTIVATED: 'product.deactivated',
+} as const;
+
 // Export exchange names as constants
 export const EXCHANGES = {
   INVENTORY_EVENTS: 'inventory.events',
@@ -72,12 +78,14 @@ export const EXCHANGES = {
 export const QUEUES = {
   ORDERS_INVENTORY_EVENTS: 'orders.inventory_events',
   INVENTORY_ORDER_EVENTS: 'inventory.order_events',
+  INVENTORY_CATALOG_EVENTS: 'inventory.catalog_events',
 } as const;
 
 // Export type utilities
 export type RoutingKey =
   | typeof INVENTORY_ROUTING_KEYS[keyof typeof INVENTORY_ROUTING_KEYS]
-  | typeof ORDER_ROUTING_KEYS[keyof typeof ORDER_ROUTING_KEYS];
+  | typeof ORDER_ROUTING_KEYS[keyof typeof ORDER_ROUTING_KEYS]
+  | typeof PRODUCT_ROUTING_KEYS[keyof typeof PRODUCT_ROUTING_KEYS];
 
 export type ExchangeName = typeof EXCHANGES[keyof typeof EXCHANGES];
 export type QueueName = typeof QUEUES[keyof typeof QUEUES];